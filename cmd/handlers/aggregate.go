@@ -258,6 +258,9 @@ func runAggregateWithClassification(ctx context.Context, maxArticles, concurrenc
 		ThemeFilter:    themeFilter,
 		Since:          time.Now().Add(-time.Duration(sinceHours) * time.Hour),
 		MaxConcurrency: concurrency,
+
+		FeedFullText:     cfg.Feeds.FullText,
+		FullTextMinWords: cfg.Feeds.FullTextMinWords,
 	}
 
 	// Run aggregation with inline classification
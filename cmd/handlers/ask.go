@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"briefly/internal/ask"
+	"briefly/internal/config"
+	"briefly/internal/llm"
+	"briefly/internal/render"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultAskDays is how far back questions look when they name no period
+	defaultAskDays = 365
+	// maxAskDigests is how many recent digests are matched to retrieved articles
+	maxAskDigests = 500
+)
+
+// NewAskCmd creates the ask command for questions about the archive
+func NewAskCmd() *cobra.Command {
+	var (
+		since     int
+		limit     int
+		threshold float64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ask <question>",
+		Short: "Ask a question about your digest archive",
+		Long: `Answer a question from the articles and digests in the local cache.
+
+The question is embedded and matched against cached article embeddings; the
+closest articles are given to the model with instructions to answer only from
+them, citing each claim. Sources are listed with the digests that covered them.
+
+How far back to look comes from the question ("last month", "past 3 weeks"),
+or --since, and defaults to the past year. Articles cached without an
+embedding aren't searched.
+
+Examples:
+  # Ask about a topic
+  briefly ask "what did we cover about WASM runtimes last month?"
+
+  # Look further back, with more sources
+  briefly ask "how has our coverage of vector databases changed?" --since 730 --limit 12`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAsk(cmd.Context(), strings.Join(args, " "), since, ask.Options{Limit: limit, Threshold: threshold})
+		},
+	}
+
+	cmd.Flags().IntVar(&since, "since", 0, "Days of cached articles to search (default: from the question, or 365)")
+	cmd.Flags().IntVarP(&limit, "limit", "l", ask.DefaultOptions.Limit, "Maximum number of sources")
+	cmd.Flags().Float64VarP(&threshold, "threshold", "t", ask.DefaultOptions.Threshold, "Minimum similarity threshold (0.0-1.0)")
+
+	return cmd
+}
+
+func runAsk(ctx context.Context, question string, since int, opts ask.Options) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cache.Close() }()
+
+	days := since
+	if days <= 0 {
+		days = ask.Window(question)
+	}
+	if days <= 0 {
+		days = defaultAskDays
+	}
+
+	articles, err := cache.GetRecentArticles(days)
+	if err != nil {
+		return err
+	}
+	digests, err := cache.GetLatestDigests(maxAskDigests)
+	if err != nil {
+		return err
+	}
+	embedded := 0
+	for _, article := range articles {
+		if len(article.Embedding) > 0 {
+			embedded++
+		}
+	}
+	if embedded == 0 {
+		return fmt.Errorf("no cached articles with embeddings in the past %d days", days)
+	}
+
+	llmClient, err := llm.NewClient(config.GetAI().Gemini.Model)
+	if err != nil {
+		return fmt.Errorf("failed to create LLM client: %w", err)
+	}
+	defer llmClient.Close()
+
+	fmt.Printf("🔍 Searching %d cached articles from the past %d days...\n\n", embedded, days)
+	answer, err := ask.Ask(ctx, llmClient, llmClient, question, articles, digests, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(render.Output(answer.Markdown()))
+	return nil
+}
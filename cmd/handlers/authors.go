@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"briefly/internal/authors"
+	"briefly/internal/config"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewAuthorsCmd creates the authors command for followed writers
+func NewAuthorsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "authors",
+		Short: "Writers you follow and read most",
+		Long: `See which writers you follow and read most.
+
+Follow writers by listing them under authors.follow in .briefly.yaml. Their
+articles get a ⭐ in digests and rank higher within their topic (authors.boost).
+Bylines come from each page's author metadata (meta tags and JSON-LD).
+
+Subcommands:
+  stats - Articles per author in the cache, and how many you read`,
+	}
+
+	cmd.AddCommand(newAuthorsStatsCmd())
+
+	return cmd
+}
+
+func newAuthorsStatsCmd() *cobra.Command {
+	var (
+		since int
+		limit int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show who you read most",
+		Long: `Count cached articles per author, most read first.
+
+An article counts as read when it has a read mark ('briefly cache read-status').
+Followed authors are starred; followed authors with no cached articles in the
+period are listed at the end.
+
+Examples:
+  # Top authors over the last 90 days
+  briefly authors stats
+
+  # The whole year, top 50
+  briefly authors stats --since 365 --limit 50`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuthorsStats(since, limit)
+		},
+	}
+
+	cmd.Flags().IntVarP(&since, "since", "s", 90, "Count articles cached in the last N days")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Maximum number of authors to show")
+
+	return cmd
+}
+
+func runAuthorsStats(since int, limit int) error {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	now := time.Now().UTC()
+	articles, err := cache.GetArticlesByDateRange(now.AddDate(0, 0, -since), now)
+	if err != nil {
+		return err
+	}
+	read, err := cache.ReadURLs()
+	if err != nil {
+		return err
+	}
+
+	follow := config.GetAuthors().Follow
+	stats := authors.Tally(articles, read, follow)
+	if len(stats) == 0 {
+		fmt.Printf("No articles with a known author in the last %d days\n", since)
+		fmt.Println("💡 Authors are read from page metadata when articles are fetched")
+		return nil
+	}
+
+	fmt.Printf("✍️  Authors (last %d days, %d with a byline)\n\n", since, len(stats))
+	fmt.Printf("  %-32s %8s %6s  %s\n", "AUTHOR", "ARTICLES", "READ", "LATEST")
+	for i, stat := range stats {
+		if limit > 0 && i >= limit {
+			fmt.Printf("\n  ... and %d more\n", len(stats)-limit)
+			break
+		}
+		name := stat.Author
+		if stat.Followed {
+			name = "⭐ " + name
+		}
+		latest := ""
+		if !stat.Latest.IsZero() {
+			latest = stat.Latest.Format("2006-01-02")
+		}
+		fmt.Printf("  %-32s %8d %6d  %s\n", truncateQueueText(name, 31), stat.Articles, stat.Read, latest)
+	}
+
+	seen := make(map[string]bool, len(stats))
+	for _, stat := range stats {
+		seen[strings.ToLower(stat.Author)] = true
+	}
+	var quiet []string
+	for _, name := range follow {
+		if !seen[strings.ToLower(strings.TrimSpace(name))] {
+			quiet = append(quiet, name)
+		}
+	}
+	if len(quiet) > 0 {
+		fmt.Printf("\n⭐ Followed, nothing cached: %s\n", strings.Join(quiet, ", "))
+	}
+	return nil
+}
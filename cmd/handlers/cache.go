@@ -1,10 +1,18 @@
 package handlers
 
 import (
+	"briefly/internal/config"
 	"briefly/internal/logger"
+	"briefly/internal/snapshot"
 	"briefly/internal/store"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -20,6 +28,15 @@ func NewCacheCmd() *cobra.Command {
 	// Add subcommands
 	cacheCmd.AddCommand(newCacheStatsCmd())
 	cacheCmd.AddCommand(newCacheClearCmd())
+	cacheCmd.AddCommand(newCachePruneCmd())
+	cacheCmd.AddCommand(newCacheExportArticleCmd())
+	cacheCmd.AddCommand(newCacheOpenCmd())
+	cacheCmd.AddCommand(newCacheBackupCmd())
+	cacheCmd.AddCommand(newCacheRestoreCmd())
+	cacheCmd.AddCommand(newCacheReadStatusCmd())
+	cacheCmd.AddCommand(newCacheEncryptCmd())
+	cacheCmd.AddCommand(newCacheDecryptCmd())
+	cacheCmd.AddCommand(newCacheRedactionsCmd())
 
 	return cacheCmd
 }
@@ -56,6 +73,413 @@ func newCacheClearCmd() *cobra.Command {
 	return clearCmd
 }
 
+func newCachePruneCmd() *cobra.Command {
+	var olderThan int
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove old cached articles and summaries",
+		Long: `Remove cached articles and summaries older than --older-than days, with their
+archived content, and drop archived HTML older than cache.archive.retention.
+Digests, read marks, and comments are kept.
+
+Examples:
+  briefly cache prune
+  briefly cache prune --older-than 30`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCachePrune(olderThan)
+		},
+	}
+
+	cmd.Flags().IntVar(&olderThan, "older-than", 90, "Remove articles and summaries cached more than N days ago")
+	return cmd
+}
+
+func newCacheExportArticleCmd() *cobra.Command {
+	var (
+		raw        bool
+		outputFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-article <url>",
+		Short: "Dump an archived article's stored text",
+		Long: `Decompress and print the content archived for a cached article.
+
+The argument is the article URL or any unique part of it. By default the
+cleaned text is exported; --raw exports the original HTML (or raw text for
+PDFs and transcripts) as fetched. Originals older than cache.archive.retention
+are pruned and only the cleaned text remains.
+
+Examples:
+  briefly cache export-article https://example.com/post
+  briefly cache export-article example.com/post --raw -o post.html`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheExportArticle(args[0], raw, outputFile)
+		},
+	}
+
+	cmd.Flags().BoolVar(&raw, "raw", false, "Export the original HTML/raw text instead of the cleaned text")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write to file instead of stdout")
+
+	return cmd
+}
+
+func newCacheOpenCmd() *cobra.Command {
+	var (
+		list      bool
+		printPath bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "open [article-id | url]",
+		Short: "Open an article's archived snapshot in the browser",
+		Long: `Open the locally saved snapshot of an article's original page.
+
+The argument is the snapshot ID shown by --list, the article URL, or any unique
+part of it. Snapshots are saved during digests when cache.snapshots.enabled is
+set. For articles without one, a snapshot is created from the cached original
+HTML if it has not been pruned (cache.archive.retention).
+
+Examples:
+  briefly cache open --list
+  briefly cache open 3fa94c1d0b2e
+  briefly cache open example.com/post --print-path`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if list || len(args) == 0 {
+				return runCacheListSnapshots()
+			}
+			return runCacheOpen(args[0], printPath)
+		},
+	}
+
+	cmd.Flags().BoolVar(&list, "list", false, "List saved snapshots")
+	cmd.Flags().BoolVar(&printPath, "print-path", false, "Print the snapshot path instead of opening it")
+
+	return cmd
+}
+
+func runCacheListSnapshots() error {
+	entries, err := snapshot.List(snapshot.Dir(".briefly-cache"))
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No snapshots saved yet. Enable cache.snapshots.enabled to archive articles during digests.")
+		return nil
+	}
+
+	fmt.Printf("🗂️  %d snapshot(s)\n\n", len(entries))
+	for _, entry := range entries {
+		title := entry.Title
+		if title == "" {
+			title = entry.URL
+		}
+		fmt.Printf("%s  %-5s  %s  %s\n", entry.ID, entry.Format, entry.SavedAt.Format("2006-01-02"), title)
+		fmt.Printf("              %s\n", entry.URL)
+	}
+	return nil
+}
+
+func runCacheOpen(query string, printPath bool) error {
+	dir := snapshot.Dir(".briefly-cache")
+
+	matches, err := snapshot.Find(dir, query)
+	if err != nil {
+		return err
+	}
+
+	var entry *snapshot.Entry
+	switch len(matches) {
+	case 0:
+		if entry, err = snapshotFromArchive(query); err != nil {
+			return err
+		}
+	case 1:
+		entry = &matches[0]
+	default:
+		var candidates []string
+		for _, match := range matches {
+			candidates = append(candidates, match.ID+"  "+match.URL)
+		}
+		return fmt.Errorf("%q matches %d snapshots, be more specific:\n  %s", query, len(matches), strings.Join(candidates, "\n  "))
+	}
+
+	path, err := filepath.Abs(entry.Path(dir))
+	if err != nil {
+		return fmt.Errorf("failed to resolve snapshot path: %w", err)
+	}
+
+	if printPath {
+		fmt.Println(path)
+		return nil
+	}
+
+	fmt.Printf("🌐 Opening %s (%s)\n", entry.URL, path)
+	if err := openInBrowser(path); err != nil {
+		return err
+	}
+	markOpenedRead(entry.URL)
+	return nil
+}
+
+// snapshotFromArchive writes an HTML snapshot for an article that was cached before
+// snapshots were enabled, using the original HTML kept in the content archive
+func snapshotFromArchive(query string) (*snapshot.Entry, error) {
+	cacheStore, err := store.NewStore(".briefly-cache")
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cache store: %w", err)
+	}
+	defer func() {
+		if err := cacheStore.Close(); err != nil {
+			logger.Error("Failed to close cache store", err)
+		}
+	}()
+
+	if err := checkSnapshotsAllowed(cacheStore); err != nil {
+		return nil, fmt.Errorf("no snapshot matches %q and none can be written: %w", query, err)
+	}
+
+	archived, err := cacheStore.GetArchivedContent(query)
+	if err != nil {
+		return nil, err
+	}
+	if archived == nil {
+		urls, err := cacheStore.FindArchivedURLs(query)
+		if err != nil {
+			return nil, err
+		}
+		switch len(urls) {
+		case 0:
+			return nil, fmt.Errorf("no snapshot or cached article matches %q", query)
+		case 1:
+			if archived, err = cacheStore.GetArchivedContent(urls[0]); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%q matches %d cached articles, be more specific:\n  %s", query, len(urls), strings.Join(urls, "\n  "))
+		}
+	}
+
+	if archived.HTML == "" {
+		return nil, fmt.Errorf("original HTML for %s is not available (pruned by cache.archive.retention, or not a web page)", archived.URL)
+	}
+
+	title := ""
+	if article, err := cacheStore.GetArticleByURL(archived.URL); err == nil && article != nil {
+		title = article.Title
+	}
+	return snapshot.SaveHTML(".briefly-cache", archived.URL, title, archived.HTML)
+}
+
+// openInBrowser opens a local file with the platform's default handler
+func openInBrowser(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s (use --print-path to get the file): %w", path, err)
+	}
+	return nil
+}
+
+func runCacheExportArticle(query string, raw bool, outputFile string) error {
+	cacheStore, err := store.NewStore(".briefly-cache")
+	if err != nil {
+		return fmt.Errorf("failed to initialize cache store: %w", err)
+	}
+	defer func() {
+		if err := cacheStore.Close(); err != nil {
+			logger.Error("Failed to close cache store", err)
+		}
+	}()
+
+	archived, err := cacheStore.GetArchivedContent(query)
+	if err != nil {
+		return err
+	}
+	if archived == nil {
+		matches, err := cacheStore.FindArchivedURLs(query)
+		if err != nil {
+			return err
+		}
+		switch len(matches) {
+		case 0:
+			return fmt.Errorf("no archived article matches %q", query)
+		case 1:
+			if archived, err = cacheStore.GetArchivedContent(matches[0]); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%q matches %d archived articles, be more specific:\n  %s", query, len(matches), strings.Join(matches, "\n  "))
+		}
+	}
+
+	content := archived.CleanedText
+	if raw {
+		content = archived.HTML
+		if content == "" {
+			content = archived.RawContent
+		}
+		if content == "" {
+			return fmt.Errorf("original content for %s was pruned (cache.archive.retention); only cleaned text is available", archived.URL)
+		}
+	}
+
+	if outputFile == "" {
+		fmt.Println(content)
+		return nil
+	}
+
+	if err := os.WriteFile(outputFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFile, err)
+	}
+	fmt.Printf("✅ Exported %s (%d bytes) to %s\n", archived.URL, len(content), outputFile)
+	return nil
+}
+
+// defaultBackupPassphraseEnv names the environment variable holding the backup passphrase
+const defaultBackupPassphraseEnv = "BRIEFLY_BACKUP_PASSPHRASE"
+
+func newCacheBackupCmd() *cobra.Command {
+	var encrypt bool
+	var passphraseEnv string
+
+	cmd := &cobra.Command{
+		Use:   "backup <path>",
+		Short: "Write a consistent snapshot of the cache to a backup file",
+		Long: `Back up the whole cache — articles, summaries, digests, embeddings, and saved
+page snapshots — to a single gzipped archive. The database is copied with the
+SQLite online backup API, so the snapshot is consistent even while other commands
+are using the cache.
+
+With --encrypt the archive is encrypted with AES-256-GCM using a passphrase read
+from $BRIEFLY_BACKUP_PASSPHRASE (or the variable named by --passphrase-env).
+
+Examples:
+  briefly cache backup ~/briefly-backup.tar.gz
+  BRIEFLY_BACKUP_PASSPHRASE=... briefly cache backup archive.enc --encrypt`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheBackup(cmd, args[0], encrypt, passphraseEnv)
+		},
+	}
+
+	cmd.Flags().BoolVar(&encrypt, "encrypt", false, "Encrypt the backup with a passphrase")
+	cmd.Flags().StringVar(&passphraseEnv, "passphrase-env", defaultBackupPassphraseEnv, "Environment variable holding the backup passphrase")
+	return cmd
+}
+
+func newCacheRestoreCmd() *cobra.Command {
+	var confirm bool
+	var passphraseEnv string
+
+	cmd := &cobra.Command{
+		Use:   "restore <path>",
+		Short: "Replace the cache with the contents of a backup file",
+		Long: `Restore a backup written by 'briefly cache backup'. The backup is verified
+before it replaces the current cache, which is kept as briefly.db.pre-restore in
+the cache directory. Encrypted backups read their passphrase from
+$BRIEFLY_BACKUP_PASSPHRASE (or the variable named by --passphrase-env).
+
+Examples:
+  briefly cache restore ~/briefly-backup.tar.gz
+  BRIEFLY_BACKUP_PASSPHRASE=... briefly cache restore archive.enc --confirm`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheRestore(args[0], confirm, passphraseEnv)
+		},
+	}
+
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&passphraseEnv, "passphrase-env", defaultBackupPassphraseEnv, "Environment variable holding the backup passphrase")
+	return cmd
+}
+
+func runCacheBackup(cmd *cobra.Command, path string, encrypt bool, passphraseEnv string) error {
+	passphrase := ""
+	if encrypt {
+		passphrase = os.Getenv(passphraseEnv)
+		if passphrase == "" {
+			return fmt.Errorf("--encrypt requires a passphrase in $%s", passphraseEnv)
+		}
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cache.Close() }()
+
+	fmt.Printf("💾 Backing up cache to %s...\n", path)
+	manifest, err := cache.Backup(cmd.Context(), path, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to back up cache: %w", err)
+	}
+
+	printBackupManifest(manifest)
+	if encrypt {
+		fmt.Println("🔒 Backup is encrypted — keep the passphrase, it cannot be recovered")
+	}
+	fmt.Printf("✅ Backup written to %s\n", path)
+	return nil
+}
+
+func runCacheRestore(path string, confirm bool, passphraseEnv string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	if _, err := config.Load(cfgFile); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cacheDir := config.GetCacheDirectory()
+	if cacheDir == "" {
+		cacheDir = ".briefly-cache"
+	}
+
+	if !confirm {
+		fmt.Printf("⚠️  This will replace the cache in %s with %s. Continue? [y/N]: ", cacheDir, path)
+		var response string
+		_, _ = fmt.Scanln(&response)
+		if response != "y" && response != "Y" && response != "yes" {
+			fmt.Println("Restore cancelled")
+			return nil
+		}
+	}
+
+	manifest, err := store.RestoreBackup(path, cacheDir, os.Getenv(passphraseEnv))
+	if errors.Is(err, store.ErrBackupPassphrase) {
+		return fmt.Errorf("%w (set $%s)", err, passphraseEnv)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore cache: %w", err)
+	}
+
+	fmt.Printf("📦 Restored backup from %s\n", manifest.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	printBackupManifest(manifest)
+	fmt.Printf("✅ Cache restored to %s (previous database kept as briefly.db.pre-restore)\n", cacheDir)
+	return nil
+}
+
+// printBackupManifest prints what a backup contains
+func printBackupManifest(manifest *store.BackupManifest) {
+	fmt.Printf("📄 Articles: %d\n", manifest.Articles)
+	fmt.Printf("📝 Summaries: %d\n", manifest.Summaries)
+	fmt.Printf("📊 Digests: %d\n", manifest.Digests)
+	fmt.Printf("🧭 Embeddings: %d\n", manifest.Embeddings)
+	fmt.Printf("📸 Snapshots: %d\n", manifest.Snapshots)
+	fmt.Printf("💾 Database size: %.2f MB\n", float64(manifest.DatabaseSize)/1024/1024)
+}
+
 func runCacheStats() error {
 	fmt.Println("📊 Cache Statistics")
 	fmt.Println("==================")
@@ -85,6 +509,51 @@ func runCacheStats() error {
 	fmt.Printf("📅 Last updated: %s\n", stats.LastUpdated.Format("2006-01-02 15:04:05"))
 	fmt.Printf("📡 RSS feeds: %d\n", stats.FeedCount)
 
+	archiveStats, err := cacheStore.GetArchiveStats()
+	if err != nil {
+		return fmt.Errorf("failed to get archive statistics: %w", err)
+	}
+	if archiveStats.Count > 0 {
+		fmt.Printf("🗜️  Archived content: %d articles, %.2f MB → %.2f MB compressed\n",
+			archiveStats.Count, float64(archiveStats.OriginalSize)/1024/1024, float64(archiveStats.CompressedSize)/1024/1024)
+	}
+
+	switch {
+	case cacheStore.Encrypted():
+		fmt.Println("🔐 Encryption: on (AES-256-GCM)")
+	case cacheStore.Locked():
+		fmt.Printf("🔒 Encryption: locked (set %s to read content)\n", store.CacheKeyEnv)
+	default:
+		fmt.Println("🔓 Encryption: off")
+	}
+
+	return nil
+}
+
+func runCachePrune(olderThan int) error {
+	if olderThan <= 0 {
+		return fmt.Errorf("--older-than must be at least 1 day")
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	maxAge := time.Duration(olderThan) * 24 * time.Hour
+	if err := cache.CleanupOldCache(maxAge, maxAge); err != nil {
+		return err
+	}
+	pruned, err := cache.PruneArchive(config.GetArchiveRetention())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Removed articles and summaries cached more than %d days ago\n", olderThan)
+	if pruned > 0 {
+		fmt.Printf("🗜️  Dropped archived HTML of %d article(s) past cache.archive.retention\n", pruned)
+	}
 	return nil
 }
 
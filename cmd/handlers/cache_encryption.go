@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/snapshot"
+	"briefly/internal/store"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newCacheEncryptCmd() *cobra.Command {
+	var keygen bool
+
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt cached article text, summaries, and digests at rest",
+		Long: `Encrypt the content already in the cache with the key from BRIEFLY_CACHE_KEY
+(or the output of BRIEFLY_CACHE_KEY_COMMAND, e.g. a keychain lookup).
+
+With a key set, new content is always written encrypted (AES-256-GCM) and
+decrypted transparently when read; this command migrates content cached before
+the key was set. Titles, URLs, and embeddings stay readable so the cache can
+still be searched. Snapshots are not encrypted, so no new snapshots are saved
+while the cache is encrypted; delete any saved before with 'rm -r <cache>/snapshots'.
+
+Once a cache is encrypted, using it without the key fails rather than mixing
+plaintext back in. To change keys, run 'cache decrypt' with the old key, then
+'cache encrypt' with the new one.
+
+Examples:
+  briefly cache encrypt --keygen          # Print a new random key
+  export BRIEFLY_CACHE_KEY=...            # Or BRIEFLY_CACHE_KEY_COMMAND="security find-generic-password -w -s briefly"
+  briefly cache encrypt`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keygen {
+				key, err := store.GenerateCacheKey()
+				if err != nil {
+					return err
+				}
+				fmt.Println(key)
+				return nil
+			}
+			return runCacheRecrypt(true)
+		},
+	}
+
+	cmd.Flags().BoolVar(&keygen, "keygen", false, "Print a new random key for "+store.CacheKeyEnv+" and exit")
+	return cmd
+}
+
+func newCacheDecryptCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "decrypt",
+		Short: "Write encrypted cache content back as plaintext",
+		Long: `Decrypt all cached content with the current key and turn encryption off, so
+the cache can be used without a key or re-encrypted with a new one.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheRecrypt(false)
+		},
+	}
+}
+
+func runCacheRecrypt(encrypt bool) error {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	var report store.EncryptionReport
+	if encrypt {
+		report, err = cache.EncryptContent()
+	} else {
+		report, err = cache.DecryptContent()
+	}
+	if err != nil {
+		return err
+	}
+
+	verb := "Encrypted"
+	if !encrypt {
+		verb = "Decrypted"
+	}
+	fmt.Printf("✅ %s %d article(s), %d summary(ies), %d digest(s)\n", verb, report.Articles, report.Summaries, report.Digests)
+	if report.Redactions > 0 {
+		fmt.Printf("🔒 %s %d redacted value(s)\n", verb, report.Redactions)
+	}
+	if others := report.ResearchBriefs + report.TrendReports + report.Takes + report.TopicPages + report.Comments; others > 0 {
+		fmt.Printf("🔒 %s %d research brief(s), %d trend report(s), %d take(s), %d topic page(s), %d comment(s)\n",
+			verb, report.ResearchBriefs, report.TrendReports, report.Takes, report.TopicPages, report.Comments)
+	}
+	if encrypt {
+		cacheDir := config.GetCacheDirectory()
+		if cacheDir == "" {
+			cacheDir = ".briefly-cache"
+		}
+		if entries, err := snapshot.List(snapshot.Dir(cacheDir)); err == nil && len(entries) > 0 {
+			fmt.Printf("⚠️  %d snapshot(s) in %s are still plaintext; delete them to keep those pages private\n", len(entries), snapshot.Dir(cacheDir))
+		}
+		fmt.Printf("💡 Keep %s safe: the cached content can't be read without it\n", store.CacheKeyEnv)
+	}
+	return nil
+}
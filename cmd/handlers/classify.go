@@ -246,6 +246,9 @@ func runClassify(ctx context.Context, maxArticles int, minRelevance float64, the
 		SkipProcessed:  true,
 		FetchContent:   true,
 		MaxConcurrency: concurrency,
+
+		FeedFullText:     cfg.Feeds.FullText,
+		FullTextMinWords: cfg.Feeds.FullTextMinWords,
 	}
 
 	// Run classification
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"briefly/internal/collect"
+	"briefly/internal/config"
+	"briefly/internal/store"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// collectOptions are the flags of 'briefly collect'
+type collectOptions struct {
+	dir         string
+	dropDir     string
+	noClipboard bool
+	interval    time.Duration
+}
+
+// NewCollectCmd creates the collect command for gathering URLs during the week
+func NewCollectCmd() *cobra.Command {
+	var opts collectOptions
+
+	cmd := &cobra.Command{
+		Use:   "collect",
+		Short: "Collect URLs from the clipboard or a drop directory into this week's input file",
+		Long: `Watch for URLs during the week and append them to a dated input file for
+'briefly digest from-file'.
+
+Every URL you copy to the clipboard is added to input/links-<monday>.md, the file
+for the current week. Files saved to the drop directory (browser .url/.webloc
+shortcuts, text, or markdown) are added too, then moved to its collected/
+subdirectory. URLs already in the week's file are skipped, and tracking
+parameters are removed.
+
+Whatever is on the clipboard when collecting starts is ignored. On Linux the
+clipboard is read with wl-paste, xclip, or xsel.
+
+Examples:
+  # Collect copied URLs until Ctrl+C
+  briefly collect
+
+  # Also watch a drop directory, or only watch it
+  briefly collect --drop ~/briefly-drop
+  briefly collect --drop ~/briefly-drop --no-clipboard
+
+  # What has been gathered so far, then digest it
+  briefly collect preview
+  briefly digest from-file input/links-2025-06-02.md`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCollect(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.dir, "dir", "", "Directory for weekly input files (default: collect.directory)")
+	cmd.Flags().StringVar(&opts.dropDir, "drop", "", "Directory to watch for dropped files with URLs (default: collect.drop_dir)")
+	cmd.Flags().BoolVar(&opts.noClipboard, "no-clipboard", false, "Don't watch the clipboard")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 0, "How often to check for new URLs (default: collect.interval)")
+
+	cmd.AddCommand(newCollectPreviewCmd())
+
+	return cmd
+}
+
+func newCollectPreviewCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Show the URLs gathered for the next digest",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			collector, _, err := newCollector(collectOptions{dir: dir})
+			if err != nil {
+				return err
+			}
+			return printCollectPreview(collector)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory for weekly input files (default: collect.directory)")
+	return cmd
+}
+
+// newCollector applies the collect config under the command's flags
+func newCollector(opts collectOptions) (*collect.Collector, collectOptions, error) {
+	if _, err := config.Load(cfgFile); err != nil {
+		return nil, opts, fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg := config.GetCollect()
+	if opts.dir == "" {
+		opts.dir = cfg.Directory
+	}
+	if opts.dir == "" {
+		opts.dir = "input"
+	}
+	if opts.dropDir == "" {
+		opts.dropDir = cfg.DropDir
+	}
+	if !cfg.Clipboard {
+		opts.noClipboard = true
+	}
+	if opts.interval <= 0 {
+		opts.interval = cfg.Interval
+	}
+	return collect.New(opts.dir), opts, nil
+}
+
+func runCollect(cmd *cobra.Command, opts collectOptions) error {
+	collector, opts, err := newCollector(opts)
+	if err != nil {
+		return err
+	}
+
+	watcher := &collect.Watcher{
+		Collector: collector,
+		DropDir:   opts.dropDir,
+		Interval:  opts.interval,
+	}
+	if !opts.noClipboard {
+		clipboard, err := collect.SystemClipboard()
+		if err != nil && opts.dropDir == "" {
+			return fmt.Errorf("%w; use --drop to collect from a directory instead", err)
+		}
+		if err != nil {
+			fmt.Printf("⚠️  %v; only watching %s\n", err, opts.dropDir)
+		}
+		watcher.Clipboard = clipboard
+	}
+
+	if err := printCollectPreview(collector); err != nil {
+		return err
+	}
+
+	var watching []string
+	if watcher.Clipboard != nil {
+		watching = append(watching, "the clipboard")
+	}
+	if watcher.DropDir != "" {
+		watching = append(watching, watcher.DropDir)
+	}
+	fmt.Printf("\n👀 Watching %s (Ctrl+C to stop)\n", joinWatched(watching))
+
+	total := 0
+	if items, err := collector.Items(); err == nil {
+		total = len(items)
+	}
+	inbox, err := openPriorityInbox()
+	if err != nil {
+		fmt.Printf("⚠️  Priority inbox skipped: %v\n", err)
+	}
+	if inbox != nil {
+		defer inbox.Close()
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher.OnAdd = func(added []collect.Item) {
+		for _, item := range added {
+			total++
+			fmt.Printf("➕ [%d] %s (%s, %s)\n", total, item.URL, item.Source, item.CollectedAt.Format("Mon 15:04"))
+			if inbox != nil {
+				inbox.CheckURL(ctx, item.URL, "collect", "")
+			}
+		}
+	}
+	watcher.OnError = func(err error) {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+
+	if err := watcher.Run(ctx); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✅ %d URL(s) in %s\n", total, collector.CurrentFile())
+	return nil
+}
+
+// printCollectPreview lists this week's gathered URLs with their cached titles
+func printCollectPreview(collector *collect.Collector) error {
+	items, err := collector.Items()
+	if err != nil {
+		return err
+	}
+
+	path := collector.CurrentFile()
+	if len(items) == 0 {
+		fmt.Printf("📭 Nothing collected yet this week (%s)\n", path)
+		return nil
+	}
+
+	cache := openCollectCache()
+	if cache != nil {
+		defer cache.Close()
+	}
+
+	bySource := make(map[string]int)
+	fmt.Printf("📥 %d URL(s) gathered for the next digest (%s)\n", len(items), path)
+	for i, item := range items {
+		source := item.Source
+		if source == "" {
+			source = "manual"
+		}
+		bySource[source]++
+
+		when := ""
+		if !item.CollectedAt.IsZero() {
+			when = " · " + item.CollectedAt.Format("Mon 15:04")
+		}
+		fmt.Printf("   %2d. %s\n       %s (%s%s)\n", i+1, readerNoteTitle(cache, item.URL), item.URL, source, when)
+	}
+
+	fmt.Print("\n   ")
+	for _, source := range []string{collect.SourceClipboard, collect.SourceDrop, "manual"} {
+		if bySource[source] > 0 {
+			fmt.Printf("%s: %d  ", source, bySource[source])
+		}
+	}
+	fmt.Printf("\n💡 Digest them with: briefly digest from-file %s\n", path)
+	return nil
+}
+
+// openCollectCache opens the article cache for titles; previews work without it
+func openCollectCache() *store.Store {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return nil
+	}
+	return cache
+}
+
+// joinWatched joins the watched sources for display
+func joinWatched(watching []string) string {
+	switch len(watching) {
+	case 0:
+		return "nothing"
+	case 1:
+		return watching[0]
+	default:
+		return watching[0] + " and " + watching[1]
+	}
+}
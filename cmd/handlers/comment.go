@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/server"
+	"briefly/internal/store"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommentCmd creates the comment command for team feedback on digests
+func NewCommentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment",
+		Short: "Comment on digest articles for the next issue's reader notes",
+		Long: `Record team comments and reactions on the articles of a digest.
+
+Comments attach to issues recorded in the local cache (digests generated with
+--series). The next issue of the same series shows them under "Reader notes", so
+the conversation carries over from week to week.
+
+Comments also arrive through 'briefly serve': POST /api/digests/{id}/comments, and,
+with messaging.slack.signing_secret set, reactions on and thread replies to issues
+delivered with a Slack bot token. Start a reply with "[3]" to comment on article 3.
+
+Examples:
+  briefly comment add 3f2a9c1e 2 "We should pilot this in the platform team"
+  briefly comment add 3f2a9c1e 4 --reaction fire
+  briefly comment list 3f2a9c1e`,
+	}
+
+	cmd.AddCommand(newCommentAddCmd())
+	cmd.AddCommand(newCommentListCmd())
+
+	return cmd
+}
+
+func newCommentAddCmd() *cobra.Command {
+	var author, reaction string
+
+	cmd := &cobra.Command{
+		Use:   "add <digest-id> <article-num> [text]",
+		Short: "Comment or react on an article of a digest (article 0 = the whole issue)",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			articleNum, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("article number must be a number, got %q", args[1])
+			}
+			text := ""
+			if len(args) == 3 {
+				text = args[2]
+			}
+			return runCommentAdd(args[0], articleNum, text, reaction, author)
+		},
+	}
+
+	cmd.Flags().StringVar(&author, "author", os.Getenv("USER"), "Who is commenting")
+	cmd.Flags().StringVar(&reaction, "reaction", "", "React instead of (or as well as) commenting, e.g. fire or +1")
+	return cmd
+}
+
+func newCommentListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <digest-id>",
+		Short: "List the comments on a digest",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentList(args[0])
+		},
+	}
+}
+
+func runCommentAdd(digestID string, articleNum int, text, reaction, author string) error {
+	if strings.TrimSpace(text) == "" && strings.TrimSpace(reaction) == "" {
+		return fmt.Errorf("give the comment text or a --reaction")
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	recorder := &storeCommentRecorder{cache: cache}
+	comment, title, err := recorder.resolve(server.Comment{
+		DigestID:   digestID,
+		ArticleNum: articleNum,
+		Author:     author,
+		Text:       text,
+		Reaction:   reaction,
+		Source:     store.CommentSourceCLI,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := cache.AddDigestComment(comment); err != nil {
+		return err
+	}
+
+	target := "the whole issue"
+	if comment.ArticleURL != "" {
+		target = fmt.Sprintf("article %d (%s)", comment.ArticleNum, comment.ArticleURL)
+	}
+	fmt.Printf("✅ Comment saved on %s of %q\n", target, title)
+	fmt.Println("💡 It will appear under Reader notes in the next issue of the series")
+	return nil
+}
+
+func runCommentList(digestID string) error {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	digest, err := cache.FindDigestByPartialID(digestID)
+	if err != nil {
+		return err
+	}
+	if digest == nil {
+		return fmt.Errorf("%w %q (see 'briefly digest series <name>' for issue IDs)", server.ErrUnknownDigest, digestID)
+	}
+
+	comments, err := cache.ListDigestComments(digest.ID)
+	if err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		fmt.Printf("📭 No comments on %q\n", digest.Title)
+		return nil
+	}
+
+	fmt.Printf("💬 %d comment(s) on %q\n", len(comments), digest.Title)
+	for _, comment := range comments {
+		target := "issue"
+		if comment.ArticleNum > 0 {
+			target = fmt.Sprintf("[%d]", comment.ArticleNum)
+		}
+		body := comment.Text
+		if comment.Reaction != "" {
+			body = strings.TrimSpace(reactionEmoji(comment.Reaction) + " " + body)
+		}
+		author := comment.Author
+		if author == "" {
+			author = "anonymous"
+		}
+		status := ""
+		if comment.RenderedIn != "" {
+			status = " · shown"
+		}
+		fmt.Printf("   %s %-6s %s — %s (%s%s)\n", comment.CreatedAt.Format("2006-01-02"), target, body, author, comment.Source, status)
+	}
+	return nil
+}
+
+// storeCommentRecorder records comments from the HTTP API and Slack in the cache
+type storeCommentRecorder struct {
+	cache *store.Store
+}
+
+// RecordComment implements server.CommentRecorder
+func (r *storeCommentRecorder) RecordComment(comment server.Comment) (int64, error) {
+	resolved, _, err := r.resolve(comment)
+	if err != nil {
+		return 0, err
+	}
+	return r.cache.AddDigestComment(resolved)
+}
+
+// DigestForSlackMessage implements server.CommentRecorder
+func (r *storeCommentRecorder) DigestForSlackMessage(channel, ts string) (string, error) {
+	return r.cache.DigestForMessage(channel, ts)
+}
+
+// resolve finds the comment's digest by full or partial ID and the URL of its
+// article, returning the comment to store and the digest's title
+func (r *storeCommentRecorder) resolve(comment server.Comment) (store.DigestComment, string, error) {
+	digest, err := r.cache.FindDigestByPartialID(strings.TrimSpace(comment.DigestID))
+	if err != nil {
+		return store.DigestComment{}, "", err
+	}
+	if digest == nil {
+		return store.DigestComment{}, "", fmt.Errorf("%w %q (see 'briefly digest series <name>' for issue IDs)", server.ErrUnknownDigest, comment.DigestID)
+	}
+
+	resolved := store.DigestComment{
+		DigestID:   digest.ID,
+		ArticleNum: comment.ArticleNum,
+		Author:     comment.Author,
+		Text:       comment.Text,
+		Reaction:   comment.Reaction,
+		Source:     comment.Source,
+	}
+	if comment.ArticleNum < 0 || comment.ArticleNum > len(digest.ArticleURLs) {
+		return store.DigestComment{}, "", fmt.Errorf("%w %d: %q has %d articles", server.ErrInvalidArticle, comment.ArticleNum, digest.Title, len(digest.ArticleURLs))
+	}
+	if comment.ArticleNum > 0 {
+		resolved.ArticleURL = digest.ArticleURLs[comment.ArticleNum-1]
+	}
+	return resolved, digest.Title, nil
+}
+
+// buildReaderNotes groups comments by article, in the order each article was first
+// commented on, with notes on the whole issue first. Titles come from the article
+// cache, falling back to the URL's host.
+func buildReaderNotes(cache *store.Store, comments []store.DigestComment) []core.ReaderNote {
+	index := make(map[string]int)
+	var notes []core.ReaderNote
+	for _, comment := range comments {
+		i, ok := index[comment.ArticleURL]
+		if !ok {
+			note := core.ReaderNote{ArticleURL: comment.ArticleURL}
+			if comment.ArticleURL != "" {
+				note.ArticleTitle = readerNoteTitle(cache, comment.ArticleURL)
+			}
+			i = len(notes)
+			index[comment.ArticleURL] = i
+			notes = append(notes, note)
+		}
+
+		if comment.Text != "" {
+			notes[i].Comments = append(notes[i].Comments, core.ReaderComment{Author: comment.Author, Text: comment.Text})
+		}
+		if comment.Reaction != "" {
+			if notes[i].Reactions == nil {
+				notes[i].Reactions = make(map[string]int)
+			}
+			notes[i].Reactions[comment.Reaction]++
+		}
+	}
+
+	sort.SliceStable(notes, func(a, b int) bool {
+		return notes[a].ArticleURL == "" && notes[b].ArticleURL != ""
+	})
+	return notes
+}
+
+// readerNoteTitle is the cached title of an article, or its host
+func readerNoteTitle(cache *store.Store, articleURL string) string {
+	if cache != nil {
+		if article, err := cache.GetArticleByURL(articleURL); err == nil && article != nil && article.Title != "" {
+			return article.Title
+		}
+	}
+	if parsed, err := url.Parse(articleURL); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	return articleURL
+}
+
+// renderReaderNotes renders the "Reader notes" section of a markdown digest; empty
+// without notes
+func renderReaderNotes(notes []core.ReaderNote) string {
+	if len(notes) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString("## 💬 Reader Notes\n\n")
+	content.WriteString("*What the team said about last issue*\n\n")
+	for _, note := range notes {
+		if note.ArticleURL == "" {
+			content.WriteString("**On the issue**")
+		} else {
+			content.WriteString(fmt.Sprintf("**[%s](%s)**", note.ArticleTitle, note.ArticleURL))
+		}
+		if reactions := formatReactions(note.Reactions, reactionEmoji); reactions != "" {
+			content.WriteString(" " + reactions)
+		}
+		content.WriteString("\n")
+		for _, comment := range note.Comments {
+			content.WriteString(fmt.Sprintf("> %s%s\n", comment.Text, commentAttribution(comment.Author)))
+		}
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderSlackReaderNotes renders reader notes in Slack mrkdwn, with reactions as
+// Slack emoji codes; empty without notes
+func renderSlackReaderNotes(notes []core.ReaderNote) string {
+	if len(notes) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString("\n*💬 Reader notes on last issue*\n")
+	for _, note := range notes {
+		target := "On the issue"
+		if note.ArticleURL != "" {
+			target = fmt.Sprintf("<%s|%s>", note.ArticleURL, note.ArticleTitle)
+		}
+		content.WriteString("• " + target)
+		if reactions := formatReactions(note.Reactions, func(name string) string { return ":" + name + ":" }); reactions != "" {
+			content.WriteString(" " + reactions)
+		}
+		content.WriteString("\n")
+		for _, comment := range note.Comments {
+			content.WriteString(fmt.Sprintf("   > %s%s\n", comment.Text, commentAttribution(comment.Author)))
+		}
+	}
+	return content.String()
+}
+
+// commentAttribution credits a comment's author, e.g. " — sam"
+func commentAttribution(author string) string {
+	if author == "" {
+		return ""
+	}
+	return " — " + author
+}
+
+// formatReactions renders reaction counts, most frequent first, e.g. "🔥 3 · 👍 1"
+func formatReactions(reactions map[string]int, emoji func(string) string) string {
+	names := make([]string, 0, len(reactions))
+	for name := range reactions {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(a, b int) bool {
+		if reactions[names[a]] != reactions[names[b]] {
+			return reactions[names[a]] > reactions[names[b]]
+		}
+		return names[a] < names[b]
+	})
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %d", emoji(name), reactions[name]))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// reactionEmoji maps common Slack reaction names to emoji, leaving others as :name:
+func reactionEmoji(name string) string {
+	switch name {
+	case "+1", "thumbsup":
+		return "👍"
+	case "-1", "thumbsdown":
+		return "👎"
+	case "fire":
+		return "🔥"
+	case "heart":
+		return "❤️"
+	case "eyes":
+		return "👀"
+	case "tada":
+		return "🎉"
+	case "100":
+		return "💯"
+	case "rocket":
+		return "🚀"
+	case "thinking_face":
+		return "🤔"
+	default:
+		return ":" + name + ":"
+	}
+}
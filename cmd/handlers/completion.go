@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"briefly/internal/persistence"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds database lookups so <TAB> never hangs the shell
+const completionTimeout = 2 * time.Second
+
+// NewCompletionCmd creates the shell completion command
+func NewCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate shell completion scripts",
+		Long: `Generate shell completion scripts for briefly.
+
+Completion includes dynamic suggestions pulled from the database:
+  • Digest IDs (with titles) for digest show, export epub, and digest compare,
+    including its --baseline, --variants, and --digest-id flags
+  • Feed IDs (with titles) for feed remove, enable, disable, stats
+  • Theme IDs (with names) for theme remove, enable, disable, update
+
+Setup:
+  # Bash (current session)
+  source <(briefly completion bash)
+
+  # Bash (permanent, Linux)
+  briefly completion bash > /etc/bash_completion.d/briefly
+
+  # Zsh
+  briefly completion zsh > "${fpath[1]}/_briefly"
+
+  # Fish
+  briefly completion fish > ~/.config/fish/completions/briefly.fish
+
+  # PowerShell
+  briefly completion powershell | Out-String | Invoke-Expression`,
+		DisableFlagsInUseLine: true,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return root.GenZshCompletion(os.Stdout)
+			case "fish":
+				return root.GenFishCompletion(os.Stdout, true)
+			case "powershell":
+				return root.GenPowerShellCompletionWithDesc(os.Stdout)
+			}
+			return fmt.Errorf("unsupported shell: %s", args[0])
+		},
+	}
+}
+
+// completeDigestIDs suggests recent digest IDs, described by their titles.
+// maxArgs limits how many positional digest IDs the command accepts.
+func completeDigestIDs(maxArgs int) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return withCompletionDB(func(ctx context.Context, db persistence.Database) []string {
+			digests, err := db.Digests().GetLatest(ctx, 50)
+			if err != nil {
+				return nil
+			}
+
+			var candidates []string
+			for _, digest := range digests {
+				title := digest.Title
+				if title == "" {
+					title = digest.ProcessedDate.Format("2006-01-02")
+				}
+				candidates = appendCompletion(candidates, digest.ID, title, toComplete)
+			}
+			return candidates
+		})
+	}
+}
+
+// completeDigestIDFlag suggests digest IDs for a flag, whatever positional
+// arguments were given
+func completeDigestIDFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeDigestIDs(1)(cmd, nil, toComplete)
+}
+
+// completeDigestIDList suggests the next digest ID for a comma-separated flag,
+// keeping the IDs already typed
+func completeDigestIDList(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	typed := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		typed, toComplete = toComplete[:i+1], toComplete[i+1:]
+	}
+	candidates, directive := completeDigestIDs(1)(cmd, nil, toComplete)
+	for i := range candidates {
+		candidates[i] = typed + candidates[i]
+	}
+	return candidates, directive | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeFeedIDs suggests feed IDs, described by their titles
+func completeFeedIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return withCompletionDB(func(ctx context.Context, db persistence.Database) []string {
+		feeds, err := db.Feeds().List(ctx, persistence.ListOptions{Limit: 1000})
+		if err != nil {
+			return nil
+		}
+
+		var candidates []string
+		for _, feed := range feeds {
+			candidates = appendCompletion(candidates, feed.ID, feed.Title, toComplete)
+		}
+		return candidates
+	})
+}
+
+// completeThemeIDs suggests theme IDs, described by their names
+func completeThemeIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return withCompletionDB(func(ctx context.Context, db persistence.Database) []string {
+		themes, err := db.Themes().List(ctx, false)
+		if err != nil {
+			return nil
+		}
+
+		var candidates []string
+		for _, theme := range themes {
+			candidates = appendCompletion(candidates, theme.ID, theme.Name, toComplete)
+		}
+		return candidates
+	})
+}
+
+// withCompletionDB opens the database for a completion lookup. Any failure
+// yields no suggestions rather than an error, since completion output must stay clean.
+func withCompletionDB(lookup func(ctx context.Context, db persistence.Database) []string) ([]string, cobra.ShellCompDirective) {
+	db, err := getDatabase()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	return lookup(ctx, db), cobra.ShellCompDirectiveNoFileComp
+}
+
+// appendCompletion adds "id<TAB>description" when the ID matches the typed prefix
+func appendCompletion(candidates []string, id, description, toComplete string) []string {
+	if !strings.HasPrefix(id, toComplete) {
+		return candidates
+	}
+
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		return append(candidates, id)
+	}
+	return append(candidates, id+"\t"+description)
+}
@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"briefly/internal/clustering"
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"briefly/internal/logger"
+	"briefly/internal/narrative"
+	"briefly/internal/parser"
+	"briefly/internal/persistence"
+	"briefly/internal/store"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCostCmd creates the cost command
+func NewCostCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "LLM cost and latency attribution",
+		Long: `Show where LLM tokens, time, and money went.
+
+Every LLM call made by 'briefly digest generate' is recorded with its model,
+tokens in/out, latency, and retry count, attributed to the article, summary, or
+digest it was made for and to its pipeline phase.
+
+Subcommands:
+  breakdown - Cost of one digest, per article and per phase`,
+	}
+
+	cmd.AddCommand(newCostBreakdownCmd())
+
+	return cmd
+}
+
+func newCostBreakdownCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "breakdown <digest-id>",
+		Short: "Show a digest's LLM cost per article and per phase",
+		Long: `Show the LLM calls behind a digest: the calls made for the digest itself
+(narratives, executive summary) and for each of its articles (summaries,
+classification, embeddings), grouped by phase and by article.
+
+Costs are estimated from list prices per million tokens. Summaries reused from
+earlier runs are included, since their cost is part of what the digest took.
+
+Calls are also grouped by model. When per-task models are configured
+(summarize.model, digest.model, title.model, research.model), the breakdown shows
+what the same calls would cost on that mix, to compare before switching.
+
+Examples:
+  # Where did this digest's money go?
+  briefly cost breakdown 3f2a9c1e-...
+
+  # Show every article, not just the 10 most expensive
+  briefly cost breakdown 3f2a9c1e-... --limit 0`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDigestIDs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCostBreakdown(cmd.Context(), args[0], limit)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Maximum number of articles to show (0 = all)")
+
+	return cmd
+}
+
+// costTotals aggregates LLM calls
+type costTotals struct {
+	Calls     int
+	Retries   int
+	Failures  int
+	TokensIn  int
+	TokensOut int
+	LatencyMs int64
+	Cost      float64
+}
+
+func (t *costTotals) add(call core.LLMCall) {
+	t.Calls++
+	t.Retries += call.Retries
+	if call.Error != "" {
+		t.Failures++
+	}
+	t.TokensIn += call.TokensIn
+	t.TokensOut += call.TokensOut
+	t.LatencyMs += call.LatencyMs
+	t.Cost += llm.EstimateCost(call.Model, call.TokensIn, call.TokensOut)
+}
+
+// recordLLMCalls returns a usage recorder that stores every LLM call in the database
+func recordLLMCalls(db persistence.Database) llm.UsageRecorder {
+	log := logger.Get()
+	return func(ctx context.Context, call core.LLMCall) {
+		if err := db.LLMCalls().Record(context.WithoutCancel(ctx), &call); err != nil {
+			log.Warn("Failed to record LLM call", "phase", call.Phase, "error", err)
+		}
+	}
+}
+
+func runCostBreakdown(ctx context.Context, digestID string, limit int) error {
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	calls, err := db.LLMCalls().ListByDigest(ctx, digestID)
+	if err != nil {
+		return fmt.Errorf("failed to load LLM calls: %w", err)
+	}
+	if len(calls) == 0 {
+		fmt.Printf("No LLM calls recorded for digest %s\n", digestID)
+		fmt.Println("💡 Calls are recorded by 'briefly digest generate'; digests generated before this was added have none")
+		return nil
+	}
+
+	title := digestID
+	if digest, err := db.Digests().Get(ctx, digestID); err == nil && digest.Title != "" {
+		title = digest.Title
+	}
+
+	var total costTotals
+	byPhase := make(map[string]*costTotals)
+	byModel := make(map[string]*costTotals)
+	byArticle := make(map[string]*costTotals)
+	taskModels := llm.ConfiguredTaskModels()
+	repriced := 0.0
+	for _, call := range calls {
+		total.add(call)
+		repriced += taskModels.EstimateCallCost(call)
+
+		if byModel[call.Model] == nil {
+			byModel[call.Model] = &costTotals{}
+		}
+		byModel[call.Model].add(call)
+
+		if byPhase[call.Phase] == nil {
+			byPhase[call.Phase] = &costTotals{}
+		}
+		byPhase[call.Phase].add(call)
+
+		if call.ArticleID != "" {
+			if byArticle[call.ArticleID] == nil {
+				byArticle[call.ArticleID] = &costTotals{}
+			}
+			byArticle[call.ArticleID].add(call)
+		}
+	}
+
+	fmt.Printf("\n💰 Cost breakdown: %s\n", title)
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("Total: %s · %d calls · %d tokens in / %d out · %.1fs LLM time · %d retries",
+		usd(total.Cost), total.Calls, total.TokensIn, total.TokensOut, float64(total.LatencyMs)/1000, total.Retries)
+	if total.Failures > 0 {
+		fmt.Printf(" · %d failed", total.Failures)
+	}
+	fmt.Println()
+
+	phases := make([]string, 0, len(byPhase))
+	for phase := range byPhase {
+		phases = append(phases, phase)
+	}
+	sort.Slice(phases, func(i, j int) bool { return byPhase[phases[i]].Cost > byPhase[phases[j]].Cost })
+
+	fmt.Println("\nBy phase")
+	fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+	fmt.Printf("%-12s %6s %10s %10s %9s %8s %10s\n", "Phase", "Calls", "Tokens in", "Tokens out", "Latency", "Retries", "Cost")
+	for _, phase := range phases {
+		t := byPhase[phase]
+		fmt.Printf("%-12s %6d %10d %10d %8.1fs %8d %10s %s\n", phase, t.Calls, t.TokensIn, t.TokensOut,
+			float64(t.LatencyMs)/1000, t.Retries, usd(t.Cost), costShare(t.Cost, total.Cost))
+	}
+
+	models := make([]string, 0, len(byModel))
+	for model := range byModel {
+		models = append(models, model)
+	}
+	sort.Slice(models, func(i, j int) bool { return byModel[models[i]].Cost > byModel[models[j]].Cost })
+
+	fmt.Println("\nBy model")
+	fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+	fmt.Printf("%-28s %6s %10s %10s %10s\n", "Model", "Calls", "Tokens in", "Tokens out", "Cost")
+	for _, model := range models {
+		t := byModel[model]
+		fmt.Printf("%-28s %6d %10d %10d %10s %s\n", model, t.Calls, t.TokensIn, t.TokensOut, usd(t.Cost), costShare(t.Cost, total.Cost))
+	}
+	if described := taskModels.Describe(); described != "" && math.Abs(repriced-total.Cost) >= 0.00005 {
+		fmt.Printf("With the configured models (%s): %s", described, usd(repriced))
+		if total.Cost > 0 {
+			fmt.Printf(" (%+.0f%%)", (repriced-total.Cost)/total.Cost*100)
+		}
+		fmt.Println()
+	}
+
+	articleIDs := make([]string, 0, len(byArticle))
+	for id := range byArticle {
+		articleIDs = append(articleIDs, id)
+	}
+	sort.Slice(articleIDs, func(i, j int) bool { return byArticle[articleIDs[i]].Cost > byArticle[articleIDs[j]].Cost })
+
+	shown := articleIDs
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+
+	fmt.Printf("\nBy article (%d of %d, most expensive first)\n", len(shown), len(articleIDs))
+	fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+	fmt.Printf("%9s %6s %10s %10s %8s  %s\n", "Cost", "Calls", "Tokens in", "Tokens out", "Retries", "Article")
+	for _, id := range shown {
+		t := byArticle[id]
+		name := id
+		if article, err := db.Articles().Get(ctx, id); err == nil && article.Title != "" {
+			name = article.Title
+		}
+		if len(name) > 40 {
+			name = name[:37] + "..."
+		}
+		fmt.Printf("%9s %6d %10d %10d %8d  %s\n", usd(t.Cost), t.Calls, t.TokensIn, t.TokensOut, t.Retries, name)
+	}
+
+	var digestLevel costTotals
+	for _, call := range calls {
+		if call.ArticleID == "" {
+			digestLevel.add(call)
+		}
+	}
+	if digestLevel.Calls > 0 {
+		fmt.Printf("\nDigest-level calls (narratives, summary): %s across %d calls %s\n",
+			usd(digestLevel.Cost), digestLevel.Calls, costShare(digestLevel.Cost, total.Cost))
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+
+	return nil
+}
+
+// usd formats an estimated cost in dollars
+func usd(cost float64) string {
+	return fmt.Sprintf("$%.4f", cost)
+}
+
+// costShare formats part as a percentage of total
+func costShare(part, total float64) string {
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%.0f%%)", part/total*100)
+}
+
+// runDigestEstimate simulates the LLM calls of 'digest from-file' on an input file,
+// pricing each phase on its configured model. Cached article text sizes the
+// summarization prompts, articles with cached summaries aren't summarized again, and
+// cacheHitRate is the share of the rest expected to be cached by the time the run starts.
+func runDigestEstimate(inputFile string, numClusters int, noCache bool, cacheHitRate float64, outputFormat string, opts digestOptions) error {
+	if cacheHitRate < 0 || cacheHitRate > 1 {
+		return fmt.Errorf("--cache-hit-rate must be between 0 and 1, got %g", cacheHitRate)
+	}
+	if _, err := config.Load(cfgFile); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	links, err := parser.NewParser().ParseMarkdownFile(inputFile)
+	if err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		return fmt.Errorf("no valid URLs found in %s", inputFile)
+	}
+
+	var cache *store.Store
+	if !noCache {
+		if cache, err = openSeriesCache(); err == nil {
+			defer cache.Close()
+		}
+	}
+
+	defaultModel := config.GetAI().Gemini.Model
+	if defaultModel == "" {
+		defaultModel = llm.DefaultModel
+	}
+	if numClusters == 0 {
+		numClusters = clustering.AutoClusterCount(len(links))
+	}
+	input := llm.DigestRunInput{
+		CacheHitRate:   cacheHitRate,
+		Clusters:       numClusters,
+		TwoPass:        config.GetSummarize().TwoPass,
+		Classify:       true,
+		Sentiment:      opts.Sentiment,
+		Perspectives:   opts.Perspectives,
+		DefaultModel:   defaultModel,
+		EmbeddingModel: config.GetAI().Gemini.EmbeddingModel,
+	}
+	// Slack digests are written in one pass; the markdown digest gets critiqued
+	if outputFormat != "slack" {
+		input.CritiquePasses = narrative.DefaultCritiqueConfig().MaxRetries + 1
+	}
+	cachedText := 0
+	for _, link := range links {
+		tokens := 0
+		if cache != nil {
+			if article, err := cache.GetArticleByURL(link.URL); err == nil && article != nil && article.CleanedText != "" {
+				if opts.Audience == "" && cachedSummary(cache, *article) != nil {
+					input.CachedSummaries++
+					continue
+				}
+				tokens = len(article.CleanedText) / 4
+				cachedText++
+			}
+		}
+		input.ArticleTokens = append(input.ArticleTokens, tokens)
+	}
+
+	taskModels := llm.ConfiguredTaskModels()
+	estimates := taskModels.EstimateDigestRun(input)
+
+	fmt.Printf("\n💰 Cost estimate: %s\n", inputFile)
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("%d URL(s): %d to summarize (%d with cached text), %d cached summaries, ~%d topics\n",
+		len(links), len(input.ArticleTokens), cachedText, input.CachedSummaries, min(numClusters, len(links)))
+	if cacheHitRate > 0 {
+		fmt.Printf("Assuming %.0f%% of the URLs to summarize are cached by run time\n", cacheHitRate*100)
+	}
+	fmt.Println()
+	fmt.Printf("%-12s %-28s %6s %10s %10s %10s\n", "Phase", "Model", "Calls", "Tokens in", "Tokens out", "Cost")
+
+	var total, singleModel float64
+	for _, estimate := range estimates {
+		total += estimate.Cost
+		if estimate.Phase == "embedding" {
+			singleModel += estimate.Cost
+		} else {
+			singleModel += llm.EstimateCost(defaultModel, estimate.TokensIn, estimate.TokensOut)
+		}
+	}
+	for _, estimate := range estimates {
+		fmt.Printf("%-12s %-28s %6s %10d %10d %10s %s\n", estimate.Phase, estimate.Model, expectedCalls(estimate.Calls),
+			estimate.TokensIn, estimate.TokensOut, usd(estimate.Cost), costShare(estimate.Cost, total))
+	}
+	fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+	fmt.Printf("Estimated total: %s\n", usd(total))
+	if taskModels.Describe() != "" {
+		fmt.Printf("All on %s: %s\n", defaultModel, usd(singleModel))
+	}
+	fmt.Println("\n💡 Token counts are estimates and fractional calls are averages (e.g. critique retries);")
+	fmt.Println("   'briefly cost breakdown <digest-id>' shows what a run actually used")
+	return nil
+}
+
+// expectedCalls formats an expected call count, with a decimal only when it isn't whole
+func expectedCalls(calls float64) string {
+	if calls == math.Trunc(calls) {
+		return fmt.Sprintf("%d", int(calls))
+	}
+	return fmt.Sprintf("%.1f", calls)
+}
@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/scheduler"
+	"briefly/internal/store"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// daemonStopGrace is how long a running task gets to exit after the daemon is
+// stopped before it is killed
+const daemonStopGrace = 30 * time.Second
+
+// scheduledTaskNames lists the tasks a schedule can run
+var scheduledTaskNames = []string{"feed-pull", "digest", "cache-prune", "trend-report", "ops-digest"}
+
+// NewDaemonCmd creates the daemon command that runs scheduled tasks
+func NewDaemonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run scheduled tasks from the schedules block in config",
+		Long: `Run in the foreground and start tasks on the cron schedules configured under
+schedules in .briefly.yaml, until interrupted (Ctrl+C or SIGTERM).
+
+Tasks:
+  feed-pull     - 'briefly feed pull'
+  digest        - 'briefly digest --issue next', with --series when series is set
+  cache-prune   - 'briefly cache prune'
+  trend-report  - 'briefly quality trends', with --notify when series is set
+  ops-digest    - 'briefly ops digest', with --notify when series is set
+
+Each run is a separate briefly process, so a failing task can't take the daemon
+down. A task still running when it comes due again is skipped, not started twice.
+Every run, failure, and skip is recorded in the cache ('briefly daemon history').
+Cron times are in the publication time zone (schedule.timezone; default local time).
+
+Example configuration:
+  schedules:
+    - name: hourly-pull
+      cron: "0 * * * *"
+      task: feed-pull
+    - name: ai-weekly
+      cron: "0 9 * * mon"
+      task: digest
+      series: ai-weekly
+    - cron: "@daily"
+      task: cache-prune
+      args: ["--older-than", "120"]
+
+Subcommands:
+  list    - Show the schedules and when each runs next
+  history - Show recent runs`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaemon(cmd.Context())
+		},
+	}
+
+	cmd.AddCommand(newDaemonListCmd())
+	cmd.AddCommand(newDaemonHistoryCmd())
+
+	return cmd
+}
+
+func newDaemonListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Show the configured schedules and their next runs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobs, commands, err := scheduledJobs()
+			if err != nil {
+				return err
+			}
+			printSchedules(jobs, commands, publicationNow())
+			return nil
+		},
+	}
+}
+
+func newDaemonHistoryCmd() *cobra.Command {
+	var (
+		name  string
+		limit int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show recent runs of scheduled tasks",
+		Long: `Show recent runs of scheduled tasks, newest first: when each started, how long
+it took, and how it ended (succeeded, failed, skipped because the previous run
+was still going, or interrupted when the daemon stopped mid-run).
+
+Examples:
+  briefly daemon history
+  briefly daemon history --name hourly-pull --limit 50`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaemonHistory(name, limit)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Only show runs of this schedule")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Maximum number of runs to show")
+
+	return cmd
+}
+
+func runDaemon(ctx context.Context) error {
+	jobs, commands, err := scheduledJobs()
+	if err != nil {
+		return err
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	if interrupted, err := cache.InterruptScheduleRuns(); err != nil {
+		return err
+	} else if interrupted > 0 {
+		fmt.Printf("⚠️  %d run(s) were cut short when the daemon last stopped\n", interrupted)
+	}
+
+	sched, err := scheduler.New(jobs, cache, func(format string, args ...any) {
+		fmt.Printf("%s %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, args...))
+	})
+	if err != nil {
+		return err
+	}
+	sched.SetLocation(config.GetPublicationLocation())
+
+	printSchedules(jobs, commands, publicationNow())
+	fmt.Println("\n⏰ Daemon running (Ctrl+C to stop)")
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := sched.Run(ctx); err != nil {
+		return err
+	}
+
+	fmt.Println("👋 Daemon stopped")
+	return nil
+}
+
+// scheduledJobs builds a job per configured schedule, along with the briefly command
+// line each one runs
+func scheduledJobs() ([]scheduler.Job, [][]string, error) {
+	if _, err := config.Load(cfgFile); err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	tasks := config.GetSchedules()
+	if len(tasks) == 0 {
+		return nil, nil, fmt.Errorf("no schedules configured (add a schedules block to .briefly.yaml; see 'briefly daemon --help')")
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to locate the briefly executable: %w", err)
+	}
+
+	jobs := make([]scheduler.Job, 0, len(tasks))
+	commands := make([][]string, 0, len(tasks))
+	for i, task := range tasks {
+		name := task.Name
+		if name == "" {
+			name = task.Task
+		}
+		cron, err := scheduler.ParseCron(task.Cron)
+		if err != nil {
+			return nil, nil, fmt.Errorf("schedule %d (%s): %w", i+1, name, err)
+		}
+		args, err := scheduledTaskArgs(task)
+		if err != nil {
+			return nil, nil, fmt.Errorf("schedule %d (%s): %w", i+1, name, err)
+		}
+
+		jobs = append(jobs, scheduler.Job{
+			Name:     name,
+			Task:     task.Task,
+			Schedule: cron,
+			Run: func(ctx context.Context) error {
+				return runScheduledCommand(ctx, executable, args)
+			},
+		})
+		commands = append(commands, args)
+	}
+	return jobs, commands, nil
+}
+
+// scheduledTaskArgs returns the briefly arguments that run a scheduled task
+func scheduledTaskArgs(task config.ScheduledTask) ([]string, error) {
+	var args []string
+	switch task.Task {
+	case "feed-pull":
+		args = []string{"feed", "pull"}
+	case "digest":
+		args = []string{"digest", "--issue", "next"}
+		if task.Series != "" {
+			args = append(args, "--series", task.Series)
+		}
+	case "cache-prune":
+		args = []string{"cache", "prune"}
+	case "trend-report":
+		args = []string{"quality", "trends"}
+		if task.Series != "" {
+			args = append(args, "--notify", task.Series)
+		}
+	case "ops-digest":
+		args = []string{"ops", "digest"}
+		if task.Series != "" {
+			args = append(args, "--notify", task.Series)
+		}
+	case "":
+		return nil, fmt.Errorf("task is required (%s)", strings.Join(scheduledTaskNames, ", "))
+	default:
+		return nil, fmt.Errorf("unknown task %q (supported: %s)", task.Task, strings.Join(scheduledTaskNames, ", "))
+	}
+
+	if cfgFile != "" {
+		args = append(args, "--config", cfgFile)
+	}
+	return append(args, task.Args...), nil
+}
+
+// runScheduledCommand runs briefly with args, passing its output through. When ctx
+// is cancelled the command is interrupted, then killed after daemonStopGrace.
+func runScheduledCommand(ctx context.Context, executable string, args []string) error {
+	stderr := &lastLine{}
+	cmd := exec.CommandContext(ctx, executable, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = io.MultiWriter(os.Stderr, stderr)
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = daemonStopGrace
+
+	if err := cmd.Run(); err != nil {
+		if line := stderr.String(); line != "" {
+			return fmt.Errorf("%w: %s", err, line)
+		}
+		return err
+	}
+	return nil
+}
+
+// lastLine remembers the last non-empty line written to it, to explain a failed run
+type lastLine struct {
+	mu      sync.Mutex
+	line    string
+	partial string
+}
+
+func (l *lastLine) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, line := range strings.Split(l.partial+string(p), "\n") {
+		l.partial = line
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			l.line = trimmed
+		}
+	}
+	return len(p), nil
+}
+
+func (l *lastLine) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return truncateQueueText(l.line, 200)
+}
+
+func printSchedules(jobs []scheduler.Job, commands [][]string, now time.Time) {
+	fmt.Printf("🗓️  Schedules (%d)\n\n", len(jobs))
+	fmt.Printf("  %-20s %-16s %-17s  %s\n", "NAME", "CRON", "NEXT RUN", "COMMAND")
+	for i, job := range jobs {
+		next := "never"
+		if at := job.Schedule.Next(now); !at.IsZero() {
+			next = at.Format("Mon 01-02 15:04")
+		}
+		fmt.Printf("  %-20s %-16s %-17s  briefly %s\n", truncateQueueText(job.Name, 20), job.Schedule, next, strings.Join(commands[i], " "))
+	}
+}
+
+func runDaemonHistory(name string, limit int) error {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	runs, err := cache.ListScheduleRuns(name, limit)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		fmt.Println("No scheduled runs recorded yet")
+		fmt.Println("💡 Start the daemon with 'briefly daemon'")
+		return nil
+	}
+
+	icons := map[string]string{
+		scheduler.StatusRunning:      "▶️ ",
+		scheduler.StatusSucceeded:    "✅",
+		scheduler.StatusFailed:       "❌",
+		scheduler.StatusSkipped:      "⏭️ ",
+		store.ScheduleRunInterrupted: "⚠️ ",
+	}
+
+	fmt.Printf("📜 Scheduled runs (%d)\n\n", len(runs))
+	for _, run := range runs {
+		took := ""
+		if !run.FinishedAt.IsZero() {
+			took = " in " + run.FinishedAt.Sub(run.StartedAt).Round(time.Second).String()
+		}
+		fmt.Printf("%s %s  %-20s %s%s\n", icons[run.Status], run.StartedAt.Local().Format("2006-01-02 15:04"), truncateQueueText(run.Name, 20), run.Status, took)
+		if run.Message != "" {
+			fmt.Printf("     %s\n", run.Message)
+		}
+	}
+	return nil
+}
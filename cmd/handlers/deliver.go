@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/delivery"
+	"briefly/internal/email"
+	"context"
+	"fmt"
+	"os"
+)
+
+// deliveryChannels builds the channels under delivery.channels, filling unset
+// settings from the messaging, email, and tts sections
+func deliveryChannels() ([]delivery.Channel, error) {
+	cfg := config.GetDelivery()
+	if len(cfg.Channels) == 0 {
+		return nil, fmt.Errorf("--deliver needs at least one entry under delivery.channels in config")
+	}
+
+	messaging := config.GetMessaging()
+	tts := config.GetTTS()
+	channels := make([]delivery.Channel, 0, len(cfg.Channels))
+	for i, c := range cfg.Channels {
+		channel := delivery.Channel{
+			Type:       c.Type,
+			Name:       c.Name,
+			Format:     c.Format,
+			Directory:  c.Directory,
+			To:         c.To,
+			WebhookURL: c.WebhookURL,
+		}
+		switch c.Type {
+		case delivery.TypeEmail:
+			channel.Sender = emailSender()
+		case delivery.TypeSlack:
+			if channel.WebhookURL == "" {
+				channel.WebhookURL = messaging.Slack.WebhookURL
+			}
+		case delivery.TypeDiscord:
+			if channel.WebhookURL == "" {
+				channel.WebhookURL = messaging.Discord.WebhookURL
+			}
+		case delivery.TypeTTS:
+			channel.TTS = ttsOptions(tts, c)
+			if channel.Directory == "" {
+				channel.Directory = tts.OutputDirectory
+			}
+		case delivery.TypeConfluence:
+			channel.Confluence = delivery.ConfluenceOptions{
+				BaseURL:  cfg.Confluence.BaseURL,
+				Space:    c.Space,
+				ParentID: c.ParentID,
+				Username: cfg.Confluence.Username,
+				APIToken: cfg.Confluence.APIToken,
+			}
+		}
+		if err := channel.Validate(); err != nil {
+			return nil, fmt.Errorf("delivery channel %d: %w", i+1, err)
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+// ttsOptions resolves a tts channel's provider, voice, and key from the tts section
+func ttsOptions(tts config.TTS, c config.DeliveryChannel) delivery.TTSOptions {
+	opts := delivery.TTSOptions{Provider: c.Provider, Voice: c.Voice, Speed: float64(tts.DefaultSpeed)}
+	if opts.Provider == "" {
+		opts.Provider = tts.DefaultProvider
+	}
+	switch opts.Provider {
+	case delivery.ProviderOpenAI:
+		opts.APIKey = tts.Providers.OpenAI.APIKey
+		opts.Model = tts.Providers.OpenAI.Model
+		if opts.Voice == "" {
+			opts.Voice = tts.DefaultVoice
+		}
+	case delivery.ProviderElevenLabs:
+		opts.APIKey = tts.Providers.ElevenLabs.APIKey
+	}
+	return opts
+}
+
+// emailSender builds an SMTP sender from the email section
+func emailSender() email.Sender {
+	emailCfg := config.GetEmail()
+	return email.Sender{
+		Host:       emailCfg.SMTP.Host,
+		Port:       emailCfg.SMTP.Port,
+		Username:   emailCfg.SMTP.Username,
+		Password:   emailCfg.SMTP.Password,
+		TLSEnabled: emailCfg.SMTP.TLSEnabled,
+		From:       emailCfg.FromAddress,
+		FromName:   emailCfg.FromName,
+	}
+}
+
+// deliverDigest sends the saved digest at path to every configured channel.
+// Failures are reported per channel but never fail the command.
+func deliverDigest(ctx context.Context, title, path, format string) {
+	channels, err := deliveryChannels()
+	if err != nil {
+		fmt.Printf("   ⚠️  Delivery skipped: %v\n", err)
+		return
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("   ⚠️  Delivery skipped: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\n📬 Delivering to %d channel(s)...\n", len(channels))
+	results := delivery.Dispatch(context.WithoutCancel(ctx), channels, delivery.Digest{
+		Title:   title,
+		Content: string(content),
+		Format:  format,
+		Path:    path,
+	})
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("   ⚠️  %s: %v\n", result.Channel, result.Err)
+			continue
+		}
+		fmt.Printf("   ✓ %s: %s\n", result.Channel, result.Target)
+	}
+}
@@ -1,11 +1,36 @@
 package handlers
 
 import (
+	"briefly/internal/config"
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
 // NewDigestCmd creates the parent digest command with subcommands
 func NewDigestCmd() *cobra.Command {
+	var (
+		fromCache      bool
+		fromFeeds      bool
+		category       string
+		since          string
+		until          string
+		outputDir      string
+		numClusters    int
+		themeThreshold float64
+		outputFormat   string
+		trackLinks     bool
+		issue          string
+		seriesKey      string
+		audience       string
+		figures        bool
+		excludeRead    bool
+		scoreSentiment bool
+		deliver        bool
+		social         bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "digest",
 		Short: "Manage and generate digests",
@@ -16,6 +41,23 @@ Subcommands:
   from-file - Generate digest from curated markdown file
   list      - List recent digests from database
   show      - Display a specific digest
+  series    - List named series or show a series' issue history
+  verify    - Check a digest file against its provenance and signature
+
+Without a subcommand, --from-cache builds a digest purely from articles
+already in the local cache for a date range (no input file, no fetching).
+--issue picks that range from the publishing schedule (schedule.* in config):
+"next" covers everything since the previous issue, skips holidays, and names
+the output after the issue. --series selects a named series (series.* in
+config) with its own title template, format, output directory, delivery
+webhooks, audience, and topic/my-take history.
+--deliver renders the finished digest once per channel under delivery.channels
+in config (file, email, slack, discord, tts, confluence), each in its own format,
+and sends it in the same run.
+
+--from-feeds --category builds a digest from database articles whose feeds
+are filed under a category (see 'briefly feed category'), for the same
+--since/--until range (default: the last 7 days).
 
 Examples:
   # Generate from database (last 7 days)
@@ -28,15 +70,98 @@ Examples:
   briefly digest list --limit 20
 
   # Show a specific digest
-  briefly digest show abc123`,
+  briefly digest show abc123
+
+  # Build a digest from a week of cached articles
+  briefly digest --from-cache --since 2025-06-01 --until 2025-06-07
+
+  # Build the next scheduled issue (e.g. Tuesdays and Fridays)
+  briefly digest --issue next
+
+  # Build the next issue of a named series (own title, format, output, delivery)
+  briefly digest --series ai-weekly --issue next
+
+  # Digest only the security feeds from the past week
+  briefly digest --from-feeds --category security
+
+  # Build the next issue and send it to every configured delivery channel
+  briefly digest --issue next --deliver`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			issueName := ""
+			if issue != "" {
+				if since != "" || until != "" {
+					return fmt.Errorf("--issue sets the date range; do not combine it with --since/--until")
+				}
+				var err error
+				since, until, issueName, err = resolveIssueWindow(issue, time.Now())
+				if err != nil {
+					return err
+				}
+				if !fromFeeds {
+					fromCache = true
+				}
+			}
+			if fromCache && fromFeeds {
+				return fmt.Errorf("--from-cache and --from-feeds are mutually exclusive")
+			}
+			if category != "" && !fromFeeds {
+				return fmt.Errorf("--category requires --from-feeds")
+			}
+			if !fromCache && !fromFeeds {
+				return cmd.Help()
+			}
+			ser, err := resolveDigestSeries(cmd, seriesKey, &outputDir, &outputFormat)
+			if err != nil {
+				return err
+			}
+			digestOpts := digestOptions{Figures: figures, ExcludeRead: excludeRead, Sentiment: scoreSentiment, Deliver: deliver, Social: social}
+			if deliver {
+				// Catch delivery misconfiguration before spending on the run
+				if _, err := deliveryChannels(); err != nil {
+					return err
+				}
+			}
+			if digestOpts.Audience, err = resolveAudience(audience, ser); err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("figures") {
+				digestOpts.Figures = config.GetVisual().Figures.Describe
+			}
+			if fromFeeds {
+				return runDigestFromFeeds(cmd.Context(), category, since, until, outputDir, numClusters, themeThreshold, outputFormat, trackLinks, cmd.Flags().Changed("track-links"), issueName, ser, digestOpts)
+			}
+			return runDigestFromCache(cmd.Context(), since, until, outputDir, numClusters, themeThreshold, outputFormat, trackLinks, cmd.Flags().Changed("track-links"), issueName, ser, digestOpts)
+		},
 	}
 
+	cmd.Flags().BoolVar(&fromCache, "from-cache", false, "Build digest from articles already in the local cache")
+	cmd.Flags().BoolVar(&fromFeeds, "from-feeds", false, "Build digest from database articles of one feed category (requires --category)")
+	cmd.Flags().StringVar(&category, "category", "", "Feed category for --from-feeds (e.g. security)")
+	cmd.Flags().StringVar(&since, "since", "", "Start date for --from-cache/--from-feeds (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&until, "until", "", "End date for --from-cache/--from-feeds, inclusive (YYYY-MM-DD, default: today)")
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "digests", "Output directory for digest file")
+	cmd.Flags().IntVar(&numClusters, "clusters", 0, "Number of clusters (0 = auto-determine)")
+	cmd.Flags().Float64Var(&themeThreshold, "theme-threshold", 0.4, "Minimum theme relevance score (0.0-1.0)")
+	cmd.Flags().StringVar(&outputFormat, "format", "markdown", "Output format: markdown (default), slack")
+	cmd.Flags().BoolVar(&trackLinks, "track-links", false, "Rewrite article links for click tracking (default: link_tracking.enabled)")
+	cmd.Flags().StringVar(&issue, "issue", "", "Build a scheduled issue from the cache: next, previous, or its date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&seriesKey, "series", "", "Named series (series.* in config) supplying title template, format, output dir, and delivery")
+	cmd.Flags().StringVar(&audience, "audience", "", "Write for: expert, practitioner, exec, or newcomer (default: the series' audience)")
+	cmd.Flags().BoolVar(&figures, "figures", false, "Describe chart/benchmark images in cached articles with the vision model (default: visual.figures.describe)")
+	cmd.Flags().BoolVar(&excludeRead, "exclude-read", false, "Leave out articles already marked read (see 'briefly cache read-status')")
+	cmd.Flags().BoolVar(&scoreSentiment, "sentiment", false, "Score article sentiment in batches, reusing scores cached by earlier runs")
+	cmd.Flags().BoolVar(&deliver, "deliver", false, "Send the digest to every channel under delivery.channels (file, email, slack, discord, tts, confluence)")
+	cmd.Flags().BoolVar(&social, "social", false, "Also draft ready-to-paste LinkedIn and X posts from the digest into <digest>.social.md")
+
 	// Add subcommands
 	cmd.AddCommand(NewDigestGenerateCmd()) // Database-driven digest generation
 	cmd.AddCommand(NewDigestFromFileCmd()) // File-based digest generation
 	cmd.AddCommand(NewDigestListCmd())     // List recent digests
 	cmd.AddCommand(NewDigestShowCmd())     // Show specific digest
 	cmd.AddCommand(NewDigestCompareCmd())  // Compare digests (A/B testing)
+	cmd.AddCommand(NewDigestSeriesCmd())   // Named series and their history
+	cmd.AddCommand(NewDigestVerifyCmd())   // Check provenance and signature
 
 	return cmd
 }
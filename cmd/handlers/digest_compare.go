@@ -34,7 +34,8 @@ Examples:
 
   # Batch compare multiple variants
   briefly digest compare --baseline 100 --variants 101,102,103`,
-		RunE: runDigestCompare,
+		ValidArgsFunction: completeDigestIDs(2),
+		RunE:              runDigestCompare,
 	}
 
 	// Flags
@@ -42,6 +43,9 @@ Examples:
 	cmd.Flags().StringSlice("variants", []string{}, "Comma-separated variant digest IDs")
 	cmd.Flags().Bool("previous", false, "Compare against previous digest (requires --digest-id)")
 	cmd.Flags().String("digest-id", "", "Digest ID for --previous comparison")
+	_ = cmd.RegisterFlagCompletionFunc("baseline", completeDigestIDFlag)
+	_ = cmd.RegisterFlagCompletionFunc("digest-id", completeDigestIDFlag)
+	_ = cmd.RegisterFlagCompletionFunc("variants", completeDigestIDList)
 
 	return cmd
 }
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/fetch"
+	"briefly/internal/llm"
+	"briefly/internal/logger"
+	"briefly/internal/schedule"
+	"briefly/internal/series"
+	"briefly/internal/store"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// cacheDateLayout is the accepted format for --since/--until in from-cache mode
+const cacheDateLayout = "2006-01-02"
+
+// parseCacheDateRange parses --since/--until into an inclusive [start, end] range.
+// An empty until defaults to today; the end date covers the whole day. Dates are
+// days in now's location.
+func parseCacheDateRange(since, until string, now time.Time) (time.Time, time.Time, error) {
+	if since == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("--since is required with --from-cache (format: YYYY-MM-DD)")
+	}
+
+	start, err := time.ParseInLocation(cacheDateLayout, since, now.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --since date %q (expected YYYY-MM-DD): %w", since, err)
+	}
+
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if until != "" {
+		end, err = time.ParseInLocation(cacheDateLayout, until, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --until date %q (expected YYYY-MM-DD): %w", until, err)
+		}
+	}
+	end = end.Add(24*time.Hour - time.Nanosecond)
+
+	if end.Before(start) {
+		if until == "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("--since (%s) is after today (%s); --until defaults to today", since, now.Format(cacheDateLayout))
+		}
+		return time.Time{}, time.Time{}, fmt.Errorf("--until (%s) is before --since (%s)", until, since)
+	}
+
+	return start, end, nil
+}
+
+// resolveIssueWindow resolves an --issue value against the configured schedule into
+// the --since/--until dates of the issue's window and its output name
+func resolveIssueWindow(spec string, now time.Time) (string, string, string, error) {
+	if _, err := config.Load(cfgFile); err != nil {
+		return "", "", "", fmt.Errorf("failed to load config: %w", err)
+	}
+	sched := config.GetSchedule()
+
+	calendar, err := schedule.NewCalendar(sched.Preset, sched.PublishDays, sched.Holidays, sched.SkipHolidays, sched.FirstIssue)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid schedule config: %w", err)
+	}
+	calendar.Location = config.GetPublicationLocation()
+
+	issue, err := calendar.Resolve(spec, now)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	fmt.Printf("🗓️  %s: articles from %s through %s\n", issue.Label(),
+		issue.WindowStart.Format("Mon Jan 2"), issue.WindowEnd.Format("Mon Jan 2"))
+
+	return issue.WindowStart.Format(cacheDateLayout), issue.WindowEnd.Format(cacheDateLayout), issue.Name(), nil
+}
+
+// publicationNow is the current time in the publication time zone
+// (schedule.timezone), which issue dates, windows, and file names are reckoned in
+func publicationNow() time.Time {
+	return time.Now().In(config.GetPublicationLocation())
+}
+
+// runDigestFromCache builds a digest purely from articles already stored in the
+// local cache, without reading an input file or fetching anything
+func runDigestFromCache(ctx context.Context, since, until string, outputDir string, numClusters int, themeThreshold float64, outputFormat string, trackLinks bool, trackLinksSet bool, issueName string, ser *series.Series, digestOpts digestOptions) error {
+	startTime := time.Now()
+	log := logger.Get()
+
+	// Config first: --since/--until are days in the publication time zone
+	_, err := config.Load(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg := config.Get()
+
+	start, end, err := parseCacheDateRange(since, until, publicationNow())
+	if err != nil {
+		return err
+	}
+
+	log.Info("Starting digest generation from cache",
+		"since", start.Format(cacheDateLayout),
+		"until", end.Format(cacheDateLayout),
+		"output_dir", outputDir,
+		"format", outputFormat,
+	)
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	cacheDir := cfg.Cache.Directory
+	if cacheDir == "" {
+		cacheDir = ".briefly-cache"
+	}
+	cache, err := store.NewStore(cacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+	defer cache.Close()
+
+	// Steps 1-2: Load articles from the cache instead of parsing and fetching
+	rangeLabel := fmt.Sprintf("cache %s → %s", start.Format(cacheDateLayout), end.Format(cacheDateLayout))
+	fmt.Printf("\n💾 Step 1-2/9: Loading cached articles (%s)...\n", rangeLabel)
+
+	cached, err := cache.GetArticlesByDateRange(start, end)
+	if err != nil {
+		return fmt.Errorf("failed to load cached articles: %w", err)
+	}
+
+	articles := prepareCachedArticles(cached)
+	if digestOpts.ExcludeRead {
+		read, err := loadReadURLs(cache)
+		if err != nil {
+			return err
+		}
+		var skipped int
+		if articles, skipped = excludeReadArticles(articles, read); skipped > 0 {
+			fmt.Printf("   ✓ Skipped %d already-read article(s)\n", skipped)
+		}
+	}
+	if len(articles) == 0 {
+		fmt.Println("⚠️  No cached articles with content found in this date range")
+		fmt.Println("💡 Populate the cache with 'briefly read <url>' or 'briefly digest from-file'")
+		return nil
+	}
+
+	fmt.Printf("   ✓ Loaded %d/%d cached articles\n", len(articles), len(cached))
+
+	modelName := cfg.AI.Gemini.Model
+	if modelName == "" {
+		modelName = "gemini-3-flash-preview"
+	}
+
+	fmt.Printf("🔧 Initializing AI client (model: %s%s)...\n", modelName, taskModelNote())
+	llmClient, err := llm.NewClient(modelName)
+	if err != nil {
+		return fmt.Errorf("failed to create LLM client: %w", err)
+	}
+	defer llmClient.Close()
+
+	if !trackLinksSet {
+		trackLinks = cfg.LinkTracking.Enabled
+	}
+
+	return generateDigestFromArticles(ctx, llmClient, cache, articles, outputDir, numClusters, themeThreshold, outputFormat, startTime, rangeLabel, len(cached), trackLinks, false, issueName, ser, digestOpts)
+}
+
+// prepareCachedArticles drops articles without content, removes duplicate URLs
+// (including different URLs with the same canonical URL),
+// and fills in identifiers that the cache schema does not persist
+func prepareCachedArticles(cached []core.Article) []core.Article {
+	seen := make(map[string]bool)
+	articles := make([]core.Article, 0, len(cached))
+
+	for _, article := range cached {
+		if article.CleanedText == "" {
+			continue
+		}
+
+		key := article.URL
+		if key == "" {
+			key = article.LinkID
+		}
+		// The same story syndicated or linked with tracking parameters shares a canonical URL
+		if seen[key] || (article.CanonicalURL != "" && seen[article.CanonicalURL]) {
+			continue
+		}
+		seen[key] = true
+		if article.CanonicalURL != "" {
+			seen[article.CanonicalURL] = true
+		}
+
+		if article.ID == "" {
+			article.ID = uuid.NewSHA1(uuid.NameSpaceURL, []byte(key)).String()
+		}
+		if article.EstimatedReadMinutes == 0 {
+			article.EstimatedReadMinutes = fetch.CalculateReadingTime(&article)
+		}
+
+		articles = append(articles, article)
+	}
+
+	return articles
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/llm"
+	"briefly/internal/series"
+	"briefly/internal/sources"
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// maxCategoryArticles caps how many articles a category digest loads from the database
+const maxCategoryArticles = 500
+
+// runDigestFromFeeds builds a digest from database articles that came from the feeds
+// filed under category, fetched within the --since/--until date range
+func runDigestFromFeeds(ctx context.Context, category, since, until string, outputDir string, numClusters int, themeThreshold float64, outputFormat string, trackLinks bool, trackLinksSet bool, issueName string, ser *series.Series, digestOpts digestOptions) error {
+	startTime := time.Now()
+
+	category = sources.NormalizeCategory(category)
+	if category == "" {
+		return fmt.Errorf("--from-feeds requires --category (see 'briefly feed list')")
+	}
+
+	if _, err := config.Load(cfgFile); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	now := publicationNow()
+	if since == "" {
+		since = now.AddDate(0, 0, -7).Format(cacheDateLayout)
+	}
+	start, end, err := parseCacheDateRange(since, until, now)
+	if err != nil {
+		return err
+	}
+
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	cfg := config.Get()
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Steps 1-2: Load the category's articles from the database
+	rangeLabel := fmt.Sprintf("feeds in %s, %s → %s", category, start.Format(cacheDateLayout), end.Format(cacheDateLayout))
+	fmt.Printf("\n🗂️  Step 1-2/9: Loading articles (%s)...\n", rangeLabel)
+
+	stored, err := db.Articles().GetByFeedCategory(ctx, category, start, end, maxCategoryArticles)
+	if err != nil {
+		return fmt.Errorf("failed to load articles for category %s: %w", category, err)
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	articles := prepareCachedArticles(stored)
+	if digestOpts.ExcludeRead {
+		read, err := loadReadURLs(cache)
+		if err != nil {
+			return err
+		}
+		var skipped int
+		if articles, skipped = excludeReadArticles(articles, read); skipped > 0 {
+			fmt.Printf("   ✓ Skipped %d already-read article(s)\n", skipped)
+		}
+	}
+	if len(articles) == 0 {
+		fmt.Printf("⚠️  No articles with content from %s feeds in this date range\n", category)
+		fmt.Println("💡 File feeds with 'briefly feed category <feed-id> <category>' and run 'briefly aggregate'")
+		return nil
+	}
+
+	fmt.Printf("   ✓ Loaded %d/%d articles\n", len(articles), len(stored))
+
+	modelName := cfg.AI.Gemini.Model
+	if modelName == "" {
+		modelName = "gemini-3-flash-preview"
+	}
+
+	fmt.Printf("🔧 Initializing AI client (model: %s%s)...\n", modelName, taskModelNote())
+	llmClient, err := llm.NewClient(modelName)
+	if err != nil {
+		return fmt.Errorf("failed to create LLM client: %w", err)
+	}
+	defer llmClient.Close()
+
+	if !trackLinksSet {
+		trackLinks = cfg.LinkTracking.Enabled
+	}
+
+	return generateDigestFromArticles(ctx, llmClient, cache, articles, outputDir, numClusters, themeThreshold, outputFormat, startTime, rangeLabel, len(stored), trackLinks, false, issueName, ser, digestOpts)
+}
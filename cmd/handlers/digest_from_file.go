@@ -3,21 +3,37 @@ package handlers
 import (
 	"briefly/internal/agent"
 	"briefly/internal/agent/tools"
+	"briefly/internal/authors"
 	"briefly/internal/clustering"
 	"briefly/internal/config"
 	"briefly/internal/core"
+	"briefly/internal/corpus"
+	"briefly/internal/curate"
+	"briefly/internal/events"
 	"briefly/internal/fetch"
+	"briefly/internal/links"
 	"briefly/internal/llm"
 	"briefly/internal/logger"
 	"briefly/internal/markdown"
 	"briefly/internal/narrative"
 	"briefly/internal/parser"
+	"briefly/internal/persistence"
+	"briefly/internal/quality"
+	"briefly/internal/render"
+	"briefly/internal/sentiment"
+	"briefly/internal/series"
+	"briefly/internal/snapshot"
 	"briefly/internal/store"
 	"briefly/internal/summarize"
 	"briefly/internal/themes"
+	"briefly/internal/topics"
+	"briefly/internal/transcript"
+	"briefly/internal/visual"
+	"bufio"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -36,10 +52,18 @@ func NewDigestFromFileCmd() *cobra.Command {
 		useAgent         bool
 		maxIterations    int
 		qualityThreshold float64
+		trackLinks       bool
+		offline          bool
+		dryRun           bool
+		cacheHitRate     float64
+		batch            bool
+		seriesKey        string
+		audience         string
+		digestOpts       digestOptions
 	)
 
 	cmd := &cobra.Command{
-		Use:   "from-file <input.md>",
+		Use:   "from-file [input.md]",
 		Short: "Generate digest from curated markdown file",
 		Long: `Generate a digest from a curated markdown file containing URLs.
 
@@ -69,13 +93,83 @@ Examples:
   briefly digest from-file input/weekly.md --clusters 5
 
   # Generate Slack-optimized digest
-  briefly digest from-file input/weekly.md --format slack`,
-		Args: cobra.ExactArgs(1),
+  briefly digest from-file input/weekly.md --format slack
+
+  # Rewrite article links for click tracking (see 'briefly stats clicks')
+  briefly digest from-file input/weekly.md --format slack --track-links
+
+  # Estimate the LLM cost on the configured models without calling them
+  briefly digest from-file input/weekly.md --dry-run
+
+  # Plan a scheduled run where about half the links get read (and summarized) first
+  briefly digest from-file input/weekly.md --dry-run --cache-hit-rate 0.5
+
+  # Summarize via the Gemini Batch API (lower cost, results can take hours)
+  briefly digest from-file input/weekly.md --batch
+
+  # Publish as an issue of a named series
+  briefly digest from-file input/platform.md --series platform-notes
+
+  # Pin an article as the Must-Read instead of the LLM's pick
+  briefly digest from-file input/weekly.md --must-read https://example.com/post
+
+  # Add an "Other side" viewpoint on the top story
+  briefly digest from-file input/weekly.md --perspectives
+
+  # Explain things for readers new to the topic
+  briefly digest from-file input/weekly.md --audience newcomer
+
+  # Describe chart and benchmark images with the vision model
+  briefly digest from-file input/weekly.md --figures
+
+  # Review the LLM's suggested cuts (duplicates, low-value links) before summarizing
+  briefly digest from-file input/weekly.md --curate
+
+  # Also write HTML, email it, and post it to Slack (delivery.channels in config)
+  briefly digest from-file input/weekly.md --deliver
+
+  # Run end-to-end on the bundled sample corpus (no network or API key)
+  briefly digest from-file --offline`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if offline {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			inputFile := ""
+			if len(args) > 0 {
+				inputFile = args[0]
+			}
+			if dryRun {
+				return runDigestEstimate(inputFile, numClusters, noCache, cacheHitRate, outputFormat, digestOpts)
+			}
 			if useAgent {
-				return runAgentDigest(cmd.Context(), args[0], outputDir, noCache, maxIterations, qualityThreshold, outputFormat)
+				if offline || batch || seriesKey != "" || audience != "" || digestOpts.Deliver || digestOpts.Curate {
+					return fmt.Errorf("--agent is not supported with --offline, --batch, --series, --audience, --deliver, or --curate")
+				}
+				return runAgentDigest(cmd.Context(), inputFile, outputDir, noCache, maxIterations, qualityThreshold, outputFormat)
+			}
+			ser, err := resolveDigestSeries(cmd, seriesKey, &outputDir, &outputFormat)
+			if err != nil {
+				return err
+			}
+			if ser == nil {
+				applyOutputDefaults(cmd, &outputDir, &outputFormat)
+			}
+			if digestOpts.Audience, err = resolveAudience(audience, ser); err != nil {
+				return err
 			}
-			return runDigestFromFile(cmd.Context(), args[0], outputDir, numClusters, noCache, themeThreshold, outputFormat)
+			if digestOpts.Deliver {
+				// Catch delivery misconfiguration before spending on the run
+				if _, err := deliveryChannels(); err != nil {
+					return err
+				}
+			}
+			if !cmd.Flags().Changed("figures") {
+				digestOpts.Figures = config.GetVisual().Figures.Describe
+			}
+			return runDigestFromFile(cmd.Context(), inputFile, outputDir, numClusters, noCache, themeThreshold, outputFormat, trackLinks, cmd.Flags().Changed("track-links"), offline, batch, ser, digestOpts)
 		},
 	}
 
@@ -87,6 +181,21 @@ Examples:
 	cmd.Flags().BoolVar(&useAgent, "agent", false, "Use agentic digest generation with reflect/revise loop")
 	cmd.Flags().IntVar(&maxIterations, "max-iterations", 3, "Max reflect/revise iterations (agent mode only)")
 	cmd.Flags().Float64Var(&qualityThreshold, "quality-threshold", 0.7, "Min quality score 0-1 (agent mode only)")
+	cmd.Flags().BoolVar(&trackLinks, "track-links", false, "Rewrite article links through the configured short-link tracker (default: link_tracking.enabled)")
+	cmd.Flags().BoolVar(&batch, "batch", false, "Summarize articles with one Gemini Batch API job (cheaper, can take hours; for scheduled runs)")
+	cmd.Flags().StringVar(&seriesKey, "series", "", "Named series (series.* in config) supplying title template, format, output dir, and delivery")
+	cmd.Flags().StringVar(&digestOpts.MustReadURL, "must-read", "", "URL of an article to pin as the Must-Read, overriding the LLM's choice")
+	cmd.Flags().BoolVar(&digestOpts.Perspectives, "perspectives", false, "Add an \"Other side\" viewpoint on the top story, from the sources or a quick web search")
+	cmd.Flags().StringVar(&audience, "audience", "", "Write for: expert, practitioner, exec, or newcomer (default: the series' audience)")
+	cmd.Flags().BoolVar(&digestOpts.Figures, "figures", false, "Describe chart/benchmark images in articles with the vision model (default: visual.figures.describe)")
+	cmd.Flags().BoolVar(&digestOpts.ExcludeRead, "exclude-read", false, "Skip URLs already marked read (see 'briefly cache read-status')")
+	cmd.Flags().BoolVar(&digestOpts.Curate, "curate", false, "Before summarizing, have the LLM flag likely duplicate or low-value links from their titles, and confirm which to cut")
+	cmd.Flags().BoolVar(&digestOpts.Sentiment, "sentiment", false, "Score article sentiment in batches, reusing scores cached by earlier runs")
+	cmd.Flags().BoolVar(&digestOpts.Deliver, "deliver", false, "Send the digest to every channel under delivery.channels (file, email, slack, discord, tts, confluence)")
+	cmd.Flags().BoolVar(&digestOpts.Social, "social", false, "Also draft ready-to-paste LinkedIn and X posts from the digest into <digest>.social.md")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Estimate the LLM cost of this run on the configured models, without fetching or calling the LLM")
+	cmd.Flags().Float64Var(&cacheHitRate, "cache-hit-rate", 0, "With --dry-run, share (0-1) of uncached URLs expected to have cached summaries by the time the digest runs")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Use the deterministic mock LLM and bundled sample pages (input file defaults to the sample corpus)")
 
 	return cmd
 }
@@ -105,7 +214,7 @@ func runAgentDigest(ctx context.Context, inputFile string, outputDir string, noC
 	var cache *store.Store
 	if !noCache {
 		cacheDir := ".briefly-cache"
-		cache, err = store.NewStore(cacheDir)
+		cache, err = openCacheStore(cacheDir)
 		if err != nil {
 			fmt.Printf("   ⚠️  Cache initialization failed: %v (continuing without cache)\n", err)
 		}
@@ -162,7 +271,7 @@ func runAgentDigest(ctx context.Context, inputFile string, outputDir string, noC
 	if err != nil {
 		fmt.Printf("   ❌ Agent failed: %v\n", err)
 		fmt.Printf("   Falling back to linear pipeline...\n\n")
-		return runDigestFromFile(ctx, inputFile, outputDir, 0, noCache, 0.4, outputFormat)
+		return runDigestFromFile(ctx, inputFile, outputDir, 0, noCache, 0.4, outputFormat, false, false, false, false, nil, digestOptions{})
 	}
 
 	// Print results
@@ -183,7 +292,7 @@ func runAgentDigest(ctx context.Context, inputFile string, outputDir string, noC
 	return nil
 }
 
-func runDigestFromFile(ctx context.Context, inputFile string, outputDir string, numClusters int, noCache bool, themeThreshold float64, outputFormat string) error {
+func runDigestFromFile(ctx context.Context, inputFile string, outputDir string, numClusters int, noCache bool, themeThreshold float64, outputFormat string, trackLinks bool, trackLinksSet bool, offline bool, batch bool, ser *series.Series, digestOpts digestOptions) error {
 	startTime := time.Now()
 	log := logger.Get()
 	log.Info("Starting digest generation from file",
@@ -192,8 +301,14 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 		"clusters", numClusters,
 		"no_cache", noCache,
 		"format", outputFormat,
+		"offline", offline,
+		"batch", batch,
 	)
 
+	if offline {
+		return runOfflineDigestFromFile(ctx, inputFile, outputDir, numClusters, themeThreshold, outputFormat, startTime, batch, ser, digestOpts)
+	}
+
 	// Load configuration
 	_, err := config.Load(cfgFile)
 	if err != nil {
@@ -218,7 +333,7 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 		modelName = "gemini-3-flash-preview"
 	}
 
-	fmt.Printf("🔧 Initializing AI client (model: %s)...\n", modelName)
+	fmt.Printf("🔧 Initializing AI client (model: %s%s)...\n", modelName, taskModelNote())
 	llmClient, err := llm.NewClient(modelName)
 	if err != nil {
 		return fmt.Errorf("failed to create LLM client: %w", err)
@@ -232,12 +347,20 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 		if cacheDir == "" {
 			cacheDir = ".briefly-cache"
 		}
-		cache, err = store.NewStore(cacheDir)
+		cache, err = openCacheStore(cacheDir)
 		if err != nil {
 			log.Warn("Failed to initialize cache, continuing without cache", "error", err)
 		} else {
 			defer cache.Close()
-			fmt.Println("   ✓ Cache initialized")
+			if !cache.InMemory() {
+				fmt.Println("   ✓ Cache initialized")
+			}
+
+			if pruned, err := cache.PruneArchive(config.GetArchiveRetention()); err != nil {
+				log.Warn("Failed to prune content archive", "error", err)
+			} else if pruned > 0 {
+				fmt.Printf("   ✓ Pruned original content of %d archived articles\n", pruned)
+			}
 		}
 	}
 
@@ -256,10 +379,26 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 
 	fmt.Printf("   ✓ Found %d URLs\n", len(links))
 
+	if digestOpts.ExcludeRead {
+		read, err := loadReadURLs(cache)
+		if err != nil {
+			return err
+		}
+		var skipped int
+		if links, skipped = excludeReadLinks(links, read); skipped > 0 {
+			fmt.Printf("   ✓ Skipped %d already-read URL(s)\n", skipped)
+		}
+		if len(links) == 0 {
+			fmt.Println("⚠️  Every URL in the file is already marked read")
+			return nil
+		}
+	}
+
 	// Step 2: Fetch articles
 	fmt.Printf("\n🔍 Step 2/9: Fetching and processing articles...\n")
 	processor := fetch.NewContentProcessor()
 	articles := make([]core.Article, 0, len(links))
+	downloadsSkipped := 0
 
 	for i, link := range links {
 		fmt.Printf("   [%d/%d] Fetching: %s\n", i+1, len(links), link.URL)
@@ -274,6 +413,8 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 				if article.EstimatedReadMinutes == 0 {
 					article.EstimatedReadMinutes = fetch.CalculateReadingTime(article)
 				}
+				// The link text may have changed since the article was cached
+				fetch.ApplyLinkTitle(article, link.Title)
 				fmt.Println("           ✓ Cache hit")
 			}
 		}
@@ -281,12 +422,21 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 		// Fetch if not cached
 		if article == nil {
 			fetchedArticle, err := processor.ProcessArticle(ctx, link.URL)
+			if skipped, ok := fetch.SkippedArticle(link.URL, err); ok {
+				// Kept so the content gate lists it with the run's other skipped pages
+				log.Info("Skipped download", "url", link.URL, "reason", skipped.SkipReason)
+				fmt.Printf("           ⏭  Skipped: %s\n", skipped.SkipReason)
+				articles = append(articles, *skipped)
+				downloadsSkipped++
+				continue
+			}
 			if err != nil {
 				log.Warn("Failed to fetch article", "url", link.URL, "error", err)
 				fmt.Printf("           ⚠ Fetch failed: %v\n", err)
 				continue
 			}
 			article = fetchedArticle
+			fetch.ApplyLinkTitle(article, link.Title)
 
 			// Save to cache
 			if cache != nil {
@@ -305,21 +455,237 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 		return nil
 	}
 
-	fmt.Printf("   ✓ Successfully fetched %d/%d articles\n", len(articles), len(links))
+	fmt.Printf("   ✓ Successfully fetched %d/%d articles\n", len(articles)-downloadsSkipped, len(links))
+
+	if cfg.Cache.Snapshots.Enabled {
+		if err := checkSnapshotsAllowed(cache); err != nil {
+			fmt.Printf("   ⚠️  Snapshots skipped: %v\n", err)
+		} else {
+			saveArticleSnapshots(ctx, cfg.Cache.Directory, cfg.Cache.Snapshots.Format, articles)
+		}
+	}
+
+	if !trackLinksSet {
+		trackLinks = cfg.LinkTracking.Enabled
+	}
+
+	return generateDigestFromArticles(ctx, llmClient, cache, articles, outputDir, numClusters, themeThreshold, outputFormat, startTime, inputFile, len(links), trackLinks, batch, "", ser, digestOpts)
+}
+
+// checkSnapshotsAllowed refuses snapshots next to an encrypted cache: snapshot files
+// are plain HTML opened straight in the browser, so they would leave the pages the
+// cache encrypts readable on disk
+func checkSnapshotsAllowed(cache *store.Store) error {
+	encrypted := cache != nil && (cache.Encrypted() || cache.Locked())
+	if cache == nil {
+		key, err := store.LoadCacheKey()
+		encrypted = err != nil || key != ""
+	}
+	if encrypted {
+		return fmt.Errorf("snapshots are not encrypted, so they are off while the cache is (disable cache.snapshots.enabled or run 'briefly cache decrypt')")
+	}
+	return nil
+}
+
+// saveArticleSnapshots archives the original page of each article under the cache's
+// snapshot directory. Failures are logged; a digest never fails over a snapshot.
+func saveArticleSnapshots(ctx context.Context, cacheDir, format string, articles []core.Article) {
+	log := logger.Get()
+	if cacheDir == "" {
+		cacheDir = ".briefly-cache"
+	}
+
+	archiver, err := snapshot.NewArchiver(cacheDir, format)
+	if err != nil {
+		log.Warn("Snapshots disabled", "error", err)
+		return
+	}
+
+	saved := 0
+	for _, article := range articles {
+		if article.SkipReason != "" {
+			continue
+		}
+		_, created, err := archiver.Save(ctx, article)
+		if err != nil {
+			log.Warn("Failed to save article snapshot", "url", article.URL, "error", err)
+			continue
+		}
+		if created {
+			saved++
+		}
+	}
+	if saved > 0 {
+		fmt.Printf("   ✓ Saved %d %s snapshot(s) to %s\n", saved, format, snapshot.Dir(cacheDir))
+	}
+}
+
+// applyOutputDefaults takes the output directory and format from output.directory
+// and output.format (as written by 'briefly init') unless given on the command line
+func applyOutputDefaults(cmd *cobra.Command, outputDir, outputFormat *string) {
+	if _, err := config.Load(cfgFile); err != nil {
+		return
+	}
+	output := config.GetOutput()
+	if !cmd.Flags().Changed("output") && output.Directory != "" {
+		*outputDir = output.Directory
+	}
+	// Older configs carry the unused default "standard"; only digest formats apply
+	if !cmd.Flags().Changed("format") && (output.Format == "markdown" || output.Format == "slack") {
+		*outputFormat = output.Format
+	}
+}
+
+// runOfflineDigestFromFile runs the file digest without config, network, or API keys.
+// Articles come from the bundled sample corpus and all LLM calls go to the
+// deterministic offline client, so output is reproducible for demos and tests.
+func runOfflineDigestFromFile(ctx context.Context, inputFile string, outputDir string, numClusters int, themeThreshold float64, outputFormat string, startTime time.Time, batch bool, ser *series.Series, digestOpts digestOptions) error {
+	if inputFile == "" {
+		corpusFile, err := corpus.WriteInputFile()
+		if err != nil {
+			return err
+		}
+		defer os.Remove(corpusFile)
+		inputFile = corpusFile
+	}
+
+	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+		return fmt.Errorf("input file not found: %s", inputFile)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	fmt.Printf("🔌 Offline mode (model: %s, %d sample pages)\n", llm.OfflineModel, len(corpus.URLs()))
+	llmClient := llm.NewOfflineClient()
+
+	fmt.Printf("\n📄 Step 1/9: Parsing URLs from %s...\n", inputFile)
+	links, err := parser.NewParser().ParseMarkdownFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse markdown file: %w", err)
+	}
+
+	if len(links) == 0 {
+		fmt.Println("⚠️  No URLs found in markdown file")
+		return nil
+	}
+
+	fmt.Printf("   ✓ Found %d URLs\n", len(links))
+
+	fmt.Printf("\n🔍 Step 2/9: Loading articles from the sample corpus...\n")
+	processor := fetch.NewOfflineContentProcessor(corpus.Pages())
+	articles := make([]core.Article, 0, len(links))
+
+	for i, link := range links {
+		fmt.Printf("   [%d/%d] Loading: %s\n", i+1, len(links), link.URL)
+
+		article, err := processor.ProcessArticle(ctx, link.URL)
+		if err != nil {
+			fmt.Printf("           ⚠ Skipped: %v\n", err)
+			continue
+		}
+		fetch.ApplyLinkTitle(article, link.Title)
+		articles = append(articles, *article)
+	}
+
+	if len(articles) == 0 {
+		fmt.Printf("\n⚠️  None of the URLs are in the offline corpus (pages live under %s)\n", corpus.BaseURL)
+		return nil
+	}
+
+	fmt.Printf("   ✓ Loaded %d/%d articles\n", len(articles), len(links))
+
+	return generateDigestFromArticles(ctx, llmClient, nil, articles, outputDir, numClusters, themeThreshold, outputFormat, startTime, inputFile, len(links), false, batch, "", ser, digestOpts)
+}
+
+// generateDigestFromArticles runs steps 3-9 of the digest pipeline (summarize, classify,
+// embed, cluster, narrate, render) on articles that have already been fetched.
+// source describes where the articles came from and is only used for reporting.
+// When trackLinks is set, article links in the saved file are rewritten for click tracking.
+// When batch is set, summaries go through the Gemini Batch API (cheaper, slower).
+// With ser, the issue is titled, recorded, and delivered as part of that series.
+func generateDigestFromArticles(ctx context.Context, llmClient *llm.Client, cache *store.Store, articles []core.Article, outputDir string, numClusters int, themeThreshold float64, outputFormat string, startTime time.Time, source string, totalLinks int, trackLinks bool, batch bool, issueName string, ser *series.Series, digestOpts digestOptions) error {
+	log := logger.Get()
+
+	var run *seriesRun
+	if ser != nil {
+		var err error
+		run, err = startSeriesRun(ser)
+		if err != nil {
+			return err
+		}
+		defer run.Close()
+	}
+
+	// Report the run's LLM spend to event webhooks when it passes events.cost_budget
+	llmClient.SetUsageRecorder(costBudgetRecorder(nil))
+
+	articles, noisy := filterNoisyArticles(articles)
+	if len(articles) == 0 {
+		printNoiseSkips(noisy)
+		return fmt.Errorf("every article failed the content gate (see filtering.noise in config)")
+	}
+
+	if digestOpts.Curate {
+		articles = curateArticles(ctx, llmClient, articles)
+	}
+
+	if digestOpts.Figures && !llmClient.IsOffline() {
+		describeArticleFigures(ctx, llmClient, cache, articles)
+	}
 
 	// Step 3: Generate summaries
 	fmt.Printf("\n📝 Step 3/9: Generating article summaries...\n")
 	adapter := &llmClientAdapter{client: llmClient}
-	summarizer := summarize.NewSummarizerWithDefaults(adapter)
+	summarizer := newAudienceSummarizer(adapter, digestOpts.Audience)
+
+	// Sentiment doesn't depend on the audience, so it keeps the cache either way
+	sentimentCache := cache
+
+	// Cached summaries use the default framing, so an audience run neither reads nor writes them
+	if digestOpts.Audience != "" {
+		cache = nil
+	}
+
+	// Batch mode: submit every summary as one Batch API job up front
+	var batchSummaries []*core.Summary
+	var batchErrs []error
+	if batch {
+		batchSummarizer := newAudienceSummarizer(&batchLLMClientAdapter{llmClientAdapter: *adapter}, digestOpts.Audience)
+		articlePtrs := make([]*core.Article, len(articles))
+		for i := range articles {
+			articlePtrs[i] = &articles[i]
+		}
+		batchSummaries, batchErrs = batchSummarizer.SummarizeArticlesBatch(ctx, articlePtrs)
+	}
 
 	articleSummaries := make(map[string]*core.Summary)
 	summaryList := make([]core.Summary, 0, len(articles))
 
 	for i, article := range articles {
-		fmt.Printf("   [%d/%d] Summarizing: %s\n", i+1, len(articles), article.Title)
+		var summary *core.Summary
+		var err error
+		if batch {
+			fmt.Printf("   [%d/%d] Batch result: %s\n", i+1, len(articles), article.Title)
+			summary, err = batchSummaries[i], batchErrs[i]
+		} else {
+			fmt.Printf("   [%d/%d] Summarizing: %s\n", i+1, len(articles), article.Title)
 
-		// Generate summary (cache lookup is complex, skip for now)
-		summary, err := summarizer.SummarizeArticle(ctx, &article)
+			// Reuse summaries cached by earlier runs (e.g. 'briefly read') while the text is unchanged
+			if cached := cachedSummary(cache, article); cached != nil {
+				fmt.Println("           ✓ Cache hit")
+				articleSummaries[article.ID] = cached
+				summaryList = append(summaryList, *cached)
+				continue
+			}
+			summary, err = summarizer.SummarizeArticle(ctx, &article)
+		}
+		if err == nil && cache != nil {
+			if cacheErr := cache.CacheSummary(*summary, article.URL, store.SummaryContentHash(article)); cacheErr != nil {
+				log.Warn("Failed to cache summary", "url", article.URL, "error", cacheErr)
+			}
+		}
 		if err != nil {
 			log.Warn("Failed to generate summary", "article_id", article.ID, "error", err)
 			// Create fallback summary
@@ -330,12 +696,21 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 				ModelUsed:   "fallback",
 			}
 		}
-		fmt.Println("           ✓ Generated")
+		if summary.QualityFlagged {
+			fmt.Printf("           ⚠️  Generated with low quality score (%.2f)\n", summary.QualityScore)
+		} else {
+			fmt.Println("           ✓ Generated")
+		}
 
 		articleSummaries[article.ID] = summary
 		summaryList = append(summaryList, *summary)
 	}
 
+	var overallSentiment string
+	if digestOpts.Sentiment {
+		overallSentiment = analyzeArticleSentiment(ctx, llmClient, sentimentCache, articles)
+	}
+
 	// Step 4: Classify articles by theme
 	fmt.Printf("\n🏷️  Step 4/9: Classifying articles by theme...\n")
 
@@ -385,14 +760,17 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 			textForEmbedding = summary.SummaryText
 		}
 
-		// Truncate if too long
-		if len(textForEmbedding) > 2000 {
-			textForEmbedding = textForEmbedding[:2000]
-		}
-
 		fmt.Printf("   [%d/%d] Embedding: %s\n", i+1, len(articles), article.Title)
 
-		embedding, err := llmClient.GenerateEmbedding(textForEmbedding)
+		// Cached summaries carry the embedding 'briefly warm' made for them
+		if hasSummary && len(summary.Embedding) > 0 {
+			embeddingsMap[article.ID] = summary.Embedding
+			articles[i].Embedding = summary.Embedding
+			fmt.Println("           ✓ Cache hit")
+			continue
+		}
+
+		embedding, err := llmClient.GenerateEmbedding(embeddingText(textForEmbedding))
 		if err != nil {
 			log.Warn("Failed to generate embedding", "article_id", article.ID, "error", err)
 			fmt.Println("           ⚠ Failed")
@@ -409,13 +787,7 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 
 	// Auto-determine clusters if not specified
 	if numClusters == 0 {
-		numClusters = (len(articles) + 4) / 5 // ~5 articles per cluster
-		if numClusters < 3 {
-			numClusters = 3
-		}
-		if numClusters > 15 {
-			numClusters = 15
-		}
+		numClusters = clustering.AutoClusterCount(len(articles))
 	}
 
 	fmt.Printf("   🔍 Clustering %d articles into ~%d topics (K-means++ with cosine distance)...\n", len(articles), numClusters)
@@ -430,20 +802,52 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 		return fmt.Errorf("no clusters found")
 	}
 
+	// Create article and summary maps
+	summaryMap := make(map[string]core.Summary)
+	for _, summary := range summaryList {
+		for _, articleID := range summary.ArticleIDs {
+			summaryMap[articleID] = summary
+		}
+	}
+
+	// Re-extract keywords with summaries so labels reflect what each cluster covers
+	clustering.AssignKeywords(clusters, articles, summaryMap)
+
 	fmt.Printf("   ✓ Found %d topic clusters\n", len(clusters))
 	for i, cluster := range clusters {
 		fmt.Printf("      %d. %s (%d articles)\n", i+1, cluster.Label, len(cluster.ArticleIDs))
 	}
 
-	// Create article and summary maps
+	// Takes imported with 'briefly my-take import' appear under their articles
+	if cache != nil {
+		if applied := applyArticleTakes(cache, articles); applied > 0 {
+			fmt.Printf("   💭 %d article(s) with your take\n", applied)
+		}
+	}
+
+	// Score signal-to-noise before building the article map so scores travel with it
+	quality.ApplySignalScores(articles, summaryMap)
+	if boosted := authors.Boost(articles, config.GetAuthors().Follow, config.GetAuthors().Boost); boosted > 0 {
+		fmt.Printf("   ⭐ %d article(s) by authors you follow\n", boosted)
+	}
+
 	articleMap := make(map[string]core.Article)
-	summaryMap := make(map[string]core.Summary)
 	for i, article := range articles {
 		articleMap[article.ID] = articles[i]
 	}
-	for _, summary := range summaryList {
-		for _, articleID := range summary.ArticleIDs {
-			summaryMap[articleID] = summary
+
+	// Highest-signal articles lead each cluster (and get the lowest citation numbers)
+	for i := range clusters {
+		quality.SortArticleIDsBySignal(clusters[i].ArticleIDs, articleMap)
+	}
+
+	// Cap each topic's cited articles so a busy topic can't crowd out the rest; its
+	// lower-signal articles are listed as links after the section. Slack digests
+	// pick their own Big 3 and radar items, so they keep every article.
+	maxPerTopic := config.GetOutput().MaxPerTopic
+	if outputFormat != "slack" {
+		if moved := clustering.BalanceCoverage(clusters, maxPerTopic); moved > 0 {
+			fmt.Printf("   ⚖️  Capped topics at %d articles (output.max_per_topic); %d more listed as links only\n", maxPerTopic, moved)
 		}
 	}
 
@@ -451,6 +855,8 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 	fmt.Printf("\n📖 Step 7/9: Generating cluster narratives from ALL articles...\n")
 	narrativeAdapter := &narrativeLLMAdapter{client: llmClient}
 	narrativeGen := narrative.NewGenerator(narrativeAdapter)
+	narrativeGen.SetAudience(digestOpts.Audience)
+	narrativeGen.SetContextBudget(config.GetAI().Gemini.ContextBudget)
 
 	for i, cluster := range clusters {
 		if len(cluster.ArticleIDs) == 0 {
@@ -459,7 +865,7 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 
 		fmt.Printf("   [%d/%d] Cluster: %s (%d articles)\n", i+1, len(clusters), cluster.Label, len(cluster.ArticleIDs))
 
-		clusterNarrative, err := narrativeGen.GenerateClusterSummary(ctx, cluster, articleMap, summaryMap)
+		clusterNarrative, err := narrativeGen.GenerateClusterSection(ctx, cluster, articleMap, summaryMap)
 		if err != nil {
 			log.Warn("Failed to generate cluster narrative", "cluster", cluster.Label, "error", err)
 			fmt.Println("           ⚠ Narrative generation failed")
@@ -475,37 +881,296 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 		for _, stat := range clusterNarrative.KeyStats {
 			wordCount += len(strings.Fields(stat.Stat)) + len(strings.Fields(stat.Context))
 		}
+		if clusterNarrative.Degraded {
+			fmt.Printf("   ⚠ Degraded section: %s (built from article summaries)\n", clusterNarrative.Title)
+			continue
+		}
 		fmt.Printf("   ✓ Generated: %s (%d words)\n", clusterNarrative.Title, wordCount)
 	}
 
+	// Append this week's sections to the living page of each recurring topic
+	recordTopicPages(cache, clusters, articleMap, outputDir)
+
 	// Handle Slack format - generate and render separately
 	if outputFormat == "slack" {
-		return generateSlackDigest(ctx, narrativeGen, clusters, articleMap, summaryMap, articles, outputDir, startTime, inputFile, len(links))
+		return generateSlackDigest(ctx, narrativeGen, clusters, articleMap, summaryMap, articles, outputDir, startTime, source, totalLinks, trackLinks, issueName, run, noisy, digestOpts.Deliver)
+	}
+
+	// Oversized runs become a multi-part digest, highest-priority topics first, so no
+	// single file or final synthesis has to take every article
+	splitAt := config.GetOutput().SplitAt
+	parts := clustering.SplitIntoParts(clusters, articleMap, splitAt)
+	if len(parts) > 1 {
+		fmt.Printf("\n✂️  %d articles (output.split_at %d): splitting into %d parts by topic priority\n", len(articles), splitAt, len(parts))
+	}
+
+	build := &digestBuild{
+		llmClient:        llmClient,
+		narrativeGen:     narrativeGen,
+		cache:            cache,
+		articles:         articles,
+		articleMap:       articleMap,
+		summaryMap:       summaryMap,
+		summaryList:      summaryList,
+		overallSentiment: overallSentiment,
+		issueName:        issueName,
+		run:              run,
+		digestOpts:       digestOpts,
+		outputDir:        outputDir,
+		outputFormat:     outputFormat,
+		trackLinks:       trackLinks,
+	}
+	// Every part shares one date, so their file names (and cross-links) match
+	now := publicationNow()
+	digests := make([]*core.Digest, 0, len(parts))
+	outputPaths := make([]string, 0, len(parts))
+	offset := 0
+	for i, partClusters := range parts {
+		digest, outputPath, err := build.savePart(ctx, partClusters, i+1, len(parts), offset, now)
+		if err != nil {
+			return err
+		}
+		offset += digest.ArticleCount
+		digests = append(digests, digest)
+		outputPaths = append(outputPaths, outputPath)
+	}
+
+	duration := time.Since(startTime)
+
+	// Print summary
+	if len(digests) > 1 {
+		fmt.Printf("\n✅ Successfully generated %d-part digest!\n", len(digests))
+	} else {
+		fmt.Printf("\n✅ Successfully generated unified digest!\n")
+	}
+	fmt.Printf("   Title: %s\n", digests[0].Title)
+	fmt.Printf("   Source: %s\n", source)
+	fmt.Printf("   Total URLs: %d\n", totalLinks)
+	fmt.Printf("   Articles fetched: %d\n", len(articles))
+	fmt.Printf("   Topic clusters: %d\n", len(clusters))
+	for _, outputPath := range outputPaths {
+		fmt.Printf("   Output file: %s\n", outputPath)
+	}
+	fmt.Printf("   Duration: %s\n", duration.Round(time.Millisecond))
+
+	// Show cluster breakdown
+	fmt.Println("\n📊 Cluster Breakdown:")
+	groupNum := 1
+	for _, digest := range digests {
+		if len(digests) > 1 {
+			fmt.Printf("   Part %d of %d:\n", digest.Metadata.Part, digest.Metadata.Parts)
+		}
+		for _, group := range digest.ArticleGroups {
+			fmt.Printf("   %d. %s (%d articles)\n", groupNum, group.Theme, len(group.Articles))
+			groupNum++
+		}
 	}
 
-	// Step 8: Generate unified executive summary from ALL cluster narratives
-	fmt.Printf("\n✨ Step 8/9: Generating unified executive summary from all clusters...\n")
+	printFlaggedSummaries(summaryList, articles)
+	printNoiseSkips(noisy)
+
+	fmt.Println("\n💡 Next steps:")
+	fmt.Println("   • Review the digest:", strings.Join(outputPaths, ", "))
+	fmt.Println("   • Edit and refine as needed")
+	fmt.Println("   • Share on LinkedIn or your preferred platform")
+
+	return nil
+}
+
+// recordTopicPages appends each cluster to its topic page (see internal/topics).
+// Failures are reported but don't stop the digest.
+func recordTopicPages(cache *store.Store, clusters []core.TopicCluster, articleMap map[string]core.Article, outputDir string) {
+	settings := config.GetOutput().TopicPages
+	if cache == nil || !settings.Enabled {
+		return
+	}
+
+	dir := settings.Directory
+	if dir == "" {
+		dir = filepath.Join(outputDir, "topics")
+	}
+	threshold := settings.MatchThreshold
+	if threshold == 0 {
+		threshold = topics.DefaultMatchThreshold
+	}
+
+	updates, err := topics.Record(cache, dir, clusters, articleMap, time.Now(), threshold)
+	if err != nil {
+		fmt.Printf("   ⚠️  Could not update topic pages: %v\n", err)
+	}
+	for _, update := range updates {
+		if update.Created {
+			fmt.Printf("   🗂️  New topic page: %s\n", update.Path)
+			continue
+		}
+		fmt.Printf("   🗂️  Topic page %s: %d runs\n", update.Path, update.Sections)
+	}
+}
+
+// generateSlackDigest handles Slack format digest generation
+func generateSlackDigest(ctx context.Context, narrativeGen *narrative.Generator, clusters []core.TopicCluster, articleMap map[string]core.Article, summaryMap map[string]core.Summary, articles []core.Article, outputDir string, startTime time.Time, source string, totalLinks int, trackLinks bool, issueName string, run *seriesRun, noisy []noiseSkip, deliver bool) error {
+	log := logger.Get()
+
+	fmt.Printf("\n📱 Step 8/9: Generating Slack-formatted digest...\n")
+
+	slackContent, err := narrativeGen.GenerateSlackDigest(ctx, clusters, articleMap, summaryMap)
+	if err != nil {
+		log.Error("Failed to generate Slack digest", "error", err)
+		return fmt.Errorf("failed to generate Slack digest: %w", err)
+	}
+
+	fmt.Printf("   ✓ Generated Slack digest: %s\n", slackContent.WeekRange)
+	fmt.Printf("      Big 3: %d items\n", len(slackContent.Big3))
+	fmt.Printf("      Also on radar: %d items\n", len(slackContent.AlsoOnRadar))
+	fmt.Printf("      Thread content: %d items\n", len(slackContent.ThreadContent))
+
+	// Step 9: Render Slack format
+	fmt.Printf("\n📄 Step 9/9: Rendering Slack markdown...\n")
+
+	header := fmt.Sprintf("*AI Weekly* — %s", slackContent.WeekRange)
+	if run != nil {
+		header = fmt.Sprintf("*%s*", run.Title(slackContent.WeekRange, issueName, publicationNow()))
+	}
+	output := renderSlackFormat(slackContent, articles, clusters, header)
+	if run != nil {
+		output += renderSlackReaderNotes(run.ReaderNotes())
+	}
+
+	// Save to file
+	timestamp := publicationNow().Format("2006-01-02")
+	if issueName != "" {
+		timestamp = issueName
+	}
+	filename := fmt.Sprintf("digest_slack_%s.md", timestamp)
+	outputPath := fmt.Sprintf("%s/%s", outputDir, filename)
+
+	if err := os.WriteFile(outputPath, []byte(render.Output(output)), 0644); err != nil {
+		return fmt.Errorf("failed to write Slack digest: %w", err)
+	}
+
+	fmt.Printf("   ✓ Saved: %s\n", outputPath)
+
+	if trackLinks {
+		trackDigestLinks(ctx, outputPath)
+	}
+
+	if run != nil {
+		run.Finish(ctx, strings.Trim(header, "*"), outputPath, "slack", clusters, articles)
+	}
+
+	emitEvent(ctx, events.DigestGenerated, events.Digest{
+		Title:        strings.Trim(header, "*"),
+		Path:         outputPath,
+		Format:       "slack",
+		ArticleCount: len(articles),
+		TopicCount:   len(clusters),
+	})
+
+	if deliver {
+		deliverDigest(ctx, strings.Trim(header, "*"), outputPath, "slack")
+	}
+
+	duration := time.Since(startTime)
+
+	// Print summary
+	fmt.Printf("\n✅ Successfully generated Slack digest!\n")
+	fmt.Printf("   Week: %s\n", slackContent.WeekRange)
+	fmt.Printf("   Source: %s\n", source)
+	fmt.Printf("   Total URLs: %d\n", totalLinks)
+	fmt.Printf("   Articles fetched: %d\n", len(articles))
+	fmt.Printf("   Output file: %s\n", outputPath)
+	fmt.Printf("   Duration: %s\n", duration.Round(time.Millisecond))
+
+	summaryList := make([]core.Summary, 0, len(summaryMap))
+	for _, article := range articles {
+		if summary, ok := summaryMap[article.ID]; ok {
+			summaryList = append(summaryList, summary)
+		}
+	}
+	printFlaggedSummaries(summaryList, articles)
+	printNoiseSkips(noisy)
+
+	fmt.Println("\n💡 Next steps:")
+	fmt.Println("   • Copy the main content to Slack")
+	fmt.Println("   • Post thread content as replies")
+	fmt.Println("   • File:", outputPath)
+
+	return nil
+}
+
+// embeddingText is the part of text that is embedded for clustering: its first
+// 2000 bytes
+func embeddingText(text string) string {
+	if len(text) > 2000 {
+		return text[:2000]
+	}
+	return text
+}
+
+// cachedSummary returns the cached summary for article, or nil on a miss or without a cache
+func cachedSummary(cache *store.Store, article core.Article) *core.Summary {
+	if cache == nil || article.CleanedText == "" {
+		return nil
+	}
+	summary, err := cache.GetCachedSummary(article.URL, store.SummaryContentHash(article), 24*time.Hour)
+	if err != nil || summary == nil || summary.SummaryText == "" {
+		return nil
+	}
+	summary.ArticleIDs = []string{article.ID}
+	return summary
+}
+
+// digestBuild carries a from-articles run's shared state into savePart, which
+// synthesizes, renders, and delivers each part of the digest
+type digestBuild struct {
+	llmClient        *llm.Client
+	narrativeGen     *narrative.Generator
+	cache            *store.Store
+	articles         []core.Article
+	articleMap       map[string]core.Article
+	summaryMap       map[string]core.Summary
+	summaryList      []core.Summary
+	overallSentiment string
+	issueName        string
+	run              *seriesRun
+	digestOpts       digestOptions
+	outputDir        string
+	outputFormat     string
+	trackLinks       bool
+}
+
+// savePart synthesizes one part of the digest from its clusters and saves it. An
+// unsplit run is part 1 of 1. offset is the number of articles in earlier parts,
+// which this part's citations continue from.
+func (b *digestBuild) savePart(ctx context.Context, clusters []core.TopicCluster, part, parts, offset int, now time.Time) (*core.Digest, string, error) {
+	log := logger.Get()
+	split := parts > 1
+
+	// Step 8: Generate unified executive summary from the part's cluster narratives
+	if split {
+		fmt.Printf("\n✨ Step 8/9: Generating executive summary for part %d of %d...\n", part, parts)
+	} else {
+		fmt.Printf("\n✨ Step 8/9: Generating unified executive summary from all clusters...\n")
+	}
 
 	// Generate ONE digest content from ALL clusters (hierarchical summarization)
 	critiqueConfig := narrative.DefaultCritiqueConfig()
-	digestContent, err := narrativeGen.GenerateDigestContentWithCritique(ctx, clusters, articleMap, summaryMap, critiqueConfig)
+	digestContent, err := b.narrativeGen.GenerateDigestContentWithCritique(ctx, clusters, b.articleMap, b.summaryMap, critiqueConfig)
 	if err != nil {
 		log.Warn("Failed to generate unified digest content", "error", err)
-		// Use fallback
-		digestContent = &narrative.DigestContent{
-			Title:            fmt.Sprintf("Weekly Tech Digest - %d Articles", len(articles)),
-			TLDRSummary:      fmt.Sprintf("Digest covering %d articles across %d topics", len(articles), len(clusters)),
-			KeyMoments:       []core.KeyMoment{},
-			Perspectives:     []core.Perspective{},
-			ExecutiveSummary: "This digest covers recent developments in technology.",
-		}
+		// Only the top-level synthesis failed; assemble it from the cluster sections
+		fmt.Println("   ⚠ Final synthesis failed, assembling digest from cluster sections")
+		digestContent = narrative.AssembleDigestContent(clusters, b.articleMap, b.summaryMap)
 	}
 
 	fmt.Printf("   ✓ Generated unified digest: %s\n", digestContent.Title)
 
+	// Link sections to stored deep-research briefs on the same topic
+	relatedBriefs := linkResearchBriefs(b.cache, clusters)
+
 	// Build article groups organized by cluster
 	articleGroups := make([]core.ArticleGroup, 0, len(clusters))
-	for _, cluster := range clusters {
+	for i, cluster := range clusters {
 		if len(cluster.ArticleIDs) == 0 {
 			continue
 		}
@@ -513,7 +1178,7 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 		// Build article list for this cluster
 		clusterArticles := make([]core.Article, 0, len(cluster.ArticleIDs))
 		for _, articleID := range cluster.ArticleIDs {
-			if article, found := articleMap[articleID]; found {
+			if article, found := b.articleMap[articleID]; found {
 				clusterArticles = append(clusterArticles, article)
 			}
 		}
@@ -530,20 +1195,41 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 			clusterSummary = cluster.Narrative.Summary
 		}
 
+		var moreLinks []core.Article
+		for _, articleID := range cluster.OverflowIDs {
+			if article, found := b.articleMap[articleID]; found {
+				moreLinks = append(moreLinks, article)
+			}
+		}
+
 		articleGroups = append(articleGroups, core.ArticleGroup{
 			Theme:            themeName,
 			Articles:         clusterArticles,
 			Summary:          clusterSummary,
 			ClusterNarrative: cluster.Narrative, // NEW v3.1: Include cluster narrative for bullet rendering
 			Category:         themeName,
+			RelatedResearch:  relatedBriefs[i],
+			MoreLinks:        moreLinks,
 		})
 	}
 
+	// Key moments quoting a video link to where they are said; citations number the
+	// articles in section order
+	var citedArticles []core.Article
+	for _, group := range articleGroups {
+		citedArticles = append(citedArticles, group.Articles...)
+	}
+	transcript.AttachTimestamps(digestContent.KeyMoments, citedArticles)
+
+	// A part holds only its own articles, numbered in section order like the file
+	articles := b.articles
+	if split {
+		articles = citedArticles
+	}
+
 	// Inject citations into executive summary
 	summaryWithCitations := markdown.InjectCitationURLs(digestContent.ExecutiveSummary, articles)
 
-	now := time.Now()
-
 	// Create ONE unified digest with all articles
 	digest := &core.Digest{
 		ID:            uuid.NewString(),
@@ -562,102 +1248,147 @@ func runDigestFromFile(ctx context.Context, inputFile string, outputDir string,
 		WhyItMatters:    digestContent.WhyItMatters,
 		MustRead:        convertMustRead(digestContent.MustRead),
 
-		ArticleGroups: articleGroups,
-		DigestSummary: digestContent.ExecutiveSummary,
+		ArticleGroups:    articleGroups,
+		DigestSummary:    digestContent.ExecutiveSummary,
+		OverallSentiment: b.overallSentiment,
 		Metadata: core.DigestMetadata{
 			Title:         digestContent.Title,
 			ArticleCount:  len(articles),
 			DateGenerated: now,
 			TLDRSummary:   digestContent.TLDRSummary,
+			Issue:         b.issueName,
 		},
 	}
+	if split {
+		digest.Metadata.Part = part
+		digest.Metadata.Parts = parts
+		digest.Metadata.ArticleOffset = offset
+	}
+
+	if b.run != nil {
+		digest.Title = b.run.Title(digest.Title, b.issueName, now)
+		// Reader notes are the issue's, so they open part 1 only
+		if part == 1 {
+			digest.ReaderNotes = b.run.ReaderNotes()
+		}
+	}
+	if split {
+		digest.Title = fmt.Sprintf("%s (Part %d/%d)", digest.Title, part, parts)
+	}
+
+	// Banner themes and alt text, for templates and a later image generator
+	digest.Banner = visual.PlanBanner(digest, config.GetVisual().Banners.DefaultStyle)
+	if digest.Banner != nil {
+		fmt.Printf("   ✓ Banner themes: %s\n", strings.Join(digest.Banner.Themes, ", "))
+	}
+
+	if b.digestOpts.MustReadURL != "" {
+		if pinMustRead(digest, b.digestOpts.MustReadURL, b.summaryList) {
+			fmt.Printf("   📌 Pinned Must-Read: %s\n", digest.MustRead.Title)
+		} else if !split {
+			fmt.Printf("   ⚠️  --must-read %s is not in this digest; keeping the LLM's pick\n", b.digestOpts.MustReadURL)
+		}
+	}
+
+	// The top story leads part 1, so that's where its other side goes
+	if b.digestOpts.Perspectives && part == 1 {
+		fmt.Println("   ⚖️  Looking for the other side of the top story...")
+		if !b.llmClient.IsOffline() {
+			b.narrativeGen.SetSearcher(b.llmClient)
+		}
+		addPerspectives(ctx, b.narrativeGen, digest, clusters, b.articleMap, b.summaryMap)
+	}
+
+	offsetCitations(digest, offset)
 
 	// Step 9: Render unified markdown file
 	fmt.Printf("\n📄 Step 9/9: Rendering unified markdown digest...\n")
 
-	outputPath, err := saveDigestMarkdown(digest, outputDir)
+	outputPath, err := saveDigestMarkdown(digest, b.outputDir)
 	if err != nil {
-		return fmt.Errorf("failed to save digest markdown: %w", err)
+		return nil, "", fmt.Errorf("failed to save digest markdown: %w", err)
 	}
 
 	fmt.Printf("   ✓ Saved: %s\n", outputPath)
 
-	duration := time.Since(startTime)
+	if b.trackLinks {
+		trackDigestLinks(ctx, outputPath)
+	}
 
-	// Print summary
-	fmt.Printf("\n✅ Successfully generated unified digest!\n")
-	fmt.Printf("   Title: %s\n", digest.Title)
-	fmt.Printf("   Input file: %s\n", inputFile)
-	fmt.Printf("   Total URLs: %d\n", len(links))
-	fmt.Printf("   Articles fetched: %d\n", len(articles))
-	fmt.Printf("   Topic clusters: %d\n", len(clusters))
-	fmt.Printf("   Output file: %s\n", outputPath)
-	fmt.Printf("   Duration: %s\n", duration.Round(time.Millisecond))
+	stampDigestProvenance(digest, outputPath, b.llmClient.GetModelName())
 
-	// Show cluster breakdown
-	fmt.Println("\n📊 Cluster Breakdown:")
-	for i, group := range articleGroups {
-		fmt.Printf("   %d. %s (%d articles)\n", i+1, group.Theme, len(group.Articles))
+	if b.digestOpts.Social {
+		writeSocialPosts(ctx, b.llmClient, digest, outputPath)
 	}
 
-	fmt.Println("\n💡 Next steps:")
-	fmt.Println("   • Review the digest:", outputPath)
-	fmt.Println("   • Edit and refine as needed")
-	fmt.Println("   • Share on LinkedIn or your preferred platform")
+	if b.run != nil {
+		// The saved file numbers articles group by group
+		b.run.Finish(ctx, digest.Title, outputPath, b.outputFormat, clusters, citedArticles)
+	}
 
-	return nil
+	emitEvent(ctx, events.DigestGenerated, events.Digest{
+		ID:           digest.ID,
+		Title:        digest.Title,
+		Path:         outputPath,
+		Format:       b.outputFormat,
+		ArticleCount: len(articles),
+		TopicCount:   len(articleGroups),
+	})
+
+	if b.digestOpts.Deliver {
+		deliverDigest(ctx, digest.Title, outputPath, b.outputFormat)
+	}
+
+	return digest, outputPath, nil
 }
 
-// generateSlackDigest handles Slack format digest generation
-func generateSlackDigest(ctx context.Context, narrativeGen *narrative.Generator, clusters []core.TopicCluster, articleMap map[string]core.Article, summaryMap map[string]core.Summary, articles []core.Article, outputDir string, startTime time.Time, inputFile string, totalLinks int) error {
-	log := logger.Get()
+// trackDigestLinks rewrites article links in a saved digest through the configured
+// shortener. The file name (without extension) is the digest key reported by
+// 'briefly stats clicks'. Failures only warn, since the digest itself is already saved.
+func trackDigestLinks(ctx context.Context, outputPath string) {
+	cfg := config.GetLinkTracking()
 
-	fmt.Printf("\n📱 Step 8/9: Generating Slack-formatted digest...\n")
+	var db persistence.Database
+	if cfg.Mode == "" || cfg.Mode == "redirector" {
+		database, err := getDatabase()
+		if err != nil {
+			fmt.Printf("   ⚠️  Link tracking skipped: %v\n", err)
+			return
+		}
+		defer database.Close()
+		db = database
+	}
 
-	slackContent, err := narrativeGen.GenerateSlackDigest(ctx, clusters, articleMap, summaryMap)
+	shortener, err := links.NewFromConfig(cfg, db)
 	if err != nil {
-		log.Error("Failed to generate Slack digest", "error", err)
-		return fmt.Errorf("failed to generate Slack digest: %w", err)
+		fmt.Printf("   ⚠️  Link tracking skipped: %v\n", err)
+		return
 	}
 
-	fmt.Printf("   ✓ Generated Slack digest: %s\n", slackContent.WeekRange)
-	fmt.Printf("      Big 3: %d items\n", len(slackContent.Big3))
-	fmt.Printf("      Also on radar: %d items\n", len(slackContent.AlsoOnRadar))
-	fmt.Printf("      Thread content: %d items\n", len(slackContent.ThreadContent))
-
-	// Step 9: Render Slack format
-	fmt.Printf("\n📄 Step 9/9: Rendering Slack markdown...\n")
-
-	output := renderSlackFormat(slackContent, articles, clusters)
-
-	// Save to file
-	timestamp := time.Now().Format("2006-01-02")
-	filename := fmt.Sprintf("digest_slack_%s.md", timestamp)
-	outputPath := fmt.Sprintf("%s/%s", outputDir, filename)
-
-	if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
-		return fmt.Errorf("failed to write Slack digest: %w", err)
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		fmt.Printf("   ⚠️  Link tracking skipped: failed to read digest: %v\n", err)
+		return
 	}
 
-	fmt.Printf("   ✓ Saved: %s\n", outputPath)
-
-	duration := time.Since(startTime)
+	digestKey := strings.TrimSuffix(filepath.Base(outputPath), filepath.Ext(outputPath))
+	skipPrefix := ""
+	if cfg.BaseURL != "" {
+		skipPrefix = strings.TrimRight(cfg.BaseURL, "/") + "/r/"
+	}
 
-	// Print summary
-	fmt.Printf("\n✅ Successfully generated Slack digest!\n")
-	fmt.Printf("   Week: %s\n", slackContent.WeekRange)
-	fmt.Printf("   Input file: %s\n", inputFile)
-	fmt.Printf("   Total URLs: %d\n", totalLinks)
-	fmt.Printf("   Articles fetched: %d\n", len(articles))
-	fmt.Printf("   Output file: %s\n", outputPath)
-	fmt.Printf("   Duration: %s\n", duration.Round(time.Millisecond))
+	rewritten, count, err := links.RewriteLinks(ctx, string(content), digestKey, skipPrefix, shortener)
+	if err != nil {
+		fmt.Printf("   ⚠️  Link tracking skipped: %v\n", err)
+		return
+	}
 
-	fmt.Println("\n💡 Next steps:")
-	fmt.Println("   • Copy the main content to Slack")
-	fmt.Println("   • Post thread content as replies")
-	fmt.Println("   • File:", outputPath)
+	if err := os.WriteFile(outputPath, []byte(rewritten), 0644); err != nil {
+		fmt.Printf("   ⚠️  Link tracking skipped: failed to write digest: %v\n", err)
+		return
+	}
 
-	return nil
+	fmt.Printf("   ✓ Tracking %d links (digest key: %s)\n", count, digestKey)
 }
 
 // SlackMessageChunk represents a chunked message for Slack
@@ -669,15 +1400,16 @@ type SlackMessageChunk struct {
 // SlackChunkLimit is the max characters per Slack message (leaving buffer for formatting)
 const SlackChunkLimit = 3000
 
-// renderSlackFormat renders SlackDigestContent to Slack mrkdwn format with chunked thread content
-func renderSlackFormat(content *narrative.SlackDigestContent, articles []core.Article, clusters []core.TopicCluster) string {
+// renderSlackFormat renders SlackDigestContent to Slack mrkdwn format with chunked thread content.
+// header is the mrkdwn title line (newsletter name and week range).
+func renderSlackFormat(content *narrative.SlackDigestContent, articles []core.Article, clusters []core.TopicCluster, header string) string {
 	var out strings.Builder
 
 	// Build article URL map (1-based citation number -> URL)
 	articleURLs := buildArticleURLMap(articles, clusters)
 
 	// Header
-	out.WriteString(fmt.Sprintf("🤖 *AI Weekly* — %s\n\n", content.WeekRange))
+	out.WriteString(fmt.Sprintf("🤖 %s\n\n", header))
 
 	// Big 3 Section
 	out.WriteString("*🔥 This Week's Big 3*\n\n")
@@ -771,3 +1503,180 @@ func getArticleURL(urlMap map[int]string, articleNum int) string {
 	}
 	return fmt.Sprintf("[Article %d URL not found]", articleNum)
 }
+
+// noiseSkip is an article the content gate kept out of the digest
+type noiseSkip struct {
+	Title   string
+	URL     string
+	Verdict quality.NoiseVerdict
+}
+
+// filterNoisyArticles drops link farms, login walls, error pages, and other
+// low-content pages before any LLM call (filtering.noise in config). Articles whose
+// download was skipped are dropped even with the gate off.
+func filterNoisyArticles(articles []core.Article) ([]core.Article, []noiseSkip) {
+	cfg := config.GetFiltering().Noise
+	var thresholds quality.NoiseThresholds
+	if cfg.Enabled {
+		thresholds = quality.NoiseThresholds{
+			MinWords:           cfg.MinWords,
+			MaxBoilerplate:     cfg.MaxBoilerplate,
+			Languages:          cfg.Languages,
+			ErrorPageDetection: cfg.ErrorPages,
+		}
+	}
+
+	log := logger.Get()
+	kept := articles[:0]
+	var skipped []noiseSkip
+	for _, article := range articles {
+		verdict := quality.CheckNoise(article, thresholds)
+		if verdict == nil {
+			kept = append(kept, article)
+			continue
+		}
+		log.Info("Skipped low-content page", "url", article.URL, "check", verdict.Check, "reason", verdict.Reason)
+		skipped = append(skipped, noiseSkip{Title: article.Title, URL: article.URL, Verdict: *verdict})
+	}
+
+	if len(skipped) > 0 {
+		fmt.Printf("   🧹 Skipped %d low-content page(s) before summarizing\n", len(skipped))
+	}
+	return kept, skipped
+}
+
+// printNoiseSkips lists the pages the content gate skipped and why
+func printNoiseSkips(skipped []noiseSkip) {
+	if len(skipped) == 0 {
+		return
+	}
+
+	fmt.Printf("\n🧹 Skipped before summarizing (%d):\n", len(skipped))
+	for _, skip := range skipped {
+		title := skip.Title
+		if title == "" {
+			title = skip.URL
+		}
+		fmt.Printf("   • %s — %s: %s\n", title, skip.Verdict.Check, skip.Verdict.Reason)
+		if skip.Title != "" {
+			fmt.Printf("     %s\n", skip.URL)
+		}
+	}
+}
+
+// analyzeArticleSentiment scores the sentiment of articles in batches, reusing scores
+// cached for unchanged articles, and returns the overall mix. Failures leave articles
+// unscored; a digest is complete without sentiment.
+func analyzeArticleSentiment(ctx context.Context, llmClient *llm.Client, cache *store.Store, articles []core.Article) string {
+	fmt.Println("   🎭 Scoring article sentiment...")
+
+	var sentimentCache sentiment.Cache
+	if cache != nil {
+		sentimentCache = cache
+	}
+	sentiments, stats := sentiment.NewAnalyzer(llmClient, sentimentCache).AnalyzeBatch(ctx, articles)
+	sentiment.Apply(articles, sentiments)
+
+	fmt.Printf("           ✓ %d cached, %d analyzed in %d batch(es)", stats.Cached, stats.Analyzed, stats.Batches)
+	if stats.Failed > 0 {
+		fmt.Printf(", %d failed", stats.Failed)
+	}
+	fmt.Println()
+
+	overall := sentiment.Overall(sentiments)
+	if overall != "" {
+		fmt.Printf("           ✓ Mood: %s\n", overall)
+	}
+	return overall
+}
+
+// curateArticles asks the LLM which articles look like duplicates or low-value
+// links, from their titles, domains, and notes alone, and cuts the ones confirmed at
+// the prompt. Without a terminal the suggestions are only listed and every article
+// is kept. Failures keep every article too.
+func curateArticles(ctx context.Context, llmClient *llm.Client, articles []core.Article) []core.Article {
+	fmt.Printf("\n✂️  Curating %d articles...\n", len(articles))
+	ctx = llm.WithAttribution(ctx, llm.Attribution{Phase: "curate"})
+	suggestions, err := curate.Suggest(ctx, llmClient, articles)
+	if err != nil {
+		fmt.Printf("   ⚠️  %v; keeping every article\n", err)
+		return articles
+	}
+	if len(suggestions) == 0 {
+		fmt.Println("           ✓ Nothing flagged")
+		return articles
+	}
+
+	for i, suggestion := range suggestions {
+		article := articles[suggestion.Index]
+		label := suggestion.Kind
+		if suggestion.DuplicateOf >= 0 {
+			label = fmt.Sprintf("duplicate of %q", articles[suggestion.DuplicateOf].Title)
+		}
+		fmt.Printf("   %d. %s (%s)\n      %s", i+1, article.Title, curate.Domain(article), label)
+		if suggestion.Reason != "" {
+			fmt.Printf(": %s", suggestion.Reason)
+		}
+		fmt.Println()
+	}
+
+	if !isTerminal(os.Stdin) {
+		fmt.Println("   ⚠️  Not running in a terminal; keeping every article")
+		return articles
+	}
+
+	var chosen map[int]bool
+	reader := bufio.NewReader(os.Stdin)
+	for chosen == nil {
+		fmt.Printf("Cut these? [Y]es, [n]o, or the numbers to cut (e.g. 1,3): ")
+		line, readErr := reader.ReadString('\n')
+		chosen, err = curate.ParseChoice(line, len(suggestions))
+		if err != nil {
+			fmt.Printf("   ⚠️  %v\n", err)
+		}
+		if chosen == nil && readErr != nil {
+			fmt.Println("   ⚠️  No answer; keeping every article")
+			return articles
+		}
+	}
+
+	cut := make(map[int]bool, len(chosen))
+	for i := range chosen {
+		cut[suggestions[i].Index] = true
+	}
+	if len(cut) == 0 {
+		fmt.Println("           ✓ Keeping every article")
+		return articles
+	}
+	fmt.Printf("           ✓ Cut %d article(s) before summarizing, %d left\n", len(cut), len(articles)-len(cut))
+	return curate.Cut(articles, cut)
+}
+
+// describeArticleFigures runs chart/figure images through the vision model so their
+// takeaways appear in the digest. Descriptions are written back to the cache so later
+// runs (e.g. --from-cache) don't repeat the vision calls.
+func describeArticleFigures(ctx context.Context, llmClient *llm.Client, cache *store.Store, articles []core.Article) {
+	limit := config.GetVisual().Figures.MaxPerArticle
+	if limit <= 0 {
+		return
+	}
+
+	fmt.Printf("\n📈 Describing figures...\n")
+	total := 0
+	for i := range articles {
+		n := fetch.DescribeFigures(ctx, &articles[i], llmClient, limit)
+		if n == 0 {
+			continue
+		}
+		total += n
+		fmt.Printf("   ✓ %d figure(s): %s\n", n, articles[i].Title)
+		if cache != nil {
+			if err := cache.CacheArticle(articles[i]); err != nil {
+				logger.Get().Warn("Failed to cache figure descriptions", "url", articles[i].URL, "error", err)
+			}
+		}
+	}
+	if total == 0 {
+		fmt.Println("   No chart or figure images found")
+	}
+}
@@ -1,25 +1,38 @@
 package handlers
 
 import (
+	"briefly/internal/authors"
+	"briefly/internal/clustering"
 	"briefly/internal/config"
 	"briefly/internal/core"
+	"briefly/internal/events"
+	"briefly/internal/footnotes"
+	"briefly/internal/licensing"
 	"briefly/internal/llm"
 	"briefly/internal/logger"
 	"briefly/internal/narrative"
 	"briefly/internal/persistence"
 	"briefly/internal/pipeline"
+	"briefly/internal/render"
+	"briefly/internal/replay"
+	"briefly/internal/series"
 	"briefly/internal/summarize"
+	"briefly/internal/textutil"
+	"briefly/internal/transcript"
 	"briefly/internal/vectorstore"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
+	"google.golang.org/genai"
 )
 
 // llmClientAdapter adapts llm.Client to summarize.LLMClient interface
@@ -29,7 +42,49 @@ type llmClientAdapter struct {
 
 // GenerateText implements summarize.LLMClient interface
 func (a *llmClientAdapter) GenerateText(ctx context.Context, prompt string, opts interface{}) (string, error) {
-	return a.client.GenerateText(ctx, prompt, llm.TextGenerationOptions{})
+	options := llm.TextGenerationOptions{}
+	if structOpts, ok := opts.(summarize.GenerationOptions); ok {
+		options.ResponseSchema = structOpts.ResponseSchema
+		options.Temperature = structOpts.Temperature
+	}
+	return a.client.GenerateText(ctx, prompt, options)
+}
+
+// summaryModel is the model article summaries run on (summarize.model or the default)
+func (a *llmClientAdapter) summaryModel() string {
+	return a.client.TaskModels().ModelFor("summarize", a.client.GetModelName())
+}
+
+// batchLLMClientAdapter adds Gemini Batch API support to llmClientAdapter so the
+// summarizer submits all article summaries as one discounted job (--batch)
+type batchLLMClientAdapter struct {
+	llmClientAdapter
+}
+
+// GenerateTextBatch implements summarize.BatchLLMClient interface
+func (a *batchLLMClientAdapter) GenerateTextBatch(ctx context.Context, prompts []string) ([]string, []error, error) {
+	requests := make([]llm.BatchRequest, len(prompts))
+	for i, prompt := range prompts {
+		requests[i] = llm.BatchRequest{Prompt: prompt}
+	}
+
+	fmt.Printf("   📦 Submitting %d summarization requests as a batch job (this can take a while)...\n", len(prompts))
+	results, err := a.client.GenerateTextBatch(ctx, requests, llm.BatchOptions{
+		DisplayName: fmt.Sprintf("briefly-summaries-%s", time.Now().Format("20060102-150405")),
+		OnPoll: func(job string, state genai.JobState, elapsed time.Duration) {
+			fmt.Printf("   ⏳ %s: %s (%s elapsed)\n", job, state, elapsed.Round(time.Second))
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]string, len(results))
+	errs := make([]error, len(results))
+	for i, result := range results {
+		responses[i], errs[i] = result.Text, result.Err
+	}
+	return responses, errs, nil
 }
 
 // narrativeLLMAdapter adapts llm.Client to narrative.LLMClient interface
@@ -49,6 +104,9 @@ func NewDigestGenerateCmd() *cobra.Command {
 		themeFilter string
 		outputDir   string
 		minArticles int
+		audience    string
+		replayPath  string
+		digestOpts  digestOptions
 	)
 
 	cmd := &cobra.Command{
@@ -79,9 +137,36 @@ Examples:
   briefly digest generate --since 1
 
   # Require minimum articles
-  briefly digest generate --since 7 --min-articles 5`,
+  briefly digest generate --since 7 --min-articles 5
+
+  # Pin an article as the Must-Read instead of the LLM's pick
+  briefly digest generate --since 7 --must-read https://example.com/post
+
+  # Add an "Other side" viewpoint on each digest's top story
+  briefly digest generate --since 7 --perspectives
+
+  # Write for readers new to the topic (also: expert, practitioner, exec)
+  briefly digest generate --since 7 --audience newcomer
+
+  # Cluster on full-text embeddings instead of summaries for this run
+  briefly digest generate --since 7 --embed-from full-text
+
+  # Reproducible run: temperature 0, fixed seeds, inputs recorded in digests/runs/
+  briefly digest generate --since 7 --deterministic
+
+  # Re-run a recorded run against the same database and diff the output
+  briefly digest generate --replay digests/runs/run_20251017-090000.json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDigestGenerate(cmd.Context(), sinceDay, themeFilter, outputDir, minArticles)
+			var err error
+			if replayPath != "" {
+				err = applyReplayOptions(replayPath, &sinceDay, &themeFilter, &minArticles, &digestOpts)
+			} else {
+				digestOpts.Audience, err = resolveAudience(audience, nil)
+			}
+			if err != nil {
+				return err
+			}
+			return runDigestGenerate(cmd.Context(), sinceDay, themeFilter, outputDir, minArticles, digestOpts)
 		},
 	}
 
@@ -89,11 +174,18 @@ Examples:
 	cmd.Flags().StringVar(&themeFilter, "theme", "", "Filter by specific theme name")
 	cmd.Flags().StringVarP(&outputDir, "output", "o", "digests", "Output directory for digest file")
 	cmd.Flags().IntVar(&minArticles, "min-articles", 3, "Minimum articles required to generate digest")
+	cmd.Flags().StringVar(&digestOpts.MustReadURL, "must-read", "", "URL of an article to pin as the Must-Read, overriding the LLM's choice")
+	cmd.Flags().BoolVar(&digestOpts.Perspectives, "perspectives", false, "Add an \"Other side\" viewpoint on the top story, from the sources or a quick web search")
+	cmd.Flags().StringVar(&audience, "audience", "", "Write for: expert, practitioner, exec, or newcomer (default: built-in senior-engineer framing)")
+	cmd.Flags().StringVar(&digestOpts.EmbedFrom, "embed-from", "", "Text embedded for clustering: summary or full-text (default: ai.gemini.embedding_source)")
+	cmd.Flags().BoolVar(&digestOpts.Social, "social", false, "Also draft ready-to-paste LinkedIn and X posts from the digest into <digest>.social.md")
+	cmd.Flags().BoolVar(&digestOpts.Deterministic, "deterministic", false, "Temperature 0, fixed seeds and prompt versions; record the run's inputs and output for --replay")
+	cmd.Flags().StringVar(&replayPath, "replay", "", "Re-run a recorded deterministic run (with its flags) and diff against its output")
 
 	return cmd
 }
 
-func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, outputDir string, minArticles int) error {
+func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, outputDir string, minArticles int, digestOpts digestOptions) error {
 	startTime := time.Now()
 	log := logger.Get()
 	log.Info("Starting digest generation from database",
@@ -110,6 +202,16 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 
 	cfg := config.Get()
 
+	embedFrom := digestOpts.EmbedFrom
+	if embedFrom == "" {
+		embedFrom = cfg.AI.Gemini.EmbeddingSource
+	}
+	embeddingSource, err := clustering.ParseEmbeddingSource(embedFrom)
+	if err != nil {
+		return err
+	}
+	digestOpts.EmbedFrom = string(embeddingSource)
+
 	// Get database connection
 	dbConnStr := cfg.Database.ConnectionString
 	if dbConnStr == "" {
@@ -132,8 +234,16 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 
 	log.Info("Connected to database")
 
-	// Calculate date range
-	since := time.Now().AddDate(0, 0, -sinceDays)
+	// Calculate date range. Deterministic runs pin "now", and a replay reuses the
+	// recorded run's, so the same articles are selected.
+	reference := time.Now()
+	switch {
+	case digestOpts.Replay != nil:
+		reference = digestOpts.Replay.ReferenceTime
+	case digestOpts.Deterministic:
+		reference = reference.UTC().Truncate(time.Second)
+	}
+	since := reference.AddDate(0, 0, -sinceDays)
 
 	// Query classified articles
 	log.Info("Querying classified articles", "since", since.Format("2006-01-02"), "theme", themeFilter)
@@ -142,6 +252,9 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 	if err != nil {
 		return fmt.Errorf("failed to query articles: %w", err)
 	}
+	if digestOpts.Deterministic {
+		articles = pinArticles(articles, reference)
+	}
 
 	if len(articles) == 0 {
 		fmt.Println("⚠️  No classified articles found")
@@ -184,26 +297,32 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 		return fmt.Errorf("failed to create LLM client: %w", err)
 	}
 	defer llmClient.Close()
+	llmClient.SetUsageRecorder(costBudgetRecorder(recordLLMCalls(db)))
+	llmClient.SetDeterministic(digestOpts.Deterministic)
 
 	// Load or generate summaries for all articles
 	fmt.Println("\n📝 Loading/generating article summaries...")
 	summaries := make([]core.Summary, 0, len(articles))
 	adapter := &llmClientAdapter{client: llmClient}
-	summarizer := summarize.NewSummarizerWithDefaults(adapter)
+	summarizer := newAudienceSummarizer(adapter, digestOpts.Audience)
 
 	for i, article := range articles {
 		fmt.Printf("   [%d/%d] Processing: %s\n", i+1, len(articles), article.Title)
 
-		// Try to fetch existing summary from database
-		existingSummary, err := db.Summaries().Get(ctx, article.ID)
-		if err == nil && existingSummary != nil {
-			summaries = append(summaries, *existingSummary)
-			log.Info("Using existing summary", "article_id", article.ID)
-			continue
+		// Try to fetch existing summary from database. Stored summaries use the default
+		// framing, so an audience run summarizes afresh and keeps the result to itself.
+		if digestOpts.Audience == "" {
+			existingSummary, err := db.Summaries().Get(ctx, article.ID)
+			if err == nil && existingSummary != nil {
+				summaries = append(summaries, *existingSummary)
+				log.Info("Using existing summary", "article_id", article.ID)
+				continue
+			}
 		}
 
 		// Generate new summary
-		summary, err := summarizer.SummarizeArticle(ctx, &article)
+		summarizeCtx := llm.WithAttribution(ctx, llm.Attribution{ArticleID: article.ID, Phase: "summarize"})
+		summary, err := summarizer.SummarizeArticle(summarizeCtx, &article)
 		if err != nil {
 			log.Warn("Failed to generate summary", "article_id", article.ID, "error", err)
 			// Create fallback summary
@@ -216,8 +335,14 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 		}
 
 		// Store summary in database
+		if digestOpts.Audience != "" {
+			summaries = append(summaries, *summary)
+			continue
+		}
 		if err := db.Summaries().Create(ctx, summary); err != nil {
 			log.Warn("Failed to save summary to database", "error", err)
+		} else if err := db.LLMCalls().AttachSummary(ctx, article.ID, summary.ID); err != nil {
+			log.Warn("Failed to attribute LLM calls to summary", "summary_id", summary.ID, "error", err)
 		}
 
 		summaries = append(summaries, *summary)
@@ -228,13 +353,31 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 	fmt.Println("🔧 Initializing Pipeline with Phase 2 semantic clustering...")
 
 	// Phase 2: Initialize vector store for semantic clustering
-	vectorStore := vectorstore.NewPgVectorAdapter(db.GetDB())
+	vectorStore, err := vectorstore.NewFromConfig(cfg.VectorStore, db.GetDB())
+	if err != nil {
+		return fmt.Errorf("failed to initialize vector store: %w", err)
+	}
+
+	// Prior coverage joins against digest membership, which only Postgres has
+	priorCoverageStore := vectorstore.NewPgVectorAdapter(db.GetDB())
+
+	var seed int64
+	var pinnedNow time.Time
+	if digestOpts.Deterministic {
+		seed, pinnedNow = replay.Seed, reference
+	}
 
 	pipelineBuilder := pipeline.NewBuilder().
 		WithDatabase(db).
 		WithLLMClient(llmClient).
 		WithVectorStore(pipeline.NewVectorStoreAdapter(vectorStore)).
-		WithCacheDir(".briefly-cache")
+		WithCacheDir(".briefly-cache").
+		WithAudience(digestOpts.Audience).
+		WithContextBudget(cfg.AI.Gemini.ContextBudget).
+		WithTwoPassSummaries(cfg.Summarize.TwoPass).
+		WithFollowedAuthors(cfg.Authors.Follow, cfg.Authors.Boost).
+		WithSeed(seed).
+		WithEmbeddingSource(embeddingSource)
 
 	pipe, err := pipelineBuilder.Build()
 	if err != nil {
@@ -248,6 +391,7 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 		Summaries:      summaries,
 		NumClusters:    0, // Auto-determine
 		GenerateBanner: false,
+		Now:            pinnedNow,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to generate digests: %w", err)
@@ -261,13 +405,77 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 
 	fmt.Printf("\n✨ Generated %d digests in %s\n", len(digests), result.ProcessingTime.Round(time.Second))
 
-	// Save each digest to database
-	fmt.Printf("\n💾 Saving %d digests to database...\n", len(digests))
+	// Save each digest to database (a replay only renders them)
+	if digestOpts.Replay == nil {
+		fmt.Printf("\n💾 Saving %d digests to database...\n", len(digests))
+	}
 	savedCount := 0
 	var outputPaths []string
 
+	// Digests from this run must not link to each other as prior coverage
+	runDigestIDs := make([]string, 0, len(digests))
+	for _, digest := range digests {
+		runDigestIDs = append(runDigestIDs, digest.ID)
+	}
+
+	if digestOpts.MustReadURL != "" {
+		pinned := false
+		for _, digest := range digests {
+			if pinMustRead(digest, digestOpts.MustReadURL, summaries) {
+				fmt.Printf("📌 Pinned Must-Read in %q: %s\n", digest.Title, digest.MustRead.Title)
+				pinned = true
+			}
+		}
+		if !pinned {
+			fmt.Printf("⚠️  --must-read %s is not in any generated digest; keeping the LLM's picks\n", digestOpts.MustReadURL)
+		}
+	}
+
+	if digestOpts.Perspectives {
+		fmt.Println("\n⚖️  Looking for the other side of each digest's top story...")
+		perspectiveGen := narrative.NewGenerator(&narrativeLLMAdapter{client: llmClient})
+		perspectiveGen.SetAudience(digestOpts.Audience)
+		if !llmClient.IsOffline() {
+			perspectiveGen.SetSearcher(llmClient)
+		}
+
+		summaryMap := make(map[string]core.Summary, len(summaries))
+		for _, summary := range summaries {
+			for _, articleID := range summary.ArticleIDs {
+				summaryMap[articleID] = summary
+			}
+		}
+
+		for _, digest := range digests {
+			// Number articles in the digest's own order so citations match its article list
+			cluster := core.TopicCluster{Label: digest.Title}
+			if digest.ClusterID != nil && *digest.ClusterID < len(result.Clusters) {
+				cluster = result.Clusters[*digest.ClusterID]
+			}
+			cluster.ArticleIDs = make([]string, 0, len(digest.Articles))
+			articleMap := make(map[string]core.Article, len(digest.Articles))
+			for _, article := range digest.Articles {
+				cluster.ArticleIDs = append(cluster.ArticleIDs, article.ID)
+				articleMap[article.ID] = article
+			}
+
+			addPerspectives(ctx, perspectiveGen, digest, []core.TopicCluster{cluster}, articleMap, summaryMap)
+		}
+	}
+
+	var record *replay.Record
+	if digestOpts.Deterministic {
+		record = newRunRecord(reference, llmClient.GetModelName(), sinceDays, themeFilter, minArticles, digestOpts, articles, summaries)
+	}
+	if digestOpts.Replay != nil {
+		return finishReplay(ctx, digestOpts.Replay, record, digests, priorCoverageStore, runDigestIDs, cfg.LinkTracking.BaseURL)
+	}
+
 	for i, digest := range digests {
 		fmt.Printf("   [%d/%d] Saving: %s\n", i+1, len(digests), digest.Title)
+		if record != nil && digest.Metadata.DateGenerated.IsZero() {
+			digest.Metadata.DateGenerated = reference
+		}
 
 		// Build article IDs and theme IDs for this digest
 		articleIDs := make([]string, 0, len(digest.Articles))
@@ -291,13 +499,35 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 			continue
 		}
 
+		fmt.Printf("   %s%s\n", savedDigestIDPrefix, digest.ID)
+
+		// Link articles to related coverage in earlier digests
+		attachPriorCoverage(ctx, priorCoverageStore, digest, runDigestIDs, cfg.LinkTracking.BaseURL)
+
 		// Save markdown file
 		outputPath, err := saveDigestMarkdown(digest, outputDir)
 		if err != nil {
 			log.Warn("Failed to save markdown file", "digest_id", digest.ID, "error", err)
 		} else {
 			outputPaths = append(outputPaths, outputPath)
+			if record != nil {
+				if err := recordDigestOutput(record, digest, outputPath); err != nil {
+					log.Warn("Failed to record digest output", "digest_id", digest.ID, "error", err)
+				}
+			}
+			stampDigestProvenance(digest, outputPath, llmClient.GetModelName())
+			if digestOpts.Social {
+				writeSocialPosts(ctx, llmClient, digest, outputPath)
+			}
 		}
+		emitEvent(ctx, events.DigestGenerated, events.Digest{
+			ID:           digest.ID,
+			Title:        digest.Title,
+			Path:         outputPath,
+			Format:       "markdown",
+			ArticleCount: len(articleIDs),
+			TopicCount:   1,
+		})
 
 		savedCount++
 		log.Info("Digest saved", "digest_id", digest.ID, "cluster_id", digest.ClusterID, "articles", len(articleIDs))
@@ -311,6 +541,13 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 	fmt.Printf("   Database: Saved ✓\n")
 	fmt.Printf("   Markdown files: %d\n", len(outputPaths))
 	fmt.Printf("   Duration: %s\n", duration.Round(time.Millisecond))
+	if record != nil {
+		if recordPath, err := record.Save(outputDir); err != nil {
+			fmt.Printf("   ⚠️  Run record not saved: %v\n", err)
+		} else {
+			fmt.Printf("   Run record: %s (replay with --replay %s)\n", recordPath, recordPath)
+		}
+	}
 
 	// Show digest breakdown
 	fmt.Println("\n📊 Digest Breakdown:")
@@ -318,9 +555,42 @@ func runDigestGenerate(ctx context.Context, sinceDays int, themeFilter string, o
 		fmt.Printf("   %d. %s (%d articles)\n", i+1, digest.Title, digest.ArticleCount)
 	}
 
+	printFlaggedSummaries(summaries, articles)
+
 	return nil
 }
 
+// printFlaggedSummaries lists summaries that stayed below the quality threshold
+// after automatic re-summarization, so they can be reviewed by hand
+func printFlaggedSummaries(summaries []core.Summary, articles []core.Article) {
+	titles := make(map[string]string, len(articles))
+	for _, article := range articles {
+		titles[article.ID] = article.Title
+	}
+
+	var flagged []core.Summary
+	for _, summary := range summaries {
+		if summary.QualityFlagged {
+			flagged = append(flagged, summary)
+		}
+	}
+	if len(flagged) == 0 {
+		return
+	}
+
+	fmt.Printf("\n⚠️  Low-quality summaries (%d/%d) - review before publishing:\n", len(flagged), len(summaries))
+	for _, summary := range flagged {
+		title := ""
+		if len(summary.ArticleIDs) > 0 {
+			title = titles[summary.ArticleIDs[0]]
+		}
+		fmt.Printf("   • %s (score %.2f)\n", title, summary.QualityScore)
+		for _, issue := range summary.QualityIssues {
+			fmt.Printf("       - %s\n", issue)
+		}
+	}
+}
+
 // queryClassifiedArticles fetches articles from database with filters
 func queryClassifiedArticles(ctx context.Context, db *persistence.PostgresDB, since time.Time, themeFilter string) ([]core.Article, error) {
 	log := logger.Get()
@@ -482,6 +752,279 @@ func convertMustRead(narrativeMustRead *narrative.MustReadHighlight) *core.MustR
 	}
 }
 
+// digestOptions controls optional, per-run digest settings
+type digestOptions struct {
+	MustReadURL  string        // Pin this article as the Must-Read
+	Perspectives bool          // Run the perspectives pass on the top story
+	Audience     core.Audience // Who summaries and digest content are written for
+	Figures      bool          // Describe chart/figure images with the vision model
+	ExcludeRead  bool          // Leave out articles marked read (see 'cache read-status')
+	Sentiment    bool          // Score article sentiment, reusing cached scores
+	Deliver      bool          // Send the saved digest to every channel under delivery.channels
+	EmbedFrom    string        // Text embedded for clustering: summary or full-text (empty = ai.gemini.embedding_source)
+	Curate       bool          // Confirm the LLM's suggested cuts before summarizing
+	Social       bool          // Draft LinkedIn and X posts from the saved digest into a sidecar file
+
+	Deterministic bool           // Temperature 0 and fixed seeds; the run is recorded for replay
+	Replay        *replay.Record // Re-run this recorded run and diff against its output
+}
+
+// resolveAudience validates the --audience flag, falling back to the series' audience
+func resolveAudience(flag string, ser *series.Series) (core.Audience, error) {
+	audience, err := core.ParseAudience(flag)
+	if err != nil {
+		return "", err
+	}
+	if audience == "" && ser != nil {
+		audience = ser.Audience
+	}
+	if audience != "" {
+		fmt.Printf("👥 Audience: %s\n", audience)
+	}
+	return audience, nil
+}
+
+// newAudienceSummarizer creates a summarizer with default options writing for audience,
+// in two passes when summarize.two_pass is set
+func newAudienceSummarizer(llmClient summarize.LLMClient, audience core.Audience) *summarize.Summarizer {
+	opts := summarize.DefaultSummarizerOptions()
+	opts.Audience = audience
+	opts.TwoPass = config.GetSummarize().TwoPass
+	if adapter, ok := llmClient.(interface{ summaryModel() string }); ok {
+		opts.ModelName = adapter.summaryModel()
+	}
+	return summarize.NewSummarizer(llmClient, opts)
+}
+
+// taskModelNote lists the configured per-task models for the startup banner, e.g.
+// "; summarize: gemini-2.5-flash-lite, digest: gemini-3-pro-preview"
+func taskModelNote() string {
+	if described := llm.ConfiguredTaskModels().Describe(); described != "" {
+		return "; " + described
+	}
+	return ""
+}
+
+// addPerspectives runs the perspectives pass unless the digest already has an opposing
+// viewpoint. Failures are logged; a digest is complete without one.
+func addPerspectives(ctx context.Context, gen *narrative.Generator, digest *core.Digest, clusters []core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) {
+	for _, perspective := range digest.Perspectives {
+		if perspective.Type == "opposing" {
+			return
+		}
+	}
+
+	perspectives, err := gen.GeneratePerspectives(llm.WithAttribution(ctx, llm.Attribution{DigestID: digest.ID}), clusters, articles, summaries)
+	if err != nil {
+		logger.Get().Warn("Perspectives pass failed", "digest_id", digest.ID, "error", err)
+		return
+	}
+	if len(perspectives) == 0 {
+		fmt.Printf("   ℹ️  No other side found for %q\n", digest.Title)
+		return
+	}
+
+	digest.Perspectives = perspectives
+	fmt.Printf("   ✓ Added the other side for %q\n", digest.Title)
+}
+
+// renderOtherSide renders the supporting and opposing perspectives as one balanced
+// paragraph with citations; empty when there is no opposing perspective
+func renderOtherSide(perspectives []core.Perspective) string {
+	var supporting, opposing *core.Perspective
+	for i := range perspectives {
+		switch perspectives[i].Type {
+		case "supporting":
+			if supporting == nil {
+				supporting = &perspectives[i]
+			}
+		case "opposing":
+			if opposing == nil {
+				opposing = &perspectives[i]
+			}
+		}
+	}
+	if opposing == nil {
+		return ""
+	}
+
+	cite := func(p *core.Perspective) string {
+		var refs strings.Builder
+		for _, num := range p.CitationNumbers {
+			refs.WriteString(fmt.Sprintf(" [%d]", num))
+		}
+		for i, url := range p.SourceURLs {
+			refs.WriteString(fmt.Sprintf(" [source %d](%s)", i+1, url))
+		}
+		return refs.String()
+	}
+
+	var content strings.Builder
+	content.WriteString("## ⚖️ The Other Side\n\n")
+	if supporting != nil {
+		content.WriteString(fmt.Sprintf("**The case:** %s%s ", supporting.Summary, cite(supporting)))
+	}
+	content.WriteString(fmt.Sprintf("**The pushback:** %s%s\n\n", opposing.Summary, cite(opposing)))
+
+	return content.String()
+}
+
+// renderTimestampedMoments lists the key moments said in a cited video or podcast,
+// each linked to the moment it starts. Quotes count toward their article's quota
+// (articleURLs maps citation numbers to articles); moments past it are left out.
+// Returns "" when none have a timestamp.
+func renderTimestampedMoments(moments []core.KeyMoment, articleURLs map[int]string, quota *licensing.Quota) string {
+	var content strings.Builder
+	for _, moment := range moments {
+		if moment.TimestampURL == "" {
+			continue
+		}
+		quote, ok := quota.Excerpt(articleURLs[moment.CitationNumber], strings.Trim(moment.Quote, "\" "))
+		if !ok {
+			continue
+		}
+		if content.Len() == 0 {
+			content.WriteString("## ⏱️ Timestamped Moments\n\n")
+		}
+		content.WriteString(fmt.Sprintf("• [▶ %s](%s) \"%s\" [%d]\n",
+			transcript.FormatTimestamp(moment.Timestamp), moment.TimestampURL,
+			quote, moment.CitationNumber))
+	}
+	if content.Len() > 0 {
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderMoreLinks lists a section's articles past the per-topic budget as plain,
+// uncited links
+func renderMoreLinks(group core.ArticleGroup) string {
+	if len(group.MoreLinks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("🔗 **More on %s:**\n", group.Theme))
+	for _, article := range group.MoreLinks {
+		title := article.Title
+		if title == "" {
+			title = article.URL
+		}
+		b.WriteString(fmt.Sprintf("- [%s](%s)\n", title, article.URL))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderRelatedResearch links a digest section to the stored research brief on its
+// topic, with a short refresher. Brief paths are made relative to the digest file.
+func renderRelatedResearch(related *core.RelatedResearch, outputDir string) string {
+	if related == nil {
+		return ""
+	}
+
+	brief := fmt.Sprintf("*%s*", related.Topic)
+	if related.Path != "" {
+		link := related.Path
+		if rel, err := filepath.Rel(outputDir, related.Path); err == nil {
+			link = filepath.ToSlash(rel)
+		}
+		brief = fmt.Sprintf("[%s](%s)", related.Topic, link)
+	}
+
+	line := fmt.Sprintf("📚 **Further reading:** research brief on %s", brief)
+	if related.Refresher != "" {
+		line += " — " + related.Refresher
+	}
+	return line + "\n\n"
+}
+
+// pinMustRead makes the article at pinURL the digest's Must-Read, overriding the LLM's
+// choice. The article is numbered in rendering order so its [N] matches the article
+// list. Returns false when the digest does not include the article.
+func pinMustRead(digest *core.Digest, pinURL string, summaries []core.Summary) bool {
+	ordered := digest.Articles
+	if len(digest.ArticleGroups) > 0 {
+		ordered = nil
+		for _, group := range digest.ArticleGroups {
+			ordered = append(ordered, group.Articles...)
+		}
+	}
+
+	normalize := func(u string) string {
+		return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(u), "/"))
+	}
+
+	for i, article := range ordered {
+		if normalize(article.URL) != normalize(pinURL) {
+			continue
+		}
+
+		// Keep the LLM's explanation when it already picked this article
+		if digest.MustRead != nil && digest.MustRead.ArticleNum == i+1 && digest.MustRead.WhyMustRead != "" {
+			digest.MustRead.Title = article.Title
+			digest.MustRead.ReadTime = article.EstimatedReadMinutes
+			return true
+		}
+
+		why := ""
+		for _, summary := range summaries {
+			if slices.Contains(summary.ArticleIDs, article.ID) {
+				why = textutil.FirstSentence(summary.SummaryText)
+				break
+			}
+		}
+		digest.MustRead = &core.MustReadHighlight{
+			ArticleNum:  i + 1,
+			Title:       article.Title,
+			WhyMustRead: why,
+			ReadTime:    article.EstimatedReadMinutes,
+		}
+		return true
+	}
+
+	return false
+}
+
+// attachPriorCoverage looks up each article's nearest neighbors among previously digested
+// articles and records the earlier digests on the article for rendering. Failures are
+// logged and skipped; continuity links are never worth failing a digest over.
+func attachPriorCoverage(ctx context.Context, store vectorstore.VectorStore, digest *core.Digest, excludeDigestIDs []string, baseURL string) {
+	log := logger.Get()
+
+	for g := range digest.ArticleGroups {
+		for i := range digest.ArticleGroups[g].Articles {
+			article := &digest.ArticleGroups[g].Articles[i]
+
+			matches, err := store.SearchPriorCoverage(ctx, vectorstore.PriorCoverageQuery{
+				ArticleID:        article.ID,
+				ExcludeDigestIDs: excludeDigestIDs,
+			})
+			if err != nil {
+				log.Warn("Failed to search prior coverage", "article_id", article.ID, "error", err)
+				continue
+			}
+
+			for j := range matches {
+				matches[j].DigestURL = priorDigestURL(matches[j], baseURL)
+			}
+			article.PriorCoverage = matches
+		}
+	}
+}
+
+// priorDigestURL links to an earlier digest on the briefly server when a public URL is
+// configured, otherwise to its markdown file alongside the current one
+func priorDigestURL(prior core.PriorCoverage, baseURL string) string {
+	if baseURL != "" {
+		return fmt.Sprintf("%s/digests/%s", strings.TrimSuffix(baseURL, "/"), prior.DigestID)
+	}
+	return digestFilename(&core.Digest{Metadata: core.DigestMetadata{
+		DateGenerated: prior.DigestDate,
+		Issue:         prior.DigestIssue,
+		Parts:         prior.DigestParts,
+	}}, prior.DigestPart)
+}
+
 // saveDigestMarkdown renders digest to LinkedIn-ready markdown file
 func saveDigestMarkdown(digest *core.Digest, outputDir string) (string, error) {
 	// Create output directory if needed
@@ -490,8 +1033,7 @@ func saveDigestMarkdown(digest *core.Digest, outputDir string) (string, error) {
 	}
 
 	// Generate filename
-	timestamp := digest.Metadata.DateGenerated.Format("2006-01-02")
-	filename := fmt.Sprintf("digest_%s.md", timestamp)
+	filename := digestFilename(digest, digest.Metadata.Part)
 	outputPath := fmt.Sprintf("%s/%s", outputDir, filename)
 
 	// Render markdown
@@ -517,6 +1059,8 @@ func saveDigestMarkdown(digest *core.Digest, outputDir string) (string, error) {
 	}
 
 	content.WriteString(fmt.Sprintf("# 🗞️ %s\n\n", digestTitle))
+	partNavigation := renderPartNavigation(digest)
+	content.WriteString(partNavigation)
 
 	// Calculate total reading time
 	totalReadTime := 0
@@ -541,6 +1085,9 @@ func saveDigestMarkdown(digest *core.Digest, outputDir string) (string, error) {
 			digest.Metadata.ArticleCount,
 			len(digest.ArticleGroups)))
 	}
+	if digest.OverallSentiment != "" {
+		content.WriteString(fmt.Sprintf("*Mood: %s*\n\n", digest.OverallSentiment))
+	}
 	content.WriteString("---\n\n")
 
 	// Must-Read Highlight Section (v3.1 - appears first)
@@ -586,14 +1133,31 @@ func saveDigestMarkdown(digest *core.Digest, outputDir string) (string, error) {
 		content.WriteString("\n\n---\n\n")
 	}
 
+	// Balanced take on the top story (perspectives pass)
+	if otherSide := renderOtherSide(digest.Perspectives); otherSide != "" {
+		content.WriteString(otherSide)
+		content.WriteString("---\n\n")
+	}
+
+	// Words quoted from each article are capped by its publisher's reuse policy
+	quota := licensingPolicies().NewQuota()
+
+	// Quotes from videos and podcasts, linked to where they are said
+	if moments := renderTimestampedMoments(digest.KeyMoments, citedURLs(digest), quota); moments != "" {
+		content.WriteString(moments)
+		content.WriteString("---\n\n")
+	}
+
 	// Collect all articles with their original numbers for intent-based grouping
 	type numberedArticle struct {
 		num     int
 		article core.Article
 	}
 
+	// Parts of a split digest continue the numbering of the parts before them
+	firstNum := digest.Metadata.ArticleOffset + 1
 	allArticles := make([]numberedArticle, 0)
-	articleNum := 1
+	articleNum := firstNum
 	for _, group := range digest.ArticleGroups {
 		for _, article := range group.Articles {
 			allArticles = append(allArticles, numberedArticle{num: articleNum, article: article})
@@ -601,6 +1165,33 @@ func saveDigestMarkdown(digest *core.Digest, outputDir string) (string, error) {
 		}
 	}
 
+	// With output.footnotes every citation becomes a [^N] footnote, numbered like the
+	// article list and defined once at the end
+	var cite *footnotes.Set
+	if config.GetOutput().Footnotes {
+		cite = footnotes.New(firstNum)
+		for _, na := range allArticles {
+			cite.Add(na.article.URL, na.article.Title)
+		}
+	}
+
+	// Split older articles into their own section when the digest has both fresh and
+	// older ones; the usual sections then go one level down under "This Week"
+	older := olderArticles(digest, config.GetOutput().FreshWindow)
+	sectionHeading := "##"
+	if len(older) > 0 {
+		content.WriteString("## 🗓️ This Week\n\n")
+		sectionHeading = "###"
+
+		fresh := allArticles[:0:0]
+		for _, na := range allArticles {
+			if !older[na.article.ID] {
+				fresh = append(fresh, na)
+			}
+		}
+		allArticles = fresh
+	}
+
 	// Check if any articles have intent classification
 	hasIntentClassification := false
 	for _, na := range allArticles {
@@ -637,29 +1228,45 @@ func saveDigestMarkdown(digest *core.Digest, outputDir string) (string, error) {
 			}
 
 			// Intent section header
-			content.WriteString(fmt.Sprintf("## %s\n\n", getIntentSectionTitle(intent)))
+			content.WriteString(fmt.Sprintf("%s %s\n\n", sectionHeading, getIntentSectionTitle(intent)))
 			content.WriteString(fmt.Sprintf("*%s*\n\n", getIntentDescription(intent)))
 
 			// Render articles in this intent group
 			for _, na := range articles {
-				renderArticleEntry(&content, na.num, na.article, digest.Summaries)
+				renderArticleEntry(&content, na.num, na.article, digest.Summaries, quota, cite)
 			}
 		}
 
 		// Render uncategorized if any
 		if len(intentGroups[""]) > 0 {
-			content.WriteString("## 📌 Other\n\n")
+			content.WriteString(fmt.Sprintf("%s 📌 Other\n\n", sectionHeading))
 			for _, na := range intentGroups[""] {
-				renderArticleEntry(&content, na.num, na.article, digest.Summaries)
+				renderArticleEntry(&content, na.num, na.article, digest.Summaries, quota, cite)
 			}
 		}
+
+		// Sections aren't rendered by intent, so collect their extra and research links at the end
+		var related strings.Builder
+		for _, group := range digest.ArticleGroups {
+			related.WriteString(renderMoreLinks(group))
+			related.WriteString(renderRelatedResearch(group.RelatedResearch, outputDir))
+		}
+		if related.Len() > 0 {
+			content.WriteString(fmt.Sprintf("%s 🔬 Further Reading\n\n", sectionHeading))
+			content.WriteString(related.String())
+		}
 	} else {
 		// Fall back to theme-based grouping (legacy)
-		articleNum = 1
+		articleNum = firstNum
 		for _, group := range digest.ArticleGroups {
+			if !hasFreshArticle(group, older) {
+				articleNum += len(group.Articles)
+				continue
+			}
+
 			// Theme header with emoji based on theme name
 			emoji := getThemeEmoji(group.Theme)
-			content.WriteString(fmt.Sprintf("## %s %s\n\n", emoji, group.Theme))
+			content.WriteString(fmt.Sprintf("%s %s %s\n\n", sectionHeading, emoji, group.Theme))
 
 			// Build citation number mapping (cluster-relative → digest-global)
 			citationMap := make(map[int]int)
@@ -693,24 +1300,115 @@ func saveDigestMarkdown(digest *core.Digest, outputDir string) (string, error) {
 
 			// Articles in this theme
 			for _, article := range group.Articles {
-				renderArticleEntry(&content, articleNum, article, digest.Summaries)
+				if !older[article.ID] {
+					renderArticleEntry(&content, articleNum, article, digest.Summaries, quota, cite)
+				}
 				articleNum++
 			}
+
+			content.WriteString(renderMoreLinks(group))
+			content.WriteString(renderRelatedResearch(group.RelatedResearch, outputDir))
+		}
+	}
+
+	if len(older) > 0 {
+		content.WriteString("## 🌲 Evergreen & Older\n\n")
+		content.WriteString(fmt.Sprintf("*Published more than %s before this digest, still worth the read.*\n\n",
+			formatFreshWindow(config.GetOutput().FreshWindow)))
+		articleNum = firstNum
+		for _, group := range digest.ArticleGroups {
+			for _, article := range group.Articles {
+				if older[article.ID] {
+					renderArticleEntry(&content, articleNum, article, digest.Summaries, quota, cite)
+				}
+				articleNum++
+			}
+		}
+	}
+
+	if digest.MyTake != "" {
+		content.WriteString(fmt.Sprintf("## 💭 My Take\n\n%s\n\n", digest.MyTake))
+	}
+	content.WriteString(renderReaderNotes(digest.ReaderNotes))
+
+	if cite != nil {
+		body := cite.Cite(content.String()) + cite.References()
+		for _, unresolved := range cite.Unresolved() {
+			fmt.Printf("   ⚠️  Citation %s matches no article in the digest\n", unresolved)
 		}
+		for _, problem := range footnotes.Validate(body) {
+			fmt.Printf("   ⚠️  Footnote %s\n", problem)
+		}
+		content.Reset()
+		content.WriteString(body)
 	}
 
 	// Footer
+	content.WriteString(partNavigation)
 	content.WriteString(fmt.Sprintf("*Generated on %s*\n",
-		digest.Metadata.DateGenerated.Format("Jan 2, 2006")))
+		digest.Metadata.DateGenerated.In(config.GetPublicationLocation()).Format("Jan 2, 2006")))
 
 	// Write file
-	if err := os.WriteFile(outputPath, []byte(content.String()), 0644); err != nil {
+	if err := os.WriteFile(outputPath, []byte(render.Output(content.String())), 0644); err != nil {
 		return "", fmt.Errorf("failed to write file: %w", err)
 	}
 
 	return outputPath, nil
 }
 
+// olderArticles returns the IDs of articles published more than window before the
+// digest was generated. It returns nil unless the digest has articles on both sides
+// of the window, so a digest of only fresh (or only older) articles isn't split.
+// Articles with no known publication date count as fresh.
+func olderArticles(digest *core.Digest, window time.Duration) map[string]bool {
+	if window <= 0 {
+		return nil
+	}
+	reference := digest.Metadata.DateGenerated
+	if reference.IsZero() {
+		reference = time.Now()
+	}
+	cutoff := reference.Add(-window)
+
+	older := make(map[string]bool)
+	total := 0
+	for _, group := range digest.ArticleGroups {
+		for _, article := range group.Articles {
+			total++
+			if !article.DatePublished.IsZero() && article.DatePublished.Before(cutoff) {
+				older[article.ID] = true
+			}
+		}
+	}
+	if len(older) == 0 || len(older) == total {
+		return nil
+	}
+	return older
+}
+
+// hasFreshArticle reports whether any article of group is outside the older set
+func hasFreshArticle(group core.ArticleGroup, older map[string]bool) bool {
+	for _, article := range group.Articles {
+		if !older[article.ID] {
+			return true
+		}
+	}
+	return false
+}
+
+// formatFreshWindow describes the fresh window in days, e.g. "7 days"
+func formatFreshWindow(window time.Duration) string {
+	days := int(window.Hours() / 24)
+	switch {
+	case days == 1:
+		return "1 day"
+	case days > 1:
+		return fmt.Sprintf("%d days", days)
+	default:
+		return window.String()
+	}
+}
+
 // remapCitations remaps citation numbers in text from cluster-relative to digest-global
 // e.g., "[1]" in cluster might need to become "[4]" in the full digest
 func remapCitations(text string, citationMap map[int]int) string {
@@ -804,15 +1502,63 @@ func getIntentDescription(intent string) string {
 	}
 }
 
-// renderArticleEntry renders a single article entry in the digest
-func renderArticleEntry(content *strings.Builder, articleNum int, article core.Article, summaries []core.Summary) {
+// citedURLs maps each article's citation number, in rendering order, to its URL
+func citedURLs(digest *core.Digest) map[int]string {
+	urls := make(map[int]string)
+	num := digest.Metadata.ArticleOffset + 1
+	for _, group := range digest.ArticleGroups {
+		for _, article := range group.Articles {
+			urls[num] = article.URL
+			num++
+		}
+	}
+	return urls
+}
+
+// licensingPolicies returns the per-domain quoting limits from config
+func licensingPolicies() *licensing.Policies {
+	cfg := config.GetLicensing()
+	domains := make([]licensing.Policy, 0, len(cfg.Domains))
+	for _, domain := range cfg.Domains {
+		domains = append(domains, licensing.Policy{Domain: domain.Domain, MaxQuoteWords: domain.MaxQuoteWords, Publisher: domain.Publisher})
+	}
+	return licensing.New(domains, cfg.DefaultMaxQuoteWords, cfg.Attribution)
+}
+
+// renderArticleEntry renders a single article entry in the digest, quoting no more
+// of the article than quota allows
+func renderArticleEntry(content *strings.Builder, articleNum int, article core.Article, summaries []core.Summary, quota *licensing.Quota, cite *footnotes.Set) {
+	// Writers you follow are starred
+	title := article.Title
+	if authors.Followed(article.Author, config.GetAuthors().Follow) != "" {
+		title = "⭐ " + title
+	}
+
+	// With footnotes the article cites its reference instead of linking inline
+	ref := ""
+	if cite != nil {
+		ref = cite.Ref(article.URL)
+	}
+
 	// Use numbered format with reading time
 	if article.EstimatedReadMinutes > 0 {
-		content.WriteString(fmt.Sprintf("**%d. %s** 📖 %d min\n\n", articleNum, article.Title, article.EstimatedReadMinutes))
+		content.WriteString(fmt.Sprintf("**%d. %s**%s 📖 %d min\n\n", articleNum, title, ref, article.EstimatedReadMinutes))
 	} else {
-		content.WriteString(fmt.Sprintf("**%d. %s**\n\n", articleNum, article.Title))
+		content.WriteString(fmt.Sprintf("**%d. %s**%s\n\n", articleNum, title, ref))
+	}
+	var links []string
+	if ref == "" {
+		links = append(links, fmt.Sprintf("🔗 [Read Article](%s)", article.URL))
+	}
+	if byline := render.Byline(article.Author, article.SiteName, time.Time{}); byline != "" {
+		links = append(links, "✍️ "+byline)
+	}
+	if !article.DatePublished.IsZero() {
+		links = append(links, "📅 "+article.DatePublished.Format("Jan 2, 2006"))
+	}
+	if len(links) > 0 {
+		content.WriteString(strings.Join(links, " • ") + "\n\n")
 	}
-	content.WriteString(fmt.Sprintf("🔗 [Read Article](%s)\n\n", article.URL))
 
 	// Find summary
 	var summary *core.Summary
@@ -829,9 +1575,37 @@ func renderArticleEntry(content *strings.Builder, articleNum int, article core.A
 	}
 
 	if summary != nil && summary.SummaryText != "" {
-		content.WriteString(summary.SummaryText)
+		content.WriteString(quota.Limit(article.URL, summary.SummaryText))
 		content.WriteString("\n\n")
 	}
+	if article.MyTake != "" {
+		content.WriteString(fmt.Sprintf("💭 **My take:** %s\n\n", article.MyTake))
+	}
+	if attribution := quota.Attribution(article); attribution != "" {
+		content.WriteString(attribution + "\n\n")
+	}
+
+	for _, image := range article.Images {
+		if image.Description == "" {
+			continue
+		}
+		if config.GetVisual().Figures.EmbedImages {
+			content.WriteString(fmt.Sprintf("![%s](%s)\n\n", image.Alt, image.URL))
+		}
+		content.WriteString(fmt.Sprintf("📈 *Figure:* %s\n\n", image.Description))
+	}
+
+	if len(article.PriorCoverage) > 0 {
+		links := make([]string, 0, len(article.PriorCoverage))
+		for _, prior := range article.PriorCoverage {
+			title := prior.DigestTitle
+			if title == "" {
+				title = "Digest"
+			}
+			links = append(links, fmt.Sprintf("[%s](%s) (%s)", title, prior.DigestURL, prior.DigestDate.Format("Jan 2")))
+		}
+		content.WriteString(fmt.Sprintf("⏪ *Previously on Briefly:* %s\n\n", strings.Join(links, " • ")))
+	}
 
 	content.WriteString("---\n\n")
 }
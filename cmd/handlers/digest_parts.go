@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"fmt"
+	"strings"
+)
+
+// offsetCitations renumbers a digest part's citations, which the LLM numbered from 1
+// within the part, so they continue from the parts before it
+func offsetCitations(digest *core.Digest, offset int) {
+	if offset == 0 {
+		return
+	}
+	shift := make(map[int]int, digest.ArticleCount)
+	for num := 1; num <= digest.ArticleCount; num++ {
+		shift[num] = num + offset
+	}
+	shiftNum := func(num int) int {
+		if shifted, ok := shift[num]; ok {
+			return shifted
+		}
+		return num
+	}
+
+	digest.Summary = remapCitations(digest.Summary, shift)
+	digest.DigestSummary = remapCitations(digest.DigestSummary, shift)
+	digest.TLDRSummary = remapCitations(digest.TLDRSummary, shift)
+	digest.WhyItMatters = remapCitations(digest.WhyItMatters, shift)
+	for i := range digest.TopDevelopments {
+		digest.TopDevelopments[i] = remapCitations(digest.TopDevelopments[i], shift)
+	}
+	for i := range digest.ByTheNumbers {
+		digest.ByTheNumbers[i].Context = remapCitations(digest.ByTheNumbers[i].Context, shift)
+	}
+	if digest.MustRead != nil {
+		digest.MustRead.ArticleNum = shiftNum(digest.MustRead.ArticleNum)
+	}
+	for i := range digest.KeyMoments {
+		digest.KeyMoments[i].CitationNumber = shiftNum(digest.KeyMoments[i].CitationNumber)
+	}
+	for i := range digest.Perspectives {
+		digest.Perspectives[i].Summary = remapCitations(digest.Perspectives[i].Summary, shift)
+		for j, num := range digest.Perspectives[i].CitationNumbers {
+			digest.Perspectives[i].CitationNumbers[j] = shiftNum(num)
+		}
+	}
+}
+
+// digestFilename names a digest's markdown file: digest_<date or issue>.md, with a
+// _partN suffix for each part of a split run
+func digestFilename(digest *core.Digest, part int) string {
+	name := digest.Metadata.DateGenerated.In(config.GetPublicationLocation()).Format("2006-01-02")
+	if digest.Metadata.Issue != "" {
+		name = digest.Metadata.Issue
+	}
+	if digest.Metadata.Parts > 1 {
+		return fmt.Sprintf("digest_%s_part%d.md", name, part)
+	}
+	return fmt.Sprintf("digest_%s.md", name)
+}
+
+// renderPartNavigation links a part of a split digest to its other parts, e.g.
+// "**Part 1 of 2** · [Part 2 →](digest_2025-06-09_part2.md)". Empty when not split.
+func renderPartNavigation(digest *core.Digest) string {
+	meta := digest.Metadata
+	if meta.Parts <= 1 {
+		return ""
+	}
+	links := []string{fmt.Sprintf("**Part %d of %d**", meta.Part, meta.Parts)}
+	for part := 1; part <= meta.Parts; part++ {
+		switch {
+		case part == meta.Part:
+			continue
+		case part < meta.Part:
+			links = append(links, fmt.Sprintf("[← Part %d](%s)", part, digestFilename(digest, part)))
+		default:
+			links = append(links, fmt.Sprintf("[Part %d →](%s)", part, digestFilename(digest, part)))
+		}
+	}
+	return strings.Join(links, " · ") + "\n\n"
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"briefly/internal/clustering"
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"briefly/internal/narrative"
+	"briefly/internal/replay"
+	"briefly/internal/vectorstore"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// applyReplayOptions loads a run record and takes the run's flags from it, so a
+// replay selects and shapes the digest exactly as the recorded run did
+func applyReplayOptions(path string, sinceDays *int, themeFilter *string, minArticles *int, opts *digestOptions) error {
+	record, err := replay.Load(path)
+	if err != nil {
+		return err
+	}
+	audience, err := resolveAudience(record.Options.Audience, nil)
+	if err != nil {
+		return err
+	}
+	*sinceDays = record.Options.SinceDays
+	*themeFilter = record.Options.Theme
+	*minArticles = record.Options.MinArticles
+	opts.Audience = audience
+	opts.MustReadURL = record.Options.MustReadURL
+	opts.Perspectives = record.Options.Perspectives
+	opts.EmbedFrom = record.Options.EmbedFrom
+	if opts.EmbedFrom == "" {
+		opts.EmbedFrom = string(clustering.SourceSummary)
+	}
+	opts.Deterministic = true
+	opts.Replay = record
+	return nil
+}
+
+// pinArticles drops articles fetched after the run's reference time and orders the
+// rest newest first by ID, so a deterministic run doesn't depend on the database's
+// row order or on articles cached since
+func pinArticles(articles []core.Article, reference time.Time) []core.Article {
+	pinned := articles[:0:0]
+	for _, article := range articles {
+		if !article.DateFetched.After(reference) {
+			pinned = append(pinned, article)
+		}
+	}
+	sort.SliceStable(pinned, func(i, j int) bool {
+		if !pinned[i].DateFetched.Equal(pinned[j].DateFetched) {
+			return pinned[i].DateFetched.After(pinned[j].DateFetched)
+		}
+		return pinned[i].ID < pinned[j].ID
+	})
+	return pinned
+}
+
+// newRunRecord describes a deterministic run, before its digests are added
+func newRunRecord(reference time.Time, model string, sinceDays int, themeFilter string, minArticles int, opts digestOptions, articles []core.Article, summaries []core.Summary) *replay.Record {
+	return &replay.Record{
+		Tool:           Version,
+		ReferenceTime:  reference.UTC(),
+		Model:          model,
+		PromptVersions: narrative.PromptVersions(),
+		Seed:           replay.Seed,
+		SamplingSeed:   llm.DeterministicSeed,
+		Options: replay.Options{
+			SinceDays:    sinceDays,
+			Theme:        themeFilter,
+			MinArticles:  minArticles,
+			Audience:     string(opts.Audience),
+			MustReadURL:  opts.MustReadURL,
+			Perspectives: opts.Perspectives,
+			EmbedFrom:    opts.EmbedFrom,
+		},
+		Inputs: replay.Inputs(articles, summaries),
+	}
+}
+
+// recordDigestOutput adds a saved digest's markdown to the run record. Called before
+// provenance is stamped, so the record holds the digest as rendered.
+func recordDigestOutput(record *replay.Record, digest *core.Digest, outputPath string) error {
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read digest for the run record: %w", err)
+	}
+	record.Outputs = append(record.Outputs, replay.NewOutput(digest.ID, digest.Title, string(content)))
+	return nil
+}
+
+// finishReplay renders a replay's digests to a scratch directory, without touching
+// the database, and reports how the run differs from the recorded one
+func finishReplay(ctx context.Context, recorded, current *replay.Record, digests []*core.Digest, priorCoverage vectorstore.VectorStore, excludeDigestIDs []string, baseURL string) error {
+	scratch, err := os.MkdirTemp("", "briefly-replay-")
+	if err != nil {
+		return fmt.Errorf("failed to create replay directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(scratch) }()
+
+	// The recorded run's digests are in the database now; they aren't prior coverage
+	for _, output := range recorded.Outputs {
+		excludeDigestIDs = append(excludeDigestIDs, output.DigestID)
+	}
+	for _, digest := range digests {
+		if digest.Metadata.DateGenerated.IsZero() {
+			digest.Metadata.DateGenerated = current.ReferenceTime
+		}
+		attachPriorCoverage(ctx, priorCoverage, digest, excludeDigestIDs, baseURL)
+		outputPath, err := saveDigestMarkdown(digest, scratch)
+		if err != nil {
+			return err
+		}
+		if err := recordDigestOutput(current, digest, outputPath); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\n🔁 Replay of the run from %s\n", recorded.ReferenceTime.Format(time.RFC3339))
+	if mismatches := recorded.Mismatches(current); len(mismatches) > 0 {
+		fmt.Printf("   ⚠️  The run's setup changed, so identical output isn't expected:\n")
+		for _, mismatch := range mismatches {
+			fmt.Printf("      • %s\n", mismatch)
+		}
+	}
+
+	diffs := replay.Compare(recorded.Outputs, current.Outputs)
+	if len(diffs) == 0 {
+		fmt.Printf("✅ %d digest(s) byte-identical to the recorded run\n", len(current.Outputs))
+		return nil
+	}
+	fmt.Printf("❌ Output differs from the recorded run:\n\n")
+	for _, diff := range diffs {
+		fmt.Println(diff)
+	}
+	return fmt.Errorf("replay differs from the recorded run in %d place(s)", len(diffs))
+}
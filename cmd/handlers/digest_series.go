@@ -0,0 +1,452 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/series"
+	"briefly/internal/store"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// seriesTrendHistory is how many earlier issues topic trends are compared against
+const seriesTrendHistory = 8
+
+// seriesTopicsPerCluster is how many keywords of each cluster are recorded as topics
+const seriesTopicsPerCluster = 3
+
+// NewDigestSeriesCmd creates the digest series command for inspecting named series
+func NewDigestSeriesCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "series [name]",
+		Short: "List digest series or show a series' issue history",
+		Long: `List the digest series configured under series.* or show one series' history.
+
+A series ("AI Weekly", "Platform Notes") has its own title template, output format,
+output directory, and delivery webhooks. Its issues are recorded in the local cache,
+so topic trends and my-takes are tracked per series.
+
+Examples:
+  # List configured series
+  briefly digest series
+
+  # Show the last issues of a series with their my-takes
+  briefly digest series ai-weekly
+
+  # Record your take on the latest issue
+  briefly digest series take ai-weekly "Agents are finally boring, which is good"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return runDigestSeriesList()
+			}
+			return runDigestSeriesHistory(args[0], limit)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Maximum number of issues to show")
+
+	cmd.AddCommand(newDigestSeriesTakeCmd())
+
+	return cmd
+}
+
+func newDigestSeriesTakeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "take <name> <text>",
+		Short: "Record your take on the latest issue of a series",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDigestSeriesTake(args[0], args[1])
+		},
+	}
+}
+
+// resolveDigestSeries loads a configured series and applies its format and output
+// directory unless --format or --output were given explicitly
+func resolveDigestSeries(cmd *cobra.Command, key string, outputDir, outputFormat *string) (*series.Series, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	if _, err := config.Load(cfgFile); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ser, seriesCfg, err := loadSeries(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cmd.Flags().Changed("output") {
+		*outputDir = seriesCfg.OutputDir
+		if *outputDir == "" {
+			*outputDir = filepath.Join("digests", key)
+		}
+	}
+	if !cmd.Flags().Changed("format") && seriesCfg.Format != "" {
+		*outputFormat = seriesCfg.Format
+	}
+
+	fmt.Printf("📚 Series: %s (output: %s, format: %s)\n", ser.Name, *outputDir, *outputFormat)
+	return ser, nil
+}
+
+// loadSeries builds the configured series key, for its delivery settings
+func loadSeries(key string) (*series.Series, config.SeriesConfig, error) {
+	seriesCfg, ok := config.GetSeries(key)
+	if !ok {
+		return nil, seriesCfg, fmt.Errorf("unknown series %q (configured: %s)", key, strings.Join(configuredSeriesKeys(), ", "))
+	}
+
+	ser, err := series.New(key, series.Options{
+		Name:           seriesCfg.Name,
+		TitleTemplate:  seriesCfg.TitleTemplate,
+		SlackWebhook:   seriesCfg.SlackWebhook,
+		SlackBotToken:  seriesCfg.SlackBotToken,
+		SlackChannel:   seriesCfg.SlackChannel,
+		DiscordWebhook: seriesCfg.DiscordWebhook,
+		Audience:       seriesCfg.Audience,
+	})
+	if err != nil {
+		return nil, seriesCfg, err
+	}
+	return ser, seriesCfg, nil
+}
+
+// configuredSeriesKeys returns the configured series keys in order
+func configuredSeriesKeys() []string {
+	keys := make([]string, 0, len(config.Get().Series))
+	for key := range config.Get().Series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) == 0 {
+		return []string{"none"}
+	}
+	return keys
+}
+
+// seriesRun tracks one issue of a series while its digest is generated
+type seriesRun struct {
+	series  *series.Series
+	cache   *store.Store
+	history []store.SeriesIssue   // Earlier issues, newest first
+	pending []store.DigestComment // Comments on earlier issues no issue has shown yet
+	number  int
+}
+
+// startSeriesRun opens the cache and loads the series' earlier issues
+func startSeriesRun(ser *series.Series) (*seriesRun, error) {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := cache.CountSeriesIssues(ser.Key)
+	if err != nil {
+		_ = cache.Close()
+		return nil, err
+	}
+	history, err := cache.GetSeriesIssues(ser.Key, seriesTrendHistory)
+	if err != nil {
+		_ = cache.Close()
+		return nil, err
+	}
+
+	pending, err := cache.PendingSeriesComments(ser.Key)
+	if err != nil {
+		fmt.Printf("   ⚠️  Reader notes skipped: %v\n", err)
+	}
+
+	return &seriesRun{series: ser, cache: cache, history: history, pending: pending, number: count + 1}, nil
+}
+
+// Close closes the cache
+func (r *seriesRun) Close() error {
+	return r.cache.Close()
+}
+
+// Title renders the series title for this issue
+func (r *seriesRun) Title(generated, issueName string, date time.Time) string {
+	return r.series.Title(series.TitleData{
+		Title:  generated,
+		Date:   date.In(config.GetPublicationLocation()).Format("2006-01-02"),
+		Issue:  issueName,
+		Number: r.number,
+	})
+}
+
+// ReaderNotes groups the team's comments on earlier issues for this issue's
+// "Reader notes" section
+func (r *seriesRun) ReaderNotes() []core.ReaderNote {
+	return buildReaderNotes(r.cache, r.pending)
+}
+
+// Finish reports topic trends against earlier issues, records the issue, and
+// delivers the saved file to the series' channels. articles must be in the order the
+// digest numbers them, so comments can name an article by its number.
+func (r *seriesRun) Finish(ctx context.Context, title, outputPath, format string, clusters []core.TopicCluster, articles []core.Article) {
+	topics := seriesTopics(clusters)
+	r.printTrends(topics)
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		fmt.Printf("   ⚠️  Series issue not recorded: %v\n", err)
+		return
+	}
+
+	urls := make([]string, 0, len(articles))
+	for _, article := range articles {
+		urls = append(urls, article.URL)
+	}
+
+	issue := store.SeriesIssue{
+		DigestID:    uuid.NewString(),
+		Series:      r.series.Key,
+		Title:       title,
+		Content:     string(content),
+		Format:      format,
+		Topics:      topics,
+		ArticleURLs: urls,
+	}
+	saveErr := r.cache.SaveSeriesIssue(issue)
+	recorded := saveErr == nil
+	if !recorded {
+		fmt.Printf("   ⚠️  Series issue not recorded: %v\n", saveErr)
+	} else {
+		fmt.Printf("   ✓ Recorded %s issue #%d (ID: %s)\n", r.series.Name, r.number, issue.DigestID[:8])
+		r.markNotesShown(issue.DigestID)
+	}
+
+	if !r.series.HasDelivery() {
+		return
+	}
+	delivered, err := r.series.Deliver(ctx, string(content))
+	if len(delivered) > 0 {
+		fmt.Printf("   ✓ Delivered to %s\n", strings.Join(delivered, ", "))
+	}
+	if err != nil {
+		fmt.Printf("   ⚠️  %v\n", err)
+	}
+
+	// Reactions and thread replies on the delivered messages become comments on this issue
+	if channel, timestamps := r.series.SlackMessages(); recorded && len(timestamps) > 0 {
+		if err := r.cache.SaveDigestMessages(issue.DigestID, channel, timestamps); err != nil {
+			fmt.Printf("   ⚠️  %v\n", err)
+		}
+	}
+}
+
+// markNotesShown records that this issue showed the pending reader notes, so the
+// next issue doesn't repeat them
+func (r *seriesRun) markNotesShown(digestID string) {
+	ids := make([]int64, len(r.pending))
+	for i, comment := range r.pending {
+		ids[i] = comment.ID
+	}
+	if err := r.cache.MarkCommentsRendered(ids, digestID); err != nil {
+		fmt.Printf("   ⚠️  %v\n", err)
+	}
+}
+
+// printTrends compares topics with the series' earlier issues
+func (r *seriesRun) printTrends(topics []string) {
+	if len(r.history) == 0 {
+		fmt.Printf("\n📈 First issue of %s; topic trends start with the next one\n", r.series.Name)
+		return
+	}
+
+	previous := make([][]string, len(r.history))
+	for i, issue := range r.history {
+		previous[i] = issue.Topics
+	}
+
+	var fresh, running []string
+	for _, trend := range series.CompareTopics(topics, previous) {
+		switch {
+		case trend.New:
+			fresh = append(fresh, trend.Topic)
+		case trend.Streak > 1:
+			running = append(running, fmt.Sprintf("%s (%d issues)", trend.Topic, trend.Streak))
+		}
+	}
+
+	fmt.Printf("\n📈 %s trends (vs %d earlier issue(s)):\n", r.series.Name, len(r.history))
+	if len(fresh) > 0 {
+		fmt.Printf("   New: %s\n", strings.Join(fresh, ", "))
+	}
+	if len(running) > 0 {
+		fmt.Printf("   Continuing: %s\n", strings.Join(running, ", "))
+	}
+	if len(fresh) == 0 && len(running) == 0 {
+		fmt.Println("   Only returning topics")
+	}
+}
+
+// seriesTopics collects the leading keywords of each cluster
+func seriesTopics(clusters []core.TopicCluster) []string {
+	seen := make(map[string]bool)
+	var topics []string
+	for _, cluster := range clusters {
+		for i, keyword := range cluster.Keywords {
+			if i >= seriesTopicsPerCluster {
+				break
+			}
+			keyword = strings.ToLower(strings.TrimSpace(keyword))
+			if keyword == "" || seen[keyword] {
+				continue
+			}
+			seen[keyword] = true
+			topics = append(topics, keyword)
+		}
+	}
+	return topics
+}
+
+func runDigestSeriesList() error {
+	if _, err := config.Load(cfgFile); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	keys := configuredSeriesKeys()
+	if len(config.Get().Series) == 0 {
+		fmt.Println("📭 No series configured")
+		fmt.Println("💡 Add one under series.<key> in .briefly.yaml (see .briefly.yaml.example)")
+		return nil
+	}
+
+	fmt.Printf("📚 Digest series (%d)\n", len(keys))
+	for _, key := range keys {
+		seriesCfg, _ := config.GetSeries(key)
+		name := seriesCfg.Name
+		if name == "" {
+			name = key
+		}
+		outputDir := seriesCfg.OutputDir
+		if outputDir == "" {
+			outputDir = filepath.Join("digests", key)
+		}
+		format := seriesCfg.Format
+		if format == "" {
+			format = "markdown"
+		}
+
+		var channels []string
+		if seriesCfg.SlackBotToken != "" && seriesCfg.SlackChannel != "" {
+			channels = append(channels, "slack (threaded)")
+		} else if seriesCfg.SlackWebhook != "" {
+			channels = append(channels, "slack")
+		}
+		if seriesCfg.DiscordWebhook != "" {
+			channels = append(channels, "discord")
+		}
+		if len(channels) == 0 {
+			channels = append(channels, "file only")
+		}
+
+		fmt.Printf("\n   %s — %s\n", key, name)
+		fmt.Printf("      Format: %s · Output: %s · Delivery: %s\n", format, outputDir, strings.Join(channels, ", "))
+	}
+	return nil
+}
+
+func runDigestSeriesHistory(key string, limit int) error {
+	if !series.ValidKey(key) {
+		return fmt.Errorf("invalid series key %q", key)
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	issues, err := cache.GetSeriesIssues(key, limit)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Printf("📭 No issues recorded for %s\n", key)
+		fmt.Printf("💡 Generate one with 'briefly digest --series %s --issue next'\n", key)
+		return nil
+	}
+
+	fmt.Printf("📚 %s: last %d issue(s)\n", key, len(issues))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, issue := range issues {
+		fmt.Printf("\n%s  %s\n", issue.DateGenerated.Format("2006-01-02"), issue.Title)
+		fmt.Printf("   ID: %s · %s · %d articles\n", issue.DigestID[:8], issue.Format, len(issue.ArticleURLs))
+		if len(issue.Topics) > 0 {
+			fmt.Printf("   Topics: %s\n", strings.Join(issue.Topics, ", "))
+		}
+		if issue.MyTake != "" {
+			fmt.Printf("   💭 %s\n", issue.MyTake)
+		}
+	}
+	return nil
+}
+
+func runDigestSeriesTake(key, take string) error {
+	take = strings.TrimSpace(take)
+	if take == "" {
+		return fmt.Errorf("take is empty")
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	issues, err := cache.GetSeriesIssues(key, 1)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		return fmt.Errorf("no issues recorded for series %q", key)
+	}
+
+	if err := cache.UpdateDigestMyTake(issues[0].DigestID, take); err != nil {
+		return fmt.Errorf("failed to save take: %w", err)
+	}
+
+	fmt.Printf("✅ Saved your take on %q\n", issues[0].Title)
+	return nil
+}
+
+// openSeriesCache opens the cache store holding series history
+func openSeriesCache() (*store.Store, error) {
+	if _, err := config.Load(cfgFile); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	cacheDir := config.GetCacheDirectory()
+	if cacheDir == "" {
+		cacheDir = ".briefly-cache"
+	}
+	return openCacheStore(cacheDir)
+}
+
+// openCacheStore opens the cache in cacheDir, falling back to an in-memory cache
+// when it can't be written (e.g. a read-only filesystem), so the command still runs
+// and only persistence is lost
+func openCacheStore(cacheDir string) (*store.Store, error) {
+	cache, err := store.OpenStore(cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache: %w", err)
+	}
+	if reason := cache.Fallback(); reason != nil {
+		fmt.Printf("   ⚠️  Cache unavailable (%v); using an in-memory cache, nothing will be saved\n", reason)
+	}
+	return cache, nil
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"briefly/internal/core"
 	"briefly/internal/persistence"
+	"briefly/internal/transcript"
 	"context"
 	"fmt"
 	"os"
@@ -32,7 +33,8 @@ Examples:
 
   # Show digest in markdown format
   briefly digest show abc123 --format markdown`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDigestIDs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			digestShowRun(cmd, args[0], format)
 		},
@@ -121,6 +123,9 @@ func displayDigestText(digest *core.Digest) {
 		fmt.Println(strings.Repeat("─", 80))
 		for i, moment := range digest.KeyMoments {
 			fmt.Printf("%d. \"%s\" [%d]\n", i+1, moment.Quote, moment.CitationNumber)
+			if moment.TimestampURL != "" {
+				fmt.Printf("   ▶ %s %s\n", transcript.FormatTimestamp(moment.Timestamp), moment.TimestampURL)
+			}
 		}
 		fmt.Println()
 	}
@@ -138,7 +143,11 @@ func displayDigestText(digest *core.Digest) {
 			capitalizedType := strings.ToUpper(string(persp.Type[0])) + persp.Type[1:]
 			fmt.Printf("%s %s View\n", icon, capitalizedType)
 			fmt.Printf("  %s\n", persp.Summary)
-			fmt.Printf("  Sources: %v\n\n", persp.CitationNumbers)
+			fmt.Printf("  Sources: %v\n", persp.CitationNumbers)
+			for _, url := range persp.SourceURLs {
+				fmt.Printf("  🔗 %s\n", url)
+			}
+			fmt.Println()
 		}
 	}
 
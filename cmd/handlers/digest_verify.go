@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/narrative"
+	"briefly/internal/provenance"
+	"crypto/ed25519"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewDigestVerifyCmd creates the digest verify subcommand
+func NewDigestVerifyCmd() *cobra.Command {
+	var publicKey string
+
+	cmd := &cobra.Command{
+		Use:   "verify <digest.md>",
+		Short: "Verify a digest's provenance and signature",
+		Long: `Check that a generated digest still matches the provenance recorded when it was
+generated, and that the provenance was signed by the expected key.
+
+Provenance is read from the digest's YAML frontmatter, or from the
+<digest>.provenance.json sidecar next to it. Enable it with provenance.enabled
+in config; set provenance.signing_key (or BRIEFLY_SIGNING_KEY) to sign.
+
+Without a public key (--public-key or provenance.public_key) only the content
+hash is checked.
+
+Examples:
+  # Verify content hash and signature
+  briefly digest verify digests/digest_2025-06-07.md
+
+  # Verify against a specific key
+  briefly digest verify digests/digest_2025-06-07.md --public-key <base64>`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDigestVerify(args[0], publicKey)
+		},
+	}
+
+	cmd.Flags().StringVar(&publicKey, "public-key", "", "Base64 ed25519 public key (default: provenance.public_key)")
+
+	return cmd
+}
+
+func runDigestVerify(path string, publicKey string) error {
+	if publicKey == "" {
+		publicKey = config.GetProvenance().PublicKey
+	}
+
+	var key ed25519.PublicKey
+	if publicKey != "" {
+		parsed, err := provenance.ParsePublicKey(publicKey)
+		if err != nil {
+			return err
+		}
+		key = parsed
+	}
+
+	meta, err := provenance.Verify(path, key)
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	fmt.Printf("✅ %s matches its provenance\n", path)
+	fmt.Printf("   Generated: %s by %s %s\n", meta.GeneratedAt.Format(time.RFC3339), meta.Tool, meta.Version)
+	fmt.Printf("   Model: %s\n", meta.Model)
+	fmt.Printf("   Sources: %d (sha256 %s)\n", meta.SourceCount, meta.SourcesHash)
+	switch {
+	case key != nil:
+		fmt.Printf("   Signature: valid (key %s)\n", meta.KeyID)
+	case meta.Signature != "":
+		fmt.Printf("   Signature: present (key %s) but not checked; pass --public-key\n", meta.KeyID)
+	default:
+		fmt.Println("   Signature: none")
+	}
+
+	return nil
+}
+
+// stampDigestProvenance records provenance for a saved digest when provenance.enabled
+// is set. Runs after link tracking, since the hash covers the final file. Failures
+// only warn, since the digest itself is already saved.
+func stampDigestProvenance(digest *core.Digest, outputPath string, model string) {
+	cfg := config.GetProvenance()
+	if !cfg.Enabled {
+		return
+	}
+
+	var key ed25519.PrivateKey
+	if cfg.SigningKey != "" {
+		parsed, err := provenance.ParsePrivateKey(cfg.SigningKey)
+		if err != nil {
+			fmt.Printf("   ⚠️  Provenance skipped: %v\n", err)
+			return
+		}
+		key = parsed
+	}
+
+	urls := digestSourceURLs(digest)
+	meta, err := provenance.Stamp(outputPath, provenance.Metadata{
+		Tool:           "briefly",
+		Version:        Version,
+		GeneratedAt:    time.Now().UTC(),
+		DigestID:       digest.ID,
+		Model:          model,
+		PromptVersions: narrative.PromptVersions(),
+		SourceCount:    len(urls),
+		SourcesHash:    provenance.HashSources(urls),
+	}, cfg.Format, key)
+	if err != nil {
+		fmt.Printf("   ⚠️  Provenance skipped: %v\n", err)
+		return
+	}
+
+	if meta.Signature != "" {
+		fmt.Printf("   ✓ Provenance recorded and signed (key %s)\n", meta.KeyID)
+	} else {
+		fmt.Printf("   ✓ Provenance recorded (unsigned)\n")
+	}
+}
+
+// digestSourceURLs lists the URLs of the articles a digest was built from
+func digestSourceURLs(digest *core.Digest) []string {
+	var urls []string
+	for _, group := range digest.ArticleGroups {
+		for _, article := range group.Articles {
+			urls = append(urls, article.URL)
+		}
+	}
+	if len(urls) == 0 {
+		for _, article := range digest.Articles {
+			urls = append(urls, article.URL)
+		}
+	}
+	if len(urls) == 0 {
+		urls = append(urls, digest.ArticleURLs...)
+	}
+	return urls
+}
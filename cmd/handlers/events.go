@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/events"
+	"briefly/internal/llm"
+	"context"
+	"fmt"
+)
+
+// openEventEmitter returns an emitter for the configured event webhooks, or nil when
+// there are none. Misconfigured webhooks are reported and no events are sent.
+func openEventEmitter() *events.Emitter {
+	cfg := config.GetEvents()
+	webhooks := make([]events.Webhook, len(cfg.Webhooks))
+	for i, webhook := range cfg.Webhooks {
+		webhooks[i] = events.Webhook{URL: webhook.URL, Events: webhook.Events, Secret: webhook.Secret}
+	}
+	emitter, err := events.New(webhooks)
+	if err != nil {
+		fmt.Printf("⚠️  Event webhooks skipped: %v\n", err)
+		return nil
+	}
+	return emitter
+}
+
+// emitEvent posts an event to the configured webhooks; failed deliveries are
+// reported but never fail the command
+func emitEvent(ctx context.Context, event string, data interface{}) {
+	if err := openEventEmitter().Emit(context.WithoutCancel(ctx), event, data); err != nil {
+		fmt.Printf("   ⚠️  Could not send %s event: %v\n", event, err)
+	}
+}
+
+// costBudgetRecorder wraps next (which may be nil) so the run's LLM spend is
+// checked against events.cost_budget
+func costBudgetRecorder(next llm.UsageRecorder) llm.UsageRecorder {
+	return events.NewCostBudget(config.GetEvents().CostBudget, openEventEmitter()).Recorder(next)
+}
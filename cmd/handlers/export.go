@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"briefly/internal/export"
+	"briefly/internal/logger"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// NewExportCmd creates the export command for offline reading formats
+func NewExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export digests to offline reading formats",
+		Long: `Export digests to formats suitable for e-readers.
+
+Subcommands:
+  epub      Export a digest and its full articles as an EPUB book`,
+	}
+
+	cmd.AddCommand(newExportEpubCmd())
+
+	return cmd
+}
+
+func newExportEpubCmd() *cobra.Command {
+	var outputPath string
+	var mobi bool
+
+	cmd := &cobra.Command{
+		Use:   "epub <digest-id>",
+		Short: "Export a digest as an EPUB for Kindle/Kobo",
+		Long: `Export a digest as an EPUB book for offline reading.
+
+The book contains:
+  • The digest summary as the opening chapter
+  • One chapter per article with the full cleaned text
+  • Source attribution linking back to each original article
+
+With --mobi, the EPUB is additionally converted to MOBI using Calibre's
+ebook-convert (must be installed and on PATH).
+
+Examples:
+  briefly export epub abc123
+  briefly export epub abc123 --output ~/Books/digest.epub
+  briefly export epub abc123 --mobi`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDigestIDs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExportEpub(cmd.Context(), args[0], outputPath, mobi)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path (default: digests/digest_<id>.epub)")
+	cmd.Flags().BoolVar(&mobi, "mobi", false, "Also convert to MOBI via ebook-convert")
+
+	return cmd
+}
+
+func runExportEpub(ctx context.Context, digestID, outputPath string, mobi bool) error {
+	log := logger.Get()
+	log.Info("Exporting digest to EPUB", "digest_id", digestID)
+
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	digest, err := db.Digests().GetWithArticles(ctx, digestID)
+	if err != nil {
+		return fmt.Errorf("failed to get digest: %w", err)
+	}
+
+	articles := digest.Articles
+	if len(articles) == 0 {
+		articles, err = db.Digests().GetDigestArticles(ctx, digest.ID)
+		if err != nil {
+			return fmt.Errorf("failed to load digest articles: %w", err)
+		}
+	}
+
+	if outputPath == "" {
+		shortID := digest.ID
+		if len(shortID) > 8 {
+			shortID = shortID[:8]
+		}
+		outputPath = filepath.Join("digests", fmt.Sprintf("digest_%s.epub", shortID))
+	}
+
+	book := export.NewBookFromDigest(digest, articles)
+	if err := export.SaveEPUB(book, outputPath); err != nil {
+		return fmt.Errorf("failed to write EPUB: %w", err)
+	}
+
+	fmt.Println("✅ EPUB exported successfully")
+	fmt.Printf("   Title:    %s\n", book.Title)
+	fmt.Printf("   Chapters: %d (%d articles)\n", len(book.Chapters), len(articles))
+	fmt.Printf("   File:     %s\n", outputPath)
+
+	if mobi {
+		mobiPath := strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + ".mobi"
+		// The EPUB stays on disk; only the requested MOBI is missing
+		if err := convertToMobi(outputPath, mobiPath); err != nil {
+			return fmt.Errorf("mobi conversion failed (EPUB kept at %s): %w", outputPath, err)
+		}
+		fmt.Printf("   MOBI:     %s\n", mobiPath)
+	}
+
+	return nil
+}
+
+// convertToMobi shells out to Calibre's ebook-convert, which handles the
+// proprietary MOBI container far better than anything we'd maintain ourselves
+func convertToMobi(epubPath, mobiPath string) error {
+	bin, err := exec.LookPath("ebook-convert")
+	if err != nil {
+		return fmt.Errorf("ebook-convert not found (install Calibre to enable MOBI export)")
+	}
+
+	out, err := exec.Command(bin, epubPath, mobiPath).CombinedOutput()
+	if err != nil {
+		logger.Get().Warn("ebook-convert failed", "output", string(out))
+		return fmt.Errorf("ebook-convert: %w", err)
+	}
+
+	if _, err := os.Stat(mobiPath); err != nil {
+		return fmt.Errorf("ebook-convert did not produce %s", mobiPath)
+	}
+	return nil
+}
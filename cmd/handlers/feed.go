@@ -2,13 +2,22 @@ package handlers
 
 import (
 	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/events"
+	"briefly/internal/feeds"
 	"briefly/internal/logger"
 	"briefly/internal/persistence"
+	"briefly/internal/search"
 	"briefly/internal/sources"
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -26,7 +35,10 @@ Subcommands:
   list      List all feed sources
   enable    Enable a feed
   disable   Disable a feed
-  stats     Show statistics for feeds`,
+  category  File a feed under a category (folder)
+  stats     Show statistics for feeds
+  pull      Queue new items from feeds and followed topics
+  items     List recently pulled items, flagging high-priority ones`,
 	}
 
 	cmd.AddCommand(newFeedAddCmd())
@@ -34,18 +46,27 @@ Subcommands:
 	cmd.AddCommand(newFeedListCmd())
 	cmd.AddCommand(newFeedEnableCmd())
 	cmd.AddCommand(newFeedDisableCmd())
+	cmd.AddCommand(newFeedCategoryCmd())
 	cmd.AddCommand(newFeedStatsCmd())
+	cmd.AddCommand(newFeedPullCmd())
+	cmd.AddCommand(newFeedItemsCmd())
 
 	return cmd
 }
 
 func newFeedAddCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "add <feed-url>",
+	var category string
+	var pick int
+
+	cmd := &cobra.Command{
+		Use:   "add <feed-or-website-url>",
 		Short: "Add a new RSS/Atom feed source",
 		Long: `Add a new feed source for news aggregation.
 
-The feed URL must be a valid RSS or Atom feed. The command will:
+The URL can be an RSS or Atom feed, or a website. For a website, its feeds are
+discovered from <link rel="alternate"> tags and common paths (/feed, /rss.xml,
+/atom.xml, ...) and listed; a single feed is added directly, otherwise choose one
+at the prompt or with --pick. The command will:
   • Validate the feed format
   • Fetch initial metadata
   • Store feed in database
@@ -53,20 +74,28 @@ The feed URL must be a valid RSS or Atom feed. The command will:
 
 Examples:
   briefly feed add https://hnrss.org/newest
-  briefly feed add https://arxiv.org/rss/cs.AI`,
+  briefly feed add https://arxiv.org/rss/cs.AI --category research
+  briefly feed add https://blog.example.com
+  briefly feed add https://blog.example.com --pick 2`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			feedURL := args[0]
-			return runFeedAdd(cmd.Context(), feedURL)
+			return runFeedAdd(cmd.Context(), feedURL, category, pick)
 		},
 	}
+
+	cmd.Flags().StringVar(&category, "category", "", "File the feed under this category (e.g. security)")
+	cmd.Flags().IntVar(&pick, "pick", 0, "Add the nth feed discovered on a website (default: prompt when there are several)")
+
+	return cmd
 }
 
 func newFeedRemoveCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "remove <feed-id>",
-		Short: "Remove a feed source",
-		Args:  cobra.ExactArgs(1),
+		Use:               "remove <feed-id>",
+		Short:             "Remove a feed source",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFeedIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			feedID := args[0]
 			return runFeedRemove(cmd.Context(), feedID)
@@ -76,25 +105,28 @@ func newFeedRemoveCmd() *cobra.Command {
 
 func newFeedListCmd() *cobra.Command {
 	var showInactive bool
+	var category string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all feed sources",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runFeedList(cmd.Context(), showInactive)
+			return runFeedList(cmd.Context(), showInactive, category)
 		},
 	}
 
 	cmd.Flags().BoolVar(&showInactive, "all", false, "Show inactive feeds as well")
+	cmd.Flags().StringVar(&category, "category", "", "Only show feeds in this category")
 
 	return cmd
 }
 
 func newFeedEnableCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "enable <feed-id>",
-		Short: "Enable a feed source",
-		Args:  cobra.ExactArgs(1),
+		Use:               "enable <feed-id>",
+		Short:             "Enable a feed source",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFeedIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			feedID := args[0]
 			return runFeedToggle(cmd.Context(), feedID, true)
@@ -104,9 +136,10 @@ func newFeedEnableCmd() *cobra.Command {
 
 func newFeedDisableCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "disable <feed-id>",
-		Short: "Disable a feed source",
-		Args:  cobra.ExactArgs(1),
+		Use:               "disable <feed-id>",
+		Short:             "Disable a feed source",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFeedIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			feedID := args[0]
 			return runFeedToggle(cmd.Context(), feedID, false)
@@ -114,6 +147,45 @@ func newFeedDisableCmd() *cobra.Command {
 	}
 }
 
+func newFeedCategoryCmd() *cobra.Command {
+	var clear bool
+
+	cmd := &cobra.Command{
+		Use:   "category <feed-id> [category]",
+		Short: "File a feed under a category (folder)",
+		Long: `Set or clear the category a feed is filed under.
+
+Categories are folder names like "security" or "ai-research". Names are
+lowercased and spaces become dashes. Build a digest from one category's feeds
+with 'briefly digest --from-feeds --category <name>'.
+
+Examples:
+  briefly feed category 3f2a9c1e security
+  briefly feed category 3f2a9c1e --clear`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if clear {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+				return fmt.Errorf("%w (use --clear to remove a feed's category)", err)
+			}
+			return nil
+		},
+		ValidArgsFunction: completeFeedIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			category := ""
+			if len(args) > 1 {
+				category = args[1]
+			}
+			return runFeedCategory(cmd.Context(), args[0], category)
+		},
+	}
+
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove the feed from its category")
+
+	return cmd
+}
+
 func newFeedStatsCmd() *cobra.Command {
 	var feedID string
 
@@ -136,6 +208,99 @@ Otherwise, shows detailed statistics for the specified feed.`,
 	return cmd
 }
 
+// feedPullOptions are the flags of 'briefly feed pull'
+type feedPullOptions struct {
+	sinceHours  int
+	maxItems    int
+	noFollow    bool
+	timeout     time.Duration
+	concurrency int
+}
+
+func newFeedPullCmd() *cobra.Command {
+	var opts feedPullOptions
+
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Queue new items from feeds and followed topics",
+		Long: `Pull new items from all active feeds into the queue of unprocessed items.
+
+Feeds are fetched concurrently (feeds.concurrency at once), and each gets
+feeds.timeout to respond, so one hanging feed doesn't hold up the rest. Feeds
+that time out or fail are listed at the end and retried on the next pull.
+
+Each new item's title and description is pre-screened against the priority rules'
+keywords and watchlist (see 'briefly priority --help'); matches are flagged in
+'briefly feed items'.
+
+Standing search queries configured under feeds.follow run against their search
+provider too, and result URLs not seen before are queued as items of the
+"Followed Topics" feed, for coverage beyond RSS sources. 'briefly classify'
+then fetches and classifies queued items like any other.
+
+Example configuration:
+  feeds:
+    follow:
+      - query: "eBPF security"
+      - query: "WebAssembly component model"
+        provider: serpapi
+        max_results: 5
+
+Examples:
+  briefly feed pull
+  briefly feed pull --since 48
+  briefly feed pull --no-follow
+  briefly feed pull --timeout 10s --concurrency 32`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFeedPull(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.sinceHours, "since", 24, "Only queue items published (or searched for) in the last N hours")
+	cmd.Flags().IntVar(&opts.maxItems, "max-items", 0, "Maximum items to queue per feed (default: feeds.max_items_per_feed)")
+	cmd.Flags().BoolVar(&opts.noFollow, "no-follow", false, "Skip the followed topics' search queries")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 0, "How long each feed gets to respond (default: feeds.timeout)")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 0, "Feeds fetched at once (default: feeds.concurrency)")
+
+	return cmd
+}
+
+func newFeedItemsCmd() *cobra.Command {
+	var (
+		limit        int
+		priorityOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "items [feed-id]",
+		Short: "List recently pulled items, flagging high-priority ones",
+		Long: `List the most recently published feed items, from every feed or one.
+
+Items whose title or description matched a priority rule's keywords or the
+watchlist when they were pulled are flagged 🚩 with the rule and terms (see
+'briefly priority --help').
+
+Examples:
+  briefly feed items
+  briefly feed items --priority
+  briefly feed items <feed-id> --limit 50`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeFeedIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			feedID := ""
+			if len(args) == 1 {
+				feedID = args[0]
+			}
+			return runFeedItems(cmd.Context(), feedID, limit, priorityOnly)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "l", 30, "Maximum items to show")
+	cmd.Flags().BoolVar(&priorityOnly, "priority", false, "Only show the high-priority items among them")
+
+	return cmd
+}
+
 // Implementation functions
 
 // getDatabase is a helper function to load config and connect to database
@@ -163,9 +328,8 @@ func getDatabase() (persistence.Database, error) {
 	return db, nil
 }
 
-func runFeedAdd(ctx context.Context, feedURL string) error {
+func runFeedAdd(ctx context.Context, feedURL string, category string, pick int) error {
 	log := logger.Get()
-	log.Info("Adding new feed", "url", feedURL)
 
 	db, err := getDatabase()
 	if err != nil {
@@ -173,8 +337,14 @@ func runFeedAdd(ctx context.Context, feedURL string) error {
 	}
 	defer db.Close()
 
+	feedURL, err = resolveFeedURL(feedURL, pick)
+	if err != nil {
+		return err
+	}
+	log.Info("Adding new feed", "url", feedURL)
+
 	sourceMgr := sources.NewManager(db)
-	feed, err := sourceMgr.AddFeed(ctx, feedURL)
+	feed, err := sourceMgr.AddFeed(ctx, feedURL, category)
 	if err != nil {
 		return fmt.Errorf("failed to add feed: %w", err)
 	}
@@ -183,6 +353,9 @@ func runFeedAdd(ctx context.Context, feedURL string) error {
 	fmt.Printf("   ID:    %s\n", feed.ID)
 	fmt.Printf("   Title: %s\n", feed.Title)
 	fmt.Printf("   URL:   %s\n", feed.URL)
+	if feed.Category != "" {
+		fmt.Printf("   Category: %s\n", feed.Category)
+	}
 	fmt.Println("\nNext steps:")
 	fmt.Println("  • Run aggregation: briefly aggregate")
 	fmt.Println("  • View feed stats: briefly feed stats", feed.ID)
@@ -190,6 +363,58 @@ func runFeedAdd(ctx context.Context, feedURL string) error {
 	return nil
 }
 
+// resolveFeedURL returns the feed to add for a feed or website URL: the URL itself
+// when it is a feed, the only feed discovered, the --pick'th, or the one chosen at
+// a prompt
+func resolveFeedURL(siteURL string, pick int) (string, error) {
+	found, err := feeds.NewFeedManager().DiscoverFeeds(siteURL)
+	if err != nil {
+		return "", err
+	}
+	if len(found) == 0 {
+		return "", fmt.Errorf("no RSS or Atom feed found at %s (checked <link rel=\"alternate\"> tags and common paths such as /feed and /rss.xml)", siteURL)
+	}
+	if len(found) == 1 && found[0].Source == feeds.SourceDirect {
+		return found[0].URL, nil
+	}
+
+	fmt.Printf("🔎 Found %d feed(s) for %s:\n", len(found), siteURL)
+	for i, feed := range found {
+		fmt.Printf("   %d. %s\n      %s (%d items, %s)\n", i+1, feed.Title, feed.URL, feed.Items, discoverySourceLabel(feed.Source))
+	}
+
+	switch {
+	case pick > 0:
+		if pick > len(found) {
+			return "", fmt.Errorf("--pick %d is out of range (found %d feeds)", pick, len(found))
+		}
+		return found[pick-1].URL, nil
+	case len(found) == 1:
+		return found[0].URL, nil
+	case !isTerminal(os.Stdin):
+		return "", fmt.Errorf("found %d feeds; choose one with --pick", len(found))
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("Add which feed? [1-%d]: ", len(found))
+		line, err := reader.ReadString('\n')
+		if n, convErr := strconv.Atoi(strings.TrimSpace(line)); convErr == nil && n >= 1 && n <= len(found) {
+			return found[n-1].URL, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("no feed chosen")
+		}
+	}
+}
+
+func discoverySourceLabel(source string) string {
+	if source == feeds.SourceLink {
+		return "advertised by the page"
+	}
+	return "common path"
+}
+
 func runFeedRemove(ctx context.Context, feedID string) error {
 	log := logger.Get()
 	log.Info("Removing feed", "id", feedID)
@@ -209,7 +434,7 @@ func runFeedRemove(ctx context.Context, feedID string) error {
 	return nil
 }
 
-func runFeedList(ctx context.Context, showInactive bool) error {
+func runFeedList(ctx context.Context, showInactive bool, category string) error {
 	db, err := getDatabase()
 	if err != nil {
 		return err
@@ -222,6 +447,17 @@ func runFeedList(ctx context.Context, showInactive bool) error {
 		return fmt.Errorf("failed to list feeds: %w", err)
 	}
 
+	if category != "" {
+		category = sources.NormalizeCategory(category)
+		var inCategory []core.Feed
+		for _, feed := range feeds {
+			if feed.Category == category {
+				inCategory = append(inCategory, feed)
+			}
+		}
+		feeds = inCategory
+	}
+
 	if len(feeds) == 0 {
 		fmt.Println("No feeds found")
 		fmt.Println("\nAdd your first feed:")
@@ -231,8 +467,8 @@ func runFeedList(ctx context.Context, showInactive bool) error {
 
 	// Display feeds in a table
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "ID\tTitle\tActive\tLast Fetched\tError Count\n")
-	fmt.Fprintf(w, "━━━━━━━━━━\t━━━━━━━━━━━━━━━━━━━━\t━━━━━━\t━━━━━━━━━━━━\t━━━━━━━━━━━\n")
+	fmt.Fprintf(w, "ID\tTitle\tCategory\tActive\tLast Fetched\tError Count\n")
+	fmt.Fprintf(w, "━━━━━━━━━━\t━━━━━━━━━━━━━━━━━━━━\t━━━━━━━━\t━━━━━━\t━━━━━━━━━━━━\t━━━━━━━━━━━\n")
 
 	for _, feed := range feeds {
 		status := "✓"
@@ -261,8 +497,13 @@ func runFeedList(ctx context.Context, showInactive bool) error {
 			idShort = feed.ID[:8] + "..."
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-			idShort, titleShort, status, lastFetched, errorCount,
+		category := feed.Category
+		if category == "" {
+			category = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			idShort, titleShort, category, status, lastFetched, errorCount,
 		)
 	}
 	w.Flush()
@@ -302,6 +543,27 @@ func runFeedToggle(ctx context.Context, feedID string, active bool) error {
 	return nil
 }
 
+func runFeedCategory(ctx context.Context, feedID string, category string) error {
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sourceMgr := sources.NewManager(db)
+	feed, err := sourceMgr.SetFeedCategory(ctx, feedID, category)
+	if err != nil {
+		return fmt.Errorf("failed to set feed category: %w", err)
+	}
+
+	if feed.Category == "" {
+		fmt.Printf("✅ %s is no longer in a category\n", feed.Title)
+	} else {
+		fmt.Printf("✅ %s filed under %s\n", feed.Title, feed.Category)
+	}
+	return nil
+}
+
 func runFeedStats(ctx context.Context, feedID string) error {
 	db, err := getDatabase()
 	if err != nil {
@@ -325,6 +587,9 @@ func runFeedStats(ctx context.Context, feedID string) error {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("Title:            %s\n", stats.Feed.Title)
 	fmt.Printf("URL:              %s\n", stats.Feed.URL)
+	if stats.Feed.Category != "" {
+		fmt.Printf("Category:         %s\n", stats.Feed.Category)
+	}
 	fmt.Printf("Active:           %v\n", stats.Feed.Active)
 	fmt.Printf("Total Items:      %d\n", stats.TotalItems)
 	fmt.Printf("Processed Items:  %d\n", stats.ProcessedItems)
@@ -384,3 +649,260 @@ func showAllFeedStats(ctx context.Context, db persistence.Database) error {
 
 	return nil
 }
+
+func runFeedPull(ctx context.Context, pull feedPullOptions) error {
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sourceMgr := sources.NewManager(db)
+	since := time.Now().Add(-time.Duration(pull.sinceHours) * time.Hour)
+	feedsCfg := config.GetFeeds()
+
+	opts := sources.DefaultAggregateOptions()
+	opts.Since = since
+	opts.MaxArticlesPerFeed = feedsCfg.MaxItemsPerFeed
+	if pull.maxItems > 0 {
+		opts.MaxArticlesPerFeed = pull.maxItems
+	}
+	if feedsCfg.Concurrency > 0 {
+		opts.MaxConcurrency = feedsCfg.Concurrency
+	}
+	if pull.concurrency > 0 {
+		opts.MaxConcurrency = pull.concurrency
+	}
+	if timeout, err := time.ParseDuration(feedsCfg.Timeout); err == nil {
+		opts.FeedTimeout = timeout
+	}
+	if pull.timeout > 0 {
+		opts.FeedTimeout = pull.timeout
+	}
+
+	fmt.Printf("📡 Pulling feeds (%d at once, %s each)...\n", opts.MaxConcurrency, opts.FeedTimeout)
+	start := time.Now()
+	result, err := sourceMgr.Aggregate(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to pull feeds: %w", err)
+	}
+	fmt.Printf("   Feeds fetched: %d (%d not modified, %d failed) in %s\n", result.FeedsFetched, result.FeedsSkipped, result.FeedsFailed, time.Since(start).Round(100*time.Millisecond))
+	fmt.Printf("   New items:     %d\n", result.NewArticles)
+	printFeedPullErrors(result.Errors)
+	emitFeedErrorEvents(ctx, result.FailedFeeds)
+	queued := result.NewArticles
+	feedTitles := feedTitlesByID(ctx, db)
+	screenFeedItems(ctx, result.Items, feedTitles)
+	checkPriorityFeedItems(ctx, result.Items, feedTitles)
+
+	topics := followTopics(feedsCfg.Follow)
+	if !pull.noFollow && len(topics) > 0 {
+		added, err := pullFollowedTopics(ctx, sourceMgr, topics, since)
+		if err != nil {
+			fmt.Printf("⚠️  Followed topics skipped: %v\n", err)
+		}
+		queued += added
+	}
+
+	fmt.Printf("\n✅ Queued %d new item(s)\n", queued)
+	if queued > 0 {
+		fmt.Println("💡 Classify them with: briefly classify")
+	}
+	return nil
+}
+
+func runFeedItems(ctx context.Context, feedID string, limit int, priorityOnly bool) error {
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var items []core.FeedItem
+	if feedID != "" {
+		items, err = db.FeedItems().GetByFeedID(ctx, feedID, limit)
+	} else {
+		items, err = db.FeedItems().List(ctx, persistence.ListOptions{Limit: limit})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list feed items: %w", err)
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	feedTitles := feedTitlesByID(ctx, db)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Published\tFeed\tTitle\tPriority\n")
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━\t━━━━━━━━━━━━━━━━━━━━\t━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\t━━━━━━━━\n")
+
+	shown, flagged := 0, 0
+	for _, item := range items {
+		flag, err := cache.GetPriorityFlag(item.Link)
+		if err != nil {
+			return err
+		}
+		if flag == nil && priorityOnly {
+			continue
+		}
+
+		mark := ""
+		if flag != nil {
+			mark = "🚩 " + flag.Rule
+			if len(flag.Terms) > 0 {
+				mark += " (" + strings.Join(flag.Terms, ", ") + ")"
+			}
+			flagged++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			item.Published.Local().Format("2006-01-02 15:04"),
+			truncateQueueText(feedTitles[item.FeedID], 20),
+			truncateQueueText(item.Title, 40),
+			mark,
+		)
+		shown++
+	}
+	w.Flush()
+
+	if shown == 0 {
+		fmt.Println("\nNo items found")
+		return nil
+	}
+	fmt.Printf("\nShowing %d item(s), %d high priority\n", shown, flagged)
+	return nil
+}
+
+// emitFeedErrorEvents sends feed.error_threshold for each failed feed whose
+// consecutive failures just reached events.feed_error_threshold
+func emitFeedErrorEvents(ctx context.Context, failed []core.Feed) {
+	threshold := config.GetEvents().FeedErrorThreshold
+	for _, feed := range failed {
+		if !events.FeedCrossedThreshold(feed, threshold) {
+			continue
+		}
+		fmt.Printf("   ⚠️  %s has failed %d times in a row\n", feed.URL, feed.ErrorCount)
+		emitEvent(ctx, events.FeedErrorThreshold, events.FeedErrors{
+			FeedID:     feed.ID,
+			Title:      feed.Title,
+			URL:        feed.URL,
+			ErrorCount: feed.ErrorCount,
+			Threshold:  threshold,
+			LastError:  feed.LastError,
+		})
+	}
+}
+
+// checkPriorityFeedItems pushes pulled items that match a priority rule; a
+// misconfigured priority inbox is reported but doesn't fail the pull
+func checkPriorityFeedItems(ctx context.Context, items []core.FeedItem, feedTitles map[string]string) {
+	if len(items) == 0 {
+		return
+	}
+	inbox, err := openPriorityInbox()
+	if err != nil {
+		fmt.Printf("⚠️  Priority inbox skipped: %v\n", err)
+		return
+	}
+	if inbox == nil {
+		return
+	}
+	defer inbox.Close()
+
+	fmt.Printf("🚦 Checking %d item(s) against priority rules...\n", len(items))
+	if alerted := inbox.CheckFeedItems(ctx, items, feedTitles); alerted > 0 {
+		fmt.Printf("   Pushed %d priority alert(s)\n", alerted)
+	}
+}
+
+// feedTitlesByID maps feed IDs to titles, for naming an item's source
+func feedTitlesByID(ctx context.Context, db persistence.Database) map[string]string {
+	feedTitles := make(map[string]string)
+	if feeds, err := db.Feeds().List(ctx, persistence.ListOptions{Limit: 1000}); err == nil {
+		for _, feed := range feeds {
+			feedTitles[feed.ID] = feed.Title
+		}
+	}
+	return feedTitles
+}
+
+// pullFollowedTopics runs the standing search queries, each on its provider with
+// that provider's rate limit and daily quota, and returns how many items it queued
+func pullFollowedTopics(ctx context.Context, sourceMgr *sources.Manager, topics []config.FollowTopic, since time.Time) (int, error) {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return 0, err
+	}
+	defer cache.Close()
+
+	searchCfg := config.GetSearch()
+	fmt.Printf("\n🔭 Following %d topic(s)...\n", len(topics))
+
+	queued := 0
+	searchers := make(map[string]*search.Limited)
+	for _, topic := range topics {
+		provider := topic.Provider
+		if provider == "" {
+			provider = searchCfg.DefaultProvider
+		}
+		searcher, ok := searchers[provider]
+		if !ok {
+			searcher, err = newLimitedSearch(provider, cache, since)
+			if err != nil {
+				fmt.Printf("   ⚠️  %s: %v\n", topic.Query, err)
+				continue
+			}
+			searchers[provider] = searcher
+		}
+
+		maxResults := topic.MaxResults
+		if maxResults <= 0 {
+			maxResults = searchCfg.MaxResults
+		}
+		result, err := sourceMgr.PullFollowedTopics(ctx, searcher, []sources.FollowQuery{{Query: topic.Query, MaxResults: maxResults}})
+		if err != nil {
+			return queued, err
+		}
+		if len(result.Errors) > 0 && result.QueriesRun == 0 {
+			fmt.Printf("   ⚠️  %s: %v\n", topic.Query, result.Errors[0])
+			continue
+		}
+		fmt.Printf("   • %s (%s): %d new, %d already queued\n", topic.Query, provider, result.NewItems, result.Duplicates)
+		printPullErrors(result.Errors)
+		queued += result.NewItems
+	}
+	return queued, nil
+}
+
+// printFeedPullErrors reports the feeds that failed in a pull, timeouts first as
+// one group, then the first few other errors
+func printFeedPullErrors(errs []error) {
+	var timedOut, failed []error
+	for _, err := range errs {
+		if errors.Is(err, sources.ErrFeedTimeout) {
+			timedOut = append(timedOut, err)
+		} else {
+			failed = append(failed, err)
+		}
+	}
+	if len(timedOut) > 0 {
+		fmt.Printf("   ⏱️  %d feed(s) timed out:\n", len(timedOut))
+		for _, err := range timedOut {
+			fmt.Printf("      • %v\n", err)
+		}
+	}
+	printPullErrors(failed)
+}
+
+// printPullErrors lists the first few errors of a pull
+func printPullErrors(errs []error) {
+	for i, err := range errs {
+		if i >= 5 {
+			fmt.Printf("   ... and %d more errors\n", len(errs)-5)
+			break
+		}
+		fmt.Printf("   ⚠️  %v\n", err)
+	}
+}
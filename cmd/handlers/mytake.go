@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/parser"
+	"briefly/internal/store"
+	"briefly/internal/takes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewMyTakeCmd creates the my-take command for your commentary on articles and digests
+func NewMyTakeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "my-take",
+		Short: "Import your takes on articles and digests",
+		Long: `Draft your commentary in an editor and merge it into digests.
+
+A takes file maps article URLs or digest IDs to your take on them, as YAML or
+markdown. Imported takes are stored in the local cache: article takes appear under
+the article in the next digest that includes it, and in 'briefly regenerate'
+output; digest takes appear as the digest's "My Take" when it is regenerated.
+Importing a take again replaces it.
+
+YAML, as a list or as a mapping:
+  - url: https://example.com/pg18
+    take: Worth it for the benchmark section alone.
+  - digest: 1234abcd
+    take: A quieter week, but the eval results matter.
+
+Markdown, one heading per take:
+  ## [Postgres 18 ships async I/O](https://example.com/pg18)
+  Worth it for the benchmark section alone.
+
+  ## digest 1234abcd
+  A quieter week, but the eval results matter.
+
+Examples:
+  briefly my-take import takes.yaml
+  briefly my-take import takes.md --dry-run
+  briefly regenerate 1234abcd --format newsletter`,
+	}
+
+	cmd.AddCommand(newMyTakeImportCmd())
+
+	return cmd
+}
+
+func newMyTakeImportCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import takes from a YAML or markdown file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMyTakeImport(args[0], dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the takes found without saving them")
+
+	return cmd
+}
+
+func runMyTakeImport(path string, dryRun bool) error {
+	entries, err := takes.ParseFile(path)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Printf("📭 No takes in %s\n", path)
+		return nil
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	articles, digests := 0, 0
+	for _, entry := range entries {
+		take := store.MyTake{Kind: store.TakeArticle, Target: articleTakeKey(entry.URL), Take: entry.Text, Source: path}
+		label := take.Target
+		if entry.DigestID != "" {
+			take.Kind = store.TakeDigest
+			take.Target = entry.DigestID
+			label = "digest " + entry.DigestID
+			// A short ID of a cached digest is stored under its full ID
+			digest, err := cache.FindDigestByPartialID(entry.DigestID)
+			if err != nil {
+				return err
+			}
+			if digest != nil {
+				take.Target = digest.ID
+				label = fmt.Sprintf("digest %s (%s)", digest.ID, digest.Title)
+			} else {
+				label += " (not in the local cache; used when regenerating this ID)"
+			}
+		}
+		fmt.Printf("   💭 %s\n", label)
+
+		if dryRun {
+			continue
+		}
+		if err := cache.SaveMyTake(take); err != nil {
+			return err
+		}
+		if take.Kind == store.TakeDigest {
+			// Keep the issue history shown by 'briefly digest series' in step
+			if err := cache.UpdateDigestMyTake(take.Target, take.Take); err != nil {
+				return fmt.Errorf("failed to save take: %w", err)
+			}
+			digests++
+		} else {
+			articles++
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("\n🔍 Dry run: %d take(s) found in %s, nothing saved\n", len(entries), path)
+		return nil
+	}
+	fmt.Printf("\n✅ Imported %d article take(s) and %d digest take(s) from %s\n", articles, digests, path)
+	return nil
+}
+
+// articleTakeKey is the URL an article's take is stored under, without tracking
+// parameters so it matches however the link was shared
+func articleTakeKey(articleURL string) string {
+	return parser.NewParser().NormalizeURL(articleURL)
+}
+
+// applyArticleTakes sets each article's MyTake from its imported take, leaving
+// articles without one as they are. Lookup failures only skip the take.
+func applyArticleTakes(cache *store.Store, articles []core.Article) int {
+	applied := 0
+	for i := range articles {
+		take, err := cache.GetMyTake(store.TakeArticle, articleTakeKey(articles[i].URL))
+		if err != nil || take == "" {
+			continue
+		}
+		articles[i].MyTake = take
+		applied++
+	}
+	return applied
+}
+
+// digestTake returns the imported take on a digest, under its full ID or the ID
+// it was asked for by
+func digestTake(cache *store.Store, ids ...string) string {
+	for _, id := range ids {
+		if take, err := cache.GetMyTake(store.TakeDigest, id); err == nil && take != "" {
+			return take
+		}
+	}
+	return ""
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"briefly/internal/clustering"
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/email"
+	"briefly/internal/ops"
+	"briefly/internal/persistence"
+	"briefly/internal/render"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// opsTopicCount is how many top topics the ops digest lists
+const opsTopicCount = 8
+
+// NewOpsCmd creates the ops command
+func NewOpsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ops",
+		Short: "Pipeline health reports for maintainers",
+		Long: `Reports on how the pipeline itself is doing, for the people who run it.
+
+Subcommands:
+  digest - Weekly pipeline health report (cache, feeds, fetches, spend, topics)`,
+	}
+
+	cmd.AddCommand(newOpsDigestCmd())
+
+	return cmd
+}
+
+func newOpsDigestCmd() *cobra.Command {
+	var (
+		since  int
+		notify string
+		to     []string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Report pipeline health for the last week",
+		Long: `Build a short pipeline health report for the last --since days:
+
+  • Cache: size on disk and how many articles and summaries were added
+  • Feeds: active feeds whose last fetches failed
+  • Failed fetches: queued articles that couldn't be fetched, by domain
+  • LLM spend: cost and calls by phase, compared with the period before
+  • Top topics: keywords that set this period's articles apart
+
+Feed, fetch, spend, and topic sections need the database; without it the report
+says so and covers the cache only.
+
+The report is printed, and can be posted to a series' Slack/Discord webhooks
+(--notify), emailed through email.smtp (--email), or written to a file (--output).
+Run it weekly with the ops-digest schedule task (see 'briefly daemon --help').
+
+Examples:
+  briefly ops digest
+  briefly ops digest --notify platform-notes
+  briefly ops digest --email ops@example.com --email me@example.com
+  briefly ops digest --since 30 --output reports/ops.md`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOpsDigest(cmd, since, notify, to, output)
+		},
+	}
+
+	cmd.Flags().IntVarP(&since, "since", "s", 7, "Report on the last N days")
+	cmd.Flags().StringVar(&notify, "notify", "", "Post the report to this series' delivery webhooks")
+	cmd.Flags().StringSliceVar(&to, "email", nil, "Email the report to this address (repeatable; uses email.smtp)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Also write the report to this markdown file")
+
+	return cmd
+}
+
+func runOpsDigest(cmd *cobra.Command, since int, notify string, to []string, output string) error {
+	if since <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+	if _, err := config.Load(cfgFile); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Check delivery settings before gathering, so a misconfigured schedule fails fast
+	var outputDir, outputFormat string
+	ser, err := resolveDigestSeries(cmd, notify, &outputDir, &outputFormat)
+	if err != nil {
+		return err
+	}
+	if ser != nil && !ser.HasDelivery() {
+		return fmt.Errorf("series %q has no slack_webhook or discord_webhook configured", notify)
+	}
+	var sender email.Sender
+	if len(to) > 0 {
+		sender = emailSender()
+		if sender.Host == "" || sender.From == "" {
+			return fmt.Errorf("--email needs email.smtp.host and email.from_address in config")
+		}
+	}
+
+	ctx := cmd.Context()
+	until := time.Now()
+	report := &ops.Report{Since: until.AddDate(0, 0, -since), Until: until}
+
+	report.Cache = opsCacheHealth(report.Since)
+	if db, err := getDatabase(); err != nil {
+		report.DatabaseError = err.Error()
+	} else {
+		defer db.Close()
+		if err := gatherOpsFromDatabase(ctx, db, report, since); err != nil {
+			report.DatabaseError = err.Error()
+		}
+	}
+
+	content := render.Output(report.Markdown())
+	fmt.Println(content)
+
+	if output != "" {
+		if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Printf("💾 Saved to %s\n", output)
+	}
+
+	var failures []string
+	if ser != nil {
+		delivered, err := ser.Deliver(ctx, content)
+		if len(delivered) > 0 {
+			fmt.Printf("📤 Posted to %s\n", strings.Join(delivered, ", "))
+		}
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(to) > 0 {
+		if err := sender.Send(to, report.Title(), content); err != nil {
+			failures = append(failures, fmt.Sprintf("email: %v", err))
+		} else {
+			fmt.Printf("📧 Emailed to %s\n", strings.Join(to, ", "))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("ops digest delivery failed: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// opsCacheHealth reads the local cache's size and growth, or nil if it can't be opened
+func opsCacheHealth(since time.Time) *ops.CacheHealth {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return nil
+	}
+	defer cache.Close()
+
+	stats, err := cache.GetCacheStats()
+	if err != nil {
+		return nil
+	}
+	health := &ops.CacheHealth{SizeBytes: stats.CacheSize, Articles: stats.ArticleCount, Summaries: stats.SummaryCount}
+	if growth, err := cache.GetCacheGrowth(since); err == nil {
+		health.NewArticles = growth.Articles
+		health.NewSummaries = growth.Summaries
+	}
+	return health
+}
+
+// gatherOpsFromDatabase fills the report's feed, fetch, spend, and topic sections
+func gatherOpsFromDatabase(ctx context.Context, db persistence.Database, report *ops.Report, days int) error {
+	feeds, err := db.Feeds().List(ctx, persistence.ListOptions{Limit: 1000})
+	if err != nil {
+		return fmt.Errorf("failed to list feeds: %w", err)
+	}
+	report.FeedErrors = ops.FeedErrorsFrom(feeds)
+
+	failedItems, err := db.FeedItems().ListFailed(ctx, report.Since, 1000)
+	if err != nil {
+		return fmt.Errorf("failed to list failed fetches: %w", err)
+	}
+	var failures []ops.Failure
+	for _, item := range failedItems {
+		failures = append(failures, ops.Failure{URL: item.Link, Error: item.FetchError})
+	}
+	failedURLs, err := db.ManualURLs().GetByStatus(ctx, core.ManualURLStatusFailed, 1000)
+	if err != nil {
+		return fmt.Errorf("failed to list failed URLs: %w", err)
+	}
+	for _, manual := range failedURLs {
+		if manual.ProcessedAt != nil && !manual.ProcessedAt.Before(report.Since) {
+			failures = append(failures, ops.Failure{URL: manual.URL, Error: manual.ErrorMessage})
+		}
+	}
+	report.FailedDomains = ops.GroupFailures(failures)
+
+	calls, err := db.LLMCalls().ListSince(ctx, report.Since, report.Until)
+	if err != nil {
+		return fmt.Errorf("failed to list LLM calls: %w", err)
+	}
+	report.Spend = ops.SpendFrom(calls)
+	previous, err := db.LLMCalls().ListSince(ctx, report.Since.AddDate(0, 0, -days), report.Since)
+	if err != nil {
+		return fmt.Errorf("failed to list LLM calls: %w", err)
+	}
+	report.PreviousSpend = ops.SpendFrom(previous)
+
+	// Keywords that set this period apart from the three before it
+	articles, err := db.Articles().GetRecent(ctx, report.Since.AddDate(0, 0, -3*days), 5000)
+	if err != nil {
+		return fmt.Errorf("failed to list recent articles: %w", err)
+	}
+	var current []core.Article
+	for _, article := range articles {
+		if !article.DateFetched.Before(report.Since) {
+			current = append(current, article)
+		}
+	}
+	if len(current) > 0 {
+		report.TopTopics = clustering.ExtractKeywords(current, articles, nil, opsTopicCount)
+	}
+	return nil
+}
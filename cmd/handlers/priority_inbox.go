@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"briefly/internal/collect"
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/events"
+	"briefly/internal/fetch"
+	"briefly/internal/llm"
+	"briefly/internal/logger"
+	"briefly/internal/pipeline"
+	"briefly/internal/priority"
+	"briefly/internal/series"
+	"briefly/internal/store"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// priorityCaptureText caps how much of a captured page is checked against the rules
+const priorityCaptureText = 2000
+
+// priorityInbox pushes items that match a priority rule or the watchlist to the
+// priority series' channels as soon as they are pulled or captured
+type priorityInbox struct {
+	triage    *priority.Triage
+	series    *series.Series
+	cache     *store.Store
+	llmClient *llm.Client
+	pipe      *pipeline.Pipeline
+	processor *fetch.ContentProcessor
+}
+
+// openPriorityInbox returns the configured priority inbox, or nil when priority.enabled
+// is off or there are no rules or watchlist terms
+func openPriorityInbox() (*priorityInbox, error) {
+	if _, err := config.Load(cfgFile); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg := config.GetPriority()
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	watchlist := watchlistTerms(cfg.Watchlist)
+	if len(cfg.Rules) == 0 && len(watchlist) == 0 {
+		return nil, nil
+	}
+	if cfg.Series == "" {
+		return nil, fmt.Errorf("priority.series must name the series that receives alerts")
+	}
+	ser, _, err := loadSeries(cfg.Series)
+	if err != nil {
+		return nil, fmt.Errorf("priority inbox: %w", err)
+	}
+	if !ser.HasDelivery() {
+		return nil, fmt.Errorf("priority inbox: series %q has no slack_webhook or discord_webhook configured", cfg.Series)
+	}
+
+	llmClient, err := llm.NewClient("")
+	if err != nil {
+		return nil, fmt.Errorf("priority inbox: failed to initialize LLM client: %w", err)
+	}
+
+	model := cfg.Model
+	if model == "" {
+		model = config.Get().Title.Model
+	}
+	rules := make([]priority.Rule, len(cfg.Rules))
+	for i, rule := range cfg.Rules {
+		rules[i] = priority.Rule{Name: rule.Name, Keywords: rule.Keywords, Condition: rule.Condition}
+	}
+	triage, err := priority.New(rules, watchlist, priority.ModelJudge{LLM: llmClient, Model: model})
+	if err != nil {
+		llmClient.Close()
+		return nil, err
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		llmClient.Close()
+		return nil, err
+	}
+
+	cacheDir := config.GetCacheDirectory()
+	if cacheDir == "" {
+		cacheDir = ".briefly-cache"
+	}
+	pipe, err := pipeline.NewBuilder().
+		WithLLMClient(llmClient).
+		WithCacheDir(cacheDir).
+		Build()
+	if err != nil {
+		cache.Close()
+		llmClient.Close()
+		return nil, fmt.Errorf("priority inbox: failed to build pipeline: %w", err)
+	}
+
+	return &priorityInbox{
+		triage:    triage,
+		series:    ser,
+		cache:     cache,
+		llmClient: llmClient,
+		pipe:      pipe,
+		processor: fetch.NewContentProcessor(),
+	}, nil
+}
+
+// Close releases the inbox's cache and LLM client
+func (p *priorityInbox) Close() {
+	p.cache.Close()
+	p.llmClient.Close()
+}
+
+// CheckFeedItems triages freshly pulled feed items by title and description and
+// returns how many were pushed. feedTitles maps feed IDs to titles for the alerts.
+func (p *priorityInbox) CheckFeedItems(ctx context.Context, items []core.FeedItem, feedTitles map[string]string) int {
+	alerted := 0
+	for _, item := range items {
+		if p.alert(ctx, priority.Item{
+			URL:    item.Link,
+			Title:  item.Title,
+			Text:   item.Description,
+			Source: feedTitles[item.FeedID],
+		}) {
+			alerted++
+		}
+	}
+	return alerted
+}
+
+// CheckURL fetches a captured URL and triages its title and opening text along
+// with the capture's note. It reports whether an alert was pushed.
+func (p *priorityInbox) CheckURL(ctx context.Context, url, source, note string) bool {
+	if p.alreadyAlerted(url) {
+		return false
+	}
+	item := priority.Item{URL: url, Text: note, Source: source}
+	if article, err := p.processor.ProcessArticle(ctx, url); err != nil {
+		logger.Get().Warn("Priority triage could not fetch capture", "url", url, "error", err)
+	} else {
+		item.Title = article.Title
+		text := []rune(article.CleanedText)
+		if len(text) > priorityCaptureText {
+			text = text[:priorityCaptureText]
+		}
+		item.Text = strings.TrimSpace(note + "\n" + string(text))
+	}
+	return p.alert(ctx, item)
+}
+
+// alert checks item and, when it matches, summarizes it and pushes it once
+func (p *priorityInbox) alert(ctx context.Context, item priority.Item) bool {
+	log := logger.Get()
+	if item.URL == "" || p.alreadyAlerted(item.URL) {
+		return false
+	}
+
+	match, err := p.triage.Check(ctx, item)
+	if err != nil {
+		log.Warn("Priority rule check failed", "url", item.URL, "error", err)
+	}
+	if match == nil {
+		return false
+	}
+
+	// An alert without a summary still beats waiting for the digest
+	summary := ""
+	if result, err := p.pipe.QuickRead(ctx, pipeline.QuickReadOptions{URL: item.URL}); err != nil {
+		log.Warn("Priority alert summary failed", "url", item.URL, "error", err)
+	} else {
+		if match.Item.Title == "" {
+			match.Item.Title = result.Article.Title
+		}
+		summary = result.Summary.SummaryText
+	}
+
+	delivered, err := p.series.Deliver(ctx, match.Message(summary))
+	if len(delivered) == 0 {
+		log.Error("Priority alert delivery failed", "url", item.URL, "rule", match.Rule, "error", err)
+		return false
+	}
+	if err != nil {
+		log.Warn("Priority alert partly delivered", "url", item.URL, "delivered", delivered, "error", err)
+	}
+
+	if err := p.cache.RecordPriorityAlert(store.PriorityAlert{
+		URL:       item.URL,
+		Rule:      match.Rule,
+		Title:     match.Item.Title,
+		AlertedAt: time.Now(),
+	}); err != nil {
+		log.Warn("Failed to record priority alert", "url", item.URL, "error", err)
+	}
+	fmt.Printf("🚨 Priority (%s): %s → %s\n", match.Rule, item.URL, strings.Join(delivered, ", "))
+	emitEvent(ctx, events.AlertTriggered, events.Alert{
+		Rule:   match.Rule,
+		URL:    item.URL,
+		Title:  match.Item.Title,
+		Source: item.Source,
+		Terms:  match.Terms,
+	})
+	return true
+}
+
+func (p *priorityInbox) alreadyAlerted(url string) bool {
+	alerted, err := p.cache.HasPriorityAlert(url)
+	if err != nil {
+		logger.Get().Warn("Failed to check priority alerts", "url", url, "error", err)
+	}
+	return alerted
+}
+
+// screenFeedItems flags pulled feed items whose title or description mentions a
+// priority rule's keywords or a watchlist term. Nothing is fetched and no model is
+// asked, so every item is screened; flags show in 'briefly feed items'. With
+// priority.auto_include, flagged items are also added to this week's input file.
+func screenFeedItems(ctx context.Context, items []core.FeedItem, feedTitles map[string]string) {
+	if len(items) == 0 {
+		return
+	}
+	cfg := config.GetPriority()
+	watchlist := watchlistTerms(cfg.Watchlist)
+	if len(cfg.Rules) == 0 && len(watchlist) == 0 {
+		return
+	}
+	rules := make([]priority.Rule, len(cfg.Rules))
+	for i, rule := range cfg.Rules {
+		rules[i] = priority.Rule{Name: rule.Name, Keywords: rule.Keywords}
+	}
+	screen, err := priority.NewScreen(rules, watchlist)
+	if err != nil {
+		fmt.Printf("⚠️  Priority pre-screen skipped: %v\n", err)
+		return
+	}
+	cache, err := openSeriesCache()
+	if err != nil {
+		fmt.Printf("⚠️  Priority pre-screen skipped: %v\n", err)
+		return
+	}
+	defer cache.Close()
+
+	var flagged []string
+	for _, item := range items {
+		match, _ := screen.Check(ctx, priority.Item{
+			URL:    item.Link,
+			Title:  item.Title,
+			Text:   item.Description,
+			Source: feedTitles[item.FeedID],
+		})
+		if match == nil || item.Link == "" {
+			continue
+		}
+		if err := cache.FlagPriorityItem(store.PriorityFlag{URL: item.Link, Rule: match.Rule, Terms: match.Terms, FlaggedAt: time.Now()}); err != nil {
+			logger.Get().Warn("Failed to flag priority item", "url", item.Link, "error", err)
+			continue
+		}
+		if len(flagged) == 0 {
+			fmt.Println("🚩 High-priority items (keyword pre-screen):")
+		}
+		fmt.Printf("   🚩 [%s] %s (%s)\n", match.Rule, item.Title, strings.Join(match.Terms, ", "))
+		flagged = append(flagged, item.Link)
+	}
+
+	if len(flagged) == 0 || !cfg.AutoInclude {
+		return
+	}
+	collector, _, err := newCollector(collectOptions{})
+	if err != nil {
+		fmt.Printf("   ⚠️  Not added to the input file: %v\n", err)
+		return
+	}
+	added, err := collector.Add(strings.Join(flagged, "\n"), collect.SourcePriority)
+	if err != nil {
+		fmt.Printf("   ⚠️  Not added to the input file: %v\n", err)
+		return
+	}
+	if len(added) > 0 {
+		fmt.Printf("   ➕ Added %d to %s\n", len(added), collector.CurrentFile())
+	}
+}
+
+// NewPriorityCmd creates the priority command
+func NewPriorityCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "priority",
+		Short: "Priority inbox: urgent items pushed before the digest",
+		Long: `The priority inbox checks items as they arrive, during 'briefly feed pull',
+browser captures to 'briefly serve', and 'briefly collect', against the rules and
+watchlist under priority in .briefly.yaml. Anything that matches is summarized and
+pushed right away to the channels of the series named by priority.series, instead
+of waiting for the next digest. Each URL is pushed at most once.
+
+Before that, every pulled feed item's title and description is pre-screened against
+the rules' keywords and the watchlist, without fetching or asking the model.
+Matches are flagged in 'briefly feed items', and with priority.auto_include they
+are added to this week's input file (see 'briefly collect') for the next digest.
+
+Rules match on keywords first; a rule's condition is only put to the model for
+items that mention one of its keywords, so triage stays cheap.
+
+Topics that 'briefly quality trends --graduate' finds in several digests in a row
+join the watchlist, and are also searched during 'briefly feed pull'.
+
+Subcommands:
+  history   - List recent priority alerts
+  graduated - List or remove topics graduated from trends`,
+	}
+
+	cmd.AddCommand(newPriorityHistoryCmd())
+	cmd.AddCommand(newPriorityGraduatedCmd())
+
+	return cmd
+}
+
+func newPriorityHistoryCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List recent priority alerts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cache, err := openSeriesCache()
+			if err != nil {
+				return err
+			}
+			defer cache.Close()
+
+			alerts, err := cache.ListPriorityAlerts(limit)
+			if err != nil {
+				return err
+			}
+			if len(alerts) == 0 {
+				fmt.Println("No priority alerts yet")
+				return nil
+			}
+			for _, alert := range alerts {
+				title := alert.Title
+				if title == "" {
+					title = alert.URL
+				}
+				fmt.Printf("%s  [%s] %s\n", alert.AlertedAt.Local().Format("2006-01-02 15:04"), alert.Rule, title)
+				if title != alert.URL {
+					fmt.Printf("                  %s\n", alert.URL)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Number of alerts to show")
+
+	return cmd
+}
@@ -12,9 +12,10 @@ func NewQualityCmd() *cobra.Command {
 		Long: `Evaluate digest quality, track metrics over time, and analyze improvements.
 
 Subcommands:
-  audit     - Audit quality of recent digests (coverage, vagueness, specificity)
-  report    - Generate detailed quality report for a specific digest
-  trends    - Analyze quality trends over time
+  audit      - Audit quality of recent digests (coverage, vagueness, specificity)
+  report     - Generate detailed quality report for a specific digest
+  trends     - Analyze quality trends over time
+  embeddings - Compare clustering on summary vs. full-text embeddings
 
 Examples:
   # Audit last 10 digests
@@ -27,13 +28,17 @@ Examples:
   briefly quality report <digest-id>
 
   # Analyze quality trends
-  briefly quality trends --since 90`,
+  briefly quality trends --since 90
+
+  # Benchmark summary vs. full-text embeddings for clustering
+  briefly quality embeddings --since 7`,
 	}
 
 	// Add subcommands
 	cmd.AddCommand(NewQualityAuditCmd())
 	cmd.AddCommand(NewQualityReportCmd())
 	cmd.AddCommand(NewQualityTrendsCmd())
+	cmd.AddCommand(NewQualityEmbeddingsCmd())
 
 	return cmd
 }
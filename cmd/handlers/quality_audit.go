@@ -4,11 +4,21 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"briefly/internal/clustering"
+	"briefly/internal/config"
 	"briefly/internal/core"
+	"briefly/internal/delivery"
+	"briefly/internal/email"
 	"briefly/internal/persistence"
 	"briefly/internal/quality"
+	"briefly/internal/render"
+	"briefly/internal/series"
+	"briefly/internal/store"
+	"briefly/internal/trends"
 	"github.com/spf13/cobra"
 )
 
@@ -268,7 +278,7 @@ func qualityReportRun(cmd *cobra.Command, digestID string) {
 
 // NewQualityTrendsCmd creates the quality trends command
 func NewQualityTrendsCmd() *cobra.Command {
-	var since int
+	var opts qualityTrendsOptions
 
 	cmd := &cobra.Command{
 		Use:   "trends",
@@ -279,27 +289,103 @@ Shows:
   - Average quality scores by week/month
   - Grade distribution trends
   - Coverage and specificity trends
+  - Top topic keywords by week (TF-IDF)
+  - Rapidly emerging topics: keywords whose weekly mentions are accelerating
   - Regression detection
 
+With --notify, emerging-topic alerts are posted to the named series' Slack/Discord
+webhooks, so a weekly scheduled run flags a topic the week it starts spiking.
+
+Topics among the top keywords of the last 3 digests in a row (--graduate-after) are
+suggested for ongoing coverage. With --graduate they are added to the priority
+watchlist and run as standing search queries during 'briefly feed pull'; list or
+remove them with 'briefly priority graduated'.
+
+With --output, the report is also written as a digest-style markdown file, or as an
+HTML page with --format html (or an .html name); --email sends the HTML version.
+Every report is saved in the cache, and with trends.embed_in_newsletter the latest
+one is embedded in newsletter and email digests once a week.
+
 Examples:
   # Analyze last 90 days
   briefly quality trends --since 90
 
   # Analyze last 6 months
-  briefly quality trends --since 180`,
+  briefly quality trends --since 180
+
+  # Alert the weekly series' channels about emerging topics
+  briefly quality trends --since 28 --notify weekly
+
+  # Keep covering topics that made the last 3 digests
+  briefly quality trends --since 28 --graduate
+
+  # Save this week's report next to the digests
+  briefly quality trends --since 28 -o digests/trends.md`,
 		Run: func(cmd *cobra.Command, args []string) {
-			qualityTrendsRun(cmd, since)
+			qualityTrendsRun(cmd, opts)
 		},
 	}
 
-	cmd.Flags().IntVarP(&since, "since", "s", 90, "Analyze trends from last N days")
+	cmd.Flags().IntVarP(&opts.since, "since", "s", 90, "Analyze trends from last N days")
+	cmd.Flags().StringVar(&opts.notify, "notify", "", "Post emerging-topic alerts to this series' delivery webhooks")
+	cmd.Flags().IntVar(&opts.minMentions, "min-mentions", clustering.DefaultVelocityOptions.MinMentions, "Articles this week that must mention a keyword to flag it as emerging")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Also write the report to this file")
+	cmd.Flags().StringVar(&opts.format, "format", "", "Format of the --output file: markdown or html (default: from the file name)")
+	cmd.Flags().StringSliceVar(&opts.email, "email", nil, "Email the report to this address (repeatable; uses email.smtp)")
+	cmd.Flags().IntVar(&opts.graduateAfter, "graduate-after", trends.DefaultGraduateAfter, "Suggest watching topics among the top keywords of this many digests in a row (0 to disable)")
+	cmd.Flags().BoolVar(&opts.graduate, "graduate", false, "Add those topics to the priority watchlist and followed searches instead of suggesting them")
 
 	return cmd
 }
 
-func qualityTrendsRun(cmd *cobra.Command, since int) {
+// qualityTrendsOptions holds the quality trends flags
+type qualityTrendsOptions struct {
+	since       int
+	notify      string
+	minMentions int
+	output      string
+	format      string
+	email       []string
+
+	graduateAfter int
+	graduate      bool
+}
+
+func qualityTrendsRun(cmd *cobra.Command, opts qualityTrendsOptions) {
 	ctx := context.Background()
 
+	format, err := trendReportFormat(opts.output, opts.format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	var ser *series.Series
+	if opts.notify != "" {
+		var outputDir, outputFormat string
+		ser, err = resolveDigestSeries(cmd, opts.notify, &outputDir, &outputFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		if !ser.HasDelivery() {
+			fmt.Fprintf(os.Stderr, "❌ Series %q has no slack_webhook or discord_webhook configured\n", opts.notify)
+			os.Exit(1)
+		}
+	}
+	var sender email.Sender
+	if len(opts.email) > 0 {
+		if _, err := config.Load(cfgFile); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to load config: %v\n", err)
+			os.Exit(1)
+		}
+		sender = emailSender()
+		if sender.Host == "" || sender.From == "" {
+			fmt.Fprintf(os.Stderr, "❌ --email needs email.smtp.host and email.from_address in config\n")
+			os.Exit(1)
+		}
+	}
+
 	// Get database connection
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL == "" {
@@ -314,7 +400,12 @@ func qualityTrendsRun(cmd *cobra.Command, since int) {
 	}
 	defer db.Close()
 
-	sinceDate := time.Now().AddDate(0, 0, -since)
+	// Weeks start on Monday in the publication time zone, when the config loads
+	until := time.Now()
+	if _, err := config.Load(cfgFile); err == nil {
+		until = publicationNow()
+	}
+	sinceDate := until.AddDate(0, 0, -opts.since)
 
 	// Fetch digests
 	digests, err := db.Digests().ListRecent(ctx, sinceDate, 1000) // Get all in range
@@ -325,7 +416,7 @@ func qualityTrendsRun(cmd *cobra.Command, since int) {
 
 	if len(digests) < 2 {
 		fmt.Println("\n⚠️  Not enough digests for trend analysis (need at least 2)")
-		fmt.Printf("💡 Found: %d digests in last %d days\n", len(digests), since)
+		fmt.Printf("💡 Found: %d digests in last %d days\n", len(digests), opts.since)
 		return
 	}
 
@@ -342,62 +433,133 @@ func qualityTrendsRun(cmd *cobra.Command, since int) {
 	fmt.Printf("\r✓ Fetched articles for %d digests\n\n", len(articlesMap))
 
 	// Analyze trends
-	analyzeTrends(digests, articlesMap)
-}
-
-func analyzeTrends(digests []core.Digest, articlesMap map[string][]core.Article) {
-	evaluator := quality.NewDigestEvaluator()
-
-	// Group by week
-	type weekStats struct {
-		weekStart      time.Time
-		count          int
-		avgCoverage    float64
-		avgVagueness   float64
-		avgSpecificity float64
-		gradeACounts   int
-		gradeBCounts   int
-		gradeCCounts   int
-		gradeDCounts   int
-	}
-
-	weekMap := make(map[string]*weekStats)
-
-	for _, digest := range digests {
-		articles := articlesMap[digest.ID]
-		metrics := evaluator.EvaluateDigest(&digest, articles)
-
-		// Get week start (Monday)
-		weekStart := digest.ProcessedDate
-		for weekStart.Weekday() != time.Monday {
-			weekStart = weekStart.AddDate(0, 0, -1)
+	velocity := clustering.DefaultVelocityOptions
+	velocity.MinMentions = opts.minMentions
+	report := trends.Build(sinceDate, until, digests, articlesMap, velocity)
+	printTrendReport(report)
+	graduateTopics(digests, articlesMap, opts)
+
+	content := render.Output(report.Markdown())
+	saveTrendReport(report, content)
+
+	if opts.output != "" {
+		if err := writeTrendReport(opts.output, format, report.Title(), content); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
 		}
-		weekKey := weekStart.Format("2006-01-02")
+		fmt.Printf("💾 Saved to %s\n", opts.output)
+	}
+	if len(opts.email) > 0 {
+		if err := sender.SendHTML(opts.email, report.Title(), delivery.Page(report.Title(), content)); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Email: %v\n", err)
+		} else {
+			fmt.Printf("📧 Emailed to %s\n", strings.Join(opts.email, ", "))
+		}
+	}
 
-		if _, ok := weekMap[weekKey]; !ok {
-			weekMap[weekKey] = &weekStats{weekStart: weekStart}
+	if ser != nil && len(report.Emerging) > 0 {
+		delivered, err := ser.Deliver(ctx, formatEmergingTopicsAlert(report.Emerging))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Emerging-topic alert: %v\n", err)
 		}
+		if len(delivered) > 0 {
+			fmt.Printf("🔔 Emerging-topic alert sent to %s\n", strings.Join(delivered, ", "))
+		}
+	}
+}
 
-		ws := weekMap[weekKey]
-		ws.count++
-		ws.avgCoverage += metrics.CoveragePct
-		ws.avgVagueness += float64(metrics.VaguePhrases)
-		ws.avgSpecificity += float64(metrics.SpecificityScore)
-
-		grade := metrics.Grade[:1] // Extract letter only
-		switch grade {
-		case "A":
-			ws.gradeACounts++
-		case "B":
-			ws.gradeBCounts++
-		case "C":
-			ws.gradeCCounts++
-		case "D":
-			ws.gradeDCounts++
+// trendReportFormat returns the format of the --output file: format when given,
+// otherwise html for an .html name and markdown for anything else
+func trendReportFormat(output, format string) (string, error) {
+	format = strings.ToLower(format)
+	if format == "md" {
+		format = delivery.FormatMarkdown
+	}
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(output)) {
+		case ".html", ".htm":
+			format = delivery.FormatHTML
+		default:
+			format = delivery.FormatMarkdown
 		}
 	}
+	if format != delivery.FormatMarkdown && format != delivery.FormatHTML {
+		return "", fmt.Errorf("unknown report format %q (use markdown or html)", format)
+	}
+	return format, nil
+}
 
-	// Calculate averages and print
+// writeTrendReport writes the report's markdown, or an HTML page of it, to path
+func writeTrendReport(path, format, title, content string) error {
+	if format == delivery.FormatHTML {
+		content = delivery.Page(title, content)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// saveTrendReport keeps the report in the cache for newsletters to embed
+func saveTrendReport(report *trends.Report, content string) {
+	cache, err := openSeriesCache()
+	if err != nil {
+		fmt.Printf("   ⚠️  Trend report not cached: %v\n", err)
+		return
+	}
+	defer cache.Close()
+
+	saved := &store.TrendReport{
+		Title:    report.Title(),
+		Markdown: content,
+		Summary:  render.Output(report.Summary()),
+		Since:    report.Since,
+		Until:    report.Until,
+	}
+	if err := cache.SaveTrendReport(saved); err != nil {
+		fmt.Printf("   ⚠️  Trend report not cached: %v\n", err)
+	}
+}
+
+// weeklyTrendReport returns the cached trend report due to be embedded in a
+// newsletter, or nil when trends.embed_in_newsletter is off or a report was already
+// embedded this week
+func weeklyTrendReport() *store.TrendReport {
+	if !config.GetTrends().EmbedInNewsletter {
+		return nil
+	}
+	cache, err := openSeriesCache()
+	if err != nil {
+		return nil
+	}
+	defer cache.Close()
+
+	report, err := cache.TrendReportToEmbed(time.Now())
+	if err != nil {
+		fmt.Printf("   ⚠️  Trend report: %v\n", err)
+		return nil
+	}
+	return report
+}
+
+// markTrendReportEmbedded records that the report went out in a newsletter, so the
+// next one waits a week
+func markTrendReportEmbedded(report *store.TrendReport) {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return
+	}
+	defer cache.Close()
+	if err := cache.MarkTrendReportEmbedded(report.ID, time.Now()); err != nil {
+		fmt.Printf("   ⚠️  %v\n", err)
+	}
+}
+
+// printTrendReport prints weekly quality and topic trends
+func printTrendReport(report *trends.Report) {
 	fmt.Println("═══════════════════════════════════════════════════════════════════")
 	fmt.Println("QUALITY TRENDS (by week)")
 	fmt.Println("═══════════════════════════════════════════════════════════════════")
@@ -405,76 +567,77 @@ func analyzeTrends(digests []core.Digest, articlesMap map[string][]core.Article)
 		"Week", "Count", "Coverage", "Vagueness", "Specificity", "Grades (A/B/C/D)")
 	fmt.Println("───────────────────────────────────────────────────────────────────")
 
-	// Sort weeks chronologically
-	weeks := make([]string, 0, len(weekMap))
-	for weekKey := range weekMap {
-		weeks = append(weeks, weekKey)
-	}
-	// Simple bubble sort for small datasets
-	for i := 0; i < len(weeks); i++ {
-		for j := i + 1; j < len(weeks); j++ {
-			if weeks[i] > weeks[j] {
-				weeks[i], weeks[j] = weeks[j], weeks[i]
-			}
-		}
-	}
-
-	for _, weekKey := range weeks {
-		ws := weekMap[weekKey]
-		if ws.count == 0 {
-			continue
-		}
-
-		avgCoverage := ws.avgCoverage / float64(ws.count)
-		avgVagueness := ws.avgVagueness / float64(ws.count)
-		avgSpecificity := ws.avgSpecificity / float64(ws.count)
-
+	for _, week := range report.Weeks {
 		fmt.Printf("%-12s  %5d  %7.0f%%  %9.1f  %11.0f  %d/%d/%d/%d\n",
-			ws.weekStart.Format("Jan 02"),
-			ws.count,
-			avgCoverage*100,
-			avgVagueness,
-			avgSpecificity,
-			ws.gradeACounts, ws.gradeBCounts, ws.gradeCCounts, ws.gradeDCounts)
+			week.Start.Format("Jan 02"),
+			week.Digests,
+			week.Coverage*100,
+			week.Vagueness,
+			week.Specificity,
+			week.Grades[0], week.Grades[1], week.Grades[2], week.Grades[3])
 	}
 
 	fmt.Println("═══════════════════════════════════════════════════════════════════")
 
-	// Simple trend detection
-	if len(weeks) >= 4 {
-		// Compare first 2 weeks vs last 2 weeks
-		firstHalf := weeks[:len(weeks)/2]
-		secondHalf := weeks[len(weeks)/2:]
-
-		var firstCoverage, secondCoverage float64
-		var firstCount, secondCount int
+	printKeywordTrends(report.Weeks)
+	printEmergingTopics(report.Emerging)
+
+	if coverage := report.Coverage; coverage != nil {
+		fmt.Println("📈 TREND ANALYSIS")
+		fmt.Println("─────────────────────────────────────────────────────────────────")
+		switch coverage.Direction() {
+		case "improving":
+			fmt.Printf("🟢 Coverage improving: %.0f%% → %.0f%% (+%.1f%%)\n", coverage.Before, coverage.After, coverage.Change())
+		case "declining":
+			fmt.Printf("🔴 Coverage declining: %.0f%% → %.0f%% (%.1f%%)\n", coverage.Before, coverage.After, coverage.Change())
+		default:
+			fmt.Printf("🟡 Coverage stable: %.0f%% → %.0f%% (%.1f%%)\n", coverage.Before, coverage.After, coverage.Change())
+		}
+		fmt.Println("═══════════════════════════════════════════════════════════════════")
+	}
+}
 
-		for _, weekKey := range firstHalf {
-			ws := weekMap[weekKey]
-			firstCoverage += ws.avgCoverage
-			firstCount += ws.count
+// printKeywordTrends prints each week's distinctive keywords
+func printKeywordTrends(weeks []trends.Week) {
+	printed := false
+	for _, week := range weeks {
+		if len(week.Keywords) == 0 {
+			continue
 		}
-		for _, weekKey := range secondHalf {
-			ws := weekMap[weekKey]
-			secondCoverage += ws.avgCoverage
-			secondCount += ws.count
+		if !printed {
+			fmt.Println("🏷️  TOP KEYWORDS (by week)")
+			fmt.Println("─────────────────────────────────────────────────────────────────")
+			printed = true
 		}
+		fmt.Printf("%-12s  %s\n", week.Start.Format("Jan 02"), strings.Join(week.Keywords, ", "))
+	}
+	if printed {
+		fmt.Println()
+	}
+}
+
+// printEmergingTopics prints keywords whose weekly mentions are accelerating into
+// the latest week
+func printEmergingTopics(emerging []clustering.KeywordTrend) {
+	if len(emerging) == 0 {
+		return
+	}
 
-		if firstCount > 0 && secondCount > 0 {
-			firstAvg := (firstCoverage / float64(firstCount)) * 100
-			secondAvg := (secondCoverage / float64(secondCount)) * 100
-			change := secondAvg - firstAvg
+	fmt.Println("🚀 RAPIDLY EMERGING TOPICS (this week)")
+	fmt.Println("─────────────────────────────────────────────────────────────────")
+	for _, trend := range emerging {
+		fmt.Printf("%-28s  %s  (+%d, %.1fx)\n", trend.Keyword, trends.MentionCounts(trend.Counts), trend.Velocity, trend.Growth)
+	}
+	fmt.Println()
+}
 
-			fmt.Println("📈 TREND ANALYSIS")
-			fmt.Println("─────────────────────────────────────────────────────────────────")
-			if change > 5 {
-				fmt.Printf("🟢 Coverage improving: %.0f%% → %.0f%% (+%.1f%%)\n", firstAvg, secondAvg, change)
-			} else if change < -5 {
-				fmt.Printf("🔴 Coverage declining: %.0f%% → %.0f%% (%.1f%%)\n", firstAvg, secondAvg, change)
-			} else {
-				fmt.Printf("🟡 Coverage stable: %.0f%% → %.0f%% (%.1f%%)\n", firstAvg, secondAvg, change)
-			}
-			fmt.Println("═══════════════════════════════════════════════════════════════════")
-		}
+// formatEmergingTopicsAlert renders the emerging-topic alert posted to series webhooks
+func formatEmergingTopicsAlert(emerging []clustering.KeywordTrend) string {
+	var content strings.Builder
+	content.WriteString("🚀 *Rapidly emerging topics this week*\n")
+	for _, trend := range emerging {
+		content.WriteString(fmt.Sprintf("• *%s*: %d articles (weekly mentions %s, %.1fx the earlier average)\n",
+			trend.Keyword, trend.Counts[len(trend.Counts)-1], trends.MentionCounts(trend.Counts), trend.Growth))
 	}
+	return content.String()
 }
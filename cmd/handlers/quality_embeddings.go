@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"briefly/internal/clustering"
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"briefly/internal/quality"
+	"briefly/internal/replay"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewQualityEmbeddingsCmd creates the quality embeddings command, which benchmarks
+// clustering on summary embeddings against clustering on full-text embeddings
+func NewQualityEmbeddingsCmd() *cobra.Command {
+	var (
+		since int
+		limit int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "embeddings",
+		Short: "Compare clustering on summary vs. full-text embeddings",
+		Long: `Embed recent articles twice, once from their summaries and once from their
+full text, cluster each set the same way, and compare cost and quality.
+
+Reported for each source:
+  - Characters and estimated tokens sent to the embedding model, and time taken
+  - Silhouette and cohesion of the clusters, in the source's own embedding space
+    and in the other source's (a fairer comparison, since the spaces differ)
+  - Agreement: the share of article pairs both clusterings group the same way
+
+Only articles with a stored summary are used; nothing is summarized. Clustering uses
+K-means with a fixed seed, so the difference comes from the embeddings alone. Pick
+the source for digest runs with ai.gemini.embedding_source or
+'digest generate --embed-from'.
+
+Examples:
+  # Benchmark on the last week's articles
+  briefly quality embeddings
+
+  # A larger sample
+  briefly quality embeddings --since 30 --limit 100`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQualityEmbeddings(cmd.Context(), since, limit)
+		},
+	}
+
+	cmd.Flags().IntVarP(&since, "since", "s", 7, "Use articles from the last N days")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 50, "Maximum articles to embed (each is embedded twice)")
+
+	return cmd
+}
+
+func runQualityEmbeddings(ctx context.Context, since, limit int) error {
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	recent, err := db.Articles().GetRecent(ctx, time.Now().AddDate(0, 0, -since), limit)
+	if err != nil {
+		return fmt.Errorf("failed to load articles: %w", err)
+	}
+	stored := loadStoredSummaries(ctx, db, recent)
+	var articles []core.Article
+	var summaries []core.Summary
+	for _, article := range recent {
+		if summary, ok := stored[article.ID]; ok {
+			articles = append(articles, article)
+			summaries = append(summaries, summary)
+		}
+	}
+	if len(articles) < 3 {
+		return fmt.Errorf("need at least 3 summarized articles from the last %d days, found %d", since, len(articles))
+	}
+
+	client, err := llm.NewClient(config.GetAI().Gemini.Model)
+	if err != nil {
+		return fmt.Errorf("failed to create LLM client: %w", err)
+	}
+	defer client.Close()
+
+	fmt.Printf("📐 Benchmarking clustering on %d articles from the last %d days\n\n", len(articles), since)
+	runs := make([]quality.EmbeddingRun, 0, 2)
+	for _, source := range []clustering.EmbeddingSource{clustering.SourceSummary, clustering.SourceFullText} {
+		run, err := embeddingBenchmarkRun(ctx, client, articles, summaries, source)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run)
+	}
+	printEmbeddingBenchmark(runs[0], runs[1])
+	return nil
+}
+
+// embeddingBenchmarkRun embeds the articles from one source and clusters them
+func embeddingBenchmarkRun(ctx context.Context, client *llm.Client, articles []core.Article, summaries []core.Summary, source clustering.EmbeddingSource) (quality.EmbeddingRun, error) {
+	run := quality.EmbeddingRun{Source: string(source), Embeddings: make(map[string][]float64, len(articles))}
+	embedded := make([]core.Article, 0, len(articles))
+
+	fmt.Printf("🧠 Embedding from %s...\n", source)
+	start := time.Now()
+	for i, article := range articles {
+		text := clustering.EmbeddingText(article, summaries[i].SummaryText, source)
+		embedCtx := llm.WithAttribution(ctx, llm.Attribution{ArticleID: article.ID, Phase: "embedding"})
+		embedding, err := client.GenerateEmbeddingContext(embedCtx, text)
+		if err != nil {
+			fmt.Printf("   ⚠️  %s: %v\n", article.Title, err)
+			run.Failed++
+			continue
+		}
+		run.Chars += len(text)
+		run.Embeddings[article.ID] = embedding
+		article.Embedding = embedding
+		embedded = append(embedded, article)
+	}
+	run.Duration = time.Since(start)
+	if len(embedded) < 3 {
+		return run, fmt.Errorf("only %d articles could be embedded from %s", len(embedded), source)
+	}
+
+	clusterer := clustering.NewKMeansClusterer()
+	clusterer.Seed = replay.Seed
+	clusters, err := clusterer.Cluster(embedded, clustering.AutoClusterCount(len(embedded)))
+	if err != nil {
+		return run, fmt.Errorf("failed to cluster %s embeddings: %w", source, err)
+	}
+	run.Clusters = clusters
+	run.Coherence = quality.NewClusterCoherenceEvaluator().EvaluateClusterCoherence(clusters, run.Embeddings)
+	fmt.Printf("   ✓ %d embeddings in %s, %d clusters\n\n", len(embedded), run.Duration.Round(time.Millisecond), len(clusters))
+	return run, nil
+}
+
+func printEmbeddingBenchmark(summary, fullText quality.EmbeddingRun) {
+	evaluator := quality.NewClusterCoherenceEvaluator()
+	// Each clustering scored on the other's vectors, so both are judged in the same space
+	summaryCross := evaluator.EvaluateClusterCoherence(summary.Clusters, fullText.Embeddings)
+	fullTextCross := evaluator.EvaluateClusterCoherence(fullText.Clusters, summary.Embeddings)
+
+	row := func(label, a, b string) { fmt.Printf("   %-28s %12s %12s\n", label, a, b) }
+	row("", summary.Source, fullText.Source)
+	row("Embedded text (chars)", fmt.Sprintf("%d", summary.Chars), fmt.Sprintf("%d", fullText.Chars))
+	row("Estimated tokens", fmt.Sprintf("~%d", summary.EstimatedTokens()), fmt.Sprintf("~%d", fullText.EstimatedTokens()))
+	row("Embedding time", summary.Duration.Round(time.Millisecond).String(), fullText.Duration.Round(time.Millisecond).String())
+	if summary.Failed > 0 || fullText.Failed > 0 {
+		row("Failed", fmt.Sprintf("%d", summary.Failed), fmt.Sprintf("%d", fullText.Failed))
+	}
+	row("Clusters", fmt.Sprintf("%d", len(summary.Clusters)), fmt.Sprintf("%d", len(fullText.Clusters)))
+	row("Silhouette (own space)", fmt.Sprintf("%.2f", summary.Coherence.AvgSilhouette), fmt.Sprintf("%.2f", fullText.Coherence.AvgSilhouette))
+	row("Cohesion (own space)", fmt.Sprintf("%.2f", summary.Coherence.AvgIntraClusterSimilarity), fmt.Sprintf("%.2f", fullText.Coherence.AvgIntraClusterSimilarity))
+	row("Silhouette (full-text space)", fmt.Sprintf("%.2f", summaryCross.AvgSilhouette), fmt.Sprintf("%.2f", fullText.Coherence.AvgSilhouette))
+	row("Silhouette (summary space)", fmt.Sprintf("%.2f", summary.Coherence.AvgSilhouette), fmt.Sprintf("%.2f", fullTextCross.AvgSilhouette))
+
+	fmt.Printf("\n   Agreement: %.0f%% of article pairs are grouped the same way\n", 100*quality.RandIndex(summary.Clusters, fullText.Clusters))
+	if fullText.Chars > 0 {
+		fmt.Printf("   Summaries embed %.0f%% of the full-text tokens\n", 100*float64(summary.Chars)/float64(fullText.Chars))
+	}
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/persistence"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// NewQueueCmd creates the queue command for managing captured links
+func NewQueueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Manage the link queue (browser captures and added URLs)",
+		Long: `Manage the link queue that aggregation pulls from.
+
+Pages captured by a browser extension (POST /api/capture on 'briefly serve')
+land here with their highlighted text, note, and tags, next to URLs added
+with 'briefly manual-url add'.
+
+Subcommands:
+  list      Show queued items with notes and tags
+  clear     Remove items from the queue`,
+	}
+
+	cmd.AddCommand(newQueueListCmd())
+	cmd.AddCommand(newQueueClearCmd())
+
+	return cmd
+}
+
+func newQueueListCmd() *cobra.Command {
+	var (
+		status string
+		tag    string
+		limit  int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List queued items",
+		Long: `List items in the link queue. Pending items are shown by default.
+
+Examples:
+  briefly queue list
+  briefly queue list --tag ai
+  briefly queue list --status all --limit 100`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQueueList(cmd.Context(), status, tag, limit)
+		},
+	}
+
+	cmd.Flags().StringVarP(&status, "status", "s", core.ManualURLStatusPending, "Status to show (pending/processing/processed/failed/all)")
+	cmd.Flags().StringVarP(&tag, "tag", "t", "", "Only show items with this tag")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 50, "Maximum number of items to display")
+
+	return cmd
+}
+
+func newQueueClearCmd() *cobra.Command {
+	var status string
+
+	cmd := &cobra.Command{
+		Use:   "clear [id...]",
+		Short: "Remove items from the queue",
+		Long: `Remove queued items. With IDs, only those items are removed; otherwise every
+item with --status (default: pending) is removed.
+
+Examples:
+  briefly queue clear
+  briefly queue clear 3f2a9c1e-...
+  briefly queue clear --status all`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQueueClear(cmd.Context(), args, status)
+		},
+	}
+
+	cmd.Flags().StringVarP(&status, "status", "s", core.ManualURLStatusPending, "Status to clear when no IDs are given (pending/processed/failed/all)")
+
+	return cmd
+}
+
+func runQueueList(ctx context.Context, status, tag string, limit int) error {
+	if err := validateQueueStatus(status); err != nil {
+		return err
+	}
+
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var items []core.ManualURL
+	if status == "all" {
+		items, err = db.ManualURLs().List(ctx, persistence.ListOptions{Limit: limit})
+	} else {
+		items, err = db.ManualURLs().GetByStatus(ctx, status, limit)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list queue: %w", err)
+	}
+
+	if tag != "" {
+		items = filterQueueByTag(items, tag)
+	}
+
+	if len(items) == 0 {
+		fmt.Printf("📭 Queue is empty (status: %s)\n", status)
+		if tag != "" {
+			fmt.Printf("   (filtered by tag: %s)\n", tag)
+		}
+		return nil
+	}
+
+	fmt.Printf("📥 Link queue (%d item(s), status: %s)\n", len(items), status)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, item := range items {
+		fmt.Printf("\n%s %s\n", getStatusIcon(item.Status), item.URL)
+		fmt.Printf("   ID: %s · %s · %s\n", item.ID, item.SubmittedBy, item.CreatedAt.Format("2006-01-02 15:04"))
+		if len(item.Tags) > 0 {
+			fmt.Printf("   🏷️  %s\n", strings.Join(item.Tags, ", "))
+		}
+		if item.Note != "" {
+			fmt.Printf("   📝 %s\n", item.Note)
+		}
+		if item.Selection != "" {
+			fmt.Printf("   ❝ %s\n", truncateQueueText(item.Selection, 200))
+		}
+		if item.ErrorMessage != "" {
+			fmt.Printf("   ⚠️  %s\n", item.ErrorMessage)
+		}
+	}
+
+	return nil
+}
+
+func runQueueClear(ctx context.Context, ids []string, status string) error {
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if len(ids) > 0 {
+		removed := 0
+		for _, id := range ids {
+			if err := db.ManualURLs().Delete(ctx, id); err != nil {
+				fmt.Printf("⚠️  Failed to remove %s: %v\n", id, err)
+				continue
+			}
+			removed++
+		}
+		fmt.Printf("✅ Removed %d item(s)\n", removed)
+		return nil
+	}
+
+	if err := validateQueueStatus(status); err != nil {
+		return err
+	}
+	if status == core.ManualURLStatusProcessing {
+		return fmt.Errorf("items being processed cannot be cleared")
+	}
+
+	filter := status
+	if status == "all" {
+		filter = ""
+	}
+	removed, err := db.ManualURLs().DeleteByStatus(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to clear queue: %w", err)
+	}
+
+	fmt.Printf("✅ Cleared %d item(s) (status: %s)\n", removed, status)
+	return nil
+}
+
+// validateQueueStatus accepts the manual URL statuses plus "all"
+func validateQueueStatus(status string) error {
+	switch status {
+	case "all", core.ManualURLStatusPending, core.ManualURLStatusProcessing,
+		core.ManualURLStatusProcessed, core.ManualURLStatusFailed:
+		return nil
+	}
+	return fmt.Errorf("invalid status %q (use pending, processing, processed, failed, or all)", status)
+}
+
+// filterQueueByTag keeps items carrying tag (case-insensitive)
+func filterQueueByTag(items []core.ManualURL, tag string) []core.ManualURL {
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	var filtered []core.ManualURL
+	for _, item := range items {
+		for _, itemTag := range item.Tags {
+			if strings.EqualFold(itemTag, tag) {
+				filtered = append(filtered, item)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// truncateQueueText shortens long highlighted text for display
+func truncateQueueText(text string, maxRunes int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > maxRunes {
+		return string(runes[:maxRunes]) + "…"
+	}
+	return text
+}
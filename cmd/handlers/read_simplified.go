@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"briefly/internal/config"
 	"briefly/internal/llm"
 	"briefly/internal/logger"
+	"briefly/internal/parser"
 	"briefly/internal/pipeline"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -16,8 +20,9 @@ import (
 // NewReadSimplifiedCmd creates the new simplified read command
 func NewReadSimplifiedCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "read [url]",
-		Short: "Quick summary of a single article",
+		Use:     "read [url...]",
+		Aliases: []string{"summarize"},
+		Short:   "Quick summary of one or more articles",
 		Long: `Generate a quick summary of a single article for fast reading.
 
 This command provides:
@@ -28,39 +33,100 @@ This command provides:
 
 Perfect for quickly understanding an article without reading the full text.
 
+Several URLs (or --file, a markdown or plain-text list of links) are read
+concurrently and printed one after another in input order. Summaries are
+cached, so a digest built from the same links later reuses them.
+
 Examples:
   briefly read https://example.com/article
   briefly read --no-cache https://example.com/fresh-article
-  briefly read https://example.com/long-article`,
-		Args: cobra.ExactArgs(1),
-		Run:  readSimplifiedRun,
+  briefly read https://example.com/long-article
+
+  # Several articles at once
+  briefly summarize https://example.com/a https://example.com/b
+  briefly read --file input/weekly.md --concurrency 8
+
+  # Machine-readable output for scripts
+  briefly read --file links.txt --json | jq '.[].title'`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			file, _ := cmd.Flags().GetString("file")
+			if len(args) == 0 && file == "" {
+				return fmt.Errorf("requires at least one URL or --file")
+			}
+			return nil
+		},
+		Run: readSimplifiedRun,
 	}
 
 	// Flags
 	cmd.Flags().Bool("no-cache", false, "Disable caching (fetch fresh content)")
 	cmd.Flags().Bool("raw", false, "Output raw markdown without formatting")
+	cmd.Flags().StringP("file", "f", "", "Read every URL in a markdown or plain-text file")
+	cmd.Flags().Int("concurrency", 4, "Articles to read in parallel")
+	cmd.Flags().Bool("json", false, "Output results as a JSON array")
 
 	return cmd
 }
 
+// readResult is one article's outcome in a batch read
+type readResult struct {
+	url     string
+	result  *pipeline.QuickReadResult
+	err     error
+	elapsed time.Duration
+}
+
+// readResultJSON is the --json form of a read result
+type readResultJSON struct {
+	URL         string   `json:"url"`
+	Title       string   `json:"title,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	KeyPoints   []string `json:"key_points,omitempty"`
+	ContentType string   `json:"content_type,omitempty"`
+	ReadMinutes int      `json:"read_minutes,omitempty"`
+	Cached      bool     `json:"cached"`
+	Error       string   `json:"error,omitempty"`
+}
+
 func readSimplifiedRun(cmd *cobra.Command, args []string) {
 	startTime := time.Now()
 
 	// Get flags
-	url := args[0]
 	noCache, _ := cmd.Flags().GetBool("no-cache")
 	raw, _ := cmd.Flags().GetBool("raw")
+	file, _ := cmd.Flags().GetString("file")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	quiet := raw || jsonOutput
+
+	urls := append([]string(nil), args...)
+	if file != "" {
+		links, err := parser.NewParser().ParseMarkdownFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		for _, link := range links {
+			urls = append(urls, link.URL)
+		}
+	}
+	if len(urls) == 0 {
+		fmt.Fprintf(os.Stderr, "❌ No URLs found in %s\n", file)
+		os.Exit(1)
+	}
 
-	logger.Info("Starting quick read", "url", url, "no_cache", noCache)
+	logger.Info("Starting quick read", "urls", len(urls), "no_cache", noCache)
 
-	// Validate URL
-	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
-		fmt.Fprintf(os.Stderr, "❌ Invalid URL: must start with http:// or https://\n")
-		os.Exit(1)
+	// Validate URLs
+	for _, url := range urls {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			fmt.Fprintf(os.Stderr, "❌ Invalid URL %q: must start with http:// or https://\n", url)
+			os.Exit(1)
+		}
 	}
 
 	// Initialize LLM client
-	if !raw {
+	if !quiet {
 		fmt.Println("🔧 Initializing AI client...")
 	}
 
@@ -72,10 +138,15 @@ func readSimplifiedRun(cmd *cobra.Command, args []string) {
 	}
 	defer llmClient.Close()
 
-	// Build pipeline
+	// Build pipeline; the cache directory matches digest runs so they reuse these summaries
+	cacheDir := config.GetCache().Directory
+	if cacheDir == "" {
+		cacheDir = ".briefly-cache"
+	}
 	builder := pipeline.NewBuilder().
 		WithLLMClient(llmClient).
-		WithCacheDir(".briefly-cache")
+		WithCacheDir(cacheDir).
+		WithTwoPassSummaries(config.GetSummarize().TwoPass)
 
 	if noCache {
 		builder = builder.WithoutCache()
@@ -87,38 +158,139 @@ func readSimplifiedRun(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Execute quick read
 	ctx := context.Background()
-	opts := pipeline.QuickReadOptions{
-		URL: url,
+
+	// Single article: keep the original one-shot output
+	if len(urls) == 1 && !jsonOutput {
+		url := urls[0]
+		if !raw {
+			fmt.Printf("📖 Reading: %s\n\n", url)
+		}
+
+		result, err := pipe.QuickRead(ctx, pipeline.QuickReadOptions{URL: url})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\n❌ Failed to read article: %v\n", err)
+			os.Exit(1)
+		}
+
+		elapsed := time.Since(startTime)
+
+		// Display results
+		if raw {
+			// Raw markdown output
+			fmt.Println(result.Markdown)
+		} else {
+			// Formatted output
+			printQuickReadResult(result, elapsed)
+		}
+
+		// Log completion
+		logger.Info("Quick read completed",
+			"url", url,
+			"cached", result.WasCached,
+			"duration", elapsed)
+		return
 	}
 
-	if !raw {
-		fmt.Printf("📖 Reading: %s\n\n", url)
+	if !quiet {
+		fmt.Printf("📖 Reading %d articles (%d at a time)...\n\n", len(urls), concurrency)
 	}
 
-	result, err := pipe.QuickRead(ctx, opts)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "\n❌ Failed to read article: %v\n", err)
+	results := readBatch(ctx, pipe, urls, concurrency)
+
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+
+	switch {
+	case jsonOutput:
+		out := make([]readResultJSON, 0, len(results))
+		for _, r := range results {
+			out = append(out, toReadResultJSON(r))
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to encode results: %v\n", err)
+			os.Exit(1)
+		}
+	case raw:
+		for i, r := range results {
+			if i > 0 {
+				fmt.Println("\n---")
+			}
+			if r.err != nil {
+				fmt.Printf("<!-- %s: %v -->\n", r.url, r.err)
+				continue
+			}
+			fmt.Println(r.result.Markdown)
+		}
+	default:
+		for i, r := range results {
+			fmt.Printf("[%d/%d] ", i+1, len(results))
+			if r.err != nil {
+				fmt.Printf("❌ %s\n   %v\n\n", r.url, r.err)
+				continue
+			}
+			printQuickReadResult(r.result, r.elapsed)
+			fmt.Println()
+		}
+		fmt.Printf("✅ Read %d/%d articles in %v\n", len(results)-failed, len(results), time.Since(startTime).Round(time.Millisecond))
+	}
+
+	logger.Info("Batch read completed",
+		"urls", len(urls),
+		"failed", failed,
+		"duration", time.Since(startTime))
+
+	if failed == len(results) {
 		os.Exit(1)
 	}
+}
 
-	elapsed := time.Since(startTime)
+// readBatch reads urls with up to concurrency in flight, returning results in input order
+func readBatch(ctx context.Context, pipe *pipeline.Pipeline, urls []string, concurrency int) []readResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
 
-	// Display results
-	if raw {
-		// Raw markdown output
-		fmt.Println(result.Markdown)
-	} else {
-		// Formatted output
-		printQuickReadResult(result, elapsed)
+	results := make([]readResult, len(urls))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, url := range urls {
+		wg.Add(1)
+		sem <- struct{}{} // Acquire semaphore
+		go func(i int, url string) {
+			defer wg.Done()
+			defer func() { <-sem }() // Release semaphore
+
+			start := time.Now()
+			result, err := pipe.QuickRead(ctx, pipeline.QuickReadOptions{URL: url})
+			results[i] = readResult{url: url, result: result, err: err, elapsed: time.Since(start)}
+		}(i, url)
 	}
 
-	// Log completion
-	logger.Info("Quick read completed",
-		"url", url,
-		"cached", result.WasCached,
-		"duration", elapsed)
+	wg.Wait()
+	return results
+}
+
+func toReadResultJSON(r readResult) readResultJSON {
+	if r.err != nil {
+		return readResultJSON{URL: r.url, Error: r.err.Error()}
+	}
+	return readResultJSON{
+		URL:         r.url,
+		Title:       r.result.Article.Title,
+		Summary:     r.result.Summary.SummaryText,
+		KeyPoints:   extractKeyPointsFromSummary(r.result.Summary.SummaryText),
+		ContentType: string(r.result.Article.ContentType),
+		ReadMinutes: estimateReadTime(r.result.Article.CleanedText),
+		Cached:      r.result.WasCached,
+	}
 }
 
 func printQuickReadResult(result *pipeline.QuickReadResult, elapsed time.Duration) {
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/parser"
+	"briefly/internal/store"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// maxClickSyncLinks caps how many tracked links one read-status sync scans
+const maxClickSyncLinks = 10000
+
+func newCacheReadStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "read-status",
+		Short: "Track which articles you have already read",
+		Long: `Track which articles you have already read, so digests built with
+--exclude-read don't re-surface them.
+
+Articles are marked read when you open them with 'briefly cache open', when you
+mark them by hand, or when 'sync' imports clicks on tracked digest links.
+
+Examples:
+  briefly cache read-status mark https://example.com/post
+  briefly cache read-status sync --since 30
+  briefly cache read-status list
+  briefly digest --from-cache --since 2025-06-01 --exclude-read`,
+	}
+
+	cmd.AddCommand(newReadStatusMarkCmd())
+	cmd.AddCommand(newReadStatusUnmarkCmd())
+	cmd.AddCommand(newReadStatusListCmd())
+	cmd.AddCommand(newReadStatusSyncCmd())
+
+	return cmd
+}
+
+func newReadStatusMarkCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "mark <url>...",
+		Short: "Mark articles as read",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReadStatusMark(args, true)
+		},
+	}
+}
+
+func newReadStatusUnmarkCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unmark <url>...",
+		Short: "Mark articles as unread",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReadStatusMark(args, false)
+		},
+	}
+}
+
+func newReadStatusListCmd() *cobra.Command {
+	var sinceDays, limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List articles marked as read",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReadStatusList(sinceDays, limit)
+		},
+	}
+
+	cmd.Flags().IntVar(&sinceDays, "since", 30, "Show articles read in the last N days (0 = all)")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum articles to show (0 = all)")
+	return cmd
+}
+
+func newReadStatusSyncCmd() *cobra.Command {
+	var sinceDays int
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Mark articles read from clicks on tracked digest links",
+		Long: `Import clicks recorded by the link tracker (see link_tracking in config) and
+mark every clicked article as read. Requires the database.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReadStatusSync(cmd.Context(), sinceDays)
+		},
+	}
+
+	cmd.Flags().IntVar(&sinceDays, "since", 30, "Import clicks from the last N days")
+	return cmd
+}
+
+func runReadStatusMark(urls []string, read bool) error {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	urlParser := parser.NewParser()
+	changed := 0
+	for _, rawURL := range urls {
+		url := urlParser.NormalizeURL(rawURL)
+		var ok bool
+		if read {
+			ok, err = cache.MarkRead(url, store.ReadSourceManual, time.Now().UTC())
+		} else {
+			ok, err = cache.MarkUnread(url)
+		}
+		if err != nil {
+			return err
+		}
+		if ok {
+			changed++
+		}
+	}
+
+	if read {
+		fmt.Printf("✅ Marked %d article(s) as read (%d already read)\n", changed, len(urls)-changed)
+	} else {
+		fmt.Printf("✅ Marked %d article(s) as unread (%d were not marked read)\n", changed, len(urls)-changed)
+	}
+	return nil
+}
+
+func runReadStatusList(sinceDays int, limit int) error {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	var since time.Time
+	if sinceDays > 0 {
+		since = time.Now().UTC().AddDate(0, 0, -sinceDays)
+	}
+	marks, err := cache.ListReadMarks(since, limit)
+	if err != nil {
+		return err
+	}
+
+	if len(marks) == 0 {
+		fmt.Println("No articles marked as read")
+		return nil
+	}
+
+	fmt.Printf("📖 Read articles (%d)\n\n", len(marks))
+	fmt.Printf("%-17s %-7s %s\n", "READ", "SOURCE", "URL")
+	for _, mark := range marks {
+		fmt.Printf("%-17s %-7s %s\n", mark.ReadAt.Local().Format("2006-01-02 15:04"), mark.Source, mark.URL)
+	}
+	return nil
+}
+
+func runReadStatusSync(ctx context.Context, sinceDays int) error {
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	since := time.Now().UTC().AddDate(0, 0, -sinceDays)
+	links, err := db.Links().ClickStats(ctx, since, "", maxClickSyncLinks)
+	if err != nil {
+		return fmt.Errorf("failed to load link clicks: %w", err)
+	}
+
+	clicked, added := 0, 0
+	for _, link := range links {
+		if link.Clicks == 0 {
+			continue
+		}
+		clicked++
+		readAt := time.Now().UTC()
+		if link.LastClicked != nil {
+			readAt = *link.LastClicked
+		}
+		isNew, err := cache.MarkRead(link.URL, store.ReadSourceClick, readAt)
+		if err != nil {
+			return err
+		}
+		if isNew {
+			added++
+		}
+	}
+
+	fmt.Printf("✅ Synced %d clicked link(s) from the last %d days: %d newly marked read\n", clicked, sinceDays, added)
+	return nil
+}
+
+// markOpenedRead records an article opened from the cache as read. Failures are
+// reported but never block opening the article.
+func markOpenedRead(url string) {
+	cache, err := openSeriesCache()
+	if err != nil {
+		fmt.Printf("⚠️  Could not record read status: %v\n", err)
+		return
+	}
+	defer cache.Close()
+
+	if _, err := cache.MarkRead(url, store.ReadSourceOpened, time.Now().UTC()); err != nil {
+		fmt.Printf("⚠️  Could not record read status: %v\n", err)
+	}
+}
+
+// loadReadURLs returns the URLs marked read in cache for --exclude-read
+func loadReadURLs(cache *store.Store) (map[string]bool, error) {
+	if cache == nil {
+		return nil, fmt.Errorf("--exclude-read needs the cache (drop --no-cache)")
+	}
+	read, err := cache.ReadURLs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load read status: %w", err)
+	}
+	return read, nil
+}
+
+// excludeReadArticles drops articles whose URL is marked read, returning the rest
+// and how many were dropped
+func excludeReadArticles(articles []core.Article, read map[string]bool) ([]core.Article, int) {
+	unread := make([]core.Article, 0, len(articles))
+	for _, article := range articles {
+		if read[article.URL] || (article.LinkID != "" && read[article.LinkID]) {
+			continue
+		}
+		unread = append(unread, article)
+	}
+	return unread, len(articles) - len(unread)
+}
+
+// excludeReadLinks drops links whose URL is marked read, returning the rest and
+// how many were dropped
+func excludeReadLinks(links []core.Link, read map[string]bool) ([]core.Link, int) {
+	unread := make([]core.Link, 0, len(links))
+	for _, link := range links {
+		if read[link.URL] {
+			continue
+		}
+		unread = append(unread, link)
+	}
+	return unread, len(links) - len(unread)
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/parser"
+	"briefly/internal/readlater"
+	"briefly/internal/store"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewReadLaterCmd creates the readlater command for syncing with Readwise Reader and Raindrop.io
+func NewReadLaterCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "readlater",
+		Short: "Sync saved articles and summaries with Readwise Reader and Raindrop.io",
+		Long: `Keep a read-it-later app and Briefly in sync.
+
+'pull' adds the articles saved for later to this week's collect input file, so
+the next 'briefly digest from-file' covers them. 'push' writes the summary Briefly
+generated for each saved article back as its note: the bookmark note on
+Raindrop.io, the document summary on Readwise Reader. Briefly's part of a note is
+marked, so a later push replaces it and leaves anything you wrote alone.
+
+Services are configured under read_later in .briefly.yaml, or with the
+READWISE_TOKEN and RAINDROP_TOKEN environment variables. Every configured service
+is synced unless --service picks one.
+
+Examples:
+  # Add this week's saved articles to the input file, then digest it
+  briefly readlater pull
+  briefly digest from-file input/links-2025-06-02.md
+
+  # Push summaries back to Raindrop.io only, previewing first
+  briefly readlater push --service raindrop --dry-run
+  briefly readlater push --service raindrop`,
+	}
+
+	cmd.AddCommand(newReadLaterPullCmd())
+	cmd.AddCommand(newReadLaterPushCmd())
+
+	return cmd
+}
+
+func newReadLaterPullCmd() *cobra.Command {
+	var (
+		service string
+		since   int
+		limit   int
+		dir     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Add saved-for-later articles to this week's input file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			collector, _, err := newCollector(collectOptions{dir: dir})
+			if err != nil {
+				return err
+			}
+			services, err := readLaterServices(service)
+			if err != nil {
+				return err
+			}
+
+			after := time.Now().AddDate(0, 0, -since)
+			for _, svc := range services {
+				items, err := svc.Saved(cmd.Context(), after, limit)
+				if err != nil {
+					fmt.Printf("   ⚠️  %v\n", err)
+					continue
+				}
+				urls := make([]string, 0, len(items))
+				for _, item := range items {
+					urls = append(urls, item.URL)
+				}
+				added, err := collector.Add(strings.Join(urls, "\n"), svc.Name())
+				if err != nil {
+					return err
+				}
+				fmt.Printf("📥 %s: %d saved in the last %d days, %d new\n", svc.Name(), len(items), since, len(added))
+				for _, item := range added {
+					fmt.Printf("   ➕ %s\n", item.URL)
+				}
+			}
+
+			fmt.Printf("\n✅ Input file: %s\n", collector.CurrentFile())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&service, "service", "", "Only pull from this service (readwise or raindrop)")
+	cmd.Flags().IntVar(&since, "since", 7, "Pull articles saved in the last N days")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Pull at most N articles per service (0 = no limit)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory for weekly input files (default: collect.directory)")
+
+	return cmd
+}
+
+func newReadLaterPushCmd() *cobra.Command {
+	var (
+		service string
+		since   int
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "push",
+		Short: "Write Briefly's summaries back as notes on saved articles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cache, err := openSeriesCache()
+			if err != nil {
+				return err
+			}
+			defer cache.Close()
+			services, err := readLaterServices(service)
+			if err != nil {
+				return err
+			}
+
+			after := time.Now().AddDate(0, 0, -since)
+			for _, svc := range services {
+				items, err := svc.Saved(cmd.Context(), after, 0)
+				if err != nil {
+					fmt.Printf("   ⚠️  %v\n", err)
+					continue
+				}
+
+				pushed, unsummarized := 0, 0
+				for _, item := range items {
+					summary, err := readLaterSummary(cache, item.URL)
+					if err != nil {
+						return err
+					}
+					if summary == "" {
+						unsummarized++
+						continue
+					}
+					note := readlater.MergeNote(item.Note, summary)
+					if note == item.Note {
+						continue
+					}
+					if !dryRun {
+						if err := svc.SetNote(cmd.Context(), item, note); err != nil {
+							fmt.Printf("   ⚠️  %v\n", err)
+							continue
+						}
+					}
+					pushed++
+					fmt.Printf("   📝 %s\n", item.URL)
+				}
+
+				verb := "updated"
+				if dryRun {
+					verb = "would be updated"
+				}
+				fmt.Printf("📤 %s: %d note(s) %s, %d saved article(s) not summarized yet\n", svc.Name(), pushed, verb, unsummarized)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&service, "service", "", "Only push to this service (readwise or raindrop)")
+	cmd.Flags().IntVar(&since, "since", 30, "Push to articles saved in the last N days")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show which notes would change without writing them")
+
+	return cmd
+}
+
+// readLaterServices returns the configured read-it-later services, or only the
+// one named when name is set
+func readLaterServices(name string) ([]readlater.Service, error) {
+	if _, err := config.Load(cfgFile); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg := config.GetReadLater()
+
+	var services []readlater.Service
+	if cfg.Readwise.Token != "" {
+		services = append(services, &readlater.Readwise{Token: cfg.Readwise.Token, Location: cfg.Readwise.Location})
+	}
+	if cfg.Raindrop.Token != "" {
+		services = append(services, &readlater.Raindrop{Token: cfg.Raindrop.Token, Collection: cfg.Raindrop.Collection})
+	}
+
+	if name != "" {
+		for _, svc := range services {
+			if svc.Name() == name {
+				return []readlater.Service{svc}, nil
+			}
+		}
+		if name != "readwise" && name != "raindrop" {
+			return nil, fmt.Errorf("unknown service %q (use readwise or raindrop)", name)
+		}
+		return nil, fmt.Errorf("%s is not configured; set read_later.%s.token", name, name)
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("no read-it-later service configured; set read_later.readwise.token or read_later.raindrop.token")
+	}
+	return services, nil
+}
+
+// readLaterSummary returns the latest cached summary of a saved article, looking
+// it up by its URL as saved and with tracking parameters removed
+func readLaterSummary(cache *store.Store, articleURL string) (string, error) {
+	summary, err := cache.GetLatestSummaryText(articleURL)
+	if err != nil || summary != "" {
+		return summary, err
+	}
+	normalized := parser.NewParser().NormalizeURL(articleURL)
+	if normalized == articleURL {
+		return "", nil
+	}
+	return cache.GetLatestSummaryText(normalized)
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/llm"
+	"briefly/internal/redact"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// enableRedaction turns on the redaction stage for every LLM call when
+// redaction.enabled is set. The placeholder mapping is kept in the local cache,
+// which stays open for the life of the process.
+func enableRedaction() error {
+	cfg := config.GetRedaction()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	rules := make([]redact.Rule, 0, len(cfg.Patterns))
+	for _, pattern := range cfg.Patterns {
+		rule, err := redact.CompileRule(pattern.Name, pattern.Pattern)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 && len(cfg.Terms) == 0 {
+		return fmt.Errorf("redaction.enabled is set but no redaction.patterns or redaction.terms are configured")
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	redactor, err := redact.New(rules, cfg.Terms, cache)
+	if err != nil {
+		_ = cache.Close()
+		return err
+	}
+
+	llm.SetRedactor(redactor)
+	return nil
+}
+
+func newCacheRedactionsCmd() *cobra.Command {
+	var test string
+
+	cmd := &cobra.Command{
+		Use:   "redactions",
+		Short: "Show the redaction mapping kept in the cache",
+		Long: `List the placeholders the redaction stage has sent to LLMs in place of
+sensitive text, and the originals they stand for.
+
+With redaction.enabled set, matches of redaction.patterns and redaction.terms
+are replaced with placeholders such as REDACTED_HOST_1 before any content is
+sent to an LLM, and the originals are put back in what comes back. The mapping
+is kept here so the same value always gets the same placeholder. With a cache
+key set, the originals are stored encrypted.
+
+Examples:
+  # List the mapping
+  briefly cache redactions
+
+  # Preview what an LLM would see for some text
+  briefly cache redactions --test "deploy to billing.corp.example.com for Acme Corp"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheRedactions(test)
+		},
+	}
+
+	cmd.Flags().StringVar(&test, "test", "", "Print this text as it would be sent to an LLM")
+	return cmd
+}
+
+func runCacheRedactions(test string) error {
+	if test != "" {
+		redactor := llm.ActiveRedactor()
+		if redactor == nil {
+			return fmt.Errorf("redaction is not enabled (set redaction.enabled in .briefly.yaml)")
+		}
+		fmt.Println(redactor.Redact(test))
+		return redactor.Err()
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	mapping, err := cache.LoadRedactions()
+	if err != nil {
+		return err
+	}
+	if len(mapping) == 0 {
+		fmt.Println("No redacted values yet")
+		if !config.GetRedaction().Enabled {
+			fmt.Println("💡 Set redaction.enabled with redaction.patterns or redaction.terms in .briefly.yaml")
+		}
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(mapping))
+	for placeholder := range mapping {
+		placeholders = append(placeholders, placeholder)
+	}
+	sort.Strings(placeholders)
+
+	fmt.Printf("🔒 Redacted values (%d)\n\n", len(mapping))
+	for _, placeholder := range placeholders {
+		fmt.Printf("  %-24s %s\n", placeholder, mapping[placeholder])
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/delivery"
+	"briefly/internal/logger"
+	"briefly/internal/persistence"
+	"briefly/internal/render"
+	"briefly/internal/store"
+	"briefly/internal/templates"
+	"briefly/internal/textutil"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// regenerateFormats are the formats a stored digest can be rebuilt into
+var regenerateFormats = append([]string{"markdown"}, templates.GetAvailableFormats()...)
+
+// NewRegenerateCmd creates the regenerate command for re-rendering stored digests
+func NewRegenerateCmd() *cobra.Command {
+	var (
+		format     string
+		outputDir  string
+		emailStyle string
+		overrides  templates.Overrides
+	)
+
+	cmd := &cobra.Command{
+		Use:   "regenerate <digest-id>",
+		Short: "Rebuild a stored digest in another format",
+		Long: `Rebuild a previously generated digest in another output format.
+
+The digest is rebuilt from what the database already has: its articles, their
+summaries, and the topic clusters they were assigned to. Nothing is fetched or
+summarized again and no LLM calls are made, so one digest run can feed several
+channels.
+
+Formats: ` + strings.Join(regenerateFormats, ", ") + `
+
+A template format's sections can be switched on or off for one digest with
+--with and --without, and its word budget replaced with --max-words, without
+defining a custom template. Features: ` + strings.Join(templates.FeatureNames(), ", ") + `
+
+Newsletter, scannable, and email renders carry the publication's logo, footer
+links, disclaimer, and unsubscribe line from branding in .briefly.yaml.
+
+Examples:
+  # HTML email from a digest generated earlier
+  briefly regenerate abc123 --format email
+
+  # Newsletter-style email and a brief markdown version
+  briefly regenerate abc123 --format email --email-style newsletter
+  briefly regenerate abc123 --format brief --output digests/brief
+
+  # A scannable newsletter grouped by topic, without the game-changer section
+  briefly regenerate abc123 --format scannable --with topic-clustering --without game-changer --max-words 600`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDigestIDs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRegenerate(cmd.Context(), args[0], format, outputDir, emailStyle, overrides)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "markdown", "Output format: "+strings.Join(regenerateFormats, ", "))
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "digests", "Output directory")
+	cmd.Flags().StringVar(&emailStyle, "email-style", "default", "Email style for --format email: default, newsletter, minimal")
+	cmd.Flags().StringSliceVar(&overrides.With, "with", nil, "Template features to turn on (comma-separated or repeated)")
+	cmd.Flags().StringSliceVar(&overrides.Without, "without", nil, "Template features to turn off (comma-separated or repeated)")
+	cmd.Flags().IntVar(&overrides.MaxWords, "max-words", 0, "Word budget for the whole digest, replacing the format's")
+
+	return cmd
+}
+
+func runRegenerate(ctx context.Context, digestID, format, outputDir, emailStyle string, overrides templates.Overrides) error {
+	log := logger.Get()
+	format = strings.ToLower(format)
+	if format == "md" {
+		format = "markdown"
+	}
+	if !isRegenerateFormat(format) {
+		return fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(regenerateFormats, ", "))
+	}
+	// Markdown and email aren't rendered from a template, so there's nothing to tweak
+	if !overrides.IsZero() && (format == "markdown" || format == string(templates.FormatEmail)) {
+		return fmt.Errorf("--with, --without, and --max-words apply to template formats, not %s", format)
+	}
+	tmpl := templates.GetTemplate(templates.DigestFormat(format))
+	tmpl.Footnotes = config.GetOutput().Footnotes
+	if err := overrides.Apply(tmpl); err != nil {
+		return err
+	}
+
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	digest, err := db.Digests().GetFull(ctx, digestID)
+	if err != nil {
+		return fmt.Errorf("failed to get digest: %w", err)
+	}
+
+	// Cluster assignments are stored on the articles themselves
+	articles, err := db.Digests().GetDigestArticles(ctx, digest.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load digest articles: %w", err)
+	}
+	if len(articles) == 0 {
+		return fmt.Errorf("digest %s has no stored articles to rebuild from", digest.ID)
+	}
+	publishers := make(map[string]string, len(digest.Articles))
+	for _, article := range digest.Articles {
+		publishers[article.ID] = article.Publisher
+	}
+	for i := range articles {
+		articles[i].Publisher = publishers[articles[i].ID]
+	}
+
+	summaries := loadStoredSummaries(ctx, db, articles)
+	if missing := len(articles) - len(summaries); missing > 0 {
+		log.Warn("Some digest articles have no stored summary", "digest_id", digest.ID, "missing", missing)
+	}
+
+	title := digest.Title
+	if title == "" {
+		title = digest.Metadata.Title
+	}
+	fmt.Printf("♻️  Regenerating %q as %s\n", title, format)
+	fmt.Printf("   %d articles, %d cached summaries (no fetching or summarizing)\n", len(articles), len(summaries))
+
+	// Takes imported with 'briefly my-take import' are merged into the rebuild
+	if cache, err := openSeriesCache(); err != nil {
+		log.Warn("Failed to open cache, regenerating without imported takes", "error", err)
+	} else {
+		if applied := applyArticleTakes(cache, articles); applied > 0 {
+			fmt.Printf("   💭 %d article take(s)\n", applied)
+		}
+		if take := digestTake(cache, digest.ID, digestID); take != "" {
+			digest.MyTake = take
+			fmt.Println("   💭 Your take on the digest")
+		}
+		cache.Close()
+	}
+
+	digest.Articles = articles
+	digest.ArticleGroups = regroupByCluster(digest, articles)
+	digest.ArticleCount = len(articles)
+	digest.Metadata.ArticleCount = len(articles)
+	if digest.Metadata.DateGenerated.IsZero() {
+		digest.Metadata.DateGenerated = digest.ProcessedDate
+	}
+	fmt.Printf("   %d topic cluster(s) from stored assignments\n", len(digest.ArticleGroups))
+
+	summary := digest.Summary
+	if summary == "" {
+		summary = digest.DigestSummary
+	}
+
+	// Newsletters carry the week's trend report once a week
+	var trendReport *store.TrendReport
+	if format == string(templates.FormatNewsletter) || format == string(templates.FormatEmail) {
+		trendReport = weeklyTrendReport()
+	}
+
+	// Newsletters go out under the publication's own logo and footer
+	var branding *render.Branding
+	switch format {
+	case string(templates.FormatNewsletter), string(templates.FormatScannableNewsletter), string(templates.FormatEmail):
+		branding = publicationBranding()
+	}
+
+	var outputPath string
+	switch format {
+	case "markdown":
+		summaryList := make([]core.Summary, 0, len(summaries))
+		for _, article := range articles {
+			if s, ok := summaries[article.ID]; ok {
+				summaryList = append(summaryList, s)
+			}
+		}
+		digest.Summaries = summaryList
+		outputPath, err = saveDigestMarkdown(digest, outputDir)
+	case string(templates.FormatEmail):
+		var trendsSummary string
+		if trendReport != nil {
+			trendsSummary, _ = delivery.Render(trendReport.Summary, delivery.FormatMarkdown, delivery.FormatText)
+		}
+		_, outputPath, err = templates.RenderHTMLEmailWithBanner(regenerateItems(digest, summaries), outputDir, summary, title,
+			digest.OverallSentiment, "", trendsSummary, nil, emailStyle, digest.Banner, config.GetEmail().RemoteImages, branding)
+	case string(templates.FormatSignal):
+		_, outputPath, err = templates.RenderSignalStyleDigest(regenerateItems(digest, summaries), outputDir, summary,
+			tmpl, title)
+	default:
+		// The prompt corner is written by the LLM; a rebuild makes no LLM calls
+		tmpl.IncludePromptCorner = false
+		if trendReport != nil {
+			tmpl.TrendReport = trendReport.Summary
+		}
+		tmpl.Branding = branding
+		outputPath, err = templates.RenderWithTemplateAndMyTakeWithTitle(regenerateItems(digest, summaries), outputDir, summary, digest.MyTake, tmpl, title)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to render %s digest: %w", format, err)
+	}
+	if trendReport != nil {
+		markTrendReportEmbedded(trendReport)
+		fmt.Printf("   📈 Embedded %s\n", trendReport.Title)
+	}
+
+	fmt.Printf("\n✅ Regenerated digest\n")
+	fmt.Printf("   Format: %s\n", format)
+	fmt.Printf("   Output file: %s\n", outputPath)
+	return nil
+}
+
+// isRegenerateFormat reports whether a stored digest can be rebuilt into format
+func isRegenerateFormat(format string) bool {
+	for _, f := range regenerateFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// loadStoredSummaries returns the newest stored summary of each article, keyed by article ID
+func loadStoredSummaries(ctx context.Context, db persistence.Database, articles []core.Article) map[string]core.Summary {
+	summaries := make(map[string]core.Summary, len(articles))
+	for _, article := range articles {
+		stored, err := db.Summaries().GetByArticleID(ctx, article.ID)
+		if err != nil || len(stored) == 0 {
+			continue
+		}
+		newest := stored[0]
+		for _, s := range stored[1:] {
+			if s.DateGenerated.After(newest.DateGenerated) {
+				newest = s
+			}
+		}
+		summaries[article.ID] = newest
+	}
+	return summaries
+}
+
+// regroupByCluster groups articles by their stored topic cluster, in citation order,
+// keeping the narratives of the digest's saved groups where a cluster matches one
+func regroupByCluster(digest *core.Digest, articles []core.Article) []core.ArticleGroup {
+	saved := make(map[string]core.ArticleGroup, len(digest.ArticleGroups))
+	for _, group := range digest.ArticleGroups {
+		saved[strings.ToLower(group.Theme)] = group
+	}
+
+	var groups []core.ArticleGroup
+	index := make(map[string]int)
+	for _, article := range articles {
+		label := article.TopicCluster
+		if label == "" {
+			label = digest.Title
+		}
+		i, ok := index[label]
+		if !ok {
+			i = len(groups)
+			index[label] = i
+			groups = append(groups, core.ArticleGroup{Theme: label, Category: label})
+		}
+		groups[i].Articles = append(groups[i].Articles, article)
+	}
+
+	for i, group := range groups {
+		match, ok := saved[strings.ToLower(group.Theme)]
+		if !ok && len(groups) == 1 && len(digest.ArticleGroups) == 1 {
+			match, ok = digest.ArticleGroups[0], true
+		}
+		if !ok {
+			continue
+		}
+		if match.Theme != "" {
+			groups[i].Theme = match.Theme
+		}
+		groups[i].Summary = match.Summary
+		groups[i].ClusterNarrative = match.ClusterNarrative
+		groups[i].RelatedResearch = match.RelatedResearch
+	}
+	if len(groups) == 1 && groups[0].Summary == "" {
+		groups[0].Summary = digest.TLDRSummary
+	}
+	return groups
+}
+
+// regenerateItems flattens the digest's groups into template items, in group order,
+// with quotes in summaries held to each publisher's limit
+func regenerateItems(digest *core.Digest, summaries map[string]core.Summary) []render.DigestData {
+	var items []render.DigestData
+	quota := licensingPolicies().NewQuota()
+	for _, group := range digest.ArticleGroups {
+		for _, article := range group.Articles {
+			summaryText := summaries[article.ID].SummaryText
+			if summaryText == "" {
+				summaryText = textutil.FirstSentence(article.CleanedText)
+			}
+			summaryText = quota.Limit(article.URL, summaryText)
+			items = append(items, render.DigestData{
+				Title:           article.Title,
+				URL:             article.URL,
+				SummaryText:     summaryText,
+				TopicCluster:    group.Theme,
+				TopicConfidence: article.ClusterConfidence,
+				ContentType:     string(article.ContentType),
+				SignalStrength:  article.SignalStrength,
+				Figures:         article.Images,
+				Author:          article.Author,
+				SiteName:        article.SiteName,
+				HeroImage:       article.HeroImage,
+				DatePublished:   article.DatePublished,
+				MyTake:          article.MyTake,
+			})
+		}
+	}
+	return items
+}
+
+// publicationBranding returns the branding from config, or nil when none is set
+func publicationBranding() *render.Branding {
+	cfg := config.GetBranding()
+	branding := &render.Branding{
+		Name:            cfg.Name,
+		LogoURL:         cfg.LogoURL,
+		LogoLink:        cfg.LogoLink,
+		UnsubscribeText: cfg.UnsubscribeText,
+		UnsubscribeURL:  cfg.UnsubscribeURL,
+		Disclaimer:      cfg.Disclaimer,
+	}
+	for _, link := range cfg.FooterLinks {
+		if link.URL == "" {
+			continue
+		}
+		label := link.Label
+		if label == "" {
+			label = link.URL
+		}
+		branding.FooterLinks = append(branding.FooterLinks, render.Link{Label: label, URL: link.URL})
+	}
+	if branding.UnsubscribeText == "" && branding.UnsubscribeURL != "" {
+		branding.UnsubscribeText = "Unsubscribe"
+	}
+	if !branding.HasHeader() && !branding.HasFooter() {
+		return nil
+	}
+	return branding
+}
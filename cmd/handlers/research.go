@@ -0,0 +1,800 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/export"
+	"briefly/internal/fetch"
+	"briefly/internal/llm"
+	"briefly/internal/research"
+	"briefly/internal/search"
+	"briefly/internal/store"
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// maxBriefEmbeddingText caps the brief text embedded for matching, like article embeddings
+const maxBriefEmbeddingText = 2000
+
+// NewResearchCmd creates the research command for deep-research briefs
+func NewResearchCmd() *cobra.Command {
+	var (
+		outputDir    string
+		maxQueries   int
+		provider     string
+		maxResults   int
+		maxPerDomain int
+		papers       bool
+		noFetch      bool
+		exportList   string
+		interactive  bool
+		templateName string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "research <topic>",
+		Aliases: []string{"deep-research"},
+		Short:   "Write a deep-research brief on a topic",
+		Long: `Research a topic in depth and write a cited brief.
+
+The topic is broken into sub-queries, each answered with a search-grounded model
+(the default), web search providers, or academic indexes, and the findings are
+synthesized into a markdown brief with numbered sources.
+
+For technical topics, --papers adds arXiv and Semantic Scholar, so the brief
+cites papers with their authors, year, venue, and citation count instead of
+leaning on blog posts. --provider takes a comma list to mix any providers.
+
+Sources found by more than one sub-query are cited once, and no more than
+--max-per-domain sources come from one domain, so one site can't dominate a
+brief. The brief reports its source mix: news, docs, blogs, and papers.
+
+Search providers are rate limited and count their requests against a daily
+quota kept in the cache (search.providers.<name>.daily_quota). Before a run,
+the plan is checked against what is left today: results per query are cut back
+when the quota is low, and queries are dropped when it can't cover them all.
+
+Each source's page is read before synthesis, so the brief draws on more than
+search snippets. Pages are stored in the article cache (pages cached in the last
+research.source_max_age are reused), so a later digest that links one of them
+reads it from the cache instead of fetching it again. Pass --no-fetch to skip this.
+
+Briefs are kept in the cache. When a later digest has a section on the same
+topic, it links the brief as further reading with a short refresher
+(see research.related_threshold in config).
+
+With --interactive, sub-query progress can be steered from the terminal while
+the run searches: type "skip" to abandon the running sub-query (or "skip 3" for a
+queued one), "add <query>" to queue your own, "list" to see the queue, or "stop"
+(or Ctrl-C) to stop searching and synthesize the brief from what was gathered.
+
+For readers who won't open markdown, --export docx,pdf also writes the brief as
+a Word document and a PDF next to it: a cover page, citations as footnotes, and
+the full source list. 'research export' does the same for a stored brief.
+
+Recurring briefs can be saved as templates under research.templates.<name> in
+config: a topic pattern, a since-window, query and source limits, providers,
+focus areas for the sub-queries, the brief's section headings, and export
+formats. --template <name> runs one, so every vendor evaluation (say) covers
+the same ground under the same headings. Flags given explicitly override the
+template. 'research templates' lists them.
+
+Examples:
+  briefly research "vector database benchmarks"
+  briefly research "WebGPU adoption" --queries 8 --output research
+  briefly research "Temporal.io" --provider serpapi --max-results 10
+  briefly research "retrieval-augmented generation evaluation" --papers
+  briefly research "HNSW index tuning" --provider arxiv,semanticscholar
+  briefly research "EU AI Act obligations" --export docx,pdf
+  briefly research "CRDT sync engines" --interactive
+  briefly research --template vendor-eval "Temporal.io"
+  briefly research templates
+  briefly research export 3f2a9c1b --format pdf
+  briefly research quota
+  briefly research list`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tmpl, err := resolveResearchTemplate(cmd, templateName, &maxQueries, &provider, &exportList)
+			if err != nil {
+				return err
+			}
+			if papers {
+				provider = withPaperProviders(provider)
+			}
+			formats, err := parseExportFormats(exportList)
+			if err != nil {
+				return err
+			}
+			return runResearch(cmd.Context(), strings.Join(args, " "), outputDir, maxQueries, provider, maxResults, cmd.Flags().Changed("max-per-domain"), maxPerDomain, !noFetch, formats, interactive, tmpl)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "research", "Output directory for research briefs")
+	cmd.Flags().IntVar(&maxQueries, "queries", 0, "Number of sub-queries to research (default: research.max_queries)")
+	cmd.Flags().StringVar(&provider, "provider", "", "Comma list of gemini (grounding), google, serpapi, duckduckgo, arxiv, semanticscholar (default: research.provider)")
+	cmd.Flags().BoolVar(&papers, "papers", false, "Also search arXiv and Semantic Scholar for papers")
+	cmd.Flags().IntVar(&maxResults, "max-results", 0, "Results per sub-query from a search provider (default: search.max_results)")
+	cmd.Flags().IntVar(&maxPerDomain, "max-per-domain", 0, "Max sources from one domain, 0 for no cap (default: research.max_per_domain)")
+	cmd.Flags().BoolVar(&noFetch, "no-fetch", false, "Cite search snippets only, without reading source pages into the article cache")
+	cmd.Flags().StringVar(&exportList, "export", "", "Also export the brief: comma list of docx, pdf")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Steer the run from the terminal: skip or add sub-queries, or stop early")
+	cmd.Flags().StringVarP(&templateName, "template", "t", "", "Run a saved research template (see 'research templates')")
+
+	cmd.AddCommand(newResearchListCmd())
+	cmd.AddCommand(newResearchQuotaCmd())
+	cmd.AddCommand(newResearchDeleteCmd())
+	cmd.AddCommand(newResearchExportCmd())
+	cmd.AddCommand(newResearchTemplatesCmd())
+
+	return cmd
+}
+
+func newResearchListCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored research briefs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResearchList(limit)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum briefs to show (0 = all)")
+	return cmd
+}
+
+func newResearchDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a stored research brief so digests stop linking it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResearchDelete(args[0])
+		},
+	}
+}
+
+func newResearchExportCmd() *cobra.Command {
+	var (
+		format     string
+		outputPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export <id>",
+		Short: "Export a stored research brief to DOCX or PDF",
+		Long: `Export a stored research brief as a Word document or PDF with a cover page,
+citations as footnotes, and the full source list.
+
+The file is written next to the brief's markdown unless --output is given.
+
+Examples:
+  briefly research export 3f2a9c1b
+  briefly research export 3f2a9c1b --format docx -o ~/Desktop/brief.docx`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResearchExport(args[0], format, outputPath)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "pdf", "Export format (docx, pdf)")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file (default: next to the brief's markdown)")
+	return cmd
+}
+
+func newResearchTemplatesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "templates",
+		Short: "List saved research templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResearchTemplates()
+		},
+	}
+}
+
+func newResearchQuotaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "quota",
+		Short: "Show today's search provider usage against quotas",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResearchQuota()
+		},
+	}
+}
+
+func runResearch(ctx context.Context, topic string, outputDir string, maxQueries int, provider string, maxResults int, maxPerDomainSet bool, maxPerDomain int, fetchSources bool, exportFormats []string, interactive bool, tmpl *research.Template) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	if maxQueries <= 0 {
+		maxQueries = config.GetResearch().MaxQueries
+	}
+	if provider == "" {
+		provider = config.GetResearch().Provider
+	} else if strings.HasPrefix(provider, ",") {
+		provider = config.GetResearch().Provider + provider // --papers on top of the configured provider
+	}
+	if maxResults <= 0 {
+		maxResults = config.GetSearch().MaxResults
+	}
+	if !maxPerDomainSet {
+		maxPerDomain = config.GetResearch().MaxPerDomain
+	}
+
+	var since time.Time
+	if tmpl != nil {
+		since = tmpl.Since(time.Now())
+		fmt.Printf("📋 Template: %s\n", tmpl.Name)
+	}
+
+	// Fit the run into each provider's remaining quota before spending anything. The
+	// run makes as many queries as the tightest provider allows.
+	grounding := false
+	var providers []*search.Limited
+	var providerResults []int
+	for _, name := range parseProviderList(provider) {
+		if name == "gemini" {
+			grounding = true
+			continue
+		}
+		limited, err := newLimitedSearch(name, cache, since)
+		if err != nil {
+			return err
+		}
+		plan, err := limited.Plan(maxQueries, maxResults)
+		if err != nil {
+			return err
+		}
+		for _, warning := range plan.Warnings {
+			fmt.Printf("⚠️  %s\n", warning)
+		}
+		if plan.Remaining >= 0 {
+			fmt.Printf("🔎 %s quota: %d request(s) left today, this run needs up to %d\n",
+				name, plan.Remaining, plan.Queries*limited.CallsFor(plan.MaxResults))
+		}
+		maxQueries = min(maxQueries, plan.Queries)
+		providers = append(providers, limited)
+		providerResults = append(providerResults, plan.MaxResults)
+	}
+
+	llmClient, err := llm.NewClient(config.GetAI().Gemini.Model)
+	if err != nil {
+		return fmt.Errorf("failed to create LLM client: %w", err)
+	}
+	defer llmClient.Close()
+
+	startTime := time.Now()
+	if tmpl != nil {
+		topic = tmpl.Topic(topic, startTime)
+	}
+	fmt.Printf("🔬 Researching: %s\n\n", topic)
+
+	researcher := research.NewResearcher(llmClient, llmClient)
+	researcher.SetMaxQueries(maxQueries)
+	researcher.SetMaxPerDomain(maxPerDomain)
+	researcher.SetGrounding(grounding)
+	for i, limited := range providers {
+		researcher.AddProvider(limited, providerResults[i])
+	}
+	if tmpl != nil {
+		tmpl.Apply(researcher, startTime)
+	}
+	if fetchSources && config.GetResearch().FetchSources {
+		maxAge, _ := time.ParseDuration(config.GetResearch().SourceMaxAge) // Validated with config
+		researcher.SetSourceCache(fetch.NewContentProcessor(), cache, maxAge)
+	}
+	if interactive {
+		if isTerminal(os.Stdin) {
+			stopSteering := steerResearch(researcher)
+			defer stopSteering()
+		} else {
+			fmt.Printf("⚠️  --interactive needs a terminal on stdin; running unattended\n")
+		}
+	}
+
+	report, err := researcher.Run(ctx, topic)
+	if err != nil {
+		return err
+	}
+
+	outputPath, err := research.SaveBrief(report, outputDir)
+	if err != nil {
+		return err
+	}
+
+	brief := &store.ResearchBrief{
+		ID:          report.ID,
+		Topic:       report.Query,
+		Summary:     report.Summary,
+		Path:        outputPath,
+		SourceCount: len(report.Results),
+		Report:      report,
+		CreatedAt:   report.DateGenerated,
+	}
+	if brief.Embedding, err = llmClient.GenerateEmbeddingContext(ctx, briefEmbeddingText(report)); err != nil {
+		fmt.Printf("⚠️  Could not embed the brief; digests won't link it: %v\n", err)
+	}
+	if err := cache.SaveResearchBrief(brief); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✅ Research brief written\n")
+	fmt.Printf("   Topic: %s\n", report.Query)
+	fmt.Printf("   Queries: %d\n", len(report.GeneratedQueries))
+	fmt.Printf("   Sources: %d\n", len(report.Results))
+	if mix := research.FormatSourceMix(report.SourceMix); mix != "" {
+		fmt.Printf("   Source mix: %s\n", mix)
+	}
+	fmt.Printf("   Output file: %s\n", outputPath)
+	for _, format := range exportFormats {
+		exportPath := briefExportPath(outputPath, format)
+		if err := exportBrief(report, format, exportPath); err != nil {
+			fmt.Printf("⚠️  Failed to export %s: %v\n", strings.ToUpper(format), err)
+			continue
+		}
+		fmt.Printf("   %s: %s\n", strings.ToUpper(format), exportPath)
+	}
+	fmt.Printf("   Duration: %s\n", time.Since(startTime).Round(time.Millisecond))
+	return nil
+}
+
+func runResearchExport(id, format, outputPath string) error {
+	formats, err := parseExportFormats(format)
+	if err != nil {
+		return err
+	}
+	if len(formats) != 1 {
+		return fmt.Errorf("--format takes one of docx, pdf")
+	}
+
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	fullID, err := resolveBriefID(cache, id)
+	if err != nil {
+		return err
+	}
+	brief, err := cache.GetResearchBrief(fullID)
+	if err != nil {
+		return err
+	}
+	if brief == nil {
+		return fmt.Errorf("no research brief with ID %s", id)
+	}
+
+	report := brief.Report
+	if report == nil {
+		// Briefs stored before reports were kept have no sources to footnote
+		fmt.Printf("⚠️  Brief %s was stored without its sources; citations will export as plain text\n", fullID[:8])
+		report = &core.ResearchReport{ID: brief.ID, Query: brief.Topic, Summary: brief.Summary, DateGenerated: brief.CreatedAt}
+	}
+
+	if outputPath == "" {
+		base := brief.Path
+		if base == "" {
+			base = filepath.Join("research", "research-"+fullID[:8]+".md")
+		}
+		outputPath = briefExportPath(base, formats[0])
+	}
+	if err := exportBrief(report, formats[0], outputPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Exported research brief %s\n", fullID[:8])
+	fmt.Printf("   Topic: %s\n", report.Query)
+	fmt.Printf("   Output file: %s\n", outputPath)
+	return nil
+}
+
+// exportBrief writes a research report to path as DOCX or PDF
+func exportBrief(report *core.ResearchReport, format, path string) error {
+	doc := export.NewDocumentFromBrief(report)
+	switch format {
+	case "docx":
+		return export.SaveDOCX(doc, path)
+	case "pdf":
+		return export.SavePDF(doc, path)
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// briefExportPath swaps the extension of a brief's markdown path for the export format
+func briefExportPath(markdownPath, format string) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + "." + format
+}
+
+// parseExportFormats splits a comma list of export formats, rejecting unknown ones
+func parseExportFormats(list string) ([]string, error) {
+	var formats []string
+	seen := make(map[string]bool)
+	for _, format := range strings.Split(list, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "" || seen[format] {
+			continue
+		}
+		if format != "docx" && format != "pdf" {
+			return nil, fmt.Errorf("unsupported export format %q (use docx or pdf)", format)
+		}
+		seen[format] = true
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+// steerResearch feeds commands typed on stdin to the researcher, and turns the first
+// Ctrl-C into a stop so the run still writes a brief. It returns a function that
+// restores the default Ctrl-C handling.
+func steerResearch(researcher *research.Researcher) func() {
+	commands := make(chan research.Command, 8)
+	researcher.SetCommands(commands)
+	fmt.Printf("🎛  Interactive: type %s and press Enter\n", research.SteeringHelp)
+
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+			command, err := research.ParseCommand(scanner.Text())
+			if err != nil {
+				fmt.Printf("           ⚠ %v\n", err)
+				continue
+			}
+			commands <- command
+		}
+	}()
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	go func() {
+		if _, ok := <-interrupts; ok {
+			signal.Stop(interrupts) // A second Ctrl-C quits
+			fmt.Printf("\n           (Ctrl-C again to quit without a brief)\n")
+			commands <- research.Command{Kind: research.CommandStop}
+		}
+	}()
+
+	return func() {
+		signal.Stop(interrupts)
+		close(interrupts)
+	}
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func runResearchQuota() error {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	providers := config.GetSearch().Providers
+	quotas := []struct {
+		name  string
+		quota int
+	}{
+		{search.ProviderGoogle, providers.Google.DailyQuota},
+		{search.ProviderSerpAPI, providers.SerpAPI.DailyQuota},
+		{search.ProviderDuckDuckGo, 0},
+		{search.ProviderArXiv, 0},
+		{search.ProviderSemanticScholar, providers.SemanticScholar.DailyQuota},
+	}
+
+	fmt.Printf("🔎 Search usage today (UTC)\n\n")
+	fmt.Printf("%-15s %6s %6s %9s\n", "PROVIDER", "USED", "QUOTA", "REMAINING")
+	for _, q := range quotas {
+		used, err := cache.SearchUsage(q.name, time.Now())
+		if err != nil {
+			return err
+		}
+		if q.quota <= 0 {
+			fmt.Printf("%-15s %6d %6s %9s\n", q.name, used, "-", "unlimited")
+			continue
+		}
+		fmt.Printf("%-15s %6d %6d %9d\n", q.name, used, q.quota, max(q.quota-used, 0))
+	}
+	return nil
+}
+
+func runResearchList(limit int) error {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	briefs, err := cache.ListResearchBriefs(limit)
+	if err != nil {
+		return err
+	}
+	if len(briefs) == 0 {
+		fmt.Println("No research briefs stored. Write one with: briefly research <topic>")
+		return nil
+	}
+
+	fmt.Printf("🔬 Research briefs (%d)\n\n", len(briefs))
+	fmt.Printf("%-8s %-10s %-7s %s\n", "ID", "DATE", "SOURCES", "TOPIC")
+	for _, brief := range briefs {
+		fmt.Printf("%-8s %-10s %-7d %s\n", brief.ID[:8], brief.CreatedAt.Local().Format("2006-01-02"), brief.SourceCount, brief.Topic)
+		if brief.Path != "" {
+			fmt.Printf("%-8s %-10s %-7s %s\n", "", "", "", brief.Path)
+		}
+	}
+	return nil
+}
+
+func runResearchDelete(id string) error {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+
+	fullID, err := resolveBriefID(cache, id)
+	if err != nil {
+		return err
+	}
+	if _, err := cache.DeleteResearchBrief(fullID); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Deleted research brief %s\n", fullID[:8])
+	return nil
+}
+
+// resolveBriefID expands a brief ID prefix, as shown by 'research list', to the full ID
+func resolveBriefID(cache *store.Store, prefix string) (string, error) {
+	briefs, err := cache.ListResearchBriefs(0)
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, brief := range briefs {
+		if strings.HasPrefix(brief.ID, prefix) {
+			matches = append(matches, brief.ID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no research brief with ID %s", prefix)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("research brief ID %s is ambiguous (%d matches)", prefix, len(matches))
+	}
+}
+
+// newLimitedSearch creates the named search provider from config, wrapped with its
+// rate limit and daily quota. Providers that can filter by date only return results
+// published since then (zero = any time).
+func newLimitedSearch(name string, cache *store.Store, since time.Time) (*search.Limited, error) {
+	cfg := config.GetSearch()
+	opts := search.Options{Language: cfg.Language, Since: since}
+	if timeout, err := time.ParseDuration(cfg.Timeout); err == nil {
+		opts.Timeout = timeout
+	}
+
+	var limits search.Limits
+	var rateLimit string
+	switch name {
+	case search.ProviderGoogle:
+		opts.APIKey, opts.SearchID = cfg.Providers.Google.APIKey, cfg.Providers.Google.SearchID
+		rateLimit, limits.DailyQuota = cfg.Providers.Google.RateLimit, cfg.Providers.Google.DailyQuota
+	case search.ProviderSerpAPI:
+		opts.APIKey = cfg.Providers.SerpAPI.APIKey
+		rateLimit, limits.DailyQuota = cfg.Providers.SerpAPI.RateLimit, cfg.Providers.SerpAPI.DailyQuota
+	case search.ProviderDuckDuckGo:
+		rateLimit = cfg.Providers.DuckDuckGo.RateLimit
+	case search.ProviderArXiv:
+		rateLimit = cfg.Providers.ArXiv.RateLimit
+	case search.ProviderSemanticScholar:
+		opts.APIKey = cfg.Providers.SemanticScholar.APIKey
+		rateLimit, limits.DailyQuota = cfg.Providers.SemanticScholar.RateLimit, cfg.Providers.SemanticScholar.DailyQuota
+	}
+	if rateLimit != "" {
+		interval, err := time.ParseDuration(rateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate_limit for %s: %w", name, err)
+		}
+		limits.Interval = interval
+	}
+
+	provider, err := search.NewProvider(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return search.NewLimited(provider, limits, cache), nil
+}
+
+// resolveResearchTemplate loads a configured research template and applies its query
+// count, providers, and export formats unless those flags were given explicitly
+func resolveResearchTemplate(cmd *cobra.Command, name string, maxQueries *int, provider, exportList *string) (*research.Template, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	if _, err := config.Load(cfgFile); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	tmplCfg, ok := config.GetResearchTemplate(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown research template %q (configured: %s)", name, strings.Join(configuredResearchTemplates(), ", "))
+	}
+
+	tmpl, err := research.NewTemplate(name, research.TemplateOptions{
+		Description: tmplCfg.Description,
+		Topic:       tmplCfg.Topic,
+		SinceDays:   tmplCfg.SinceDays,
+		MaxQueries:  tmplCfg.Queries,
+		MaxSources:  tmplCfg.MaxSources,
+		Providers:   tmplCfg.Provider,
+		Focus:       tmplCfg.Focus,
+		Sections:    tmplCfg.Sections,
+		Export:      tmplCfg.Export,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !cmd.Flags().Changed("queries") && tmpl.MaxQueries > 0 {
+		*maxQueries = tmpl.MaxQueries
+	}
+	if !cmd.Flags().Changed("provider") && tmpl.Providers != "" {
+		*provider = tmpl.Providers
+	}
+	if !cmd.Flags().Changed("export") && tmpl.Export != "" {
+		*exportList = tmpl.Export
+	}
+	return tmpl, nil
+}
+
+// configuredResearchTemplates returns the configured research template names in order
+func configuredResearchTemplates() []string {
+	names := make([]string, 0, len(config.GetResearch().Templates))
+	for name := range config.GetResearch().Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return []string{"none"}
+	}
+	return names
+}
+
+func runResearchTemplates() error {
+	if _, err := config.Load(cfgFile); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(config.GetResearch().Templates) == 0 {
+		fmt.Println("📭 No research templates configured")
+		fmt.Println("💡 Add one under research.templates.<name> in .briefly.yaml (see .briefly.yaml.example)")
+		return nil
+	}
+
+	names := configuredResearchTemplates()
+	fmt.Printf("📋 Research templates (%d)\n", len(names))
+	for _, name := range names {
+		tmplCfg, _ := config.GetResearchTemplate(name)
+		fmt.Printf("\n   %s", name)
+		if tmplCfg.Description != "" {
+			fmt.Printf(" — %s", tmplCfg.Description)
+		}
+		fmt.Println()
+
+		var details []string
+		if tmplCfg.Topic != "" {
+			details = append(details, fmt.Sprintf("Topic: %s", tmplCfg.Topic))
+		}
+		if tmplCfg.SinceDays > 0 {
+			details = append(details, fmt.Sprintf("Last %d days", tmplCfg.SinceDays))
+		}
+		if tmplCfg.MaxSources > 0 {
+			details = append(details, fmt.Sprintf("Max %d sources", tmplCfg.MaxSources))
+		}
+		if tmplCfg.Provider != "" {
+			details = append(details, fmt.Sprintf("Providers: %s", tmplCfg.Provider))
+		}
+		if tmplCfg.Export != "" {
+			details = append(details, fmt.Sprintf("Export: %s", tmplCfg.Export))
+		}
+		if len(details) > 0 {
+			fmt.Printf("      %s\n", strings.Join(details, " · "))
+		}
+		if len(tmplCfg.Sections) > 0 {
+			fmt.Printf("      Sections: %s\n", strings.Join(tmplCfg.Sections, ", "))
+		}
+	}
+	return nil
+}
+
+// parseProviderList splits a comma list of research providers, dropping blanks and
+// repeats. An empty list searches with grounding.
+func parseProviderList(list string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return []string{"gemini"}
+	}
+	return names
+}
+
+// withPaperProviders adds the academic providers to a --provider list. An empty list
+// becomes ",arxiv,semanticscholar" so they are added to the configured provider.
+func withPaperProviders(list string) string {
+	return list + "," + search.ProviderArXiv + "," + search.ProviderSemanticScholar
+}
+
+// briefEmbeddingText is the text embedded to match a brief against digest clusters
+func briefEmbeddingText(report *core.ResearchReport) string {
+	text := report.Query + "\n\n" + report.Summary
+	if len(text) > maxBriefEmbeddingText {
+		text = text[:maxBriefEmbeddingText]
+	}
+	return text
+}
+
+// linkResearchBriefs matches digest clusters to stored research briefs, keyed by
+// cluster index. Failures are reported but never block the digest.
+func linkResearchBriefs(cache *store.Store, clusters []core.TopicCluster) map[int]*core.RelatedResearch {
+	if cache == nil {
+		return nil
+	}
+
+	briefs, err := cache.ListResearchBriefs(0)
+	if err != nil {
+		fmt.Printf("   ⚠️  Could not load research briefs: %v\n", err)
+		return nil
+	}
+	if len(briefs) == 0 {
+		return nil
+	}
+
+	threshold := config.GetResearch().RelatedThreshold
+	if threshold == 0 {
+		threshold = research.DefaultRelatedThreshold
+	}
+
+	matches := research.RelatedBriefs(clusters, briefs, threshold)
+	for i, related := range matches {
+		fmt.Printf("   📚 %s → research brief \"%s\" (similarity %.2f)\n", clusters[i].Label, related.Topic, related.Similarity)
+	}
+	return matches
+}
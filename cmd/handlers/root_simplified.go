@@ -2,14 +2,23 @@ package handlers
 
 import (
 	"briefly/internal/config"
+	"briefly/internal/fetch"
+	"briefly/internal/render"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var cfgFile string // Configuration file path
 
+var plainOutput bool // --plain: strip emoji and typographic unicode from all output
+
+// Version is the running briefly version; release builds set it with
+// -ldflags "-X briefly/cmd/handlers.Version=<version>"
+var Version = "3.1.0-hierarchical-summarization"
+
 // NewSimplifiedRootCmd creates the new simplified root command
 // This replaces the complex root.go with a clean, focused interface
 func NewSimplifiedRootCmd() *cobra.Command {
@@ -53,25 +62,46 @@ Examples:
 
   # Check cache statistics
   briefly cache stats`,
-		Version: "3.1.0-hierarchical-summarization",
+		Version: Version,
 	}
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .briefly.yaml)")
+	rootCmd.PersistentFlags().BoolVar(&plainOutput, "plain", false, "plain rendering: no emoji, ASCII separators (default is output.plain)")
 
 	// Add subcommands
+	rootCmd.AddCommand(NewInitCmd())           // NEW: First-run setup wizard
 	rootCmd.AddCommand(NewMigrateCmd())        // NEW: Database migrations
 	rootCmd.AddCommand(NewAggregateCmd())      // NEW: News aggregation
 	rootCmd.AddCommand(NewClassifyCmd())       // NEW: Article classification (Phase 1)
 	rootCmd.AddCommand(NewFeedCmd())           // NEW: Feed management
 	rootCmd.AddCommand(NewThemeCmd())          // NEW: Theme management (Phase 0)
 	rootCmd.AddCommand(NewManualURLCmd())      // NEW: Manual URL management (Phase 0)
+	rootCmd.AddCommand(NewQueueCmd())          // NEW: Link queue for browser captures
 	rootCmd.AddCommand(NewServeCmd())          // NEW: HTTP server
 	rootCmd.AddCommand(NewQualityCmd())        // NEW: Quality evaluation and metrics (Phase 1)
 	rootCmd.AddCommand(NewDigestCmd())         // Digest commands (file-based and database-based)
 	rootCmd.AddCommand(NewReadSimplifiedCmd()) // Existing: Quick read
 	rootCmd.AddCommand(NewCacheCmd())          // Existing: Cache management
+	rootCmd.AddCommand(NewWarmCmd())           // NEW: Overnight cache warming for an input file
 	rootCmd.AddCommand(NewSearchCmd())         // NEW: Semantic search (Phase 2)
+	rootCmd.AddCommand(NewResearchCmd())       // NEW: Deep-research briefs
+	rootCmd.AddCommand(NewAskCmd())            // NEW: Questions answered from the archive
+	rootCmd.AddCommand(NewExportCmd())         // NEW: E-reader export (EPUB/MOBI)
+	rootCmd.AddCommand(NewRegenerateCmd())     // NEW: Rebuild stored digests in other formats
+	rootCmd.AddCommand(NewCompletionCmd())     // NEW: Shell completion with dynamic IDs
+	rootCmd.AddCommand(NewStatsCmd())          // NEW: Click stats for tracked digest links
+	rootCmd.AddCommand(NewAuthorsCmd())        // NEW: Followed authors and reading stats
+	rootCmd.AddCommand(NewCostCmd())           // NEW: LLM cost attribution per digest
+	rootCmd.AddCommand(NewOpsCmd())            // NEW: Weekly pipeline health report
+	rootCmd.AddCommand(NewPriorityCmd())       // NEW: Priority inbox alert history
+	rootCmd.AddCommand(NewCommentCmd())        // NEW: Team comments for the next issue's reader notes
+	rootCmd.AddCommand(NewMyTakeCmd())         // NEW: Takes imported from YAML/markdown files
+	rootCmd.AddCommand(NewCollectCmd())        // NEW: Clipboard/drop-directory URL collection
+	rootCmd.AddCommand(NewReadLaterCmd())      // NEW: Readwise Reader / Raindrop.io sync
+	rootCmd.AddCommand(NewDaemonCmd())         // NEW: Scheduled tasks from the schedules block
+	rootCmd.AddCommand(NewUpdateCmd())         // NEW: Self-update from GitHub releases
+	rootCmd.AddCommand(NewTUICmd())            // NEW: Interactive digest workflow
 
 	// Initialize config before running any command
 	cobra.OnInitialize(initSimplifiedConfig)
@@ -82,9 +112,24 @@ Examples:
 // initSimplifiedConfig reads in config file and ENV variables
 func initSimplifiedConfig() {
 	_, err := config.Load(cfgFile)
+	render.SetPlain(plainOutput || (err == nil && config.GetOutput().Plain))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: Failed to load config: %v\n", err)
-		// Don't exit - allow running with just environment variables
+		// Don't exit - allow running with just environment variables, unless redaction
+		// is on: nothing may reach an LLM unredacted
+		if viper.GetBool("redaction.enabled") {
+			fmt.Fprintln(os.Stderr, "Error: redaction is enabled but the config failed to load")
+			os.Exit(1)
+		}
+		return
+	}
+
+	maxSize := config.GetFetch().MaxSize
+	fetch.SetDownloadLimits(fetch.DownloadLimits{HTML: int64(maxSize.HTML) << 20, PDF: int64(maxSize.PDF) << 20})
+
+	if err := enableRedaction(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to set up redaction: %v\n", err)
+		os.Exit(1)
 	}
 }
 
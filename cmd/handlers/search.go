@@ -19,12 +19,17 @@ func NewSearchCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "search",
 		Short: "Semantic search for articles",
-		Long: `Search for articles using semantic similarity with pgvector HNSW index.
+		Long: `Search for articles using semantic similarity.
+
+Searches run against the backend selected by vector_store.backend in config:
+pgvector (default, HNSW index over embeddings in Postgres) or qdrant (an external
+Qdrant collection, for archives too large to search in the database).
 
 Subcommands:
   query   - Search articles by text query
   similar - Find articles similar to a specific article
   stats   - Show vector store statistics
+  reindex - Copy stored embeddings into the configured vector store
 
 Examples:
   # Search by text
@@ -34,13 +39,17 @@ Examples:
   briefly search similar abc123de
 
   # Show vector store stats
-  briefly search stats`,
+  briefly search stats
+
+  # Backfill a Qdrant collection from embeddings already in Postgres
+  briefly search reindex`,
 	}
 
 	// Add subcommands
 	cmd.AddCommand(NewSearchQueryCmd())
 	cmd.AddCommand(NewSearchSimilarCmd())
 	cmd.AddCommand(NewSearchStatsCmd())
+	cmd.AddCommand(NewSearchReindexCmd())
 
 	return cmd
 }
@@ -103,6 +112,29 @@ func NewSearchStatsCmd() *cobra.Command {
 	}
 }
 
+// NewSearchReindexCmd creates the reindex subcommand
+func NewSearchReindexCmd() *cobra.Command {
+	var batchSize int
+
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Copy stored embeddings into the configured vector store",
+		Long: `Index every article embedding stored in Postgres in the configured vector store.
+
+Needed once after switching vector_store.backend to qdrant, so that articles
+embedded before the switch are searchable. New embeddings are indexed as digests
+are generated. With the pgvector backend there is nothing to copy.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearchReindex(cmd.Context(), batchSize)
+		},
+	}
+
+	cmd.Flags().IntVar(&batchSize, "batch-size", 200, "Articles loaded from Postgres per batch")
+
+	return cmd
+}
+
 func runSearchQuery(args []string, limit int, threshold float64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
@@ -138,7 +170,10 @@ func runSearchQuery(args []string, limit int, threshold float64) error {
 	}
 
 	// Initialize vector store
-	vectorStore := vectorstore.NewPgVectorAdapter(db.GetDB())
+	vectorStore, err := vectorstore.NewFromConfig(cfg.VectorStore, db.GetDB())
+	if err != nil {
+		return fmt.Errorf("failed to initialize vector store: %w", err)
+	}
 
 	// Generate embedding for query
 	queryText := strings.Join(args, " ")
@@ -222,7 +257,10 @@ func runSearchSimilar(articleID string, limit int, threshold float64) error {
 	defer db.Close()
 
 	// Initialize vector store
-	vectorStore := vectorstore.NewPgVectorAdapter(db.GetDB())
+	vectorStore, err := vectorstore.NewFromConfig(cfg.VectorStore, db.GetDB())
+	if err != nil {
+		return fmt.Errorf("failed to initialize vector store: %w", err)
+	}
 
 	fmt.Printf("🔍 Finding articles similar to: %s\n", articleID)
 	fmt.Printf("   Loading article embedding...\n")
@@ -312,7 +350,10 @@ func runSearchStats() error {
 	defer db.Close()
 
 	// Initialize vector store
-	vectorStore := vectorstore.NewPgVectorAdapter(db.GetDB())
+	vectorStore, err := vectorstore.NewFromConfig(cfg.VectorStore, db.GetDB())
+	if err != nil {
+		return fmt.Errorf("failed to initialize vector store: %w", err)
+	}
 
 	fmt.Println("📊 Vector Store Statistics")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -335,3 +376,83 @@ func runSearchStats() error {
 
 	return nil
 }
+
+func runSearchReindex(ctx context.Context, batchSize int) error {
+	_, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfg := config.Get()
+
+	dbConnStr := cfg.Database.ConnectionString
+	if dbConnStr == "" {
+		dbConnStr = os.Getenv("DATABASE_URL")
+		if dbConnStr == "" {
+			return fmt.Errorf("database connection string not configured")
+		}
+	}
+
+	db, err := persistence.NewPostgresDB(dbConnStr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	vectorStore, err := vectorstore.NewFromConfig(cfg.VectorStore, db.GetDB())
+	if err != nil {
+		return fmt.Errorf("failed to initialize vector store: %w", err)
+	}
+
+	indexer, ok := vectorStore.(vectorstore.ArticleIndexer)
+	if !ok {
+		fmt.Println("✅ pgvector searches embeddings in Postgres directly; nothing to reindex")
+		return nil
+	}
+
+	if batchSize <= 0 {
+		batchSize = 200
+	}
+
+	fmt.Printf("📦 Reindexing embeddings into %s...\n", cfg.VectorStore.Backend)
+	if err := vectorStore.CreateIndex(ctx); err != nil {
+		return fmt.Errorf("failed to prepare vector store: %w", err)
+	}
+
+	indexed, skipped, failed := 0, 0, 0
+	for offset := 0; ; offset += batchSize {
+		articles, err := db.Articles().List(ctx, persistence.ListOptions{Limit: batchSize, Offset: offset})
+		if err != nil {
+			return fmt.Errorf("failed to list articles: %w", err)
+		}
+
+		for _, article := range articles {
+			if len(article.Embedding) == 0 {
+				skipped++
+				continue
+			}
+
+			var tagIDs []string
+			if tags, _, err := db.Tags().GetArticleTags(ctx, article.ID); err == nil {
+				for _, tag := range tags {
+					tagIDs = append(tagIDs, tag.ID)
+				}
+			}
+
+			if err := indexer.IndexArticle(ctx, article, article.Embedding, tagIDs); err != nil {
+				fmt.Printf("   ⚠️  %s: %v\n", article.ID, err)
+				failed++
+				continue
+			}
+			indexed++
+		}
+
+		fmt.Printf("   • %d indexed so far\n", indexed)
+		if len(articles) < batchSize {
+			break
+		}
+	}
+
+	fmt.Printf("\n✅ Indexed %d embeddings (%d articles without embeddings skipped, %d failed)\n", indexed, skipped, failed)
+	return nil
+}
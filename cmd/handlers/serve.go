@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/llm"
 	"briefly/internal/logger"
 	"briefly/internal/persistence"
+	"briefly/internal/pipeline"
 	"briefly/internal/server"
 	"context"
 	"fmt"
@@ -22,6 +25,7 @@ func NewServeCmd() *cobra.Command {
 		staticDir   string
 		templateDir string
 		reload      bool
+		grpcPort    int
 	)
 
 	cmd := &cobra.Command{
@@ -33,6 +37,19 @@ The server provides:
   • Web UI for browsing articles and digests
   • REST API for programmatic access
   • Health check and status endpoints
+  • Slack slash command (/briefly summarize <url>) when
+    messaging.slack.signing_secret is configured
+  • Digest comments (POST /api/digests/{id}/comments) and, with a
+    signing secret, Slack reactions and replies (POST /slack/events)
+  • gRPC DigestService (article search, digest retrieval, and digest
+    generation with streaming progress) when server.grpc_port or
+    --grpc-port is set; see internal/rpc/brieflyv1/briefly.proto. Without
+    server.grpc_token it only starts on a loopback --host
+  • Multi-tenant mode when server.tenants is set: a tenant's bearer token
+    opens POST /api/summarize, run on the tenant's model and cache namespace
+    within its monthly budget, and GET /api/tenant/usage; the shared /api
+    routes need server.admin_token ('briefly serve usage' reports usage).
+    gRPC is refused in this mode, as it has no tenant scoping
 
 The server reads from the database populated by 'briefly aggregate'.
 Run aggregation separately (e.g., via cron) to keep content fresh.
@@ -47,10 +64,13 @@ Examples:
   # Start with custom directories
   briefly serve --static-dir ./static --template-dir ./templates
 
+  # Also serve gRPC for internal tooling
+  briefly serve --grpc-port 9090
+
   # Start with auto-reload (development mode)
   briefly serve --reload`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runServe(cmd.Context(), port, host, staticDir, templateDir, reload)
+			return runServe(cmd.Context(), port, host, staticDir, templateDir, reload, grpcPort)
 		},
 	}
 
@@ -59,11 +79,14 @@ Examples:
 	cmd.Flags().StringVar(&staticDir, "static-dir", "", "Static files directory (default from config)")
 	cmd.Flags().StringVar(&templateDir, "template-dir", "", "Template directory (default from config)")
 	cmd.Flags().BoolVar(&reload, "reload", false, "Auto-reload templates in dev mode (not yet implemented)")
+	cmd.Flags().IntVar(&grpcPort, "grpc-port", 0, "Also serve the gRPC DigestService on this port (default from config: off)")
+
+	cmd.AddCommand(newServeUsageCmd())
 
 	return cmd
 }
 
-func runServe(ctx context.Context, port int, host, staticDir, templateDir string, reload bool) error {
+func runServe(ctx context.Context, port int, host, staticDir, templateDir string, reload bool, grpcPort int) error {
 	log := logger.Get()
 	log.Info("Starting HTTP server")
 
@@ -87,6 +110,13 @@ func runServe(ctx context.Context, port int, host, staticDir, templateDir string
 	if templateDir != "" {
 		serverCfg.TemplateDir = templateDir
 	}
+	if grpcPort != 0 {
+		serverCfg.GRPCPort = grpcPort
+	}
+	// The gRPC service has one shared token and no tenant scoping or budgets
+	if serverCfg.GRPCPort != 0 && len(serverCfg.Tenants) > 0 {
+		return fmt.Errorf("gRPC can't run in multi-tenant mode: it would bypass per-tenant isolation and budgets (unset server.grpc_port or server.tenants)")
+	}
 
 	// Get database connection string
 	dbConnStr := cfg.Database.ConnectionString
@@ -123,6 +153,84 @@ func runServe(ctx context.Context, port int, host, staticDir, templateDir string
 	// Create HTTP server
 	srv := server.New(db, serverCfg)
 
+	// Enable the Slack slash command when a signing secret is configured
+	if cfg.Messaging.Slack.SigningSecret != "" {
+		llmClient, err := llm.NewClient("")
+		if err != nil {
+			return fmt.Errorf("failed to initialize LLM client for Slack commands: %w", err)
+		}
+		defer llmClient.Close()
+
+		cacheDir := cfg.Cache.Directory
+		if cacheDir == "" {
+			cacheDir = ".briefly-cache"
+		}
+		pipe, err := pipeline.NewBuilder().
+			WithLLMClient(llmClient).
+			WithCacheDir(cacheDir).
+			Build()
+		if err != nil {
+			return fmt.Errorf("failed to build pipeline for Slack commands: %w", err)
+		}
+
+		if err := srv.EnableSlackCommands(server.SlackCommandConfig{
+			SigningSecret: cfg.Messaging.Slack.SigningSecret,
+			BotToken:      cfg.Messaging.Slack.BotToken,
+			Summarizer:    &slackURLSummarizer{pipe: pipe, llmClient: llmClient},
+		}); err != nil {
+			return fmt.Errorf("failed to enable Slack commands: %w", err)
+		}
+	}
+
+	// Team comments on digests recorded in the local cache
+	cache, err := openSeriesCache()
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+	if err := srv.EnableComments(server.CommentsConfig{
+		Recorder:      &storeCommentRecorder{cache: cache},
+		Token:         serverCfg.CommentToken,
+		SigningSecret: cfg.Messaging.Slack.SigningSecret,
+	}); err != nil {
+		return fmt.Errorf("failed to enable comments: %w", err)
+	}
+
+	// Teams sharing the server, each with its own token, cache, model, and budget
+	if len(serverCfg.Tenants) > 0 {
+		cleanup, err := enableTenants(srv, cfg, cache)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		log.Info("Multi-tenant mode: shared /api routes need the admin token", "tenants", len(serverCfg.Tenants))
+	}
+
+	// Triage captures against the priority rules as they arrive
+	inbox, err := openPriorityInbox()
+	if err != nil {
+		return err
+	}
+	if inbox != nil {
+		defer inbox.Close()
+		srv.OnCapture(func(ctx context.Context, capture *core.ManualURL) {
+			inbox.CheckURL(ctx, capture.URL, "capture", capture.Note+"\n"+capture.Selection)
+		})
+		log.Info("Priority inbox enabled for captures", "series", config.GetPriority().Series)
+	}
+
+	// gRPC DigestService for internal tooling
+	var grpcErrors <-chan error
+	if serverCfg.GRPCPort != 0 {
+		grpcServer, errs, cleanup, err := startGRPCServer(cfg, serverCfg, db)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		defer grpcServer.Stop()
+		grpcErrors = errs
+	}
+
 	// Channel to listen for errors coming from the server
 	serverErrors := make(chan error, 1)
 
@@ -142,6 +250,9 @@ func runServe(ctx context.Context, port int, host, staticDir, templateDir string
 	case err := <-serverErrors:
 		return fmt.Errorf("server error: %w", err)
 
+	case err := <-grpcErrors:
+		return fmt.Errorf("gRPC server error: %w", err)
+
 	case sig := <-shutdown:
 		log.Info("Server shutdown initiated", "signal", sig.String())
 
@@ -161,3 +272,46 @@ func runServe(ctx context.Context, port int, host, staticDir, templateDir string
 
 	return nil
 }
+
+// slackURLSummarizer answers Slack slash commands with the quick-read pipeline, so
+// summaries share the CLI's article cache
+type slackURLSummarizer struct {
+	pipe      *pipeline.Pipeline
+	llmClient *llm.Client
+}
+
+// SummarizeURL returns the cached or freshly generated summary for url along with its
+// key moments. Key moments come from the structured summary when present and are
+// otherwise extracted from the article text.
+func (s *slackURLSummarizer) SummarizeURL(ctx context.Context, url string) (*server.QuickSummary, error) {
+	result, err := s.pipe.QuickRead(ctx, pipeline.QuickReadOptions{URL: url})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &server.QuickSummary{
+		Title:   result.Article.Title,
+		URL:     url,
+		Summary: result.Summary.SummaryText,
+		Cached:  result.WasCached,
+	}
+
+	if structured := result.Summary.StructuredContent; structured != nil && len(structured.Insights) > 0 {
+		summary.KeyMoments = structured.Insights
+		return summary, nil
+	}
+
+	keyMoments, err := s.llmClient.SummarizeArticleWithKeyMoments(*result.Article)
+	if err != nil {
+		// The summary alone is still worth posting
+		logger.Get().Warn("Key moment extraction failed", "url", url, "error", err)
+		return summary, nil
+	}
+	if keyMoments.StructuredContent != nil {
+		summary.KeyMoments = keyMoments.StructuredContent.Insights
+		if summary.Summary == "" {
+			summary.Summary = keyMoments.StructuredContent.MainInsight
+		}
+	}
+	return summary, nil
+}
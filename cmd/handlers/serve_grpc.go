@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/llm"
+	"briefly/internal/logger"
+	"briefly/internal/persistence"
+	"briefly/internal/rpc"
+	"briefly/internal/vectorstore"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// startGRPCServer serves the gRPC DigestService on serverCfg.GRPCPort. Search is
+// left off when no LLM client or vector store is available, rather than failing
+// the whole server. The returned cleanup releases the LLM client.
+func startGRPCServer(cfg *config.Config, serverCfg config.Server, db *persistence.PostgresDB) (*grpc.Server, <-chan error, func(), error) {
+	log := logger.Get()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to locate the briefly executable: %w", err)
+	}
+	opts := rpc.Options{DB: db, Generator: &commandGenerator{executable: executable}}
+
+	cleanup := func() {}
+	if llmClient, err := llm.NewClient(""); err != nil {
+		log.Warn("gRPC article search disabled: no LLM client for query embeddings", "error", err)
+	} else if store, err := vectorstore.NewFromConfig(cfg.VectorStore, db.GetDB()); err != nil {
+		llmClient.Close()
+		log.Warn("gRPC article search disabled: no vector store", "error", err)
+	} else {
+		opts.Embedder = llmClient
+		opts.VectorStore = store
+		cleanup = llmClient.Close
+	}
+
+	if err := rpc.CheckListen(serverCfg.Host, serverCfg.GRPCToken); err != nil {
+		cleanup()
+		return nil, nil, nil, err
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", serverCfg.Host, serverCfg.GRPCPort))
+	if err != nil {
+		cleanup()
+		return nil, nil, nil, fmt.Errorf("failed to listen for gRPC: %w", err)
+	}
+
+	grpcServer := rpc.NewServer(rpc.NewService(opts), serverCfg.GRPCToken)
+	errs := make(chan error, 1)
+	go func() {
+		log.Info(fmt.Sprintf("gRPC DigestService listening on %s", listener.Addr()), "token_required", serverCfg.GRPCToken != "")
+		errs <- grpcServer.Serve(listener)
+	}()
+	return grpcServer, errs, cleanup, nil
+}
+
+// savedDigestIDPrefix starts the line 'digest generate' prints for each digest it
+// saves, so a caller running it as a subprocess knows exactly which are its own
+const savedDigestIDPrefix = "Saved digest ID: "
+
+// commandGenerator generates digests by running 'briefly digest generate', the way
+// scheduled tasks do, so a run gets the CLI's full pipeline and its own process
+type commandGenerator struct {
+	executable string
+}
+
+// Generate runs digest generation, passing each line of its output to progress, and
+// returns the IDs of the digests it saved
+func (g *commandGenerator) Generate(ctx context.Context, opts rpc.GenerateOptions, progress func(line string)) ([]string, error) {
+	args := []string{"digest", "generate",
+		"--since", strconv.Itoa(opts.SinceDays),
+		"--min-articles", strconv.Itoa(opts.MinArticles),
+	}
+	if opts.Theme != "" {
+		args = append(args, "--theme", opts.Theme)
+	}
+	if opts.Audience != "" {
+		args = append(args, "--audience", opts.Audience)
+	}
+	if cfgFile != "" {
+		args = append(args, "--config", cfgFile)
+	}
+
+	reader, writer := io.Pipe()
+	stderr := &lastLine{}
+	cmd := exec.CommandContext(ctx, g.executable, args...)
+	cmd.Stdout = writer
+	cmd.Stderr = io.MultiWriter(writer, stderr)
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = daemonStopGrace
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	var ids []string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if id, ok := strings.CutPrefix(strings.TrimSpace(line), savedDigestIDPrefix); ok {
+				ids = append(ids, strings.TrimSpace(id))
+			}
+			progress(line)
+		}
+		// Keep draining so the command never blocks on a full pipe
+		_, _ = io.Copy(io.Discard, reader)
+	}()
+
+	err := cmd.Wait()
+	writer.Close()
+	<-done
+	if err != nil {
+		if line := stderr.String(); line != "" {
+			return nil, fmt.Errorf("%w: %s", err, line)
+		}
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"briefly/internal/logger"
+	"briefly/internal/pipeline"
+	"briefly/internal/server"
+	"briefly/internal/store"
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// tenantUsageStore keeps server tenants' usage in the local cache
+type tenantUsageStore struct {
+	cache *store.Store
+}
+
+// AddRequest counts one API request
+func (u *tenantUsageStore) AddRequest(tenantID, month string) error {
+	return u.cache.AddTenantUsage(store.TenantUsage{TenantID: tenantID, Month: month, Requests: 1})
+}
+
+// Usage returns a tenant's usage in a month
+func (u *tenantUsageStore) Usage(tenantID, month string) (server.TenantUsage, error) {
+	usage, err := u.cache.GetTenantUsage(tenantID, month)
+	if err != nil {
+		return server.TenantUsage{}, err
+	}
+	return server.TenantUsage{
+		TenantID:  usage.TenantID,
+		Month:     usage.Month,
+		Requests:  usage.Requests,
+		LLMCalls:  usage.LLMCalls,
+		TokensIn:  usage.TokensIn,
+		TokensOut: usage.TokensOut,
+		CostUSD:   usage.CostUSD,
+	}, nil
+}
+
+// recorder returns an LLM usage recorder that adds a tenant's calls to its usage
+func (u *tenantUsageStore) recorder(tenantID string) llm.UsageRecorder {
+	return func(ctx context.Context, call core.LLMCall) {
+		usage := store.TenantUsage{
+			TenantID:  tenantID,
+			Month:     server.UsageMonth(call.CreatedAt),
+			LLMCalls:  1,
+			TokensIn:  call.TokensIn,
+			TokensOut: call.TokensOut,
+			CostUSD:   llm.EstimateCost(call.Model, call.TokensIn, call.TokensOut),
+		}
+		if err := u.cache.AddTenantUsage(usage); err != nil {
+			logger.Get().Warn("Failed to record tenant LLM usage", "tenant", tenantID, "error", err)
+		}
+	}
+}
+
+// enableTenants turns on multi-tenant mode for server.tenants. Each tenant gets an
+// LLM client on its model, recording its usage, and a quick-read pipeline whose
+// cache is its own namespace under cache.directory/tenants/<id>. The returned
+// cleanup closes the clients.
+func enableTenants(srv *server.Server, cfg *config.Config, usageCache *store.Store) (func(), error) {
+	cacheDir := cfg.Cache.Directory
+	if cacheDir == "" {
+		cacheDir = ".briefly-cache"
+	}
+	usage := &tenantUsageStore{cache: usageCache}
+
+	var clients []*llm.Client
+	cleanup := func() {
+		for _, client := range clients {
+			client.Close()
+		}
+	}
+
+	tenants := make([]server.Tenant, 0, len(cfg.Server.Tenants))
+	for _, tenant := range cfg.Server.Tenants {
+		model := tenant.Model
+		if model == "" {
+			model = cfg.AI.Gemini.Model
+		}
+		llmClient, err := llm.NewClient(model)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to initialize LLM client for tenant %q: %w", tenant.ID, err)
+		}
+		clients = append(clients, llmClient)
+		llmClient.SetUsageRecorder(usage.recorder(tenant.ID))
+
+		pipe, err := pipeline.NewBuilder().
+			WithLLMClient(llmClient).
+			WithCacheDir(filepath.Join(cacheDir, "tenants", tenant.ID)).
+			Build()
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to build pipeline for tenant %q: %w", tenant.ID, err)
+		}
+
+		tenants = append(tenants, server.Tenant{
+			ID:            tenant.ID,
+			Name:          tenant.Name,
+			Token:         tenant.Token,
+			MonthlyBudget: tenant.MonthlyBudget,
+			Summarizer:    &slackURLSummarizer{pipe: pipe, llmClient: llmClient},
+		})
+	}
+
+	if err := srv.EnableTenants(server.TenantsConfig{
+		Tenants:    tenants,
+		AdminToken: cfg.Server.AdminToken,
+		Usage:      usage,
+	}); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to enable tenants: %w", err)
+	}
+	return cleanup, nil
+}
+
+func newServeUsageCmd() *cobra.Command {
+	var month string
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Report each server tenant's requests and LLM spend",
+		Long: `Report the API requests, LLM calls, tokens, and estimated LLM spend of each
+tenant in server.tenants for a month, against its monthly budget.
+
+Examples:
+  briefly serve usage
+  briefly serve usage --month 2026-09`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if month == "" {
+				month = server.UsageMonth(time.Now())
+			} else if _, err := time.Parse("2006-01", month); err != nil {
+				return fmt.Errorf("--month must be YYYY-MM")
+			}
+
+			cache, err := openSeriesCache()
+			if err != nil {
+				return err
+			}
+			defer cache.Close()
+
+			tenants := config.GetServer().Tenants
+			if len(tenants) == 0 {
+				fmt.Println("No tenants configured")
+				fmt.Println("💡 Add teams under server.tenants to run the server in multi-tenant mode")
+				return nil
+			}
+
+			fmt.Printf("📊 Tenant usage for %s\n\n", month)
+			fmt.Printf("%-16s %9s %9s %12s %12s %10s %10s\n", "TENANT", "REQUESTS", "LLM CALLS", "TOKENS IN", "TOKENS OUT", "COST", "BUDGET")
+			for _, tenant := range tenants {
+				usage, err := cache.GetTenantUsage(tenant.ID, month)
+				if err != nil {
+					return err
+				}
+				budget := "-"
+				if tenant.MonthlyBudget > 0 {
+					budget = fmt.Sprintf("$%.2f", tenant.MonthlyBudget)
+				}
+				fmt.Printf("%-16s %9d %9d %12d %12d %10s %10s\n", tenant.ID, usage.Requests, usage.LLMCalls, usage.TokensIn, usage.TokensOut, fmt.Sprintf("$%.4f", usage.CostUSD), budget)
+				if tenant.MonthlyBudget > 0 && usage.CostUSD >= tenant.MonthlyBudget {
+					fmt.Printf("   ⚠️  %s has spent its budget; its summaries are refused until next month\n", tenant.ID)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&month, "month", "", "Month to report as YYYY-MM (default: this month)")
+
+	return cmd
+}
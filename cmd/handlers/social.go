@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"briefly/internal/render"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// socialPostsPath is where the social post drafts for a digest file go, e.g.
+// digest-2026-10-17.social.md next to digest-2026-10-17.md
+func socialPostsPath(outputPath string) string {
+	return strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + ".social.md"
+}
+
+// writeSocialPosts has the LLM draft LinkedIn and X posts from the saved digest
+// (--social) and writes them next to it, each in a block ready to paste. Runs after
+// link tracking, so the posts are drafted from the final file. Failures only warn,
+// since the digest itself is already saved.
+func writeSocialPosts(ctx context.Context, llmClient *llm.Client, digest *core.Digest, outputPath string) {
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		fmt.Printf("   ⚠️  Social posts skipped: %v\n", err)
+		return
+	}
+
+	ctx = llm.WithAttribution(ctx, llm.Attribution{DigestID: digest.ID, Phase: "social"})
+	posts, err := llmClient.GenerateSocialPosts(ctx, llm.SocialPostsInput{Title: digest.Title, DigestContent: string(content)})
+	if err != nil {
+		fmt.Printf("   ⚠️  Social posts skipped: %v\n", err)
+		return
+	}
+
+	link := socialLink(digest)
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Social posts: %s\n\n", digest.Title)
+	fmt.Fprintf(&b, "## LinkedIn\n\n```text\n%s\n```\n\n", posts.LinkedIn.Text(link))
+	fmt.Fprintf(&b, "## X / Twitter\n\n```text\n%s\n```\n", posts.X.XText(link))
+
+	path := socialPostsPath(outputPath)
+	if err := os.WriteFile(path, []byte(render.Output(b.String())), 0644); err != nil {
+		fmt.Printf("   ⚠️  Social posts skipped: %v\n", err)
+		return
+	}
+	fmt.Printf("   ✓ Social post drafts: %s\n", path)
+}
+
+// socialLink is the link the posts share: output.social_link (the published issue
+// or the newsletter's archive) when set, otherwise the Must-Read article, or the
+// digest's first article
+func socialLink(digest *core.Digest) string {
+	if link := config.GetOutput().SocialLink; link != "" {
+		return link
+	}
+	if digest.MustRead != nil {
+		// Citation numbers continue from earlier parts of a split digest
+		i := digest.MustRead.ArticleNum - 1 - digest.Metadata.ArticleOffset
+		if i >= 0 && i < len(digest.Articles) {
+			return digest.Articles[i].URL
+		}
+	}
+	if len(digest.Articles) > 0 {
+		return digest.Articles[0].URL
+	}
+	return ""
+}
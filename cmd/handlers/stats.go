@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewStatsCmd creates the stats command
+func NewStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Engagement statistics for shared digests",
+		Long: `Show engagement statistics for digests shared with your team.
+
+Subcommands:
+  clicks - Which article links readers actually clicked`,
+	}
+
+	cmd.AddCommand(newStatsClicksCmd())
+
+	return cmd
+}
+
+func newStatsClicksCmd() *cobra.Command {
+	var (
+		since    int
+		digestID string
+		limit    int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clicks",
+		Short: "Show click counts for tracked digest links",
+		Long: `Show click counts for article links rewritten with --track-links.
+
+Clicks are recorded by the self-hosted redirector (/r/{code} on 'briefly serve').
+Links created through an external short-link service are counted by that
+service and do not appear here.
+
+Examples:
+  # Most-clicked links in the last 7 days
+  briefly stats clicks
+
+  # Clicks for a single digest over the last 30 days
+  briefly stats clicks --digest digest_slack_2025-06-07 --since 30`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatsClicks(cmd.Context(), since, digestID, limit)
+		},
+	}
+
+	cmd.Flags().IntVarP(&since, "since", "s", 7, "Count clicks from last N days")
+	cmd.Flags().StringVar(&digestID, "digest", "", "Only show links from this digest key (file name without extension)")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Maximum number of links to show")
+
+	return cmd
+}
+
+func runStatsClicks(ctx context.Context, since int, digestID string, limit int) error {
+	db, err := getDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sinceDate := time.Now().AddDate(0, 0, -since)
+	links, err := db.Links().ClickStats(ctx, sinceDate, digestID, limit)
+	if err != nil {
+		return fmt.Errorf("failed to load click stats: %w", err)
+	}
+
+	if len(links) == 0 {
+		fmt.Println("No tracked links found")
+		fmt.Println("💡 Generate a digest with --track-links to start tracking clicks")
+		return nil
+	}
+
+	totalClicks := 0
+	for _, link := range links {
+		totalClicks += link.Clicks
+	}
+
+	fmt.Printf("\n🔗 Link Clicks (last %d days)\n", since)
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("%6s  %-12s  %-26s  %s\n", "Clicks", "Last Click", "Digest", "URL")
+	fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+
+	for _, link := range links {
+		lastClick := "-"
+		if link.LastClicked != nil {
+			lastClick = link.LastClicked.Format("Jan 02 15:04")
+		}
+
+		digest := "-"
+		if link.DigestID != nil {
+			digest = *link.DigestID
+		}
+		if len(digest) > 26 {
+			digest = digest[:23] + "..."
+		}
+
+		url := link.URL
+		if len(url) > 60 {
+			url = url[:57] + "..."
+		}
+
+		fmt.Printf("%6d  %-12s  %-26s  %s\n", link.Clicks, lastClick, digest, url)
+	}
+
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("Total: %d clicks across %d links\n", totalClicks, len(links))
+
+	return nil
+}
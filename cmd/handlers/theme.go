@@ -80,7 +80,8 @@ func newThemeRemoveCmd() *cobra.Command {
 
 Note: This will not delete the theme if there are articles already classified
 under it. Instead, it will be marked as disabled.`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeThemeIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			themeID := args[0]
 			return runThemeRemove(cmd.Context(), themeID)
@@ -109,9 +110,10 @@ By default, only enabled themes are shown. Use --all to show disabled themes as
 
 func newThemeEnableCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "enable <theme-id>",
-		Short: "Enable a theme",
-		Args:  cobra.ExactArgs(1),
+		Use:               "enable <theme-id>",
+		Short:             "Enable a theme",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeThemeIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			themeID := args[0]
 			return runThemeToggle(cmd.Context(), themeID, true)
@@ -121,9 +123,10 @@ func newThemeEnableCmd() *cobra.Command {
 
 func newThemeDisableCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "disable <theme-id>",
-		Short: "Disable a theme",
-		Args:  cobra.ExactArgs(1),
+		Use:               "disable <theme-id>",
+		Short:             "Disable a theme",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeThemeIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			themeID := args[0]
 			return runThemeToggle(cmd.Context(), themeID, false)
@@ -147,7 +150,8 @@ Examples:
   briefly theme update abc123 --description "New description"
   briefly theme update abc123 --keywords "new,keywords,here"
   briefly theme update abc123 --description "New description" --keywords "new,keywords"`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeThemeIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			themeID := args[0]
 			return runThemeUpdate(cmd.Context(), themeID, description, keywords, cmd.Flags().Changed("description"), cmd.Flags().Changed("keywords"))
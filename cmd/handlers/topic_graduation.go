@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/logger"
+	"briefly/internal/store"
+	"briefly/internal/trends"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// graduateTopics finds the keywords in each of the last opts.graduateAfter digests
+// that aren't covered yet by priority.watchlist, feeds.follow, or an earlier
+// graduation, and either suggests them or, with --graduate, saves them so they are
+// watched and searched from then on
+func graduateTopics(digests []core.Digest, articles map[string][]core.Article, opts qualityTrendsOptions) {
+	if opts.graduateAfter <= 0 {
+		return
+	}
+	graduating := trends.Graduating(digests, articles, opts.graduateAfter)
+	if len(graduating) == 0 {
+		return
+	}
+	cache, err := openSeriesCache()
+	if err != nil {
+		fmt.Printf("⚠️  Topic graduation skipped: %v\n", err)
+		return
+	}
+	defer cache.Close()
+
+	covered := make(map[string]bool)
+	for _, term := range config.GetPriority().Watchlist {
+		covered[strings.ToLower(term)] = true
+	}
+	for _, topic := range config.GetFeeds().Follow {
+		covered[strings.ToLower(topic.Query)] = true
+	}
+	graduated, err := cache.ListGraduatedTopics()
+	if err != nil {
+		fmt.Printf("⚠️  Topic graduation skipped: %v\n", err)
+		return
+	}
+	for _, topic := range graduated {
+		covered[strings.ToLower(topic.Keyword)] = true
+	}
+
+	var fresh []trends.Graduation
+	for _, graduation := range graduating {
+		if !covered[strings.ToLower(graduation.Keyword)] {
+			fresh = append(fresh, graduation)
+		}
+	}
+	if len(fresh) == 0 {
+		return
+	}
+
+	fmt.Printf("\n🎓 Topics in the last %d+ digests, not yet watched or followed:\n", opts.graduateAfter)
+	for _, graduation := range fresh {
+		fmt.Printf("   • %s: top keyword of the last %d digests (since %s)\n", graduation.Keyword, graduation.Streak, graduation.Since.Format("Jan 02"))
+	}
+	if !opts.graduate {
+		fmt.Println("💡 Add them to the priority watchlist and followed searches with --graduate")
+		return
+	}
+
+	saved := 0
+	for _, graduation := range fresh {
+		topic := store.GraduatedTopic{Keyword: graduation.Keyword, Streak: graduation.Streak, GraduatedAt: time.Now()}
+		if err := cache.SaveGraduatedTopic(topic); err != nil {
+			fmt.Printf("   ⚠️  %v\n", err)
+			continue
+		}
+		saved++
+	}
+	if saved > 0 {
+		fmt.Printf("   ➕ %d added to the priority watchlist and followed searches (see 'briefly priority graduated')\n", saved)
+	}
+}
+
+// graduatedKeywords returns the keywords of the graduated topics, or nil when the
+// cache can't be read
+func graduatedKeywords() []string {
+	cache, err := openSeriesCache()
+	if err != nil {
+		return nil
+	}
+	defer cache.Close()
+
+	topics, err := cache.ListGraduatedTopics()
+	if err != nil {
+		logger.Get().Warn("Failed to list graduated topics", "error", err)
+		return nil
+	}
+	keywords := make([]string, len(topics))
+	for i, topic := range topics {
+		keywords[i] = topic.Keyword
+	}
+	return keywords
+}
+
+// watchlistTerms returns priority.watchlist plus the graduated topics
+func watchlistTerms(watchlist []string) []string {
+	terms := append([]string(nil), watchlist...)
+	seen := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		seen[strings.ToLower(term)] = true
+	}
+	for _, keyword := range graduatedKeywords() {
+		if !seen[strings.ToLower(keyword)] {
+			seen[strings.ToLower(keyword)] = true
+			terms = append(terms, keyword)
+		}
+	}
+	return terms
+}
+
+// followTopics returns feeds.follow plus a standing search query for each
+// graduated topic, run on the default provider
+func followTopics(follow []config.FollowTopic) []config.FollowTopic {
+	topics := append([]config.FollowTopic(nil), follow...)
+	seen := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		seen[strings.ToLower(topic.Query)] = true
+	}
+	for _, keyword := range graduatedKeywords() {
+		if !seen[strings.ToLower(keyword)] {
+			seen[strings.ToLower(keyword)] = true
+			topics = append(topics, config.FollowTopic{Query: keyword})
+		}
+	}
+	return topics
+}
+
+func newPriorityGraduatedCmd() *cobra.Command {
+	var remove []string
+
+	cmd := &cobra.Command{
+		Use:   "graduated",
+		Short: "List topics graduated from trends to ongoing coverage",
+		Long: `List the topics 'briefly quality trends --graduate' moved to ongoing coverage.
+Each is matched like a priority.watchlist term and searched like a feeds.follow
+query during 'briefly feed pull'.
+
+Examples:
+  briefly priority graduated
+  briefly priority graduated --remove "model context protocol"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cache, err := openSeriesCache()
+			if err != nil {
+				return err
+			}
+			defer cache.Close()
+
+			for _, keyword := range remove {
+				removed, err := cache.DeleteGraduatedTopic(keyword)
+				if err != nil {
+					return err
+				}
+				if !removed {
+					return fmt.Errorf("%q is not a graduated topic", keyword)
+				}
+				fmt.Printf("🗑️  Stopped covering %s\n", keyword)
+			}
+			if len(remove) > 0 {
+				return nil
+			}
+
+			topics, err := cache.ListGraduatedTopics()
+			if err != nil {
+				return err
+			}
+			if len(topics) == 0 {
+				fmt.Println("No graduated topics yet")
+				fmt.Println("💡 Graduate topics from 'briefly quality trends --graduate'")
+				return nil
+			}
+			for _, topic := range topics {
+				fmt.Printf("%s  %s (top keyword of %d digests in a row)\n", topic.GraduatedAt.Local().Format("2006-01-02"), topic.Keyword, topic.Streak)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&remove, "remove", nil, "Stop covering this graduated topic (repeatable)")
+
+	return cmd
+}
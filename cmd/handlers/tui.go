@@ -0,0 +1,336 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/render"
+	"briefly/internal/tui"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// tuiPreviewLines caps the digest preview; the full file is a path away
+const tuiPreviewLines = 200
+
+// tuiFileFlags are the options offered for a digest from a file
+var tuiFileFlags = []tui.Option{
+	{Label: "--no-cache", Help: "Fetch every article fresh"},
+	{Label: "--curate", Help: "Have the LLM flag duplicate or low-value links first"},
+	{Label: "--agent", Help: "Agentic generation with a reflect/revise loop"},
+	{Label: "--perspectives", Help: `Add an "Other side" viewpoint on the top story`},
+	{Label: "--sentiment", Help: "Score article sentiment"},
+	{Label: "--social", Help: "Draft LinkedIn and X posts"},
+	{Label: "--track-links", Help: "Rewrite links for click tracking"},
+	{Label: "--exclude-read", Help: "Skip URLs already marked read"},
+	{Label: "--figures", Help: "Describe chart images with the vision model"},
+}
+
+// tuiCacheFlags are the options offered for digests built from stored articles
+var tuiCacheFlags = []tui.Option{
+	{Label: "--sentiment", Help: "Score article sentiment"},
+	{Label: "--social", Help: "Draft LinkedIn and X posts"},
+	{Label: "--track-links", Help: "Rewrite links for click tracking"},
+	{Label: "--exclude-read", Help: "Leave out articles already marked read"},
+	{Label: "--figures", Help: "Describe chart images with the vision model"},
+}
+
+// NewTUICmd creates the tui command, an interactive digest workflow
+func NewTUICmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tui",
+		Short: "Build, preview, and deliver a digest interactively",
+		Long: `Walk through a digest run without remembering its flags.
+
+The workflow asks for:
+  • The input: a curated markdown file (from input/ or any path), a feed
+    category from the database, cached articles for a date range, or the next
+    scheduled issue
+  • The output format and directory
+  • Options such as --curate, --perspectives, or --social, toggled by number
+
+It prints the equivalent command, runs it with live progress, previews the
+digest with syntax highlighting (plain with --plain or NO_COLOR), and offers to
+send it to the channels under delivery.channels.
+
+Examples:
+  briefly tui
+  briefly tui --config ~/.briefly.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := runTUI(cmd.Context())
+			if errors.Is(err, tui.ErrInputClosed) {
+				return nil
+			}
+			return err
+		},
+	}
+}
+
+func runTUI(ctx context.Context) error {
+	prompt := tui.NewPrompter(os.Stdin, os.Stdout)
+	fmt.Println("🧭 Briefly digest workflow (Ctrl-D to quit)")
+
+	plan, err := askTUIPlan(prompt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n▶️  %s\n", plan.Command())
+	if run, err := prompt.Confirm("Run it?", true); err != nil || !run {
+		return err
+	}
+
+	started := time.Now()
+	if err := runTUIPlan(ctx, plan); err != nil {
+		return err
+	}
+
+	path, err := latestDigestFile(plan.OutputDir, started)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		fmt.Printf("\n⚠️  No new digest in %s to preview\n", plan.OutputDir)
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read digest: %w", err)
+	}
+	previewDigest(path, string(content))
+
+	if _, err := config.Load(cfgFile); err != nil {
+		return nil
+	}
+	if _, err := deliveryChannels(); err != nil {
+		fmt.Printf("\n💡 Add delivery.channels to config to send digests from here\n")
+		return nil
+	}
+	if send, err := prompt.Confirm("Send it to the configured delivery channels?", false); err != nil || !send {
+		return err
+	}
+	deliverDigest(ctx, digestTitle(path, string(content)), path, plan.Format)
+	return nil
+}
+
+// askTUIPlan fills a plan from the source, format, output, and option forms
+func askTUIPlan(prompt *tui.Prompter) (tui.Plan, error) {
+	var plan tui.Plan
+	sources := []string{tui.SourceFile, tui.SourceFeeds, tui.SourceCache, tui.SourceIssue}
+	source, err := prompt.Choose("📥 What should the digest cover?", []string{
+		"Links in a curated markdown file",
+		"Database articles from one feed category",
+		"Articles already in the local cache, by date",
+		"The next scheduled issue (schedule.* in config)",
+	}, 0)
+	if err != nil {
+		return plan, err
+	}
+	plan.Source = sources[source]
+
+	flags := tuiCacheFlags
+	switch plan.Source {
+	case tui.SourceFile:
+		flags = tuiFileFlags
+		if plan.InputFile, err = askTUIInputFile(prompt); err != nil {
+			return plan, err
+		}
+	case tui.SourceFeeds:
+		for plan.Category == "" {
+			if plan.Category, err = prompt.Ask("Feed category (see 'briefly feed list')", ""); err != nil {
+				return plan, err
+			}
+		}
+		fallthrough
+	case tui.SourceCache:
+		if plan.Since, err = askTUIDate(prompt, "Since (YYYY-MM-DD)", time.Now().AddDate(0, 0, -7).Format("2006-01-02")); err != nil {
+			return plan, err
+		}
+		if plan.Until, err = askTUIDate(prompt, "Until (YYYY-MM-DD)", time.Now().Format("2006-01-02")); err != nil {
+			return plan, err
+		}
+	}
+
+	// Format and directory default to output.* when the config loads
+	var output config.Output
+	if _, err := config.Load(cfgFile); err == nil {
+		output = config.GetOutput()
+	}
+	formats := []string{"markdown", "slack"}
+	def := 0
+	if output.Format == "slack" {
+		def = 1
+	}
+	format, err := prompt.Choose("📝 Format", formats, def)
+	if err != nil {
+		return plan, err
+	}
+	plan.Format = formats[format]
+
+	outputDir := output.Directory
+	if outputDir == "" {
+		outputDir = "digests"
+	}
+	fmt.Println()
+	if plan.OutputDir, err = prompt.Ask("Output directory", outputDir); err != nil {
+		return plan, err
+	}
+
+	options, err := prompt.Toggle("⚙️  Options", flags)
+	if err != nil {
+		return plan, err
+	}
+	for _, option := range options {
+		if option.On {
+			plan.Flags = append(plan.Flags, option.Label)
+		}
+	}
+	return plan, nil
+}
+
+// askTUIInputFile offers the newest markdown files under input/, or any path
+func askTUIInputFile(prompt *tui.Prompter) (string, error) {
+	files, _ := filepath.Glob(filepath.Join("input", "*.md"))
+	sort.Slice(files, func(i, j int) bool { return modTime(files[i]).After(modTime(files[j])) })
+	if len(files) > 9 {
+		files = files[:9]
+	}
+
+	if len(files) > 0 {
+		choice, err := prompt.Choose("📄 Input file", append(append([]string(nil), files...), "Another file…"), 0)
+		if err != nil {
+			return "", err
+		}
+		if choice < len(files) {
+			return files[choice], nil
+		}
+	}
+
+	fmt.Println()
+	for {
+		path, err := prompt.Ask("Path to a markdown file of links", "")
+		if err != nil {
+			return "", err
+		}
+		if info, statErr := os.Stat(path); statErr == nil && !info.IsDir() {
+			return path, nil
+		}
+		fmt.Printf("   No file at %q\n", path)
+	}
+}
+
+// askTUIDate asks for a YYYY-MM-DD date until one parses
+func askTUIDate(prompt *tui.Prompter, question, def string) (string, error) {
+	for {
+		date, err := prompt.Ask(question, def)
+		if err != nil {
+			return "", err
+		}
+		if _, parseErr := time.Parse("2006-01-02", date); parseErr == nil {
+			return date, nil
+		}
+		fmt.Println("   Use the YYYY-MM-DD format")
+	}
+}
+
+// runTUIPlan runs the plan as its own briefly process, so its progress streams
+// live and every run starts from fresh flag state
+func runTUIPlan(ctx context.Context, plan tui.Plan) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the briefly binary: %w", err)
+	}
+	args := plan.Args()
+	if cfgFile != "" {
+		args = append(args, "--config", cfgFile)
+	}
+	if plainOutput {
+		args = append(args, "--plain")
+	}
+
+	cmd := exec.CommandContext(ctx, self, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("digest run failed: %w", err)
+	}
+	return nil
+}
+
+// latestDigestFile returns the newest digest written to dir since started, or ""
+func latestDigestFile(dir string, started time.Time) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read output directory: %w", err)
+	}
+	latest, latestTime := "", started.Add(-time.Second)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".md") || strings.HasSuffix(name, ".social.md") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if t := modTime(path); t.After(latestTime) {
+			latest, latestTime = path, t
+		}
+	}
+	return latest, nil
+}
+
+// previewDigest prints the digest, highlighted when the terminal shows color
+func previewDigest(path, content string) {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	more := 0
+	if len(lines) > tuiPreviewLines {
+		more = len(lines) - tuiPreviewLines
+		lines = lines[:tuiPreviewLines]
+	}
+	preview := strings.Join(lines, "\n")
+	if colorOutput() {
+		preview = tui.Highlight(preview)
+	}
+
+	fmt.Printf("\n👀 Preview of %s\n\n%s\n", path, preview)
+	if more > 0 {
+		fmt.Printf("\n… %d more line(s) in %s\n", more, path)
+	}
+}
+
+// colorOutput reports whether stdout is a terminal that should get ANSI colors
+func colorOutput() bool {
+	if render.PlainMode() || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// digestTitle is the digest's first heading (markdown) or bold line (Slack)
+func digestTitle(path, content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			return strings.TrimSpace(strings.TrimLeft(line, "#"))
+		}
+		if strings.HasPrefix(line, "*") && strings.HasSuffix(line, "*") && len(line) > 2 {
+			return strings.Trim(line, "*")
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(path), ".md")
+}
+
+// modTime returns a file's modification time, zero when it can't be read
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/update"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// NewUpdateCmd creates the update command
+func NewUpdateCmd() *cobra.Command {
+	var (
+		checkOnly     bool
+		channel       string
+		force         bool
+		allowUnsigned bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update briefly to the latest release",
+		Long: `Check GitHub releases for a newer version of briefly and install it.
+
+The download is verified against the release's checksums.txt, and checksums.txt
+against its ed25519 signature (checksums.txt.sig) using update.public_key, before
+the running binary is replaced. Without update.public_key, briefly refuses to
+install an update unless --allow-unsigned accepts checksums alone; those come from
+the same release, so they catch a corrupt download but not a tampered release.
+
+Channels (update.channel in config, or --channel):
+  stable - Published releases only (default)
+  beta   - Pre-releases too, for trying changes early
+
+Examples:
+  # Is there a newer version?
+  briefly update --check
+
+  # Install the latest stable release
+  briefly update
+
+  # Follow the beta channel this once
+  briefly update --channel beta`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpdate(cmd.Context(), checkOnly, channel, force, allowUnsigned)
+		},
+	}
+
+	cmd.Flags().BoolVar(&checkOnly, "check", false, "Only check for a newer version, don't install it")
+	cmd.Flags().StringVar(&channel, "channel", "", "Release channel: stable or beta (default: update.channel)")
+	cmd.Flags().BoolVar(&force, "force", false, "Reinstall even if the latest release is not newer")
+	cmd.Flags().BoolVar(&allowUnsigned, "allow-unsigned", false, "Install without update.public_key, trusting checksums alone")
+
+	return cmd
+}
+
+func runUpdate(ctx context.Context, checkOnly bool, channel string, force, allowUnsigned bool) error {
+	cfg := config.GetUpdate()
+	if channel == "" {
+		channel = cfg.Channel
+	}
+
+	updater, err := update.NewUpdater(cfg.Repository, channel, cfg.PublicKey)
+	if err != nil {
+		return err
+	}
+	if allowUnsigned {
+		updater.AllowUnsigned()
+	}
+
+	fmt.Printf("🔍 Checking %s releases of %s...\n", updater.Channel(), cfg.Repository)
+	release, err := updater.Latest(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("   Current: %s\n", Version)
+	fmt.Printf("   Latest:  %s", release.Version())
+	if release.Prerelease {
+		fmt.Print(" (pre-release)")
+	}
+	fmt.Println()
+
+	if !update.Newer(release.Version(), Version) && !force {
+		fmt.Println("✅ briefly is up to date")
+		return nil
+	}
+
+	if checkOnly {
+		fmt.Printf("⬆️  Update available: %s\n", release.HTMLURL)
+		fmt.Println("💡 Run 'briefly update' to install it")
+		return nil
+	}
+	if !updater.VerifiesSignatures() && !allowUnsigned {
+		return fmt.Errorf("refusing to install %s: %w (set update.public_key, or pass --allow-unsigned to trust checksums alone)", release.TagName, update.ErrUnsigned)
+	}
+
+	target, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate running binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		target = resolved
+	}
+
+	fmt.Printf("⬇️  Downloading %s...\n", release.TagName)
+	binary, err := updater.Download(ctx, release)
+	if err != nil {
+		return fmt.Errorf("failed to download update: %w", err)
+	}
+	if updater.VerifiesSignatures() {
+		fmt.Println("   ✓ Checksum and signature verified")
+	} else {
+		fmt.Println("   ⚠️  Checksum verified, signature not checked (--allow-unsigned)")
+	}
+
+	if err := update.Apply(binary, target); err != nil {
+		return fmt.Errorf("failed to install update: %w", err)
+	}
+
+	fmt.Printf("✅ Updated briefly %s → %s (%s)\n", Version, release.Version(), target)
+	return nil
+}
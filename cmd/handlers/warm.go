@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/fetch"
+	"briefly/internal/llm"
+	"briefly/internal/logger"
+	"briefly/internal/parser"
+	"briefly/internal/store"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// warmStats counts what 'briefly warm' found cached and what it added
+type warmStats struct {
+	links            int
+	articlesCached   int
+	articlesFetched  int
+	skipped          int
+	failed           int
+	summariesCached  int
+	summarized       int
+	embeddingsCached int
+	embedded         int
+}
+
+// NewWarmCmd creates the warm command for pre-filling the cache from an input file
+func NewWarmCmd() *cobra.Command {
+	var summarize bool
+
+	cmd := &cobra.Command{
+		Use:   "warm <input-file>",
+		Short: "Pre-fetch, clean, and embed an input file's links into the cache",
+		Long: `Fill the cache for an input file ahead of the digest run, so that
+'briefly digest from-file' on publication morning reads everything from the cache.
+
+Every link is fetched and cleaned as in from-file. With --summarize, articles
+without a cached summary are summarized as one Gemini Batch API job, which is
+billed at a discount but can take hours, so it suits an overnight run. Each
+summary is then embedded, the same text the digest clusters on, and the embedding
+is cached with it. Without --summarize, only summaries cached by earlier runs are
+embedded.
+
+Cached pages and summaries are reused for 24 hours, so warm the night before.
+
+Examples:
+  # Fetch and clean every link
+  briefly warm input/next-week.md
+
+  # Overnight: also summarize (batch) and embed, then digest in the morning
+  briefly warm input/next-week.md --summarize
+  briefly digest from-file input/next-week.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWarm(cmd, args[0], summarize)
+		},
+	}
+
+	cmd.Flags().BoolVar(&summarize, "summarize", false, "Also summarize uncached articles with one Gemini Batch API job (cheaper, can take hours)")
+
+	return cmd
+}
+
+func runWarm(cmd *cobra.Command, inputFile string, summarize bool) error {
+	ctx := cmd.Context()
+	log := logger.Get()
+
+	if _, err := config.Load(cfgFile); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg := config.Get()
+
+	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+		return fmt.Errorf("input file not found: %s", inputFile)
+	}
+
+	cacheDir := cfg.Cache.Directory
+	if cacheDir == "" {
+		cacheDir = ".briefly-cache"
+	}
+	cache, err := openCacheStore(cacheDir)
+	if err != nil {
+		return err
+	}
+	defer cache.Close()
+	if cache.InMemory() {
+		return fmt.Errorf("the cache at %s can't be written, so there is nothing to warm", cacheDir)
+	}
+
+	links, err := parser.NewParser().ParseMarkdownFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse markdown file: %w", err)
+	}
+	if len(links) == 0 {
+		fmt.Println("⚠️  No URLs found in markdown file")
+		return nil
+	}
+	stats := warmStats{links: len(links)}
+
+	fmt.Printf("🔍 Fetching %d URLs from %s...\n", len(links), inputFile)
+	processor := fetch.NewContentProcessor()
+	articles := make([]core.Article, 0, len(links))
+	for i, link := range links {
+		fmt.Printf("   [%d/%d] %s\n", i+1, len(links), link.URL)
+		if cached, err := cache.GetCachedArticle(link.URL, 24*time.Hour); err == nil && cached != nil {
+			fmt.Println("           ✓ Cache hit")
+			articles = append(articles, *cached)
+			stats.articlesCached++
+			continue
+		}
+
+		article, err := processor.ProcessArticle(ctx, link.URL)
+		if skipped, ok := fetch.SkippedArticle(link.URL, err); ok {
+			fmt.Printf("           ⏭  Skipped: %s\n", skipped.SkipReason)
+			stats.skipped++
+			continue
+		}
+		if err != nil {
+			log.Warn("Failed to fetch article", "url", link.URL, "error", err)
+			fmt.Printf("           ⚠ Fetch failed: %v\n", err)
+			stats.failed++
+			continue
+		}
+		fetch.ApplyLinkTitle(article, link.Title)
+		if err := cache.SaveArticle(article); err != nil {
+			return fmt.Errorf("failed to cache article %s: %w", link.URL, err)
+		}
+		fmt.Println("           ✓ Fetched and cached")
+		articles = append(articles, *article)
+		stats.articlesFetched++
+	}
+
+	if len(articles) > 0 && (summarize || hasCachedSummaries(cache, articles)) {
+		modelName := cfg.AI.Gemini.Model
+		if modelName == "" {
+			modelName = "gemini-3-flash-preview"
+		}
+		llmClient, err := llm.NewClient(modelName)
+		if err != nil {
+			return fmt.Errorf("failed to create LLM client: %w", err)
+		}
+		defer llmClient.Close()
+
+		summaries := warmSummaries(ctx, llmClient, cache, articles, summarize, &stats)
+		warmEmbeddings(llmClient, cache, articles, summaries, &stats)
+	}
+
+	printWarmStats(stats, summarize)
+	return nil
+}
+
+// hasCachedSummaries reports whether any article has a cached summary to embed
+func hasCachedSummaries(cache *store.Store, articles []core.Article) bool {
+	for _, article := range articles {
+		if cachedSummary(cache, article) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// warmSummaries returns each article's summary, indexed like articles: the cached one
+// when there is one and, with summarize, a new one from a single batch job for the
+// rest, cached as it arrives. Articles without a summary are nil.
+func warmSummaries(ctx context.Context, llmClient *llm.Client, cache *store.Store, articles []core.Article, summarize bool, stats *warmStats) []*core.Summary {
+	summaries := make([]*core.Summary, len(articles))
+	var pending []*core.Article
+	var pendingIndex []int
+	for i := range articles {
+		if cached := cachedSummary(cache, articles[i]); cached != nil {
+			summaries[i] = cached
+			stats.summariesCached++
+			continue
+		}
+		if articles[i].CleanedText != "" {
+			pending = append(pending, &articles[i])
+			pendingIndex = append(pendingIndex, i)
+		}
+	}
+	if !summarize || len(pending) == 0 {
+		return summaries
+	}
+
+	fmt.Printf("\n📝 Summarizing %d articles (%d already cached)...\n", len(pending), stats.summariesCached)
+	summarizer := newAudienceSummarizer(&batchLLMClientAdapter{llmClientAdapter: llmClientAdapter{client: llmClient}}, "")
+	results, errs := summarizer.SummarizeArticlesBatch(ctx, pending)
+	for j, summary := range results {
+		article := pending[j]
+		if errs[j] != nil || summary == nil {
+			logger.Get().Warn("Failed to generate summary", "url", article.URL, "error", errs[j])
+			fmt.Printf("   ⚠ %s: %v\n", article.Title, errs[j])
+			continue
+		}
+		if err := cache.CacheSummary(*summary, article.URL, store.SummaryContentHash(*article)); err != nil {
+			logger.Get().Warn("Failed to cache summary", "url", article.URL, "error", err)
+			continue
+		}
+		summaries[pendingIndex[j]] = summary
+		stats.summarized++
+	}
+	fmt.Printf("   ✓ Summarized %d/%d\n", stats.summarized, len(pending))
+	return summaries
+}
+
+// warmEmbeddings embeds each summary that has no embedding yet, from the same text
+// digest from-file embeds, and caches the embedding with the summary
+func warmEmbeddings(llmClient *llm.Client, cache *store.Store, articles []core.Article, summaries []*core.Summary, stats *warmStats) {
+	fmt.Println("\n🧠 Embedding summaries...")
+	for i, summary := range summaries {
+		if summary == nil {
+			continue
+		}
+		if len(summary.Embedding) > 0 {
+			stats.embeddingsCached++
+			continue
+		}
+		embedding, err := llmClient.GenerateEmbedding(embeddingText(summary.SummaryText))
+		if err != nil {
+			logger.Get().Warn("Failed to generate embedding", "url", articles[i].URL, "error", err)
+			continue
+		}
+		summary.Embedding = embedding
+		if err := cache.CacheSummary(*summary, articles[i].URL, store.SummaryContentHash(articles[i])); err != nil {
+			logger.Get().Warn("Failed to cache embedding", "url", articles[i].URL, "error", err)
+			continue
+		}
+		stats.embedded++
+	}
+	fmt.Printf("   ✓ Embedded %d (%d already cached)\n", stats.embedded, stats.embeddingsCached)
+}
+
+// printWarmStats reports how much of the next digest run will come from the cache
+func printWarmStats(stats warmStats, summarize bool) {
+	articles := stats.articlesCached + stats.articlesFetched
+	summaries := stats.summariesCached + stats.summarized
+	embeddings := stats.embeddingsCached + stats.embedded
+
+	fmt.Println("\n🔥 Cache warmed")
+	fmt.Printf("   Articles:   %d/%d cached (%d fetched now)\n", articles, stats.links, stats.articlesFetched)
+	if stats.skipped > 0 || stats.failed > 0 {
+		fmt.Printf("   ⏭  %d skipped, %d failed to fetch\n", stats.skipped, stats.failed)
+	}
+	if articles == 0 {
+		return
+	}
+	fmt.Printf("   Summaries:  %d/%d cached (%d summarized now)\n", summaries, articles, stats.summarized)
+	fmt.Printf("   Embeddings: %d/%d cached (%d embedded now)\n", embeddings, articles, stats.embedded)
+	fmt.Printf("   Expected cache hits on the next run: %.0f%%\n", 100*float64(articles+summaries+embeddings)/float64(stats.links+2*articles))
+	if !summarize && summaries < articles {
+		fmt.Println("💡 Pre-summarize the rest with --summarize")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"briefly/internal/agent"
 	"briefly/internal/core"
 	"briefly/internal/narrative"
+	"briefly/internal/transcript"
 	"context"
 	"fmt"
 	"time"
@@ -73,6 +74,16 @@ func (t *GenerateExecutiveSummaryTool) Execute(ctx context.Context, memory *agen
 		return nil, fmt.Errorf("executive summary generation failed: %w", err)
 	}
 
+	// Key moments quoting a video link to where they are said, while citations are
+	// still generator-local
+	var citedArticles []core.Article
+	for _, c := range clusters {
+		for _, aid := range c.ArticleIDs {
+			citedArticles = append(citedArticles, articles[aid])
+		}
+	}
+	transcript.AttachTimestamps(digestContent.KeyMoments, citedArticles)
+
 	// Remap all citations from generator-local to global
 	digestContent.TLDRSummary = remapCitations(digestContent.TLDRSummary, digestCitationMap)
 	digestContent.WhyItMatters = remapCitations(digestContent.WhyItMatters, digestCitationMap)
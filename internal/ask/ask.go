@@ -0,0 +1,224 @@
+// Package ask answers questions about the archive ('briefly ask'): it retrieves the
+// cached articles closest to the question by embedding, builds a prompt grounded in
+// them, and answers with numbered citations back to the digests that covered them.
+package ask
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxSourceChars caps how much of each article's text goes into the prompt
+const maxSourceChars = 1500
+
+// Embedder embeds the question for retrieval
+type Embedder interface {
+	GenerateEmbeddingContext(ctx context.Context, text string) ([]float64, error)
+}
+
+// Options tune retrieval
+type Options struct {
+	Limit     int     // Most sources passed to the model
+	Threshold float64 // Minimum cosine similarity to the question
+}
+
+// DefaultOptions retrieve up to eight sources at least loosely related to the question
+var DefaultOptions = Options{Limit: 8, Threshold: 0.5}
+
+// Source is a retrieved article and the digests that included it
+type Source struct {
+	Article    core.Article
+	Similarity float64
+	Digests    []core.Digest // Newest first
+}
+
+// Answer is the model's answer and the sources it was grounded in
+type Answer struct {
+	Question string
+	Text     string
+	Sources  []Source // Numbered from 1 in the order given to the model
+}
+
+// noSourcesAnswer is the answer when nothing in the archive is close to the question
+const noSourcesAnswer = "Nothing in the archive covers this question."
+
+var windowPattern = regexp.MustCompile(`(?i)\b(?:last|past|previous)\s+(?:(\d+)\s+)?(day|week|month|year)s?\b`)
+
+// Window infers how far back a question looks from phrases like "last month" or
+// "past 3 weeks", in days. Returns 0 when the question names no period.
+func Window(question string) int {
+	if strings.Contains(strings.ToLower(question), "yesterday") {
+		return 2
+	}
+	match := windowPattern.FindStringSubmatch(question)
+	if match == nil {
+		return 0
+	}
+	count := 1
+	if match[1] != "" {
+		count, _ = strconv.Atoi(match[1])
+	}
+	days := map[string]int{"day": 1, "week": 7, "month": 31, "year": 366}[strings.ToLower(match[2])]
+	return count * days
+}
+
+// Retrieve ranks articles by similarity to the question, keeping those above the
+// threshold, and attaches the digests (matched by article URL) that included each.
+// Articles without embeddings are skipped.
+func Retrieve(ctx context.Context, embedder Embedder, question string, articles []core.Article, digests []core.Digest, opts Options) ([]Source, error) {
+	embedding, err := embedder.GenerateEmbeddingContext(ctx, question)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed question: %w", err)
+	}
+
+	var sources []Source
+	for _, article := range articles {
+		if len(article.Embedding) == 0 {
+			continue
+		}
+		if similarity := llm.CosineSimilarity(embedding, article.Embedding); similarity >= opts.Threshold {
+			sources = append(sources, Source{Article: article, Similarity: similarity})
+		}
+	}
+	sort.SliceStable(sources, func(i, j int) bool { return sources[i].Similarity > sources[j].Similarity })
+	if opts.Limit > 0 && len(sources) > opts.Limit {
+		sources = sources[:opts.Limit]
+	}
+
+	byURL := make(map[string][]core.Digest)
+	for _, digest := range digests {
+		for _, url := range digest.ArticleURLs {
+			byURL[url] = append(byURL[url], digest)
+		}
+	}
+	for i := range sources {
+		matched := byURL[sources[i].Article.URL]
+		sort.SliceStable(matched, func(a, b int) bool { return matched[a].DateGenerated.After(matched[b].DateGenerated) })
+		sources[i].Digests = matched
+	}
+	return sources, nil
+}
+
+// Prompt builds the grounded prompt: the numbered sources and instructions to
+// answer only from them, citing each claim
+func Prompt(question string, sources []Source) string {
+	var b strings.Builder
+	b.WriteString("You answer questions about a personal archive of tech news digests. ")
+	b.WriteString("Answer ONLY from the numbered sources below. Cite every claim with the source number in brackets, e.g. [1] or [2][3]. ")
+	b.WriteString("If the sources don't answer the question, say the archive doesn't cover it rather than guessing. ")
+	b.WriteString("Keep the answer to a few short paragraphs.\n\n")
+
+	b.WriteString("SOURCES:\n\n")
+	for i, source := range sources {
+		article := source.Article
+		fmt.Fprintf(&b, "[%d] %s\n", i+1, article.Title)
+		fmt.Fprintf(&b, "URL: %s\n", article.URL)
+		if !article.DateFetched.IsZero() {
+			fmt.Fprintf(&b, "Fetched: %s\n", article.DateFetched.Format("Jan 2, 2006"))
+		}
+		if len(source.Digests) > 0 {
+			fmt.Fprintf(&b, "Covered in: %s\n", digestLabel(source.Digests[0]))
+		}
+		text := strings.TrimSpace(article.CleanedText)
+		if len(text) > maxSourceChars {
+			text = strings.TrimSpace(strings.ToValidUTF8(text[:maxSourceChars], "")) + "…"
+		}
+		if text != "" {
+			fmt.Fprintf(&b, "%s\n", text)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "QUESTION: %s\n\nANSWER:", question)
+	return b.String()
+}
+
+// Ask retrieves sources for the question and asks the model to answer from them.
+// When nothing relevant is cached, the model isn't called.
+func Ask(ctx context.Context, embedder Embedder, generator llm.Generator, question string, articles []core.Article, digests []core.Digest, opts Options) (*Answer, error) {
+	sources, err := Retrieve(ctx, embedder, question, articles, digests, opts)
+	if err != nil {
+		return nil, err
+	}
+	answer := &Answer{Question: question, Sources: sources}
+	if len(sources) == 0 {
+		answer.Text = noSourcesAnswer
+		return answer, nil
+	}
+
+	text, err := generator.GenerateText(ctx, Prompt(question, sources), llm.TextGenerationOptions{Temperature: 0.2})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate answer: %w", err)
+	}
+	answer.Text = strings.TrimSpace(text)
+	return answer, nil
+}
+
+var citationPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// Cited returns the numbers of the sources the answer cites, in order. When the answer
+// cites none, every source is returned.
+func (a *Answer) Cited() []int {
+	cited := make(map[int]bool)
+	for _, match := range citationPattern.FindAllStringSubmatch(a.Text, -1) {
+		if n, err := strconv.Atoi(match[1]); err == nil && n >= 1 && n <= len(a.Sources) {
+			cited[n] = true
+		}
+	}
+	var numbers []int
+	for n := 1; n <= len(a.Sources); n++ {
+		if cited[n] || len(cited) == 0 {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+// Markdown renders the answer followed by the cited sources and the digests that
+// covered them
+func (a *Answer) Markdown() string {
+	var b strings.Builder
+	b.WriteString(a.Text)
+	b.WriteString("\n")
+
+	cited := a.Cited()
+	if len(cited) == 0 {
+		return b.String()
+	}
+	b.WriteString("\n**Sources**\n\n")
+	for _, n := range cited {
+		source := a.Sources[n-1]
+		fmt.Fprintf(&b, "[%d] [%s](%s)", n, source.Article.Title, source.Article.URL)
+		if len(source.Digests) > 0 {
+			labels := make([]string, len(source.Digests))
+			for i, digest := range source.Digests {
+				labels[i] = digestLabel(digest)
+			}
+			fmt.Fprintf(&b, " — in %s", strings.Join(labels, "; "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// digestLabel names a digest for citations, e.g. "Weekly AI (Oct 12, 2026, 3f9a1c2e)"
+func digestLabel(digest core.Digest) string {
+	id := digest.ID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	title := digest.Title
+	if title == "" {
+		title = "Digest"
+	}
+	if digest.DateGenerated.IsZero() {
+		return fmt.Sprintf("%s (%s)", title, id)
+	}
+	return fmt.Sprintf("%s (%s, %s)", title, digest.DateGenerated.Format("Jan 2, 2006"), id)
+}
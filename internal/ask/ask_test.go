@@ -0,0 +1,95 @@
+package ask
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeModel struct {
+	prompt string
+	answer string
+}
+
+func (f *fakeModel) GenerateEmbeddingContext(ctx context.Context, text string) ([]float64, error) {
+	return []float64{1, 0}, nil
+}
+
+func (f *fakeModel) GenerateText(ctx context.Context, prompt string, options llm.TextGenerationOptions) (string, error) {
+	f.prompt = prompt
+	return f.answer, nil
+}
+
+func testArchive() ([]core.Article, []core.Digest) {
+	articles := []core.Article{
+		{URL: "https://example.com/wasmtime", Title: "Wasmtime 30 ships", CleanedText: "Wasmtime 30 adds component model support.", Embedding: []float64{0.9, 0.1}},
+		{URL: "https://example.com/wasmer", Title: "Wasmer raises prices", Embedding: []float64{1, 0}},
+		{URL: "https://example.com/kotlin", Title: "Kotlin 3", Embedding: []float64{0, 1}},
+		{URL: "https://example.com/unembedded", Title: "Not embedded"},
+	}
+	digests := []core.Digest{
+		{ID: "aaaaaaaa-1111", Title: "Weekly Runtimes", ArticleURLs: []string{"https://example.com/wasmtime"}, DateGenerated: time.Date(2026, 9, 20, 0, 0, 0, 0, time.UTC)},
+		{ID: "bbbbbbbb-2222", Title: "Weekly Runtimes", ArticleURLs: []string{"https://example.com/wasmtime", "https://example.com/wasmer"}, DateGenerated: time.Date(2026, 9, 27, 0, 0, 0, 0, time.UTC)},
+	}
+	return articles, digests
+}
+
+func TestAsk(t *testing.T) {
+	articles, digests := testArchive()
+	model := &fakeModel{answer: "Wasmtime 30 added the component model [2]."}
+
+	answer, err := Ask(context.Background(), model, model, "what about WASM runtimes?", articles, digests, DefaultOptions)
+	if err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+	if len(answer.Sources) != 2 || answer.Sources[0].Article.Title != "Wasmer raises prices" {
+		t.Fatalf("expected the two related articles, closest first, got %+v", answer.Sources)
+	}
+	if digests := answer.Sources[1].Digests; len(digests) != 2 || digests[0].ID != "bbbbbbbb-2222" {
+		t.Errorf("expected both digests newest first, got %+v", digests)
+	}
+	for _, want := range []string{"[1] Wasmer raises prices", "[2] Wasmtime 30 ships", "component model support", "QUESTION: what about WASM runtimes?"} {
+		if !strings.Contains(model.prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, model.prompt)
+		}
+	}
+
+	markdown := answer.Markdown()
+	if !strings.Contains(markdown, "[2] [Wasmtime 30 ships](https://example.com/wasmtime) — in Weekly Runtimes (Sep 27, 2026, bbbbbbbb); Weekly Runtimes (Sep 20, 2026, aaaaaaaa)") {
+		t.Errorf("expected the cited source with its digests, got:\n%s", markdown)
+	}
+	if strings.Contains(markdown, "[1] [Wasmer") {
+		t.Errorf("expected uncited sources left out, got:\n%s", markdown)
+	}
+}
+
+func TestAsk_NoSources(t *testing.T) {
+	articles, digests := testArchive()
+	model := &fakeModel{}
+
+	// Only an unrelated and an unembedded article are left
+	answer, err := Ask(context.Background(), model, model, "what about WASM runtimes?", articles[2:], digests, DefaultOptions)
+	if err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+	if answer.Text != noSourcesAnswer || model.prompt != "" {
+		t.Errorf("expected no model call without sources, got %q", answer.Text)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := map[string]int{
+		"what did we cover about WASM runtimes last month?": 31,
+		"anything on Rust in the past 3 weeks":              21,
+		"what happened yesterday":                           2,
+		"how has the Go scheduler evolved":                  0,
+	}
+	for question, want := range tests {
+		if got := Window(question); got != want {
+			t.Errorf("Window(%q) = %d, want %d", question, got, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package authors matches article bylines against the writers a reader follows
+// (authors.follow) and tallies who they read most.
+package authors
+
+import (
+	"briefly/internal/core"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bylineSeparator splits a byline listing several authors ("A, B and C", "A & B")
+var bylineSeparator = regexp.MustCompile(`\s*(?:,|&|\band\b)\s*`)
+
+// Names splits a byline into author names, dropping a leading "By"
+func Names(byline string) []string {
+	byline = strings.TrimSpace(byline)
+	if len(byline) > 3 && strings.EqualFold(byline[:3], "by ") {
+		byline = byline[3:]
+	}
+
+	var names []string
+	for _, name := range bylineSeparator.Split(byline, -1) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Followed returns the followed author named in byline, as written in follow, or ""
+// when the article isn't by anyone followed. Names match case-insensitively.
+func Followed(byline string, follow []string) string {
+	if byline == "" || len(follow) == 0 {
+		return ""
+	}
+	for _, name := range Names(byline) {
+		for _, followed := range follow {
+			if strings.EqualFold(name, strings.TrimSpace(followed)) {
+				return followed
+			}
+		}
+	}
+	return ""
+}
+
+// Boost raises the signal score of articles by followed authors by boost (capped at
+// 1.0), so they lead their topic. It returns how many articles were boosted.
+func Boost(articles []core.Article, follow []string, boost float64) int {
+	if boost <= 0 || len(follow) == 0 {
+		return 0
+	}
+
+	boosted := 0
+	for i := range articles {
+		if Followed(articles[i].Author, follow) == "" {
+			continue
+		}
+		articles[i].SignalStrength += boost
+		if articles[i].SignalStrength > 1 {
+			articles[i].SignalStrength = 1
+		}
+		boosted++
+	}
+	return boosted
+}
+
+// Stat is how much of one author's writing passed through briefly
+type Stat struct {
+	Author   string
+	Articles int       // Articles by the author in the cache
+	Read     int       // Of those, articles marked read
+	Followed bool      // Listed in authors.follow
+	Latest   time.Time // Most recent publication (or fetch) date
+}
+
+// Tally counts articles per author, with read marks keyed by article URL. Authors are
+// ordered by articles read, then articles seen, then name. Articles without a byline
+// are skipped.
+func Tally(articles []core.Article, read map[string]bool, follow []string) []Stat {
+	byKey := make(map[string]*Stat)
+	for _, article := range articles {
+		date := article.DatePublished
+		if date.IsZero() {
+			date = article.DateFetched
+		}
+
+		for _, name := range Names(article.Author) {
+			key := strings.ToLower(name)
+			stat, ok := byKey[key]
+			if !ok {
+				stat = &Stat{Author: name, Followed: Followed(name, follow) != ""}
+				byKey[key] = stat
+			}
+			stat.Articles++
+			if read[article.URL] {
+				stat.Read++
+			}
+			if date.After(stat.Latest) {
+				stat.Latest = date
+			}
+		}
+	}
+
+	stats := make([]Stat, 0, len(byKey))
+	for _, stat := range byKey {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Read != stats[j].Read {
+			return stats[i].Read > stats[j].Read
+		}
+		if stats[i].Articles != stats[j].Articles {
+			return stats[i].Articles > stats[j].Articles
+		}
+		return stats[i].Author < stats[j].Author
+	})
+	return stats
+}
@@ -0,0 +1,92 @@
+package authors
+
+import (
+	"briefly/internal/core"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		byline string
+		want   []string
+	}{
+		{"Jane Doe", []string{"Jane Doe"}},
+		{"By Jane Doe", []string{"Jane Doe"}},
+		{"Jane Doe, John Roe and Ann Lee", []string{"Jane Doe", "John Roe", "Ann Lee"}},
+		{"Jane Doe & Alexander Smith", []string{"Jane Doe", "Alexander Smith"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := Names(tt.byline); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Names(%q) = %q, want %q", tt.byline, got, tt.want)
+		}
+	}
+}
+
+func TestFollowed(t *testing.T) {
+	follow := []string{"Julia Evans", "Simon Willison"}
+
+	if got := Followed("simon willison", follow); got != "Simon Willison" {
+		t.Errorf("expected a case-insensitive match, got %q", got)
+	}
+	if got := Followed("Jane Doe, Julia Evans", follow); got != "Julia Evans" {
+		t.Errorf("expected a co-author match, got %q", got)
+	}
+	if got := Followed("Julia Evansson", follow); got != "" {
+		t.Errorf("expected no partial-name match, got %q", got)
+	}
+	if got := Followed("Julia Evans", nil); got != "" {
+		t.Errorf("expected no match without follows, got %q", got)
+	}
+}
+
+func TestBoost(t *testing.T) {
+	articles := []core.Article{
+		{ID: "a", Author: "Julia Evans", SignalStrength: 0.5},
+		{ID: "b", Author: "Jane Doe", SignalStrength: 0.5},
+		{ID: "c", Author: "Julia Evans", SignalStrength: 0.9},
+	}
+
+	if boosted := Boost(articles, []string{"Julia Evans"}, 0.2); boosted != 2 {
+		t.Errorf("expected 2 articles boosted, got %d", boosted)
+	}
+	if articles[0].SignalStrength != 0.7 || articles[1].SignalStrength != 0.5 || articles[2].SignalStrength != 1 {
+		t.Errorf("unexpected scores %v, %v, %v", articles[0].SignalStrength, articles[1].SignalStrength, articles[2].SignalStrength)
+	}
+
+	if boosted := Boost(articles, []string{"Julia Evans"}, 0); boosted != 0 {
+		t.Errorf("expected no boost when disabled, got %d", boosted)
+	}
+}
+
+func TestTally(t *testing.T) {
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	articles := []core.Article{
+		{URL: "https://a.example/1", Author: "Jane Doe", DatePublished: day},
+		{URL: "https://a.example/2", Author: "jane doe, John Roe", DateFetched: day.AddDate(0, 0, 2)},
+		{URL: "https://b.example/1", Author: "Ann Lee"},
+		{URL: "https://b.example/2", Author: "Ann Lee"},
+		{URL: "https://b.example/3", Author: "Ann Lee"},
+		{URL: "https://c.example/1"},
+	}
+	read := map[string]bool{"https://a.example/1": true, "https://a.example/2": true}
+
+	stats := Tally(articles, read, []string{"John Roe"})
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 authors, got %+v", stats)
+	}
+	if stats[0].Author != "Jane Doe" || stats[0].Articles != 2 || stats[0].Read != 2 {
+		t.Errorf("expected the most-read author first, got %+v", stats[0])
+	}
+	if !stats[0].Latest.Equal(day.AddDate(0, 0, 2)) {
+		t.Errorf("expected the latest date, got %v", stats[0].Latest)
+	}
+	if stats[1].Author != "John Roe" || !stats[1].Followed {
+		t.Errorf("expected the followed co-author second, got %+v", stats[1])
+	}
+	if stats[2].Author != "Ann Lee" || stats[2].Articles != 3 || stats[2].Read != 0 {
+		t.Errorf("unexpected stat %+v", stats[2])
+	}
+}
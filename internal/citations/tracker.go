@@ -43,16 +43,22 @@ func (t *Tracker) TrackArticle(ctx context.Context, article *core.Article) (*cor
 
 	// Create citation
 	citation := &core.Citation{
-		ID:            uuid.NewString(),
-		ArticleID:     article.ID,
-		URL:           article.URL,
-		Title:         article.Title,
-		Publisher:     publisher,
-		Author:        "",  // Could be extracted from article metadata if available
-		PublishedDate: nil, // Could be extracted from article metadata if available
-		AccessedDate:  article.DateFetched,
-		Metadata:      make(map[string]interface{}),
-		CreatedAt:     time.Now().UTC(),
+		ID:           uuid.NewString(),
+		ArticleID:    article.ID,
+		URL:          article.URL,
+		Title:        article.Title,
+		Publisher:    publisher,
+		Author:       article.Author,
+		AccessedDate: article.DateFetched,
+		Metadata:     make(map[string]interface{}),
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	if !article.DatePublished.IsZero() {
+		citation.PublishedDate = &article.DatePublished
+	}
+	if article.SiteName != "" {
+		citation.Publisher = article.SiteName
 	}
 
 	// Add content type to metadata
@@ -145,17 +145,19 @@ func (m *MockDatabase) Citations() persistence.CitationRepository {
 	return m.citationRepo
 }
 
-func (m *MockDatabase) Articles() persistence.ArticleRepository                   { return nil }
-func (m *MockDatabase) Summaries() persistence.SummaryRepository                   { return nil }
-func (m *MockDatabase) Feeds() persistence.FeedRepository                          { return nil }
-func (m *MockDatabase) FeedItems() persistence.FeedItemRepository                  { return nil }
-func (m *MockDatabase) Digests() persistence.DigestRepository                      { return nil }
-func (m *MockDatabase) Themes() persistence.ThemeRepository                        { return nil }
-func (m *MockDatabase) ManualURLs() persistence.ManualURLRepository                { return nil }
-func (m *MockDatabase) Tags() persistence.TagRepository                            { return nil }
-func (m *MockDatabase) ClusterCoherence() persistence.ClusterCoherenceRepository   { return nil }
-func (m *MockDatabase) Close() error                                               { return nil }
-func (m *MockDatabase) Ping(ctx context.Context) error                             { return nil }
+func (m *MockDatabase) Articles() persistence.ArticleRepository                  { return nil }
+func (m *MockDatabase) Summaries() persistence.SummaryRepository                 { return nil }
+func (m *MockDatabase) Feeds() persistence.FeedRepository                        { return nil }
+func (m *MockDatabase) FeedItems() persistence.FeedItemRepository                { return nil }
+func (m *MockDatabase) Digests() persistence.DigestRepository                    { return nil }
+func (m *MockDatabase) Themes() persistence.ThemeRepository                      { return nil }
+func (m *MockDatabase) ManualURLs() persistence.ManualURLRepository              { return nil }
+func (m *MockDatabase) Tags() persistence.TagRepository                          { return nil }
+func (m *MockDatabase) ClusterCoherence() persistence.ClusterCoherenceRepository { return nil }
+func (m *MockDatabase) Links() persistence.LinkRepository                        { return nil }
+func (m *MockDatabase) LLMCalls() persistence.LLMCallRepository                  { return nil }
+func (m *MockDatabase) Close() error                                             { return nil }
+func (m *MockDatabase) Ping(ctx context.Context) error                           { return nil }
 func (m *MockDatabase) BeginTx(ctx context.Context) (persistence.Transaction, error) {
 	return nil, nil
 }
@@ -0,0 +1,25 @@
+package clustering
+
+import "briefly/internal/core"
+
+// BalanceCoverage caps each cluster at maxPerCluster articles, so one busy topic
+// doesn't turn a digest into a single-topic issue. ArticleIDs must already be in
+// priority order (see quality.SortArticleIDsBySignal): the first maxPerCluster stay,
+// and the rest move to OverflowIDs, which digests list as links only. Returns how
+// many articles were moved; maxPerCluster <= 0 leaves clusters as they are.
+func BalanceCoverage(clusters []core.TopicCluster, maxPerCluster int) int {
+	if maxPerCluster <= 0 {
+		return 0
+	}
+	moved := 0
+	for i := range clusters {
+		ids := clusters[i].ArticleIDs
+		if len(ids) <= maxPerCluster {
+			continue
+		}
+		clusters[i].OverflowIDs = append(clusters[i].OverflowIDs, ids[maxPerCluster:]...)
+		clusters[i].ArticleIDs = ids[:maxPerCluster:maxPerCluster]
+		moved += len(ids) - maxPerCluster
+	}
+	return moved
+}
@@ -0,0 +1,34 @@
+package clustering
+
+import (
+	"briefly/internal/core"
+	"reflect"
+	"testing"
+)
+
+func TestBalanceCoverage(t *testing.T) {
+	clusters := []core.TopicCluster{
+		{ID: "big", ArticleIDs: []string{"a1", "a2", "a3", "a4", "a5"}},
+		{ID: "small", ArticleIDs: []string{"b1", "b2"}},
+	}
+
+	if moved := BalanceCoverage(clusters, 0); moved != 0 || len(clusters[0].ArticleIDs) != 5 {
+		t.Fatalf("expected no cap at 0, moved %d", moved)
+	}
+
+	if moved := BalanceCoverage(clusters, 3); moved != 2 {
+		t.Errorf("expected 2 articles moved, got %d", moved)
+	}
+	if !reflect.DeepEqual(clusters[0].ArticleIDs, []string{"a1", "a2", "a3"}) || !reflect.DeepEqual(clusters[0].OverflowIDs, []string{"a4", "a5"}) {
+		t.Errorf("expected the leading articles kept and the rest overflowed, got %v / %v", clusters[0].ArticleIDs, clusters[0].OverflowIDs)
+	}
+	if len(clusters[1].ArticleIDs) != 2 || clusters[1].OverflowIDs != nil {
+		t.Errorf("expected the small cluster untouched, got %+v", clusters[1])
+	}
+
+	// Appending to the capped cluster must not overwrite its overflow
+	clusters[0].ArticleIDs = append(clusters[0].ArticleIDs, "x")
+	if clusters[0].OverflowIDs[0] != "a4" {
+		t.Errorf("expected overflow unaffected by appends, got %v", clusters[0].OverflowIDs)
+	}
+}
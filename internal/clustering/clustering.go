@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
-	"sort"
 	"time"
 )
 
@@ -18,6 +17,7 @@ type ClusteringAlgorithm interface {
 type KMeansClusterer struct {
 	MaxIterations int
 	Tolerance     float64
+	Seed          int64 // Fixed K-means++ seed for reproducible clusters (0 = random)
 }
 
 // NewKMeansClusterer creates a new K-means clusterer with default parameters
@@ -28,6 +28,12 @@ func NewKMeansClusterer() *KMeansClusterer {
 	}
 }
 
+// AutoClusterCount is how many topics a digest of n articles is clustered into when
+// none is given: about five articles per topic, between 3 and 15
+func AutoClusterCount(n int) int {
+	return min(max((n+4)/5, 3), 15)
+}
+
 // Cluster performs K-means clustering on articles using their embeddings
 func (k *KMeansClusterer) Cluster(articles []core.Article, numClusters int) ([]core.TopicCluster, error) {
 	if len(articles) == 0 {
@@ -122,7 +128,8 @@ func (k *KMeansClusterer) Cluster(articles []core.Article, numClusters int) ([]c
 	// Generate topic labels based on article titles
 	for i := range clusters {
 		clusters[i].Label = k.generateTopicLabel(articlesWithEmbeddings, assignments, i)
-		clusters[i].Keywords = k.extractKeywords(articlesWithEmbeddings, assignments, i)
+		clusters[i].Keywords = k.extractKeywords(articlesWithEmbeddings, assignments, i, clusters[i].Centroid)
+		relabelFromKeywords(&clusters[i])
 	}
 
 	return clusters, nil
@@ -133,7 +140,7 @@ func (k *KMeansClusterer) Cluster(articles []core.Article, numClusters int) ([]c
 // leading to better convergence and cluster quality compared to random initialization
 func (k *KMeansClusterer) initializeCentroids(articles []core.Article, numClusters, embeddingDim int) [][]float64 {
 	centroids := make([][]float64, numClusters)
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rng := rand.New(rand.NewSource(seedOrNow(k.Seed)))
 
 	// Step 1: Choose first centroid randomly
 	firstIndex := rng.Intn(len(articles))
@@ -277,52 +284,15 @@ func (k *KMeansClusterer) generateTopicLabel(articles []core.Article, assignment
 	return fmt.Sprintf("Topic %d", clusterID+1)
 }
 
-// extractKeywords extracts key terms from articles in a cluster
-func (k *KMeansClusterer) extractKeywords(articles []core.Article, assignments []int, clusterID int) []string {
-	wordCounts := make(map[string]int)
-
+// extractKeywords extracts the cluster's TF-IDF keywords against all clustered articles
+func (k *KMeansClusterer) extractKeywords(articles []core.Article, assignments []int, clusterID int, centroid []float64) []string {
+	var members []core.Article
 	for i, assignment := range assignments {
 		if assignment == clusterID {
-			// Extract words from title and first part of content
-			words := extractWords(articles[i].Title)
-			if len(articles[i].CleanedText) > 200 {
-				words = append(words, extractWords(articles[i].CleanedText[:200])...)
-			} else {
-				words = append(words, extractWords(articles[i].CleanedText)...)
-			}
-
-			for _, word := range words {
-				if len(word) > 3 {
-					wordCounts[word]++
-				}
-			}
-		}
-	}
-
-	// Sort words by frequency
-	type wordFreq struct {
-		word  string
-		count int
-	}
-	var sortedWords []wordFreq
-	for word, count := range wordCounts {
-		sortedWords = append(sortedWords, wordFreq{word, count})
-	}
-
-	sort.Slice(sortedWords, func(i, j int) bool {
-		return sortedWords[i].count > sortedWords[j].count
-	})
-
-	// Return top 5 keywords
-	var keywords []string
-	for i, wf := range sortedWords {
-		if i >= 5 {
-			break
+			members = append(members, articles[i])
 		}
-		keywords = append(keywords, wf.word)
 	}
-
-	return keywords
+	return ExtractKeywords(members, articles, centroid, DefaultKeywordCount)
 }
 
 // cosineDistanceKMeans computes cosine distance between two vectors
@@ -460,3 +430,11 @@ func AutoDetectOptimalClusters(articles []core.Article, maxClusters int) (int, e
 
 	return optimalK, nil
 }
+
+// seedOrNow returns seed, or a time-based seed when seed is 0
+func seedOrNow(seed int64) int64 {
+	if seed != 0 {
+		return seed
+	}
+	return time.Now().UnixNano()
+}
@@ -201,7 +201,7 @@ func (h *HDBSCANClusterer) clusteringToTopicClusters(articles []core.Article, cl
 		label := h.generateTopicLabel(clusterArticles)
 
 		// Extract keywords
-		keywords := extractKeywordsFromArticles(clusterArticles)
+		keywords := extractKeywordsFromArticles(clusterArticles, articles, centroid)
 
 		cluster := core.TopicCluster{
 			ID:         fmt.Sprintf("hdbscan_cluster_%d", clusterID),
@@ -211,6 +211,7 @@ func (h *HDBSCANClusterer) clusteringToTopicClusters(articles []core.Article, cl
 			Keywords:   keywords,
 			CreatedAt:  time.Now().UTC(),
 		}
+		relabelFromKeywords(&cluster)
 
 		clusters = append(clusters, cluster)
 	}
@@ -281,55 +282,9 @@ func calculateCentroid(articles []core.Article) []float64 {
 	return centroid
 }
 
-// extractKeywordsFromArticles extracts key terms from a set of articles
-func extractKeywordsFromArticles(articles []core.Article) []string {
-	wordCounts := make(map[string]int)
-
-	for _, article := range articles {
-		// Extract words from title and first part of content
-		words := extractWords(article.Title)
-		if len(article.CleanedText) > 200 {
-			words = append(words, extractWords(article.CleanedText[:200])...)
-		} else {
-			words = append(words, extractWords(article.CleanedText)...)
-		}
-
-		for _, word := range words {
-			if len(word) > 3 {
-				wordCounts[word]++
-			}
-		}
-	}
-
-	// Sort words by frequency
-	type wordFreq struct {
-		word  string
-		count int
-	}
-	var sortedWords []wordFreq
-	for word, count := range wordCounts {
-		sortedWords = append(sortedWords, wordFreq{word, count})
-	}
-
-	// Sort by count descending
-	for i := 0; i < len(sortedWords)-1; i++ {
-		for j := i + 1; j < len(sortedWords); j++ {
-			if sortedWords[j].count > sortedWords[i].count {
-				sortedWords[i], sortedWords[j] = sortedWords[j], sortedWords[i]
-			}
-		}
-	}
-
-	// Return top 5 keywords
-	var keywords []string
-	for i, wf := range sortedWords {
-		if i >= 5 {
-			break
-		}
-		keywords = append(keywords, wf.word)
-	}
-
-	return keywords
+// extractKeywordsFromArticles extracts a cluster's TF-IDF keywords against corpus
+func extractKeywordsFromArticles(members, corpus []core.Article, centroid []float64) []string {
+	return ExtractKeywords(members, corpus, centroid, DefaultKeywordCount)
 }
 
 // getArticleIDs extracts article IDs from a list of articles
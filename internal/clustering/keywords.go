@@ -0,0 +1,322 @@
+package clustering
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/language"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultKeywordCount is the number of keywords extracted per cluster
+const DefaultKeywordCount = 5
+
+// keywordTextLimit caps how much article text (in runes) is tokenized per document
+const keywordTextLimit = 3000
+
+// keywordToken matches candidate terms: words in any script, acronyms, and names like
+// GPT-4o or C++
+var keywordToken = regexp.MustCompile(`\p{L}[\p{L}\p{N}]*(?:[-.+][\p{L}\p{N}+]+)*`)
+
+// placeholderLabel matches generated labels that carry no topic information
+var placeholderLabel = regexp.MustCompile(`^(.* - )?((Cluster|Topic) \d+|\S+ & Related)$`)
+
+// keywordStopwords are dropped before scoring. Besides common English function words
+// the list covers words that are frequent in tech news but say nothing about a topic.
+// Function words of other languages are dropped too (see language.IsStopword).
+var keywordStopwords = wordSet(`
+		a about above after again against all also am an and any are aren as at be because
+		been before being below between both but by can cannot could did do does doing down
+		during each few for from further had has have having he her here hers herself him
+		himself his how i if in into is it its itself just let me more most my myself no nor
+		not now of off on once only or other our ours ourselves out over own same she should
+		so some such than that the their theirs them themselves then there these they this
+		those through to too under until up very was we were what when where which while who
+		whom why will with would you your yours yourself yourselves
+		across already although among another anything around away back become becomes
+		began best better big come comes could day days did done each else even ever every
+		first get gets getting give go goes going good got great however i'm instead it's
+		know last later least less like likely little long look made make makes making many
+		may maybe means might much must need needs new next now often one ones part per
+		perhaps possible put quite rather read really right said say says see seems set
+		several since still take takes thing things think though three time times today
+		together two us use used uses using want way ways week well whether within without
+		work works year years yet
+		article blog click content email follow http https link newsletter post posts
+		privacy share sign subscribe www com html
+	`)
+
+// keywordDocument is one tokenized article
+type keywordDocument struct {
+	terms   map[string]float64 // Term -> weighted occurrence count
+	surface map[string]string  // Term -> original spelling for display
+}
+
+// ExtractKeywords returns the terms that best distinguish members from the rest of
+// corpus, scored by TF-IDF: a term counts for a cluster when it recurs across its
+// members and is rare in the other articles of the run. When centroid is set, members
+// closer to it in embedding space weigh more, so outliers pull keywords less.
+// Bigrams compete with single words; a word already covered by a chosen phrase is
+// skipped. Keywords keep their most common original spelling (e.g. "OpenAI").
+func ExtractKeywords(members, corpus []core.Article, centroid []float64, limit int) []string {
+	if len(corpus) == 0 {
+		corpus = members
+	}
+	return newKeywordIndex(corpus, nil).keywords(members, centroid, limit)
+}
+
+// AssignKeywords sets Keywords on every cluster from its member articles, using all
+// articles as the TF-IDF corpus and summary text when available. Clusters with a
+// placeholder label ("Cluster 3", "Topic 1", "word & Related") or one built from
+// earlier keywords are relabeled from their top keywords.
+func AssignKeywords(clusters []core.TopicCluster, articles []core.Article, summaries map[string]core.Summary) {
+	articleMap := make(map[string]core.Article, len(articles))
+	for _, article := range articles {
+		articleMap[article.ID] = article
+	}
+	index := newKeywordIndex(articles, summaries)
+
+	for i := range clusters {
+		members := make([]core.Article, 0, len(clusters[i].ArticleIDs))
+		for _, articleID := range clusters[i].ArticleIDs {
+			if article, ok := articleMap[articleID]; ok {
+				members = append(members, article)
+			}
+		}
+
+		keywords := index.keywords(members, clusters[i].Centroid, DefaultKeywordCount)
+		if len(keywords) == 0 {
+			continue
+		}
+		// Labels built from the previous keywords follow the new ones
+		if previous := LabelFromKeywords(clusters[i].Keywords); previous != "" && strings.HasSuffix(clusters[i].Label, previous) {
+			clusters[i].Label = strings.TrimSuffix(clusters[i].Label, previous) + LabelFromKeywords(keywords)
+		}
+		clusters[i].Keywords = keywords
+		relabelFromKeywords(&clusters[i])
+	}
+}
+
+// relabelFromKeywords replaces a placeholder label with one built from the cluster's
+// keywords, keeping any tag prefix such as "AI - "
+func relabelFromKeywords(cluster *core.TopicCluster) {
+	if len(cluster.Keywords) == 0 {
+		return
+	}
+	if match := placeholderLabel.FindStringSubmatch(cluster.Label); match != nil {
+		cluster.Label = match[1] + LabelFromKeywords(cluster.Keywords)
+	}
+}
+
+// LabelFromKeywords builds a short human-readable label from the top two keywords
+func LabelFromKeywords(keywords []string) string {
+	switch len(keywords) {
+	case 0:
+		return ""
+	case 1:
+		return displayKeyword(keywords[0])
+	default:
+		return displayKeyword(keywords[0]) + " & " + displayKeyword(keywords[1])
+	}
+}
+
+// keywordIndex holds the tokenized corpus and its document frequencies
+type keywordIndex struct {
+	summaries map[string]core.Summary
+	docs      map[string]keywordDocument // Article ID -> tokens
+	docFreq   map[string]int
+	size      float64
+}
+
+// newKeywordIndex tokenizes corpus once so every cluster is scored against it
+func newKeywordIndex(corpus []core.Article, summaries map[string]core.Summary) *keywordIndex {
+	index := &keywordIndex{
+		summaries: summaries,
+		docs:      make(map[string]keywordDocument, len(corpus)),
+		docFreq:   make(map[string]int),
+		size:      float64(len(corpus)),
+	}
+	for _, article := range corpus {
+		doc := tokenizeArticle(article, summaries)
+		index.docs[article.ID] = doc
+		for term := range doc.terms {
+			index.docFreq[term]++
+		}
+	}
+	return index
+}
+
+// keywords scores the terms of members against the corpus and returns the top limit
+func (idx *keywordIndex) keywords(members []core.Article, centroid []float64, limit int) []string {
+	if len(members) == 0 || limit <= 0 {
+		return nil
+	}
+
+	scores := make(map[string]float64)
+	memberDocs := make(map[string]int)
+	surfaceCounts := make(map[string]map[string]int)
+	for _, article := range members {
+		doc, ok := idx.docs[article.ID]
+		if !ok {
+			doc = tokenizeArticle(article, idx.summaries)
+		}
+		weight := memberWeight(article.Embedding, centroid)
+
+		for term, count := range doc.terms {
+			scores[term] += weight * (1 + math.Log(count))
+			memberDocs[term]++
+
+			if surfaceCounts[term] == nil {
+				surfaceCounts[term] = make(map[string]int)
+			}
+			surfaceCounts[term][doc.surface[term]]++
+		}
+	}
+
+	type scoredTerm struct {
+		term  string
+		score float64
+	}
+	ranked := make([]scoredTerm, 0, len(scores))
+	for term, tf := range scores {
+		// Phrases must recur across members unless the cluster is a single article
+		if strings.Contains(term, " ") && len(members) > 1 && memberDocs[term] < 2 {
+			continue
+		}
+
+		idf := math.Log((1+idx.size)/(1+float64(idx.docFreq[term]))) + 1
+		coverage := float64(memberDocs[term]) / float64(len(members))
+		score := tf * idf * coverage
+		if strings.Contains(term, " ") {
+			score *= 1.5
+		}
+		ranked = append(ranked, scoredTerm{term, score})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].term < ranked[j].term
+	})
+
+	var keywords []string
+	chosenWords := make(map[string]bool)
+	for _, candidate := range ranked {
+		if len(keywords) >= limit {
+			break
+		}
+
+		words := strings.Fields(candidate.term)
+		overlaps := false
+		for _, word := range words {
+			if chosenWords[word] {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			continue
+		}
+
+		for _, word := range words {
+			chosenWords[word] = true
+		}
+		keywords = append(keywords, mostCommonSurface(surfaceCounts[candidate.term], candidate.term))
+	}
+
+	return keywords
+}
+
+// tokenizeArticle counts unigram and bigram terms in an article's title (counted
+// twice), summary, and leading text
+func tokenizeArticle(article core.Article, summaries map[string]core.Summary) keywordDocument {
+	doc := keywordDocument{
+		terms:   make(map[string]float64),
+		surface: make(map[string]string),
+	}
+
+	text := article.CleanedText
+	if text == "" {
+		text = article.RawContent
+	}
+	if runes := []rune(text); len(runes) > keywordTextLimit {
+		text = string(runes[:keywordTextLimit])
+	}
+
+	sections := []string{article.Title, article.Title, text}
+	if summary, ok := summaries[article.ID]; ok {
+		sections = append(sections, summary.SummaryText)
+	}
+
+	for _, section := range sections {
+		var previous, previousSurface string
+		for _, token := range keywordToken.FindAllString(section, -1) {
+			term := strings.ToLower(token)
+			if len(term) < 3 || keywordStopwords[term] || language.IsStopword(term, "") {
+				previous = ""
+				continue
+			}
+
+			doc.terms[term]++
+			doc.surface[term] = token
+
+			if previous != "" {
+				bigram := previous + " " + term
+				doc.terms[bigram]++
+				doc.surface[bigram] = previousSurface + " " + token
+			}
+			previous, previousSurface = term, token
+		}
+	}
+
+	return doc
+}
+
+// memberWeight down-weights members far from the cluster centroid. Without
+// embeddings every member weighs 1.
+func memberWeight(embedding, centroid []float64) float64 {
+	if len(embedding) == 0 || len(centroid) == 0 || len(embedding) != len(centroid) {
+		return 1
+	}
+	similarity := 1 - cosineDistanceKMeans(embedding, centroid)
+	return 0.5 + 0.5*math.Max(0, similarity)
+}
+
+// mostCommonSurface returns the spelling seen most often for a term
+func mostCommonSurface(counts map[string]int, fallback string) string {
+	best, bestCount := fallback, 0
+	for surface, count := range counts {
+		if count > bestCount || (count == bestCount && surface < best) {
+			best, bestCount = surface, count
+		}
+	}
+	return best
+}
+
+// displayKeyword capitalizes all-lowercase keywords for labels, leaving names and
+// acronyms with their own casing
+func displayKeyword(keyword string) string {
+	if keyword != strings.ToLower(keyword) {
+		return keyword
+	}
+
+	words := strings.Fields(keyword)
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// wordSet builds a lookup set from whitespace-separated words
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,90 @@
+package clustering
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+)
+
+func keywordTestArticles() []core.Article {
+	return []core.Article{
+		{ID: "k1", Title: "Kubernetes autoscaling gets smarter", CleanedText: "The Kubernetes scheduler now supports vertical pod autoscaling for stateful workloads."},
+		{ID: "k2", Title: "Running Kubernetes on bare metal", CleanedText: "Operators compare pod autoscaling and cluster autoscaling on bare metal Kubernetes."},
+		{ID: "l1", Title: "OpenAI ships a faster reasoning model", CleanedText: "OpenAI released a reasoning model with lower latency for coding agents."},
+		{ID: "l2", Title: "Coding agents benchmarked", CleanedText: "A benchmark of coding agents built on OpenAI and Anthropic reasoning models."},
+	}
+}
+
+func TestExtractKeywords_FavorsDistinctiveTerms(t *testing.T) {
+	articles := keywordTestArticles()
+
+	keywords := ExtractKeywords(articles[:2], articles, nil, 3)
+	if len(keywords) == 0 {
+		t.Fatal("Expected keywords for the Kubernetes cluster")
+	}
+	if !strings.EqualFold(keywords[0], "Kubernetes") && !strings.Contains(strings.ToLower(keywords[0]), "autoscaling") {
+		t.Errorf("Expected a Kubernetes or autoscaling term first, got %v", keywords)
+	}
+	for _, keyword := range keywords {
+		if strings.Contains(strings.ToLower(keyword), "openai") {
+			t.Errorf("Keyword %q belongs to the other cluster: %v", keyword, keywords)
+		}
+	}
+}
+
+func TestExtractKeywords_NonEnglish(t *testing.T) {
+	german := []core.Article{
+		{ID: "d1", Title: "Die neue Verschlüsselung für das Rechenzentrum", CleanedText: "Die Verschlüsselung im Rechenzentrum wird mit dem neuen Schlüsselverwaltung schneller, und das Team hat die Verschlüsselung auch für Backups aktiviert."},
+		{ID: "d2", Title: "Verschlüsselung und Schlüsselverwaltung im Überblick", CleanedText: "Wie die Verschlüsselung der Daten im Rechenzentrum mit der Schlüsselverwaltung zusammenhängt, ist für das Team nicht neu."},
+	}
+	corpus := append(german, keywordTestArticles()...)
+
+	keywords := ExtractKeywords(german, corpus, nil, 5)
+	if len(keywords) == 0 || !strings.EqualFold(keywords[0], "Verschlüsselung") {
+		t.Fatalf("Expected the accented term kept whole and ranked first, got %v", keywords)
+	}
+	for _, keyword := range keywords {
+		for _, word := range strings.Fields(strings.ToLower(keyword)) {
+			if word == "die" || word == "das" || word == "und" || word == "für" {
+				t.Errorf("Keyword %q contains a German function word: %v", keyword, keywords)
+			}
+		}
+	}
+}
+
+func TestAssignKeywords_RelabelsPlaceholders(t *testing.T) {
+	articles := keywordTestArticles()
+	clusters := []core.TopicCluster{
+		{ID: "c1", Label: "Cluster 1", ArticleIDs: []string{"k1", "k2"}},
+		{ID: "c2", Label: "AI - Cluster 2", ArticleIDs: []string{"l1", "l2"}},
+		{ID: "c3", Label: "Infrastructure Weekly", ArticleIDs: []string{"k1"}},
+	}
+
+	AssignKeywords(clusters, articles, map[string]core.Summary{
+		"l1": {SummaryText: "OpenAI's new reasoning model cuts latency for coding agents."},
+	})
+
+	for _, cluster := range clusters {
+		if len(cluster.Keywords) == 0 {
+			t.Errorf("Expected keywords on %s", cluster.ID)
+		}
+	}
+	if clusters[0].Label == "Cluster 1" || !strings.Contains(clusters[0].Label, "&") {
+		t.Errorf("Expected a keyword label, got %q", clusters[0].Label)
+	}
+	if !strings.HasPrefix(clusters[1].Label, "AI - ") || clusters[1].Label == "AI - Cluster 2" {
+		t.Errorf("Expected the tag prefix kept with a keyword label, got %q", clusters[1].Label)
+	}
+	if clusters[2].Label != "Infrastructure Weekly" {
+		t.Errorf("Expected a descriptive label to be kept, got %q", clusters[2].Label)
+	}
+}
+
+func TestLabelFromKeywords(t *testing.T) {
+	if got := LabelFromKeywords([]string{"coding agents", "OpenAI", "latency"}); got != "Coding Agents & OpenAI" {
+		t.Errorf("Unexpected label %q", got)
+	}
+	if got := LabelFromKeywords(nil); got != "" {
+		t.Errorf("Expected empty label, got %q", got)
+	}
+}
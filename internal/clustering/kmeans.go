@@ -5,7 +5,6 @@ import (
 	"log/slog"
 	"math"
 	"math/rand"
-	"sort"
 	"time"
 
 	"briefly/internal/core"
@@ -20,6 +19,7 @@ type KMeansConfig struct {
 	MaxK          int     // Maximum number of clusters for auto-detection
 	MinSilhouette float64 // Minimum acceptable silhouette score
 	UseOptimalK   bool    // Whether to auto-detect optimal K using silhouette
+	Seed          int64   // Fixed K-means++ seed for reproducible clusters (0 = random)
 }
 
 // DefaultKMeansConfig returns sensible defaults for K-means clustering
@@ -196,7 +196,7 @@ func (km *KMeansClustererV2) initializeCentroidsKMeansPP(
 	embeddingDim int,
 ) [][]float64 {
 	centroids := make([][]float64, k)
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rng := rand.New(rand.NewSource(seedOrNow(km.config.Seed)))
 
 	// Step 1: Choose first centroid randomly
 	firstIndex := rng.Intn(len(embeddings))
@@ -334,7 +334,8 @@ func (km *KMeansClustererV2) buildClusters(
 	// Generate labels and keywords
 	for i := range clusters {
 		clusters[i].Label = km.generateTopicLabel(articles, assignments, i)
-		clusters[i].Keywords = km.extractKeywords(articles, assignments, i)
+		clusters[i].Keywords = km.extractKeywords(articles, assignments, i, centroids[i])
+		relabelFromKeywords(&clusters[i])
 	}
 
 	return clusters
@@ -385,56 +386,20 @@ func (km *KMeansClustererV2) generateTopicLabel(
 	return fmt.Sprintf("Topic %d", clusterID+1)
 }
 
-// extractKeywords extracts key terms from articles in a cluster
+// extractKeywords extracts the cluster's TF-IDF keywords against all clustered articles
 func (km *KMeansClustererV2) extractKeywords(
 	articles []core.Article,
 	assignments []int,
 	clusterID int,
+	centroid []float64,
 ) []string {
-	wordCounts := make(map[string]int)
-
+	var members []core.Article
 	for i, assignment := range assignments {
 		if assignment == clusterID {
-			// Extract from title and content preview
-			words := extractWords(articles[i].Title)
-			contentPreview := articles[i].CleanedText
-			if len(contentPreview) > 200 {
-				contentPreview = contentPreview[:200]
-			}
-			words = append(words, extractWords(contentPreview)...)
-
-			for _, word := range words {
-				if len(word) > 3 {
-					wordCounts[word]++
-				}
-			}
+			members = append(members, articles[i])
 		}
 	}
-
-	// Sort by frequency
-	type wordFreq struct {
-		word  string
-		count int
-	}
-	var sortedWords []wordFreq
-	for word, count := range wordCounts {
-		sortedWords = append(sortedWords, wordFreq{word, count})
-	}
-
-	sort.Slice(sortedWords, func(i, j int) bool {
-		return sortedWords[i].count > sortedWords[j].count
-	})
-
-	// Return top 5 keywords
-	var keywords []string
-	for i, wf := range sortedWords {
-		if i >= 5 {
-			break
-		}
-		keywords = append(keywords, wf.word)
-	}
-
-	return keywords
+	return ExtractKeywords(members, articles, centroid, DefaultKeywordCount)
 }
 
 // prepareData extracts embeddings and filters articles
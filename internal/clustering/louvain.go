@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"sort"
 	"time"
 
 	"briefly/internal/logger"
@@ -24,6 +26,7 @@ type LouvainClusterer struct {
 	maxNeighbors   int            // k for k-NN graph building
 	minClusterSize int            // Minimum articles per cluster
 	tagAware       bool           // Whether to cluster within tag boundaries
+	seed           int64          // Fixed seed for reproducible communities (0 = random)
 	log            *slog.Logger
 }
 
@@ -72,6 +75,22 @@ func (l *LouvainClusterer) WithTagAware(enabled bool) *LouvainClusterer {
 	return l
 }
 
+// WithSeed fixes the seed of community detection, so the same graph always yields the
+// same communities (0 = random)
+func (l *LouvainClusterer) WithSeed(seed int64) *LouvainClusterer {
+	l.seed = seed
+	return l
+}
+
+// randSource returns a fresh source for one Modularize call: seeded when a seed is
+// set, nil (random) otherwise
+func (l *LouvainClusterer) randSource() rand.Source {
+	if l.seed == 0 {
+		return nil
+	}
+	return rand.NewPCG(uint64(l.seed), 0)
+}
+
 // ClusterArticles performs Louvain community detection on articles
 func (l *LouvainClusterer) ClusterArticles(ctx context.Context, articles []core.Article, embeddings map[string][]float64) ([]core.TopicCluster, error) {
 	if len(articles) == 0 {
@@ -112,7 +131,7 @@ func (l *LouvainClusterer) ClusterArticles(ctx context.Context, articles []core.
 	l.log.Info(fmt.Sprintf("   Built similarity graph: %d nodes, %d edges", len(articlesWithEmbeddings), edgeCount))
 
 	// Run Louvain community detection (optimizes modularity Q)
-	reducedGraph := community.Modularize(g, l.resolution, nil)
+	reducedGraph := community.Modularize(g, l.resolution, l.randSource())
 	communities := reducedGraph.Communities()
 
 	// Calculate modularity score
@@ -328,7 +347,15 @@ func (l *LouvainClusterer) clusterByTheme(ctx context.Context, articles []core.A
 	var allClusters []core.TopicCluster
 	clusterIndex := 0
 
-	for themeID, themeArticles := range themeGroups {
+	// Themes in a fixed order, so clusters come out in the same order every run
+	themeIDs := make([]string, 0, len(themeGroups))
+	for themeID := range themeGroups {
+		themeIDs = append(themeIDs, themeID)
+	}
+	sort.Strings(themeIDs)
+
+	for _, themeID := range themeIDs {
+		themeArticles := themeGroups[themeID]
 		if len(themeArticles) == 0 {
 			continue
 		}
@@ -356,7 +383,7 @@ func (l *LouvainClusterer) clusterByTheme(ctx context.Context, articles []core.A
 			themeClusters = l.createSingleCluster(articlesWithEmbeddings, embeddings)
 		} else {
 			// Run Louvain
-			reducedGraph := community.Modularize(g, l.resolution, nil)
+			reducedGraph := community.Modularize(g, l.resolution, l.randSource())
 			communities := reducedGraph.Communities()
 			themeClusters = l.buildClusters(communities, articlesWithEmbeddings, articleIDMap, nodeIDMap, embeddings)
 		}
@@ -389,7 +416,7 @@ func (l *LouvainClusterer) clusterByTheme(ctx context.Context, articles []core.A
 			if g.Edges().Len() == 0 {
 				untaggedClusters = l.createSingleCluster(articlesWithEmbeddings, embeddings)
 			} else {
-				reducedGraph := community.Modularize(g, l.resolution, nil)
+				reducedGraph := community.Modularize(g, l.resolution, l.randSource())
 				communities := reducedGraph.Communities()
 				untaggedClusters = l.buildClusters(communities, articlesWithEmbeddings, articleIDMap, nodeIDMap, embeddings)
 			}
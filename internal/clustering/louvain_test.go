@@ -3,6 +3,8 @@ package clustering
 import (
 	"briefly/internal/core"
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -337,6 +339,46 @@ func TestLouvainClusterer_TagAwareClustering(t *testing.T) {
 	}
 }
 
+func TestLouvainClusterer_Seed(t *testing.T) {
+	themes := []string{"ai-ml", "devops", "security"}
+	similarityMap := make(map[string][]SearchResult)
+	var articles []core.Article
+	embeddings := make(map[string][]float64)
+	for i := 0; i < 9; i++ {
+		id := fmt.Sprintf("article-%d", i)
+		articles = append(articles, core.Article{ID: id, ThemeID: &themes[i%3]})
+		embeddings[id] = make([]float64, 8)
+		for j := 0; j < 9; j++ {
+			if j != i && j%3 == i%3 {
+				similarityMap[id] = append(similarityMap[id], SearchResult{ArticleID: fmt.Sprintf("article-%d", j), Similarity: 0.5 + float64(i+j)/100})
+			}
+		}
+	}
+
+	run := func() string {
+		clusterer := NewLouvainClusterer(&mockVectorSearcher{similarityMap: similarityMap}).
+			WithTagAware(true).
+			WithMinClusterSize(1).
+			WithSeed(7)
+		clusters, err := clusterer.ClusterArticles(context.Background(), articles, embeddings)
+		if err != nil {
+			t.Fatalf("ClusterArticles failed: %v", err)
+		}
+		var out strings.Builder
+		for _, cluster := range clusters {
+			fmt.Fprintf(&out, "%s=%v;", cluster.ID, cluster.ArticleIDs)
+		}
+		return out.String()
+	}
+
+	first := run()
+	for i := 0; i < 5; i++ {
+		if got := run(); got != first {
+			t.Fatalf("expected identical clusters with a fixed seed:\n%s\n%s", first, got)
+		}
+	}
+}
+
 // Helper function for test
 func contains(s, substr string) bool {
 	for i := 0; i <= len(s)-len(substr); i++ {
@@ -0,0 +1,54 @@
+package clustering
+
+import (
+	"briefly/internal/core"
+	"fmt"
+	"strings"
+)
+
+// EmbeddingSource is the text articles are embedded from for clustering
+type EmbeddingSource string
+
+const (
+	// SourceSummary embeds the article's summary: a few hundred characters already
+	// distilled to the story, so it's cheap and keeps boilerplate out of the vector
+	SourceSummary EmbeddingSource = "summary"
+	// SourceFullText embeds the article's cleaned text, up to maxFullTextChars
+	SourceFullText EmbeddingSource = "full-text"
+)
+
+// maxFullTextChars keeps full-text embeddings within the embedding model's input
+// limit (2,048 tokens for gemini-embedding-001)
+const maxFullTextChars = 8000
+
+// ParseEmbeddingSource accepts "summary" or "full-text"; empty means summary
+func ParseEmbeddingSource(name string) (EmbeddingSource, error) {
+	switch EmbeddingSource(strings.ToLower(strings.TrimSpace(name))) {
+	case "", SourceSummary:
+		return SourceSummary, nil
+	case SourceFullText, "fulltext", "full_text":
+		return SourceFullText, nil
+	default:
+		return "", fmt.Errorf("unknown embedding source %q (available: %s, %s)", name, SourceSummary, SourceFullText)
+	}
+}
+
+// EmbeddingText returns the text an article is embedded from. Full text falls back to
+// the summary when the article has no text, and is cut at a word boundary.
+func EmbeddingText(article core.Article, summaryText string, source EmbeddingSource) string {
+	if source != SourceFullText || strings.TrimSpace(article.CleanedText) == "" {
+		return summaryText
+	}
+	text := strings.TrimSpace(article.Title + "\n\n" + article.CleanedText)
+	if len(text) <= maxFullTextChars {
+		return text
+	}
+	cut := strings.LastIndexAny(text[:maxFullTextChars], " \n\t")
+	if cut <= 0 {
+		cut = maxFullTextChars
+		for cut > 0 && text[cut]&0xC0 == 0x80 { // Don't split a UTF-8 sequence
+			cut--
+		}
+	}
+	return text[:cut]
+}
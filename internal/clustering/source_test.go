@@ -0,0 +1,34 @@
+package clustering
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+)
+
+func TestParseEmbeddingSource(t *testing.T) {
+	for name, want := range map[string]EmbeddingSource{"": SourceSummary, "Summary": SourceSummary, "full-text": SourceFullText, "full_text": SourceFullText} {
+		if got, err := ParseEmbeddingSource(name); err != nil || got != want {
+			t.Errorf("ParseEmbeddingSource(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseEmbeddingSource("title"); err == nil {
+		t.Error("expected an unknown source to be rejected")
+	}
+}
+
+func TestEmbeddingText(t *testing.T) {
+	article := core.Article{Title: "Title", CleanedText: strings.Repeat("word ", 3000)}
+	if got := EmbeddingText(article, "the summary", SourceSummary); got != "the summary" {
+		t.Errorf("expected the summary, got %q", got)
+	}
+
+	got := EmbeddingText(article, "the summary", SourceFullText)
+	if !strings.HasPrefix(got, "Title\n\nword") || len(got) > maxFullTextChars || strings.HasSuffix(got, " wor") {
+		t.Errorf("expected the title and text cut at a word boundary within %d chars, got %d chars ending %q", maxFullTextChars, len(got), got[len(got)-10:])
+	}
+
+	if got := EmbeddingText(core.Article{Title: "Empty"}, "the summary", SourceFullText); got != "the summary" {
+		t.Errorf("expected an article without text to fall back to its summary, got %q", got)
+	}
+}
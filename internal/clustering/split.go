@@ -0,0 +1,68 @@
+package clustering
+
+import (
+	"briefly/internal/core"
+	"sort"
+)
+
+// SplitIntoParts splits an oversized run's clusters into parts of a multi-part digest.
+// A run with at least splitAt articles is split into parts of about even size, each
+// under splitAt articles; smaller runs (or splitAt <= 1) stay one part. Clusters are
+// ordered by priority (the summed signal of their articles, so big, high-signal
+// topics lead Part 1) and are never divided, so a single cluster of splitAt or more
+// articles makes a part of its own.
+func SplitIntoParts(clusters []core.TopicCluster, articles map[string]core.Article, splitAt int) [][]core.TopicCluster {
+	total := 0
+	for _, cluster := range clusters {
+		total += len(cluster.ArticleIDs)
+	}
+	if splitAt <= 1 || total < splitAt {
+		return [][]core.TopicCluster{clusters}
+	}
+
+	ordered := make([]core.TopicCluster, len(clusters))
+	copy(ordered, clusters)
+	priority := make(map[string]float64, len(ordered))
+	for _, cluster := range ordered {
+		priority[cluster.ID] = clusterPriority(cluster, articles)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return priority[ordered[i].ID] > priority[ordered[j].ID]
+	})
+
+	numParts := (total + splitAt - 2) / (splitAt - 1)
+	target := (total + numParts - 1) / numParts
+
+	var parts [][]core.TopicCluster
+	var current []core.TopicCluster
+	size := 0
+	for _, cluster := range ordered {
+		if len(cluster.ArticleIDs) == 0 {
+			continue
+		}
+		n := len(cluster.ArticleIDs)
+		if size > 0 && (size+n > target && len(parts) < numParts-1 || size+n >= splitAt) {
+			parts = append(parts, current)
+			current, size = nil, 0
+		}
+		current = append(current, cluster)
+		size += n
+	}
+	if len(current) > 0 {
+		parts = append(parts, current)
+	}
+	return parts
+}
+
+// clusterPriority sums the signal of a cluster's articles; unscored articles count 0.5
+func clusterPriority(cluster core.TopicCluster, articles map[string]core.Article) float64 {
+	priority := 0.0
+	for _, id := range cluster.ArticleIDs {
+		signal := articles[id].SignalStrength
+		if signal == 0 {
+			signal = 0.5
+		}
+		priority += signal
+	}
+	return priority
+}
@@ -0,0 +1,62 @@
+package clustering
+
+import (
+	"briefly/internal/core"
+	"fmt"
+	"testing"
+)
+
+// sizedClusters builds clusters with the given article counts; articles in cluster i
+// have signal signals[i]
+func sizedClusters(sizes []int, signals []float64) ([]core.TopicCluster, map[string]core.Article) {
+	clusters := make([]core.TopicCluster, len(sizes))
+	articles := make(map[string]core.Article)
+	for i, size := range sizes {
+		clusters[i].ID = fmt.Sprintf("c%d", i)
+		for j := 0; j < size; j++ {
+			id := fmt.Sprintf("c%d-a%d", i, j)
+			clusters[i].ArticleIDs = append(clusters[i].ArticleIDs, id)
+			articles[id] = core.Article{ID: id, SignalStrength: signals[i]}
+		}
+	}
+	return clusters, articles
+}
+
+func partSizes(parts [][]core.TopicCluster) []int {
+	sizes := make([]int, len(parts))
+	for i, part := range parts {
+		for _, cluster := range part {
+			sizes[i] += len(cluster.ArticleIDs)
+		}
+	}
+	return sizes
+}
+
+func TestSplitIntoParts(t *testing.T) {
+	clusters, articles := sizedClusters([]int{10, 10, 10, 10, 9}, []float64{0.5, 0.5, 0.5, 0.5, 0.5})
+	if parts := SplitIntoParts(clusters, articles, 50); len(parts) != 1 || len(parts[0]) != 5 {
+		t.Errorf("expected 49 articles to stay one part, got sizes %v", partSizes(parts))
+	}
+	if parts := SplitIntoParts(clusters, articles, 0); len(parts) != 1 {
+		t.Error("expected splitAt 0 to turn splitting off")
+	}
+
+	// 60 articles at split_at 50: two parts aiming for 30 each, filled in priority order
+	// (15, 8, then 12 would overshoot)
+	clusters, articles = sizedClusters([]int{12, 8, 15, 10, 9, 6}, []float64{0.5, 0.9, 0.5, 0.2, 0.5, 0.5})
+	parts := SplitIntoParts(clusters, articles, 50)
+	if got := fmt.Sprint(partSizes(parts)); got != "[23 37]" {
+		t.Errorf("expected parts of [23 37], got %s", got)
+	}
+	if parts[0][0].ID != "c2" {
+		t.Errorf("expected the 15-article cluster to lead Part 1, got %s", parts[0][0].ID)
+	}
+
+	// A part never reaches splitAt, however the clusters fall
+	clusters, articles = sizedClusters([]int{30, 30, 30, 30}, []float64{0.5, 0.5, 0.5, 0.5})
+	for _, size := range partSizes(SplitIntoParts(clusters, articles, 50)) {
+		if size >= 50 {
+			t.Errorf("expected every part under 50 articles, got %d", size)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package clustering
+
+import (
+	"briefly/internal/core"
+	"sort"
+	"strings"
+)
+
+// VelocityOptions sets when a keyword counts as rapidly emerging
+type VelocityOptions struct {
+	MinMentions int     // Articles in the latest period that must mention the keyword
+	MinGrowth   float64 // Latest mentions as a multiple of the earlier per-period average
+	Limit       int     // Maximum keywords returned (0 = no limit)
+}
+
+// DefaultVelocityOptions flags keywords in at least 3 articles this period and at
+// least 3x their earlier average
+var DefaultVelocityOptions = VelocityOptions{MinMentions: 3, MinGrowth: 3, Limit: DefaultKeywordCount}
+
+// KeywordTrend is a keyword's mention counts across consecutive periods
+type KeywordTrend struct {
+	Keyword      string
+	Counts       []int   // Articles mentioning the keyword per period, oldest first
+	Velocity     int     // Change in mentions from the previous period to the latest
+	Acceleration int     // Change in velocity over the last period
+	Growth       float64 // Latest mentions over the earlier per-period average (floored at 1)
+}
+
+// EmergingKeywords finds keywords whose mentions are accelerating in the latest of
+// periods (oldest first), so a topic is flagged the period it starts spiking rather
+// than after it has peaked. A keyword qualifies when the latest period has at least
+// MinMentions articles mentioning it, mentions grew at least MinGrowth times over
+// the earlier average, and velocity is increasing. Articles repeated across periods
+// count in each. Results are ordered by acceleration, then latest mentions.
+func EmergingKeywords(periods [][]core.Article, opts VelocityOptions) []KeywordTrend {
+	if len(periods) < 2 {
+		return nil
+	}
+
+	counts := make(map[string][]int)
+	surfaceCounts := make(map[string]map[string]int)
+	for p, articles := range periods {
+		seen := make(map[string]bool)
+		for _, article := range articles {
+			if article.ID != "" {
+				if seen[article.ID] {
+					continue
+				}
+				seen[article.ID] = true
+			}
+
+			doc := tokenizeArticle(article, nil)
+			for term := range doc.terms {
+				if counts[term] == nil {
+					counts[term] = make([]int, len(periods))
+					surfaceCounts[term] = make(map[string]int)
+				}
+				counts[term][p]++
+				surfaceCounts[term][doc.surface[term]]++
+			}
+		}
+	}
+
+	var trends []KeywordTrend
+	for term, series := range counts {
+		trend := keywordTrend(term, series)
+		if trend.Counts[len(series)-1] < opts.MinMentions || trend.Growth < opts.MinGrowth {
+			continue
+		}
+		if trend.Velocity <= 0 || trend.Acceleration <= 0 {
+			continue
+		}
+		trend.Keyword = mostCommonSurface(surfaceCounts[term], term)
+		trends = append(trends, trend)
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].Acceleration != trends[j].Acceleration {
+			return trends[i].Acceleration > trends[j].Acceleration
+		}
+		li, lj := trends[i].Counts[len(periods)-1], trends[j].Counts[len(periods)-1]
+		if li != lj {
+			return li > lj
+		}
+		// Prefer phrases over the single words they contain
+		if wi, wj := strings.Count(trends[i].Keyword, " "), strings.Count(trends[j].Keyword, " "); wi != wj {
+			return wi > wj
+		}
+		return strings.ToLower(trends[i].Keyword) < strings.ToLower(trends[j].Keyword)
+	})
+
+	// Drop keywords covered by a higher-ranked phrase, as ExtractKeywords does
+	var emerging []KeywordTrend
+	chosenWords := make(map[string]bool)
+	for _, trend := range trends {
+		if opts.Limit > 0 && len(emerging) >= opts.Limit {
+			break
+		}
+		words := strings.Fields(strings.ToLower(trend.Keyword))
+		overlaps := false
+		for _, word := range words {
+			if chosenWords[word] {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			continue
+		}
+		for _, word := range words {
+			chosenWords[word] = true
+		}
+		emerging = append(emerging, trend)
+	}
+
+	return emerging
+}
+
+// keywordTrend computes velocity, acceleration, and growth from per-period counts
+func keywordTrend(term string, counts []int) KeywordTrend {
+	n := len(counts)
+	trend := KeywordTrend{Keyword: term, Counts: counts}
+
+	latest, previous := counts[n-1], counts[n-2]
+	trend.Velocity = latest - previous
+
+	// With only two periods there is no earlier velocity, so acceleration equals velocity
+	previousVelocity := 0
+	if n >= 3 {
+		previousVelocity = previous - counts[n-3]
+	}
+	trend.Acceleration = trend.Velocity - previousVelocity
+
+	earlier := 0
+	for _, count := range counts[:n-1] {
+		earlier += count
+	}
+	baseline := float64(earlier) / float64(n-1)
+	if baseline < 1 {
+		baseline = 1
+	}
+	trend.Growth = float64(latest) / baseline
+
+	return trend
+}
@@ -0,0 +1,67 @@
+package clustering
+
+import (
+	"briefly/internal/core"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func velocityPeriod(prefix string, mcpMentions, steadyMentions int) []core.Article {
+	var articles []core.Article
+	for i := 0; i < mcpMentions; i++ {
+		articles = append(articles, core.Article{
+			ID:    fmt.Sprintf("%s-mcp-%d", prefix, i),
+			Title: "Model Context Protocol servers everywhere",
+		})
+	}
+	for i := 0; i < steadyMentions; i++ {
+		articles = append(articles, core.Article{
+			ID:    fmt.Sprintf("%s-rust-%d", prefix, i),
+			Title: "Rust compiler release notes",
+		})
+	}
+	return articles
+}
+
+func TestEmergingKeywords_FlagsSpike(t *testing.T) {
+	periods := [][]core.Article{
+		velocityPeriod("w1", 0, 4),
+		velocityPeriod("w2", 1, 4),
+		velocityPeriod("w3", 5, 4),
+	}
+
+	trends := EmergingKeywords(periods, DefaultVelocityOptions)
+	if len(trends) == 0 {
+		t.Fatal("Expected the spiking topic to be flagged")
+	}
+
+	top := trends[0]
+	if !strings.Contains(top.Keyword, "Context Protocol") && !strings.Contains(top.Keyword, "Model Context") {
+		t.Errorf("Expected a Model Context Protocol phrase first, got %q", top.Keyword)
+	}
+	if top.Counts[2] != 5 || top.Velocity != 4 || top.Acceleration != 3 {
+		t.Errorf("Unexpected counts/velocity: %+v", top)
+	}
+	for _, trend := range trends {
+		if strings.Contains(strings.ToLower(trend.Keyword), "rust") {
+			t.Errorf("Steady topic %q should not be flagged", trend.Keyword)
+		}
+	}
+}
+
+func TestEmergingKeywords_IgnoresDecelerating(t *testing.T) {
+	// Mentions still rise, but slower than the previous period
+	periods := [][]core.Article{
+		velocityPeriod("w1", 0, 0),
+		velocityPeriod("w2", 6, 0),
+		velocityPeriod("w3", 7, 0),
+	}
+	if trends := EmergingKeywords(periods, DefaultVelocityOptions); len(trends) != 0 {
+		t.Errorf("Expected no emerging keywords after the spike, got %+v", trends)
+	}
+
+	if trends := EmergingKeywords(periods[:1], DefaultVelocityOptions); trends != nil {
+		t.Errorf("Expected nil for a single period, got %+v", trends)
+	}
+}
@@ -0,0 +1,170 @@
+// Package collect gathers URLs during the week into a dated markdown input file
+// for 'briefly digest from-file'. URLs arrive from the system clipboard or from
+// files dropped into a directory; each one is appended once, with where and when
+// it was collected.
+package collect
+
+import (
+	"briefly/internal/parser"
+	"briefly/internal/timeutil"
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Sources a URL can be collected from
+const (
+	SourceClipboard = "clipboard"
+	SourceDrop      = "drop"
+	SourcePriority  = "priority" // Feed items flagged by the priority pre-screen
+)
+
+// timeLayout is how collection times are written to the input file
+const timeLayout = "2006-01-02 15:04"
+
+var (
+	// urlPattern finds URLs in clipboard text, markdown, .url shortcuts, and .webloc plists
+	urlPattern = regexp.MustCompile(`https?://[^\s<>"'\])]+`)
+
+	// itemPattern reads back a collected line: "- <url> <!-- via <source>, <time> -->"
+	itemPattern = regexp.MustCompile(`^- (\S+)(?: <!-- via ([^,]+), ([^>]+?) -->)?\s*$`)
+)
+
+// Item is a URL in an input file
+type Item struct {
+	URL         string
+	Source      string
+	CollectedAt time.Time
+}
+
+// Collector appends URLs to one input file per week under a directory
+type Collector struct {
+	dir    string
+	parser *parser.Parser
+	now    func() time.Time
+}
+
+// New creates a collector writing input files to dir
+func New(dir string) *Collector {
+	return &Collector{dir: dir, parser: parser.NewParser(), now: time.Now}
+}
+
+// FilePath returns the input file for the week containing t, named after the
+// week's Monday, e.g. links-2025-06-02.md
+func (c *Collector) FilePath(t time.Time) string {
+	return filepath.Join(c.dir, "links-"+timeutil.WeekStart(t).Format("2006-01-02")+".md")
+}
+
+// CurrentFile returns this week's input file
+func (c *Collector) CurrentFile() string {
+	return c.FilePath(c.now())
+}
+
+// ExtractURLs finds the http(s) URLs in text, normalized (tracking parameters
+// and fragments removed) and deduplicated, in order of appearance
+func (c *Collector) ExtractURLs(text string) []string {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, match := range urlPattern.FindAllString(text, -1) {
+		match = strings.TrimRight(match, ".,;:!?")
+		if c.parser.ValidateURL(match) != nil {
+			continue
+		}
+		normalized := c.parser.NormalizeURL(match)
+		if !seen[normalized] {
+			seen[normalized] = true
+			urls = append(urls, normalized)
+		}
+	}
+	return urls
+}
+
+// Add appends the URLs found in text to this week's input file, skipping any
+// already in it, and returns the items added
+func (c *Collector) Add(text, source string) ([]Item, error) {
+	urls := c.ExtractURLs(text)
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	now := c.now()
+	path := c.FilePath(now)
+	existing, err := ReadItems(path)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, item := range existing {
+		seen[c.parser.NormalizeURL(item.URL)] = true
+	}
+
+	var added []Item
+	for _, u := range urls {
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+		added = append(added, Item{URL: u, Source: source, CollectedAt: now})
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create input directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var content strings.Builder
+	if info, err := file.Stat(); err == nil && info.Size() == 0 {
+		content.WriteString(fmt.Sprintf("# Links for the week of %s\n\n", timeutil.WeekStart(now).Format("January 2, 2006")))
+	}
+	for _, item := range added {
+		content.WriteString(fmt.Sprintf("- %s <!-- via %s, %s -->\n", item.URL, item.Source, item.CollectedAt.Format(timeLayout)))
+	}
+	if _, err := file.WriteString(content.String()); err != nil {
+		return nil, fmt.Errorf("failed to write input file: %w", err)
+	}
+	return added, nil
+}
+
+// Items returns the items gathered in this week's input file
+func (c *Collector) Items() ([]Item, error) {
+	return ReadItems(c.CurrentFile())
+}
+
+// ReadItems reads the collected items of an input file; a missing file has none.
+// Lines added by hand ("- https://...") are read too, without a source.
+func ReadItems(path string) ([]Item, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var items []Item
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		match := itemPattern.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil || !strings.HasPrefix(match[1], "http") {
+			continue
+		}
+		item := Item{URL: match[1], Source: match[2]}
+		if match[3] != "" {
+			item.CollectedAt, _ = time.ParseInLocation(timeLayout, match[3], time.Local)
+		}
+		items = append(items, item)
+	}
+	return items, scanner.Err()
+}
@@ -0,0 +1,92 @@
+package collect
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollector_AddDeduplicates(t *testing.T) {
+	dir := t.TempDir()
+	c := New(dir)
+	c.now = func() time.Time { return time.Date(2025, 6, 4, 9, 30, 0, 0, time.Local) } // a Wednesday
+
+	added, err := c.Add("Read https://example.com/post?utm_source=x and [this](https://example.org/a/).", SourceClipboard)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if len(added) != 2 || added[0].URL != "https://example.com/post" || added[1].URL != "https://example.org/a" {
+		t.Fatalf("unexpected items %+v", added)
+	}
+
+	added, err = c.Add("https://example.com/post#comments https://example.net/new", SourceDrop)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if len(added) != 1 || added[0].URL != "https://example.net/new" {
+		t.Fatalf("expected only the new URL, got %+v", added)
+	}
+
+	path := filepath.Join(dir, "links-2025-06-02.md")
+	if c.CurrentFile() != path {
+		t.Errorf("CurrentFile = %s, want %s", c.CurrentFile(), path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "# Links for the week of June 2, 2025\n") {
+		t.Errorf("expected a dated heading, got %q", content)
+	}
+
+	items, err := c.Items()
+	if err != nil {
+		t.Fatalf("Items failed: %v", err)
+	}
+	if len(items) != 3 || items[2].Source != SourceDrop || !items[2].CollectedAt.Equal(c.now()) {
+		t.Errorf("unexpected items %+v", items)
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	dir := t.TempDir()
+	dropDir := filepath.Join(dir, "drop")
+	if err := os.MkdirAll(dropDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	clipboard := "https://example.com/stale"
+	var collected []Item
+	w := &Watcher{
+		Collector: New(filepath.Join(dir, "input")),
+		Clipboard: func(ctx context.Context) (string, error) { return clipboard, nil },
+		DropDir:   dropDir,
+		OnAdd:     func(added []Item) { collected = append(collected, added...) },
+		OnError:   func(err error) { t.Errorf("unexpected error: %v", err) },
+	}
+
+	dropped := filepath.Join(dropDir, "link.webloc")
+	plist := `<plist><dict><key>URL</key><string>https://example.org/dropped</string></dict></plist>`
+	if err := os.WriteFile(dropped, []byte(plist), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(dropped, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	w.Poll(context.Background())
+	clipboard = "https://example.com/copied"
+	w.Poll(context.Background())
+	w.Poll(context.Background())
+
+	if len(collected) != 2 || collected[0].URL != "https://example.org/dropped" || collected[1].URL != "https://example.com/copied" {
+		t.Fatalf("expected the dropped and newly copied URLs only, got %+v", collected)
+	}
+	if _, err := os.Stat(filepath.Join(dropDir, collectedDir, "link.webloc")); err != nil {
+		t.Errorf("expected the dropped file moved to collected/: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package collect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// collectedDir is where processed drop files are moved, inside the drop directory
+const collectedDir = "collected"
+
+// settleTime leaves a dropped file alone until it has stopped changing
+const settleTime = time.Second
+
+// ErrNoClipboard is returned when no clipboard command is available
+var ErrNoClipboard = errors.New("no clipboard command found (install xclip, xsel, or wl-clipboard)")
+
+// ClipboardReader returns the clipboard's current text
+type ClipboardReader func(ctx context.Context) (string, error)
+
+// SystemClipboard returns a reader for the OS clipboard: pbpaste on macOS,
+// PowerShell's Get-Clipboard on Windows, and wl-paste, xclip, or xsel on Linux
+func SystemClipboard() (ClipboardReader, error) {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbpaste"}}
+	case "windows":
+		candidates = [][]string{{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}}
+	default:
+		candidates = [][]string{
+			{"wl-paste", "--no-newline"},
+			{"xclip", "-selection", "clipboard", "-o"},
+			{"xsel", "--clipboard", "--output"},
+		}
+	}
+
+	for _, args := range candidates {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		if args[0] == "wl-paste" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			continue
+		}
+		args := args
+		return func(ctx context.Context) (string, error) {
+			// An empty clipboard makes some of these exit non-zero
+			output, err := exec.CommandContext(ctx, args[0], args[1:]...).Output()
+			if err != nil && len(output) == 0 {
+				return "", nil
+			}
+			return string(output), nil
+		}, nil
+	}
+	return nil, ErrNoClipboard
+}
+
+// Watcher polls the clipboard and a drop directory, adding the URLs it finds
+type Watcher struct {
+	Collector *Collector
+	Clipboard ClipboardReader // nil to leave the clipboard alone
+	DropDir   string          // empty to watch no directory
+	Interval  time.Duration
+	OnAdd     func(added []Item) // called after each batch of new URLs
+	OnError   func(err error)    // called for errors that don't stop watching
+
+	lastClipboard string
+	seeded        bool
+}
+
+// Run polls until ctx is done
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.Clipboard == nil && w.DropDir == "" {
+		return fmt.Errorf("nothing to watch: enable the clipboard or set a drop directory")
+	}
+	if w.DropDir != "" {
+		if err := os.MkdirAll(w.DropDir, 0755); err != nil {
+			return fmt.Errorf("failed to create drop directory: %w", err)
+		}
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks the clipboard and drop directory once. Whatever is on the
+// clipboard when watching starts is not collected; only later copies are.
+func (w *Watcher) Poll(ctx context.Context) {
+	if w.Clipboard != nil {
+		w.pollClipboard(ctx)
+	}
+	if w.DropDir != "" {
+		w.pollDropDir()
+	}
+}
+
+func (w *Watcher) pollClipboard(ctx context.Context) {
+	text, err := w.Clipboard(ctx)
+	if err != nil {
+		w.reportError(fmt.Errorf("failed to read clipboard: %w", err))
+		return
+	}
+	if !w.seeded {
+		w.lastClipboard, w.seeded = text, true
+		return
+	}
+	if text == w.lastClipboard {
+		return
+	}
+	w.lastClipboard = text
+	w.add(text, SourceClipboard)
+}
+
+// pollDropDir collects URLs from each settled file in the drop directory and
+// moves the file to its collected/ subdirectory
+func (w *Watcher) pollDropDir() {
+	entries, err := os.ReadDir(w.DropDir)
+	if err != nil {
+		w.reportError(fmt.Errorf("failed to read drop directory: %w", err))
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < settleTime {
+			continue
+		}
+
+		path := filepath.Join(w.DropDir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			w.reportError(fmt.Errorf("failed to read %s: %w", entry.Name(), err))
+			continue
+		}
+		w.add(string(content), SourceDrop)
+
+		if err := os.MkdirAll(filepath.Join(w.DropDir, collectedDir), 0755); err == nil {
+			err = os.Rename(path, filepath.Join(w.DropDir, collectedDir, entry.Name()))
+		}
+		if err != nil {
+			w.reportError(fmt.Errorf("failed to move %s out of the drop directory: %w", entry.Name(), err))
+		}
+	}
+}
+
+func (w *Watcher) add(text, source string) {
+	added, err := w.Collector.Add(text, source)
+	if err != nil {
+		w.reportError(err)
+		return
+	}
+	if len(added) > 0 && w.OnAdd != nil {
+		w.OnAdd(added)
+	}
+}
+
+func (w *Watcher) reportError(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -13,25 +14,46 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	App           App           `mapstructure:"app"`
-	AI            AI            `mapstructure:"ai"`
-	Database      Database      `mapstructure:"database"`
-	Server        Server        `mapstructure:"server"`
-	Search        Search        `mapstructure:"search"`
-	Output        Output        `mapstructure:"output"`
-	Cache         Cache         `mapstructure:"cache"`
-	Visual        Visual        `mapstructure:"visual"`
-	TTS           TTS           `mapstructure:"tts"`
-	Messaging     Messaging     `mapstructure:"messaging"`
-	Email         Email         `mapstructure:"email"`
-	Feeds         Feeds         `mapstructure:"feeds"`
-	Research      Research      `mapstructure:"research"`
-	Filtering     Filtering     `mapstructure:"filtering"`
-	Team          Team          `mapstructure:"team"`
-	Logging       Logging       `mapstructure:"logging"`
-	CLI           CLI           `mapstructure:"cli"`
-	Observability Observability `mapstructure:"observability"`
-	Themes        Themes        `mapstructure:"themes"`
+	App           App                     `mapstructure:"app"`
+	AI            AI                      `mapstructure:"ai"`
+	Database      Database                `mapstructure:"database"`
+	Server        Server                  `mapstructure:"server"`
+	Search        Search                  `mapstructure:"search"`
+	Output        Output                  `mapstructure:"output"`
+	Cache         Cache                   `mapstructure:"cache"`
+	Fetch         Fetch                   `mapstructure:"fetch"`
+	Visual        Visual                  `mapstructure:"visual"`
+	TTS           TTS                     `mapstructure:"tts"`
+	Messaging     Messaging               `mapstructure:"messaging"`
+	LinkTracking  LinkTracking            `mapstructure:"link_tracking"`
+	Email         Email                   `mapstructure:"email"`
+	Feeds         Feeds                   `mapstructure:"feeds"`
+	Research      Research                `mapstructure:"research"`
+	Filtering     Filtering               `mapstructure:"filtering"`
+	Team          Team                    `mapstructure:"team"`
+	Logging       Logging                 `mapstructure:"logging"`
+	CLI           CLI                     `mapstructure:"cli"`
+	Observability Observability           `mapstructure:"observability"`
+	Themes        Themes                  `mapstructure:"themes"`
+	Schedule      Schedule                `mapstructure:"schedule"`
+	Schedules     []ScheduledTask         `mapstructure:"schedules"`
+	Series        map[string]SeriesConfig `mapstructure:"series"`
+	VectorStore   VectorStore             `mapstructure:"vector_store"`
+	Update        Update                  `mapstructure:"update"`
+	Provenance    Provenance              `mapstructure:"provenance"`
+	Collect       Collect                 `mapstructure:"collect"`
+	ReadLater     ReadLater               `mapstructure:"read_later"`
+	Authors       Authors                 `mapstructure:"authors"`
+	Redaction     Redaction               `mapstructure:"redaction"`
+	Priority      Priority                `mapstructure:"priority"`
+	Events        Events                  `mapstructure:"events"`
+	Delivery      Delivery                `mapstructure:"delivery"`
+	Licensing     Licensing               `mapstructure:"licensing"`
+	Trends        Trends                  `mapstructure:"trends"`
+	Branding      Branding                `mapstructure:"branding"`
+	Summarize     Summarize               `mapstructure:"summarize"`
+	Digest        TaskModel               `mapstructure:"digest"`
+	Title         TaskModel               `mapstructure:"title"`
 }
 
 // Database holds database configuration
@@ -52,8 +74,27 @@ type Server struct {
 	TemplateDir     string          `mapstructure:"template_dir"`
 	CORS            CORSConfig      `mapstructure:"cors"`
 	RateLimit       RateLimitConfig `mapstructure:"rate_limit"`
+	CaptureToken    string          `mapstructure:"capture_token"` // Bearer token required by POST /api/capture (empty = open)
+	CommentToken    string          `mapstructure:"comment_token"` // Bearer token required by POST /api/digests/{id}/comments (empty = open)
+	GRPCPort        int             `mapstructure:"grpc_port"`     // Port of the gRPC DigestService (0 = off)
+	GRPCToken       string          `mapstructure:"grpc_token"`    // Bearer token required by gRPC calls (empty = open, loopback host only)
+	Tenants         []Tenant        `mapstructure:"tenants"`       // Teams sharing the server; tenant tokens open only the tenant /api routes
+	AdminToken      string          `mapstructure:"admin_token"`   // Bearer token for GET /api/tenants/usage and, with tenants, the shared /api routes
 }
 
+// Tenant is a team sharing the server, with its own token, cache namespace under
+// cache.directory/tenants/<id>, model, and monthly LLM budget
+type Tenant struct {
+	ID            string  `mapstructure:"id"` // Cache namespace and usage key; a-z, 0-9, _ and - only
+	Name          string  `mapstructure:"name"`
+	Token         string  `mapstructure:"token"`          // Bearer token for the tenant's /api calls
+	Model         string  `mapstructure:"model"`          // Gemini model for the tenant's LLM calls (default ai.gemini.model)
+	MonthlyBudget float64 `mapstructure:"monthly_budget"` // Estimated LLM spend in USD per calendar month (0 = unlimited)
+}
+
+// tenantIDPattern is the form of a tenant ID
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
 // CORSConfig holds CORS configuration
 type CORSConfig struct {
 	Enabled        bool     `mapstructure:"enabled"`
@@ -82,12 +123,14 @@ type AI struct {
 
 // GeminiConfig holds Google Gemini configuration
 type GeminiConfig struct {
-	APIKey         string  `mapstructure:"api_key"`
-	Model          string  `mapstructure:"model"`
-	Timeout        string  `mapstructure:"timeout"`
-	MaxTokens      int32   `mapstructure:"max_tokens"`
-	Temperature    float32 `mapstructure:"temperature"`
-	EmbeddingModel string  `mapstructure:"embedding_model"`
+	APIKey          string  `mapstructure:"api_key"`
+	Model           string  `mapstructure:"model"`
+	Timeout         string  `mapstructure:"timeout"`
+	MaxTokens       int32   `mapstructure:"max_tokens"`
+	Temperature     float32 `mapstructure:"temperature"`
+	EmbeddingModel  string  `mapstructure:"embedding_model"`
+	EmbeddingSource string  `mapstructure:"embedding_source"` // Text embedded for clustering: summary or full-text
+	ContextBudget   int     `mapstructure:"context_budget"`   // Tokens of cluster narratives in the final digest prompt
 }
 
 // OpenAIConfig holds OpenAI configuration
@@ -109,20 +152,26 @@ type Search struct {
 
 // SearchProviders holds configuration for all search providers
 type SearchProviders struct {
-	Google     GoogleSearchConfig `mapstructure:"google"`
-	SerpAPI    SerpAPIConfig      `mapstructure:"serpapi"`
-	DuckDuckGo DuckDuckGoConfig   `mapstructure:"duckduckgo"`
+	Google          GoogleSearchConfig    `mapstructure:"google"`
+	SerpAPI         SerpAPIConfig         `mapstructure:"serpapi"`
+	DuckDuckGo      DuckDuckGoConfig      `mapstructure:"duckduckgo"`
+	ArXiv           ArXivConfig           `mapstructure:"arxiv"`
+	SemanticScholar SemanticScholarConfig `mapstructure:"semanticscholar"`
 }
 
 // GoogleSearchConfig holds Google Custom Search configuration
 type GoogleSearchConfig struct {
-	APIKey   string `mapstructure:"api_key"`
-	SearchID string `mapstructure:"search_id"`
+	APIKey     string `mapstructure:"api_key"`
+	SearchID   string `mapstructure:"search_id"`
+	RateLimit  string `mapstructure:"rate_limit"`  // Minimum gap between requests
+	DailyQuota int    `mapstructure:"daily_quota"` // Requests per UTC day (0 = unlimited)
 }
 
 // SerpAPIConfig holds SerpAPI configuration
 type SerpAPIConfig struct {
-	APIKey string `mapstructure:"api_key"`
+	APIKey     string `mapstructure:"api_key"`
+	RateLimit  string `mapstructure:"rate_limit"`  // Minimum gap between requests
+	DailyQuota int    `mapstructure:"daily_quota"` // Searches per UTC day (0 = unlimited)
 }
 
 // DuckDuckGoConfig holds DuckDuckGo configuration
@@ -130,11 +179,63 @@ type DuckDuckGoConfig struct {
 	RateLimit string `mapstructure:"rate_limit"`
 }
 
+// ArXivConfig holds arXiv API configuration
+type ArXivConfig struct {
+	RateLimit string `mapstructure:"rate_limit"` // arXiv asks for 3s between requests
+}
+
+// SemanticScholarConfig holds Semantic Scholar API configuration
+type SemanticScholarConfig struct {
+	APIKey     string `mapstructure:"api_key"`     // Optional; raises the shared rate limit
+	RateLimit  string `mapstructure:"rate_limit"`  // Minimum gap between requests
+	DailyQuota int    `mapstructure:"daily_quota"` // Requests per UTC day (0 = unlimited)
+}
+
 // Output holds output configuration
 type Output struct {
 	Directory    string `mapstructure:"directory"`
 	Format       string `mapstructure:"format"`
 	TemplatesDir string `mapstructure:"templates_dir"`
+	// FreshWindow splits markdown digests into "This Week" and "Evergreen & Older"
+	// by article publication date. 0 disables the split.
+	FreshWindow time.Duration `mapstructure:"fresh_window"`
+	TopicPages  TopicPages    `mapstructure:"topic_pages"`
+	// Plain strips emoji and typographic unicode from everything written (digest
+	// files, email, messages, speech) for clients that mangle them. --plain sets it.
+	Plain bool `mapstructure:"plain"`
+	// SplitAt splits a digest run of at least this many articles into parts, each
+	// with its own synthesis and file, numbered continuously. 0 disables splitting.
+	SplitAt int `mapstructure:"split_at"`
+	// MaxPerTopic caps the articles summarized and cited in each topic section; the
+	// rest are listed as links after it, so one busy topic can't crowd out the
+	// others. 0 disables the cap.
+	MaxPerTopic int `mapstructure:"max_per_topic"`
+	// Footnotes cites sources in markdown digests as [^N] footnotes, numbered once
+	// per digest, with a single references block at the end instead of inline links
+	Footnotes bool `mapstructure:"footnotes"`
+	// SocialLink is the link in --social post drafts, e.g. the newsletter's archive
+	// page. Empty links the Must-Read article.
+	SocialLink string `mapstructure:"social_link"`
+}
+
+// TopicPages controls the living per-topic pages digests append to
+type TopicPages struct {
+	Enabled        bool    `mapstructure:"enabled"`
+	Directory      string  `mapstructure:"directory"`       // Default: <output directory>/topics
+	MatchThreshold float64 `mapstructure:"match_threshold"` // Min centroid similarity to continue a topic's page
+}
+
+// Fetch controls article downloads
+type Fetch struct {
+	MaxSize FetchMaxSize `mapstructure:"max_size"`
+}
+
+// FetchMaxSize caps each download by content type, in megabytes. Larger URLs are
+// skipped, by their HEAD response or Content-Length when the server reports one and
+// otherwise as soon as the streamed body passes the cap. 0 disables a limit.
+type FetchMaxSize struct {
+	HTML int `mapstructure:"html"` // Pages, and URLs of unknown type
+	PDF  int `mapstructure:"pdf"`
 }
 
 // Cache holds cache configuration
@@ -142,6 +243,22 @@ type Cache struct {
 	Directory string         `mapstructure:"directory"`
 	Database  DatabaseConfig `mapstructure:"database"`
 	TTL       TTLConfig      `mapstructure:"ttl"`
+	Archive   ArchiveConfig  `mapstructure:"archive"`
+	Snapshots SnapshotConfig `mapstructure:"snapshots"`
+}
+
+// ArchiveConfig holds settings for the compressed article content archive
+type ArchiveConfig struct {
+	// Retention is how long original HTML/raw content is kept; cleaned text is kept
+	// as long as the article. "0" keeps everything.
+	Retention string `mapstructure:"retention"`
+}
+
+// SnapshotConfig controls saving standalone copies of digested articles' original
+// pages under <cache>/snapshots
+type SnapshotConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Format  string `mapstructure:"format"` // html (page as fetched) or mhtml (single file with images and CSS)
 }
 
 // DatabaseConfig holds database configuration
@@ -160,6 +277,14 @@ type TTLConfig struct {
 // Visual holds visual/banner configuration
 type Visual struct {
 	Banners BannerConfig `mapstructure:"banners"`
+	Figures FigureConfig `mapstructure:"figures"`
+}
+
+// FigureConfig holds inline image/chart extraction configuration
+type FigureConfig struct {
+	Describe      bool `mapstructure:"describe"`        // Describe chart/figure images with the vision model
+	EmbedImages   bool `mapstructure:"embed_images"`    // Embed the figure image itself in detailed digests
+	MaxPerArticle int  `mapstructure:"max_per_article"` // Vision calls per article
 }
 
 // BannerConfig holds banner generation configuration
@@ -219,6 +344,8 @@ type SlackConfig struct {
 	DefaultChannel string `mapstructure:"default_channel"`
 	Username       string `mapstructure:"username"`
 	IconEmoji      string `mapstructure:"icon_emoji"`
+	SigningSecret  string `mapstructure:"signing_secret"` // Verifies slash command requests in server mode
+	BotToken       string `mapstructure:"bot_token"`      // xoxb- token for threaded replies to slash commands
 }
 
 // DiscordConfig holds Discord configuration
@@ -228,12 +355,217 @@ type DiscordConfig struct {
 	AvatarURL  string `mapstructure:"avatar_url"`
 }
 
+// LinkTracking holds short-link and click tracking configuration for shared digests
+type LinkTracking struct {
+	Enabled    bool   `mapstructure:"enabled"`     // Rewrite links by default for Slack/email output
+	Mode       string `mapstructure:"mode"`        // "redirector" (self-hosted via briefly serve) or "service"
+	BaseURL    string `mapstructure:"base_url"`    // Public URL of the briefly server (redirector mode)
+	ServiceURL string `mapstructure:"service_url"` // Short-link service endpoint (service mode)
+	APIKey     string `mapstructure:"api_key"`     // Bearer token for the short-link service
+}
+
+// VectorStore selects the backend used for semantic search, dedup, and related-article lookups
+type VectorStore struct {
+	Backend string       `mapstructure:"backend"` // "pgvector" (default, embeddings in Postgres) or "qdrant"
+	Qdrant  QdrantConfig `mapstructure:"qdrant"`
+}
+
+// QdrantConfig holds Qdrant connection settings for the qdrant vector store backend
+type QdrantConfig struct {
+	URL        string `mapstructure:"url"`
+	APIKey     string `mapstructure:"api_key"`
+	Collection string `mapstructure:"collection"`
+	Dimensions int    `mapstructure:"dimensions"` // Embedding size used when creating the collection
+}
+
+// Update controls 'briefly update' self-updates from GitHub releases
+type Update struct {
+	Channel    string `mapstructure:"channel"`    // "stable" (default) or "beta" (includes pre-releases)
+	Repository string `mapstructure:"repository"` // GitHub owner/repo publishing releases
+	PublicKey  string `mapstructure:"public_key"` // Base64 ed25519 key checksums.txt.sig must verify against; required unless --allow-unsigned
+}
+
+// Provenance controls provenance metadata and signing for generated digests
+type Provenance struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Format     string `mapstructure:"format"`      // "sidecar" (default), "frontmatter", or "both"
+	SigningKey string `mapstructure:"signing_key"` // Base64 ed25519 private key or seed; unsigned when empty
+	PublicKey  string `mapstructure:"public_key"`  // Base64 ed25519 key used by 'briefly digest verify'
+}
+
+// TaskModel overrides ai.gemini.model for one kind of LLM call (summarize, digest,
+// title, or research), so cheap per-article work and the final narrative can run
+// on different models
+type TaskModel struct {
+	Model string `mapstructure:"model"` // Empty = ai.gemini.model
+}
+
+// Summarize configures per-article summarization
+type Summarize struct {
+	TaskModel `mapstructure:",squash"`
+
+	// TwoPass extracts quote-backed facts from each article first, then writes the
+	// summary from only those facts. It costs one more LLM call per article.
+	TwoPass bool `mapstructure:"two_pass"`
+}
+
+// Collect configures 'briefly collect', which gathers URLs into weekly input files
+type Collect struct {
+	Directory string        `mapstructure:"directory"` // Where links-<monday>.md input files are written
+	DropDir   string        `mapstructure:"drop_dir"`  // Directory watched for dropped files with URLs (empty = off)
+	Clipboard bool          `mapstructure:"clipboard"` // Watch the system clipboard for copied URLs
+	Interval  time.Duration `mapstructure:"interval"`  // How often the clipboard and drop directory are checked
+}
+
+// ReadLater configures the read-it-later services 'briefly readlater' syncs with.
+// A service is used when its token is set.
+type ReadLater struct {
+	Readwise ReadwiseConfig `mapstructure:"readwise"`
+	Raindrop RaindropConfig `mapstructure:"raindrop"`
+}
+
+// ReadwiseConfig is a Readwise Reader account
+type ReadwiseConfig struct {
+	Token    string `mapstructure:"token"`    // Access token from readwise.io/access_token
+	Location string `mapstructure:"location"` // Reader location pulled: later, new, shortlist
+}
+
+// RaindropConfig is a Raindrop.io account
+type RaindropConfig struct {
+	Token      string `mapstructure:"token"`      // Test token of an app from app.raindrop.io/settings/integrations
+	Collection int    `mapstructure:"collection"` // Collection ID pulled: 0 = all bookmarks, -1 = Unsorted
+}
+
+// Authors configures writers you follow: their articles are starred in digests and
+// ranked higher within their topic
+type Authors struct {
+	Follow []string `mapstructure:"follow"` // Author names, matched case-insensitively against bylines
+	Boost  float64  `mapstructure:"boost"`  // Added to a followed author's signal score (0.0-1.0)
+}
+
+// Redaction configures the redaction stage: matching text is replaced with
+// placeholders before it is sent to an LLM, and restored in what comes back
+type Redaction struct {
+	Enabled  bool               `mapstructure:"enabled"`
+	Patterns []RedactionPattern `mapstructure:"patterns"` // Regular expressions to redact
+	Terms    []string           `mapstructure:"terms"`    // Words and names to redact, matched case-insensitively
+}
+
+// RedactionPattern is a named regular expression; the name labels its placeholders
+type RedactionPattern struct {
+	Name    string `mapstructure:"name"`
+	Pattern string `mapstructure:"pattern"`
+}
+
+// Priority configures the priority inbox: items pulled from feeds or captured that
+// match a rule or the watchlist are summarized and pushed to a series' channels
+// right away instead of waiting for the next digest
+type Priority struct {
+	Enabled   bool           `mapstructure:"enabled"`
+	Series    string         `mapstructure:"series"`    // Series whose slack_webhook/discord_webhook receive alerts
+	Model     string         `mapstructure:"model"`     // Model that judges rule conditions (empty = title.model, then ai.gemini.model)
+	Watchlist []string       `mapstructure:"watchlist"` // Names and terms that make any item urgent
+	Rules     []PriorityRule `mapstructure:"rules"`
+	// AutoInclude adds feed items the keyword pre-screen flags at pull time to this
+	// week's collect input file, so the next 'digest from-file' run includes them
+	AutoInclude bool `mapstructure:"auto_include"`
+}
+
+// PriorityRule is an alert condition: keywords an item must mention, a yes/no
+// question the model must answer yes to, or both
+type PriorityRule struct {
+	Name      string   `mapstructure:"name"`
+	Keywords  []string `mapstructure:"keywords"`
+	Condition string   `mapstructure:"condition"`
+}
+
+// Events configures the JSON webhooks posted on pipeline milestones
+type Events struct {
+	Webhooks           []EventWebhook `mapstructure:"webhooks"`
+	FeedErrorThreshold int            `mapstructure:"feed_error_threshold"` // Consecutive fetch failures that fire feed.error_threshold (0 = never)
+	CostBudget         float64        `mapstructure:"cost_budget"`          // Estimated LLM spend in USD per run that fires budget.exceeded (0 = none)
+}
+
+// EventWebhook is an endpoint that receives events
+type EventWebhook struct {
+	URL    string   `mapstructure:"url"`
+	Events []string `mapstructure:"events"` // Event types to send (empty = all)
+	Secret string   `mapstructure:"secret"` // Signs each payload with HMAC-SHA256 in X-Briefly-Signature
+}
+
+// Delivery lists the channels `briefly digest --deliver` sends a finished digest to
+type Delivery struct {
+	Channels   []DeliveryChannel `mapstructure:"channels"`
+	Confluence ConfluenceConfig  `mapstructure:"confluence"`
+}
+
+// DeliveryChannel is one destination of a delivered digest. Settings left empty
+// fall back to the messaging, email, and tts sections.
+type DeliveryChannel struct {
+	Type       string   `mapstructure:"type"`        // file, email, slack, discord, tts, or confluence
+	Name       string   `mapstructure:"name"`        // Label in run output (default: type)
+	Format     string   `mapstructure:"format"`      // markdown, html, text, or slack (default depends on type)
+	Directory  string   `mapstructure:"directory"`   // file, tts: output directory
+	To         []string `mapstructure:"to"`          // email: recipients
+	WebhookURL string   `mapstructure:"webhook_url"` // slack, discord (default: messaging.<type>.webhook_url)
+	Provider   string   `mapstructure:"provider"`    // tts: openai or elevenlabs (default: tts.default_provider)
+	Voice      string   `mapstructure:"voice"`       // tts (default: tts.default_voice)
+	Space      string   `mapstructure:"space"`       // confluence: space key
+	ParentID   string   `mapstructure:"parent_id"`   // confluence: page to nest digests under
+}
+
+// ConfluenceConfig holds the Confluence site digests are published to
+type ConfluenceConfig struct {
+	BaseURL  string `mapstructure:"base_url"` // e.g. https://example.atlassian.net/wiki
+	Username string `mapstructure:"username"` // Account email
+	APIToken string `mapstructure:"api_token"`
+}
+
+// Licensing limits how much of each article a digest quotes, for digests
+// redistributed as newsletters under publishers' reuse policies
+type Licensing struct {
+	Attribution          bool              `mapstructure:"attribution"`             // Credit every article with a "Summarized from" line (listed domains always are)
+	DefaultMaxQuoteWords int               `mapstructure:"default_max_quote_words"` // Limit for domains not listed (0 = no limit)
+	Domains              []LicensingDomain `mapstructure:"domains"`
+}
+
+// LicensingDomain is the reuse policy of one publisher's domain and its subdomains
+type LicensingDomain struct {
+	Domain        string `mapstructure:"domain"`          // e.g. nytimes.com
+	MaxQuoteWords int    `mapstructure:"max_quote_words"` // Words quoted per article (0 = no limit)
+	Publisher     string `mapstructure:"publisher"`       // Name in the attribution line (default: the site's name)
+}
+
+// Trends configures how trend reports ('briefly quality trends') reach readers
+type Trends struct {
+	EmbedInNewsletter bool `mapstructure:"embed_in_newsletter"` // Embed the latest cached report in newsletter and email digests, once a week
+}
+
+// Branding is the publication's logo and footer, added to newsletter and email
+// digests
+type Branding struct {
+	Name            string         `mapstructure:"name"`     // Publication name, the logo's alt text
+	LogoURL         string         `mapstructure:"logo_url"` // Shown above the title
+	LogoLink        string         `mapstructure:"logo_link"`
+	FooterLinks     []BrandingLink `mapstructure:"footer_links"`
+	UnsubscribeText string         `mapstructure:"unsubscribe_text"`
+	UnsubscribeURL  string         `mapstructure:"unsubscribe_url"` // Used as given, e.g. an ESP merge tag such as *|UNSUB|*
+	Disclaimer      string         `mapstructure:"disclaimer"`
+}
+
+// BrandingLink is a labeled link in the footer
+type BrandingLink struct {
+	Label string `mapstructure:"label"`
+	URL   string `mapstructure:"url"`
+}
+
 // Email holds email configuration
 type Email struct {
 	SMTP            SMTPConfig `mapstructure:"smtp"`
 	DefaultTemplate string     `mapstructure:"default_template"`
 	FromAddress     string     `mapstructure:"from_address"`
 	FromName        string     `mapstructure:"from_name"`
+	RemoteImages    bool       `mapstructure:"remote_images"` // Hero and banner images in HTML email; false sends text-only cards
 }
 
 // SMTPConfig holds SMTP configuration
@@ -247,11 +579,25 @@ type SMTPConfig struct {
 
 // Feeds holds RSS/feed configuration
 type Feeds struct {
-	FetchInterval   string `mapstructure:"fetch_interval"`
-	UserAgent       string `mapstructure:"user_agent"`
-	Timeout         string `mapstructure:"timeout"`
-	MaxItemsPerFeed int    `mapstructure:"max_items_per_feed"`
-	CleanupInterval string `mapstructure:"cleanup_interval"`
+	FetchInterval   string        `mapstructure:"fetch_interval"`
+	UserAgent       string        `mapstructure:"user_agent"`
+	Timeout         string        `mapstructure:"timeout"`
+	MaxItemsPerFeed int           `mapstructure:"max_items_per_feed"`
+	Concurrency     int           `mapstructure:"concurrency"` // Feeds fetched at once by 'briefly feed pull'
+	CleanupInterval string        `mapstructure:"cleanup_interval"`
+	Follow          []FollowTopic `mapstructure:"follow"` // Standing search queries run during 'briefly feed pull'
+
+	// FullText uses the full body a feed publishes (content:encoded / Atom content) as
+	// the article instead of fetching the page; truncated bodies are still fetched
+	FullText         bool `mapstructure:"full_text"`
+	FullTextMinWords int  `mapstructure:"full_text_min_words"` // Shorter feed bodies count as truncated
+}
+
+// FollowTopic is a standing search query whose results are queued like feed items
+type FollowTopic struct {
+	Query      string `mapstructure:"query"`
+	Provider   string `mapstructure:"provider"`    // Search provider (default: search.default_provider)
+	MaxResults int    `mapstructure:"max_results"` // Results queued per pull (default: search.max_results)
 }
 
 // Research holds research configuration
@@ -260,7 +606,29 @@ type Research struct {
 	MaxQueries         int        `mapstructure:"max_queries"`
 	Timeout            string     `mapstructure:"timeout"`
 	ConcurrentSearches int        `mapstructure:"concurrent_searches"`
+	RelatedThreshold   float64    `mapstructure:"related_threshold"` // Min similarity to link a brief from a digest section
+	Provider           string     `mapstructure:"provider"`          // Comma list of gemini (search grounding), google, serpapi, duckduckgo, arxiv, semanticscholar
+	MaxPerDomain       int        `mapstructure:"max_per_domain"`    // Max sources a brief cites from one domain (0 = no cap)
+	FetchSources       bool       `mapstructure:"fetch_sources"`     // Read source pages into the article cache before synthesis
+	SourceMaxAge       string     `mapstructure:"source_max_age"`    // How old a cached source page can be and still be reused
+	Model              string     `mapstructure:"model"`             // Model for research briefs (empty = ai.gemini.model)
 	V2                 ResearchV2 `mapstructure:"v2"`
+
+	Templates map[string]ResearchTemplate `mapstructure:"templates"` // Named briefs, selected with `research --template <name>`
+}
+
+// ResearchTemplate holds one named research template, so a recurring brief keeps the
+// same scope, sources, and structure every run
+type ResearchTemplate struct {
+	Description string   `mapstructure:"description"`
+	Topic       string   `mapstructure:"topic"`       // Go template: {{.Topic}} {{.Date}}
+	SinceDays   int      `mapstructure:"since_days"`  // Only cite sources from the last N days (0 = any time)
+	Queries     int      `mapstructure:"queries"`     // Sub-queries to plan (default: research.max_queries)
+	MaxSources  int      `mapstructure:"max_sources"` // Most sources the brief cites (0 = no cap)
+	Provider    string   `mapstructure:"provider"`    // Comma list of providers (default: research.provider)
+	Focus       []string `mapstructure:"focus"`       // Areas the sub-queries must cover
+	Sections    []string `mapstructure:"sections"`    // Headings the brief is written under
+	Export      string   `mapstructure:"export"`      // Also export the brief: comma list of docx, pdf
 }
 
 // ResearchV2 holds research v2 enhanced features configuration
@@ -315,6 +683,17 @@ type Filtering struct {
 	Method       string            `mapstructure:"method"`        // Scoring method: keyword, embedding, hybrid
 	Weights      FilteringWeights  `mapstructure:"weights"`       // Scoring weights configuration
 	Templates    TemplateFiltering `mapstructure:"templates"`     // Per-template filtering settings
+	Noise        NoiseFiltering    `mapstructure:"noise"`         // Content gate for low-content pages
+}
+
+// NoiseFiltering holds the content gate that skips link farms, login walls, and error
+// pages after extraction, before they reach the LLM
+type NoiseFiltering struct {
+	Enabled        bool     `mapstructure:"enabled"`
+	MinWords       int      `mapstructure:"min_words"`       // Fewest words a page needs (0 = no minimum)
+	MaxBoilerplate float64  `mapstructure:"max_boilerplate"` // Largest share of link/boilerplate lines (0.0-1.0, 0 = no limit)
+	Languages      []string `mapstructure:"languages"`       // Allowed page languages, e.g. ["en"] (empty = any)
+	ErrorPages     bool     `mapstructure:"error_pages"`     // Skip pages that read like error, login, or paywall pages
 }
 
 // FilteringWeights holds scoring weight configuration
@@ -400,6 +779,40 @@ type Themes struct {
 	ClassificationModel string  `mapstructure:"classification_model"` // LLM model to use for classification
 }
 
+// Schedule holds the digest publishing cadence used by `digest --issue`
+type Schedule struct {
+	Preset       string   `mapstructure:"preset"`        // daily, weekdays, weekly, twice-weekly
+	PublishDays  []string `mapstructure:"publish_days"`  // Weekday names; overrides preset when set
+	Holidays     []string `mapstructure:"holidays"`      // YYYY-MM-DD dates with no issue
+	SkipHolidays bool     `mapstructure:"skip_holidays"` // Also skip common holidays (New Year, Jul 4, Thanksgiving, Christmas)
+	FirstIssue   string   `mapstructure:"first_issue"`   // YYYY-MM-DD of issue #1, enables issue numbers
+	// Timezone is the IANA zone issues are published in (e.g. America/New_York). Issue
+	// dates and windows, trend weeks, and daemon schedules use it. Default: local time.
+	Timezone string `mapstructure:"timezone"`
+}
+
+// ScheduledTask runs a task on a cron schedule in daemon mode (`briefly daemon`)
+type ScheduledTask struct {
+	Name   string   `mapstructure:"name"`   // Names the schedule in run history (default: the task)
+	Cron   string   `mapstructure:"cron"`   // Five-field cron expression, or @hourly, @daily, @weekly, @monthly
+	Task   string   `mapstructure:"task"`   // feed-pull, digest, cache-prune, or trend-report
+	Series string   `mapstructure:"series"` // digest: series to build the next issue of; trend-report: series to alert
+	Args   []string `mapstructure:"args"`   // Extra flags for the task's command, e.g. ["--since", "48"]
+}
+
+// SeriesConfig holds one named digest series, selected with `digest --series <key>`
+type SeriesConfig struct {
+	Name           string `mapstructure:"name"`            // Display name, e.g. "AI Weekly"
+	TitleTemplate  string `mapstructure:"title_template"`  // Go template: {{.Name}} {{.Title}} {{.Date}} {{.Issue}} {{.Number}}
+	Format         string `mapstructure:"format"`          // Default output format (markdown, slack)
+	OutputDir      string `mapstructure:"output_dir"`      // Default output directory (default: digests/<key>)
+	SlackWebhook   string `mapstructure:"slack_webhook"`   // Post each issue to this Slack incoming webhook
+	SlackBotToken  string `mapstructure:"slack_bot_token"` // With slack_channel, post long issues as a threaded series of parts
+	SlackChannel   string `mapstructure:"slack_channel"`   // Slack channel ID the bot token posts to
+	DiscordWebhook string `mapstructure:"discord_webhook"` // Post each issue to this Discord webhook
+	Audience       string `mapstructure:"audience"`        // Who issues are written for: expert, practitioner, exec, newcomer
+}
+
 var globalConfig *Config
 
 // Load loads the configuration from various sources
@@ -500,6 +913,8 @@ func setDefaults() {
 	viper.SetDefault("ai.gemini.max_tokens", 8192)
 	viper.SetDefault("ai.gemini.temperature", 0.7)
 	viper.SetDefault("ai.gemini.embedding_model", "gemini-embedding-001")
+	viper.SetDefault("ai.gemini.embedding_source", "summary")
+	viper.SetDefault("ai.gemini.context_budget", 24000)
 	viper.SetDefault("ai.openai.model", "gpt-image-1")
 	viper.SetDefault("ai.openai.base_url", "https://api.openai.com/v1")
 	viper.SetDefault("ai.openai.timeout", "30s")
@@ -510,11 +925,22 @@ func setDefaults() {
 	viper.SetDefault("search.timeout", "15s")
 	viper.SetDefault("search.language", "en")
 	viper.SetDefault("search.providers.duckduckgo.rate_limit", "1s")
+	viper.SetDefault("search.providers.google.rate_limit", "1s")
+	viper.SetDefault("search.providers.google.daily_quota", 100) // Custom Search free tier
+	viper.SetDefault("search.providers.serpapi.rate_limit", "1s")
+	viper.SetDefault("search.providers.arxiv.rate_limit", "3s")
+	viper.SetDefault("search.providers.semanticscholar.rate_limit", "1s")
 
 	// Output defaults
 	viper.SetDefault("output.directory", "digests")
 	viper.SetDefault("output.format", "standard")
 	viper.SetDefault("output.templates_dir", "templates")
+	viper.SetDefault("output.fresh_window", "168h")
+	viper.SetDefault("output.split_at", 50)
+	viper.SetDefault("output.max_per_topic", 0)
+	viper.SetDefault("output.footnotes", false)
+	viper.SetDefault("output.topic_pages.enabled", true)
+	viper.SetDefault("output.topic_pages.match_threshold", 0.8)
 
 	// Cache defaults
 	viper.SetDefault("cache.directory", ".briefly-cache")
@@ -523,6 +949,9 @@ func setDefaults() {
 	viper.SetDefault("cache.ttl.summaries", "168h")
 	viper.SetDefault("cache.ttl.digests", "720h")
 	viper.SetDefault("cache.ttl.feeds", "1h")
+	viper.SetDefault("cache.archive.retention", "2160h")
+	viper.SetDefault("cache.snapshots.enabled", false)
+	viper.SetDefault("cache.snapshots.format", "html")
 
 	// Visual defaults
 	viper.SetDefault("visual.banners.default_style", "tech")
@@ -531,6 +960,9 @@ func setDefaults() {
 	viper.SetDefault("visual.banners.output_directory", "banners")
 	viper.SetDefault("visual.banners.quality", "high")
 	viper.SetDefault("visual.banners.format", "PNG")
+	viper.SetDefault("visual.figures.describe", false)
+	viper.SetDefault("visual.figures.embed_images", false)
+	viper.SetDefault("visual.figures.max_per_article", 2)
 
 	// TTS defaults
 	viper.SetDefault("tts.default_provider", "openai")
@@ -547,24 +979,77 @@ func setDefaults() {
 	viper.SetDefault("messaging.slack.icon_emoji", ":newspaper:")
 	viper.SetDefault("messaging.discord.username", "Briefly")
 
+	// Link tracking defaults
+	viper.SetDefault("link_tracking.enabled", false)
+	viper.SetDefault("link_tracking.mode", "redirector")
+
+	// Vector store defaults
+	viper.SetDefault("vector_store.backend", "pgvector")
+	viper.SetDefault("vector_store.qdrant.url", "http://localhost:6333")
+	viper.SetDefault("vector_store.qdrant.collection", "briefly_articles")
+	viper.SetDefault("vector_store.qdrant.dimensions", 768)
+
+	// Self-update defaults
+	viper.SetDefault("update.channel", "stable")
+	viper.SetDefault("update.repository", "rcliao/briefly")
+
+	// Provenance defaults
+	viper.SetDefault("provenance.enabled", false)
+	viper.SetDefault("provenance.format", "sidecar")
+
+	// Collect defaults
+	viper.SetDefault("collect.directory", "input")
+	viper.SetDefault("collect.clipboard", true)
+	viper.SetDefault("collect.interval", "2s")
+
+	// Read-it-later defaults
+	viper.SetDefault("read_later.readwise.location", "later")
+	viper.SetDefault("read_later.raindrop.collection", 0)
+
+	// Followed authors defaults
+	viper.SetDefault("authors.boost", 0.2)
+
+	// Redaction defaults
+	viper.SetDefault("redaction.enabled", false)
+
+	// Licensing defaults
+	viper.SetDefault("licensing.attribution", false)
+	viper.SetDefault("licensing.default_max_quote_words", 0)
+
+	// Trends defaults
+	viper.SetDefault("trends.embed_in_newsletter", false)
+
 	// Email defaults
 	viper.SetDefault("email.smtp.port", 587)
 	viper.SetDefault("email.smtp.tls_enabled", true)
 	viper.SetDefault("email.default_template", "default")
 	viper.SetDefault("email.from_name", "Briefly")
+	viper.SetDefault("email.remote_images", true)
+
+	// Fetch defaults (megabytes)
+	viper.SetDefault("fetch.max_size.html", 10)
+	viper.SetDefault("fetch.max_size.pdf", 50)
 
 	// Feeds defaults
 	viper.SetDefault("feeds.fetch_interval", "1h")
 	viper.SetDefault("feeds.user_agent", "Briefly/1.0")
 	viper.SetDefault("feeds.timeout", "30s")
 	viper.SetDefault("feeds.max_items_per_feed", 50)
+	viper.SetDefault("feeds.concurrency", 16)
 	viper.SetDefault("feeds.cleanup_interval", "24h")
+	viper.SetDefault("feeds.full_text", true)
+	viper.SetDefault("feeds.full_text_min_words", 150)
 
 	// Research defaults
 	viper.SetDefault("research.max_depth", 3)
 	viper.SetDefault("research.max_queries", 5)
 	viper.SetDefault("research.timeout", "60s")
 	viper.SetDefault("research.concurrent_searches", 3)
+	viper.SetDefault("research.related_threshold", 0.78)
+	viper.SetDefault("research.provider", "gemini")
+	viper.SetDefault("research.max_per_domain", 3)
+	viper.SetDefault("research.fetch_sources", true)
+	viper.SetDefault("research.source_max_age", "24h")
 
 	// Research V2 defaults
 	viper.SetDefault("research.v2.enabled", true)
@@ -596,6 +1081,13 @@ func setDefaults() {
 	viper.SetDefault("filtering.weights.recency", 0.0)
 	viper.SetDefault("filtering.weights.quality", 0.0)
 
+	// Noise gate defaults
+	viper.SetDefault("filtering.noise.enabled", true)
+	viper.SetDefault("filtering.noise.min_words", 100)
+	viper.SetDefault("filtering.noise.max_boilerplate", 0.6)
+	viper.SetDefault("filtering.noise.languages", []string{"en"})
+	viper.SetDefault("filtering.noise.error_pages", true)
+
 	// Template-specific filtering defaults
 	viper.SetDefault("filtering.templates.brief.min_relevance", 0.6) // Stricter for brief
 	viper.SetDefault("filtering.templates.brief.max_words", 200)
@@ -649,6 +1141,14 @@ func setDefaults() {
 	viper.SetDefault("themes.enabled", true)
 	viper.SetDefault("themes.min_relevance_score", 0.6)
 	viper.SetDefault("themes.classification_model", "gemini-3-flash-preview")
+
+	// Schedule defaults
+	viper.SetDefault("schedule.preset", "weekly")
+	viper.SetDefault("schedule.skip_holidays", true)
+
+	// Event webhook defaults
+	viper.SetDefault("events.feed_error_threshold", 3)
+	viper.SetDefault("events.cost_budget", 0)
 }
 
 // bindEnvironmentVariables sets up flexible environment variable binding
@@ -678,12 +1178,22 @@ func bindEnvironmentVariables() {
 		"GOOGLE_SEARCH_ENGINE_ID",
 	})
 
+	// Read-it-later services
+	bindEnvKeys("read_later.readwise.token", []string{"READWISE_TOKEN"})
+	bindEnvKeys("read_later.raindrop.token", []string{"RAINDROP_TOKEN"})
+
 	// SerpAPI
 	bindEnvKeys("search.providers.serpapi.api_key", []string{
 		"SERPAPI_API_KEY",
 		"SERPAPI_KEY",
 	})
 
+	// Semantic Scholar
+	bindEnvKeys("search.providers.semanticscholar.api_key", []string{
+		"SEMANTIC_SCHOLAR_API_KEY",
+		"S2_API_KEY",
+	})
+
 	// TTS providers
 	bindEnvKeys("tts.providers.openai.api_key", []string{
 		"OPENAI_API_KEY",
@@ -705,11 +1215,48 @@ func bindEnvironmentVariables() {
 		"SLACK_WEBHOOK",
 	})
 
+	bindEnvKeys("messaging.slack.signing_secret", []string{
+		"SLACK_SIGNING_SECRET",
+	})
+
+	bindEnvKeys("messaging.slack.bot_token", []string{
+		"SLACK_BOT_TOKEN",
+	})
+
 	bindEnvKeys("messaging.discord.webhook_url", []string{
 		"DISCORD_WEBHOOK_URL",
 		"DISCORD_WEBHOOK",
 	})
 
+	// Confluence delivery
+	bindEnvKeys("delivery.confluence.username", []string{
+		"CONFLUENCE_USERNAME",
+	})
+
+	bindEnvKeys("delivery.confluence.api_token", []string{
+		"CONFLUENCE_API_TOKEN",
+	})
+
+	// Link tracking
+	bindEnvKeys("link_tracking.base_url", []string{
+		"BRIEFLY_PUBLIC_URL",
+		"LINK_TRACKING_BASE_URL",
+	})
+
+	bindEnvKeys("link_tracking.api_key", []string{
+		"SHORTLINK_API_KEY",
+		"LINK_TRACKING_API_KEY",
+	})
+
+	// Vector store
+	bindEnvKeys("vector_store.qdrant.url", []string{
+		"QDRANT_URL",
+	})
+
+	bindEnvKeys("vector_store.qdrant.api_key", []string{
+		"QDRANT_API_KEY",
+	})
+
 	// Email SMTP
 	bindEnvKeys("email.smtp.host", []string{
 		"SMTP_HOST",
@@ -747,6 +1294,22 @@ func bindEnvironmentVariables() {
 		"PORT",
 	})
 
+	bindEnvKeys("server.capture_token", []string{
+		"BRIEFLY_CAPTURE_TOKEN",
+	})
+
+	bindEnvKeys("server.comment_token", []string{
+		"BRIEFLY_COMMENT_TOKEN",
+	})
+
+	bindEnvKeys("server.grpc_token", []string{
+		"BRIEFLY_GRPC_TOKEN",
+	})
+
+	bindEnvKeys("server.admin_token", []string{
+		"BRIEFLY_ADMIN_TOKEN",
+	})
+
 	// LangFuse observability
 	bindEnvKeys("observability.langfuse.public_key", []string{
 		"LANGFUSE_PUBLIC_KEY",
@@ -773,6 +1336,11 @@ func bindEnvironmentVariables() {
 		"POSTHOG_HOST",
 		"POSTHOG_URL",
 	})
+
+	// Digest signing key
+	bindEnvKeys("provenance.signing_key", []string{
+		"BRIEFLY_SIGNING_KEY",
+	})
 }
 
 // bindEnvKeys binds the first found environment variable to a viper key
@@ -800,23 +1368,37 @@ func postProcessConfig(config *Config) error {
 	if config.TTS.OutputDirectory != "" {
 		config.TTS.OutputDirectory = expandPath(config.TTS.OutputDirectory)
 	}
+	if config.Collect.Directory != "" {
+		config.Collect.Directory = expandPath(config.Collect.Directory)
+	}
+	if config.Collect.DropDir != "" {
+		config.Collect.DropDir = expandPath(config.Collect.DropDir)
+	}
 
 	// Validate durations
 	durations := map[string]string{
-		"ai.gemini.timeout":      config.AI.Gemini.Timeout,
-		"ai.openai.timeout":      config.AI.OpenAI.Timeout,
-		"search.timeout":         config.Search.Timeout,
-		"cache.database.timeout": config.Cache.Database.Timeout,
-		"cache.ttl.articles":     config.Cache.TTL.Articles,
-		"cache.ttl.summaries":    config.Cache.TTL.Summaries,
-		"cache.ttl.digests":      config.Cache.TTL.Digests,
-		"cache.ttl.feeds":        config.Cache.TTL.Feeds,
-		"tts.timeout":            config.TTS.Timeout,
-		"messaging.timeout":      config.Messaging.Timeout,
-		"feeds.fetch_interval":   config.Feeds.FetchInterval,
-		"feeds.timeout":          config.Feeds.Timeout,
-		"feeds.cleanup_interval": config.Feeds.CleanupInterval,
-		"research.timeout":       config.Research.Timeout,
+		"ai.gemini.timeout":       config.AI.Gemini.Timeout,
+		"ai.openai.timeout":       config.AI.OpenAI.Timeout,
+		"search.timeout":          config.Search.Timeout,
+		"cache.database.timeout":  config.Cache.Database.Timeout,
+		"cache.ttl.articles":      config.Cache.TTL.Articles,
+		"cache.ttl.summaries":     config.Cache.TTL.Summaries,
+		"cache.ttl.digests":       config.Cache.TTL.Digests,
+		"cache.ttl.feeds":         config.Cache.TTL.Feeds,
+		"cache.archive.retention": config.Cache.Archive.Retention,
+		"tts.timeout":             config.TTS.Timeout,
+		"messaging.timeout":       config.Messaging.Timeout,
+		"feeds.fetch_interval":    config.Feeds.FetchInterval,
+		"feeds.timeout":           config.Feeds.Timeout,
+		"feeds.cleanup_interval":  config.Feeds.CleanupInterval,
+		"research.timeout":        config.Research.Timeout,
+		"research.source_max_age": config.Research.SourceMaxAge,
+
+		"search.providers.google.rate_limit":          config.Search.Providers.Google.RateLimit,
+		"search.providers.serpapi.rate_limit":         config.Search.Providers.SerpAPI.RateLimit,
+		"search.providers.duckduckgo.rate_limit":      config.Search.Providers.DuckDuckGo.RateLimit,
+		"search.providers.arxiv.rate_limit":           config.Search.Providers.ArXiv.RateLimit,
+		"search.providers.semanticscholar.rate_limit": config.Search.Providers.SemanticScholar.RateLimit,
 	}
 
 	for key, duration := range durations {
@@ -850,6 +1432,9 @@ func validateConfig(config *Config) error {
 	if config.AI.Gemini.APIKey == "" {
 		errors = append(errors, "Gemini API key is required. Set GEMINI_API_KEY environment variable or ai.gemini.api_key in config file.\nGet your API key from: https://makersuite.google.com/app/apikey")
 	}
+	if config.AI.Gemini.ContextBudget < 0 {
+		errors = append(errors, "ai.gemini.context_budget must not be negative")
+	}
 
 	// Validate search provider configuration
 	if config.Search.DefaultProvider != "" {
@@ -882,6 +1467,109 @@ func validateConfig(config *Config) error {
 		}
 	}
 
+	if config.Research.RelatedThreshold < 0 || config.Research.RelatedThreshold > 1 {
+		errors = append(errors, "research.related_threshold must be between 0 and 1")
+	}
+	for _, provider := range strings.Split(config.Research.Provider, ",") {
+		switch strings.TrimSpace(provider) {
+		case "", "gemini", "google", "serpapi", "duckduckgo", "arxiv", "semanticscholar":
+		default:
+			errors = append(errors, fmt.Sprintf("Unknown research provider: %s. Supported: gemini, google, serpapi, duckduckgo, arxiv, semanticscholar", provider))
+		}
+	}
+	if config.Research.MaxPerDomain < 0 {
+		errors = append(errors, "research.max_per_domain must not be negative")
+	}
+	for name, tmpl := range config.Research.Templates {
+		if tmpl.SinceDays < 0 || tmpl.Queries < 0 || tmpl.MaxSources < 0 {
+			errors = append(errors, fmt.Sprintf("research.templates.%s: since_days, queries, and max_sources must not be negative", name))
+		}
+		for _, provider := range strings.Split(tmpl.Provider, ",") {
+			switch strings.TrimSpace(provider) {
+			case "", "gemini", "google", "serpapi", "duckduckgo", "arxiv", "semanticscholar":
+			default:
+				errors = append(errors, fmt.Sprintf("Unknown provider in research.templates.%s: %s", name, provider))
+			}
+		}
+		for _, format := range strings.Split(tmpl.Export, ",") {
+			switch strings.ToLower(strings.TrimSpace(format)) {
+			case "", "docx", "pdf":
+			default:
+				errors = append(errors, fmt.Sprintf("Unknown export format in research.templates.%s: %s. Supported: docx, pdf", name, format))
+			}
+		}
+	}
+	if config.Search.Providers.Google.DailyQuota < 0 || config.Search.Providers.SerpAPI.DailyQuota < 0 ||
+		config.Search.Providers.SemanticScholar.DailyQuota < 0 {
+		errors = append(errors, "search provider daily_quota must not be negative")
+	}
+
+	// Validate link tracking when enabled
+	if config.LinkTracking.Enabled {
+		switch config.LinkTracking.Mode {
+		case "redirector":
+			if config.LinkTracking.BaseURL == "" {
+				errors = append(errors, "Link tracking redirector mode requires link_tracking.base_url (or BRIEFLY_PUBLIC_URL)")
+			}
+		case "service":
+			if config.LinkTracking.ServiceURL == "" {
+				errors = append(errors, "Link tracking service mode requires link_tracking.service_url")
+			}
+		default:
+			errors = append(errors, fmt.Sprintf("Unknown link tracking mode: %s. Supported: redirector, service", config.LinkTracking.Mode))
+		}
+	}
+
+	// Validate vector store backend
+	switch config.VectorStore.Backend {
+	case "", "pgvector":
+	case "qdrant":
+		if config.VectorStore.Qdrant.URL == "" {
+			errors = append(errors, "Qdrant vector store requires vector_store.qdrant.url (or QDRANT_URL)")
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("Unknown vector store backend: %s. Supported: pgvector, qdrant", config.VectorStore.Backend))
+	}
+
+	// Validate update channel
+	switch config.Update.Channel {
+	case "", "stable", "beta":
+	default:
+		errors = append(errors, fmt.Sprintf("Unknown update channel: %s. Supported: stable, beta", config.Update.Channel))
+	}
+
+	// Validate publication time zone
+	if config.Schedule.Timezone != "" {
+		if _, err := time.LoadLocation(config.Schedule.Timezone); err != nil {
+			errors = append(errors, fmt.Sprintf("Unknown schedule.timezone: %s. Use an IANA zone name like America/New_York", config.Schedule.Timezone))
+		}
+	}
+
+	// Validate provenance format
+	switch config.Provenance.Format {
+	case "", "sidecar", "frontmatter", "both":
+	default:
+		errors = append(errors, fmt.Sprintf("Unknown provenance format: %s. Supported: sidecar, frontmatter, both", config.Provenance.Format))
+	}
+
+	if config.Filtering.Noise.MinWords < 0 {
+		errors = append(errors, "filtering.noise.min_words must not be negative")
+	}
+	if config.Filtering.Noise.MaxBoilerplate < 0 || config.Filtering.Noise.MaxBoilerplate > 1 {
+		errors = append(errors, "filtering.noise.max_boilerplate must be between 0 and 1")
+	}
+
+	if config.Visual.Figures.MaxPerArticle < 0 {
+		errors = append(errors, "visual.figures.max_per_article must not be negative")
+	}
+
+	// Tenant IDs name cache directories, so they must not reach outside them
+	for _, tenant := range config.Server.Tenants {
+		if !tenantIDPattern.MatchString(tenant.ID) {
+			errors = append(errors, fmt.Sprintf("server.tenants: id %q must contain only a-z, 0-9, _ and -", tenant.ID))
+		}
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("configuration errors:\n- %s", strings.Join(errors, "\n- "))
 	}
@@ -897,9 +1585,11 @@ func GetServer() Server               { return Get().Server }
 func GetSearch() Search               { return Get().Search }
 func GetOutput() Output               { return Get().Output }
 func GetCache() Cache                 { return Get().Cache }
+func GetFetch() Fetch                 { return Get().Fetch }
 func GetVisual() Visual               { return Get().Visual }
 func GetTTS() TTS                     { return Get().TTS }
 func GetMessaging() Messaging         { return Get().Messaging }
+func GetLinkTracking() LinkTracking   { return Get().LinkTracking }
 func GetEmail() Email                 { return Get().Email }
 func GetFeeds() Feeds                 { return Get().Feeds }
 func GetResearch() Research           { return Get().Research }
@@ -909,6 +1599,45 @@ func GetLogging() Logging             { return Get().Logging }
 func GetCLI() CLI                     { return Get().CLI }
 func GetObservability() Observability { return Get().Observability }
 func GetThemes() Themes               { return Get().Themes }
+func GetSchedule() Schedule           { return Get().Schedule }
+func GetSchedules() []ScheduledTask   { return Get().Schedules }
+func GetVectorStore() VectorStore     { return Get().VectorStore }
+func GetUpdate() Update               { return Get().Update }
+func GetProvenance() Provenance       { return Get().Provenance }
+func GetCollect() Collect             { return Get().Collect }
+func GetReadLater() ReadLater         { return Get().ReadLater }
+func GetAuthors() Authors             { return Get().Authors }
+func GetRedaction() Redaction         { return Get().Redaction }
+func GetPriority() Priority           { return Get().Priority }
+func GetEvents() Events               { return Get().Events }
+func GetDelivery() Delivery           { return Get().Delivery }
+func GetLicensing() Licensing         { return Get().Licensing }
+func GetTrends() Trends               { return Get().Trends }
+func GetBranding() Branding           { return Get().Branding }
+func GetSummarize() Summarize         { return Get().Summarize }
+
+// GetSeries returns the configuration of a named digest series
+func GetSeries(key string) (SeriesConfig, bool) {
+	series, ok := Get().Series[key]
+	return series, ok
+}
+
+// GetResearchTemplate returns the configuration of a named research template
+func GetResearchTemplate(name string) (ResearchTemplate, bool) {
+	tmpl, ok := Get().Research.Templates[name]
+	return tmpl, ok
+}
+
+// GetPublicationLocation returns the time zone issues are published in
+// (schedule.timezone), or local time when none is set
+func GetPublicationLocation() *time.Location {
+	if name := Get().Schedule.Timezone; name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
 
 // Specific convenience getters for frequently accessed values
 func GetGeminiAPIKey() string   { return Get().AI.Gemini.APIKey }
@@ -922,7 +1651,16 @@ func GetGoogleSearchConfig() (string, string) {
 func GetSerpAPIKey() string      { return Get().Search.Providers.SerpAPI.APIKey }
 func GetOutputDirectory() string { return Get().Output.Directory }
 func GetCacheDirectory() string  { return Get().Cache.Directory }
-func IsDebugMode() bool          { return Get().App.Debug }
+
+// GetArchiveRetention returns the configured content archive retention (0 = keep forever)
+func GetArchiveRetention() time.Duration {
+	retention, err := time.ParseDuration(Get().Cache.Archive.Retention)
+	if err != nil {
+		return 0
+	}
+	return retention
+}
+func IsDebugMode() bool { return Get().App.Debug }
 
 // Team context convenience getters
 func GetTeamTechStack() []string  { return Get().Team.TechStack }
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Audience is who a digest is written for. It sets the depth and vocabulary of the
+// summarization and digest prompts.
+type Audience string
+
+const (
+	AudienceExpert       Audience = "expert"       // Specialists: skip basics, go deep on specifics
+	AudiencePractitioner Audience = "practitioner" // Working engineers: practical implications
+	AudienceExec         Audience = "exec"         // Leaders: business impact, decisions, risk
+	AudienceNewcomer     Audience = "newcomer"     // New to the topic: explain terms and context
+)
+
+// Audiences lists the supported audiences
+var Audiences = []Audience{AudienceExpert, AudiencePractitioner, AudienceExec, AudienceNewcomer}
+
+// ParseAudience validates an audience name. An empty name is the default audience
+// (the prompts' built-in senior-engineer framing).
+func ParseAudience(name string) (Audience, error) {
+	audience := Audience(strings.ToLower(strings.TrimSpace(name)))
+	if audience == "" {
+		return "", nil
+	}
+	for _, supported := range Audiences {
+		if audience == supported {
+			return audience, nil
+		}
+	}
+	return "", fmt.Errorf("unknown audience %q (supported: expert, practitioner, exec, newcomer)", name)
+}
+
+// Guidance is the prompt block describing the audience and how to write for it.
+// Empty for the default audience.
+func (a Audience) Guidance() string {
+	switch a {
+	case AudienceExpert:
+		return `**TARGET AUDIENCE:** Domain experts and researchers who:
+- Already know the field's vocabulary, history, and major players
+- Want technical depth: architectures, benchmarks, methodology, limitations
+- Are bored by background they already know
+
+**TONE:** Dense and precise. Use technical terms without defining them. Skip introductory context.
+`
+	case AudiencePractitioner:
+		return `**TARGET AUDIENCE:** Working software engineers who:
+- Build and operate systems day to day
+- Want to know what they can use, adopt, or watch out for
+- Care about "so what does this mean for my work?"
+
+**TONE:** Technical and practical, skeptical of hype. Define only niche terms.
+`
+	case AudienceExec:
+		return `**TARGET AUDIENCE:** Engineering and business leaders who:
+- Make investment, hiring, and vendor decisions
+- Have little time and no need for implementation detail
+- Care about cost, risk, competitive position, and timing
+
+**TONE:** Plain business language. Lead with impact and decisions; name technologies but don't explain how they work.
+`
+	case AudienceNewcomer:
+		return `**TARGET AUDIENCE:** Readers new to this topic who:
+- Are curious and technically literate but don't know the field's jargon
+- Need context on why something is significant
+- Would be lost by unexplained acronyms or insider references
+
+**TONE:** Friendly and clear. Define every acronym and technical term in a few words the first time it appears, and add one sentence of background where a story assumes prior knowledge.
+`
+	default:
+		return ""
+	}
+}
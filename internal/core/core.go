@@ -6,6 +6,7 @@ import "time"
 type Link struct {
 	ID        string    `json:"id"`         // Unique identifier for the link
 	URL       string    `json:"url"`        // The URL string
+	Title     string    `json:"title"`      // Link text from the source document, if any
 	DateAdded time.Time `json:"date_added"` // Timestamp when the link was added
 	Source    string    `json:"source"`     // Source of the link (e.g., "file", "rss", "deep_research")
 }
@@ -28,13 +29,25 @@ type Article struct {
 	ContentType ContentType `json:"content_type"` // html, pdf, youtube
 	Publisher   string      `json:"publisher"`    // Publisher domain (e.g., "anthropic.com", "openai.com") - v2.0
 
+	// Title sources: Title is the user's link text when it names the article, else the page's
+	LinkTitle string `json:"link_title,omitempty"` // Link text from the input file
+	PageTitle string `json:"page_title,omitempty"` // Title read from the page, kept when LinkTitle replaces it
+
 	// Content
-	CleanedText string `json:"cleaned_text"`
-	RawContent  string `json:"raw_content,omitempty"` // For non-HTML
+	CleanedText string         `json:"cleaned_text"`
+	RawContent  string         `json:"raw_content,omitempty"` // For non-HTML
+	Images      []ArticleImage `json:"images,omitempty"`      // Primary figures from the article body
+
+	// Page metadata (OpenGraph, Twitter card, JSON-LD)
+	Author       string `json:"author,omitempty"`        // Byline
+	SiteName     string `json:"site_name,omitempty"`     // Human-readable site name (e.g., "The Verge")
+	HeroImage    string `json:"hero_image,omitempty"`    // Share image URL
+	CanonicalURL string `json:"canonical_url,omitempty"` // Canonical URL, used to spot the same article under different URLs
 
 	// Processing metadata
 	DateFetched    time.Time `json:"date_fetched"`
-	ProcessingMode string    `json:"processing_mode"` // local, cloud, hybrid
+	ProcessingMode string    `json:"processing_mode"`       // local, cloud, hybrid
+	SkipReason     string    `json:"skip_reason,omitempty"` // Why the download was skipped (e.g. over its size limit); the article has no content
 
 	// Intelligence
 	TopicCluster      string  `json:"topic_cluster"`
@@ -47,13 +60,17 @@ type Article struct {
 	ThemeID             *string   `json:"theme_id,omitempty"`              // Primary theme assigned to this article
 	ThemeRelevanceScore *float64  `json:"theme_relevance_score,omitempty"` // Relevance score (0.0-1.0) for the assigned theme
 	ReaderIntent        string    `json:"reader_intent,omitempty"`         // Reader intent: "skim", "read", or "deep_dive"
-	DatePublished       time.Time `json:"date_published"`                  // Original publication date from feed
+	DatePublished       time.Time `json:"date_published"`                  // Original publication date from feed or page metadata
+
+	// Continuity across digests (populated at render time, not persisted)
+	PriorCoverage []PriorCoverage `json:"prior_coverage,omitempty"` // Related articles from earlier digests
 
 	// Content-specific metadata (conditional)
-	Duration             int    `json:"duration,omitempty"`               // YouTube only
-	Channel              string `json:"channel,omitempty"`                // YouTube only
-	PageCount            int    `json:"page_count,omitempty"`             // PDF only
-	EstimatedReadMinutes int    `json:"estimated_read_minutes,omitempty"` // Estimated reading time in minutes
+	Duration             int                 `json:"duration,omitempty"`               // YouTube only
+	Channel              string              `json:"channel,omitempty"`                // YouTube only
+	Transcript           []TranscriptSegment `json:"transcript,omitempty"`             // Video and podcast captions, when available
+	PageCount            int                 `json:"page_count,omitempty"`             // PDF only
+	EstimatedReadMinutes int                 `json:"estimated_read_minutes,omitempty"` // Estimated reading time in minutes
 
 	// User interaction
 	ExplorationCount int      `json:"exploration_count"`     // How often user clicked through
@@ -76,6 +93,63 @@ type Article struct {
 	FileSize        int64     `json:"file_size,omitempty"`        // Legacy
 }
 
+// TranscriptSegment is one caption of a video or podcast transcript
+type TranscriptSegment struct {
+	Start int    `json:"start"` // Seconds from the start of the media
+	Text  string `json:"text"`
+}
+
+// ArticleImage is a primary image (typically a chart, benchmark, or diagram) found in
+// an article's main content
+type ArticleImage struct {
+	URL         string `json:"url"`
+	Alt         string `json:"alt,omitempty"`
+	Caption     string `json:"caption,omitempty"`     // figcaption text, if any
+	Figure      bool   `json:"figure,omitempty"`      // Looks like a chart/figure rather than a photo
+	Description string `json:"description,omitempty"` // Vision model description of the figure
+}
+
+// ArticleSentiment is the tone of one article's text. It is cached by content hash and
+// analyzer version, so unchanged articles are not analyzed again.
+type ArticleSentiment struct {
+	ContentHash     string    `json:"content_hash"`
+	AnalyzerVersion string    `json:"analyzer_version"`
+	Score           float64   `json:"score"` // -1.0 (very negative) to 1.0 (very positive)
+	Label           string    `json:"label"` // positive, negative, or neutral
+	Emoji           string    `json:"emoji"`
+	AnalyzedAt      time.Time `json:"analyzed_at"`
+}
+
+// ReaderNote gathers the team's comments and reactions on one article of an earlier
+// digest, shown under "Reader notes" in the next issue
+type ReaderNote struct {
+	ArticleTitle string          `json:"article_title,omitempty"` // Empty for notes on the issue as a whole
+	ArticleURL   string          `json:"article_url,omitempty"`
+	Comments     []ReaderComment `json:"comments,omitempty"`
+	Reactions    map[string]int  `json:"reactions,omitempty"` // Reaction name (e.g. "fire") to count
+}
+
+// ReaderComment is one reader's comment on a digest article
+type ReaderComment struct {
+	Author string `json:"author,omitempty"`
+	Text   string `json:"text"`
+}
+
+// PriorCoverage points from an article to an earlier digest that covered a related
+// story, found by embedding similarity
+type PriorCoverage struct {
+	DigestID     string    `json:"digest_id"`
+	DigestTitle  string    `json:"digest_title"`
+	DigestDate   time.Time `json:"digest_date"`
+	DigestIssue  string    `json:"digest_issue,omitempty"` // Scheduled issue name, when the digest had one
+	DigestPart   int       `json:"digest_part,omitempty"`  // Part of a split run, 0 when not split
+	DigestParts  int       `json:"digest_parts,omitempty"`
+	DigestURL    string    `json:"digest_url,omitempty"` // Where the earlier digest can be read
+	ArticleID    string    `json:"article_id"`           // The related article in that digest
+	ArticleTitle string    `json:"article_title"`
+	Similarity   float64   `json:"similarity"`
+}
+
 // Summary represents a summarized version of one or more articles.
 type Summary struct {
 	ID              string    `json:"id"`               // Unique identifier for the summary
@@ -93,6 +167,14 @@ type Summary struct {
 	// Phase 1: Structured summary support
 	SummaryType       string                    `json:"summary_type,omitempty"`       // Type: "simple" or "structured"
 	StructuredContent *StructuredSummaryContent `json:"structured_content,omitempty"` // Structured sections (if type=structured)
+
+	// Quality scoring (0.0-1.0); flagged summaries stayed below threshold after re-summarization
+	QualityScore   float64  `json:"quality_score,omitempty"`
+	QualityIssues  []string `json:"quality_issues,omitempty"`
+	QualityFlagged bool     `json:"quality_flagged,omitempty"`
+
+	// Facts the summary was written from, when summarized in two passes
+	Facts *FactSheet `json:"facts,omitempty"`
 }
 
 // StructuredSummaryContent represents structured summary sections (Phase 1)
@@ -103,6 +185,19 @@ type StructuredSummaryContent struct {
 	MainInsight      string   `json:"main_insight"`                // Core takeaway (1-2 sentences)
 	TechnicalDetails string   `json:"technical_details,omitempty"` // Optional: Technical aspects
 	Impact           string   `json:"impact,omitempty"`            // Optional: Who/how it affects
+
+	// Typed fields from schema-constrained summarization (format and key-moments paths)
+	Entities []string         `json:"entities,omitempty"` // Named people, companies, and products
+	Stats    []Statistic      `json:"stats,omitempty"`    // Exact metrics with context
+	Insights []ArticleInsight `json:"insights,omitempty"` // Key moments with quotes
+}
+
+// ArticleInsight is a single key moment extracted from an article
+type ArticleInsight struct {
+	Title        string `json:"title"`          // Short descriptive title (e.g., "Performance Breakthrough")
+	Emoji        string `json:"emoji"`          // Category emoji (💡 📊 🚀 🔍 ⚡ 🔒 💰)
+	Quote        string `json:"quote"`          // Exact quote from the article (1-2 sentences)
+	WhyItMatters string `json:"why_it_matters"` // One-sentence significance
 }
 
 // Digest represents a complete digest with user's take (v3.0 simplified)
@@ -125,6 +220,8 @@ type Digest struct {
 	ByTheNumbers    []Statistic        `json:"by_the_numbers,omitempty"`   // 3-5 key metrics/statistics
 	WhyItMatters    string             `json:"why_it_matters,omitempty"`   // Single sentence connecting to reader impact
 	MustRead        *MustReadHighlight `json:"must_read,omitempty"`        // v3.1: Single most impactful article highlight
+	Banner          *BannerImage       `json:"banner,omitempty"`           // Banner themes and alt text (image only once generated)
+	ReaderNotes     []ReaderNote       `json:"reader_notes,omitempty"`     // Team feedback on the previous issue
 
 	// v3.0 new structure (legacy, being phased out)
 	Signal        Signal         `json:"signal,omitempty"`         // Primary insight
@@ -163,9 +260,11 @@ type MustReadHighlight struct {
 
 // KeyMoment represents an important quote from an article in the digest (v2.0)
 type KeyMoment struct {
-	Quote          string `json:"quote"`                // The key quote text
-	CitationNumber int    `json:"citation_number"`      // Reference to article citation [1][2][3]
-	ArticleID      string `json:"article_id,omitempty"` // Optional: Direct article reference
+	Quote          string `json:"quote"`                   // The key quote text
+	CitationNumber int    `json:"citation_number"`         // Reference to article citation [1][2][3]
+	ArticleID      string `json:"article_id,omitempty"`    // Optional: Direct article reference
+	Timestamp      int    `json:"timestamp,omitempty"`     // Seconds into the cited video or podcast where the quote is said
+	TimestampURL   string `json:"timestamp_url,omitempty"` // Deep link to Timestamp (e.g. youtu.be/ID?t=273); empty for text sources
 }
 
 // Perspective represents supporting or opposing viewpoints in a digest (v2.0)
@@ -174,6 +273,7 @@ type Perspective struct {
 	Summary         string   `json:"summary"`               // Summary of this perspective
 	CitationNumbers []int    `json:"citation_numbers"`      // Articles supporting this perspective [1,2,3]
 	ArticleIDs      []string `json:"article_ids,omitempty"` // Optional: Direct article references
+	SourceURLs      []string `json:"source_urls,omitempty"` // Optional: Outside sources found by web search
 }
 
 // Statistic represents a key metric or data point for scannable digest format (v3.0)
@@ -182,6 +282,27 @@ type Statistic struct {
 	Context string `json:"context"` // Brief context explaining the stat with citations
 }
 
+// FactSheet holds the facts extracted from one article by the first pass of
+// two-pass summarization. Every fact carries the article text that supports it, so
+// prose and digest statistics can be checked against the source.
+type FactSheet struct {
+	Claims   []Fact `json:"claims"`   // Statements the article makes
+	Numbers  []Fact `json:"numbers"`  // Metrics, with Value set to the exact figure
+	Entities []Fact `json:"entities"` // People, companies, and products, with Value set to the name
+}
+
+// Fact is one quote-backed fact from an article
+type Fact struct {
+	Text  string `json:"text"`            // The fact in a short sentence
+	Value string `json:"value,omitempty"` // The figure or name for numbers and entities
+	Quote string `json:"quote"`           // Verbatim article text supporting the fact
+}
+
+// Empty reports whether the sheet has no facts
+func (f *FactSheet) Empty() bool {
+	return f == nil || len(f.Claims)+len(f.Numbers)+len(f.Entities) == 0
+}
+
 // Prompt represents a generic prompt that can be used for various LLM interactions.
 type Prompt struct {
 	ID           string    `json:"id"`             // Unique identifier for the prompt
@@ -205,6 +326,7 @@ type Feed struct {
 	ErrorCount   int        `json:"error_count"`   // Number of consecutive errors
 	LastError    string     `json:"last_error"`    // Last error encountered
 	DateAdded    time.Time  `json:"date_added"`    // When the feed was added
+	Category     string     `json:"category"`      // Folder/category the feed is filed under ("" = uncategorized)
 }
 
 // FeedItem represents an item discovered in an RSS/Atom feed.
@@ -214,10 +336,12 @@ type FeedItem struct {
 	Title          string    `json:"title"`           // Item title
 	Link           string    `json:"link"`            // Item URL
 	Description    string    `json:"description"`     // Item description/summary
+	Content        string    `json:"content"`         // Full HTML body from the feed (content:encoded / Atom content), if any
 	Published      time.Time `json:"published"`       // Publication date
 	GUID           string    `json:"guid"`            // Unique identifier from the feed
 	Processed      bool      `json:"processed"`       // Whether the item has been processed
 	DateDiscovered time.Time `json:"date_discovered"` // When the item was discovered
+	FetchError     string    `json:"fetch_error"`     // Why the item's article couldn't be fetched, if it failed
 }
 
 // ClusterNarrative represents a generated summary narrative for a topic cluster
@@ -230,6 +354,7 @@ type ClusterNarrative struct {
 	KeyThemes      []string    `json:"key_themes"`       // 3-5 main themes from the cluster
 	ArticleRefs    []int       `json:"article_refs"`     // Citation numbers of articles included
 	Confidence     float64     `json:"confidence"`       // Confidence in cluster coherence (0-1)
+	Degraded       bool        `json:"degraded,omitempty"` // Built from article summaries after synthesis failed
 }
 
 // TopicCluster represents a cluster of articles with similar topics.
@@ -241,6 +366,8 @@ type TopicCluster struct {
 	Centroid   []float64         `json:"centroid"`            // Cluster centroid in embedding space
 	CreatedAt  time.Time         `json:"created_at"`          // When the cluster was created
 	Narrative  *ClusterNarrative `json:"narrative,omitempty"` // Generated cluster summary (hierarchical summarization)
+	// OverflowIDs are articles past the per-topic budget, listed as links only
+	OverflowIDs []string `json:"overflow_ids,omitempty"`
 }
 
 // CacheStats represents statistics about the cache.
@@ -256,15 +383,16 @@ type CacheStats struct {
 
 // ResearchReport represents the results of a research operation
 type ResearchReport struct {
-	ID               string           `json:"id"`                // Unique identifier for the research report
-	Query            string           `json:"query"`             // Original research query
-	Depth            int              `json:"depth"`             // Research depth level
-	GeneratedQueries []string         `json:"generated_queries"` // AI-generated search queries
-	Results          []ResearchResult `json:"results"`           // Research results
-	Summary          string           `json:"summary"`           // Summary of findings
-	DateGenerated    time.Time        `json:"date_generated"`    // When the research was conducted
-	TotalResults     int              `json:"total_results"`     // Total number of results found
-	RelevanceScore   float64          `json:"relevance_score"`   // Overall relevance score
+	ID               string           `json:"id"`                   // Unique identifier for the research report
+	Query            string           `json:"query"`                // Original research query
+	Depth            int              `json:"depth"`                // Research depth level
+	GeneratedQueries []string         `json:"generated_queries"`    // AI-generated search queries
+	Results          []ResearchResult `json:"results"`              // Research results
+	Summary          string           `json:"summary"`              // Summary of findings
+	DateGenerated    time.Time        `json:"date_generated"`       // When the research was conducted
+	TotalResults     int              `json:"total_results"`        // Total number of results found
+	RelevanceScore   float64          `json:"relevance_score"`      // Overall relevance score
+	SourceMix        map[string]int   `json:"source_mix,omitempty"` // Results by kind (news, docs, blog, paper, other)
 }
 
 // ResearchResult represents a single research result
@@ -277,6 +405,13 @@ type ResearchResult struct {
 	Relevance float64   `json:"relevance"`  // Relevance score (0-1)
 	DateFound time.Time `json:"date_found"` // When this result was found
 	Keywords  []string  `json:"keywords"`   // Extracted keywords
+	Kind      string    `json:"kind"`       // news, docs, blog, paper, or other
+
+	// Paper metadata, set for results from academic providers
+	Authors       []string  `json:"authors,omitempty"`
+	PublishedDate time.Time `json:"published_date,omitempty"`
+	Venue         string    `json:"venue,omitempty"`
+	CitationCount int       `json:"citation_count,omitempty"` // 0 when unknown
 }
 
 // FeedAnalysisReport represents analysis of RSS feed content
@@ -354,6 +489,19 @@ type ArticleGroup struct {
 	Summary          string            `json:"summary"`           // LEGACY: Group-level insight (50 words max) - deprecated
 	ClusterNarrative *ClusterNarrative `json:"cluster_narrative"` // NEW v3.1: Bullet-based cluster summary
 	Priority         int               `json:"priority"`          // 1-5 for ordering
+	// Stored research brief on the same topic, linked as further reading
+	RelatedResearch *RelatedResearch `json:"related_research,omitempty"`
+	// Articles past the per-topic budget, listed as uncited links after the section
+	MoreLinks []Article `json:"more_links,omitempty"`
+}
+
+// RelatedResearch points a digest section at a stored deep-research brief on the same topic
+type RelatedResearch struct {
+	BriefID    string  `json:"brief_id"`
+	Topic      string  `json:"topic"`
+	Path       string  `json:"path,omitempty"` // Where the full brief was written
+	Refresher  string  `json:"refresher"`      // Two-sentence reminder of the brief's findings
+	Similarity float64 `json:"similarity"`     // Cosine similarity between cluster and brief
 }
 
 // DigestMetadata contains digest processing information (v3.0)
@@ -368,6 +516,13 @@ type DigestMetadata struct {
 	ProcessingTime   time.Duration  `json:"processing_time"`
 	ProcessingCost   ProcessingCost `json:"processing_cost"`
 	QualityScore     float64        `json:"quality_score"` // Overall digest quality
+	Issue            string         `json:"issue,omitempty"` // Scheduled issue name (digest --issue); names the output file
+
+	// Oversized runs are split into parts (output.split_at); a part's article numbers
+	// continue from the parts before it
+	Part          int `json:"part,omitempty"`           // 1-based; 0 when the digest isn't split
+	Parts         int `json:"parts,omitempty"`          // Number of parts the run was split into
+	ArticleOffset int `json:"article_offset,omitempty"` // Articles numbered in earlier parts
 }
 
 // UserFeedback captures user ratings and comments (v3.0)
@@ -473,6 +628,11 @@ type ManualURL struct {
 	ErrorMessage string     `json:"error_message,omitempty"` // Error details if failed
 	ProcessedAt  *time.Time `json:"processed_at,omitempty"`  // When processing completed
 	CreatedAt    time.Time  `json:"created_at"`              // Submission timestamp
+
+	// Captured from a browser extension (see POST /api/capture)
+	Selection string   `json:"selection,omitempty"` // Text highlighted on the page
+	Note      string   `json:"note,omitempty"`      // Reader's note about why it was saved
+	Tags      []string `json:"tags,omitempty"`      // Free-form tags
 }
 
 // ManualURLStatus constants for ManualURL.Status field
@@ -483,6 +643,32 @@ const (
 	ManualURLStatusFailed     = "failed"
 )
 
+// TrackedLink is a short code that redirects to an article URL and counts clicks
+type TrackedLink struct {
+	Code        string     `json:"code"`                   // Short code used in /r/{code}
+	URL         string     `json:"url"`                    // Original destination URL
+	DigestID    *string    `json:"digest_id,omitempty"`    // Digest the link was shared in
+	CreatedAt   time.Time  `json:"created_at"`             // When the short link was created
+	Clicks      int        `json:"clicks"`                 // Click count (populated by stats queries)
+	LastClicked *time.Time `json:"last_clicked,omitempty"` // Most recent click (populated by stats queries)
+}
+
+// LLMCall records the usage of one LLM call and what it was made for
+type LLMCall struct {
+	ID        int64     `json:"id"`
+	ArticleID string    `json:"article_id,omitempty"` // Article the call was made for
+	SummaryID string    `json:"summary_id,omitempty"` // Summary the call produced
+	DigestID  string    `json:"digest_id,omitempty"`  // Digest the call was made for
+	Phase     string    `json:"phase"`                // Pipeline phase (summarize, embedding, narrative, ...)
+	Model     string    `json:"model"`
+	TokensIn  int       `json:"tokens_in"`
+	TokensOut int       `json:"tokens_out"`
+	LatencyMs int64     `json:"latency_ms"`
+	Retries   int       `json:"retries"`         // Attempts before this one for the same request
+	Error     string    `json:"error,omitempty"` // Set when the call failed
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // Citation represents source attribution metadata for an article (Phase 1)
 // Updated in v2.0 to support both article metadata citations AND digest inline citations
 type Citation struct {
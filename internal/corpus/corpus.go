@@ -0,0 +1,82 @@
+// Package corpus bundles a small set of sample article pages used by offline mode.
+// Together with the offline LLM client it lets the full digest pipeline
+// (fetch → clean → summarize → cluster → render) run without network or API keys.
+package corpus
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// BaseURL prefixes every corpus page URL. The host is never contacted.
+const BaseURL = "https://corpus.briefly.local/"
+
+//go:embed pages/*.html
+var pagesFS embed.FS
+
+// Pages returns the corpus HTML keyed by URL
+func Pages() map[string]string {
+	entries, err := pagesFS.ReadDir("pages")
+	if err != nil {
+		// Embedded at build time, so this only fails if the embed directive is broken
+		panic(fmt.Sprintf("corpus: failed to read embedded pages: %v", err))
+	}
+
+	pages := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := pagesFS.ReadFile(path.Join("pages", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("corpus: failed to read %s: %v", entry.Name(), err))
+		}
+		pages[URL(entry.Name())] = string(data)
+	}
+
+	return pages
+}
+
+// URL returns the corpus URL for a page file name
+func URL(fileName string) string {
+	return BaseURL + strings.TrimSuffix(fileName, ".html")
+}
+
+// URLs returns all corpus URLs in a stable order
+func URLs() []string {
+	pages := Pages()
+	urls := make([]string, 0, len(pages))
+	for url := range pages {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// InputMarkdown returns a curated-links file, in the format digest from-file
+// accepts, that lists every corpus page
+func InputMarkdown() string {
+	var b strings.Builder
+	b.WriteString("# Offline Sample Corpus\n\n")
+	for _, url := range URLs() {
+		b.WriteString(fmt.Sprintf("- %s\n", url))
+	}
+	return b.String()
+}
+
+// WriteInputFile writes InputMarkdown to a temporary file and returns its path.
+// The caller is responsible for removing it.
+func WriteInputFile() (string, error) {
+	f, err := os.CreateTemp("", "briefly-corpus-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create corpus input file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(InputMarkdown()); err != nil {
+		return "", fmt.Errorf("failed to write corpus input file: %w", err)
+	}
+
+	return f.Name(), nil
+}
@@ -0,0 +1,42 @@
+package corpus
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPages(t *testing.T) {
+	pages := Pages()
+	if len(pages) < 5 {
+		t.Fatalf("Expected a handful of sample pages, got %d", len(pages))
+	}
+
+	for url, html := range pages {
+		if !strings.HasPrefix(url, BaseURL) {
+			t.Errorf("URL %s does not use the corpus base URL", url)
+		}
+		if !strings.Contains(html, "<title>") {
+			t.Errorf("Page %s has no <title>", url)
+		}
+	}
+}
+
+func TestWriteInputFile(t *testing.T) {
+	path, err := WriteInputFile()
+	if err != nil {
+		t.Fatalf("WriteInputFile failed: %v", err)
+	}
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read input file: %v", err)
+	}
+
+	for _, url := range URLs() {
+		if !strings.Contains(string(data), "- "+url+"\n") {
+			t.Errorf("Input file does not list %s", url)
+		}
+	}
+}
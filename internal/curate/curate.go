@@ -0,0 +1,171 @@
+// Package curate suggests which links to cut before a digest is summarized. The
+// model sees only each link's title, domain, and note, so the pass costs a small
+// fraction of summarizing the links it flags as duplicates or low value.
+package curate
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Suggestion kinds
+const (
+	KindDuplicate = "duplicate"
+	KindLowValue  = "low-value"
+)
+
+// Suggestion flags one article as a likely cut
+type Suggestion struct {
+	Index       int    // 0-based position of the article in the list suggested from
+	Kind        string // KindDuplicate or KindLowValue
+	DuplicateOf int    // 0-based position of the article it repeats, -1 if none named
+	Reason      string
+}
+
+// Suggest asks the model which articles look duplicative or low value, from their
+// titles, domains, and notes only. Suggestions are in article order.
+func Suggest(ctx context.Context, generator llm.Generator, articles []core.Article) ([]Suggestion, error) {
+	if len(articles) < 2 {
+		return nil, nil
+	}
+	response, err := generator.GenerateText(ctx, buildPrompt(articles), llm.TextGenerationOptions{
+		Temperature: 0.1,
+		MaxTokens:   int32(48 * len(articles)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest cuts: %w", err)
+	}
+	return parseResponse(response, len(articles)), nil
+}
+
+// Cut returns the articles without the suggested ones at the cut indexes
+func Cut(articles []core.Article, cut map[int]bool) []core.Article {
+	kept := make([]core.Article, 0, len(articles))
+	for i, article := range articles {
+		if !cut[i] {
+			kept = append(kept, article)
+		}
+	}
+	return kept
+}
+
+// Domain is the host an article is shown under, without "www."
+func Domain(article core.Article) string {
+	if article.Publisher != "" {
+		return article.Publisher
+	}
+	parsed, err := url.Parse(article.URL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(parsed.Hostname(), "www.")
+}
+
+// Note is what the reader wrote about an article: their take, or link text that
+// differs from the page title
+func Note(article core.Article) string {
+	if article.MyTake != "" {
+		return article.MyTake
+	}
+	if article.LinkTitle != "" && article.PageTitle != "" && article.LinkTitle != article.PageTitle {
+		return article.LinkTitle
+	}
+	return ""
+}
+
+// buildPrompt lists the articles by number and asks for one line per flagged article
+func buildPrompt(articles []core.Article) string {
+	var prompt strings.Builder
+	prompt.WriteString("You are helping curate links for a weekly tech news digest. Before the articles are read and summarized, flag the ones worth cutting:\n")
+	prompt.WriteString("- duplicate: covers the same story or announcement as another article in the list (keep the more substantial source)\n")
+	prompt.WriteString("- low-value: a thin announcement, listicle, promotional page, or otherwise unlikely to be worth a reader's time\n\n")
+	prompt.WriteString("You only see each article's title, domain, and the curator's note. A note means the curator had a reason to save the link, so flag an article with a note only if it is clearly a duplicate.\n\n")
+
+	for i, article := range articles {
+		prompt.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, article.Title, Domain(article)))
+		if note := Note(article); note != "" {
+			prompt.WriteString(fmt.Sprintf("   Note: %s\n", strings.Join(strings.Fields(note), " ")))
+		}
+	}
+
+	prompt.WriteString(`
+Respond with one line per flagged article, in one of these formats, and nothing else:
+<article number> | duplicate of <article number> | <short reason>
+<article number> | low-value | <short reason>
+
+Example:
+4 | duplicate of 2 | Same launch announcement, 2 has the benchmarks
+7 | low-value | Press release with no technical detail
+
+If nothing should be cut, respond with: none`)
+	return prompt.String()
+}
+
+var linePattern = regexp.MustCompile(`(?i)^\D*?(\d+)\s*[|:.)]\s*(duplicate(?:\s+of\s+#?(\d+))?|low[- ]value)\s*(?:\|\s*(.*))?$`)
+
+// parseResponse reads "n | kind | reason" lines. Lines for articles outside
+// 1..count, repeated numbers, and duplicates of themselves are ignored.
+func parseResponse(response string, count int) []Suggestion {
+	seen := make(map[int]bool)
+	var suggestions []Suggestion
+	for _, line := range strings.Split(response, "\n") {
+		match := linePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		number, err := strconv.Atoi(match[1])
+		if err != nil || number < 1 || number > count || seen[number] {
+			continue
+		}
+
+		suggestion := Suggestion{Index: number - 1, Kind: KindLowValue, DuplicateOf: -1, Reason: strings.TrimSpace(match[4])}
+		if strings.HasPrefix(strings.ToLower(match[2]), KindDuplicate) {
+			suggestion.Kind = KindDuplicate
+			if match[3] != "" {
+				other, err := strconv.Atoi(match[3])
+				if err != nil || other == number || other < 1 || other > count {
+					continue
+				}
+				suggestion.DuplicateOf = other - 1
+			}
+		}
+		seen[number] = true
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].Index < suggestions[j].Index })
+	return suggestions
+}
+
+// ParseChoice reads the answer to "cut these?" for n numbered suggestions: empty or
+// "y" cuts all of them, "n" none, and numbers ("1,3" or "1 3") only those. The
+// result holds the 0-based positions of the suggestions to cut.
+func ParseChoice(answer string, n int) (map[int]bool, error) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	cut := make(map[int]bool)
+	switch answer {
+	case "", "y", "yes", "all":
+		for i := 0; i < n; i++ {
+			cut[i] = true
+		}
+		return cut, nil
+	case "n", "no", "none":
+		return cut, nil
+	}
+
+	for _, field := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 1 || number > n {
+			return nil, fmt.Errorf("%q is not a suggestion number (1-%d)", field, n)
+		}
+		cut[number-1] = true
+	}
+	return cut, nil
+}
@@ -0,0 +1,102 @@
+package curate
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"context"
+	"strings"
+	"testing"
+)
+
+type mockGenerator struct {
+	response string
+	prompt   string
+}
+
+func (m *mockGenerator) GenerateText(ctx context.Context, prompt string, options llm.TextGenerationOptions) (string, error) {
+	m.prompt = prompt
+	return m.response, nil
+}
+
+func TestSuggest(t *testing.T) {
+	articles := []core.Article{
+		{URL: "https://www.example.com/launch", Title: "Model X launches", PageTitle: "Model X launches"},
+		{URL: "https://news.example.org/x", Title: "Model X is here", LinkTitle: "read for the evals", PageTitle: "Model X is here"},
+		{URL: "https://blog.example.net/top-10", Title: "Top 10 AI tools", Publisher: "example.net"},
+	}
+	generator := &mockGenerator{response: "Here you go:\n2 | duplicate of 1 | Same launch\n3 | Low-value | Listicle\n3 | low-value | repeated\n9 | low-value | out of range\n1 | duplicate of 1 | itself\n"}
+
+	suggestions, err := Suggest(context.Background(), generator, articles)
+	if err != nil {
+		t.Fatalf("Suggest failed: %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("expected 2 suggestions, got %+v", suggestions)
+	}
+	if suggestions[0] != (Suggestion{Index: 1, Kind: KindDuplicate, DuplicateOf: 0, Reason: "Same launch"}) {
+		t.Errorf("unexpected duplicate suggestion %+v", suggestions[0])
+	}
+	if suggestions[1] != (Suggestion{Index: 2, Kind: KindLowValue, DuplicateOf: -1, Reason: "Listicle"}) {
+		t.Errorf("unexpected low-value suggestion %+v", suggestions[1])
+	}
+
+	for _, want := range []string{"1. Model X launches (example.com)", "Note: read for the evals", "3. Top 10 AI tools (example.net)"} {
+		if !strings.Contains(generator.prompt, want) {
+			t.Errorf("expected prompt to contain %q:\n%s", want, generator.prompt)
+		}
+	}
+	if strings.Contains(generator.prompt, "Note: Model X launches") {
+		t.Error("expected no note for link text matching the page title")
+	}
+}
+
+func TestSuggest_None(t *testing.T) {
+	articles := []core.Article{{Title: "A"}, {Title: "B"}}
+	suggestions, err := Suggest(context.Background(), &mockGenerator{response: "none"}, articles)
+	if err != nil || len(suggestions) != 0 {
+		t.Errorf("expected no suggestions, got %+v, %v", suggestions, err)
+	}
+}
+
+func TestParseChoice(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   []int
+	}{
+		{"", []int{0, 1, 2}},
+		{"Y", []int{0, 1, 2}},
+		{"n", nil},
+		{"1,3", []int{0, 2}},
+		{" 2 3 ", []int{1, 2}},
+	}
+	for _, tt := range tests {
+		cut, err := ParseChoice(tt.answer, 3)
+		if err != nil {
+			t.Errorf("ParseChoice(%q) failed: %v", tt.answer, err)
+			continue
+		}
+		if len(cut) != len(tt.want) {
+			t.Errorf("ParseChoice(%q) = %v, want %v", tt.answer, cut, tt.want)
+		}
+		for _, i := range tt.want {
+			if !cut[i] {
+				t.Errorf("ParseChoice(%q) = %v, want %v", tt.answer, cut, tt.want)
+			}
+		}
+	}
+
+	if _, err := ParseChoice("4", 3); err == nil {
+		t.Error("expected an error for a number out of range")
+	}
+	if _, err := ParseChoice("maybe", 3); err == nil {
+		t.Error("expected an error for an unknown answer")
+	}
+}
+
+func TestCut(t *testing.T) {
+	articles := []core.Article{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	kept := Cut(articles, map[int]bool{1: true})
+	if len(kept) != 2 || kept[0].ID != "a" || kept[1].ID != "c" {
+		t.Errorf("unexpected articles kept %+v", kept)
+	}
+}
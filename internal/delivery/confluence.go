@@ -0,0 +1,89 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ConfluenceOptions configures the Confluence space digests are published to
+type ConfluenceOptions struct {
+	BaseURL  string // Site URL including /wiki, e.g. https://example.atlassian.net/wiki
+	Space    string // Space key
+	ParentID string // Page to nest digests under; empty for the space root
+	Username string // Account email
+	APIToken string
+}
+
+func (o ConfluenceOptions) validate() error {
+	if o.BaseURL == "" || o.Space == "" {
+		return fmt.Errorf("confluence channel needs delivery.confluence.base_url and a space")
+	}
+	if o.Username == "" || o.APIToken == "" {
+		return fmt.Errorf("confluence channel needs delivery.confluence.username and api_token")
+	}
+	return nil
+}
+
+// publish creates a page holding the HTML digest and returns its URL. Confluence
+// rejects a second page with the same title in a space, so re-delivering a digest
+// fails rather than duplicating it.
+func (o ConfluenceOptions) publish(ctx context.Context, title, content string) (string, error) {
+	page := map[string]interface{}{
+		"type":  "page",
+		"title": title,
+		"space": map[string]string{"key": o.Space},
+		"body": map[string]interface{}{
+			"storage": map[string]string{"value": content, "representation": "storage"},
+		},
+	}
+	if o.ParentID != "" {
+		page["ancestors"] = []map[string]string{{"id": o.ParentID}}
+	}
+	body, err := json.Marshal(page)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode page: %w", err)
+	}
+
+	url := strings.TrimRight(o.BaseURL, "/") + "/rest/api/content"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(o.Username, o.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("confluence returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+
+	var created struct {
+		ID    string `json:"id"`
+		Links struct {
+			Base  string `json:"base"`
+			WebUI string `json:"webui"`
+		} `json:"_links"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("failed to decode confluence response: %w", err)
+	}
+	if created.Links.WebUI == "" {
+		return "page " + created.ID, nil
+	}
+	base := created.Links.Base
+	if base == "" {
+		base = strings.TrimRight(o.BaseURL, "/")
+	}
+	return base + created.Links.WebUI, nil
+}
@@ -0,0 +1,253 @@
+// Package delivery fans a generated digest out to the channels configured under
+// delivery.channels: files in other formats, email, Slack, Discord, a spoken audio
+// version, and Confluence pages. Each channel renders the digest in its own format,
+// so one run replaces separate commands that each re-read the input.
+package delivery
+
+import (
+	"briefly/internal/email"
+	"briefly/internal/render"
+	"briefly/internal/series"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Output formats a channel can render the digest in
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatText     = "text"
+	FormatSlack    = "slack"
+)
+
+// Formats lists every output format
+var Formats = []string{FormatMarkdown, FormatHTML, FormatText, FormatSlack}
+
+// Channel types
+const (
+	TypeFile       = "file"
+	TypeEmail      = "email"
+	TypeSlack      = "slack"
+	TypeDiscord    = "discord"
+	TypeTTS        = "tts"
+	TypeConfluence = "confluence"
+)
+
+// Types lists every channel type
+var Types = []string{TypeFile, TypeEmail, TypeSlack, TypeDiscord, TypeTTS, TypeConfluence}
+
+// channelFormats is each channel type's default format followed by the others it accepts
+var channelFormats = map[string][]string{
+	TypeFile:       {FormatHTML, FormatMarkdown, FormatText, FormatSlack},
+	TypeEmail:      {FormatHTML, FormatText, FormatMarkdown},
+	TypeSlack:      {FormatSlack, FormatText, FormatMarkdown},
+	TypeDiscord:    {FormatMarkdown, FormatText},
+	TypeTTS:        {FormatText},
+	TypeConfluence: {FormatHTML},
+}
+
+// requestTimeout bounds each HTTP request a channel makes
+const requestTimeout = 60 * time.Second
+
+// Digest is a generated digest as saved to disk
+type Digest struct {
+	Title   string
+	Content string
+	Format  string // Format Content is in: markdown or slack
+	Path    string // Saved file; outputs written to disk are named after it
+}
+
+// Channel is one destination. Only the settings of its Type are used.
+type Channel struct {
+	Type   string
+	Name   string // Label in results; defaults to Type
+	Format string // Defaults to the type's default (see channelFormats)
+
+	Directory  string       // file, tts: where output is written (file defaults to the digest's directory)
+	To         []string     // email recipients
+	Sender     email.Sender // email
+	WebhookURL string       // slack, discord
+	TTS        TTSOptions
+	Confluence ConfluenceOptions
+}
+
+// Result reports one channel's delivery
+type Result struct {
+	Channel string
+	Target  string // File written, page URL, or recipients
+	Err     error
+}
+
+// Label returns the channel's name, or its type when unnamed
+func (c Channel) Label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.Type
+}
+
+// Validate checks the channel's type, format, and the settings its type needs,
+// and fills in the default format
+func (c *Channel) Validate() error {
+	formats, ok := channelFormats[c.Type]
+	if !ok {
+		return fmt.Errorf("unknown channel type %q (use one of %s)", c.Type, strings.Join(Types, ", "))
+	}
+	if c.Format == "" {
+		c.Format = formats[0]
+	}
+	if !contains(formats, c.Format) {
+		return fmt.Errorf("%s channel cannot deliver %s (use one of %s)", c.Type, c.Format, strings.Join(formats, ", "))
+	}
+
+	switch c.Type {
+	case TypeEmail:
+		if len(c.To) == 0 {
+			return fmt.Errorf("email channel has no recipients (to)")
+		}
+		if c.Sender.Host == "" || c.Sender.From == "" {
+			return fmt.Errorf("email channel needs email.smtp.host and email.from_address in config")
+		}
+	case TypeSlack, TypeDiscord:
+		if c.WebhookURL == "" {
+			return fmt.Errorf("%s channel has no webhook_url (or messaging.%s.webhook_url)", c.Type, c.Type)
+		}
+	case TypeTTS:
+		return c.TTS.validate()
+	case TypeConfluence:
+		return c.Confluence.validate()
+	}
+	return nil
+}
+
+// Dispatch renders the digest for each channel and delivers it. Every channel is
+// tried; each result carries its own error.
+func Dispatch(ctx context.Context, channels []Channel, digest Digest) []Result {
+	digest.Title = render.Output(digest.Title)
+	digest.Content = render.Output(stripFrontmatter(digest.Content))
+	if digest.Format == "" {
+		digest.Format = FormatMarkdown
+	}
+
+	results := make([]Result, 0, len(channels))
+	for _, channel := range channels {
+		target, err := channel.deliver(ctx, digest)
+		results = append(results, Result{Channel: channel.Label(), Target: target, Err: err})
+	}
+	return results
+}
+
+// deliver renders and sends the digest to one channel, returning where it went
+func (c Channel) deliver(ctx context.Context, digest Digest) (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+
+	if c.Type == TypeTTS {
+		text, err := speechText(digest.Content, digest.Format)
+		if err != nil {
+			return "", err
+		}
+		return c.TTS.synthesize(ctx, text, c.outputPath(digest, ".mp3"))
+	}
+
+	content, err := Render(digest.Content, digest.Format, c.Format)
+	if err != nil {
+		return "", err
+	}
+
+	switch c.Type {
+	case TypeFile:
+		return c.writeFile(digest, content)
+	case TypeEmail:
+		if c.Format == FormatHTML {
+			return strings.Join(c.To, ", "), c.Sender.SendHTML(c.To, digest.Title, htmlDocument(digest.Title, content))
+		}
+		return strings.Join(c.To, ", "), c.Sender.Send(c.To, digest.Title, content)
+	case TypeSlack, TypeDiscord:
+		return c.Type, c.post(ctx, content)
+	case TypeConfluence:
+		return c.Confluence.publish(ctx, digest.Title, content)
+	}
+	return "", fmt.Errorf("unknown channel type %q", c.Type)
+}
+
+// writeFile saves the rendered digest next to the original (or in Directory), named
+// after it with the format's extension
+func (c Channel) writeFile(digest Digest, content string) (string, error) {
+	path := c.outputPath(digest, extensions[c.Format])
+	if filepath.Clean(path) == filepath.Clean(digest.Path) {
+		return "", fmt.Errorf("%s would overwrite the digest; set a different directory or format", path)
+	}
+	if c.Format == FormatHTML {
+		content = htmlDocument(digest.Title, content)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// extensions names the file each format is written to
+var extensions = map[string]string{
+	FormatMarkdown: ".md",
+	FormatHTML:     ".html",
+	FormatText:     ".txt",
+	FormatSlack:    ".slack.txt",
+}
+
+// outputPath names an output after the digest file, in Directory when set
+func (c Channel) outputPath(digest Digest, ext string) string {
+	base := strings.TrimSuffix(filepath.Base(digest.Path), filepath.Ext(digest.Path))
+	if base == "" || base == "." {
+		base = "digest"
+	}
+	dir := c.Directory
+	if dir == "" {
+		dir = filepath.Dir(digest.Path)
+	}
+	return filepath.Join(dir, base+ext)
+}
+
+// post sends the digest to a Slack or Discord webhook through the series delivery,
+// which splits long digests into a table of contents and numbered parts and retries
+// when rate limited
+func (c Channel) post(ctx context.Context, content string) error {
+	opts := series.Options{Name: c.Label()}
+	if c.Type == TypeSlack {
+		opts.SlackWebhook = c.WebhookURL
+	} else {
+		opts.DiscordWebhook = c.WebhookURL
+	}
+	ser, err := series.New("delivery", opts)
+	if err != nil {
+		return err
+	}
+	_, err = ser.Deliver(ctx, content)
+	return err
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: requestTimeout}
+}
+
+func validFormat(format string) bool {
+	return contains(Formats, format)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
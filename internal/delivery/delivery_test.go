@@ -0,0 +1,184 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleDigest = `---
+digest_id: abc
+---
+# AI Weekly
+
+## Agents ship
+
+Teams are shipping **agents** [[1]](https://example.com/a1).
+
+- [Agents in prod](https://example.com/a1)
+`
+
+func TestRender(t *testing.T) {
+	content := stripFrontmatter(sampleDigest)
+	if strings.Contains(content, "digest_id") {
+		t.Fatalf("expected frontmatter stripped, got:\n%s", content)
+	}
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{FormatHTML, []string{"<h1", "AI Weekly</h1>", "<strong>agents</strong>", `href="https://example.com/a1"`}},
+		{FormatText, []string{"AI Weekly\n", "Teams are shipping agents [1].", "- Agents in prod (https://example.com/a1)"}},
+		{FormatSlack, []string{"*AI Weekly*\n", "shipping *agents* <https://example.com/a1|[1]>.", "• <https://example.com/a1|Agents in prod>"}},
+	}
+	for _, tt := range tests {
+		got, err := Render(content, FormatMarkdown, tt.format)
+		if err != nil {
+			t.Fatalf("Render(%s): %v", tt.format, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("Render(%s) missing %q:\n%s", tt.format, want, got)
+			}
+		}
+	}
+
+	if _, err := Render(content, FormatMarkdown, "pdf"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	fromSlack, err := Render("*Top story* <https://example.com|Read more>", FormatSlack, FormatText)
+	if err != nil || !strings.Contains(fromSlack, "Top story Read more (https://example.com)") {
+		t.Errorf("expected Slack mrkdwn converted to text, got %q, %v", fromSlack, err)
+	}
+
+	speech, err := speechText(content, FormatMarkdown)
+	if err != nil || strings.Contains(speech, "http") || strings.Contains(speech, "[1]") {
+		t.Errorf("expected speech text without URLs or citations, got %q, %v", speech, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	file := Channel{Type: TypeFile}
+	if err := file.Validate(); err != nil || file.Format != FormatHTML {
+		t.Errorf("expected file to default to html, got %q, %v", file.Format, err)
+	}
+
+	for _, channel := range []Channel{
+		{Type: "fax"},
+		{Type: TypeTTS, Format: FormatHTML},
+		{Type: TypeConfluence, Format: FormatMarkdown},
+		{Type: TypeEmail},
+		{Type: TypeSlack},
+		{Type: TypeTTS, TTS: TTSOptions{Provider: ProviderElevenLabs, APIKey: "k"}},
+		{Type: TypeTTS, TTS: TTSOptions{Provider: "google", APIKey: "k"}},
+		{Type: TypeConfluence, Confluence: ConfluenceOptions{BaseURL: "https://example.atlassian.net/wiki", Space: "ENG"}},
+	} {
+		if err := channel.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", channel)
+		}
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "digest_2026-10-17.md")
+	if err := os.WriteFile(path, []byte(sampleDigest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var slackText string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		slackText = payload["text"]
+	}))
+	defer slack.Close()
+
+	var speechInput string
+	speech := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		var payload map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		speechInput, _ = payload["input"].(string)
+		_, _ = w.Write([]byte("ID3audio"))
+	}))
+	defer speech.Close()
+
+	var page map[string]interface{}
+	confluence := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "me@example.com" || token != "tok" || r.URL.Path != "/wiki/rest/api/content" {
+			t.Errorf("unexpected confluence request %s as %s", r.URL.Path, user)
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &page)
+		_, _ = w.Write([]byte(`{"id":"42","_links":{"base":"https://example.atlassian.net/wiki","webui":"/spaces/ENG/pages/42"}}`))
+	}))
+	defer confluence.Close()
+
+	channels := []Channel{
+		{Type: TypeFile},
+		{Type: TypeFile, Name: "archive", Format: FormatText, Directory: filepath.Join(dir, "archive")},
+		{Type: TypeFile, Name: "clobber", Format: FormatMarkdown},
+		{Type: TypeSlack, WebhookURL: slack.URL},
+		{Type: TypeTTS, Directory: filepath.Join(dir, "audio"), TTS: TTSOptions{Provider: ProviderOpenAI, Voice: "alloy", Model: "tts-1", APIKey: "sk-test", BaseURL: speech.URL}},
+		{Type: TypeConfluence, Confluence: ConfluenceOptions{BaseURL: confluence.URL + "/wiki", Space: "ENG", ParentID: "7", Username: "me@example.com", APIToken: "tok"}},
+	}
+	results := Dispatch(context.Background(), channels, Digest{Title: "AI Weekly", Content: sampleDigest, Path: path})
+
+	if len(results) != len(channels) {
+		t.Fatalf("expected a result per channel, got %+v", results)
+	}
+	for i, result := range results {
+		if i == 2 {
+			if result.Err == nil {
+				t.Error("expected the markdown file channel to refuse overwriting the digest")
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("%s: %v", result.Channel, result.Err)
+		}
+	}
+
+	html, err := os.ReadFile(filepath.Join(dir, "digest_2026-10-17.html"))
+	if err != nil || !strings.Contains(string(html), "<title>AI Weekly</title>") {
+		t.Errorf("expected an HTML page next to the digest, got %v", err)
+	}
+	if results[1].Target != filepath.Join(dir, "archive", "digest_2026-10-17.txt") {
+		t.Errorf("unexpected text file target %q", results[1].Target)
+	}
+	if !strings.Contains(slackText, "*AI Weekly*") {
+		t.Errorf("expected Slack mrkdwn posted, got %q", slackText)
+	}
+	if audio, err := os.ReadFile(filepath.Join(dir, "audio", "digest_2026-10-17.mp3")); err != nil || string(audio) != "ID3audio" {
+		t.Errorf("expected the spoken digest saved, got %q, %v", audio, err)
+	}
+	if !strings.HasPrefix(speechInput, "AI Weekly") || strings.Contains(speechInput, "example.com") {
+		t.Errorf("unexpected speech input %q", speechInput)
+	}
+	if results[5].Target != "https://example.atlassian.net/wiki/spaces/ENG/pages/42" || page["title"] != "AI Weekly" {
+		t.Errorf("unexpected confluence result %q for page %v", results[5].Target, page)
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	text := strings.Repeat("One sentence here. ", 10)
+	chunks := splitText(text, 50)
+	for _, chunk := range chunks {
+		if len(chunk) > 50 || !strings.HasSuffix(chunk, ".") {
+			t.Errorf("expected chunks split at sentence ends within the limit, got %q", chunk)
+		}
+	}
+	if strings.Join(chunks, " ") != strings.TrimSpace(text) {
+		t.Error("expected chunks to cover the whole text")
+	}
+}
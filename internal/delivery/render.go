@@ -0,0 +1,162 @@
+package delivery
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/gomarkdown/markdown"
+	mdhtml "github.com/gomarkdown/markdown/html"
+	"github.com/gomarkdown/markdown/parser"
+)
+
+var (
+	citationLink    = regexp.MustCompile(`\[\[(\d+)\]\]\(([^)\s]+)\)`)
+	imageLink       = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	markdownLink    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldText        = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	inlineCode      = regexp.MustCompile("`([^`]+)`")
+	htmlComment     = regexp.MustCompile(`(?s)<!--.*?-->`)
+	slackLink       = regexp.MustCompile(`<(https?://[^|>]+)\|([^>]+)>`)
+	slackBold       = regexp.MustCompile(`(^|[\s(])\*([^*\n]+)\*`)
+	citationRef     = regexp.MustCompile(`\s*\[\d+(?:\s*,\s*\d+)*\]`)
+	parenthesizeURL = regexp.MustCompile(`\s*\(https?://[^)\s]+\)`)
+	bareURL         = regexp.MustCompile(`https?://\S+`)
+)
+
+// Render converts digest content from one format to another. Markdown converts to
+// every format; Slack mrkdwn (a digest generated with --format slack) is first
+// turned back into markdown.
+func Render(content, from, to string) (string, error) {
+	if !validFormat(to) {
+		return "", fmt.Errorf("unknown format %q (use one of %s)", to, strings.Join(Formats, ", "))
+	}
+	if from == to {
+		return content, nil
+	}
+	switch from {
+	case FormatMarkdown, "":
+	case FormatSlack:
+		content = slackToMarkdown(content)
+	default:
+		return "", fmt.Errorf("cannot convert from %s", from)
+	}
+
+	switch to {
+	case FormatHTML:
+		return markdownToHTML(content), nil
+	case FormatText:
+		return markdownToText(content), nil
+	case FormatSlack:
+		return MarkdownToSlack(content), nil
+	}
+	return content, nil
+}
+
+// markdownToHTML renders markdown as an XHTML fragment, which is also valid in
+// Confluence's storage format
+func markdownToHTML(content string) string {
+	mdParser := parser.NewWithExtensions(parser.CommonExtensions | parser.AutoHeadingIDs)
+	renderer := mdhtml.NewRenderer(mdhtml.RendererOptions{
+		Flags: mdhtml.CommonFlags | mdhtml.HrefTargetBlank | mdhtml.UseXHTML,
+	})
+	return string(markdown.ToHTML([]byte(content), mdParser, renderer))
+}
+
+// markdownToText strips markdown syntax, keeping link targets in parentheses
+func markdownToText(content string) string {
+	content = htmlComment.ReplaceAllString(content, "")
+	content = imageLink.ReplaceAllString(content, "")
+	content = citationLink.ReplaceAllString(content, "[$1]")
+	content = markdownLink.ReplaceAllStringFunc(content, func(link string) string {
+		parts := markdownLink.FindStringSubmatch(link)
+		if parts[1] == parts[2] {
+			return parts[2]
+		}
+		return fmt.Sprintf("%s (%s)", parts[1], parts[2])
+	})
+	content = boldText.ReplaceAllString(content, "$1$2")
+	content = inlineCode.ReplaceAllString(content, "$1")
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if trimmed := strings.TrimLeft(line, "#"); trimmed != line {
+			line = strings.TrimSpace(trimmed)
+		}
+		lines[i] = line
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")) + "\n"
+}
+
+// MarkdownToSlack converts markdown to Slack mrkdwn: bold headings, single-asterisk
+// emphasis, • bullets, and <url|text> links
+func MarkdownToSlack(content string) string {
+	content = htmlComment.ReplaceAllString(content, "")
+	content = imageLink.ReplaceAllString(content, "")
+	content = citationLink.ReplaceAllString(content, "<$2|[$1]>")
+	content = markdownLink.ReplaceAllString(content, "<$2|$1>")
+	content = boldText.ReplaceAllString(content, "*$1$2*")
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if trimmed := strings.TrimLeft(line, "#"); trimmed != line {
+			line = "*" + strings.Trim(strings.TrimSpace(trimmed), "*") + "*"
+		} else if strings.HasPrefix(line, "- ") {
+			line = "• " + line[2:]
+		}
+		lines[i] = line
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")) + "\n"
+}
+
+// slackToMarkdown converts Slack mrkdwn links and bold text back to markdown
+func slackToMarkdown(content string) string {
+	content = slackLink.ReplaceAllString(content, "[$2]($1)")
+	return slackBold.ReplaceAllString(content, "$1**$2**")
+}
+
+// speechText reduces a digest to what is worth reading aloud: plain text without
+// citations or URLs
+func speechText(content, format string) (string, error) {
+	text, err := Render(content, format, FormatText)
+	if err != nil {
+		return "", err
+	}
+	text = citationRef.ReplaceAllString(text, "")
+	text = parenthesizeURL.ReplaceAllString(text, "")
+	text = bareURL.ReplaceAllString(text, "")
+	return strings.TrimSpace(text), nil
+}
+
+// Page renders markdown as a standalone HTML page, as the file and email channels
+// deliver it
+func Page(title, content string) string {
+	return htmlDocument(title, markdownToHTML(content))
+}
+
+// htmlDocument wraps an HTML fragment in a standalone page
+func htmlDocument(title, body string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+</head>
+<body>
+%s</body>
+</html>
+`, html.EscapeString(title), body)
+}
+
+// stripFrontmatter drops a leading YAML frontmatter block (e.g. provenance metadata)
+func stripFrontmatter(content string) string {
+	if !strings.HasPrefix(content, "---\n") {
+		return content
+	}
+	end := strings.Index(content[4:], "\n---\n")
+	if end < 0 {
+		return content
+	}
+	return strings.TrimLeft(content[4+end+5:], "\n")
+}
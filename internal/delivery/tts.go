@@ -0,0 +1,161 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Speech providers
+const (
+	ProviderOpenAI     = "openai"
+	ProviderElevenLabs = "elevenlabs"
+)
+
+const (
+	openAISpeechURL     = "https://api.openai.com/v1/audio/speech"
+	elevenLabsSpeechURL = "https://api.elevenlabs.io/v1/text-to-speech"
+	elevenLabsModel     = "eleven_multilingual_v2"
+)
+
+// speechChunkLimit keeps each request under the providers' input limits (4,096
+// characters for OpenAI); the MP3 responses are concatenated
+const speechChunkLimit = 4000
+
+// TTSOptions configures the spoken version of a digest
+type TTSOptions struct {
+	Provider string  // openai or elevenlabs
+	Voice    string  // OpenAI voice name, or ElevenLabs voice ID
+	Model    string  // OpenAI model (e.g. tts-1)
+	Speed    float64 // OpenAI only; 0 for the default
+	APIKey   string
+	BaseURL  string // Overrides the provider's endpoint
+}
+
+func (o TTSOptions) validate() error {
+	switch o.Provider {
+	case ProviderOpenAI:
+	case ProviderElevenLabs:
+		if o.Voice == "" {
+			return fmt.Errorf("elevenlabs needs a voice ID (voice)")
+		}
+	default:
+		return fmt.Errorf("unsupported tts provider %q (use %s or %s)", o.Provider, ProviderOpenAI, ProviderElevenLabs)
+	}
+	if o.APIKey == "" {
+		return fmt.Errorf("no API key for tts provider %s", o.Provider)
+	}
+	return nil
+}
+
+// synthesize speaks text in chunks and writes the MP3 to path
+func (o TTSOptions) synthesize(ctx context.Context, text, path string) (string, error) {
+	if text == "" {
+		return "", fmt.Errorf("nothing to read aloud")
+	}
+
+	client := newHTTPClient()
+	var audio bytes.Buffer
+	for i, chunk := range splitText(text, speechChunkLimit) {
+		data, err := o.speak(ctx, client, chunk)
+		if err != nil {
+			return "", fmt.Errorf("chunk %d: %w", i+1, err)
+		}
+		audio.Write(data)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create audio directory: %w", err)
+	}
+	if err := os.WriteFile(path, audio.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// speak requests audio for one chunk of text
+func (o TTSOptions) speak(ctx context.Context, client *http.Client, text string) ([]byte, error) {
+	var (
+		url     string
+		payload map[string]interface{}
+	)
+	switch o.Provider {
+	case ProviderElevenLabs:
+		url = o.BaseURL
+		if url == "" {
+			url = elevenLabsSpeechURL
+		}
+		url = strings.TrimRight(url, "/") + "/" + o.Voice
+		payload = map[string]interface{}{"text": text, "model_id": elevenLabsModel}
+	default:
+		url = o.BaseURL
+		if url == "" {
+			url = openAISpeechURL
+		}
+		payload = map[string]interface{}{"model": o.Model, "input": text, "voice": o.Voice, "response_format": "mp3"}
+		if o.Speed > 0 {
+			payload["speed"] = o.Speed
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "audio/mpeg")
+	if o.Provider == ProviderElevenLabs {
+		req.Header.Set("xi-api-key", o.APIKey)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s returned %s: %s", o.Provider, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// splitText splits text into chunks of at most limit bytes at paragraph, line, or
+// sentence boundaries
+func splitText(text string, limit int) []string {
+	var chunks []string
+	for len(text) > limit {
+		cut := -1
+		for _, sep := range []string{"\n\n", "\n", ". ", " "} {
+			if i := strings.LastIndex(text[:limit], sep); i > 0 {
+				cut = i + len(sep)
+				break
+			}
+		}
+		if cut <= 0 {
+			cut = limit
+			for cut > 0 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+		}
+		chunks = append(chunks, strings.TrimSpace(text[:cut]))
+		text = strings.TrimSpace(text[cut:])
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
@@ -24,6 +24,7 @@ type EmailTemplate struct {
 	FontFamily        string
 	ShowTopicClusters bool
 	ShowInsights      bool
+	RemoteImages      bool // Load hero and banner images from the web; false renders text-only cards
 }
 
 // EmailData contains all data needed for email rendering
@@ -40,6 +41,7 @@ type EmailData struct {
 	ResearchSuggestions []string
 	Conclusion          string
 	Banner              *core.BannerImage
+	Branding            *render.Branding // Publication logo and footer; nil keeps the default footer
 }
 
 // TopicGroup represents a group of articles with the same topic cluster
@@ -64,6 +66,7 @@ func GetDefaultEmailTemplate() *EmailTemplate {
 		FontFamily:        "system-ui, -apple-system, 'Segoe UI', Roboto, sans-serif",
 		ShowTopicClusters: true,
 		ShowInsights:      true,
+		RemoteImages:      true,
 	}
 }
 
@@ -82,6 +85,7 @@ func GetNewsletterEmailTemplate() *EmailTemplate {
 		FontFamily:        "Georgia, 'Times New Roman', serif",
 		ShowTopicClusters: true,
 		ShowInsights:      true,
+		RemoteImages:      true,
 	}
 }
 
@@ -100,6 +104,7 @@ func GetMinimalEmailTemplate() *EmailTemplate {
 		FontFamily:        "Inter, system-ui, sans-serif",
 		ShowTopicClusters: false,
 		ShowInsights:      false,
+		RemoteImages:      true,
 	}
 }
 
@@ -152,6 +157,13 @@ func getEmailCSS(tmpl *EmailTemplate) string {
     padding: 24px;
     text-align: center;
   }
+  .header .logo {
+    margin: 0 0 12px 0;
+  }
+  .header .logo img {
+    height: 40px;
+    width: auto;
+  }
   .header h1 {
     margin: 0;
     font-size: 24px;
@@ -221,6 +233,42 @@ func getEmailCSS(tmpl *EmailTemplate) string {
     line-height: 1.6;
     margin: 0 0 16px 0;
   }
+  .article-byline {
+    font-size: 13px;
+    color: #64748b;
+    margin: -4px 0 8px 0;
+  }
+
+  .article-title a {
+    color: inherit;
+  }
+  .article-domain {
+    font-size: 12px;
+    font-weight: 600;
+    letter-spacing: 0.04em;
+    text-transform: uppercase;
+    color: #64748b;
+    margin: 0 0 4px 0;
+  }
+
+  /* Preview cards: the hero image spans the card; cards without one stay text-only */
+  .article-card.has-hero {
+    padding: 0;
+    overflow: hidden;
+  }
+  .article-card.has-hero .card-body {
+    padding: 20px;
+  }
+  .article-hero {
+    display: block;
+    width: 100%%;
+    max-width: 100%%;
+    height: auto;
+    max-height: 320px;
+    object-fit: cover;
+    border: 0;
+  }
+
   .article-meta {
     font-size: 13px;
     color: #64748b;
@@ -319,6 +367,12 @@ func getEmailCSS(tmpl *EmailTemplate) string {
     .article-card {
       padding: 16px !important;
     }
+    .article-card.has-hero {
+      padding: 0 !important;
+    }
+    .article-card.has-hero .card-body {
+      padding: 16px !important;
+    }
   }
 </style>
 `,
@@ -392,12 +446,13 @@ func RenderHTMLEmail(data EmailData, emailTemplate *EmailTemplate) (string, erro
                 <div class="container">
                     <!-- Header -->
                     <div class="header">
+                        {{if .Data.Branding.HasHeader}}{{with .Data.Branding}}<p class="logo">{{if .LogoLink}}<a href="{{.LogoLink}}">{{end}}<img src="{{.LogoURL}}" alt="{{.Name}}" height="40">{{if .LogoLink}}</a>{{end}}</p>{{end}}{{end}}
                         <h1>{{.Data.Title}}</h1>
                         <p class="date">{{.Data.Date}}</p>
                     </div>
 
                     <!-- Banner Image -->
-                    {{if .Data.Banner}}
+                    {{if and .Template.RemoteImages .Data.Banner .Data.Banner.ImageURL}}
                     <div class="banner-section">
                         <img src="{{.Data.Banner.ImageURL}}" alt="{{.Data.Banner.AltText}}" 
                              style="width: 100%; max-width: 600px; height: auto; border-radius: 8px; margin: 20px 0;" />
@@ -464,51 +519,15 @@ func RenderHTMLEmail(data EmailData, emailTemplate *EmailTemplate) (string, erro
                         <div class="topic-group">
                             <h2 class="topic-title">📑 {{.TopicCluster}}</h2>
                             {{range .Articles}}
-                            <div class="article-card">
-                                <h3 class="article-title">
-                                    {{if .SentimentEmoji}}{{.SentimentEmoji}} {{end}}{{.Title}}
-                                </h3>
-                                {{if .SummaryText}}
-                                <div class="article-summary">{{.SummaryText}}</div>
-                                {{end}}
-                                {{if .MyTake}}
-                                <div style="background-color: #fef3c7; padding: 12px; border-radius: 4px; margin: 12px 0; border-left: 4px solid #f59e0b;">
-                                    <strong>💡 Key Insight:</strong> {{.MyTake}}
-                                </div>
-                                {{end}}
-                                <div class="article-meta">
-                                    <a href="{{.URL}}" class="btn">Read Article</a>
-                                    {{if .AlertTriggered}}
-                                    <span style="color: #dc2626; font-weight: 600; margin-left: 12px;">🚨 Alert Triggered</span>
-                                    {{end}}
-                                </div>
-                            </div>
+                            {{template "card" (card . $.Template.RemoteImages)}}
                             {{end}}
                         </div>
                         {{end}}
                         {{else}}
                         <!-- Traditional article listing -->
                         <h2>📄 Articles</h2>
-                        {{range $index, $article := .Data.DigestItems}}
-                        <div class="article-card">
-                            <h3 class="article-title">
-                                {{if $article.SentimentEmoji}}{{$article.SentimentEmoji}} {{end}}{{$article.Title}}
-                            </h3>
-                            {{if $article.SummaryText}}
-                            <div class="article-summary">{{$article.SummaryText}}</div>
-                            {{end}}
-                            {{if $article.MyTake}}
-                            <div style="background-color: #fef3c7; padding: 12px; border-radius: 4px; margin: 12px 0; border-left: 4px solid #f59e0b;">
-                                <strong>💡 Key Insight:</strong> {{$article.MyTake}}
-                            </div>
-                            {{end}}
-                            <div class="article-meta">
-                                <a href="{{$article.URL}}" class="btn">Read Article</a>
-                                {{if $article.AlertTriggered}}
-                                <span style="color: #dc2626; font-weight: 600; margin-left: 12px;">🚨 Alert Triggered</span>
-                                {{end}}
-                            </div>
-                        </div>
+                        {{range .Data.DigestItems}}
+                        {{template "card" (card . $.Template.RemoteImages)}}
                         {{end}}
                         {{end}}
 
@@ -520,10 +539,16 @@ func RenderHTMLEmail(data EmailData, emailTemplate *EmailTemplate) (string, erro
 
                     <!-- Footer -->
                     <div class="footer">
+                        {{if .Data.Branding.HasFooter}}{{with .Data.Branding}}
+                        {{if .FooterLinks}}<p>{{range $i, $link := .FooterLinks}}{{if $i}} · {{end}}<a href="{{$link.URL}}">{{$link.Label}}</a>{{end}}</p>{{end}}
+                        {{if .Disclaimer}}<p style="font-size: 12px; margin-top: 8px;">{{.Disclaimer}}</p>{{end}}
+                        {{if .UnsubscribeText}}<p style="font-size: 12px; margin-top: 8px;">{{if .UnsubscribeURL}}<a {{unsubscribeHref .UnsubscribeURL}}>{{.UnsubscribeText}}</a>{{else}}{{.UnsubscribeText}}{{end}}</p>{{end}}
+                        {{end}}{{else}}
                         <p>Generated by <a href="https://github.com/rcliao/briefly">Briefly</a> on {{.Data.Date}}</p>
                         <p style="font-size: 12px; margin-top: 8px;">
                             This digest was created using AI-powered analysis and insights.
                         </p>
+                        {{end}}
                     </div>
                 </div>
             </td>
@@ -533,7 +558,7 @@ func RenderHTMLEmail(data EmailData, emailTemplate *EmailTemplate) (string, erro
 </html>`
 
 	// Parse and execute template
-	tmpl, err := template.New("email").Parse(htmlTemplate)
+	tmpl, err := template.New("email").Funcs(template.FuncMap{"card": newCard, "unsubscribeHref": unsubscribeHref}).Parse(htmlTemplate + cardTemplate)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse email template: %w", err)
 	}
@@ -556,6 +581,54 @@ func RenderHTMLEmail(data EmailData, emailTemplate *EmailTemplate) (string, erro
 	return buf.String(), nil
 }
 
+// cardTemplate renders one article as a preview card: hero image, domain, title,
+// byline, and summary. Articles without a hero image, or emails with remote images
+// turned off, get the same card without the image.
+const cardTemplate = `
+{{define "card"}}{{$article := .Article}}{{$hero := and .RemoteImages $article.HeroImage}}
+<div class="article-card{{if $hero}} has-hero{{end}}">
+    {{if $hero}}<a href="{{$article.URL}}"><img src="{{$article.HeroImage}}" alt="{{$article.Title}}" width="600" class="article-hero"></a>{{end}}
+    <div class="card-body">
+        {{with $article.Domain}}<div class="article-domain">{{.}}</div>{{end}}
+        <h3 class="article-title">
+            {{if $article.SentimentEmoji}}{{$article.SentimentEmoji}} {{end}}<a href="{{$article.URL}}">{{$article.Title}}</a>
+        </h3>
+        {{with $article.Byline}}<div class="article-byline">{{.}}</div>{{end}}
+        {{if $article.SummaryText}}
+        <div class="article-summary">{{$article.SummaryText}}</div>
+        {{end}}
+        {{if $article.MyTake}}
+        <div style="background-color: #fef3c7; padding: 12px; border-radius: 4px; margin: 12px 0; border-left: 4px solid #f59e0b;">
+            <strong>💡 Key Insight:</strong> {{$article.MyTake}}
+        </div>
+        {{end}}
+        <div class="article-meta">
+            <a href="{{$article.URL}}" class="btn">Read Article</a>
+            {{if $article.AlertTriggered}}
+            <span style="color: #dc2626; font-weight: 600; margin-left: 12px;">🚨 Alert Triggered</span>
+            {{end}}
+        </div>
+    </div>
+</div>
+{{end}}`
+
+// card is the data of one preview card
+type card struct {
+	Article      render.DigestData
+	RemoteImages bool
+}
+
+func newCard(article render.DigestData, remoteImages bool) card {
+	return card{Article: article, RemoteImages: remoteImages}
+}
+
+// unsubscribeHref renders the href of the unsubscribe link with the configured
+// URL as given, since it's usually an ESP merge tag such as *|UNSUB|* that the
+// sending service replaces and URL escaping would break
+func unsubscribeHref(url string) template.HTMLAttr {
+	return template.HTMLAttr(`href="` + strings.ReplaceAll(url, `"`, "&quot;") + `"`)
+}
+
 // GenerateSubject generates email subject using template
 func GenerateSubject(emailTemplate *EmailTemplate, title string, date string) (string, error) {
 	tmpl, err := template.New("subject").Parse(emailTemplate.Subject)
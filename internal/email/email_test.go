@@ -1,6 +1,7 @@
 package email
 
 import (
+	"briefly/internal/core"
 	"briefly/internal/render"
 	"math"
 	"os"
@@ -264,6 +265,94 @@ func TestRenderHTMLEmail_Basic(t *testing.T) {
 	}
 }
 
+func TestRenderHTMLEmail_Branding(t *testing.T) {
+	emailData := EmailData{
+		Title: "Test Digest",
+		Date:  "January 1, 2024",
+		Branding: &render.Branding{
+			Name:            "Acme Weekly",
+			LogoURL:         "https://acme.example/logo.png",
+			LogoLink:        "https://acme.example",
+			FooterLinks:     []render.Link{{Label: "Archive", URL: "https://acme.example/archive"}, {Label: "About", URL: "https://acme.example/about"}},
+			UnsubscribeText: "Unsubscribe",
+			UnsubscribeURL:  "*|UNSUB|*",
+			Disclaimer:      "Views are our own.",
+		},
+	}
+
+	html, err := RenderHTMLEmail(emailData, GetDefaultEmailTemplate())
+	if err != nil {
+		t.Fatalf("RenderHTMLEmail failed: %v", err)
+	}
+	for _, want := range []string{
+		`<a href="https://acme.example"><img src="https://acme.example/logo.png" alt="Acme Weekly"`,
+		`<a href="https://acme.example/archive">Archive</a> · <a href="https://acme.example/about">About</a>`,
+		"Views are our own.",
+		`<a href="*|UNSUB|*">Unsubscribe</a>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+	if strings.Contains(html, "Generated by") {
+		t.Error("expected the branded footer to replace the default one")
+	}
+
+	// Without branding the default footer stays
+	emailData.Branding = nil
+	html, err = RenderHTMLEmail(emailData, GetDefaultEmailTemplate())
+	if err != nil {
+		t.Fatalf("RenderHTMLEmail failed: %v", err)
+	}
+	if !strings.Contains(html, "Generated by") || strings.Contains(html, `class="logo"`) {
+		t.Error("expected the default footer and no logo without branding")
+	}
+}
+
+func TestRenderHTMLEmail_PreviewCards(t *testing.T) {
+	emailData := EmailData{
+		Title: "Test Digest",
+		DigestItems: []render.DigestData{
+			{Title: "With Image", URL: "https://www.example.com/a", HeroImage: "https://cdn.example.com/a.png", SummaryText: "Summary A."},
+			{Title: "Without Image", URL: "https://blog.example.org/b", SummaryText: "Summary B."},
+		},
+		Banner: &core.BannerImage{ImageURL: "https://cdn.example.com/banner.png"},
+	}
+
+	tmpl := GetDefaultEmailTemplate()
+	tmpl.ShowTopicClusters = false
+	html, err := RenderHTMLEmail(emailData, tmpl)
+	if err != nil {
+		t.Fatalf("RenderHTMLEmail failed: %v", err)
+	}
+	if strings.Count(html, `class="article-card has-hero"`) != 1 || strings.Count(html, `class="article-card"`) != 1 {
+		t.Error("expected one image card and one text-only fallback card")
+	}
+	for _, want := range []string{
+		`<img src="https://cdn.example.com/a.png" alt="With Image"`,
+		`<div class="article-domain">example.com</div>`,
+		`<div class="article-domain">blog.example.org</div>`,
+		`<a href="https://blog.example.org/b">Without Image</a>`,
+		"banner.png",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected email to contain %q", want)
+		}
+	}
+
+	tmpl.RemoteImages = false
+	html, err = RenderHTMLEmail(emailData, tmpl)
+	if err != nil {
+		t.Fatalf("RenderHTMLEmail failed: %v", err)
+	}
+	if strings.Contains(html, "<img") || strings.Contains(html, `class="article-card has-hero"`) {
+		t.Error("expected no images with remote images turned off")
+	}
+	if !strings.Contains(html, "Summary A.") {
+		t.Error("expected the article to fall back to a text-only card")
+	}
+}
+
 func TestRenderHTMLEmail_WithTopicClusters(t *testing.T) {
 	emailData := EmailData{
 		Title: "Test Digest",
@@ -610,3 +699,31 @@ func TestEmailTemplate_Structure(t *testing.T) {
 		t.Error("ShowInsights field not working")
 	}
 }
+
+func TestSenderMessage(t *testing.T) {
+	sender := Sender{From: "briefly@example.com", FromName: "Briefly Ops"}
+	date := time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)
+	msg := string(sender.message([]string{"a@example.com", "b@example.com"}, "Pipeline health: Jun 2 – Jun 9", "text/plain", "# Report\n- line", date))
+
+	for _, want := range []string{
+		"From: Briefly Ops <briefly@example.com>\r\n",
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: =?utf-8?q?",
+		"Date: Mon, 09 Jun 2025 09:00:00 +0000\r\n",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+		"\r\n\r\n# Report\r\n- line",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected message to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestSenderSend_RequiresConfig(t *testing.T) {
+	if err := (Sender{From: "briefly@example.com"}).Send([]string{"a@example.com"}, "s", "b"); err == nil {
+		t.Error("expected an error without an SMTP host")
+	}
+	if err := (Sender{Host: "localhost"}).Send([]string{"a@example.com"}, "s", "b"); err == nil {
+		t.Error("expected an error without a from address")
+	}
+}
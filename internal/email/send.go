@@ -0,0 +1,114 @@
+package email
+
+import (
+	"briefly/internal/render"
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sender sends plain-text or HTML mail through an SMTP server (email.smtp in config)
+type Sender struct {
+	Host       string
+	Port       int
+	Username   string // Empty sends without authentication
+	Password   string
+	TLSEnabled bool // Require STARTTLS before authenticating
+	From       string
+	FromName   string
+}
+
+// Send mails a plain-text message to each recipient
+func (s Sender) Send(to []string, subject, body string) error {
+	return s.send(to, subject, "text/plain", body)
+}
+
+// SendHTML mails an HTML message to each recipient
+func (s Sender) SendHTML(to []string, subject, html string) error {
+	return s.send(to, subject, "text/html", html)
+}
+
+func (s Sender) send(to []string, subject, contentType, body string) error {
+	if s.Host == "" {
+		return fmt.Errorf("email.smtp.host is not configured")
+	}
+	if s.From == "" {
+		return fmt.Errorf("email.from_address is not configured")
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	port := s.Port
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(port))
+
+	client, err := smtp.Dial(addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	if s.TLSEnabled {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%s does not support STARTTLS (set email.smtp.tls_enabled: false to send unencrypted)", addr)
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			return fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+	if s.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.From); err != nil {
+		return fmt.Errorf("MAIL FROM rejected: %w", err)
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("recipient %s rejected: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA rejected: %w", err)
+	}
+	subject, body = render.Output(subject), render.Output(body)
+	if _, err := w.Write(s.message(to, subject, contentType, body, time.Now())); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// message formats the headers and body of a UTF-8 message of contentType
+func (s Sender) message(to []string, subject, contentType, body string, date time.Time) []byte {
+	from := s.From
+	if s.FromName != "" {
+		from = fmt.Sprintf("%s <%s>", mime.QEncoding.Encode("utf-8", s.FromName), s.From)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return msg.Bytes()
+}
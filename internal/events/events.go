@@ -0,0 +1,298 @@
+// Package events posts JSON webhooks on pipeline milestones (a digest generated, a
+// priority alert pushed, a feed failing repeatedly, a run going over its LLM cost
+// budget) so automation tools like n8n or Zapier can react without polling.
+package events
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Event types, used as the payload's "event" field and in webhook subscriptions
+const (
+	DigestGenerated    = "digest.generated"
+	AlertTriggered     = "alert.triggered"
+	FeedErrorThreshold = "feed.error_threshold"
+	BudgetExceeded     = "budget.exceeded"
+)
+
+// Types lists every event type
+var Types = []string{DigestGenerated, AlertTriggered, FeedErrorThreshold, BudgetExceeded}
+
+// Header names set on every webhook request
+const (
+	EventHeader     = "X-Briefly-Event"
+	VersionHeader   = "X-Briefly-Schema-Version"
+	SignatureHeader = "X-Briefly-Signature" // "sha256=<hex HMAC of the body>", when the webhook has a secret
+)
+
+// SchemaVersion is the version of the payload layout, sent as the payload's
+// "version" field and in the X-Briefly-Schema-Version header. It goes up only when
+// a change would break receivers (a field renamed, removed, or retyped); new fields
+// are added without a bump.
+const SchemaVersion = 1
+
+// requestTimeout bounds each webhook request, so a slow receiver can't stall a run
+const requestTimeout = 10 * time.Second
+
+// Payload is the JSON body posted for every event
+type Payload struct {
+	Version   int         `json:"version"`
+	Event     string      `json:"event"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// Digest is the data of a digest.generated event
+type Digest struct {
+	ID           string `json:"id,omitempty"`
+	Title        string `json:"title"`
+	Path         string `json:"path,omitempty"` // Markdown file the digest was written to
+	Format       string `json:"format,omitempty"`
+	ArticleCount int    `json:"article_count"`
+	TopicCount   int    `json:"topic_count"`
+}
+
+// Alert is the data of an alert.triggered event
+type Alert struct {
+	Rule   string   `json:"rule"`
+	URL    string   `json:"url"`
+	Title  string   `json:"title,omitempty"`
+	Source string   `json:"source,omitempty"`
+	Terms  []string `json:"terms,omitempty"`
+}
+
+// FeedErrors is the data of a feed.error_threshold event
+type FeedErrors struct {
+	FeedID     string `json:"feed_id"`
+	Title      string `json:"title,omitempty"`
+	URL        string `json:"url"`
+	ErrorCount int    `json:"error_count"`
+	Threshold  int    `json:"threshold"`
+	LastError  string `json:"last_error,omitempty"`
+}
+
+// Budget is the data of a budget.exceeded event
+type Budget struct {
+	Budget float64 `json:"budget_usd"`
+	Spent  float64 `json:"spent_usd"`
+	Calls  int     `json:"calls"`
+	Phase  string  `json:"phase,omitempty"` // Phase of the call that went over
+}
+
+// Webhook is an endpoint and the events it receives; no events means all of them
+type Webhook struct {
+	URL    string
+	Events []string
+	Secret string // Signs each body with HMAC-SHA256 in the X-Briefly-Signature header
+}
+
+// Emitter posts events to the webhooks subscribed to them. A nil Emitter emits
+// nothing, so callers needn't check whether webhooks are configured.
+type Emitter struct {
+	webhooks []Webhook
+	client   *http.Client
+}
+
+// New validates the webhooks and returns an Emitter, or nil when there are none
+func New(webhooks []Webhook) (*Emitter, error) {
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]bool, len(Types))
+	for _, t := range Types {
+		known[t] = true
+	}
+	for i, webhook := range webhooks {
+		if webhook.URL == "" {
+			return nil, fmt.Errorf("webhook %d has no url", i+1)
+		}
+		for _, event := range webhook.Events {
+			if !known[event] {
+				return nil, fmt.Errorf("webhook %s: unknown event %q (known: %v)", webhook.URL, event, Types)
+			}
+		}
+	}
+	return &Emitter{webhooks: webhooks, client: &http.Client{Timeout: requestTimeout}}, nil
+}
+
+// Emit posts an event to every webhook subscribed to it. Every webhook is tried;
+// the returned error joins the failures.
+func (e *Emitter) Emit(ctx context.Context, event string, data interface{}) error {
+	if e == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(Payload{Version: SchemaVersion, Event: event, Timestamp: time.Now().UTC(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event, err)
+	}
+
+	var errs []error
+	for _, webhook := range e.webhooks {
+		if !webhook.subscribed(event) {
+			continue
+		}
+		if err := e.post(ctx, webhook, event, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", webhook.URL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Subscribed reports whether any webhook receives event
+func (e *Emitter) Subscribed(event string) bool {
+	if e == nil {
+		return false
+	}
+	for _, webhook := range e.webhooks {
+		if webhook.subscribed(event) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Webhook) subscribed(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// post sends one event body to one webhook; any non-2xx response is an error
+func (e *Emitter) post(ctx context.Context, webhook Webhook, event string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(VersionHeader, strconv.Itoa(SchemaVersion))
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the X-Briefly-Signature value for body: "sha256=" and the hex
+// HMAC-SHA256 of body keyed with secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a received webhook the way a receiver should: the signature must
+// match body under secret, the payload's schema version must be one this package
+// knows, and, when maxAge is positive, its timestamp must be within maxAge of now
+// so a captured request can't be replayed later. The timestamp is inside the signed
+// body, so it can't be changed without breaking the signature.
+func Verify(secret string, body []byte, signature string, maxAge time.Duration) (*Payload, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("no secret to verify the signature with")
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, body))) {
+		return nil, fmt.Errorf("signature does not match the payload")
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	if payload.Version < 1 || payload.Version > SchemaVersion {
+		return nil, fmt.Errorf("unsupported payload schema version %d (supported: 1-%d)", payload.Version, SchemaVersion)
+	}
+	if maxAge > 0 {
+		if age := time.Since(payload.Timestamp); age > maxAge || age < -maxAge {
+			return nil, fmt.Errorf("payload timestamp %s is outside the allowed %s", payload.Timestamp.Format(time.RFC3339), maxAge)
+		}
+	}
+	return &payload, nil
+}
+
+// FeedCrossedThreshold reports whether a feed that just failed has reached
+// threshold consecutive failures. It is true only on the failure that reaches it,
+// so one streak of failures emits one event.
+func FeedCrossedThreshold(feed core.Feed, threshold int) bool {
+	return threshold > 0 && feed.ErrorCount == threshold
+}
+
+// CostBudget tracks a run's estimated LLM spend and emits budget.exceeded the
+// first time it passes the limit. The run is not stopped.
+type CostBudget struct {
+	limit   float64
+	emitter *Emitter
+
+	mu    sync.Mutex
+	spent float64
+	calls int
+	fired bool
+}
+
+// NewCostBudget returns a budget of limit USD per run, or nil when limit is not
+// positive or nothing is subscribed to budget.exceeded
+func NewCostBudget(limit float64, emitter *Emitter) *CostBudget {
+	if limit <= 0 || !emitter.Subscribed(BudgetExceeded) {
+		return nil
+	}
+	return &CostBudget{limit: limit, emitter: emitter}
+}
+
+// Recorder returns a usage recorder that adds each call to the budget before
+// passing it on to next (which may be nil). A nil budget returns next unchanged.
+func (b *CostBudget) Recorder(next llm.UsageRecorder) llm.UsageRecorder {
+	if b == nil {
+		return next
+	}
+	return func(ctx context.Context, call core.LLMCall) {
+		if next != nil {
+			next(ctx, call)
+		}
+		if over, ok := b.add(call); ok {
+			if err := b.emitter.Emit(context.WithoutCancel(ctx), BudgetExceeded, over); err != nil {
+				log.Printf("[WARN] Failed to send %s event: %v", BudgetExceeded, err)
+			}
+		}
+	}
+}
+
+// add records a call and returns the event data when it takes spend over the limit
+func (b *CostBudget) add(call core.LLMCall) (Budget, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent += llm.EstimateCost(call.Model, call.TokensIn, call.TokensOut)
+	b.calls++
+	if b.fired || b.spent <= b.limit {
+		return Budget{}, false
+	}
+	b.fired = true
+	return Budget{Budget: b.limit, Spent: b.spent, Calls: b.calls, Phase: call.Phase}, true
+}
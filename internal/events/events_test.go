@@ -0,0 +1,173 @@
+package events
+
+import (
+	"briefly/internal/core"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// receiver records the requests posted to a test webhook
+type receiver struct {
+	mu       sync.Mutex
+	payloads []Payload
+	headers  []http.Header
+	bodies   [][]byte
+}
+
+func (r *receiver) server(t *testing.T, status int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		var payload Payload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		r.mu.Lock()
+		r.payloads = append(r.payloads, payload)
+		r.headers = append(r.headers, req.Header)
+		r.bodies = append(r.bodies, body)
+		r.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNew(t *testing.T) {
+	if emitter, err := New(nil); emitter != nil || err != nil {
+		t.Errorf("expected no emitter without webhooks, got %v, %v", emitter, err)
+	}
+	if _, err := New([]Webhook{{Events: []string{DigestGenerated}}}); err == nil {
+		t.Error("expected an error for a webhook without a url")
+	}
+	if _, err := New([]Webhook{{URL: "http://example.com", Events: []string{"digest.created"}}}); err == nil {
+		t.Error("expected an error for an unknown event")
+	}
+
+	var nilEmitter *Emitter
+	if err := nilEmitter.Emit(context.Background(), DigestGenerated, Digest{}); err != nil {
+		t.Errorf("expected a nil emitter to do nothing, got %v", err)
+	}
+}
+
+func TestEmit(t *testing.T) {
+	all, digests := &receiver{}, &receiver{}
+	failing := (&receiver{}).server(t, http.StatusInternalServerError)
+	emitter, err := New([]Webhook{
+		{URL: all.server(t, http.StatusOK).URL, Secret: "s3cret"},
+		{URL: digests.server(t, http.StatusNoContent).URL, Events: []string{DigestGenerated}},
+		{URL: failing.URL, Events: []string{AlertTriggered}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := emitter.Emit(ctx, DigestGenerated, Digest{Title: "AI Weekly", ArticleCount: 12}); err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+	if err := emitter.Emit(ctx, AlertTriggered, Alert{Rule: "cve", URL: "https://example.com/cve"}); err == nil {
+		t.Error("expected the failing webhook's error")
+	}
+
+	if len(all.payloads) != 2 || len(digests.payloads) != 1 {
+		t.Fatalf("expected the unfiltered webhook to get both events and the digest webhook one, got %d and %d", len(all.payloads), len(digests.payloads))
+	}
+	got := digests.payloads[0]
+	if got.Version != SchemaVersion || got.Event != DigestGenerated || got.Timestamp.IsZero() {
+		t.Errorf("unexpected payload: %+v", got)
+	}
+	if data, _ := got.Data.(map[string]interface{}); data["title"] != "AI Weekly" || data["article_count"] != float64(12) {
+		t.Errorf("unexpected data: %+v", got.Data)
+	}
+	if digests.headers[0].Get(EventHeader) != DigestGenerated || digests.headers[0].Get(VersionHeader) != "1" || digests.headers[0].Get(SignatureHeader) != "" {
+		t.Errorf("unexpected headers: %v", digests.headers[0])
+	}
+	if signature := all.headers[0].Get(SignatureHeader); signature != Sign("s3cret", all.bodies[0]) {
+		t.Errorf("expected the body signed with the webhook secret, got %q", signature)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	signed := func(payload Payload) ([]byte, string) {
+		body, _ := json.Marshal(payload)
+		return body, Sign("s3cret", body)
+	}
+
+	body, signature := signed(Payload{Version: SchemaVersion, Event: DigestGenerated, Timestamp: time.Now().UTC()})
+	payload, err := Verify("s3cret", body, signature, 5*time.Minute)
+	if err != nil || payload.Event != DigestGenerated {
+		t.Fatalf("expected a fresh signed payload to verify, got %+v, %v", payload, err)
+	}
+	if _, err := Verify("other", body, signature, 0); err == nil {
+		t.Error("expected a signature under another secret to fail")
+	}
+	if _, err := Verify("s3cret", append(body, ' '), signature, 0); err == nil {
+		t.Error("expected a changed body to fail")
+	}
+	if _, err := Verify("", body, Sign("", body), 0); err == nil {
+		t.Error("expected verifying without a secret to fail")
+	}
+
+	body, signature = signed(Payload{Version: SchemaVersion, Event: DigestGenerated, Timestamp: time.Now().Add(-time.Hour)})
+	if _, err := Verify("s3cret", body, signature, 5*time.Minute); err == nil {
+		t.Error("expected a stale payload to fail")
+	}
+	if _, err := Verify("s3cret", body, signature, 0); err != nil {
+		t.Errorf("expected no age check without maxAge, got %v", err)
+	}
+
+	body, signature = signed(Payload{Version: SchemaVersion + 1, Event: DigestGenerated, Timestamp: time.Now()})
+	if _, err := Verify("s3cret", body, signature, 0); err == nil {
+		t.Error("expected an unknown schema version to fail")
+	}
+}
+
+func TestCostBudget(t *testing.T) {
+	var nilBudget *CostBudget
+	if nilBudget.Recorder(nil) != nil {
+		t.Error("expected a nil budget to pass the recorder through")
+	}
+
+	budgets := &receiver{}
+	emitter, err := New([]Webhook{{URL: budgets.server(t, http.StatusOK).URL, Events: []string{BudgetExceeded}}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if NewCostBudget(0, emitter) != nil {
+		t.Error("expected no budget without a limit")
+	}
+
+	recorded := 0
+	recorder := NewCostBudget(1, emitter).Recorder(func(context.Context, core.LLMCall) { recorded++ })
+	// gemini-2.5-pro: each call is $0.125 in and $0.40 out, so the second one goes over
+	call := core.LLMCall{Model: "gemini-2.5-pro", Phase: "digest", TokensIn: 100_000, TokensOut: 40_000}
+	for i := 0; i < 3; i++ {
+		recorder(context.Background(), call)
+	}
+
+	if recorded != 3 {
+		t.Errorf("expected every call passed on, got %d", recorded)
+	}
+	if len(budgets.payloads) != 1 {
+		t.Fatalf("expected one event when spend first passed the budget, got %d", len(budgets.payloads))
+	}
+	data, _ := budgets.payloads[0].Data.(map[string]interface{})
+	if data["calls"] != float64(2) || data["budget_usd"] != float64(1) || data["phase"] != "digest" {
+		t.Errorf("unexpected data: %+v", data)
+	}
+}
+
+func TestFeedCrossedThreshold(t *testing.T) {
+	if !FeedCrossedThreshold(core.Feed{ErrorCount: 3}, 3) {
+		t.Error("expected the failure reaching the threshold to cross it")
+	}
+	if FeedCrossedThreshold(core.Feed{ErrorCount: 4}, 3) || FeedCrossedThreshold(core.Feed{ErrorCount: 3}, 0) {
+		t.Error("expected later failures and a disabled threshold not to cross it")
+	}
+}
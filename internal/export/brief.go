@@ -0,0 +1,226 @@
+package export
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/research"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlockKind is the kind of a document block
+type BlockKind int
+
+const (
+	BlockHeading BlockKind = iota
+	BlockParagraph
+	BlockBullet
+)
+
+// Document is a research brief prepared for DOCX and PDF export: a cover page, the
+// brief's sections, footnotes for its citations, and the full source list
+type Document struct {
+	Title    string
+	Subtitle string
+	Date     time.Time
+	Cover    []string // Cover page details, one per line
+	Blocks   []Block
+	Notes    []Note // Footnotes, numbered from 1 in order of first citation
+	Sources  []Note // Every source, in the brief's [n] numbering
+}
+
+// Block is a heading, paragraph, or bullet
+type Block struct {
+	Kind  BlockKind
+	Spans []Span
+}
+
+// Span is a run of text in one style. A span with Note set is a reference to that
+// footnote instead of text; First marks the reference the footnote is placed with.
+type Span struct {
+	Text   string
+	Bold   bool
+	Italic bool
+	Note   int
+	First  bool
+}
+
+// Note is a footnote or source entry
+type Note struct {
+	Text string
+	URL  string
+}
+
+var (
+	// briefCitationPattern matches [n] and [n, m] citations in a brief's summary
+	briefCitationPattern = regexp.MustCompile(`\s*\[(\d+(?:\s*,\s*\d+)*)\]`)
+	// markdownLinkPattern matches [text](url) links, which export as their text
+	markdownLinkPattern = regexp.MustCompile(`\[([^\]]+)\]\([^)]+\)`)
+)
+
+// NewDocumentFromBrief lays out a research report for export. Citations in the
+// summary become footnotes; a source cited again refers back to its footnote.
+func NewDocumentFromBrief(report *core.ResearchReport) *Document {
+	date := report.DateGenerated
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+
+	doc := &Document{
+		Title:    report.Query,
+		Subtitle: "Research Brief",
+		Date:     date,
+	}
+	for _, result := range report.Results {
+		doc.Sources = append(doc.Sources, sourceNote(result))
+	}
+
+	doc.Cover = append(doc.Cover, date.Format("January 2, 2006"))
+	doc.Cover = append(doc.Cover, fmt.Sprintf("%d sources · %d research queries", len(report.Results), len(report.GeneratedQueries)))
+	mix := report.SourceMix
+	if mix == nil && len(report.Results) > 0 {
+		mix = research.SourceMix(report.Results)
+	}
+	if formatted := research.FormatSourceMix(mix); formatted != "" {
+		doc.Cover = append(doc.Cover, "Source mix: "+formatted)
+	}
+	doc.Cover = append(doc.Cover, "Prepared with Briefly")
+
+	noteFor := make(map[int]int) // Source index -> footnote number
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			doc.Blocks = append(doc.Blocks, Block{Kind: BlockParagraph, Spans: doc.parseInline(strings.Join(paragraph, " "), noteFor)})
+			paragraph = nil
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(report.Summary, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+			flush()
+			heading := strings.TrimSpace(strings.TrimLeft(line, "#"))
+			doc.Blocks = append(doc.Blocks, Block{Kind: BlockHeading, Spans: doc.parseInline(heading, noteFor)})
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "• "):
+			flush()
+			item := strings.TrimSpace(line[strings.Index(line, " ")+1:])
+			doc.Blocks = append(doc.Blocks, Block{Kind: BlockBullet, Spans: doc.parseInline(item, noteFor)})
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+
+	return doc
+}
+
+// parseInline splits text into styled spans, turning citations of known sources
+// into footnote references
+func (doc *Document) parseInline(text string, noteFor map[int]int) []Span {
+	text = markdownLinkPattern.ReplaceAllString(text, "$1")
+
+	var spans []Span
+	last := 0
+	for _, match := range briefCitationPattern.FindAllStringSubmatchIndex(text, -1) {
+		var refs []Span
+		for _, part := range strings.Split(text[match[2]:match[3]], ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || n < 1 || n > len(doc.Sources) {
+				refs = nil
+				break
+			}
+			note, ok := noteFor[n-1]
+			if !ok {
+				doc.Notes = append(doc.Notes, doc.Sources[n-1])
+				note = len(doc.Notes)
+				noteFor[n-1] = note
+			}
+			refs = append(refs, Span{Note: note, First: !ok})
+		}
+		if refs == nil {
+			continue // Not a citation of a known source; keep it as text
+		}
+		spans = append(spans, parseEmphasis(text[last:match[0]])...)
+		spans = append(spans, refs...)
+		last = match[1]
+	}
+	return append(spans, parseEmphasis(text[last:])...)
+}
+
+// parseEmphasis splits text on **bold** and *italic* markers
+func parseEmphasis(text string) []Span {
+	var spans []Span
+	var current strings.Builder
+	bold, italic := false, false
+	emit := func() {
+		if current.Len() > 0 {
+			spans = append(spans, Span{Text: current.String(), Bold: bold, Italic: italic})
+			current.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], "**"):
+			emit()
+			bold = !bold
+			i++
+		case text[i] == '*' && (italic || (i+1 < len(text) && text[i+1] != ' ')):
+			emit()
+			italic = !italic
+		default:
+			current.WriteByte(text[i])
+		}
+	}
+	emit()
+	return spans
+}
+
+// sourceNote renders a source as a reference: papers as "Authors (Year). Title.
+// Venue · N citations.", other sources as "Title. domain."
+func sourceNote(result core.ResearchResult) Note {
+	title := strings.TrimSpace(result.Title)
+	if title == "" {
+		title = result.URL
+	}
+
+	var text strings.Builder
+	if len(result.Authors) > 0 {
+		authors := result.Authors
+		byline := strings.Join(authors, ", ")
+		if len(authors) > 3 {
+			byline = strings.Join(authors[:3], ", ") + " et al."
+		}
+		if !result.PublishedDate.IsZero() {
+			byline += fmt.Sprintf(" (%d)", result.PublishedDate.Year())
+		}
+		text.WriteString(strings.TrimSuffix(byline, ".") + ". ")
+		text.WriteString(strings.TrimSuffix(title, ".") + ".")
+		var details []string
+		if result.Venue != "" {
+			details = append(details, result.Venue)
+		}
+		if result.CitationCount > 0 {
+			details = append(details, fmt.Sprintf("%d citations", result.CitationCount))
+		}
+		if len(details) > 0 {
+			text.WriteString(" " + strings.Join(details, " · ") + ".")
+		}
+		return Note{Text: text.String(), URL: result.URL}
+	}
+
+	text.WriteString(strings.TrimSuffix(title, ".") + ".")
+	if parsed, err := url.Parse(result.URL); err == nil {
+		host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+		if host != "" && !strings.EqualFold(host, title) && !strings.HasSuffix(host, "vertexaisearch.cloud.google.com") {
+			text.WriteString(" " + host + ".")
+		}
+	}
+	return Note{Text: text.String(), URL: result.URL}
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testBriefReport() *core.ResearchReport {
+	return &core.ResearchReport{
+		Query:            "Vector search",
+		GeneratedQueries: []string{"q1", "q2"},
+		DateGenerated:    time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC),
+		Summary: "HNSW is the **default** index [1]. It trades memory for speed [1, 2].\n" +
+			"Quantization helps [9].\n\n" +
+			"## Key Findings\n" +
+			"- Graph indexes lead on *recall* [2]\n" +
+			"- See [the docs](https://example.com/docs) for tuning",
+		Results: []core.ResearchResult{
+			{
+				Title:         "Efficient and robust ANN search",
+				URL:           "https://arxiv.org/abs/1603.09320",
+				Kind:          "paper",
+				Authors:       []string{"Yury Malkov", "Dmitry Yashunin"},
+				PublishedDate: time.Date(2016, time.March, 30, 0, 0, 0, 0, time.UTC),
+				Venue:         "TPAMI",
+				CitationCount: 1200,
+			},
+			{Title: "Vector search explained", URL: "https://www.example.com/vectors", Kind: "blog"},
+		},
+	}
+}
+
+func TestNewDocumentFromBrief(t *testing.T) {
+	doc := NewDocumentFromBrief(testBriefReport())
+
+	if doc.Title != "Vector search" || len(doc.Sources) != 2 || len(doc.Notes) != 2 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	if doc.Sources[0].Text != "Yury Malkov, Dmitry Yashunin (2016). Efficient and robust ANN search. TPAMI · 1200 citations." {
+		t.Errorf("unexpected paper reference %q", doc.Sources[0].Text)
+	}
+	if doc.Sources[1].Text != "Vector search explained. example.com." {
+		t.Errorf("unexpected web reference %q", doc.Sources[1].Text)
+	}
+	if !strings.Contains(strings.Join(doc.Cover, "\n"), "2 sources · 2 research queries") {
+		t.Errorf("unexpected cover %v", doc.Cover)
+	}
+
+	if len(doc.Blocks) != 4 || doc.Blocks[1].Kind != BlockHeading || doc.Blocks[2].Kind != BlockBullet {
+		t.Fatalf("unexpected blocks: %+v", doc.Blocks)
+	}
+
+	// "HNSW is the " "default" " index" [1 first] ". It trades memory for speed" [1] [2 first] ". Quantization helps [9]."
+	spans := doc.Blocks[0].Spans
+	if !spans[1].Bold || spans[1].Text != "default" || spans[2].Text != " index" {
+		t.Errorf("expected bold text and the space before the citation dropped, got %+v", spans)
+	}
+	if spans[3].Note != 1 || !spans[3].First || spans[5].Note != 1 || spans[5].First || spans[6].Note != 2 || !spans[6].First {
+		t.Errorf("expected footnote 1 placed once and referenced again, got %+v", spans)
+	}
+	if last := spans[len(spans)-1]; last.Text != ". Quantization helps [9]." {
+		t.Errorf("expected a citation of an unknown source kept as text, got %+v", last)
+	}
+
+	bullet := doc.Blocks[2].Spans
+	if !bullet[1].Italic || bullet[2].Note != 2 || bullet[2].First {
+		t.Errorf("unexpected bullet spans %+v", bullet)
+	}
+	if doc.Blocks[3].Spans[0].Text != "See the docs for tuning" {
+		t.Errorf("expected markdown links exported as text, got %+v", doc.Blocks[3].Spans)
+	}
+}
@@ -0,0 +1,298 @@
+package export
+
+import (
+	"archive/zip"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	wordNamespaces = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+	hyperlinkRelType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
+
+	numBullet  = 1 // numbering.xml num IDs
+	numDecimal = 2
+)
+
+// WriteDOCX writes the document as a Word file with a cover page, native footnotes
+// for citations, and a numbered source list
+func WriteDOCX(doc *Document, w io.Writer) error {
+	documentLinks := &docxLinks{}
+	footnoteLinks := &docxLinks{}
+	body := renderDOCXBody(doc, documentLinks)
+	footnotes := renderDOCXFootnotes(doc, footnoteLinks)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxPackageRels},
+		{"docProps/core.xml", renderDOCXCoreProps(doc)},
+		{"word/document.xml", body},
+		{"word/styles.xml", docxStyles},
+		{"word/numbering.xml", docxNumbering},
+		{"word/footnotes.xml", footnotes},
+		{"word/_rels/document.xml.rels", documentLinks.render(`
+  <Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+  <Relationship Id="rIdNumbering" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>
+  <Relationship Id="rIdFootnotes" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/footnotes" Target="footnotes.xml"/>`)},
+		{"word/_rels/footnotes.xml.rels", footnoteLinks.render("")},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", f.name, err)
+		}
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// SaveDOCX writes the document to the given path, creating parent directories as needed
+func SaveDOCX(doc *Document, path string) error {
+	return saveFile(path, func(w io.Writer) error { return WriteDOCX(doc, w) })
+}
+
+// saveFile creates path and its parent directories and writes it with write
+func saveFile(path string, write func(io.Writer) error) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// docxLinks collects the external hyperlinks of one part as relationships
+type docxLinks struct {
+	targets []string
+}
+
+func (l *docxLinks) add(target string) string {
+	l.targets = append(l.targets, target)
+	return fmt.Sprintf("rIdLink%d", len(l.targets))
+}
+
+func (l *docxLinks) render(fixed string) string {
+	var rels strings.Builder
+	rels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	rels.WriteString(fixed)
+	for i, target := range l.targets {
+		fmt.Fprintf(&rels, "\n  <Relationship Id=\"rIdLink%d\" Type=\"%s\" Target=\"%s\" TargetMode=\"External\"/>",
+			i+1, hyperlinkRelType, html.EscapeString(target))
+	}
+	rels.WriteString("\n</Relationships>\n")
+	return rels.String()
+}
+
+func renderDOCXBody(doc *Document, links *docxLinks) string {
+	var body strings.Builder
+
+	// Cover page
+	body.WriteString(docxParagraph("Title", "", docxRun(doc.Title, false, false)))
+	body.WriteString(docxParagraph("Subtitle", "", docxRun(doc.Subtitle, false, false)))
+	for _, line := range doc.Cover {
+		body.WriteString(docxParagraph("CoverDetail", "", docxRun(line, false, false)))
+	}
+	body.WriteString(`<w:p><w:r><w:br w:type="page"/></w:r></w:p>`)
+
+	for _, block := range doc.Blocks {
+		var runs strings.Builder
+		for i, span := range block.Spans {
+			if span.Note > 0 && i > 0 && block.Spans[i-1].Note > 0 {
+				runs.WriteString(`<w:r><w:rPr><w:rStyle w:val="FootnoteReference"/></w:rPr><w:t>,</w:t></w:r>`)
+			}
+			runs.WriteString(docxSpan(span))
+		}
+		switch block.Kind {
+		case BlockHeading:
+			body.WriteString(docxParagraph("Heading1", "", runs.String()))
+		case BlockBullet:
+			body.WriteString(docxParagraph("ListParagraph", docxNumPr(numBullet), runs.String()))
+		default:
+			body.WriteString(docxParagraph("", "", runs.String()))
+		}
+	}
+
+	if len(doc.Sources) > 0 {
+		body.WriteString(docxParagraph("Heading1", "", docxRun("Sources", false, false)))
+		for _, source := range doc.Sources {
+			runs := docxRun(source.Text+" ", false, false) + docxHyperlink(source.URL, links)
+			body.WriteString(docxParagraph("ListParagraph", docxNumPr(numDecimal), runs))
+		}
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document %s>
+<w:body>
+%s
+<w:sectPr><w:pgSz w:w="12240" w:h="15840"/><w:pgMar w:top="1440" w:right="1440" w:bottom="1440" w:left="1440" w:header="720" w:footer="720" w:gutter="0"/></w:sectPr>
+</w:body>
+</w:document>
+`, wordNamespaces, body.String())
+}
+
+func renderDOCXFootnotes(doc *Document, links *docxLinks) string {
+	var notes strings.Builder
+	for i, note := range doc.Notes {
+		runs := `<w:r><w:rPr><w:rStyle w:val="FootnoteReference"/></w:rPr><w:footnoteRef/></w:r>` +
+			docxRun(" "+note.Text+" ", false, false) + docxHyperlink(note.URL, links)
+		fmt.Fprintf(&notes, "<w:footnote w:id=\"%d\">%s</w:footnote>\n", i+1, docxParagraph("FootnoteText", "", runs))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:footnotes %s>
+<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>
+<w:footnote w:type="continuationSeparator" w:id="0"><w:p><w:r><w:continuationSeparator/></w:r></w:p></w:footnote>
+%s</w:footnotes>
+`, wordNamespaces, notes.String())
+}
+
+func renderDOCXCoreProps(doc *Document) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <dc:title>%s</dc:title>
+  <dc:subject>%s</dc:subject>
+  <dc:creator>Briefly</dc:creator>
+  <dcterms:created xsi:type="dcterms:W3CDTF">%s</dcterms:created>
+</cp:coreProperties>
+`, docxText(doc.Title), docxText(doc.Subtitle), doc.Date.UTC().Format("2006-01-02T15:04:05Z"))
+}
+
+// docxSpan renders a span: text as a run, a footnote's first reference as the
+// footnote itself, and later references as a superscript number
+func docxSpan(span Span) string {
+	switch {
+	case span.Note > 0 && span.First:
+		return fmt.Sprintf(`<w:r><w:rPr><w:rStyle w:val="FootnoteReference"/></w:rPr><w:footnoteReference w:id="%d"/></w:r>`, span.Note)
+	case span.Note > 0:
+		return fmt.Sprintf(`<w:r><w:rPr><w:rStyle w:val="FootnoteReference"/></w:rPr><w:t>%d</w:t></w:r>`, span.Note)
+	default:
+		return docxRun(span.Text, span.Bold, span.Italic)
+	}
+}
+
+func docxParagraph(style, numPr, runs string) string {
+	var props string
+	if style != "" || numPr != "" {
+		props = "<w:pPr>"
+		if style != "" {
+			props += fmt.Sprintf(`<w:pStyle w:val="%s"/>`, style)
+		}
+		props += numPr + "</w:pPr>"
+	}
+	return "<w:p>" + props + runs + "</w:p>\n"
+}
+
+func docxNumPr(numID int) string {
+	return fmt.Sprintf(`<w:numPr><w:ilvl w:val="0"/><w:numId w:val="%d"/></w:numPr>`, numID)
+}
+
+func docxRun(text string, bold, italic bool) string {
+	if text == "" {
+		return ""
+	}
+	var props string
+	if bold || italic {
+		props = "<w:rPr>"
+		if bold {
+			props += "<w:b/>"
+		}
+		if italic {
+			props += "<w:i/>"
+		}
+		props += "</w:rPr>"
+	}
+	return fmt.Sprintf(`<w:r>%s<w:t xml:space="preserve">%s</w:t></w:r>`, props, docxText(text))
+}
+
+func docxHyperlink(target string, links *docxLinks) string {
+	if target == "" {
+		return ""
+	}
+	return fmt.Sprintf(`<w:hyperlink r:id="%s"><w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t>%s</w:t></w:r></w:hyperlink>`,
+		links.add(target), docxText(target))
+}
+
+// docxText escapes text for WordprocessingML, dropping characters XML can't carry
+func docxText(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, text)
+	return html.EscapeString(text)
+}
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+  <Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+  <Default Extension="xml" ContentType="application/xml"/>
+  <Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+  <Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+  <Override PartName="/word/numbering.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"/>
+  <Override PartName="/word/footnotes.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.footnotes+xml"/>
+  <Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>
+`
+
+const docxPackageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>
+`
+
+const docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:docDefaults>
+    <w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:cs="Calibri"/><w:sz w:val="22"/></w:rPr></w:rPrDefault>
+    <w:pPrDefault><w:pPr><w:spacing w:after="160" w:line="276" w:lineRule="auto"/></w:pPr></w:pPrDefault>
+  </w:docDefaults>
+  <w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>
+  <w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/>
+    <w:pPr><w:spacing w:before="2880" w:after="240"/></w:pPr><w:rPr><w:b/><w:sz w:val="56"/></w:rPr></w:style>
+  <w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/>
+    <w:pPr><w:spacing w:after="480"/></w:pPr><w:rPr><w:color w:val="595959"/><w:sz w:val="32"/></w:rPr></w:style>
+  <w:style w:type="paragraph" w:customStyle="1" w:styleId="CoverDetail"><w:name w:val="Cover Detail"/><w:basedOn w:val="Normal"/>
+    <w:pPr><w:spacing w:after="80"/></w:pPr><w:rPr><w:color w:val="404040"/></w:rPr></w:style>
+  <w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>
+    <w:pPr><w:keepNext/><w:spacing w:before="320" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="28"/></w:rPr></w:style>
+  <w:style w:type="paragraph" w:styleId="ListParagraph"><w:name w:val="List Paragraph"/><w:basedOn w:val="Normal"/>
+    <w:pPr><w:spacing w:after="80"/><w:ind w:left="720"/></w:pPr></w:style>
+  <w:style w:type="paragraph" w:styleId="FootnoteText"><w:name w:val="footnote text"/><w:basedOn w:val="Normal"/>
+    <w:pPr><w:spacing w:after="0" w:line="240" w:lineRule="auto"/></w:pPr><w:rPr><w:sz w:val="18"/></w:rPr></w:style>
+  <w:style w:type="character" w:styleId="FootnoteReference"><w:name w:val="footnote reference"/><w:rPr><w:vertAlign w:val="superscript"/></w:rPr></w:style>
+  <w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/><w:rPr><w:color w:val="0563C1"/><w:u w:val="single"/></w:rPr></w:style>
+</w:styles>
+`
+
+const docxNumbering = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:abstractNum w:abstractNumId="0"><w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="•"/><w:lvlJc w:val="left"/><w:pPr><w:ind w:left="720" w:hanging="360"/></w:pPr></w:lvl></w:abstractNum>
+  <w:abstractNum w:abstractNumId="1"><w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="decimal"/><w:lvlText w:val="%1."/><w:lvlJc w:val="left"/><w:pPr><w:ind w:left="720" w:hanging="360"/></w:pPr></w:lvl></w:abstractNum>
+  <w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>
+  <w:num w:numId="2"><w:abstractNumId w:val="1"/></w:num>
+</w:numbering>
+`
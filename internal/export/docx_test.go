@@ -0,0 +1,57 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOCX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDOCX(NewDocumentFromBrief(testBriefReport()), &buf); err != nil {
+		t.Fatalf("WriteDOCX failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("output is not a zip: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		parts[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/styles.xml",
+		"word/numbering.xml", "word/footnotes.xml", "word/_rels/document.xml.rels", "word/_rels/footnotes.xml.rels"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	document := parts["word/document.xml"]
+	if strings.Count(document, "<w:footnoteReference") != 2 {
+		t.Errorf("expected one footnote reference per cited source:\n%s", document)
+	}
+	if !strings.Contains(document, `<w:br w:type="page"/>`) || !strings.Contains(document, "Research Brief") {
+		t.Error("expected a cover page")
+	}
+	if !strings.Contains(document, "TPAMI · 1200 citations") || !strings.Contains(document, `<w:hyperlink r:id="rIdLink1">`) {
+		t.Error("expected the source list with links")
+	}
+
+	footnotes := parts["word/footnotes.xml"]
+	if !strings.Contains(footnotes, `<w:footnote w:id="2">`) || !strings.Contains(footnotes, "Vector search explained. example.com.") {
+		t.Errorf("unexpected footnotes:\n%s", footnotes)
+	}
+	if !strings.Contains(parts["word/_rels/footnotes.xml.rels"], `Target="https://www.example.com/vectors" TargetMode="External"`) {
+		t.Error("expected footnote links as external relationships")
+	}
+}
@@ -0,0 +1,322 @@
+// Package export converts digests into offline reading formats such as EPUB, and
+// research briefs into DOCX and PDF documents for sharing
+package export
+
+import (
+	"archive/zip"
+	"briefly/internal/core"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Chapter is a single XHTML document inside the book
+type Chapter struct {
+	Title  string
+	Source string // Original article URL (empty for non-article chapters)
+	Body   string // Plain text or light markdown; paragraphs separated by blank lines
+}
+
+// Book is an e-reader friendly representation of a digest
+type Book struct {
+	ID       string
+	Title    string
+	Author   string
+	Language string
+	Date     time.Time
+	Chapters []Chapter
+}
+
+// NewBookFromDigest builds a book with the digest summary as the first chapter
+// followed by one chapter per article containing its full cleaned text.
+func NewBookFromDigest(digest *core.Digest, articles []core.Article) *Book {
+	title := digest.Title
+	if title == "" {
+		title = digest.Metadata.Title
+	}
+	if title == "" {
+		title = "Briefly Digest"
+	}
+
+	date := digest.ProcessedDate
+	if date.IsZero() {
+		date = digest.DateGenerated
+	}
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+
+	book := &Book{
+		ID:       digest.ID,
+		Title:    title,
+		Author:   "Briefly",
+		Language: "en",
+		Date:     date,
+	}
+
+	// Digest overview chapter
+	var overview strings.Builder
+	if digest.TLDRSummary != "" {
+		overview.WriteString(digest.TLDRSummary)
+		overview.WriteString("\n\n")
+	}
+	summary := digest.Summary
+	if summary == "" {
+		summary = digest.DigestSummary
+	}
+	if summary == "" {
+		summary = digest.Content
+	}
+	overview.WriteString(summary)
+	if len(digest.TopDevelopments) > 0 {
+		overview.WriteString("\n\n## Top Developments\n\n")
+		for _, dev := range digest.TopDevelopments {
+			overview.WriteString("- " + dev + "\n")
+		}
+	}
+	if digest.WhyItMatters != "" {
+		overview.WriteString("\n\n## Why It Matters\n\n")
+		overview.WriteString(digest.WhyItMatters)
+	}
+	book.Chapters = append(book.Chapters, Chapter{Title: "Digest Summary", Body: overview.String()})
+
+	for i, article := range articles {
+		articleTitle := article.Title
+		if articleTitle == "" {
+			articleTitle = article.URL
+		}
+		text := article.CleanedText
+		if text == "" {
+			text = article.RawContent
+		}
+		if text == "" {
+			text = "Full text was not available for this article."
+		}
+		book.Chapters = append(book.Chapters, Chapter{
+			Title:  fmt.Sprintf("%d. %s", i+1, articleTitle),
+			Source: article.URL,
+			Body:   text,
+		})
+	}
+
+	return book
+}
+
+// WriteEPUB writes the book as an EPUB 3 file (with an NCX for older readers)
+func WriteEPUB(book *Book, w io.Writer) error {
+	if book.ID == "" {
+		book.ID = uuid.NewString()
+	}
+
+	zw := zip.NewWriter(w)
+
+	// The mimetype entry must be first and stored without compression
+	mt, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("failed to write mimetype: %w", err)
+	}
+	if _, err := io.WriteString(mt, "application/epub+zip"); err != nil {
+		return fmt.Errorf("failed to write mimetype: %w", err)
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"META-INF/container.xml", containerXML},
+		{"OEBPS/content.opf", renderOPF(book)},
+		{"OEBPS/nav.xhtml", renderNav(book)},
+		{"OEBPS/toc.ncx", renderNCX(book)},
+		{"OEBPS/style.css", stylesheet},
+	}
+	for i, ch := range book.Chapters {
+		files = append(files, struct {
+			name    string
+			content string
+		}{"OEBPS/" + chapterFile(i), renderChapter(ch)})
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", f.name, err)
+		}
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+// SaveEPUB writes the book to the given path, creating parent directories as needed
+func SaveEPUB(book *Book, path string) error {
+	return saveFile(path, func(w io.Writer) error { return WriteEPUB(book, w) })
+}
+
+func chapterFile(i int) string {
+	return fmt.Sprintf("chapter_%03d.xhtml", i+1)
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+const stylesheet = `body { font-family: serif; line-height: 1.5; }
+h1 { font-size: 1.4em; }
+p.source { font-size: 0.85em; font-style: italic; }
+`
+
+func renderOPF(book *Book) string {
+	var manifest, spine strings.Builder
+	for i := range book.Chapters {
+		fmt.Fprintf(&manifest, "    <item id=\"ch%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i+1, chapterFile(i))
+		fmt.Fprintf(&spine, "    <itemref idref=\"ch%d\"/>\n", i+1)
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="bookid">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="bookid">urn:briefly:%s</dc:identifier>
+    <dc:title>%s</dc:title>
+    <dc:creator>%s</dc:creator>
+    <dc:language>%s</dc:language>
+    <dc:date>%s</dc:date>
+    <meta property="dcterms:modified">%s</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="css" href="style.css" media-type="text/css"/>
+%s  </manifest>
+  <spine toc="ncx">
+%s  </spine>
+</package>
+`,
+		html.EscapeString(book.ID),
+		html.EscapeString(book.Title),
+		html.EscapeString(book.Author),
+		html.EscapeString(book.Language),
+		book.Date.UTC().Format("2006-01-02"),
+		book.Date.UTC().Format("2006-01-02T15:04:05Z"),
+		manifest.String(),
+		spine.String(),
+	)
+}
+
+func renderNav(book *Book) string {
+	var items strings.Builder
+	for i, ch := range book.Chapters {
+		fmt.Fprintf(&items, "      <li><a href=\"%s\">%s</a></li>\n", chapterFile(i), html.EscapeString(ch.Title))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>%s</title></head>
+<body>
+  <nav epub:type="toc">
+    <h1>Contents</h1>
+    <ol>
+%s    </ol>
+  </nav>
+</body>
+</html>
+`, html.EscapeString(book.Title), items.String())
+}
+
+func renderNCX(book *Book) string {
+	var points strings.Builder
+	for i, ch := range book.Chapters {
+		fmt.Fprintf(&points, `    <navPoint id="np%d" playOrder="%d">
+      <navLabel><text>%s</text></navLabel>
+      <content src="%s"/>
+    </navPoint>
+`, i+1, i+1, html.EscapeString(ch.Title), chapterFile(i))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <head><meta name="dtb:uid" content="urn:briefly:%s"/></head>
+  <docTitle><text>%s</text></docTitle>
+  <navMap>
+%s  </navMap>
+</ncx>
+`, html.EscapeString(book.ID), html.EscapeString(book.Title), points.String())
+}
+
+func renderChapter(ch Chapter) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "  <h1>%s</h1>\n", html.EscapeString(ch.Title))
+	if ch.Source != "" {
+		escaped := html.EscapeString(ch.Source)
+		fmt.Fprintf(&body, "  <p class=\"source\">Source: <a href=\"%s\">%s</a></p>\n", escaped, escaped)
+	}
+	body.WriteString(textToXHTML(ch.Body))
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head>
+  <title>%s</title>
+  <link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+%s</body>
+</html>
+`, html.EscapeString(ch.Title), body.String())
+}
+
+// textToXHTML converts plain text with light markdown (headings and bullets)
+// into escaped XHTML paragraphs
+func textToXHTML(text string) string {
+	var out strings.Builder
+	inList := false
+
+	closeList := func() {
+		if inList {
+			out.WriteString("  </ul>\n")
+			inList = false
+		}
+	}
+
+	for _, block := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+
+		for _, line := range strings.Split(block, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			switch {
+			case strings.HasPrefix(line, "#"):
+				closeList()
+				fmt.Fprintf(&out, "  <h2>%s</h2>\n", html.EscapeString(strings.TrimSpace(strings.TrimLeft(line, "#"))))
+			case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "• "):
+				if !inList {
+					out.WriteString("  <ul>\n")
+					inList = true
+				}
+				item := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(line, "- "), "* "), "• "))
+				fmt.Fprintf(&out, "    <li>%s</li>\n", html.EscapeString(item))
+			default:
+				closeList()
+				fmt.Fprintf(&out, "  <p>%s</p>\n", html.EscapeString(line))
+			}
+		}
+		closeList()
+	}
+
+	return out.String()
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"archive/zip"
+	"briefly/internal/core"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewBookFromDigest(t *testing.T) {
+	digest := &core.Digest{
+		ID:            "digest-1",
+		Title:         "AI Weekly",
+		Summary:       "Big week for models.",
+		ProcessedDate: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	articles := []core.Article{
+		{Title: "First", URL: "https://example.com/1", CleanedText: "Body one"},
+		{URL: "https://example.com/2"},
+	}
+
+	book := NewBookFromDigest(digest, articles)
+
+	if book.Title != "AI Weekly" {
+		t.Errorf("Expected title 'AI Weekly', got %q", book.Title)
+	}
+	if len(book.Chapters) != 3 {
+		t.Fatalf("Expected 3 chapters (summary + 2 articles), got %d", len(book.Chapters))
+	}
+	if book.Chapters[1].Source != "https://example.com/1" {
+		t.Errorf("Expected source attribution on article chapter, got %q", book.Chapters[1].Source)
+	}
+	if book.Chapters[2].Title != "2. https://example.com/2" {
+		t.Errorf("Expected URL fallback title, got %q", book.Chapters[2].Title)
+	}
+}
+
+func TestWriteEPUB_Structure(t *testing.T) {
+	book := &Book{
+		ID:       "abc",
+		Title:    "Tools & Tips",
+		Author:   "Briefly",
+		Language: "en",
+		Date:     time.Now(),
+		Chapters: []Chapter{
+			{Title: "Summary", Body: "Hello <world>\n\n- one\n- two"},
+			{Title: "Article", Source: "https://example.com/?a=1&b=2", Body: "Text"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEPUB(book, &buf); err != nil {
+		t.Fatalf("WriteEPUB failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Output is not a valid zip: %v", err)
+	}
+
+	if zr.File[0].Name != "mimetype" || zr.File[0].Method != zip.Store {
+		t.Error("mimetype must be the first, uncompressed entry")
+	}
+
+	contents := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		contents[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"META-INF/container.xml", "OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/chapter_001.xhtml", "OEBPS/chapter_002.xhtml"} {
+		if _, ok := contents[name]; !ok {
+			t.Errorf("Missing %s", name)
+		}
+	}
+
+	if !strings.Contains(contents["OEBPS/content.opf"], "Tools &amp; Tips") {
+		t.Error("Title should be escaped in OPF")
+	}
+	if !strings.Contains(contents["OEBPS/chapter_001.xhtml"], "Hello &lt;world&gt;") {
+		t.Error("Chapter text should be escaped")
+	}
+	if !strings.Contains(contents["OEBPS/chapter_001.xhtml"], "<li>two</li>") {
+		t.Error("Bullets should render as list items")
+	}
+	if !strings.Contains(contents["OEBPS/chapter_002.xhtml"], "a=1&amp;b=2") {
+		t.Error("Source URL should be attributed and escaped")
+	}
+}
@@ -0,0 +1,394 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	pdfPageWidth  = 612.0 // US Letter, in points
+	pdfPageHeight = 792.0
+	pdfMargin     = 72.0
+	pdfTextWidth  = pdfPageWidth - 2*pdfMargin
+
+	pdfBodySize       = 11.0
+	pdfBodyLeading    = 15.0
+	pdfNoteSize       = 8.0
+	pdfNoteLeading    = 10.0
+	pdfListIndent     = 22.0
+	pdfNoteSeparation = 10.0 // Gap between the body and the footnote rule
+)
+
+// pdfWord is a styled word laid out as a unit
+type pdfWord struct {
+	text  []byte // WinAnsi-encoded
+	font  int
+	size  float64
+	rise  float64 // Baseline shift, for footnote references
+	space bool    // Preceded by a space
+	notes []int   // Footnotes first referenced by this word
+}
+
+func (w pdfWord) width() float64 { return textWidth(w.font, w.text, w.size) }
+
+// pdfPage is one page's content stream and the footnotes placed on it
+type pdfPage struct {
+	content bytes.Buffer
+	notes   []int
+	lines   int
+	number  int // Printed page number; 0 on the cover
+}
+
+// pdfLayout flows a document onto pages, keeping each footnote on the page where
+// its source is first cited
+type pdfLayout struct {
+	doc       *Document
+	pages     []*pdfPage
+	page      *pdfPage
+	y         float64             // Top of the next line
+	noteLines map[int][][]pdfWord // Footnotes wrapped to the text width
+}
+
+// WritePDF writes the document as a PDF with a cover page, footnoted citations,
+// page numbers, and a numbered source list
+func WritePDF(doc *Document, w io.Writer) error {
+	layout := &pdfLayout{doc: doc, noteLines: make(map[int][][]pdfWord)}
+	for i, note := range doc.Notes {
+		words := []pdfWord{{text: []byte(strconv.Itoa(i + 1)), font: fontRegular, size: pdfNoteSize * 0.75, rise: pdfNoteSize * 0.3}}
+		words = append(words, noteWords(note, pdfNoteSize)...)
+		layout.noteLines[i+1] = wrapWords(words, pdfTextWidth)
+	}
+
+	layout.renderCover()
+	layout.newPage()
+	for _, block := range doc.Blocks {
+		layout.renderBlock(block)
+	}
+	layout.renderSources()
+	layout.finishPage()
+
+	return writePDFFile(doc, layout.pages, w)
+}
+
+// SavePDF writes the document to the given path, creating parent directories as needed
+func SavePDF(doc *Document, path string) error {
+	return saveFile(path, func(w io.Writer) error { return WritePDF(doc, w) })
+}
+
+func (l *pdfLayout) renderCover() {
+	l.page = &pdfPage{}
+	l.pages = append(l.pages, l.page)
+	l.y = pdfPageHeight - 250
+
+	for _, line := range wrapWords(textWords(l.doc.Title, fontBold, 28), pdfTextWidth) {
+		l.drawLine(line, pdfMargin, 34)
+	}
+	l.y -= 10
+	l.page.content.WriteString("0.35 g\n")
+	for _, line := range wrapWords(textWords(l.doc.Subtitle, fontRegular, 16), pdfTextWidth) {
+		l.drawLine(line, pdfMargin, 22)
+	}
+	l.y -= 24
+	l.page.content.WriteString("0.25 g\n")
+	for _, detail := range l.doc.Cover {
+		for _, line := range wrapWords(textWords(detail, fontRegular, pdfBodySize), pdfTextWidth) {
+			l.drawLine(line, pdfMargin, 16)
+		}
+	}
+	l.page.content.WriteString("0 g\n")
+}
+
+func (l *pdfLayout) renderBlock(block Block) {
+	switch block.Kind {
+	case BlockHeading:
+		if l.page.lines > 0 {
+			l.y -= 10
+		}
+		// Keep a heading with the first lines of its section
+		if l.y-60 < pdfMargin+l.notesHeight(l.page.notes) {
+			l.newPage()
+		}
+		for _, line := range wrapWords(spanWords(block.Spans, 14, true), pdfTextWidth) {
+			l.placeLine(line, pdfMargin, 18)
+		}
+		l.y -= 4
+	case BlockBullet:
+		lines := wrapWords(spanWords(block.Spans, pdfBodySize, false), pdfTextWidth-pdfListIndent)
+		for i, line := range lines {
+			if i == 0 {
+				line[0].space = false
+				line = append([]pdfWord{{text: winAnsi("•"), font: fontRegular, size: pdfBodySize}}, line...)
+				l.placeLine(line, pdfMargin+8, pdfBodyLeading, pdfListIndent-8)
+				continue
+			}
+			l.placeLine(line, pdfMargin+pdfListIndent, pdfBodyLeading)
+		}
+		l.y -= 4
+	default:
+		for _, line := range wrapWords(spanWords(block.Spans, pdfBodySize, false), pdfTextWidth) {
+			l.placeLine(line, pdfMargin, pdfBodyLeading)
+		}
+		l.y -= 8
+	}
+}
+
+func (l *pdfLayout) renderSources() {
+	if len(l.doc.Sources) == 0 {
+		return
+	}
+	l.renderBlock(Block{Kind: BlockHeading, Spans: []Span{{Text: "Sources"}}})
+	for i, source := range l.doc.Sources {
+		for j, line := range wrapWords(noteWords(source, 10), pdfTextWidth-pdfListIndent) {
+			if j == 0 {
+				line[0].space = false
+				label := pdfWord{text: []byte(fmt.Sprintf("%d.", i+1)), font: fontRegular, size: 10}
+				l.placeLine(append([]pdfWord{label}, line...), pdfMargin, 13, pdfListIndent-label.width())
+				continue
+			}
+			l.placeLine(line, pdfMargin+pdfListIndent, 13)
+		}
+		l.y -= 4
+	}
+}
+
+// placeLine draws a line at x, first moving to a new page if the line and the
+// footnotes it introduces don't fit above the page's footnotes. A gap after the
+// first word sets where the rest of the line starts, for list markers.
+func (l *pdfLayout) placeLine(words []pdfWord, x, leading float64, gap ...float64) {
+	var newNotes []int
+	for _, word := range words {
+		newNotes = append(newNotes, word.notes...)
+	}
+	notes := append(append([]int(nil), l.page.notes...), newNotes...)
+	if l.page.lines > 0 && l.y-leading < pdfMargin+l.notesHeight(notes) {
+		l.newPage()
+	}
+	l.page.notes = append(l.page.notes, newNotes...)
+	l.drawLine(words, x, leading, gap...)
+}
+
+// drawLine writes a line's words into the page's content stream
+func (l *pdfLayout) drawLine(words []pdfWord, x, leading float64, gap ...float64) {
+	baseline := l.y - leading*0.8
+	for i, word := range words {
+		if i > 0 && word.space {
+			x += textWidth(word.font, []byte(" "), word.size)
+		}
+		fmt.Fprintf(&l.page.content, "BT /F%d %.2f Tf %.2f %.2f Td (%s) Tj ET\n",
+			word.font, word.size, x, baseline+word.rise, pdfEscape(word.text))
+		x += word.width()
+		if i == 0 && len(gap) > 0 {
+			x += gap[0]
+		}
+	}
+	l.y -= leading
+	l.page.lines++
+}
+
+func (l *pdfLayout) newPage() {
+	if l.page != nil {
+		l.finishPage()
+	}
+	l.page = &pdfPage{number: len(l.pages)}
+	l.pages = append(l.pages, l.page)
+	l.y = pdfPageHeight - pdfMargin
+}
+
+// finishPage draws the page's footnotes above the bottom margin and its number below
+func (l *pdfLayout) finishPage() {
+	page := l.page
+	if len(page.notes) > 0 {
+		top := pdfMargin + l.notesHeight(page.notes)
+		fmt.Fprintf(&page.content, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, top-pdfNoteSeparation/2, pdfMargin+144, top-pdfNoteSeparation/2)
+		l.y = top - pdfNoteSeparation
+		for _, note := range page.notes {
+			for _, line := range l.noteLines[note] {
+				l.drawLine(line, pdfMargin, pdfNoteLeading)
+			}
+			l.y -= 2
+		}
+	}
+	if page.number > 0 {
+		number := []byte(strconv.Itoa(page.number))
+		x := (pdfPageWidth - textWidth(fontRegular, number, 9)) / 2
+		fmt.Fprintf(&page.content, "BT /F%d 9 Tf %.2f %.2f Td (%s) Tj ET\n", fontRegular, x, pdfMargin/2, number)
+	}
+}
+
+// notesHeight is the space footnotes take at the bottom of a page
+func (l *pdfLayout) notesHeight(notes []int) float64 {
+	if len(notes) == 0 {
+		return 0
+	}
+	height := pdfNoteSeparation
+	for _, note := range notes {
+		height += float64(len(l.noteLines[note]))*pdfNoteLeading + 2
+	}
+	return height
+}
+
+// spanWords turns styled spans into words. Footnote references become raised
+// numbers attached to the word before them.
+func spanWords(spans []Span, size float64, bold bool) []pdfWord {
+	var words []pdfWord
+	space := false
+	for i, span := range spans {
+		if span.Note > 0 {
+			text := strconv.Itoa(span.Note)
+			if i > 0 && spans[i-1].Note > 0 {
+				text = "," + text
+			}
+			word := pdfWord{text: []byte(text), font: fontRegular, size: size * 0.65, rise: size * 0.35}
+			if span.First {
+				word.notes = []int{span.Note}
+			}
+			words = append(words, word)
+			space = false
+			continue
+		}
+
+		font := fontRegular
+		switch {
+		case (span.Bold || bold) && span.Italic:
+			font = fontBoldItalic
+		case span.Bold || bold:
+			font = fontBold
+		case span.Italic:
+			font = fontItalic
+		}
+		if strings.HasPrefix(span.Text, " ") {
+			space = true
+		}
+		for j, field := range strings.Fields(span.Text) {
+			encoded := winAnsi(field)
+			if len(encoded) == 0 {
+				continue
+			}
+			words = append(words, pdfWord{text: encoded, font: font, size: size, space: space || j > 0})
+			space = false
+		}
+		space = strings.HasSuffix(span.Text, " ")
+	}
+	return words
+}
+
+func textWords(text string, font int, size float64) []pdfWord {
+	return spanWords([]Span{{Text: text, Bold: font == fontBold}}, size, false)
+}
+
+// noteWords is a footnote or source entry's text followed by its URL
+func noteWords(note Note, size float64) []pdfWord {
+	words := spanWords([]Span{{Text: " " + note.Text}}, size, false)
+	if note.URL != "" {
+		words = append(words, pdfWord{text: winAnsi(note.URL), font: fontRegular, size: size, space: true})
+	}
+	return words
+}
+
+// wrapWords breaks words into lines no wider than width, splitting words that are
+// wider than a line (long URLs) and keeping footnote numbers with their word
+func wrapWords(words []pdfWord, width float64) [][]pdfWord {
+	var lines [][]pdfWord
+	var line []pdfWord
+	lineWidth := 0.0
+	for _, word := range words {
+		for _, part := range splitWide(word, width) {
+			add := part.width()
+			if len(line) > 0 && part.space {
+				add += textWidth(part.font, []byte(" "), part.size)
+			}
+			glued := !part.space && part.rise > 0
+			if len(line) > 0 && lineWidth+add > width && !glued {
+				lines = append(lines, line)
+				line, lineWidth, add = nil, 0, part.width()
+			}
+			line = append(line, part)
+			lineWidth += add
+		}
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitWide splits a word wider than width into pieces that fit
+func splitWide(word pdfWord, width float64) []pdfWord {
+	if word.width() <= width {
+		return []pdfWord{word}
+	}
+	var parts []pdfWord
+	start := 0
+	for i := range word.text {
+		if i > start && textWidth(word.font, word.text[start:i+1], word.size) > width {
+			part := word
+			part.text = word.text[start:i]
+			parts = append(parts, part)
+			word.space, word.notes = false, nil
+			start = i
+		}
+	}
+	last := word
+	last.text = word.text[start:]
+	return append(parts, last)
+}
+
+// pdfEscape escapes a PDF literal string
+func pdfEscape(text []byte) []byte {
+	var out bytes.Buffer
+	for _, b := range text {
+		if b == '\\' || b == '(' || b == ')' {
+			out.WriteByte('\\')
+		}
+		out.WriteByte(b)
+	}
+	return out.Bytes()
+}
+
+// writePDFFile writes the pages as a PDF file: catalog, page tree, the four
+// standard fonts, document info, then each page and its content stream
+func writePDFFile(doc *Document, pages []*pdfPage, w io.Writer) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	const firstPageObject = 8 // After the catalog, page tree, fonts, and info
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	for _, name := range pdfFontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+	object(fmt.Sprintf("<< /Title (%s) /Subject (%s) /Creator (Briefly) /CreationDate (D:%s) >>",
+		pdfEscape(winAnsi(doc.Title)), pdfEscape(winAnsi(doc.Subtitle)), doc.Date.UTC().Format("20060102150405Z")))
+
+	fonts := "/F0 3 0 R /F1 4 0 R /F2 5 0 R /F3 6 0 R"
+	for i, page := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, fonts, firstPageObject+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 7 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if _, err := w.Write(out.Bytes()); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package export
+
+// PDF text uses the standard Type 1 Helvetica family, which every reader ships, so
+// no fonts are embedded. Layout needs their glyph widths (from the Adobe AFM files,
+// in 1/1000 em) for printable ASCII; the obliques share the upright widths.
+
+const (
+	fontRegular = iota
+	fontBold
+	fontItalic
+	fontBoldItalic
+)
+
+// pdfFontNames are the base font names, indexed by font
+var pdfFontNames = []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Helvetica-BoldOblique"}
+
+// helveticaWidths covers ' ' (32) through '~' (126)
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// helveticaBoldWidths covers ' ' (32) through '~' (126)
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// winAnsiSpecials maps the punctuation briefs commonly use outside Latin-1 to its
+// WinAnsiEncoding byte
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// winAnsiWidths are the widths of those bytes, regular then bold
+var winAnsiWidths = map[byte][2]int{
+	0x80: {556, 556}, 0x85: {1000, 1000}, 0x91: {222, 278}, 0x92: {222, 278}, 0x93: {333, 500},
+	0x94: {333, 500}, 0x95: {350, 350}, 0x96: {556, 556}, 0x97: {1000, 1000}, 0x99: {1000, 1000},
+	0xA0: {278, 278}, 0xB7: {278, 278},
+}
+
+// winAnsi encodes text for a standard font, dropping what WinAnsiEncoding can't
+// show (emoji, CJK) rather than printing garbage
+func winAnsi(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r >= 0x20 && r < 0x7F:
+			out = append(out, byte(r))
+		case r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		default:
+			if b, ok := winAnsiSpecials[r]; ok {
+				out = append(out, b)
+			}
+		}
+	}
+	return out
+}
+
+// glyphWidth is the width of an encoded byte in 1/1000 em
+func glyphWidth(font int, b byte) int {
+	bold := font == fontBold || font == fontBoldItalic
+	if b >= 32 && b <= 126 {
+		if bold {
+			return helveticaBoldWidths[b-32]
+		}
+		return helveticaWidths[b-32]
+	}
+	if widths, ok := winAnsiWidths[b]; ok {
+		if bold {
+			return widths[1]
+		}
+		return widths[0]
+	}
+	return 556 // Accented Latin-1 letters are close to the lowercase average
+}
+
+// textWidth is the width of encoded text in points at size
+func textWidth(font int, text []byte, size float64) float64 {
+	total := 0
+	for _, b := range text {
+		total += glyphWidth(font, b)
+	}
+	return float64(total) * size / 1000
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestWritePDF(t *testing.T) {
+	report := testBriefReport()
+	// Enough text to flow onto a second body page
+	report.Summary += "\n\n" + strings.Repeat("Long paragraph about recall and latency trade-offs. ", 120) + "[2]"
+
+	var buf bytes.Buffer
+	if err := WritePDF(NewDocumentFromBrief(report), &buf); err != nil {
+		t.Fatalf("WritePDF failed: %v", err)
+	}
+
+	reader, err := pdf.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("output is not a readable PDF: %v", err)
+	}
+	if reader.NumPage() < 3 {
+		t.Fatalf("expected a cover and at least two body pages, got %d", reader.NumPage())
+	}
+
+	var text strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		pageText, err := reader.Page(i).GetPlainText(nil)
+		if err != nil {
+			t.Fatalf("failed to read page %d: %v", i, err)
+		}
+		text.WriteString(pageText + "\n")
+	}
+	// Each word is drawn on its own, so extracted text has a word per line
+	words := strings.Join(strings.Fields(text.String()), " ")
+	for _, want := range []string{"Vector search", "Research Brief", "Key Findings", "Sources", "TPAMI", "arxiv.org/abs/1603.09320"} {
+		if !strings.Contains(words, want) {
+			t.Errorf("expected %q in the PDF text", want)
+		}
+	}
+}
+
+func TestWrapWords(t *testing.T) {
+	words := spanWords([]Span{{Text: "alpha beta gamma"}, {Note: 1, First: true}}, 10, false)
+	lines := wrapWords(words, textWidth(fontRegular, []byte("alpha beta"), 10))
+	if len(lines) != 2 || len(lines[1]) != 2 || string(lines[1][1].text) != "1" {
+		t.Errorf("expected the footnote number kept with its word, got %d lines", len(lines))
+	}
+
+	long := spanWords([]Span{{Text: "https://example.com/" + strings.Repeat("x", 200)}}, 10, false)
+	if lines := wrapWords(long, 100); len(lines) < 2 {
+		t.Error("expected a word wider than the line to be split")
+	}
+}
@@ -0,0 +1,172 @@
+package feeds
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Where a discovered feed was found
+const (
+	SourceDirect = "direct" // The URL given was itself a feed
+	SourceLink   = "link"   // Advertised by the page with <link rel="alternate">
+	SourcePath   = "path"   // Found at a common feed path
+)
+
+// commonFeedPaths are tried when a page doesn't advertise its feeds
+var commonFeedPaths = []string{"/feed", "/rss.xml", "/atom.xml", "/feed.xml", "/rss", "/index.xml", "/feeds/all.atom.xml"}
+
+// feedLinkTypes are the <link rel="alternate"> types the parser can read
+var feedLinkTypes = map[string]bool{
+	"application/rss+xml":  true,
+	"application/atom+xml": true,
+	"application/xml":      true,
+	"text/xml":             true,
+}
+
+// maxDiscoveryPage caps how much of a website's page is read when looking for feeds
+const maxDiscoveryPage = 5 << 20
+
+// DiscoveredFeed is a feed found for a website
+type DiscoveredFeed struct {
+	URL    string
+	Title  string // The feed's own title
+	Items  int    // Entries in the feed when it was checked
+	Source string // SourceDirect, SourceLink, or SourcePath
+}
+
+// DiscoverFeeds finds the feeds a website offers. A URL that is already a feed is
+// returned as is. Otherwise the page's <link rel="alternate"> feeds come first,
+// followed by feeds at common paths (/feed, /rss.xml, /atom.xml, ...) under the
+// page's directory and the site root. Every candidate is fetched and parsed, so
+// only working feeds are returned.
+func (fm *FeedManager) DiscoverFeeds(websiteURL string) ([]DiscoveredFeed, error) {
+	req, err := http.NewRequest("GET", websiteURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Briefly RSS Reader/1.0")
+
+	resp, err := fm.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch website: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("website returned status %d", resp.StatusCode)
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxDiscoveryPage))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read website: %w", err)
+	}
+	pageURL := resp.Request.URL
+
+	if parsed, err := fm.parseFeed(page, websiteURL); err == nil {
+		return []DiscoveredFeed{discovered(parsed, websiteURL, SourceDirect)}, nil
+	}
+
+	var feeds []DiscoveredFeed
+	seen := make(map[string]bool)
+	try := func(candidate, source string) {
+		if seen[candidate] {
+			return
+		}
+		seen[candidate] = true
+		parsed, err := fm.FetchFeed(candidate, "", "")
+		if err != nil || parsed.NotModified {
+			return
+		}
+		found := discovered(parsed, candidate, source)
+		for _, feed := range feeds {
+			if feed.Title == found.Title && feed.Items == found.Items {
+				return // The same feed at another URL, e.g. /feed redirecting to /feed/
+			}
+		}
+		feeds = append(feeds, found)
+	}
+
+	links, err := feedLinks(page, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		try(link, SourceLink)
+	}
+	for _, candidate := range feedPathCandidates(pageURL) {
+		try(candidate, SourcePath)
+	}
+	return feeds, nil
+}
+
+// feedLinks returns the feed URLs a page advertises with <link rel="alternate">,
+// resolved against the page URL (or its <base href>)
+func feedLinks(page []byte, pageURL *url.URL) ([]string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse website: %w", err)
+	}
+
+	base := pageURL
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if resolved, err := pageURL.Parse(href); err == nil {
+			base = resolved
+		}
+	}
+
+	var links []string
+	doc.Find("link[href]").Each(func(_ int, link *goquery.Selection) {
+		rel := strings.Fields(strings.ToLower(link.AttrOr("rel", "")))
+		linkType := strings.ToLower(strings.TrimSpace(link.AttrOr("type", "")))
+		if !containsString(rel, "alternate") || !feedLinkTypes[linkType] {
+			return
+		}
+		if resolved, err := base.Parse(strings.TrimSpace(link.AttrOr("href", ""))); err == nil {
+			links = append(links, resolved.String())
+		}
+	})
+	return links, nil
+}
+
+// feedPathCandidates returns the common feed paths under the page's directory, when
+// it isn't the site root, and then under the root
+func feedPathCandidates(pageURL *url.URL) []string {
+	root := &url.URL{Scheme: pageURL.Scheme, Host: pageURL.Host}
+	dir := pageURL.Path
+	if dir != "" && !strings.HasSuffix(dir, "/") && strings.Contains(path.Base(dir), ".") {
+		dir = path.Dir(dir) // A page such as /blog/index.html
+	}
+	prefixes := []string{""}
+	if dir = strings.TrimSuffix(dir, "/"); dir != "" {
+		prefixes = []string{dir, ""}
+	}
+
+	var candidates []string
+	for _, prefix := range prefixes {
+		for _, feedPath := range commonFeedPaths {
+			candidate := *root
+			candidate.Path = prefix + feedPath
+			candidates = append(candidates, candidate.String())
+		}
+	}
+	return candidates
+}
+
+func discovered(parsed *ParsedFeed, feedURL, source string) DiscoveredFeed {
+	return DiscoveredFeed{URL: feedURL, Title: parsed.Feed.Title, Items: len(parsed.Items), Source: source}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
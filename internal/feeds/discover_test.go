@@ -0,0 +1,65 @@
+package feeds
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+const (
+	testRSS  = `<?xml version="1.0"?><rss version="2.0"><channel><title>Example Blog</title><item><title>Post</title><link>https://blog.example.com/post</link></item></channel></rss>`
+	testAtom = `<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom"><title>Example Comments</title><entry><title>Reply</title></entry><entry><title>Reply 2</title></entry></feed>`
+)
+
+func TestDiscoverFeeds(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/blog/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blog/" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`<html><head>
+			<link rel="alternate" type="application/rss+xml" title="Posts" href="posts.xml">
+			<link rel="alternate" type="application/rss+xml" href="/missing.xml">
+			<link rel="stylesheet" href="/style.css">
+			</head><body>Blog</body></html>`))
+	})
+	mux.HandleFunc("/blog/posts.xml", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(testRSS)) })
+	mux.HandleFunc("/blog/feed", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(testRSS)) }) // Same feed again
+	mux.HandleFunc("/atom.xml", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(testAtom)) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fm := NewFeedManager()
+	found, err := fm.DiscoverFeeds(server.URL + "/blog/")
+	if err != nil {
+		t.Fatalf("DiscoverFeeds: %v", err)
+	}
+	want := []DiscoveredFeed{
+		{URL: server.URL + "/blog/posts.xml", Title: "Example Blog", Items: 1, Source: SourceLink},
+		{URL: server.URL + "/atom.xml", Title: "Example Comments", Items: 2, Source: SourcePath},
+	}
+	if !reflect.DeepEqual(found, want) {
+		t.Errorf("DiscoverFeeds =\n%+v\nwant\n%+v", found, want)
+	}
+
+	direct, err := fm.DiscoverFeeds(server.URL + "/blog/posts.xml")
+	if err != nil || len(direct) != 1 || direct[0].Source != SourceDirect {
+		t.Errorf("expected a feed URL returned as is, got %+v, %v", direct, err)
+	}
+}
+
+func TestFeedPathCandidates(t *testing.T) {
+	page, _ := url.Parse("https://example.com/blog/index.html")
+	candidates := feedPathCandidates(page)
+	if candidates[0] != "https://example.com/blog/feed" || candidates[len(commonFeedPaths)] != "https://example.com/feed" {
+		t.Errorf("expected the page's directory before the site root, got %v", candidates)
+	}
+
+	root, _ := url.Parse("https://example.com")
+	if got := feedPathCandidates(root); len(got) != len(commonFeedPaths) {
+		t.Errorf("expected only root candidates for the site root, got %v", got)
+	}
+}
@@ -3,8 +3,10 @@ package feeds
 
 import (
 	"briefly/internal/core"
+	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -41,6 +43,9 @@ type RSSItem struct {
 	Description string `xml:"description"`
 	PubDate     string `xml:"pubDate"`
 	GUID        string `xml:"guid"`
+
+	// ContentEncoded is the full HTML body some feeds publish alongside the summary
+	ContentEncoded string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
 }
 
 // AtomLink represents an Atom link element
@@ -52,12 +57,29 @@ type AtomLink struct {
 
 // AtomEntry represents an Atom entry
 type AtomEntry struct {
-	Title     string     `xml:"title"`
-	Link      []AtomLink `xml:"link"`
-	Summary   string     `xml:"summary"`
-	Published string     `xml:"published"`
-	Updated   string     `xml:"updated"`
-	ID        string     `xml:"id"`
+	Title     string      `xml:"title"`
+	Link      []AtomLink  `xml:"link"`
+	Summary   string      `xml:"summary"`
+	Content   AtomContent `xml:"content"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	ID        string      `xml:"id"`
+}
+
+// AtomContent is an Atom entry's content element, which holds escaped HTML or
+// inline XHTML depending on its type
+type AtomContent struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// HTML returns the content as an HTML string
+func (c AtomContent) HTML() string {
+	if c.Type == "xhtml" {
+		return strings.TrimSpace(c.Inner)
+	}
+	return strings.TrimSpace(c.Text)
 }
 
 // FeedManager manages RSS/Atom feed operations
@@ -76,7 +98,12 @@ func NewFeedManager() *FeedManager {
 
 // FetchFeed fetches and parses a feed from the given URL
 func (fm *FeedManager) FetchFeed(feedURL string, lastModified, etag string) (*ParsedFeed, error) {
-	req, err := http.NewRequest("GET", feedURL, nil)
+	return fm.FetchFeedContext(context.Background(), feedURL, lastModified, etag)
+}
+
+// FetchFeedContext is FetchFeed, abandoning the request and its body when ctx is done
+func (fm *FeedManager) FetchFeedContext(ctx context.Context, feedURL string, lastModified, etag string) (*ParsedFeed, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -91,7 +118,14 @@ func (fm *FeedManager) FetchFeed(feedURL string, lastModified, etag string) (*Pa
 
 	req.Header.Set("User-Agent", "Briefly RSS Reader/1.0")
 
-	resp, err := fm.client.Do(req)
+	// A deadline on ctx replaces the client's default timeout
+	client := fm.client
+	if _, ok := ctx.Deadline(); ok {
+		unbounded := *fm.client
+		unbounded.Timeout = 0
+		client = &unbounded
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch feed: %w", err)
 	}
@@ -130,26 +164,22 @@ type ParsedFeed struct {
 
 // parseResponse attempts to parse the HTTP response as either RSS or Atom
 func (fm *FeedManager) parseResponse(resp *http.Response, feedURL string) (*ParsedFeed, error) {
-	// Read and decode the response
-	decoder := xml.NewDecoder(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+	return fm.parseFeed(data, feedURL)
+}
 
-	// Try RSS first
+// parseFeed parses a feed document as RSS, then as Atom
+func (fm *FeedManager) parseFeed(data []byte, feedURL string) (*ParsedFeed, error) {
 	var rss RSS
-	if err := decoder.Decode(&rss); err == nil && rss.Channel.Title != "" {
+	if err := xml.Unmarshal(data, &rss); err == nil && rss.Channel.Title != "" {
 		return fm.parseRSS(rss, feedURL), nil
 	}
 
-	// Reset and try Atom
-	_ = resp.Body.Close()
-	resp, err := fm.client.Get(feedURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to re-fetch for Atom parsing: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	decoder = xml.NewDecoder(resp.Body)
 	var atom Atom
-	if err := decoder.Decode(&atom); err == nil && atom.Title != "" {
+	if err := xml.Unmarshal(data, &atom); err == nil && atom.Title != "" {
 		return fm.parseAtom(atom, feedURL), nil
 	}
 
@@ -175,6 +205,7 @@ func (fm *FeedManager) parseRSS(rss RSS, feedURL string) *ParsedFeed {
 			Title:          item.Title,
 			Link:           item.Link,
 			Description:    item.Description,
+			Content:        item.ContentEncoded,
 			GUID:           item.GUID,
 			Published:      parseRSSDate(item.PubDate),
 			DateDiscovered: time.Now().UTC(),
@@ -217,6 +248,7 @@ func (fm *FeedManager) parseAtom(atom Atom, feedURL string) *ParsedFeed {
 			Title:          entry.Title,
 			Link:           link,
 			Description:    entry.Summary,
+			Content:        entry.Content.HTML(),
 			GUID:           entry.ID,
 			Published:      parseAtomDate(entry.Published),
 			DateDiscovered: time.Now().UTC(),
@@ -291,39 +323,3 @@ func (fm *FeedManager) ValidateFeedURL(feedURL string) error {
 	}
 	return nil
 }
-
-// DiscoverFeedURL attempts to discover feed URLs from a website
-func (fm *FeedManager) DiscoverFeedURL(websiteURL string) ([]string, error) {
-	resp, err := fm.client.Get(websiteURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch website: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	// This is a simplified implementation
-	// In a full implementation, you would parse HTML and look for:
-	// <link rel="alternate" type="application/rss+xml" href="...">
-	// <link rel="alternate" type="application/atom+xml" href="...">
-
-	var candidates []string
-
-	// Common feed URL patterns
-	baseURL := strings.TrimSuffix(websiteURL, "/")
-	candidates = append(candidates,
-		baseURL+"/feed",
-		baseURL+"/rss",
-		baseURL+"/atom.xml",
-		baseURL+"/rss.xml",
-		baseURL+"/feed.xml",
-		baseURL+"/feeds/all.atom.xml",
-	)
-
-	var validFeeds []string
-	for _, candidate := range candidates {
-		if err := fm.ValidateFeedURL(candidate); err == nil {
-			validFeeds = append(validFeeds, candidate)
-		}
-	}
-
-	return validFeeds, nil
-}
@@ -0,0 +1,59 @@
+package feeds
+
+import (
+	"briefly/internal/core"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DefaultFullTextMinWords is the shortest feed body taken as the full article
+const DefaultFullTextMinWords = 150
+
+// truncationSuffixes end a feed body that was cut off mid-article
+var truncationSuffixes = []string{"…", "...", "[…]", "[...]", "(more)"}
+
+// readMorePhrases near the end of a feed body link out to the rest of the article
+var readMorePhrases = []string{"read more", "continue reading", "keep reading", "read the full", "read the rest"}
+
+// HasFullText reports whether a feed item carries the whole article in its content,
+// so the page doesn't need fetching. Bodies shorter than minWords (0 = default) or
+// ending in an ellipsis or a "read more" link are taken to be truncated.
+func HasFullText(item core.FeedItem, minWords int) bool {
+	if minWords <= 0 {
+		minWords = DefaultFullTextMinWords
+	}
+	text := feedContentText(item.Content)
+	if len(strings.Fields(text)) < minWords {
+		return false
+	}
+
+	tail := strings.ToLower(text)
+	if len(tail) > 80 {
+		tail = tail[len(tail)-80:]
+	}
+	for _, suffix := range truncationSuffixes {
+		if strings.HasSuffix(tail, suffix) {
+			return false
+		}
+	}
+	for _, phrase := range readMorePhrases {
+		if strings.Contains(tail, phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+// feedContentText returns the visible text of a feed body
+func feedContentText(content string) string {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return ""
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+	return strings.Join(strings.Fields(doc.Text()), " ")
+}
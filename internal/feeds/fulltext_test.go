@@ -0,0 +1,84 @@
+package feeds
+
+import (
+	"briefly/internal/core"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func paragraphs(words int) string {
+	return "<p>" + strings.TrimSpace(strings.Repeat("word ", words)) + ".</p>"
+}
+
+func TestHasFullText(t *testing.T) {
+	full := paragraphs(100) + paragraphs(100)
+
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"full body", full, true},
+		{"no content", "", false},
+		{"too short", paragraphs(40), false},
+		{"ellipsis", full + "<p>And then…</p>", false},
+		{"dots", "<p>" + strings.Repeat("word ", 200) + "and so...</p>", false},
+		{"read more link", full + `<p><a href="https://example.com/post">Continue reading &rarr;</a></p>`, false},
+		{"ellipsis mid-article", "<p>Wait... what?</p>" + full, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasFullText(core.FeedItem{Content: tt.content}, 0); got != tt.want {
+				t.Errorf("HasFullText = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !HasFullText(core.FeedItem{Content: paragraphs(40)}, 20) {
+		t.Error("expected a custom minimum word count honored")
+	}
+}
+
+func TestParseFeedContent(t *testing.T) {
+	rssXML := `<?xml version="1.0"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel><title>Blog</title>
+    <item>
+      <title>Post</title><link>https://example.com/post</link>
+      <description>Short summary</description>
+      <content:encoded><![CDATA[<p>Full <b>body</b></p>]]></content:encoded>
+    </item>
+  </channel>
+</rss>`
+	var rss RSS
+	if err := xml.Unmarshal([]byte(rssXML), &rss); err != nil {
+		t.Fatal(err)
+	}
+	parsed := NewFeedManager().parseRSS(rss, "https://example.com/feed")
+	if got := parsed.Items[0].Content; got != "<p>Full <b>body</b></p>" {
+		t.Errorf("RSS content = %q", got)
+	}
+	if got := parsed.Items[0].Description; got != "Short summary" {
+		t.Errorf("RSS description = %q", got)
+	}
+
+	atomXML := `<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Blog</title>
+  <entry><title>Escaped</title><link href="https://example.com/a"/><id>a</id>
+    <content type="html">&lt;p&gt;Escaped body&lt;/p&gt;</content></entry>
+  <entry><title>Inline</title><link href="https://example.com/b"/><id>b</id>
+    <content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Inline body</p></div></content></entry>
+</feed>`
+	var atom Atom
+	if err := xml.Unmarshal([]byte(atomXML), &atom); err != nil {
+		t.Fatal(err)
+	}
+	parsed = NewFeedManager().parseAtom(atom, "https://example.com/atom")
+	if got := parsed.Items[0].Content; got != "<p>Escaped body</p>" {
+		t.Errorf("Atom html content = %q", got)
+	}
+	if got := parsed.Items[1].Content; !strings.Contains(got, "<p>Inline body</p>") {
+		t.Errorf("Atom xhtml content = %q", got)
+	}
+}
@@ -0,0 +1,82 @@
+package feeds
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// Subscription is a feed listed in an OPML export or a plain list of feed URLs
+type Subscription struct {
+	Title    string
+	URL      string
+	Category string // The OPML folder the feed was filed under, if any
+}
+
+type opmlDocument struct {
+	Body struct {
+		Outlines []opmlOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr"`
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// ParseSubscriptions reads the feeds in an OPML export from a feed reader or, when
+// the data isn't XML, one feed URL per line (blank lines and # comments skipped).
+// Feeds listed twice are returned once.
+func ParseSubscriptions(data []byte) ([]Subscription, error) {
+	var subs []Subscription
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		var doc opmlDocument
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid OPML: %w", err)
+		}
+		subs = collectOutlines(doc.Body.Outlines, "", nil)
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			subs = append(subs, Subscription{URL: line})
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool, len(subs))
+	unique := subs[:0]
+	for _, sub := range subs {
+		if !seen[sub.URL] {
+			seen[sub.URL] = true
+			unique = append(unique, sub)
+		}
+	}
+	return unique, nil
+}
+
+// collectOutlines flattens OPML outlines: those with an xmlUrl are feeds, the rest
+// are folders whose name becomes the category of the feeds inside
+func collectOutlines(outlines []opmlOutline, category string, subs []Subscription) []Subscription {
+	for _, outline := range outlines {
+		title := outline.Title
+		if title == "" {
+			title = outline.Text
+		}
+		if url := strings.TrimSpace(outline.XMLURL); url != "" {
+			subs = append(subs, Subscription{Title: title, URL: url, Category: category})
+			continue
+		}
+		subs = collectOutlines(outline.Outlines, title, subs)
+	}
+	return subs
+}
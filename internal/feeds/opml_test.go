@@ -0,0 +1,45 @@
+package feeds
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSubscriptions_OPML(t *testing.T) {
+	subs, err := ParseSubscriptions([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Reader export</title></head>
+  <body>
+    <outline text="Hacker News" xmlUrl="https://hnrss.org/newest" type="rss"/>
+    <outline text="Security" title="Security">
+      <outline text="Krebs" title="Krebs on Security" xmlUrl="https://krebsonsecurity.com/feed/" type="rss"/>
+      <outline text="HN again" xmlUrl="https://hnrss.org/newest" type="rss"/>
+    </outline>
+  </body>
+</opml>`))
+	if err != nil {
+		t.Fatalf("ParseSubscriptions: %v", err)
+	}
+	want := []Subscription{
+		{Title: "Hacker News", URL: "https://hnrss.org/newest"},
+		{Title: "Krebs on Security", URL: "https://krebsonsecurity.com/feed/", Category: "Security"},
+	}
+	if !reflect.DeepEqual(subs, want) {
+		t.Errorf("ParseSubscriptions = %+v, want %+v", subs, want)
+	}
+
+	if _, err := ParseSubscriptions([]byte("<opml><body>")); err == nil {
+		t.Error("expected an error for malformed OPML")
+	}
+}
+
+func TestParseSubscriptions_List(t *testing.T) {
+	subs, err := ParseSubscriptions([]byte("# My feeds\nhttps://hnrss.org/newest\n\n  https://blog.example.com  \n"))
+	if err != nil {
+		t.Fatalf("ParseSubscriptions: %v", err)
+	}
+	want := []Subscription{{URL: "https://hnrss.org/newest"}, {URL: "https://blog.example.com"}}
+	if !reflect.DeepEqual(subs, want) {
+		t.Errorf("ParseSubscriptions = %+v, want %+v", subs, want)
+	}
+}
@@ -3,8 +3,8 @@ package fetch
 import (
 	"briefly/internal/core"
 	"bufio"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -127,7 +127,11 @@ func FetchArticle(link core.Link) (core.Article, error) {
 		return core.Article{}, fmt.Errorf("failed to fetch URL %s: status code %d", link.URL, resp.StatusCode)
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	bodyBytes, err := readBody(resp, link.URL, core.ContentTypeHTML)
+	var skip *SkipError
+	if errors.As(err, &skip) {
+		return core.Article{}, err
+	}
 	if err != nil {
 		return core.Article{}, fmt.Errorf("failed to read response body from %s: %w", link.URL, err)
 	}
@@ -187,6 +191,25 @@ func ParseArticleContent(article *core.Article) error {
 		return fmt.Errorf("failed to create goquery document for article %s: %w", article.ID, err)
 	}
 
+	// Primary images (charts, benchmarks) are picked from the untouched page
+	pageURL := article.URL
+	if pageURL == "" {
+		pageURL = article.LinkID
+	}
+	article.Images = ExtractImages(article.FetchedHTML, pageURL)
+
+	// Byline, site name, hero image, and dates from OpenGraph/Twitter/JSON-LD; a feed
+	// item's date wins over the page's own
+	meta := ExtractPageMetadata(article.FetchedHTML, pageURL)
+	ApplyPageMetadata(article, meta)
+
+	// Substack, Medium, and Ghost posts have a known body and their own prompts and
+	// embeds to drop; other pages get only the generic selectors below
+	adapter := siteAdapterFor(pageURL, doc)
+	if adapter != nil {
+		doc.Find(strings.Join(adapter.remove, ", ")).Remove()
+	}
+
 	// Remove common non-content elements
 	// This list is similar to the one in main.go, can be expanded.
 	doc.Find("script, style, nav, footer, header, aside, form, iframe, noscript, .sidebar, #sidebar, .ad, .advertisement, .popup, .modal, .cookie-banner").Remove()
@@ -199,6 +222,9 @@ func ParseArticleContent(article *core.Article) error {
 		".content", "#content", // Generic content containers
 		// Add more specific selectors if common patterns are observed in target sites
 	}
+	if adapter != nil {
+		mainContentSelectors = append(append([]string(nil), adapter.content...), mainContentSelectors...)
+	}
 
 	foundMainContent := false
 	for _, selector := range mainContentSelectors {
@@ -236,6 +262,9 @@ func ParseArticleContent(article *core.Article) error {
 	article.CleanedText = cleanedText
 
 	// If title was not extracted during fetch, try again from parsed doc
+	if article.Title == "" {
+		article.Title = meta.Title
+	}
 	if article.Title == "" {
 		article.Title = extractTitle(article.FetchedHTML, article.LinkID) // LinkID used as a stand-in for URL here
 	}
@@ -2,12 +2,14 @@ package fetch
 
 import (
 	"briefly/internal/core"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestReadLinksFromFile(t *testing.T) {
@@ -402,3 +404,337 @@ func TestParseArticleContent_InvalidHTML(t *testing.T) {
 		t.Fatalf("ParseArticleContent should handle invalid HTML gracefully: %v", err)
 	}
 }
+
+func TestOfflineContentProcessor(t *testing.T) {
+	url := "https://corpus.briefly.local/sample"
+	processor := NewOfflineContentProcessor(map[string]string{
+		url: `<html><head><title>Sample Page</title></head><body><main><p>Offline article body text.</p></main></body></html>`,
+	})
+
+	article, err := processor.ProcessArticle(context.Background(), url)
+	if err != nil {
+		t.Fatalf("ProcessArticle failed: %v", err)
+	}
+	if article.Title != "Sample Page" {
+		t.Errorf("Expected title 'Sample Page', got '%s'", article.Title)
+	}
+	if !strings.Contains(article.CleanedText, "Offline article body text.") {
+		t.Errorf("Expected cleaned text from page body, got '%s'", article.CleanedText)
+	}
+
+	again, _ := processor.ProcessArticle(context.Background(), url)
+	if again.ID != article.ID {
+		t.Error("Expected stable article IDs across runs")
+	}
+
+	if _, err := processor.ProcessArticle(context.Background(), "https://example.com/missing"); err == nil {
+		t.Error("Expected error for URL outside the offline corpus")
+	}
+}
+
+func TestProcessFeedContent(t *testing.T) {
+	processor := NewContentProcessor()
+
+	article, err := processor.ProcessFeedContent("https://example.com/post", "Feed Title", `<p>First paragraph.</p><p>Second <em>paragraph</em>.</p>`)
+	if err != nil {
+		t.Fatalf("ProcessFeedContent failed: %v", err)
+	}
+	if article.Title != "Feed Title" || article.URL != "https://example.com/post" {
+		t.Errorf("Expected feed title and URL kept, got %q %q", article.Title, article.URL)
+	}
+	if !strings.Contains(article.CleanedText, "First paragraph.") || !strings.Contains(article.CleanedText, "Second paragraph.") {
+		t.Errorf("Expected cleaned text from the feed body, got '%s'", article.CleanedText)
+	}
+	if article.ContentType != core.ContentTypeHTML {
+		t.Errorf("Expected HTML content type, got %s", article.ContentType)
+	}
+
+	if _, err := processor.ProcessFeedContent("https://example.com/empty", "Empty", "<img src='x.png'>"); err == nil {
+		t.Error("Expected error for a feed body without text")
+	}
+}
+
+func TestExtractImages(t *testing.T) {
+	testHTML := `<html><body>
+    <header><img src="/logo.png" alt="Site logo"></header>
+    <article>
+        <p>We benchmarked three databases.</p>
+        <figure>
+            <img src="/img/results.png" alt="Query latency">
+            <figcaption>p99 latency by database</figcaption>
+        </figure>
+        <img data-src="https://cdn.example.com/team.jpg" src="data:image/gif;base64,R0lGOD" alt="Our team">
+        <img src="/img/avatar-jane.png" alt="Jane">
+        <img src="/img/tiny.png" width="16" height="16">
+        <img src="/img/results.png" alt="Duplicate">
+    </article>
+</body></html>`
+
+	images := ExtractImages(testHTML, "https://blog.example.com/posts/db-bench")
+	if len(images) != 2 {
+		t.Fatalf("Expected 2 images, got %d: %+v", len(images), images)
+	}
+
+	chart := images[0]
+	if chart.URL != "https://blog.example.com/img/results.png" {
+		t.Errorf("Expected resolved URL, got %q", chart.URL)
+	}
+	if !chart.Figure || chart.Caption != "p99 latency by database" {
+		t.Errorf("Expected figure with caption, got %+v", chart)
+	}
+
+	photo := images[1]
+	if photo.URL != "https://cdn.example.com/team.jpg" {
+		t.Errorf("Expected lazy-load source, got %q", photo.URL)
+	}
+	if photo.Figure {
+		t.Errorf("Expected plain photo not to be marked as a figure: %+v", photo)
+	}
+}
+
+type fakeDescriber struct {
+	calls int
+}
+
+func (f *fakeDescriber) DescribeImage(ctx context.Context, data []byte, mimeType string, articleContext string) (string, error) {
+	f.calls++
+	return "Postgres has the lowest p99 latency.", nil
+}
+
+func TestDescribeFigures(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n0000")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(png)
+	}))
+	defer server.Close()
+
+	article := &core.Article{
+		Title: "Database benchmarks",
+		Images: []core.ArticleImage{
+			{URL: server.URL + "/photo.png"},
+			{URL: server.URL + "/chart1.png", Figure: true},
+			{URL: server.URL + "/chart2.png", Figure: true},
+		},
+	}
+
+	describer := &fakeDescriber{}
+	if n := DescribeFigures(context.Background(), article, describer, 1); n != 1 {
+		t.Fatalf("Expected 1 described figure, got %d", n)
+	}
+	if article.Images[0].Description != "" {
+		t.Error("Non-figure image should not be described")
+	}
+	if article.Images[1].Description != "Postgres has the lowest p99 latency." {
+		t.Errorf("Unexpected description: %q", article.Images[1].Description)
+	}
+	if article.Images[2].Description != "" || describer.calls != 1 {
+		t.Error("Limit should cap vision calls per article")
+	}
+}
+
+func TestExtractPublishedDate(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "open graph article meta",
+			html: `<html><head><meta property="article:published_time" content="2025-03-04T10:30:00-08:00"></head></html>`,
+			want: "2025-03-04T18:30:00Z",
+		},
+		{
+			name: "date-only meta",
+			html: `<html><head><meta name="date" content="2024-11-20"></head></html>`,
+			want: "2024-11-20T00:00:00Z",
+		},
+		{
+			name: "json-ld graph",
+			html: `<html><head><script type="application/ld+json">{"@context":"https://schema.org","@graph":[{"@type":"WebSite"},{"@type":"BlogPosting","datePublished":"2025-01-15T08:00:00Z"}]}</script></head></html>`,
+			want: "2025-01-15T08:00:00Z",
+		},
+		{
+			name: "time element",
+			html: `<html><body><article><time itemprop="datePublished" datetime="2023-06-01T12:00:00Z">June 1</time></article></body></html>`,
+			want: "2023-06-01T12:00:00Z",
+		},
+		{
+			name: "meta wins over json-ld",
+			html: `<html><head><meta property="article:published_time" content="2025-02-01T00:00:00Z"><script type="application/ld+json">{"datePublished":"2020-01-01"}</script></head></html>`,
+			want: "2025-02-01T00:00:00Z",
+		},
+		{
+			name: "unparseable date",
+			html: `<html><head><meta name="date" content="sometime last week"></head></html>`,
+		},
+		{
+			name: "no date",
+			html: `<html><body><p>Hello</p></body></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractPublishedDate(tt.html)
+			if tt.want == "" {
+				if !got.IsZero() {
+					t.Errorf("expected no date, got %v", got)
+				}
+				return
+			}
+			if got.Format(time.RFC3339) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestParseArticleContent_PublishedDate(t *testing.T) {
+	article := &core.Article{
+		URL:         "https://example.com/post",
+		FetchedHTML: `<html><head><meta property="article:published_time" content="2025-03-04T10:30:00Z"></head><body><article><p>Body text</p></article></body></html>`,
+	}
+	if err := ParseArticleContent(article); err != nil {
+		t.Fatalf("ParseArticleContent failed: %v", err)
+	}
+	if article.DatePublished.Format("2006-01-02") != "2025-03-04" {
+		t.Errorf("expected the page's published date, got %v", article.DatePublished)
+	}
+
+	// A date already known from the feed is kept
+	feedDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	article = &core.Article{
+		URL:           "https://example.com/post",
+		FetchedHTML:   `<html><head><meta name="date" content="2025-03-04"></head><body><p>Body</p></body></html>`,
+		DatePublished: feedDate,
+	}
+	if err := ParseArticleContent(article); err != nil {
+		t.Fatalf("ParseArticleContent failed: %v", err)
+	}
+	if !article.DatePublished.Equal(feedDate) {
+		t.Errorf("expected the feed date kept, got %v", article.DatePublished)
+	}
+}
+
+func TestExtractPageMetadata(t *testing.T) {
+	openGraph := `<html><head>
+		<title>Scaling Postgres | Example Blog</title>
+		<meta property="og:title" content="Scaling Postgres">
+		<meta property="og:site_name" content="Example Blog">
+		<meta property="og:image" content="/images/hero.png">
+		<meta name="author" content="Jane Doe">
+		<link rel="canonical" href="https://example.com/posts/scaling-postgres">
+		<meta property="article:published_time" content="2025-03-04T10:30:00Z">
+	</head><body></body></html>`
+
+	meta := ExtractPageMetadata(openGraph, "https://example.com/posts/scaling-postgres?utm_source=rss")
+	if meta.Title != "Scaling Postgres" || meta.SiteName != "Example Blog" || meta.Author != "Jane Doe" {
+		t.Errorf("unexpected OpenGraph metadata %+v", meta)
+	}
+	if meta.HeroImage != "https://example.com/images/hero.png" {
+		t.Errorf("expected the hero image resolved against the page URL, got %q", meta.HeroImage)
+	}
+	if meta.CanonicalURL != "https://example.com/posts/scaling-postgres" {
+		t.Errorf("unexpected canonical URL %q", meta.CanonicalURL)
+	}
+	if meta.DatePublished.Format("2006-01-02") != "2025-03-04" {
+		t.Errorf("unexpected published date %v", meta.DatePublished)
+	}
+
+	// JSON-LD fills what the meta tags leave out; the article object wins over the site's
+	jsonLD := `<html><head>
+		<meta property="article:author" content="https://example.com/authors/jane">
+		<script type="application/ld+json">{"@graph":[
+			{"@type":"WebSite","name":"Example","image":"https://example.com/logo.png"},
+			{"@type":"NewsArticle","headline":"Release Notes","author":[{"@type":"Person","name":"Jane Doe"},{"@type":"Person","name":"John Roe"}],
+			 "publisher":{"@type":"Organization","name":"Example News"},"image":{"@type":"ImageObject","url":"https://cdn.example.com/hero.jpg"}}
+		]}</script>
+	</head><body></body></html>`
+
+	meta = ExtractPageMetadata(jsonLD, "https://example.com/news/release")
+	if meta.Title != "Release Notes" {
+		t.Errorf("expected the JSON-LD headline, got %q", meta.Title)
+	}
+	if meta.Author != "Jane Doe, John Roe" {
+		t.Errorf("expected JSON-LD authors instead of the profile URL, got %q", meta.Author)
+	}
+	if meta.SiteName != "Example News" || meta.HeroImage != "https://cdn.example.com/hero.jpg" {
+		t.Errorf("unexpected JSON-LD publisher/image %+v", meta)
+	}
+
+	if meta := ExtractPageMetadata(`<html><body><p>Plain page</p></body></html>`, "https://example.com"); meta != (PageMetadata{}) {
+		t.Errorf("expected no metadata, got %+v", meta)
+	}
+}
+
+func TestApplyPageMetadata_KeepsExistingFields(t *testing.T) {
+	feedDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	article := &core.Article{Author: "Feed Author", DatePublished: feedDate}
+	ApplyPageMetadata(article, PageMetadata{
+		Author:        "Page Author",
+		SiteName:      "Example Blog",
+		DatePublished: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC),
+	})
+
+	if article.Author != "Feed Author" || !article.DatePublished.Equal(feedDate) {
+		t.Errorf("expected existing fields kept, got %+v", article)
+	}
+	if article.SiteName != "Example Blog" {
+		t.Errorf("expected empty fields filled, got %q", article.SiteName)
+	}
+}
+
+func TestApplyLinkTitle(t *testing.T) {
+	article := &core.Article{Title: "10 Things You Won't Believe About Postgres | SEO Blog"}
+	ApplyLinkTitle(article, "Scaling Postgres  to 1M writes/sec")
+	if article.Title != "Scaling Postgres to 1M writes/sec" || article.LinkTitle != article.Title {
+		t.Errorf("expected the link text as the title, got %+v", article)
+	}
+	if article.PageTitle != "10 Things You Won't Believe About Postgres | SEO Blog" {
+		t.Errorf("expected the page title kept, got %q", article.PageTitle)
+	}
+
+	// Applying again (e.g. on a cache hit) starts from the page title
+	ApplyLinkTitle(article, "Postgres write scaling")
+	if article.Title != "Postgres write scaling" || article.PageTitle != "10 Things You Won't Believe About Postgres | SEO Blog" {
+		t.Errorf("expected the new link text over the page title, got %+v", article)
+	}
+
+	for _, text := range []string{"", "here", "Read more →", "https://example.com/post", "example.com", "www.example.com/post"} {
+		ApplyLinkTitle(article, text)
+		if article.Title != article.PageTitle || article.LinkTitle != "" {
+			t.Errorf("link text %q: expected the page title, got %+v", text, article)
+		}
+	}
+}
+
+func TestGetYouTubeTranscript_FallsBackToAutomaticCaptions(t *testing.T) {
+	var kinds []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kinds = append(kinds, r.URL.Query().Get("kind"))
+		if r.URL.Query().Get("v") != "abc123def45" {
+			t.Errorf("unexpected video ID %q", r.URL.Query().Get("v"))
+		}
+		if r.URL.Query().Get("kind") != "asr" {
+			return // No uploaded captions
+		}
+		_, _ = w.Write([]byte(`<transcript><text start="12.5" dur="2">hello there</text></transcript>`))
+	}))
+	defer server.Close()
+
+	original := youTubeTimedTextURL
+	youTubeTimedTextURL = server.URL
+	defer func() { youTubeTimedTextURL = original }()
+
+	segments, err := getYouTubeTranscript("abc123def45")
+	if err != nil {
+		t.Fatalf("getYouTubeTranscript: %v", err)
+	}
+	if len(segments) != 1 || segments[0].Start != 12 || segments[0].Text != "hello there" {
+		t.Errorf("segments = %+v", segments)
+	}
+	if strings.Join(kinds, ",") != ",asr" {
+		t.Errorf("expected uploaded then automatic captions, requested %q", kinds)
+	}
+}
@@ -0,0 +1,198 @@
+package fetch
+
+import (
+	"briefly/internal/core"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// maxArticleImages caps how many primary images are kept per article
+const maxArticleImages = 4
+
+// maxImageDownloadSize caps images sent to the vision model (Gemini inline data limit is 20MB)
+const maxImageDownloadSize = 8 << 20
+
+// minImageDimension skips images declared smaller than this (icons, avatars, spacers)
+const minImageDimension = 200
+
+var (
+	// decorativeImagePattern matches src/class/alt hints of non-content images
+	decorativeImagePattern = regexp.MustCompile(`(?i)(logo|avatar|icon|badge|emoji|sprite|spinner|pixel|tracking|gravatar|profile|author|share|button|banner-ad)`)
+
+	// figureHintPattern matches alt/caption/src hints that an image is a chart or figure
+	figureHintPattern = regexp.MustCompile(`(?i)(chart|graph|plot|benchmark|figure|fig\.|diagram|latency|throughput|results|comparison|performance|table|leaderboard|score)`)
+)
+
+// ImageDescriber describes an image for a reader. *llm.Client implements it.
+type ImageDescriber interface {
+	DescribeImage(ctx context.Context, data []byte, mimeType string, articleContext string) (string, error)
+}
+
+// ExtractImages finds the primary images in an article's main content, skipping
+// decorative ones. Relative URLs are resolved against pageURL. Images inside a
+// <figure> or whose alt text/caption mentions a chart are marked as figures.
+func ExtractImages(htmlContent string, pageURL string) []core.ArticleImage {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+
+	doc.Find("nav, footer, header, aside, .sidebar, #sidebar, .ad, .advertisement, .related, .comments").Remove()
+
+	// Search the main content if we can find it, otherwise the whole body
+	root := doc.Find("body")
+	for _, selector := range []string{"article", "main", "[role='main']", ".post-content", ".entry-content", ".article-body"} {
+		if found := doc.Find(selector).First(); found.Length() > 0 {
+			root = found
+			break
+		}
+	}
+
+	base, _ := url.Parse(pageURL)
+	seen := make(map[string]bool)
+	var images []core.ArticleImage
+
+	root.Find("img").EachWithBreak(func(_ int, img *goquery.Selection) bool {
+		src := imageSource(img)
+		if src == "" || strings.HasPrefix(src, "data:") {
+			return true
+		}
+		if base != nil {
+			if resolved, err := base.Parse(src); err == nil {
+				src = resolved.String()
+			}
+		}
+		if seen[src] || !strings.HasPrefix(src, "http") {
+			return true
+		}
+
+		alt := strings.TrimSpace(img.AttrOr("alt", ""))
+		class := img.AttrOr("class", "")
+		if decorativeImagePattern.MatchString(src + " " + class + " " + alt) {
+			return true
+		}
+		if tooSmall(img.AttrOr("width", ""), img.AttrOr("height", "")) {
+			return true
+		}
+		if strings.HasSuffix(strings.ToLower(strings.SplitN(src, "?", 2)[0]), ".svg") && alt == "" {
+			return true
+		}
+
+		image := core.ArticleImage{URL: src, Alt: alt}
+		if figure := img.Closest("figure"); figure.Length() > 0 {
+			image.Caption = strings.TrimSpace(figure.Find("figcaption").First().Text())
+			image.Figure = true
+		}
+		if figureHintPattern.MatchString(image.Alt + " " + image.Caption + " " + src) {
+			image.Figure = true
+		}
+
+		seen[src] = true
+		images = append(images, image)
+		return len(images) < maxArticleImages
+	})
+
+	return images
+}
+
+// imageSource returns the image URL, preferring lazy-load attributes over placeholder srcs
+func imageSource(img *goquery.Selection) string {
+	for _, attr := range []string{"data-src", "data-original", "src"} {
+		if src := strings.TrimSpace(img.AttrOr(attr, "")); src != "" {
+			return src
+		}
+	}
+	if srcset := img.AttrOr("srcset", ""); srcset != "" {
+		return strings.Fields(srcset)[0]
+	}
+	return ""
+}
+
+// tooSmall reports whether declared width or height is below minImageDimension
+func tooSmall(width, height string) bool {
+	for _, dim := range []string{width, height} {
+		if n, err := strconv.Atoi(strings.TrimSuffix(dim, "px")); err == nil && n < minImageDimension {
+			return true
+		}
+	}
+	return false
+}
+
+// DescribeFigures runs up to limit of the article's figure images through describer,
+// storing each description on the image. Images already described are skipped.
+// Returns how many images were described; individual failures are skipped.
+func DescribeFigures(ctx context.Context, article *core.Article, describer ImageDescriber, limit int) int {
+	client := &http.Client{Timeout: 30 * time.Second}
+	articleContext := article.Title
+	if len(article.CleanedText) > 500 {
+		articleContext += "\n\n" + article.CleanedText[:500]
+	} else if article.CleanedText != "" {
+		articleContext += "\n\n" + article.CleanedText
+	}
+
+	described := 0
+	for i := range article.Images {
+		if described >= limit {
+			break
+		}
+		image := &article.Images[i]
+		if !image.Figure || image.Description != "" {
+			continue
+		}
+
+		data, mimeType, err := downloadImage(ctx, client, image.URL)
+		if err != nil {
+			continue
+		}
+		description, err := describer.DescribeImage(ctx, data, mimeType, articleContext)
+		if err != nil || strings.TrimSpace(description) == "" {
+			continue
+		}
+		image.Description = strings.TrimSpace(description)
+		described++
+	}
+	return described
+}
+
+// downloadImage fetches an image, returning its bytes and MIME type
+func downloadImage(ctx context.Context, client *http.Client, imageURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download image %s: %w", imageURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to download image %s: status code %d", imageURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageDownloadSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image %s: %w", imageURL, err)
+	}
+	if len(data) > maxImageDownloadSize {
+		return nil, "", fmt.Errorf("image %s is larger than %d bytes", imageURL, maxImageDownloadSize)
+	}
+
+	mimeType := strings.TrimSpace(strings.SplitN(resp.Header.Get("Content-Type"), ";", 2)[0])
+	if !strings.HasPrefix(mimeType, "image/") {
+		mimeType = http.DetectContentType(data)
+	}
+	if !strings.HasPrefix(mimeType, "image/") || mimeType == "image/svg+xml" {
+		return nil, "", fmt.Errorf("unsupported image type %q for %s", mimeType, imageURL)
+	}
+	return data, mimeType, nil
+}
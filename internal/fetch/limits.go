@@ -0,0 +1,167 @@
+package fetch
+
+import (
+	"briefly/internal/core"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DownloadLimits caps how many bytes the fetcher downloads per content type. 0
+// disables a limit.
+type DownloadLimits struct {
+	HTML int64
+	PDF  int64
+}
+
+// DefaultDownloadLimits are used until SetDownloadLimits is called
+var DefaultDownloadLimits = DownloadLimits{HTML: 10 << 20, PDF: 50 << 20}
+
+var downloadLimits atomic.Pointer[DownloadLimits]
+
+// SetDownloadLimits sets the download limits for the whole process (fetch.max_size
+// in config)
+func SetDownloadLimits(limits DownloadLimits) {
+	downloadLimits.Store(&limits)
+}
+
+// limitFor returns the download limit for a content type
+func limitFor(contentType core.ContentType) int64 {
+	limits := DefaultDownloadLimits
+	if configured := downloadLimits.Load(); configured != nil {
+		limits = *configured
+	}
+	if contentType == core.ContentTypePDF {
+		return limits.PDF
+	}
+	return limits.HTML
+}
+
+var (
+	// ErrTooLarge is wrapped by a SkipError for a download over its size limit
+	ErrTooLarge = errors.New("download too large")
+	// ErrNotArticle is wrapped by a SkipError for a URL serving video, audio, or
+	// another type that has no text to summarize
+	ErrNotArticle = errors.New("not an article")
+)
+
+// SkipError reports a URL skipped without being downloaded in full
+type SkipError struct {
+	URL    string
+	Reason string // e.g. "PDF is 2.1 GB, over the 50 MB limit (fetch.max_size.pdf)"
+	Err    error  // ErrTooLarge or ErrNotArticle
+}
+
+func (e *SkipError) Error() string {
+	return fmt.Sprintf("skipped %s: %s", e.URL, e.Reason)
+}
+
+func (e *SkipError) Unwrap() error {
+	return e.Err
+}
+
+// SkippedArticle returns an article recording why urlStr was skipped when err is
+// a SkipError, so the skip can be reported with the run's other skipped pages. The
+// article has no content and must not be summarized.
+func SkippedArticle(urlStr string, err error) (*core.Article, bool) {
+	var skip *SkipError
+	if !errors.As(err, &skip) {
+		return nil, false
+	}
+	return &core.Article{
+		ID:          uuid.NewString(),
+		URL:         urlStr,
+		DateFetched: time.Now().UTC(),
+		SkipReason:  skip.Reason,
+	}, true
+}
+
+// tooLarge builds the skip for a download over limit. size is -1 when unknown,
+// as for a streamed response without Content-Length.
+func tooLarge(urlStr string, contentType core.ContentType, size, limit int64) *SkipError {
+	label, key := "page", "html"
+	if contentType == core.ContentTypePDF {
+		label, key = "PDF", "pdf"
+	}
+	reason := fmt.Sprintf("%s is over the %s limit (fetch.max_size.%s)", label, formatSize(limit), key)
+	if size >= 0 {
+		reason = fmt.Sprintf("%s is %s, over the %s limit (fetch.max_size.%s)", label, formatSize(size), formatSize(limit), key)
+	}
+	return &SkipError{URL: urlStr, Reason: reason, Err: ErrTooLarge}
+}
+
+// checkHeadSize skips a URL whose HEAD response already shows it can't be used:
+// a media or archive type, or a Content-Length over the limit for its type
+func checkHeadSize(urlStr string, head headInfo) error {
+	if notArticleType(head.mediaType) {
+		reason := fmt.Sprintf("serves %s, which has no text to summarize", head.mediaType)
+		if head.length > 0 {
+			reason += fmt.Sprintf(" (%s)", formatSize(head.length))
+		}
+		return &SkipError{URL: urlStr, Reason: reason, Err: ErrNotArticle}
+	}
+	if limit := limitFor(head.contentType); limit > 0 && head.length > limit {
+		return tooLarge(urlStr, head.contentType, head.length, limit)
+	}
+	return nil
+}
+
+// notArticleType reports whether a media type is one the fetcher can't extract
+// text from. application/octet-stream isn't one: servers use it for PDFs too.
+func notArticleType(mediaType string) bool {
+	for _, prefix := range []string{"video/", "audio/", "image/", "font/"} {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	switch mediaType {
+	case "application/zip", "application/gzip", "application/x-tar",
+		"application/x-7z-compressed", "application/vnd.rar", "application/x-iso9660-image":
+		return true
+	}
+	return false
+}
+
+// readBody reads a response body of contentType, refusing one whose Content-Length
+// is over the limit and stopping a streamed one as soon as it passes it
+func readBody(resp *http.Response, urlStr string, contentType core.ContentType) ([]byte, error) {
+	limit := limitFor(contentType)
+	if limit <= 0 {
+		return io.ReadAll(resp.Body)
+	}
+	if resp.ContentLength > limit {
+		return nil, tooLarge(urlStr, contentType, resp.ContentLength, limit)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, tooLarge(urlStr, contentType, -1, limit)
+	}
+	return data, nil
+}
+
+// formatSize renders a byte count as e.g. "512 KB", "50 MB", or "2.1 GB"
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		if size%(1<<20) == 0 {
+			return fmt.Sprintf("%d MB", size>>20)
+		}
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%d KB", size>>10)
+	default:
+		return fmt.Sprintf("%d bytes", size)
+	}
+}
@@ -0,0 +1,130 @@
+package fetch
+
+import (
+	"briefly/internal/core"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func withDownloadLimits(t *testing.T, limits DownloadLimits) {
+	t.Helper()
+	SetDownloadLimits(limits)
+	t.Cleanup(func() { SetDownloadLimits(DefaultDownloadLimits) })
+}
+
+func TestFetchArticle_SizeLimits(t *testing.T) {
+	withDownloadLimits(t, DownloadLimits{HTML: 1 << 10, PDF: 1 << 20})
+	page := "<html><body>" + strings.Repeat("x", 4<<10) + "</body></html>"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/declared" {
+			w.Header().Set("Content-Length", strconv.Itoa(len(page)))
+			_, _ = w.Write([]byte(page))
+			return
+		}
+		// Streamed without a Content-Length
+		for i := 0; i < len(page); i += 512 {
+			_, _ = w.Write([]byte(page[i:min(i+512, len(page))]))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	_, err := FetchArticle(core.Link{URL: server.URL + "/declared"})
+	var skip *SkipError
+	if !errors.As(err, &skip) || !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("expected a too-large skip, got %v", err)
+	}
+	if skip.Reason != "page is 4 KB, over the 1 KB limit (fetch.max_size.html)" {
+		t.Errorf("unexpected reason %q", skip.Reason)
+	}
+
+	_, err = FetchArticle(core.Link{URL: server.URL + "/streamed"})
+	if !errors.As(err, &skip) || skip.Reason != "page is over the 1 KB limit (fetch.max_size.html)" {
+		t.Errorf("expected the streamed page stopped at the limit, got %v", err)
+	}
+
+	withDownloadLimits(t, DownloadLimits{})
+	if _, err := FetchArticle(core.Link{URL: server.URL + "/streamed"}); err != nil {
+		t.Errorf("expected no limit with 0, got %v", err)
+	}
+}
+
+func TestProcessArticle_HeadSkipsMedia(t *testing.T) {
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+		switch r.URL.Path {
+		case "/talk":
+			w.Header().Set("Content-Type", "video/mp4")
+			w.Header().Set("Content-Length", strconv.Itoa(2<<30))
+		case "/report":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Header().Set("Content-Length", strconv.Itoa(80<<20))
+		}
+	}))
+	defer server.Close()
+
+	processor := NewContentProcessor()
+	_, err := processor.ProcessArticle(context.Background(), server.URL+"/talk")
+	if !errors.Is(err, ErrNotArticle) || !strings.Contains(err.Error(), "video/mp4") || !strings.Contains(err.Error(), "2.0 GB") {
+		t.Errorf("expected the video skipped from its HEAD response, got %v", err)
+	}
+
+	_, err = processor.ProcessArticle(context.Background(), server.URL+"/report")
+	if !errors.Is(err, ErrTooLarge) || !strings.Contains(err.Error(), "PDF is 80 MB, over the 50 MB limit") {
+		t.Errorf("expected the PDF skipped from its HEAD response, got %v", err)
+	}
+
+	if gets.Load() != 0 {
+		t.Errorf("expected no downloads, got %d GET requests", gets.Load())
+	}
+}
+
+func TestProcessArticle_HeadTypeNeedsSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/paper":
+			// PDFs are often served as a generic binary type
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("%PDF-1.4 not a real document"))
+		case r.Method == http.MethodHead:
+			// A HEAD error page that says nothing about the article
+			w.Header().Set("Content-Type", "image/png")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			_, _ = w.Write([]byte("<html><head><title>Post</title></head><body><p>" + strings.Repeat("Words here. ", 50) + "</p></body></html>"))
+		}
+	}))
+	defer server.Close()
+
+	processor := NewContentProcessor()
+	if _, err := processor.ProcessArticle(context.Background(), server.URL+"/post"); err != nil {
+		t.Errorf("expected the page fetched despite its HEAD error response, got %v", err)
+	}
+
+	_, err := processor.ProcessArticle(context.Background(), server.URL+"/paper")
+	if errors.Is(err, ErrNotArticle) || err == nil || !strings.Contains(err.Error(), "pdf content") {
+		t.Errorf("expected the octet-stream PDF handed to the PDF reader, got %v", err)
+	}
+}
+
+func TestSkippedArticle(t *testing.T) {
+	err := tooLarge("https://example.com/big.pdf", core.ContentTypePDF, -1, 50<<20)
+	article, ok := SkippedArticle("https://example.com/big.pdf", err)
+	if !ok || article.SkipReason != err.Reason || article.URL != "https://example.com/big.pdf" {
+		t.Errorf("unexpected skipped article %+v", article)
+	}
+
+	if _, ok := SkippedArticle("https://example.com", errors.New("connection refused")); ok {
+		t.Error("expected other errors not to make a skipped article")
+	}
+}
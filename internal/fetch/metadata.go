@@ -0,0 +1,357 @@
+package fetch
+
+import (
+	"briefly/internal/core"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// PageMetadata is what a page says about itself in OpenGraph and Twitter-card meta
+// tags and JSON-LD, as opposed to what is scraped from its body
+type PageMetadata struct {
+	Title         string
+	Author        string
+	SiteName      string
+	HeroImage     string // Absolute URL of the og:image / twitter:image / JSON-LD image
+	CanonicalURL  string // Absolute URL from <link rel="canonical"> or og:url
+	DatePublished time.Time
+}
+
+// Meta tag selectors for each field, most reliable first
+var (
+	titleMetaSelectors = []string{
+		"meta[property='og:title']",
+		"meta[name='twitter:title']",
+		"meta[property='twitter:title']",
+	}
+	authorMetaSelectors = []string{
+		"meta[name='author']",
+		"meta[property='article:author']",
+		"meta[name='parsely-author']",
+		"meta[name='sailthru.author']",
+		"meta[name='dc.creator']",
+		"meta[name='DC.creator']",
+	}
+	siteNameMetaSelectors = []string{
+		"meta[property='og:site_name']",
+		"meta[name='application-name']",
+	}
+	heroImageMetaSelectors = []string{
+		"meta[property='og:image:secure_url']",
+		"meta[property='og:image']",
+		"meta[name='twitter:image']",
+		"meta[name='twitter:image:src']",
+		"meta[property='twitter:image']",
+	}
+	publishedMetaSelectors = []string{
+		"meta[property='article:published_time']",
+		"meta[property='og:published_time']",
+		"meta[itemprop='datePublished']",
+		"meta[name='parsely-pub-date']",
+		"meta[name='sailthru.date']",
+		"meta[name='DC.date.issued']",
+		"meta[name='dcterms.created']",
+		"meta[name='publish-date']",
+		"meta[name='pubdate']",
+		"meta[name='date']",
+	}
+)
+
+// articleLDTypes are JSON-LD types describing the article itself rather than the
+// site, breadcrumbs, or an organization
+var articleLDTypes = map[string]bool{
+	"article":              true,
+	"newsarticle":          true,
+	"blogposting":          true,
+	"techarticle":          true,
+	"scholarlyarticle":     true,
+	"report":               true,
+	"reportagenewsarticle": true,
+	"analysisnewsarticle":  true,
+	"opinionnewsarticle":   true,
+	"socialmediaposting":   true,
+}
+
+// publishedLayouts are the date formats found in meta tags and JSON-LD
+var publishedLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"January 2, 2006",
+}
+
+// ExtractPageMetadata reads a page's OpenGraph, Twitter-card, and JSON-LD metadata.
+// Meta tags win over JSON-LD, and JSON-LD describing an article wins over other
+// JSON-LD objects. Relative image and canonical URLs are resolved against pageURL.
+// Fields the page doesn't declare are left empty.
+func ExtractPageMetadata(htmlContent string, pageURL string) PageMetadata {
+	var meta PageMetadata
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return meta
+	}
+	objects := jsonLDObjects(doc)
+	base, _ := url.Parse(pageURL)
+
+	meta.Title = firstMeta(doc, titleMetaSelectors)
+	if meta.Title == "" {
+		meta.Title = jsonLDString(objects, "headline")
+	}
+
+	meta.Author = firstMeta(doc, authorMetaSelectors)
+	if strings.HasPrefix(meta.Author, "http") {
+		// article:author is often a profile URL rather than a name
+		meta.Author = ""
+	}
+	if meta.Author == "" {
+		meta.Author = jsonLDAuthor(objects)
+	}
+
+	meta.SiteName = firstMeta(doc, siteNameMetaSelectors)
+	if meta.SiteName == "" {
+		meta.SiteName = jsonLDName(objects, "publisher")
+	}
+
+	meta.HeroImage = resolveMetaURL(base, firstMeta(doc, heroImageMetaSelectors))
+	if meta.HeroImage == "" {
+		meta.HeroImage = resolveMetaURL(base, jsonLDImage(objects))
+	}
+
+	meta.CanonicalURL = resolveMetaURL(base, doc.Find("link[rel='canonical']").First().AttrOr("href", ""))
+	if meta.CanonicalURL == "" {
+		meta.CanonicalURL = resolveMetaURL(base, firstMeta(doc, []string{"meta[property='og:url']"}))
+	}
+
+	for _, selector := range publishedMetaSelectors {
+		if meta.DatePublished = parsePublished(doc.Find(selector).First().AttrOr("content", "")); !meta.DatePublished.IsZero() {
+			break
+		}
+	}
+	if meta.DatePublished.IsZero() {
+		meta.DatePublished = parsePublished(jsonLDString(objects, "datePublished"))
+	}
+	if meta.DatePublished.IsZero() {
+		timeElement := doc.Find("time[itemprop='datePublished'], time[pubdate], article time[datetime]").First()
+		meta.DatePublished = parsePublished(timeElement.AttrOr("datetime", ""))
+	}
+
+	return meta
+}
+
+// ExtractPublishedDate finds when a page was published from its meta tags, JSON-LD
+// (datePublished), or a <time> element marked as the publication date. It returns
+// the zero time when the page doesn't say.
+func ExtractPublishedDate(htmlContent string) time.Time {
+	return ExtractPageMetadata(htmlContent, "").DatePublished
+}
+
+// ApplyPageMetadata fills the article's empty fields from the page's metadata. Values
+// already set, e.g. a feed item's date or a YouTube channel, are kept.
+func ApplyPageMetadata(article *core.Article, meta PageMetadata) {
+	if article.Author == "" {
+		article.Author = meta.Author
+	}
+	if article.SiteName == "" {
+		article.SiteName = meta.SiteName
+	}
+	if article.HeroImage == "" {
+		article.HeroImage = meta.HeroImage
+	}
+	if article.CanonicalURL == "" {
+		article.CanonicalURL = meta.CanonicalURL
+	}
+	if article.DatePublished.IsZero() {
+		article.DatePublished = meta.DatePublished
+	}
+}
+
+// firstMeta returns the first non-empty content of the selected meta tags
+func firstMeta(doc *goquery.Document, selectors []string) string {
+	for _, selector := range selectors {
+		if content := strings.TrimSpace(doc.Find(selector).First().AttrOr("content", "")); content != "" {
+			return content
+		}
+	}
+	return ""
+}
+
+// resolveMetaURL makes a metadata URL absolute, returning "" for anything that isn't http(s)
+func resolveMetaURL(base *url.URL, raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	if base != nil && base.Scheme != "" {
+		if resolved, err := base.Parse(raw); err == nil {
+			raw = resolved.String()
+		}
+	}
+	if !strings.HasPrefix(raw, "http://") && !strings.HasPrefix(raw, "https://") {
+		return ""
+	}
+	return raw
+}
+
+// jsonLDObjects returns the page's JSON-LD objects, flattening lists, @graph, and
+// mainEntity, with article-typed objects first
+func jsonLDObjects(doc *goquery.Document) []map[string]interface{} {
+	var articles, others []map[string]interface{}
+
+	var collect func(data interface{})
+	collect = func(data interface{}) {
+		switch value := data.(type) {
+		case map[string]interface{}:
+			if isArticleLD(value) {
+				articles = append(articles, value)
+			} else {
+				others = append(others, value)
+			}
+			for _, key := range []string{"@graph", "mainEntity", "mainEntityOfPage"} {
+				collect(value[key])
+			}
+		case []interface{}:
+			for _, item := range value {
+				collect(item)
+			}
+		}
+	}
+
+	doc.Find("script[type='application/ld+json']").Each(func(_ int, script *goquery.Selection) {
+		var data interface{}
+		if err := json.Unmarshal([]byte(script.Text()), &data); err == nil {
+			collect(data)
+		}
+	})
+
+	return append(articles, others...)
+}
+
+// isArticleLD reports whether a JSON-LD object's @type is an article type
+func isArticleLD(object map[string]interface{}) bool {
+	switch types := object["@type"].(type) {
+	case string:
+		return articleLDTypes[strings.ToLower(types)]
+	case []interface{}:
+		for _, t := range types {
+			if name, ok := t.(string); ok && articleLDTypes[strings.ToLower(name)] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonLDString returns the first non-empty string value of key
+func jsonLDString(objects []map[string]interface{}, key string) string {
+	for _, object := range objects {
+		if value, ok := object[key].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// jsonLDName returns the name of the first object (or string) under key, e.g. publisher
+func jsonLDName(objects []map[string]interface{}, key string) string {
+	for _, object := range objects {
+		if name := ldName(object[key]); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// jsonLDAuthor returns the article's authors as a comma-separated list
+func jsonLDAuthor(objects []map[string]interface{}) string {
+	for _, object := range objects {
+		var names []string
+		switch authors := object["author"].(type) {
+		case []interface{}:
+			for _, author := range authors {
+				if name := ldName(author); name != "" {
+					names = append(names, name)
+				}
+			}
+		default:
+			if name := ldName(authors); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			return strings.Join(names, ", ")
+		}
+	}
+	return ""
+}
+
+// ldName reads a JSON-LD Person or Organization given as a string or as an object with a name
+func ldName(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "http") {
+			return ""
+		}
+		return strings.TrimSpace(v)
+	case map[string]interface{}:
+		if name, ok := v["name"].(string); ok {
+			return strings.TrimSpace(name)
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			return ldName(v[0])
+		}
+	}
+	return ""
+}
+
+// jsonLDImage returns the first image URL, given as a string, an ImageObject, or a list
+func jsonLDImage(objects []map[string]interface{}) string {
+	var imageURL func(value interface{}) string
+	imageURL = func(value interface{}) string {
+		switch v := value.(type) {
+		case string:
+			return v
+		case map[string]interface{}:
+			if u, ok := v["url"].(string); ok {
+				return u
+			}
+		case []interface{}:
+			for _, item := range v {
+				if u := imageURL(item); u != "" {
+					return u
+				}
+			}
+		}
+		return ""
+	}
+
+	for _, object := range objects {
+		if u := imageURL(object["image"]); u != "" {
+			return u
+		}
+	}
+	return ""
+}
+
+// parsePublished parses a publication date in any of publishedLayouts, as UTC
+func parsePublished(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range publishedLayouts {
+		if published, err := time.Parse(layout, value); err == nil {
+			return published.UTC()
+		}
+	}
+	return time.Time{}
+}
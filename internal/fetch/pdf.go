@@ -2,6 +2,7 @@ package fetch
 
 import (
 	"briefly/internal/core"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,9 @@ import (
 	"github.com/ledongthuc/pdf"
 )
 
+// pdfMagic starts every PDF file
+const pdfMagic = "%PDF-"
+
 // ProcessPDFContent extracts text content from a PDF file
 func ProcessPDFContent(link core.Link) (core.Article, error) {
 	var reader io.ReaderAt
@@ -42,6 +46,10 @@ func ProcessPDFContent(link core.Link) (core.Article, error) {
 			return core.Article{}, fmt.Errorf("failed to stat PDF file %s: %w", filePath, err)
 		}
 
+		if limit := limitFor(core.ContentTypePDF); limit > 0 && stat.Size() > limit {
+			return core.Article{}, tooLarge(link.URL, core.ContentTypePDF, stat.Size(), limit)
+		}
+
 		reader = file
 		size = stat.Size()
 	} else {
@@ -71,8 +79,12 @@ func ProcessPDFContent(link core.Link) (core.Article, error) {
 			return core.Article{}, fmt.Errorf("URL %s does not return a PDF (Content-Type: %s)", link.URL, contentType)
 		}
 
-		// Read the entire response into memory
-		data, err := io.ReadAll(resp.Body)
+		// Read the response into memory, up to fetch.max_size.pdf
+		data, err := readBody(resp, link.URL, core.ContentTypePDF)
+		var skip *SkipError
+		if errors.As(err, &skip) {
+			return core.Article{}, err
+		}
 		if err != nil {
 			return core.Article{}, fmt.Errorf("failed to read PDF data from %s: %w", link.URL, err)
 		}
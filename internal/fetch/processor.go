@@ -3,6 +3,7 @@ package fetch
 import (
 	"briefly/internal/core"
 	"context"
+	"errors"
 	"fmt"
 	"mime"
 	"net/http"
@@ -10,18 +11,32 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // ContentProcessor implements the ArticleProcessor interface with multi-format support
-type ContentProcessor struct{}
+type ContentProcessor struct {
+	offlinePages map[string]string // URL -> HTML; when set, the network is never used
+}
 
 // NewContentProcessor creates a new ContentProcessor
 func NewContentProcessor() *ContentProcessor {
 	return &ContentProcessor{}
 }
 
+// NewOfflineContentProcessor creates a ContentProcessor that serves HTML from pages
+// (keyed by URL) instead of fetching it. URLs not in pages fail to process.
+func NewOfflineContentProcessor(pages map[string]string) *ContentProcessor {
+	return &ContentProcessor{offlinePages: pages}
+}
+
 // ProcessArticle processes a single article from a URL, detecting content type automatically
 func (cp *ContentProcessor) ProcessArticle(ctx context.Context, urlStr string) (*core.Article, error) {
+	if cp.offlinePages != nil {
+		return cp.processOfflineArticle(urlStr)
+	}
+
 	// Create a basic link structure
 	link := core.Link{
 		URL: urlStr,
@@ -30,7 +45,7 @@ func (cp *ContentProcessor) ProcessArticle(ctx context.Context, urlStr string) (
 	// Detect content type
 	contentType, err := cp.detectContentType(urlStr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to detect content type for %s: %w", urlStr, err)
+		return nil, err
 	}
 
 	// Process based on content type
@@ -44,12 +59,20 @@ func (cp *ContentProcessor) ProcessArticle(ctx context.Context, urlStr string) (
 		fallthrough
 	default:
 		article, err = FetchArticle(link)
-		if err == nil {
+		if err == nil && strings.HasPrefix(article.FetchedHTML, pdfMagic) {
+			// A PDF served under a generic type such as application/octet-stream
+			contentType = core.ContentTypePDF
+			article, err = ProcessPDFContent(link)
+		} else if err == nil {
 			article.ContentType = core.ContentTypeHTML
 			err = ParseArticleContent(&article)
 		}
 	}
 
+	var skip *SkipError
+	if errors.As(err, &skip) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to process %s content from %s: %w", contentType, urlStr, err)
 	}
@@ -60,6 +83,55 @@ func (cp *ContentProcessor) ProcessArticle(ctx context.Context, urlStr string) (
 	return &article, nil
 }
 
+// processOfflineArticle runs the normal HTML cleaning path on a page from the offline corpus
+func (cp *ContentProcessor) processOfflineArticle(urlStr string) (*core.Article, error) {
+	html, ok := cp.offlinePages[urlStr]
+	if !ok {
+		return nil, fmt.Errorf("URL %s is not in the offline corpus", urlStr)
+	}
+
+	article := core.Article{
+		ID:          uuid.NewSHA1(uuid.NameSpaceURL, []byte(urlStr)).String(),
+		URL:         urlStr,
+		ContentType: core.ContentTypeHTML,
+		FetchedHTML: html,
+		DateFetched: time.Now().UTC(),
+		Title:       extractTitle(html, urlStr),
+	}
+
+	if err := ParseArticleContent(&article); err != nil {
+		return nil, fmt.Errorf("failed to process offline page %s: %w", urlStr, err)
+	}
+
+	article.EstimatedReadMinutes = CalculateReadingTime(&article)
+
+	return &article, nil
+}
+
+// ProcessFeedContent builds an article from the full HTML body a feed published for
+// urlStr, running the normal cleaning path without fetching the page
+func (cp *ContentProcessor) ProcessFeedContent(urlStr, title, content string) (*core.Article, error) {
+	article := core.Article{
+		ID:          uuid.NewString(),
+		URL:         urlStr,
+		ContentType: core.ContentTypeHTML,
+		FetchedHTML: "<html><body><article>" + content + "</article></body></html>",
+		DateFetched: time.Now().UTC(),
+		Title:       title,
+	}
+
+	if err := ParseArticleContent(&article); err != nil {
+		return nil, fmt.Errorf("failed to process feed content for %s: %w", urlStr, err)
+	}
+	if strings.TrimSpace(article.CleanedText) == "" {
+		return nil, fmt.Errorf("feed content for %s has no text", urlStr)
+	}
+
+	article.EstimatedReadMinutes = CalculateReadingTime(&article)
+
+	return &article, nil
+}
+
 // CalculateReadingTime estimates reading time in minutes for an article
 // Uses ~200 words per minute for technical content (slower than casual reading)
 // For YouTube videos, uses the video duration if available
@@ -126,7 +198,9 @@ func (cp *ContentProcessor) CleanAndExtractContent(ctx context.Context, article
 	}
 }
 
-// detectContentType determines the content type of a URL
+// detectContentType determines the content type of a URL. For an HTTP URL of
+// unknown type it asks with a HEAD request, returning a *SkipError when the
+// response is a media type or too large to download.
 func (cp *ContentProcessor) detectContentType(urlStr string) (core.ContentType, error) {
 	// Check for YouTube URLs first
 	if DetectYouTubeURL(urlStr) {
@@ -156,20 +230,32 @@ func (cp *ContentProcessor) detectContentType(urlStr string) (core.ContentType,
 	}
 
 	if parsedURL.Scheme == "http" || parsedURL.Scheme == "https" {
-		contentType, err := cp.getContentTypeFromHTTP(urlStr)
+		head, err := cp.getContentTypeFromHTTP(urlStr)
 		if err != nil {
-			// If we can't determine from HTTP, default to HTML
+			// If we can't determine from HTTP, default to HTML; the download is still capped
 			return core.ContentTypeHTML, nil
 		}
-		return contentType, nil
+		if err := checkHeadSize(urlStr, head); err != nil {
+			return head.contentType, err
+		}
+		return head.contentType, nil
 	}
 
 	// Default to HTML for unknown cases
 	return core.ContentTypeHTML, nil
 }
 
-// getContentTypeFromHTTP makes a HEAD request to determine content type
-func (cp *ContentProcessor) getContentTypeFromHTTP(urlStr string) (core.ContentType, error) {
+// headInfo is what a HEAD request reports about a URL
+type headInfo struct {
+	contentType core.ContentType
+	mediaType   string // e.g. "text/html", "video/mp4"; empty when not reported
+	length      int64  // Content-Length, -1 when not reported
+}
+
+// getContentTypeFromHTTP makes a HEAD request to determine content type and size
+func (cp *ContentProcessor) getContentTypeFromHTTP(urlStr string) (headInfo, error) {
+	head := headInfo{contentType: core.ContentTypeHTML, length: -1}
+
 	// Create HTTP client with timeout to prevent hanging
 	client := &http.Client{
 		Timeout: 30 * time.Second,
@@ -177,34 +263,41 @@ func (cp *ContentProcessor) getContentTypeFromHTTP(urlStr string) (core.ContentT
 
 	resp, err := client.Head(urlStr)
 	if err != nil {
-		return core.ContentTypeHTML, err
+		return head, err
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
 			fmt.Printf("Warning: failed to close HTTP response body: %v\n", err)
 		}
 	}()
+	// Error pages report their own type and size, not the URL's
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return head, nil
+	}
+	if resp.StatusCode == http.StatusOK {
+		head.length = resp.ContentLength
+	}
 
 	contentType := resp.Header.Get("Content-Type")
 	if contentType == "" {
-		return core.ContentTypeHTML, nil
+		return head, nil
 	}
 
 	// Parse the media type
 	mediaType, _, err := mime.ParseMediaType(contentType)
 	if err != nil {
-		return core.ContentTypeHTML, nil
+		return head, nil
 	}
+	head.mediaType = mediaType
 
 	switch mediaType {
 	case "application/pdf":
-		return core.ContentTypePDF, nil
-	case "text/html":
-		return core.ContentTypeHTML, nil
+		head.contentType = core.ContentTypePDF
 	default:
-		// Default to HTML for unknown types
-		return core.ContentTypeHTML, nil
+		// text/html, and HTML for unknown types
+		head.contentType = core.ContentTypeHTML
 	}
+	return head, nil
 }
 
 // ProcessLinksFromFile reads links from a file and processes them with content type detection
@@ -0,0 +1,88 @@
+package fetch
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// siteAdapter tunes extraction for a publishing platform whose layout the generic
+// selectors handle badly: where the post body is, and the subscribe prompts, clap
+// bars, and embeds inside it to drop
+type siteAdapter struct {
+	name    string
+	domains []string // Hosts the platform serves posts from, subdomains included
+	// detect recognizes the platform on a custom domain from the page itself
+	detect  func(doc *goquery.Document) bool
+	content []string // Post body selectors, tried before the generic ones
+	remove  []string // Selectors removed before extraction
+}
+
+// siteAdapters are the platform adapters, checked in order
+var siteAdapters = []siteAdapter{
+	{
+		name:    "substack",
+		domains: []string{"substack.com"},
+		detect: func(doc *goquery.Document) bool {
+			return doc.Find(`link[href*="substackcdn.com"], script[src*="substackcdn.com"]`).Length() > 0
+		},
+		content: []string{".available-content .body.markup", ".body.markup"},
+		remove: []string{
+			".subscription-widget-wrap", ".subscription-widget", ".subscribe-widget",
+			".paywall", ".paywall-jump", ".button-wrapper", ".captioned-button-wrap",
+			".post-ufi", ".share-dialog", ".footnote-anchor",
+			".embedded-post-wrap", ".digest-post-embed", ".tweet", ".youtube-wrap",
+			".instagram", ".spotify-wrap", ".image-link-expand",
+		},
+	},
+	{
+		name:    "medium",
+		domains: []string{"medium.com"},
+		detect: func(doc *goquery.Document) bool {
+			return doc.Find(`meta[property="al:android:package"][content="com.medium.reader"]`).Length() > 0
+		},
+		content: []string{"article section", "article"},
+		remove: []string{
+			".speechify-ignore", ".pw-multi-vote-icon", ".pw-multi-vote-count",
+			".pw-responses-count", `[data-testid="headerClapButton"]`,
+			`[data-testid="audioPlayButton"]`, `[aria-label="clap"]`,
+			`[aria-label="responses"]`, "figure iframe",
+		},
+	},
+	{
+		name:    "ghost",
+		domains: []string{"ghost.io"},
+		detect: func(doc *goquery.Document) bool {
+			generator, _ := doc.Find(`meta[name="generator"]`).Attr("content")
+			return strings.HasPrefix(generator, "Ghost")
+		},
+		content: []string{".gh-content", ".post-content", ".article-content"},
+		remove: []string{
+			".kg-signup-card", ".gh-post-upgrade-cta", ".gh-cta", ".subscribe-form",
+			".kg-embed-card", ".kg-bookmark-card", ".kg-button-card",
+		},
+	},
+}
+
+// siteAdapterFor returns the adapter for a page, by its URL's domain or, for a
+// custom domain, by the platform's markup; nil means the generic extractor
+func siteAdapterFor(pageURL string, doc *goquery.Document) *siteAdapter {
+	host := ""
+	if parsed, err := url.Parse(pageURL); err == nil {
+		host = strings.ToLower(strings.TrimPrefix(parsed.Hostname(), "www."))
+	}
+	for i := range siteAdapters {
+		for _, domain := range siteAdapters[i].domains {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return &siteAdapters[i]
+			}
+		}
+	}
+	for i := range siteAdapters {
+		if siteAdapters[i].detect(doc) {
+			return &siteAdapters[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package fetch
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+)
+
+func TestParseArticleContent_SiteAdapters(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		html    string
+		want    string
+		notWant []string
+	}{
+		{
+			name: "substack",
+			url:  "https://example.substack.com/p/post",
+			html: `<html><body>
+				<div class="single-post"><div class="available-content"><div class="body markup">
+					<p>The substack post body.</p>
+					<div class="subscription-widget-wrap"><p>Subscribe to keep reading</p></div>
+					<div class="embedded-post-wrap"><p>An embedded other post</p></div>
+				</div></div>
+				<div class="post-ufi"><p>42 likes</p></div></div>
+			</body></html>`,
+			want:    "The substack post body.",
+			notWant: []string{"Subscribe to keep reading", "embedded other post", "42 likes"},
+		},
+		{
+			name: "substack on a custom domain",
+			url:  "https://newsletter.example.com/p/post",
+			html: `<html><head><link rel="preconnect" href="https://substackcdn.com"></head><body>
+				<div class="body markup"><p>Custom domain body.</p>
+					<p class="button-wrapper">Share</p></div>
+			</body></html>`,
+			want:    "Custom domain body.",
+			notWant: []string{"Share"},
+		},
+		{
+			name: "medium",
+			url:  "https://medium.com/@writer/post-123",
+			html: `<html><body><article>
+				<div class="speechify-ignore"><p>Writer · 5 min read</p><div class="pw-multi-vote-icon"><p>Clap</p></div></div>
+				<section><p>The medium post body.</p></section>
+			</article></body></html>`,
+			want:    "The medium post body.",
+			notWant: []string{"5 min read", "Clap"},
+		},
+		{
+			name: "ghost on a custom domain",
+			url:  "https://blog.example.com/post/",
+			html: `<html><head><meta name="generator" content="Ghost 5.80"></head><body>
+				<main><p>Site chrome</p><section class="gh-content">
+					<p>The ghost post body.</p>
+					<div class="kg-signup-card"><p>Sign up for updates</p></div>
+					<figure class="kg-bookmark-card"><p>Bookmarked link</p></figure>
+				</section></main>
+			</body></html>`,
+			want:    "The ghost post body.",
+			notWant: []string{"Site chrome", "Sign up for updates", "Bookmarked link"},
+		},
+		{
+			name:    "generic page",
+			url:     "https://example.com/post",
+			html:    `<html><body><article><p>A generic article.</p></article></body></html>`,
+			want:    "A generic article.",
+			notWant: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article := core.Article{ID: "a", URL: tt.url, FetchedHTML: tt.html}
+			if err := ParseArticleContent(&article); err != nil {
+				t.Fatalf("ParseArticleContent failed: %v", err)
+			}
+			if !strings.Contains(article.CleanedText, tt.want) {
+				t.Errorf("expected %q in cleaned text, got %q", tt.want, article.CleanedText)
+			}
+			for _, unwanted := range tt.notWant {
+				if strings.Contains(article.CleanedText, unwanted) {
+					t.Errorf("expected %q removed, got %q", unwanted, article.CleanedText)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package fetch
+
+import (
+	"briefly/internal/core"
+	"strings"
+)
+
+// genericLinkTexts are link texts that say nothing about the article behind them
+var genericLinkTexts = map[string]bool{
+	"link": true, "here": true, "this": true, "source": true, "article": true, "post": true,
+	"blog post": true, "read": true, "read more": true, "click here": true, "more": true,
+	"thread": true, "video": true, "paper": true, "pdf": true, "details": true,
+}
+
+// ApplyLinkTitle makes the text a link was given in the input file the article's
+// displayed title, keeping the page's own title in PageTitle. Link text that is a
+// URL, a bare domain, or a generic word ("here", "link") leaves the page title in
+// place. Safe to call again on a cached article with a different link text.
+func ApplyLinkTitle(article *core.Article, linkTitle string) {
+	if article.LinkTitle != "" || article.PageTitle != "" {
+		// Start over from the page's title
+		article.Title = article.PageTitle
+	}
+	article.PageTitle = article.Title
+	article.LinkTitle = ""
+
+	linkTitle = strings.Join(strings.Fields(linkTitle), " ")
+	if !IsDescriptiveLinkText(linkTitle) {
+		return
+	}
+	article.LinkTitle = linkTitle
+	article.Title = linkTitle
+}
+
+// IsDescriptiveLinkText reports whether link text could serve as an article title
+func IsDescriptiveLinkText(text string) bool {
+	text = strings.TrimSpace(text)
+	if len([]rune(text)) < 3 || genericLinkTexts[strings.ToLower(strings.Trim(text, ".:!→ "))] {
+		return false
+	}
+	if strings.Contains(text, "://") || strings.HasPrefix(strings.ToLower(text), "www.") {
+		return false
+	}
+	// A lone domain like "example.com"
+	if !strings.Contains(text, " ") && strings.Contains(text, ".") {
+		return false
+	}
+	return true
+}
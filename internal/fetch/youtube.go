@@ -2,10 +2,12 @@ package fetch
 
 import (
 	"briefly/internal/core"
+	"briefly/internal/transcript"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
@@ -27,11 +29,20 @@ func ProcessYouTubeContent(link core.Link) (core.Article, error) {
 		return core.Article{}, fmt.Errorf("failed to extract video ID from %s: %w", link.URL, err)
 	}
 
-	// Get video content (this function now handles all fallbacks internally and never fails)
-	videoContent, err := getYouTubeContent(videoID)
-	if err != nil {
-		// This should never happen now, but just in case
-		videoContent = fmt.Sprintf("YouTube Video (ID: %s). Content generation failed.", videoID)
+	// Prefer the captions, which carry what was actually said and when; fall back to
+	// content built from the video's metadata
+	segments, err := getYouTubeTranscript(videoID)
+	var videoContent string
+	if err == nil && len(segments) > 0 {
+		videoContent = transcript.Text(segments)
+	} else {
+		segments = nil
+		// Get video content (this function now handles all fallbacks internally and never fails)
+		videoContent, err = getYouTubeContent(videoID)
+		if err != nil {
+			// This should never happen now, but just in case
+			videoContent = fmt.Sprintf("YouTube Video (ID: %s). Content generation failed.", videoID)
+		}
 	}
 
 	// Clean the content
@@ -51,6 +62,10 @@ func ProcessYouTubeContent(link core.Link) (core.Article, error) {
 		channel = videoInfo.Channel
 		duration = videoInfo.Duration
 	}
+	if duration == 0 && len(segments) > 0 {
+		// oEmbed has no duration; the last caption is close enough for watch time
+		duration = segments[len(segments)-1].Start
+	}
 
 	article := core.Article{
 		ID:          uuid.NewString(),
@@ -63,6 +78,7 @@ func ProcessYouTubeContent(link core.Link) (core.Article, error) {
 		DateFetched: time.Now().UTC(),
 		Duration:    duration,
 		Channel:     channel,
+		Transcript:  segments,
 	}
 
 	return article, nil
@@ -134,6 +150,47 @@ func getYouTubeVideoInfo(videoID string) (*YouTubeVideoInfo, error) {
 	}, nil
 }
 
+// youTubeTimedTextURL is YouTube's caption endpoint; it answers with an empty body
+// when a video has no captions in the requested track
+var youTubeTimedTextURL = "https://www.youtube.com/api/timedtext"
+
+// getYouTubeTranscript fetches a video's English captions, trying uploaded captions
+// before automatic ones
+func getYouTubeTranscript(videoID string) ([]core.TranscriptSegment, error) {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	for _, kind := range []string{"", "asr"} {
+		params := url.Values{"v": {videoID}, "lang": {"en"}}
+		if kind != "" {
+			params.Set("kind", kind)
+		}
+
+		resp, err := client.Get(youTubeTimedTextURL + "?" + params.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch captions: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read captions: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK || len(strings.TrimSpace(string(body))) == 0 {
+			continue
+		}
+
+		segments, err := transcript.ParseTimedText(body)
+		if err != nil {
+			return nil, err
+		}
+		if len(segments) > 0 {
+			return segments, nil
+		}
+	}
+	return nil, fmt.Errorf("no English captions for video %s", videoID)
+}
+
 // getYouTubeContent generates intelligent video content using AI analysis
 func getYouTubeContent(videoID string) (string, error) {
 	// Get video info first
@@ -0,0 +1,166 @@
+// Package footnotes cites a digest's sources as markdown footnotes. Sources are
+// numbered once per digest, in article order, so the executive summary, topic
+// sections, and article list all use the same [^N] for an article; the citations
+// the model writes ([N], [[N]], [[N]](url), [N](url)) are rewritten to match, and
+// a single references block at the end defines every number.
+package footnotes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// citation matches [[N]], [N], and either followed by (url)
+	citation = regexp.MustCompile(`\[\[(\d+)\]\](?:\(([^)\s]+)\))?|\[(\d+)\](?:\(([^)\s]+)\))?`)
+	// reference matches a footnote reference [^N], or a definition when followed by ':'
+	reference = regexp.MustCompile(`\[\^(\d+)\]`)
+	// definition matches a footnote definition at the start of a line
+	definition = regexp.MustCompile(`(?m)^\[\^(\d+)\]:`)
+)
+
+// Source is a cited article or page
+type Source struct {
+	Number int
+	URL    string
+	Title  string
+}
+
+// Set numbers a digest's sources
+type Set struct {
+	first      int
+	sources    []Source
+	byURL      map[string]int // URL -> index in sources
+	unresolved []string
+}
+
+// New returns an empty set whose first source is numbered first, so parts of a
+// split digest can continue the numbering of the parts before them
+func New(first int) *Set {
+	if first < 1 {
+		first = 1
+	}
+	return &Set{first: first, byURL: make(map[string]int)}
+}
+
+// Add numbers a source, returning the number it already has when its URL was added
+// before. A source without a title is listed by its URL.
+func (s *Set) Add(url, title string) int {
+	if i, ok := s.byURL[url]; ok {
+		if s.sources[i].Title == "" {
+			s.sources[i].Title = title
+		}
+		return s.sources[i].Number
+	}
+	number := s.first + len(s.sources)
+	s.byURL[url] = len(s.sources)
+	s.sources = append(s.sources, Source{Number: number, URL: url, Title: title})
+	return number
+}
+
+// Ref returns the footnote reference for a URL, e.g. "[^3]", or "" when it wasn't
+// added
+func (s *Set) Ref(url string) string {
+	i, ok := s.byURL[url]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("[^%d]", s.sources[i].Number)
+}
+
+// Cite rewrites the numbered citations in text as footnote references. A citation
+// with a URL is numbered by its URL, adding the URL when it's new; a bare [N] must
+// be the number of a source already added. Citations that resolve to no source are
+// left as written and reported by Unresolved.
+func (s *Set) Cite(text string) string {
+	return citation.ReplaceAllStringFunc(text, func(match string) string {
+		parts := citation.FindStringSubmatch(match)
+		numStr, url := parts[1], parts[2]
+		if numStr == "" {
+			numStr, url = parts[3], parts[4]
+		}
+		if url != "" {
+			return fmt.Sprintf("[^%d]", s.Add(url, ""))
+		}
+		num, _ := strconv.Atoi(numStr)
+		if num < s.first || num >= s.first+len(s.sources) {
+			s.unresolved = append(s.unresolved, match)
+			return match
+		}
+		return fmt.Sprintf("[^%d]", num)
+	})
+}
+
+// Unresolved lists the citations Cite couldn't match to a source, in order
+func (s *Set) Unresolved() []string {
+	return s.unresolved
+}
+
+// Sources returns the numbered sources in order
+func (s *Set) Sources() []Source {
+	return s.sources
+}
+
+// References renders the references block defining every source, or "" when
+// there are none
+func (s *Set) References() string {
+	if len(s.sources) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("## 📚 References\n\n")
+	for _, source := range s.sources {
+		title := strings.TrimSpace(source.Title)
+		if title == "" {
+			fmt.Fprintf(&b, "[^%d]: <%s>\n", source.Number, source.URL)
+			continue
+		}
+		fmt.Fprintf(&b, "[^%d]: [%s](%s)\n", source.Number, escapeTitle(title), source.URL)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// Validate checks the footnotes of a markdown document: every [^N] reference must
+// have a definition, and each number may be defined only once. Returns the problems
+// found, or nil.
+func Validate(markdown string) []string {
+	defined := make(map[int]int)
+	for _, match := range definition.FindAllStringSubmatch(markdown, -1) {
+		num, _ := strconv.Atoi(match[1])
+		defined[num]++
+	}
+
+	var problems []string
+	missing := make(map[int]bool)
+	for _, loc := range reference.FindAllStringSubmatchIndex(markdown, -1) {
+		if loc[1] < len(markdown) && markdown[loc[1]] == ':' {
+			continue
+		}
+		num, _ := strconv.Atoi(markdown[loc[2]:loc[3]])
+		if defined[num] == 0 && !missing[num] {
+			missing[num] = true
+			problems = append(problems, fmt.Sprintf("[^%d] is cited but has no reference", num))
+		}
+	}
+
+	var duplicated []int
+	for num, count := range defined {
+		if count > 1 {
+			duplicated = append(duplicated, num)
+		}
+	}
+	sort.Ints(duplicated)
+	for _, num := range duplicated {
+		problems = append(problems, fmt.Sprintf("[^%d] is defined %d times", num, defined[num]))
+	}
+	return problems
+}
+
+// escapeTitle keeps brackets in a title from ending its link text early
+func escapeTitle(title string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`).Replace(title)
+}
@@ -0,0 +1,62 @@
+package footnotes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCite(t *testing.T) {
+	set := New(1)
+	set.Add("https://a.example", "Agents [beta] ship")
+	set.Add("https://b.example", "Rust 2.0")
+	if again := set.Add("https://a.example", "Other title"); again != 1 {
+		t.Errorf("expected a known URL to keep its number, got %d", again)
+	}
+
+	text := "Agents shipped [1][2], see [[2]](https://b.example) and [[7]](https://c.example). Also [9] and [[1]]."
+	got := set.Cite(text)
+	want := "Agents shipped [^1][^2], see [^2] and [^3]. Also [9] and [^1]."
+	if got != want {
+		t.Errorf("Cite = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(set.Unresolved(), []string{"[9]"}) {
+		t.Errorf("expected [9] unresolved, got %v", set.Unresolved())
+	}
+
+	references := set.References()
+	for _, line := range []string{
+		`[^1]: [Agents \[beta\] ship](https://a.example)`,
+		"[^2]: [Rust 2.0](https://b.example)",
+		"[^3]: <https://c.example>",
+	} {
+		if !strings.Contains(references, line+"\n") {
+			t.Errorf("references missing %q:\n%s", line, references)
+		}
+	}
+	if problems := Validate(got + "\n\n" + references); len(problems) != 0 {
+		t.Errorf("expected every citation to resolve, got %v", problems)
+	}
+}
+
+func TestNew_ContinuesNumbering(t *testing.T) {
+	set := New(11)
+	set.Add("https://a.example", "A")
+	if got := set.Cite("[11] and [1]"); got != "[^11] and [1]" {
+		t.Errorf("Cite = %q", got)
+	}
+	if ref := set.Ref("https://a.example"); ref != "[^11]" {
+		t.Errorf("Ref = %q", ref)
+	}
+	if ref := set.Ref("https://missing.example"); ref != "" {
+		t.Errorf("expected no ref for an unknown URL, got %q", ref)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	markdown := "One[^1][^2] and [^4].\n\n[^1]: A\n[^2]: B\n[^2]: B again\n"
+	want := []string{"[^4] is cited but has no reference", "[^2] is defined 2 times"}
+	if got := Validate(markdown); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate = %v, want %v", got, want)
+	}
+}
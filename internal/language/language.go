@@ -0,0 +1,161 @@
+// Package language guesses the language of article text and knows the function words
+// of the languages briefly reads, so keyword and theme extraction can skip them and
+// prompts can tell the model what language an article is written in.
+package language
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// English is the language summaries and digests are written in
+const English = "en"
+
+// MinDetectWords is how many words DetectText needs to make a guess
+const MinDetectWords = 20
+
+var wordPattern = regexp.MustCompile(`\p{L}+`)
+
+// detectionWords are the most frequent function words of each language, used to
+// guess which one a text is written in
+var detectionWords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "for", "with", "it", "this", "are", "on", "as", "be"},
+	"es": {"el", "la", "de", "que", "y", "en", "los", "las", "del", "por", "con", "una", "para", "es", "se"},
+	"fr": {"le", "la", "les", "de", "des", "et", "est", "une", "du", "que", "pour", "dans", "en", "sur", "pas"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "den", "ein", "eine", "zu", "von", "auf", "für", "sich"},
+	"pt": {"o", "a", "de", "que", "e", "do", "da", "em", "um", "para", "com", "não", "uma", "os", "no"},
+	"it": {"il", "di", "che", "e", "la", "per", "un", "non", "sono", "della", "gli", "una", "del", "le", "nel"},
+	"nl": {"de", "het", "een", "van", "en", "is", "dat", "niet", "met", "voor", "zijn", "ook", "op", "aan", "wordt"},
+}
+
+// extraStopwords extend the detection words into fuller stopword lists. Words that
+// are also meaningful in English tech writing (e.g. "plus", "door") are left out,
+// since one list serves mixed-language digests.
+var extraStopwords = map[string]string{
+	"en": `a an or but at by from was were has have had not its they their them he she his her
+		we our you your can will would could should may might been being these those there
+		here than then when where which while who what why how into about over after also`,
+	"es": `al lo le les su sus como más pero este esta estos estas ese esa muy sin sobre
+		también entre cuando todo todos ya hay ser fue han puede desde donde porque nos`,
+	"fr": `au aux ce cet cette ces il ils elle elles nous vous leur leurs qui son sa ses mais
+		ou avec par pas été être sont aussi comme tout tous très peut entre depuis`,
+	"de": `dem des im ins am auch als an aus bei bis durch es hat haben ich ihr ihre ist kann
+		kein keine man mehr nach noch nur oder sehr sie sind so über um uns unter vom vor wie
+		wird werden wir zum zur dass einer einen einem eines`,
+	"pt": `as ao aos das dos na nas nos se seu sua seus suas mas como mais por pelo pela foi
+		são ser tem também já muito quando entre sobre isso este esta esse essa`,
+	"it": `al alla alle ai agli dei delle dal dalla nella nelle ma anche come più si suo sua
+		suoi sue questo questa quello quella è ha hanno essere stato tra fra molto`,
+	"nl": `er om te bij naar dan als maar ze zij hij wij we hun haar deze die dit uit heeft
+		hebben worden werd kan meer nog wel al tot`,
+}
+
+// stopwords maps each language to its full stopword set
+var stopwords = buildStopwords()
+
+// anyStopword holds the stopwords of every language
+var anyStopword = func() map[string]bool {
+	all := make(map[string]bool)
+	for _, words := range stopwords {
+		for word := range words {
+			all[word] = true
+		}
+	}
+	return all
+}()
+
+var names = map[string]string{
+	"en": "English", "es": "Spanish", "fr": "French", "de": "German",
+	"pt": "Portuguese", "it": "Italian", "nl": "Dutch",
+}
+
+func buildStopwords() map[string]map[string]bool {
+	sets := make(map[string]map[string]bool, len(detectionWords))
+	for code, words := range detectionWords {
+		set := make(map[string]bool)
+		for _, word := range words {
+			set[word] = true
+		}
+		for _, word := range strings.Fields(extraStopwords[code]) {
+			set[word] = true
+		}
+		sets[code] = set
+	}
+	return sets
+}
+
+// Words splits text into its words (runs of letters)
+func Words(text string) []string {
+	return wordPattern.FindAllString(text, -1)
+}
+
+// Detect guesses the language of words from common function words. It returns ""
+// when no known language stands out.
+func Detect(words []string) string {
+	counts := make(map[string]int)
+	for _, word := range words {
+		word = strings.ToLower(word)
+		for language, function := range detectionWords {
+			for _, fw := range function {
+				if word == fw {
+					counts[language]++
+					break
+				}
+			}
+		}
+	}
+
+	best, bestCount, runnerUp := "", 0, 0
+	for language, count := range counts {
+		if count > bestCount || (count == bestCount && language < best) {
+			best, bestCount, runnerUp = language, count, bestCount
+		} else if count > runnerUp {
+			runnerUp = count
+		}
+	}
+
+	// Function words make up a large share of running text; require a clear winner
+	if float64(bestCount) < 0.08*float64(len(words)) || bestCount < 2*runnerUp {
+		return ""
+	}
+	return best
+}
+
+// DetectText guesses the language of text, or returns "" when it is too short to
+// tell or no known language stands out
+func DetectText(text string) string {
+	words := Words(text)
+	if len(words) < MinDetectWords {
+		return ""
+	}
+	return Detect(words)
+}
+
+// IsStopword reports whether word is a function word in language, or in any known
+// language when language is ""
+func IsStopword(word, language string) bool {
+	word = strings.ToLower(word)
+	if language == "" {
+		return anyStopword[word]
+	}
+	return stopwords[language][word]
+}
+
+// Name returns the English name of a language code, or the code itself if unknown
+func Name(code string) string {
+	if name, ok := names[strings.ToLower(code)]; ok {
+		return name
+	}
+	return code
+}
+
+// PromptNote returns a line telling the model that text is not in English and its
+// answer should be, or "" for English text and text whose language is unknown
+func PromptNote(text string) string {
+	detected := DetectText(text)
+	if detected == "" || detected == English {
+		return ""
+	}
+	return fmt.Sprintf("LANGUAGE: The article is written in %s. Write your answer in English: translate quotes and terms, but keep names of people, products, and organizations as written.", Name(detected))
+}
@@ -0,0 +1,58 @@
+package language
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectText(t *testing.T) {
+	tests := map[string]string{
+		"The team shipped the new scheduler this week, and it is faster than the old one for most of the workloads that we tested in production.":                              "en",
+		"El equipo lanzó el nuevo planificador esta semana y es más rápido que el anterior para la mayoría de las cargas de trabajo que probamos en producción.":               "es",
+		"Das Team hat diese Woche den neuen Scheduler veröffentlicht, und er ist für die meisten Workloads, die wir in der Produktion getestet haben, schneller als der alte.": "de",
+		"L'équipe a livré le nouveau planificateur cette semaine, et il est plus rapide que l'ancien pour la plupart des charges que nous avons testées dans la production.":   "fr",
+		"Kubernetes Docker Terraform Grafana Prometheus Envoy Istio": "",
+	}
+	for text, want := range tests {
+		if got := DetectText(strings.Repeat(text+" ", 2)); got != want {
+			t.Errorf("DetectText(%.40q) = %q, want %q", text, got, want)
+		}
+	}
+
+	if got := DetectText("Das ist gut"); got != "" {
+		t.Errorf("expected no guess for a short text, got %q", got)
+	}
+}
+
+func TestIsStopword(t *testing.T) {
+	for _, word := range []string{"The", "und", "dass", "cette", "também", "della", "het"} {
+		if !IsStopword(word, "") {
+			t.Errorf("expected %q to be a stopword in some language", word)
+		}
+	}
+	if IsStopword("und", "en") || !IsStopword("und", "de") {
+		t.Error("expected per-language lists to stay separate")
+	}
+	for _, word := range []string{"kubernetes", "scheduler", "plus", "door"} {
+		if IsStopword(word, "") {
+			t.Errorf("expected %q kept as a content word", word)
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	if Name("de") != "German" || Name("xx") != "xx" {
+		t.Errorf("unexpected names %q %q", Name("de"), Name("xx"))
+	}
+}
+
+func TestPromptNote(t *testing.T) {
+	german := strings.Repeat("Das Team hat diese Woche den neuen Scheduler veröffentlicht, und er ist schneller als der alte. ", 3)
+	if note := PromptNote(german); !strings.Contains(note, "German") || !strings.Contains(note, "in English") {
+		t.Errorf("expected a note naming German, got %q", note)
+	}
+	english := strings.Repeat("The team shipped the new scheduler this week, and it is faster than the old one. ", 3)
+	if note := PromptNote(english); note != "" {
+		t.Errorf("expected no note for English, got %q", note)
+	}
+}
@@ -0,0 +1,198 @@
+// Package licensing keeps redistributed digests within publishers' reuse policies:
+// it caps how many words are quoted from each article (per domain, configured under
+// licensing.domains) and writes the "summarized from" line crediting each source.
+package licensing
+
+import (
+	"briefly/internal/core"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Removed stands in for a quote dropped because its article's quota is used up
+const Removed = "[excerpt removed]"
+
+// Policy limits quoting from one domain and its subdomains
+type Policy struct {
+	Domain        string
+	MaxQuoteWords int    // 0 = no limit
+	Publisher     string // Name used in the attribution line (default: the article's site name)
+}
+
+// Policies looks up the quoting policy for an article's URL
+type Policies struct {
+	domains     []Policy
+	defaultMax  int
+	attribution bool
+}
+
+// New returns the policies for domains. defaultMax limits quoting from domains
+// that aren't listed (0 = no limit); attribution credits every article rather than
+// only those from listed domains.
+func New(domains []Policy, defaultMax int, attribution bool) *Policies {
+	normalized := make([]Policy, 0, len(domains))
+	for _, policy := range domains {
+		policy.Domain = normalizeHost(policy.Domain)
+		if policy.Domain != "" {
+			normalized = append(normalized, policy)
+		}
+	}
+	return &Policies{domains: normalized, defaultMax: defaultMax, attribution: attribution}
+}
+
+// Lookup returns the policy of the most specific listed domain matching the URL's
+// host, and false when the domain isn't listed
+func (p *Policies) Lookup(articleURL string) (Policy, bool) {
+	if p == nil {
+		return Policy{}, false
+	}
+	host := hostOf(articleURL)
+	best, found := Policy{}, false
+	for _, policy := range p.domains {
+		if host != policy.Domain && !strings.HasSuffix(host, "."+policy.Domain) {
+			continue
+		}
+		if !found || len(policy.Domain) > len(best.Domain) {
+			best, found = policy, true
+		}
+	}
+	return best, found
+}
+
+// MaxQuoteWords returns how many words may be quoted from the article (0 = no limit)
+func (p *Policies) MaxQuoteWords(articleURL string) int {
+	if p == nil {
+		return 0
+	}
+	if policy, ok := p.Lookup(articleURL); ok {
+		return policy.MaxQuoteWords
+	}
+	return p.defaultMax
+}
+
+// Attribution returns the line crediting the article's source, such as
+// "*Summarized from [The New York Times](https://...)*", or "" when the article
+// isn't from a listed domain and attribution isn't on for every article
+func (p *Policies) Attribution(article core.Article) string {
+	if p == nil || article.URL == "" {
+		return ""
+	}
+	policy, listed := p.Lookup(article.URL)
+	if !listed && !p.attribution {
+		return ""
+	}
+
+	publisher := policy.Publisher
+	if publisher == "" {
+		publisher = article.SiteName
+	}
+	if publisher == "" {
+		publisher = article.Publisher
+	}
+	if publisher == "" {
+		publisher = hostOf(article.URL)
+	}
+	return fmt.Sprintf("*Summarized from [%s](%s)*", publisher, article.URL)
+}
+
+// Quota tracks the words quoted from each article across a digest, so the limit
+// covers an article's summary and its key moments together
+type Quota struct {
+	policies *Policies
+	used     map[string]int
+}
+
+// NewQuota starts counting quoted words for one digest
+func (p *Policies) NewQuota() *Quota {
+	return &Quota{policies: p, used: make(map[string]int)}
+}
+
+// Attribution credits the article's source under the quota's policies
+func (q *Quota) Attribution(article core.Article) string {
+	if q == nil {
+		return ""
+	}
+	return q.policies.Attribution(article)
+}
+
+// Excerpt returns as much of quote as the article's remaining quota allows, cut
+// with "…" when shortened, and false when the quota is already used up
+func (q *Quota) Excerpt(articleURL, quote string) (string, bool) {
+	if q == nil {
+		return quote, true
+	}
+	limit := q.policies.MaxQuoteWords(articleURL)
+	if limit <= 0 {
+		return quote, true
+	}
+
+	words := strings.Fields(quote)
+	remaining := limit - q.used[articleURL]
+	if remaining <= 0 {
+		return "", false
+	}
+	if len(words) <= remaining {
+		q.used[articleURL] += len(words)
+		return quote, true
+	}
+	q.used[articleURL] = limit
+	return strings.Join(words[:remaining], " ") + "…", true
+}
+
+// inlineQuote matches a quotation within a line, in straight or curly quotes
+var inlineQuote = regexp.MustCompile(`"([^"\n]+)"|“([^”\n]+)”`)
+
+// Limit enforces the article's quota on the quotations in text: inline quotes and
+// "> " blockquote lines. Quotes past the quota are cut short; once it is used up,
+// inline quotes become Removed and blockquote lines are dropped.
+func (q *Quota) Limit(articleURL, text string) string {
+	if q == nil || q.policies.MaxQuoteWords(articleURL) <= 0 {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, ">") {
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			if quote == "" {
+				kept = append(kept, line)
+				continue
+			}
+			if excerpt, ok := q.Excerpt(articleURL, quote); ok {
+				kept = append(kept, "> "+excerpt)
+			}
+			continue
+		}
+
+		kept = append(kept, inlineQuote.ReplaceAllStringFunc(line, func(match string) string {
+			opening, closing := match[:1], match[len(match)-1:]
+			if strings.HasPrefix(match, "“") {
+				opening, closing = "“", "”"
+			}
+			excerpt, ok := q.Excerpt(articleURL, strings.TrimSuffix(strings.TrimPrefix(match, opening), closing))
+			if !ok {
+				return Removed
+			}
+			return opening + excerpt + closing
+		}))
+	}
+	return strings.Join(kept, "\n")
+}
+
+// hostOf returns the URL's host without "www.", lowercased
+func hostOf(articleURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(articleURL))
+	if err != nil {
+		return ""
+	}
+	return normalizeHost(parsed.Hostname())
+}
+
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	host = strings.TrimSuffix(host, ".")
+	return strings.TrimPrefix(host, "www.")
+}
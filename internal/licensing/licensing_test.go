@@ -0,0 +1,89 @@
+package licensing
+
+import (
+	"briefly/internal/core"
+	"testing"
+)
+
+func testPolicies(attribution bool) *Policies {
+	return New([]Policy{
+		{Domain: "nytimes.com", MaxQuoteWords: 5, Publisher: "The New York Times"},
+		{Domain: "cooking.nytimes.com", MaxQuoteWords: 2},
+		{Domain: "openlicense.org"},
+	}, 10, attribution)
+}
+
+func TestLookup(t *testing.T) {
+	policies := testPolicies(false)
+	tests := []struct {
+		url  string
+		max  int
+		list bool
+	}{
+		{"https://www.nytimes.com/2026/10/17/tech/ai.html", 5, true},
+		{"https://cooking.nytimes.com/recipes/1", 2, true},
+		{"https://notnytimes.com/a", 10, false},
+		{"https://openlicense.org/post", 0, true},
+		{"not a url", 10, false},
+	}
+	for _, tt := range tests {
+		_, listed := policies.Lookup(tt.url)
+		if got := policies.MaxQuoteWords(tt.url); got != tt.max || listed != tt.list {
+			t.Errorf("%s: got limit %d listed %v, want %d %v", tt.url, got, listed, tt.max, tt.list)
+		}
+	}
+
+	var none *Policies
+	if none.MaxQuoteWords("https://nytimes.com") != 0 || none.Attribution(core.Article{URL: "https://nytimes.com"}) != "" {
+		t.Error("expected nil policies to allow everything")
+	}
+}
+
+func TestAttribution(t *testing.T) {
+	nyt := core.Article{URL: "https://www.nytimes.com/a", SiteName: "NYT"}
+	other := core.Article{URL: "https://blog.example.com/post", SiteName: ""}
+
+	policies := testPolicies(false)
+	if got := policies.Attribution(nyt); got != "*Summarized from [The New York Times](https://www.nytimes.com/a)*" {
+		t.Errorf("unexpected attribution %q", got)
+	}
+	if got := policies.Attribution(other); got != "" {
+		t.Errorf("expected no attribution for unlisted domains, got %q", got)
+	}
+	if got := testPolicies(true).Attribution(other); got != "*Summarized from [blog.example.com](https://blog.example.com/post)*" {
+		t.Errorf("expected the host credited when attributing every article, got %q", got)
+	}
+}
+
+func TestQuotaLimit(t *testing.T) {
+	quota := testPolicies(false).NewQuota()
+	url := "https://nytimes.com/a"
+
+	summary := "The chief said \"we will ship it this year\" and later “nothing more to add today”."
+	want := "The chief said \"we will ship it this…\" and later [excerpt removed]."
+	if got := quota.Limit(url, summary); got != want {
+		t.Errorf("Limit =\n%q\nwant\n%q", got, want)
+	}
+
+	// The quota is shared with the article's other quotes
+	if _, ok := quota.Excerpt(url, "one more"); ok {
+		t.Error("expected the article's quota to be used up")
+	}
+	if got := quota.Limit(url, "Intro\n> Quoted at length\nOutro"); got != "Intro\nOutro" {
+		t.Errorf("expected the blockquote dropped, got %q", got)
+	}
+
+	// Other articles have their own quota
+	if got, ok := quota.Excerpt("https://nytimes.com/b", "short quote"); !ok || got != "short quote" {
+		t.Errorf("expected a fresh quota per article, got %q, %v", got, ok)
+	}
+	unlimited := "\"a quote well over the default limit of ten words, kept whole\""
+	if got := quota.Limit("https://openlicense.org/post", unlimited); got != unlimited {
+		t.Errorf("expected no limit for a domain listed without one, got %q", got)
+	}
+
+	var none *Quota
+	if got := none.Limit(url, summary); got != summary {
+		t.Error("expected a nil quota to leave text unchanged")
+	}
+}
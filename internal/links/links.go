@@ -0,0 +1,249 @@
+// Package links rewrites outbound article links in shared digests so clicks
+// can be counted, either through a self-hosted redirector (/r/{code} on the
+// briefly server) or an external short-link service.
+package links
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/persistence"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// codeLength is the number of base62 characters in a redirector short code
+const codeLength = 8
+
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Shortener turns a destination URL into a trackable short URL
+type Shortener interface {
+	Shorten(ctx context.Context, url string, digestID string) (string, error)
+}
+
+// Redirector creates short links served by the briefly server's /r/{code} route
+type Redirector struct {
+	repo    persistence.LinkRepository
+	baseURL string
+}
+
+// NewRedirector creates a self-hosted redirector shortener
+func NewRedirector(repo persistence.LinkRepository, baseURL string) *Redirector {
+	return &Redirector{
+		repo:    repo,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Shorten stores the link and returns {baseURL}/r/{code}. Codes are derived from
+// the URL and digest, so re-running a digest reuses the same short links.
+func (r *Redirector) Shorten(ctx context.Context, url string, digestID string) (string, error) {
+	code := Code(url, digestID)
+
+	link := &core.TrackedLink{
+		Code: code,
+		URL:  url,
+	}
+	if digestID != "" {
+		link.DigestID = &digestID
+	}
+
+	if err := r.repo.Create(ctx, link); err != nil {
+		return "", fmt.Errorf("failed to store tracked link: %w", err)
+	}
+
+	return r.baseURL + "/r/" + code, nil
+}
+
+// Code returns the deterministic short code for a URL shared in a digest
+func Code(url string, digestID string) string {
+	sum := sha1.Sum([]byte(url + "|" + digestID))
+	n := new(big.Int).SetBytes(sum[:])
+
+	base := big.NewInt(int64(len(base62Alphabet)))
+	mod := new(big.Int)
+	code := make([]byte, codeLength)
+	for i := range code {
+		n.DivMod(n, base, mod)
+		code[i] = base62Alphabet[mod.Int64()]
+	}
+
+	return string(code)
+}
+
+// ServiceShortener creates short links through an external shortening API.
+// The service receives {"url": "..."} and must return the short URL as
+// short_url, shortUrl, or link in its JSON response.
+type ServiceShortener struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// NewServiceShortener creates a shortener backed by an external service
+func NewServiceShortener(endpoint, apiKey string) *ServiceShortener {
+	return &ServiceShortener{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Shorten requests a short URL from the external service
+func (s *ServiceShortener) Shorten(ctx context.Context, url string, digestID string) (string, error) {
+	payload := map[string]string{"url": url}
+	if digestID != "" {
+		payload["tag"] = digestID
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode shortener request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create shortener request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("shortener request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("shortener returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		ShortURL      string `json:"short_url"`
+		ShortURLCamel string `json:"shortUrl"`
+		Link          string `json:"link"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode shortener response: %w", err)
+	}
+
+	for _, short := range []string{result.ShortURL, result.ShortURLCamel, result.Link} {
+		if short != "" {
+			return short, nil
+		}
+	}
+	return "", fmt.Errorf("shortener response did not include a short URL")
+}
+
+// NewFromConfig builds the shortener selected by link_tracking.mode.
+// The redirector mode needs a database to store codes; service mode does not.
+func NewFromConfig(cfg config.LinkTracking, db persistence.Database) (Shortener, error) {
+	switch cfg.Mode {
+	case "", "redirector":
+		if db == nil {
+			return nil, fmt.Errorf("redirector link tracking requires a database")
+		}
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("link_tracking.base_url is required for redirector mode")
+		}
+		return NewRedirector(db.Links(), cfg.BaseURL), nil
+	case "service":
+		if cfg.ServiceURL == "" {
+			return nil, fmt.Errorf("link_tracking.service_url is required for service mode")
+		}
+		return NewServiceShortener(cfg.ServiceURL, cfg.APIKey), nil
+	default:
+		return nil, fmt.Errorf("unknown link_tracking.mode: %s", cfg.Mode)
+	}
+}
+
+// urlPattern matches http(s) URLs in markdown and Slack mrkdwn, stopping at
+// characters that close a link (>, |, ]) or whitespace. Parentheses are matched too,
+// and balanced afterwards by trimUnbalanced.
+var urlPattern = regexp.MustCompile(`https?://[^\s>|\]"]+`)
+
+// RewriteLinks replaces every http(s) URL in content with its short URL. Image
+// sources (![alt](url)) are left alone, as are URLs already pointing at skipPrefix
+// (e.g. the redirector itself). Returns the rewritten content and the number of
+// distinct links shortened.
+func RewriteLinks(ctx context.Context, content string, digestID string, skipPrefix string, s Shortener) (string, int, error) {
+	shortened := make(map[string]string)
+	var firstErr error
+
+	var rewritten strings.Builder
+	last := 0
+	for _, loc := range urlPattern.FindAllStringIndex(content, -1) {
+		// A ")" the URL didn't open closes the markdown link around it, and trailing
+		// sentence punctuation is not part of the URL either
+		url := strings.TrimRight(trimUnbalanced(content[loc[0]:loc[1]]), ".,;:!?")
+		if firstErr != nil || isImageSource(content, loc[0]) || (skipPrefix != "" && strings.HasPrefix(url, skipPrefix)) {
+			continue
+		}
+
+		short, ok := shortened[url]
+		if !ok {
+			var err error
+			if short, err = s.Shorten(ctx, url, digestID); err != nil {
+				firstErr = err
+				continue
+			}
+			shortened[url] = short
+		}
+		rewritten.WriteString(content[last:loc[0]])
+		rewritten.WriteString(short)
+		last = loc[0] + len(url)
+	}
+	rewritten.WriteString(content[last:])
+
+	if firstErr != nil {
+		return content, 0, firstErr
+	}
+	return rewritten.String(), len(shortened), nil
+}
+
+// trimUnbalanced cuts url at the first ")" that closes no "(" within it, so
+// https://en.wikipedia.org/wiki/Go_(language) survives inside [text](url)
+func trimUnbalanced(url string) string {
+	depth := 0
+	for i, c := range url {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return url[:i]
+			}
+			depth--
+		}
+	}
+	return url
+}
+
+// isImageSource reports whether the URL starting at start is the source of a
+// markdown image, ![alt](url), rather than a link's target
+func isImageSource(content string, start int) bool {
+	if start < 2 || content[start-2:start] != "](" {
+		return false
+	}
+	depth := 0
+	for i := start - 2; i >= 0; i-- {
+		switch content[i] {
+		case ']':
+			depth++
+		case '[':
+			if depth--; depth == 0 {
+				return i > 0 && content[i-1] == '!'
+			}
+		}
+	}
+	return false
+}
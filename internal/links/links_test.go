@@ -0,0 +1,167 @@
+package links
+
+import (
+	"briefly/internal/core"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeShortener maps URLs to predictable short links and counts calls
+type fakeShortener struct {
+	calls int
+}
+
+func (f *fakeShortener) Shorten(ctx context.Context, url string, digestID string) (string, error) {
+	f.calls++
+	return "https://brief.ly/r/" + Code(url, digestID), nil
+}
+
+func TestRewriteLinks(t *testing.T) {
+	content := `# Digest
+
+See [Claude release](https://example.com/claude) and <https://example.com/gemini|Gemini>.
+Again: https://example.com/claude.
+Already tracked: https://brief.ly/r/abc12345`
+
+	s := &fakeShortener{}
+	out, n, err := RewriteLinks(context.Background(), content, "d1", "https://brief.ly/", s)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if n != 2 {
+		t.Errorf("Expected 2 distinct links shortened, got %d", n)
+	}
+	if s.calls != 2 {
+		t.Errorf("Expected repeated URLs to be cached (2 calls), got %d", s.calls)
+	}
+	if strings.Contains(out, "example.com") {
+		t.Errorf("Expected all article links rewritten, got:\n%s", out)
+	}
+	if !strings.Contains(out, "https://brief.ly/r/abc12345") {
+		t.Error("Expected existing tracked link to be left alone")
+	}
+
+	claude := "https://brief.ly/r/" + Code("https://example.com/claude", "d1")
+	if !strings.Contains(out, "("+claude+")") {
+		t.Errorf("Expected markdown link target rewritten to %s", claude)
+	}
+	if !strings.Contains(out, claude+".") {
+		t.Error("Expected trailing period to be preserved outside the URL")
+	}
+	if !strings.Contains(out, "|Gemini>") {
+		t.Error("Expected Slack link label to be preserved")
+	}
+}
+
+func TestRewriteLinks_ImagesAndParentheses(t *testing.T) {
+	content := `![Chart](https://example.com/chart.png)
+[![Logo](https://example.com/logo.png)](https://example.com/home)
+Read [Go](https://en.wikipedia.org/wiki/Go_(language)) (or https://example.com/faq).`
+
+	out, n, err := RewriteLinks(context.Background(), content, "d1", "", &fakeShortener{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 links shortened, got %d:\n%s", n, out)
+	}
+	if !strings.Contains(out, "(https://example.com/chart.png)") || !strings.Contains(out, "(https://example.com/logo.png)") {
+		t.Errorf("Expected image sources left alone, got:\n%s", out)
+	}
+	for _, url := range []string{"https://example.com/home", "https://en.wikipedia.org/wiki/Go_(language)", "https://example.com/faq"} {
+		short := "https://brief.ly/r/" + Code(url, "d1")
+		if !strings.Contains(out, short+")") {
+			t.Errorf("Expected %s rewritten to %s, got:\n%s", url, short, out)
+		}
+	}
+}
+
+func TestCode_Deterministic(t *testing.T) {
+	a := Code("https://example.com/a", "d1")
+	if len(a) != codeLength {
+		t.Errorf("Expected code length %d, got %d", codeLength, len(a))
+	}
+	if a != Code("https://example.com/a", "d1") {
+		t.Error("Expected the same URL and digest to produce the same code")
+	}
+	if a == Code("https://example.com/a", "d2") {
+		t.Error("Expected different digests to produce different codes")
+	}
+}
+
+// memoryLinkRepo is an in-memory LinkRepository for tests
+type memoryLinkRepo struct {
+	links map[string]*core.TrackedLink
+}
+
+func (m *memoryLinkRepo) Create(ctx context.Context, link *core.TrackedLink) error {
+	if _, ok := m.links[link.Code]; !ok {
+		m.links[link.Code] = link
+	}
+	return nil
+}
+
+func (m *memoryLinkRepo) GetByCode(ctx context.Context, code string) (*core.TrackedLink, error) {
+	return m.links[code], nil
+}
+
+func (m *memoryLinkRepo) RecordClick(ctx context.Context, code, referer, userAgent string) error {
+	return nil
+}
+
+func (m *memoryLinkRepo) ClickStats(ctx context.Context, since time.Time, digestID string, limit int) ([]core.TrackedLink, error) {
+	return nil, nil
+}
+
+func TestRedirector_Shorten(t *testing.T) {
+	repo := &memoryLinkRepo{links: make(map[string]*core.TrackedLink)}
+	r := NewRedirector(repo, "https://briefly.example.com/")
+
+	short, err := r.Shorten(context.Background(), "https://example.com/a", "d1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	code := Code("https://example.com/a", "d1")
+	if short != "https://briefly.example.com/r/"+code {
+		t.Errorf("Unexpected short URL: %s", short)
+	}
+
+	stored := repo.links[code]
+	if stored == nil || stored.URL != "https://example.com/a" || stored.DigestID == nil || *stored.DigestID != "d1" {
+		t.Errorf("Expected link to be stored with URL and digest, got %+v", stored)
+	}
+}
+
+func TestServiceShortener_Shorten(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(map[string]string{"shortUrl": "https://sho.rt/x?u=" + req["url"]})
+	}))
+	defer srv.Close()
+
+	s := NewServiceShortener(srv.URL, "secret")
+	short, err := s.Shorten(context.Background(), "https://example.com/a", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if short != "https://sho.rt/x?u=https://example.com/a" {
+		t.Errorf("Unexpected short URL: %s", short)
+	}
+
+	bad := NewServiceShortener(srv.URL, "wrong")
+	if _, err := bad.Shorten(context.Background(), "https://example.com/a", ""); err == nil {
+		t.Error("Expected error for non-2xx response")
+	}
+}
@@ -0,0 +1,261 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+const (
+	// DefaultBatchPollInterval is the initial delay between batch job status checks
+	DefaultBatchPollInterval = 30 * time.Second
+
+	// maxBatchPollInterval caps the poll backoff so finished jobs are noticed promptly
+	maxBatchPollInterval = 5 * time.Minute
+
+	// DefaultBatchTimeout matches Gemini's 24-hour batch turnaround target
+	DefaultBatchTimeout = 24 * time.Hour
+
+	// maxBatchInlineBytes keeps each job under the 20MB inline request limit
+	maxBatchInlineBytes = 15 << 20
+
+	// batchSubmitRetries is how many times a rate-limited job submission is retried
+	batchSubmitRetries = 3
+)
+
+// BatchRequest is a single prompt submitted as part of a batch job
+type BatchRequest struct {
+	Prompt  string
+	Options TextGenerationOptions
+}
+
+// BatchResult is the outcome of one BatchRequest. Err is set when that request failed;
+// other requests in the same batch are unaffected.
+type BatchResult struct {
+	Text string
+	Err  error
+}
+
+// BatchOptions controls batch submission and polling
+type BatchOptions struct {
+	DisplayName  string        // Shown in the Gemini console; defaults to "briefly-batch"
+	PollInterval time.Duration // Initial poll delay (default: DefaultBatchPollInterval), doubles up to 5 minutes
+	Timeout      time.Duration // Give up waiting after this long (default: DefaultBatchTimeout)
+
+	// OnPoll, when set, is called after every status check
+	OnPoll func(job string, state genai.JobState, elapsed time.Duration)
+}
+
+// GenerateTextBatch runs requests through the Gemini Batch API, which is billed at a
+// discount but may take hours to complete. Requests are split into jobs that fit the
+// inline size limit, rate-limited submissions are retried with backoff, and the call
+// blocks until every job finishes. Results are returned in request order.
+func (c *Client) GenerateTextBatch(ctx context.Context, requests []BatchRequest, opts BatchOptions) ([]BatchResult, error) {
+	results := make([]BatchResult, len(requests))
+
+	if c.offline {
+		for i, req := range requests {
+			results[i].Text, results[i].Err = c.GenerateText(ctx, req.Prompt, req.Options)
+		}
+		return results, nil
+	}
+
+	if opts.DisplayName == "" {
+		opts.DisplayName = "briefly-batch"
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultBatchPollInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultBatchTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	// Submit every chunk up front so the jobs run concurrently on the server
+	type submittedJob struct {
+		name       string
+		start, end int
+	}
+	var jobs []submittedJob
+
+	chunks := chunkBatchRequests(requests, maxBatchInlineBytes)
+	for i, chunk := range chunks {
+		name := opts.DisplayName
+		if len(chunks) > 1 {
+			name = fmt.Sprintf("%s-%d", opts.DisplayName, i+1)
+		}
+
+		job, err := c.submitBatchJob(ctx, name, requests[chunk[0]:chunk[1]])
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, submittedJob{name: job.Name, start: chunk[0], end: chunk[1]})
+	}
+
+	for _, job := range jobs {
+		finished, err := c.waitForBatchJob(ctx, job.name, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		var responses []*genai.InlinedResponse
+		if finished.Dest != nil {
+			responses = finished.Dest.InlinedResponses
+		}
+
+		// Inlined responses come back in request order
+		for i := job.start; i < job.end; i++ {
+			if i-job.start < len(responses) {
+				results[i] = batchResultFromResponse(responses[i-job.start])
+				results[i].Text = restoreText(results[i].Text, requests[i].Options.ResponseSchema != nil)
+			} else {
+				results[i].Err = fmt.Errorf("batch job %s returned no response for this request", job.name)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// submitBatchJob creates one batch job, backing off when the API reports rate limiting
+func (c *Client) submitBatchJob(ctx context.Context, displayName string, requests []BatchRequest) (*genai.BatchJob, error) {
+	inlined := make([]*genai.InlinedRequest, len(requests))
+	for i, req := range requests {
+		inlined[i] = &genai.InlinedRequest{
+			Contents: redactContents([]*genai.Content{{
+				Parts: []*genai.Part{{Text: req.Prompt}},
+				Role:  "user",
+			}}),
+			Config: c.pinSampling(generationConfig(req.Options)),
+		}
+	}
+
+	src := &genai.BatchJobSource{InlinedRequests: inlined}
+	config := &genai.CreateBatchJobConfig{DisplayName: displayName}
+
+	backoff := time.Minute
+	for attempt := 0; ; attempt++ {
+		job, err := c.gClient.Batches.Create(ctx, c.modelFor(ctx, ""), src, config)
+		if err == nil {
+			return job, nil
+		}
+
+		var apiErr genai.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusTooManyRequests || attempt >= batchSubmitRetries {
+			return nil, fmt.Errorf("failed to create batch job: %w", err)
+		}
+
+		log.Printf("[WARN] Batch submission rate limited, retrying in %s", backoff)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to create batch job: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// waitForBatchJob polls a batch job with exponential backoff until it reaches a final state
+func (c *Client) waitForBatchJob(ctx context.Context, name string, opts BatchOptions) (*genai.BatchJob, error) {
+	start := time.Now()
+	interval := opts.PollInterval
+
+	for {
+		job, err := c.gClient.Batches.Get(ctx, name, nil)
+		if err != nil {
+			var apiErr genai.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != http.StatusTooManyRequests {
+				return nil, fmt.Errorf("failed to get batch job %s: %w", name, err)
+			}
+			// Rate-limited status check: keep the job, just wait longer
+		} else {
+			if opts.OnPoll != nil {
+				opts.OnPoll(name, job.State, time.Since(start))
+			}
+
+			switch job.State {
+			case genai.JobStateSucceeded:
+				return job, nil
+			case genai.JobStateFailed, genai.JobStateCancelled, genai.JobStateExpired:
+				if job.Error != nil && job.Error.Message != "" {
+					return nil, fmt.Errorf("batch job %s ended in state %s: %s", name, job.State, job.Error.Message)
+				}
+				return nil, fmt.Errorf("batch job %s ended in state %s", name, job.State)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("gave up waiting for batch job %s: %w", name, ctx.Err())
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > maxBatchPollInterval {
+			interval = maxBatchPollInterval
+		}
+	}
+}
+
+// generationConfig converts TextGenerationOptions into a request config, or nil when
+// no option is set
+func generationConfig(options TextGenerationOptions) *genai.GenerateContentConfig {
+	if options.MaxTokens <= 0 && options.Temperature <= 0 && options.ResponseSchema == nil {
+		return nil
+	}
+
+	config := &genai.GenerateContentConfig{}
+	if options.MaxTokens > 0 {
+		config.MaxOutputTokens = int32(options.MaxTokens)
+	}
+	if options.Temperature > 0 {
+		temp := float32(options.Temperature)
+		config.Temperature = &temp
+	}
+	if options.ResponseSchema != nil {
+		config.ResponseMIMEType = "application/json"
+		config.ResponseSchema = options.ResponseSchema
+	}
+	return config
+}
+
+// batchResultFromResponse converts one inlined batch response into a BatchResult
+func batchResultFromResponse(resp *genai.InlinedResponse) BatchResult {
+	if resp == nil {
+		return BatchResult{Err: fmt.Errorf("missing batch response")}
+	}
+	if resp.Error != nil {
+		return BatchResult{Err: fmt.Errorf("batch request failed: %s", resp.Error.Message)}
+	}
+	if resp.Response == nil || resp.Response.Text() == "" {
+		return BatchResult{Err: fmt.Errorf("empty response from LLM")}
+	}
+	return BatchResult{Text: resp.Response.Text()}
+}
+
+// chunkBatchRequests splits requests into [start, end) ranges whose prompts total at
+// most maxBytes. A single oversized prompt still gets its own chunk.
+func chunkBatchRequests(requests []BatchRequest, maxBytes int) [][2]int {
+	var chunks [][2]int
+	start, size := 0, 0
+
+	for i, req := range requests {
+		if i > start && size+len(req.Prompt) > maxBytes {
+			chunks = append(chunks, [2]int{start, i})
+			start, size = i, 0
+		}
+		size += len(req.Prompt)
+	}
+	if start < len(requests) {
+		chunks = append(chunks, [2]int{start, len(requests)})
+	}
+
+	return chunks
+}
@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestChunkBatchRequests(t *testing.T) {
+	requests := []BatchRequest{
+		{Prompt: strings.Repeat("a", 40)},
+		{Prompt: strings.Repeat("b", 40)},
+		{Prompt: strings.Repeat("c", 150)}, // Larger than the limit on its own
+		{Prompt: strings.Repeat("d", 10)},
+	}
+
+	chunks := chunkBatchRequests(requests, 100)
+
+	expected := [][2]int{{0, 2}, {2, 3}, {3, 4}}
+	if len(chunks) != len(expected) {
+		t.Fatalf("Expected %d chunks, got %v", len(expected), chunks)
+	}
+	for i := range expected {
+		if chunks[i] != expected[i] {
+			t.Errorf("Chunk %d: expected %v, got %v", i, expected[i], chunks[i])
+		}
+	}
+
+	if chunks := chunkBatchRequests(nil, 100); len(chunks) != 0 {
+		t.Errorf("Expected no chunks for no requests, got %v", chunks)
+	}
+}
+
+func TestBatchResultFromResponse(t *testing.T) {
+	ok := batchResultFromResponse(&genai.InlinedResponse{
+		Response: &genai.GenerateContentResponse{
+			Candidates: []*genai.Candidate{{Content: &genai.Content{Parts: []*genai.Part{{Text: "summary"}}}}},
+		},
+	})
+	if ok.Err != nil || ok.Text != "summary" {
+		t.Errorf("Expected text result, got %+v", ok)
+	}
+
+	failed := batchResultFromResponse(&genai.InlinedResponse{Error: &genai.JobError{Message: "quota"}})
+	if failed.Err == nil || !strings.Contains(failed.Err.Error(), "quota") {
+		t.Errorf("Expected request error, got %+v", failed)
+	}
+
+	if empty := batchResultFromResponse(&genai.InlinedResponse{}); empty.Err == nil {
+		t.Error("Expected error for empty response")
+	}
+}
+
+func TestGenerateTextBatch_Offline(t *testing.T) {
+	client := NewOfflineClient()
+	requests := []BatchRequest{
+		{Prompt: "Article Content:\n" + offlineTestArticle},
+		{Prompt: "Article Content:\n" + offlineTestArticle, Options: TextGenerationOptions{ResponseSchema: FormatSummarySchema()}},
+	}
+
+	results, err := client.GenerateTextBatch(context.Background(), requests, BatchOptions{})
+	if err != nil {
+		t.Fatalf("GenerateTextBatch failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if _, _, err := parseFormatSummary(results[1].Text); err != nil {
+		t.Errorf("Expected schema request to return schema JSON: %v", err)
+	}
+}
+
+func TestPinSampling(t *testing.T) {
+	client := &Client{}
+	options := TextGenerationOptions{Temperature: 0.7, MaxTokens: 100}
+	if config := client.pinSampling(generationConfig(options)); config.Seed != nil || *config.Temperature != 0.7 {
+		t.Errorf("expected sampling untouched by default, got %+v", config)
+	}
+
+	client.SetDeterministic(true)
+	original := generationConfig(options)
+	config := client.pinSampling(original)
+	if *config.Temperature != 0 || config.Seed == nil || *config.Seed != DeterministicSeed || config.MaxOutputTokens != 100 {
+		t.Errorf("expected temperature 0 and the fixed seed, got %+v", config)
+	}
+	if *original.Temperature != 0.7 {
+		t.Error("expected the caller's config left unchanged")
+	}
+	if config := client.pinSampling(nil); config == nil || *config.Temperature != 0 {
+		t.Errorf("expected a pinned config for calls without one, got %+v", config)
+	}
+}
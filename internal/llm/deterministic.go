@@ -0,0 +1,32 @@
+package llm
+
+import "google.golang.org/genai"
+
+// DeterministicSeed is the sampling seed of clients in deterministic mode
+const DeterministicSeed int32 = 42
+
+// SetDeterministic pins sampling for reproducible runs: every text call uses
+// temperature 0 and DeterministicSeed, overriding the caller's temperature
+func (c *Client) SetDeterministic(enabled bool) {
+	c.deterministic = enabled
+}
+
+// IsDeterministic reports whether the client pins sampling (see SetDeterministic)
+func (c *Client) IsDeterministic() bool {
+	return c.deterministic
+}
+
+// pinSampling returns config with temperature 0 and the fixed seed when the client is
+// deterministic, and config unchanged otherwise. The caller's config isn't modified.
+func (c *Client) pinSampling(config *genai.GenerateContentConfig) *genai.GenerateContentConfig {
+	if !c.deterministic {
+		return config
+	}
+	pinned := genai.GenerateContentConfig{}
+	if config != nil {
+		pinned = *config
+	}
+	pinned.Temperature = genai.Ptr(float32(0))
+	pinned.Seed = genai.Ptr(DeterministicSeed)
+	return &pinned
+}
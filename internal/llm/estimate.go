@@ -0,0 +1,146 @@
+package llm
+
+import "math"
+
+// Token counts assumed by EstimateDigestRun, from typical file digests
+const (
+	estimateArticleTokens      = 2000 // Article text that isn't cached yet
+	estimateSummarizePrompt    = 400  // Summarization instructions around the text
+	estimateSummaryTokens      = 350  // One article summary
+	estimateFactsPrompt        = 250  // Fact extraction instructions around the text
+	estimateFactSheetTokens    = 600  // One article's fact sheet, with quotes
+	estimateClassifyPrompt     = 900  // Theme list plus the first 2000 characters of text
+	estimateClassifyTokens     = 250  // Structured classification with reasoning
+	estimateSentimentPrompt    = 300  // Sentiment instructions for one batch
+	estimateSentimentArticle   = 400  // One article's text in a sentiment batch
+	estimateSentimentTokens    = 40   // One article's score and label
+	estimateSentimentBatch     = 10   // Articles per sentiment prompt
+	estimateArticlesPerCluster = 5
+	estimateNarrativePrompt    = 600
+	estimateNarrativeTokens    = 800
+	estimateDigestPrompt       = 1000
+	estimateDigestTokens       = 2000
+	estimateCritiquePrompt     = 800  // Critique instructions around the draft and sources
+	estimatePerspectivesPrompt = 1500 // Top story with its sources
+	estimatePerspectivesTokens = 500
+
+	// estimateCritiqueRetryShare is how often a critique pass doesn't improve the draft
+	// and is run again, when more than one pass is allowed
+	estimateCritiqueRetryShare = 0.3
+)
+
+// DigestRunInput describes a digest run to estimate
+type DigestRunInput struct {
+	ArticleTokens   []int   // Text tokens of each article to summarize; 0 = not cached, assume a typical article
+	CachedSummaries int     // Articles whose cached summaries are reused
+	CacheHitRate    float64 // Share of ArticleTokens expected to have cached summaries by the time the run starts (0-1)
+	Clusters        int     // Topic clusters (0 = one per 5 articles)
+	CritiquePasses  int     // Most self-critique passes over the digest (0 = none)
+	TwoPass         bool    // Articles are summarized from an extracted fact sheet (summarize.two_pass)
+	Classify        bool    // Each article is classified by theme
+	Sentiment       bool    // Articles are scored for sentiment in batches
+	Perspectives    bool    // An "other side" pass runs on the top story
+	DefaultModel    string  // ai.gemini.model
+	EmbeddingModel  string
+}
+
+// PhaseEstimate is the estimated LLM usage of one phase of a run. Calls and tokens
+// are expected values, so calls can be fractional when a phase runs only sometimes.
+type PhaseEstimate struct {
+	Phase     string
+	Model     string
+	Calls     float64
+	TokensIn  int
+	TokensOut int
+	Cost      float64
+}
+
+// EstimateDigestRun simulates the calls of a file digest run per phase, each priced
+// on the model its task is configured to use
+func (m TaskModels) EstimateDigestRun(in DigestRunInput) []PhaseEstimate {
+	articles := len(in.ArticleTokens) + in.CachedSummaries
+	if articles == 0 {
+		return nil
+	}
+	clusters := in.Clusters
+	if clusters <= 0 {
+		clusters = (articles + estimateArticlesPerCluster - 1) / estimateArticlesPerCluster
+	}
+	clusters = min(clusters, articles)
+
+	var estimates []PhaseEstimate
+	add := func(phase, model string, calls, tokensIn, tokensOut float64) {
+		if calls <= 0 {
+			return
+		}
+		roundedIn, roundedOut := int(math.Round(tokensIn)), int(math.Round(tokensOut))
+		estimates = append(estimates, PhaseEstimate{
+			Phase:     phase,
+			Model:     model,
+			Calls:     calls,
+			TokensIn:  roundedIn,
+			TokensOut: roundedOut,
+			Cost:      EstimateCost(model, roundedIn, roundedOut),
+		})
+	}
+
+	// Articles that may still turn out cached are summarized with probability 1-hitRate
+	miss := 1 - math.Min(math.Max(in.CacheHitRate, 0), 1)
+	summarizeIn := 0
+	for _, tokens := range in.ArticleTokens {
+		if tokens <= 0 {
+			tokens = estimateArticleTokens
+		}
+		summarizeIn += estimateSummarizePrompt + tokens
+	}
+	summarized := float64(len(in.ArticleTokens)) * miss
+	if in.TwoPass {
+		// Facts are extracted from the text; the summary is written from the fact sheet
+		factsIn := summarizeIn + len(in.ArticleTokens)*(estimateFactsPrompt-estimateSummarizePrompt)
+		add("facts", m.ModelFor("summarize", in.DefaultModel), summarized,
+			float64(factsIn)*miss, summarized*estimateFactSheetTokens)
+		summarizeIn = len(in.ArticleTokens) * (estimateSummarizePrompt + estimateFactSheetTokens)
+	}
+	add("summarize", m.ModelFor("summarize", in.DefaultModel), summarized,
+		float64(summarizeIn)*miss, summarized*estimateSummaryTokens)
+
+	if in.Classify {
+		add("classify", in.DefaultModel, float64(articles),
+			float64(articles*estimateClassifyPrompt), float64(articles*estimateClassifyTokens))
+	}
+	if in.Sentiment {
+		// Scores are cached per article, so articles summarized before are taken as scored
+		batches := math.Ceil(summarized / estimateSentimentBatch)
+		add("sentiment", in.DefaultModel, batches,
+			batches*estimateSentimentPrompt+summarized*estimateSentimentArticle, summarized*estimateSentimentTokens)
+	}
+
+	embeddingModel := in.EmbeddingModel
+	if embeddingModel == "" {
+		embeddingModel = DefaultEmbeddingModel
+	}
+	add("embedding", embeddingModel, float64(articles), float64(articles*estimateSummaryTokens), 0)
+
+	add("narrative", m.ModelFor("narrative", in.DefaultModel), float64(clusters),
+		float64(clusters*estimateNarrativePrompt+articles*estimateSummaryTokens), float64(clusters*estimateNarrativeTokens))
+	add("digest", m.ModelFor("digest", in.DefaultModel), 1,
+		float64(estimateDigestPrompt+clusters*estimateNarrativeTokens), estimateDigestTokens)
+
+	if in.CritiquePasses > 0 {
+		// The first pass always runs; each further one only when the last didn't help
+		passes, run := 0.0, 1.0
+		for i := 0; i < in.CritiquePasses; i++ {
+			passes += run
+			run *= estimateCritiqueRetryShare
+		}
+		critiqueIn := estimateCritiquePrompt + estimateDigestTokens + clusters*estimateNarrativeTokens + articles*estimateSummaryTokens
+		add("critique", m.ModelFor("digest", in.DefaultModel), passes,
+			passes*float64(critiqueIn), passes*estimateDigestTokens)
+	}
+	if in.Perspectives {
+		add("perspectives", m.ModelFor("perspectives", in.DefaultModel), 1,
+			estimatePerspectivesPrompt, estimatePerspectivesTokens)
+	}
+
+	return estimates
+}
@@ -0,0 +1,70 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formatBudgetRetries is how many times an over-budget summary is regenerated with
+// length feedback before the closest attempt is kept
+const formatBudgetRetries = 2
+
+// FormatBudget is the length a summary must fit for a digest format. The word
+// limits match the per-article limits the digest templates render with.
+type FormatBudget struct {
+	MinWords int
+	MaxWords int
+	MaxChars int
+}
+
+// formatBudgets are keyed by digest format name
+var formatBudgets = map[string]FormatBudget{
+	"brief":      {MinWords: 15, MaxWords: 25, MaxChars: 180},
+	"standard":   {MinWords: 15, MaxWords: 25, MaxChars: 180},
+	"detailed":   {MinWords: 30, MaxWords: 50, MaxChars: 350},
+	"newsletter": {MinWords: 15, MaxWords: 25, MaxChars: 180},
+	"scannable":  {MinWords: 20, MaxWords: 22, MaxChars: 160},
+	"email":      {MinWords: 15, MaxWords: 25, MaxChars: 180},
+}
+
+// defaultFormatBudget applies to formats without their own budget
+var defaultFormatBudget = FormatBudget{MinWords: 15, MaxWords: 25, MaxChars: 180}
+
+// FormatBudgetFor returns the summary length budget for a digest format
+func FormatBudgetFor(format string) FormatBudget {
+	if budget, ok := formatBudgets[strings.ToLower(strings.TrimSpace(format))]; ok {
+		return budget
+	}
+	return defaultFormatBudget
+}
+
+// Describe renders the budget as a prompt instruction
+func (b FormatBudget) Describe() string {
+	return fmt.Sprintf("%d-%d words and at most %d characters", b.MinWords, b.MaxWords, b.MaxChars)
+}
+
+// Fits reports whether text is within the budget. Summaries shorter than MinWords
+// still fit; the minimum is guidance for the model, not grounds to regenerate.
+func (b FormatBudget) Fits(text string) bool {
+	return b.overage(text) == 0
+}
+
+// Feedback explains how text misses the budget, for the regeneration prompt
+func (b FormatBudget) Feedback(text string) string {
+	return fmt.Sprintf("Your previous summary was %d words and %d characters, over the budget of %s. "+
+		"Rewrite it to fit, keeping the most important fact in complete sentences. Do not end with an ellipsis.",
+		len(strings.Fields(text)), len([]rune(text)), b.Describe())
+}
+
+// overage measures how far text exceeds the budget; 0 when it fits. Character
+// overage is scaled to roughly words so attempts can be compared.
+func (b FormatBudget) overage(text string) int {
+	over := 0
+	if words := len(strings.Fields(text)); b.MaxWords > 0 && words > b.MaxWords {
+		over += words - b.MaxWords
+	}
+	if chars := len([]rune(text)); b.MaxChars > 0 && chars > b.MaxChars {
+		over += (chars - b.MaxChars + 5) / 6
+	}
+	return over
+}
@@ -0,0 +1,47 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+
+	"briefly/internal/core"
+)
+
+func TestFormatBudget_Fits(t *testing.T) {
+	budget := FormatBudgetFor("Scannable")
+	if budget.MaxWords != 22 {
+		t.Fatalf("Expected scannable budget of 22 words, got %+v", budget)
+	}
+
+	if !budget.Fits("Go 1.24 ships faster maps.") {
+		t.Error("Expected a short summary to fit")
+	}
+
+	long := strings.Repeat("word ", 30)
+	if budget.Fits(long) {
+		t.Error("Expected a 30-word summary to exceed the scannable budget")
+	}
+	if feedback := budget.Feedback(long); !strings.Contains(feedback, "30 words") || !strings.Contains(feedback, budget.Describe()) {
+		t.Errorf("Expected feedback to state the length and budget, got %q", feedback)
+	}
+
+	if FormatBudgetFor("unknown") != defaultFormatBudget {
+		t.Error("Expected unknown formats to use the default budget")
+	}
+}
+
+func TestSummarizeArticleTextWithFormat_OfflineWithinBudget(t *testing.T) {
+	client := NewOfflineClient()
+	article := core.Article{
+		ID:          "a1",
+		CleanedText: "The Go team released version 1.24. It ships faster maps and generic type aliases. Tooling gained a new vet check.",
+	}
+
+	summary, err := client.SummarizeArticleTextWithFormat(article, "brief")
+	if err != nil {
+		t.Fatalf("SummarizeArticleTextWithFormat failed: %v", err)
+	}
+	if !FormatBudgetFor("brief").Fits(summary.SummaryText) {
+		t.Errorf("Expected summary within the brief budget, got %q", summary.SummaryText)
+	}
+}
@@ -0,0 +1,62 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// GroundingSource is a web page the model consulted for a search-grounded answer
+type GroundingSource struct {
+	Title string
+	URL   string
+}
+
+// SearchGrounded answers prompt with Google Search grounding, returning the answer and
+// the web pages it was grounded on. Not available in offline mode.
+func (c *Client) SearchGrounded(ctx context.Context, prompt string) (string, []GroundingSource, error) {
+	if c.offline {
+		return "", nil, fmt.Errorf("SearchGrounded: search grounding is not available in offline mode")
+	}
+
+	start := time.Now()
+	contents := []*genai.Content{{
+		Parts: []*genai.Part{{Text: prompt}},
+		Role:  "user",
+	}}
+	config := &genai.GenerateContentConfig{
+		Tools: []*genai.Tool{{GoogleSearch: &genai.GoogleSearch{}}},
+	}
+
+	model := c.modelFor(ctx, "search")
+	resp, err := c.gClient.Models.GenerateContent(ctx, model, redactContents(contents), c.pinSampling(config))
+	restoreResponse(resp, false)
+	c.recordResponse(ctx, model, "search", resp, prompt, start, err)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate grounded content: %w", err)
+	}
+
+	text := resp.Text()
+	if text == "" {
+		return "", nil, fmt.Errorf("empty response from model")
+	}
+
+	var sources []GroundingSource
+	seen := make(map[string]bool)
+	for _, candidate := range resp.Candidates {
+		if candidate.GroundingMetadata == nil {
+			continue
+		}
+		for _, chunk := range candidate.GroundingMetadata.GroundingChunks {
+			if chunk == nil || chunk.Web == nil || chunk.Web.URI == "" || seen[chunk.Web.URI] {
+				continue
+			}
+			seen[chunk.Web.URI] = true
+			sources = append(sources, GroundingSource{Title: chunk.Web.Title, URL: chunk.Web.URI})
+		}
+	}
+
+	return text, sources, nil
+}
@@ -0,0 +1,112 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// LinkedInCopy is the LLM-written copy for a LinkedIn post's opening hook and its
+// "This Week's Game-Changer" section
+type LinkedInCopy struct {
+	Hook         string `json:"hook"`
+	WhyItMatters string `json:"why_it_matters"`
+	TryIt        string `json:"try_it"`
+	RealityCheck string `json:"reality_check"`
+}
+
+// LinkedInCopyInput is the digest content LinkedIn copy is written from
+type LinkedInCopyInput struct {
+	DigestContent      string   // Executive summary or full digest text
+	TopTitles          []string // Highest-priority article titles, most important first
+	GameChangerTitle   string
+	GameChangerSummary string
+}
+
+// LinkedInCopySchema returns the response schema for LinkedIn hook and Game-Changer copy
+func LinkedInCopySchema() *genai.Schema {
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"hook": {
+				Type:        genai.TypeString,
+				Description: "2-3 line opening for a LinkedIn post naming this week's specific development, ending with 👇",
+			},
+			"why_it_matters": {
+				Type:        genai.TypeString,
+				Description: "One sentence (max 20 words) on the concrete impact of the game-changer for engineers",
+			},
+			"try_it": {
+				Type:        genai.TypeString,
+				Description: "One actionable suggestion (max 20 words) for trying the game-changer this week",
+			},
+			"reality_check": {
+				Type:        genai.TypeString,
+				Description: "One sentence (max 20 words) on limitations or caveats stated or implied by the article",
+			},
+		},
+		Required: []string{"hook", "why_it_matters", "try_it", "reality_check"},
+	}
+}
+
+// GenerateLinkedInCopy writes the LinkedIn hook and Game-Changer copy from digest content
+// using structured output. Every field is required to be non-empty.
+func (c *Client) GenerateLinkedInCopy(ctx context.Context, input LinkedInCopyInput) (*LinkedInCopy, error) {
+	var prompt strings.Builder
+	prompt.WriteString("Write LinkedIn copy for a weekly engineering digest.\n\n")
+	prompt.WriteString("Base every line on the content below. Name the actual products, companies, and numbers involved; ")
+	prompt.WriteString("do not invent facts and avoid hype words like \"revolutionary\" or \"game-changing\".\n\n")
+
+	if len(input.TopTitles) > 0 {
+		prompt.WriteString("TOP STORIES:\n")
+		for i, title := range input.TopTitles {
+			prompt.WriteString(fmt.Sprintf("%d. %s\n", i+1, title))
+		}
+		prompt.WriteString("\n")
+	}
+
+	prompt.WriteString("GAME-CHANGER ARTICLE:\n")
+	prompt.WriteString(fmt.Sprintf("Title: %s\n", input.GameChangerTitle))
+	prompt.WriteString(fmt.Sprintf("Summary: %s\n\n", input.GameChangerSummary))
+
+	if input.DigestContent != "" {
+		prompt.WriteString("DIGEST:\n---\n")
+		prompt.WriteString(input.DigestContent)
+		prompt.WriteString("\n---\n")
+	}
+
+	response, err := c.generateStructuredContent(ctx, prompt.String(), LinkedInCopySchema())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate LinkedIn copy: %w", err)
+	}
+
+	var result LinkedInCopy
+	if err := json.Unmarshal([]byte(cleanStructuredResponse(response)), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse LinkedIn copy: %w", err)
+	}
+
+	result.Hook = strings.TrimSpace(result.Hook)
+	result.WhyItMatters = strings.TrimSpace(result.WhyItMatters)
+	result.TryIt = strings.TrimSpace(result.TryIt)
+	result.RealityCheck = strings.TrimSpace(result.RealityCheck)
+	if result.Hook == "" || result.WhyItMatters == "" || result.TryIt == "" || result.RealityCheck == "" {
+		return nil, fmt.Errorf("incomplete LinkedIn copy in response")
+	}
+
+	return &result, nil
+}
+
+// GenerateLinkedInCopy is a convenience wrapper that creates a client from the
+// environment, matching GeneratePromptCorner for callers without a client
+func GenerateLinkedInCopy(input LinkedInCopyInput) (*LinkedInCopy, error) {
+	client, err := NewClient(DefaultModel)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.GenerateLinkedInCopy(context.Background(), input)
+}
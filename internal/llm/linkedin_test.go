@@ -0,0 +1,34 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGenerateLinkedInCopy_Offline(t *testing.T) {
+	client := NewOfflineClient()
+
+	result, err := client.GenerateLinkedInCopy(context.Background(), LinkedInCopyInput{
+		TopTitles:          []string{"Gemini Flash price cut"},
+		GameChangerTitle:   "Gemini Flash price cut",
+		GameChangerSummary: strings.ReplaceAll(offlineTestArticle, "\n", " "),
+	})
+	if err != nil {
+		t.Fatalf("GenerateLinkedInCopy failed: %v", err)
+	}
+
+	for name, field := range map[string]string{
+		"hook":           result.Hook,
+		"why_it_matters": result.WhyItMatters,
+		"try_it":         result.TryIt,
+		"reality_check":  result.RealityCheck,
+	} {
+		if field == "" {
+			t.Errorf("Expected %s to be filled", name)
+		}
+		if strings.Contains(field, "LinkedIn copy") {
+			t.Errorf("Expected %s drawn from the article, not the prompt: %q", name, field)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"briefly/internal/core"
+	"briefly/internal/language"
 	"context"
 	"fmt"
 	"log"
@@ -26,13 +27,9 @@ const (
 	SummarizeTextPromptTemplate = "Please summarize the following text concisely:\n\n---\n%s\n---"
 	// SummarizeTextWithFormatPromptTemplate is the template for format-aware summarization.
 	SummarizeTextWithFormatPromptTemplate = `Summarize the following text for a %s format. Focus on what matters and why it's relevant. Write only the summary, no meta-commentary or format explanations.
+Alongside the summary, list the key points, the people/companies/products named, and any exact statistics.
 
-FORMAT REQUIREMENTS:
-- Brief: 50-100 words, essential information only
-- Standard: 100-200 words, key points with context  
-- Detailed: 200-300 words, comprehensive analysis
-- Newsletter: 150-250 words, engaging and shareable
-- Scannable: 20-40 words, one complete sentence focusing on the core insight
+LENGTH BUDGET: %s. Write complete sentences that end naturally within the budget; never trail off with an ellipsis.
 
 Text to summarize:
 %s`
@@ -44,6 +41,13 @@ type Client struct {
 	apiKey    string
 	modelName string
 	gClient   *genai.Client // Store the main client (new SDK)
+	offline   bool          // Deterministic mock backend, no API calls (see NewOfflineClient)
+
+	taskModels TaskModels // Per-task models, chosen by attribution phase (see SetTaskModels)
+
+	usageRecorder UsageRecorder // Receives tokens, latency, and retries of every call (optional)
+
+	deterministic bool // Temperature 0 and a fixed seed on every call (see SetDeterministic)
 }
 
 // TextGenerationOptions contains options for text generation
@@ -54,6 +58,12 @@ type TextGenerationOptions struct {
 	ResponseSchema *genai.Schema // Optional: Schema for structured output (Phase 1)
 }
 
+// Generator generates text from a prompt. *Client and *TracedClient implement it,
+// and packages that only need text take it so tests can supply a fake.
+type Generator interface {
+	GenerateText(ctx context.Context, prompt string, options TextGenerationOptions) (string, error)
+}
+
 // NewClient creates a new LLM client.
 // It supports multiple ways to get the API key (in order of preference):
 // 1. Environment variable: GEMINI_API_KEY (or alternatives)
@@ -91,9 +101,10 @@ func NewClient(modelName string) (*Client, error) {
 	}
 
 	return &Client{
-		apiKey:    apiKey,
-		modelName: modelName,
-		gClient:   gClient,
+		apiKey:     apiKey,
+		modelName:  modelName,
+		gClient:    gClient,
+		taskModels: ConfiguredTaskModels(),
 	}, nil
 }
 
@@ -106,13 +117,17 @@ func (c *Client) GenerateContentWithTools(
 	tools []*genai.Tool,
 	config *genai.GenerateContentConfig,
 ) (*genai.GenerateContentResponse, error) {
+	if c.offline {
+		return nil, fmt.Errorf("GenerateContentWithTools: tool use is not available in offline mode")
+	}
 	if config == nil {
 		config = &genai.GenerateContentConfig{}
 	}
 	if tools != nil {
 		config.Tools = tools
 	}
-	resp, err := c.gClient.Models.GenerateContent(ctx, c.modelName, contents, config)
+	resp, err := c.gClient.Models.GenerateContent(ctx, c.modelName, redactContents(contents), redactConfig(c.pinSampling(config)))
+	restoreResponse(resp, config.ResponseSchema != nil)
 	if err != nil {
 		return nil, fmt.Errorf("GenerateContentWithTools: %w", err)
 	}
@@ -121,12 +136,22 @@ func (c *Client) GenerateContentWithTools(
 
 // generateContent is a helper that wraps the new SDK's GenerateContent call
 func (c *Client) generateContent(ctx context.Context, prompt string) (string, error) {
+	start := time.Now()
+	if c.offline {
+		text, err := offlineGenerate(prompt, nil)
+		c.recordUsage(ctx, c.modelName, "text", nil, prompt, text, start, err)
+		return text, err
+	}
+
 	contents := []*genai.Content{{
 		Parts: []*genai.Part{{Text: prompt}},
 		Role:  "user",
 	}}
 
-	resp, err := c.gClient.Models.GenerateContent(ctx, c.modelName, contents, nil)
+	model := c.modelFor(ctx, "")
+	resp, err := c.gClient.Models.GenerateContent(ctx, model, redactContents(contents), c.pinSampling(nil))
+	restoreResponse(resp, false)
+	c.recordResponse(ctx, model, "text", resp, prompt, start, err)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate content: %w", err)
 	}
@@ -140,6 +165,42 @@ func (c *Client) generateContent(ctx context.Context, prompt string) (string, er
 	return text, nil
 }
 
+// generateStructuredContent calls the model with a response schema so the output is JSON
+// matching that schema rather than free text
+func (c *Client) generateStructuredContent(ctx context.Context, prompt string, schema *genai.Schema) (string, error) {
+	start := time.Now()
+	if c.offline {
+		text, err := offlineGenerate(prompt, schema)
+		c.recordUsage(ctx, c.modelName, "text", nil, prompt, text, start, err)
+		return text, err
+	}
+
+	contents := []*genai.Content{{
+		Parts: []*genai.Part{{Text: prompt}},
+		Role:  "user",
+	}}
+
+	config := &genai.GenerateContentConfig{
+		ResponseMIMEType: "application/json",
+		ResponseSchema:   schema,
+	}
+
+	model := c.modelFor(ctx, "")
+	resp, err := c.gClient.Models.GenerateContent(ctx, model, redactContents(contents), c.pinSampling(config))
+	restoreResponse(resp, true)
+	c.recordResponse(ctx, model, "text", resp, prompt, start, err)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	text := resp.Text()
+	if text == "" {
+		return "", fmt.Errorf("empty response from model")
+	}
+
+	return text, nil
+}
+
 // SummarizeArticleText takes an Article object, extracts its CleanedText,
 // and returns a Summary object.
 func (c *Client) SummarizeArticleText(article core.Article) (core.Summary, error) {
@@ -147,9 +208,9 @@ func (c *Client) SummarizeArticleText(article core.Article) (core.Summary, error
 		return core.Summary{}, fmt.Errorf("article ID %s has no CleanedText to summarize", article.ID)
 	}
 
-	prompt := fmt.Sprintf(SummarizeTextPromptTemplate, article.CleanedText)
+	prompt := WithLanguageNote(article.CleanedText, fmt.Sprintf(SummarizeTextPromptTemplate, article.CleanedText))
 
-	ctx := context.Background()
+	ctx := WithAttribution(context.Background(), Attribution{ArticleID: article.ID, Phase: "summarize"})
 	summaryText, err := c.generateContent(ctx, prompt)
 	if err != nil {
 		return core.Summary{}, fmt.Errorf("failed to generate content for article ID %s: %w", article.ID, err)
@@ -159,7 +220,7 @@ func (c *Client) SummarizeArticleText(article core.Article) (core.Summary, error
 	summary := core.Summary{
 		ArticleIDs:   []string{article.ID},
 		SummaryText:  summaryText,
-		ModelUsed:    c.modelName,
+		ModelUsed:    c.modelFor(ctx, ""),
 		Instructions: SummarizeTextPromptTemplate,
 	}
 
@@ -167,91 +228,130 @@ func (c *Client) SummarizeArticleText(article core.Article) (core.Summary, error
 }
 
 // SummarizeArticleTextWithFormat takes an Article object, extracts its CleanedText,
-// and returns a Summary object with format-specific guidance.
+// and returns a Summary object with format-specific guidance. The model responds
+// against FormatSummarySchema, so key points, entities, and stats come back typed
+// in StructuredContent.
 func (c *Client) SummarizeArticleTextWithFormat(article core.Article, format string) (core.Summary, error) {
 	if article.CleanedText == "" {
 		return core.Summary{}, fmt.Errorf("article ID %s has no CleanedText to summarize", article.ID)
 	}
 
-	prompt := fmt.Sprintf(SummarizeTextWithFormatPromptTemplate, format, article.CleanedText)
+	budget := FormatBudgetFor(format)
+	prompt := WithLanguageNote(article.CleanedText, fmt.Sprintf(SummarizeTextWithFormatPromptTemplate, format, budget.Describe(), article.CleanedText))
 
-	ctx := context.Background()
-	summaryText, err := c.generateContent(ctx, prompt)
+	ctx := WithAttribution(context.Background(), Attribution{ArticleID: article.ID, Phase: "summarize"})
+	response, err := c.generateStructuredContent(ctx, prompt, FormatSummarySchema())
 	if err != nil {
 		return core.Summary{}, fmt.Errorf("failed to generate content for article ID %s: %w", article.ID, err)
 	}
+	response = c.regenerateOverBudget(ctx, prompt, response, budget)
 
 	// Populate the Summary struct
 	summary := core.Summary{
 		ArticleIDs:   []string{article.ID},
-		SummaryText:  summaryText,
-		ModelUsed:    c.modelName,
+		ModelUsed:    c.modelFor(ctx, ""),
 		Instructions: fmt.Sprintf("Format-aware summarization for %s format", format),
 	}
 
-	return summary, nil
-}
-
-// SummarizeArticleWithKeyMoments creates a summary with key moments in a specific format
-func (c *Client) SummarizeArticleWithKeyMoments(article core.Article) (core.Summary, error) {
-	if article.CleanedText == "" {
-		return core.Summary{}, fmt.Errorf("article ID %s has no CleanedText to summarize", article.ID)
+	summaryText, structured, err := parseFormatSummary(response)
+	if err != nil {
+		// Keep the raw response rather than failing the article
+		log.Printf("[WARN] SummarizeArticleTextWithFormat: %v, using raw response", err)
+		summary.SummaryText = strings.TrimSpace(response)
+		return summary, nil
 	}
 
-	// Custom prompt for summary with key moments
-	keyMomentsPrompt := `Please analyze the following article and provide a summary in this exact format:
+	summary.SummaryText = summaryText
+	summary.SummaryType = "structured"
+	summary.StructuredContent = structured
 
-# Executive Summary
-
-[Write a concise 2-3 sentence summary that captures the main topic and key takeaway]
-
-# Key Insights
-
-## 💡 [Short descriptive title for first insight]
-> "[Select a powerful, concise quote (1-2 sentences max) from the article]"
+	return summary, nil
+}
 
-**Why it matters:** [One clear sentence explaining the significance]
+// regenerateOverBudget re-asks for a format summary while the summary in response is
+// over budget, telling the model how long its last attempt was. The attempt closest to
+// the budget is returned; failed retries keep the best response so far.
+func (c *Client) regenerateOverBudget(ctx context.Context, prompt, response string, budget FormatBudget) string {
+	best := response
+	bestOverage := budget.overage(formatSummaryText(response))
+
+	for attempt := 0; attempt < formatBudgetRetries && bestOverage > 0; attempt++ {
+		feedbackPrompt := prompt + "\n\nLENGTH FEEDBACK:\n" + budget.Feedback(formatSummaryText(best))
+		retry, err := c.generateStructuredContent(WithRetry(ctx, attempt+1), feedbackPrompt, FormatSummarySchema())
+		if err != nil {
+			log.Printf("[WARN] Over-budget summary regeneration failed: %v", err)
+			break
+		}
 
-## 📊 [Short descriptive title for second insight]
-> "[Another impactful quote from the article]"
+		if overage := budget.overage(formatSummaryText(retry)); overage < bestOverage {
+			best, bestOverage = retry, overage
+		}
+	}
 
-**Why it matters:** [One clear sentence explaining the significance]
+	return best
+}
 
-## 🚀 [Short descriptive title for third insight]
-> "[Another important quote from the article]"
+// WithLanguageNote prefixes prompt with language.PromptNote when text isn't English,
+// so summaries of foreign-language articles still come back in English
+func WithLanguageNote(text, prompt string) string {
+	if note := language.PromptNote(text); note != "" {
+		return note + "\n\n" + prompt
+	}
+	return prompt
+}
 
-**Why it matters:** [One clear sentence explaining the significance]
+// formatSummaryText returns the summary text from a format summary response, or the
+// raw response when it does not parse
+func formatSummaryText(response string) string {
+	if text, _, err := parseFormatSummary(response); err == nil {
+		return text
+	}
+	return strings.TrimSpace(response)
+}
 
-## 🔍 [Short descriptive title for fourth insight]
-> "[Final key quote from the article]"
+// SummarizeArticleWithKeyMoments creates a summary with key moments in a specific format
+func (c *Client) SummarizeArticleWithKeyMoments(article core.Article) (core.Summary, error) {
+	if article.CleanedText == "" {
+		return core.Summary{}, fmt.Errorf("article ID %s has no CleanedText to summarize", article.ID)
+	}
 
-**Why it matters:** [One clear sentence explaining the significance]
+	// Custom prompt for summary with key moments; structure is enforced by KeyMomentsSchema
+	keyMomentsPrompt := `Analyze the following article and extract an executive summary plus its key moments.
 
 Instructions:
+- Executive summary: 2-3 sentences capturing the main topic and key takeaway
+- Select 3-4 most impactful moments that represent different aspects of the story
 - Use EXACT, concise quotes (1-2 sentences maximum) from the article
 - Create descriptive titles that categorize each insight (e.g., "Performance Breakthrough", "New Feature Launch", "Market Impact")
-- Keep explanations to one clear, punchy sentence
+- Keep "why it matters" to one clear, punchy sentence
 - Use appropriate emojis for insight categories: 💡 (concepts), 📊 (data/metrics), 🚀 (launches/features), 🔍 (analysis), ⚡ (speed/performance), 🔒 (security/privacy), 💰 (business/cost)
-- Select 3-4 most impactful moments that represent different aspects of the story
 
 Article Content:
 %s`
 
-	prompt := fmt.Sprintf(keyMomentsPrompt, article.CleanedText)
+	prompt := WithLanguageNote(article.CleanedText, fmt.Sprintf(keyMomentsPrompt, article.CleanedText))
 
-	ctx := context.Background()
-	summaryText, err := c.generateContent(ctx, prompt)
+	ctx := WithAttribution(context.Background(), Attribution{ArticleID: article.ID, Phase: "summarize"})
+	response, err := c.generateStructuredContent(ctx, prompt, KeyMomentsSchema())
 	if err != nil {
 		return core.Summary{}, fmt.Errorf("failed to generate content for article ID %s: %w", article.ID, err)
 	}
 
+	structured, err := parseKeyMoments(response)
+	if err != nil {
+		return core.Summary{}, fmt.Errorf("failed to parse key moments for article ID %s: %w", article.ID, err)
+	}
+
 	// Create the Summary object
 	summary := core.Summary{
 		ArticleIDs:    []string{article.ID},
-		SummaryText:   summaryText,
-		ModelUsed:     c.modelName,
+		SummaryText:   RenderKeyMoments(structured),
+		ModelUsed:     c.modelFor(ctx, ""),
 		Instructions:  "Article summarization with key moments and explanations",
 		DateGenerated: time.Now().UTC(),
+
+		SummaryType:       "structured",
+		StructuredContent: structured,
 	}
 
 	return summary, nil
@@ -634,8 +734,15 @@ func (c *Client) GenerateText(ctx context.Context, prompt string, options TextGe
 		return "", fmt.Errorf("prompt cannot be empty")
 	}
 
-	// Determine which model to use
-	modelName := c.modelName
+	start := time.Now()
+	if c.offline {
+		text, err := offlineGenerate(prompt, options.ResponseSchema)
+		c.recordUsage(ctx, c.modelName, "text", nil, prompt, text, start, err)
+		return text, err
+	}
+
+	// Determine which model to use: the caller's, the task's, or the client's
+	modelName := c.modelFor(ctx, "")
 	if options.Model != "" {
 		modelName = options.Model
 	}
@@ -646,27 +753,13 @@ func (c *Client) GenerateText(ctx context.Context, prompt string, options TextGe
 		Role:  "user",
 	}}
 
-	// Build config if options are provided
-	var config *genai.GenerateContentConfig
-	if options.MaxTokens > 0 || options.Temperature > 0 || options.ResponseSchema != nil {
-		config = &genai.GenerateContentConfig{}
-		if options.MaxTokens > 0 {
-			maxTokens := int32(options.MaxTokens)
-			config.MaxOutputTokens = maxTokens
-		}
-		if options.Temperature > 0 {
-			temp := float32(options.Temperature)
-			config.Temperature = &temp
-		}
-		// Phase 1: Structured output support
-		if options.ResponseSchema != nil {
-			config.ResponseMIMEType = "application/json"
-			config.ResponseSchema = options.ResponseSchema
-		}
-	}
+	// Build config if options are provided (includes structured output schema)
+	config := generationConfig(options)
 
 	// Generate content
-	resp, err := c.gClient.Models.GenerateContent(ctx, modelName, contents, config)
+	resp, err := c.gClient.Models.GenerateContent(ctx, modelName, redactContents(contents), c.pinSampling(config))
+	restoreResponse(resp, options.ResponseSchema != nil)
+	c.recordResponse(ctx, modelName, "text", resp, prompt, start, err)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate text: %w", err)
 	}
@@ -705,13 +798,14 @@ func GenerateSummary(textContent string) (string, error) {
 		return "", fmt.Errorf("failed to create Gemini client: %w", err)
 	}
 
-	prompt := fmt.Sprintf(SummarizeTextPromptTemplate, textContent)
+	prompt := WithLanguageNote(textContent, fmt.Sprintf(SummarizeTextPromptTemplate, textContent))
 	contents := []*genai.Content{{
 		Parts: []*genai.Part{{Text: prompt}},
 		Role:  "user",
 	}}
 
-	resp, err := client.Models.GenerateContent(ctx, DefaultModel, contents, nil)
+	resp, err := client.Models.GenerateContent(ctx, DefaultModel, redactContents(contents), nil)
+	restoreResponse(resp, false)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate content for summarization: %w", err)
 	}
@@ -774,7 +868,8 @@ Please provide the complete rewritten digest:`, digestContent, myTake, format)
 		Role:  "user",
 	}}
 
-	resp, err := client.Models.GenerateContent(ctx, DefaultModel, contents, nil)
+	resp, err := client.Models.GenerateContent(ctx, DefaultModel, redactContents(contents), nil)
+	restoreResponse(resp, false)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate content for digest regeneration: %w", err)
 	}
@@ -830,7 +925,8 @@ Please generate the Prompt Corner section:`, digestContent)
 		Role:  "user",
 	}}
 
-	resp, err := client.Models.GenerateContent(ctx, DefaultModel, contents, nil)
+	resp, err := client.Models.GenerateContent(ctx, DefaultModel, redactContents(contents), nil)
+	restoreResponse(resp, false)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate content for prompt corner: %w", err)
 	}
@@ -872,7 +968,7 @@ DIGEST CONTENT:
 
 Generate only the Smart Headline text, without quotes or additional formatting:`, format, digestContent)
 
-	ctx := context.Background()
+	ctx := WithAttribution(context.Background(), Attribution{Phase: "title"})
 	titleText, err := c.generateContent(ctx, prompt)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate title: %w", err)
@@ -930,7 +1026,8 @@ Generate only the Smart Headline text, without quotes or additional formatting:`
 		Role:  "user",
 	}}
 
-	resp, err := client.Models.GenerateContent(ctx, DefaultModel, contents, nil)
+	resp, err := client.Models.GenerateContent(ctx, DefaultModel, redactContents(contents), nil)
+	restoreResponse(resp, false)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate title: %w", err)
 	}
@@ -950,7 +1047,17 @@ Generate only the Smart Headline text, without quotes or additional formatting:`
 // GenerateEmbedding generates a vector embedding for the given text using Gemini's embedding model
 // Uses gemini-embedding-001 with Matryoshka to output 768 dimensions for compatibility
 func (c *Client) GenerateEmbedding(text string) ([]float64, error) {
-	ctx := context.Background()
+	return c.GenerateEmbeddingContext(context.Background(), text)
+}
+
+// GenerateEmbeddingContext is GenerateEmbedding with a context, whose attribution
+// (see WithAttribution) is recorded with the call
+func (c *Client) GenerateEmbeddingContext(ctx context.Context, text string) ([]float64, error) {
+	start := time.Now()
+	if c.offline {
+		c.recordUsage(ctx, DefaultEmbeddingModel, "embedding", nil, text, "", start, nil)
+		return offlineEmbedding(text), nil
+	}
 
 	// Build content for embedding
 	contents := []*genai.Content{{
@@ -964,7 +1071,8 @@ func (c *Client) GenerateEmbedding(text string) ([]float64, error) {
 		OutputDimensionality: &dims,
 	}
 
-	resp, err := c.gClient.Models.EmbedContent(ctx, DefaultEmbeddingModel, contents, config)
+	resp, err := c.gClient.Models.EmbedContent(ctx, DefaultEmbeddingModel, redactContents(contents), config)
+	c.recordUsage(ctx, DefaultEmbeddingModel, "embedding", nil, text, "", start, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate embedding: %w", err)
 	}
@@ -1415,6 +1523,10 @@ type ChatSession struct {
 
 // StartChatSession initializes a new chat session with the given context
 func (c *Client) StartChatSession(ctx context.Context, initialContext string) (*ChatSession, error) {
+	if c.offline {
+		return nil, fmt.Errorf("chat sessions are not available in offline mode")
+	}
+
 	// Initialize history with system context
 	history := []*genai.Content{{
 		Parts: []*genai.Part{{Text: initialContext}},
@@ -1426,7 +1538,8 @@ func (c *Client) StartChatSession(ctx context.Context, initialContext string) (*
 		Temperature: genai.Ptr(float32(0.7)),
 	}
 
-	resp, err := c.gClient.Models.GenerateContent(ctx, c.modelName, history, config)
+	resp, err := c.gClient.Models.GenerateContent(ctx, c.modelName, redactContents(history), config)
+	restoreResponse(resp, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize chat session: %w", err)
 	}
@@ -1451,6 +1564,9 @@ func (c *Client) SendChatMessage(ctx context.Context, session *ChatSession, mess
 	if session == nil {
 		return "", fmt.Errorf("invalid chat session")
 	}
+	if c.offline {
+		return "", fmt.Errorf("chat sessions are not available in offline mode")
+	}
 
 	// Add user message to history
 	session.history = append(session.history, &genai.Content{
@@ -1464,7 +1580,8 @@ func (c *Client) SendChatMessage(ctx context.Context, session *ChatSession, mess
 	}
 
 	// Send the full history
-	resp, err := c.gClient.Models.GenerateContent(ctx, session.modelName, session.history, config)
+	resp, err := c.gClient.Models.GenerateContent(ctx, session.modelName, redactContents(session.history), config)
+	restoreResponse(resp, false)
 	if err != nil {
 		return "", fmt.Errorf("failed to send message: %w", err)
 	}
@@ -0,0 +1,102 @@
+package llm
+
+import (
+	"briefly/internal/core"
+	"context"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// TaskModels picks a model per task, so per-article work can run on a cheap model
+// while the digest narrative gets a stronger one. Empty fields use the client's
+// default model.
+type TaskModels struct {
+	Summarize string // Per-article summaries
+	Digest    string // Digest narratives, executive summaries, and perspectives
+	Title     string // Digest titles, article titles, and cluster themes
+	Research  string // Research briefs and their search grounding
+}
+
+// ConfiguredTaskModels reads summarize.model, digest.model, title.model, and
+// research.model from the configuration
+func ConfiguredTaskModels() TaskModels {
+	return TaskModels{
+		Summarize: viper.GetString("summarize.model"),
+		Digest:    viper.GetString("digest.model"),
+		Title:     viper.GetString("title.model"),
+		Research:  viper.GetString("research.model"),
+	}
+}
+
+// ForPhase returns the model configured for an attribution phase, or "" when the
+// phase has no task model
+func (m TaskModels) ForPhase(phase string) string {
+	switch phase {
+	case "summarize", "facts":
+		return m.Summarize
+	case "digest", "narrative", "perspectives":
+		return m.Digest
+	case "title":
+		return m.Title
+	case "research":
+		return m.Research
+	default:
+		return ""
+	}
+}
+
+// ModelFor returns the model a call in phase runs on, given the default model
+func (m TaskModels) ModelFor(phase, defaultModel string) string {
+	if model := m.ForPhase(phase); model != "" {
+		return model
+	}
+	return defaultModel
+}
+
+// EstimateCallCost estimates what a recorded call would cost on its task's model.
+// Calls in phases without a task model (embeddings, classification) keep the
+// model they ran on.
+func (m TaskModels) EstimateCallCost(call core.LLMCall) float64 {
+	model := call.Model
+	if taskModel := m.ForPhase(call.Phase); taskModel != "" && model != OfflineModel {
+		model = taskModel
+	}
+	return EstimateCost(model, call.TokensIn, call.TokensOut)
+}
+
+// Describe lists the tasks with their own model, e.g. "summarize: gemini-2.5-flash-lite";
+// empty when every task uses the default model
+func (m TaskModels) Describe() string {
+	var parts []string
+	for _, task := range []struct{ name, model string }{
+		{"summarize", m.Summarize},
+		{"digest", m.Digest},
+		{"title", m.Title},
+		{"research", m.Research},
+	} {
+		if task.model != "" {
+			parts = append(parts, task.name+": "+task.model)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SetTaskModels routes calls to per-task models by their attribution phase
+func (c *Client) SetTaskModels(models TaskModels) {
+	c.taskModels = models
+}
+
+// TaskModels returns the client's per-task models
+func (c *Client) TaskModels() TaskModels {
+	return c.taskModels
+}
+
+// modelFor returns the model for a call: the task model of the phase attributed in
+// ctx (or of phase when ctx has none), falling back to the client's model
+func (c *Client) modelFor(ctx context.Context, phase string) string {
+	if attributed := AttributionFrom(ctx).Phase; attributed != "" {
+		phase = attributed
+	}
+	return c.taskModels.ModelFor(phase, c.modelName)
+}
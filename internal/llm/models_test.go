@@ -0,0 +1,146 @@
+package llm
+
+import (
+	"briefly/internal/core"
+	"context"
+	"math"
+	"testing"
+)
+
+func TestTaskModels_RouteByPhase(t *testing.T) {
+	client := &Client{modelName: "gemini-3-flash-preview"}
+	client.SetTaskModels(TaskModels{Summarize: "gemini-2.5-flash-lite", Digest: "gemini-3-pro-preview"})
+
+	tests := []struct {
+		phase string
+		want  string
+	}{
+		{"summarize", "gemini-2.5-flash-lite"},
+		{"narrative", "gemini-3-pro-preview"},
+		{"digest", "gemini-3-pro-preview"},
+		{"title", "gemini-3-flash-preview"},
+		{"classify", "gemini-3-flash-preview"},
+		{"", "gemini-3-flash-preview"},
+	}
+	for _, tt := range tests {
+		ctx := WithAttribution(context.Background(), Attribution{Phase: tt.phase})
+		if got := client.modelFor(ctx, ""); got != tt.want {
+			t.Errorf("modelFor(%q) = %q, want %q", tt.phase, got, tt.want)
+		}
+	}
+
+	// The attributed phase wins over the call's own
+	ctx := WithAttribution(context.Background(), Attribution{Phase: "summarize"})
+	if got := client.modelFor(ctx, "search"); got != "gemini-2.5-flash-lite" {
+		t.Errorf("expected the attributed phase's model, got %q", got)
+	}
+}
+
+func TestTaskModels_EstimateCallCost(t *testing.T) {
+	models := TaskModels{Summarize: "gemini-2.5-flash-lite"}
+
+	summary := core.LLMCall{Phase: "summarize", Model: "gemini-3-flash-preview", TokensIn: 1_000_000, TokensOut: 1_000_000}
+	if got, want := models.EstimateCallCost(summary), 0.10+0.40; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected the summary repriced on the task model, got %f want %f", got, want)
+	}
+
+	embedding := core.LLMCall{Phase: "embedding", Model: "gemini-embedding-001", TokensIn: 1_000_000}
+	if got := models.EstimateCallCost(embedding); math.Abs(got-0.15) > 1e-9 {
+		t.Errorf("expected the embedding to keep its model, got %f", got)
+	}
+
+	if got := models.Describe(); got != "summarize: gemini-2.5-flash-lite" {
+		t.Errorf("Describe = %q", got)
+	}
+}
+
+func TestTaskModels_EstimateDigestRun(t *testing.T) {
+	models := TaskModels{Summarize: "gemini-2.5-flash-lite", Digest: "gemini-3-pro-preview"}
+	estimates := models.EstimateDigestRun(DigestRunInput{
+		ArticleTokens:   []int{0, 1000},
+		CachedSummaries: 3,
+		DefaultModel:    "gemini-3-flash-preview",
+	})
+
+	byPhase := make(map[string]PhaseEstimate)
+	for _, estimate := range estimates {
+		byPhase[estimate.Phase] = estimate
+	}
+	summarize := byPhase["summarize"]
+	if summarize.Model != "gemini-2.5-flash-lite" || summarize.Calls != 2 || summarize.TokensIn != 2*estimateSummarizePrompt+estimateArticleTokens+1000 {
+		t.Errorf("unexpected summarize estimate %+v", summarize)
+	}
+	if byPhase["embedding"].Calls != 5 || byPhase["narrative"].Calls != 1 {
+		t.Errorf("expected every article embedded in one cluster, got %+v", estimates)
+	}
+	if digest := byPhase["digest"]; digest.Model != "gemini-3-pro-preview" || digest.Cost <= 0 {
+		t.Errorf("expected the digest priced on the digest model, got %+v", digest)
+	}
+
+	if _, ok := byPhase["critique"]; ok {
+		t.Errorf("expected no critique phase unless requested, got %+v", estimates)
+	}
+
+	if estimates := models.EstimateDigestRun(DigestRunInput{}); estimates != nil {
+		t.Errorf("expected no estimate without articles, got %+v", estimates)
+	}
+}
+
+func TestTaskModels_EstimateDigestRun_Simulation(t *testing.T) {
+	models := TaskModels{Digest: "gemini-3-pro-preview"}
+	estimates := models.EstimateDigestRun(DigestRunInput{
+		ArticleTokens:  make([]int, 20),
+		CacheHitRate:   0.25,
+		Clusters:       4,
+		CritiquePasses: 2,
+		Classify:       true,
+		Sentiment:      true,
+		Perspectives:   true,
+		DefaultModel:   "gemini-3-flash-preview",
+	})
+
+	byPhase := make(map[string]PhaseEstimate)
+	for _, estimate := range estimates {
+		byPhase[estimate.Phase] = estimate
+	}
+	if got := byPhase["summarize"].Calls; got != 15 {
+		t.Errorf("expected 15 of 20 articles summarized at a 25%% hit rate, got %v", got)
+	}
+	if got := byPhase["classify"].Calls; got != 20 {
+		t.Errorf("expected every article classified, got %v", got)
+	}
+	if got := byPhase["sentiment"].Calls; got != 2 {
+		t.Errorf("expected 15 articles scored in 2 batches, got %v", got)
+	}
+	if got := byPhase["narrative"].Calls; got != 4 {
+		t.Errorf("expected one narrative per cluster, got %v", got)
+	}
+	critique := byPhase["critique"]
+	if math.Abs(critique.Calls-(1+estimateCritiqueRetryShare)) > 1e-9 || critique.Model != "gemini-3-pro-preview" {
+		t.Errorf("expected one critique pass plus the expected retry on the digest model, got %+v", critique)
+	}
+	if byPhase["perspectives"].Calls != 1 {
+		t.Errorf("expected a perspectives pass, got %+v", estimates)
+	}
+
+	// Two passes: facts come from the article text, the summary from the fact sheet
+	twoPass := models.EstimateDigestRun(DigestRunInput{ArticleTokens: []int{1000, 3000}, TwoPass: true, DefaultModel: "gemini-3-flash-preview"})
+	byPhase = make(map[string]PhaseEstimate)
+	for _, estimate := range twoPass {
+		byPhase[estimate.Phase] = estimate
+	}
+	if facts := byPhase["facts"]; facts.Calls != 2 || facts.TokensIn != 2*estimateFactsPrompt+4000 || facts.TokensOut != 2*estimateFactSheetTokens {
+		t.Errorf("unexpected facts estimate %+v", facts)
+	}
+	if summarize := byPhase["summarize"]; summarize.Calls != 2 || summarize.TokensIn != 2*(estimateSummarizePrompt+estimateFactSheetTokens) {
+		t.Errorf("expected summaries written from fact sheets, got %+v", summarize)
+	}
+
+	// Every summary cached: nothing to summarize or score
+	cached := models.EstimateDigestRun(DigestRunInput{ArticleTokens: make([]int, 4), CacheHitRate: 1, Sentiment: true, DefaultModel: "gemini-3-flash-preview"})
+	for _, estimate := range cached {
+		if estimate.Phase == "summarize" || estimate.Phase == "sentiment" {
+			t.Errorf("expected no %s calls at a 100%% hit rate, got %+v", estimate.Phase, estimate)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// OfflineModel is the model name reported by clients created with NewOfflineClient
+const OfflineModel = "offline-mock"
+
+// offlineSourceMarkers introduce the source text inside the prompts this package and
+// its callers build, in priority order; the offline backend answers from that text.
+// Markers ending in a newline open a block, the others a single line.
+var offlineSourceMarkers = []string{
+	"**Content:**\n",
+	"Article Content:\n",
+	"Text to summarize:\n",
+	"**Cluster Summary:**\n",
+	"Summary: ",
+	"Content: ",
+	"## Cluster ",
+	"---\n",
+}
+
+// offlineSectionEnd marks where embedded source text stops and prompt instructions resume
+var offlineSectionEnd = regexp.MustCompile(`\n(\n\*\*|---|\n[A-Z][A-Z /]{3,}:)`)
+
+// offlineLineNumber strips list numbering such as "1: " from single-line sections
+var offlineLineNumber = regexp.MustCompile(`^\d+:\s*`)
+
+var offlineSentenceSplit = regexp.MustCompile(`[.!?]+\s+`)
+
+var offlineWordPattern = regexp.MustCompile(`[a-z0-9][a-z0-9\-]*`)
+
+// NewOfflineClient creates a client that never calls the Gemini API. Responses are
+// deterministic functions of the prompt: free-text calls get extractive summaries of
+// the article text in the prompt, schema calls get JSON conforming to the schema,
+// and embeddings are hashed bag-of-words vectors. Intended for tests and demos.
+func NewOfflineClient() *Client {
+	return &Client{
+		modelName: OfflineModel,
+		offline:   true,
+	}
+}
+
+// IsOffline reports whether the client uses the deterministic offline backend
+func (c *Client) IsOffline() bool {
+	return c.offline
+}
+
+// offlineGenerate returns the offline response for a prompt, honoring schema when set
+func offlineGenerate(prompt string, schema *genai.Schema) (string, error) {
+	sentences := offlineSentences(offlineSource(prompt))
+
+	if schema != nil {
+		value := offlineValue(schema, "", sentences)
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode offline response: %w", err)
+		}
+		return string(data), nil
+	}
+
+	summary := offlineSummary(sentences, 60, 140)
+
+	// The summarizer's prompt asks for SUMMARY/KEY POINTS sections
+	if strings.Contains(prompt, "SUMMARY:") {
+		var b strings.Builder
+		b.WriteString("SUMMARY:\n")
+		b.WriteString(summary)
+		b.WriteString("\n\nKEY POINTS:\n")
+		for i, sentence := range sentences {
+			if i == 5 {
+				break
+			}
+			b.WriteString("- " + sentence + "\n")
+		}
+		return b.String(), nil
+	}
+
+	return summary, nil
+}
+
+// offlineSource extracts the source text embedded in a prompt, joining every
+// occurrence of the first marker present
+func offlineSource(prompt string) string {
+	for _, marker := range offlineSourceMarkers {
+		parts := strings.Split(prompt, marker)
+		if len(parts) < 2 {
+			continue
+		}
+
+		var sections []string
+		for _, section := range parts[1:] {
+			if strings.HasSuffix(marker, "\n") {
+				if loc := offlineSectionEnd.FindStringIndex(section); loc != nil {
+					section = section[:loc[0]]
+				}
+			} else {
+				if idx := strings.Index(section, "\n"); idx >= 0 {
+					section = section[:idx]
+				}
+				section = offlineLineNumber.ReplaceAllString(section, "")
+			}
+			if strings.TrimSpace(section) != "" {
+				sections = append(sections, strings.TrimSpace(section))
+			}
+		}
+		if len(sections) > 0 {
+			return strings.Join(sections, "\n\n")
+		}
+	}
+	return prompt
+}
+
+// offlineSentences splits text into sentences long enough to carry content
+func offlineSentences(text string) []string {
+	// Prompts that embed JSON carry escaped newlines
+	text = strings.ReplaceAll(text, `\n`, " ")
+	text = strings.Join(strings.Fields(text), " ")
+
+	var sentences []string
+	for _, part := range offlineSentenceSplit.Split(text, -1) {
+		part = strings.TrimSpace(part)
+		if len(part) < 20 {
+			continue
+		}
+		sentences = append(sentences, strings.TrimRight(part, ".!?")+".")
+	}
+
+	if len(sentences) == 0 && strings.TrimSpace(text) != "" {
+		sentences = []string{strings.TrimSpace(text)}
+	}
+	return sentences
+}
+
+// offlineSummary joins leading sentences until minWords is reached, capped at maxWords
+func offlineSummary(sentences []string, minWords, maxWords int) string {
+	var words []string
+	for _, sentence := range sentences {
+		words = append(words, strings.Fields(sentence)...)
+		if len(words) >= minWords {
+			break
+		}
+	}
+	if len(words) > maxWords {
+		words = words[:maxWords]
+	}
+	return strings.Join(words, " ")
+}
+
+// offlineValue builds a deterministic value conforming to schema. Strings are drawn
+// from the source sentences, chosen by the property path so different fields differ.
+func offlineValue(schema *genai.Schema, path string, sentences []string) interface{} {
+	name := strings.ToLower(path[strings.LastIndex(path, ".")+1:])
+
+	switch schema.Type {
+	case genai.TypeObject:
+		obj := make(map[string]interface{}, len(schema.Properties))
+		keys := make([]string, 0, len(schema.Properties))
+		for key := range schema.Properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			obj[key] = offlineValue(schema.Properties[key], path+"."+key, sentences)
+		}
+		return obj
+
+	case genai.TypeArray:
+		count := 3
+		if schema.MinItems != nil && int(*schema.MinItems) > count {
+			count = int(*schema.MinItems)
+		}
+		if schema.MaxItems != nil && int(*schema.MaxItems) < count {
+			count = int(*schema.MaxItems)
+		}
+		items := make([]interface{}, 0, count)
+		if schema.Items == nil {
+			return items
+		}
+		for i := 0; i < count; i++ {
+			items = append(items, offlineValue(schema.Items, fmt.Sprintf("%s[%d]", path, i), sentences))
+		}
+		return items
+
+	case genai.TypeInteger:
+		return 1
+
+	case genai.TypeNumber:
+		return 0.8
+
+	case genai.TypeBoolean:
+		return false
+
+	default:
+		if len(schema.Enum) > 0 {
+			return schema.Enum[0]
+		}
+		if len(sentences) == 0 {
+			return "Offline response."
+		}
+
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(path))
+		sentence := sentences[h.Sum32()%uint32(len(sentences))]
+
+		// Titles and names read better short
+		if strings.Contains(name, "title") || strings.Contains(name, "name") {
+			words := strings.Fields(strings.TrimRight(sentence, "."))
+			if len(words) > 6 {
+				words = words[:6]
+			}
+			return strings.Join(words, " ")
+		}
+		return sentence
+	}
+}
+
+// offlineEmbedding hashes content words into a normalized vector so texts that share
+// vocabulary land close together, which keeps offline clustering meaningful
+func offlineEmbedding(text string) []float64 {
+	embedding := make([]float64, DefaultEmbeddingDimensions)
+
+	for _, word := range offlineWordPattern.FindAllString(strings.ToLower(text), -1) {
+		if len(word) < 4 {
+			continue
+		}
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(word))
+		embedding[h.Sum32()%uint32(len(embedding))]++
+	}
+
+	var norm float64
+	for _, v := range embedding {
+		norm += v * v
+	}
+	if norm == 0 {
+		embedding[0] = 1
+		return embedding
+	}
+
+	norm = math.Sqrt(norm)
+	for i := range embedding {
+		embedding[i] /= norm
+	}
+	return embedding
+}
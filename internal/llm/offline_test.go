@@ -0,0 +1,83 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const offlineTestArticle = `Google reduced the price of Gemini Flash by 50% on April 2, 2025.
+Input now costs $0.075 per million tokens for all API customers.
+The change applies to Vertex AI deployments in 14 regions worldwide.`
+
+func TestOfflineClient_SummarizeIsDeterministic(t *testing.T) {
+	client := NewOfflineClient()
+	if !client.IsOffline() {
+		t.Fatal("Expected offline client")
+	}
+
+	prompt := "Summarize this article.\n\nArticle Content:\n" + offlineTestArticle + "\n\nSUMMARY:\n[summary]"
+	first, err := client.GenerateText(context.Background(), prompt, TextGenerationOptions{})
+	if err != nil {
+		t.Fatalf("GenerateText failed: %v", err)
+	}
+	second, _ := client.GenerateText(context.Background(), prompt, TextGenerationOptions{})
+
+	if first != second {
+		t.Errorf("Expected identical responses, got %q and %q", first, second)
+	}
+	if !strings.HasPrefix(first, "SUMMARY:\n") || !strings.Contains(first, "KEY POINTS:") {
+		t.Errorf("Expected SUMMARY/KEY POINTS sections, got %q", first)
+	}
+	if !strings.Contains(first, "Gemini Flash") || strings.Contains(first, "Summarize this article") {
+		t.Errorf("Expected summary drawn from article text only, got %q", first)
+	}
+}
+
+func TestOfflineClient_StructuredOutputMatchesSchema(t *testing.T) {
+	client := NewOfflineClient()
+	prompt := "Article Content:\n" + offlineTestArticle
+
+	response, err := client.GenerateText(context.Background(), prompt, TextGenerationOptions{ResponseSchema: KeyMomentsSchema()})
+	if err != nil {
+		t.Fatalf("GenerateText failed: %v", err)
+	}
+
+	content, err := parseKeyMoments(response)
+	if err != nil {
+		t.Fatalf("Offline response does not parse against KeyMomentsSchema: %v\n%s", err, response)
+	}
+	for _, insight := range content.Insights {
+		if insight.Emoji != "💡" {
+			t.Errorf("Expected first enum value for emoji, got %q", insight.Emoji)
+		}
+		if len(strings.Fields(insight.Title)) > 6 {
+			t.Errorf("Expected short title, got %q", insight.Title)
+		}
+	}
+}
+
+func TestOfflineEmbedding_SharedVocabularyIsCloser(t *testing.T) {
+	client := NewOfflineClient()
+
+	gemini1, _ := client.GenerateEmbedding("Gemini Flash pricing drops for developers using tokens")
+	gemini2, _ := client.GenerateEmbedding("Developers pay less per million tokens on Gemini Flash")
+	kube, err := client.GenerateEmbedding("Kubernetes release adds in-place pod resizing")
+	if err != nil {
+		t.Fatalf("GenerateEmbedding failed: %v", err)
+	}
+
+	if len(kube) != int(DefaultEmbeddingDimensions) {
+		t.Fatalf("Expected %d dimensions, got %d", DefaultEmbeddingDimensions, len(kube))
+	}
+	if CosineSimilarity(gemini1, gemini2) <= CosineSimilarity(gemini1, kube) {
+		t.Error("Expected texts with shared vocabulary to be more similar")
+	}
+}
+
+func TestOfflineClient_RejectsChatAndTools(t *testing.T) {
+	client := NewOfflineClient()
+	if _, err := client.StartChatSession(context.Background(), ""); err == nil {
+		t.Error("Expected chat sessions to be unavailable offline")
+	}
+}
@@ -0,0 +1,144 @@
+package llm
+
+import (
+	"briefly/internal/redact"
+	"sync/atomic"
+
+	"google.golang.org/genai"
+)
+
+// activeRedactor redacts every prompt sent to the API and restores every response
+// (nil = redaction off). It is process-wide so the package-level helpers that
+// create their own API client are covered as well.
+var activeRedactor atomic.Pointer[redact.Redactor]
+
+// SetRedactor turns on the redaction stage for all LLM calls: text matching its rules
+// is replaced with placeholders before it leaves the process, and placeholders in
+// responses are replaced with the originals. A nil redactor turns redaction off.
+func SetRedactor(r *redact.Redactor) {
+	activeRedactor.Store(r)
+}
+
+// ActiveRedactor returns the redactor set with SetRedactor, or nil
+func ActiveRedactor() *redact.Redactor {
+	return activeRedactor.Load()
+}
+
+// redactContents returns contents with text, function call arguments, and function
+// responses redacted. The originals are left untouched so chat history keeps them.
+func redactContents(contents []*genai.Content) []*genai.Content {
+	r := ActiveRedactor()
+	if r == nil {
+		return contents
+	}
+
+	redacted := make([]*genai.Content, len(contents))
+	for i, content := range contents {
+		redacted[i] = redactContent(r, content)
+	}
+	return redacted
+}
+
+func redactContent(r *redact.Redactor, content *genai.Content) *genai.Content {
+	if content == nil {
+		return nil
+	}
+	copied := *content
+	copied.Parts = make([]*genai.Part, len(content.Parts))
+	for i, part := range content.Parts {
+		if part == nil {
+			continue
+		}
+		p := *part
+		p.Text = r.Redact(part.Text)
+		if part.FunctionCall != nil {
+			call := *part.FunctionCall
+			call.Args = mapValues(part.FunctionCall.Args, r.Redact)
+			p.FunctionCall = &call
+		}
+		if part.FunctionResponse != nil {
+			response := *part.FunctionResponse
+			response.Response = mapValues(part.FunctionResponse.Response, r.Redact)
+			p.FunctionResponse = &response
+		}
+		copied.Parts[i] = &p
+	}
+	return &copied
+}
+
+// redactConfig returns config with its system instruction redacted
+func redactConfig(config *genai.GenerateContentConfig) *genai.GenerateContentConfig {
+	r := ActiveRedactor()
+	if r == nil || config == nil || config.SystemInstruction == nil {
+		return config
+	}
+	copied := *config
+	copied.SystemInstruction = redactContent(r, config.SystemInstruction)
+	return &copied
+}
+
+// restoreResponse puts the originals back into a response's text parts and function
+// call arguments. structured marks JSON output, whose restored values are escaped.
+func restoreResponse(resp *genai.GenerateContentResponse, structured bool) {
+	r := ActiveRedactor()
+	if r == nil || resp == nil {
+		return
+	}
+	for _, candidate := range resp.Candidates {
+		if candidate == nil || candidate.Content == nil {
+			continue
+		}
+		for _, part := range candidate.Content.Parts {
+			if part == nil {
+				continue
+			}
+			part.Text = restoreText(part.Text, structured)
+			if part.FunctionCall != nil {
+				part.FunctionCall.Args = mapValues(part.FunctionCall.Args, r.Restore)
+			}
+		}
+	}
+}
+
+// restoreText puts the originals back into generated text
+func restoreText(text string, structured bool) string {
+	if structured {
+		return ActiveRedactor().RestoreJSON(text)
+	}
+	return ActiveRedactor().Restore(text)
+}
+
+// mapValues returns a copy of m with fn applied to every string value, however deeply nested
+func mapValues(m map[string]any, fn func(string) string) map[string]any {
+	if m == nil {
+		return nil
+	}
+	mapped := make(map[string]any, len(m))
+	for key, value := range m {
+		mapped[key] = mapValue(value, fn)
+	}
+	return mapped
+}
+
+func mapValue(value any, fn func(string) string) any {
+	switch v := value.(type) {
+	case string:
+		return fn(v)
+	case map[string]any:
+		return mapValues(v, fn)
+	case []any:
+		mapped := make([]any, len(v))
+		for i, item := range v {
+			mapped[i] = mapValue(item, fn)
+		}
+		return mapped
+	case []string:
+		mapped := make([]string, len(v))
+		for i, item := range v {
+			mapped[i] = fn(item)
+		}
+		return mapped
+	default:
+		return value
+	}
+}
@@ -0,0 +1,61 @@
+package llm
+
+import (
+	"briefly/internal/redact"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestRedaction_ContentsAndResponse(t *testing.T) {
+	r, err := redact.New(nil, []string{"Acme Corp"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetRedactor(r)
+	defer SetRedactor(nil)
+
+	contents := []*genai.Content{
+		{Role: "user", Parts: []*genai.Part{{Text: "Acme Corp shipped a new SDK"}}},
+		{Role: "user", Parts: []*genai.Part{{FunctionResponse: &genai.FunctionResponse{
+			Name:     "fetch",
+			Response: map[string]any{"pages": []any{map[string]any{"text": "Acme Corp pricing"}}},
+		}}}},
+	}
+	redacted := redactContents(contents)
+
+	if text := redacted[0].Parts[0].Text; text != "REDACTED_TERM_1 shipped a new SDK" {
+		t.Errorf("unexpected redacted prompt %q", text)
+	}
+	page := redacted[1].Parts[0].FunctionResponse.Response["pages"].([]any)[0].(map[string]any)
+	if page["text"] != "REDACTED_TERM_1 pricing" {
+		t.Errorf("expected function responses redacted, got %v", page)
+	}
+	if contents[0].Parts[0].Text != "Acme Corp shipped a new SDK" {
+		t.Error("expected the caller's contents left unchanged")
+	}
+
+	resp := &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{Content: &genai.Content{Parts: []*genai.Part{
+		{Text: "REDACTED_TERM_1 launched an SDK."},
+		{FunctionCall: &genai.FunctionCall{Name: "search", Args: map[string]any{"query": "REDACTED_TERM_1 SDK"}}},
+	}}}}}
+	restoreResponse(resp, false)
+
+	if text := resp.Text(); !strings.HasPrefix(text, "Acme Corp launched") {
+		t.Errorf("expected response restored, got %q", text)
+	}
+	if query := resp.Candidates[0].Content.Parts[1].FunctionCall.Args["query"]; query != "Acme Corp SDK" {
+		t.Errorf("expected function call args restored, got %v", query)
+	}
+}
+
+func TestRedaction_OffByDefault(t *testing.T) {
+	contents := []*genai.Content{{Role: "user", Parts: []*genai.Part{{Text: "Acme Corp"}}}}
+	if got := redactContents(contents); got[0].Parts[0].Text != "Acme Corp" {
+		t.Errorf("expected no redaction without a redactor, got %q", got[0].Parts[0].Text)
+	}
+	if got := restoreText("REDACTED_TERM_1", true); got != "REDACTED_TERM_1" {
+		t.Errorf("expected text unchanged without a redactor, got %q", got)
+	}
+}
@@ -0,0 +1,197 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+)
+
+// XCharLimit is the length limit of a post on X; links count as xLinkLength
+// characters whatever their length
+const (
+	XCharLimit  = 280
+	xLinkLength = 23
+)
+
+// SocialPost is the copy of a post promoting a digest: an opening hook, three
+// bullets, and hashtags. The link is added when it is rendered.
+type SocialPost struct {
+	Hook     string   `json:"hook"`
+	Bullets  []string `json:"bullets"`
+	Hashtags []string `json:"hashtags"`
+}
+
+// SocialPosts are the LinkedIn and X/Twitter drafts for a digest
+type SocialPosts struct {
+	LinkedIn SocialPost `json:"linkedin"`
+	X        SocialPost `json:"x"`
+}
+
+// SocialPostsInput is the finished digest the posts are written from
+type SocialPostsInput struct {
+	Title         string
+	DigestContent string // The digest as published
+}
+
+// socialPostSchema returns the schema of one post; hookHint and hashtags describe
+// the platform's register
+func socialPostSchema(hookHint string, minTags, maxTags int64) *genai.Schema {
+	three := int64(3)
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"hook": {Type: genai.TypeString, Description: hookHint},
+			"bullets": {
+				Type:        genai.TypeArray,
+				Description: "The three most important stories, one short line each, naming the product, company, or number involved",
+				Items:       &genai.Schema{Type: genai.TypeString},
+				MinItems:    &three,
+				MaxItems:    &three,
+			},
+			"hashtags": {
+				Type:        genai.TypeArray,
+				Description: "Hashtags for the digest's main topics, without spaces",
+				Items:       &genai.Schema{Type: genai.TypeString},
+				MinItems:    &minTags,
+				MaxItems:    &maxTags,
+			},
+		},
+		Required: []string{"hook", "bullets", "hashtags"},
+	}
+}
+
+// SocialPostsSchema returns the response schema for LinkedIn and X post drafts
+func SocialPostsSchema() *genai.Schema {
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"linkedin": socialPostSchema("2-3 line LinkedIn opening naming this issue's most significant development, ending with 👇", 3, 5),
+			"x":        socialPostSchema("One punchy sentence (max 100 characters) naming this issue's most significant development", 1, 2),
+		},
+		Required: []string{"linkedin", "x"},
+	}
+}
+
+// GenerateSocialPosts writes LinkedIn and X post drafts from a finished digest using
+// structured output. Each post needs a hook and three bullets.
+func (c *Client) GenerateSocialPosts(ctx context.Context, input SocialPostsInput) (*SocialPosts, error) {
+	var prompt strings.Builder
+	prompt.WriteString("Write a LinkedIn post and an X/Twitter post promoting this issue of a weekly engineering digest.\n\n")
+	prompt.WriteString("Base every line on the digest below. Name the actual products, companies, and numbers involved; ")
+	prompt.WriteString("do not invent facts and avoid hype words like \"revolutionary\" or \"game-changing\". ")
+	prompt.WriteString("Do not include links; one is added after the bullets.\n\n")
+	if input.Title != "" {
+		prompt.WriteString(fmt.Sprintf("TITLE: %s\n\n", input.Title))
+	}
+	prompt.WriteString("DIGEST:\n---\n")
+	prompt.WriteString(input.DigestContent)
+	prompt.WriteString("\n---\n")
+
+	response, err := c.generateStructuredContent(ctx, prompt.String(), SocialPostsSchema())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate social posts: %w", err)
+	}
+
+	var result SocialPosts
+	if err := json.Unmarshal([]byte(cleanStructuredResponse(response)), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse social posts: %w", err)
+	}
+	for name, post := range map[string]*SocialPost{"LinkedIn": &result.LinkedIn, "X": &result.X} {
+		post.normalize()
+		if post.Hook == "" || len(post.Bullets) == 0 {
+			return nil, fmt.Errorf("incomplete %s post in response", name)
+		}
+	}
+	return &result, nil
+}
+
+// normalize trims the copy, drops list markers the model added to bullets, and
+// writes hashtags as #CamelCase words
+func (p *SocialPost) normalize() {
+	p.Hook = strings.TrimSpace(p.Hook)
+
+	bullets := p.Bullets[:0]
+	for _, bullet := range p.Bullets {
+		bullet = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(bullet), "-•*"))
+		if bullet != "" {
+			bullets = append(bullets, bullet)
+		}
+	}
+	p.Bullets = bullets
+
+	tags := p.Hashtags[:0]
+	seen := make(map[string]bool)
+	for _, tag := range p.Hashtags {
+		var b strings.Builder
+		for _, word := range strings.Fields(strings.TrimLeft(strings.TrimSpace(tag), "#")) {
+			first, size := utf8.DecodeRuneInString(word)
+			b.WriteString(strings.ToUpper(string(first)) + word[size:])
+		}
+		if b.Len() == 0 || seen[strings.ToLower(b.String())] {
+			continue
+		}
+		seen[strings.ToLower(b.String())] = true
+		tags = append(tags, "#"+b.String())
+	}
+	p.Hashtags = tags
+}
+
+// Text renders the post: hook, bullets, link, and hashtags, separated by blank lines
+func (p SocialPost) Text(link string) string {
+	var parts []string
+	if p.Hook != "" {
+		parts = append(parts, p.Hook)
+	}
+	if len(p.Bullets) > 0 {
+		bullets := make([]string, len(p.Bullets))
+		for i, bullet := range p.Bullets {
+			bullets[i] = "• " + bullet
+		}
+		parts = append(parts, strings.Join(bullets, "\n"))
+	}
+	if link != "" {
+		parts = append(parts, link)
+	}
+	if len(p.Hashtags) > 0 {
+		parts = append(parts, strings.Join(p.Hashtags, " "))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// XText renders the post within X's length limit, dropping hashtags and then
+// trailing bullets until it fits, and shortening the last bullet if it still doesn't
+func (p SocialPost) XText(link string) string {
+	post := SocialPost{Hook: p.Hook, Bullets: append([]string(nil), p.Bullets...), Hashtags: append([]string(nil), p.Hashtags...)}
+	for xLength(post.Text(link), link) > XCharLimit {
+		switch {
+		case len(post.Hashtags) > 0:
+			post.Hashtags = post.Hashtags[:len(post.Hashtags)-1]
+		case len(post.Bullets) > 1:
+			post.Bullets = post.Bullets[:len(post.Bullets)-1]
+		case len(post.Bullets) == 1:
+			over := xLength(post.Text(link), link) - XCharLimit
+			runes := []rune(post.Bullets[0])
+			if over+1 >= len(runes) {
+				post.Bullets = nil
+				continue
+			}
+			post.Bullets[0] = strings.TrimSpace(string(runes[:len(runes)-over-1])) + "…"
+		default:
+			return post.Text(link)
+		}
+	}
+	return post.Text(link)
+}
+
+// xLength is a post's length as X counts it, with link at its fixed length
+func xLength(text, link string) int {
+	length := utf8.RuneCountInString(text)
+	if link != "" && strings.Contains(text, link) {
+		length += xLinkLength - utf8.RuneCountInString(link)
+	}
+	return length
+}
@@ -0,0 +1,82 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerateSocialPosts_Offline(t *testing.T) {
+	client := NewOfflineClient()
+
+	posts, err := client.GenerateSocialPosts(context.Background(), SocialPostsInput{
+		Title:         "Weekly digest",
+		DigestContent: offlineTestArticle,
+	})
+	if err != nil {
+		t.Fatalf("GenerateSocialPosts failed: %v", err)
+	}
+	for name, post := range map[string]SocialPost{"linkedin": posts.LinkedIn, "x": posts.X} {
+		if post.Hook == "" || len(post.Bullets) != 3 || len(post.Hashtags) == 0 {
+			t.Errorf("expected a complete %s post, got %+v", name, post)
+		}
+		for _, tag := range post.Hashtags {
+			if !strings.HasPrefix(tag, "#") || strings.Contains(tag, " ") {
+				t.Errorf("expected %s hashtags without spaces, got %q", name, tag)
+			}
+		}
+	}
+}
+
+func TestSocialPost_Normalize(t *testing.T) {
+	post := SocialPost{
+		Hook:     "  Hook  ",
+		Bullets:  []string{"- First", "• Second", "  "},
+		Hashtags: []string{"machine learning", "#AI", "ai", ""},
+	}
+	post.normalize()
+	if post.Hook != "Hook" || len(post.Bullets) != 2 || post.Bullets[0] != "First" || post.Bullets[1] != "Second" {
+		t.Errorf("unexpected copy %+v", post)
+	}
+	if strings.Join(post.Hashtags, " ") != "#MachineLearning #AI" {
+		t.Errorf("unexpected hashtags %v", post.Hashtags)
+	}
+
+	text := post.Text("https://example.com/issue")
+	want := "Hook\n\n• First\n• Second\n\nhttps://example.com/issue\n\n#MachineLearning #AI"
+	if text != want {
+		t.Errorf("Text = %q, want %q", text, want)
+	}
+}
+
+func TestSocialPost_XText(t *testing.T) {
+	link := "https://example.com/a/very/long/link/to/this/weeks/issue/of/the/digest"
+	post := SocialPost{
+		Hook:     "Gemini Flash got 40% cheaper this week, and three other things shipped.",
+		Bullets:  []string{strings.Repeat("a", 90), strings.Repeat("b", 90), strings.Repeat("c", 90)},
+		Hashtags: []string{"#AI", "#LLMs"},
+	}
+
+	text := post.XText(link)
+	if got := xLength(text, link); got > XCharLimit {
+		t.Fatalf("expected at most %d characters, got %d: %q", XCharLimit, got, text)
+	}
+	if !strings.Contains(text, link) || !strings.HasPrefix(text, post.Hook) {
+		t.Errorf("expected the hook and link kept, got %q", text)
+	}
+	if strings.Contains(text, "#AI") || strings.Contains(text, "ccc") {
+		t.Errorf("expected hashtags and the last bullet dropped first, got %q", text)
+	}
+	if len(post.Hashtags) != 2 || len(post.Bullets) != 3 {
+		t.Error("expected XText to leave the post unchanged")
+	}
+
+	short := SocialPost{Hook: "Short", Bullets: []string{"One"}, Hashtags: []string{"#AI"}}
+	if got := short.XText(link); got != short.Text(link) {
+		t.Errorf("expected a short post unchanged, got %q", got)
+	}
+	if utf8.RuneCountInString(post.XText("")) > XCharLimit {
+		t.Error("expected the limit applied without a link")
+	}
+}
@@ -0,0 +1,189 @@
+package llm
+
+import (
+	"briefly/internal/core"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// formatSummaryResponse mirrors FormatSummarySchema
+type formatSummaryResponse struct {
+	Summary     string           `json:"summary"`
+	MainInsight string           `json:"main_insight"`
+	KeyPoints   []string         `json:"key_points"`
+	Entities    []string         `json:"entities"`
+	Stats       []core.Statistic `json:"stats"`
+}
+
+// keyMomentsResponse mirrors KeyMomentsSchema
+type keyMomentsResponse struct {
+	ExecutiveSummary string                `json:"executive_summary"`
+	Insights         []core.ArticleInsight `json:"insights"`
+}
+
+// statisticSchema describes a single metric with its context
+func statisticSchema() *genai.Schema {
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"stat": {
+				Type:        genai.TypeString,
+				Description: "The exact metric value as written in the article (e.g., \"40%\", \"$1.5M\", \"768 dimensions\")",
+			},
+			"context": {
+				Type:        genai.TypeString,
+				Description: "Brief context explaining what the metric measures",
+			},
+		},
+		Required: []string{"stat", "context"},
+	}
+}
+
+// FormatSummarySchema returns the response schema for format-aware article summaries
+func FormatSummarySchema() *genai.Schema {
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"summary": {
+				Type:        genai.TypeString,
+				Description: "The summary text, following the word budget for the requested format",
+			},
+			"main_insight": {
+				Type:        genai.TypeString,
+				Description: "The core takeaway in one sentence",
+			},
+			"key_points": {
+				Type:        genai.TypeArray,
+				Description: "3-5 specific key points, each stating one concrete fact",
+				Items:       &genai.Schema{Type: genai.TypeString},
+			},
+			"entities": {
+				Type:        genai.TypeArray,
+				Description: "People, companies, and products named in the article",
+				Items:       &genai.Schema{Type: genai.TypeString},
+			},
+			"stats": {
+				Type:        genai.TypeArray,
+				Description: "Exact numbers and metrics mentioned in the article (empty if none)",
+				Items:       statisticSchema(),
+			},
+		},
+		Required: []string{"summary", "key_points"},
+	}
+}
+
+// KeyMomentsSchema returns the response schema for key-moments extraction
+func KeyMomentsSchema() *genai.Schema {
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"executive_summary": {
+				Type:        genai.TypeString,
+				Description: "Concise 2-3 sentence summary capturing the main topic and key takeaway",
+			},
+			"insights": {
+				Type:        genai.TypeArray,
+				Description: "The 3-4 most impactful moments, each covering a different aspect of the story",
+				Items: &genai.Schema{
+					Type: genai.TypeObject,
+					Properties: map[string]*genai.Schema{
+						"title": {
+							Type:        genai.TypeString,
+							Description: "Short descriptive title categorizing the insight (e.g., \"Performance Breakthrough\")",
+						},
+						"emoji": {
+							Type:        genai.TypeString,
+							Description: "Category emoji",
+							Enum:        []string{"💡", "📊", "🚀", "🔍", "⚡", "🔒", "💰"},
+						},
+						"quote": {
+							Type:        genai.TypeString,
+							Description: "An EXACT quote from the article, 1-2 sentences maximum",
+						},
+						"why_it_matters": {
+							Type:        genai.TypeString,
+							Description: "One clear sentence explaining the significance",
+						},
+					},
+					Required: []string{"title", "emoji", "quote", "why_it_matters"},
+				},
+			},
+		},
+		Required: []string{"executive_summary", "insights"},
+	}
+}
+
+// cleanStructuredResponse strips markdown code fences some models add around JSON
+func cleanStructuredResponse(response string) string {
+	cleaned := strings.TrimSpace(response)
+	cleaned = strings.TrimPrefix(cleaned, "```json")
+	cleaned = strings.TrimPrefix(cleaned, "```")
+	cleaned = strings.TrimSuffix(cleaned, "```")
+	return strings.TrimSpace(cleaned)
+}
+
+// parseFormatSummary decodes a FormatSummarySchema response into summary text
+// and typed structured content
+func parseFormatSummary(response string) (string, *core.StructuredSummaryContent, error) {
+	var parsed formatSummaryResponse
+	if err := json.Unmarshal([]byte(cleanStructuredResponse(response)), &parsed); err != nil {
+		return "", nil, fmt.Errorf("failed to parse structured summary JSON: %w", err)
+	}
+
+	summary := strings.TrimSpace(parsed.Summary)
+	if summary == "" {
+		return "", nil, fmt.Errorf("structured summary has no summary text")
+	}
+
+	return summary, &core.StructuredSummaryContent{
+		KeyPoints:   parsed.KeyPoints,
+		MainInsight: parsed.MainInsight,
+		Entities:    parsed.Entities,
+		Stats:       parsed.Stats,
+	}, nil
+}
+
+// parseKeyMoments decodes a KeyMomentsSchema response into structured content
+func parseKeyMoments(response string) (*core.StructuredSummaryContent, error) {
+	var parsed keyMomentsResponse
+	if err := json.Unmarshal([]byte(cleanStructuredResponse(response)), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse key moments JSON: %w", err)
+	}
+
+	if strings.TrimSpace(parsed.ExecutiveSummary) == "" {
+		return nil, fmt.Errorf("key moments response has no executive summary")
+	}
+	if len(parsed.Insights) == 0 {
+		return nil, fmt.Errorf("key moments response has no insights")
+	}
+
+	return &core.StructuredSummaryContent{
+		MainInsight: strings.TrimSpace(parsed.ExecutiveSummary),
+		Insights:    parsed.Insights,
+	}, nil
+}
+
+// RenderKeyMoments renders key-moments content in the markdown layout used by
+// earlier free-text summaries, so existing consumers of SummaryText keep working
+func RenderKeyMoments(content *core.StructuredSummaryContent) string {
+	var b strings.Builder
+
+	b.WriteString("# Executive Summary\n\n")
+	b.WriteString(content.MainInsight)
+	b.WriteString("\n\n# Key Insights\n")
+
+	for _, insight := range content.Insights {
+		emoji := insight.Emoji
+		if emoji == "" {
+			emoji = "💡"
+		}
+		b.WriteString(fmt.Sprintf("\n## %s %s\n", emoji, insight.Title))
+		b.WriteString(fmt.Sprintf("> \"%s\"\n\n", strings.Trim(insight.Quote, "\" ")))
+		b.WriteString(fmt.Sprintf("**Why it matters:** %s\n", insight.WhyItMatters))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFormatSummary(t *testing.T) {
+	response := "```json\n" + `{
+		"summary": "Anthropic released Claude with a 200K context window.",
+		"main_insight": "Longer context is now standard.",
+		"key_points": ["200K tokens", "Available via API"],
+		"entities": ["Anthropic", "Claude"],
+		"stats": [{"stat": "200K", "context": "context window tokens"}]
+	}` + "\n```"
+
+	text, content, err := parseFormatSummary(response)
+	if err != nil {
+		t.Fatalf("parseFormatSummary failed: %v", err)
+	}
+
+	if text != "Anthropic released Claude with a 200K context window." {
+		t.Errorf("Unexpected summary text: %q", text)
+	}
+	if len(content.KeyPoints) != 2 || len(content.Entities) != 2 {
+		t.Errorf("Expected key points and entities to be decoded, got %+v", content)
+	}
+	if len(content.Stats) != 1 || content.Stats[0].Stat != "200K" {
+		t.Errorf("Expected typed stats, got %v", content.Stats)
+	}
+}
+
+func TestParseFormatSummary_Invalid(t *testing.T) {
+	if _, _, err := parseFormatSummary("Just a plain text summary."); err == nil {
+		t.Error("Expected error for non-JSON response")
+	}
+	if _, _, err := parseFormatSummary(`{"summary": "", "key_points": []}`); err == nil {
+		t.Error("Expected error for empty summary")
+	}
+}
+
+func TestParseKeyMomentsAndRender(t *testing.T) {
+	response := `{
+		"executive_summary": "Gemini 2.5 cuts latency by 40%.",
+		"insights": [
+			{"title": "Performance Breakthrough", "emoji": "⚡", "quote": "Latency dropped 40%.", "why_it_matters": "Real-time apps become viable."},
+			{"title": "Pricing", "emoji": "💰", "quote": "\"Prices fall by half.\"", "why_it_matters": "Cheaper experimentation."}
+		]
+	}`
+
+	content, err := parseKeyMoments(response)
+	if err != nil {
+		t.Fatalf("parseKeyMoments failed: %v", err)
+	}
+	if len(content.Insights) != 2 {
+		t.Fatalf("Expected 2 insights, got %d", len(content.Insights))
+	}
+
+	rendered := RenderKeyMoments(content)
+	for _, want := range []string{
+		"# Executive Summary\n\nGemini 2.5 cuts latency by 40%.",
+		"## ⚡ Performance Breakthrough",
+		"> \"Prices fall by half.\"",
+		"**Why it matters:** Cheaper experimentation.",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Rendered key moments missing %q:\n%s", want, rendered)
+		}
+	}
+
+	if _, err := parseKeyMoments(`{"executive_summary": "x", "insights": []}`); err == nil {
+		t.Error("Expected error when no insights are returned")
+	}
+}
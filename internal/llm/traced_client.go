@@ -66,15 +66,14 @@ func (tc *TracedClient) GenerateText(ctx context.Context, prompt string, options
 			model = tc.client.modelName
 		}
 
-		_ = tc.langfuse.TrackGeneration(trace, observability.GenerationOptions{
+		_ = tc.langfuse.TrackGeneration(trace, withEstimatedUsage(observability.GenerationOptions{
 			Model:       model,
 			Prompt:      prompt,
 			Completion:  result,
 			Temperature: options.Temperature,
 			MaxTokens:   options.MaxTokens,
-			TotalTokens: estimateTokens(prompt, result),
 			LatencyMs:   latencyMs,
-		})
+		}))
 	}
 
 	// Track in PostHog for analytics
@@ -111,13 +110,12 @@ func (tc *TracedClient) GenerateEmbedding(text string) ([]float64, error) {
 	latencyMs := time.Since(startTime).Milliseconds()
 
 	if trace != nil {
-		_ = tc.langfuse.TrackGeneration(trace, observability.GenerationOptions{
-			Model:       DefaultEmbeddingModel,
-			Prompt:      text,
-			Completion:  "embedding_vector",
-			TotalTokens: estimateTokens(text, ""),
-			LatencyMs:   latencyMs,
-		})
+		_ = tc.langfuse.TrackGeneration(trace, withEstimatedUsage(observability.GenerationOptions{
+			Model:      DefaultEmbeddingModel,
+			Prompt:     text,
+			Completion: "embedding_vector",
+			LatencyMs:  latencyMs,
+		}))
 	}
 
 	if tc.posthog.IsEnabled() {
@@ -152,13 +150,12 @@ func (tc *TracedClient) SummarizeArticleText(article core.Article) (core.Summary
 	latencyMs := time.Since(startTime).Milliseconds()
 
 	if trace != nil {
-		_ = tc.langfuse.TrackGeneration(trace, observability.GenerationOptions{
-			Model:       tc.client.modelName,
-			Prompt:      article.CleanedText,
-			Completion:  result.SummaryText,
-			TotalTokens: estimateTokens(article.CleanedText, result.SummaryText),
-			LatencyMs:   latencyMs,
-		})
+		_ = tc.langfuse.TrackGeneration(trace, withEstimatedUsage(observability.GenerationOptions{
+			Model:      tc.client.modelName,
+			Prompt:     article.CleanedText,
+			Completion: result.SummaryText,
+			LatencyMs:  latencyMs,
+		}))
 	}
 
 	if tc.posthog.IsEnabled() {
@@ -194,13 +191,12 @@ func (tc *TracedClient) SummarizeArticleTextWithFormat(article core.Article, for
 	latencyMs := time.Since(startTime).Milliseconds()
 
 	if trace != nil {
-		_ = tc.langfuse.TrackGeneration(trace, observability.GenerationOptions{
-			Model:       tc.client.modelName,
-			Prompt:      article.CleanedText,
-			Completion:  result.SummaryText,
-			TotalTokens: estimateTokens(article.CleanedText, result.SummaryText),
-			LatencyMs:   latencyMs,
-		})
+		_ = tc.langfuse.TrackGeneration(trace, withEstimatedUsage(observability.GenerationOptions{
+			Model:      tc.client.modelName,
+			Prompt:     article.CleanedText,
+			Completion: result.SummaryText,
+			LatencyMs:  latencyMs,
+		}))
 	}
 
 	if tc.posthog.IsEnabled() {
@@ -239,13 +235,12 @@ func (tc *TracedClient) CategorizeArticle(ctx context.Context, article core.Arti
 			completion += " (reason: " + result.Reasoning + ")"
 		}
 
-		_ = tc.langfuse.TrackGeneration(trace, observability.GenerationOptions{
-			Model:       tc.client.modelName,
-			Prompt:      article.CleanedText,
-			Completion:  completion,
-			TotalTokens: estimateTokens(article.CleanedText, completion),
-			LatencyMs:   latencyMs,
-		})
+		_ = tc.langfuse.TrackGeneration(trace, withEstimatedUsage(observability.GenerationOptions{
+			Model:      tc.client.modelName,
+			Prompt:     article.CleanedText,
+			Completion: completion,
+			LatencyMs:  latencyMs,
+		}))
 	}
 
 	if tc.posthog.IsEnabled() {
@@ -274,6 +269,16 @@ func estimateTokens(prompt, completion string) int {
 	return (len(prompt) + len(completion)) / 4
 }
 
+// withEstimatedUsage fills a generation's token counts, estimated from its text, and
+// prices them with EstimateCost
+func withEstimatedUsage(opts observability.GenerationOptions) observability.GenerationOptions {
+	opts.PromptTokens = estimateTokens(opts.Prompt, "")
+	opts.CompletionTokens = estimateTokens("", opts.Completion)
+	opts.TotalTokens = opts.PromptTokens + opts.CompletionTokens
+	opts.TotalCost = EstimateCost(opts.Model, opts.PromptTokens, opts.CompletionTokens)
+	return opts
+}
+
 // Passthrough methods that don't need special tracing
 // These delegate directly to the underlying client
 
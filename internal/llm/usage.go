@@ -0,0 +1,161 @@
+package llm
+
+import (
+	"briefly/internal/core"
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// Attribution identifies what an LLM call was made for. It travels in the context
+// so calls deep inside summarizers and narrative generators are still attributed
+// to their article, summary, or digest and to a pipeline phase.
+type Attribution struct {
+	ArticleID string
+	SummaryID string
+	DigestID  string
+	Phase     string // e.g. summarize, embedding, classify, narrative, digest
+}
+
+// UsageRecorder receives one record per LLM call made by a Client
+type UsageRecorder func(ctx context.Context, call core.LLMCall)
+
+type attributionKey struct{}
+type retryKey struct{}
+
+// WithAttribution returns a context whose LLM calls are attributed to a. Empty
+// fields keep the values of any attribution already in ctx.
+func WithAttribution(ctx context.Context, a Attribution) context.Context {
+	current := AttributionFrom(ctx)
+	if a.ArticleID == "" {
+		a.ArticleID = current.ArticleID
+	}
+	if a.SummaryID == "" {
+		a.SummaryID = current.SummaryID
+	}
+	if a.DigestID == "" {
+		a.DigestID = current.DigestID
+	}
+	if a.Phase == "" {
+		a.Phase = current.Phase
+	}
+	return context.WithValue(ctx, attributionKey{}, a)
+}
+
+// AttributionFrom returns the attribution carried by ctx, if any
+func AttributionFrom(ctx context.Context) Attribution {
+	a, _ := ctx.Value(attributionKey{}).(Attribution)
+	return a
+}
+
+// WithRetry marks calls made with ctx as a retry; attempt counts the attempts
+// before this one (0 = first attempt)
+func WithRetry(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, retryKey{}, attempt)
+}
+
+// SetUsageRecorder registers a recorder for every call this client makes
+func (c *Client) SetUsageRecorder(recorder UsageRecorder) {
+	c.usageRecorder = recorder
+}
+
+// recordUsage reports a finished call to the usage recorder. Token counts come from
+// the response's usage metadata when available and are estimated otherwise.
+func (c *Client) recordUsage(ctx context.Context, model, phase string, usage *genai.GenerateContentResponseUsageMetadata, prompt, completion string, start time.Time, callErr error) {
+	if c.usageRecorder == nil {
+		return
+	}
+
+	a := AttributionFrom(ctx)
+	if a.Phase != "" {
+		phase = a.Phase
+	}
+	retries, _ := ctx.Value(retryKey{}).(int)
+
+	call := core.LLMCall{
+		ArticleID: a.ArticleID,
+		SummaryID: a.SummaryID,
+		DigestID:  a.DigestID,
+		Phase:     phase,
+		Model:     model,
+		LatencyMs: time.Since(start).Milliseconds(),
+		Retries:   retries,
+		CreatedAt: time.Now().UTC(),
+	}
+	if c.offline {
+		call.Model = OfflineModel
+	}
+
+	if usage != nil {
+		call.TokensIn = int(usage.PromptTokenCount)
+		call.TokensOut = int(usage.CandidatesTokenCount + usage.ThoughtsTokenCount)
+	} else {
+		call.TokensIn = estimateTokens(prompt, "")
+		call.TokensOut = estimateTokens(completion, "")
+	}
+	if callErr != nil {
+		call.Error = callErr.Error()
+	}
+
+	c.usageRecorder(ctx, call)
+}
+
+// recordResponse records a GenerateContent call, using the response's usage metadata
+func (c *Client) recordResponse(ctx context.Context, model, phase string, resp *genai.GenerateContentResponse, prompt string, start time.Time, callErr error) {
+	if c.usageRecorder == nil {
+		return
+	}
+	var usage *genai.GenerateContentResponseUsageMetadata
+	completion := ""
+	if resp != nil {
+		usage = resp.UsageMetadata
+		completion = resp.Text()
+	}
+	c.recordUsage(ctx, model, phase, usage, prompt, completion, start, callErr)
+}
+
+// modelPrice is a model's price in USD per million tokens
+type modelPrice struct {
+	prefix string
+	input  float64
+	output float64
+}
+
+// modelPrices lists list prices by model name prefix; more specific prefixes come first
+var modelPrices = []modelPrice{
+	{"gemini-3-pro", 2.00, 12.00},
+	{"gemini-3-flash", 0.50, 3.00},
+	{"gemini-2.5-pro", 1.25, 10.00},
+	{"gemini-2.5-flash-lite", 0.10, 0.40},
+	{"gemini-2.5-flash", 0.30, 2.50},
+	{"gemini-2.0-flash-lite", 0.075, 0.30},
+	{"gemini-2.0-flash", 0.10, 0.40},
+	{"gemini-flash-lite", 0.10, 0.40},
+	{"gemini-embedding", 0.15, 0},
+	{"text-embedding", 0.15, 0},
+}
+
+// EstimateCost estimates the USD cost of a call from its token counts. Unknown models
+// are priced like the default model; offline calls are free.
+func EstimateCost(model string, tokensIn, tokensOut int) float64 {
+	if model == OfflineModel {
+		return 0
+	}
+
+	price, ok := priceFor(model)
+	if !ok {
+		price, _ = priceFor(DefaultModel)
+	}
+	return float64(tokensIn)/1_000_000*price.input + float64(tokensOut)/1_000_000*price.output
+}
+
+func priceFor(model string) (modelPrice, bool) {
+	for _, p := range modelPrices {
+		if strings.HasPrefix(model, p.prefix) {
+			return p, true
+		}
+	}
+	return modelPrice{}, false
+}
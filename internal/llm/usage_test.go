@@ -0,0 +1,65 @@
+package llm
+
+import (
+	"briefly/internal/core"
+	"context"
+	"math"
+	"testing"
+)
+
+func TestUsageRecorder_AttributesCalls(t *testing.T) {
+	client := NewOfflineClient()
+	var calls []core.LLMCall
+	client.SetUsageRecorder(func(ctx context.Context, call core.LLMCall) {
+		calls = append(calls, call)
+	})
+
+	ctx := WithAttribution(context.Background(), Attribution{DigestID: "digest-1"})
+	ctx = WithAttribution(ctx, Attribution{ArticleID: "article-1", Phase: "narrative"})
+	if _, err := client.GenerateText(WithRetry(ctx, 2), "Article Content:\n"+offlineTestArticle, TextGenerationOptions{}); err != nil {
+		t.Fatalf("GenerateText failed: %v", err)
+	}
+	if _, err := client.GenerateEmbeddingContext(WithAttribution(context.Background(), Attribution{ArticleID: "article-2"}), offlineTestArticle); err != nil {
+		t.Fatalf("GenerateEmbeddingContext failed: %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 recorded calls, got %d", len(calls))
+	}
+
+	text := calls[0]
+	if text.DigestID != "digest-1" || text.ArticleID != "article-1" || text.Phase != "narrative" {
+		t.Errorf("Expected attribution to be merged, got %+v", text)
+	}
+	if text.Retries != 2 {
+		t.Errorf("Expected 2 retries, got %d", text.Retries)
+	}
+	if text.TokensIn == 0 || text.TokensOut == 0 {
+		t.Errorf("Expected estimated token counts, got in=%d out=%d", text.TokensIn, text.TokensOut)
+	}
+	if text.Model != OfflineModel {
+		t.Errorf("Expected offline model, got %q", text.Model)
+	}
+
+	embedding := calls[1]
+	if embedding.ArticleID != "article-2" || embedding.Phase != "embedding" || embedding.DigestID != "" {
+		t.Errorf("Expected embedding call attributed to article-2, got %+v", embedding)
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	// gemini-2.5-flash: $0.30 in, $2.50 out per million tokens
+	if got := EstimateCost("gemini-2.5-flash", 1_000_000, 1_000_000); math.Abs(got-2.80) > 1e-9 {
+		t.Errorf("gemini-2.5-flash cost = %f, want 2.80", got)
+	}
+	// The lite variant must not be priced as gemini-2.5-flash
+	if got := EstimateCost("gemini-2.5-flash-lite", 1_000_000, 0); math.Abs(got-0.10) > 1e-9 {
+		t.Errorf("gemini-2.5-flash-lite cost = %f, want 0.10", got)
+	}
+	if got := EstimateCost("some-new-model", 1_000_000, 0); got != EstimateCost(DefaultModel, 1_000_000, 0) {
+		t.Errorf("Unknown model should be priced like %s, got %f", DefaultModel, got)
+	}
+	if got := EstimateCost(OfflineModel, 1_000_000, 1_000_000); got != 0 {
+		t.Errorf("Offline calls should be free, got %f", got)
+	}
+}
@@ -0,0 +1,52 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// DescribeImage asks the vision model to describe a chart or figure taken from an
+// article, so readers get the figure's takeaway without opening it. articleContext
+// (title and opening text) helps the model interpret axes and labels.
+func (c *Client) DescribeImage(ctx context.Context, data []byte, mimeType string, articleContext string) (string, error) {
+	if c.offline {
+		return "", fmt.Errorf("image description is not available in offline mode")
+	}
+
+	prompt := fmt.Sprintf(`This image comes from an article. Describe what it shows for a reader who cannot see it.
+
+Article context:
+%s
+
+If it is a chart, graph, benchmark, or table: state what is being compared, the units, and the headline result with key numbers (e.g., "Model A scores 82%% vs 71%% for Model B on MMLU").
+If it is a diagram: summarize the structure or flow it depicts.
+If it is a photo or decorative image with no information content, reply with exactly: NONE
+
+Write 1-3 sentences, plain text, no preamble.`, strings.TrimSpace(articleContext))
+
+	contents := []*genai.Content{{
+		Parts: []*genai.Part{
+			{InlineData: &genai.Blob{MIMEType: mimeType, Data: data}},
+			{Text: prompt},
+		},
+		Role: "user",
+	}}
+
+	start := time.Now()
+	resp, err := c.gClient.Models.GenerateContent(ctx, c.modelName, redactContents(contents), c.pinSampling(nil))
+	restoreResponse(resp, false)
+	c.recordResponse(ctx, c.modelName, "vision", resp, prompt, start, err)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe image: %w", err)
+	}
+
+	text := strings.TrimSpace(resp.Text())
+	if text == "" || strings.EqualFold(strings.Trim(text, ". "), "NONE") {
+		return "", nil
+	}
+	return text, nil
+}
@@ -11,11 +11,12 @@ var (
 	once          sync.Once
 )
 
-// Init initializes the default logger with a JSON handler writing to os.Stdout.
+// Init initializes the default logger with a JSON handler writing to os.Stderr,
+// keeping stdout clean for command output and shell completion.
 // It ensures that the logger is initialized only once.
 func Init() {
 	once.Do(func() {
-		defaultLogger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		defaultLogger = slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
 			Level: slog.LevelDebug, // Default to Debug level, can be made configurable
 		}))
 		slog.SetDefault(defaultLogger) // Optionally set as the default logger for the slog package
@@ -45,7 +45,7 @@ func (g *Generator) RefineDigestWithCritique(
 	// Generate critique and improved digest using structured output
 	schema := g.buildCritiqueSchema()
 
-	response, err := g.llmClient.GenerateText(ctx, prompt, llm.TextGenerationOptions{
+	response, err := g.llmClient.GenerateText(llm.WithAttribution(ctx, llm.Attribution{Phase: "digest"}), prompt, llm.TextGenerationOptions{
 		ResponseSchema: schema,
 		Temperature:    0.7,
 		MaxTokens:      8192, // Max tokens to ensure complete JSON output
@@ -71,6 +71,7 @@ func (g *Generator) buildCritiquePrompt(
 	summaries map[string]core.Summary,
 ) string {
 	var prompt strings.Builder
+	g.writeAudience(&prompt)
 
 	prompt.WriteString("Review and improve this digest by critiquing against the source material.\n\n")
 
@@ -0,0 +1,114 @@
+package narrative
+
+import (
+	"briefly/internal/core"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var figureDigits = regexp.MustCompile(`\d+`)
+
+// citedFigures maps the citation number of each article listed for citation to the
+// figures on its summary's fact sheet. Articles summarized without a fact sheet are
+// left out, so their statistics can't be checked.
+func citedFigures(clusters []core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) map[int][]core.Fact {
+	figures := make(map[int][]core.Fact)
+	articleNum := 1
+	for _, cluster := range clusters {
+		for _, articleID := range cluster.ArticleIDs {
+			if _, found := articles[articleID]; !found {
+				continue
+			}
+			if facts := summaries[articleID].Facts; facts != nil {
+				figures[articleNum] = facts.Numbers
+			}
+			articleNum++
+		}
+	}
+	return figures
+}
+
+// factCheckedStatistics drops statistics whose figure isn't on the fact sheet of
+// any article they cite. Stats citing only articles without a fact sheet, or with
+// no digits to compare, are kept as they are.
+func factCheckedStatistics(stats []Statistic, figures map[int][]core.Fact) []Statistic {
+	if len(figures) == 0 {
+		return stats
+	}
+	checked := make([]Statistic, 0, len(stats))
+	for _, stat := range stats {
+		if err := checkStatisticFigure(stat, figures); err != nil {
+			log.Printf("[WARN] Dropping statistic: %v", err)
+			continue
+		}
+		checked = append(checked, stat)
+	}
+	return checked
+}
+
+// factCheckedKeyStats is factCheckedStatistics for a cluster narrative's key stats
+func factCheckedKeyStats(stats []core.Statistic, figures map[int][]core.Fact) []core.Statistic {
+	if len(figures) == 0 {
+		return stats
+	}
+	checked := make([]core.Statistic, 0, len(stats))
+	for _, stat := range stats {
+		if err := checkStatisticFigure(Statistic(stat), figures); err != nil {
+			log.Printf("[WARN] Dropping cluster key stat: %v", err)
+			continue
+		}
+		checked = append(checked, stat)
+	}
+	return checked
+}
+
+// checkStatisticFigure checks a stat's figure against the fact sheets it cites
+func checkStatisticFigure(stat Statistic, figures map[int][]core.Fact) error {
+	digits := figureDigits.FindAllString(stat.Stat, -1)
+	if len(digits) == 0 {
+		return nil
+	}
+
+	checkable := false
+	for _, ref := range citationRef.FindAllStringSubmatch(stat.Context, -1) {
+		num, _ := strconv.Atoi(ref[1])
+		facts, ok := figures[num]
+		if !ok {
+			continue
+		}
+		checkable = true
+		for _, fact := range facts {
+			if containsAll(fact.Value+" "+fact.Quote, digits) {
+				return nil
+			}
+		}
+	}
+	if checkable {
+		return fmt.Errorf("%q is not on the fact sheet of the articles it cites", stat.Stat)
+	}
+	return nil
+}
+
+// containsAll reports whether text contains every one of parts
+func containsAll(text string, parts []string) bool {
+	for _, part := range parts {
+		if !strings.Contains(text, part) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeFigures lists an article's fact-checked figures under it in a prompt
+func writeFigures(prompt *strings.Builder, facts *core.FactSheet) {
+	if facts == nil || len(facts.Numbers) == 0 {
+		return
+	}
+	prompt.WriteString("    Verified Figures (quote-backed; use only these for this article's stats):\n")
+	for _, fact := range facts.Numbers {
+		prompt.WriteString(fmt.Sprintf("    - %s: %s\n", fact.Value, fact.Text))
+	}
+}
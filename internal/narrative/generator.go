@@ -3,11 +3,14 @@ package narrative
 import (
 	"briefly/internal/core"
 	"briefly/internal/llm"
+	"briefly/internal/quality"
+	"briefly/internal/textutil"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"sort"
+	"strconv"
 	"strings"
 
 	"google.golang.org/genai"
@@ -40,6 +43,10 @@ type LLMClient interface {
 // Generator creates executive summaries from clustered articles
 type Generator struct {
 	llmClient LLMClient
+	searcher  WebSearcher   // Optional: finds outside viewpoints for the perspectives pass
+	audience  core.Audience // Optional: who the digest is written for
+
+	contextBudget int // Token budget for cluster narratives in the digest prompt (0 = DefaultContextBudget)
 }
 
 // NewGenerator creates a new narrative generator
@@ -49,6 +56,39 @@ func NewGenerator(llmClient LLMClient) *Generator {
 	}
 }
 
+// SetAudience tunes the depth and vocabulary of every digest prompt for audience.
+// The empty audience keeps the prompts' default framing.
+func (g *Generator) SetAudience(audience core.Audience) {
+	g.audience = audience
+}
+
+// writeAudience opens a prompt with the audience guidance, if an audience is set
+func (g *Generator) writeAudience(prompt *strings.Builder) {
+	if guidance := g.audience.Guidance(); guidance != "" {
+		prompt.WriteString(guidance)
+		prompt.WriteString("Write every part of your output for this audience.\n\n")
+	}
+}
+
+// Prompt versions recorded in digest provenance. Bump the matching version whenever a
+// prompt or its schema changes in a way that affects output.
+const (
+	clusterNarrativePromptVersion = "v3"
+	digestContentPromptVersion    = "v5"
+	critiquePromptVersion         = "v1"
+	perspectivesPromptVersion     = "v1"
+)
+
+// PromptVersions returns the version of each prompt the generator uses, keyed by prompt
+func PromptVersions() map[string]string {
+	return map[string]string{
+		"cluster_narrative": clusterNarrativePromptVersion,
+		"digest_content":    digestContentPromptVersion,
+		"critique":          critiquePromptVersion,
+		"perspectives":      perspectivesPromptVersion,
+	}
+}
+
 // Statistic represents a key metric or data point for the "By the Numbers" section
 type Statistic struct {
 	Stat    string `json:"stat"`    // The metric value (e.g., "60%", "400 Gbps", "12 articles")
@@ -111,7 +151,16 @@ type ThreadItem struct {
 // GenerateClusterSummary generates a comprehensive narrative for a single cluster using ALL articles
 // This implements hierarchical summarization: cluster summary → executive summary
 func (g *Generator) GenerateClusterSummary(ctx context.Context, cluster core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) (*core.ClusterNarrative, error) {
-	// Collect all articles in this cluster
+	clusterArticles := g.collectClusterArticles(cluster, articles, summaries)
+	if len(clusterArticles) == 0 {
+		return nil, fmt.Errorf("no articles found for cluster %s", cluster.Label)
+	}
+
+	return g.generateClusterNarrative(ctx, cluster, clusterArticles)
+}
+
+// collectClusterArticles gathers the summarized articles of a cluster in cluster order
+func (g *Generator) collectClusterArticles(cluster core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) []ArticleSummary {
 	clusterArticles := make([]ArticleSummary, 0, len(cluster.ArticleIDs))
 
 	for _, articleID := range cluster.ArticleIDs {
@@ -127,18 +176,19 @@ func (g *Generator) GenerateClusterSummary(ctx context.Context, cluster core.Top
 			URL:       article.URL,
 			Summary:   summary.SummaryText,
 			KeyPoints: g.extractKeyPoints(summary),
+			Facts:     summary.Facts,
 		})
 	}
 
-	if len(clusterArticles) == 0 {
-		return nil, fmt.Errorf("no articles found for cluster %s", cluster.Label)
-	}
+	return clusterArticles
+}
 
-	// Generate cluster narrative using LLM
-	prompt := g.buildClusterSummaryPrompt(cluster.Label, clusterArticles)
+// generateClusterNarrative asks the LLM for one cluster's narrative from clusterArticles
+func (g *Generator) generateClusterNarrative(ctx context.Context, cluster core.TopicCluster, clusterArticles []ArticleSummary) (*core.ClusterNarrative, error) {
+	prompt := g.buildClusterSummaryPrompt(cluster.Label, cluster.Keywords, clusterArticles)
 	schema := g.buildClusterNarrativeSchema()
 
-	response, err := g.llmClient.GenerateText(ctx, prompt, llm.TextGenerationOptions{
+	response, err := g.llmClient.GenerateText(llm.WithAttribution(ctx, llm.Attribution{Phase: "narrative"}), prompt, llm.TextGenerationOptions{
 		ResponseSchema: schema,
 		Temperature:    0.7,
 		MaxTokens:      8192, // Max tokens to ensure complete JSON output
@@ -152,6 +202,18 @@ func (g *Generator) GenerateClusterSummary(ctx context.Context, cluster core.Top
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse cluster narrative: %w", err)
 	}
+	if len(narrative.KeyThemes) == 0 {
+		narrative.KeyThemes = cluster.Keywords
+	}
+
+	// Key stats cite articles by their number in this cluster's prompt
+	figures := make(map[int][]core.Fact)
+	for i, article := range clusterArticles {
+		if article.Facts != nil {
+			figures[i+1] = article.Facts.Numbers
+		}
+	}
+	narrative.KeyStats = factCheckedKeyStats(narrative.KeyStats, figures)
 
 	return narrative, nil
 }
@@ -213,6 +275,7 @@ func (g *Generator) GenerateDigestContentWithCritique(ctx context.Context, clust
 			}
 
 			finalDigest = critiqueResult.ImprovedDigest
+			finalDigest.ByTheNumbers = factCheckedStatistics(citedStatistics(finalDigest.ByTheNumbers, countCitableArticles(clusters, articles)), citedFigures(clusters, articles, summaries))
 			break
 		}
 
@@ -254,11 +317,21 @@ func (g *Generator) GenerateDigestContent(ctx context.Context, clusters []core.T
 	}
 
 	var prompt string
+	var citable int // Number of articles the prompt lists for citation
 	schema := g.buildDigestContentSchema()
 
 	if hasNarratives {
 		// NEW: Use hierarchical summarization with cluster narratives
-		prompt = g.buildNarrativePromptFromClusters(clusters, articles, summaries)
+		packed, report := g.PackClusterNarratives(ctx, clusters, articles)
+		if len(report.Condensed) > 0 || len(report.Trimmed) > 0 {
+			fmt.Printf("   ✓ Packed cluster narratives into ~%d of %d tokens (condensed %d, trimmed %d)\n",
+				report.Used, report.Budget, len(report.Condensed), len(report.Trimmed))
+			if len(report.Trimmed) > 0 {
+				fmt.Printf("   ⚠️  Could not condense %s; cut at a sentence boundary instead\n", strings.Join(report.Trimmed, ", "))
+			}
+		}
+		prompt = g.buildNarrativePromptFromClusters(packed, articles, summaries)
+		citable = countCitableArticles(clusters, articles)
 		fmt.Println("   ✓ Using hierarchical summarization (cluster narratives)")
 	} else {
 		// LEGACY: Fall back to top-3 article approach
@@ -278,16 +351,24 @@ func (g *Generator) GenerateDigestContent(ctx context.Context, clusters []core.T
 		}
 
 		prompt = g.buildStructuredNarrativePrompt(clusterInsights)
+		for _, insight := range clusterInsights {
+			citable += len(insight.TopArticles)
+		}
 		fmt.Println("   ⚠️  Using legacy top-3 article summarization")
 	}
 
 	// Generate content using LLM with structured output (v2.0)
-	response, err := g.llmClient.GenerateText(ctx, prompt, llm.TextGenerationOptions{
+	response, err := g.llmClient.GenerateText(llm.WithAttribution(ctx, llm.Attribution{Phase: "digest"}), prompt, llm.TextGenerationOptions{
 		ResponseSchema: schema,
 		Temperature:    0.7,
 		MaxTokens:      8192, // Max tokens to ensure complete JSON output
 	})
 	if err != nil {
+		// Cluster narratives already carry the substance; assemble the digest from them
+		if hasNarratives {
+			return AssembleDigestContent(clusters, articles, summaries), nil
+		}
+
 		// Fallback to simple generation if LLM fails
 		// Build fallback from cluster insights (legacy)
 		clusterInsights := make([]ClusterInsight, 0, len(clusters))
@@ -302,6 +383,10 @@ func (g *Generator) GenerateDigestContent(ctx context.Context, clusters []core.T
 	// Parse JSON response to DigestContent
 	content, err := g.parseStructuredDigestContent(response)
 	if err != nil {
+		if hasNarratives {
+			return AssembleDigestContent(clusters, articles, summaries), nil
+		}
+
 		// Fallback if parsing fails
 		clusterInsights := make([]ClusterInsight, 0, len(clusters))
 		for _, cluster := range clusters {
@@ -309,12 +394,100 @@ func (g *Generator) GenerateDigestContent(ctx context.Context, clusters []core.T
 			clusterInsights = append(clusterInsights, insight)
 		}
 		fallback := g.generateFallbackContent(clusterInsights)
+		fallback.MustRead = mustReadBySignal(clusters, articles, summaries)
 		return &fallback, nil
 	}
 
+	if content.MustRead == nil {
+		content.MustRead = mustReadBySignal(clusters, articles, summaries)
+	}
+	content.ByTheNumbers = citedStatistics(content.ByTheNumbers, citable)
+	if hasNarratives {
+		content.ByTheNumbers = factCheckedStatistics(content.ByTheNumbers, citedFigures(clusters, articles, summaries))
+	}
+
 	return content, nil
 }
 
+// ValidateStatistic checks that a "By the Numbers" stat has a value and cites at
+// least one article, and that every [N] citation in its context refers to one of
+// the articleCount articles the digest cites
+func ValidateStatistic(stat Statistic, articleCount int) error {
+	if strings.TrimSpace(stat.Stat) == "" {
+		return fmt.Errorf("statistic has no value")
+	}
+
+	refs := citationRef.FindAllStringSubmatch(stat.Context, -1)
+	if len(refs) == 0 {
+		return fmt.Errorf("statistic %q has no citation", stat.Stat)
+	}
+	for _, ref := range refs {
+		num, err := strconv.Atoi(ref[1])
+		if err != nil || num < 1 || num > articleCount {
+			return fmt.Errorf("statistic %q cites [%s] but the digest has %d articles", stat.Stat, ref[1], articleCount)
+		}
+	}
+	return nil
+}
+
+// countCitableArticles counts the articles listed for citation, numbered [1..N] in
+// cluster order
+func countCitableArticles(clusters []core.TopicCluster, articles map[string]core.Article) int {
+	count := 0
+	for _, cluster := range clusters {
+		for _, articleID := range cluster.ArticleIDs {
+			if _, found := articles[articleID]; found {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// citedStatistics drops statistics that fail ValidateStatistic, so every stat in a
+// rendered digest links back to a real article
+func citedStatistics(stats []Statistic, articleCount int) []Statistic {
+	valid := make([]Statistic, 0, len(stats))
+	for _, stat := range stats {
+		if err := ValidateStatistic(stat, articleCount); err != nil {
+			log.Printf("[WARN] Dropping By the Numbers entry: %v", err)
+			continue
+		}
+		valid = append(valid, stat)
+	}
+	return valid
+}
+
+// mustReadBySignal picks the highest-signal article as the Must-Read when the LLM did
+// not choose one. Article numbers follow the citation order used in the prompt.
+// Returns nil when no article has been scored.
+func mustReadBySignal(clusters []core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) *MustReadHighlight {
+	var best *MustReadHighlight
+	bestSignal := 0.0
+	articleNum := 1
+
+	for _, cluster := range clusters {
+		for _, articleID := range cluster.ArticleIDs {
+			article, found := articles[articleID]
+			if !found {
+				continue
+			}
+			if article.SignalStrength > bestSignal {
+				bestSignal = article.SignalStrength
+				best = &MustReadHighlight{
+					ArticleNum:  articleNum,
+					Title:       article.Title,
+					WhyMustRead: textutil.FirstSentence(summaries[articleID].SummaryText),
+					ReadTime:    article.EstimatedReadMinutes,
+				}
+			}
+			articleNum++
+		}
+	}
+
+	return best
+}
+
 // GenerateExecutiveSummary creates a story-driven narrative from clustered articles
 // DEPRECATED: Use GenerateDigestContent instead
 // Maintained for backward compatibility
@@ -340,6 +513,7 @@ type ArticleSummary struct {
 	URL       string
 	Summary   string
 	KeyPoints []string
+	Facts     *core.FactSheet // Set when the summary was written from a fact sheet
 }
 
 // extractClusterInsight extracts the top 3 articles and key information from a cluster
@@ -350,10 +524,12 @@ func (g *Generator) extractClusterInsight(cluster core.TopicCluster, articles ma
 		ArticleCount: len(cluster.ArticleIDs),
 	}
 
-	// Collect article summaries for this cluster
+	// Collect article summaries for this cluster, highest signal first
 	articleSummaries := make([]ArticleSummary, 0, len(cluster.ArticleIDs))
+	articleIDs := append([]string(nil), cluster.ArticleIDs...)
+	quality.SortArticleIDsBySignal(articleIDs, articles)
 
-	for _, articleID := range cluster.ArticleIDs {
+	for _, articleID := range articleIDs {
 		article, hasArticle := articles[articleID]
 		summary, hasSummary := summaries[articleID]
 
@@ -376,8 +552,7 @@ func (g *Generator) extractClusterInsight(cluster core.TopicCluster, articles ma
 		return insight, fmt.Errorf("no article summaries found for cluster")
 	}
 
-	// Sort by relevance/quality and take top 3
-	// For now, just take first 3 (can add scoring later)
+	// Take the top 3 by signal
 	maxArticles := 3
 	if len(articleSummaries) < maxArticles {
 		maxArticles = len(articleSummaries)
@@ -427,6 +602,7 @@ func (g *Generator) extractKeyPoints(summary core.Summary) []string {
 //nolint:unused
 func (g *Generator) buildNarrativePrompt(insights []ClusterInsight) string {
 	var prompt strings.Builder
+	g.writeAudience(&prompt)
 
 	prompt.WriteString("Generate complete content for a weekly tech digest newsletter using domain storytelling principles.\n\n")
 
@@ -523,7 +699,7 @@ func (g *Generator) generateFallbackNarrative(insights []ClusterInsight) string
 			titles := make([]string, 0, len(insight.TopArticles))
 			for _, article := range insight.TopArticles {
 				// Extract first sentence of summary as key point
-				firstSentence := extractFirstSentence(article.Summary)
+				firstSentence := textutil.FirstSentence(article.Summary)
 				if firstSentence != "" {
 					titles = append(titles, strings.ToLower(firstSentence))
 				}
@@ -560,7 +736,7 @@ func (g *Generator) IdentifyClusterTheme(ctx context.Context, cluster core.Topic
 
 	prompt.WriteString("\nTheme:")
 
-	theme, err := g.llmClient.GenerateText(ctx, prompt.String(), llm.TextGenerationOptions{})
+	theme, err := g.llmClient.GenerateText(llm.WithAttribution(ctx, llm.Attribution{Phase: "title"}), prompt.String(), llm.TextGenerationOptions{})
 	if err != nil {
 		// Fallback to generic theme
 		return fmt.Sprintf("Topic Cluster %d", 1), nil
@@ -625,25 +801,6 @@ func truncateText(text string, maxLength int) string {
 	return truncated + "..."
 }
 
-func extractFirstSentence(text string) string {
-	// Find first sentence (ending with . ! or ?)
-	for i, char := range text {
-		if char == '.' || char == '!' || char == '?' {
-			if i+1 < len(text) {
-				return strings.TrimSpace(text[:i+1])
-			}
-			return strings.TrimSpace(text)
-		}
-	}
-
-	// If no sentence ending found, truncate at reasonable length
-	if len(text) > 100 {
-		return truncateText(text, 100)
-	}
-
-	return text
-}
-
 func joinWithAnd(items []string) string {
 	if len(items) == 0 {
 		return ""
@@ -771,10 +928,14 @@ func (g *Generator) convertOldMomentsToStructured(oldMoments []string) []core.Ke
 // ============================================================================
 
 // buildClusterSummaryPrompt creates a prompt for generating cluster narrative from ALL articles
-func (g *Generator) buildClusterSummaryPrompt(clusterLabel string, articles []ArticleSummary) string {
+func (g *Generator) buildClusterSummaryPrompt(clusterLabel string, keywords []string, articles []ArticleSummary) string {
 	var prompt strings.Builder
+	g.writeAudience(&prompt)
 
 	prompt.WriteString(fmt.Sprintf("Generate a cohesive narrative for the \"%s\" topic cluster.\n\n", clusterLabel))
+	if len(keywords) > 0 {
+		prompt.WriteString(fmt.Sprintf("**Distinctive cluster keywords:** %s (use these to anchor key_themes)\n\n", strings.Join(keywords, ", ")))
+	}
 
 	prompt.WriteString("**ALL Articles in this cluster:**\n")
 	for i, article := range articles {
@@ -787,6 +948,7 @@ func (g *Generator) buildClusterSummaryPrompt(clusterLabel string, articles []Ar
 				prompt.WriteString(fmt.Sprintf("    - %s\n", point))
 			}
 		}
+		writeFigures(&prompt, article.Facts)
 	}
 
 	prompt.WriteString("\n**TASK:**\n")
@@ -965,10 +1127,44 @@ func (g *Generator) parseClusterNarrative(jsonResponse string) (*core.ClusterNar
 // v2.0 Structured Output Functions
 // ============================================================================
 
+// writeCoverageNote tells the synthesis how many articles each cluster really had
+// when the per-topic budget capped some of them, so the executive summary reflects
+// the week's true proportions instead of treating capped topics as equal
+func writeCoverageNote(prompt *strings.Builder, clusters []core.TopicCluster) {
+	capped := false
+	total := 0
+	for _, cluster := range clusters {
+		if len(cluster.OverflowIDs) > 0 {
+			capped = true
+		}
+		total += len(cluster.ArticleIDs) + len(cluster.OverflowIDs)
+	}
+	if !capped || total == 0 {
+		return
+	}
+
+	prompt.WriteString("\n**Coverage Balance:**\n")
+	prompt.WriteString("Sections were capped so one busy topic doesn't crowd out the rest. True article counts this week:\n")
+	for i, cluster := range clusters {
+		title := cluster.Label
+		if cluster.Narrative != nil && cluster.Narrative.Title != "" {
+			title = cluster.Narrative.Title
+		}
+		count := len(cluster.ArticleIDs) + len(cluster.OverflowIDs)
+		prompt.WriteString(fmt.Sprintf("- Cluster %d (%s): %d articles, %d%% of the week", i+1, title, count, count*100/total))
+		if len(cluster.OverflowIDs) > 0 {
+			prompt.WriteString(fmt.Sprintf(" (%d cited above)", len(cluster.ArticleIDs)))
+		}
+		prompt.WriteString("\n")
+	}
+	prompt.WriteString("Let the executive summary reflect these proportions: when one topic dominated the week, say so rather than weighting every topic equally. Only cite the numbered articles listed above.\n")
+}
+
 // buildNarrativePromptFromClusters creates prompt using cluster narratives (hierarchical summarization)
 // This is the NEW approach that synthesizes from cluster-level summaries
 func (g *Generator) buildNarrativePromptFromClusters(clusters []core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) string {
 	var prompt strings.Builder
+	g.writeAudience(&prompt)
 
 	prompt.WriteString("Generate structured content for a technical digest using hierarchical summarization.\n\n")
 
@@ -983,7 +1179,11 @@ func (g *Generator) buildNarrativePromptFromClusters(clusters []core.TopicCluste
 		prompt.WriteString(fmt.Sprintf("## Cluster %d: %s\n", i+1, cluster.Narrative.Title))
 		prompt.WriteString(fmt.Sprintf("**Theme:** %s\n", cluster.Label))
 		prompt.WriteString(fmt.Sprintf("**Key Themes:** %s\n", strings.Join(cluster.Narrative.KeyThemes, ", ")))
-		prompt.WriteString(fmt.Sprintf("**Articles Covered:** %d\n\n", len(cluster.ArticleIDs)))
+		if len(cluster.OverflowIDs) > 0 {
+			prompt.WriteString(fmt.Sprintf("**Articles Covered:** %d (%d more this week, linked without summaries)\n\n", len(cluster.ArticleIDs), len(cluster.OverflowIDs)))
+		} else {
+			prompt.WriteString(fmt.Sprintf("**Articles Covered:** %d\n\n", len(cluster.ArticleIDs)))
+		}
 		prompt.WriteString("**Cluster Summary:**\n")
 		prompt.WriteString(cluster.Narrative.Summary)
 		prompt.WriteString("\n\n---\n\n")
@@ -992,16 +1192,26 @@ func (g *Generator) buildNarrativePromptFromClusters(clusters []core.TopicCluste
 	// Add article reference list for citations
 	prompt.WriteString("**All Articles (for citation references):**\n")
 	articleNum := 1
+	hasSignal := false
 	for _, cluster := range clusters {
 		for _, articleID := range cluster.ArticleIDs {
 			if article, found := articles[articleID]; found {
-				prompt.WriteString(fmt.Sprintf("[%d] %s\n", articleNum, article.Title))
-				prompt.WriteString(fmt.Sprintf("    URL: %s\n\n", article.URL))
+				if article.SignalStrength > 0 {
+					hasSignal = true
+					prompt.WriteString(fmt.Sprintf("[%d] %s (signal %.2f)\n", articleNum, article.Title, article.SignalStrength))
+				} else {
+					prompt.WriteString(fmt.Sprintf("[%d] %s\n", articleNum, article.Title))
+				}
+				prompt.WriteString(fmt.Sprintf("    URL: %s\n", article.URL))
+				writeFigures(&prompt, summaries[articleID].Facts)
+				prompt.WriteString("\n")
 				articleNum++
 			}
 		}
 	}
 
+	writeCoverageNote(&prompt, clusters)
+
 	prompt.WriteString("\n**REQUIREMENTS:**\n\n")
 
 	prompt.WriteString("**Title (20-40 characters STRICT MAXIMUM):**\n")
@@ -1038,6 +1248,9 @@ func (g *Generator) buildNarrativePromptFromClusters(clusters []core.TopicCluste
 	prompt.WriteString("  1. Practical tools/frameworks engineers can use immediately\n")
 	prompt.WriteString("  2. Major technical breakthroughs with measurable impact\n")
 	prompt.WriteString("  3. Industry-shaping announcements affecting engineering practices\n")
+	if hasSignal {
+		prompt.WriteString("- Signal scores (0-1, from novelty, depth and actionability) are shown per article; prefer the highest-signal article unless another is clearly more impactful\n")
+	}
 	prompt.WriteString("- REQUIRED FIELDS:\n")
 	prompt.WriteString("  • article_num: Citation number [N] of the selected article\n")
 	prompt.WriteString("  • title: Full article title\n")
@@ -1161,6 +1374,7 @@ func (g *Generator) buildNarrativePromptFromClusters(clusters []core.TopicCluste
 // buildStructuredNarrativePrompt creates a prompt for generating structured digest content with JSON schema
 func (g *Generator) buildStructuredNarrativePrompt(insights []ClusterInsight) string {
 	var prompt strings.Builder
+	g.writeAudience(&prompt)
 
 	prompt.WriteString("Generate structured content for a technical digest following v2.0 architecture.\n\n")
 
@@ -1291,7 +1505,7 @@ func (g *Generator) buildDigestContentSchema() *genai.Schema {
 						},
 						"context": {
 							Type:        genai.TypeString,
-							Description: "Brief context explaining the stat with citations (e.g., 'Database queries cut by agent-discovered caching pattern [3]')",
+							Description: "Brief context explaining the stat, citing its source article(s) from the article list with [N] (e.g., 'Database queries cut by agent-discovered caching pattern [3]'). Stats without a valid citation are dropped.",
 						},
 					},
 					Required: []string{"stat", "context"},
@@ -1508,7 +1722,7 @@ func (g *Generator) generateFallbackKeyMoments(insights []ClusterInsight) []core
 		if len(insight.TopArticles) > 0 {
 			article := insight.TopArticles[0]
 			// Extract first sentence from summary as quote
-			quote := extractFirstSentence(article.Summary)
+			quote := textutil.FirstSentence(article.Summary)
 			if quote == "" {
 				quote = article.Title
 			}
@@ -1545,7 +1759,7 @@ func (g *Generator) GenerateSlackDigest(ctx context.Context, clusters []core.Top
 	prompt := g.buildSlackDigestPrompt(clusters, articles, summaries)
 	schema := g.buildSlackDigestSchema()
 
-	response, err := g.llmClient.GenerateText(ctx, prompt, llm.TextGenerationOptions{
+	response, err := g.llmClient.GenerateText(llm.WithAttribution(ctx, llm.Attribution{Phase: "digest"}), prompt, llm.TextGenerationOptions{
 		ResponseSchema: schema,
 		Temperature:    0.8, // Slightly higher for editorial voice creativity
 		MaxTokens:      16384, // Increased for generating content for all articles
@@ -1560,6 +1774,7 @@ func (g *Generator) GenerateSlackDigest(ctx context.Context, clusters []core.Top
 // buildSlackDigestPrompt creates the prompt for Slack digest generation with editorial voice
 func (g *Generator) buildSlackDigestPrompt(clusters []core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) string {
 	var prompt strings.Builder
+	g.writeAudience(&prompt)
 
 	prompt.WriteString("You are a senior tech editor writing a weekly digest for busy engineering leaders.\n")
 	prompt.WriteString("Your voice is PUNCHY, OPINIONATED, and SPECIFIC - not neutral or academic.\n\n")
@@ -0,0 +1,200 @@
+package narrative
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultContextBudget is the token budget for the cluster narratives and article
+// list in the final digest prompt. The instructions around them add ~4K tokens.
+const DefaultContextBudget = 24000
+
+const (
+	// charsPerToken approximates tokenization for budgeting, as estimateTokens does in llm
+	charsPerToken = 4
+
+	// minClusterTokens is the smallest share a cluster is packed into, so every
+	// cluster keeps at least a short paragraph
+	minClusterTokens = 150
+)
+
+// PackReport describes how cluster narratives were fitted into the context budget
+type PackReport struct {
+	Budget    int      // Token budget for the prompt's cluster and article sections
+	Used      int      // Estimated tokens after packing
+	Condensed []string // Clusters whose narrative was re-summarized to fit
+	Trimmed   []string // Clusters cut at a sentence boundary because condensing failed
+}
+
+// SetContextBudget sets the token budget for the final digest prompt's cluster
+// narratives. Zero or negative uses DefaultContextBudget.
+func (g *Generator) SetContextBudget(tokens int) {
+	g.contextBudget = tokens
+}
+
+// estimatePromptTokens roughly counts the tokens text will use in a prompt
+func estimatePromptTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// PackClusterNarratives fits cluster narratives into the context budget before they
+// are combined into the digest prompt. The article reference list is kept whole
+// (every article must stay citable) and the remaining budget goes to clusters in
+// order of signal strength: higher-signal clusters keep their full narrative while
+// lower-signal ones that overflow are re-summarized to their share rather than
+// truncated. The returned clusters are copies; the input is not modified.
+func (g *Generator) PackClusterNarratives(ctx context.Context, clusters []core.TopicCluster, articles map[string]core.Article) ([]core.TopicCluster, PackReport) {
+	budget := g.contextBudget
+	if budget <= 0 {
+		budget = DefaultContextBudget
+	}
+	report := PackReport{Budget: budget}
+
+	packed := make([]core.TopicCluster, len(clusters))
+	copy(packed, clusters)
+
+	referenceTokens := 0
+	for _, cluster := range clusters {
+		for _, articleID := range cluster.ArticleIDs {
+			if article, found := articles[articleID]; found {
+				// "[N] title (signal 0.00)\n    URL: url\n\n"
+				referenceTokens += estimatePromptTokens(article.Title+article.URL) + 8
+			}
+		}
+	}
+
+	needs := make([]int, len(packed))
+	var narrated []int
+	total := 0
+	for i, cluster := range packed {
+		if cluster.Narrative == nil {
+			continue
+		}
+		needs[i] = clusterNarrativeTokens(cluster)
+		total += needs[i]
+		narrated = append(narrated, i)
+	}
+
+	available := budget - referenceTokens
+	if floor := minClusterTokens * len(narrated); available < floor {
+		available = floor
+	}
+	if total <= available {
+		report.Used = referenceTokens + total
+		return packed, report
+	}
+
+	// Highest-signal clusters are allocated first; the rest share what remains
+	sort.SliceStable(narrated, func(a, b int) bool {
+		sa, sb := clusterSignal(packed[narrated[a]], articles), clusterSignal(packed[narrated[b]], articles)
+		if sa != sb {
+			return sa > sb
+		}
+		return len(packed[narrated[a]].ArticleIDs) > len(packed[narrated[b]].ArticleIDs)
+	})
+
+	remaining := available
+	used := referenceTokens
+	for n, i := range narrated {
+		reserve := minClusterTokens * (len(narrated) - n - 1)
+		share := remaining - reserve
+		if share < minClusterTokens {
+			share = minClusterTokens
+		}
+
+		if needs[i] > share {
+			narrative := *packed[i].Narrative
+			summaryShare := share - (needs[i] - estimatePromptTokens(narrative.Summary))
+			if summaryShare < minClusterTokens/2 {
+				summaryShare = minClusterTokens / 2
+			}
+
+			condensed, err := g.condenseNarrative(ctx, packed[i], summaryShare)
+			if err == nil && estimatePromptTokens(condensed) <= summaryShare {
+				narrative.Summary = condensed
+				report.Condensed = append(report.Condensed, packed[i].Label)
+			} else {
+				narrative.Summary = truncateAtSentence(narrative.Summary, summaryShare*charsPerToken)
+				report.Trimmed = append(report.Trimmed, packed[i].Label)
+			}
+			packed[i].Narrative = &narrative
+			needs[i] = clusterNarrativeTokens(packed[i])
+		}
+
+		remaining -= needs[i]
+		used += needs[i]
+	}
+
+	report.Used = used
+	return packed, report
+}
+
+// clusterNarrativeTokens estimates the prompt tokens a cluster's narrative block uses
+func clusterNarrativeTokens(cluster core.TopicCluster) int {
+	narrative := cluster.Narrative
+	header := narrative.Title + cluster.Label + strings.Join(narrative.KeyThemes, ", ")
+	// Headings, labels, and the separator add roughly 25 tokens
+	return estimatePromptTokens(header) + estimatePromptTokens(narrative.Summary) + 25
+}
+
+// clusterSignal is the mean signal strength of a cluster's articles (0 when unscored)
+func clusterSignal(cluster core.TopicCluster, articles map[string]core.Article) float64 {
+	sum, n := 0.0, 0
+	for _, articleID := range cluster.ArticleIDs {
+		if article, found := articles[articleID]; found {
+			sum += article.SignalStrength
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// condenseNarrative re-summarizes a cluster narrative into roughly maxTokens
+func (g *Generator) condenseNarrative(ctx context.Context, cluster core.TopicCluster, maxTokens int) (string, error) {
+	maxWords := maxTokens * 3 / 4
+
+	var prompt strings.Builder
+	g.writeAudience(&prompt)
+	prompt.WriteString(fmt.Sprintf("Condense this summary of the %q topic cluster to at most %d words.\n\n", cluster.Label, maxWords))
+	prompt.WriteString("- Keep every specific number, metric, and named company, product, or person that fits\n")
+	prompt.WriteString("- Keep citation markers like [3] attached to the claims they support\n")
+	prompt.WriteString("- Drop background and repetition first\n")
+	prompt.WriteString("- Return plain prose only, no preamble\n\n")
+	prompt.WriteString("**Summary:**\n")
+	prompt.WriteString(cluster.Narrative.Summary)
+
+	response, err := g.llmClient.GenerateText(llm.WithAttribution(ctx, llm.Attribution{Phase: "narrative"}), prompt.String(), llm.TextGenerationOptions{
+		Temperature: 0.3,
+		MaxTokens:   int32(maxTokens * 2),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to condense cluster %s: %w", cluster.Label, err)
+	}
+
+	condensed := strings.TrimSpace(response)
+	if condensed == "" {
+		return "", fmt.Errorf("failed to condense cluster %s: empty response", cluster.Label)
+	}
+	return condensed, nil
+}
+
+// truncateAtSentence cuts text to at most maxChars, ending at the last full sentence
+// when there is one
+func truncateAtSentence(text string, maxChars int) string {
+	if len(text) <= maxChars {
+		return text
+	}
+
+	cut := text[:maxChars]
+	if end := strings.LastIndexAny(cut, ".!?"); end > maxChars/3 {
+		return strings.TrimSpace(cut[:end+1])
+	}
+	return truncateText(text, maxChars)
+}
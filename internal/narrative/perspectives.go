@@ -0,0 +1,229 @@
+package narrative
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// WebSearcher answers a prompt with web search grounding. *llm.Client implements it.
+type WebSearcher interface {
+	SearchGrounded(ctx context.Context, prompt string) (string, []llm.GroundingSource, error)
+}
+
+// SetSearcher lets the perspectives pass look outside the digest's sources when none
+// of them disagree with the top story
+func (g *Generator) SetSearcher(searcher WebSearcher) {
+	g.searcher = searcher
+}
+
+// GeneratePerspectives finds a contrasting viewpoint on the digest's top cluster. It first
+// looks among the digest's own articles; failing that, and if a searcher is set, it asks
+// a search-grounded model for credible pushback. Returns a supporting and an opposing
+// perspective with citations, or nil when no other side was found.
+func (g *Generator) GeneratePerspectives(ctx context.Context, clusters []core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) ([]core.Perspective, error) {
+	top := topCluster(clusters, articles)
+	if top == nil {
+		return nil, fmt.Errorf("no clusters with articles")
+	}
+
+	ctx = llm.WithAttribution(ctx, llm.Attribution{Phase: "perspectives"})
+
+	response, err := g.llmClient.GenerateText(ctx, g.buildPerspectivesPrompt(*top, clusters, articles, summaries), llm.TextGenerationOptions{
+		ResponseSchema: buildPerspectivesSchema(),
+		Temperature:    0.3,
+		MaxTokens:      2048,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate perspectives: %w", err)
+	}
+
+	var parsed struct {
+		MainView       string `json:"main_view"`
+		MainCitations  []int  `json:"main_citations"`
+		OtherSide      string `json:"other_side"`
+		OtherCitations []int  `json:"other_citations"`
+	}
+	if err := json.Unmarshal([]byte(cleanJSONResponse(response)), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse perspectives: %w", err)
+	}
+
+	articleCount := countCitableArticles(clusters, articles)
+	mainCitations := validCitations(parsed.MainCitations, articleCount)
+	if strings.TrimSpace(parsed.MainView) == "" || len(mainCitations) == 0 {
+		return nil, nil
+	}
+	supporting := core.Perspective{
+		Type:            "supporting",
+		Summary:         parsed.MainView,
+		CitationNumbers: mainCitations,
+	}
+
+	otherCitations := validCitations(parsed.OtherCitations, articleCount)
+	if strings.TrimSpace(parsed.OtherSide) != "" && len(otherCitations) > 0 {
+		return []core.Perspective{supporting, {
+			Type:            "opposing",
+			Summary:         parsed.OtherSide,
+			CitationNumbers: otherCitations,
+		}}, nil
+	}
+
+	if g.searcher == nil {
+		return nil, nil
+	}
+
+	opposing, err := g.searchOtherSide(ctx, parsed.MainView)
+	if err != nil {
+		return nil, err
+	}
+	if opposing == nil {
+		return nil, nil
+	}
+	return []core.Perspective{supporting, *opposing}, nil
+}
+
+// searchOtherSide asks a search-grounded model for credible pushback on mainView.
+// Returns nil when the search turns up nothing it can cite.
+func (g *Generator) searchOtherSide(ctx context.Context, mainView string) (*core.Perspective, error) {
+	var prompt strings.Builder
+	prompt.WriteString("A tech digest's lead story makes this case:\n\n")
+	prompt.WriteString(mainView)
+	prompt.WriteString("\n\nSearch the web for the strongest credible counterpoint from practitioners, researchers, or critics. ")
+	prompt.WriteString("Summarize it fairly in 1-2 sentences (30-50 words), naming who holds it. ")
+	prompt.WriteString("If there is no credible disagreement, reply with exactly NONE.")
+
+	text, sources, err := g.searcher.SearchGrounded(ctx, prompt.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for other side: %w", err)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" || strings.EqualFold(text, "NONE") || len(sources) == 0 {
+		return nil, nil
+	}
+
+	urls := make([]string, 0, len(sources))
+	for _, source := range sources {
+		urls = append(urls, source.URL)
+		if len(urls) == 3 {
+			break
+		}
+	}
+
+	return &core.Perspective{
+		Type:       "opposing",
+		Summary:    text,
+		SourceURLs: urls,
+	}, nil
+}
+
+// topCluster returns the cluster with the most articles, preferring earlier clusters on ties
+func topCluster(clusters []core.TopicCluster, articles map[string]core.Article) *core.TopicCluster {
+	var top *core.TopicCluster
+	topCount := 0
+	for i := range clusters {
+		count := 0
+		for _, articleID := range clusters[i].ArticleIDs {
+			if _, found := articles[articleID]; found {
+				count++
+			}
+		}
+		if count > topCount {
+			top = &clusters[i]
+			topCount = count
+		}
+	}
+	return top
+}
+
+// validCitations keeps the citation numbers that refer to one of articleCount articles
+func validCitations(nums []int, articleCount int) []int {
+	valid := make([]int, 0, len(nums))
+	seen := make(map[int]bool)
+	for _, num := range nums {
+		if num >= 1 && num <= articleCount && !seen[num] {
+			valid = append(valid, num)
+			seen[num] = true
+		}
+	}
+	return valid
+}
+
+// buildPerspectivesPrompt lists every digest article with its digest-wide citation number,
+// marking the top cluster's articles, and asks for the main view and a contrasting one
+func (g *Generator) buildPerspectivesPrompt(top core.TopicCluster, clusters []core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) string {
+	var prompt strings.Builder
+	g.writeAudience(&prompt)
+
+	prompt.WriteString("Find the other side of this digest's top story.\n\n")
+
+	prompt.WriteString(fmt.Sprintf("**Top story:** %s\n", top.Label))
+	if top.Narrative != nil {
+		prompt.WriteString(fmt.Sprintf("%s\n", top.Narrative.Summary))
+	}
+
+	inTop := make(map[string]bool, len(top.ArticleIDs))
+	for _, articleID := range top.ArticleIDs {
+		inTop[articleID] = true
+	}
+
+	prompt.WriteString("\n**All articles (★ = top story):**\n")
+	articleNum := 1
+	for _, cluster := range clusters {
+		for _, articleID := range cluster.ArticleIDs {
+			article, found := articles[articleID]
+			if !found {
+				continue
+			}
+			marker := ""
+			if inTop[articleID] {
+				marker = " ★"
+			}
+			prompt.WriteString(fmt.Sprintf("[%d]%s %s\n", articleNum, marker, article.Title))
+			if summary, ok := summaries[articleID]; ok {
+				prompt.WriteString(fmt.Sprintf("    Summary: %s\n", truncateText(summary.SummaryText, 300)))
+			}
+			articleNum++
+		}
+	}
+
+	prompt.WriteString("\n**TASK:**\n")
+	prompt.WriteString("1. main_view: The top story's central claim in one sentence (20-30 words), citing the ★ articles that make it\n")
+	prompt.WriteString("2. other_side: If any article (★ or not) disagrees, raises risks, or reports contrary results, summarize that viewpoint fairly in 1-2 sentences, citing those articles\n")
+	prompt.WriteString("3. Leave other_side empty and other_citations [] if no article genuinely disagrees. Do NOT invent disagreement.\n")
+	prompt.WriteString("4. Citations are the article numbers above; use only numbers from the list\n")
+
+	return prompt.String()
+}
+
+// buildPerspectivesSchema defines the Gemini JSON schema for the perspectives pass
+func buildPerspectivesSchema() *genai.Schema {
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"main_view": {
+				Type:        genai.TypeString,
+				Description: "The top story's central claim in one sentence",
+			},
+			"main_citations": {
+				Type:        genai.TypeArray,
+				Description: "Article numbers making the main claim",
+				Items:       &genai.Schema{Type: genai.TypeInteger},
+			},
+			"other_side": {
+				Type:        genai.TypeString,
+				Description: "Contrasting viewpoint from the listed articles, or empty if none disagrees",
+			},
+			"other_citations": {
+				Type:        genai.TypeArray,
+				Description: "Article numbers holding the contrasting viewpoint",
+				Items:       &genai.Schema{Type: genai.TypeInteger},
+			},
+		},
+		Required: []string{"main_view", "main_citations", "other_side", "other_citations"},
+	}
+}
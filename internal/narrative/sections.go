@@ -0,0 +1,187 @@
+package narrative
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"briefly/internal/textutil"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// clusterPromptLimits shrinks the cluster prompt on each retry: fewer articles (the
+// cluster's highest-signal ones come first), shorter summaries, and no key points
+var clusterPromptLimits = []struct {
+	maxArticles     int // 0 = all
+	maxSummaryChars int // 0 = full summary
+	keyPoints       bool
+}{
+	{0, 0, true},
+	{8, 600, false},
+	{4, 300, false},
+}
+
+// citationRef matches a [N] citation
+var citationRef = regexp.MustCompile(`\[(\d+)\]`)
+
+// GenerateClusterSection generates one cluster's section of the digest so a failure
+// stays within that cluster. Failed syntheses are retried with progressively smaller
+// prompts; if every attempt fails, the section is built from the article summaries
+// and marked Degraded. Errors only when the cluster has no summarized articles.
+func (g *Generator) GenerateClusterSection(ctx context.Context, cluster core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) (*core.ClusterNarrative, error) {
+	clusterArticles := g.collectClusterArticles(cluster, articles, summaries)
+	if len(clusterArticles) == 0 {
+		return nil, fmt.Errorf("no articles found for cluster %s", cluster.Label)
+	}
+
+	ctx = llm.WithAttribution(ctx, llm.Attribution{Phase: "narrative"})
+
+	var lastErr error
+	for attempt, limits := range clusterPromptLimits {
+		if ctx.Err() != nil {
+			break
+		}
+
+		prompted := shrinkClusterArticles(clusterArticles, limits.maxArticles, limits.maxSummaryChars, limits.keyPoints)
+		if attempt > 0 {
+			fmt.Printf("           ↻ Retrying %q with %d article(s), shorter summaries\n", cluster.Label, len(prompted))
+		}
+
+		narrative, err := g.generateClusterNarrative(llm.WithRetry(ctx, attempt), cluster, prompted)
+		if err == nil {
+			return narrative, nil
+		}
+		lastErr = err
+	}
+
+	fmt.Printf("           ⚠ Synthesis failed for %q (%v); using article summaries for this section\n", cluster.Label, lastErr)
+	return extractiveClusterNarrative(cluster, clusterArticles), nil
+}
+
+// shrinkClusterArticles returns a copy of articles limited for a smaller prompt
+func shrinkClusterArticles(articles []ArticleSummary, maxArticles, maxSummaryChars int, keyPoints bool) []ArticleSummary {
+	if maxArticles > 0 && len(articles) > maxArticles {
+		articles = articles[:maxArticles]
+	}
+
+	shrunk := make([]ArticleSummary, len(articles))
+	for i, article := range articles {
+		shrunk[i] = article
+		if maxSummaryChars > 0 {
+			shrunk[i].Summary = truncateText(article.Summary, maxSummaryChars)
+		}
+		if !keyPoints {
+			shrunk[i].KeyPoints = nil
+		}
+	}
+	return shrunk
+}
+
+// extractiveClusterNarrative builds a section without the LLM: the lead article's
+// first sentence as the one-liner and one cited bullet per article
+func extractiveClusterNarrative(cluster core.TopicCluster, articles []ArticleSummary) *core.ClusterNarrative {
+	narrative := &core.ClusterNarrative{
+		Title:     cluster.Label,
+		OneLiner:  textutil.FirstSentence(articles[0].Summary),
+		KeyThemes: cluster.Keywords,
+		Degraded:  true,
+	}
+
+	for i, article := range articles {
+		narrative.ArticleRefs = append(narrative.ArticleRefs, i+1)
+		if i < 4 {
+			narrative.KeyDevelopments = append(narrative.KeyDevelopments,
+				fmt.Sprintf("**%s** - %s [%d]", article.Title, textutil.FirstSentence(article.Summary), i+1))
+		}
+	}
+	narrative.Summary = strings.Join(narrative.KeyDevelopments, "\n")
+
+	return narrative
+}
+
+// AssembleDigestContent builds the digest's top-level content directly from the
+// cluster sections, for when the final synthesis call fails. Each cluster's lead
+// development, stats, and one-liner are kept, with citations renumbered from the
+// cluster's own [1..n] to the digest-wide numbering.
+func AssembleDigestContent(clusters []core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) *DigestContent {
+	content := &DigestContent{
+		KeyMoments:   []core.KeyMoment{},
+		Perspectives: []core.Perspective{},
+		MustRead:     mustReadBySignal(clusters, articles, summaries),
+	}
+
+	var paragraphs, titles []string
+	articleCount := 0
+	articleNum := 1
+
+	for _, cluster := range clusters {
+		// Map the cluster prompt's local numbers (summarized articles only) to digest numbers
+		localToGlobal := make(map[int]int)
+		local := 1
+		for _, articleID := range cluster.ArticleIDs {
+			if _, found := articles[articleID]; !found {
+				continue
+			}
+			if _, hasSummary := summaries[articleID]; hasSummary {
+				localToGlobal[local] = articleNum
+				local++
+			}
+			articleNum++
+			articleCount++
+		}
+
+		if cluster.Narrative == nil {
+			continue
+		}
+		narrative := cluster.Narrative
+		titles = append(titles, narrative.Title)
+
+		if len(narrative.KeyDevelopments) > 0 && len(content.TopDevelopments) < 5 {
+			content.TopDevelopments = append(content.TopDevelopments, renumberCitations(narrative.KeyDevelopments[0], localToGlobal))
+		}
+		for _, stat := range narrative.KeyStats {
+			if len(content.ByTheNumbers) >= 5 {
+				break
+			}
+			content.ByTheNumbers = append(content.ByTheNumbers, Statistic{
+				Stat:    stat.Stat,
+				Context: renumberCitations(stat.Context, localToGlobal),
+			})
+		}
+		if narrative.OneLiner != "" {
+			paragraphs = append(paragraphs, fmt.Sprintf("**%s**: %s", narrative.Title, renumberCitations(narrative.OneLiner, localToGlobal)))
+		}
+	}
+
+	switch {
+	case len(titles) == 0:
+		content.Title = "Weekly Tech Digest"
+	case len(titles) == 1:
+		content.Title = titles[0]
+	default:
+		content.Title = titles[0] + " & " + titles[1]
+	}
+	content.TLDRSummary = fmt.Sprintf("%d articles across %d topics", articleCount, len(clusters))
+	if len(paragraphs) > 0 {
+		content.WhyItMatters = strings.TrimPrefix(paragraphs[0], "**"+titles[0]+"**: ")
+	}
+	content.ExecutiveSummary = strings.Join(paragraphs, "\n\n")
+
+	return content
+}
+
+// renumberCitations rewrites [N] citations through mapping; unknown numbers are kept
+func renumberCitations(text string, mapping map[int]int) string {
+	return citationRef.ReplaceAllStringFunc(text, func(ref string) string {
+		n, err := strconv.Atoi(ref[1 : len(ref)-1])
+		if err != nil {
+			return ref
+		}
+		if global, ok := mapping[n]; ok {
+			return fmt.Sprintf("[%d]", global)
+		}
+		return ref
+	})
+}
@@ -65,7 +65,7 @@ type GenerationOptions struct {
 	LatencyMs int64 // Latency in milliseconds
 
 	// Cost (optional)
-	TotalCost float64 // Total cost in USD
+	TotalCost float64 // Total cost in USD, e.g. from llm.EstimateCost
 }
 
 // NewLangFuseClient creates a new LangFuse observability client
@@ -149,11 +149,6 @@ func (l *LangFuseClient) TrackGeneration(trace *TraceClient, opts GenerationOpti
 		return nil
 	}
 
-	// Calculate cost if not provided (example rates for Gemini)
-	if opts.TotalCost == 0 && opts.TotalTokens > 0 {
-		opts.TotalCost = l.estimateCost(opts.Model, opts.PromptTokens, opts.CompletionTokens)
-	}
-
 	l.log.Info("LangFuse generation tracked",
 		"trace_id", trace.id,
 		"model", opts.Model,
@@ -202,33 +197,6 @@ func (l *LangFuseClient) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-// estimateCost estimates the cost of an LLM call based on token usage
-// These are example rates and should be updated based on actual pricing
-func (l *LangFuseClient) estimateCost(model string, promptTokens, completionTokens int) float64 {
-	// Example pricing (per 1M tokens) - update with actual rates
-	var promptCostPer1M, completionCostPer1M float64
-
-	switch {
-	case model == "gemini-flash-lite-latest":
-		// Gemini Flash pricing example
-		promptCostPer1M = 0.075    // $0.075 per 1M input tokens
-		completionCostPer1M = 0.30 // $0.30 per 1M output tokens
-	case model == "text-embedding-004":
-		// Embedding pricing example
-		promptCostPer1M = 0.00001 // Very cheap for embeddings
-		completionCostPer1M = 0.0
-	default:
-		// Default conservative estimate
-		promptCostPer1M = 0.50
-		completionCostPer1M = 1.50
-	}
-
-	promptCost := float64(promptTokens) / 1_000_000.0 * promptCostPer1M
-	completionCost := float64(completionTokens) / 1_000_000.0 * completionCostPer1M
-
-	return promptCost + completionCost
-}
-
 // Helper function to create a simple trace for one-off operations
 func (l *LangFuseClient) SimpleTrace(ctx context.Context, name string, fn func(*TraceClient) error) error {
 	if !l.enabled {
@@ -0,0 +1,270 @@
+// Package ops builds the periodic pipeline health report ('briefly ops digest'): cache
+// growth, failing feeds and fetch domains, LLM spend, and the week's top topics, so
+// drift shows up without reading logs.
+package ops
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxListed is how many feeds, domains, and phases a section lists
+const maxListed = 8
+
+// Report is one period's pipeline health
+type Report struct {
+	Since time.Time
+	Until time.Time
+
+	Cache *CacheHealth // nil when the local cache couldn't be read
+
+	// Database sections; DatabaseError explains why they are empty
+	FeedErrors    []FeedError
+	FailedDomains []DomainFailures
+	Spend         *Spend
+	PreviousSpend *Spend // The period before, for comparison
+	TopTopics     []string
+	DatabaseError string
+}
+
+// CacheHealth is the state of the local cache
+type CacheHealth struct {
+	SizeBytes    int64
+	Articles     int
+	Summaries    int
+	NewArticles  int // Cached during the period
+	NewSummaries int
+}
+
+// FeedError is a feed whose last fetches failed
+type FeedError struct {
+	Title      string
+	URL        string
+	ErrorCount int
+	LastError  string
+}
+
+// DomainFailures counts the article fetches that failed for one domain
+type DomainFailures struct {
+	Domain    string
+	Failures  int
+	LastError string
+}
+
+// Spend is the LLM usage of a period
+type Spend struct {
+	Calls     int
+	Failures  int
+	TokensIn  int
+	TokensOut int
+	Cost      float64
+	ByPhase   []PhaseSpend // Most expensive first
+}
+
+// PhaseSpend is the LLM usage of one pipeline phase
+type PhaseSpend struct {
+	Phase string
+	Calls int
+	Cost  float64
+}
+
+// FeedErrorsFrom returns the feeds with errors, most errors first
+func FeedErrorsFrom(feeds []core.Feed) []FeedError {
+	var errs []FeedError
+	for _, feed := range feeds {
+		if feed.ErrorCount == 0 || !feed.Active {
+			continue
+		}
+		title := feed.Title
+		if title == "" {
+			title = feed.URL
+		}
+		errs = append(errs, FeedError{Title: title, URL: feed.URL, ErrorCount: feed.ErrorCount, LastError: feed.LastError})
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].ErrorCount > errs[j].ErrorCount })
+	return errs
+}
+
+// Failure is one article that couldn't be fetched
+type Failure struct {
+	URL   string
+	Error string
+}
+
+// GroupFailures counts failed fetches by domain, most failures first. Each domain
+// keeps the error of its first failure in the list, so pass failures newest first.
+func GroupFailures(failures []Failure) []DomainFailures {
+	byDomain := make(map[string]*DomainFailures)
+	var order []string
+	for _, failure := range failures {
+		domain := Domain(failure.URL)
+		group, ok := byDomain[domain]
+		if !ok {
+			group = &DomainFailures{Domain: domain, LastError: failure.Error}
+			byDomain[domain] = group
+			order = append(order, domain)
+		}
+		group.Failures++
+	}
+
+	groups := make([]DomainFailures, 0, len(order))
+	for _, domain := range order {
+		groups = append(groups, *byDomain[domain])
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Failures > groups[j].Failures })
+	return groups
+}
+
+// Domain returns the host of a URL without "www.", or the URL itself if it has none
+func Domain(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// SpendFrom totals LLM calls, priced at list prices
+func SpendFrom(calls []core.LLMCall) *Spend {
+	spend := &Spend{}
+	byPhase := make(map[string]*PhaseSpend)
+	for _, call := range calls {
+		cost := llm.EstimateCost(call.Model, call.TokensIn, call.TokensOut)
+		spend.Calls++
+		if call.Error != "" {
+			spend.Failures++
+		}
+		spend.TokensIn += call.TokensIn
+		spend.TokensOut += call.TokensOut
+		spend.Cost += cost
+
+		phase := call.Phase
+		if phase == "" {
+			phase = "other"
+		}
+		if byPhase[phase] == nil {
+			byPhase[phase] = &PhaseSpend{Phase: phase}
+		}
+		byPhase[phase].Calls++
+		byPhase[phase].Cost += cost
+	}
+
+	for _, phase := range byPhase {
+		spend.ByPhase = append(spend.ByPhase, *phase)
+	}
+	sort.Slice(spend.ByPhase, func(i, j int) bool {
+		if spend.ByPhase[i].Cost != spend.ByPhase[j].Cost {
+			return spend.ByPhase[i].Cost > spend.ByPhase[j].Cost
+		}
+		return spend.ByPhase[i].Phase < spend.ByPhase[j].Phase
+	})
+	return spend
+}
+
+// Title is the report's heading, e.g. "Pipeline health: Jun 2 – Jun 9"
+func (r *Report) Title() string {
+	return fmt.Sprintf("Pipeline health: %s – %s", r.Since.Format("Jan 2"), r.Until.Format("Jan 2"))
+}
+
+// Markdown renders the report as markdown suitable for Slack, Discord, or email
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# 🩺 %s\n", r.Title())
+
+	b.WriteString("\n## Cache\n\n")
+	if r.Cache == nil {
+		b.WriteString("Local cache unavailable.\n")
+	} else {
+		fmt.Fprintf(&b, "- %s on disk: %d articles, %d summaries\n", formatBytes(r.Cache.SizeBytes), r.Cache.Articles, r.Cache.Summaries)
+		fmt.Fprintf(&b, "- Added this period: %d articles, %d summaries\n", r.Cache.NewArticles, r.Cache.NewSummaries)
+	}
+
+	if r.DatabaseError != "" {
+		fmt.Fprintf(&b, "\n## Database\n\n⚠️ Feed, fetch, spend, and topic sections skipped: %s\n", r.DatabaseError)
+		return b.String()
+	}
+
+	b.WriteString("\n## Feeds\n\n")
+	if len(r.FeedErrors) == 0 {
+		b.WriteString("✅ No active feeds are failing.\n")
+	} else {
+		fmt.Fprintf(&b, "⚠️ %d active feed(s) failing:\n", len(r.FeedErrors))
+		for i, feed := range r.FeedErrors {
+			if i == maxListed {
+				fmt.Fprintf(&b, "- … and %d more\n", len(r.FeedErrors)-maxListed)
+				break
+			}
+			fmt.Fprintf(&b, "- %s: %d error(s), last: %s\n", feed.Title, feed.ErrorCount, truncate(feed.LastError, 120))
+		}
+	}
+
+	b.WriteString("\n## Failed fetches\n\n")
+	if len(r.FailedDomains) == 0 {
+		b.WriteString("✅ No article fetches failed.\n")
+	} else {
+		total := 0
+		for _, group := range r.FailedDomains {
+			total += group.Failures
+		}
+		fmt.Fprintf(&b, "%d failed fetch(es) across %d domain(s):\n", total, len(r.FailedDomains))
+		for i, group := range r.FailedDomains {
+			if i == maxListed {
+				fmt.Fprintf(&b, "- … and %d more domains\n", len(r.FailedDomains)-maxListed)
+				break
+			}
+			fmt.Fprintf(&b, "- %s: %d (%s)\n", group.Domain, group.Failures, truncate(group.LastError, 100))
+		}
+	}
+
+	b.WriteString("\n## LLM spend\n\n")
+	if r.Spend == nil || r.Spend.Calls == 0 {
+		b.WriteString("No LLM calls recorded.\n")
+	} else {
+		fmt.Fprintf(&b, "- $%.2f across %d calls (%d tokens in / %d out)", r.Spend.Cost, r.Spend.Calls, r.Spend.TokensIn, r.Spend.TokensOut)
+		if r.PreviousSpend != nil && r.PreviousSpend.Cost > 0 {
+			fmt.Fprintf(&b, ", %+.0f%% vs the period before", (r.Spend.Cost-r.PreviousSpend.Cost)/r.PreviousSpend.Cost*100)
+		}
+		b.WriteString("\n")
+		if r.Spend.Failures > 0 {
+			fmt.Fprintf(&b, "- ⚠️ %d call(s) failed\n", r.Spend.Failures)
+		}
+		for i, phase := range r.Spend.ByPhase {
+			if i == maxListed {
+				break
+			}
+			fmt.Fprintf(&b, "- %s: $%.2f (%d calls)\n", phase.Phase, phase.Cost, phase.Calls)
+		}
+	}
+
+	b.WriteString("\n## Top topics\n\n")
+	if len(r.TopTopics) == 0 {
+		b.WriteString("No articles classified this period.\n")
+	} else {
+		b.WriteString(strings.Join(r.TopTopics, " · ") + "\n")
+	}
+
+	return b.String()
+}
+
+// formatBytes formats a size in KB or MB
+func formatBytes(size int64) string {
+	if size < 1024*1024 {
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+}
+
+// truncate shortens text to limit runes, on one line
+func truncate(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
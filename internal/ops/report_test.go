@@ -0,0 +1,80 @@
+package ops
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGroupFailures(t *testing.T) {
+	groups := GroupFailures([]Failure{
+		{URL: "https://www.example.com/a", Error: "403 Forbidden"},
+		{URL: "https://blog.other.org/post", Error: "timeout"},
+		{URL: "https://example.com/b", Error: "404 Not Found"},
+	})
+
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 domains, got %+v", groups)
+	}
+	if groups[0].Domain != "example.com" || groups[0].Failures != 2 || groups[0].LastError != "403 Forbidden" {
+		t.Errorf("expected example.com first with its newest error, got %+v", groups[0])
+	}
+	if groups[1].Domain != "blog.other.org" || groups[1].Failures != 1 {
+		t.Errorf("unexpected second domain %+v", groups[1])
+	}
+}
+
+func TestSpendFrom(t *testing.T) {
+	spend := SpendFrom([]core.LLMCall{
+		{Phase: "summarize", Model: "gemini-2.5-flash", TokensIn: 1_000_000},
+		{Phase: "summarize", Model: "gemini-2.5-flash", TokensIn: 1_000_000, Error: "quota"},
+		{Phase: "digest", Model: "gemini-2.5-flash", TokensOut: 1_000_000},
+	})
+
+	if spend.Calls != 3 || spend.Failures != 1 || spend.TokensIn != 2_000_000 {
+		t.Errorf("unexpected totals %+v", spend)
+	}
+	if len(spend.ByPhase) != 2 || spend.ByPhase[0].Phase != "digest" {
+		t.Errorf("expected phases by cost with digest first, got %+v", spend.ByPhase)
+	}
+}
+
+func TestReportMarkdown(t *testing.T) {
+	report := &Report{
+		Since:         time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+		Until:         time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC),
+		Cache:         &CacheHealth{SizeBytes: 3 * 1024 * 1024, Articles: 120, Summaries: 80, NewArticles: 14, NewSummaries: 9},
+		FeedErrors:    FeedErrorsFrom([]core.Feed{{Title: "Broken Blog", Active: true, ErrorCount: 4, LastError: "404"}, {Title: "Paused", ErrorCount: 9}}),
+		FailedDomains: []DomainFailures{{Domain: "example.com", Failures: 3, LastError: "403 Forbidden"}},
+		Spend:         &Spend{Calls: 10, Cost: 1.5, ByPhase: []PhaseSpend{{Phase: "summarize", Calls: 8, Cost: 1}}},
+		PreviousSpend: &Spend{Calls: 8, Cost: 1},
+		TopTopics:     []string{"Postgres", "AI agents"},
+	}
+
+	md := report.Markdown()
+	for _, want := range []string{
+		"Pipeline health: Jun 2 – Jun 9",
+		"3.0 MB on disk: 120 articles, 80 summaries",
+		"Added this period: 14 articles, 9 summaries",
+		"1 active feed(s) failing",
+		"Broken Blog: 4 error(s)",
+		"example.com: 3 (403 Forbidden)",
+		"$1.50 across 10 calls",
+		"+50% vs the period before",
+		"Postgres · AI agents",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Paused") {
+		t.Error("expected inactive feeds left out")
+	}
+
+	// Without a database only the cache section is reported
+	md = (&Report{Since: report.Since, Until: report.Until, DatabaseError: "DATABASE_URL not set"}).Markdown()
+	if !strings.Contains(md, "Local cache unavailable") || !strings.Contains(md, "DATABASE_URL not set") || strings.Contains(md, "LLM spend") {
+		t.Errorf("unexpected report without a database:\n%s", md)
+	}
+}
@@ -2,25 +2,26 @@ package parser
 
 import (
 	"briefly/internal/core"
-	"bufio"
 	"fmt"
 	"net/url"
 	"os"
-	"regexp"
 	"strings"
 	"time"
 
+	"github.com/gomarkdown/markdown/ast"
+	mdparser "github.com/gomarkdown/markdown/parser"
 	"github.com/google/uuid"
 )
 
-// URL regex patterns
-var (
-	// Matches markdown links: [text](url)
-	markdownLinkRegex = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^\)]+)\)`)
+// markdownExtensions are the parser extensions used to read link files. Autolink
+// turns bare URLs in text into links, so they are found alongside [text](url) links.
+const markdownExtensions = mdparser.CommonExtensions | mdparser.Autolink
 
-	// Matches raw URLs in text
-	rawURLRegex = regexp.MustCompile(`https?://[^\s)]+`)
-)
+// MarkdownLink is a link found in a markdown document
+type MarkdownLink struct {
+	URL   string // Normalized URL
+	Title string // Link text, or the link's title attribute when the text is the URL itself
+}
 
 // Parser handles URL extraction and validation from markdown files
 type Parser struct {
@@ -40,13 +41,14 @@ func (p *Parser) ParseMarkdownFile(filePath string) ([]core.Link, error) {
 		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
 
-	urls := p.ParseMarkdownContent(string(content))
-	links := make([]core.Link, 0, len(urls))
+	found := p.ParseMarkdownLinks(string(content))
+	links := make([]core.Link, 0, len(found))
 
-	for _, u := range urls {
+	for _, l := range found {
 		links = append(links, core.Link{
 			ID:        uuid.NewString(),
-			URL:       u,
+			URL:       l.URL,
+			Title:     l.Title,
 			DateAdded: time.Now().UTC(),
 			Source:    "file:" + filePath,
 		})
@@ -56,49 +58,97 @@ func (p *Parser) ParseMarkdownFile(filePath string) ([]core.Link, error) {
 }
 
 // ParseMarkdownContent extracts URLs from markdown content string
-// Handles both markdown links [text](url) and raw URLs
+// Handles inline, reference-style, and autolinks as well as bare URLs
 // Returns deduplicated list of URLs in document order
 func (p *Parser) ParseMarkdownContent(content string) []string {
-	urlMap := make(map[string]bool)
-	var urls []string
-
-	// Process line by line to maintain document order
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// First, check for markdown links [text](url)
-		markdownMatches := markdownLinkRegex.FindAllStringSubmatch(line, -1)
-		if len(markdownMatches) > 0 {
-			// Extract markdown link URLs from this line
-			for _, match := range markdownMatches {
-				if len(match) >= 3 {
-					url := match[2] // URL is the second capture group
-					if p.isValidURL(url) {
-						normalized := p.NormalizeURL(url)
-						if !urlMap[normalized] {
-							urlMap[normalized] = true
-							urls = append(urls, normalized)
-						}
-					}
-				}
+	found := p.ParseMarkdownLinks(content)
+	urls := make([]string, 0, len(found))
+	for _, l := range found {
+		urls = append(urls, l.URL)
+	}
+	return urls
+}
+
+// ParseMarkdownLinks walks the markdown syntax tree of content and returns its http(s)
+// links in document order, deduplicated after normalization. The first occurrence of a
+// URL that has a title supplies it. Images and code are not links to read.
+func (p *Parser) ParseMarkdownLinks(content string) []MarkdownLink {
+	doc := mdparser.NewWithExtensions(markdownExtensions).Parse([]byte(content))
+
+	index := make(map[string]int)
+	var links []MarkdownLink
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		switch n := node.(type) {
+		case *ast.Image, *ast.Code, *ast.CodeBlock:
+			return ast.SkipChildren
+		case *ast.Link:
+			rawURL := string(n.Destination)
+			text := strings.TrimSpace(linkText(n))
+			if text == rawURL {
+				// Bare URLs are cut at the first unbalanced ")"; take the rest back
+				rawURL = balanceParens(rawURL, n)
+				text = ""
+			}
+			if !p.isValidURL(rawURL) {
+				return ast.SkipChildren
 			}
-		} else {
-			// If no markdown links, look for raw URLs
-			rawMatches := rawURLRegex.FindAllString(line, -1)
-			for _, rawURL := range rawMatches {
-				if p.isValidURL(rawURL) {
-					normalized := p.NormalizeURL(rawURL)
-					if !urlMap[normalized] {
-						urlMap[normalized] = true
-						urls = append(urls, normalized)
-					}
+
+			title := text
+			if title == "" {
+				title = strings.TrimSpace(string(n.Title))
+			}
+
+			normalized := p.NormalizeURL(rawURL)
+			if i, ok := index[normalized]; ok {
+				if links[i].Title == "" {
+					links[i].Title = title
 				}
+				return ast.SkipChildren
 			}
+			index[normalized] = len(links)
+			links = append(links, MarkdownLink{URL: normalized, Title: title})
+			return ast.SkipChildren
 		}
-	}
+		return ast.GoToNext
+	})
 
-	return urls
+	return links
+}
+
+// linkText returns the plain text inside a link
+func linkText(link *ast.Link) string {
+	var text strings.Builder
+	ast.WalkFunc(link, func(node ast.Node, entering bool) ast.WalkStatus {
+		if leaf := node.AsLeaf(); entering && leaf != nil {
+			text.Write(leaf.Literal)
+		}
+		return ast.GoToNext
+	})
+	return text.String()
+}
+
+// balanceParens extends a bare URL with the ")" characters that follow it in the
+// text while its parentheses are unbalanced, e.g. wiki/Go_(programming_language)
+func balanceParens(rawURL string, link *ast.Link) string {
+	open := strings.Count(rawURL, "(") - strings.Count(rawURL, ")")
+	if open <= 0 {
+		return rawURL
+	}
+	next, ok := ast.GetNextNode(link).(*ast.Text)
+	if !ok {
+		return rawURL
+	}
+	for _, c := range string(next.Literal) {
+		if c != ')' || open == 0 {
+			break
+		}
+		rawURL += ")"
+		open--
+	}
+	return rawURL
 }
 
 // ValidateURL checks if a URL is valid and accessible
@@ -96,6 +96,43 @@ Just some text without any links.`,
 	}
 }
 
+func TestParseMarkdownLinks(t *testing.T) {
+	parser := NewParser()
+
+	content := `# Weekly Links
+1. [Reference one][r1] and a [collapsed reference][]
+2. <https://example.com/autolink>
+3. [Go](https://en.wikipedia.org/wiki/Go_(programming_language_(v2)))
+4. https://en.wikipedia.org/wiki/Rust_(programming_language), a bare URL
+5. [Titled](https://example.com/titled "The Title") and <https://example.com/bare> "x"
+6. ![diagram](https://example.com/diagram.png) and ` + "`https://example.com/in-code`" + `
+7. [Again](https://example.com/autolink)
+
+[r1]: https://example.com/ref1
+[collapsed reference]: https://example.com/ref2 "Reference title"
+`
+
+	expected := []MarkdownLink{
+		{URL: "https://example.com/ref1", Title: "Reference one"},
+		{URL: "https://example.com/ref2", Title: "collapsed reference"},
+		{URL: "https://example.com/autolink", Title: "Again"},
+		{URL: "https://en.wikipedia.org/wiki/Go_(programming_language_(v2))", Title: "Go"},
+		{URL: "https://en.wikipedia.org/wiki/Rust_(programming_language)", Title: ""},
+		{URL: "https://example.com/titled", Title: "Titled"},
+		{URL: "https://example.com/bare", Title: ""},
+	}
+
+	links := parser.ParseMarkdownLinks(content)
+	if len(links) != len(expected) {
+		t.Fatalf("Expected %d links, got %d: %+v", len(expected), len(links), links)
+	}
+	for i, want := range expected {
+		if links[i] != want {
+			t.Errorf("link[%d] = %+v, want %+v", i, links[i], want)
+		}
+	}
+}
+
 func TestNormalizeURL(t *testing.T) {
 	parser := NewParser()
 
@@ -277,6 +314,10 @@ func TestParseMarkdownFile(t *testing.T) {
 			t.Errorf("Link[%d] missing ID", i)
 		}
 
+		if i != 1 && links[i].Title == "" {
+			t.Errorf("Link[%d] missing title from link text", i)
+		}
+
 		if links[i].Source != "file:"+testFile {
 			t.Errorf("Expected source 'file:%s', got '%s'", testFile, links[i].Source)
 		}
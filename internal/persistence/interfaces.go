@@ -34,6 +34,10 @@ type ArticleRepository interface {
 	// GetByCluster retrieves articles belonging to a specific cluster
 	GetByCluster(ctx context.Context, clusterLabel string, limit int) ([]core.Article, error)
 
+	// GetByFeedCategory retrieves articles fetched between since and until whose
+	// feed items came from feeds in the given category
+	GetByFeedCategory(ctx context.Context, category string, since, until time.Time, limit int) ([]core.Article, error)
+
 	// UpdateClusterAssignment updates the cluster assignment for an article (Phase 1)
 	// This is called after clustering to persist cluster labels and confidence scores
 	UpdateClusterAssignment(ctx context.Context, articleID string, clusterLabel string, confidence float64) error
@@ -114,6 +118,12 @@ type FeedItemRepository interface {
 	// MarkProcessed marks a feed item as processed
 	MarkProcessed(ctx context.Context, id string) error
 
+	// MarkFailed marks a feed item as processed, recording why its article couldn't be fetched
+	MarkFailed(ctx context.Context, id string, fetchError string) error
+
+	// ListFailed retrieves items discovered since the given time whose fetch failed, newest first
+	ListFailed(ctx context.Context, since time.Time, limit int) ([]core.FeedItem, error)
+
 	// Delete removes a feed item by ID
 	Delete(ctx context.Context, id string) error
 }
@@ -235,6 +245,10 @@ type ManualURLRepository interface {
 
 	// Delete removes a manual URL by ID
 	Delete(ctx context.Context, id string) error
+
+	// DeleteByStatus removes every manual URL with status (all of them when status
+	// is empty) and returns how many were removed
+	DeleteByStatus(ctx context.Context, status string) (int64, error)
 }
 
 // TagRepository handles tag persistence operations (Phase 1)
@@ -321,6 +335,37 @@ type CitationRepository interface {
 	DeleteByDigestID(ctx context.Context, digestID string) error
 }
 
+// LinkRepository handles short links and click tracking for shared digests
+type LinkRepository interface {
+	// Create stores a tracked link (no-op if the code already exists)
+	Create(ctx context.Context, link *core.TrackedLink) error
+
+	// GetByCode retrieves a tracked link by its short code
+	GetByCode(ctx context.Context, code string) (*core.TrackedLink, error)
+
+	// RecordClick logs a click on a tracked link
+	RecordClick(ctx context.Context, code string, referer string, userAgent string) error
+
+	// ClickStats returns links ordered by clicks since the given time,
+	// optionally restricted to a single digest (empty digestID = all)
+	ClickStats(ctx context.Context, since time.Time, digestID string, limit int) ([]core.TrackedLink, error)
+}
+
+// LLMCallRepository records LLM usage for per-article and per-phase cost attribution
+type LLMCallRepository interface {
+	// Record stores one LLM call
+	Record(ctx context.Context, call *core.LLMCall) error
+
+	// AttachSummary links an article's unattributed summarize calls to the summary they produced
+	AttachSummary(ctx context.Context, articleID string, summaryID string) error
+
+	// ListByDigest returns the calls made for a digest and for the articles it contains
+	ListByDigest(ctx context.Context, digestID string) ([]core.LLMCall, error)
+
+	// ListSince returns every call made in [since, until), oldest first
+	ListSince(ctx context.Context, since, until time.Time) ([]core.LLMCall, error)
+}
+
 // ClusterCoherenceRepository handles cluster coherence metrics persistence
 // Used for tracking clustering quality over time for trend analysis
 type ClusterCoherenceRepository interface {
@@ -396,6 +441,12 @@ type Database interface {
 	// ClusterCoherence returns the cluster coherence metrics repository
 	ClusterCoherence() ClusterCoherenceRepository
 
+	// Links returns the short link and click tracking repository
+	Links() LinkRepository
+
+	// LLMCalls returns the LLM usage repository
+	LLMCalls() LLMCallRepository
+
 	// Close closes the database connection
 	Close() error
 
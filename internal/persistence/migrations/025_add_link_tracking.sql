@@ -0,0 +1,26 @@
+-- Migration 025: Add short links and click tracking for shared digests
+-- Article links in Slack/email digests can be rewritten through the built-in
+-- redirector (/r/{code}), which records a click before redirecting
+
+CREATE TABLE IF NOT EXISTS tracked_links (
+    code VARCHAR(16) PRIMARY KEY,
+    url TEXT NOT NULL,
+    digest_id VARCHAR(255), -- Optional: digest the link was shared in
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tracked_links_digest_id ON tracked_links(digest_id);
+
+CREATE TABLE IF NOT EXISTS link_clicks (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(16) NOT NULL REFERENCES tracked_links(code) ON DELETE CASCADE,
+    clicked_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    referer TEXT,
+    user_agent TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_clicks_code ON link_clicks(code);
+CREATE INDEX IF NOT EXISTS idx_link_clicks_clicked_at ON link_clicks(clicked_at DESC);
+
+COMMENT ON TABLE tracked_links IS 'Short codes for article links shared in digests';
+COMMENT ON TABLE link_clicks IS 'One row per redirect through /r/{code}';
@@ -0,0 +1,11 @@
+-- Migration 026: Add browser capture fields to manual URLs
+-- A browser extension can POST pages to /api/capture with the highlighted text,
+-- a note, and tags; they are queued alongside manually added URLs
+
+ALTER TABLE manual_urls ADD COLUMN IF NOT EXISTS selection TEXT NOT NULL DEFAULT '';
+ALTER TABLE manual_urls ADD COLUMN IF NOT EXISTS note TEXT NOT NULL DEFAULT '';
+ALTER TABLE manual_urls ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN manual_urls.selection IS 'Text highlighted on the page when it was captured';
+COMMENT ON COLUMN manual_urls.note IS 'Reader note attached to the capture';
+COMMENT ON COLUMN manual_urls.tags IS 'Free-form tags attached to the capture';
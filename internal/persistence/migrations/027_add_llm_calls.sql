@@ -0,0 +1,25 @@
+-- Migration 027: Record every LLM call with its tokens, latency, and retries
+-- Calls are attributed to the article, summary, or digest they were made for,
+-- and to the pipeline phase, so 'briefly cost breakdown' can show where the money went
+
+CREATE TABLE IF NOT EXISTS llm_calls (
+    id SERIAL PRIMARY KEY,
+    article_id VARCHAR(255), -- Article the call was made for (summaries, embeddings, classification)
+    summary_id VARCHAR(255), -- Summary the call produced, once saved
+    digest_id VARCHAR(255),  -- Digest the call was made for (narratives, executive summary)
+    phase VARCHAR(50) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    tokens_in INTEGER NOT NULL DEFAULT 0,
+    tokens_out INTEGER NOT NULL DEFAULT 0,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    retries INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_llm_calls_article_id ON llm_calls(article_id);
+CREATE INDEX IF NOT EXISTS idx_llm_calls_summary_id ON llm_calls(summary_id);
+CREATE INDEX IF NOT EXISTS idx_llm_calls_digest_id ON llm_calls(digest_id);
+
+COMMENT ON TABLE llm_calls IS 'One row per LLM call, for per-article and per-phase cost attribution';
+COMMENT ON COLUMN llm_calls.retries IS 'Attempts before this one for the same request (0 = first attempt)';
@@ -0,0 +1,10 @@
+-- Migration 028: Organize feeds into categories (folders)
+-- A feed belongs to at most one category; 'briefly digest --from-feeds --category X'
+-- builds a digest from articles whose feed items came from that category's feeds
+
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS category VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_feeds_category ON feeds(category);
+
+-- Articles are joined to their feed items by URL when filtering by category
+CREATE INDEX IF NOT EXISTS idx_feed_items_link ON feed_items(link);
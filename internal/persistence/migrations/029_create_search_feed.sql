@@ -0,0 +1,21 @@
+-- Migration 029: Create the "Followed Topics" feed
+-- Results of standing search queries ('feeds.follow') are queued as feed items of
+-- this feed during 'briefly feed pull', alongside items from RSS sources
+
+INSERT INTO feeds (
+    id,
+    url,
+    title,
+    description,
+    active,
+    error_count,
+    date_added
+) VALUES (
+    'search',  -- Fixed ID, sources.SearchFeedID
+    'internal://search',  -- Special URL to indicate it's not a real feed
+    'Followed Topics',
+    'Search results for standing topic queries',
+    false,  -- Inactive - don't fetch this as a real feed
+    0,
+    NOW()
+) ON CONFLICT (id) DO UPDATE SET active = false;
@@ -0,0 +1,7 @@
+-- Migration 030: Store when articles were published
+-- Dates come from the feed item or the page's meta tags and JSON-LD; digests split
+-- articles published within the fresh window from evergreen/older ones
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS date_published TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_articles_date_published ON articles(date_published DESC);
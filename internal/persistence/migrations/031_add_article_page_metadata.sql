@@ -0,0 +1,6 @@
+-- Migration 031: Store page metadata read from OpenGraph, Twitter-card, and JSON-LD tags
+-- Bylines, site names, and hero images come from the page itself, without LLM calls
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS author TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS site_name TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS hero_image_url TEXT;
@@ -0,0 +1,4 @@
+-- Migration 032: Keep the full body some feeds publish (RSS content:encoded, Atom content)
+-- Items whose feed carries the whole article are built from it instead of fetching the page
+
+ALTER TABLE feed_items ADD COLUMN IF NOT EXISTS content TEXT;
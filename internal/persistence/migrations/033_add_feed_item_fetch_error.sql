@@ -0,0 +1,6 @@
+-- Migration 033: Record why a queued feed item couldn't be fetched
+-- Failed items are still marked processed; the error lets ops reports group failures by domain
+
+ALTER TABLE feed_items ADD COLUMN IF NOT EXISTS fetch_error TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_feed_items_fetch_error ON feed_items(date_discovered DESC) WHERE fetch_error IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration 034: Keep summary quality scores with the summary
+-- Summaries left below the quality threshold after re-summarization stay flagged when
+-- a later run loads them, so they are still listed for review
+
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS quality_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS quality_issues JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS quality_flagged BOOLEAN NOT NULL DEFAULT FALSE;
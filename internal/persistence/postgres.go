@@ -20,11 +20,13 @@ type PostgresDB struct {
 	feeds            FeedRepository
 	feedItems        FeedItemRepository
 	digests          DigestRepository
-	themes           ThemeRepository           // Phase 0
-	manualURLs       ManualURLRepository       // Phase 0
-	citations        CitationRepository        // Phase 1
-	tags             TagRepository             // Phase 1
+	themes           ThemeRepository            // Phase 0
+	manualURLs       ManualURLRepository        // Phase 0
+	citations        CitationRepository         // Phase 1
+	tags             TagRepository              // Phase 1
 	clusterCoherence ClusterCoherenceRepository // Cluster quality metrics
+	links            LinkRepository             // Short links and click tracking
+	llmCalls         LLMCallRepository          // LLM usage for cost attribution
 }
 
 // NewPostgresDB creates a new PostgreSQL database connection
@@ -52,25 +54,29 @@ func NewPostgresDB(connectionString string) (*PostgresDB, error) {
 	pgDB.feeds = &postgresFeedRepo{db: db}
 	pgDB.feedItems = &postgresFeedItemRepo{db: db}
 	pgDB.digests = &postgresDigestRepo{db: db}
-	pgDB.themes = &postgresThemeRepo{db: db}                       // Phase 0
-	pgDB.manualURLs = &postgresManualURLRepo{db: db}               // Phase 0
-	pgDB.citations = &postgresCitationRepo{db: db}                 // Phase 1
-	pgDB.tags = &postgresTagRepo{db: db}                           // Phase 1
-	pgDB.clusterCoherence = &postgresClusterCoherenceRepo{db: db}  // Cluster quality metrics
+	pgDB.themes = &postgresThemeRepo{db: db}                      // Phase 0
+	pgDB.manualURLs = &postgresManualURLRepo{db: db}              // Phase 0
+	pgDB.citations = &postgresCitationRepo{db: db}                // Phase 1
+	pgDB.tags = &postgresTagRepo{db: db}                          // Phase 1
+	pgDB.clusterCoherence = &postgresClusterCoherenceRepo{db: db} // Cluster quality metrics
+	pgDB.links = &postgresLinkRepo{db: db}                        // Short links and click tracking
+	pgDB.llmCalls = &postgresLLMCallRepo{db: db}                  // LLM usage for cost attribution
 
 	return pgDB, nil
 }
 
-func (p *PostgresDB) Articles() ArticleRepository                   { return p.articles }
-func (p *PostgresDB) Summaries() SummaryRepository                   { return p.summaries }
-func (p *PostgresDB) Feeds() FeedRepository                          { return p.feeds }
-func (p *PostgresDB) FeedItems() FeedItemRepository                  { return p.feedItems }
-func (p *PostgresDB) Digests() DigestRepository                      { return p.digests }
-func (p *PostgresDB) Themes() ThemeRepository                        { return p.themes }           // Phase 0
-func (p *PostgresDB) ManualURLs() ManualURLRepository                { return p.manualURLs }       // Phase 0
-func (p *PostgresDB) Citations() CitationRepository                  { return p.citations }        // Phase 1
-func (p *PostgresDB) Tags() TagRepository                            { return p.tags }             // Phase 1
-func (p *PostgresDB) ClusterCoherence() ClusterCoherenceRepository   { return p.clusterCoherence } // Cluster quality metrics
+func (p *PostgresDB) Articles() ArticleRepository                  { return p.articles }
+func (p *PostgresDB) Summaries() SummaryRepository                 { return p.summaries }
+func (p *PostgresDB) Feeds() FeedRepository                        { return p.feeds }
+func (p *PostgresDB) FeedItems() FeedItemRepository                { return p.feedItems }
+func (p *PostgresDB) Digests() DigestRepository                    { return p.digests }
+func (p *PostgresDB) Themes() ThemeRepository                      { return p.themes }           // Phase 0
+func (p *PostgresDB) ManualURLs() ManualURLRepository              { return p.manualURLs }       // Phase 0
+func (p *PostgresDB) Citations() CitationRepository                { return p.citations }        // Phase 1
+func (p *PostgresDB) Tags() TagRepository                          { return p.tags }             // Phase 1
+func (p *PostgresDB) ClusterCoherence() ClusterCoherenceRepository { return p.clusterCoherence } // Cluster quality metrics
+func (p *PostgresDB) Links() LinkRepository                        { return p.links }            // Short links and click tracking
+func (p *PostgresDB) LLMCalls() LLMCallRepository                  { return p.llmCalls }         // LLM usage for cost attribution
 
 func (p *PostgresDB) Close() error {
 	return p.db.Close()
@@ -157,8 +163,9 @@ func (r *postgresArticleRepo) Create(ctx context.Context, article *core.Article)
 		INSERT INTO articles (
 			id, url, title, content_type, cleaned_text, raw_content,
 			topic_cluster, cluster_confidence, embedding, embedding_vector, date_fetched, date_added,
-			theme_id, theme_relevance_score
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CAST($10 AS VECTOR(768)), $11, $12, $13, $14)
+			theme_id, theme_relevance_score, date_published,
+			author, site_name, hero_image_url
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CAST($10 AS VECTOR(768)), $11, $12, $13, $14, $15, $16, $17, $18)
 		ON CONFLICT (url) DO UPDATE SET
 			title = EXCLUDED.title,
 			content_type = EXCLUDED.content_type,
@@ -167,7 +174,11 @@ func (r *postgresArticleRepo) Create(ctx context.Context, article *core.Article)
 			embedding_vector = CAST(EXCLUDED.embedding_vector AS TEXT)::VECTOR(768),
 			theme_id = EXCLUDED.theme_id,
 			theme_relevance_score = EXCLUDED.theme_relevance_score,
-			date_fetched = EXCLUDED.date_fetched
+			date_fetched = EXCLUDED.date_fetched,
+			date_published = COALESCE(EXCLUDED.date_published, articles.date_published),
+			author = COALESCE(EXCLUDED.author, articles.author),
+			site_name = COALESCE(EXCLUDED.site_name, articles.site_name),
+			hero_image_url = COALESCE(EXCLUDED.hero_image_url, articles.hero_image_url)
 	`
 
 	// Convert embedding to VECTOR format for pgvector
@@ -190,7 +201,8 @@ func (r *postgresArticleRepo) Create(ctx context.Context, article *core.Article)
 		article.ID, article.URL, article.Title, article.ContentType,
 		article.CleanedText, article.RawContent, article.TopicCluster,
 		article.ClusterConfidence, embeddingJSON, embeddingVector, article.DateFetched, time.Now().UTC(),
-		article.ThemeID, article.ThemeRelevanceScore,
+		article.ThemeID, article.ThemeRelevanceScore, nullTime(article.DatePublished),
+		nullIfEmpty(article.Author), nullIfEmpty(article.SiteName), nullIfEmpty(article.HeroImage),
 	)
 
 	if err != nil {
@@ -203,7 +215,8 @@ func (r *postgresArticleRepo) Get(ctx context.Context, id string) (*core.Article
 	query := `
 		SELECT id, url, title, content_type, cleaned_text, raw_content,
 			   topic_cluster, cluster_confidence, embedding, date_fetched, date_added,
-			   theme_id, theme_relevance_score
+			   theme_id, theme_relevance_score, date_published,
+			   COALESCE(author, ''), COALESCE(site_name, ''), COALESCE(hero_image_url, '')
 		FROM articles WHERE id = $1
 	`
 	row := r.query().QueryRowContext(ctx, query, id)
@@ -214,7 +227,8 @@ func (r *postgresArticleRepo) GetByURL(ctx context.Context, url string) (*core.A
 	query := `
 		SELECT id, url, title, content_type, cleaned_text, raw_content,
 			   topic_cluster, cluster_confidence, embedding, date_fetched, date_added,
-			   theme_id, theme_relevance_score
+			   theme_id, theme_relevance_score, date_published,
+			   COALESCE(author, ''), COALESCE(site_name, ''), COALESCE(hero_image_url, '')
 		FROM articles WHERE url = $1
 	`
 	row := r.query().QueryRowContext(ctx, query, url)
@@ -225,7 +239,8 @@ func (r *postgresArticleRepo) List(ctx context.Context, opts ListOptions) ([]cor
 	query := `
 		SELECT id, url, title, content_type, cleaned_text, raw_content,
 			   topic_cluster, cluster_confidence, embedding, date_fetched, date_added,
-			   theme_id, theme_relevance_score
+			   theme_id, theme_relevance_score, date_published,
+			   COALESCE(author, ''), COALESCE(site_name, ''), COALESCE(hero_image_url, '')
 		FROM articles
 		ORDER BY date_added DESC
 		LIMIT $1 OFFSET $2
@@ -294,7 +309,9 @@ func (r *postgresArticleRepo) UpdateClusterAssignment(ctx context.Context, artic
 func (r *postgresArticleRepo) GetRecent(ctx context.Context, since time.Time, limit int) ([]core.Article, error) {
 	query := `
 		SELECT id, url, title, content_type, cleaned_text, raw_content,
-			   topic_cluster, cluster_confidence, embedding, date_fetched, date_added
+			   topic_cluster, cluster_confidence, embedding, date_fetched, date_added,
+			   theme_id, theme_relevance_score, date_published,
+			   COALESCE(author, ''), COALESCE(site_name, ''), COALESCE(hero_image_url, '')
 		FROM articles
 		WHERE date_fetched >= $1
 		ORDER BY date_fetched DESC
@@ -320,7 +337,9 @@ func (r *postgresArticleRepo) GetRecent(ctx context.Context, since time.Time, li
 func (r *postgresArticleRepo) GetByCluster(ctx context.Context, clusterLabel string, limit int) ([]core.Article, error) {
 	query := `
 		SELECT id, url, title, content_type, cleaned_text, raw_content,
-			   topic_cluster, cluster_confidence, embedding, date_fetched, date_added
+			   topic_cluster, cluster_confidence, embedding, date_fetched, date_added,
+			   theme_id, theme_relevance_score, date_published,
+			   COALESCE(author, ''), COALESCE(site_name, ''), COALESCE(hero_image_url, '')
 		FROM articles
 		WHERE topic_cluster = $1
 		ORDER BY cluster_confidence DESC, date_fetched DESC
@@ -343,16 +362,51 @@ func (r *postgresArticleRepo) GetByCluster(ctx context.Context, clusterLabel str
 	return articles, rows.Err()
 }
 
+func (r *postgresArticleRepo) GetByFeedCategory(ctx context.Context, category string, since, until time.Time, limit int) ([]core.Article, error) {
+	query := `
+		SELECT a.id, a.url, a.title, a.content_type, a.cleaned_text, a.raw_content,
+			   a.topic_cluster, a.cluster_confidence, a.embedding, a.date_fetched, a.date_added,
+			   a.theme_id, a.theme_relevance_score, a.date_published,
+			   COALESCE(a.author, ''), COALESCE(a.site_name, ''), COALESCE(a.hero_image_url, '')
+		FROM articles a
+		WHERE a.date_fetched BETWEEN $2 AND $3
+		  AND EXISTS (
+			SELECT 1 FROM feed_items fi
+			JOIN feeds f ON f.id = fi.feed_id
+			WHERE fi.link = a.url AND f.category = $1
+		  )
+		ORDER BY a.date_fetched DESC
+		LIMIT $4
+	`
+	rows, err := r.query().QueryContext(ctx, query, category, since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var articles []core.Article
+	for rows.Next() {
+		article, err := r.scanArticleRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, *article)
+	}
+	return articles, rows.Err()
+}
+
 func (r *postgresArticleRepo) scanArticle(row *sql.Row) (*core.Article, error) {
 	var article core.Article
 	var embeddingJSON []byte
 	var dateAdded time.Time
+	var datePublished sql.NullTime
 
 	err := row.Scan(
 		&article.ID, &article.URL, &article.Title, &article.ContentType,
 		&article.CleanedText, &article.RawContent, &article.TopicCluster,
 		&article.ClusterConfidence, &embeddingJSON, &article.DateFetched, &dateAdded,
-		&article.ThemeID, &article.ThemeRelevanceScore,
+		&article.ThemeID, &article.ThemeRelevanceScore, &datePublished,
+		&article.Author, &article.SiteName, &article.HeroImage,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -366,6 +420,9 @@ func (r *postgresArticleRepo) scanArticle(row *sql.Row) (*core.Article, error) {
 			return nil, fmt.Errorf("failed to unmarshal embedding: %w", err)
 		}
 	}
+	if datePublished.Valid {
+		article.DatePublished = datePublished.Time
+	}
 
 	return &article, nil
 }
@@ -374,12 +431,14 @@ func (r *postgresArticleRepo) scanArticleRow(rows *sql.Rows) (*core.Article, err
 	var article core.Article
 	var embeddingJSON []byte
 	var dateAdded time.Time
+	var datePublished sql.NullTime
 
 	err := rows.Scan(
 		&article.ID, &article.URL, &article.Title, &article.ContentType,
 		&article.CleanedText, &article.RawContent, &article.TopicCluster,
 		&article.ClusterConfidence, &embeddingJSON, &article.DateFetched, &dateAdded,
-		&article.ThemeID, &article.ThemeRelevanceScore,
+		&article.ThemeID, &article.ThemeRelevanceScore, &datePublished,
+		&article.Author, &article.SiteName, &article.HeroImage,
 	)
 	if err != nil {
 		return nil, err
@@ -390,10 +449,18 @@ func (r *postgresArticleRepo) scanArticleRow(rows *sql.Rows) (*core.Article, err
 			return nil, fmt.Errorf("failed to unmarshal embedding: %w", err)
 		}
 	}
+	if datePublished.Valid {
+		article.DatePublished = datePublished.Time
+	}
 
 	return &article, nil
 }
 
+// nullTime stores a zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
 func (r *postgresArticleRepo) UpdateEmbedding(ctx context.Context, articleID string, embedding []float64) error {
 	// Validate embedding dimensions
 	if len(embedding) != 768 {
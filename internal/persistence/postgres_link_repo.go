@@ -0,0 +1,116 @@
+package persistence
+
+import (
+	"briefly/internal/core"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// postgresLinkRepo implements LinkRepository for PostgreSQL
+type postgresLinkRepo struct {
+	db *sql.DB
+	tx *sql.Tx
+}
+
+func (r *postgresLinkRepo) query() interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+} {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+func (r *postgresLinkRepo) Create(ctx context.Context, link *core.TrackedLink) error {
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = time.Now().UTC()
+	}
+
+	query := `
+		INSERT INTO tracked_links (code, url, digest_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code) DO NOTHING
+	`
+	_, err := r.query().ExecContext(ctx, query, link.Code, link.URL, link.DigestID, link.CreatedAt)
+	return err
+}
+
+func (r *postgresLinkRepo) GetByCode(ctx context.Context, code string) (*core.TrackedLink, error) {
+	query := `
+		SELECT code, url, digest_id, created_at
+		FROM tracked_links
+		WHERE code = $1
+	`
+
+	var link core.TrackedLink
+	var digestID sql.NullString
+	err := r.query().QueryRowContext(ctx, query, code).Scan(&link.Code, &link.URL, &digestID, &link.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("tracked link not found")
+		}
+		return nil, err
+	}
+	if digestID.Valid {
+		link.DigestID = &digestID.String
+	}
+
+	return &link, nil
+}
+
+func (r *postgresLinkRepo) RecordClick(ctx context.Context, code string, referer string, userAgent string) error {
+	query := `
+		INSERT INTO link_clicks (code, clicked_at, referer, user_agent)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.query().ExecContext(ctx, query, code, time.Now().UTC(), referer, userAgent)
+	return err
+}
+
+func (r *postgresLinkRepo) ClickStats(ctx context.Context, since time.Time, digestID string, limit int) ([]core.TrackedLink, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	query := `
+		SELECT l.code, l.url, l.digest_id, l.created_at,
+		       COUNT(c.id) AS clicks, MAX(c.clicked_at) AS last_clicked
+		FROM tracked_links l
+		LEFT JOIN link_clicks c ON c.code = l.code AND c.clicked_at >= $1
+		WHERE ($2 = '' OR l.digest_id = $2)
+		GROUP BY l.code, l.url, l.digest_id, l.created_at
+		ORDER BY clicks DESC, l.created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.query().QueryContext(ctx, query, since, digestID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query click stats failed: %w", err)
+	}
+	defer rows.Close()
+
+	var links []core.TrackedLink
+	for rows.Next() {
+		var link core.TrackedLink
+		var linkDigestID sql.NullString
+		var lastClicked sql.NullTime
+
+		if err := rows.Scan(&link.Code, &link.URL, &linkDigestID, &link.CreatedAt, &link.Clicks, &lastClicked); err != nil {
+			return nil, fmt.Errorf("scan click stats failed: %w", err)
+		}
+		if linkDigestID.Valid {
+			link.DigestID = &linkDigestID.String
+		}
+		if lastClicked.Valid {
+			link.LastClicked = &lastClicked.Time
+		}
+
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"briefly/internal/core"
+	"context"
+	"database/sql"
+	"time"
+)
+
+// postgresLLMCallRepo implements LLMCallRepository for PostgreSQL
+type postgresLLMCallRepo struct {
+	db *sql.DB
+	tx *sql.Tx
+}
+
+func (r *postgresLLMCallRepo) query() interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+} {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+func (r *postgresLLMCallRepo) Record(ctx context.Context, call *core.LLMCall) error {
+	if call.CreatedAt.IsZero() {
+		call.CreatedAt = time.Now().UTC()
+	}
+
+	query := `
+		INSERT INTO llm_calls (article_id, summary_id, digest_id, phase, model,
+			tokens_in, tokens_out, latency_ms, retries, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+	return r.query().QueryRowContext(ctx, query,
+		nullIfEmpty(call.ArticleID), nullIfEmpty(call.SummaryID), nullIfEmpty(call.DigestID),
+		call.Phase, call.Model, call.TokensIn, call.TokensOut, call.LatencyMs, call.Retries,
+		call.Error, call.CreatedAt,
+	).Scan(&call.ID)
+}
+
+func (r *postgresLLMCallRepo) AttachSummary(ctx context.Context, articleID string, summaryID string) error {
+	query := `
+		UPDATE llm_calls
+		SET summary_id = $2
+		WHERE article_id = $1 AND summary_id IS NULL AND phase = 'summarize'
+	`
+	_, err := r.query().ExecContext(ctx, query, articleID, summaryID)
+	return err
+}
+
+func (r *postgresLLMCallRepo) ListByDigest(ctx context.Context, digestID string) ([]core.LLMCall, error) {
+	query := `
+		SELECT id, article_id, summary_id, digest_id, phase, model,
+			   tokens_in, tokens_out, latency_ms, retries, error, created_at
+		FROM llm_calls
+		WHERE digest_id = $1
+		   OR article_id IN (SELECT article_id FROM digest_articles WHERE digest_id = $1)
+		ORDER BY created_at
+	`
+	rows, err := r.query().QueryContext(ctx, query, digestID)
+	if err != nil {
+		return nil, err
+	}
+	return scanLLMCalls(rows)
+}
+
+func (r *postgresLLMCallRepo) ListSince(ctx context.Context, since, until time.Time) ([]core.LLMCall, error) {
+	query := `
+		SELECT id, article_id, summary_id, digest_id, phase, model,
+			   tokens_in, tokens_out, latency_ms, retries, error, created_at
+		FROM llm_calls
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at
+	`
+	rows, err := r.query().QueryContext(ctx, query, since, until)
+	if err != nil {
+		return nil, err
+	}
+	return scanLLMCalls(rows)
+}
+
+// scanLLMCalls reads llm_calls rows selected in column order, closing rows
+func scanLLMCalls(rows *sql.Rows) ([]core.LLMCall, error) {
+	defer rows.Close()
+
+	var calls []core.LLMCall
+	for rows.Next() {
+		var call core.LLMCall
+		var articleID, summaryID, callDigestID sql.NullString
+		if err := rows.Scan(&call.ID, &articleID, &summaryID, &callDigestID, &call.Phase, &call.Model,
+			&call.TokensIn, &call.TokensOut, &call.LatencyMs, &call.Retries, &call.Error, &call.CreatedAt); err != nil {
+			return nil, err
+		}
+		call.ArticleID = articleID.String
+		call.SummaryID = summaryID.String
+		call.DigestID = callDigestID.String
+		calls = append(calls, call)
+	}
+	return calls, rows.Err()
+}
+
+// nullIfEmpty stores empty strings as NULL
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -13,6 +13,22 @@ import (
 	"github.com/lib/pq"
 )
 
+// summaryColumns are the summaries columns scanSummary and scanSummaryRow read
+const summaryColumns = "id, article_ids, summary_text, model_used, date_created, quality_score, quality_issues, quality_flagged"
+
+// marshalQualityIssues encodes a summary's quality issues for the quality_issues
+// column, which holds an empty array rather than null
+func marshalQualityIssues(issues []string) ([]byte, error) {
+	if issues == nil {
+		issues = []string{}
+	}
+	data, err := json.Marshal(issues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal quality issues: %w", err)
+	}
+	return data, nil
+}
+
 // postgresSummaryRepo implements SummaryRepository for PostgreSQL
 type postgresSummaryRepo struct {
 	db *sql.DB
@@ -36,24 +52,30 @@ func (r *postgresSummaryRepo) Create(ctx context.Context, summary *core.Summary)
 		return fmt.Errorf("failed to marshal article IDs: %w", err)
 	}
 
+	qualityIssuesJSON, err := marshalQualityIssues(summary.QualityIssues)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO summaries (id, article_ids, summary_text, model_used, date_created)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO summaries (id, article_ids, summary_text, model_used, date_created, quality_score, quality_issues, quality_flagged)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 	_, err = r.query().ExecContext(ctx, query,
 		summary.ID, articleIDsJSON, summary.SummaryText, summary.ModelUsed, time.Now().UTC(),
+		summary.QualityScore, qualityIssuesJSON, summary.QualityFlagged,
 	)
 	return err
 }
 
 func (r *postgresSummaryRepo) Get(ctx context.Context, id string) (*core.Summary, error) {
-	query := `SELECT id, article_ids, summary_text, model_used, date_created FROM summaries WHERE id = $1`
+	query := "SELECT " + summaryColumns + ` FROM summaries WHERE id = $1`
 	row := r.query().QueryRowContext(ctx, query, id)
 	return r.scanSummary(row)
 }
 
 func (r *postgresSummaryRepo) GetByArticleID(ctx context.Context, articleID string) ([]core.Summary, error) {
-	query := `SELECT id, article_ids, summary_text, model_used, date_created FROM summaries WHERE article_ids @> $1`
+	query := "SELECT " + summaryColumns + ` FROM summaries WHERE article_ids @> $1`
 	rows, err := r.query().QueryContext(ctx, query, fmt.Sprintf(`["%s"]`, articleID))
 	if err != nil {
 		return nil, err
@@ -76,7 +98,7 @@ func (r *postgresSummaryRepo) List(ctx context.Context, opts ListOptions) ([]cor
 	if limit == 0 {
 		limit = 100
 	}
-	query := `SELECT id, article_ids, summary_text, model_used, date_created FROM summaries ORDER BY date_created DESC LIMIT $1 OFFSET $2`
+	query := "SELECT " + summaryColumns + ` FROM summaries ORDER BY date_created DESC LIMIT $1 OFFSET $2`
 	rows, err := r.query().QueryContext(ctx, query, limit, opts.Offset)
 	if err != nil {
 		return nil, err
@@ -100,8 +122,14 @@ func (r *postgresSummaryRepo) Update(ctx context.Context, summary *core.Summary)
 		return fmt.Errorf("failed to marshal article IDs: %w", err)
 	}
 
-	query := `UPDATE summaries SET article_ids = $2, summary_text = $3, model_used = $4 WHERE id = $1`
-	_, err = r.query().ExecContext(ctx, query, summary.ID, articleIDsJSON, summary.SummaryText, summary.ModelUsed)
+	qualityIssuesJSON, err := marshalQualityIssues(summary.QualityIssues)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE summaries SET article_ids = $2, summary_text = $3, model_used = $4, quality_score = $5, quality_issues = $6, quality_flagged = $7 WHERE id = $1`
+	_, err = r.query().ExecContext(ctx, query, summary.ID, articleIDsJSON, summary.SummaryText, summary.ModelUsed,
+		summary.QualityScore, qualityIssuesJSON, summary.QualityFlagged)
 	return err
 }
 
@@ -113,10 +141,11 @@ func (r *postgresSummaryRepo) Delete(ctx context.Context, id string) error {
 
 func (r *postgresSummaryRepo) scanSummary(row *sql.Row) (*core.Summary, error) {
 	var summary core.Summary
-	var articleIDsJSON []byte
+	var articleIDsJSON, qualityIssuesJSON []byte
 	var dateCreated time.Time
 
-	err := row.Scan(&summary.ID, &articleIDsJSON, &summary.SummaryText, &summary.ModelUsed, &dateCreated)
+	err := row.Scan(&summary.ID, &articleIDsJSON, &summary.SummaryText, &summary.ModelUsed, &dateCreated,
+		&summary.QualityScore, &qualityIssuesJSON, &summary.QualityFlagged)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("summary not found")
@@ -127,16 +156,20 @@ func (r *postgresSummaryRepo) scanSummary(row *sql.Row) (*core.Summary, error) {
 	if err := json.Unmarshal(articleIDsJSON, &summary.ArticleIDs); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal article IDs: %w", err)
 	}
+	if err := json.Unmarshal(qualityIssuesJSON, &summary.QualityIssues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal quality issues: %w", err)
+	}
 
 	return &summary, nil
 }
 
 func (r *postgresSummaryRepo) scanSummaryRow(rows *sql.Rows) (*core.Summary, error) {
 	var summary core.Summary
-	var articleIDsJSON []byte
+	var articleIDsJSON, qualityIssuesJSON []byte
 	var dateCreated time.Time
 
-	err := rows.Scan(&summary.ID, &articleIDsJSON, &summary.SummaryText, &summary.ModelUsed, &dateCreated)
+	err := rows.Scan(&summary.ID, &articleIDsJSON, &summary.SummaryText, &summary.ModelUsed, &dateCreated,
+		&summary.QualityScore, &qualityIssuesJSON, &summary.QualityFlagged)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +177,9 @@ func (r *postgresSummaryRepo) scanSummaryRow(rows *sql.Rows) (*core.Summary, err
 	if err := json.Unmarshal(articleIDsJSON, &summary.ArticleIDs); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal article IDs: %w", err)
 	}
+	if err := json.Unmarshal(qualityIssuesJSON, &summary.QualityIssues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal quality issues: %w", err)
+	}
 
 	return &summary, nil
 }
@@ -169,13 +205,13 @@ func (r *postgresFeedRepo) Create(ctx context.Context, feed *core.Feed) error {
 	query := `
 		INSERT INTO feeds (
 			id, url, title, description, last_fetched, last_modified, etag,
-			active, error_count, last_error, date_added
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			active, error_count, last_error, date_added, category
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 	_, err := r.query().ExecContext(ctx, query,
 		feed.ID, feed.URL, feed.Title, feed.Description, feed.LastFetched,
 		feed.LastModified, feed.ETag, feed.Active, feed.ErrorCount,
-		feed.LastError, feed.DateAdded,
+		feed.LastError, feed.DateAdded, feed.Category,
 	)
 	return err
 }
@@ -183,7 +219,7 @@ func (r *postgresFeedRepo) Create(ctx context.Context, feed *core.Feed) error {
 func (r *postgresFeedRepo) Get(ctx context.Context, id string) (*core.Feed, error) {
 	query := `
 		SELECT id, url, title, description, last_fetched, last_modified, etag,
-			   active, error_count, last_error, date_added
+			   active, error_count, last_error, date_added, category
 		FROM feeds WHERE id = $1
 	`
 	row := r.query().QueryRowContext(ctx, query, id)
@@ -193,7 +229,7 @@ func (r *postgresFeedRepo) Get(ctx context.Context, id string) (*core.Feed, erro
 func (r *postgresFeedRepo) GetByURL(ctx context.Context, url string) (*core.Feed, error) {
 	query := `
 		SELECT id, url, title, description, last_fetched, last_modified, etag,
-			   active, error_count, last_error, date_added
+			   active, error_count, last_error, date_added, category
 		FROM feeds WHERE url = $1
 	`
 	row := r.query().QueryRowContext(ctx, query, url)
@@ -203,7 +239,7 @@ func (r *postgresFeedRepo) GetByURL(ctx context.Context, url string) (*core.Feed
 func (r *postgresFeedRepo) ListActive(ctx context.Context) ([]core.Feed, error) {
 	query := `
 		SELECT id, url, title, description, last_fetched, last_modified, etag,
-			   active, error_count, last_error, date_added
+			   active, error_count, last_error, date_added, category
 		FROM feeds WHERE active = true
 		ORDER BY title
 	`
@@ -231,7 +267,7 @@ func (r *postgresFeedRepo) List(ctx context.Context, opts ListOptions) ([]core.F
 	}
 	query := `
 		SELECT id, url, title, description, last_fetched, last_modified, etag,
-			   active, error_count, last_error, date_added
+			   active, error_count, last_error, date_added, category
 		FROM feeds ORDER BY title LIMIT $1 OFFSET $2
 	`
 	rows, err := r.query().QueryContext(ctx, query, limit, opts.Offset)
@@ -256,12 +292,13 @@ func (r *postgresFeedRepo) Update(ctx context.Context, feed *core.Feed) error {
 		UPDATE feeds SET
 			url = $2, title = $3, description = $4, last_fetched = $5,
 			last_modified = $6, etag = $7, active = $8, error_count = $9,
-			last_error = $10
+			last_error = $10, category = $11
 		WHERE id = $1
 	`
 	_, err := r.query().ExecContext(ctx, query,
 		feed.ID, feed.URL, feed.Title, feed.Description, feed.LastFetched,
 		feed.LastModified, feed.ETag, feed.Active, feed.ErrorCount, feed.LastError,
+		feed.Category,
 	)
 	return err
 }
@@ -286,7 +323,7 @@ func (r *postgresFeedRepo) scanFeed(row *sql.Row) (*core.Feed, error) {
 	err := row.Scan(
 		&feed.ID, &feed.URL, &feed.Title, &feed.Description, &lastFetched,
 		&lastModified, &etag, &feed.Active, &feed.ErrorCount,
-		&lastError, &feed.DateAdded,
+		&lastError, &feed.DateAdded, &feed.Category,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -320,7 +357,7 @@ func (r *postgresFeedRepo) scanFeedRow(rows *sql.Rows) (*core.Feed, error) {
 	err := rows.Scan(
 		&feed.ID, &feed.URL, &feed.Title, &feed.Description, &lastFetched,
 		&lastModified, &etag, &feed.Active, &feed.ErrorCount,
-		&lastError, &feed.DateAdded,
+		&lastError, &feed.DateAdded, &feed.Category,
 	)
 	if err != nil {
 		return nil, err
@@ -363,11 +400,11 @@ func (r *postgresFeedItemRepo) query() interface {
 func (r *postgresFeedItemRepo) Create(ctx context.Context, item *core.FeedItem) error {
 	query := `
 		INSERT INTO feed_items (
-			id, feed_id, title, link, description, published, guid, processed, date_discovered
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			id, feed_id, title, link, description, content, published, guid, processed, date_discovered
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 	_, err := r.query().ExecContext(ctx, query,
-		item.ID, item.FeedID, item.Title, item.Link, item.Description,
+		item.ID, item.FeedID, item.Title, item.Link, item.Description, item.Content,
 		item.Published, item.GUID, item.Processed, item.DateDiscovered,
 	)
 	return err
@@ -393,8 +430,8 @@ func (r *postgresFeedItemRepo) CreateBatch(ctx context.Context, items []core.Fee
 
 	stmt, err := tx.PrepareContext(ctx, `
 		INSERT INTO feed_items (
-			id, feed_id, title, link, description, published, guid, processed, date_discovered
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			id, feed_id, title, link, description, content, published, guid, processed, date_discovered
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		ON CONFLICT (id) DO NOTHING
 	`)
 	if err != nil {
@@ -404,7 +441,7 @@ func (r *postgresFeedItemRepo) CreateBatch(ctx context.Context, items []core.Fee
 
 	for _, item := range items {
 		_, err := stmt.ExecContext(ctx,
-			item.ID, item.FeedID, item.Title, item.Link, item.Description,
+			item.ID, item.FeedID, item.Title, item.Link, item.Description, item.Content,
 			item.Published, item.GUID, item.Processed, item.DateDiscovered,
 		)
 		if err != nil {
@@ -420,7 +457,7 @@ func (r *postgresFeedItemRepo) CreateBatch(ctx context.Context, items []core.Fee
 
 func (r *postgresFeedItemRepo) Get(ctx context.Context, id string) (*core.FeedItem, error) {
 	query := `
-		SELECT id, feed_id, title, link, description, published, guid, processed, date_discovered
+		SELECT id, feed_id, title, link, description, COALESCE(content, ''), published, guid, processed, date_discovered
 		FROM feed_items WHERE id = $1
 	`
 	row := r.query().QueryRowContext(ctx, query, id)
@@ -429,7 +466,7 @@ func (r *postgresFeedItemRepo) Get(ctx context.Context, id string) (*core.FeedIt
 
 func (r *postgresFeedItemRepo) GetByFeedID(ctx context.Context, feedID string, limit int) ([]core.FeedItem, error) {
 	query := `
-		SELECT id, feed_id, title, link, description, published, guid, processed, date_discovered
+		SELECT id, feed_id, title, link, description, COALESCE(content, ''), published, guid, processed, date_discovered
 		FROM feed_items WHERE feed_id = $1
 		ORDER BY published DESC
 		LIMIT $2
@@ -453,7 +490,7 @@ func (r *postgresFeedItemRepo) GetByFeedID(ctx context.Context, feedID string, l
 
 func (r *postgresFeedItemRepo) GetUnprocessed(ctx context.Context, limit int) ([]core.FeedItem, error) {
 	query := `
-		SELECT id, feed_id, title, link, description, published, guid, processed, date_discovered
+		SELECT id, feed_id, title, link, description, COALESCE(content, ''), published, guid, processed, date_discovered
 		FROM feed_items WHERE processed = false
 		ORDER BY published DESC
 		LIMIT $1
@@ -481,7 +518,7 @@ func (r *postgresFeedItemRepo) List(ctx context.Context, opts ListOptions) ([]co
 		limit = 100
 	}
 	query := `
-		SELECT id, feed_id, title, link, description, published, guid, processed, date_discovered
+		SELECT id, feed_id, title, link, description, COALESCE(content, ''), published, guid, processed, date_discovered
 		FROM feed_items ORDER BY published DESC LIMIT $1 OFFSET $2
 	`
 	rows, err := r.query().QueryContext(ctx, query, limit, opts.Offset)
@@ -507,6 +544,38 @@ func (r *postgresFeedItemRepo) MarkProcessed(ctx context.Context, id string) err
 	return err
 }
 
+func (r *postgresFeedItemRepo) MarkFailed(ctx context.Context, id string, fetchError string) error {
+	query := `UPDATE feed_items SET processed = true, fetch_error = $2 WHERE id = $1`
+	_, err := r.query().ExecContext(ctx, query, id, fetchError)
+	return err
+}
+
+func (r *postgresFeedItemRepo) ListFailed(ctx context.Context, since time.Time, limit int) ([]core.FeedItem, error) {
+	query := `
+		SELECT id, feed_id, title, link, description, published, guid, processed, date_discovered, fetch_error
+		FROM feed_items
+		WHERE fetch_error IS NOT NULL AND date_discovered >= $1
+		ORDER BY date_discovered DESC
+		LIMIT $2
+	`
+	rows, err := r.query().QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []core.FeedItem
+	for rows.Next() {
+		var item core.FeedItem
+		if err := rows.Scan(&item.ID, &item.FeedID, &item.Title, &item.Link, &item.Description,
+			&item.Published, &item.GUID, &item.Processed, &item.DateDiscovered, &item.FetchError); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
 func (r *postgresFeedItemRepo) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM feed_items WHERE id = $1`
 	_, err := r.query().ExecContext(ctx, query, id)
@@ -516,7 +585,7 @@ func (r *postgresFeedItemRepo) Delete(ctx context.Context, id string) error {
 func (r *postgresFeedItemRepo) scanFeedItem(row *sql.Row) (*core.FeedItem, error) {
 	var item core.FeedItem
 	err := row.Scan(
-		&item.ID, &item.FeedID, &item.Title, &item.Link, &item.Description,
+		&item.ID, &item.FeedID, &item.Title, &item.Link, &item.Description, &item.Content,
 		&item.Published, &item.GUID, &item.Processed, &item.DateDiscovered,
 	)
 	if err != nil {
@@ -531,7 +600,7 @@ func (r *postgresFeedItemRepo) scanFeedItem(row *sql.Row) (*core.FeedItem, error
 func (r *postgresFeedItemRepo) scanFeedItemRow(rows *sql.Rows) (*core.FeedItem, error) {
 	var item core.FeedItem
 	err := rows.Scan(
-		&item.ID, &item.FeedID, &item.Title, &item.Link, &item.Description,
+		&item.ID, &item.FeedID, &item.Title, &item.Link, &item.Description, &item.Content,
 		&item.Published, &item.GUID, &item.Processed, &item.DateDiscovered,
 	)
 	if err != nil {
@@ -779,7 +848,8 @@ func (r *postgresDigestRepo) Get(ctx context.Context, id string) (*core.Digest,
 	// Load associated articles from digest_articles relationship
 	articlesQuery := `
 		SELECT a.id, a.url, a.title, a.content_type, a.publisher, a.cleaned_text,
-		       a.date_fetched, da.citation_order
+		       a.date_fetched, a.date_published,
+		       COALESCE(a.author, ''), COALESCE(a.site_name, ''), COALESCE(a.hero_image_url, ''), da.citation_order
 		FROM articles a
 		INNER JOIN digest_articles da ON a.id = da.article_id
 		WHERE da.digest_id = $1
@@ -796,6 +866,7 @@ func (r *postgresDigestRepo) Get(ctx context.Context, id string) (*core.Digest,
 		var article core.Article
 		var citationOrder int
 		var publisher sql.NullString // Handle nullable publisher field
+		var datePublished sql.NullTime
 
 		if err := articleRows.Scan(
 			&article.ID,
@@ -805,6 +876,10 @@ func (r *postgresDigestRepo) Get(ctx context.Context, id string) (*core.Digest,
 			&publisher,
 			&article.CleanedText,
 			&article.DateFetched,
+			&datePublished,
+			&article.Author,
+			&article.SiteName,
+			&article.HeroImage,
 			&citationOrder,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan article: %w", err)
@@ -814,6 +889,9 @@ func (r *postgresDigestRepo) Get(ctx context.Context, id string) (*core.Digest,
 		if publisher.Valid {
 			article.Publisher = publisher.String
 		}
+		if datePublished.Valid {
+			article.DatePublished = datePublished.Time
+		}
 
 		articles = append(articles, article)
 	}
@@ -1062,7 +1140,12 @@ func (r *postgresDigestRepo) StoreWithRelationships(ctx context.Context, digest
 		return fmt.Errorf("failed to marshal by_the_numbers: %w", err)
 	}
 
-	// Build legacy content JSONB for backward compatibility
+	// Build legacy content JSONB for backward compatibility. Issue and part name the
+	// digest's markdown file, so later digests can link to it as prior coverage.
+	dateGenerated := digest.Metadata.DateGenerated
+	if dateGenerated.IsZero() {
+		dateGenerated = time.Now()
+	}
 	contentJSON := map[string]interface{}{
 		"summary": digest.Summary,
 		"title":   digest.Title,
@@ -1071,7 +1154,10 @@ func (r *postgresDigestRepo) StoreWithRelationships(ctx context.Context, digest
 			"title":          digest.Title,
 			"tldr_summary":   digest.TLDRSummary,
 			"article_count":  digest.ArticleCount,
-			"date_generated": time.Now().UTC(),
+			"date_generated": dateGenerated.UTC(),
+			"issue":          digest.Metadata.Issue,
+			"part":           digest.Metadata.Part,
+			"parts":          digest.Metadata.Parts,
 		},
 	}
 	contentJSONBytes, err := json.Marshal(contentJSON)
@@ -1315,11 +1401,12 @@ func (r *postgresDigestRepo) GetDigestArticles(ctx context.Context, digestID str
 	query := `
 		SELECT
 			a.id, a.url, a.title, a.content_type, a.cleaned_text, a.raw_content,
-			a.topic_cluster, a.cluster_confidence, a.date_fetched, a.embedding
+			a.topic_cluster, a.cluster_confidence, a.date_fetched, a.date_published, a.embedding,
+			COALESCE(a.author, ''), COALESCE(a.site_name, ''), COALESCE(a.hero_image_url, '')
 		FROM articles a
 		INNER JOIN digest_articles da ON a.id = da.article_id
 		WHERE da.digest_id = $1
-		ORDER BY da.citation_number ASC`
+		ORDER BY da.citation_order ASC`
 
 	rows, err := r.query().QueryContext(ctx, query, digestID)
 	if err != nil {
@@ -1331,6 +1418,7 @@ func (r *postgresDigestRepo) GetDigestArticles(ctx context.Context, digestID str
 	for rows.Next() {
 		var article core.Article
 		var embedding []byte
+		var datePublished sql.NullTime
 
 		err := rows.Scan(
 			&article.ID,
@@ -1342,11 +1430,18 @@ func (r *postgresDigestRepo) GetDigestArticles(ctx context.Context, digestID str
 			&article.TopicCluster,
 			&article.ClusterConfidence,
 			&article.DateFetched,
+			&datePublished,
 			&embedding,
+			&article.Author,
+			&article.SiteName,
+			&article.HeroImage,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan article failed: %w", err)
 		}
+		if datePublished.Valid {
+			article.DatePublished = datePublished.Time
+		}
 
 		// Deserialize embedding if present
 		if len(embedding) > 0 {
@@ -1505,6 +1600,9 @@ func (r *postgresThemeRepo) scanThemeRow(rows *sql.Rows) (*core.Theme, error) {
 	return &theme, nil
 }
 
+// manualURLColumns is the column list scanned by scanManualURL and scanManualURLRow
+const manualURLColumns = `id, url, submitted_by, status, error_message, processed_at, created_at, selection, note, tags`
+
 // postgresManualURLRepo implements ManualURLRepository for PostgreSQL (Phase 0)
 type postgresManualURLRepo struct {
 	db *sql.DB
@@ -1524,9 +1622,13 @@ func (r *postgresManualURLRepo) query() interface {
 
 func (r *postgresManualURLRepo) Create(ctx context.Context, manualURL *core.ManualURL) error {
 	query := `
-		INSERT INTO manual_urls (id, url, submitted_by, status, error_message, processed_at, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO manual_urls (id, url, submitted_by, status, error_message, processed_at, created_at, selection, note, tags)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
+	tags := manualURL.Tags
+	if tags == nil {
+		tags = []string{}
+	}
 	_, err := r.query().ExecContext(ctx, query,
 		manualURL.ID,
 		manualURL.URL,
@@ -1535,6 +1637,9 @@ func (r *postgresManualURLRepo) Create(ctx context.Context, manualURL *core.Manu
 		manualURL.ErrorMessage,
 		manualURL.ProcessedAt,
 		time.Now().UTC(),
+		manualURL.Selection,
+		manualURL.Note,
+		pq.Array(tags),
 	)
 	return err
 }
@@ -1561,7 +1666,7 @@ func (r *postgresManualURLRepo) CreateBatch(ctx context.Context, urls []string,
 }
 
 func (r *postgresManualURLRepo) Get(ctx context.Context, id string) (*core.ManualURL, error) {
-	query := `SELECT id, url, submitted_by, status, error_message, processed_at, created_at FROM manual_urls WHERE id = $1`
+	query := `SELECT ` + manualURLColumns + ` FROM manual_urls WHERE id = $1`
 	row := r.query().QueryRowContext(ctx, query, id)
 	return r.scanManualURL(row)
 }
@@ -1571,7 +1676,7 @@ func (r *postgresManualURLRepo) List(ctx context.Context, opts ListOptions) ([]c
 	if limit == 0 {
 		limit = 100
 	}
-	query := `SELECT id, url, submitted_by, status, error_message, processed_at, created_at FROM manual_urls ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+	query := `SELECT ` + manualURLColumns + ` FROM manual_urls ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 	rows, err := r.query().QueryContext(ctx, query, limit, opts.Offset)
 	if err != nil {
 		return nil, err
@@ -1593,7 +1698,7 @@ func (r *postgresManualURLRepo) GetPending(ctx context.Context, limit int) ([]co
 	if limit == 0 {
 		limit = 100
 	}
-	query := `SELECT id, url, submitted_by, status, error_message, processed_at, created_at FROM manual_urls WHERE status = $1 ORDER BY created_at ASC LIMIT $2`
+	query := `SELECT ` + manualURLColumns + ` FROM manual_urls WHERE status = $1 ORDER BY created_at ASC LIMIT $2`
 	rows, err := r.query().QueryContext(ctx, query, core.ManualURLStatusPending, limit)
 	if err != nil {
 		return nil, err
@@ -1612,7 +1717,7 @@ func (r *postgresManualURLRepo) GetPending(ctx context.Context, limit int) ([]co
 }
 
 func (r *postgresManualURLRepo) GetByURL(ctx context.Context, url string) (*core.ManualURL, error) {
-	query := `SELECT id, url, submitted_by, status, error_message, processed_at, created_at FROM manual_urls WHERE url = $1`
+	query := `SELECT ` + manualURLColumns + ` FROM manual_urls WHERE url = $1`
 	row := r.query().QueryRowContext(ctx, query, url)
 	return r.scanManualURL(row)
 }
@@ -1621,7 +1726,7 @@ func (r *postgresManualURLRepo) GetByStatus(ctx context.Context, status string,
 	if limit == 0 {
 		limit = 100
 	}
-	query := `SELECT id, url, submitted_by, status, error_message, processed_at, created_at FROM manual_urls WHERE status = $1 ORDER BY created_at DESC LIMIT $2`
+	query := `SELECT ` + manualURLColumns + ` FROM manual_urls WHERE status = $1 ORDER BY created_at DESC LIMIT $2`
 	rows, err := r.query().QueryContext(ctx, query, status, limit)
 	if err != nil {
 		return nil, err
@@ -1664,6 +1769,20 @@ func (r *postgresManualURLRepo) Delete(ctx context.Context, id string) error {
 	return err
 }
 
+func (r *postgresManualURLRepo) DeleteByStatus(ctx context.Context, status string) (int64, error) {
+	query := `DELETE FROM manual_urls WHERE status = $1`
+	args := []interface{}{status}
+	if status == "" {
+		query = `DELETE FROM manual_urls`
+		args = nil
+	}
+	result, err := r.query().ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func (r *postgresManualURLRepo) scanManualURL(row *sql.Row) (*core.ManualURL, error) {
 	var manualURL core.ManualURL
 	err := row.Scan(
@@ -1674,6 +1793,9 @@ func (r *postgresManualURLRepo) scanManualURL(row *sql.Row) (*core.ManualURL, er
 		&manualURL.ErrorMessage,
 		&manualURL.ProcessedAt,
 		&manualURL.CreatedAt,
+		&manualURL.Selection,
+		&manualURL.Note,
+		pq.Array(&manualURL.Tags),
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -1694,6 +1816,9 @@ func (r *postgresManualURLRepo) scanManualURLRow(rows *sql.Rows) (*core.ManualUR
 		&manualURL.ErrorMessage,
 		&manualURL.ProcessedAt,
 		&manualURL.CreatedAt,
+		&manualURL.Selection,
+		&manualURL.Note,
+		pq.Array(&manualURL.Tags),
 	)
 	if err != nil {
 		return nil, err
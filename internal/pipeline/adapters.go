@@ -12,6 +12,7 @@ import (
 	"briefly/internal/render"
 	"briefly/internal/store"
 	"briefly/internal/vectorstore"
+	"briefly/internal/visual"
 	"context"
 	"fmt"
 	"strings"
@@ -72,13 +73,13 @@ func NewLLMAdapter(client *llm.Client) *LLMAdapter {
 }
 
 func (a *LLMAdapter) GenerateEmbedding(ctx context.Context, text string) ([]float64, error) {
-	return a.client.GenerateEmbedding(text)
+	return a.client.GenerateEmbeddingContext(ctx, text)
 }
 
 func (a *LLMAdapter) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float64, error) {
 	embeddings := make([][]float64, 0, len(texts))
 	for _, text := range texts {
-		emb, err := a.client.GenerateEmbedding(text)
+		emb, err := a.client.GenerateEmbeddingContext(ctx, text)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate embedding: %w", err)
 		}
@@ -92,10 +93,12 @@ type ClustererAdapter struct {
 	clusterer *clustering.KMeansClusterer
 }
 
-func NewClustererAdapter() *ClustererAdapter {
-	return &ClustererAdapter{
-		clusterer: clustering.NewKMeansClusterer(),
-	}
+// NewClustererAdapter creates a K-means clusterer adapter; seed fixes centroid
+// initialization (0 = random)
+func NewClustererAdapter(seed int64) *ClustererAdapter {
+	clusterer := clustering.NewKMeansClusterer()
+	clusterer.Seed = seed
+	return &ClustererAdapter{clusterer: clusterer}
 }
 
 func (a *ClustererAdapter) ClusterArticles(ctx context.Context, articles []core.Article, summaries []core.Summary, embeddings map[string][]float64) ([]core.TopicCluster, error) {
@@ -117,8 +120,10 @@ func (a *ClustererAdapter) ClusterArticles(ctx context.Context, articles []core.
 	for i, article := range articles {
 		articlesWithEmbeddings[i] = article
 
-		// Find the corresponding summary ID and embedding
-		if summaryID, exists := articleToSummaryID[article.ID]; exists {
+		// Pipeline embeddings are keyed by article ID; fall back to the summary ID
+		if embedding, hasEmbedding := embeddings[article.ID]; hasEmbedding {
+			articlesWithEmbeddings[i].Embedding = embedding
+		} else if summaryID, exists := articleToSummaryID[article.ID]; exists {
 			if embedding, hasEmbedding := embeddings[summaryID]; hasEmbedding {
 				articlesWithEmbeddings[i].Embedding = embedding
 			}
@@ -212,14 +217,16 @@ type LouvainClustererAdapter struct {
 	clusterer *clustering.LouvainClusterer
 }
 
-// NewLouvainClustererAdapter creates a new Louvain clusterer adapter
-func NewLouvainClustererAdapter(vectorStore VectorStore) *LouvainClustererAdapter {
+// NewLouvainClustererAdapter creates a new Louvain clusterer adapter; seed fixes
+// community detection (0 = random)
+func NewLouvainClustererAdapter(vectorStore VectorStore, seed int64) *LouvainClustererAdapter {
 	searcher := &vectorSearcherWrapper{store: vectorStore}
 	clusterer := clustering.NewLouvainClusterer(searcher).
 		WithTagAware(true).
 		WithResolution(1.0).
 		WithMinSimilarity(0.3).
-		WithMaxNeighbors(10)
+		WithMaxNeighbors(10).
+		WithSeed(seed)
 	return &LouvainClustererAdapter{clusterer: clusterer}
 }
 
@@ -307,9 +314,10 @@ func (a *NarrativeAdapter) SelectTopArticles(cluster core.TopicCluster, articles
 	return a.generator.SelectTopArticles(cluster, articles, n)
 }
 
-// GenerateClusterSummary generates a comprehensive narrative for a single cluster using ALL articles
+// GenerateClusterSummary generates a comprehensive narrative for a single cluster using ALL articles.
+// Failed syntheses are retried with smaller prompts before degrading to an extractive section.
 func (a *NarrativeAdapter) GenerateClusterSummary(ctx context.Context, cluster core.TopicCluster, articles map[string]core.Article, summaries map[string]core.Summary) (*core.ClusterNarrative, error) {
-	return a.generator.GenerateClusterSummary(ctx, cluster, articles, summaries)
+	return a.generator.GenerateClusterSection(ctx, cluster, articles, summaries)
 }
 
 // RendererAdapter wraps internal/render and templates
@@ -474,10 +482,13 @@ type CacheAdapter struct {
 }
 
 func NewCacheAdapter(cacheDir string) (*CacheAdapter, error) {
-	s, err := store.NewStore(cacheDir)
+	s, err := store.OpenStore(cacheDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize cache: %w", err)
 	}
+	if reason := s.Fallback(); reason != nil {
+		fmt.Printf("Warning: cache unavailable (%v); caching in memory for this run\n", reason)
+	}
 
 	return &CacheAdapter{
 		store: s,
@@ -495,10 +506,7 @@ func (a *CacheAdapter) GetArticleWithSummary(url string, ttl time.Duration) (*co
 		return nil, nil, nil // Cache miss - no article found
 	}
 
-	// Generate content hash for summary lookup
-	contentHash := fmt.Sprintf("%x", article.CleanedText)
-
-	summary, err := a.store.GetCachedSummary(article.URL, contentHash, ttl)
+	summary, err := a.store.GetCachedSummary(article.URL, store.SummaryContentHash(*article), ttl)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -511,10 +519,7 @@ func (a *CacheAdapter) StoreArticleWithSummary(article *core.Article, summary *c
 		return fmt.Errorf("failed to cache article: %w", err)
 	}
 
-	// Generate content hash
-	contentHash := fmt.Sprintf("%x", article.CleanedText)
-
-	if err := a.store.CacheSummary(*summary, article.URL, contentHash); err != nil {
+	if err := a.store.CacheSummary(*summary, article.URL, store.SummaryContentHash(*article)); err != nil {
 		return fmt.Errorf("failed to cache summary: %w", err)
 	}
 
@@ -570,8 +575,11 @@ func (a *BannerAdapter) GenerateBanner(ctx context.Context, digest *core.Digest,
 }
 
 func (a *BannerAdapter) AnalyzeThemes(digest *core.Digest) ([]core.ContentTheme, error) {
-	// Theme analysis for banners
-	return nil, fmt.Errorf("theme analysis not yet implemented in simplified architecture")
+	themes := visual.AnalyzeThemes(digest)
+	if len(themes) == 0 {
+		return nil, fmt.Errorf("digest has no articles to analyze")
+	}
+	return themes, nil
 }
 
 // LLMClientAdapter implements narrative.LLMClient for narrative generation
@@ -716,6 +724,13 @@ func (a *VectorStoreAdapter) SearchByTags(ctx context.Context, query VectorSearc
 	return a.convertResults(results), nil
 }
 
+// ArticleIndexer returns the wrapped store as an article indexer, or nil when the store
+// reads article metadata from Postgres itself (pgvector)
+func (a *VectorStoreAdapter) ArticleIndexer() vectorstore.ArticleIndexer {
+	indexer, _ := a.store.(vectorstore.ArticleIndexer)
+	return indexer
+}
+
 func (a *VectorStoreAdapter) Delete(ctx context.Context, articleID string) error {
 	return a.store.Delete(ctx, articleID)
 }
@@ -2,7 +2,9 @@ package pipeline
 
 import (
 	"briefly/internal/categorization"
+	"briefly/internal/clustering"
 	"briefly/internal/core"
+	"briefly/internal/fetch"
 	"briefly/internal/llm"
 	"briefly/internal/observability"
 	"briefly/internal/persistence"
@@ -10,8 +12,6 @@ import (
 	"briefly/internal/tags" // Phase 1: For tag classification
 	"context"
 	"fmt"
-
-	"google.golang.org/genai" // Phase 1: For structured summaries
 )
 
 // Builder helps construct a fully configured Pipeline
@@ -27,6 +27,7 @@ type Builder struct {
 	skipBanner     bool
 	useThemeSystem bool // Enable theme-based categorization
 	vectorStore    VectorStore // Phase 2: Optional vector store for semantic search
+	offlinePages   map[string]string // Offline mode: URL -> HTML served instead of fetching
 }
 
 // NewBuilder creates a new pipeline builder with default settings
@@ -114,6 +115,64 @@ func (b *Builder) WithVectorStore(store VectorStore) *Builder {
 	return b
 }
 
+// WithAudience writes summaries and digest content for audience
+func (b *Builder) WithAudience(audience core.Audience) *Builder {
+	if b.config != nil {
+		b.config.Audience = audience
+	}
+	return b
+}
+
+// WithTwoPassSummaries extracts quote-backed facts before each summary and writes
+// the summary from only those facts
+func (b *Builder) WithTwoPassSummaries(enabled bool) *Builder {
+	if b.config != nil {
+		b.config.TwoPassSummaries = enabled
+	}
+	return b
+}
+
+// WithContextBudget caps the cluster narrative tokens in the final digest prompt
+func (b *Builder) WithContextBudget(tokens int) *Builder {
+	if b.config != nil {
+		b.config.ContextBudget = tokens
+	}
+	return b
+}
+
+// WithSeed fixes the clustering seed so the same articles always cluster the same way
+// (0 = random)
+func (b *Builder) WithSeed(seed int64) *Builder {
+	if b.config != nil {
+		b.config.Seed = seed
+	}
+	return b
+}
+
+// WithEmbeddingSource sets the text articles are embedded from for clustering
+func (b *Builder) WithEmbeddingSource(source clustering.EmbeddingSource) *Builder {
+	if b.config != nil {
+		b.config.EmbeddingSource = source
+	}
+	return b
+}
+
+// WithFollowedAuthors ranks articles by the given authors higher within their cluster
+func (b *Builder) WithFollowedAuthors(names []string, boost float64) *Builder {
+	if b.config != nil {
+		b.config.FollowedAuthors = names
+		b.config.AuthorBoost = boost
+	}
+	return b
+}
+
+// WithOffline serves article HTML from pages instead of the network and disables
+// caching. Pair with llm.NewOfflineClient for a run with no external calls.
+func (b *Builder) WithOffline(pages map[string]string) *Builder {
+	b.offlinePages = pages
+	return b.WithoutCache()
+}
+
 // Build constructs a fully configured Pipeline
 func (b *Builder) Build() (*Pipeline, error) {
 	// Validate required components
@@ -124,6 +183,9 @@ func (b *Builder) Build() (*Pipeline, error) {
 	// Initialize all adapters
 	parser := NewParserAdapter()
 	fetcher := NewFetcherAdapter()
+	if b.offlinePages != nil {
+		fetcher = &FetcherAdapter{processor: fetch.NewOfflineContentProcessor(b.offlinePages)}
+	}
 	embedder := NewLLMAdapter(b.llmClient)
 
 	// Phase 2: Use Louvain community detection if vector store is available, otherwise K-means
@@ -131,10 +193,10 @@ func (b *Builder) Build() (*Pipeline, error) {
 	var clusterer TopicClusterer
 	if b.vectorStore != nil {
 		fmt.Println("🔍 Using Louvain community detection with pgvector HNSW index")
-		clusterer = NewLouvainClustererAdapter(b.vectorStore)
+		clusterer = NewLouvainClustererAdapter(b.vectorStore, b.config.Seed)
 	} else {
 		fmt.Println("📊 Using K-means clustering (legacy)")
-		clusterer = NewClustererAdapter()
+		clusterer = NewClustererAdapter(b.config.Seed)
 	}
 
 	orderer := NewOrdererAdapter()
@@ -143,6 +205,8 @@ func (b *Builder) Build() (*Pipeline, error) {
 	// Create LLM client adapter for narrative generation
 	llmClientAdapter := NewLLMClientAdapter(b.llmClient)
 	narrative := NewNarrativeAdapter(llmClientAdapter)
+	narrative.generator.SetAudience(b.config.Audience)
+	narrative.generator.SetContextBudget(b.config.ContextBudget)
 
 	// Initialize cache (optional)
 	var cache CacheManager
@@ -167,7 +231,10 @@ func (b *Builder) Build() (*Pipeline, error) {
 	// Create summarizer adapter using the new summarize package
 	llmClientForSummarize := &LLMClientForSummarize{client: b.llmClient}
 	var summarizerCore summarize.SummarizerInterface
-	summarizerCore = summarize.NewSummarizerWithDefaults(llmClientForSummarize)
+	summarizerOpts := summarize.DefaultSummarizerOptions()
+	summarizerOpts.Audience = b.config.Audience
+	summarizerOpts.TwoPass = b.config.TwoPassSummaries
+	summarizerCore = summarize.NewSummarizer(llmClientForSummarize, summarizerOpts)
 
 	// Phase 1: Wrap with LangFuse tracking if available
 	if b.langfuse != nil && b.langfuse.IsEnabled() {
@@ -280,19 +347,10 @@ func (l *LLMClientForSummarize) GenerateText(ctx context.Context, prompt string,
 	// Phase 1: Handle structured summary options with ResponseSchema
 	llmOptions := llm.TextGenerationOptions{}
 
-	// Try to extract options if provided
-	if options != nil {
-		// Handle struct with ResponseSchema (for structured summaries)
-		type StructuredOptions struct {
-			ResponseSchema *genai.Schema
-			Temperature    float32
-		}
-
-		// Type assertion to check if it's our structured options
-		if structOpts, ok := options.(StructuredOptions); ok {
-			llmOptions.ResponseSchema = structOpts.ResponseSchema
-			llmOptions.Temperature = structOpts.Temperature
-		}
+	// Handle structured summary options (for structured summaries)
+	if structOpts, ok := options.(summarize.GenerationOptions); ok {
+		llmOptions.ResponseSchema = structOpts.ResponseSchema
+		llmOptions.Temperature = structOpts.Temperature
 	}
 
 	return l.client.GenerateText(ctx, prompt, llmOptions)
@@ -0,0 +1,57 @@
+package pipeline
+
+import (
+	"briefly/internal/corpus"
+	"briefly/internal/llm"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateDigests_Offline runs the whole digest pipeline against the bundled
+// corpus with the offline LLM: no network, no API key, deterministic output
+func TestGenerateDigests_Offline(t *testing.T) {
+	dir := t.TempDir()
+	inputFile := filepath.Join(dir, "input.md")
+	if err := os.WriteFile(inputFile, []byte(corpus.InputMarkdown()), 0644); err != nil {
+		t.Fatalf("Failed to write input file: %v", err)
+	}
+
+	p, err := NewBuilder().
+		WithLLMClient(llm.NewOfflineClient()).
+		WithOffline(corpus.Pages()).
+		WithoutBanner().
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	results, err := p.GenerateDigests(context.Background(), DigestOptions{
+		InputFile:  inputFile,
+		OutputPath: dir,
+	})
+	if err != nil {
+		t.Fatalf("GenerateDigests failed: %v", err)
+	}
+	if len(results) == 0 {
+		t.Fatal("Expected at least one digest")
+	}
+
+	for _, result := range results {
+		if result.Stats.SuccessfulArticles != len(corpus.URLs()) {
+			t.Errorf("Expected all %d corpus articles to process, got %d", len(corpus.URLs()), result.Stats.SuccessfulArticles)
+		}
+		if result.MarkdownPath == "" {
+			continue
+		}
+		content, err := os.ReadFile(result.MarkdownPath)
+		if err != nil {
+			t.Fatalf("Failed to read rendered digest: %v", err)
+		}
+		if !strings.Contains(string(content), corpus.BaseURL) {
+			t.Errorf("Expected rendered digest to cite corpus URLs:\n%s", content)
+		}
+	}
+}
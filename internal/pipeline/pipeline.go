@@ -1,10 +1,16 @@
 package pipeline
 
 import (
+	"briefly/internal/authors"
+	"briefly/internal/clustering"
 	"briefly/internal/core"
+	"briefly/internal/fetch"
+	"briefly/internal/llm"
 	"briefly/internal/narrative"
 	"briefly/internal/persistence"
 	"briefly/internal/quality"
+	"briefly/internal/transcript"
+	"briefly/internal/visual"
 	"context"
 	"fmt"
 	"strings"
@@ -62,6 +68,25 @@ type Config struct {
 
 	// Phase 1: Summary settings
 	UseStructuredSummaries bool // Use structured summaries with sections (default: false)
+
+	// Audience tunes summary and digest prompts (empty = default framing)
+	Audience core.Audience
+
+	// TwoPassSummaries summarizes from an extracted fact sheet (summarize.two_pass)
+	TwoPassSummaries bool
+
+	// ContextBudget caps the tokens of cluster narratives in the final digest prompt (0 = narrative default)
+	ContextBudget int
+
+	// FollowedAuthors rank higher within their cluster by AuthorBoost (authors.follow)
+	FollowedAuthors []string
+	AuthorBoost     float64
+
+	// Seed fixes clustering for reproducible runs (0 = random)
+	Seed int64
+
+	// EmbeddingSource is the text articles are embedded from for clustering (empty = summary)
+	EmbeddingSource clustering.EmbeddingSource
 }
 
 // DefaultConfig returns sensible default configuration
@@ -255,6 +280,8 @@ func (p *Pipeline) GenerateDigests(ctx context.Context, opts DigestOptions) ([]D
 
 	stats.ClustersGenerated = len(clusters)
 	fmt.Printf("   ✓ Created %d topic clusters\n", stats.ClustersGenerated)
+	clustering.AssignKeywords(clusters, articles, summariesToMap(summaries))
+	rankBySignal(articles, summaries, clusters, p.config)
 
 	// Persist cluster assignments to database (Phase 1 fix)
 	if p.articleRepo != nil {
@@ -304,7 +331,7 @@ func (p *Pipeline) GenerateDigests(ctx context.Context, opts DigestOptions) ([]D
 
 	// Step 5: Generate cluster narratives (hierarchical summarization)
 	fmt.Printf("📖 Step 5/9: Generating cluster narratives from ALL articles...\n")
-	clusters, err = p.generateClusterNarratives(ctx, clusters, articles, summaries)
+	clusters, err = p.generateClusterNarratives(ctx, clusters, articles, summaries, nil)
 	if err != nil {
 		// Non-fatal: log warning and continue without cluster narratives
 		fmt.Printf("   ⚠️  Cluster narrative generation failed: %v\n", err)
@@ -372,6 +399,9 @@ func (p *Pipeline) GenerateDigests(ctx context.Context, opts DigestOptions) ([]D
 			fmt.Printf("   ✓ Generated: %s\n", digestContent.Title)
 		}
 
+		// Key moments quoting a video link to where they are said
+		transcript.AttachTimestamps(digestContent.KeyMoments, clusterArticles)
+
 		// Update digest with generated content
 		digest.Title = digestContent.Title
 		digest.TLDRSummary = digestContent.TLDRSummary
@@ -427,6 +457,9 @@ func (p *Pipeline) GenerateDigests(ctx context.Context, opts DigestOptions) ([]D
 			}
 		}
 
+		// Banner themes and alt text are filled even when no image is generated
+		digest.Banner = visual.PlanBanner(digest, p.config.BannerStyle)
+
 		// Render markdown for this digest
 		markdownPath := ""
 		if opts.OutputPath != "" {
@@ -466,6 +499,7 @@ type DatabaseDigestOptions struct {
 	Summaries     []core.Summary
 	NumClusters   int  // 0 = auto-determine
 	GenerateBanner bool
+	Now           time.Time // Reference time stamped on digests (zero = now); pinned by deterministic runs
 }
 
 // DatabaseDigestResult contains digests generated from database articles
@@ -504,8 +538,12 @@ func (p *Pipeline) GenerateDigestsFromDatabase(ctx context.Context, opts Databas
 		fmt.Printf("   ⚠️  Tag classification skipped (not configured)\n\n")
 	}
 
-	// Step 2: Generate embeddings from summaries (Phase 1 fix)
-	fmt.Printf("🧠 Step 2/6: Generating embeddings from summaries...\n")
+	// Step 2: Generate embeddings from summaries (Phase 1 fix), or full text when configured
+	embeddingSource := p.config.EmbeddingSource
+	if embeddingSource == "" {
+		embeddingSource = clustering.SourceSummary
+	}
+	fmt.Printf("🧠 Step 2/6: Generating embeddings from %s...\n", embeddingSource)
 	embeddings, err := p.generateEmbeddings(ctx, articles, summaries)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate embeddings: %w", err)
@@ -527,6 +565,7 @@ func (p *Pipeline) GenerateDigestsFromDatabase(ctx context.Context, opts Databas
 	} else {
 		fmt.Printf("   ⚠️  No article repository configured - embeddings not persisted\n\n")
 	}
+	p.indexEmbeddings(ctx, articles, embeddings)
 
 	// Step 3: Cluster articles by topic
 	fmt.Printf("🔗 Step 3/6: Clustering articles by topic...\n")
@@ -535,6 +574,8 @@ func (p *Pipeline) GenerateDigestsFromDatabase(ctx context.Context, opts Databas
 		return nil, fmt.Errorf("failed to cluster articles: %w", err)
 	}
 	fmt.Printf("   ✓ Created %d topic clusters\n", len(clusters))
+	clustering.AssignKeywords(clusters, articles, summariesToMap(summaries))
+	rankBySignal(articles, summaries, clusters, p.config)
 
 	// Persist cluster assignments to database (Phase 1 fix)
 	if p.articleRepo != nil {
@@ -566,9 +607,16 @@ func (p *Pipeline) GenerateDigestsFromDatabase(ctx context.Context, opts Databas
 		fmt.Printf("   ✓ Persisted %d cluster assignments\n\n", persistedCount)
 	}
 
+	// Each cluster becomes one digest; its ID is assigned up front so the narrative and
+	// digest LLM calls are attributed to it
+	digestIDs := make([]string, len(clusters))
+	for i := range digestIDs {
+		digestIDs[i] = uuid.NewString()
+	}
+
 	// Step 4: Generate cluster narratives (hierarchical summarization)
 	fmt.Printf("📖 Step 4/6: Generating cluster narratives from ALL articles...\n")
-	clustersWithNarratives, err := p.generateClusterNarratives(ctx, clusters, articles, summaries)
+	clustersWithNarratives, err := p.generateClusterNarratives(ctx, clusters, articles, summaries, digestIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate cluster narratives: %w", err)
 	}
@@ -577,6 +625,10 @@ func (p *Pipeline) GenerateDigestsFromDatabase(ctx context.Context, opts Databas
 	// Step 5: Generate digest for each cluster
 	fmt.Printf("✨ Step 5/6: Generating digest for each cluster...\n")
 	digests := make([]*core.Digest, 0, len(clustersWithNarratives))
+	processedDate := opts.Now.UTC()
+	if opts.Now.IsZero() {
+		processedDate = time.Now().UTC()
+	}
 
 	for i, cluster := range clustersWithNarratives {
 		fmt.Printf("   [%d/%d] Cluster: %s (%d articles)\n", i+1, len(clustersWithNarratives), cluster.Label, len(cluster.ArticleIDs))
@@ -593,7 +645,8 @@ func (p *Pipeline) GenerateDigestsFromDatabase(ctx context.Context, opts Databas
 		}
 
 		// Generate digest content using hierarchical summarization
-		digestContent, err := p.generateDigestContentWithNarratives(ctx, []core.TopicCluster{cluster}, clusterArticles, summaries)
+		digestCtx := llm.WithAttribution(ctx, llm.Attribution{DigestID: digestIDs[i], Phase: "digest"})
+		digestContent, err := p.generateDigestContentWithNarratives(digestCtx, []core.TopicCluster{cluster}, clusterArticles, summaries)
 		if err != nil {
 			fmt.Printf("   ⚠️  Failed to generate digest: %v\n", err)
 			continue
@@ -608,6 +661,17 @@ func (p *Pipeline) GenerateDigestsFromDatabase(ctx context.Context, opts Databas
 			}
 		}
 
+		// Convert narrative.MustReadHighlight to core.MustReadHighlight
+		var mustRead *core.MustReadHighlight
+		if digestContent.MustRead != nil {
+			mustRead = &core.MustReadHighlight{
+				ArticleNum:  digestContent.MustRead.ArticleNum,
+				Title:       digestContent.MustRead.Title,
+				WhyMustRead: digestContent.MustRead.WhyMustRead,
+				ReadTime:    digestContent.MustRead.ReadTime,
+			}
+		}
+
 		// Build Summary from v3.0 structured fields if ExecutiveSummary is empty
 		// This ensures quality metrics have content to evaluate
 		summary := digestContent.ExecutiveSummary
@@ -633,14 +697,15 @@ func (p *Pipeline) GenerateDigestsFromDatabase(ctx context.Context, opts Databas
 		// Build digest structure
 		clusterIDVal := i
 		digest := &core.Digest{
-			ID:              uuid.NewString(), // Use UUID for unique digest ID
+			ID:              digestIDs[i], // Assigned before narrative generation
 			ClusterID:       &clusterIDVal,
-			ProcessedDate:   time.Now().UTC(),
+			ProcessedDate:   processedDate,
 			Title:           digestContent.Title,            // v3.0 generated title
 			TLDRSummary:     digestContent.TLDRSummary,      // v3.0 one-sentence summary
 			TopDevelopments: digestContent.TopDevelopments,  // v3.0 bullet points
 			ByTheNumbers:    statistics,                     // v3.0 statistics (converted)
 			WhyItMatters:    digestContent.WhyItMatters,     // v3.0 impact
+			MustRead:        mustRead,                       // v3.1 must-read highlight
 			Summary:         summary,                        // Built from v3.0 fields if needed
 			Articles:        clusterArticles,
 			KeyMoments:      digestContent.KeyMoments,
@@ -742,6 +807,7 @@ func (p *Pipeline) processArticles(ctx context.Context, links []core.Link, stats
 			cachedArticle, cachedSummary, err := p.checkArticleCache(link.URL)
 			if err == nil && cachedArticle != nil && cachedSummary != nil {
 				fmt.Printf("           ✓ Cache hit\n")
+				fetch.ApplyLinkTitle(cachedArticle, link.Title)
 				articles = append(articles, *cachedArticle)
 				summaries = append(summaries, *cachedSummary)
 				stats.CacheHits++
@@ -758,6 +824,7 @@ func (p *Pipeline) processArticles(ctx context.Context, links []core.Link, stats
 			fmt.Printf("           ✗ Fetch failed: %v\n", err)
 			continue
 		}
+		fetch.ApplyLinkTitle(article, link.Title)
 
 		// Validate article quality
 		if len(article.CleanedText) < p.config.MinArticleLength {
@@ -820,26 +887,26 @@ func (p *Pipeline) generateEmbeddings(ctx context.Context, articles []core.Artic
 		fmt.Printf("   [%d/%d] Generating embedding for article %s\n", i+1, len(summaries), articleID)
 
 		// Get corresponding article for validation
-		_, found := articleMap[articleID]
+		article, found := articleMap[articleID]
 		if !found {
 			fmt.Printf("           ✗ Article not found for article ID %s\n", articleID)
 			failedCount++
 			continue
 		}
 
-		// Phase 1 Fix: Use summaries for embeddings (better signal-to-noise ratio)
-		// Summaries are already distilled by LLM and contain the key semantic content
-		// This leads to better clustering quality than using raw article content
-		embeddingText := summary.SummaryText
+		// Summaries by default: already distilled by the LLM, they carry the story's key
+		// semantic content at a fraction of the tokens, which clusters better than raw text
+		embeddingText := clustering.EmbeddingText(*article, summary.SummaryText, p.config.EmbeddingSource)
 
-		// Skip if summary is too short (likely an error)
+		// Skip if the text is too short (likely an error)
 		if len(embeddingText) < 50 {
-			fmt.Printf("           ✗ Summary too short (%d chars), skipping\n", len(embeddingText))
+			fmt.Printf("           ✗ Embedding text too short (%d chars), skipping\n", len(embeddingText))
 			failedCount++
 			continue
 		}
 
-		embedding, err := p.embedder.GenerateEmbedding(ctx, embeddingText)
+		embeddingCtx := llm.WithAttribution(ctx, llm.Attribution{ArticleID: articleID, SummaryID: summary.ID, Phase: "embedding"})
+		embedding, err := p.embedder.GenerateEmbedding(embeddingCtx, embeddingText)
 		if err != nil {
 			// Log error but continue with other articles
 			fmt.Printf("           ✗ Embedding generation failed: %v\n", err)
@@ -864,7 +931,9 @@ func (p *Pipeline) generateEmbeddings(ctx context.Context, articles []core.Artic
 
 // generateClusterNarratives generates comprehensive narratives for each cluster using ALL articles
 // This implements hierarchical summarization: cluster summary → executive summary
-func (p *Pipeline) generateClusterNarratives(ctx context.Context, clusters []core.TopicCluster, articles []core.Article, summaries []core.Summary) ([]core.TopicCluster, error) {
+// digestIDs, when given, holds the ID of the digest each cluster becomes, in cluster order;
+// narrative LLM calls are attributed to it.
+func (p *Pipeline) generateClusterNarratives(ctx context.Context, clusters []core.TopicCluster, articles []core.Article, summaries []core.Summary, digestIDs []string) ([]core.TopicCluster, error) {
 	// Build maps for fast lookup
 	articleMap := articlesToMap(articles)
 	summaryMap := summariesToMap(summaries)
@@ -887,7 +956,12 @@ func (p *Pipeline) generateClusterNarratives(ctx context.Context, clusters []cor
 		fmt.Printf("   [%d/%d] Generating narrative for cluster: %s (%d articles)\n",
 			i+1, len(clusters), cluster.Label, len(cluster.ArticleIDs))
 
-		narrative, err := summarizer.GenerateClusterSummary(ctx, cluster, articleMap, summaryMap)
+		clusterCtx := ctx
+		if i < len(digestIDs) {
+			clusterCtx = llm.WithAttribution(ctx, llm.Attribution{DigestID: digestIDs[i]})
+		}
+
+		narrative, err := summarizer.GenerateClusterSummary(clusterCtx, cluster, articleMap, summaryMap)
 		if err != nil {
 			fmt.Printf("           ✗ Narrative generation failed: %v\n", err)
 			failedCount++
@@ -900,7 +974,11 @@ func (p *Pipeline) generateClusterNarratives(ctx context.Context, clusters []cor
 		cluster.Narrative = narrative
 		updatedClusters = append(updatedClusters, cluster)
 
-		fmt.Printf("           ✓ Generated narrative: %s\n", narrative.Title)
+		if narrative.Degraded {
+			fmt.Printf("           ⚠ Degraded section (built from article summaries): %s\n", narrative.Title)
+		} else {
+			fmt.Printf("           ✓ Generated narrative: %s\n", narrative.Title)
+		}
 		// Calculate word count from v3.1 fields (OneLiner + KeyDevelopments + KeyStats)
 		wordCount := len(strings.Fields(narrative.OneLiner))
 		for _, dev := range narrative.KeyDevelopments {
@@ -1003,7 +1081,23 @@ func (p *Pipeline) generateDigestContentWithNarratives(ctx context.Context, clus
 	// Use NEW generator with self-critique refinement pass
 	// This ensures quality through always-on critique (signal over noise)
 	critiqueConfig := narrative.DefaultCritiqueConfig()
-	return gen.GenerateDigestContentWithCritique(ctx, clusters, articleMap, summaryMap, critiqueConfig)
+	content, err := gen.GenerateDigestContentWithCritique(ctx, clusters, articleMap, summaryMap, critiqueConfig)
+	if err != nil && hasClusterNarratives(clusters) {
+		// Keep the cluster sections that did synthesize instead of failing the whole digest
+		fmt.Printf("   ⚠️  Final synthesis failed (%v); assembling digest from cluster sections\n", err)
+		return narrative.AssembleDigestContent(clusters, articleMap, summaryMap), nil
+	}
+	return content, err
+}
+
+// hasClusterNarratives reports whether any cluster has a generated narrative
+func hasClusterNarratives(clusters []core.TopicCluster) bool {
+	for _, cluster := range clusters {
+		if cluster.Narrative != nil {
+			return true
+		}
+	}
+	return false
 }
 
 // checkArticleCache checks if an article and its summary are cached
@@ -1046,6 +1140,21 @@ func articlesToMap(articles []core.Article) map[string]core.Article {
 	return result
 }
 
+// rankBySignal scores each article's signal-to-noise, boosts followed authors, and orders
+// every cluster's article IDs by it, so citation numbers, rendered order and the
+// Must-Read pick share one ranking
+func rankBySignal(articles []core.Article, summaries []core.Summary, clusters []core.TopicCluster, config *Config) {
+	quality.ApplySignalScores(articles, summariesToMap(summaries))
+	if config != nil {
+		authors.Boost(articles, config.FollowedAuthors, config.AuthorBoost)
+	}
+
+	articleMap := articlesToMap(articles)
+	for i := range clusters {
+		quality.SortArticleIDsBySignal(clusters[i].ArticleIDs, articleMap)
+	}
+}
+
 func summariesToMap(summaries []core.Summary) map[string]core.Summary {
 	result := make(map[string]core.Summary)
 	for _, summary := range summaries {
@@ -1111,6 +1220,44 @@ func (p *Pipeline) storeQualityMetrics(ctx context.Context, digest *core.Digest,
 
 // classifyArticlesIntoTags classifies articles with multi-label tags and persists assignments
 // This implements Phase 1 hierarchical clustering: theme → tag → semantic clustering
+// indexEmbeddings sends embeddings, with article metadata and tags, to a vector store
+// that keeps its own copy (Qdrant). pgvector reads the persisted embeddings directly.
+func (p *Pipeline) indexEmbeddings(ctx context.Context, articles []core.Article, embeddings map[string][]float64) {
+	adapter, ok := p.vectorStore.(*VectorStoreAdapter)
+	if !ok {
+		return
+	}
+	indexer := adapter.ArticleIndexer()
+	if indexer == nil {
+		return
+	}
+
+	fmt.Printf("   • Indexing embeddings in vector store...\n")
+	indexedCount := 0
+	for _, article := range articles {
+		embedding, ok := embeddings[article.ID]
+		if !ok {
+			continue
+		}
+
+		var tagIDs []string
+		if p.tagRepo != nil {
+			if tags, _, err := p.tagRepo.GetArticleTags(ctx, article.ID); err == nil {
+				for _, tag := range tags {
+					tagIDs = append(tagIDs, tag.ID)
+				}
+			}
+		}
+
+		if err := indexer.IndexArticle(ctx, article, embedding, tagIDs); err != nil {
+			fmt.Printf("   ⚠️  Failed to index article %s: %v\n", article.ID, err)
+			continue
+		}
+		indexedCount++
+	}
+	fmt.Printf("   ✓ Indexed %d embeddings\n\n", indexedCount)
+}
+
 func (p *Pipeline) classifyArticlesIntoTags(ctx context.Context, articles []core.Article, summaries []core.Summary) (map[string]*TagClassificationResult, error) {
 	// Load all enabled tags from database
 	tags, err := p.tagRepo.ListEnabled(ctx)
@@ -1145,7 +1292,8 @@ func (p *Pipeline) classifyArticlesIntoTags(ctx context.Context, articles []core
 		}
 
 		// Classify article into tags
-		classification, err := p.tagClassifier.ClassifyArticle(ctx, article, &summary, tags, minRelevance)
+		classifyCtx := llm.WithAttribution(ctx, llm.Attribution{ArticleID: article.ID, Phase: "classify"})
+		classification, err := p.tagClassifier.ClassifyArticle(classifyCtx, article, &summary, tags, minRelevance)
 		if err != nil {
 			fmt.Printf("           ✗ Classification failed: %v\n", err)
 			failCount++
@@ -0,0 +1,251 @@
+// Package priority is the priority inbox: items are checked against alert rules and
+// a watchlist as they are pulled from feeds or captured, and anything that matches
+// is pushed right away instead of waiting for the next digest. Rules filter on
+// keywords first, so the model is only asked about items that could plausibly match.
+package priority
+
+import (
+	"briefly/internal/llm"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WatchlistRule names matches of the watchlist
+const WatchlistRule = "watchlist"
+
+// maxJudgeText caps the item text sent to the model with a condition
+const maxJudgeText = 1500
+
+// Item is something just pulled or captured
+type Item struct {
+	URL    string
+	Title  string
+	Text   string // Feed description, or a capture's selection and note
+	Source string // Feed title, "capture", or "collect"
+}
+
+// Rule marks items as urgent. With keywords, an item must mention one of them;
+// with a condition, the model must also answer yes to it. A rule needs at least one.
+type Rule struct {
+	Name      string
+	Keywords  []string
+	Condition string // Yes/no question about the item, e.g. "Is a widely used package actively exploited?"
+}
+
+// Match is an urgent item and the rule it met
+type Match struct {
+	Item  Item
+	Rule  string
+	Terms []string // Keywords or watchlist terms the item mentions
+}
+
+// Judge decides whether an item meets a rule's condition
+type Judge interface {
+	Meets(ctx context.Context, item Item, condition string) (bool, error)
+}
+
+// Triage checks items against the rules and watchlist
+type Triage struct {
+	rules     []compiledRule
+	watchlist *compiledTerms
+	judge     Judge
+}
+
+type compiledRule struct {
+	Rule
+	keywords *compiledTerms
+}
+
+// compiledTerms matches any of a list of terms as whole words, case-insensitively
+type compiledTerms struct {
+	terms   []string
+	pattern *regexp.Regexp
+}
+
+// New validates the rules and returns a Triage. judge may be nil when no rule has
+// a condition.
+func New(rules []Rule, watchlist []string, judge Judge) (*Triage, error) {
+	t := &Triage{watchlist: compileTerms(watchlist), judge: judge}
+	for i, rule := range rules {
+		name := strings.TrimSpace(rule.Name)
+		if name == "" {
+			return nil, fmt.Errorf("priority rule %d has no name", i+1)
+		}
+		keywords := compileTerms(rule.Keywords)
+		condition := strings.TrimSpace(rule.Condition)
+		if keywords == nil && condition == "" {
+			return nil, fmt.Errorf("priority rule %q needs keywords or a condition", name)
+		}
+		if condition != "" && judge == nil {
+			return nil, fmt.Errorf("priority rule %q has a condition but no model to judge it", name)
+		}
+		t.rules = append(t.rules, compiledRule{
+			Rule:     Rule{Name: name, Keywords: rule.Keywords, Condition: condition},
+			keywords: keywords,
+		})
+	}
+	return t, nil
+}
+
+// NewScreen returns a Triage that checks the rules' keywords and the watchlist only,
+// never asking the model: a pre-screen cheap enough for every pulled feed item.
+// Conditions are dropped, so a rule with keywords matches on them alone and a rule
+// with only a condition never matches.
+func NewScreen(rules []Rule, watchlist []string) (*Triage, error) {
+	var keywordRules []Rule
+	for _, rule := range rules {
+		if compileTerms(rule.Keywords) != nil {
+			keywordRules = append(keywordRules, Rule{Name: rule.Name, Keywords: rule.Keywords})
+		}
+	}
+	return New(keywordRules, watchlist, nil)
+}
+
+// Empty reports whether there is nothing to check items against
+func (t *Triage) Empty() bool {
+	return len(t.rules) == 0 && t.watchlist == nil
+}
+
+// Check returns the first rule an item meets, the watchlist last, or nil. A failed
+// condition check skips that rule and is returned alongside any later match.
+func (t *Triage) Check(ctx context.Context, item Item) (*Match, error) {
+	text := item.Title + "\n" + item.Text
+
+	var judgeErr error
+	for _, rule := range t.rules {
+		var terms []string
+		if rule.keywords != nil {
+			if terms = rule.keywords.find(text); len(terms) == 0 {
+				continue
+			}
+		}
+		if rule.Condition != "" {
+			meets, err := t.judge.Meets(ctx, item, rule.Condition)
+			if err != nil {
+				judgeErr = fmt.Errorf("rule %q: %w", rule.Name, err)
+				continue
+			}
+			if !meets {
+				continue
+			}
+		}
+		return &Match{Item: item, Rule: rule.Name, Terms: terms}, judgeErr
+	}
+
+	if t.watchlist != nil {
+		if terms := t.watchlist.find(text); len(terms) > 0 {
+			return &Match{Item: item, Rule: WatchlistRule, Terms: terms}, judgeErr
+		}
+	}
+	return nil, judgeErr
+}
+
+// Message formats an alert for Slack or Discord, with the item's summary when
+// there is one
+func (m Match) Message(summary string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🚨 **Priority: %s**", m.Rule)
+	if len(m.Terms) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(m.Terms, ", "))
+	}
+	b.WriteString("\n\n")
+
+	title := m.Item.Title
+	if title == "" {
+		title = m.Item.URL
+	}
+	fmt.Fprintf(&b, "**[%s](%s)**", title, m.Item.URL)
+	if m.Item.Source != "" {
+		fmt.Fprintf(&b, " · %s", m.Item.Source)
+	}
+	b.WriteString("\n")
+
+	if summary = strings.TrimSpace(summary); summary != "" {
+		b.WriteString("\n" + summary + "\n")
+	}
+	return b.String()
+}
+
+func compileTerms(terms []string) *compiledTerms {
+	var kept, alternatives []string
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		kept = append(kept, term)
+		alternatives = append(alternatives, wordBoundaries(term))
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return &compiledTerms{
+		terms:   kept,
+		pattern: regexp.MustCompile(`(?i)(` + strings.Join(alternatives, "|") + `)`),
+	}
+}
+
+// wordBoundaries quotes term and anchors it at word boundaries. \b only applies
+// next to word characters, so terms like "C++" or ".NET" are anchored on one side.
+func wordBoundaries(term string) string {
+	isWord := func(b byte) bool {
+		return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || b >= 0x80
+	}
+	pattern := regexp.QuoteMeta(term)
+	if isWord(term[0]) {
+		pattern = `\b` + pattern
+	}
+	if isWord(term[len(term)-1]) {
+		pattern += `\b`
+	}
+	return pattern
+}
+
+// find returns the terms text mentions, as configured, in configured order
+func (c *compiledTerms) find(text string) []string {
+	found := make(map[string]bool)
+	for _, match := range c.pattern.FindAllString(text, -1) {
+		found[strings.ToLower(match)] = true
+	}
+	var terms []string
+	for _, term := range c.terms {
+		if found[strings.ToLower(term)] {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// ModelJudge asks a model for a yes/no answer to a rule's condition
+type ModelJudge struct {
+	LLM   llm.Generator
+	Model string // Empty uses the client's model
+}
+
+// Meets reports whether the model answers yes to condition about item
+func (j ModelJudge) Meets(ctx context.Context, item Item, condition string) (bool, error) {
+	text := item.Text
+	if runes := []rune(text); len(runes) > maxJudgeText {
+		text = string(runes[:maxJudgeText])
+	}
+
+	prompt := fmt.Sprintf(`You triage incoming articles for urgent alerts. Answer the question about the article with YES or NO only.
+
+QUESTION: %s
+
+TITLE: %s
+URL: %s
+TEXT: %s
+
+Answer YES only when the article clearly meets the question; answer NO when unsure.`, condition, item.Title, item.URL, text)
+
+	ctx = llm.WithAttribution(ctx, llm.Attribution{Phase: "priority"})
+	response, err := j.LLM.GenerateText(ctx, prompt, llm.TextGenerationOptions{Model: j.Model, MaxTokens: 256, Temperature: 0})
+	if err != nil {
+		return false, err
+	}
+	answer := strings.ToUpper(strings.TrimSpace(response))
+	return strings.HasPrefix(answer, "YES"), nil
+}
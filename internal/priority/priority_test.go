@@ -0,0 +1,170 @@
+package priority
+
+import (
+	"briefly/internal/llm"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeJudge answers conditions from a map and counts the questions it was asked
+type fakeJudge struct {
+	answers map[string]bool
+	err     error
+	asked   int
+}
+
+func (f *fakeJudge) Meets(ctx context.Context, item Item, condition string) (bool, error) {
+	f.asked++
+	return f.answers[condition], f.err
+}
+
+func TestNew_ValidatesRules(t *testing.T) {
+	if _, err := New([]Rule{{Keywords: []string{"CVE"}}}, nil, nil); err == nil {
+		t.Error("expected an error for a rule without a name")
+	}
+	if _, err := New([]Rule{{Name: "empty", Keywords: []string{" "}}}, nil, nil); err == nil {
+		t.Error("expected an error for a rule without keywords or a condition")
+	}
+	if _, err := New([]Rule{{Name: "judged", Condition: "Is it urgent?"}}, nil, nil); err == nil {
+		t.Error("expected an error for a condition without a judge")
+	}
+
+	triage, err := New(nil, []string{""}, nil)
+	if err != nil || !triage.Empty() {
+		t.Errorf("New with a blank watchlist = %v, empty %v; want an empty triage", err, triage.Empty())
+	}
+}
+
+func TestTriage_Check(t *testing.T) {
+	judge := &fakeJudge{answers: map[string]bool{"Is it actively exploited?": true}}
+	triage, err := New([]Rule{
+		{Name: "outage", Keywords: []string{"outage", "downtime"}},
+		{Name: "security", Keywords: []string{"CVE", "zero-day"}, Condition: "Is it actively exploited?"},
+		{Name: "launch", Keywords: []string{"C++"}},
+	}, []string{"Kubernetes", "Postgres"}, judge)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		item  Item
+		rule  string
+		terms string
+	}{
+		{"keyword rule", Item{Title: "Major cloud OUTAGE hits us-east-1"}, "outage", "outage"},
+		{"keyword and condition", Item{Title: "Zero-day in OpenSSL", Text: "CVE-2025-1 is a zero-day"}, "security", "CVE, zero-day"},
+		{"punctuated term", Item{Title: "What's new in C++26"}, "launch", "C++"},
+		{"watchlist", Item{Title: "Postgres 18 released", Text: "Runs on kubernetes too"}, WatchlistRule, "Kubernetes, Postgres"},
+		{"partial word", Item{Title: "Downtimes and outages are rare"}, "", ""},
+		{"no match", Item{Title: "A quiet week in Rust"}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := triage.Check(context.Background(), tt.item)
+			if err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+			if tt.rule == "" {
+				if match != nil {
+					t.Errorf("expected no match, got %+v", match)
+				}
+				return
+			}
+			if match == nil || match.Rule != tt.rule || strings.Join(match.Terms, ", ") != tt.terms {
+				t.Errorf("Check = %+v, want rule %q with terms %q", match, tt.rule, tt.terms)
+			}
+		})
+	}
+}
+
+func TestTriage_CheckAsksJudgeOnlyAfterKeywords(t *testing.T) {
+	judge := &fakeJudge{answers: map[string]bool{}}
+	triage, _ := New([]Rule{{Name: "security", Keywords: []string{"CVE"}, Condition: "Is it actively exploited?"}}, nil, judge)
+
+	if match, _ := triage.Check(context.Background(), Item{Title: "A new web framework"}); match != nil || judge.asked != 0 {
+		t.Errorf("judge asked %d times about an item without keywords", judge.asked)
+	}
+	if match, _ := triage.Check(context.Background(), Item{Title: "CVE-2025-1 patched"}); match != nil || judge.asked != 1 {
+		t.Errorf("Check = %+v after %d questions; want no match after one", match, judge.asked)
+	}
+
+	judge.err = errors.New("quota exceeded")
+	if match, err := triage.Check(context.Background(), Item{Title: "CVE-2025-2 patched"}); match != nil || err == nil {
+		t.Errorf("Check = %+v, %v; want the judge error", match, err)
+	}
+}
+
+func TestNewScreen_KeywordsOnly(t *testing.T) {
+	screen, err := NewScreen([]Rule{
+		{Name: "judged", Condition: "Is it urgent?"},
+		{Name: "security", Keywords: []string{"CVE"}, Condition: "Is it actively exploited?"},
+	}, []string{"Postgres"})
+	if err != nil {
+		t.Fatalf("NewScreen: %v", err)
+	}
+
+	match, err := screen.Check(context.Background(), Item{Title: "CVE-2025-1 disclosed", Text: "Not exploited yet"})
+	if err != nil || match == nil || match.Rule != "security" {
+		t.Errorf("expected the security rule on its keywords alone, got %+v, %v", match, err)
+	}
+	if match, _ := screen.Check(context.Background(), Item{Title: "Postgres 18 released"}); match == nil || match.Rule != WatchlistRule {
+		t.Errorf("expected a watchlist match, got %+v", match)
+	}
+	if match, _ := screen.Check(context.Background(), Item{Title: "Quarterly earnings"}); match != nil {
+		t.Errorf("expected no match, got %+v", match)
+	}
+}
+
+func TestMatch_Message(t *testing.T) {
+	match := Match{
+		Item:  Item{URL: "https://example.com/a", Title: "Zero-day in OpenSSL", Source: "Security Weekly"},
+		Rule:  "security",
+		Terms: []string{"zero-day"},
+	}
+	msg := match.Message("  Attackers exploit a heap overflow.  ")
+	for _, want := range []string{
+		"🚨 **Priority: security** (zero-day)",
+		"**[Zero-day in OpenSSL](https://example.com/a)** · Security Weekly",
+		"\nAttackers exploit a heap overflow.\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q:\n%s", want, msg)
+		}
+	}
+
+	untitled := Match{Item: Item{URL: "https://example.com/b"}, Rule: WatchlistRule}
+	if msg := untitled.Message(""); !strings.Contains(msg, "**[https://example.com/b](https://example.com/b)**") {
+		t.Errorf("untitled item should link its URL:\n%s", msg)
+	}
+}
+
+// fakeGenerator returns a canned response
+type fakeGenerator struct {
+	response string
+	prompt   string
+}
+
+func (f *fakeGenerator) GenerateText(ctx context.Context, prompt string, options llm.TextGenerationOptions) (string, error) {
+	f.prompt = prompt
+	return f.response, nil
+}
+
+func TestModelJudge_Meets(t *testing.T) {
+	gen := &fakeGenerator{response: " yes.\n"}
+	judge := ModelJudge{LLM: gen}
+	meets, err := judge.Meets(context.Background(), Item{Title: "Zero-day", Text: strings.Repeat("x", 5000)}, "Is it actively exploited?")
+	if err != nil || !meets {
+		t.Fatalf("Meets = %v, %v; want yes", meets, err)
+	}
+	if !strings.Contains(gen.prompt, "QUESTION: Is it actively exploited?") || strings.Contains(gen.prompt, strings.Repeat("x", maxJudgeText+1)) {
+		t.Error("prompt should carry the question and capped text")
+	}
+
+	gen.response = "NO"
+	if meets, _ := judge.Meets(context.Background(), Item{Title: "Zero-day"}, "Is it actively exploited?"); meets {
+		t.Error("expected no for a NO answer")
+	}
+}
@@ -0,0 +1,255 @@
+// Package provenance records how a digest was produced (tool version, model, prompt
+// versions, and a hash of its sources) and optionally signs that record so a
+// published digest can be verified.
+package provenance
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats for provenance metadata
+const (
+	FormatSidecar     = "sidecar"     // <digest>.provenance.json next to the digest
+	FormatFrontmatter = "frontmatter" // YAML frontmatter at the top of the digest
+	FormatBoth        = "both"
+)
+
+const frontmatterDelimiter = "---\n"
+
+// Metadata describes how a digest was produced
+type Metadata struct {
+	Tool           string            `json:"tool" yaml:"tool"`
+	Version        string            `json:"version" yaml:"version"`
+	GeneratedAt    time.Time         `json:"generated_at" yaml:"generated_at"`
+	DigestID       string            `json:"digest_id,omitempty" yaml:"digest_id,omitempty"`
+	Model          string            `json:"model" yaml:"model"`
+	PromptVersions map[string]string `json:"prompt_versions,omitempty" yaml:"prompt_versions,omitempty"`
+	SourceCount    int               `json:"source_count" yaml:"source_count"`
+	SourcesHash    string            `json:"sources_sha256" yaml:"sources_sha256"` // See HashSources
+	ContentHash    string            `json:"content_sha256" yaml:"content_sha256"` // Digest body, excluding frontmatter
+	KeyID          string            `json:"key_id,omitempty" yaml:"key_id,omitempty"`
+	Signature      string            `json:"signature,omitempty" yaml:"signature,omitempty"` // Base64 ed25519 over the unsigned metadata
+}
+
+// HashSources hashes a digest's source URLs independent of their order, so two digests
+// built from the same links share a hash
+func HashSources(urls []string) string {
+	sorted := append([]string(nil), urls...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// signingPayload is the canonical form that gets signed: the metadata as JSON with
+// the signature fields cleared
+func (m Metadata) signingPayload() ([]byte, error) {
+	m.Signature = ""
+	return json.Marshal(m)
+}
+
+// Sign signs the metadata with key, recording the key's ID
+func (m *Metadata) Sign(key ed25519.PrivateKey) error {
+	m.KeyID = KeyID(key.Public().(ed25519.PublicKey))
+	payload, err := m.signingPayload()
+	if err != nil {
+		return fmt.Errorf("failed to encode provenance: %w", err)
+	}
+	m.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload))
+	return nil
+}
+
+// VerifySignature checks the metadata's signature against key
+func (m Metadata) VerifySignature(key ed25519.PublicKey) error {
+	if m.Signature == "" {
+		return fmt.Errorf("digest is not signed")
+	}
+	if m.KeyID != "" && m.KeyID != KeyID(key) {
+		return fmt.Errorf("digest was signed with key %s, not %s", m.KeyID, KeyID(key))
+	}
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	payload, err := m.signingPayload()
+	if err != nil {
+		return fmt.Errorf("failed to encode provenance: %w", err)
+	}
+	if !ed25519.Verify(key, payload, sig) {
+		return fmt.Errorf("signature does not match the provenance metadata")
+	}
+	return nil
+}
+
+// KeyID is a short fingerprint of a public key
+func KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// ParsePrivateKey decodes a base64 ed25519 private key, either the 32-byte seed or
+// the full 64-byte key
+func ParsePrivateKey(encoded string) (ed25519.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("signing key is not base64: %w", err)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	default:
+		return nil, fmt.Errorf("signing key must be a %d-byte ed25519 seed or %d-byte private key", ed25519.SeedSize, ed25519.PrivateKeySize)
+	}
+}
+
+// ParsePublicKey decodes a base64 ed25519 public key
+func ParsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be a base64 ed25519 public key")
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// SidecarPath is where the provenance sidecar for a digest file lives
+func SidecarPath(path string) string {
+	return strings.TrimSuffix(path, ".md") + ".provenance.json"
+}
+
+// Stamp records provenance for the digest file at path: it hashes the digest body,
+// signs the metadata when key is non-nil, and writes it in the given format.
+// Re-stamping replaces any existing frontmatter.
+func Stamp(path string, meta Metadata, format string, key ed25519.PrivateKey) (*Metadata, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read digest: %w", err)
+	}
+	_, body, err := splitFrontmatter(content)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.ContentHash = hashContent(body)
+	meta.KeyID = ""
+	meta.Signature = ""
+	if key != nil {
+		if err := meta.Sign(key); err != nil {
+			return nil, err
+		}
+	}
+
+	if format == "" {
+		format = FormatSidecar
+	}
+	switch format {
+	case FormatSidecar, FormatFrontmatter, FormatBoth:
+	default:
+		return nil, fmt.Errorf("unknown provenance format %q (supported: sidecar, frontmatter, both)", format)
+	}
+
+	if format == FormatSidecar || format == FormatBoth {
+		data, err := json.MarshalIndent(meta, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode provenance: %w", err)
+		}
+		if err := os.WriteFile(SidecarPath(path), append(data, '\n'), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write provenance sidecar: %w", err)
+		}
+	}
+
+	if format == FormatFrontmatter || format == FormatBoth {
+		front, err := yaml.Marshal(struct {
+			Provenance Metadata `yaml:"provenance"`
+		}{meta})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode provenance frontmatter: %w", err)
+		}
+		var out bytes.Buffer
+		out.WriteString(frontmatterDelimiter)
+		out.Write(front)
+		out.WriteString(frontmatterDelimiter)
+		out.Write(body)
+		if err := os.WriteFile(path, out.Bytes(), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write digest frontmatter: %w", err)
+		}
+	}
+
+	return &meta, nil
+}
+
+// Verify loads the provenance for the digest file at path (frontmatter first, then the
+// sidecar) and checks that the digest body still matches its recorded hash. With a
+// key, the metadata must also carry a valid signature from that key.
+func Verify(path string, key ed25519.PublicKey) (*Metadata, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read digest: %w", err)
+	}
+	meta, body, err := splitFrontmatter(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta == nil {
+		data, err := os.ReadFile(SidecarPath(path))
+		if err != nil {
+			return nil, fmt.Errorf("no provenance found (no frontmatter and no %s)", SidecarPath(path))
+		}
+		meta = &Metadata{}
+		if err := json.Unmarshal(data, meta); err != nil {
+			return nil, fmt.Errorf("failed to parse provenance sidecar: %w", err)
+		}
+	}
+
+	if got := hashContent(body); got != meta.ContentHash {
+		return meta, fmt.Errorf("digest content has changed since it was generated (sha256 %s, recorded %s)", got, meta.ContentHash)
+	}
+	if key != nil {
+		if err := meta.VerifySignature(key); err != nil {
+			return meta, err
+		}
+	}
+	return meta, nil
+}
+
+// splitFrontmatter separates provenance frontmatter from the digest body. Returns nil
+// metadata when the file has no frontmatter.
+func splitFrontmatter(content []byte) (*Metadata, []byte, error) {
+	if !bytes.HasPrefix(content, []byte(frontmatterDelimiter)) {
+		return nil, content, nil
+	}
+	rest := content[len(frontmatterDelimiter):]
+	end := bytes.Index(rest, []byte("\n"+frontmatterDelimiter))
+	if end < 0 {
+		return nil, content, nil
+	}
+
+	var front struct {
+		Provenance *Metadata `yaml:"provenance"`
+	}
+	if err := yaml.Unmarshal(rest[:end+1], &front); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	if front.Provenance == nil {
+		return nil, content, nil
+	}
+	return front.Provenance, rest[end+1+len(frontmatterDelimiter):], nil
+}
@@ -0,0 +1,134 @@
+package provenance
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testMetadata() Metadata {
+	return Metadata{
+		Tool:           "briefly",
+		Version:        "3.1.0",
+		GeneratedAt:    time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		Model:          "gemini-flash-lite-latest",
+		PromptVersions: map[string]string{"digest_content": "v3"},
+		SourceCount:    2,
+		SourcesHash:    HashSources([]string{"https://b.example", "https://a.example"}),
+	}
+}
+
+func TestHashSourcesIgnoresOrder(t *testing.T) {
+	a := HashSources([]string{"https://a.example", "https://b.example"})
+	b := HashSources([]string{"https://b.example", "https://a.example"})
+	if a != b {
+		t.Errorf("hash depends on order: %s vs %s", a, b)
+	}
+	if a == HashSources([]string{"https://a.example"}) {
+		t.Error("different source lists share a hash")
+	}
+}
+
+func TestStampAndVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{FormatSidecar, FormatFrontmatter, FormatBoth} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "digest.md")
+			body := "# Weekly Digest\n\nSomething happened [1].\n"
+			if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := Stamp(path, testMetadata(), format, priv); err != nil {
+				t.Fatalf("Stamp failed: %v", err)
+			}
+
+			meta, err := Verify(path, pub)
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if meta.Model != "gemini-flash-lite-latest" || meta.KeyID != KeyID(pub) {
+				t.Errorf("unexpected metadata: %+v", meta)
+			}
+
+			// Re-stamping must not nest frontmatter or change the content hash
+			if _, err := Stamp(path, testMetadata(), format, priv); err != nil {
+				t.Fatalf("re-Stamp failed: %v", err)
+			}
+			data, _ := os.ReadFile(path)
+			if strings.Count(string(data), "provenance:") > 1 {
+				t.Error("re-stamping duplicated the frontmatter")
+			}
+			if !strings.HasSuffix(string(data), body) {
+				t.Error("stamping changed the digest body")
+			}
+
+			tampered := strings.Replace(string(data), "Something", "Nothing", 1)
+			if err := os.WriteFile(path, []byte(tampered), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Verify(path, pub); err == nil {
+				t.Error("expected verification to fail for edited digest")
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsForgedMetadata(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+
+	meta := testMetadata()
+	if err := meta.Sign(priv); err != nil {
+		t.Fatal(err)
+	}
+	if err := meta.VerifySignature(pub); err != nil {
+		t.Fatalf("VerifySignature failed: %v", err)
+	}
+	if err := meta.VerifySignature(otherPub); err == nil {
+		t.Error("expected failure with a different key")
+	}
+
+	meta.Model = "some-other-model"
+	if err := meta.VerifySignature(pub); err == nil {
+		t.Error("expected failure after changing signed metadata")
+	}
+
+	unsigned := testMetadata()
+	if err := unsigned.VerifySignature(pub); err == nil {
+		t.Error("expected failure for unsigned metadata")
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+
+	for name, encoded := range map[string]string{
+		"seed": base64.StdEncoding.EncodeToString(priv.Seed()),
+		"full": base64.StdEncoding.EncodeToString(priv),
+	} {
+		key, err := ParsePrivateKey(encoded)
+		if err != nil {
+			t.Fatalf("ParsePrivateKey(%s) failed: %v", name, err)
+		}
+		if !key.Equal(priv) {
+			t.Errorf("ParsePrivateKey(%s) returned a different key", name)
+		}
+	}
+
+	parsed, err := ParsePublicKey(base64.StdEncoding.EncodeToString(pub))
+	if err != nil || !parsed.Equal(pub) {
+		t.Errorf("ParsePublicKey failed: %v", err)
+	}
+	if _, err := ParsePrivateKey("not base64!"); err == nil {
+		t.Error("expected error for invalid key")
+	}
+}
@@ -0,0 +1,66 @@
+package quality
+
+import (
+	"briefly/internal/core"
+	"time"
+)
+
+// EmbeddingRun is one clustering of an embedding benchmark: the same articles
+// embedded from one source (summary or full text) and clustered
+type EmbeddingRun struct {
+	Source     string
+	Chars      int           // Characters sent to the embedding model
+	Duration   time.Duration // Time spent embedding
+	Failed     int           // Articles that couldn't be embedded
+	Clusters   []core.TopicCluster
+	Embeddings map[string][]float64
+	Coherence  *ClusterCoherenceMetrics // Measured in the run's own embedding space
+}
+
+// EstimatedTokens approximates the embedding input tokens at four characters per token
+func (r EmbeddingRun) EstimatedTokens() int {
+	return (r.Chars + 3) / 4
+}
+
+// RandIndex is the share of article pairs two clusterings agree on: both put the pair
+// in one cluster, or both split it. 1 means identical topics; articles missing from
+// either clustering are ignored. Returns 1 when fewer than two articles are shared.
+func RandIndex(a, b []core.TopicCluster) float64 {
+	clusterA := clusterOf(a)
+	clusterB := clusterOf(b)
+
+	var shared []string
+	for _, cluster := range a {
+		for _, id := range cluster.ArticleIDs {
+			if _, ok := clusterB[id]; ok {
+				shared = append(shared, id)
+			}
+		}
+	}
+	if len(shared) < 2 {
+		return 1
+	}
+
+	agree, pairs := 0, 0
+	for i := range shared {
+		for j := i + 1; j < len(shared); j++ {
+			togetherA := clusterA[shared[i]] == clusterA[shared[j]]
+			togetherB := clusterB[shared[i]] == clusterB[shared[j]]
+			if togetherA == togetherB {
+				agree++
+			}
+			pairs++
+		}
+	}
+	return float64(agree) / float64(pairs)
+}
+
+func clusterOf(clusters []core.TopicCluster) map[string]int {
+	index := make(map[string]int)
+	for i, cluster := range clusters {
+		for _, id := range cluster.ArticleIDs {
+			index[id] = i
+		}
+	}
+	return index
+}
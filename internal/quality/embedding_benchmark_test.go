@@ -0,0 +1,31 @@
+package quality
+
+import (
+	"briefly/internal/core"
+	"testing"
+)
+
+func TestRandIndex(t *testing.T) {
+	a := []core.TopicCluster{{ArticleIDs: []string{"1", "2"}}, {ArticleIDs: []string{"3", "4"}}}
+
+	relabeled := []core.TopicCluster{{ArticleIDs: []string{"4", "3"}}, {ArticleIDs: []string{"2", "1"}}}
+	if got := RandIndex(a, relabeled); got != 1 {
+		t.Errorf("expected relabeled clusters to agree fully, got %.2f", got)
+	}
+
+	// Moving 2 next to 3 and 4 flips 3 of the 6 pairs: (1,2), (2,3), (2,4)
+	moved := []core.TopicCluster{{ArticleIDs: []string{"1"}}, {ArticleIDs: []string{"2", "3", "4"}}}
+	if got := RandIndex(a, moved); got != 0.5 {
+		t.Errorf("expected 0.5, got %.2f", got)
+	}
+
+	if got := RandIndex(a, []core.TopicCluster{{ArticleIDs: []string{"1", "9"}}}); got != 1 {
+		t.Errorf("expected 1 with fewer than two shared articles, got %.2f", got)
+	}
+}
+
+func TestEmbeddingRun_EstimatedTokens(t *testing.T) {
+	if got := (EmbeddingRun{Chars: 10}).EstimatedTokens(); got != 3 {
+		t.Errorf("expected 3 tokens for 10 chars, got %d", got)
+	}
+}
@@ -0,0 +1,153 @@
+package quality
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/language"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NoiseThresholds configures the content gate that skips low-content pages (link
+// farms, login walls, error pages) before they reach the LLM
+type NoiseThresholds struct {
+	MinWords           int      // Fewest words a page needs (0 = no minimum)
+	MaxBoilerplate     float64  // Largest share of lines that look like navigation or boilerplate (0 = no limit)
+	Languages          []string // Languages a page may be in, e.g. "en" (empty = any)
+	ErrorPageDetection bool     // Skip pages that read like error, login, or paywall pages
+}
+
+// DefaultNoiseThresholds returns the gate's defaults
+func DefaultNoiseThresholds() NoiseThresholds {
+	return NoiseThresholds{
+		MinWords:           100,
+		MaxBoilerplate:     0.6,
+		Languages:          []string{"en"},
+		ErrorPageDetection: true,
+	}
+}
+
+// Noise check names, used in skip reasons and run reports
+const (
+	NoiseTooShort    = "too-short"
+	NoiseBoilerplate = "boilerplate"
+	NoiseLanguage    = "language"
+	NoiseErrorPage   = "error-page"
+	NoiseDownload    = "download" // The fetcher skipped the download (see core.Article.SkipReason)
+)
+
+// NoiseVerdict explains why a page was judged noise
+type NoiseVerdict struct {
+	Check  string // One of the Noise* check names
+	Reason string // Human-readable detail, e.g. "42 words (minimum 100)"
+}
+
+// minBoilerplateLines is how many lines a page needs before its boilerplate share
+// means anything; short pages are left to the word count
+const minBoilerplateLines = 8
+
+// minLanguageWords is how many words language detection needs to be trusted
+const minLanguageWords = 40
+
+var (
+	// errorPagePattern matches the language of error, login, and paywall pages
+	errorPagePattern = regexp.MustCompile(`(?i)\b(page not found|404 not found|access denied|403 forbidden|` +
+		`sign in to (continue|view|read)|log ?in to (continue|view|read)|please (log|sign) ?in|` +
+		`create an account to (continue|read)|subscribe to (continue|read)|this content is for subscribers|` +
+		`enable javascript|javascript is (disabled|required)|are you a robot|verify you are human|` +
+		`checking your browser|captcha|service unavailable|something went wrong)\b`)
+
+	// boilerplatePattern matches navigation, cookie, sharing, and footer lines
+	boilerplatePattern = regexp.MustCompile(`(?i)(cookie|privacy policy|terms of (service|use)|all rights reserved|` +
+		`©|subscribe|newsletter|share on|follow us|sign up|log ?in|read more|related posts|advertisement|skip to content)`)
+
+	wordPattern = regexp.MustCompile(`\p{L}+`)
+)
+
+// CheckNoise runs the content gate on an extracted page. It returns nil for a page
+// worth summarizing. YouTube articles are described from metadata, so they pass.
+// Articles whose download was skipped always fail, whatever the thresholds.
+func CheckNoise(article core.Article, thresholds NoiseThresholds) *NoiseVerdict {
+	if article.SkipReason != "" {
+		return &NoiseVerdict{Check: NoiseDownload, Reason: article.SkipReason}
+	}
+	if article.ContentType == core.ContentTypeYouTube {
+		return nil
+	}
+
+	text := article.CleanedText
+	if text == "" {
+		text = article.RawContent
+	}
+	words := wordPattern.FindAllString(text, -1)
+
+	if thresholds.ErrorPageDetection && len(words) < 400 {
+		// Long articles that mention "captcha" or "404" are about them, not blocked by them
+		if match := errorPagePattern.FindString(article.Title + "\n" + text); match != "" {
+			return &NoiseVerdict{Check: NoiseErrorPage, Reason: fmt.Sprintf("looks like an error or login page (%q)", strings.ToLower(match))}
+		}
+	}
+
+	if thresholds.MinWords > 0 && len(words) < thresholds.MinWords {
+		return &NoiseVerdict{Check: NoiseTooShort, Reason: fmt.Sprintf("%d words (minimum %d)", len(words), thresholds.MinWords)}
+	}
+
+	if thresholds.MaxBoilerplate > 0 {
+		if ratio, lines := BoilerplateRatio(text); lines >= minBoilerplateLines && ratio > thresholds.MaxBoilerplate {
+			return &NoiseVerdict{Check: NoiseBoilerplate, Reason: fmt.Sprintf("%.0f%% of lines are links or boilerplate (maximum %.0f%%)", ratio*100, thresholds.MaxBoilerplate*100)}
+		}
+	}
+
+	if len(thresholds.Languages) > 0 && len(words) >= minLanguageWords {
+		if detected := DetectLanguage(words); detected != "" && !containsFold(thresholds.Languages, detected) {
+			return &NoiseVerdict{Check: NoiseLanguage, Reason: fmt.Sprintf("written in %s (allowed: %s)", detected, strings.Join(thresholds.Languages, ", "))}
+		}
+	}
+
+	return nil
+}
+
+// BoilerplateRatio is the share of non-empty lines that look like navigation or
+// boilerplate: link-list stubs of a few words, repeated lines, and cookie, sharing,
+// or footer text. It also returns the number of non-empty lines.
+func BoilerplateRatio(text string) (float64, int) {
+	seen := make(map[string]bool)
+	var lines, boilerplate int
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lines++
+
+		key := strings.ToLower(line)
+		wordCount := len(wordPattern.FindAllString(line, -1))
+		switch {
+		case seen[key], wordCount <= 4:
+			boilerplate++
+		case wordCount <= 20 && boilerplatePattern.MatchString(line):
+			boilerplate++
+		}
+		seen[key] = true
+	}
+	if lines == 0 {
+		return 0, 0
+	}
+	return float64(boilerplate) / float64(lines), lines
+}
+
+// DetectLanguage guesses the language of words from common function words. It
+// returns "" when no known language stands out.
+func DetectLanguage(words []string) string {
+	return language.Detect(words)
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package quality
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+)
+
+// prose repeats an English paragraph until it has at least n words
+func prose(n int) string {
+	paragraph := "The team shipped the new scheduler this week, and it is faster than the old one for most of the workloads that we tested in production. "
+	var b strings.Builder
+	for len(strings.Fields(b.String())) < n {
+		b.WriteString(paragraph)
+	}
+	return b.String()
+}
+
+func TestCheckNoise(t *testing.T) {
+	thresholds := DefaultNoiseThresholds()
+
+	linkFarm := strings.Repeat("Best deals today\nTop 10 gadgets\nClick here\n", 20) + prose(80)
+	spanish := strings.Repeat("El equipo lanzó el nuevo planificador esta semana y es más rápido que el anterior para la mayoría de las cargas de trabajo que probamos en producción. ", 8)
+
+	tests := []struct {
+		name    string
+		article core.Article
+		want    string
+	}{
+		{"article", core.Article{Title: "Scheduler v2", CleanedText: prose(200)}, ""},
+		{"too short", core.Article{Title: "Teaser", CleanedText: prose(30)}, NoiseTooShort},
+		{"login wall", core.Article{Title: "Sign in", CleanedText: "Please log in to continue reading this story. " + prose(120)}, NoiseErrorPage},
+		{"not found", core.Article{Title: "Page Not Found", CleanedText: "Sorry."}, NoiseErrorPage},
+		{"link farm", core.Article{Title: "Deals", CleanedText: linkFarm}, NoiseBoilerplate},
+		{"other language", core.Article{Title: "Planificador", CleanedText: spanish}, NoiseLanguage},
+		{"youtube", core.Article{Title: "Talk", ContentType: core.ContentTypeYouTube, CleanedText: "Short description"}, ""},
+		{"long article about captchas", core.Article{Title: "How CAPTCHA solvers work", CleanedText: "captcha " + prose(450)}, ""},
+		{"skipped download", core.Article{SkipReason: "PDF is over the 50 MB limit"}, NoiseDownload},
+	}
+
+	for _, tt := range tests {
+		verdict := CheckNoise(tt.article, thresholds)
+		got := ""
+		if verdict != nil {
+			got = verdict.Check
+		}
+		if got != tt.want {
+			t.Errorf("%s: check = %q, want %q (%+v)", tt.name, got, tt.want, verdict)
+		}
+	}
+
+	// Zero thresholds turn each check off
+	if verdict := CheckNoise(core.Article{Title: "Planificador", CleanedText: spanish}, NoiseThresholds{}); verdict != nil {
+		t.Errorf("expected no verdict with checks off, got %+v", verdict)
+	}
+	if verdict := CheckNoise(core.Article{SkipReason: "too big"}, NoiseThresholds{}); verdict == nil || verdict.Reason != "too big" {
+		t.Errorf("expected a skipped download to fail with checks off, got %+v", verdict)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	if got := DetectLanguage(strings.Fields(prose(60))); got != "en" {
+		t.Errorf("DetectLanguage(english) = %q", got)
+	}
+	if got := DetectLanguage(strings.Fields("Kubernetes Docker Terraform Grafana Prometheus Envoy Istio")); got != "" {
+		t.Errorf("expected no language for a list of names, got %q", got)
+	}
+}
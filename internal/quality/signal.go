@@ -0,0 +1,149 @@
+package quality
+
+import (
+	"briefly/internal/core"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SignalScore breaks an article's signal-to-noise rating into components (0.0-1.0 each)
+type SignalScore struct {
+	Novelty       float64 // Fresh, announces something new (releases, versions, results)
+	Depth         float64 // Substantial, specific content rather than a short blurb
+	Actionability float64 // Something readers can try or apply (code, guides, available tools)
+	Signal        float64 // Weighted blend of the three; stored as Article.SignalStrength
+	Quality       float64 // Depth blended with summary quality; stored as Article.QualityScore
+}
+
+// Signal weights; novelty leads because digests are about what changed this week
+const (
+	noveltyWeight       = 0.4
+	depthWeight         = 0.3
+	actionabilityWeight = 0.3
+)
+
+var (
+	// noveltyPattern matches announcement language and version numbers
+	noveltyPattern = regexp.MustCompile(`(?i)\b(launch\w*|releas\w*|introduc\w*|announc\w*|unveil\w*|open[- ]sourc\w*|preview|benchmark\w*|v?\d+\.\d+)\b`)
+
+	// actionablePattern matches language about things readers can use now
+	actionablePattern = regexp.MustCompile(`(?i)\b(how to|tutorial|guide|step[- ]by[- ]step|walkthrough|getting started|available|install|api|sdk|cli|github\.com|try it)\b`)
+
+	// codePattern matches code blocks and shell or import lines in article text
+	codePattern = regexp.MustCompile("(?m)(```|^\\s*(\\$ |func |import |def |class |npm |pip |go get|docker |kubectl ))")
+)
+
+// ScoreArticle rates an article heuristically from its text, metadata, and summary.
+// summary may be nil. The score is deterministic, so ordering is stable across runs.
+func ScoreArticle(article core.Article, summary *core.Summary) SignalScore {
+	text := article.CleanedText
+	if text == "" {
+		text = article.RawContent
+	}
+
+	summaryText := ""
+	if summary != nil {
+		summaryText = summary.SummaryText
+	}
+	headline := article.Title + "\n" + summaryText
+
+	score := SignalScore{
+		Novelty:       noveltyScore(article.DatePublished, headline),
+		Depth:         depthScore(text, summaryText, article.ContentType),
+		Actionability: actionabilityScore(text, headline),
+	}
+
+	score.Signal = clamp(noveltyWeight*score.Novelty + depthWeight*score.Depth + actionabilityWeight*score.Actionability)
+
+	score.Quality = score.Depth
+	if summary != nil && summary.QualityScore > 0 {
+		score.Quality = (score.Depth + summary.QualityScore) / 2
+	}
+	vague, _ := DetectVaguePhrases(summaryText)
+	score.Quality = clamp(score.Quality - math.Min(0.2, 0.05*float64(vague)))
+
+	return score
+}
+
+// ApplySignalScores fills SignalStrength and QualityScore on every article, using the
+// summary keyed by article ID when one exists
+func ApplySignalScores(articles []core.Article, summaries map[string]core.Summary) {
+	for i := range articles {
+		var summary *core.Summary
+		if s, ok := summaries[articles[i].ID]; ok {
+			summary = &s
+		}
+
+		score := ScoreArticle(articles[i], summary)
+		articles[i].SignalStrength = score.Signal
+		articles[i].QualityScore = score.Quality
+	}
+}
+
+// SortArticleIDsBySignal orders article IDs by SignalStrength, highest first. Ties keep
+// their original order; IDs missing from articles sort last.
+func SortArticleIDsBySignal(articleIDs []string, articles map[string]core.Article) {
+	sort.SliceStable(articleIDs, func(i, j int) bool {
+		return articles[articleIDs[i]].SignalStrength > articles[articleIDs[j]].SignalStrength
+	})
+}
+
+// noveltyScore combines recency with announcement language in the title and summary
+func noveltyScore(published time.Time, headline string) float64 {
+	recency := 0.5 // Unknown publish date
+	if !published.IsZero() {
+		age := time.Since(published)
+		switch {
+		case age <= 3*24*time.Hour:
+			recency = 1.0
+		case age <= 7*24*time.Hour:
+			recency = 0.8
+		case age <= 30*24*time.Hour:
+			recency = 0.5
+		default:
+			recency = 0.2
+		}
+	}
+
+	announcements := math.Min(1, float64(len(noveltyPattern.FindAllString(headline, -1)))/2)
+	return clamp(0.5*recency + 0.5*announcements)
+}
+
+// depthScore rewards longer articles (log-scaled, ~150 words → 0, ~3000 → 1) and
+// summaries carrying specific numbers and names
+func depthScore(text, summaryText string, contentType core.ContentType) float64 {
+	words := float64(len(strings.Fields(text)))
+	length := 0.0
+	if words > 150 {
+		length = clamp(math.Log10(words/150) / math.Log10(20))
+	}
+
+	numbers, _ := DetectNumbers(summaryText)
+	names, _ := DetectProperNouns(summaryText)
+	specificity := math.Min(1, (float64(numbers)+float64(names)/2)/6)
+
+	depth := 0.7*length + 0.3*specificity
+	if contentType == core.ContentTypePDF {
+		depth += 0.1 // Papers and whitepapers
+	}
+	return clamp(depth)
+}
+
+// actionabilityScore rewards code, guides, and tools readers can pick up now
+func actionabilityScore(text, headline string) float64 {
+	score := 0.0
+	if codePattern.MatchString(text) {
+		score += 0.4
+	}
+	score += math.Min(0.6, 0.15*float64(len(actionablePattern.FindAllString(headline, -1))))
+	score += math.Min(0.2, 0.02*float64(len(actionablePattern.FindAllString(text, -1))))
+	return clamp(score)
+}
+
+// clamp bounds a score to [0, 1]
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
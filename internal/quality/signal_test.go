@@ -0,0 +1,74 @@
+package quality
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"briefly/internal/core"
+)
+
+func TestScoreArticle_RanksSubstanceAboveNoise(t *testing.T) {
+	release := core.Article{
+		ID:            "release",
+		Title:         "Go 1.24 released with generic type aliases",
+		DatePublished: time.Now().Add(-24 * time.Hour),
+		CleanedText: strings.Repeat("The Go team released version 1.24 with faster maps and new tooling. ", 150) +
+			"\n```go\nfunc main() {}\n```\nInstall it with go get golang.org/dl/go1.24.",
+	}
+	releaseSummary := &core.Summary{
+		SummaryText:  "Go 1.24 ships Swiss-table maps that cut lookup time 30% and adds generic type aliases for Kubernetes-scale codebases.",
+		QualityScore: 0.9,
+	}
+
+	opinion := core.Article{
+		ID:            "opinion",
+		Title:         "Thoughts",
+		DatePublished: time.Now().Add(-90 * 24 * time.Hour),
+		CleanedText:   "Some people think various things are changing in many ways.",
+	}
+	opinionSummary := &core.Summary{SummaryText: "Various experts believe several things may change."}
+
+	high := ScoreArticle(release, releaseSummary)
+	low := ScoreArticle(opinion, opinionSummary)
+
+	if high.Signal <= low.Signal {
+		t.Errorf("Expected release signal %.2f > opinion signal %.2f", high.Signal, low.Signal)
+	}
+	if high.Novelty <= low.Novelty || high.Depth <= low.Depth || high.Actionability <= low.Actionability {
+		t.Errorf("Expected every component to favor the release: %+v vs %+v", high, low)
+	}
+	if high.Quality <= low.Quality {
+		t.Errorf("Expected release quality %.2f > opinion quality %.2f", high.Quality, low.Quality)
+	}
+
+	for _, score := range []SignalScore{high, low} {
+		for _, v := range []float64{score.Novelty, score.Depth, score.Actionability, score.Signal, score.Quality} {
+			if v < 0 || v > 1 {
+				t.Errorf("Score component out of range: %+v", score)
+			}
+		}
+	}
+}
+
+func TestApplySignalScoresAndSort(t *testing.T) {
+	articles := []core.Article{
+		{ID: "a", Title: "Weekly thoughts", CleanedText: "Short note."},
+		{ID: "b", Title: "How to install the new CLI v2.0 release", CleanedText: "```\n$ brew install tool\n```"},
+	}
+	summaries := map[string]core.Summary{
+		"b": {SummaryText: "Version 2.0 of the CLI launches with an API and SDK.", QualityScore: 0.8},
+	}
+
+	ApplySignalScores(articles, summaries)
+	if articles[0].SignalStrength == 0 || articles[1].SignalStrength == 0 {
+		t.Fatalf("Expected signal strength on every article, got %.2f and %.2f", articles[0].SignalStrength, articles[1].SignalStrength)
+	}
+
+	articleMap := map[string]core.Article{"a": articles[0], "b": articles[1]}
+	ids := []string{"a", "b"}
+	SortArticleIDsBySignal(ids, articleMap)
+	if ids[0] != "b" {
+		t.Errorf("Expected the actionable release first, got %v", ids)
+	}
+}
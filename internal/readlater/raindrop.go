@@ -0,0 +1,94 @@
+package readlater
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RaindropBaseURL is the Raindrop.io REST API
+const RaindropBaseURL = "https://api.raindrop.io/rest/v1"
+
+// raindropPageSize is the most bookmarks Raindrop returns per page
+const raindropPageSize = 50
+
+// Raindrop is Raindrop.io. A pushed summary becomes the bookmark's note.
+type Raindrop struct {
+	Token      string
+	Collection int    // Collection pulled: 0 = all bookmarks, -1 = Unsorted
+	BaseURL    string // Default: RaindropBaseURL
+	Client     *http.Client
+}
+
+// Name implements Service
+func (r *Raindrop) Name() string { return "raindrop" }
+
+type raindropBookmark struct {
+	ID      int64     `json:"_id"`
+	Link    string    `json:"link"`
+	Title   string    `json:"title"`
+	Note    string    `json:"note"`
+	Created time.Time `json:"created"`
+}
+
+// Saved implements Service, paging newest first until bookmarks are older than since
+func (r *Raindrop) Saved(ctx context.Context, since time.Time, limit int) ([]Item, error) {
+	var items []Item
+	for page := 0; ; page++ {
+		query := url.Values{
+			"sort":    {"-created"},
+			"perpage": {strconv.Itoa(raindropPageSize)},
+			"page":    {strconv.Itoa(page)},
+		}
+		endpoint := fmt.Sprintf("%s/raindrops/%d?%s", r.baseURL(), r.Collection, query.Encode())
+		var result struct {
+			Items []raindropBookmark `json:"items"`
+		}
+		if err := doJSON(ctx, r.Client, http.MethodGet, endpoint, r.authorization(), nil, &result); err != nil {
+			return nil, fmt.Errorf("failed to list Raindrop bookmarks: %w", err)
+		}
+
+		for _, bookmark := range result.Items {
+			if bookmark.Created.Before(since) {
+				return items, nil
+			}
+			items = append(items, Item{
+				ID:      strconv.FormatInt(bookmark.ID, 10),
+				URL:     bookmark.Link,
+				Title:   bookmark.Title,
+				Note:    bookmark.Note,
+				SavedAt: bookmark.Created,
+			})
+			if limit > 0 && len(items) == limit {
+				return items, nil
+			}
+		}
+		if len(result.Items) < raindropPageSize {
+			return items, nil
+		}
+	}
+}
+
+// SetNote implements Service by replacing the bookmark's note
+func (r *Raindrop) SetNote(ctx context.Context, item Item, note string) error {
+	endpoint := fmt.Sprintf("%s/raindrop/%s", r.baseURL(), url.PathEscape(item.ID))
+	if err := doJSON(ctx, r.Client, http.MethodPut, endpoint, r.authorization(), map[string]string{"note": note}, nil); err != nil {
+		return fmt.Errorf("failed to update Raindrop bookmark %s: %w", item.ID, err)
+	}
+	return nil
+}
+
+func (r *Raindrop) baseURL() string {
+	if r.BaseURL == "" {
+		return RaindropBaseURL
+	}
+	return strings.TrimRight(r.BaseURL, "/")
+}
+
+func (r *Raindrop) authorization() string {
+	return "Bearer " + r.Token
+}
@@ -0,0 +1,100 @@
+// Package readlater syncs with read-it-later services (Readwise Reader and
+// Raindrop.io): the saved-for-later queue is pulled as digest input, and the
+// summaries Briefly wrote are pushed back as each item's note, so both tools show
+// the same reading list.
+package readlater
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds each API request
+const requestTimeout = 30 * time.Second
+
+// NoteMarker opens the part of a note Briefly wrote, so a later push replaces it
+// and leaves anything the reader wrote above it alone
+const NoteMarker = "📰 Briefly summary:"
+
+// Item is a saved article
+type Item struct {
+	ID      string
+	URL     string
+	Title   string
+	Note    string // The item's note (Raindrop) or summary (Readwise Reader)
+	SavedAt time.Time
+}
+
+// Service is a read-it-later service
+type Service interface {
+	// Name names the service in output and collected input files
+	Name() string
+	// Saved returns items saved since, newest first, at most limit (0 = no limit)
+	Saved(ctx context.Context, since time.Time, limit int) ([]Item, error)
+	// SetNote replaces an item's note
+	SetNote(ctx context.Context, item Item, note string) error
+}
+
+// MergeNote returns an item's note with summary as Briefly's part: replacing the
+// part an earlier push wrote, or added below the reader's own note. A result equal
+// to existing means there is nothing to push.
+func MergeNote(existing, summary string) string {
+	ours := NoteMarker + "\n" + strings.TrimSpace(summary)
+	own := existing
+	if i := strings.Index(existing, NoteMarker); i >= 0 {
+		own = existing[:i]
+	}
+	own = strings.TrimSpace(own)
+	if own == "" {
+		return ours
+	}
+	return own + "\n\n" + ours
+}
+
+// doJSON sends a JSON request with an authorization header and decodes the JSON
+// response into out (nil to discard it)
+func doJSON(ctx context.Context, client *http.Client, method, url, authorization string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned %s: %s", req.URL.Host, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", req.URL.Host, err)
+	}
+	return nil
+}
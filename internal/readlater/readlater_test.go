@@ -0,0 +1,120 @@
+package readlater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeNote(t *testing.T) {
+	first := MergeNote("", "Postgres 18 ships async I/O.")
+	if first != NoteMarker+"\nPostgres 18 ships async I/O." {
+		t.Errorf("unexpected note on an empty item: %q", first)
+	}
+	if MergeNote(first, "Postgres 18 ships async I/O.") != first {
+		t.Error("pushing the same summary again should leave the note unchanged")
+	}
+
+	own := "Read before the migration."
+	merged := MergeNote(own+"\n\n"+first, "Postgres 18 ships async I/O and UUIDv7.")
+	if !strings.HasPrefix(merged, own+"\n\n"+NoteMarker) || !strings.HasSuffix(merged, "UUIDv7.") || strings.Count(merged, NoteMarker) != 1 {
+		t.Errorf("expected the reader's note kept and Briefly's part replaced, got %q", merged)
+	}
+}
+
+func TestReadwise_SavedAndSetNote(t *testing.T) {
+	since := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	var patched map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("pageCursor") == "":
+			if r.URL.Query().Get("location") != "later" || r.URL.Query().Get("updatedAfter") != "2025-06-02T00:00:00Z" {
+				t.Errorf("unexpected list query %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"results": [
+				{"id": "a", "url": "https://read.readwise.io/read/a", "source_url": "https://example.com/a", "title": "A", "created_at": "2025-06-03T10:00:00Z"},
+				{"id": "h", "source_url": "https://example.com/a", "created_at": "2025-06-03T11:00:00Z", "parent_id": "a"},
+				{"id": "old", "source_url": "https://example.com/old", "created_at": "2025-05-01T10:00:00Z"}
+			], "nextPageCursor": "p2"}`)
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"results": [{"id": "b", "source_url": "https://example.com/b", "title": "B", "summary": "Auto excerpt", "created_at": "2025-06-04T10:00:00Z"}]}`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/update/b/":
+			_ = json.NewDecoder(r.Body).Decode(&patched)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	reader := &Readwise{Token: "secret", BaseURL: server.URL}
+	items, err := reader.Saved(context.Background(), since, 0)
+	if err != nil {
+		t.Fatalf("Saved failed: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != "b" || items[1].URL != "https://example.com/a" {
+		t.Fatalf("expected documents b and a, newest first, with their article URLs, got %+v", items)
+	}
+	if items[0].Note != "Auto excerpt" {
+		t.Errorf("expected the document summary as the note, got %q", items[0].Note)
+	}
+
+	if err := reader.SetNote(context.Background(), items[0], "New summary"); err != nil {
+		t.Fatalf("SetNote failed: %v", err)
+	}
+	if patched["summary"] != "New summary" {
+		t.Errorf("expected the summary patched, got %+v", patched)
+	}
+}
+
+func TestRaindrop_SavedStopsAtSince(t *testing.T) {
+	since := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	var put map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/raindrops/-1":
+			fmt.Fprint(w, `{"items": [
+				{"_id": 2, "link": "https://example.com/new", "title": "New", "note": "mine", "created": "2025-06-04T10:00:00Z"},
+				{"_id": 1, "link": "https://example.com/old", "title": "Old", "created": "2025-05-30T10:00:00Z"}
+			]}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/raindrop/2":
+			_ = json.NewDecoder(r.Body).Decode(&put)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	raindrop := &Raindrop{Token: "secret", Collection: -1, BaseURL: server.URL}
+	items, err := raindrop.Saved(context.Background(), since, 0)
+	if err != nil {
+		t.Fatalf("Saved failed: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "2" || items[0].Note != "mine" {
+		t.Fatalf("expected only the bookmark saved since, got %+v", items)
+	}
+
+	if err := raindrop.SetNote(context.Background(), items[0], "mine\n\nsummary"); err != nil {
+		t.Fatalf("SetNote failed: %v", err)
+	}
+	if put["note"] != "mine\n\nsummary" {
+		t.Errorf("expected the note replaced, got %+v", put)
+	}
+
+	raindrop.Token = "wrong"
+	if _, err := raindrop.Saved(context.Background(), since, 0); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected an authorization error, got %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+// Package redact replaces sensitive strings (internal hostnames, customer names) with
+// placeholders before text is sent to an LLM, and restores them in what comes back.
+// The placeholder mapping is kept locally, so the same value always gets the same
+// placeholder and responses can be restored later.
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TermsRuleName labels placeholders of dictionary terms
+const TermsRuleName = "term"
+
+// placeholderPattern matches placeholders in LLM output, e.g. REDACTED_HOST_3
+var placeholderPattern = regexp.MustCompile(`REDACTED_[A-Z0-9]+_\d+`)
+
+// Rule is a named pattern whose matches are redacted. The name labels the
+// placeholder, e.g. a "host" rule yields REDACTED_HOST_1.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// Store keeps the placeholder mapping so it survives the process
+type Store interface {
+	LoadRedactions() (map[string]string, error)       // placeholder → original
+	SaveRedaction(placeholder, original string) error // Called once per new placeholder
+}
+
+// Redactor redacts and restores text. A nil *Redactor passes text through unchanged.
+type Redactor struct {
+	rules []Rule
+	store Store
+
+	mu            sync.Mutex
+	toPlaceholder map[string]string // original → placeholder
+	toOriginal    map[string]string // placeholder → original
+	counters      map[string]int    // last number used per label
+	saveErr       error             // first failure to persist the mapping
+}
+
+// New creates a redactor for rules and dictionary terms (matched case-insensitively as
+// whole words), continuing the mapping kept in store. store may be nil to keep the
+// mapping in memory only.
+func New(rules []Rule, terms []string, store Store) (*Redactor, error) {
+	r := &Redactor{
+		rules:         append([]Rule(nil), rules...),
+		store:         store,
+		toPlaceholder: make(map[string]string),
+		toOriginal:    make(map[string]string),
+		counters:      make(map[string]int),
+	}
+
+	if termRule, ok := termsRule(terms); ok {
+		r.rules = append([]Rule{termRule}, r.rules...)
+	}
+
+	if store != nil {
+		existing, err := store.LoadRedactions()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load redaction mapping: %w", err)
+		}
+		for placeholder, original := range existing {
+			r.remember(placeholder, original)
+		}
+	}
+
+	return r, nil
+}
+
+// CompileRule compiles a named pattern into a rule
+func CompileRule(name, pattern string) (Rule, error) {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid redaction pattern %q: %w", name, err)
+	}
+	return Rule{Name: name, Pattern: compiled}, nil
+}
+
+// termsRule builds one case-insensitive whole-word pattern for the dictionary terms,
+// longest first so "Acme Corp" wins over "Acme"
+func termsRule(terms []string) (Rule, bool) {
+	var quoted []string
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			quoted = append(quoted, regexp.QuoteMeta(term))
+		}
+	}
+	if len(quoted) == 0 {
+		return Rule{}, false
+	}
+	sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+
+	pattern := regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	return Rule{Name: TermsRuleName, Pattern: pattern}, true
+}
+
+// Redact replaces every match of the rules with its placeholder
+func (r *Redactor) Redact(text string) string {
+	if r == nil || text == "" {
+		return text
+	}
+	for _, rule := range r.rules {
+		text = rule.Pattern.ReplaceAllStringFunc(text, func(match string) string {
+			return r.placeholder(rule.Name, match)
+		})
+	}
+	return text
+}
+
+// Restore puts the originals back in place of placeholders
+func (r *Redactor) Restore(text string) string {
+	return r.restore(text, false)
+}
+
+// RestoreJSON is Restore for JSON output: originals are escaped as JSON string content
+func (r *Redactor) RestoreJSON(text string) string {
+	return r.restore(text, true)
+}
+
+func (r *Redactor) restore(text string, escapeJSON bool) string {
+	if r == nil || !strings.Contains(text, "REDACTED_") {
+		return text
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		original, ok := r.toOriginal[placeholder]
+		if !ok {
+			return placeholder
+		}
+		if escapeJSON {
+			encoded, _ := json.Marshal(original)
+			return string(encoded[1 : len(encoded)-1])
+		}
+		return original
+	})
+}
+
+// Len returns how many distinct values have a placeholder
+func (r *Redactor) Len() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.toOriginal)
+}
+
+// Err returns the first failure to persist the mapping. Text is still redacted when the
+// store fails; only restoring it in a later run is affected.
+func (r *Redactor) Err() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.saveErr
+}
+
+// placeholder returns the placeholder for original, creating and persisting one the
+// first time the value is seen. Values are matched case-insensitively.
+func (r *Redactor) placeholder(name, original string) string {
+	key := strings.ToLower(original)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if placeholder, ok := r.toPlaceholder[key]; ok {
+		return placeholder
+	}
+
+	label := placeholderLabel(name)
+	r.counters[label]++
+	placeholder := fmt.Sprintf("REDACTED_%s_%d", label, r.counters[label])
+	r.remember(placeholder, original)
+
+	if r.store != nil {
+		if err := r.store.SaveRedaction(placeholder, original); err != nil && r.saveErr == nil {
+			r.saveErr = err
+		}
+	}
+	return placeholder
+}
+
+// remember records a mapping and advances the label's counter past its number
+func (r *Redactor) remember(placeholder, original string) {
+	r.toPlaceholder[strings.ToLower(original)] = placeholder
+	r.toOriginal[placeholder] = original
+
+	rest := strings.TrimPrefix(placeholder, "REDACTED_")
+	if i := strings.LastIndex(rest, "_"); i > 0 {
+		if n, err := strconv.Atoi(rest[i+1:]); err == nil && n > r.counters[rest[:i]] {
+			r.counters[rest[:i]] = n
+		}
+	}
+}
+
+// placeholderLabel upper-cases a rule name into a placeholder label ("internal-host" → "INTERNALHOST")
+func placeholderLabel(name string) string {
+	var label strings.Builder
+	for _, ch := range strings.ToUpper(name) {
+		if (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') {
+			label.WriteRune(ch)
+		}
+	}
+	if label.Len() == 0 {
+		return "VALUE"
+	}
+	return label.String()
+}
@@ -0,0 +1,123 @@
+package redact
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// memoryStore is a Store kept in a map
+type memoryStore struct {
+	mapping map[string]string
+	saves   int
+	fail    bool
+}
+
+func (m *memoryStore) LoadRedactions() (map[string]string, error) {
+	return m.mapping, nil
+}
+
+func (m *memoryStore) SaveRedaction(placeholder, original string) error {
+	if m.fail {
+		return errors.New("disk full")
+	}
+	if m.mapping == nil {
+		m.mapping = make(map[string]string)
+	}
+	m.mapping[placeholder] = original
+	m.saves++
+	return nil
+}
+
+func newTestRedactor(t *testing.T, store Store) *Redactor {
+	t.Helper()
+	host, err := CompileRule("host", `\b[a-z0-9-]+\.corp\.example\.com\b`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := New([]Rule{host}, []string{"Acme", "Acme Corp", "Globex"}, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRedactAndRestore(t *testing.T) {
+	store := &memoryStore{}
+	r := newTestRedactor(t, store)
+
+	text := "Acme Corp moved billing.corp.example.com behind Globex's proxy; acme corp says billing.corp.example.com is fine. Acme too."
+	redacted := r.Redact(text)
+
+	for _, secret := range []string{"Acme", "Globex", "billing.corp.example.com"} {
+		if strings.Contains(strings.ToLower(redacted), strings.ToLower(secret)) {
+			t.Errorf("expected %q redacted, got %q", secret, redacted)
+		}
+	}
+	if !strings.HasPrefix(redacted, "REDACTED_TERM_1 moved REDACTED_HOST_1 behind REDACTED_TERM_2's proxy; REDACTED_TERM_1 says REDACTED_HOST_1") {
+		t.Errorf("expected stable placeholders with the longest term first, got %q", redacted)
+	}
+	if r.Len() != 4 || store.saves != 4 {
+		t.Errorf("expected 4 mapped values saved once each, got %d mapped, %d saves", r.Len(), store.saves)
+	}
+
+	// Case variants of one value share its placeholder and restore to its first spelling
+	want := strings.Replace(text, "acme corp", "Acme Corp", 1)
+	if restored := r.Restore(redacted); restored != want {
+		t.Errorf("Restore = %q, want %q", restored, want)
+	}
+	if got := r.Restore("REDACTED_HOST_9 is unknown"); got != "REDACTED_HOST_9 is unknown" {
+		t.Errorf("expected unknown placeholders kept, got %q", got)
+	}
+}
+
+func TestRestoreJSON(t *testing.T) {
+	r, err := New(nil, []string{`Quote "Co" Ltd`}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	placeholder := r.Redact(`Quote "Co" Ltd`)
+
+	restored := r.RestoreJSON(`{"summary":"` + placeholder + ` shipped"}`)
+	var parsed struct{ Summary string }
+	if err := json.Unmarshal([]byte(restored), &parsed); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", restored, err)
+	}
+	if parsed.Summary != `Quote "Co" Ltd shipped` {
+		t.Errorf("unexpected summary %q", parsed.Summary)
+	}
+}
+
+func TestRedactor_ContinuesStoredMapping(t *testing.T) {
+	store := &memoryStore{mapping: map[string]string{"REDACTED_TERM_1": "Acme", "REDACTED_HOST_4": "db.corp.example.com"}}
+	r := newTestRedactor(t, store)
+
+	if got := r.Redact("Acme and Globex"); got != "REDACTED_TERM_1 and REDACTED_TERM_2" {
+		t.Errorf("expected the stored placeholder reused, got %q", got)
+	}
+	if got := r.Redact("api.corp.example.com"); got != "REDACTED_HOST_5" {
+		t.Errorf("expected numbering to continue after stored placeholders, got %q", got)
+	}
+	if got := r.Restore("REDACTED_HOST_4"); got != "db.corp.example.com" {
+		t.Errorf("expected a stored placeholder restored, got %q", got)
+	}
+}
+
+func TestRedactor_SaveFailureStillRedacts(t *testing.T) {
+	r := newTestRedactor(t, &memoryStore{fail: true})
+
+	if got := r.Redact("Globex"); got != "REDACTED_TERM_1" {
+		t.Errorf("expected redaction despite the store failing, got %q", got)
+	}
+	if r.Err() == nil {
+		t.Error("expected the save failure reported")
+	}
+}
+
+func TestNilRedactor(t *testing.T) {
+	var r *Redactor
+	if r.Redact("Acme") != "Acme" || r.Restore("REDACTED_TERM_1") != "REDACTED_TERM_1" || r.Len() != 0 {
+		t.Error("expected a nil redactor to pass text through")
+	}
+}
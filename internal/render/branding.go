@@ -0,0 +1,73 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Branding is the publication's own header and footer (branding in config), added
+// to newsletter and email digests so they can go out without editing
+type Branding struct {
+	Name            string // Publication name, used as the logo's alt text
+	LogoURL         string
+	LogoLink        string // Where the logo links (optional)
+	FooterLinks     []Link
+	UnsubscribeText string
+	UnsubscribeURL  string // Left as given, so ESP merge tags such as *|UNSUB|* work
+	Disclaimer      string
+}
+
+// Link is a labeled footer link
+type Link struct {
+	Label string
+	URL   string
+}
+
+// HasHeader reports whether there is a logo to show above the digest
+func (b *Branding) HasHeader() bool {
+	return b != nil && b.LogoURL != ""
+}
+
+// HasFooter reports whether there is anything to show below the digest
+func (b *Branding) HasFooter() bool {
+	return b != nil && (len(b.FooterLinks) > 0 || b.UnsubscribeText != "" || b.Disclaimer != "")
+}
+
+// MarkdownHeader renders the logo, or "" when there is none
+func (b *Branding) MarkdownHeader() string {
+	if !b.HasHeader() {
+		return ""
+	}
+	logo := fmt.Sprintf("![%s](%s)", b.Name, b.LogoURL)
+	if b.LogoLink != "" {
+		logo = fmt.Sprintf("[%s](%s)", logo, b.LogoLink)
+	}
+	return logo + "\n\n"
+}
+
+// MarkdownFooter renders the footer links, disclaimer, and unsubscribe line after a
+// rule, or "" when there are none
+func (b *Branding) MarkdownFooter() string {
+	if !b.HasFooter() {
+		return ""
+	}
+	var parts []string
+	if len(b.FooterLinks) > 0 {
+		links := make([]string, len(b.FooterLinks))
+		for i, link := range b.FooterLinks {
+			links[i] = fmt.Sprintf("[%s](%s)", link.Label, link.URL)
+		}
+		parts = append(parts, strings.Join(links, " · "))
+	}
+	if b.Disclaimer != "" {
+		parts = append(parts, "*"+strings.TrimSpace(b.Disclaimer)+"*")
+	}
+	if b.UnsubscribeText != "" {
+		unsubscribe := b.UnsubscribeText
+		if b.UnsubscribeURL != "" {
+			unsubscribe = fmt.Sprintf("[%s](%s)", unsubscribe, b.UnsubscribeURL)
+		}
+		parts = append(parts, unsubscribe)
+	}
+	return "\n\n---\n\n" + strings.Join(parts, "\n\n") + "\n"
+}
@@ -0,0 +1,31 @@
+package render
+
+import "testing"
+
+func TestBranding_Markdown(t *testing.T) {
+	var none *Branding
+	if none.MarkdownHeader() != "" || none.MarkdownFooter() != "" {
+		t.Error("expected nothing without branding")
+	}
+
+	branding := &Branding{
+		Name:            "Acme Weekly",
+		LogoURL:         "https://acme.example/logo.png",
+		LogoLink:        "https://acme.example",
+		FooterLinks:     []Link{{Label: "Archive", URL: "https://acme.example/archive"}, {Label: "About", URL: "https://acme.example/about"}},
+		UnsubscribeText: "Unsubscribe",
+		UnsubscribeURL:  "{{unsubscribe}}",
+		Disclaimer:      "Views are our own.",
+	}
+	if got, want := branding.MarkdownHeader(), "[![Acme Weekly](https://acme.example/logo.png)](https://acme.example)\n\n"; got != want {
+		t.Errorf("MarkdownHeader = %q, want %q", got, want)
+	}
+	want := "\n\n---\n\n[Archive](https://acme.example/archive) · [About](https://acme.example/about)\n\n*Views are our own.*\n\n[Unsubscribe]({{unsubscribe}})\n"
+	if got := branding.MarkdownFooter(); got != want {
+		t.Errorf("MarkdownFooter = %q, want %q", got, want)
+	}
+
+	if footer := (&Branding{LogoURL: "https://acme.example/logo.png"}).MarkdownFooter(); footer != "" {
+		t.Errorf("expected no footer with only a logo, got %q", footer)
+	}
+}
@@ -0,0 +1,121 @@
+package render
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// plainMode is set by --plain (output.plain); see SetPlain
+var plainMode atomic.Bool
+
+// SetPlain turns plain output on or off for the whole process. Writers of
+// user-facing output (digest files, email, messages, speech) pass their text
+// through Output, so one switch covers every channel.
+func SetPlain(enabled bool) {
+	plainMode.Store(enabled)
+}
+
+// PlainMode reports whether plain output is on
+func PlainMode() bool {
+	return plainMode.Load()
+}
+
+// Output returns text as it should be written: unchanged, or Plain in plain mode
+func Output(text string) string {
+	if !PlainMode() {
+		return text
+	}
+	return Plain(text)
+}
+
+// asciiReplacements spells typographic unicode the way plain-text clients and
+// speech engines handle reliably
+var asciiReplacements = strings.NewReplacer(
+	"•", "-", "·", "-", "▪", "-", "◦", "-",
+	"—", "--", "–", "-", "‐", "-", "−", "-",
+	"━", "-", "─", "-", "═", "=",
+	"…", "...",
+	"‘", "'", "’", "'", "“", `"`, "”", `"`,
+	"→", "->", "←", "<-", "⇒", "=>", "↔", "<->",
+	"×", "x", "≈", "~", "≥", ">=", "≤", "<=",
+	"©", "(c)", "®", "(R)", "™", "(TM)",
+	" ", " ",
+)
+
+// Plain strips emoji, spells typographic punctuation and separators in ASCII, and
+// drops headings left empty, for corporate email clients and text-to-speech that
+// mangle them. Letters outside ASCII (accents, CJK) are kept.
+func Plain(text string) string {
+	text = asciiReplacements.Replace(text)
+
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		plain, changed := stripEmoji(line)
+		if changed {
+			plain = strings.TrimRight(plain, " \t")
+			if isHeading(line) && strings.Trim(plain, "#*_ ") == "" {
+				continue
+			}
+		}
+		kept = append(kept, plain)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// stripEmoji drops the emoji in a line along with the space after one that
+// starts a word, so "## 🔥 Top" becomes "## Top" and "a 🔥 b" becomes "a b"
+func stripEmoji(line string) (string, bool) {
+	var out strings.Builder
+	changed, dropped := false, false
+	for _, r := range line {
+		if isEmoji(r) {
+			changed, dropped = true, true
+			continue
+		}
+		if dropped && r == ' ' && wordBoundary(out.String()) {
+			dropped = false
+			continue
+		}
+		dropped = false
+		out.WriteRune(r)
+	}
+	return out.String(), changed
+}
+
+// wordBoundary reports whether text so far ends where a word would start
+func wordBoundary(text string) bool {
+	if text == "" {
+		return true
+	}
+	last := text[len(text)-1]
+	return last == ' ' || last == '\t' || strings.IndexByte("#*_>([-", last) >= 0
+}
+
+// isEmoji reports whether r is an emoji, pictograph, or one of the joiners and
+// selectors that combine them
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // Pictographs, emoticons, transport, flags, skin tones
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // Miscellaneous technical (⏱ ⌛)
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // Stars and arrows (⭐)
+		return true
+	case r >= 0x2190 && r <= 0x21FF: // Arrows not spelled out above
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Tag sequences
+		return true
+	case r == 0x200D, r == 0x20E3, r == 0xFE0E, r == 0xFE0F:
+		return true
+	}
+	return unicode.Is(unicode.Co, r) // Private use glyphs
+}
+
+func isHeading(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "#") || (strings.HasPrefix(trimmed, "*") && strings.HasSuffix(trimmed, "*"))
+}
@@ -1,7 +1,10 @@
 package render
 
 import (
+	"briefly/internal/core"
+	"briefly/internal/footnotes"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -34,10 +37,47 @@ type DigestData struct {
 	AlertConditions []string // List of alert conditions that matched
 	ResearchQueries []string // Generated research queries for this article
 	// v2.0 Priority scoring
-	PriorityScore float64 // Combined priority score for article ordering (0.0-1.0)
+	PriorityScore  float64 // Combined priority score for article ordering (0.0-1.0)
+	SignalStrength float64 // Article signal-to-noise score (novelty, depth, actionability); 0 when unscored
 	// v2.1 Interactive features
 	UserSelected bool   // Whether this article was manually selected by user
 	UserTakeText string // User's personal commentary for this specific article
+	// Inline figures (charts, benchmarks) extracted from the article
+	Figures []core.ArticleImage
+	// Page metadata (OpenGraph, Twitter card, JSON-LD)
+	Author        string    // Byline
+	SiteName      string    // Human-readable site name
+	HeroImage     string    // Share image URL
+	DatePublished time.Time // Original publication date; zero when unknown
+}
+
+// Byline returns the item's "author · site · date" line, or "" when none is known
+func (d DigestData) Byline() string {
+	return Byline(d.Author, d.SiteName, d.DatePublished)
+}
+
+// Domain returns the host of the item's URL without "www.", or "" when it has none
+func (d DigestData) Domain() string {
+	parsed, err := url.Parse(d.URL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// Byline joins whichever of author, site name, and publication date are known
+func Byline(author, siteName string, published time.Time) string {
+	var parts []string
+	if author != "" {
+		parts = append(parts, author)
+	}
+	if siteName != "" {
+		parts = append(parts, siteName)
+	}
+	if !published.IsZero() {
+		parts = append(parts, published.Format("Jan 2, 2006"))
+	}
+	return strings.Join(parts, " · ")
 }
 
 // InteractiveSession manages the interactive article selection workflow
@@ -78,9 +118,16 @@ func RenderMarkdownDigest(digestItems []DigestData, outputDir string, finalDiges
 	if len(digestItems) == 0 {
 		markdownContent.WriteString("No articles processed for this digest.\n")
 	} else {
+		// Articles are footnotes, numbered in order; citations in the final digest
+		// resolve to the same numbers
+		cite := footnotes.New(1)
+		for _, item := range digestItems {
+			cite.Add(item.URL, item.Title)
+		}
+
 		if finalDigest != "" {
 			// Use final digest as main content
-			markdownContent.WriteString(finalDigest)
+			markdownContent.WriteString(cite.Cite(finalDigest))
 			markdownContent.WriteString("\n\n---\n\n")
 			markdownContent.WriteString("## Individual Article Summaries\n\n")
 			markdownContent.WriteString("*For reference, here are the individual summaries that were used to create the digest above.*\n\n")
@@ -88,17 +135,17 @@ func RenderMarkdownDigest(digestItems []DigestData, outputDir string, finalDiges
 
 		// Add individual summaries (either as main content or as appendix)
 		for i, item := range digestItems {
-			markdownContent.WriteString(fmt.Sprintf("### %d. %s\n\n", i+1, item.Title))
+			markdownContent.WriteString(fmt.Sprintf("### %d. %s%s\n\n", i+1, item.Title, cite.Ref(item.URL)))
 			markdownContent.WriteString(item.SummaryText + "\n\n")
 			if item.MyTake != "" {
 				markdownContent.WriteString(fmt.Sprintf("**My Take:** %s\n\n", item.MyTake))
 			}
-			markdownContent.WriteString(fmt.Sprintf("[^%d]: %s\n\n", i+1, item.URL))
 			markdownContent.WriteString("---\n\n")
 		}
+		markdownContent.WriteString(cite.References())
 	}
 
-	err = os.WriteFile(filePath, []byte(markdownContent.String()), 0644)
+	err = os.WriteFile(filePath, []byte(Output(markdownContent.String())), 0644)
 	if err != nil {
 		return "", fmt.Errorf("failed to write digest file %s: %w", filePath, err)
 	}
@@ -120,7 +167,7 @@ func WriteDigestToFile(content, outputDir, filename string) (string, error) {
 
 	filePath := filepath.Join(outputDir, filename)
 
-	err = os.WriteFile(filePath, []byte(content), 0644)
+	err = os.WriteFile(filePath, []byte(Output(content)), 0644)
 	if err != nil {
 		return "", fmt.Errorf("failed to write digest file %s: %w", filePath, err)
 	}
@@ -451,3 +451,53 @@ func TestRenderMarkdownDigest_NoMyTake(t *testing.T) {
 		t.Error("Content should contain article summary")
 	}
 }
+
+func TestByline(t *testing.T) {
+	published := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	item := DigestData{Author: "Jane Doe", SiteName: "Example Blog", DatePublished: published}
+	if got := item.Byline(); got != "Jane Doe · Example Blog · Mar 4, 2025" {
+		t.Errorf("unexpected byline %q", got)
+	}
+	if got := Byline("", "Example Blog", time.Time{}); got != "Example Blog" {
+		t.Errorf("expected only the site name, got %q", got)
+	}
+	if got := (DigestData{}).Byline(); got != "" {
+		t.Errorf("expected no byline, got %q", got)
+	}
+}
+
+func TestPlain(t *testing.T) {
+	input := "# 🗞️ AI Weekly\n\n*12 articles • 30 min total read time*\n\n## 🎯\n\n## 🔥 Top Developments\n\n" +
+		"• Agents ship — finally… 🚀\n📖 5 min read\n*🧵 Thread 1/2*\nCafé “déjà vu” → next\n"
+	want := "# AI Weekly\n\n*12 articles - 30 min total read time*\n\n\n## Top Developments\n\n" +
+		"- Agents ship -- finally...\n5 min read\n*Thread 1/2*\nCafé \"déjà vu\" -> next\n"
+
+	got := Plain(input)
+	if got != want {
+		t.Errorf("Plain() =\n%q\nwant\n%q", got, want)
+	}
+	if Plain(got) != got {
+		t.Error("expected Plain to be idempotent")
+	}
+}
+
+func TestOutput(t *testing.T) {
+	defer SetPlain(false)
+
+	if Output("🔥 Hot") != "🔥 Hot" {
+		t.Error("expected output unchanged when plain mode is off")
+	}
+	SetPlain(true)
+	if Output("🔥 Hot") != "Hot" {
+		t.Errorf("expected plain output, got %q", Output("🔥 Hot"))
+	}
+
+	path, err := WriteDigestToFile("## ✅ Done\n", t.TempDir(), "digest.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "## Done\n" {
+		t.Errorf("expected the written digest to be plain, got %q", data)
+	}
+}
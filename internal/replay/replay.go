@@ -0,0 +1,214 @@
+// Package replay records deterministic digest runs ('digest generate --deterministic'):
+// the options, reference time, model, prompt versions, and seeds of the run, a hash
+// of every input article and summary, and the digests it produced. Replaying a record
+// against the same cache checks that nothing but the output could differ, then diffs
+// the new digests against the recorded ones.
+package replay
+
+import (
+	"briefly/internal/core"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Seed is the clustering seed of deterministic runs
+const Seed int64 = 42
+
+// formatVersion is the version of the record file format
+const formatVersion = 1
+
+// Options are the digest generate flags that select and shape a run
+type Options struct {
+	SinceDays    int    `json:"since_days"`
+	Theme        string `json:"theme,omitempty"`
+	MinArticles  int    `json:"min_articles"`
+	Audience     string `json:"audience,omitempty"`
+	MustReadURL  string `json:"must_read_url,omitempty"`
+	Perspectives bool   `json:"perspectives,omitempty"`
+	EmbedFrom    string `json:"embed_from,omitempty"` // Clustering embedding source; empty = summary
+}
+
+// Input is one article of a run and the summary it was digested from
+type Input struct {
+	ArticleID   string `json:"article_id"`
+	URL         string `json:"url"`
+	ContentHash string `json:"content_hash"`           // Title and text
+	SummaryHash string `json:"summary_hash,omitempty"` // Summary text
+}
+
+// Output is one digest a run produced, as rendered to markdown
+type Output struct {
+	DigestID string `json:"digest_id"`
+	Title    string `json:"title"`
+	SHA256   string `json:"sha256"`
+	Content  string `json:"content"`
+}
+
+// Record is everything needed to re-run a deterministic digest run and check its output
+type Record struct {
+	Version        int               `json:"version"`
+	Tool           string            `json:"tool"`           // briefly version that made the run
+	ReferenceTime  time.Time         `json:"reference_time"` // "Now" for the run: the article window and digest dates
+	Model          string            `json:"model"`
+	PromptVersions map[string]string `json:"prompt_versions"`
+	Seed           int64             `json:"seed"`          // Clustering
+	SamplingSeed   int32             `json:"sampling_seed"` // LLM sampling, at temperature 0
+	Options        Options           `json:"options"`
+	Inputs         []Input           `json:"inputs"`
+	Outputs        []Output          `json:"outputs"`
+}
+
+// Inputs lists the articles of a run with their summaries' hashes, sorted by article ID
+func Inputs(articles []core.Article, summaries []core.Summary) []Input {
+	summaryText := make(map[string]string, len(summaries))
+	for _, summary := range summaries {
+		for _, articleID := range summary.ArticleIDs {
+			summaryText[articleID] = summary.SummaryText
+		}
+	}
+
+	inputs := make([]Input, 0, len(articles))
+	for _, article := range articles {
+		input := Input{
+			ArticleID:   article.ID,
+			URL:         article.URL,
+			ContentHash: Hash(article.Title + "\n" + article.CleanedText),
+		}
+		if text, ok := summaryText[article.ID]; ok {
+			input.SummaryHash = Hash(text)
+		}
+		inputs = append(inputs, input)
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].ArticleID < inputs[j].ArticleID })
+	return inputs
+}
+
+// NewOutput records a rendered digest
+func NewOutput(digestID, title, content string) Output {
+	return Output{DigestID: digestID, Title: title, SHA256: Hash(content), Content: content}
+}
+
+// Hash returns the hex SHA-256 of text
+func Hash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// Save writes the record to dir/runs/run_<reference time>.json and returns its path
+func (r *Record) Save(dir string) (string, error) {
+	r.Version = formatVersion
+	runsDir := filepath.Join(dir, "runs")
+	if err := os.MkdirAll(runsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create runs directory: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode run record: %w", err)
+	}
+	path := filepath.Join(runsDir, fmt.Sprintf("run_%s.json", r.ReferenceTime.UTC().Format("20060102-150405")))
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write run record: %w", err)
+	}
+	return path, nil
+}
+
+// Load reads a record written by Save
+func Load(path string) (*Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run record: %w", err)
+	}
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse run record %s: %w", path, err)
+	}
+	if record.Version != formatVersion {
+		return nil, fmt.Errorf("run record %s has version %d; this briefly reads version %d", path, record.Version, formatVersion)
+	}
+	return &record, nil
+}
+
+// Mismatches lists how a replay's setup differs from the recorded run: model, prompt
+// versions, seeds, and input articles and summaries. Any mismatch means the output
+// isn't expected to be identical.
+func (r *Record) Mismatches(current *Record) []string {
+	var mismatches []string
+	if r.Model != current.Model {
+		mismatches = append(mismatches, fmt.Sprintf("model changed: %s → %s", r.Model, current.Model))
+	}
+
+	prompts := make([]string, 0, len(r.PromptVersions))
+	for prompt := range r.PromptVersions {
+		prompts = append(prompts, prompt)
+	}
+	for prompt := range current.PromptVersions {
+		if _, ok := r.PromptVersions[prompt]; !ok {
+			prompts = append(prompts, prompt)
+		}
+	}
+	sort.Strings(prompts)
+	for _, prompt := range prompts {
+		if recorded, now := r.PromptVersions[prompt], current.PromptVersions[prompt]; recorded != now {
+			mismatches = append(mismatches, fmt.Sprintf("%s prompt changed: %s → %s", prompt, orNone(recorded), orNone(now)))
+		}
+	}
+
+	if r.Seed != current.Seed || r.SamplingSeed != current.SamplingSeed {
+		mismatches = append(mismatches, fmt.Sprintf("seeds changed: %d/%d → %d/%d", r.Seed, r.SamplingSeed, current.Seed, current.SamplingSeed))
+	}
+
+	recorded := make(map[string]Input, len(r.Inputs))
+	for _, input := range r.Inputs {
+		recorded[input.ArticleID] = input
+	}
+	seen := make(map[string]bool, len(current.Inputs))
+	for _, input := range current.Inputs {
+		seen[input.ArticleID] = true
+		was, ok := recorded[input.ArticleID]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("article added: %s", input.URL))
+		case was.ContentHash != input.ContentHash:
+			mismatches = append(mismatches, fmt.Sprintf("article content changed: %s", input.URL))
+		case was.SummaryHash != input.SummaryHash:
+			mismatches = append(mismatches, fmt.Sprintf("summary changed: %s", input.URL))
+		}
+	}
+	for _, input := range r.Inputs {
+		if !seen[input.ArticleID] {
+			mismatches = append(mismatches, fmt.Sprintf("article missing: %s", input.URL))
+		}
+	}
+	return mismatches
+}
+
+func orNone(version string) string {
+	if version == "" {
+		return "none"
+	}
+	return version
+}
+
+// Compare diffs each replayed digest against the recorded one in the same position.
+// Returns one entry per difference, or nil when every digest is byte-identical.
+func Compare(recorded, current []Output) []string {
+	var diffs []string
+	for i := 0; i < max(len(recorded), len(current)); i++ {
+		switch {
+		case i >= len(current):
+			diffs = append(diffs, fmt.Sprintf("digest %d (%s) was not produced", i+1, recorded[i].Title))
+		case i >= len(recorded):
+			diffs = append(diffs, fmt.Sprintf("digest %d (%s) is new", i+1, current[i].Title))
+		case recorded[i].SHA256 != current[i].SHA256:
+			diffs = append(diffs, Diff(recorded[i].Content, current[i].Content,
+				fmt.Sprintf("recorded/digest-%d.md", i+1), fmt.Sprintf("replay/digest-%d.md", i+1)))
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,101 @@
+package replay
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRecord() *Record {
+	articles := []core.Article{
+		{ID: "b", URL: "https://example.com/b", Title: "B", CleanedText: "second"},
+		{ID: "a", URL: "https://example.com/a", Title: "A", CleanedText: "first"},
+	}
+	summaries := []core.Summary{{ArticleIDs: []string{"a"}, SummaryText: "A in short"}}
+	return &Record{
+		ReferenceTime:  time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC),
+		Model:          "gemini-flash",
+		PromptVersions: map[string]string{"digest_content": "v4", "critique": "v1"},
+		Seed:           Seed,
+		SamplingSeed:   42,
+		Options:        Options{SinceDays: 7, MinArticles: 3},
+		Inputs:         Inputs(articles, summaries),
+		Outputs:        []Output{NewOutput("d1", "Weekly", "# Weekly\n\nBody\n")},
+	}
+}
+
+func TestRecord_SaveLoad(t *testing.T) {
+	record := testRecord()
+	if record.Inputs[0].ArticleID != "a" || record.Inputs[0].SummaryHash == "" || record.Inputs[1].SummaryHash != "" {
+		t.Fatalf("expected inputs sorted by article with summary hashes, got %+v", record.Inputs)
+	}
+
+	path, err := record.Save(t.TempDir())
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if !strings.HasSuffix(path, "runs/run_20261017-093000.json") {
+		t.Errorf("unexpected record path %s", path)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if mismatches := record.Mismatches(loaded); len(mismatches) != 0 || loaded.Outputs[0].Content != record.Outputs[0].Content {
+		t.Errorf("expected an identical record after a round trip, got %v", mismatches)
+	}
+}
+
+func TestRecord_Mismatches(t *testing.T) {
+	recorded := testRecord()
+	current := testRecord()
+	current.PromptVersions["digest_content"] = "v5"
+	current.Inputs[0].SummaryHash = Hash("rewritten")
+	current.Inputs = append(current.Inputs[:1], Input{ArticleID: "c", URL: "https://example.com/c"})
+
+	got := strings.Join(recorded.Mismatches(current), "\n")
+	for _, want := range []string{
+		"digest_content prompt changed: v4 → v5",
+		"summary changed: https://example.com/a",
+		"article added: https://example.com/c",
+		"article missing: https://example.com/b",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("mismatches missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	recorded := []Output{NewOutput("d1", "Weekly", "# Weekly\n\nBody\n")}
+	if diffs := Compare(recorded, []Output{NewOutput("d2", "Weekly", "# Weekly\n\nBody\n")}); diffs != nil {
+		t.Errorf("expected identical content to compare equal despite new IDs, got %v", diffs)
+	}
+
+	diffs := Compare(recorded, []Output{NewOutput("d2", "Weekly", "# Weekly\n\nNew body\n"), NewOutput("d3", "Extra", "x")})
+	if len(diffs) != 2 || !strings.Contains(diffs[0], "-Body\n+New body") || diffs[1] != "digest 2 (Extra) is new" {
+		t.Errorf("unexpected diffs %q", diffs)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	var before, after []string
+	for i := 1; i <= 20; i++ {
+		before = append(before, "line")
+		after = append(after, "line")
+	}
+	before[1], after[1] = "old two", "new two"
+	after = append(after[:15], append([]string{"inserted"}, after[15:]...)...)
+
+	got := Diff(strings.Join(before, "\n")+"\n", strings.Join(after, "\n")+"\n", "a.md", "b.md")
+	want := "--- a.md\n+++ b.md\n" +
+		"@@ -1,5 +1,5 @@\n line\n-old two\n+new two\n line\n line\n line\n" +
+		"@@ -13,6 +13,7 @@\n line\n line\n line\n+inserted\n line\n line\n line\n"
+	if got != want {
+		t.Errorf("Diff =\n%s\nwant\n%s", got, want)
+	}
+	if Diff("same\n", "same\n", "a", "b") != "" {
+		t.Error("expected no diff for equal text")
+	}
+}
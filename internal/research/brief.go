@@ -0,0 +1,168 @@
+package research
+
+import (
+	"briefly/internal/core"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// citationPattern matches [n] citations, which only make sense inside the brief
+var citationPattern = regexp.MustCompile(`\s*\[\d+(?:,\s*\d+)*\]`)
+
+// RenderBrief renders a research report as a markdown brief
+func RenderBrief(report *core.ResearchReport) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("# 🔬 Research Brief: %s\n\n", report.Query))
+	content.WriteString(fmt.Sprintf("*%s • %d sources • %d queries*\n\n",
+		report.DateGenerated.Format("Jan 2, 2006"), len(report.Results), len(report.GeneratedQueries)))
+	content.WriteString("---\n\n")
+
+	content.WriteString(report.Summary)
+	content.WriteString("\n\n---\n\n")
+
+	if len(report.Results) > 0 {
+		content.WriteString("## 🧭 Source Mix\n\n")
+		mix := report.SourceMix
+		if mix == nil {
+			mix = SourceMix(report.Results)
+		}
+		content.WriteString(FormatSourceMix(mix) + "\n\n")
+		if domains := topDomains(report.Results, 5); len(domains) > 0 {
+			content.WriteString(fmt.Sprintf("Top domains: %s\n\n", strings.Join(domains, ", ")))
+		}
+
+		content.WriteString("## 📚 Sources\n\n")
+		for i, result := range report.Results {
+			if len(result.Authors) > 0 {
+				content.WriteString(fmt.Sprintf("%d. %s\n", i+1, formatPaperCitation(result)))
+				continue
+			}
+			kind := ""
+			if result.Kind != "" {
+				kind = fmt.Sprintf(" *(%s)*", result.Kind)
+			}
+			content.WriteString(fmt.Sprintf("%d. [%s](%s)%s\n", i+1, result.Title, result.URL, kind))
+		}
+		content.WriteString("\n")
+	}
+
+	if len(report.GeneratedQueries) > 0 {
+		content.WriteString("## 🔎 Queries\n\n")
+		for _, query := range report.GeneratedQueries {
+			content.WriteString(fmt.Sprintf("- %s\n", query))
+		}
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// formatPaperCitation renders a paper source as a reference:
+// "Yury Malkov, Dmitry Yashunin (2018). [Title](url). *TPAMI* · 1,200 citations"
+func formatPaperCitation(result core.ResearchResult) string {
+	authors := result.Authors
+	byline := strings.Join(authors, ", ")
+	if len(authors) > 3 {
+		byline = strings.Join(authors[:3], ", ") + " et al."
+	}
+	if !result.PublishedDate.IsZero() {
+		byline += fmt.Sprintf(" (%d)", result.PublishedDate.Year())
+	}
+
+	if !strings.HasSuffix(byline, ".") {
+		byline += "."
+	}
+	citation := fmt.Sprintf("%s [%s](%s).", byline, result.Title, result.URL)
+	var details []string
+	if result.Venue != "" {
+		details = append(details, fmt.Sprintf("*%s*", result.Venue))
+	}
+	if result.CitationCount > 0 {
+		details = append(details, fmt.Sprintf("%s citations", formatThousands(result.CitationCount)))
+	}
+	if len(details) > 0 {
+		citation += " " + strings.Join(details, " · ")
+	}
+	return citation
+}
+
+// formatThousands renders n with comma separators
+func formatThousands(n int) string {
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// SaveBrief writes the rendered brief to outputDir and returns its path
+func SaveBrief(report *core.ResearchReport, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("research_%s_%s.md", report.DateGenerated.Format("2006-01-02"), slugify(report.Query))
+	outputPath := filepath.Join(outputDir, filename)
+	if err := os.WriteFile(outputPath, []byte(RenderBrief(report)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write research brief: %w", err)
+	}
+	return outputPath, nil
+}
+
+// Refresher returns the first two sentences of a brief's opening paragraph, without
+// the brief's own citations, for linking the brief from elsewhere
+func Refresher(summary string) string {
+	var paragraph string
+	for _, block := range strings.Split(strings.TrimSpace(summary), "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" || strings.HasPrefix(block, "#") || strings.HasPrefix(block, "-") || strings.HasPrefix(block, "*") {
+			continue
+		}
+		paragraph = strings.Join(strings.Fields(block), " ")
+		break
+	}
+	paragraph = citationPattern.ReplaceAllString(paragraph, "")
+
+	sentences := 0
+	for i, r := range paragraph {
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		if i+1 < len(paragraph) && paragraph[i+1] != ' ' {
+			continue // e.g. "3.5" or "v1.2"
+		}
+		sentences++
+		if sentences == 2 {
+			return paragraph[:i+1]
+		}
+	}
+	return paragraph
+}
+
+// slugify turns a topic into a short filename-safe slug
+func slugify(topic string) string {
+	var slug strings.Builder
+	lastDash := true
+	for _, r := range strings.ToLower(topic) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			slug.WriteRune(r)
+			lastDash = false
+		case !lastDash:
+			slug.WriteByte('-')
+			lastDash = true
+		}
+		if slug.Len() >= 50 {
+			break
+		}
+	}
+	result := strings.Trim(slug.String(), "-")
+	if result == "" {
+		return "brief"
+	}
+	return result
+}
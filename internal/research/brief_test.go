@@ -0,0 +1,45 @@
+package research
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderBrief_PaperCitations(t *testing.T) {
+	report := &core.ResearchReport{
+		Query:         "hnsw",
+		Summary:       "Overview.",
+		DateGenerated: time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC),
+		Results: []core.ResearchResult{
+			{
+				Title:         "Efficient and robust ANN search",
+				URL:           "https://arxiv.org/abs/1603.09320",
+				Kind:          KindPaper,
+				Authors:       []string{"Yury Malkov", "Dmitry Yashunin"},
+				PublishedDate: time.Date(2016, time.March, 30, 0, 0, 0, 0, time.UTC),
+				Venue:         "TPAMI",
+				CitationCount: 1200,
+			},
+			{
+				Title:   "Four authors",
+				URL:     "https://arxiv.org/abs/2401.00001",
+				Kind:    KindPaper,
+				Authors: []string{"A", "B", "C", "D"},
+			},
+			{Title: "A blog post", URL: "https://blog.example.com/hnsw", Kind: KindBlog},
+		},
+	}
+
+	brief := RenderBrief(report)
+	for _, want := range []string{
+		"1. Yury Malkov, Dmitry Yashunin (2016). [Efficient and robust ANN search](https://arxiv.org/abs/1603.09320). *TPAMI* · 1,200 citations\n",
+		"2. A, B, C et al. [Four authors](https://arxiv.org/abs/2401.00001).\n",
+		"3. [A blog post](https://blog.example.com/hnsw) *(blog)*\n",
+	} {
+		if !strings.Contains(brief, want) {
+			t.Errorf("expected %q in brief:\n%s", want, brief)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package research
+
+import (
+	"briefly/internal/core"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultSourceMaxAge is how old a cached source page can be and still be reused,
+// matching the digest pipeline's article cache window
+const DefaultSourceMaxAge = 24 * time.Hour
+
+// maxExcerptLength caps the fetched page text each source contributes to synthesis
+const maxExcerptLength = 600
+
+// Fetcher fetches and cleans a source page. *fetch.ContentProcessor implements it.
+type Fetcher interface {
+	ProcessArticle(ctx context.Context, url string) (*core.Article, error)
+}
+
+// SourceCache is the article cache shared with the digest pipeline. *store.Store
+// implements it.
+type SourceCache interface {
+	GetCachedArticle(url string, maxAge time.Duration) (*core.Article, error)
+	SaveArticle(article *core.Article) error
+}
+
+// FetchStats reports what fetching a brief's source pages did
+type FetchStats struct {
+	Reused  int // Served from the article cache
+	Fetched int // Fetched and added to the article cache
+	Failed  int
+	Skipped int // Grounding redirects, which have no stable URL to cache under
+}
+
+// SetSourceCache fetches each kept source's page before synthesis, reusing pages
+// in cache younger than maxAge (<= 0 = DefaultSourceMaxAge) and caching new ones as
+// articles, so later digests read them without fetching again
+func (r *Researcher) SetSourceCache(fetcher Fetcher, cache SourceCache, maxAge time.Duration) {
+	if maxAge <= 0 {
+		maxAge = DefaultSourceMaxAge
+	}
+	r.fetcher = fetcher
+	r.sourceCache = cache
+	r.sourceMaxAge = maxAge
+}
+
+// fetchSources returns the page behind each result, keyed by result ID. Pages that
+// can't be fetched are skipped; the brief still cites the result's snippet.
+func (r *Researcher) fetchSources(ctx context.Context, topic string, results []core.ResearchResult) (map[string]*core.Article, FetchStats) {
+	var stats FetchStats
+	pages := make(map[string]*core.Article)
+	if r.fetcher == nil {
+		return pages, stats
+	}
+
+	for _, result := range results {
+		if parsed, err := url.Parse(result.URL); err != nil || parsed.Hostname() == groundingRedirectHost {
+			stats.Skipped++
+			continue
+		}
+
+		if r.sourceCache != nil {
+			if cached, err := r.sourceCache.GetCachedArticle(result.URL, r.sourceMaxAge); err == nil && cached != nil {
+				pages[result.ID] = cached
+				stats.Reused++
+				continue
+			}
+		}
+
+		article, err := r.fetcher.ProcessArticle(ctx, result.URL)
+		if err != nil || strings.TrimSpace(article.CleanedText) == "" {
+			stats.Failed++
+			continue
+		}
+		if article.Title == "" {
+			article.Title = result.Title
+		}
+		if article.DatePublished.IsZero() {
+			article.DatePublished = result.PublishedDate
+		}
+		if article.Publisher == "" {
+			article.Publisher = sourceDomain(result)
+		}
+		article.ResearchQueries = []string{topic}
+
+		if r.sourceCache != nil {
+			if err := r.sourceCache.SaveArticle(article); err != nil {
+				fmt.Printf("           ⚠ Could not cache %s: %v\n", result.URL, err)
+			}
+		}
+		pages[result.ID] = article
+		stats.Fetched++
+	}
+	return pages, stats
+}
+
+// excerpt returns the opening of a page's text, cut at a word boundary
+func excerpt(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) <= limit {
+		return text
+	}
+	cut := strings.LastIndex(text[:limit], " ")
+	if cut <= 0 {
+		cut = limit
+	}
+	return text[:cut] + "..."
+}
@@ -0,0 +1,95 @@
+package research
+
+import (
+	"briefly/internal/core"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeFetcher struct {
+	fetched []string
+}
+
+func (f *fakeFetcher) ProcessArticle(ctx context.Context, url string) (*core.Article, error) {
+	f.fetched = append(f.fetched, url)
+	if strings.Contains(url, "broken") {
+		return nil, fmt.Errorf("status code 404")
+	}
+	return &core.Article{URL: url, CleanedText: "Page text for " + url, DateFetched: time.Now()}, nil
+}
+
+type fakeSourceCache struct {
+	articles map[string]*core.Article
+}
+
+func (c *fakeSourceCache) GetCachedArticle(url string, maxAge time.Duration) (*core.Article, error) {
+	return c.articles[url], nil
+}
+
+func (c *fakeSourceCache) SaveArticle(article *core.Article) error {
+	c.articles[article.URL] = article
+	return nil
+}
+
+func TestFetchSources_ReusesAndCaches(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	cache := &fakeSourceCache{articles: map[string]*core.Article{
+		"https://example.com/cached": {URL: "https://example.com/cached", CleanedText: "Cached text"},
+	}}
+	researcher := NewResearcher(&fakeSearcher{}, &fakeGenerator{})
+	researcher.SetSourceCache(fetcher, cache, 0)
+
+	published := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	results := []core.ResearchResult{
+		{ID: "r1", Title: "Cached", URL: "https://example.com/cached"},
+		{ID: "r2", Title: "New paper", URL: "https://arxiv.org/abs/2405.00001", PublishedDate: published},
+		{ID: "r3", Title: "Broken", URL: "https://example.com/broken"},
+		{ID: "r4", Title: "techcrunch.com", URL: "https://vertexaisearch.cloud.google.com/grounding-api-redirect/x"},
+	}
+
+	pages, stats := researcher.fetchSources(context.Background(), "ann search", results)
+	if stats != (FetchStats{Reused: 1, Fetched: 1, Failed: 1, Skipped: 1}) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if strings.Join(fetcher.fetched, ",") != "https://arxiv.org/abs/2405.00001,https://example.com/broken" {
+		t.Errorf("expected only uncached, fetchable pages to be fetched, got %v", fetcher.fetched)
+	}
+	if pages["r1"].CleanedText != "Cached text" || pages["r2"] == nil || pages["r3"] != nil {
+		t.Errorf("unexpected pages %+v", pages)
+	}
+
+	saved := cache.articles["https://arxiv.org/abs/2405.00001"]
+	if saved == nil || saved.Title != "New paper" || !saved.DatePublished.Equal(published) ||
+		saved.Publisher != "arxiv.org" || strings.Join(saved.ResearchQueries, ",") != "ann search" {
+		t.Errorf("expected the fetched page cached as an article, got %+v", saved)
+	}
+}
+
+func TestResearcher_RunIncludesPageExcerpts(t *testing.T) {
+	generator := &fakeGenerator{}
+	researcher := NewResearcher(&fakeSearcher{}, generator)
+	researcher.SetMaxQueries(1)
+	researcher.AddProvider(&quotaProvider{left: 1}, 5)
+	researcher.SetGrounding(false)
+	researcher.SetSourceCache(&fakeFetcher{}, &fakeSourceCache{articles: map[string]*core.Article{}}, time.Hour)
+
+	if _, err := researcher.Run(context.Background(), "topic"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	synthesis := generator.prompts[len(generator.prompts)-1]
+	if !strings.Contains(synthesis, "Excerpt: Page text for https://example.com/first query") {
+		t.Errorf("expected the page excerpt in the synthesis prompt:\n%s", synthesis)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	if got := excerpt("one  two\nthree four", 13); got != "one two..." {
+		t.Errorf("excerpt = %q", got)
+	}
+	if got := excerpt("short", 13); got != "short" {
+		t.Errorf("excerpt = %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+package research
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"briefly/internal/store"
+	"sort"
+)
+
+// DefaultRelatedThreshold is the cluster-to-brief similarity a brief needs to be
+// linked from a digest section
+const DefaultRelatedThreshold = 0.78
+
+// RelatedBriefs matches digest clusters to stored research briefs by comparing each
+// cluster's centroid with each brief's embedding. A cluster links its most similar
+// brief at or above threshold, and a brief links from at most one cluster, the one
+// it is most similar to. Returns matches keyed by cluster index.
+func RelatedBriefs(clusters []core.TopicCluster, briefs []store.ResearchBrief, threshold float64) map[int]*core.RelatedResearch {
+	type candidate struct {
+		cluster    int
+		brief      int
+		similarity float64
+	}
+
+	var candidates []candidate
+	for i, cluster := range clusters {
+		if len(cluster.Centroid) == 0 {
+			continue
+		}
+		for j, brief := range briefs {
+			if len(brief.Embedding) == 0 {
+				continue
+			}
+			if similarity := llm.CosineSimilarity(cluster.Centroid, brief.Embedding); similarity >= threshold {
+				candidates = append(candidates, candidate{cluster: i, brief: j, similarity: similarity})
+			}
+		}
+	}
+
+	// Best pairs first, so each cluster and brief is claimed by its strongest match
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].similarity > candidates[b].similarity
+	})
+
+	matches := make(map[int]*core.RelatedResearch)
+	briefUsed := make(map[int]bool)
+	for _, c := range candidates {
+		if matches[c.cluster] != nil || briefUsed[c.brief] {
+			continue
+		}
+		brief := briefs[c.brief]
+		matches[c.cluster] = &core.RelatedResearch{
+			BriefID:    brief.ID,
+			Topic:      brief.Topic,
+			Path:       brief.Path,
+			Refresher:  Refresher(brief.Summary),
+			Similarity: c.similarity,
+		}
+		briefUsed[c.brief] = true
+	}
+	return matches
+}
@@ -0,0 +1,51 @@
+package research
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/store"
+	"testing"
+)
+
+func TestRelatedBriefs(t *testing.T) {
+	clusters := []core.TopicCluster{
+		{Label: "Vector DBs", Centroid: []float64{1, 0, 0}},
+		{Label: "Agents", Centroid: []float64{0, 1, 0}},
+		{Label: "Also vector DBs", Centroid: []float64{0.9, 0.1, 0}},
+		{Label: "No embedding"},
+	}
+	briefs := []store.ResearchBrief{
+		{ID: "b1", Topic: "Vector databases", Summary: "Vector stores matured. Pgvector leads. More detail.", Path: "research/b1.md", Embedding: []float64{1, 0, 0}},
+		{ID: "b2", Topic: "Unrelated", Summary: "Nothing.", Embedding: []float64{0, 0, 1}},
+	}
+
+	matches := RelatedBriefs(clusters, briefs, 0.8)
+	if len(matches) != 1 {
+		t.Fatalf("expected one match (each brief links once), got %+v", matches)
+	}
+	related := matches[0]
+	if related == nil || related.BriefID != "b1" || related.Path != "research/b1.md" {
+		t.Fatalf("expected the closest cluster to claim b1, got %+v", matches)
+	}
+	if related.Refresher != "Vector stores matured. Pgvector leads." {
+		t.Errorf("Refresher = %q", related.Refresher)
+	}
+}
+
+func TestRefresher(t *testing.T) {
+	summary := "## Overview\n\nGPU prices fell 3.5% in Q2 [1, 2]. Supply is\nrecovering [3]! Demand may spike.\n\n## Key Findings\n- more"
+	if got := Refresher(summary); got != "GPU prices fell 3.5% in Q2. Supply is recovering!" {
+		t.Errorf("Refresher = %q", got)
+	}
+	if got := Refresher("One sentence only"); got != "One sentence only" {
+		t.Errorf("Refresher = %q", got)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	if got := slugify("What's new in WebGPU?"); got != "what-s-new-in-webgpu" {
+		t.Errorf("slugify = %q", got)
+	}
+	if got := slugify("???"); got != "brief" {
+		t.Errorf("slugify = %q", got)
+	}
+}
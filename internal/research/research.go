@@ -0,0 +1,517 @@
+// Package research runs deep research on a topic: it plans sub-queries, answers each
+// with a search-grounded model, web search providers, or academic indexes, and
+// synthesizes the findings into a cited brief.
+// Stored briefs are linked from later digests whose sections cover the same topic.
+package research
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"briefly/internal/search"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxQueries is how many sub-queries a research run plans when unset
+const DefaultMaxQueries = 5
+
+// maxSnippetLength caps the finding text kept on each research result
+const maxSnippetLength = 300
+
+// Searcher answers a prompt with web search grounding. *llm.Client implements it.
+type Searcher interface {
+	SearchGrounded(ctx context.Context, prompt string) (string, []llm.GroundingSource, error)
+}
+
+// sourceGrounded marks results found by the search-grounded model
+const sourceGrounded = "gemini"
+
+// Finding is what the search for one sub-query turned up
+type Finding struct {
+	Query   string
+	Answer  string // Grounded answer, or the result snippets for provider searches
+	Sources []search.Result
+}
+
+// Researcher runs deep research on a topic
+type Researcher struct {
+	searcher     Searcher
+	generator    llm.Generator
+	maxQueries   int
+	maxPerDomain int
+
+	providers []providerSearch // Search providers asked for every sub-query
+	grounding bool             // Also answer sub-queries with the search-grounded model
+
+	fetcher      Fetcher // Fetches source pages before synthesis; nil cites snippets only
+	sourceCache  SourceCache
+	sourceMaxAge time.Duration
+
+	commands <-chan Command // Steers the run while it searches; nil runs unattended
+
+	since      time.Time // Only cite sources published since then (zero = any time)
+	maxSources int       // Most sources a brief cites (0 = no cap)
+	focus      []string  // Areas the sub-queries must cover, instead of the general spread
+	sections   []string  // Headings the brief is written under, instead of Key Findings/Open Questions
+}
+
+// providerSearch is a search provider and how many results to ask it for
+type providerSearch struct {
+	provider   search.Provider
+	maxResults int
+	exhausted  bool // Daily quota ran out during this run
+}
+
+// NewResearcher creates a researcher that searches with searcher and plans and
+// synthesizes with generator
+func NewResearcher(searcher Searcher, generator llm.Generator) *Researcher {
+	return &Researcher{
+		searcher:     searcher,
+		generator:    generator,
+		maxQueries:   DefaultMaxQueries,
+		maxPerDomain: DefaultMaxPerDomain,
+		grounding:    true,
+	}
+}
+
+// SetMaxQueries sets how many sub-queries a run plans (<= 0 keeps the default)
+func (r *Researcher) SetMaxQueries(n int) {
+	if n > 0 {
+		r.maxQueries = n
+	}
+}
+
+// SetMaxPerDomain caps how many sources a brief cites from one domain (<= 0 = no cap)
+func (r *Researcher) SetMaxPerDomain(n int) {
+	r.maxPerDomain = n
+}
+
+// SetSince limits sources to those published since then. Search providers that can
+// filter by date are created with it; the grounded model is asked to, and papers
+// dated earlier are dropped.
+func (r *Researcher) SetSince(since time.Time) {
+	r.since = since
+}
+
+// SetMaxSources caps how many sources a brief cites (<= 0 = no cap)
+func (r *Researcher) SetMaxSources(n int) {
+	r.maxSources = n
+}
+
+// SetFocus sets the areas the planned sub-queries must cover, such as a template's
+// evaluation criteria
+func (r *Researcher) SetFocus(areas []string) {
+	r.focus = areas
+}
+
+// SetSections sets the headings the brief is written under, so briefs of the same
+// kind share a structure
+func (r *Researcher) SetSections(sections []string) {
+	r.sections = sections
+}
+
+// AddProvider also searches every sub-query with provider, maxResults results each
+func (r *Researcher) AddProvider(provider search.Provider, maxResults int) {
+	r.providers = append(r.providers, providerSearch{provider: provider, maxResults: maxResults})
+}
+
+// SetGrounding sets whether sub-queries are also answered by the search-grounded
+// model. It is on by default, and a researcher with no providers always uses it.
+func (r *Researcher) SetGrounding(enabled bool) {
+	r.grounding = enabled
+}
+
+// Run researches topic and returns a report whose Summary is the synthesized brief.
+// Sub-queries that fail are skipped; the run fails only if none succeed. Providers
+// whose quota runs out are dropped for the rest of the run, and once nothing is left
+// to search with, the run synthesizes what it has. With SetCommands, sub-queries can
+// be skipped or added while it runs, and a stop synthesizes what it has so far.
+func (r *Researcher) Run(ctx context.Context, topic string) (*core.ResearchReport, error) {
+	topic = strings.TrimSpace(topic)
+	if topic == "" {
+		return nil, fmt.Errorf("research topic is required")
+	}
+
+	ctx = llm.WithAttribution(ctx, llm.Attribution{Phase: "research"})
+
+	queries, err := r.planQueries(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	var searched []string
+	var lastErr error
+	queue := newQueryQueue(queries)
+	for ; queue.current < len(queue.queries); queue.current++ {
+		r.drain(queue)
+		if queue.stopped {
+			break
+		}
+		i, query := queue.current, queue.queries[queue.current]
+		if queue.skipped[i] {
+			fmt.Printf("   [%d/%d] Skipped: %s\n", i+1, len(queue.queries), query)
+			continue
+		}
+
+		fmt.Printf("   [%d/%d] Searching: %s\n", i+1, len(queue.queries), query)
+		start := time.Now()
+		finding, abandoned, err := r.searchSteered(ctx, topic, queue)
+		if abandoned {
+			if queue.stopped {
+				break
+			}
+			continue
+		}
+		searched = append(searched, query)
+		if err != nil {
+			fmt.Printf("           ⚠ Search failed: %v\n", err)
+			lastErr = err
+			if errors.Is(err, search.ErrQuotaExhausted) && !r.canSearch() {
+				break
+			}
+			continue
+		}
+		fmt.Printf("           ✓ %d source(s) in %s\n", len(finding.Sources), time.Since(start).Round(100*time.Millisecond))
+		findings = append(findings, *finding)
+	}
+	if len(findings) == 0 {
+		if lastErr == nil || queue.stopped {
+			return nil, fmt.Errorf("research stopped before any sub-query returned sources")
+		}
+		return nil, fmt.Errorf("all %d research searches failed: %w", len(searched), lastErr)
+	}
+
+	results, filter := selectSources(collectResults(findings, time.Now().UTC()), r.maxPerDomain)
+	if filter.Duplicates > 0 || filter.OverCap > 0 {
+		fmt.Printf("   ✓ Kept %d source(s): dropped %d duplicate(s) across queries, %d over the %d-per-domain cap\n",
+			len(results), filter.Duplicates, filter.OverCap, r.maxPerDomain)
+	}
+	if !r.since.IsZero() {
+		var dropped int
+		results, dropped = dropOlderThan(results, r.since)
+		if dropped > 0 {
+			fmt.Printf("   ✓ Dropped %d source(s) published before %s\n", dropped, r.since.Format("2006-01-02"))
+		}
+	}
+	if r.maxSources > 0 && len(results) > r.maxSources {
+		fmt.Printf("   ✓ Citing the first %d of %d source(s)\n", r.maxSources, len(results))
+		results = results[:r.maxSources]
+	}
+
+	var pages map[string]*core.Article
+	if r.fetcher != nil {
+		fmt.Printf("   📄 Reading %d source page(s)...\n", len(results))
+		var stats FetchStats
+		pages, stats = r.fetchSources(ctx, topic, results)
+		fmt.Printf("   ✓ %d from cache, %d fetched and cached, %d failed, %d skipped\n",
+			stats.Reused, stats.Fetched, stats.Failed, stats.Skipped)
+	}
+
+	summary, err := r.synthesize(ctx, topic, findings, results, pages)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.ResearchReport{
+		ID:               uuid.NewString(),
+		Query:            topic,
+		Depth:            1,
+		GeneratedQueries: searched,
+		Results:          results,
+		Summary:          summary,
+		DateGenerated:    time.Now().UTC(),
+		TotalResults:     len(results),
+		SourceMix:        SourceMix(results),
+	}, nil
+}
+
+// planQueries asks the model for sub-queries that together cover topic
+func (r *Researcher) planQueries(ctx context.Context, topic string) ([]string, error) {
+	coverage := "what it is, the current state of the art,\nadoption and trade-offs, notable critiques, and recent developments"
+	if len(r.focus) > 0 {
+		coverage = "each of these areas:\n- " + strings.Join(r.focus, "\n- ") + "\n"
+	}
+	prompt := fmt.Sprintf(`Plan a deep-research brief on this topic: %s
+
+Write %d web search queries that together cover %s. Make each query specific
+and distinct from the others.
+
+Return the queries as a numbered list (1. Query one 2. Query two, etc.) without any other text:`, topic, r.maxQueries, coverage)
+
+	response, err := r.generator.GenerateText(ctx, prompt, llm.TextGenerationOptions{
+		Temperature: 0.4,
+		MaxTokens:   512,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan research queries: %w", err)
+	}
+
+	queries := parseNumberedList(response)
+	if len(queries) == 0 {
+		return []string{topic}, nil
+	}
+	if len(queries) > r.maxQueries {
+		queries = queries[:r.maxQueries]
+	}
+	return queries, nil
+}
+
+// canSearch reports whether the search-grounded model or any provider with quota
+// left can still answer sub-queries
+func (r *Researcher) canSearch() bool {
+	if r.useGrounding() {
+		return true
+	}
+	for _, p := range r.providers {
+		if !p.exhausted {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Researcher) useGrounding() bool {
+	return r.grounding || len(r.providers) == 0
+}
+
+// search answers one sub-query with the search-grounded model and each provider,
+// merging what they find. It fails only if every one of them fails.
+func (r *Researcher) search(ctx context.Context, topic, query string) (*Finding, error) {
+	finding := &Finding{Query: query}
+	var answers []string
+	var lastErr error
+	searched := false
+
+	if r.useGrounding() {
+		answer, sources, err := r.searcher.SearchGrounded(ctx, buildSearchPrompt(topic, query, r.since))
+		if err != nil {
+			lastErr = err
+		} else {
+			searched = true
+			answers = append(answers, strings.TrimSpace(answer))
+			for _, source := range sources {
+				finding.Sources = append(finding.Sources, search.Result{Title: source.Title, URL: source.URL, Source: sourceGrounded})
+			}
+		}
+	}
+
+	for i := range r.providers {
+		p := &r.providers[i]
+		if p.exhausted {
+			continue
+		}
+		results, err := p.provider.Search(ctx, query, p.maxResults)
+		if err != nil {
+			if errors.Is(err, search.ErrQuotaExhausted) {
+				p.exhausted = true
+			}
+			lastErr = err
+			continue
+		}
+		searched = true
+		var answer strings.Builder
+		for _, result := range results {
+			answer.WriteString(fmt.Sprintf("- %s%s: %s\n", result.Title, paperCitation(result.Paper), result.Snippet))
+		}
+		if answer.Len() > 0 {
+			answers = append(answers, strings.TrimSpace(answer.String()))
+		}
+		finding.Sources = append(finding.Sources, results...)
+	}
+
+	if !searched {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no search provider has quota left")
+		}
+		return nil, lastErr
+	}
+	finding.Answer = strings.Join(answers, "\n")
+	return finding, nil
+}
+
+// paperCitation renders a paper's authors, year, and citation count as
+// " (Malkov et al., 2018; 1200 citations)" for the synthesis prompt
+func paperCitation(paper *search.Paper) string {
+	if paper == nil {
+		return ""
+	}
+	var parts []string
+	if byline := authorsByline(paper.Authors); byline != "" {
+		if !paper.Published.IsZero() {
+			byline += fmt.Sprintf(", %d", paper.Published.Year())
+		}
+		parts = append(parts, byline)
+	} else if !paper.Published.IsZero() {
+		parts = append(parts, fmt.Sprintf("%d", paper.Published.Year()))
+	}
+	if paper.Citations > 0 {
+		parts = append(parts, fmt.Sprintf("%d citations", paper.Citations))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, "; ") + ")"
+}
+
+// authorsByline shortens an author list to "Lovelace", "Lovelace & Turing", or
+// "Lovelace et al." by family name
+func authorsByline(authors []string) string {
+	names := make([]string, 0, len(authors))
+	for _, author := range authors {
+		if fields := strings.Fields(author); len(fields) > 0 {
+			names = append(names, fields[len(fields)-1])
+		}
+	}
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " & " + names[1]
+	default:
+		return names[0] + " et al."
+	}
+}
+
+// buildSearchPrompt asks the search-grounded model to answer one sub-query, from
+// sources published since then when since is set
+func buildSearchPrompt(topic, query string, since time.Time) string {
+	recency := ""
+	if !since.IsZero() {
+		recency = fmt.Sprintf(" Only use sources published on or after %s.", since.Format("January 2, 2006"))
+	}
+	return fmt.Sprintf(`You are researching "%s". Search the web and answer this question:
+
+%s
+
+Report the key facts, figures, and named sources in 4-6 sentences.%s Do not speculate beyond what the sources say.`, topic, query, recency)
+}
+
+// dropOlderThan removes sources dated before since. Sources without a date, which is
+// most web pages, are kept.
+func dropOlderThan(results []core.ResearchResult, since time.Time) ([]core.ResearchResult, int) {
+	kept := results[:0]
+	for _, result := range results {
+		if !result.PublishedDate.IsZero() && result.PublishedDate.Before(since) {
+			continue
+		}
+		kept = append(kept, result)
+	}
+	dropped := len(results) - len(kept)
+	for i := range kept {
+		kept[i].ID = fmt.Sprintf("r%d", i+1)
+	}
+	return kept, dropped
+}
+
+// synthesize writes the brief from the findings, citing results by number. Sources
+// whose page was read contribute an excerpt of it.
+func (r *Researcher) synthesize(ctx context.Context, topic string, findings []Finding, results []core.ResearchResult, pages map[string]*core.Article) (string, error) {
+	var prompt strings.Builder
+	prompt.WriteString(fmt.Sprintf("Write a research brief on: %s\n\n", topic))
+
+	prompt.WriteString("**Sources:**\n")
+	for i, result := range results {
+		prompt.WriteString(fmt.Sprintf("[%d] %s (%s, %s)", i+1, result.Title, result.URL, result.Kind))
+		if result.CitationCount > 0 {
+			prompt.WriteString(fmt.Sprintf(" — %d citations", result.CitationCount))
+		}
+		prompt.WriteString("\n")
+		if page := pages[result.ID]; page != nil {
+			prompt.WriteString(fmt.Sprintf("    Excerpt: %s\n", excerpt(page.CleanedText, maxExcerptLength)))
+		}
+	}
+
+	prompt.WriteString("\n**Findings by question:**\n")
+	for _, finding := range findings {
+		prompt.WriteString(fmt.Sprintf("\nQ: %s\n%s\n", finding.Query, finding.Answer))
+	}
+
+	prompt.WriteString("\n**TASK:**\n")
+	prompt.WriteString("1. Open with a 2-3 sentence overview paragraph that a reader could use as a refresher on the topic\n")
+	if len(r.sections) > 0 {
+		headings := make([]string, len(r.sections))
+		for i, section := range r.sections {
+			headings[i] = fmt.Sprintf("\"## %s\"", section)
+		}
+		prompt.WriteString(fmt.Sprintf("2. Then these sections, in this order: %s. Give each 2-6 bullets, citing sources as [n] using the numbers above\n", strings.Join(headings, ", ")))
+		prompt.WriteString("3. Keep every section, even when the findings say little about it; then write one bullet saying what is unknown\n")
+	} else {
+		prompt.WriteString("2. Then a \"## Key Findings\" section of 4-8 bullets, citing sources as [n] using the numbers above\n")
+		prompt.WriteString("3. Then a \"## Open Questions\" section of 2-4 bullets on what remains unclear or contested\n")
+	}
+	prompt.WriteString("4. Use only the findings above; do not invent facts or sources. Stay under 600 words. Markdown only, no title\n")
+	prompt.WriteString("5. Prefer papers and docs for technical claims; attribute claims that rest on a single blog post\n")
+	prompt.WriteString("6. When citing a paper, name its authors and year in the sentence (e.g. \"Malkov et al. (2018) show...\")\n")
+
+	response, err := r.generator.GenerateText(ctx, prompt.String(), llm.TextGenerationOptions{
+		Temperature: 0.3,
+		MaxTokens:   2048,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to synthesize research brief: %w", err)
+	}
+	return strings.TrimSpace(response), nil
+}
+
+// collectResults turns the sources behind each finding into research results, in
+// the order they were found
+func collectResults(findings []Finding, foundAt time.Time) []core.ResearchResult {
+	var results []core.ResearchResult
+	for _, finding := range findings {
+		for _, source := range finding.Sources {
+			title := source.Title
+			if title == "" {
+				title = source.URL
+			}
+			snippet := source.Snippet
+			if snippet == "" {
+				snippet = finding.Answer
+			}
+			if len(snippet) > maxSnippetLength {
+				snippet = snippet[:maxSnippetLength] + "..."
+			}
+			result := core.ResearchResult{
+				ID:        fmt.Sprintf("r%d", len(results)+1),
+				Title:     title,
+				URL:       source.URL,
+				Snippet:   snippet,
+				Source:    source.Source,
+				DateFound: foundAt,
+				Keywords:  []string{finding.Query},
+			}
+			if paper := source.Paper; paper != nil {
+				result.Authors = paper.Authors
+				result.PublishedDate = paper.Published
+				result.Venue = paper.Venue
+				result.CitationCount = max(paper.Citations, 0)
+			}
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// parseNumberedList returns the items of a "1. item" or "- item" list
+func parseNumberedList(text string) []string {
+	var items []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimLeft(line, "-*• ")
+		if dot := strings.IndexAny(line, ".)"); dot > 0 && dot <= 3 && strings.Trim(line[:dot], "0123456789") == "" {
+			line = line[dot+1:]
+		}
+		line = strings.Trim(strings.TrimSpace(line), `"`)
+		if line != "" {
+			items = append(items, line)
+		}
+	}
+	return items
+}
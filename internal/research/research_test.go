@@ -0,0 +1,165 @@
+package research
+
+import (
+	"briefly/internal/llm"
+	"briefly/internal/search"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeSearcher struct {
+	fail  map[string]bool
+	calls int
+}
+
+func (f *fakeSearcher) SearchGrounded(ctx context.Context, prompt string) (string, []llm.GroundingSource, error) {
+	for query := range f.fail {
+		if strings.Contains(prompt, query) {
+			return "", nil, fmt.Errorf("quota exceeded")
+		}
+	}
+	f.calls++
+	return "Answer with facts.", []llm.GroundingSource{
+		{Title: "Source", URL: fmt.Sprintf("https://example.com/%d", f.calls)},
+	}, nil
+}
+
+type fakeGenerator struct {
+	prompts []string
+}
+
+func (f *fakeGenerator) GenerateText(ctx context.Context, prompt string, options llm.TextGenerationOptions) (string, error) {
+	f.prompts = append(f.prompts, prompt)
+	if strings.Contains(prompt, "Return the queries") {
+		return "1. first query\n2) second query\n- third query\n4. fourth query", nil
+	}
+	return "Overview sentence one [1]. Sentence two.\n\n## Key Findings\n- finding [2]", nil
+}
+
+func TestResearcher_Run(t *testing.T) {
+	generator := &fakeGenerator{}
+	researcher := NewResearcher(&fakeSearcher{fail: map[string]bool{"second query": true}}, generator)
+	researcher.SetMaxQueries(3)
+
+	report, err := researcher.Run(context.Background(), "vector databases")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := []string{"first query", "second query", "third query"}
+	if strings.Join(report.GeneratedQueries, "|") != strings.Join(want, "|") {
+		t.Errorf("GeneratedQueries = %v, want %v", report.GeneratedQueries, want)
+	}
+	if len(report.Results) != 2 || report.TotalResults != 2 {
+		t.Errorf("expected results from the two successful searches, got %+v", report.Results)
+	}
+	if !strings.HasPrefix(report.Summary, "Overview sentence one") {
+		t.Errorf("unexpected summary %q", report.Summary)
+	}
+
+	synthesis := generator.prompts[len(generator.prompts)-1]
+	if !strings.Contains(synthesis, "[1] Source") || strings.Contains(synthesis, "Q: second query") {
+		t.Errorf("expected numbered sources and only successful findings in synthesis prompt:\n%s", synthesis)
+	}
+}
+
+func TestResearcher_RunAllSearchesFail(t *testing.T) {
+	researcher := NewResearcher(&fakeSearcher{fail: map[string]bool{"query": true}}, &fakeGenerator{})
+	if _, err := researcher.Run(context.Background(), "topic"); err == nil {
+		t.Fatal("expected an error when every search fails")
+	}
+}
+
+type quotaProvider struct {
+	left int
+}
+
+func (p *quotaProvider) Name() string                { return "fake" }
+func (p *quotaProvider) CallsFor(maxResults int) int { return 1 }
+
+func (p *quotaProvider) Search(ctx context.Context, query string, maxResults int) ([]search.Result, error) {
+	if p.left == 0 {
+		return nil, fmt.Errorf("fake: %w", search.ErrQuotaExhausted)
+	}
+	p.left--
+	return []search.Result{{Title: query, URL: "https://example.com/" + query, Snippet: "snippet", Source: "fake"}}, nil
+}
+
+func TestResearcher_RunWithProviderStopsAtQuota(t *testing.T) {
+	provider := &quotaProvider{left: 2}
+	researcher := NewResearcher(&fakeSearcher{}, &fakeGenerator{})
+	researcher.SetMaxQueries(4)
+	researcher.AddProvider(provider, 5)
+	researcher.SetGrounding(false)
+
+	report, err := researcher.Run(context.Background(), "topic")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Results) != 2 || report.Results[0].Source != "fake" || report.Results[0].Snippet != "snippet" {
+		t.Errorf("expected results from the searches before the quota ran out, got %+v", report.Results)
+	}
+	if provider.left != 0 {
+		t.Errorf("expected the quota to be used, %d left", provider.left)
+	}
+}
+
+func TestParseNumberedList(t *testing.T) {
+	items := parseNumberedList("Here you go:\n\n1. \"alpha\"\n10. beta\n* gamma 2.0\n")
+	want := []string{"Here you go:", "alpha", "beta", "gamma 2.0"}
+	if strings.Join(items, "|") != strings.Join(want, "|") {
+		t.Errorf("parseNumberedList = %q, want %q", items, want)
+	}
+}
+
+type paperProvider struct {
+	source    string
+	citations int
+}
+
+func (p *paperProvider) Name() string                { return p.source }
+func (p *paperProvider) CallsFor(maxResults int) int { return 1 }
+
+func (p *paperProvider) Search(ctx context.Context, query string, maxResults int) ([]search.Result, error) {
+	return []search.Result{{
+		Title:   "Efficient and robust ANN search",
+		URL:     "https://arxiv.org/abs/1603.09320",
+		Snippet: "We present HNSW.",
+		Source:  p.source,
+		Paper: &search.Paper{
+			Authors:   []string{"Yury Malkov", "Dmitry Yashunin"},
+			Published: time.Date(2016, time.March, 30, 0, 0, 0, 0, time.UTC),
+			Citations: p.citations,
+		},
+	}}, nil
+}
+
+func TestResearcher_RunWithPaperProviders(t *testing.T) {
+	generator := &fakeGenerator{}
+	researcher := NewResearcher(&fakeSearcher{}, generator)
+	researcher.SetMaxQueries(1)
+	researcher.AddProvider(&paperProvider{source: "arxiv", citations: -1}, 5)
+	researcher.AddProvider(&paperProvider{source: "semanticscholar", citations: 1200}, 5)
+
+	report, err := researcher.Run(context.Background(), "hnsw")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// One grounded source plus the paper, which both indexes found
+	if len(report.Results) != 2 {
+		t.Fatalf("expected the grounded source and one paper, got %+v", report.Results)
+	}
+	paper := report.Results[1]
+	if paper.Source != "arxiv" || paper.CitationCount != 1200 || len(paper.Authors) != 2 || paper.Kind != KindPaper {
+		t.Errorf("expected the arXiv result with Semantic Scholar's citation count, got %+v", paper)
+	}
+
+	synthesis := generator.prompts[len(generator.prompts)-1]
+	if !strings.Contains(synthesis, "(Malkov & Yashunin, 2016; 1200 citations)") || !strings.Contains(synthesis, "— 1200 citations") {
+		t.Errorf("expected paper metadata in the synthesis prompt:\n%s", synthesis)
+	}
+}
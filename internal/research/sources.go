@@ -0,0 +1,211 @@
+package research
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/parser"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxPerDomain is how many of a brief's sources may come from one domain
+const DefaultMaxPerDomain = 3
+
+// groundingRedirectHost serves the redirect URLs search grounding cites; the real
+// domain is in the source title
+const groundingRedirectHost = "vertexaisearch.cloud.google.com"
+
+// Source kinds for a brief's source mix
+const (
+	KindNews  = "news"
+	KindDocs  = "docs"
+	KindBlog  = "blog"
+	KindPaper = "paper"
+	KindOther = "other"
+)
+
+// sourceKinds lists source kinds in the order a source mix is reported
+var sourceKinds = []string{KindNews, KindDocs, KindBlog, KindPaper, KindOther}
+
+var (
+	paperDomains = []string{"arxiv.org", "semanticscholar.org", "acm.org", "ieee.org", "openreview.net", "aclanthology.org",
+		"nature.com", "science.org", "sciencedirect.com", "springer.com", "biorxiv.org", "ssrn.com", "researchgate.net", "pnas.org"}
+	docsDomains = []string{"readthedocs.io", "github.com", "gitlab.com", "pkg.go.dev", "developer.mozilla.org", "learn.microsoft.com"}
+	blogDomains = []string{"medium.com", "substack.com", "dev.to", "hashnode.dev", "blogspot.com", "wordpress.com", "ghost.io"}
+	newsDomains = []string{"techcrunch.com", "theverge.com", "arstechnica.com", "reuters.com", "bloomberg.com", "nytimes.com",
+		"wsj.com", "wired.com", "zdnet.com", "venturebeat.com", "theinformation.com", "cnbc.com", "bbc.com", "bbc.co.uk",
+		"ft.com", "theregister.com", "axios.com", "engadget.com", "businessinsider.com", "forbes.com", "infoq.com", "semafor.com"}
+)
+
+// SourceFilter reports what selecting a brief's sources dropped
+type SourceFilter struct {
+	Duplicates int // Same URL found by more than one sub-query
+	OverCap    int // Beyond the per-domain cap
+}
+
+// selectSources drops duplicate URLs across sub-queries and caps how many results
+// come from one domain (maxPerDomain <= 0 = no cap), keeping first-found order. A
+// paper found on both arXiv and Semantic Scholar keeps the metadata of both.
+func selectSources(results []core.ResearchResult, maxPerDomain int) ([]core.ResearchResult, SourceFilter) {
+	urlParser := parser.NewParser()
+	seen := make(map[string]int) // Normalized URL -> index in kept, or -1 if over the cap
+	perDomain := make(map[string]int)
+
+	var filter SourceFilter
+	kept := make([]core.ResearchResult, 0, len(results))
+	for _, result := range results {
+		normalized := strings.Replace(strings.ToLower(urlParser.NormalizeURL(result.URL)), "://www.", "://", 1)
+		if idx, ok := seen[normalized]; ok {
+			filter.Duplicates++
+			if idx >= 0 {
+				mergePaperMetadata(&kept[idx], result)
+			}
+			continue
+		}
+		seen[normalized] = -1
+
+		domain := sourceDomain(result)
+		if maxPerDomain > 0 && perDomain[domain] >= maxPerDomain {
+			filter.OverCap++
+			continue
+		}
+		perDomain[domain]++
+
+		result.ID = fmt.Sprintf("r%d", len(kept)+1)
+		result.Kind = classifySource(domain, result.URL)
+		seen[normalized] = len(kept)
+		kept = append(kept, result)
+	}
+	return kept, filter
+}
+
+// mergePaperMetadata fills the paper metadata kept is missing from duplicate
+func mergePaperMetadata(kept *core.ResearchResult, duplicate core.ResearchResult) {
+	if len(kept.Authors) == 0 {
+		kept.Authors = duplicate.Authors
+	}
+	if kept.PublishedDate.IsZero() {
+		kept.PublishedDate = duplicate.PublishedDate
+	}
+	if kept.Venue == "" {
+		kept.Venue = duplicate.Venue
+	}
+	if kept.CitationCount == 0 {
+		kept.CitationCount = duplicate.CitationCount
+	}
+}
+
+// sourceDomain returns a result's domain without "www.". Grounded results cite a
+// redirect URL, so their domain comes from the title, which grounding sets to it.
+func sourceDomain(result core.ResearchResult) string {
+	host := ""
+	if parsed, err := url.Parse(result.URL); err == nil {
+		host = strings.ToLower(parsed.Hostname())
+	}
+	if host == groundingRedirectHost {
+		title := strings.ToLower(strings.TrimSpace(result.Title))
+		if strings.Contains(title, ".") && !strings.Contains(title, " ") {
+			host = title
+		}
+	}
+	return strings.TrimPrefix(host, "www.")
+}
+
+// classifySource guesses whether a source is news, docs, a blog, or a paper from its
+// domain and path
+func classifySource(domain, rawURL string) string {
+	path := ""
+	if parsed, err := url.Parse(rawURL); err == nil && !strings.EqualFold(parsed.Hostname(), groundingRedirectHost) {
+		path = strings.ToLower(parsed.Path)
+	}
+
+	switch {
+	case matchesDomain(domain, paperDomains) || strings.HasSuffix(path, ".pdf"):
+		return KindPaper
+	case matchesDomain(domain, docsDomains) || hasAnyPrefix(domain, "docs.", "developer.", "developers.", "learn.") ||
+		strings.Contains(path, "/docs/") || strings.Contains(path, "/documentation/") || strings.Contains(path, "/reference/"):
+		return KindDocs
+	case matchesDomain(domain, blogDomains) || strings.HasPrefix(domain, "blog.") || strings.Contains(path, "/blog"):
+		return KindBlog
+	case matchesDomain(domain, newsDomains) || strings.HasPrefix(domain, "news.") || strings.Contains(path, "/news/"):
+		return KindNews
+	default:
+		return KindOther
+	}
+}
+
+// matchesDomain reports whether domain is one of domains or a subdomain of one
+func matchesDomain(domain string, domains []string) bool {
+	for _, d := range domains {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceMix counts a brief's sources by kind
+func SourceMix(results []core.ResearchResult) map[string]int {
+	mix := make(map[string]int)
+	for _, result := range results {
+		kind := result.Kind
+		if kind == "" {
+			kind = classifySource(sourceDomain(result), result.URL)
+		}
+		mix[kind]++
+	}
+	return mix
+}
+
+// FormatSourceMix renders a source mix as "4 news · 3 docs · 1 paper"
+func FormatSourceMix(mix map[string]int) string {
+	var parts []string
+	for _, kind := range sourceKinds {
+		if count := mix[kind]; count > 0 {
+			label := kind
+			if count > 1 && kind != KindNews && kind != KindDocs && kind != KindOther {
+				label += "s"
+			}
+			parts = append(parts, fmt.Sprintf("%d %s", count, label))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// topDomains returns up to n domains with the most sources, most first
+func topDomains(results []core.ResearchResult, n int) []string {
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[sourceDomain(result)]++
+	}
+
+	domains := make([]string, 0, len(counts))
+	for domain := range counts {
+		if domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if counts[domains[i]] != counts[domains[j]] {
+			return counts[domains[i]] > counts[domains[j]]
+		}
+		return domains[i] < domains[j]
+	})
+	if len(domains) > n {
+		domains = domains[:n]
+	}
+	for i, domain := range domains {
+		domains[i] = fmt.Sprintf("%s (%d)", domain, counts[domain])
+	}
+	return domains
+}
@@ -0,0 +1,80 @@
+package research
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+)
+
+func TestSelectSources_DedupAndDomainCap(t *testing.T) {
+	results := []core.ResearchResult{
+		{Title: "A1", URL: "https://www.example.com/a?utm_source=x"},
+		{Title: "A1 again", URL: "https://example.com/a/"},
+		{Title: "A1 fragment", URL: "https://www.example.com/a#intro"},
+		{Title: "A2", URL: "https://example.com/b"},
+		{Title: "A3", URL: "https://blog.example.com/c"},
+		{Title: "A4", URL: "https://example.com/d"},
+		{Title: "Paper", URL: "https://arxiv.org/abs/2401.00001"},
+	}
+
+	kept, filter := selectSources(results, 2)
+	if filter.Duplicates != 2 || filter.OverCap != 1 {
+		t.Errorf("expected 2 duplicates and 1 over the cap, got %+v", filter)
+	}
+
+	var titles []string
+	for _, result := range kept {
+		titles = append(titles, result.Title)
+	}
+	if got := strings.Join(titles, ","); got != "A1,A2,A3,Paper" {
+		t.Errorf("kept %s, want A1,A2,A3,Paper (subdomains count separately)", got)
+	}
+	if kept[3].ID != "r4" || kept[3].Kind != KindPaper || kept[2].Kind != KindBlog {
+		t.Errorf("expected renumbered, classified results, got %+v", kept)
+	}
+
+	if uncapped, _ := selectSources(results, 0); len(uncapped) != 5 {
+		t.Errorf("expected no cap with maxPerDomain 0, kept %d", len(uncapped))
+	}
+}
+
+func TestSelectSources_GroundedDomains(t *testing.T) {
+	redirect := "https://vertexaisearch.cloud.google.com/grounding-api-redirect/"
+	results := []core.ResearchResult{
+		{Title: "techcrunch.com", URL: redirect + "1"},
+		{Title: "techcrunch.com", URL: redirect + "2"},
+		{Title: "arxiv.org", URL: redirect + "3"},
+	}
+
+	kept, filter := selectSources(results, 1)
+	if len(kept) != 2 || filter.OverCap != 1 {
+		t.Fatalf("expected grounded sources capped by their real domain, got %+v", kept)
+	}
+	if kept[0].Kind != KindNews || kept[1].Kind != KindPaper {
+		t.Errorf("unexpected kinds: %q, %q", kept[0].Kind, kept[1].Kind)
+	}
+}
+
+func TestClassifySource(t *testing.T) {
+	cases := map[string]string{
+		"https://docs.python.org/3/library/asyncio.html": KindDocs,
+		"https://github.com/temporalio/temporal":         KindDocs,
+		"https://eng.uber.com/blog/cadence":              KindBlog,
+		"https://someone.substack.com/p/post":            KindBlog,
+		"https://www.reuters.com/technology/ai":          KindNews,
+		"https://example.edu/papers/study.pdf":           KindPaper,
+		"https://example.com/about":                      KindOther,
+	}
+	for rawURL, want := range cases {
+		if got := classifySource(sourceDomain(core.ResearchResult{URL: rawURL}), rawURL); got != want {
+			t.Errorf("classifySource(%s) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
+
+func TestFormatSourceMix(t *testing.T) {
+	mix := map[string]int{KindPaper: 2, KindNews: 3, KindBlog: 1}
+	if got := FormatSourceMix(mix); got != "3 news · 1 blog · 2 papers" {
+		t.Errorf("FormatSourceMix = %q", got)
+	}
+}
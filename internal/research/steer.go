@@ -0,0 +1,188 @@
+package research
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CommandKind is what a steering command asks a running research to do
+type CommandKind int
+
+const (
+	// CommandSkip skips the running sub-query, or the queued one at Index
+	CommandSkip CommandKind = iota
+	// CommandAdd queues Query after the planned sub-queries
+	CommandAdd
+	// CommandStop stops searching and synthesizes what has been gathered
+	CommandStop
+	// CommandList prints the sub-queries and where the run is
+	CommandList
+)
+
+// Command steers a research run while it searches
+type Command struct {
+	Kind  CommandKind
+	Query string // For CommandAdd
+	Index int    // For CommandSkip: 1-based sub-query number; 0 = the running one
+}
+
+// SteeringHelp describes the commands ParseCommand accepts
+const SteeringHelp = "skip [n] · add <query> · stop · list"
+
+// ParseCommand parses a line typed during an interactive run: "skip" or "s" skips
+// the running sub-query and "skip 3" a queued one, "add <query>" or "a <query>"
+// queues a query, "stop" or "q" synthesizes now, and "list" or "l" shows the queue
+func ParseCommand(line string) (Command, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return Command{}, fmt.Errorf("empty command (%s)", SteeringHelp)
+	}
+	rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), fields[0]))
+
+	switch strings.ToLower(fields[0]) {
+	case "skip", "s":
+		if rest == "" {
+			return Command{Kind: CommandSkip}, nil
+		}
+		n, err := strconv.Atoi(rest)
+		if err != nil || n < 1 {
+			return Command{}, fmt.Errorf("skip takes a sub-query number, got %q", rest)
+		}
+		return Command{Kind: CommandSkip, Index: n}, nil
+	case "add", "a":
+		if rest == "" {
+			return Command{}, fmt.Errorf("add needs a query")
+		}
+		return Command{Kind: CommandAdd, Query: rest}, nil
+	case "stop", "q", "quit", "done":
+		return Command{Kind: CommandStop}, nil
+	case "list", "l", "ls":
+		return Command{Kind: CommandList}, nil
+	default:
+		return Command{}, fmt.Errorf("unknown command %q (%s)", fields[0], SteeringHelp)
+	}
+}
+
+// SetCommands lets the run be steered by commands received on commands: sub-queries
+// can be skipped, added, or cut short. A closed channel stops steering.
+func (r *Researcher) SetCommands(commands <-chan Command) {
+	r.commands = commands
+}
+
+// queryQueue is a run's sub-queries as they are searched, skipped, and added
+type queryQueue struct {
+	queries []string
+	skipped map[int]bool
+	current int // Index of the running sub-query
+	stopped bool
+}
+
+func newQueryQueue(queries []string) *queryQueue {
+	return &queryQueue{queries: append([]string(nil), queries...), skipped: make(map[int]bool)}
+}
+
+// apply carries out a command and reports whether the running sub-query should be
+// abandoned, because it was skipped or the run was stopped
+func (q *queryQueue) apply(cmd Command) bool {
+	switch cmd.Kind {
+	case CommandSkip:
+		i := cmd.Index - 1
+		if cmd.Index == 0 || i == q.current {
+			fmt.Printf("           ⏭ Skipping: %s\n", q.queries[q.current])
+			q.skipped[q.current] = true
+			return true
+		}
+		switch {
+		case i >= len(q.queries):
+			fmt.Printf("           ⚠ No sub-query %d (there are %d)\n", cmd.Index, len(q.queries))
+		case i < q.current:
+			fmt.Printf("           ⚠ Sub-query %d already ran\n", cmd.Index)
+		default:
+			q.skipped[i] = true
+			fmt.Printf("           ⏭ Will skip [%d]: %s\n", cmd.Index, q.queries[i])
+		}
+	case CommandAdd:
+		q.queries = append(q.queries, cmd.Query)
+		fmt.Printf("           ➕ Queued [%d]: %s\n", len(q.queries), cmd.Query)
+	case CommandStop:
+		fmt.Printf("           ⏹ Stopping early; synthesizing what has been gathered\n")
+		q.stopped = true
+		return true
+	case CommandList:
+		for i, query := range q.queries {
+			status := "queued"
+			switch {
+			case q.skipped[i]:
+				status = "skipped"
+			case i < q.current:
+				status = "done"
+			case i == q.current:
+				status = "running"
+			}
+			fmt.Printf("           [%d] %-7s %s\n", i+1, status, query)
+		}
+	}
+	return false
+}
+
+// drain applies commands that arrived between sub-queries
+func (r *Researcher) drain(queue *queryQueue) {
+	for r.commands != nil {
+		select {
+		case cmd, ok := <-r.commands:
+			if !ok {
+				r.commands = nil
+				return
+			}
+			if queue.apply(cmd) && !queue.stopped {
+				// Nothing is running between sub-queries; skip the next one instead
+				queue.skipped[queue.current] = true
+			}
+		default:
+			return
+		}
+	}
+}
+
+// searchSteered searches the current sub-query while applying commands as they
+// arrive. It reports abandoned when the sub-query was skipped or the run stopped,
+// in which case the search is cancelled and its result dropped.
+func (r *Researcher) searchSteered(ctx context.Context, topic string, queue *queryQueue) (finding *Finding, abandoned bool, err error) {
+	query := queue.queries[queue.current]
+	if r.commands == nil {
+		finding, err := r.search(ctx, topic, query)
+		return finding, false, err
+	}
+
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		finding *Finding
+		err     error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		finding, err := r.search(searchCtx, topic, query)
+		done <- outcome{finding, err}
+	}()
+
+	for {
+		select {
+		case out := <-done:
+			return out.finding, false, out.err
+		case cmd, ok := <-r.commands:
+			if !ok {
+				r.commands = nil // A nil channel blocks, so the select waits on the search alone
+				continue
+			}
+			if queue.apply(cmd) {
+				cancel()
+				<-done
+				return nil, true, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package research
+
+import (
+	"briefly/internal/llm"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    Command
+		wantErr bool
+	}{
+		{line: "skip", want: Command{Kind: CommandSkip}},
+		{line: "s 3", want: Command{Kind: CommandSkip, Index: 3}},
+		{line: "add  HNSW vs IVF recall ", want: Command{Kind: CommandAdd, Query: "HNSW vs IVF recall"}},
+		{line: "q", want: Command{Kind: CommandStop}},
+		{line: "LIST", want: Command{Kind: CommandList}},
+		{line: "skip two", wantErr: true},
+		{line: "add", wantErr: true},
+		{line: "rewind", wantErr: true},
+		{line: "  ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCommand(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCommand(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseCommand(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+// steeredSearcher blocks on queries containing "slow" until the search is
+// cancelled, and reports each query it starts
+type steeredSearcher struct {
+	started chan string
+}
+
+func (s *steeredSearcher) SearchGrounded(ctx context.Context, prompt string) (string, []llm.GroundingSource, error) {
+	query := strings.TrimSpace(prompt[strings.Index(prompt, "question:")+len("question:") : strings.Index(prompt, "\n\nReport")])
+	s.started <- query
+	if strings.Contains(query, "slow") {
+		<-ctx.Done()
+		return "", nil, ctx.Err()
+	}
+	return "Answer.", []llm.GroundingSource{{Title: query, URL: "https://example.com/" + strings.ReplaceAll(query, " ", "-")}}, nil
+}
+
+type plannedGenerator struct {
+	plan string
+}
+
+func (g *plannedGenerator) GenerateText(ctx context.Context, prompt string, options llm.TextGenerationOptions) (string, error) {
+	if strings.Contains(prompt, "Return the queries") {
+		return g.plan, nil
+	}
+	return "Brief [1].", nil
+}
+
+func TestResearcher_RunSteered(t *testing.T) {
+	searcher := &steeredSearcher{started: make(chan string, 10)}
+	researcher := NewResearcher(searcher, &plannedGenerator{plan: "1. slow one\n2. fast two\n3. fast three"})
+	commands := make(chan Command, 10)
+	researcher.SetCommands(commands)
+
+	// Skip the stuck first query, drop the third, and add one of our own
+	go func() {
+		<-searcher.started
+		commands <- Command{Kind: CommandSkip, Index: 3}
+		commands <- Command{Kind: CommandAdd, Query: "manual four"}
+		commands <- Command{Kind: CommandSkip}
+	}()
+
+	report, err := researcher.Run(context.Background(), "topic")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := strings.Join(report.GeneratedQueries, "|"); got != "fast two|manual four" {
+		t.Errorf("GeneratedQueries = %s, want the searched queries only", got)
+	}
+	if len(report.Results) != 2 || report.Results[1].Title != "manual four" {
+		t.Errorf("unexpected results %+v", report.Results)
+	}
+}
+
+func TestResearcher_RunStoppedEarly(t *testing.T) {
+	searcher := &steeredSearcher{started: make(chan string, 10)}
+	researcher := NewResearcher(searcher, &plannedGenerator{plan: "1. fast one\n2. slow two\n3. fast three"})
+	commands := make(chan Command, 10)
+	researcher.SetCommands(commands)
+
+	go func() {
+		for query := range searcher.started {
+			if strings.Contains(query, "slow") {
+				commands <- Command{Kind: CommandStop}
+				return
+			}
+		}
+	}()
+
+	report, err := researcher.Run(context.Background(), "topic")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].Title != "fast one" {
+		t.Errorf("expected a brief from what was gathered before stopping, got %+v", report.Results)
+	}
+
+	// Stopping before anything is found fails the run
+	researcher = NewResearcher(searcher, &plannedGenerator{plan: "1. slow one"})
+	commands = make(chan Command, 1)
+	commands <- Command{Kind: CommandStop}
+	researcher.SetCommands(commands)
+	if _, err := researcher.Run(context.Background(), "topic"); err == nil {
+		t.Fatal("expected an error when stopped with nothing gathered")
+	}
+}
+
+func TestQueryQueueApply(t *testing.T) {
+	queue := newQueryQueue([]string{"a", "b", "c"})
+	queue.current = 1
+
+	if queue.apply(Command{Kind: CommandSkip, Index: 1}) || queue.skipped[0] {
+		t.Error("expected a sub-query that already ran not to be skipped")
+	}
+	if queue.apply(Command{Kind: CommandSkip, Index: 9}) {
+		t.Error("expected an unknown sub-query to be ignored")
+	}
+	if !queue.apply(Command{Kind: CommandSkip, Index: 2}) || !queue.skipped[1] {
+		t.Error("expected skipping the running sub-query by number to abandon it")
+	}
+	queue.apply(Command{Kind: CommandList})
+	if !queue.apply(Command{Kind: CommandStop}) || !queue.stopped {
+		t.Error("expected stop to abandon the running sub-query")
+	}
+}
@@ -0,0 +1,121 @@
+package research
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// TemplateOptions configures a research template
+type TemplateOptions struct {
+	Description string
+	Topic       string   // text/template over TemplateData; defaults to the topic as given
+	SinceDays   int      // Only cite sources from the last N days (0 = any time)
+	MaxQueries  int      // Sub-queries to plan (0 = the run's default)
+	MaxSources  int      // Most sources the brief cites (0 = no cap)
+	Providers   string   // Comma list of providers (empty = the run's default)
+	Focus       []string // Areas the sub-queries must cover
+	Sections    []string // Headings the brief is written under
+	Export      string   // Comma list of export formats (docx, pdf)
+}
+
+// Template is a named research template, so recurring briefs (vendor evaluations,
+// incident reviews) share the same scope, sources, and structure every time
+type Template struct {
+	Name        string
+	Description string
+	SinceDays   int
+	MaxQueries  int
+	MaxSources  int
+	Providers   string
+	Focus       []string
+	Sections    []string
+	Export      string
+
+	topic *template.Template
+}
+
+// TemplateData is the data available to a template's topic pattern
+type TemplateData struct {
+	Topic string // Topic given on the command line
+	Date  string // Run date (YYYY-MM-DD)
+}
+
+// NewTemplate creates a research template. Names are lowercase letters, digits, and
+// hyphens, like series keys.
+func NewTemplate(name string, opts TemplateOptions) (*Template, error) {
+	if !templateNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid research template name %q (use lowercase letters, digits, and hyphens)", name)
+	}
+	if opts.SinceDays < 0 || opts.MaxQueries < 0 || opts.MaxSources < 0 {
+		return nil, fmt.Errorf("research template %s: since_days, max_queries, and max_sources must not be negative", name)
+	}
+
+	pattern := opts.Topic
+	if pattern == "" {
+		pattern = "{{.Topic}}"
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid topic pattern for research template %s: %w", name, err)
+	}
+
+	return &Template{
+		Name:        name,
+		Description: opts.Description,
+		SinceDays:   opts.SinceDays,
+		MaxQueries:  opts.MaxQueries,
+		MaxSources:  opts.MaxSources,
+		Providers:   opts.Providers,
+		Focus:       trimAll(opts.Focus),
+		Sections:    trimAll(opts.Sections),
+		Export:      opts.Export,
+		topic:       tmpl,
+	}, nil
+}
+
+// Topic renders the template's topic pattern around the topic given. If the pattern
+// fails or renders empty, the topic is returned unchanged.
+func (t *Template) Topic(topic string, now time.Time) string {
+	var buf bytes.Buffer
+	if err := t.topic.Execute(&buf, TemplateData{Topic: topic, Date: now.Format("2006-01-02")}); err != nil {
+		return topic
+	}
+	if rendered := strings.TrimSpace(buf.String()); rendered != "" {
+		return rendered
+	}
+	return topic
+}
+
+// Since is the earliest publication date the template cites, or zero for any time
+func (t *Template) Since(now time.Time) time.Time {
+	if t.SinceDays <= 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, -t.SinceDays)
+}
+
+// Apply sets the template's source limits, focus areas, and sections on a researcher.
+// Query counts and providers are left to the caller, which fits them to quotas.
+func (t *Template) Apply(r *Researcher, now time.Time) {
+	r.SetSince(t.Since(now))
+	r.SetMaxSources(t.MaxSources)
+	r.SetFocus(t.Focus)
+	r.SetSections(t.Sections)
+}
+
+// trimAll trims each entry and drops blanks
+func trimAll(values []string) []string {
+	var trimmed []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,98 @@
+package research
+
+import (
+	"briefly/internal/core"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTemplate(t *testing.T) {
+	if _, err := NewTemplate("Vendor Eval", TemplateOptions{}); err == nil {
+		t.Error("expected an invalid name to fail")
+	}
+	if _, err := NewTemplate("vendor-eval", TemplateOptions{Topic: "{{.Topic"}); err == nil {
+		t.Error("expected an invalid topic pattern to fail")
+	}
+	if _, err := NewTemplate("vendor-eval", TemplateOptions{MaxSources: -1}); err == nil {
+		t.Error("expected a negative max_sources to fail")
+	}
+
+	tmpl, err := NewTemplate("vendor-eval", TemplateOptions{
+		Topic:     "{{.Topic}} as a vendor: pricing, reliability, and lock-in",
+		SinceDays: 90,
+		Sections:  []string{" Summary ", "", "Pricing"},
+	})
+	if err != nil {
+		t.Fatalf("NewTemplate failed: %v", err)
+	}
+
+	now := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	if got := tmpl.Topic("Temporal.io", now); got != "Temporal.io as a vendor: pricing, reliability, and lock-in" {
+		t.Errorf("Topic = %q", got)
+	}
+	if got := tmpl.Since(now); !got.Equal(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Since = %v", got)
+	}
+	if strings.Join(tmpl.Sections, "|") != "Summary|Pricing" {
+		t.Errorf("Sections = %v", tmpl.Sections)
+	}
+
+	plain, _ := NewTemplate("plain", TemplateOptions{})
+	if got := plain.Topic("Temporal.io", now); got != "Temporal.io" {
+		t.Errorf("default Topic = %q", got)
+	}
+	if !plain.Since(now).IsZero() {
+		t.Error("expected no since date without since_days")
+	}
+}
+
+func TestResearcher_RunWithTemplate(t *testing.T) {
+	tmpl, err := NewTemplate("vendor-eval", TemplateOptions{
+		MaxSources: 2,
+		Focus:      []string{"pricing", "operational maturity"},
+		Sections:   []string{"Verdict", "Risks"},
+	})
+	if err != nil {
+		t.Fatalf("NewTemplate failed: %v", err)
+	}
+
+	generator := &fakeGenerator{}
+	researcher := NewResearcher(&fakeSearcher{}, generator)
+	researcher.SetMaxQueries(3)
+	tmpl.Apply(researcher, time.Now())
+
+	report, err := researcher.Run(context.Background(), "Temporal.io")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Results) != 2 {
+		t.Errorf("expected sources capped at 2, got %d", len(report.Results))
+	}
+
+	plan, synthesis := generator.prompts[0], generator.prompts[len(generator.prompts)-1]
+	if !strings.Contains(plan, "- pricing\n- operational maturity") {
+		t.Errorf("expected focus areas in the plan prompt:\n%s", plan)
+	}
+	if !strings.Contains(synthesis, `"## Verdict", "## Risks"`) || strings.Contains(synthesis, "Key Findings") {
+		t.Errorf("expected template sections in the synthesis prompt:\n%s", synthesis)
+	}
+}
+
+func TestDropOlderThan(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := []core.ResearchResult{
+		{ID: "r1", URL: "https://arxiv.org/abs/1", PublishedDate: since.AddDate(-1, 0, 0)},
+		{ID: "r2", URL: "https://example.com/undated"},
+		{ID: "r3", URL: "https://arxiv.org/abs/2", PublishedDate: since.AddDate(0, 1, 0)},
+	}
+
+	kept, dropped := dropOlderThan(results, since)
+	if dropped != 1 || len(kept) != 2 {
+		t.Fatalf("kept %d, dropped %d; want 2 and 1", len(kept), dropped)
+	}
+	if kept[0].URL != "https://example.com/undated" || kept[0].ID != "r1" || kept[1].ID != "r2" {
+		t.Errorf("expected undated and recent sources renumbered, got %+v", kept)
+	}
+}
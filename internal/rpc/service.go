@@ -0,0 +1,348 @@
+// Package rpc serves briefly's articles and digests over gRPC, for internal tooling
+// that wants typed access rather than the REST API. The service is defined in
+// brieflyv1/briefly.proto.
+package rpc
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/logger"
+	"briefly/internal/persistence"
+	"briefly/internal/rpc/brieflyv1"
+	"briefly/internal/vectorstore"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Request defaults, matching the CLI's
+const (
+	defaultSearchLimit     = 10
+	defaultSearchThreshold = 0.5
+	defaultListLimit       = 20
+	defaultListDays        = 30
+	defaultGenerateDays    = 7
+	defaultMinArticles     = 3
+	maxLimit               = 200
+)
+
+// Embedder turns a search query into an embedding. *llm.Client implements it.
+type Embedder interface {
+	GenerateEmbeddingContext(ctx context.Context, text string) ([]float64, error)
+}
+
+// GenerateOptions are the settings of one digest generation run
+type GenerateOptions struct {
+	SinceDays   int
+	Theme       string
+	MinArticles int
+	Audience    string
+}
+
+// Generator generates and saves digests, calling progress with each line of output.
+// It returns the IDs of the digests that run saved.
+type Generator interface {
+	Generate(ctx context.Context, opts GenerateOptions, progress func(line string)) ([]string, error)
+}
+
+// Options configures a Service. Search needs Embedder and VectorStore, and
+// GenerateDigest needs Generator; without them those calls fail as unavailable.
+type Options struct {
+	DB          persistence.Database
+	Embedder    Embedder
+	VectorStore vectorstore.VectorStore
+	Generator   Generator
+}
+
+// Service implements brieflyv1.DigestServiceServer
+type Service struct {
+	brieflyv1.UnimplementedDigestServiceServer
+	opts Options
+	log  *slog.Logger
+}
+
+// NewService creates the gRPC digest service
+func NewService(opts Options) *Service {
+	return &Service{opts: opts, log: logger.Get()}
+}
+
+// NewServer returns a gRPC server with the service registered. A non-empty token
+// is required as "authorization: Bearer <token>" metadata on every call.
+func NewServer(service *Service, token string) *grpc.Server {
+	var opts []grpc.ServerOption
+	if token != "" {
+		opts = append(opts,
+			grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := authorize(ctx, token); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}),
+			grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := authorize(ss.Context(), token); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			}),
+		)
+	}
+	server := grpc.NewServer(opts...)
+	brieflyv1.RegisterDigestServiceServer(server, service)
+	return server
+}
+
+// CheckListen refuses to serve without a token on anything but a loopback address,
+// where any network peer could read digests and spend LLM quota on GenerateDigest
+func CheckListen(host, token string) error {
+	if token != "" {
+		return nil
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	listen := host
+	if listen == "" {
+		listen = "all interfaces"
+	}
+	return fmt.Errorf("gRPC would listen on %s without a token: set server.grpc_token (or BRIEFLY_GRPC_TOKEN), or bind server.host to 127.0.0.1", listen)
+}
+
+// authorize checks the bearer token in the call's metadata
+func authorize(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		given := strings.TrimPrefix(value, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid or missing bearer token")
+}
+
+// SearchArticles finds articles semantically similar to a text query
+func (s *Service) SearchArticles(ctx context.Context, req *brieflyv1.SearchArticlesRequest) (*brieflyv1.SearchArticlesResponse, error) {
+	query := strings.TrimSpace(req.GetQuery())
+	if query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	if s.opts.Embedder == nil || s.opts.VectorStore == nil {
+		return nil, status.Error(codes.Unavailable, "search is not configured on this server")
+	}
+	threshold := req.GetThreshold()
+	if threshold < 0 || threshold > 1 {
+		return nil, status.Error(codes.InvalidArgument, "threshold must be between 0 and 1")
+	}
+	if threshold == 0 {
+		threshold = defaultSearchThreshold
+	}
+
+	embedding, err := s.opts.Embedder.GenerateEmbeddingContext(ctx, query)
+	if err != nil {
+		s.log.Error("gRPC search embedding failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to embed query: %v", err)
+	}
+	results, err := s.opts.VectorStore.Search(ctx, vectorstore.SearchQuery{
+		Embedding:           embedding,
+		Limit:               clampLimit(req.GetLimit(), defaultSearchLimit),
+		SimilarityThreshold: threshold,
+		IncludeArticle:      true,
+	})
+	if err != nil {
+		s.log.Error("gRPC search failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "search failed: %v", err)
+	}
+
+	resp := &brieflyv1.SearchArticlesResponse{}
+	for _, result := range results {
+		article := &brieflyv1.Article{Id: result.ArticleID}
+		if result.Article != nil {
+			article = toArticle(*result.Article)
+		}
+		resp.Results = append(resp.Results, &brieflyv1.SearchResult{Article: article, Similarity: result.Similarity})
+	}
+	return resp, nil
+}
+
+// GetDigest returns a digest with its articles; the id "latest" returns the newest
+func (s *Service) GetDigest(ctx context.Context, req *brieflyv1.GetDigestRequest) (*brieflyv1.Digest, error) {
+	id := strings.TrimSpace(req.GetId())
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if id == "latest" {
+		latest, err := s.opts.DB.Digests().GetLatest(ctx, 1)
+		if err != nil {
+			s.log.Error("gRPC latest digest lookup failed", "error", err)
+			return nil, status.Errorf(codes.Internal, "failed to load latest digest: %v", err)
+		}
+		if len(latest) == 0 {
+			return nil, status.Error(codes.NotFound, "no digests yet")
+		}
+		id = latest[0].ID
+	}
+
+	digest, err := s.opts.DB.Digests().Get(ctx, id)
+	if err != nil {
+		s.log.Error("gRPC digest lookup failed", "id", id, "error", err)
+		return nil, status.Errorf(codes.NotFound, "digest %s not found", id)
+	}
+	articles, err := s.opts.DB.Digests().GetDigestArticles(ctx, id)
+	if err != nil {
+		s.log.Error("gRPC digest articles lookup failed", "id", id, "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to load digest articles: %v", err)
+	}
+	digest.Articles = articles
+	return toDigest(*digest), nil
+}
+
+// ListDigests returns recent digests, newest first, without their articles
+func (s *Service) ListDigests(ctx context.Context, req *brieflyv1.ListDigestsRequest) (*brieflyv1.ListDigestsResponse, error) {
+	if req.GetSinceDays() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "since_days must not be negative")
+	}
+	days := int(req.GetSinceDays())
+	if days == 0 {
+		days = defaultListDays
+	}
+
+	digests, err := s.opts.DB.Digests().ListRecent(ctx, time.Now().AddDate(0, 0, -days), clampLimit(req.GetLimit(), defaultListLimit))
+	if err != nil {
+		s.log.Error("gRPC digest list failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list digests: %v", err)
+	}
+
+	resp := &brieflyv1.ListDigestsResponse{}
+	for _, digest := range digests {
+		resp.Digests = append(resp.Digests, toDigest(digest))
+	}
+	return resp, nil
+}
+
+// GenerateDigest runs the generator, streaming its output as progress events, and
+// ends with the digests that run reports saving
+func (s *Service) GenerateDigest(req *brieflyv1.GenerateDigestRequest, stream grpc.ServerStreamingServer[brieflyv1.GenerateDigestEvent]) error {
+	if s.opts.Generator == nil {
+		return status.Error(codes.Unavailable, "digest generation is not configured on this server")
+	}
+	if req.GetSinceDays() < 0 || req.GetMinArticles() < 0 {
+		return status.Error(codes.InvalidArgument, "since_days and min_articles must not be negative")
+	}
+	opts := GenerateOptions{
+		SinceDays:   int(req.GetSinceDays()),
+		Theme:       strings.TrimSpace(req.GetTheme()),
+		MinArticles: int(req.GetMinArticles()),
+		Audience:    strings.TrimSpace(req.GetAudience()),
+	}
+	if opts.SinceDays == 0 {
+		opts.SinceDays = defaultGenerateDays
+	}
+	if opts.MinArticles == 0 {
+		opts.MinArticles = defaultMinArticles
+	}
+
+	ctx := stream.Context()
+
+	// A client that stops reading shouldn't fail the run; later sends are dropped
+	var sendErr error
+	progress := func(line string) {
+		if line = strings.TrimSpace(line); line == "" || sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&brieflyv1.GenerateDigestEvent{
+			Event: &brieflyv1.GenerateDigestEvent_Progress{Progress: line},
+		})
+	}
+	ids, err := s.opts.Generator.Generate(ctx, opts, progress)
+	if err != nil {
+		s.log.Error("gRPC digest generation failed", "error", err)
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Internal, "digest generation failed: %v", err)
+	}
+
+	// Only this run's digests: another run saving at the same time must not show up
+	result := &brieflyv1.GenerateDigestResult{}
+	for _, id := range ids {
+		digest, err := s.opts.DB.Digests().Get(ctx, id)
+		if err != nil {
+			return status.Errorf(codes.Internal, "digest %s generated but could not be loaded: %v", id, err)
+		}
+		result.Digests = append(result.Digests, toDigest(*digest))
+	}
+	return stream.Send(&brieflyv1.GenerateDigestEvent{
+		Event: &brieflyv1.GenerateDigestEvent_Result{Result: result},
+	})
+}
+
+// clampLimit applies the default to an unset limit and caps large ones
+func clampLimit(limit int32, fallback int) int {
+	switch {
+	case limit <= 0:
+		return fallback
+	case limit > maxLimit:
+		return maxLimit
+	default:
+		return int(limit)
+	}
+}
+
+func toArticle(article core.Article) *brieflyv1.Article {
+	out := &brieflyv1.Article{
+		Id:          article.ID,
+		Url:         article.URL,
+		Title:       article.Title,
+		ContentType: string(article.ContentType),
+		Publisher:   article.Publisher,
+		Author:      article.Author,
+	}
+	if !article.DateFetched.IsZero() {
+		out.FetchedAt = timestamppb.New(article.DateFetched)
+	}
+	return out
+}
+
+func toDigest(digest core.Digest) *brieflyv1.Digest {
+	out := &brieflyv1.Digest{
+		Id:              digest.ID,
+		Title:           digest.Title,
+		TldrSummary:     digest.TLDRSummary,
+		Summary:         digest.Summary,
+		TopDevelopments: digest.TopDevelopments,
+		WhyItMatters:    digest.WhyItMatters,
+		ArticleCount:    int32(digest.ArticleCount),
+	}
+	for _, moment := range digest.KeyMoments {
+		out.KeyMoments = append(out.KeyMoments, &brieflyv1.KeyMoment{
+			Quote:            moment.Quote,
+			CitationNumber:   int32(moment.CitationNumber),
+			TimestampSeconds: int32(moment.Timestamp),
+			TimestampUrl:     moment.TimestampURL,
+		})
+	}
+	for _, theme := range digest.Themes {
+		out.Themes = append(out.Themes, theme.Name)
+	}
+	for _, article := range digest.Articles {
+		out.Articles = append(out.Articles, toArticle(article))
+	}
+	if !digest.ProcessedDate.IsZero() {
+		out.ProcessedAt = timestamppb.New(digest.ProcessedDate)
+	}
+	if out.ArticleCount == 0 {
+		out.ArticleCount = int32(len(digest.Articles))
+	}
+	return out
+}
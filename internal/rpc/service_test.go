@@ -0,0 +1,292 @@
+package rpc
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/persistence"
+	"briefly/internal/rpc/brieflyv1"
+	"briefly/internal/vectorstore"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeDB serves digests from memory; other repositories are left nil
+type fakeDB struct {
+	persistence.Database
+	digests *fakeDigests
+}
+
+func (f *fakeDB) Digests() persistence.DigestRepository { return f.digests }
+
+type fakeDigests struct {
+	persistence.DigestRepository
+	digests  []core.Digest // Newest first
+	articles map[string][]core.Article
+}
+
+func (f *fakeDigests) Get(ctx context.Context, id string) (*core.Digest, error) {
+	for _, digest := range f.digests {
+		if digest.ID == id {
+			return &digest, nil
+		}
+	}
+	return nil, errors.New("digest not found")
+}
+
+func (f *fakeDigests) GetLatest(ctx context.Context, limit int) ([]core.Digest, error) {
+	if len(f.digests) < limit {
+		return f.digests, nil
+	}
+	return f.digests[:limit], nil
+}
+
+func (f *fakeDigests) GetDigestArticles(ctx context.Context, digestID string) ([]core.Article, error) {
+	return f.articles[digestID], nil
+}
+
+func (f *fakeDigests) ListRecent(ctx context.Context, since time.Time, limit int) ([]core.Digest, error) {
+	var recent []core.Digest
+	for _, digest := range f.digests {
+		if !digest.ProcessedDate.Before(since) && len(recent) < limit {
+			recent = append(recent, digest)
+		}
+	}
+	return recent, nil
+}
+
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) GenerateEmbeddingContext(ctx context.Context, text string) ([]float64, error) {
+	return []float64{1, 0}, nil
+}
+
+type fakeVectorStore struct {
+	vectorstore.VectorStore
+	query vectorstore.SearchQuery
+}
+
+func (f *fakeVectorStore) Search(ctx context.Context, query vectorstore.SearchQuery) ([]vectorstore.SearchResult, error) {
+	f.query = query
+	return []vectorstore.SearchResult{
+		{ArticleID: "a1", Similarity: 0.91, Article: &core.Article{ID: "a1", URL: "https://example.com/a1", Title: "Agents in production"}},
+	}, nil
+}
+
+// fakeGenerator reports progress and saves a digest, as a real run would, while
+// another run saves one at the same moment
+type fakeGenerator struct {
+	db   *fakeDigests
+	opts GenerateOptions
+	err  error
+}
+
+func (f *fakeGenerator) Generate(ctx context.Context, opts GenerateOptions, progress func(line string)) ([]string, error) {
+	f.opts = opts
+	progress("📊 Clustering 12 articles")
+	progress("   ")
+	progress("💾 Saving digest")
+	if f.err != nil {
+		return nil, f.err
+	}
+	now := time.Now()
+	f.db.digests = append([]core.Digest{
+		{ID: "new", Title: "Fresh digest", ProcessedDate: now, DateGenerated: now},
+		{ID: "concurrent", Title: "Another run's digest", ProcessedDate: now, DateGenerated: now},
+	}, f.db.digests...)
+	return []string{"new"}, nil
+}
+
+// dial serves s over an in-memory connection and returns a client for it
+func dial(t *testing.T, s *Service, token string) brieflyv1.DigestServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(s, token)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return brieflyv1.NewDigestServiceClient(conn)
+}
+
+func newFakeDigests() *fakeDigests {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	return &fakeDigests{
+		digests: []core.Digest{
+			{
+				ID: "d2", Title: "Agents everywhere", TLDRSummary: "Agents ship", Summary: "Agents shipped [[1]](https://example.com/a1)",
+				KeyMoments:    []core.KeyMoment{{Quote: "Ten thousand jobs", CitationNumber: 1, Timestamp: 273, TimestampURL: "https://youtu.be/x?t=273"}},
+				Themes:        []core.Theme{{Name: "AI"}},
+				ProcessedDate: yesterday, DateGenerated: yesterday,
+			},
+			{ID: "d1", Title: "Old news", ProcessedDate: time.Now().AddDate(0, 0, -60)},
+		},
+		articles: map[string][]core.Article{
+			"d2": {{ID: "a1", URL: "https://example.com/a1", Title: "Agents in production", DateFetched: yesterday}},
+		},
+	}
+}
+
+func TestGetDigest(t *testing.T) {
+	client := dial(t, NewService(Options{DB: &fakeDB{digests: newFakeDigests()}}), "")
+	ctx := context.Background()
+
+	digest, err := client.GetDigest(ctx, &brieflyv1.GetDigestRequest{Id: "latest"})
+	if err != nil {
+		t.Fatalf("GetDigest(latest): %v", err)
+	}
+	if digest.GetId() != "d2" || digest.GetTitle() != "Agents everywhere" || len(digest.GetArticles()) != 1 {
+		t.Errorf("latest digest = %v", digest)
+	}
+	if digest.GetArticleCount() != 1 || digest.GetThemes()[0] != "AI" || digest.GetProcessedAt() == nil {
+		t.Errorf("digest fields not converted: %v", digest)
+	}
+	if moment := digest.GetKeyMoments()[0]; moment.GetTimestampSeconds() != 273 || moment.GetTimestampUrl() == "" {
+		t.Errorf("key moment = %v", moment)
+	}
+
+	_, err = client.GetDigest(ctx, &brieflyv1.GetDigestRequest{Id: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetDigest(missing) = %v, want NotFound", err)
+	}
+	_, err = client.GetDigest(ctx, &brieflyv1.GetDigestRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetDigest without id = %v, want InvalidArgument", err)
+	}
+}
+
+func TestListDigests(t *testing.T) {
+	client := dial(t, NewService(Options{DB: &fakeDB{digests: newFakeDigests()}}), "")
+
+	resp, err := client.ListDigests(context.Background(), &brieflyv1.ListDigestsRequest{})
+	if err != nil {
+		t.Fatalf("ListDigests: %v", err)
+	}
+	if len(resp.GetDigests()) != 1 || resp.GetDigests()[0].GetId() != "d2" {
+		t.Errorf("expected only the digest from the last 30 days, got %v", resp.GetDigests())
+	}
+
+	resp, _ = client.ListDigests(context.Background(), &brieflyv1.ListDigestsRequest{SinceDays: 90})
+	if len(resp.GetDigests()) != 2 {
+		t.Errorf("expected both digests over 90 days, got %d", len(resp.GetDigests()))
+	}
+}
+
+func TestSearchArticles(t *testing.T) {
+	db := &fakeDB{digests: newFakeDigests()}
+	unconfigured := dial(t, NewService(Options{DB: db}), "")
+	if _, err := unconfigured.SearchArticles(context.Background(), &brieflyv1.SearchArticlesRequest{Query: "agents"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("search without a vector store = %v, want Unavailable", err)
+	}
+
+	store := &fakeVectorStore{}
+	client := dial(t, NewService(Options{DB: db, Embedder: fakeEmbedder{}, VectorStore: store}), "")
+	resp, err := client.SearchArticles(context.Background(), &brieflyv1.SearchArticlesRequest{Query: "agents", Limit: 1000})
+	if err != nil {
+		t.Fatalf("SearchArticles: %v", err)
+	}
+	if len(resp.GetResults()) != 1 || resp.GetResults()[0].GetArticle().GetTitle() != "Agents in production" {
+		t.Errorf("results = %v", resp.GetResults())
+	}
+	if store.query.Limit != maxLimit || store.query.SimilarityThreshold != defaultSearchThreshold {
+		t.Errorf("query = %+v, want the limit capped and the default threshold", store.query)
+	}
+
+	if _, err := client.SearchArticles(context.Background(), &brieflyv1.SearchArticlesRequest{Query: " "}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty query = %v, want InvalidArgument", err)
+	}
+}
+
+func TestGenerateDigest(t *testing.T) {
+	digests := newFakeDigests()
+	generator := &fakeGenerator{db: digests}
+	client := dial(t, NewService(Options{DB: &fakeDB{digests: digests}, Generator: generator}), "")
+
+	stream, err := client.GenerateDigest(context.Background(), &brieflyv1.GenerateDigestRequest{Theme: "AI"})
+	if err != nil {
+		t.Fatalf("GenerateDigest: %v", err)
+	}
+	var progress []string
+	var result *brieflyv1.GenerateDigestResult
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if line := event.GetProgress(); line != "" {
+			progress = append(progress, line)
+		}
+		if r := event.GetResult(); r != nil {
+			result = r
+		}
+	}
+
+	if len(progress) != 2 {
+		t.Errorf("progress = %q, want the two non-blank lines", progress)
+	}
+	if result == nil || len(result.GetDigests()) != 1 || result.GetDigests()[0].GetId() != "new" {
+		t.Errorf("result = %v, want only the digest saved by this run", result)
+	}
+	if generator.opts != (GenerateOptions{SinceDays: 7, Theme: "AI", MinArticles: 3}) {
+		t.Errorf("options = %+v, want defaults with the theme", generator.opts)
+	}
+
+	generator.err = errors.New("exit status 1")
+	stream, _ = client.GenerateDigest(context.Background(), &brieflyv1.GenerateDigestRequest{})
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Internal {
+		t.Errorf("failed run = %v, want Internal", err)
+	}
+}
+
+func TestCheckListen(t *testing.T) {
+	for host, wantErr := range map[string]bool{"127.0.0.1": false, "::1": false, "localhost": false, "0.0.0.0": true, "": true, "10.0.0.5": true} {
+		if err := CheckListen(host, ""); (err != nil) != wantErr {
+			t.Errorf("CheckListen(%q) without a token = %v, want error %v", host, err, wantErr)
+		}
+	}
+	if err := CheckListen("0.0.0.0", "s3cret"); err != nil {
+		t.Errorf("CheckListen with a token = %v, want nil", err)
+	}
+}
+
+func TestNewServer_RequiresToken(t *testing.T) {
+	client := dial(t, NewService(Options{DB: &fakeDB{digests: newFakeDigests()}}), "s3cret")
+	req := &brieflyv1.GetDigestRequest{Id: "latest"}
+
+	if _, err := client.GetDigest(context.Background(), req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("call without a token = %v, want Unauthenticated", err)
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer s3cret")
+	if _, err := client.GetDigest(ctx, req); err != nil {
+		t.Errorf("call with the token: %v", err)
+	}
+	stream, err := client.GenerateDigest(context.Background(), &brieflyv1.GenerateDigestRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("stream without a token = %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,228 @@
+// Package schedule computes digest issue dates from a publishing cadence, skipping
+// holidays, and the window of articles each issue covers.
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format for issue dates, holidays, and --issue values
+const DateLayout = "2006-01-02"
+
+// maxSearchDays bounds how far the calendar looks for a publish date, so a cadence
+// whose every day is a holiday cannot loop forever
+const maxSearchDays = 366
+
+// Presets map cadence names to publish weekdays
+var Presets = map[string][]time.Weekday{
+	"daily":        {time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+	"weekdays":     {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekly":       {time.Monday},
+	"twice-weekly": {time.Tuesday, time.Friday},
+}
+
+// Calendar decides which days an issue is published
+type Calendar struct {
+	PublishDays []time.Weekday
+	Holidays    map[string]bool // Dates (DateLayout) with no issue
+	FirstIssue  time.Time       // Date of issue #1; zero disables numbering
+	Location    *time.Location  // Zone publish dates are reckoned in; nil = UTC
+
+	skipCommonHolidays bool
+}
+
+// Issue is one scheduled digest and the article window it covers
+type Issue struct {
+	Number      int       // 1-based issue number; 0 when the calendar has no FirstIssue
+	Date        time.Time // Publish date (midnight in the calendar's location)
+	WindowStart time.Time // Previous publish date, inclusive
+	WindowEnd   time.Time // End of the day before Date, inclusive
+}
+
+// NewCalendar builds a calendar from a preset name, explicit weekdays (which override
+// the preset), holiday dates, and the first issue date. With skipHolidays, common
+// fixed and floating holidays are skipped in addition to the listed ones.
+func NewCalendar(preset string, publishDays []string, holidays []string, skipHolidays bool, firstIssue string) (*Calendar, error) {
+	calendar := &Calendar{Holidays: make(map[string]bool)}
+
+	if len(publishDays) > 0 {
+		for _, day := range publishDays {
+			weekday, err := ParseWeekday(day)
+			if err != nil {
+				return nil, err
+			}
+			calendar.PublishDays = append(calendar.PublishDays, weekday)
+		}
+	} else {
+		if preset == "" {
+			preset = "weekly"
+		}
+		days, ok := Presets[strings.ToLower(preset)]
+		if !ok {
+			return nil, fmt.Errorf("unknown schedule preset %q (supported: daily, weekdays, weekly, twice-weekly)", preset)
+		}
+		calendar.PublishDays = append(calendar.PublishDays, days...)
+	}
+	sort.Slice(calendar.PublishDays, func(i, j int) bool { return calendar.PublishDays[i] < calendar.PublishDays[j] })
+
+	for _, holiday := range holidays {
+		date, err := time.Parse(DateLayout, strings.TrimSpace(holiday))
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday %q (expected YYYY-MM-DD): %w", holiday, err)
+		}
+		calendar.Holidays[date.Format(DateLayout)] = true
+	}
+
+	calendar.skipCommonHolidays = skipHolidays
+
+	if firstIssue != "" {
+		date, err := time.Parse(DateLayout, firstIssue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid first issue date %q (expected YYYY-MM-DD): %w", firstIssue, err)
+		}
+		calendar.FirstIssue = date
+	}
+
+	return calendar, nil
+}
+
+// ParseWeekday parses a weekday name or its three-letter abbreviation
+func ParseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid publish day %q (expected a weekday name like tuesday)", name)
+}
+
+// IsPublishDay reports whether an issue goes out on date
+func (c *Calendar) IsPublishDay(date time.Time) bool {
+	date = c.day(date)
+	if c.Holidays[date.Format(DateLayout)] || (c.skipCommonHolidays && isCommonHoliday(date)) {
+		return false
+	}
+	for _, weekday := range c.PublishDays {
+		if date.Weekday() == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve turns an --issue value into an issue: "next" is the first publish date on
+// or after now, "previous" (or "last") the latest one before now, and a YYYY-MM-DD
+// date the issue published on that day. "Today" is now's date in the calendar's location.
+func (c *Calendar) Resolve(spec string, now time.Time) (*Issue, error) {
+	if c.Location != nil {
+		now = now.In(c.Location)
+	}
+	switch strings.ToLower(strings.TrimSpace(spec)) {
+	case "next", "":
+		date, err := c.publishDateFrom(c.day(now), 1)
+		if err != nil {
+			return nil, err
+		}
+		return c.issueOn(date)
+	case "previous", "last":
+		date, err := c.publishDateFrom(c.day(now).AddDate(0, 0, -1), -1)
+		if err != nil {
+			return nil, err
+		}
+		return c.issueOn(date)
+	}
+
+	date, err := time.Parse(DateLayout, spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue %q (expected next, previous, or YYYY-MM-DD)", spec)
+	}
+	if !c.IsPublishDay(date) {
+		return nil, fmt.Errorf("%s (%s) is not a publish day in this schedule", spec, date.Weekday())
+	}
+	return c.issueOn(c.day(date))
+}
+
+// Name is the issue's file-safe name, e.g. "issue-042_2025-06-10" or "issue_2025-06-10"
+func (i *Issue) Name() string {
+	if i.Number > 0 {
+		return fmt.Sprintf("issue-%03d_%s", i.Number, i.Date.Format(DateLayout))
+	}
+	return "issue_" + i.Date.Format(DateLayout)
+}
+
+// Label is the human-readable issue title, e.g. "Issue #42 (Tue Jun 10)"
+func (i *Issue) Label() string {
+	if i.Number > 0 {
+		return fmt.Sprintf("Issue #%d (%s)", i.Number, i.Date.Format("Mon Jan 2"))
+	}
+	return fmt.Sprintf("Issue of %s", i.Date.Format("Mon Jan 2"))
+}
+
+// issueOn builds the issue published on date. Its window runs from the previous
+// publish date through the day before date, so consecutive issues neither overlap
+// nor leave gaps, and a skipped holiday's articles roll into the next issue.
+func (c *Calendar) issueOn(date time.Time) (*Issue, error) {
+	previous, err := c.publishDateFrom(date.AddDate(0, 0, -1), -1)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := &Issue{
+		Date:        date,
+		WindowStart: previous,
+		WindowEnd:   date.Add(-time.Nanosecond),
+	}
+
+	if first := c.day(c.FirstIssue); !c.FirstIssue.IsZero() && !date.Before(first) {
+		for d := first; !d.After(date); d = d.AddDate(0, 0, 1) {
+			if c.IsPublishDay(d) {
+				issue.Number++
+			}
+		}
+	}
+
+	return issue, nil
+}
+
+// publishDateFrom walks from start in direction step (+1 or -1) to the first publish day
+func (c *Calendar) publishDateFrom(start time.Time, step int) (time.Time, error) {
+	if len(c.PublishDays) == 0 {
+		return time.Time{}, fmt.Errorf("schedule has no publish days")
+	}
+	for i, d := 0, start; i < maxSearchDays; i, d = i+1, d.AddDate(0, 0, step) {
+		if c.IsPublishDay(d) {
+			return d, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no publish day within a year of %s", start.Format(DateLayout))
+}
+
+// day truncates t to midnight of its calendar date, in the calendar's location
+func (c *Calendar) day(t time.Time) time.Time {
+	loc := time.UTC
+	if c.Location != nil {
+		loc = c.Location
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// isCommonHoliday reports New Year's Day, Independence Day, Thanksgiving (fourth
+// Thursday of November), Christmas Eve and Christmas Day
+func isCommonHoliday(date time.Time) bool {
+	switch {
+	case date.Month() == time.January && date.Day() == 1:
+		return true
+	case date.Month() == time.July && date.Day() == 4:
+		return true
+	case date.Month() == time.December && (date.Day() == 24 || date.Day() == 25):
+		return true
+	case date.Month() == time.November && date.Weekday() == time.Thursday && (date.Day()-1)/7 == 3:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse(DateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestResolveNext_TwiceWeekly(t *testing.T) {
+	calendar, err := NewCalendar("twice-weekly", nil, nil, false, "2025-06-03")
+	if err != nil {
+		t.Fatalf("NewCalendar failed: %v", err)
+	}
+
+	// Wednesday Jun 11 2025 → next issue is Friday Jun 13, covering Tue-Thu
+	issue, err := calendar.Resolve("next", date("2025-06-11").Add(15*time.Hour))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if got := issue.Date.Format(DateLayout); got != "2025-06-13" {
+		t.Errorf("Expected issue on 2025-06-13, got %s", got)
+	}
+	if got := issue.WindowStart.Format(DateLayout); got != "2025-06-10" {
+		t.Errorf("Expected window to start at the previous issue (2025-06-10), got %s", got)
+	}
+	if got := issue.WindowEnd.Format(DateLayout); got != "2025-06-12" {
+		t.Errorf("Expected window to end the day before the issue, got %s", got)
+	}
+	// Jun 3, 6, 10, 13
+	if issue.Number != 4 || issue.Name() != "issue-004_2025-06-13" {
+		t.Errorf("Expected issue #4 named issue-004_2025-06-13, got #%d %s", issue.Number, issue.Name())
+	}
+}
+
+func TestResolve_SkipsHolidays(t *testing.T) {
+	calendar, err := NewCalendar("", []string{"thu"}, []string{"2025-07-03"}, true, "")
+	if err != nil {
+		t.Fatalf("NewCalendar failed: %v", err)
+	}
+
+	// Thanksgiving 2025 is Thursday Nov 27; the next issue moves to Dec 4
+	issue, err := calendar.Resolve("next", date("2025-11-25"))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if got := issue.Date.Format(DateLayout); got != "2025-12-04" {
+		t.Errorf("Expected Thanksgiving skipped, got %s", got)
+	}
+	if got := issue.WindowStart.Format(DateLayout); got != "2025-11-20" {
+		t.Errorf("Expected the skipped week folded into the window, got start %s", got)
+	}
+
+	if _, err := calendar.Resolve("2025-07-03", time.Now()); err == nil {
+		t.Error("Expected an error for an issue on a configured holiday")
+	}
+	if issue.Name() != "issue_2025-12-04" {
+		t.Errorf("Expected unnumbered name without first_issue, got %s", issue.Name())
+	}
+}
+
+func TestResolve_Location(t *testing.T) {
+	calendar, err := NewCalendar("daily", nil, nil, false, "2025-06-01")
+	if err != nil {
+		t.Fatalf("NewCalendar failed: %v", err)
+	}
+	tokyo := time.FixedZone("JST", 9*60*60)
+	calendar.Location = tokyo
+
+	// 23:00 UTC on Sunday Jun 8 is already Monday morning in Tokyo
+	issue, err := calendar.Resolve("next", date("2025-06-08").Add(23*time.Hour))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if got := issue.Date.Format(DateLayout); got != "2025-06-09" {
+		t.Errorf("Expected Monday's issue in Tokyo, got %s", got)
+	}
+	if want := time.Date(2025, 6, 8, 0, 0, 0, 0, tokyo); !issue.WindowStart.Equal(want) {
+		t.Errorf("Expected the window to start at midnight Tokyo time, got %s", issue.WindowStart)
+	}
+	if issue.Number != 9 {
+		t.Errorf("Expected issue #9, got #%d", issue.Number)
+	}
+}
+
+func TestNewCalendar_InvalidConfig(t *testing.T) {
+	if _, err := NewCalendar("fortnightly", nil, nil, false, ""); err == nil {
+		t.Error("Expected unknown preset to fail")
+	}
+	if _, err := NewCalendar("", []string{"funday"}, nil, false, ""); err == nil {
+		t.Error("Expected invalid weekday to fail")
+	}
+	if _, err := NewCalendar("weekly", nil, []string{"Dec 25"}, false, ""); err == nil {
+		t.Error("Expected invalid holiday to fail")
+	}
+}
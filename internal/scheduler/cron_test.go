@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCron_Next(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2025, 6, 4, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 6, 4, 10, 30, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2025, 6, 4, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon", time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2025, 6, 5, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * jan,dec 7", time.Date(2025, 12, 7, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches (the 15th, or the next Friday)
+		{"0 6 15 * fri", time.Date(2025, 6, 6, 6, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) failed: %v", tt.expr, err)
+		}
+		if got := c.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q: Next = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCron_NeverMatches(t *testing.T) {
+	c, err := ParseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := c.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); !next.IsZero() {
+		t.Errorf("expected no match for Feb 31, got %s", next)
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *", "@fortnightly"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("expected %q to be rejected", expr)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Package scheduler runs named jobs on cron schedules inside a long-running process.
+// A job still running when it comes due again is skipped rather than started twice,
+// and every run (or skip) is reported to a Recorder for run history.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Run statuses reported to the Recorder
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped" // Came due while the previous run was still going
+)
+
+// Job is a named task and the cron schedule it runs on
+type Job struct {
+	Name     string
+	Task     string // Task kind, recorded with each run
+	Schedule *Cron
+	Run      func(ctx context.Context) error
+}
+
+// Recorder keeps run history. StartScheduleRun returns an ID passed to FinishScheduleRun.
+type Recorder interface {
+	StartScheduleRun(name, task, status string, startedAt time.Time) (int64, error)
+	FinishScheduleRun(id int64, status, message string, finishedAt time.Time) error
+}
+
+// Scheduler runs jobs when their schedules come due
+type Scheduler struct {
+	jobs     []Job
+	recorder Recorder
+	now      func() time.Time
+	logf     func(format string, args ...any)
+
+	mu      sync.Mutex
+	running map[string]bool
+	wg      sync.WaitGroup
+}
+
+// New creates a scheduler for jobs. recorder may be nil to keep no history; logf
+// receives one line per start, finish, and skip (nil = silent).
+func New(jobs []Job, recorder Recorder, logf func(format string, args ...any)) (*Scheduler, error) {
+	seen := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		if job.Name == "" || job.Schedule == nil || job.Run == nil {
+			return nil, fmt.Errorf("job %q needs a name, schedule, and run function", job.Name)
+		}
+		if seen[job.Name] {
+			return nil, fmt.Errorf("duplicate job name %q", job.Name)
+		}
+		seen[job.Name] = true
+	}
+	if logf == nil {
+		logf = func(string, ...any) {}
+	}
+	return &Scheduler{
+		jobs:     jobs,
+		recorder: recorder,
+		now:      time.Now,
+		logf:     logf,
+		running:  make(map[string]bool),
+	}, nil
+}
+
+// SetLocation reads cron expressions in loc, e.g. the publication time zone, rather
+// than the system's local time
+func (s *Scheduler) SetLocation(loc *time.Location) {
+	s.now = func() time.Time { return time.Now().In(loc) }
+}
+
+// NextRuns returns each job's next run time after t, in job order
+func (s *Scheduler) NextRuns(t time.Time) []time.Time {
+	next := make([]time.Time, len(s.jobs))
+	for i, job := range s.jobs {
+		next[i] = job.Schedule.Next(t)
+	}
+	return next
+}
+
+// Run starts jobs as they come due until ctx is cancelled, then waits for running
+// jobs to finish. Jobs get a context that is cancelled with ctx.
+func (s *Scheduler) Run(ctx context.Context) error {
+	next := s.NextRuns(s.now())
+	for {
+		wake := time.Time{}
+		for _, at := range next {
+			if !at.IsZero() && (wake.IsZero() || at.Before(wake)) {
+				wake = at
+			}
+		}
+		if wake.IsZero() {
+			<-ctx.Done()
+			s.wg.Wait()
+			return nil
+		}
+
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.wg.Wait()
+			return nil
+		case <-timer.C:
+		}
+
+		now := s.now()
+		for i, job := range s.jobs {
+			if next[i].IsZero() || next[i].After(now) {
+				continue
+			}
+			s.start(ctx, job, now)
+			next[i] = job.Schedule.Next(now)
+		}
+	}
+}
+
+// start runs job in the background unless its previous run is still going. Returns
+// whether it started.
+func (s *Scheduler) start(ctx context.Context, job Job, now time.Time) bool {
+	s.mu.Lock()
+	if s.running[job.Name] {
+		s.mu.Unlock()
+		s.logf("⏭️  %s: skipped, the previous run is still going", job.Name)
+		if id, err := s.record(job, StatusSkipped, now); err == nil && id > 0 {
+			s.finish(job, id, StatusSkipped, "previous run still going")
+		}
+		return false
+	}
+	s.running[job.Name] = true
+	s.mu.Unlock()
+
+	id, err := s.record(job, StatusRunning, now)
+	if err != nil {
+		s.logf("⚠️  %s: failed to record run: %v", job.Name, err)
+	}
+	s.logf("▶️  %s: started", job.Name)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, job.Name)
+			s.mu.Unlock()
+		}()
+
+		started := s.now()
+		if runErr := job.Run(ctx); runErr != nil {
+			s.logf("❌ %s: failed after %s: %v", job.Name, s.now().Sub(started).Round(time.Second), runErr)
+			s.finish(job, id, StatusFailed, runErr.Error())
+			return
+		}
+		s.logf("✅ %s: finished in %s", job.Name, s.now().Sub(started).Round(time.Second))
+		s.finish(job, id, StatusSucceeded, "")
+	}()
+	return true
+}
+
+func (s *Scheduler) record(job Job, status string, at time.Time) (int64, error) {
+	if s.recorder == nil {
+		return 0, nil
+	}
+	return s.recorder.StartScheduleRun(job.Name, job.Task, status, at)
+}
+
+func (s *Scheduler) finish(job Job, id int64, status, message string) {
+	if s.recorder == nil || id == 0 {
+		return
+	}
+	if err := s.recorder.FinishScheduleRun(id, status, message, s.now()); err != nil {
+		s.logf("⚠️  %s: failed to record run: %v", job.Name, err)
+	}
+}
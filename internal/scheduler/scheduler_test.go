@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryRecorder keeps run history in memory
+type memoryRecorder struct {
+	mu   sync.Mutex
+	runs []recordedRun
+}
+
+type recordedRun struct {
+	name, status, message string
+}
+
+func (m *memoryRecorder) StartScheduleRun(name, task, status string, startedAt time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs = append(m.runs, recordedRun{name: name, status: status})
+	return int64(len(m.runs)), nil
+}
+
+func (m *memoryRecorder) FinishScheduleRun(id int64, status, message string, finishedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs[id-1].status = status
+	m.runs[id-1].message = message
+	return nil
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	every, _ := ParseCron("* * * * *")
+	release := make(chan struct{})
+	recorder := &memoryRecorder{}
+
+	s, err := New([]Job{
+		{Name: "slow", Task: "feed-pull", Schedule: every, Run: func(ctx context.Context) error {
+			<-release
+			return nil
+		}},
+		{Name: "broken", Task: "cache-prune", Schedule: every, Run: func(ctx context.Context) error {
+			return errors.New("cache locked")
+		}},
+	}, recorder, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	if !s.start(ctx, s.jobs[0], now) {
+		t.Fatal("expected the first run to start")
+	}
+	if s.start(ctx, s.jobs[0], now.Add(time.Minute)) {
+		t.Error("expected an overlapping run to be skipped")
+	}
+	s.start(ctx, s.jobs[1], now)
+	close(release)
+	s.wg.Wait()
+
+	if !s.start(ctx, s.jobs[0], now.Add(2*time.Minute)) {
+		t.Error("expected a run after the previous one finished to start")
+	}
+	s.wg.Wait()
+
+	want := []recordedRun{
+		{"slow", StatusSucceeded, ""},
+		{"slow", StatusSkipped, "previous run still going"},
+		{"broken", StatusFailed, "cache locked"},
+		{"slow", StatusSucceeded, ""},
+	}
+	if len(recorder.runs) != len(want) {
+		t.Fatalf("expected %d recorded runs, got %+v", len(want), recorder.runs)
+	}
+	for i := range want {
+		if recorder.runs[i] != want[i] {
+			t.Errorf("run %d = %+v, want %+v", i, recorder.runs[i], want[i])
+		}
+	}
+}
+
+func TestScheduler_RunStopsWithContext(t *testing.T) {
+	hourly, _ := ParseCron("@hourly")
+	s, err := New([]Job{{Name: "pull", Schedule: hourly, Run: func(ctx context.Context) error { return nil }}}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not stop when its context was cancelled")
+	}
+}
+
+func TestNew_RejectsInvalidJobs(t *testing.T) {
+	hourly, _ := ParseCron("@hourly")
+	run := func(ctx context.Context) error { return nil }
+
+	if _, err := New([]Job{{Name: "a", Schedule: hourly, Run: run}, {Name: "a", Schedule: hourly, Run: run}}, nil, nil); err == nil {
+		t.Error("expected duplicate job names rejected")
+	}
+	if _, err := New([]Job{{Name: "a", Run: run}}, nil, nil); err == nil {
+		t.Error("expected a job without a schedule rejected")
+	}
+}
+
+func TestScheduler_SetLocation(t *testing.T) {
+	mondays, _ := ParseCron("0 9 * * mon")
+	s, err := New([]Job{{Name: "digest", Schedule: mondays, Run: func(ctx context.Context) error { return nil }}}, nil, nil)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	s.SetLocation(tokyo)
+	next := s.NextRuns(s.now())[0]
+	if at := next.In(tokyo); at.Weekday() != time.Monday || at.Hour() != 9 {
+		t.Errorf("expected 9:00 Monday in Tokyo, got %s", at)
+	}
+}
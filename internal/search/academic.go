@@ -0,0 +1,209 @@
+package search
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	arXivURL           = "https://export.arxiv.org/api/query"
+	semanticScholarURL = "https://api.semanticscholar.org/graph/v1/paper/search"
+
+	// academicMaxResults caps one academic search; both APIs page beyond it
+	academicMaxResults = 100
+
+	// semanticScholarFields is the metadata requested for each paper
+	semanticScholarFields = "title,url,abstract,authors,venue,year,publicationDate,citationCount,externalIds"
+)
+
+// arXivProvider searches arXiv's Atom API. It needs no key; arXiv asks clients to
+// wait three seconds between requests.
+type arXivProvider struct {
+	client  *http.Client
+	since   time.Time
+	baseURL string
+}
+
+func (p *arXivProvider) Name() string { return ProviderArXiv }
+
+func (p *arXivProvider) CallsFor(maxResults int) int { return 1 }
+
+func (p *arXivProvider) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	if maxResults <= 0 || maxResults > academicMaxResults {
+		maxResults = academicMaxResults
+	}
+
+	params := url.Values{}
+	searchQuery := "all:" + query
+	if !p.since.IsZero() {
+		searchQuery += fmt.Sprintf(" AND submittedDate:[%s0000 TO %s2359]", p.since.UTC().Format("20060102"), time.Now().UTC().Format("20060102"))
+	}
+	params.Set("search_query", searchQuery)
+	params.Set("max_results", strconv.Itoa(maxResults))
+	params.Set("sortBy", "relevance")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("arxiv search failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(ProviderArXiv, resp); err != nil {
+		return nil, err
+	}
+
+	var feed struct {
+		Entries []struct {
+			ID        string `xml:"id"`
+			Title     string `xml:"title"`
+			Summary   string `xml:"summary"`
+			Published string `xml:"published"`
+			Authors   []struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+			JournalRef string `xml:"journal_ref"`
+		} `xml:"entry"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode arxiv response: %w", err)
+	}
+
+	results := make([]Result, 0, len(feed.Entries))
+	for _, entry := range feed.Entries {
+		id := arXivID(entry.ID)
+		if id == "" {
+			continue
+		}
+		paper := &Paper{Venue: collapseSpace(entry.JournalRef), Citations: -1, ArXivID: id}
+		for _, author := range entry.Authors {
+			paper.Authors = append(paper.Authors, strings.TrimSpace(author.Name))
+		}
+		paper.Published, _ = time.Parse(time.RFC3339, strings.TrimSpace(entry.Published))
+
+		results = append(results, Result{
+			Title:   collapseSpace(entry.Title),
+			URL:     arXivAbsURL(id),
+			Snippet: collapseSpace(entry.Summary),
+			Source:  ProviderArXiv,
+			Paper:   paper,
+		})
+	}
+	return results, nil
+}
+
+// semanticScholarProvider searches the Semantic Scholar Graph API, which adds citation
+// counts and venues. An API key is optional but raises the shared rate limit.
+type semanticScholarProvider struct {
+	client  *http.Client
+	apiKey  string
+	since   time.Time
+	baseURL string
+}
+
+func (p *semanticScholarProvider) Name() string { return ProviderSemanticScholar }
+
+func (p *semanticScholarProvider) CallsFor(maxResults int) int { return 1 }
+
+func (p *semanticScholarProvider) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	if maxResults <= 0 || maxResults > academicMaxResults {
+		maxResults = academicMaxResults
+	}
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("limit", strconv.Itoa(maxResults))
+	params.Set("fields", semanticScholarFields)
+	if !p.since.IsZero() {
+		params.Set("publicationDateOrYear", p.since.Format("2006-01-02")+":") // Open-ended range
+	}
+
+	var header http.Header
+	if p.apiKey != "" {
+		header = http.Header{"X-Api-Key": {p.apiKey}}
+	}
+
+	var parsed struct {
+		Data []struct {
+			Title           string `json:"title"`
+			URL             string `json:"url"`
+			Abstract        string `json:"abstract"`
+			Venue           string `json:"venue"`
+			Year            int    `json:"year"`
+			PublicationDate string `json:"publicationDate"`
+			CitationCount   int    `json:"citationCount"`
+			Authors         []struct {
+				Name string `json:"name"`
+			} `json:"authors"`
+			ExternalIDs map[string]interface{} `json:"externalIds"`
+		} `json:"data"`
+	}
+	if err := getJSON(ctx, p.client, ProviderSemanticScholar, p.baseURL+"?"+params.Encode(), header, &parsed); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(parsed.Data))
+	for _, item := range parsed.Data {
+		paper := &Paper{Venue: item.Venue, Citations: item.CitationCount}
+		for _, author := range item.Authors {
+			paper.Authors = append(paper.Authors, author.Name)
+		}
+		if published, err := time.Parse("2006-01-02", item.PublicationDate); err == nil {
+			paper.Published = published
+		} else if item.Year > 0 {
+			paper.Published = time.Date(item.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		// Cite the arXiv abstract page when there is one, so the same paper found
+		// on both indexes dedupes to one source
+		resultURL := item.URL
+		if id, ok := item.ExternalIDs["ArXiv"].(string); ok && id != "" {
+			paper.ArXivID = id
+			resultURL = arXivAbsURL(id)
+		}
+		if resultURL == "" {
+			continue
+		}
+
+		results = append(results, Result{
+			Title:   collapseSpace(item.Title),
+			URL:     resultURL,
+			Snippet: collapseSpace(item.Abstract),
+			Source:  ProviderSemanticScholar,
+			Paper:   paper,
+		})
+	}
+	return results, nil
+}
+
+// arXivID extracts the versionless ID from an arXiv entry ID such as
+// "http://arxiv.org/abs/2401.00001v2"
+func arXivID(entryID string) string {
+	idx := strings.Index(entryID, "/abs/")
+	if idx < 0 {
+		return ""
+	}
+	id := strings.TrimSpace(entryID[idx+len("/abs/"):])
+	if v := strings.LastIndex(id, "v"); v > 0 && strings.Trim(id[v+1:], "0123456789") == "" && v+1 < len(id) {
+		id = id[:v]
+	}
+	return id
+}
+
+// arXivAbsURL is the canonical abstract page for an arXiv ID
+func arXivAbsURL(id string) string {
+	return "https://arxiv.org/abs/" + id
+}
+
+// collapseSpace joins the wrapped lines of API text into one line
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestArXivProvider_Search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("search_query") != "all:vector search" || r.URL.Query().Get("max_results") != "5" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:arxiv="http://arxiv.org/schemas/atom">
+  <entry>
+    <id>http://arxiv.org/abs/2401.00001v2</id>
+    <published>2024-01-02T18:00:00Z</published>
+    <title>Approximate Nearest
+      Neighbor Search</title>
+    <summary>  We study graph indexes.
+      They are fast.</summary>
+    <author><name>Ada Lovelace</name></author>
+    <author><name>Alan Turing</name></author>
+    <arxiv:journal_ref>VLDB 2024</arxiv:journal_ref>
+  </entry>
+</feed>`)
+	}))
+	defer server.Close()
+
+	provider := &arXivProvider{client: server.Client(), baseURL: server.URL}
+	results, err := provider.Search(context.Background(), "vector search", 5)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %+v", results)
+	}
+
+	result := results[0]
+	if result.URL != "https://arxiv.org/abs/2401.00001" || result.Title != "Approximate Nearest Neighbor Search" ||
+		result.Snippet != "We study graph indexes. They are fast." || result.Source != ProviderArXiv {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	paper := result.Paper
+	if paper == nil || strings.Join(paper.Authors, ",") != "Ada Lovelace,Alan Turing" || paper.Published.Year() != 2024 ||
+		paper.Venue != "VLDB 2024" || paper.Citations != -1 || paper.ArXivID != "2401.00001" {
+		t.Errorf("unexpected paper metadata: %+v", paper)
+	}
+}
+
+func TestSemanticScholarProvider_Search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "key" || r.URL.Query().Get("limit") != "3" {
+			t.Errorf("unexpected request %s (key %q)", r.URL.RawQuery, r.Header.Get("x-api-key"))
+		}
+		fmt.Fprint(w, `{"total":2,"data":[
+			{"title":"HNSW","url":"https://www.semanticscholar.org/paper/abc","abstract":"Graphs.","venue":"TPAMI",
+			 "year":2018,"publicationDate":"2018-03-30","citationCount":1200,
+			 "authors":[{"name":"Yu. A. Malkov"}],"externalIds":{"ArXiv":"1603.09320","CorpusId":1}},
+			{"title":"Old paper","url":"https://www.semanticscholar.org/paper/def","abstract":null,"venue":"",
+			 "year":1999,"publicationDate":null,"citationCount":3,"authors":[],"externalIds":{}}]}`)
+	}))
+	defer server.Close()
+
+	provider := &semanticScholarProvider{client: server.Client(), apiKey: "key", baseURL: server.URL}
+	results, err := provider.Search(context.Background(), "hnsw", 3)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %+v", results)
+	}
+
+	if results[0].URL != "https://arxiv.org/abs/1603.09320" || results[0].Paper.Citations != 1200 ||
+		results[0].Paper.Venue != "TPAMI" || results[0].Paper.Published.Month() != 3 {
+		t.Errorf("expected the arXiv URL and full metadata, got %+v / %+v", results[0], results[0].Paper)
+	}
+	if results[1].URL != "https://www.semanticscholar.org/paper/def" || results[1].Paper.Published.Year() != 1999 {
+		t.Errorf("expected the year to stand in for a missing date, got %+v / %+v", results[1], results[1].Paper)
+	}
+}
+
+func TestArXivID(t *testing.T) {
+	cases := map[string]string{
+		"http://arxiv.org/abs/2401.00001v2":     "2401.00001",
+		"http://arxiv.org/abs/hep-th/9901001v1": "hep-th/9901001",
+		"http://arxiv.org/abs/2401.00001":       "2401.00001",
+		"http://arxiv.org/pdf/2401.00001":       "",
+	}
+	for entryID, want := range cases {
+		if got := arXivID(entryID); got != want {
+			t.Errorf("arXivID(%s) = %q, want %q", entryID, got, want)
+		}
+	}
+}
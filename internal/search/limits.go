@@ -0,0 +1,186 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// lowQuotaFraction is the share of the daily quota below which research halves the
+// results it asks for per query
+const lowQuotaFraction = 0.2
+
+// ErrQuotaExhausted is returned when a provider's daily quota is used up
+var ErrQuotaExhausted = errors.New("search quota exhausted")
+
+// UsageStore persists how many billable requests each provider made per UTC day.
+// *store.Store implements it.
+type UsageStore interface {
+	SearchUsage(provider string, day time.Time) (int, error)
+	RecordSearchUsage(provider string, day time.Time, calls int) error
+}
+
+// Limits caps how fast and how much a provider is used
+type Limits struct {
+	Interval   time.Duration // Minimum gap between requests (0 = none)
+	DailyQuota int           // Billable requests per UTC day (0 = unlimited)
+}
+
+// Limited wraps a provider with a rate limit and a daily quota. Usage is recorded in
+// the usage store, so the quota holds across runs.
+type Limited struct {
+	provider Provider
+	limits   Limits
+	usage    UsageStore
+
+	mu   sync.Mutex
+	last time.Time
+	now  func() time.Time
+}
+
+// NewLimited wraps provider with limits. A nil usage store tracks nothing, so only the
+// rate limit applies.
+func NewLimited(provider Provider, limits Limits, usage UsageStore) *Limited {
+	return &Limited{
+		provider: provider,
+		limits:   limits,
+		usage:    usage,
+		now:      time.Now,
+	}
+}
+
+// Name returns the wrapped provider's name
+func (l *Limited) Name() string { return l.provider.Name() }
+
+// CallsFor returns the wrapped provider's request count for maxResults
+func (l *Limited) CallsFor(maxResults int) int { return l.provider.CallsFor(maxResults) }
+
+// Search waits out the rate limit, then searches if the quota allows. When the quota
+// can't cover maxResults, it asks for fewer results rather than failing.
+func (l *Limited) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	remaining, err := l.Remaining()
+	if err != nil {
+		return nil, err
+	}
+	if remaining == 0 {
+		return nil, fmt.Errorf("%s: %w (%d/day)", l.Name(), ErrQuotaExhausted, l.limits.DailyQuota)
+	}
+	if remaining > 0 {
+		maxResults = l.fitResults(maxResults, remaining)
+	}
+
+	if err := l.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	results, err := l.provider.Search(ctx, query, maxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.usage != nil {
+		if err := l.usage.RecordSearchUsage(l.Name(), l.now(), l.CallsFor(maxResults)); err != nil {
+			return results, fmt.Errorf("failed to record search usage: %w", err)
+		}
+	}
+	return results, nil
+}
+
+// Used returns today's billable requests
+func (l *Limited) Used() (int, error) {
+	if l.usage == nil {
+		return 0, nil
+	}
+	return l.usage.SearchUsage(l.Name(), l.now())
+}
+
+// Remaining returns today's remaining requests, or -1 when there is no quota
+func (l *Limited) Remaining() (int, error) {
+	if l.limits.DailyQuota <= 0 || l.usage == nil {
+		return -1, nil
+	}
+	used, err := l.Used()
+	if err != nil {
+		return 0, err
+	}
+	return max(l.limits.DailyQuota-used, 0), nil
+}
+
+// Plan is a research run's searches fitted to the remaining quota
+type Plan struct {
+	Queries    int      // Sub-queries that fit
+	MaxResults int      // Results per sub-query, after down-shifting
+	Remaining  int      // Requests left today before the run (-1 = unlimited)
+	Warnings   []string // Why the plan was cut back
+}
+
+// Plan fits queries searches of maxResults each into today's remaining quota. When
+// the quota is low it asks for fewer results per query, then for fewer queries.
+// Fails with ErrQuotaExhausted when nothing is left.
+func (l *Limited) Plan(queries, maxResults int) (Plan, error) {
+	remaining, err := l.Remaining()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	plan := Plan{Queries: queries, MaxResults: maxResults, Remaining: remaining}
+	if remaining < 0 {
+		return plan, nil
+	}
+	if remaining == 0 {
+		return plan, fmt.Errorf("%s: %w (%d/day, resets at midnight UTC)", l.Name(), ErrQuotaExhausted, l.limits.DailyQuota)
+	}
+
+	if float64(remaining) < lowQuotaFraction*float64(l.limits.DailyQuota) && plan.MaxResults > 1 {
+		plan.MaxResults = max(plan.MaxResults/2, 1)
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s quota is low (%d of %d left today); asking for %d results per query instead of %d",
+			l.Name(), remaining, l.limits.DailyQuota, plan.MaxResults, maxResults))
+	}
+
+	if needed := plan.Queries * l.CallsFor(plan.MaxResults); needed > remaining {
+		shifted := plan.MaxResults
+		for l.CallsFor(shifted) > 1 && plan.Queries*l.CallsFor(shifted) > remaining {
+			shifted = max(shifted/2, 1)
+		}
+		if shifted != plan.MaxResults {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("%d queries would need %d %s requests but %d are left; asking for %d results per query",
+				plan.Queries, needed, l.Name(), remaining, shifted))
+			plan.MaxResults = shifted
+		}
+		if fit := remaining / l.CallsFor(plan.MaxResults); fit < plan.Queries {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("only %d of %d queries fit in the remaining %s quota", fit, plan.Queries, l.Name()))
+			plan.Queries = fit
+		}
+	}
+	return plan, nil
+}
+
+// fitResults lowers maxResults until its requests fit in remaining
+func (l *Limited) fitResults(maxResults, remaining int) int {
+	for maxResults > 1 && l.CallsFor(maxResults) > remaining {
+		maxResults = max(maxResults/2, 1)
+	}
+	return maxResults
+}
+
+// wait blocks until the rate limit allows another request
+func (l *Limited) wait(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limits.Interval > 0 && !l.last.IsZero() {
+		if delay := l.limits.Interval - l.now().Sub(l.last); delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	l.last = l.now()
+	return nil
+}
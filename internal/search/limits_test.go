@@ -0,0 +1,121 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type memoryUsage map[string]int
+
+func (m memoryUsage) SearchUsage(provider string, day time.Time) (int, error) {
+	return m[provider+day.UTC().Format("2006-01-02")], nil
+}
+
+func (m memoryUsage) RecordSearchUsage(provider string, day time.Time, calls int) error {
+	m[provider+day.UTC().Format("2006-01-02")] += calls
+	return nil
+}
+
+// pagedProvider bills one request per ten results, like Google Custom Search
+type pagedProvider struct {
+	searches   int
+	maxResults []int
+}
+
+func (p *pagedProvider) Name() string { return "paged" }
+
+func (p *pagedProvider) CallsFor(maxResults int) int { return (maxResults + 9) / 10 }
+
+func (p *pagedProvider) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	p.searches++
+	p.maxResults = append(p.maxResults, maxResults)
+	return []Result{{URL: "https://example.com"}}, nil
+}
+
+func TestLimited_SearchRecordsUsageAndStopsAtQuota(t *testing.T) {
+	usage := memoryUsage{}
+	provider := &pagedProvider{}
+	limited := NewLimited(provider, Limits{DailyQuota: 3}, usage)
+
+	if _, err := limited.Search(context.Background(), "q1", 20); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if used, _ := limited.Used(); used != 2 {
+		t.Fatalf("expected 2 requests recorded, got %d", used)
+	}
+
+	// One request left: the search is down-shifted to a single page
+	if _, err := limited.Search(context.Background(), "q2", 20); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if provider.maxResults[1] > 10 {
+		t.Errorf("expected the second search down-shifted to one page, asked for %d", provider.maxResults[1])
+	}
+
+	_, err := limited.Search(context.Background(), "q3", 20)
+	if !errors.Is(err, ErrQuotaExhausted) {
+		t.Fatalf("expected ErrQuotaExhausted, got %v", err)
+	}
+	if provider.searches != 2 {
+		t.Errorf("expected no request once the quota is used, got %d searches", provider.searches)
+	}
+}
+
+func TestLimited_Plan(t *testing.T) {
+	usage := memoryUsage{}
+	limited := NewLimited(&pagedProvider{}, Limits{DailyQuota: 100}, usage)
+
+	plan, err := limited.Plan(5, 20)
+	if err != nil || plan.Queries != 5 || plan.MaxResults != 20 || len(plan.Warnings) != 0 {
+		t.Fatalf("expected the full plan to fit, got %+v, %v", plan, err)
+	}
+
+	// 8 left: low quota halves results to one page, then 5 queries fit
+	_ = usage.RecordSearchUsage("paged", time.Now(), 92)
+	plan, err = limited.Plan(5, 20)
+	if err != nil || plan.Queries != 5 || plan.MaxResults != 10 || len(plan.Warnings) != 1 {
+		t.Fatalf("expected results down-shifted for low quota, got %+v, %v", plan, err)
+	}
+
+	// 3 left: only 3 single-page queries fit
+	_ = usage.RecordSearchUsage("paged", time.Now(), 5)
+	plan, err = limited.Plan(5, 40)
+	if err != nil || plan.Queries != 3 || plan.MaxResults > 10 {
+		t.Fatalf("expected queries cut to fit, got %+v, %v", plan, err)
+	}
+
+	_ = usage.RecordSearchUsage("paged", time.Now(), 3)
+	if _, err := limited.Plan(5, 20); !errors.Is(err, ErrQuotaExhausted) {
+		t.Errorf("expected ErrQuotaExhausted, got %v", err)
+	}
+}
+
+func TestLimited_Unlimited(t *testing.T) {
+	limited := NewLimited(&pagedProvider{}, Limits{}, memoryUsage{})
+	plan, err := limited.Plan(50, 100)
+	if err != nil || plan.Remaining != -1 || plan.Queries != 50 || plan.MaxResults != 100 {
+		t.Errorf("expected no quota to leave the plan alone, got %+v, %v", plan, err)
+	}
+}
+
+func TestLimited_RateLimit(t *testing.T) {
+	limited := NewLimited(&pagedProvider{}, Limits{Interval: 30 * time.Millisecond}, nil)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := limited.Search(context.Background(), "q", 10); err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected 3 searches to take at least 2 intervals, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := limited.Search(ctx, "q", 10); err == nil {
+		t.Error("expected a canceled context to stop the wait")
+	}
+}
@@ -0,0 +1,272 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	serpAPIURL      = "https://serpapi.com/search.json"
+	googleSearchURL = "https://www.googleapis.com/customsearch/v1"
+	duckDuckGoURL   = "https://html.duckduckgo.com/html/"
+
+	// googlePageSize is the most results Google Custom Search returns per request
+	googlePageSize = 10
+
+	// serpAPIMaxResults is the most organic results SerpAPI returns per request
+	serpAPIMaxResults = 100
+)
+
+// serpAPIProvider searches Google through SerpAPI. One search is one request.
+type serpAPIProvider struct {
+	client   *http.Client
+	apiKey   string
+	language string
+	since    time.Time
+	baseURL  string
+}
+
+func (p *serpAPIProvider) Name() string { return ProviderSerpAPI }
+
+func (p *serpAPIProvider) CallsFor(maxResults int) int { return 1 }
+
+func (p *serpAPIProvider) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	if maxResults <= 0 || maxResults > serpAPIMaxResults {
+		maxResults = serpAPIMaxResults
+	}
+
+	params := url.Values{}
+	params.Set("engine", "google")
+	params.Set("q", query)
+	params.Set("num", strconv.Itoa(maxResults))
+	params.Set("api_key", p.apiKey)
+	if p.language != "" {
+		params.Set("hl", p.language)
+	}
+	if !p.since.IsZero() {
+		params.Set("tbs", "cdr:1,cd_min:"+p.since.Format("1/2/2006")) // Google's custom date range
+	}
+
+	var parsed struct {
+		Error          string `json:"error"`
+		OrganicResults []struct {
+			Title   string `json:"title"`
+			Link    string `json:"link"`
+			Snippet string `json:"snippet"`
+		} `json:"organic_results"`
+	}
+	if err := getJSON(ctx, p.client, ProviderSerpAPI, p.baseURL+"?"+params.Encode(), nil, &parsed); err != nil {
+		return nil, err
+	}
+	if parsed.Error != "" {
+		return nil, fmt.Errorf("serpapi search failed: %s", parsed.Error)
+	}
+
+	results := make([]Result, 0, len(parsed.OrganicResults))
+	for _, item := range parsed.OrganicResults {
+		if item.Link == "" {
+			continue
+		}
+		results = append(results, Result{Title: item.Title, URL: item.Link, Snippet: item.Snippet, Source: ProviderSerpAPI})
+		if len(results) == maxResults {
+			break
+		}
+	}
+	return results, nil
+}
+
+// googleProvider searches with the Google Custom Search JSON API, which returns at
+// most ten results per request
+type googleProvider struct {
+	client   *http.Client
+	apiKey   string
+	searchID string
+	language string
+	since    time.Time
+	baseURL  string
+}
+
+func (p *googleProvider) Name() string { return ProviderGoogle }
+
+func (p *googleProvider) CallsFor(maxResults int) int {
+	if maxResults <= 0 {
+		return 1
+	}
+	return (maxResults + googlePageSize - 1) / googlePageSize
+}
+
+func (p *googleProvider) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	if maxResults <= 0 {
+		maxResults = googlePageSize
+	}
+
+	var results []Result
+	for start := 1; len(results) < maxResults; start += googlePageSize {
+		params := url.Values{}
+		params.Set("key", p.apiKey)
+		params.Set("cx", p.searchID)
+		params.Set("q", query)
+		params.Set("num", strconv.Itoa(min(googlePageSize, maxResults-len(results))))
+		params.Set("start", strconv.Itoa(start))
+		if p.language != "" {
+			params.Set("lr", "lang_"+p.language)
+		}
+		if !p.since.IsZero() {
+			params.Set("dateRestrict", fmt.Sprintf("d%d", daysSince(p.since)))
+		}
+
+		var parsed struct {
+			Items []struct {
+				Title   string `json:"title"`
+				Link    string `json:"link"`
+				Snippet string `json:"snippet"`
+			} `json:"items"`
+		}
+		if err := getJSON(ctx, p.client, ProviderGoogle, p.baseURL+"?"+params.Encode(), nil, &parsed); err != nil {
+			if len(results) > 0 {
+				return results, nil // Keep the pages already fetched
+			}
+			return nil, err
+		}
+
+		for _, item := range parsed.Items {
+			results = append(results, Result{Title: item.Title, URL: item.Link, Snippet: item.Snippet, Source: ProviderGoogle})
+		}
+		if len(parsed.Items) < googlePageSize {
+			break // No more pages
+		}
+	}
+
+	if len(results) > maxResults {
+		results = results[:maxResults]
+	}
+	return results, nil
+}
+
+// duckDuckGoProvider scrapes DuckDuckGo's HTML results page. It needs no key, but
+// DuckDuckGo throttles clients that search quickly.
+type duckDuckGoProvider struct {
+	client  *http.Client
+	since   time.Time
+	baseURL string
+}
+
+func (p *duckDuckGoProvider) Name() string { return ProviderDuckDuckGo }
+
+func (p *duckDuckGoProvider) CallsFor(maxResults int) int { return 1 }
+
+func (p *duckDuckGoProvider) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	params := url.Values{"q": {query}}
+	if window := duckDuckGoWindow(p.since); window != "" {
+		params.Set("df", window)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; Briefly/1.0)")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("duckduckgo search failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(ProviderDuckDuckGo, resp); err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse duckduckgo results: %w", err)
+	}
+
+	var results []Result
+	doc.Find(".result").EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		link := sel.Find("a.result__a").First()
+		href, ok := link.Attr("href")
+		if !ok {
+			return true
+		}
+		resultURL := duckDuckGoTarget(href)
+		if resultURL == "" {
+			return true
+		}
+		results = append(results, Result{
+			Title:   strings.TrimSpace(link.Text()),
+			URL:     resultURL,
+			Snippet: strings.TrimSpace(sel.Find(".result__snippet").Text()),
+			Source:  ProviderDuckDuckGo,
+		})
+		return maxResults <= 0 || len(results) < maxResults
+	})
+	return results, nil
+}
+
+// duckDuckGoTarget unwraps DuckDuckGo's redirect links ("//duckduckgo.com/l/?uddg=...")
+// to the result URL, skipping ads
+func duckDuckGoTarget(href string) string {
+	parsed, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if target := parsed.Query().Get("uddg"); target != "" {
+		href = target
+	}
+	if strings.Contains(href, "duckduckgo.com/y.js") || !strings.HasPrefix(href, "http") {
+		return ""
+	}
+	return href
+}
+
+// getJSON fetches a provider's JSON response into out, sending header if set
+func getJSON(ctx context.Context, client *http.Client, provider, requestURL string, header http.Header, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s search failed: %w", provider, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(provider, resp); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", provider, err)
+	}
+	return nil
+}
+
+// duckDuckGoWindow is the narrowest of DuckDuckGo's date filters (past day, week,
+// month, or year) that reaches back to since. Older windows search all time.
+func duckDuckGoWindow(since time.Time) string {
+	if since.IsZero() {
+		return ""
+	}
+	switch days := daysSince(since); {
+	case days <= 1:
+		return "d"
+	case days <= 7:
+		return "w"
+	case days <= 31:
+		return "m"
+	case days <= 366:
+		return "y"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,157 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSerpAPIProvider_Search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") != "key" || r.URL.Query().Get("num") != "2" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"organic_results":[
+			{"title":"A","link":"https://a.example","snippet":"about a"},
+			{"title":"No link"},
+			{"title":"B","link":"https://b.example","snippet":"about b"},
+			{"title":"C","link":"https://c.example","snippet":"about c"}]}`)
+	}))
+	defer server.Close()
+
+	provider := &serpAPIProvider{client: server.Client(), apiKey: "key", baseURL: server.URL}
+	results, err := provider.Search(context.Background(), "query", 2)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 || results[1].URL != "https://b.example" || results[0].Source != ProviderSerpAPI {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestGoogleProvider_Paginates(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		num, _ := strconv.Atoi(r.URL.Query().Get("num"))
+		fmt.Fprint(w, `{"items":[`)
+		for i := 0; i < num; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"title":"R%d","link":"https://example.com/%d"}`, start+i, start+i)
+		}
+		fmt.Fprint(w, `]}`)
+	}))
+	defer server.Close()
+
+	provider := &googleProvider{client: server.Client(), apiKey: "key", searchID: "cx", baseURL: server.URL}
+	results, err := provider.Search(context.Background(), "query", 15)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 15 || requests != 2 || results[14].URL != "https://example.com/15" {
+		t.Errorf("expected 15 results over 2 requests, got %d over %d", len(results), requests)
+	}
+	if calls := provider.CallsFor(15); calls != 2 {
+		t.Errorf("CallsFor(15) = %d, want 2", calls)
+	}
+}
+
+func TestDuckDuckGoProvider_Search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body>
+			<div class="result"><a class="result__a" href="https://duckduckgo.com/y.js?ad=1">Ad</a></div>
+			<div class="result"><a class="result__a" href="//duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2Fpost">Post</a>
+				<a class="result__snippet">A snippet</a></div>
+		</body></html>`)
+	}))
+	defer server.Close()
+
+	provider := &duckDuckGoProvider{client: server.Client(), baseURL: server.URL}
+	results, err := provider.Search(context.Background(), "query", 5)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].URL != "https://example.com/post" || results[0].Snippet != "A snippet" {
+		t.Errorf("expected the ad skipped and the redirect unwrapped, got %+v", results)
+	}
+}
+
+func TestProvider_QuotaStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	provider := &serpAPIProvider{client: server.Client(), apiKey: "key", baseURL: server.URL}
+	if _, err := provider.Search(context.Background(), "query", 5); err == nil {
+		t.Fatal("expected an error for HTTP 429")
+	}
+}
+
+func TestNewProvider_RequiresKeys(t *testing.T) {
+	if _, err := NewProvider(ProviderSerpAPI, Options{}); err == nil {
+		t.Error("expected SerpAPI without a key to fail")
+	}
+	if _, err := NewProvider(ProviderGoogle, Options{APIKey: "key"}); err == nil {
+		t.Error("expected Google without a search ID to fail")
+	}
+	if _, err := NewProvider("bing", Options{}); err == nil {
+		t.Error("expected an unknown provider to fail")
+	}
+}
+
+func TestProviders_Since(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	since := time.Now().AddDate(0, 0, -30)
+	for _, name := range []string{ProviderSerpAPI, ProviderGoogle, ProviderSemanticScholar} {
+		provider, err := NewProvider(name, Options{APIKey: "key", SearchID: "cx", Since: since})
+		if err != nil {
+			t.Fatalf("NewProvider(%s) failed: %v", name, err)
+		}
+		switch p := provider.(type) {
+		case *serpAPIProvider:
+			p.baseURL, p.client = server.URL, server.Client()
+		case *googleProvider:
+			p.baseURL, p.client = server.URL, server.Client()
+		case *semanticScholarProvider:
+			p.baseURL, p.client = server.URL, server.Client()
+		}
+		if _, err := provider.Search(context.Background(), "query", 5); err != nil {
+			t.Fatalf("%s search failed: %v", name, err)
+		}
+	}
+
+	if got := queries[0].Get("tbs"); got != "cdr:1,cd_min:"+since.Format("1/2/2006") {
+		t.Errorf("serpapi tbs = %q", got)
+	}
+	if got := queries[1].Get("dateRestrict"); got != "d30" {
+		t.Errorf("google dateRestrict = %q, want d30", got)
+	}
+	if got := queries[2].Get("publicationDateOrYear"); got != since.Format("2006-01-02")+":" {
+		t.Errorf("semanticscholar publicationDateOrYear = %q", got)
+	}
+
+	windows := map[int]string{1: "d", 5: "w", 30: "m", 200: "y", 1000: ""}
+	for days, want := range windows {
+		if got := duckDuckGoWindow(time.Now().AddDate(0, 0, -days)); got != want {
+			t.Errorf("duckDuckGoWindow(%d days) = %q, want %q", days, got, want)
+		}
+	}
+	if duckDuckGoWindow(time.Time{}) != "" {
+		t.Error("expected no window without a since date")
+	}
+}
@@ -0,0 +1,113 @@
+// Package search queries web search providers (SerpAPI, Google Custom Search,
+// DuckDuckGo) and academic indexes (arXiv, Semantic Scholar) for deep research.
+// Providers are wrapped with per-provider rate limits and daily quotas tracked in
+// the cache, so a research run can't burn a day's paid searches in one session.
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Provider names
+const (
+	ProviderGoogle     = "google"
+	ProviderSerpAPI    = "serpapi"
+	ProviderDuckDuckGo = "duckduckgo"
+
+	ProviderArXiv           = "arxiv"
+	ProviderSemanticScholar = "semanticscholar"
+)
+
+// defaultTimeout bounds a provider request when no timeout is configured
+const defaultTimeout = 15 * time.Second
+
+// Result is one web search result
+type Result struct {
+	Title   string
+	URL     string
+	Snippet string // Abstract, for papers
+	Source  string // Provider that returned it
+	Paper   *Paper // Set by academic providers
+}
+
+// Paper is the bibliographic metadata academic providers return with a result
+type Paper struct {
+	Authors   []string
+	Published time.Time // Zero when unknown
+	Venue     string
+	Citations int // -1 when the provider doesn't count citations
+	ArXivID   string
+}
+
+// Provider searches the web
+type Provider interface {
+	// Name identifies the provider for rate limits and quotas
+	Name() string
+	// Search returns up to maxResults results for query
+	Search(ctx context.Context, query string, maxResults int) ([]Result, error)
+	// CallsFor is how many billable requests a search for maxResults results makes
+	CallsFor(maxResults int) int
+}
+
+// Options configures a provider
+type Options struct {
+	APIKey   string        // Google, SerpAPI, and Semantic Scholar (optional there)
+	SearchID string        // Google Custom Search engine ID
+	Language string        // Result language, e.g. "en"
+	Timeout  time.Duration // Per-request timeout
+	Since    time.Time     // Only results published since then, where the provider can filter (zero = any time)
+}
+
+// NewProvider creates the named provider
+func NewProvider(name string, opts Options) (Provider, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	switch name {
+	case ProviderSerpAPI:
+		if opts.APIKey == "" {
+			return nil, fmt.Errorf("SerpAPI requires an API key. Set SERPAPI_API_KEY")
+		}
+		return &serpAPIProvider{client: client, apiKey: opts.APIKey, language: opts.Language, since: opts.Since, baseURL: serpAPIURL}, nil
+	case ProviderGoogle:
+		if opts.APIKey == "" || opts.SearchID == "" {
+			return nil, fmt.Errorf("Google Custom Search requires an API key and search ID. Set GOOGLE_CUSTOM_SEARCH_API_KEY and GOOGLE_CUSTOM_SEARCH_ID")
+		}
+		return &googleProvider{client: client, apiKey: opts.APIKey, searchID: opts.SearchID, language: opts.Language, since: opts.Since, baseURL: googleSearchURL}, nil
+	case ProviderDuckDuckGo:
+		return &duckDuckGoProvider{client: client, since: opts.Since, baseURL: duckDuckGoURL}, nil
+	case ProviderArXiv:
+		return &arXivProvider{client: client, since: opts.Since, baseURL: arXivURL}, nil
+	case ProviderSemanticScholar:
+		return &semanticScholarProvider{client: client, apiKey: opts.APIKey, since: opts.Since, baseURL: semanticScholarURL}, nil
+	default:
+		return nil, fmt.Errorf("unknown search provider: %s (supported: %s)", name, strings.Join(ProviderNames, ", "))
+	}
+}
+
+// ProviderNames lists the supported providers
+var ProviderNames = []string{ProviderGoogle, ProviderSerpAPI, ProviderDuckDuckGo, ProviderArXiv, ProviderSemanticScholar}
+
+// daysSince is how many days back since reaches, at least one
+func daysSince(since time.Time) int {
+	return max(int(math.Round(time.Since(since).Hours()/24)), 1)
+}
+
+// checkStatus turns a non-2xx provider response into an error
+func checkStatus(provider string, resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("%s rate limit or quota exceeded (HTTP 429)", provider)
+	}
+	return fmt.Errorf("%s search failed: HTTP %d", provider, resp.StatusCode)
+}
@@ -0,0 +1,262 @@
+// Package sentiment scores the tone of digest articles. Articles are analyzed in
+// batches, several per prompt, and results are cached by content hash and analyzer
+// version, so a digest run only analyzes articles it has not seen before.
+package sentiment
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AnalyzerVersion identifies the prompt and parsing below. Bump it when either
+// changes, so cached sentiments from the old analyzer are not reused.
+const AnalyzerVersion = "batch-v1"
+
+// DefaultBatchSize is how many articles one prompt analyzes
+const DefaultBatchSize = 10
+
+// maxArticleText caps the article text sent per article, like summary embeddings
+const maxArticleText = 1500
+
+// Sentiment labels
+const (
+	LabelPositive = "positive"
+	LabelNegative = "negative"
+	LabelNeutral  = "neutral"
+)
+
+// Cache stores sentiments between runs. *store.Store implements it.
+type Cache interface {
+	GetArticleSentiments(contentHashes []string, analyzerVersion string) (map[string]core.ArticleSentiment, error)
+	SaveArticleSentiments(sentiments []core.ArticleSentiment) error
+}
+
+// Stats reports where a run's sentiments came from
+type Stats struct {
+	Cached   int // Reused from the cache
+	Analyzed int // Analyzed by this run
+	Failed   int // Not analyzed: the batch failed or left the article out
+	Batches  int // Prompts sent
+}
+
+// Analyzer scores article sentiment in batches, reusing cached results
+type Analyzer struct {
+	generator llm.Generator
+	cache     Cache // nil analyzes every article
+	batchSize int
+}
+
+// NewAnalyzer creates an analyzer. cache may be nil.
+func NewAnalyzer(generator llm.Generator, cache Cache) *Analyzer {
+	return &Analyzer{generator: generator, cache: cache, batchSize: DefaultBatchSize}
+}
+
+// SetBatchSize sets how many articles one prompt analyzes (<= 0 keeps the default)
+func (a *Analyzer) SetBatchSize(n int) {
+	if n > 0 {
+		a.batchSize = n
+	}
+}
+
+// ContentHash is the key an article's sentiment is cached under, so it is reused
+// only while the article's title and text are unchanged
+func ContentHash(article core.Article) string {
+	sum := sha256.Sum256([]byte(article.Title + "\n" + article.CleanedText))
+	return fmt.Sprintf("%x", sum)
+}
+
+// AnalyzeBatch returns the sentiment of each article, keyed by article ID. Cached
+// sentiments are reused; the rest are analyzed batchSize articles per prompt and
+// cached. A failed batch leaves its articles out rather than failing the run.
+func (a *Analyzer) AnalyzeBatch(ctx context.Context, articles []core.Article) (map[string]core.ArticleSentiment, Stats) {
+	var stats Stats
+	results := make(map[string]core.ArticleSentiment, len(articles))
+
+	hashes := make([]string, len(articles))
+	for i, article := range articles {
+		hashes[i] = ContentHash(article)
+	}
+
+	cached := map[string]core.ArticleSentiment{}
+	if a.cache != nil {
+		if found, err := a.cache.GetArticleSentiments(hashes, AnalyzerVersion); err == nil {
+			cached = found
+		}
+	}
+
+	// Articles with the same text (e.g. the same page under two URLs) are analyzed once
+	var pending []core.Article
+	var pendingHashes []string
+	queued := make(map[string]bool)
+	for i, article := range articles {
+		if sentiment, ok := cached[hashes[i]]; ok {
+			results[article.ID] = sentiment
+			stats.Cached++
+			continue
+		}
+		if !queued[hashes[i]] {
+			queued[hashes[i]] = true
+			pending = append(pending, article)
+			pendingHashes = append(pendingHashes, hashes[i])
+		}
+	}
+
+	analyzed := make(map[string]core.ArticleSentiment)
+	var fresh []core.ArticleSentiment
+	for start := 0; start < len(pending); start += a.batchSize {
+		end := min(start+a.batchSize, len(pending))
+		stats.Batches++
+
+		response, err := a.generator.GenerateText(ctx, buildBatchPrompt(pending[start:end]), llm.TextGenerationOptions{
+			Temperature: 0.1,
+			MaxTokens:   int32(64 * (end - start)),
+		})
+		if err != nil {
+			continue
+		}
+
+		now := time.Now().UTC()
+		for index, sentiment := range parseBatchResponse(response, end-start) {
+			sentiment.ContentHash = pendingHashes[start+index]
+			sentiment.AnalyzerVersion = AnalyzerVersion
+			sentiment.AnalyzedAt = now
+			analyzed[sentiment.ContentHash] = sentiment
+			fresh = append(fresh, sentiment)
+		}
+	}
+
+	for i, article := range articles {
+		if _, ok := results[article.ID]; ok {
+			continue
+		}
+		if sentiment, ok := analyzed[hashes[i]]; ok {
+			results[article.ID] = sentiment
+			stats.Analyzed++
+		} else {
+			stats.Failed++
+		}
+	}
+
+	if a.cache != nil && len(fresh) > 0 {
+		_ = a.cache.SaveArticleSentiments(fresh) // A failed save only costs a recompute next run
+	}
+	return results, stats
+}
+
+// Apply copies sentiments onto their articles' sentiment fields
+func Apply(articles []core.Article, sentiments map[string]core.ArticleSentiment) {
+	for i := range articles {
+		if sentiment, ok := sentiments[articles[i].ID]; ok {
+			articles[i].SentimentScore = sentiment.Score
+			articles[i].SentimentLabel = sentiment.Label
+			articles[i].SentimentEmoji = sentiment.Emoji
+		}
+	}
+}
+
+// Overall describes the mix of sentiments, e.g. "😊 6 positive · 😐 3 neutral ·
+// 😞 1 negative (average +0.32)". It is empty without sentiments.
+func Overall(sentiments map[string]core.ArticleSentiment) string {
+	if len(sentiments) == 0 {
+		return ""
+	}
+
+	counts := make(map[string]int)
+	var total float64
+	for _, sentiment := range sentiments {
+		counts[sentiment.Label]++
+		total += sentiment.Score
+	}
+
+	var parts []string
+	for _, label := range []string{LabelPositive, LabelNeutral, LabelNegative} {
+		if counts[label] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d %s", Emoji(label), counts[label], label))
+		}
+	}
+	return fmt.Sprintf("%s (average %+.2f)", strings.Join(parts, " · "), total/float64(len(sentiments)))
+}
+
+// Emoji is the emoji shown for a sentiment label
+func Emoji(label string) string {
+	switch label {
+	case LabelPositive:
+		return "😊"
+	case LabelNegative:
+		return "😞"
+	default:
+		return "😐"
+	}
+}
+
+// buildBatchPrompt asks for one scored line per numbered article
+func buildBatchPrompt(articles []core.Article) string {
+	var prompt strings.Builder
+	prompt.WriteString("Rate the overall sentiment of each article below, as a reader of a tech news digest would feel it.\n\n")
+	for i, article := range articles {
+		text := article.CleanedText
+		if len(text) > maxArticleText {
+			text = text[:maxArticleText]
+		}
+		prompt.WriteString(fmt.Sprintf("ARTICLE %d: %s\n%s\n\n", i+1, article.Title, strings.TrimSpace(text)))
+	}
+	prompt.WriteString(fmt.Sprintf(`For each of the %d articles, respond with exactly one line in this format, and nothing else:
+<article number> | <score from -1.0 (very negative) to 1.0 (very positive)> | <positive, negative, or neutral>
+
+Example:
+1 | 0.6 | positive
+2 | -0.2 | neutral`, len(articles)))
+	return prompt.String()
+}
+
+var batchLinePattern = regexp.MustCompile(`^\D*?(\d+)\s*[|:.)]\s*([-+]?\d*\.?\d+)\s*\|\s*([A-Za-z]+)`)
+
+// parseBatchResponse reads "n | score | label" lines, keyed by 0-based article index.
+// Lines for articles outside 1..count or repeated numbers are ignored.
+func parseBatchResponse(response string, count int) map[int]core.ArticleSentiment {
+	sentiments := make(map[int]core.ArticleSentiment)
+	for _, line := range strings.Split(response, "\n") {
+		match := batchLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		number, err := strconv.Atoi(match[1])
+		if err != nil || number < 1 || number > count {
+			continue
+		}
+		if _, seen := sentiments[number-1]; seen {
+			continue
+		}
+		score, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		score = max(-1.0, min(1.0, score))
+
+		label := strings.ToLower(match[3])
+		if label != LabelPositive && label != LabelNegative && label != LabelNeutral {
+			label = labelForScore(score)
+		}
+		sentiments[number-1] = core.ArticleSentiment{Score: score, Label: label, Emoji: Emoji(label)}
+	}
+	return sentiments
+}
+
+// labelForScore labels a score when the model's label is unusable
+func labelForScore(score float64) string {
+	switch {
+	case score >= 0.25:
+		return LabelPositive
+	case score <= -0.25:
+		return LabelNegative
+	default:
+		return LabelNeutral
+	}
+}
@@ -0,0 +1,131 @@
+package sentiment
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type fakeGenerator struct {
+	prompts []string
+	fail    bool
+}
+
+func (f *fakeGenerator) GenerateText(ctx context.Context, prompt string, options llm.TextGenerationOptions) (string, error) {
+	f.prompts = append(f.prompts, prompt)
+	if f.fail {
+		return "", fmt.Errorf("rate limited")
+	}
+	var lines []string
+	for i := 1; strings.Contains(prompt, fmt.Sprintf("ARTICLE %d:", i)); i++ {
+		lines = append(lines, fmt.Sprintf("%d | 0.5 | positive", i))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+type memoryCache struct {
+	sentiments map[string]core.ArticleSentiment
+}
+
+func (m *memoryCache) GetArticleSentiments(hashes []string, version string) (map[string]core.ArticleSentiment, error) {
+	found := make(map[string]core.ArticleSentiment)
+	for _, hash := range hashes {
+		if sentiment, ok := m.sentiments[hash+"@"+version]; ok {
+			found[hash] = sentiment
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryCache) SaveArticleSentiments(sentiments []core.ArticleSentiment) error {
+	for _, sentiment := range sentiments {
+		m.sentiments[sentiment.ContentHash+"@"+sentiment.AnalyzerVersion] = sentiment
+	}
+	return nil
+}
+
+func testArticles(n int) []core.Article {
+	articles := make([]core.Article, n)
+	for i := range articles {
+		articles[i] = core.Article{ID: fmt.Sprintf("a%d", i), Title: fmt.Sprintf("Title %d", i), CleanedText: fmt.Sprintf("Text %d", i)}
+	}
+	return articles
+}
+
+func TestAnalyzer_AnalyzeBatchCachesAndReuses(t *testing.T) {
+	cache := &memoryCache{sentiments: map[string]core.ArticleSentiment{}}
+	generator := &fakeGenerator{}
+	analyzer := NewAnalyzer(generator, cache)
+	analyzer.SetBatchSize(2)
+
+	results, stats := analyzer.AnalyzeBatch(context.Background(), testArticles(3))
+	if len(results) != 3 || stats.Analyzed != 3 || stats.Cached != 0 || stats.Batches != 2 {
+		t.Fatalf("first run: %d results, stats %+v", len(results), stats)
+	}
+	if results["a0"].Label != LabelPositive || results["a0"].AnalyzerVersion != AnalyzerVersion {
+		t.Errorf("unexpected sentiment %+v", results["a0"])
+	}
+
+	// A second run only analyzes the new article
+	generator.prompts = nil
+	results, stats = analyzer.AnalyzeBatch(context.Background(), testArticles(4))
+	if len(results) != 4 || stats.Cached != 3 || stats.Analyzed != 1 || stats.Batches != 1 {
+		t.Fatalf("second run: %d results, stats %+v", len(results), stats)
+	}
+	if len(generator.prompts) != 1 || !strings.Contains(generator.prompts[0], "Title 3") || strings.Contains(generator.prompts[0], "Title 0") {
+		t.Errorf("expected one prompt with only the new article, got %v", generator.prompts)
+	}
+}
+
+func TestAnalyzer_AnalyzeBatchFailure(t *testing.T) {
+	cache := &memoryCache{sentiments: map[string]core.ArticleSentiment{}}
+	analyzer := NewAnalyzer(&fakeGenerator{fail: true}, cache)
+
+	results, stats := analyzer.AnalyzeBatch(context.Background(), testArticles(2))
+	if len(results) != 0 || stats.Failed != 2 {
+		t.Errorf("expected both articles to fail, got %d results, stats %+v", len(results), stats)
+	}
+	if len(cache.sentiments) != 0 {
+		t.Error("expected nothing cached after a failed batch")
+	}
+}
+
+func TestParseBatchResponse(t *testing.T) {
+	response := `1 | 0.8 | positive
+2 | -1.7 | negative
+ARTICLE 3: 0.1 | meh
+2 | 0.9 | positive
+7 | 0.5 | positive
+not a rating`
+
+	sentiments := parseBatchResponse(response, 3)
+	if len(sentiments) != 3 {
+		t.Fatalf("expected 3 sentiments, got %+v", sentiments)
+	}
+	if sentiments[0].Label != LabelPositive || sentiments[0].Emoji != "😊" {
+		t.Errorf("article 1 = %+v", sentiments[0])
+	}
+	if sentiments[1].Score != -1.0 || sentiments[1].Label != LabelNegative {
+		t.Errorf("expected article 2 clamped and first rating kept, got %+v", sentiments[1])
+	}
+	if sentiments[2].Label != LabelNeutral {
+		t.Errorf("expected an unusable label to fall back to the score, got %+v", sentiments[2])
+	}
+}
+
+func TestOverall(t *testing.T) {
+	if Overall(nil) != "" {
+		t.Error("expected no overall sentiment without articles")
+	}
+	got := Overall(map[string]core.ArticleSentiment{
+		"a": {Score: 0.6, Label: LabelPositive},
+		"b": {Score: 0.4, Label: LabelPositive},
+		"c": {Score: -0.4, Label: LabelNegative},
+	})
+	if got != "😊 2 positive · 😞 1 negative (average +0.20)" {
+		t.Errorf("Overall = %q", got)
+	}
+}
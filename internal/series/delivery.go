@@ -0,0 +1,280 @@
+package series
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// slackMessageLimit keeps Slack messages under the 40,000 characters at which Slack
+// truncates them
+const slackMessageLimit = 39000
+
+// slackAPIURL is Slack's Web API, used with a bot token to thread an issue's parts
+const slackAPIURL = "https://slack.com/api"
+
+// partLabelReserve is the room kept in each part for its "(n/m)" label
+const partLabelReserve = 16
+
+// maxRateLimitRetries is how often a rate-limited message is retried before failing
+const maxRateLimitRetries = 3
+
+// maxRetryWait caps how long delivery waits on a rate limit before retrying
+const maxRetryWait = 30 * time.Second
+
+// Message is one message of an issue as delivered to a channel
+type Message struct {
+	Text string
+	Part int // 0 for the table of contents or a single-message issue, then 1..Parts
+}
+
+// PlanMessages splits a rendered issue into messages of at most limit bytes. An
+// issue that fits is one message. A longer issue opens with a table of contents
+// (the title, its sections, and how many parts follow), then its sections packed
+// into parts labeled "(n/m)", in order. Sections too long for one part are split at
+// paragraph or line boundaries.
+func PlanMessages(content string, limit int) []Message {
+	if len(content) <= limit {
+		return []Message{{Text: content}}
+	}
+	content = strings.TrimSpace(content)
+
+	title, sections := splitSections(content)
+
+	var parts []string
+	var current strings.Builder
+	for _, section := range sections {
+		for _, piece := range splitMessage(section, limit-partLabelReserve) {
+			if current.Len() > 0 && current.Len()+2+len(piece) > limit-partLabelReserve {
+				parts = append(parts, current.String())
+				current.Reset()
+			}
+			if current.Len() > 0 {
+				current.WriteString("\n\n")
+			}
+			current.WriteString(piece)
+		}
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+
+	messages := make([]Message, 0, len(parts)+1)
+	messages = append(messages, Message{Text: tableOfContents(title, sections, len(parts), limit)})
+	for i, part := range parts {
+		messages = append(messages, Message{Text: fmt.Sprintf("(%d/%d) %s", i+1, len(parts), part), Part: i + 1})
+	}
+	return messages
+}
+
+// splitSections splits an issue at its "## " headings. The title is the first "# "
+// heading (or first line), and any text before the first section is its own section.
+func splitSections(content string) (string, []string) {
+	lines := strings.Split(content, "\n")
+	title := strings.TrimSpace(strings.TrimLeft(lines[0], "#*_ "))
+	title = strings.TrimRight(title, "*_ ")
+
+	var sections []string
+	var current []string
+	flush := func() {
+		if section := strings.TrimSpace(strings.Join(current, "\n")); section != "" {
+			sections = append(sections, section)
+		}
+		current = nil
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			flush()
+		}
+		current = append(current, line)
+	}
+	flush()
+	return title, sections
+}
+
+// tableOfContents is an issue's first message when it is sent in parts
+func tableOfContents(title string, sections []string, parts int, limit int) string {
+	var toc strings.Builder
+	toc.WriteString(title)
+	toc.WriteString("\n\nIn this issue:\n")
+	for _, section := range sections {
+		heading, _, _ := strings.Cut(section, "\n")
+		if !strings.HasPrefix(heading, "## ") {
+			continue
+		}
+		line := "• " + strings.TrimSpace(strings.TrimPrefix(heading, "## ")) + "\n"
+		if toc.Len()+len(line) > limit-64 {
+			toc.WriteString("• …\n")
+			break
+		}
+		toc.WriteString(line)
+	}
+	toc.WriteString(fmt.Sprintf("\n%d part(s) follow.", parts))
+	return toc.String()
+}
+
+// deliverSlack posts an issue to Slack. With a bot token and channel, its parts are
+// threaded under the table of contents; with only a webhook, they follow it as
+// separate messages. Parts are posted one at a time, in order.
+func (s *Series) deliverSlack(ctx context.Context, client *http.Client, content string) error {
+	messages := PlanMessages(content, slackMessageLimit)
+	s.slackPosted = nil
+
+	if s.slackBotToken != "" && s.slackChannel != "" {
+		var threadTS string
+		for _, message := range messages {
+			ts, err := s.postSlackMessage(ctx, client, message.Text, threadTS)
+			if err != nil {
+				return partError(message, len(messages), err)
+			}
+			s.slackPosted = append(s.slackPosted, ts)
+			if threadTS == "" {
+				threadTS = ts
+			}
+		}
+		return nil
+	}
+
+	for _, message := range messages {
+		if err := postWebhook(ctx, client, s.slackWebhook, map[string]string{"text": message.Text}); err != nil {
+			return partError(message, len(messages), err)
+		}
+	}
+	return nil
+}
+
+// SlackMessages returns the channel and timestamps of the Slack messages the last
+// Deliver posted. Only bot-token delivery knows them; webhooks return none.
+func (s *Series) SlackMessages() (string, []string) {
+	return s.slackChannel, s.slackPosted
+}
+
+// deliverDiscord posts an issue to a Discord webhook, the table of contents first and
+// then each part as a follow-up message. Each post waits for Discord to create the
+// message, so parts arrive in order.
+func (s *Series) deliverDiscord(ctx context.Context, client *http.Client, content string) error {
+	webhook := s.discordWebhook
+	if strings.Contains(webhook, "?") {
+		webhook += "&wait=true"
+	} else {
+		webhook += "?wait=true"
+	}
+
+	messages := PlanMessages(content, discordMessageLimit)
+	for _, message := range messages {
+		if err := postWebhook(ctx, client, webhook, map[string]string{"content": message.Text}); err != nil {
+			return partError(message, len(messages), err)
+		}
+	}
+	return nil
+}
+
+// partError says which message of an issue failed to post
+func partError(message Message, total int, err error) error {
+	if total == 1 {
+		return err
+	}
+	if message.Part == 0 {
+		return fmt.Errorf("table of contents: %w", err)
+	}
+	return fmt.Errorf("part %d/%d: %w", message.Part, total-1, err)
+}
+
+// postSlackMessage posts text with chat.postMessage, as a reply when threadTS is set,
+// and returns the new message's timestamp
+func (s *Series) postSlackMessage(ctx context.Context, client *http.Client, text, threadTS string) (string, error) {
+	payload := map[string]string{"channel": s.slackChannel, "text": text}
+	if threadTS != "" {
+		payload["thread_ts"] = threadTS
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	var parsed struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		TS    string `json:"ts"`
+	}
+	err = postWithRetry(ctx, client, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.slackAPIURL+"/chat.postMessage", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		req.Header.Set("Authorization", "Bearer "+s.slackBotToken)
+		return req, nil
+	}, func(resp *http.Response) error {
+		return json.NewDecoder(resp.Body).Decode(&parsed)
+	})
+	if err != nil {
+		return "", err
+	}
+	if !parsed.OK {
+		return "", fmt.Errorf("slack API error: %s", parsed.Error)
+	}
+	return parsed.TS, nil
+}
+
+// postWithRetry sends the request built by newRequest, retrying when the channel
+// rate limits it (HTTP 429) after the wait it asks for. Any other non-2xx response
+// is an error; a 2xx response is passed to handle.
+func postWithRetry(ctx context.Context, client *http.Client, newRequest func() (*http.Request, error), handle func(*http.Response) error) error {
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < maxRateLimitRetries {
+			wait := retryAfter(resp)
+			resp.Body.Close()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			resp.Body.Close()
+			return fmt.Errorf("webhook returned %s", resp.Status)
+		}
+		err = nil
+		if handle != nil {
+			err = handle(resp)
+		}
+		resp.Body.Close()
+		return err
+	}
+}
+
+// retryAfter reads how long a rate-limited response asks to wait, from the
+// Retry-After header or Discord's retry_after field, capped at maxRetryWait
+func retryAfter(resp *http.Response) time.Duration {
+	wait := time.Second
+	if seconds, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil {
+		wait = time.Duration(seconds * float64(time.Second))
+	} else {
+		var body struct {
+			RetryAfter float64 `json:"retry_after"`
+		}
+		if data, err := io.ReadAll(io.LimitReader(resp.Body, 4096)); err == nil && json.Unmarshal(data, &body) == nil && body.RetryAfter > 0 {
+			wait = time.Duration(body.RetryAfter * float64(time.Second))
+		}
+	}
+	return min(wait, maxRetryWait)
+}
@@ -0,0 +1,248 @@
+// Package series models named digest series (e.g. "AI Weekly" and "Platform Notes"
+// produced from one machine). Each series titles its issues from its own template,
+// delivers to its own channels, and compares topics only against its own history.
+package series
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/render"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultTitleTemplate titles an issue as "<series name>: <generated title>"
+const DefaultTitleTemplate = "{{.Name}}: {{.Title}}"
+
+// discordMessageLimit is Discord's maximum message length
+const discordMessageLimit = 2000
+
+var keyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Options configures a series
+type Options struct {
+	Name           string // Display name; defaults to the key
+	TitleTemplate  string // text/template over TitleData; defaults to DefaultTitleTemplate
+	SlackWebhook   string // Incoming webhook the rendered issue is posted to
+	SlackBotToken  string // With SlackChannel, posts through the Web API so long issues are threaded
+	SlackChannel   string
+	DiscordWebhook string
+	Audience       string // Who issues are written for (see core.Audiences); empty for the default
+}
+
+// Series is a named digest series
+type Series struct {
+	Key      string
+	Name     string
+	Audience core.Audience // Keeps every issue written for the same readers
+
+	title          *template.Template
+	slackWebhook   string
+	slackBotToken  string
+	slackChannel   string
+	slackAPIURL    string
+	discordWebhook string
+
+	slackPosted []string // Timestamps of the Slack messages posted by the last Deliver
+}
+
+// TitleData is the data available to a series title template
+type TitleData struct {
+	Name   string // Series display name
+	Title  string // Title generated for this issue
+	Date   string // Issue date (YYYY-MM-DD)
+	Issue  string // Scheduled issue name from --issue, if any
+	Number int    // Issue number within the series (1-based)
+}
+
+// TopicTrend compares one of an issue's topics against earlier issues of the series
+type TopicTrend struct {
+	Topic  string
+	New    bool // Not covered by any earlier issue
+	Streak int  // Consecutive issues, including this one, that covered the topic
+}
+
+// New creates a series. Keys are lowercase letters, digits, and hyphens so they can
+// name directories and store rows.
+func New(key string, opts Options) (*Series, error) {
+	if !ValidKey(key) {
+		return nil, fmt.Errorf("invalid series key %q (use lowercase letters, digits, and hyphens)", key)
+	}
+
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		name = key
+	}
+
+	titleTemplate := opts.TitleTemplate
+	if titleTemplate == "" {
+		titleTemplate = DefaultTitleTemplate
+	}
+	audience, err := core.ParseAudience(opts.Audience)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audience for series %s: %w", key, err)
+	}
+
+	tmpl, err := template.New(key).Option("missingkey=error").Parse(titleTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid title template for series %s: %w", key, err)
+	}
+
+	return &Series{
+		Key:            key,
+		Name:           name,
+		Audience:       audience,
+		title:          tmpl,
+		slackWebhook:   opts.SlackWebhook,
+		slackBotToken:  opts.SlackBotToken,
+		slackChannel:   opts.SlackChannel,
+		slackAPIURL:    slackAPIURL,
+		discordWebhook: opts.DiscordWebhook,
+	}, nil
+}
+
+// ValidKey reports whether key can name a series
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// Title renders the series title template. If the template fails, the generated
+// title is returned unchanged.
+func (s *Series) Title(data TitleData) string {
+	data.Name = s.Name
+
+	var buf bytes.Buffer
+	if err := s.title.Execute(&buf, data); err != nil {
+		return data.Title
+	}
+
+	title := strings.TrimSpace(buf.String())
+	if title == "" {
+		return data.Title
+	}
+	return title
+}
+
+// HasDelivery reports whether the series has any delivery channel configured
+func (s *Series) HasDelivery() bool {
+	return s.hasSlack() || s.discordWebhook != ""
+}
+
+// hasSlack reports whether the series posts to Slack, by webhook or bot token
+func (s *Series) hasSlack() bool {
+	return s.slackWebhook != "" || (s.slackBotToken != "" && s.slackChannel != "")
+}
+
+// Deliver posts a rendered issue to each configured channel, returning the names of
+// the channels it reached. Issues over a channel's message limit are sent as a table
+// of contents followed by numbered parts (see PlanMessages). An error is returned if
+// any channel failed.
+func (s *Series) Deliver(ctx context.Context, content string) ([]string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	content = render.Output(content)
+
+	var delivered, failures []string
+	if s.hasSlack() {
+		if err := s.deliverSlack(ctx, client, content); err != nil {
+			failures = append(failures, fmt.Sprintf("slack: %v", err))
+		} else {
+			delivered = append(delivered, "slack")
+		}
+	}
+	if s.discordWebhook != "" {
+		if err := s.deliverDiscord(ctx, client, content); err != nil {
+			failures = append(failures, fmt.Sprintf("discord: %v", err))
+		} else {
+			delivered = append(delivered, "discord")
+		}
+	}
+
+	if len(failures) > 0 {
+		return delivered, fmt.Errorf("delivery failed: %s", strings.Join(failures, "; "))
+	}
+	return delivered, nil
+}
+
+// CompareTopics compares an issue's topics with earlier issues of the series, given
+// newest first. Topics match case-insensitively.
+func CompareTopics(current []string, history [][]string) []TopicTrend {
+	previous := make([]map[string]bool, len(history))
+	for i, topics := range history {
+		previous[i] = make(map[string]bool, len(topics))
+		for _, topic := range topics {
+			previous[i][strings.ToLower(topic)] = true
+		}
+	}
+
+	trends := make([]TopicTrend, 0, len(current))
+	seen := make(map[string]bool)
+	for _, topic := range current {
+		key := strings.ToLower(topic)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		trend := TopicTrend{Topic: topic, Streak: 1, New: true}
+		for i, issue := range previous {
+			if !issue[key] {
+				continue
+			}
+			trend.New = false
+			if i == trend.Streak-1 {
+				trend.Streak++
+			}
+		}
+		trends = append(trends, trend)
+	}
+	return trends
+}
+
+// postWebhook posts payload as JSON, retrying when rate limited, and treats any
+// other non-2xx response as an error
+func postWebhook(ctx context.Context, client *http.Client, url string, payload map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return postWithRetry(ctx, client, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, nil)
+}
+
+// splitMessage splits content into parts of at most limit bytes, breaking at
+// paragraph or line boundaries where possible
+func splitMessage(content string, limit int) []string {
+	var parts []string
+	for len(content) > limit {
+		cut := strings.LastIndex(content[:limit], "\n\n")
+		if cut <= 0 {
+			cut = strings.LastIndex(content[:limit], "\n")
+		}
+		if cut <= 0 {
+			cut = limit
+			for cut > 0 && !utf8.RuneStart(content[cut]) {
+				cut--
+			}
+		}
+		parts = append(parts, strings.TrimSpace(content[:cut]))
+		content = strings.TrimLeft(content[cut:], "\n")
+	}
+	if strings.TrimSpace(content) != "" {
+		parts = append(parts, strings.TrimSpace(content))
+	}
+	return parts
+}
@@ -0,0 +1,228 @@
+package series
+
+import (
+	"briefly/internal/core"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTitle_RendersTemplate(t *testing.T) {
+	ser, err := New("ai-weekly", Options{Name: "AI Weekly", TitleTemplate: "{{.Name}} #{{.Number}} ({{.Date}}): {{.Title}}"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	got := ser.Title(TitleData{Title: "Agents Ship", Date: "2025-06-03", Number: 12})
+	if want := "AI Weekly #12 (2025-06-03): Agents Ship"; got != want {
+		t.Errorf("Title = %q, want %q", got, want)
+	}
+}
+
+func TestNew_Defaults(t *testing.T) {
+	ser, err := New("platform-notes", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if got := ser.Title(TitleData{Title: "Postgres 18"}); got != "platform-notes: Postgres 18" {
+		t.Errorf("Title = %q", got)
+	}
+	if ser.HasDelivery() {
+		t.Error("expected no delivery channels")
+	}
+}
+
+func TestNew_RejectsInvalidInput(t *testing.T) {
+	if _, err := New("AI Weekly", Options{}); err == nil {
+		t.Error("expected error for key with spaces and capitals")
+	}
+	if _, err := New("ai", Options{TitleTemplate: "{{.Title"}); err == nil {
+		t.Error("expected error for unparseable title template")
+	}
+	if _, err := New("ai", Options{Audience: "toddlers"}); err == nil {
+		t.Error("expected error for unknown audience")
+	}
+}
+
+func TestNew_Audience(t *testing.T) {
+	ser, err := New("exec-brief", Options{Audience: "Exec"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if ser.Audience != core.AudienceExec {
+		t.Errorf("Audience = %q, want exec", ser.Audience)
+	}
+}
+
+func TestCompareTopics(t *testing.T) {
+	history := [][]string{
+		{"agents", "postgres"}, // previous issue
+		{"agents"},
+		{"rust"},
+	}
+
+	trends := CompareTopics([]string{"Agents", "postgres", "rust", "wasm", "agents"}, history)
+	want := map[string]TopicTrend{
+		"Agents":   {Topic: "Agents", Streak: 3},
+		"postgres": {Topic: "postgres", Streak: 2},
+		"rust":     {Topic: "rust", Streak: 1},
+		"wasm":     {Topic: "wasm", Streak: 1, New: true},
+	}
+
+	if len(trends) != len(want) {
+		t.Fatalf("got %d trends, want %d: %+v", len(trends), len(want), trends)
+	}
+	for _, trend := range trends {
+		if trend != want[trend.Topic] {
+			t.Errorf("trend %q = %+v, want %+v", trend.Topic, trend, want[trend.Topic])
+		}
+	}
+}
+
+func TestDeliver_PostsToWebhooks(t *testing.T) {
+	var slackText string
+	var discordParts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		switch r.URL.Path {
+		case "/slack":
+			slackText = payload["text"]
+		case "/discord":
+			if len(payload["content"]) > discordMessageLimit {
+				t.Errorf("discord message of %d bytes exceeds limit", len(payload["content"]))
+			}
+			discordParts++
+		default:
+			http.Error(w, "gone", http.StatusGone)
+		}
+	}))
+	defer server.Close()
+
+	ser, err := New("ai-weekly", Options{SlackWebhook: server.URL + "/slack", DiscordWebhook: server.URL + "/discord"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	content := "# Issue\n\n" + strings.Repeat("A paragraph about agents.\n\n", 150)
+	delivered, err := ser.Deliver(context.Background(), content)
+	if err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+	if len(delivered) != 2 {
+		t.Errorf("delivered = %v, want slack and discord", delivered)
+	}
+	if slackText != content {
+		t.Error("slack webhook did not receive the full issue")
+	}
+	if discordParts < 2 {
+		t.Errorf("expected the issue split across discord messages, got %d", discordParts)
+	}
+
+	failing, _ := New("ai-weekly", Options{SlackWebhook: server.URL + "/missing"})
+	if _, err := failing.Deliver(context.Background(), "hi"); err == nil {
+		t.Error("expected error for failing webhook")
+	}
+}
+
+func TestPlanMessages(t *testing.T) {
+	short := "# Issue\n\nOne paragraph."
+	if messages := PlanMessages(short, 100); len(messages) != 1 || messages[0].Text != short {
+		t.Errorf("expected a short issue as one message, got %+v", messages)
+	}
+
+	content := "# AI Weekly\n\nIntro.\n\n" +
+		"## Agents\n\n" + strings.Repeat("Agents paragraph.\n\n", 20) +
+		"## Databases\n\n" + strings.Repeat("Databases paragraph.\n\n", 20) +
+		"## Security\n\nShort."
+	messages := PlanMessages(content, 200)
+
+	toc := messages[0].Text
+	if messages[0].Part != 0 || !strings.HasPrefix(toc, "AI Weekly") ||
+		!strings.Contains(toc, "• Agents\n• Databases\n• Security") {
+		t.Errorf("unexpected table of contents:\n%s", toc)
+	}
+
+	parts := len(messages) - 1
+	if !strings.Contains(toc, fmt.Sprintf("%d part(s) follow", parts)) {
+		t.Errorf("expected the table of contents to count %d parts:\n%s", parts, toc)
+	}
+
+	var rebuilt strings.Builder
+	for i, message := range messages[1:] {
+		if len(message.Text) > 200 {
+			t.Errorf("part %d is %d bytes, over the limit", i+1, len(message.Text))
+		}
+		label := fmt.Sprintf("(%d/%d) ", i+1, parts)
+		if message.Part != i+1 || !strings.HasPrefix(message.Text, label) {
+			t.Errorf("part %d = %q, want label %q", i+1, message.Text, label)
+		}
+		rebuilt.WriteString(strings.TrimPrefix(message.Text, label) + "\n\n")
+	}
+	// Every paragraph arrives, in order
+	if strings.Join(strings.Fields(rebuilt.String()), " ") != strings.Join(strings.Fields(content), " ") {
+		t.Errorf("parts do not reassemble the issue:\n%s", rebuilt.String())
+	}
+}
+
+func TestDeliver_ThreadsSlackParts(t *testing.T) {
+	var posts []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxb-token" {
+			t.Errorf("missing bot token, got %q", r.Header.Get("Authorization"))
+		}
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		if len(posts) == 1 && payload["thread_ts"] == "" {
+			// Rate limit the first reply once; delivery should wait and retry it
+			posts = append(posts, nil)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		posts = append(posts, payload)
+		fmt.Fprintf(w, `{"ok": true, "ts": "1700000000.%06d"}`, len(posts))
+	}))
+	defer server.Close()
+
+	ser, err := New("ai-weekly", Options{SlackBotToken: "xoxb-token", SlackChannel: "C123"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ser.slackAPIURL = server.URL
+	if !ser.HasDelivery() {
+		t.Fatal("expected a bot token and channel to count as delivery")
+	}
+
+	content := "# Issue\n\n## One\n\n" + strings.Repeat("x", slackMessageLimit) + "\n\n## Two\n\nEnd."
+	if _, err := ser.Deliver(context.Background(), content); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	var replies int
+	for _, post := range posts[1:] {
+		if post == nil {
+			continue
+		}
+		if post["thread_ts"] != "1700000000.000001" || post["channel"] != "C123" {
+			t.Errorf("expected a reply in the first message's thread, got %+v", post)
+		}
+		replies++
+	}
+
+	channel, timestamps := ser.SlackMessages()
+	if channel != "C123" || len(timestamps) != replies+1 || timestamps[0] != "1700000000.000001" {
+		t.Errorf("expected the posted messages to be recorded, got %s %v", channel, timestamps)
+	}
+	if posts[0]["thread_ts"] != "" || !strings.Contains(posts[0]["text"], "In this issue") || replies < 2 {
+		t.Errorf("expected a table of contents then threaded parts, got %d replies", replies)
+	}
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"briefly/internal/core"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Capture limits keep a misbehaving extension from queueing whole pages as notes
+const (
+	maxCaptureSelection = 5000
+	maxCaptureNote      = 2000
+	maxCaptureTags      = 10
+	maxCaptureTagLength = 50
+)
+
+// CaptureRequest is the payload a browser extension sends to POST /api/capture
+type CaptureRequest struct {
+	URL         string   `json:"url"`
+	Selection   string   `json:"selection,omitempty"`
+	Note        string   `json:"note,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	SubmittedBy string   `json:"submitted_by,omitempty"`
+}
+
+// CaptureResponse reports the queued item
+type CaptureResponse struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Status    string   `json:"status"`
+	Selection string   `json:"selection,omitempty"`
+	Note      string   `json:"note,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Duplicate bool     `json:"duplicate,omitempty"` // URL was already queued; nothing was added
+	CreatedAt string   `json:"created_at"`
+}
+
+// captureSchema is the JSON Schema for CaptureRequest, served so extensions can
+// validate before sending
+const captureSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "briefly-capture-v1",
+  "title": "Briefly capture",
+  "type": "object",
+  "required": ["url"],
+  "additionalProperties": false,
+  "properties": {
+    "url": {"type": "string", "format": "uri", "pattern": "^https?://", "description": "Page to queue"},
+    "selection": {"type": "string", "maxLength": 5000, "description": "Text highlighted on the page"},
+    "note": {"type": "string", "maxLength": 2000, "description": "Why the page was saved"},
+    "tags": {"type": "array", "maxItems": 10, "items": {"type": "string", "maxLength": 50}},
+    "submitted_by": {"type": "string", "description": "Who captured the page (defaults to browser-extension)"}
+  }
+}`
+
+// handleCapture handles POST /api/capture
+func (s *Server) handleCapture(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !s.captureAuthorized(r) {
+		s.respondError(w, http.StatusUnauthorized, "Invalid or missing capture token")
+		return
+	}
+
+	var req CaptureRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid capture payload: "+err.Error())
+		return
+	}
+
+	if msg := normalizeCapture(&req); msg != "" {
+		s.respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	// Re-capturing a queued page is not an error for the extension
+	if existing, _ := s.db.ManualURLs().GetByURL(ctx, req.URL); existing != nil {
+		s.respondJSON(w, http.StatusOK, captureResponse(existing, true))
+		return
+	}
+
+	manualURL := &core.ManualURL{
+		ID:          uuid.NewString(),
+		URL:         req.URL,
+		SubmittedBy: req.SubmittedBy,
+		Status:      core.ManualURLStatusPending,
+		CreatedAt:   time.Now().UTC(),
+		Selection:   req.Selection,
+		Note:        req.Note,
+		Tags:        req.Tags,
+	}
+
+	if err := s.db.ManualURLs().Create(ctx, manualURL); err != nil {
+		s.log.Error("Failed to store capture", "url", req.URL, "error", err)
+		s.respondError(w, http.StatusInternalServerError, "Failed to store capture")
+		return
+	}
+
+	s.log.Info("Captured URL", "url", req.URL, "tags", req.Tags)
+	if s.onCapture != nil {
+		// The hook may fetch and summarize; the extension shouldn't wait for it
+		go s.onCapture(context.WithoutCancel(ctx), manualURL)
+	}
+	s.respondJSON(w, http.StatusCreated, captureResponse(manualURL, false))
+}
+
+// CaptureHook is called with each newly captured URL after it is stored
+type CaptureHook func(ctx context.Context, capture *core.ManualURL)
+
+// OnCapture sets a hook run in the background for each new capture, such as
+// priority triage
+func (s *Server) OnCapture(hook CaptureHook) {
+	s.onCapture = hook
+}
+
+// handleCaptureSchema handles GET /api/capture/schema
+func (s *Server) handleCaptureSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(captureSchema))
+}
+
+// captureAuthorized checks the bearer token when server.capture_token is set; in
+// multi-tenant mode the admin token has already authorized the request
+func (s *Server) captureAuthorized(r *http.Request) bool {
+	if s.config.CaptureToken == "" || adminFrom(r.Context()) {
+		return true
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.config.CaptureToken)) == 1
+}
+
+// normalizeCapture validates req and trims its fields in place, returning a
+// client-facing message when the capture is rejected
+func normalizeCapture(req *CaptureRequest) string {
+	req.URL = strings.TrimSpace(req.URL)
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "url must be an absolute http(s) URL"
+	}
+	// Fragments point within the same article and would defeat duplicate detection
+	parsed.Fragment = ""
+	req.URL = parsed.String()
+
+	req.Selection = strings.TrimSpace(req.Selection)
+	if len([]rune(req.Selection)) > maxCaptureSelection {
+		return "selection is too long"
+	}
+	req.Note = strings.TrimSpace(req.Note)
+	if len([]rune(req.Note)) > maxCaptureNote {
+		return "note is too long"
+	}
+
+	if len(req.Tags) > maxCaptureTags {
+		return "too many tags"
+	}
+	seen := make(map[string]bool)
+	tags := make([]string, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(tag, "#")))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len([]rune(tag)) > maxCaptureTagLength {
+			return "tag is too long"
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	req.Tags = tags
+
+	req.SubmittedBy = strings.TrimSpace(req.SubmittedBy)
+	if req.SubmittedBy == "" {
+		req.SubmittedBy = "browser-extension"
+	}
+	return ""
+}
+
+// captureResponse converts a queued manual URL to the capture response
+func captureResponse(manualURL *core.ManualURL, duplicate bool) CaptureResponse {
+	return CaptureResponse{
+		ID:        manualURL.ID,
+		URL:       manualURL.URL,
+		Status:    manualURL.Status,
+		Selection: manualURL.Selection,
+		Note:      manualURL.Note,
+		Tags:      manualURL.Tags,
+		Duplicate: duplicate,
+		CreatedAt: manualURL.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"briefly/internal/persistence"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// fakeDB serves only the repositories a test sets
+type fakeDB struct {
+	persistence.Database
+	manualURLs *fakeManualURLs
+}
+
+func (f *fakeDB) ManualURLs() persistence.ManualURLRepository { return f.manualURLs }
+
+// fakeManualURLs keeps queued URLs in memory
+type fakeManualURLs struct {
+	persistence.ManualURLRepository
+	mu   sync.Mutex
+	urls []*core.ManualURL
+}
+
+func (f *fakeManualURLs) Create(ctx context.Context, manualURL *core.ManualURL) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.urls = append(f.urls, manualURL)
+	return nil
+}
+
+func (f *fakeManualURLs) GetByURL(ctx context.Context, url string) (*core.ManualURL, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, manualURL := range f.urls {
+		if manualURL.URL == url {
+			return manualURL, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestCapture(t *testing.T) {
+	queue := &fakeManualURLs{}
+	s := New(&fakeDB{manualURLs: queue}, config.Server{CaptureToken: "capture-token"})
+
+	for name, token := range map[string]string{"missing": "", "wrong": "nope"} {
+		if rec := serve(s, http.MethodPost, "/api/capture", token, `{"url": "https://example.com/a"}`); rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s token: expected 401, got %d", name, rec.Code)
+		}
+	}
+
+	for name, body := range map[string]string{
+		"relative url":  `{"url": "/posts/a"}`,
+		"ftp url":       `{"url": "ftp://example.com/a"}`,
+		"missing host":  `{"url": "https://"}`,
+		"unknown field": `{"url": "https://example.com/a", "priority": 1}`,
+		"not json":      `url=https://example.com/a`,
+	} {
+		if rec := serve(s, http.MethodPost, "/api/capture", "capture-token", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", name, rec.Code)
+		}
+	}
+	if len(queue.urls) != 0 {
+		t.Fatalf("expected rejected captures not queued, got %d", len(queue.urls))
+	}
+
+	rec := serve(s, http.MethodPost, "/api/capture", "capture-token",
+		`{"url": " https://example.com/a#comments ", "note": "Read later", "tags": ["#AI", "ai", "Go"]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	var created CaptureResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if created.URL != "https://example.com/a" || created.Status != core.ManualURLStatusPending || created.Duplicate {
+		t.Errorf("unexpected response %+v", created)
+	}
+	if len(queue.urls) != 1 || queue.urls[0].SubmittedBy != "browser-extension" || len(queue.urls[0].Tags) != 2 {
+		t.Fatalf("expected the capture queued with normalized fields, got %+v", queue.urls)
+	}
+
+	rec = serve(s, http.MethodPost, "/api/capture", "capture-token", `{"url": "https://example.com/a"}`)
+	var duplicate CaptureResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &duplicate); err != nil || rec.Code != http.StatusOK || !duplicate.Duplicate || duplicate.ID != created.ID {
+		t.Errorf("expected the queued capture returned as a duplicate, got %d: %s", rec.Code, rec.Body)
+	}
+	if len(queue.urls) != 1 {
+		t.Errorf("expected a duplicate not queued again, got %d", len(queue.urls))
+	}
+}
+
+func TestCapture_OpenWithoutToken(t *testing.T) {
+	queue := &fakeManualURLs{}
+	s := New(&fakeDB{manualURLs: queue}, config.Server{})
+
+	if rec := serve(s, http.MethodPost, "/api/capture", "", `{"url": "https://example.com/a"}`); rec.Code != http.StatusCreated {
+		t.Errorf("expected captures open without server.capture_token, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxCommentText keeps a comment to a note, not a pasted article
+const maxCommentText = 2000
+
+// Errors a CommentRecorder returns for comments that can't be attached
+var (
+	ErrUnknownDigest  = errors.New("unknown digest")
+	ErrInvalidArticle = errors.New("invalid article number")
+)
+
+// Comment is a reader's comment or reaction on a digest article
+type Comment struct {
+	DigestID   string
+	ArticleNum int // 1-based article number; 0 for the issue as a whole
+	Author     string
+	Text       string
+	Reaction   string
+	Source     string // "api" or "slack"
+}
+
+// CommentRecorder stores comments on digests recorded in the local cache
+type CommentRecorder interface {
+	// RecordComment stores a comment and returns its ID. It returns ErrUnknownDigest
+	// or ErrInvalidArticle when the comment can't be attached.
+	RecordComment(comment Comment) (int64, error)
+	// DigestForSlackMessage returns the digest delivered as a Slack message, or ""
+	DigestForSlackMessage(channel, ts string) (string, error)
+}
+
+// CommentsConfig enables comment ingestion
+type CommentsConfig struct {
+	Recorder      CommentRecorder
+	Token         string // Bearer token required by POST /api/digests/{id}/comments (empty = open)
+	SigningSecret string // Slack signing secret; enables POST /slack/events for reactions and replies
+}
+
+// CommentRequest is the payload of POST /api/digests/{id}/comments
+type CommentRequest struct {
+	Article  int    `json:"article"` // 1-based article number; 0 or omitted for the whole issue
+	Text     string `json:"text,omitempty"`
+	Reaction string `json:"reaction,omitempty"`
+	Author   string `json:"author,omitempty"`
+}
+
+// EnableComments registers POST /api/digests/{id}/comments and, with a Slack
+// signing secret, POST /slack/events, which records reactions on and thread
+// replies to delivered issues
+func (s *Server) EnableComments(cfg CommentsConfig) error {
+	if cfg.Recorder == nil {
+		return fmt.Errorf("comments require a recorder")
+	}
+
+	s.router.Post("/api/digests/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+		s.handleAddComment(cfg, w, r)
+	})
+	if cfg.SigningSecret != "" {
+		s.router.Post("/slack/events", func(w http.ResponseWriter, r *http.Request) {
+			s.handleSlackEvent(cfg, w, r)
+		})
+	}
+
+	s.log.Info("Digest comments enabled", "path", "/api/digests/{id}/comments", "slack_events", cfg.SigningSecret != "")
+	return nil
+}
+
+// handleAddComment handles POST /api/digests/{id}/comments
+func (s *Server) handleAddComment(cfg CommentsConfig, w http.ResponseWriter, r *http.Request) {
+	// The admin token already authorized the request in multi-tenant mode
+	if cfg.Token != "" && !adminFrom(r.Context()) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Token)) != 1 {
+			s.respondError(w, http.StatusUnauthorized, "Invalid or missing comment token")
+			return
+		}
+	}
+
+	var req CommentRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16<<10))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid comment payload: "+err.Error())
+		return
+	}
+
+	req.Text = strings.TrimSpace(req.Text)
+	if req.Text == "" && strings.TrimSpace(req.Reaction) == "" {
+		s.respondError(w, http.StatusBadRequest, "comment needs text or a reaction")
+		return
+	}
+	if len([]rune(req.Text)) > maxCommentText {
+		s.respondError(w, http.StatusBadRequest, "text is too long")
+		return
+	}
+
+	id, err := cfg.Recorder.RecordComment(Comment{
+		DigestID:   chi.URLParam(r, "id"),
+		ArticleNum: req.Article,
+		Author:     req.Author,
+		Text:       req.Text,
+		Reaction:   req.Reaction,
+		Source:     "api",
+	})
+	switch {
+	case errors.Is(err, ErrUnknownDigest):
+		s.respondError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, ErrInvalidArticle):
+		s.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		s.log.Error("Failed to store comment", "digest", chi.URLParam(r, "id"), "error", err)
+		s.respondError(w, http.StatusInternalServerError, "Failed to store comment")
+		return
+	}
+
+	s.respondJSON(w, http.StatusCreated, map[string]interface{}{"id": id, "status": "recorded"})
+}
+
+// slackEvent is the subset of a Slack Events API payload Briefly reads
+type slackEvent struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Event     struct {
+		Type     string `json:"type"`
+		Subtype  string `json:"subtype"`
+		User     string `json:"user"`
+		BotID    string `json:"bot_id"`
+		Reaction string `json:"reaction"`
+		Text     string `json:"text"`
+		Channel  string `json:"channel"`
+		ThreadTS string `json:"thread_ts"`
+		Item     struct {
+			Type    string `json:"type"`
+			Channel string `json:"channel"`
+			TS      string `json:"ts"`
+		} `json:"item"`
+	} `json:"event"`
+}
+
+// handleSlackEvent handles POST /slack/events. A reaction on a delivered issue is
+// recorded on the issue as a whole; a reply in an issue's thread is recorded as a
+// comment, on article n when it starts with "[n]" or "#n".
+func (s *Server) handleSlackEvent(cfg CommentsConfig, w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "Failed to read request")
+		return
+	}
+	if err := verifySlackSignature(cfg.SigningSecret, r.Header, body, time.Now()); err != nil {
+		s.log.Warn("Rejected Slack event", "error", err)
+		s.respondError(w, http.StatusUnauthorized, "Invalid Slack signature")
+		return
+	}
+
+	var payload slackEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid event payload")
+		return
+	}
+	if payload.Type == "url_verification" {
+		s.respondJSON(w, http.StatusOK, map[string]string{"challenge": payload.Challenge})
+		return
+	}
+
+	// Slack retries events that aren't acknowledged, so failures are logged, not returned
+	event := payload.Event
+	var comment *Comment
+	switch {
+	case event.Type == "reaction_added" && event.Item.Type == "message":
+		comment = s.slackComment(cfg, event.Item.Channel, event.Item.TS)
+		if comment != nil {
+			comment.Reaction = event.Reaction
+		}
+	case event.Type == "message" && event.ThreadTS != "" && event.Subtype == "" && event.BotID == "":
+		comment = s.slackComment(cfg, event.Channel, event.ThreadTS)
+		if comment != nil {
+			comment.ArticleNum, comment.Text = parseArticleReference(event.Text)
+		}
+	}
+	if comment != nil {
+		comment.Author = event.User
+		if _, err := cfg.Recorder.RecordComment(*comment); err != nil {
+			s.log.Warn("Slack comment not recorded", "digest", comment.DigestID, "error", err)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// slackComment starts a comment on the digest delivered at channel and ts, or
+// returns nil when the message is not part of a delivered issue
+func (s *Server) slackComment(cfg CommentsConfig, channel, ts string) *Comment {
+	digestID, err := cfg.Recorder.DigestForSlackMessage(channel, ts)
+	if err != nil {
+		s.log.Warn("Slack message lookup failed", "channel", channel, "error", err)
+		return nil
+	}
+	if digestID == "" {
+		return nil
+	}
+	return &Comment{DigestID: digestID, Source: "slack"}
+}
+
+var articleReferencePattern = regexp.MustCompile(`^\s*(?:\[(\d+)\]|#(\d+))\s*[:\-–—]?\s*`)
+
+// parseArticleReference splits a leading "[n]" or "#n" article reference from a
+// thread reply. Replies without one are about the whole issue (article 0).
+func parseArticleReference(text string) (int, string) {
+	match := articleReferencePattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, strings.TrimSpace(text)
+	}
+	number, _ := strconv.Atoi(match[1] + match[2])
+	return number, strings.TrimSpace(text[len(match[0]):])
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// handleLinkRedirect handles GET /r/{code}, recording the click before
+// redirecting to the original article URL
+func (s *Server) handleLinkRedirect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	code := chi.URLParam(r, "code")
+
+	link, err := s.db.Links().GetByCode(ctx, code)
+	if err != nil {
+		s.respondError(w, http.StatusNotFound, "Link not found")
+		return
+	}
+
+	// A failed click insert should never block the reader from the article
+	if err := s.db.Links().RecordClick(ctx, code, r.Referer(), r.UserAgent()); err != nil {
+		s.log.Error("Failed to record link click", "code", code, "error", err)
+	}
+
+	http.Redirect(w, r, link.URL, http.StatusFound)
+}
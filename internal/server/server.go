@@ -24,6 +24,7 @@ type Server struct {
 	log        *slog.Logger
 	renderer   *TemplateRenderer
 	analytics  interface{} // Optional analytics client
+	onCapture  CaptureHook // Optional hook run after each new capture
 }
 
 // New creates a new HTTP server instance
@@ -147,6 +148,12 @@ func (s *Server) setupRoutes() {
 			r.Post("/{id}/retry", s.handleRetryManualURL)
 			r.Delete("/{id}", s.handleDeleteManualURL)
 		})
+
+		// Browser extension capture (queues into manual URLs)
+		r.Route("/capture", func(r chi.Router) {
+			r.Post("/", s.handleCapture)
+			r.Get("/schema", s.handleCaptureSchema)
+		})
 	})
 
 	// Web routes (HTML pages)
@@ -156,6 +163,9 @@ func (s *Server) setupRoutes() {
 	s.router.Get("/themes", s.handleThemesPage)
 	s.router.Get("/submit", s.handleSubmitPage)
 
+	// Tracked link redirects (click tracking for shared digests)
+	s.router.Get("/r/{code}", s.handleLinkRedirect)
+
 	// HTMX partial routes
 	s.router.Get("/api/digests/{id}/expand", s.handleExpandDigest)
 	s.router.Get("/api/digests/{id}/collapse", s.handleCollapseDigest)
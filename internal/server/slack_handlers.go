@@ -0,0 +1,342 @@
+package server
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/delivery"
+	"briefly/internal/render"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// slackRequestMaxAge rejects slash command requests older than this, per Slack's
+// replay protection guidance
+const slackRequestMaxAge = 5 * time.Minute
+
+// slackSummaryTimeout bounds the background fetch and summarization for one command
+const slackSummaryTimeout = 3 * time.Minute
+
+// slackAPIBaseURL is the Slack Web API endpoint for chat.postMessage
+const slackAPIBaseURL = "https://slack.com/api"
+
+// QuickSummary is the summary of one URL returned to chat integrations and
+// POST /api/summarize
+type QuickSummary struct {
+	Title      string                `json:"title"`
+	URL        string                `json:"url"`
+	Summary    string                `json:"summary"`
+	KeyMoments []core.ArticleInsight `json:"key_moments,omitempty"`
+	Cached     bool                  `json:"cached"` // Served from the article cache without fetching
+}
+
+// URLSummarizer fetches and summarizes a single URL, reusing cached summaries
+type URLSummarizer interface {
+	SummarizeURL(ctx context.Context, url string) (*QuickSummary, error)
+}
+
+// SlackCommandConfig enables the /briefly slash command endpoint
+type SlackCommandConfig struct {
+	SigningSecret string        // Verifies requests come from Slack (required)
+	BotToken      string        // xoxb- token; enables threaded replies via chat.postMessage
+	Summarizer    URLSummarizer // Produces the summaries
+}
+
+// slackHandler serves Slack slash commands
+type slackHandler struct {
+	config     SlackCommandConfig
+	httpClient *http.Client
+	apiBaseURL string
+}
+
+// EnableSlackCommands registers POST /slack/commands for the `/briefly summarize <url>`
+// slash command. Slack requires an answer within three seconds, so the handler
+// acknowledges immediately and posts the summary when it is ready.
+func (s *Server) EnableSlackCommands(cfg SlackCommandConfig) error {
+	if cfg.SigningSecret == "" {
+		return fmt.Errorf("slack signing secret is required for slash commands")
+	}
+	if cfg.Summarizer == nil {
+		return fmt.Errorf("slack slash commands require a summarizer")
+	}
+
+	handler := &slackHandler{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		apiBaseURL: slackAPIBaseURL,
+	}
+	s.router.Post("/slack/commands", func(w http.ResponseWriter, r *http.Request) {
+		s.handleSlackCommand(handler, w, r)
+	})
+
+	s.log.Info("Slack slash commands enabled", "path", "/slack/commands", "threaded_replies", cfg.BotToken != "")
+	return nil
+}
+
+// handleSlackCommand handles POST /slack/commands
+func (s *Server) handleSlackCommand(h *slackHandler, w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "Failed to read request")
+		return
+	}
+
+	if err := verifySlackSignature(h.config.SigningSecret, r.Header, body, time.Now()); err != nil {
+		s.log.Warn("Rejected Slack command", "error", err)
+		s.respondError(w, http.StatusUnauthorized, "Invalid Slack signature")
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid form payload")
+		return
+	}
+
+	targetURL, usage := parseSlackCommandText(form.Get("text"))
+	if targetURL == "" {
+		s.respondJSON(w, http.StatusOK, slackMessage{ResponseType: "ephemeral", Text: usage})
+		return
+	}
+
+	command := slackCommand{
+		URL:         targetURL,
+		ChannelID:   form.Get("channel_id"),
+		UserID:      form.Get("user_id"),
+		ResponseURL: form.Get("response_url"),
+	}
+
+	// Summarize after responding; the request context ends with this handler
+	go h.summarizeAndReply(command, s)
+
+	s.respondJSON(w, http.StatusOK, slackMessage{
+		ResponseType: "ephemeral",
+		Text:         fmt.Sprintf("⏳ Summarizing %s …", targetURL),
+	})
+}
+
+// slackCommand is a parsed `/briefly summarize <url>` request
+type slackCommand struct {
+	URL         string
+	ChannelID   string
+	UserID      string
+	ResponseURL string
+}
+
+// slackMessage is the subset of Slack's message payload Briefly sends
+type slackMessage struct {
+	Channel      string `json:"channel,omitempty"`
+	Text         string `json:"text"`
+	ThreadTS     string `json:"thread_ts,omitempty"`
+	ResponseType string `json:"response_type,omitempty"`
+	UnfurlLinks  bool   `json:"unfurl_links"`
+}
+
+// summarizeAndReply summarizes the command's URL and posts the result. With a bot
+// token the reply is a channel message with the summary in its thread; otherwise the
+// full summary goes to the command's response_url.
+func (h *slackHandler) summarizeAndReply(command slackCommand, s *Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), slackSummaryTimeout)
+	defer cancel()
+
+	summary, err := h.config.Summarizer.SummarizeURL(ctx, command.URL)
+	if err != nil {
+		s.log.Error("Slack summarize failed", "url", command.URL, "error", err)
+		h.postResponse(ctx, command.ResponseURL, slackMessage{
+			ResponseType: "ephemeral",
+			Text:         fmt.Sprintf("❌ Couldn't summarize %s: %v", command.URL, err),
+		}, s)
+		return
+	}
+
+	header := formatSlackSummaryHeader(summary, command.UserID)
+	thread := formatSlackSummaryThread(summary)
+
+	if h.config.BotToken != "" && command.ChannelID != "" {
+		ts, err := h.postMessage(ctx, slackMessage{Channel: command.ChannelID, Text: header})
+		if err == nil {
+			if _, err = h.postMessage(ctx, slackMessage{Channel: command.ChannelID, Text: thread, ThreadTS: ts}); err == nil {
+				return
+			}
+		}
+		// Bots must be invited to a channel before posting; fall back to the response URL
+		s.log.Warn("Slack threaded reply failed, using response_url", "channel", command.ChannelID, "error", err)
+	}
+
+	h.postResponse(ctx, command.ResponseURL, slackMessage{
+		ResponseType: "in_channel",
+		Text:         header + "\n\n" + thread,
+	}, s)
+}
+
+// postMessage calls chat.postMessage and returns the posted message's timestamp
+func (h *slackHandler) postMessage(ctx context.Context, msg slackMessage) (string, error) {
+	msg.Text = render.Output(msg.Text)
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode Slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.apiBaseURL+"/chat.postMessage", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+h.config.BotToken)
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to post Slack message: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		TS    string `json:"ts"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode Slack response: %w", err)
+	}
+	if !result.OK {
+		return "", fmt.Errorf("slack API error: %s", result.Error)
+	}
+	return result.TS, nil
+}
+
+// postResponse sends a delayed reply through a slash command's response_url
+func (h *slackHandler) postResponse(ctx context.Context, responseURL string, msg slackMessage, s *Server) {
+	if responseURL == "" {
+		return
+	}
+	msg.Text = render.Output(msg.Text)
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		s.log.Error("Failed to encode Slack response", "error", err)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(payload))
+	if err != nil {
+		s.log.Error("Failed to create Slack response request", "error", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		s.log.Error("Failed to post Slack response", "error", err)
+		return
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		s.log.Error("Slack response_url rejected reply", "status", resp.StatusCode)
+	}
+}
+
+// verifySlackSignature checks X-Slack-Signature (v0 HMAC-SHA256 of the timestamp and
+// raw body) and rejects stale timestamps
+func verifySlackSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	signature := header.Get("X-Slack-Signature")
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("missing Slack signature headers")
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid Slack timestamp %q", timestamp)
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > slackRequestMaxAge || age < -slackRequestMaxAge {
+		return fmt.Errorf("stale Slack request (%s old)", age.Round(time.Second))
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// parseSlackCommandText extracts the URL from "summarize <url>" (or a bare URL).
+// When there is no URL it returns usage text instead.
+func parseSlackCommandText(text string) (string, string) {
+	const usage = "Usage: `/briefly summarize <url>` — posts a summary and key moments of the article in this channel."
+
+	fields := strings.Fields(text)
+	if len(fields) > 0 && strings.EqualFold(fields[0], "summarize") {
+		fields = fields[1:]
+	}
+	if len(fields) != 1 {
+		return "", usage
+	}
+
+	// Slack wraps links as <https://example.com|label>
+	target := strings.Trim(fields[0], "<>")
+	if idx := strings.Index(target, "|"); idx >= 0 {
+		target = target[:idx]
+	}
+	if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		return "", usage
+	}
+	return target, ""
+}
+
+// formatSlackSummaryHeader renders the channel message announcing a summary
+func formatSlackSummaryHeader(summary *QuickSummary, userID string) string {
+	title := summary.Title
+	if title == "" {
+		title = summary.URL
+	}
+
+	header := fmt.Sprintf("📰 *<%s|%s>*", summary.URL, title)
+	if userID != "" {
+		header += fmt.Sprintf(" — requested by <@%s>", userID)
+	}
+	return header
+}
+
+// formatSlackSummaryThread renders the summary and key moments in Slack mrkdwn
+func formatSlackSummaryThread(summary *QuickSummary) string {
+	var b strings.Builder
+
+	b.WriteString("*Summary*\n")
+	b.WriteString(strings.TrimSpace(delivery.MarkdownToSlack(summary.Summary)))
+
+	if len(summary.KeyMoments) > 0 {
+		b.WriteString("\n\n*Key moments*")
+		for _, moment := range summary.KeyMoments {
+			emoji := moment.Emoji
+			if emoji == "" {
+				emoji = "💡"
+			}
+			b.WriteString(fmt.Sprintf("\n%s *%s*\n", emoji, moment.Title))
+			if quote := strings.Trim(moment.Quote, "\" "); quote != "" {
+				b.WriteString(fmt.Sprintf("> %s\n", quote))
+			}
+			if moment.WhyItMatters != "" {
+				b.WriteString(fmt.Sprintf("_Why it matters:_ %s\n", moment.WhyItMatters))
+			}
+		}
+	}
+
+	if summary.Cached {
+		b.WriteString("\n_(from cache)_")
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"briefly/internal/config"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSlackSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// slackRequest builds a slash command request signed at timestamp
+func slackRequest(body string, timestamp time.Time) *http.Request {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testSlackSecret))
+	mac.Write([]byte("v0:" + ts + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/commands", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestSlackCommand_Signature(t *testing.T) {
+	s := newTestServer(t, config.Server{})
+	if err := s.EnableSlackCommands(SlackCommandConfig{
+		SigningSecret: testSlackSecret,
+		Summarizer:    &cachingSummarizer{cache: make(map[string]bool)},
+	}); err != nil {
+		t.Fatalf("EnableSlackCommands: %v", err)
+	}
+	// No URL in the text, so a verified command answers with usage and summarizes nothing
+	body := "command=%2Fbriefly&text=help&channel_id=C1&user_id=U1"
+
+	tampered := slackRequest(body, time.Now())
+	tampered.Body = io.NopCloser(strings.NewReader(body + "&text=summarize"))
+
+	missing := slackRequest(body, time.Now())
+	missing.Header.Del("X-Slack-Signature")
+
+	noTimestamp := slackRequest(body, time.Now())
+	noTimestamp.Header.Del("X-Slack-Request-Timestamp")
+
+	for name, tc := range map[string]struct {
+		req  *http.Request
+		want int
+	}{
+		"valid":             {slackRequest(body, time.Now()), http.StatusOK},
+		"tampered body":     {tampered, http.StatusUnauthorized},
+		"stale timestamp":   {slackRequest(body, time.Now().Add(-slackRequestMaxAge-time.Minute)), http.StatusUnauthorized},
+		"future timestamp":  {slackRequest(body, time.Now().Add(slackRequestMaxAge+time.Minute)), http.StatusUnauthorized},
+		"missing signature": {missing, http.StatusUnauthorized},
+		"missing timestamp": {noTimestamp, http.StatusUnauthorized},
+	} {
+		rec := httptest.NewRecorder()
+		s.httpServer.Handler.ServeHTTP(rec, tc.req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d: %s", name, tc.want, rec.Code, rec.Body)
+		}
+		if tc.want == http.StatusOK && !strings.Contains(rec.Body.String(), "Usage") {
+			t.Errorf("%s: expected the usage reply, got %s", name, rec.Body)
+		}
+	}
+}
+
+func TestVerifySlackSignature(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	body := []byte("text=summarize+https%3A%2F%2Fexample.com")
+	header := slackRequest(string(body), now).Header
+
+	if err := verifySlackSignature(testSlackSecret, header, body, now); err != nil {
+		t.Errorf("expected a valid signature, got %v", err)
+	}
+	if err := verifySlackSignature("other-secret", header, body, now); err == nil {
+		t.Error("expected a signature under another secret to fail")
+	}
+	// Just inside and just outside the replay window
+	if err := verifySlackSignature(testSlackSecret, header, body, now.Add(slackRequestMaxAge)); err != nil {
+		t.Errorf("expected a request at the edge of the window to pass, got %v", err)
+	}
+	if err := verifySlackSignature(testSlackSecret, header, body, now.Add(slackRequestMaxAge+time.Second)); err == nil {
+		t.Error("expected a request past the window to fail")
+	}
+
+	bad := header.Clone()
+	bad.Set("X-Slack-Request-Timestamp", "yesterday")
+	if err := verifySlackSignature(testSlackSecret, bad, body, now); err == nil {
+		t.Error("expected an unparseable timestamp to fail")
+	}
+}
@@ -0,0 +1,302 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// tenantIDPattern limits tenant IDs to names that are safe as a cache directory
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// tenantRoutes are the /api routes a tenant token opens. They read and write only
+// the tenant's own cache and usage; every other /api route serves the shared
+// database, so it needs the admin token.
+var tenantRoutes = map[string]bool{
+	"/api/summarize":    true,
+	"/api/tenant/usage": true,
+}
+
+// Tenant is a team sharing the server. Its token authorizes its /api calls, and its
+// summarizer runs with its own model and cache.
+type Tenant struct {
+	ID            string
+	Name          string
+	Token         string
+	MonthlyBudget float64       // Estimated LLM spend in USD per calendar month (0 = unlimited)
+	Summarizer    URLSummarizer // Answers POST /api/summarize for the tenant
+}
+
+// TenantUsage is a tenant's usage in one month
+type TenantUsage struct {
+	TenantID  string  `json:"tenant_id"`
+	Name      string  `json:"name,omitempty"`
+	Month     string  `json:"month"`
+	Requests  int     `json:"requests"`
+	LLMCalls  int     `json:"llm_calls"`
+	TokensIn  int     `json:"tokens_in"`
+	TokensOut int     `json:"tokens_out"`
+	CostUSD   float64 `json:"cost_usd"`
+	BudgetUSD float64 `json:"budget_usd,omitempty"` // Monthly budget, when the tenant has one
+}
+
+// TenantUsageStore keeps per-tenant usage by month. LLM calls are added by the
+// tenants' LLM clients; the server adds API requests.
+type TenantUsageStore interface {
+	AddRequest(tenantID, month string) error
+	Usage(tenantID, month string) (TenantUsage, error)
+}
+
+// TenantsConfig enables multi-tenant mode
+type TenantsConfig struct {
+	Tenants    []Tenant
+	AdminToken string // Bearer token for GET /api/tenants/usage and the shared /api routes (empty = off)
+	Usage      TenantUsageStore
+}
+
+type (
+	tenantKey struct{}
+	adminKey  struct{}
+)
+
+// TenantFrom returns the tenant a request was authorized as, or nil outside
+// multi-tenant mode
+func TenantFrom(ctx context.Context) *Tenant {
+	tenant, _ := ctx.Value(tenantKey{}).(*Tenant)
+	return tenant
+}
+
+// adminFrom reports whether the admin token authorized a request in multi-tenant
+// mode
+func adminFrom(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminKey{}).(bool)
+	return admin
+}
+
+// UsageMonth is the month usage is counted under, e.g. "2026-10"
+func UsageMonth(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
+
+// EnableTenants turns on multi-tenant mode: a tenant's bearer token opens only
+// POST /api/summarize and GET /api/tenant/usage, which it registers along with
+// GET /api/tenants/usage, and each tenant's requests are counted against it. The
+// shared /api routes (digests, articles, feeds, themes, captures, comments) need the
+// admin token, so no tenant can read or add to data other tenants see.
+func (s *Server) EnableTenants(cfg TenantsConfig) error {
+	if cfg.Usage == nil {
+		return fmt.Errorf("tenants require a usage store")
+	}
+	ids := make(map[string]bool)
+	tokens := make(map[string]bool)
+	for _, tenant := range cfg.Tenants {
+		if tenant.ID == "" || tenant.Token == "" {
+			return fmt.Errorf("every tenant needs an id and a token")
+		}
+		if !tenantIDPattern.MatchString(tenant.ID) {
+			return fmt.Errorf("tenant id %q must contain only a-z, 0-9, _ and -", tenant.ID)
+		}
+		if ids[tenant.ID] {
+			return fmt.Errorf("tenant %q is configured twice", tenant.ID)
+		}
+		if tokens[tenant.Token] {
+			return fmt.Errorf("tenant %q shares its token with another tenant", tenant.ID)
+		}
+		if tenant.Summarizer == nil {
+			return fmt.Errorf("tenant %q has no summarizer", tenant.ID)
+		}
+		ids[tenant.ID] = true
+		tokens[tenant.Token] = true
+	}
+
+	tenants := make([]*Tenant, len(cfg.Tenants))
+	for i := range cfg.Tenants {
+		tenants[i] = &cfg.Tenants[i]
+	}
+	s.router.Post("/api/summarize", func(w http.ResponseWriter, r *http.Request) {
+		s.handleTenantSummarize(cfg, w, r)
+	})
+	s.router.Get("/api/tenant/usage", func(w http.ResponseWriter, r *http.Request) {
+		s.handleTenantUsage(cfg, w, r)
+	})
+	s.router.Get("/api/tenants/usage", func(w http.ResponseWriter, r *http.Request) {
+		s.handleAllTenantsUsage(cfg, tenants, w, r)
+	})
+	// chi takes no middleware once routes exist, so the check wraps the router
+	s.httpServer.Handler = s.tenantMiddleware(cfg, tenants, s.httpServer.Handler)
+
+	s.log.Info("Multi-tenant mode enabled", "tenants", len(tenants), "admin_usage", cfg.AdminToken != "")
+	return nil
+}
+
+// tenantMiddleware authorizes /api requests: the tenant routes by tenant token,
+// counting each request against its tenant, and the shared routes by admin token.
+// Other paths (web pages, health, Slack), the status endpoint, and the digest
+// partials the web pages load pass through.
+func (s *Server) tenantMiddleware(cfg TenantsConfig, tenants []*Tenant, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !tenantScoped(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !tenantRoutes[r.URL.Path] && cfg.AdminToken != "" &&
+			subtle.ConstantTimeCompare([]byte(token), []byte(cfg.AdminToken)) == 1 {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), adminKey{}, true)))
+			return
+		}
+
+		var tenant *Tenant
+		for _, t := range tenants {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(t.Token)) == 1 {
+				tenant = t
+			}
+		}
+		if tenant == nil {
+			s.respondError(w, http.StatusUnauthorized, "Invalid or missing tenant token")
+			return
+		}
+		if !tenantRoutes[r.URL.Path] {
+			s.respondError(w, http.StatusForbidden, "Tenant tokens only open /api/summarize and /api/tenant/usage")
+			return
+		}
+		if err := cfg.Usage.AddRequest(tenant.ID, UsageMonth(time.Now())); err != nil {
+			s.log.Warn("Failed to record tenant request", "tenant", tenant.ID, "error", err)
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantKey{}, tenant)))
+	})
+}
+
+// tenantScoped reports whether a request needs a tenant token
+func tenantScoped(r *http.Request) bool {
+	path := r.URL.Path
+	if path != "/api" && !strings.HasPrefix(path, "/api/") {
+		return false
+	}
+	if path == "/api/status" {
+		return false
+	}
+	isPartial := strings.HasSuffix(path, "/expand") || strings.HasSuffix(path, "/collapse")
+	return !(r.Method == http.MethodGet && strings.HasPrefix(path, "/api/digests/") && isPartial)
+}
+
+// handleTenantSummarize handles POST /api/summarize: a quick summary of a URL with
+// the tenant's model and cache, refused once its monthly budget is spent
+func (s *Server) handleTenantSummarize(cfg TenantsConfig, w http.ResponseWriter, r *http.Request) {
+	tenant := TenantFrom(r.Context())
+	if tenant == nil {
+		s.respondError(w, http.StatusUnauthorized, "Invalid or missing tenant token")
+		return
+	}
+
+	var req struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 8<<10)).Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid payload: "+err.Error())
+		return
+	}
+	req.URL = strings.TrimSpace(req.URL)
+	if !strings.HasPrefix(req.URL, "http://") && !strings.HasPrefix(req.URL, "https://") {
+		s.respondError(w, http.StatusBadRequest, "url must be an http(s) URL")
+		return
+	}
+
+	if tenant.MonthlyBudget > 0 {
+		usage, err := cfg.Usage.Usage(tenant.ID, UsageMonth(time.Now()))
+		if err != nil {
+			s.log.Error("Failed to read tenant usage", "tenant", tenant.ID, "error", err)
+			s.respondError(w, http.StatusInternalServerError, "Failed to check the tenant budget")
+			return
+		}
+		if usage.CostUSD >= tenant.MonthlyBudget {
+			s.respondError(w, http.StatusTooManyRequests, fmt.Sprintf("Monthly LLM budget of $%.2f is spent", tenant.MonthlyBudget))
+			return
+		}
+	}
+
+	summary, err := tenant.Summarizer.SummarizeURL(r.Context(), req.URL)
+	if err != nil {
+		s.log.Warn("Tenant summary failed", "tenant", tenant.ID, "url", req.URL, "error", err)
+		s.respondError(w, http.StatusBadGateway, "Failed to summarize: "+err.Error())
+		return
+	}
+	s.respondJSON(w, http.StatusOK, summary)
+}
+
+// handleTenantUsage handles GET /api/tenant/usage: the calling tenant's usage this
+// month, or in ?month=YYYY-MM
+func (s *Server) handleTenantUsage(cfg TenantsConfig, w http.ResponseWriter, r *http.Request) {
+	tenant := TenantFrom(r.Context())
+	if tenant == nil {
+		s.respondError(w, http.StatusUnauthorized, "Invalid or missing tenant token")
+		return
+	}
+	month, ok := s.usageMonth(w, r)
+	if !ok {
+		return
+	}
+	usage, err := tenantUsage(cfg, tenant, month)
+	if err != nil {
+		s.log.Error("Failed to read tenant usage", "tenant", tenant.ID, "error", err)
+		s.respondError(w, http.StatusInternalServerError, "Failed to read usage")
+		return
+	}
+	s.respondJSON(w, http.StatusOK, usage)
+}
+
+// handleAllTenantsUsage handles GET /api/tenants/usage, every tenant's usage for the
+// admin token
+func (s *Server) handleAllTenantsUsage(cfg TenantsConfig, tenants []*Tenant, w http.ResponseWriter, r *http.Request) {
+	if !adminFrom(r.Context()) {
+		s.respondError(w, http.StatusForbidden, "Usage of all tenants needs the admin token")
+		return
+	}
+	month, ok := s.usageMonth(w, r)
+	if !ok {
+		return
+	}
+	all := make([]TenantUsage, 0, len(tenants))
+	for _, tenant := range tenants {
+		usage, err := tenantUsage(cfg, tenant, month)
+		if err != nil {
+			s.log.Error("Failed to read tenant usage", "tenant", tenant.ID, "error", err)
+			s.respondError(w, http.StatusInternalServerError, "Failed to read usage")
+			return
+		}
+		all = append(all, usage)
+	}
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{"month": month, "tenants": all})
+}
+
+// usageMonth reads ?month=YYYY-MM, defaulting to this month; false means an error
+// response was written
+func (s *Server) usageMonth(w http.ResponseWriter, r *http.Request) (string, bool) {
+	month := r.URL.Query().Get("month")
+	if month == "" {
+		return UsageMonth(time.Now()), true
+	}
+	if _, err := time.Parse("2006-01", month); err != nil {
+		s.respondError(w, http.StatusBadRequest, "month must be YYYY-MM")
+		return "", false
+	}
+	return month, true
+}
+
+// tenantUsage returns a tenant's usage with its name and budget
+func tenantUsage(cfg TenantsConfig, tenant *Tenant, month string) (TenantUsage, error) {
+	usage, err := cfg.Usage.Usage(tenant.ID, month)
+	if err != nil {
+		return usage, err
+	}
+	usage.TenantID = tenant.ID
+	usage.Name = tenant.Name
+	usage.Month = month
+	usage.BudgetUSD = tenant.MonthlyBudget
+	return usage, nil
+}
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"briefly/internal/config"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestServer returns a server without a database; tests only reach routes that
+// don't read it
+func newTestServer(t *testing.T, cfg config.Server) *Server {
+	t.Helper()
+	return New(nil, cfg)
+}
+
+// serve sends a request through the server's full handler, middleware included
+func serve(s *Server, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.httpServer.Handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// memoryUsage is an in-memory TenantUsageStore
+type memoryUsage struct {
+	mu    sync.Mutex
+	usage map[string]TenantUsage // By tenant ID and month
+}
+
+func (m *memoryUsage) AddRequest(tenantID, month string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	usage := m.usage[tenantID+"/"+month]
+	usage.Requests++
+	m.usage[tenantID+"/"+month] = usage
+	return nil
+}
+
+func (m *memoryUsage) Usage(tenantID, month string) (TenantUsage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usage[tenantID+"/"+month], nil
+}
+
+func (m *memoryUsage) spend(tenantID, month string, cost float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	usage := m.usage[tenantID+"/"+month]
+	usage.CostUSD += cost
+	m.usage[tenantID+"/"+month] = usage
+}
+
+// cachingSummarizer stands in for a tenant's pipeline and its own cache
+type cachingSummarizer struct {
+	mu    sync.Mutex
+	cache map[string]bool
+}
+
+func (c *cachingSummarizer) SummarizeURL(ctx context.Context, url string) (*QuickSummary, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached := c.cache[url]
+	c.cache[url] = true
+	return &QuickSummary{Title: "Post", URL: url, Summary: "Summary", Cached: cached}, nil
+}
+
+// newTenantServer returns a server with tenants "alpha" (budget $5) and "beta"
+func newTenantServer(t *testing.T) (*Server, *memoryUsage, map[string]*cachingSummarizer) {
+	t.Helper()
+	usage := &memoryUsage{usage: make(map[string]TenantUsage)}
+	summarizers := map[string]*cachingSummarizer{
+		"alpha": {cache: make(map[string]bool)},
+		"beta":  {cache: make(map[string]bool)},
+	}
+	s := newTestServer(t, config.Server{})
+	err := s.EnableTenants(TenantsConfig{
+		Tenants: []Tenant{
+			{ID: "alpha", Token: "alpha-token", MonthlyBudget: 5, Summarizer: summarizers["alpha"]},
+			{ID: "beta", Token: "beta-token", Summarizer: summarizers["beta"]},
+		},
+		AdminToken: "admin-token",
+		Usage:      usage,
+	})
+	if err != nil {
+		t.Fatalf("EnableTenants: %v", err)
+	}
+	return s, usage, summarizers
+}
+
+func summarize(s *Server, token, url string) *httptest.ResponseRecorder {
+	return serve(s, http.MethodPost, "/api/summarize", token, `{"url": "`+url+`"}`)
+}
+
+func TestEnableTenants_Validation(t *testing.T) {
+	summarizer := &cachingSummarizer{cache: make(map[string]bool)}
+	for name, tenants := range map[string][]Tenant{
+		"missing token": {{ID: "alpha", Summarizer: summarizer}},
+		"path in id":    {{ID: "../alpha", Token: "a", Summarizer: summarizer}},
+		"uppercase id":  {{ID: "Alpha", Token: "a", Summarizer: summarizer}},
+		"duplicate id":  {{ID: "alpha", Token: "a", Summarizer: summarizer}, {ID: "alpha", Token: "b", Summarizer: summarizer}},
+		"shared token":  {{ID: "alpha", Token: "a", Summarizer: summarizer}, {ID: "beta", Token: "a", Summarizer: summarizer}},
+		"no summarizer": {{ID: "alpha", Token: "a"}},
+	} {
+		s := newTestServer(t, config.Server{})
+		if err := s.EnableTenants(TenantsConfig{Tenants: tenants, Usage: &memoryUsage{}}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTenants_Auth(t *testing.T) {
+	s, usage, summarizers := newTenantServer(t)
+
+	for name, token := range map[string]string{"missing": "", "invalid": "nope"} {
+		if rec := summarize(s, token, "https://example.com/a"); rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s token: expected 401, got %d", name, rec.Code)
+		}
+	}
+	if len(summarizers["alpha"].cache) != 0 || len(usage.usage) != 0 {
+		t.Error("expected rejected requests neither summarized nor counted")
+	}
+
+	if rec := summarize(s, "alpha-token", "https://example.com/a"); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a tenant token, got %d: %s", rec.Code, rec.Body)
+	}
+	// The admin token is not a tenant; tenant tokens don't open shared or admin routes
+	if rec := summarize(s, "admin-token", "https://example.com/a"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for the admin token on a tenant route, got %d", rec.Code)
+	}
+	for _, path := range []string{"/api/digests", "/api/articles", "/api/manual-urls", "/api/tenants/usage"} {
+		if rec := serve(s, http.MethodGet, path, "alpha-token", ""); rec.Code != http.StatusForbidden {
+			t.Errorf("GET %s: expected 403 for a tenant token, got %d", path, rec.Code)
+		}
+	}
+	if rec := serve(s, http.MethodPost, "/api/capture", "alpha-token", `{"url": "https://example.com/a"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a tenant capture, got %d", rec.Code)
+	}
+	if rec := serve(s, http.MethodGet, "/api/digests", "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a shared route without a token, got %d", rec.Code)
+	}
+	if rec := serve(s, http.MethodGet, "/api/tenants/usage", "admin-token", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected 200 for the admin token, got %d", rec.Code)
+	}
+}
+
+func TestTenants_Budget(t *testing.T) {
+	s, usage, summarizers := newTenantServer(t)
+	month := UsageMonth(time.Now())
+
+	usage.spend("alpha", month, 4.99)
+	if rec := summarize(s, "alpha-token", "https://example.com/a"); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 under budget, got %d", rec.Code)
+	}
+
+	usage.spend("alpha", month, 0.01)
+	rec := summarize(s, "alpha-token", "https://example.com/b")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the budget is spent, got %d", rec.Code)
+	}
+	if summarizers["alpha"].cache["https://example.com/b"] {
+		t.Error("expected no summary over budget")
+	}
+
+	// Another tenant's spend doesn't count against beta, which has no budget
+	usage.spend("beta", month, 100)
+	if rec := summarize(s, "beta-token", "https://example.com/b"); rec.Code != http.StatusOK {
+		t.Errorf("expected 200 for a tenant without a budget, got %d", rec.Code)
+	}
+}
+
+func TestTenants_Usage(t *testing.T) {
+	s, usage, _ := newTenantServer(t)
+	month := UsageMonth(time.Now())
+
+	for i := 0; i < 3; i++ {
+		summarize(s, "alpha-token", "https://example.com/a")
+	}
+	summarize(s, "beta-token", "https://example.com/a")
+	summarize(s, "nope", "https://example.com/a")
+
+	if got, _ := usage.Usage("alpha", month); got.Requests != 3 {
+		t.Errorf("expected 3 requests counted for alpha, got %d", got.Requests)
+	}
+	if got, _ := usage.Usage("beta", month); got.Requests != 1 {
+		t.Errorf("expected 1 request counted for beta, got %d", got.Requests)
+	}
+
+	// The usage request itself is counted too
+	rec := serve(s, http.MethodGet, "/api/tenant/usage", "beta-token", "")
+	var own TenantUsage
+	if err := json.Unmarshal(rec.Body.Bytes(), &own); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("expected beta's usage, got %d: %s", rec.Code, rec.Body)
+	}
+	if own.TenantID != "beta" || own.Month != month || own.Requests != 2 {
+		t.Errorf("unexpected usage %+v", own)
+	}
+
+	rec = serve(s, http.MethodGet, "/api/tenants/usage", "admin-token", "")
+	var all struct {
+		Tenants []TenantUsage `json:"tenants"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &all); err != nil || len(all.Tenants) != 2 {
+		t.Fatalf("expected every tenant's usage, got %d: %s", rec.Code, rec.Body)
+	}
+	if all.Tenants[0].TenantID != "alpha" || all.Tenants[0].Requests != 3 || all.Tenants[0].BudgetUSD != 5 {
+		t.Errorf("unexpected alpha usage %+v", all.Tenants[0])
+	}
+
+	if rec := serve(s, http.MethodGet, "/api/tenant/usage?month=October", "beta-token", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a bad month, got %d", rec.Code)
+	}
+}
+
+func TestTenants_CacheIsolation(t *testing.T) {
+	s, _, summarizers := newTenantServer(t)
+	url := "https://example.com/shared-post"
+
+	summarize(s, "alpha-token", url)
+	rec := summarize(s, "alpha-token", url)
+	var summary QuickSummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil || !summary.Cached {
+		t.Fatalf("expected alpha's second request served from its cache, got %s", rec.Body)
+	}
+
+	rec = summarize(s, "beta-token", url)
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil || summary.Cached {
+		t.Errorf("expected beta not to see alpha's cached summary, got %s", rec.Body)
+	}
+	if len(summarizers["beta"].cache) != 1 || len(summarizers["alpha"].cache) != 1 {
+		t.Error("expected each tenant's request to reach only its own summarizer")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"briefly/internal/config"
 	"briefly/internal/core"
 	"briefly/internal/persistence"
+	"briefly/internal/transcript"
 )
 
 // getPostHogConfig returns PostHog configuration
@@ -501,11 +502,16 @@ func (s *Server) prepareDigestDetailData(ctx context.Context, digest *core.Diges
 	// v2.0: Use KeyMoments structs (Quote + CitationNumber)
 	keyMomentsData := make([]KeyMomentView, 0, len(digest.KeyMoments))
 	for _, moment := range digest.KeyMoments {
-		keyMomentsData = append(keyMomentsData, KeyMomentView{
+		view := KeyMomentView{
 			Quote:          moment.Quote,
 			CitationNumber: moment.CitationNumber,
 			ArticleID:      moment.ArticleID,
-		})
+			TimestampURL:   moment.TimestampURL,
+		}
+		if moment.TimestampURL != "" {
+			view.Timestamp = transcript.FormatTimestamp(moment.Timestamp)
+		}
+		keyMomentsData = append(keyMomentsData, view)
 	}
 
 	// v2.0: Use Perspectives structs (Type + Summary + CitationNumbers)
@@ -666,6 +672,8 @@ type KeyMomentView struct {
 	Quote          string
 	CitationNumber int
 	ArticleID      string
+	Timestamp      string // e.g. "4:33", when the quote is from a video or podcast
+	TimestampURL   string
 }
 
 // PerspectiveDetailView represents a perspective for v2.0 rendering
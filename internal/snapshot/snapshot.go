@@ -0,0 +1,430 @@
+// Package snapshot archives the original HTML of digested articles as standalone
+// files next to the cache, so an article can still be read after its source
+// disappears. Snapshots are either the page HTML (with a <base> so links resolve)
+// or a single-file MHTML bundle that inlines images and stylesheets.
+package snapshot
+
+import (
+	"briefly/internal/core"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Snapshot formats
+const (
+	FormatHTML  = "html"
+	FormatMHTML = "mhtml"
+)
+
+// indexFile lists every snapshot in a directory
+const indexFile = "index.json"
+
+// MHTML asset limits keep a single heavy page from bloating the archive
+const (
+	defaultMaxAssets     = 40
+	defaultMaxAssetBytes = 2 << 20
+)
+
+// Entry describes one saved snapshot
+type Entry struct {
+	ID      string    `json:"id"`
+	URL     string    `json:"url"`
+	Title   string    `json:"title"`
+	Format  string    `json:"format"`
+	File    string    `json:"file"` // Relative to the snapshot directory
+	Assets  int       `json:"assets,omitempty"`
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// Archiver writes snapshots to a directory and maintains its index
+type Archiver struct {
+	dir           string
+	format        string
+	client        *http.Client
+	maxAssets     int
+	maxAssetBytes int64
+
+	mu sync.Mutex
+}
+
+// Dir returns the snapshot directory inside a cache directory
+func Dir(cacheDir string) string {
+	return filepath.Join(cacheDir, "snapshots")
+}
+
+// ID is the stable snapshot identifier for an article URL
+func ID(articleURL string) string {
+	sum := sha256.Sum256([]byte(articleURL))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// NewArchiver creates an archiver writing format snapshots under cacheDir
+func NewArchiver(cacheDir, format string) (*Archiver, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = FormatHTML
+	}
+	if format != FormatHTML && format != FormatMHTML {
+		return nil, fmt.Errorf("unsupported snapshot format %q (use html or mhtml)", format)
+	}
+
+	// Snapshots hold full pages, so they are readable by the owner only
+	dir := Dir(cacheDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	return &Archiver{
+		dir:           dir,
+		format:        format,
+		client:        &http.Client{Timeout: 15 * time.Second},
+		maxAssets:     defaultMaxAssets,
+		maxAssetBytes: defaultMaxAssetBytes,
+	}, nil
+}
+
+// Save snapshots an article unless one already exists for its URL. Articles without
+// fetched HTML (PDFs, transcripts, pruned cache entries) are skipped. Returns the
+// entry and whether a new file was written.
+func (a *Archiver) Save(ctx context.Context, article core.Article) (*Entry, bool, error) {
+	articleURL := articleURL(article)
+	if articleURL == "" || strings.TrimSpace(article.FetchedHTML) == "" {
+		return nil, false, nil
+	}
+
+	id := ID(articleURL)
+	if existing, err := Lookup(a.dir, id); err == nil && existing != nil {
+		return existing, false, nil
+	}
+
+	entry := &Entry{
+		ID:      id,
+		URL:     articleURL,
+		Title:   article.Title,
+		Format:  a.format,
+		File:    id + "." + a.format,
+		SavedAt: time.Now().UTC(),
+	}
+
+	var data []byte
+	var err error
+	switch a.format {
+	case FormatMHTML:
+		data, entry.Assets, err = a.buildMHTML(ctx, articleURL, article.Title, article.FetchedHTML, entry.SavedAt)
+	default:
+		data = []byte(withBase(article.FetchedHTML, articleURL, entry.SavedAt))
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := os.WriteFile(filepath.Join(a.dir, entry.File), data, 0600); err != nil {
+		return nil, false, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := addToIndex(a.dir, *entry); err != nil {
+		return nil, false, err
+	}
+	return entry, true, nil
+}
+
+// SaveHTML writes an HTML snapshot from already-archived page HTML, for articles
+// digested before snapshots were enabled
+func SaveHTML(cacheDir, articleURL, title, pageHTML string) (*Entry, error) {
+	archiver, err := NewArchiver(cacheDir, FormatHTML)
+	if err != nil {
+		return nil, err
+	}
+	entry, _, err := archiver.Save(context.Background(), core.Article{URL: articleURL, Title: title, FetchedHTML: pageHTML})
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("no HTML to snapshot for %s", articleURL)
+	}
+	return entry, nil
+}
+
+// List returns the snapshots in dir, newest first
+func List(dir string) ([]Entry, error) {
+	index, err := readIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(index))
+	for _, entry := range index {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].SavedAt.After(entries[j].SavedAt) })
+	return entries, nil
+}
+
+// Lookup returns the snapshot with this exact ID, or nil when there is none
+func Lookup(dir, id string) (*Entry, error) {
+	index, err := readIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	if entry, ok := index[id]; ok {
+		if _, err := os.Stat(filepath.Join(dir, entry.File)); err == nil {
+			return &entry, nil
+		}
+	}
+	return nil, nil
+}
+
+// Find resolves a query to snapshots: an exact article URL, a snapshot ID prefix,
+// or a substring of the URL
+func Find(dir, query string) ([]Entry, error) {
+	entries, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.TrimSpace(query)
+	if entry, err := Lookup(dir, ID(query)); err == nil && entry != nil {
+		return []Entry{*entry}, nil
+	}
+
+	var matches []Entry
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.ID, query) || strings.Contains(entry.URL, query) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches, nil
+}
+
+// Path returns the absolute path of a snapshot file
+func (e Entry) Path(dir string) string {
+	return filepath.Join(dir, e.File)
+}
+
+// withBase inserts a <base> element and a provenance comment so the saved page
+// resolves relative links against the original site
+func withBase(pageHTML, articleURL string, savedAt time.Time) string {
+	header := fmt.Sprintf("<!-- Snapshot of %s saved by Briefly at %s -->\n", articleURL, savedAt.Format(time.RFC3339))
+	base := fmt.Sprintf(`<base href="%s">`, html.EscapeString(articleURL))
+
+	lower := strings.ToLower(pageHTML)
+	if strings.Contains(lower, "<base ") {
+		return header + pageHTML
+	}
+	if idx := strings.Index(lower, "<head"); idx >= 0 {
+		if end := strings.Index(lower[idx:], ">"); end >= 0 {
+			insertAt := idx + end + 1
+			return header + pageHTML[:insertAt] + base + pageHTML[insertAt:]
+		}
+	}
+	return header + base + pageHTML
+}
+
+// buildMHTML bundles the page HTML with its images and stylesheets as
+// multipart/related, the format browsers open as a single-file web archive
+func (a *Archiver) buildMHTML(ctx context.Context, articleURL, title, pageHTML string, savedAt time.Time) ([]byte, int, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	htmlPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {`text/html; charset="utf-8"`},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+		"Content-Location":          {articleURL},
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create MHTML part: %w", err)
+	}
+	qp := quotedprintable.NewWriter(htmlPart)
+	if _, err := qp.Write([]byte(pageHTML)); err != nil {
+		return nil, 0, fmt.Errorf("failed to encode snapshot HTML: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, 0, fmt.Errorf("failed to encode snapshot HTML: %w", err)
+	}
+
+	assets := 0
+	for _, assetURL := range assetURLs(pageHTML, articleURL, a.maxAssets) {
+		data, contentType, err := a.fetchAsset(ctx, assetURL)
+		if err != nil {
+			continue // Missing assets degrade the snapshot but do not fail it
+		}
+
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Location":          {assetURL},
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create MHTML part: %w", err)
+		}
+		if err := writeBase64Lines(part, data); err != nil {
+			return nil, 0, err
+		}
+		assets++
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, 0, fmt.Errorf("failed to finish MHTML: %w", err)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "From: <Saved by Briefly>\r\n")
+	fmt.Fprintf(&out, "Snapshot-Content-Location: %s\r\n", articleURL)
+	fmt.Fprintf(&out, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", title))
+	fmt.Fprintf(&out, "Date: %s\r\n", savedAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&out, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&out, "Content-Type: multipart/related; type=\"text/html\"; boundary=\"%s\"\r\n\r\n", writer.Boundary())
+	out.Write(body.Bytes())
+
+	return out.Bytes(), assets, nil
+}
+
+// assetURLs returns the absolute URLs of images and stylesheets in pageHTML
+func assetURLs(pageHTML, pageURL string, limit int) []string {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var urls []string
+	add := func(ref string) {
+		ref = strings.TrimSpace(ref)
+		if ref == "" || strings.HasPrefix(ref, "data:") || len(urls) >= limit {
+			return
+		}
+		resolved, err := base.Parse(ref)
+		if err != nil || (resolved.Scheme != "http" && resolved.Scheme != "https") {
+			return
+		}
+		if abs := resolved.String(); !seen[abs] {
+			seen[abs] = true
+			urls = append(urls, abs)
+		}
+	}
+
+	doc.Find(`link[rel~="stylesheet"]`).Each(func(_ int, s *goquery.Selection) {
+		add(s.AttrOr("href", ""))
+	})
+	doc.Find("img").Each(func(_ int, s *goquery.Selection) {
+		add(s.AttrOr("src", ""))
+	})
+	return urls
+}
+
+// fetchAsset downloads one asset within the size limit
+func (a *Archiver) fetchAsset(ctx context.Context, assetURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, assetURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("User-Agent", "Briefly/1.0 (+snapshot)")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("asset %s returned %d", assetURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, a.maxAssetBytes+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > a.maxAssetBytes {
+		return nil, "", fmt.Errorf("asset %s exceeds %d bytes", assetURL, a.maxAssetBytes)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return data, contentType, nil
+}
+
+// writeBase64Lines writes data as base64 wrapped at 76 characters, as MIME requires
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := min(76, len(encoded))
+		if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+			return fmt.Errorf("failed to write MHTML asset: %w", err)
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
+
+// articleURL returns the article's original URL
+func articleURL(article core.Article) string {
+	if article.URL != "" {
+		return article.URL
+	}
+	if strings.HasPrefix(article.LinkID, "http") {
+		return article.LinkID
+	}
+	return ""
+}
+
+// readIndex loads the snapshot index; a missing index is empty
+func readIndex(dir string) (map[string]Entry, error) {
+	index := make(map[string]Entry)
+	data, err := os.ReadFile(filepath.Join(dir, indexFile))
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot index: %w", err)
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot index: %w", err)
+	}
+	return index, nil
+}
+
+// addToIndex records entry in the directory's index
+func addToIndex(dir string, entry Entry) error {
+	index, err := readIndex(dir)
+	if err != nil {
+		return err
+	}
+	index[entry.ID] = entry
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot index: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, indexFile), data, 0600); err != nil {
+		return fmt.Errorf("failed to write snapshot index: %w", err)
+	}
+	return nil
+}
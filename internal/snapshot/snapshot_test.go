@@ -0,0 +1,142 @@
+package snapshot
+
+import (
+	"briefly/internal/core"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const testPage = `<html><head><title>Post</title></head><body><p>Hello</p><img src="/logo.png"></body></html>`
+
+func TestSaveHTML_InsertsBaseAndIndexes(t *testing.T) {
+	cacheDir := t.TempDir()
+	archiver, err := NewArchiver(cacheDir, FormatHTML)
+	if err != nil {
+		t.Fatalf("NewArchiver: %v", err)
+	}
+
+	article := core.Article{URL: "https://example.com/posts/1", Title: "Post", FetchedHTML: testPage}
+	entry, created, err := archiver.Save(context.Background(), article)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if !created || entry == nil {
+		t.Fatalf("expected a new snapshot, got created=%v entry=%v", created, entry)
+	}
+	if entry.ID != ID(article.URL) {
+		t.Errorf("ID = %q, want %q", entry.ID, ID(article.URL))
+	}
+
+	data, err := os.ReadFile(entry.Path(Dir(cacheDir)))
+	if err != nil {
+		t.Fatalf("read snapshot: %v", err)
+	}
+	if !strings.Contains(string(data), `<head><base href="https://example.com/posts/1">`) {
+		t.Errorf("snapshot is missing <base>: %s", data)
+	}
+
+	// A second save is a no-op
+	if _, created, err := archiver.Save(context.Background(), article); err != nil || created {
+		t.Errorf("second Save: created=%v err=%v, want existing snapshot", created, err)
+	}
+
+	for _, query := range []string{article.URL, entry.ID[:6], "posts/1"} {
+		matches, err := Find(Dir(cacheDir), query)
+		if err != nil {
+			t.Fatalf("Find(%q): %v", query, err)
+		}
+		if len(matches) != 1 || matches[0].ID != entry.ID {
+			t.Errorf("Find(%q) = %v, want %s", query, matches, entry.ID)
+		}
+	}
+}
+
+func TestSave_OwnerOnlyPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permissions")
+	}
+	cacheDir := t.TempDir()
+	archiver, err := NewArchiver(cacheDir, FormatHTML)
+	if err != nil {
+		t.Fatalf("NewArchiver: %v", err)
+	}
+	entry, _, err := archiver.Save(context.Background(), core.Article{URL: "https://example.com/posts/1", FetchedHTML: testPage})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	dir := Dir(cacheDir)
+	for path, want := range map[string]os.FileMode{
+		dir:                           0700,
+		entry.Path(dir):               0600,
+		filepath.Join(dir, indexFile): 0600,
+	} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat %s: %v", path, err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("%s: mode %o, want %o", filepath.Base(path), got, want)
+		}
+	}
+}
+
+func TestSave_SkipsArticlesWithoutHTML(t *testing.T) {
+	archiver, err := NewArchiver(t.TempDir(), FormatHTML)
+	if err != nil {
+		t.Fatalf("NewArchiver: %v", err)
+	}
+
+	entry, created, err := archiver.Save(context.Background(), core.Article{URL: "https://example.com/paper.pdf", RawContent: "text"})
+	if err != nil || created || entry != nil {
+		t.Errorf("Save without HTML = (%v, %v, %v), want nothing saved", entry, created, err)
+	}
+}
+
+func TestSaveMHTML_InlinesAssets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logo.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("\x89PNG fake image"))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	archiver, err := NewArchiver(cacheDir, FormatMHTML)
+	if err != nil {
+		t.Fatalf("NewArchiver: %v", err)
+	}
+
+	entry, _, err := archiver.Save(context.Background(), core.Article{URL: server.URL + "/post", Title: "Post", FetchedHTML: testPage})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if entry.Assets != 1 {
+		t.Errorf("Assets = %d, want 1", entry.Assets)
+	}
+
+	data, err := os.ReadFile(entry.Path(Dir(cacheDir)))
+	if err != nil {
+		t.Fatalf("read snapshot: %v", err)
+	}
+	for _, want := range []string{"multipart/related", "Content-Location: " + server.URL + "/logo.png", "Content-Type: image/png"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("MHTML missing %q", want)
+		}
+	}
+}
+
+func TestNewArchiver_RejectsUnknownFormat(t *testing.T) {
+	if _, err := NewArchiver(t.TempDir(), "pdf"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
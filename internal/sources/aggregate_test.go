@@ -0,0 +1,120 @@
+package sources
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/persistence"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mockFeedRepo struct {
+	mu     sync.Mutex
+	feeds  []core.Feed
+	errors map[string]int // Feed ID -> error count after updates
+}
+
+func (m *mockFeedRepo) Create(ctx context.Context, feed *core.Feed) error { return nil }
+func (m *mockFeedRepo) Get(ctx context.Context, id string) (*core.Feed, error) {
+	return nil, nil
+}
+func (m *mockFeedRepo) GetByURL(ctx context.Context, url string) (*core.Feed, error) {
+	return nil, nil
+}
+func (m *mockFeedRepo) ListActive(ctx context.Context) ([]core.Feed, error) { return m.feeds, nil }
+func (m *mockFeedRepo) List(ctx context.Context, opts persistence.ListOptions) ([]core.Feed, error) {
+	return m.feeds, nil
+}
+func (m *mockFeedRepo) Update(ctx context.Context, feed *core.Feed) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[feed.ID] = feed.ErrorCount
+	return nil
+}
+func (m *mockFeedRepo) Delete(ctx context.Context, id string) error { return nil }
+func (m *mockFeedRepo) UpdateLastFetched(ctx context.Context, id string, lastModified, etag string) error {
+	return nil
+}
+
+type feedDatabase struct {
+	*MockDatabase
+	feeds *mockFeedRepo
+}
+
+func (d *feedDatabase) Feeds() persistence.FeedRepository { return d.feeds }
+
+func TestAggregate_HangingFeedTimesOutAlone(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hanging" {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		fmt.Fprintf(w, `<rss><channel><title>%s</title><item><title>Post</title><link>https://example.com%s/post</link><pubDate>%s</pubDate></item></channel></rss>`,
+			r.URL.Path, r.URL.Path, time.Now().Format(time.RFC1123Z))
+	}))
+	defer server.Close()
+
+	repo := &mockFeedRepo{errors: make(map[string]int)}
+	repo.feeds = append(repo.feeds, core.Feed{ID: "hanging", URL: server.URL + "/hanging"})
+	for i := 0; i < 20; i++ {
+		repo.feeds = append(repo.feeds, core.Feed{ID: fmt.Sprintf("f%d", i), URL: fmt.Sprintf("%s/f%d", server.URL, i)})
+	}
+	mgr := NewManager(&feedDatabase{MockDatabase: NewMockDatabase(), feeds: repo})
+
+	opts := DefaultAggregateOptions()
+	opts.MaxConcurrency = 4
+	opts.FeedTimeout = 200 * time.Millisecond
+	start := time.Now()
+	result, err := mgr.Aggregate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the hanging feed not to stall the pull, took %s", elapsed)
+	}
+
+	if result.FeedsFetched != 20 || result.FeedsFailed != 1 || result.NewArticles != 20 {
+		t.Errorf("expected 20 feeds fetched and 1 failed, got %+v", result)
+	}
+	if len(result.Errors) != 1 || !errors.Is(result.Errors[0], ErrFeedTimeout) || !strings.Contains(result.Errors[0].Error(), "/hanging") {
+		t.Fatalf("expected one timeout error naming the feed, got %v", result.Errors)
+	}
+	if repo.errors["hanging"] != 1 {
+		t.Errorf("expected the hanging feed's error count raised, got %d", repo.errors["hanging"])
+	}
+}
+
+func TestAggregate_CancelledDoesNotCountAgainstFeeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	repo := &mockFeedRepo{errors: make(map[string]int)}
+	for i := 0; i < 3; i++ {
+		repo.feeds = append(repo.feeds, core.Feed{ID: fmt.Sprintf("f%d", i), URL: fmt.Sprintf("%s/f%d", server.URL, i)})
+	}
+	mgr := NewManager(&feedDatabase{MockDatabase: NewMockDatabase(), feeds: repo})
+
+	opts := DefaultAggregateOptions()
+	opts.MaxConcurrency = 1
+	opts.Timeout = 100 * time.Millisecond
+	opts.FeedTimeout = time.Minute
+	if _, err := mgr.Aggregate(context.Background(), opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the aggregation deadline, got %v", err)
+	}
+	if len(repo.errors) != 0 {
+		t.Errorf("expected no feed error counts raised by cancellation, got %v", repo.errors)
+	}
+}
@@ -0,0 +1,127 @@
+package sources
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/search"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SearchFeedID is the special feed that results of followed topics are filed under
+const SearchFeedID = "search"
+
+// defaultFollowResults is how many results a followed topic queues per pull
+const defaultFollowResults = 10
+
+// Searcher runs a web search for followed topics
+type Searcher interface {
+	Search(ctx context.Context, query string, maxResults int) ([]search.Result, error)
+}
+
+// FollowQuery is a standing search query whose results are queued like feed items
+type FollowQuery struct {
+	Query      string
+	MaxResults int // 0 = defaultFollowResults
+}
+
+// FollowResult contains statistics for a pull of followed topics
+type FollowResult struct {
+	QueriesRun int
+	NewItems   int
+	Duplicates int
+	Errors     []error
+}
+
+// PullFollowedTopics runs each standing query and queues result URLs it hasn't
+// seen before as unprocessed feed items of the search feed, so they are
+// classified and digested like articles from RSS sources
+func (m *Manager) PullFollowedTopics(ctx context.Context, searcher Searcher, queries []FollowQuery) (*FollowResult, error) {
+	result := &FollowResult{}
+
+	for _, query := range queries {
+		select {
+		case <-ctx.Done():
+			m.log.Warn("Followed topics pull cancelled", "reason", ctx.Err())
+			return result, ctx.Err()
+		default:
+		}
+
+		text := strings.TrimSpace(query.Query)
+		if text == "" {
+			continue
+		}
+		maxResults := query.MaxResults
+		if maxResults <= 0 {
+			maxResults = defaultFollowResults
+		}
+
+		results, err := searcher.Search(ctx, text, maxResults)
+		if err != nil {
+			m.log.Error("Followed topic search failed", "query", text, "error", err)
+			result.Errors = append(result.Errors, fmt.Errorf("follow %q: %w", text, err))
+			continue
+		}
+		result.QueriesRun++
+
+		for _, found := range results {
+			item := followItem(text, found, time.Now())
+			if item == nil {
+				continue
+			}
+
+			if existing, err := m.db.FeedItems().Get(ctx, item.ID); err == nil && existing != nil {
+				result.Duplicates++
+				continue
+			}
+			if err := m.db.FeedItems().Create(ctx, item); err != nil {
+				m.log.Error("Failed to store followed topic result", "url", item.Link, "error", err)
+				result.Errors = append(result.Errors, fmt.Errorf("store %s: %w", item.Link, err))
+				continue
+			}
+			result.NewItems++
+		}
+
+		m.log.Info("Pulled followed topic", "query", text, "results", len(results))
+	}
+
+	return result, nil
+}
+
+// followItem converts a search result into a feed item of the search feed, or nil
+// when the result has no URL. IDs are derived from the URL, so a result found again
+// on a later pull is recognized as a duplicate.
+func followItem(query string, found search.Result, now time.Time) *core.FeedItem {
+	link := strings.TrimSpace(found.URL)
+	if link == "" {
+		return nil
+	}
+
+	title := strings.TrimSpace(found.Title)
+	if title == "" {
+		title = link
+	}
+	description := fmt.Sprintf("Found by following %q", query)
+	if found.Snippet != "" {
+		description += "\n" + found.Snippet
+	}
+	published := now
+	if found.Paper != nil && !found.Paper.Published.IsZero() {
+		published = found.Paper.Published
+	}
+
+	return &core.FeedItem{
+		ID:             uuid.NewSHA1(uuid.NameSpaceURL, []byte(SearchFeedID+link)).String(),
+		FeedID:         SearchFeedID,
+		Title:          title,
+		Link:           link,
+		Description:    description,
+		Published:      published,
+		GUID:           link,
+		Processed:      false,
+		DateDiscovered: now,
+	}
+}
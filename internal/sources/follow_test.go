@@ -0,0 +1,73 @@
+package sources
+
+import (
+	"briefly/internal/logger"
+	"briefly/internal/search"
+	"context"
+	"errors"
+	"testing"
+)
+
+type mockSearcher struct {
+	results map[string][]search.Result
+	queries []string
+}
+
+func (s *mockSearcher) Search(ctx context.Context, query string, maxResults int) ([]search.Result, error) {
+	s.queries = append(s.queries, query)
+	results, ok := s.results[query]
+	if !ok {
+		return nil, errors.New("mock search error")
+	}
+	if len(results) > maxResults {
+		results = results[:maxResults]
+	}
+	return results, nil
+}
+
+func TestPullFollowedTopics_QueuesNewResults(t *testing.T) {
+	mockDB := NewMockDatabase()
+	manager := &Manager{db: mockDB, log: logger.Get()}
+	searcher := &mockSearcher{results: map[string][]search.Result{
+		"eBPF security": {
+			{Title: "Tracing syscalls with eBPF", URL: "https://example.com/ebpf", Snippet: "A look at LSM hooks"},
+			{Title: "", URL: "https://example.com/untitled"},
+			{Title: "No URL"},
+		},
+		"wasm runtimes": {
+			{Title: "Tracing syscalls with eBPF", URL: "https://example.com/ebpf"},
+		},
+	}}
+
+	result, err := manager.PullFollowedTopics(context.Background(), searcher, []FollowQuery{
+		{Query: "eBPF security"},
+		{Query: "  "},
+		{Query: "wasm runtimes", MaxResults: 1},
+		{Query: "unknown"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if result.QueriesRun != 2 || result.NewItems != 2 || result.Duplicates != 1 || len(result.Errors) != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if len(searcher.queries) != 3 {
+		t.Errorf("Expected blank queries to be skipped, searched %v", searcher.queries)
+	}
+
+	items := mockDB.feedItems.items
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 queued items, got %d", len(items))
+	}
+	first := items[0]
+	if first.FeedID != SearchFeedID || first.Processed || first.GUID != first.Link {
+		t.Errorf("Expected an unprocessed search feed item, got %+v", first)
+	}
+	if !contains(first.Description, `Found by following "eBPF security"`) || !contains(first.Description, "LSM hooks") {
+		t.Errorf("Expected the query and snippet in the description, got: %s", first.Description)
+	}
+	if items[1].Title != "https://example.com/untitled" {
+		t.Errorf("Expected an untitled result to fall back to its URL, got %q", items[1].Title)
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"briefly/internal/logger"
 	"briefly/internal/persistence"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -29,8 +32,8 @@ func NewManager(db persistence.Database) *Manager {
 	}
 }
 
-// AddFeed adds a new RSS/Atom feed source
-func (m *Manager) AddFeed(ctx context.Context, feedURL string) (*core.Feed, error) {
+// AddFeed adds a new RSS/Atom feed source, filed under category ("" = uncategorized)
+func (m *Manager) AddFeed(ctx context.Context, feedURL string, category string) (*core.Feed, error) {
 	// Check if feed already exists
 	existingFeed, err := m.db.Feeds().GetByURL(ctx, feedURL)
 	if err == nil {
@@ -44,6 +47,7 @@ func (m *Manager) AddFeed(ctx context.Context, feedURL string) (*core.Feed, erro
 	}
 
 	// Store feed in database
+	parsedFeed.Feed.Category = NormalizeCategory(category)
 	if err := m.db.Feeds().Create(ctx, &parsedFeed.Feed); err != nil {
 		return nil, fmt.Errorf("failed to store feed: %w", err)
 	}
@@ -86,12 +90,39 @@ func (m *Manager) ToggleFeed(ctx context.Context, feedID string, active bool) er
 	return nil
 }
 
+// SetFeedCategory files a feed under category, or uncategorizes it when category is empty
+func (m *Manager) SetFeedCategory(ctx context.Context, feedID string, category string) (*core.Feed, error) {
+	feed, err := m.db.Feeds().Get(ctx, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("feed not found: %w", err)
+	}
+
+	feed.Category = NormalizeCategory(category)
+	if err := m.db.Feeds().Update(ctx, feed); err != nil {
+		return nil, fmt.Errorf("failed to update feed: %w", err)
+	}
+
+	m.log.Info("Set feed category", "id", feedID, "category", feed.Category)
+	return feed, nil
+}
+
+// NormalizeCategory canonicalizes a category name so "Dev Tools" and "dev-tools"
+// name the same folder: trimmed, lowercased, with whitespace runs replaced by "-"
+func NormalizeCategory(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// ErrFeedTimeout is wrapped by the error of a feed that didn't respond within
+// AggregateOptions.FeedTimeout
+var ErrFeedTimeout = errors.New("timed out")
+
 // AggregateOptions configures the aggregation process
 type AggregateOptions struct {
 	MaxArticlesPerFeed int           // Limit articles per feed (0 = no limit)
 	MaxConcurrency     int           // Number of feeds to fetch concurrently
 	Since              time.Time     // Only fetch items published after this date
 	Timeout            time.Duration // Timeout for entire aggregation
+	FeedTimeout        time.Duration // Timeout for each feed's fetch, so one hanging feed doesn't hold up the rest (0 = none)
 }
 
 // DefaultAggregateOptions returns sensible defaults
@@ -101,6 +132,7 @@ func DefaultAggregateOptions() AggregateOptions {
 		MaxConcurrency:     5,
 		Since:              time.Now().Add(-24 * time.Hour), // Last 24 hours
 		Timeout:            10 * time.Minute,
+		FeedTimeout:        30 * time.Second,
 	}
 }
 
@@ -112,6 +144,8 @@ type AggregateResult struct {
 	NewArticles       int
 	DuplicateArticles int
 	Errors            []error
+	Items             []core.FeedItem // Items stored by this run, for triage before they're processed
+	FailedFeeds       []core.Feed     // Feeds that failed to fetch, with their updated error counts
 }
 
 // AggregateWithClassificationOptions configures aggregation with inline classification
@@ -121,6 +155,9 @@ type AggregateWithClassificationOptions struct {
 	ThemeFilter    string    // Optional: Only process articles matching this theme
 	Since          time.Time // Only fetch items published after this date
 	MaxConcurrency int       // Number of articles to process concurrently
+
+	FeedFullText     bool // Build articles from full text published in the feed instead of fetching the page
+	FullTextMinWords int  // Shortest feed body taken as the full article (0 = feeds.DefaultFullTextMinWords)
 }
 
 // AggregateWithClassificationResult contains aggregation + classification statistics
@@ -158,20 +195,26 @@ func (m *Manager) Aggregate(ctx context.Context, opts AggregateOptions) (*Aggreg
 
 	// Process feeds with concurrency control
 	result := &AggregateResult{}
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = 1
+	}
 	sem := make(chan struct{}, opts.MaxConcurrency)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 
 	for _, feed := range feeds {
+		// Wait for a free slot, or stop queueing feeds once the aggregation is cancelled
 		select {
 		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
 			m.log.Warn("Aggregation cancelled", "reason", ctx.Err())
+			wg.Wait()
 			return result, ctx.Err()
-		default:
 		}
 
 		wg.Add(1)
-		sem <- struct{}{} // Acquire semaphore
 
 		go func(f core.Feed) {
 			defer wg.Done()
@@ -186,12 +229,18 @@ func (m *Manager) Aggregate(ctx context.Context, opts AggregateOptions) (*Aggreg
 			result.NewArticles += feedResult.NewArticles
 			result.DuplicateArticles += feedResult.DuplicateArticles
 			result.Errors = append(result.Errors, feedResult.Errors...)
+			result.Items = append(result.Items, feedResult.Items...)
+			result.FailedFeeds = append(result.FailedFeeds, feedResult.FailedFeeds...)
 			mu.Unlock()
 		}(feed)
 	}
 
 	wg.Wait()
 
+	// Feeds finish in any order; report failures in a stable one
+	sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Error() < result.Errors[j].Error() })
+	sort.Slice(result.FailedFeeds, func(i, j int) bool { return result.FailedFeeds[i].URL < result.FailedFeeds[j].URL })
+
 	m.log.Info("Aggregation completed",
 		"fetched", result.FeedsFetched,
 		"skipped", result.FeedsSkipped,
@@ -309,7 +358,7 @@ func (m *Manager) AggregateWithClassification(ctx context.Context, processor Art
 			defer func() { <-sem }() // Release semaphore
 
 			// Fetch full article content
-			article, err := processor.ProcessArticle(ctx, feedItem.Link)
+			article, err := m.processFeedItem(ctx, processor, feedItem, opts.FeedFullText, opts.FullTextMinWords)
 			if err != nil {
 				m.log.Error("Failed to fetch article", "url", feedItem.Link, "error", err)
 				mu.Lock()
@@ -394,16 +443,21 @@ func (m *Manager) processFeed(ctx context.Context, feed core.Feed, opts Aggregat
 	result := &AggregateResult{}
 
 	// Fetch feed with conditional GET
-	parsedFeed, err := m.feedManager.FetchFeed(feed.URL, feed.LastModified, feed.ETag)
+	parsedFeed, err := m.fetchFeed(ctx, feed, opts.FeedTimeout)
 	if err != nil {
 		m.log.Error("Failed to fetch feed", "feed_id", feed.ID, "error", err)
 		result.FeedsFailed++
-		result.Errors = append(result.Errors, fmt.Errorf("feed %s: %w", feed.ID, err))
+		result.Errors = append(result.Errors, fmt.Errorf("feed %s: %w", feed.URL, err))
+		if ctx.Err() != nil {
+			// The whole aggregation was cancelled; that's not the feed's fault
+			return result
+		}
 
 		// Update error count
 		feed.ErrorCount++
 		feed.LastError = err.Error()
 		_ = m.db.Feeds().Update(ctx, &feed)
+		result.FailedFeeds = append(result.FailedFeeds, feed)
 		return result
 	}
 
@@ -439,6 +493,7 @@ func (m *Manager) processFeed(ctx context.Context, feed core.Feed, opts Aggregat
 			result.Errors = append(result.Errors, fmt.Errorf("store items for %s: %w", feed.ID, err))
 		} else {
 			result.NewArticles += len(newItems)
+			result.Items = newItems
 			m.log.Info("Stored feed items", "feed_id", feed.ID, "count", len(newItems))
 		}
 	}
@@ -458,6 +513,21 @@ func (m *Manager) processFeed(ctx context.Context, feed core.Feed, opts Aggregat
 	return result
 }
 
+// fetchFeed fetches a feed, giving up after timeout (0 = no limit of its own)
+func (m *Manager) fetchFeed(ctx context.Context, feed core.Feed, timeout time.Duration) (*feeds.ParsedFeed, error) {
+	if timeout <= 0 {
+		return m.feedManager.FetchFeedContext(ctx, feed.URL, feed.LastModified, feed.ETag)
+	}
+	feedCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	parsedFeed, err := m.feedManager.FetchFeedContext(feedCtx, feed.URL, feed.LastModified, feed.ETag)
+	if err != nil && ctx.Err() == nil && errors.Is(feedCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w after %s", ErrFeedTimeout, timeout)
+	}
+	return parsedFeed, err
+}
+
 // GetUnprocessedItems returns feed items that haven't been processed yet
 func (m *Manager) GetUnprocessedItems(ctx context.Context, limit int) ([]core.FeedItem, error) {
 	return m.db.FeedItems().GetUnprocessed(ctx, limit)
@@ -557,7 +627,7 @@ func (m *Manager) AggregateManualURLs(ctx context.Context, maxURLs int) (*Manual
 			FeedID:         "manual", // Special feed ID for manual URLs
 			Title:          manualURL.URL,
 			Link:           manualURL.URL,
-			Description:    fmt.Sprintf("Manually submitted by %s", manualURL.SubmittedBy),
+			Description:    manualURLDescription(manualURL),
 			Published:      manualURL.CreatedAt,
 			GUID:           manualURL.URL,
 			Processed:      false,
@@ -592,6 +662,22 @@ func (m *Manager) AggregateManualURLs(ctx context.Context, maxURLs int) (*Manual
 	return result, nil
 }
 
+// manualURLDescription describes a queued URL for its feed item, carrying a browser
+// capture's note, highlighted text, and tags into the pipeline
+func manualURLDescription(manualURL core.ManualURL) string {
+	parts := []string{fmt.Sprintf("Manually submitted by %s", manualURL.SubmittedBy)}
+	if manualURL.Note != "" {
+		parts = append(parts, "Note: "+manualURL.Note)
+	}
+	if manualURL.Selection != "" {
+		parts = append(parts, fmt.Sprintf("Highlighted: %q", manualURL.Selection))
+	}
+	if len(manualURL.Tags) > 0 {
+		parts = append(parts, "Tags: "+strings.Join(manualURL.Tags, ", "))
+	}
+	return strings.Join(parts, "\n")
+}
+
 // ClassificationOptions configures the classification process
 type ClassificationOptions struct {
 	MaxArticles    int     // Maximum number of articles to classify (0 = no limit)
@@ -600,6 +686,9 @@ type ClassificationOptions struct {
 	SkipProcessed  bool    // Skip articles that already have a theme assigned
 	FetchContent   bool    // Whether to fetch full content for classification
 	MaxConcurrency int     // Number of articles to process concurrently
+
+	FeedFullText     bool // Build articles from full text published in the feed instead of fetching the page
+	FullTextMinWords int  // Shortest feed body taken as the full article (0 = feeds.DefaultFullTextMinWords)
 }
 
 // DefaultClassificationOptions returns sensible defaults
@@ -611,6 +700,7 @@ func DefaultClassificationOptions() ClassificationOptions {
 		SkipProcessed:  true,
 		FetchContent:   true,
 		MaxConcurrency: 5,
+		FeedFullText:   true,
 	}
 }
 
@@ -723,13 +813,14 @@ func (m *Manager) classifyFeedItem(ctx context.Context, item core.FeedItem, proc
 	result := ItemClassificationResult{}
 
 	// Fetch and process article content
-	article, err := processor.ProcessArticle(ctx, item.Link)
+	article, err := m.processFeedItem(ctx, processor, item, opts.FeedFullText, opts.FullTextMinWords)
 	if err != nil {
 		m.log.Error("Failed to process article", "url", item.Link, "error", err)
 		result.Error = fmt.Errorf("process %s: %w", item.Link, err)
 
-		// Mark feed item as processed even if failed (avoid retry loops)
-		_ = m.db.FeedItems().MarkProcessed(ctx, item.ID)
+		// Mark feed item as processed even if failed (avoid retry loops), keeping the
+		// reason for ops reports
+		_ = m.db.FeedItems().MarkFailed(ctx, item.ID, err.Error())
 		return result
 	}
 
@@ -811,11 +902,34 @@ func (m *Manager) classifyFeedItem(ctx context.Context, item core.FeedItem, proc
 	return result
 }
 
+// processFeedItem builds the article for a feed item: from the full text the feed
+// published when fullText is on and the body isn't truncated, otherwise by fetching
+// the page
+func (m *Manager) processFeedItem(ctx context.Context, processor ArticleProcessor, item core.FeedItem, fullText bool, minWords int) (*core.Article, error) {
+	if fullText && feeds.HasFullText(item, minWords) {
+		if feedProcessor, ok := processor.(FeedContentProcessor); ok {
+			article, err := feedProcessor.ProcessFeedContent(item.Link, item.Title, item.Content)
+			if err == nil {
+				m.log.Debug("Using full text from feed", "url", item.Link)
+				return article, nil
+			}
+			m.log.Warn("Failed to use full text from feed, fetching page", "url", item.Link, "error", err)
+		}
+	}
+	return processor.ProcessArticle(ctx, item.Link)
+}
+
 // ArticleProcessor interface for article content processing
 type ArticleProcessor interface {
 	ProcessArticle(ctx context.Context, url string) (*core.Article, error)
 }
 
+// FeedContentProcessor is implemented by article processors that can build an
+// article from HTML a feed published, without fetching the page
+type FeedContentProcessor interface {
+	ProcessFeedContent(url, title, content string) (*core.Article, error)
+}
+
 // ThemeClassifier interface for theme classification
 // This is an abstract interface that can be implemented by themes.Classifier
 type ThemeClassifier interface {
@@ -6,6 +6,7 @@ import (
 	"briefly/internal/persistence"
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -126,6 +127,10 @@ func (m *MockManualURLRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (m *MockManualURLRepo) DeleteByStatus(ctx context.Context, status string) (int64, error) {
+	return 0, nil
+}
+
 type MockFeedItemRepo struct {
 	items           []core.FeedItem
 	failCreate      bool
@@ -160,6 +165,11 @@ func (m *MockFeedItemRepo) CreateBatch(ctx context.Context, items []core.FeedIte
 	return nil
 }
 func (m *MockFeedItemRepo) Get(ctx context.Context, id string) (*core.FeedItem, error) {
+	for i := range m.items {
+		if m.items[i].ID == id {
+			return &m.items[i], nil
+		}
+	}
 	return nil, nil
 }
 func (m *MockFeedItemRepo) GetByFeedID(ctx context.Context, feedID string, limit int) ([]core.FeedItem, error) {
@@ -174,6 +184,12 @@ func (m *MockFeedItemRepo) List(ctx context.Context, opts persistence.ListOption
 func (m *MockFeedItemRepo) MarkProcessed(ctx context.Context, id string) error {
 	return nil
 }
+func (m *MockFeedItemRepo) MarkFailed(ctx context.Context, id string, fetchError string) error {
+	return nil
+}
+func (m *MockFeedItemRepo) ListFailed(ctx context.Context, since time.Time, limit int) ([]core.FeedItem, error) {
+	return nil, nil
+}
 func (m *MockFeedItemRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
@@ -199,16 +215,18 @@ func (m *MockDatabase) FeedItems() persistence.FeedItemRepository {
 }
 
 // Stub methods
-func (m *MockDatabase) Articles() persistence.ArticleRepository                   { return nil }
-func (m *MockDatabase) Summaries() persistence.SummaryRepository                   { return nil }
-func (m *MockDatabase) Feeds() persistence.FeedRepository                          { return nil }
-func (m *MockDatabase) Digests() persistence.DigestRepository                      { return nil }
-func (m *MockDatabase) Themes() persistence.ThemeRepository                        { return nil }
-func (m *MockDatabase) Citations() persistence.CitationRepository                  { return nil }
-func (m *MockDatabase) Tags() persistence.TagRepository                            { return nil }
-func (m *MockDatabase) ClusterCoherence() persistence.ClusterCoherenceRepository   { return nil }
-func (m *MockDatabase) Close() error                                               { return nil }
-func (m *MockDatabase) Ping(ctx context.Context) error                             { return nil }
+func (m *MockDatabase) Articles() persistence.ArticleRepository                  { return nil }
+func (m *MockDatabase) Summaries() persistence.SummaryRepository                 { return nil }
+func (m *MockDatabase) Feeds() persistence.FeedRepository                        { return nil }
+func (m *MockDatabase) Digests() persistence.DigestRepository                    { return nil }
+func (m *MockDatabase) Themes() persistence.ThemeRepository                      { return nil }
+func (m *MockDatabase) Citations() persistence.CitationRepository                { return nil }
+func (m *MockDatabase) Tags() persistence.TagRepository                          { return nil }
+func (m *MockDatabase) ClusterCoherence() persistence.ClusterCoherenceRepository { return nil }
+func (m *MockDatabase) Links() persistence.LinkRepository                        { return nil }
+func (m *MockDatabase) LLMCalls() persistence.LLMCallRepository                  { return nil }
+func (m *MockDatabase) Close() error                                             { return nil }
+func (m *MockDatabase) Ping(ctx context.Context) error                           { return nil }
 func (m *MockDatabase) BeginTx(ctx context.Context) (persistence.Transaction, error) {
 	return nil, nil
 }
@@ -559,6 +577,34 @@ func TestAggregateManualURLs_FeedItemProperties(t *testing.T) {
 	}
 }
 
+func TestAggregateManualURLs_CaptureContext(t *testing.T) {
+	mockDB := NewMockDatabase()
+	testURL := createTestManualURL("url-1", "https://example.com/captured", "browser-extension")
+	testURL.Note = "Compare with our retry strategy"
+	testURL.Selection = "Retries are capped at three attempts."
+	testURL.Tags = []string{"infra", "reliability"}
+	mockDB.manualURLs.urls = []core.ManualURL{testURL}
+
+	manager := &Manager{
+		db:  mockDB,
+		log: logger.Get(),
+	}
+
+	if _, err := manager.AggregateManualURLs(context.Background(), 10); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(mockDB.feedItems.items) != 1 {
+		t.Fatalf("Expected 1 feed item, got %d", len(mockDB.feedItems.items))
+	}
+
+	description := mockDB.feedItems.items[0].Description
+	for _, want := range []string{"Compare with our retry strategy", "Retries are capped", "infra, reliability"} {
+		if !contains(description, want) {
+			t.Errorf("Expected description to contain %q, got: %s", want, description)
+		}
+	}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -575,3 +621,85 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestNormalizeCategory(t *testing.T) {
+	tests := map[string]string{
+		"security":          "security",
+		"  Dev Tools ":      "dev-tools",
+		"AI\tResearch":      "ai-research",
+		"infra/k8s":         "infra/k8s",
+		"":                  "",
+		"   ":               "",
+		"Machine  Learning": "machine-learning",
+	}
+	for input, want := range tests {
+		if got := NormalizeCategory(input); got != want {
+			t.Errorf("NormalizeCategory(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// fakeArticleProcessor records whether articles came from the feed or a page fetch
+type fakeArticleProcessor struct {
+	fetched  []string
+	fromFeed []string
+	failFeed bool
+}
+
+func (p *fakeArticleProcessor) ProcessArticle(ctx context.Context, url string) (*core.Article, error) {
+	p.fetched = append(p.fetched, url)
+	return &core.Article{URL: url, CleanedText: "fetched page"}, nil
+}
+
+func (p *fakeArticleProcessor) ProcessFeedContent(url, title, content string) (*core.Article, error) {
+	if p.failFeed {
+		return nil, errors.New("mock feed content error")
+	}
+	p.fromFeed = append(p.fromFeed, url)
+	return &core.Article{URL: url, Title: title, CleanedText: content}, nil
+}
+
+// articleOnlyProcessor hides ProcessFeedContent
+type articleOnlyProcessor struct{ inner *fakeArticleProcessor }
+
+func (p articleOnlyProcessor) ProcessArticle(ctx context.Context, url string) (*core.Article, error) {
+	return p.inner.ProcessArticle(ctx, url)
+}
+
+func TestProcessFeedItem_FullText(t *testing.T) {
+	manager := &Manager{log: logger.Get()}
+	ctx := context.Background()
+	full := core.FeedItem{Link: "https://example.com/full", Title: "Full", Content: "<p>" + strings.Repeat("word ", 200) + "end.</p>"}
+	truncated := core.FeedItem{Link: "https://example.com/cut", Title: "Cut", Content: "<p>" + strings.Repeat("word ", 200) + "and then…</p>"}
+
+	processor := &fakeArticleProcessor{}
+	if _, err := manager.processFeedItem(ctx, processor, full, true, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.processFeedItem(ctx, processor, truncated, true, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.processFeedItem(ctx, processor, full, false, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(processor.fromFeed) != 1 || processor.fromFeed[0] != full.Link {
+		t.Errorf("Expected only the full item built from the feed, got %v", processor.fromFeed)
+	}
+	if len(processor.fetched) != 2 {
+		t.Errorf("Expected the truncated item and the toggled-off item fetched, got %v", processor.fetched)
+	}
+
+	failing := &fakeArticleProcessor{failFeed: true}
+	article, err := manager.processFeedItem(ctx, failing, full, true, 0)
+	if err != nil || article.CleanedText != "fetched page" {
+		t.Errorf("Expected a fallback to fetching when feed content fails, got %v, %v", article, err)
+	}
+
+	plain := &fakeArticleProcessor{}
+	if _, err := manager.processFeedItem(ctx, articleOnlyProcessor{plain}, full, true, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(plain.fetched) != 1 {
+		t.Error("Expected processors without feed support to fetch the page")
+	}
+}
@@ -0,0 +1,265 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ArchiveCodecGzip is the compression codec for archived article content. The codec is
+// recorded per row so the format can change without rewriting existing archives.
+const ArchiveCodecGzip = "gzip"
+
+// archiveTable stores article content compressed, out of the articles table. The
+// articles row keeps metadata only; readers hydrate content from here.
+const archiveTable = `
+	CREATE TABLE IF NOT EXISTS content_archive (
+		url TEXT PRIMARY KEY,
+		codec TEXT NOT NULL,
+		cleaned_content BLOB,
+		html_content BLOB,
+		raw_content BLOB,
+		cleaned_size INTEGER DEFAULT 0,
+		original_size INTEGER DEFAULT 0,
+		compressed_size INTEGER DEFAULT 0,
+		date_archived DATETIME
+	);`
+
+// ArchivedContent is the decompressed content of one archived article
+type ArchivedContent struct {
+	URL            string
+	CleanedText    string
+	HTML           string // Fetched HTML; empty once pruned by retention
+	RawContent     string // Raw text for PDFs and transcripts; empty once pruned
+	OriginalSize   int64
+	CompressedSize int64
+	DateArchived   time.Time
+}
+
+// ArchiveStats summarizes content archive storage
+type ArchiveStats struct {
+	Count          int
+	OriginalSize   int64
+	CompressedSize int64
+}
+
+// archiveArticle writes an article's content to the archive within tx, encrypted
+// when the store has a key
+func (s *Store) archiveArticle(tx *sql.Tx, key string, article core.Article) error {
+	var originalSize, compressedSize int64
+
+	codec := ArchiveCodecGzip
+	if s.cipher != nil {
+		codec = ArchiveCodecGzipAESGCM
+	} else if s.locked {
+		return ErrCacheLocked
+	}
+
+	blobs := make([][]byte, 0, 3)
+	for _, text := range []string{article.CleanedText, article.FetchedHTML, article.RawContent} {
+		blob, err := s.encodeArchived(codec, text)
+		if err != nil {
+			return err
+		}
+		originalSize += int64(len(text))
+		compressedSize += int64(len(blob))
+		blobs = append(blobs, blob)
+	}
+
+	_, err := tx.Exec(`
+	INSERT OR REPLACE INTO content_archive
+	(url, codec, cleaned_content, html_content, raw_content, cleaned_size, original_size, compressed_size, date_archived)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		key, codec, blobs[0], blobs[1], blobs[2], len(article.CleanedText), originalSize, compressedSize, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to archive article content: %w", err)
+	}
+	return nil
+}
+
+// GetArchivedContent returns the decompressed content archived for a cache key (URL)
+func (s *Store) GetArchivedContent(url string) (*ArchivedContent, error) {
+	var codec string
+	var cleaned, html, raw []byte
+	content := &ArchivedContent{URL: url}
+
+	err := s.db.QueryRow(`
+	SELECT codec, cleaned_content, html_content, raw_content, original_size, compressed_size, date_archived
+	FROM content_archive WHERE url = ?`, url).Scan(
+		&codec, &cleaned, &html, &raw, &content.OriginalSize, &content.CompressedSize, &content.DateArchived,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archived content: %w", err)
+	}
+
+	for _, field := range []struct {
+		blob []byte
+		dst  *string
+	}{{cleaned, &content.CleanedText}, {html, &content.HTML}, {raw, &content.RawContent}} {
+		text, err := s.decodeArchived(codec, field.blob)
+		if errors.Is(err, ErrCacheLocked) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress archived content for %s: %w", url, err)
+		}
+		*field.dst = text
+	}
+
+	return content, nil
+}
+
+// FindArchivedURLs returns archived cache keys containing the given substring
+func (s *Store) FindArchivedURLs(partial string) ([]string, error) {
+	rows, err := s.db.Query("SELECT url FROM content_archive WHERE url LIKE ? ORDER BY date_archived DESC", "%"+partial+"%")
+	if err != nil {
+		return nil, fmt.Errorf("failed to search archive: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var urls []string
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, fmt.Errorf("failed to scan archive url: %w", err)
+		}
+		urls = append(urls, url)
+	}
+	return urls, rows.Err()
+}
+
+// PruneArchive drops archived HTML and raw content older than retention, keeping the
+// much smaller cleaned text. A retention of zero keeps everything. Returns the number
+// of articles pruned.
+func (s *Store) PruneArchive(retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	result, err := s.db.Exec(`
+	UPDATE content_archive
+	SET html_content = NULL, raw_content = NULL,
+	    original_size = cleaned_size, compressed_size = COALESCE(LENGTH(cleaned_content), 0)
+	WHERE date_archived < ? AND (html_content IS NOT NULL OR raw_content IS NOT NULL)`,
+		time.Now().UTC().Add(-retention),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune content archive: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetArchiveStats returns the number of archived articles and their storage sizes
+func (s *Store) GetArchiveStats() (*ArchiveStats, error) {
+	stats := &ArchiveStats{}
+	err := s.db.QueryRow(`
+	SELECT COUNT(*), COALESCE(SUM(original_size), 0), COALESCE(SUM(compressed_size), 0)
+	FROM content_archive`).Scan(&stats.Count, &stats.OriginalSize, &stats.CompressedSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archive stats: %w", err)
+	}
+	return stats, nil
+}
+
+// hydrateArticle decrypts the article's take and fills content fields from the
+// archive for articles stored without inline content. Legacy rows that still carry
+// inline content are left untouched.
+func (s *Store) hydrateArticle(article *core.Article) error {
+	myTake, err := s.openText(article.MyTake)
+	if err != nil {
+		return err
+	}
+	article.MyTake = myTake
+
+	if article.CleanedText != "" || article.FetchedHTML != "" {
+		return nil
+	}
+
+	archived, err := s.GetArchivedContent(article.LinkID)
+	if err != nil || archived == nil {
+		return err
+	}
+
+	article.CleanedText = archived.CleanedText
+	article.FetchedHTML = archived.HTML
+	article.RawContent = archived.RawContent
+	return nil
+}
+
+// encodeArchived compresses text for the given codec, encrypting it for
+// ArchiveCodecGzipAESGCM. Empty text is stored as NULL.
+func (s *Store) encodeArchived(codec, text string) ([]byte, error) {
+	blob, err := compressContent(text)
+	if err != nil || blob == nil || codec != ArchiveCodecGzipAESGCM {
+		return blob, err
+	}
+	if s.cipher == nil {
+		return nil, ErrCacheLocked
+	}
+	return s.cipher.seal(blob)
+}
+
+// decodeArchived reverses encodeArchived
+func (s *Store) decodeArchived(codec string, blob []byte) (string, error) {
+	if codec != ArchiveCodecGzipAESGCM || len(blob) == 0 {
+		return decompressContent(codec, blob)
+	}
+	if s.cipher == nil {
+		return "", ErrCacheLocked
+	}
+	compressed, err := s.cipher.open(blob)
+	if err != nil {
+		return "", err
+	}
+	return decompressContent(ArchiveCodecGzip, compressed)
+}
+
+// compressContent gzips text. Empty text is stored as NULL.
+func compressContent(text string) ([]byte, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(text)); err != nil {
+		return nil, fmt.Errorf("failed to compress content: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress content: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressContent reverses compressContent for the given codec
+func decompressContent(codec string, blob []byte) (string, error) {
+	if len(blob) == 0 {
+		return "", nil
+	}
+
+	switch codec {
+	case ArchiveCodecGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(blob))
+		if err != nil {
+			return "", err
+		}
+		defer func() { _ = reader.Close() }()
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	default:
+		return "", fmt.Errorf("unsupported archive codec %q", codec)
+	}
+}
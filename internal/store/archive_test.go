@@ -0,0 +1,97 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheArticle_ArchivesCompressedContent(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	article := core.Article{
+		URL:         "https://example.com/archived",
+		Title:       "Archived Article",
+		CleanedText: strings.Repeat("Cleaned article text. ", 200),
+		FetchedHTML: "<html><body>" + strings.Repeat("<p>Original HTML</p>", 200) + "</body></html>",
+		DateFetched: time.Now().UTC(),
+	}
+	if err := store.CacheArticle(article); err != nil {
+		t.Fatalf("CacheArticle failed: %v", err)
+	}
+
+	// Content is not stored inline in the articles table
+	var inline string
+	if err := store.db.QueryRow("SELECT content FROM articles WHERE url = ?", article.URL).Scan(&inline); err != nil {
+		t.Fatalf("Failed to query articles row: %v", err)
+	}
+	if inline != "" {
+		t.Error("Expected articles.content to be empty when content is archived")
+	}
+
+	cached, err := store.GetCachedArticle(article.URL, time.Hour)
+	if err != nil || cached == nil {
+		t.Fatalf("GetCachedArticle failed: %v", err)
+	}
+	if cached.CleanedText != article.CleanedText || cached.FetchedHTML != article.FetchedHTML {
+		t.Error("Expected content to be hydrated from the archive")
+	}
+
+	stats, err := store.GetArchiveStats()
+	if err != nil {
+		t.Fatalf("GetArchiveStats failed: %v", err)
+	}
+	if stats.Count != 1 || stats.CompressedSize >= stats.OriginalSize {
+		t.Errorf("Expected one compressed archive entry, got %+v", stats)
+	}
+}
+
+func TestPruneArchive_KeepsCleanedText(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	article := core.Article{
+		URL:         "https://example.com/old",
+		CleanedText: "Cleaned text survives retention.",
+		FetchedHTML: "<html>Original HTML is pruned.</html>",
+		DateFetched: time.Now().UTC(),
+	}
+	if err := store.CacheArticle(article); err != nil {
+		t.Fatalf("CacheArticle failed: %v", err)
+	}
+
+	if pruned, _ := store.PruneArchive(time.Hour); pruned != 0 {
+		t.Errorf("Expected fresh content to be kept, pruned %d", pruned)
+	}
+
+	_, _ = store.db.Exec("UPDATE content_archive SET date_archived = ?", time.Now().UTC().Add(-48*time.Hour))
+	pruned, err := store.PruneArchive(24 * time.Hour)
+	if err != nil || pruned != 1 {
+		t.Fatalf("Expected 1 pruned article, got %d (err: %v)", pruned, err)
+	}
+
+	archived, err := store.GetArchivedContent(article.URL)
+	if err != nil || archived == nil {
+		t.Fatalf("GetArchivedContent failed: %v", err)
+	}
+	if archived.HTML != "" {
+		t.Error("Expected HTML to be pruned")
+	}
+	if archived.CleanedText != article.CleanedText {
+		t.Errorf("Expected cleaned text to survive pruning, got %q", archived.CleanedText)
+	}
+}
+
+func TestDecompressContent_UnknownCodec(t *testing.T) {
+	if _, err := decompressContent("zstd", []byte{0x28, 0xb5}); err == nil {
+		t.Error("Expected error for unsupported codec")
+	}
+}
@@ -0,0 +1,487 @@
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Backup archive layout: a gzipped tar holding manifest.json, the SQLite database,
+// and the snapshots/ directory. Encrypted backups wrap the whole archive in
+// AES-256-GCM with a key derived from a passphrase.
+const (
+	backupManifestName = "manifest.json"
+	backupDBName       = "briefly.db"
+	backupSnapshotsDir = "snapshots"
+
+	// backupMagic prefixes encrypted backups so restore can detect them
+	backupMagic = "BRIEFLY-BACKUP-AES256GCM-1\n"
+
+	backupKDFIterations = 600000
+	backupSaltSize      = 16
+)
+
+// ErrBackupPassphrase is returned when an encrypted backup is restored without
+// a passphrase or with the wrong one
+var ErrBackupPassphrase = errors.New("backup is encrypted: a correct passphrase is required")
+
+// BackupManifest describes what a backup contains
+type BackupManifest struct {
+	CreatedAt    time.Time `json:"created_at"`
+	Articles     int       `json:"articles"`
+	Summaries    int       `json:"summaries"`
+	Digests      int       `json:"digests"`
+	Embeddings   int       `json:"embeddings"` // Articles and summaries with a stored embedding
+	Snapshots    int       `json:"snapshots"`  // Files under snapshots/
+	DatabaseSize int64     `json:"database_size"`
+	Encrypted    bool      `json:"-"` // Set when reading a backup, not stored
+}
+
+// Backup writes a consistent snapshot of the cache to path. The database is copied
+// with the SQLite online backup API, so it is safe to run while other commands use
+// the cache. With a non-empty passphrase the backup is encrypted.
+func (s *Store) Backup(ctx context.Context, path string, passphrase string) (*BackupManifest, error) {
+	if s.InMemory() {
+		return nil, fmt.Errorf("cache is held in memory; there is nothing on disk to back up")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "briefly-backup-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	dbCopy := filepath.Join(tmpDir, backupDBName)
+	if err := s.copyDatabase(ctx, dbCopy); err != nil {
+		return nil, err
+	}
+
+	manifest, err := readManifestCounts(dbCopy)
+	if err != nil {
+		return nil, err
+	}
+	manifest.CreatedAt = time.Now().UTC()
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+
+	snapshotsDir := filepath.Join(filepath.Dir(s.path), backupSnapshotsDir)
+	snapshotFiles, err := listFiles(snapshotsDir)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Snapshots = len(snapshotFiles)
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := writeTarFile(tw, backupManifestName, manifestJSON); err != nil {
+		return nil, err
+	}
+	if err := addTarFile(tw, backupDBName, dbCopy); err != nil {
+		return nil, err
+	}
+	for _, rel := range snapshotFiles {
+		if err := addTarFile(tw, filepath.ToSlash(filepath.Join(backupSnapshotsDir, rel)), filepath.Join(snapshotsDir, rel)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish backup archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish backup archive: %w", err)
+	}
+
+	data := archive.Bytes()
+	if passphrase != "" {
+		if data, err = encryptBackup(data, passphrase); err != nil {
+			return nil, err
+		}
+		manifest.Encrypted = true
+	}
+
+	// Write next to the destination and rename, so a failed backup never leaves a partial file
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create backup directory: %w", err)
+		}
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// copyDatabase copies the live database to dest with the SQLite online backup API
+func (s *Store) copyDatabase(ctx context.Context, dest string) error {
+	destDB, err := sql.Open("sqlite3", dest)
+	if err != nil {
+		return fmt.Errorf("failed to create backup database: %w", err)
+	}
+	defer func() { _ = destDB.Close() }()
+
+	srcConn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open cache connection: %w", err)
+	}
+	defer func() { _ = srcConn.Close() }()
+
+	destConn, err := destDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open backup connection: %w", err)
+	}
+	defer func() { _ = destConn.Close() }()
+
+	return destConn.Raw(func(destRaw any) error {
+		return srcConn.Raw(func(srcRaw any) error {
+			destSQLite, ok := destRaw.(*sqlite3.SQLiteConn)
+			srcSQLite, ok2 := srcRaw.(*sqlite3.SQLiteConn)
+			if !ok || !ok2 {
+				return fmt.Errorf("cache is not a SQLite database")
+			}
+
+			backup, err := destSQLite.Backup("main", srcSQLite, "main")
+			if err != nil {
+				return fmt.Errorf("failed to start database backup: %w", err)
+			}
+			// Step(-1) copies every page in one pass, holding a read lock for a consistent copy
+			if _, err := backup.Step(-1); err != nil {
+				_ = backup.Finish()
+				return fmt.Errorf("failed to copy database: %w", err)
+			}
+			if err := backup.Finish(); err != nil {
+				return fmt.Errorf("failed to finish database backup: %w", err)
+			}
+			return nil
+		})
+	})
+}
+
+// RestoreBackup replaces the cache in cacheDir with the contents of a backup. The
+// restored database is integrity-checked before it replaces the current one, which
+// is kept as briefly.db.pre-restore. Snapshot files are restored alongside existing ones.
+func RestoreBackup(path string, cacheDir string, passphrase string) (*BackupManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	encrypted := bytes.HasPrefix(data, []byte(backupMagic))
+	if encrypted {
+		if passphrase == "" {
+			return nil, ErrBackupPassphrase
+		}
+		if data, err = decryptBackup(data, passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	stageDir, err := os.MkdirTemp(cacheDir, ".restore-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create restore directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(stageDir) }()
+
+	manifest, err := extractBackup(data, stageDir)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Encrypted = encrypted
+
+	stagedDB := filepath.Join(stageDir, backupDBName)
+	if err := checkDatabaseIntegrity(stagedDB); err != nil {
+		return nil, err
+	}
+
+	dbPath := filepath.Join(cacheDir, backupDBName)
+	if _, err := os.Stat(dbPath); err == nil {
+		if err := os.Rename(dbPath, dbPath+".pre-restore"); err != nil {
+			return nil, fmt.Errorf("failed to set aside current cache: %w", err)
+		}
+	}
+	// Stale journal files would be replayed against the restored database
+	for _, suffix := range []string{"-wal", "-shm", "-journal"} {
+		_ = os.Remove(dbPath + suffix)
+	}
+	if err := os.Rename(stagedDB, dbPath); err != nil {
+		return nil, fmt.Errorf("failed to install restored cache: %w", err)
+	}
+
+	stagedSnapshots := filepath.Join(stageDir, backupSnapshotsDir)
+	files, err := listFiles(stagedSnapshots)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range files {
+		dest := filepath.Join(cacheDir, backupSnapshotsDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return nil, fmt.Errorf("failed to restore snapshots: %w", err)
+		}
+		if err := os.Rename(filepath.Join(stagedSnapshots, rel), dest); err != nil {
+			return nil, fmt.Errorf("failed to restore snapshot %s: %w", rel, err)
+		}
+	}
+
+	return manifest, nil
+}
+
+// extractBackup unpacks a decrypted backup archive into dir, returning its manifest
+func extractBackup(data []byte, dir string) (*BackupManifest, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("not a briefly backup: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	var manifest *BackupManifest
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Reject entries that would escape the restore directory
+		name := filepath.FromSlash(header.Name)
+		if filepath.IsAbs(name) || name != filepath.Clean(name) || strings.HasPrefix(name, "..") {
+			return nil, fmt.Errorf("backup contains an invalid path: %s", header.Name)
+		}
+
+		if header.Name == backupManifestName {
+			manifest = &BackupManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("failed to read backup manifest: %w", err)
+			}
+			continue
+		}
+
+		dest := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return nil, fmt.Errorf("failed to extract backup: %w", err)
+		}
+		out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract backup: %w", err)
+		}
+		_, copyErr := io.Copy(out, tr)
+		closeErr := out.Close()
+		if copyErr != nil || closeErr != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", header.Name, errors.Join(copyErr, closeErr))
+		}
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("not a briefly backup: missing %s", backupManifestName)
+	}
+	if _, err := os.Stat(filepath.Join(dir, backupDBName)); err != nil {
+		return nil, fmt.Errorf("not a briefly backup: missing %s", backupDBName)
+	}
+	return manifest, nil
+}
+
+// checkDatabaseIntegrity runs SQLite's integrity check on a database file
+func checkDatabaseIntegrity(path string) error {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return fmt.Errorf("failed to open restored database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var result string
+	if err := db.QueryRow("PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("restored database is unreadable: %w", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("restored database failed integrity check: %s", result)
+	}
+	return nil
+}
+
+// readManifestCounts counts the rows a backup database holds
+func readManifestCounts(path string) (*BackupManifest, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	manifest := &BackupManifest{}
+	counts := []struct {
+		query string
+		dest  *int
+	}{
+		{"SELECT COUNT(*) FROM articles", &manifest.Articles},
+		{"SELECT COUNT(*) FROM summaries", &manifest.Summaries},
+		{"SELECT COUNT(*) FROM digests", &manifest.Digests},
+		{"SELECT (SELECT COUNT(*) FROM articles WHERE embedding IS NOT NULL) + (SELECT COUNT(*) FROM summaries WHERE embedding IS NOT NULL)", &manifest.Embeddings},
+	}
+	for _, count := range counts {
+		if err := db.QueryRow(count.query).Scan(count.dest); err != nil {
+			return nil, fmt.Errorf("failed to count backup contents: %w", err)
+		}
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		manifest.DatabaseSize = info.Size()
+	}
+	return manifest, nil
+}
+
+// encryptBackup seals data with AES-256-GCM under a PBKDF2-derived key. The output
+// is magic || salt || nonce || ciphertext.
+func encryptBackup(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, backupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	gcm, err := backupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(backupMagic)+len(salt)+len(nonce)+len(data)+gcm.Overhead())
+	out = append(out, backupMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	// The header is authenticated so it cannot be swapped between backups
+	header := append([]byte(nil), out...)
+	return gcm.Seal(out, nonce, data, header), nil
+}
+
+// decryptBackup reverses encryptBackup
+func decryptBackup(data []byte, passphrase string) ([]byte, error) {
+	rest := data[len(backupMagic):]
+	if len(rest) < backupSaltSize {
+		return nil, fmt.Errorf("encrypted backup is truncated")
+	}
+	salt := rest[:backupSaltSize]
+	gcm, err := backupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	headerLen := len(backupMagic) + backupSaltSize + gcm.NonceSize()
+	if len(data) < headerLen+gcm.Overhead() {
+		return nil, fmt.Errorf("encrypted backup is truncated")
+	}
+	nonce := data[len(backupMagic)+backupSaltSize : headerLen]
+
+	plain, err := gcm.Open(nil, nonce, data[headerLen:], data[:headerLen])
+	if err != nil {
+		return nil, ErrBackupPassphrase
+	}
+	return plain, nil
+}
+
+// backupCipher derives the AES-256-GCM cipher for a passphrase and salt
+func backupCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, backupKDFIterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive backup key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// listFiles returns the regular files under dir as paths relative to it. A missing
+// directory has no files.
+func listFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.Type().IsRegular() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// writeTarFile adds an in-memory file to a tar archive
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now()}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s to backup: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to backup: %w", name, err)
+	}
+	return nil
+}
+
+// addTarFile adds a file from disk to a tar archive
+func addTarFile(tw *tar.Writer, name string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	header := &tar.Header{Name: name, Mode: 0644, Size: info.Size(), ModTime: info.ModTime()}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s to backup: %w", name, err)
+	}
+	if _, err := io.Copy(tw, file); err != nil {
+		return fmt.Errorf("failed to write %s to backup: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"briefly/internal/core"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	for _, passphrase := range []string{"", "correct horse battery staple"} {
+		name := "plain"
+		if passphrase != "" {
+			name = "encrypted"
+		}
+		t.Run(name, func(t *testing.T) {
+			srcDir := t.TempDir()
+			src, err := NewStore(srcDir)
+			if err != nil {
+				t.Fatalf("NewStore failed: %v", err)
+			}
+			defer func() { _ = src.Close() }()
+
+			article := core.Article{ID: "a1", URL: "https://example.com/post", Title: "Backed up", CleanedText: "Body", DateFetched: time.Now().UTC(), Embedding: []float64{0.1, 0.2}}
+			if err := src.CacheArticle(article); err != nil {
+				t.Fatalf("CacheArticle failed: %v", err)
+			}
+			snapshotFile := filepath.Join(srcDir, "snapshots", "ab", "page.html")
+			if err := os.MkdirAll(filepath.Dir(snapshotFile), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(snapshotFile, []byte("<html>snapshot</html>"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			backupPath := filepath.Join(t.TempDir(), "cache.briefly-backup")
+			manifest, err := src.Backup(context.Background(), backupPath, passphrase)
+			if err != nil {
+				t.Fatalf("Backup failed: %v", err)
+			}
+			if manifest.Articles != 1 || manifest.Embeddings != 1 || manifest.Snapshots != 1 {
+				t.Errorf("Unexpected manifest: %+v", manifest)
+			}
+
+			if passphrase != "" {
+				if _, err := RestoreBackup(backupPath, t.TempDir(), ""); !errors.Is(err, ErrBackupPassphrase) {
+					t.Errorf("Expected passphrase error without passphrase, got %v", err)
+				}
+				if _, err := RestoreBackup(backupPath, t.TempDir(), "wrong"); !errors.Is(err, ErrBackupPassphrase) {
+					t.Errorf("Expected passphrase error for wrong passphrase, got %v", err)
+				}
+			}
+
+			// Restore over an existing cache, which is set aside
+			destDir := t.TempDir()
+			existing, err := NewStore(destDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = existing.Close()
+
+			restored, err := RestoreBackup(backupPath, destDir, passphrase)
+			if err != nil {
+				t.Fatalf("RestoreBackup failed: %v", err)
+			}
+			if restored.Encrypted != (passphrase != "") || restored.Articles != 1 {
+				t.Errorf("Unexpected restored manifest: %+v", restored)
+			}
+			if _, err := os.Stat(filepath.Join(destDir, "briefly.db.pre-restore")); err != nil {
+				t.Errorf("Expected previous cache to be kept: %v", err)
+			}
+			if data, err := os.ReadFile(filepath.Join(destDir, "snapshots", "ab", "page.html")); err != nil || string(data) != "<html>snapshot</html>" {
+				t.Errorf("Snapshot not restored: %q, %v", data, err)
+			}
+
+			dest, err := NewStore(destDir)
+			if err != nil {
+				t.Fatalf("NewStore on restored cache failed: %v", err)
+			}
+			defer func() { _ = dest.Close() }()
+			got, err := dest.GetCachedArticle(article.URL, time.Hour)
+			if err != nil || got == nil || got.Title != "Backed up" {
+				t.Fatalf("Restored article missing: %+v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestRestoreBackup_RejectsNonBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not-a-backup")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RestoreBackup(path, t.TempDir(), ""); err == nil {
+		t.Error("Expected error for a file that is not a backup")
+	}
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Comment sources record how a comment reached the store
+const (
+	CommentSourceCLI   = "cli"   // Added with 'briefly comment add'
+	CommentSourceAPI   = "api"   // Posted to /api/digests/{id}/comments
+	CommentSourceSlack = "slack" // A reaction on, or reply to, a delivered Slack issue
+)
+
+// digestCommentsTable holds team comments and reactions on digest articles. A
+// comment is rendered once, in the next issue of the digest's series, and remembers
+// which issue that was.
+const digestCommentsTable = `
+	CREATE TABLE IF NOT EXISTS digest_comments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		digest_id TEXT NOT NULL,
+		article_num INTEGER NOT NULL DEFAULT 0,
+		article_url TEXT NOT NULL DEFAULT '',
+		author TEXT NOT NULL DEFAULT '',
+		text TEXT NOT NULL DEFAULT '',
+		reaction TEXT NOT NULL DEFAULT '',
+		source TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		rendered_in TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX IF NOT EXISTS idx_digest_comments_digest ON digest_comments (digest_id);`
+
+// digestMessagesTable maps delivered Slack messages back to their digest, so
+// reactions and thread replies on an issue can be recorded as comments on it
+const digestMessagesTable = `
+	CREATE TABLE IF NOT EXISTS digest_messages (
+		channel TEXT NOT NULL,
+		ts TEXT NOT NULL,
+		digest_id TEXT NOT NULL,
+		PRIMARY KEY (channel, ts)
+	);`
+
+// DigestComment is a reader's comment or reaction on an article of a digest
+type DigestComment struct {
+	ID         int64
+	DigestID   string
+	ArticleNum int    // 1-based article number in the digest; 0 for the issue as a whole
+	ArticleURL string // Resolved from ArticleNum when the comment is added
+	Author     string
+	Text       string // Empty for a bare reaction
+	Reaction   string // Reaction name without colons, e.g. "fire"; empty for a comment
+	Source     string
+	CreatedAt  time.Time
+	RenderedIn string // Digest ID of the issue that showed this comment; empty until then
+}
+
+// AddDigestComment records a comment or reaction and returns its ID
+func (s *Store) AddDigestComment(comment DigestComment) (int64, error) {
+	comment.Text = strings.TrimSpace(comment.Text)
+	comment.Reaction = strings.Trim(strings.TrimSpace(comment.Reaction), ":")
+	if comment.DigestID == "" {
+		return 0, fmt.Errorf("digest ID is required")
+	}
+	if comment.Text == "" && comment.Reaction == "" {
+		return 0, fmt.Errorf("comment needs text or a reaction")
+	}
+	if comment.Source == "" {
+		comment.Source = CommentSourceCLI
+	}
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = time.Now().UTC()
+	}
+	text, err := s.sealText(comment.Text)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.db.Exec(`INSERT INTO digest_comments
+		(digest_id, article_num, article_url, author, text, reaction, source, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		comment.DigestID, comment.ArticleNum, comment.ArticleURL, strings.TrimSpace(comment.Author),
+		text, comment.Reaction, comment.Source, comment.CreatedAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to save comment: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// ListDigestComments returns the comments on a digest, oldest first
+func (s *Store) ListDigestComments(digestID string) ([]DigestComment, error) {
+	return s.queryDigestComments(`SELECT id, digest_id, article_num, article_url, author, text, reaction, source, created_at, rendered_in
+		FROM digest_comments WHERE digest_id = ? ORDER BY created_at, id`, digestID)
+}
+
+// PendingSeriesComments returns comments on earlier issues of a series that no issue
+// has shown yet, oldest first
+func (s *Store) PendingSeriesComments(series string) ([]DigestComment, error) {
+	return s.queryDigestComments(`SELECT c.id, c.digest_id, c.article_num, c.article_url, c.author, c.text, c.reaction, c.source, c.created_at, c.rendered_in
+		FROM digest_comments c JOIN digests d ON d.id = c.digest_id
+		WHERE d.series = ? AND c.rendered_in = ''
+		ORDER BY c.created_at, c.id`, series)
+}
+
+// MarkCommentsRendered records that the issue digestID showed the given comments
+func (s *Store) MarkCommentsRendered(ids []int64, digestID string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin comment transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, id := range ids {
+		if _, err := tx.Exec(`UPDATE digest_comments SET rendered_in = ? WHERE id = ?`, digestID, id); err != nil {
+			return fmt.Errorf("failed to mark comment %d rendered: %w", id, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *Store) queryDigestComments(query string, args ...interface{}) ([]DigestComment, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []DigestComment
+	for rows.Next() {
+		var comment DigestComment
+		if err := rows.Scan(&comment.ID, &comment.DigestID, &comment.ArticleNum, &comment.ArticleURL, &comment.Author,
+			&comment.Text, &comment.Reaction, &comment.Source, &comment.CreatedAt, &comment.RenderedIn); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		if comment.Text, err = s.openText(comment.Text); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+// SaveDigestMessages records the Slack messages an issue was delivered as
+func (s *Store) SaveDigestMessages(digestID, channel string, timestamps []string) error {
+	for _, ts := range timestamps {
+		if _, err := s.db.Exec(`INSERT OR REPLACE INTO digest_messages (channel, ts, digest_id) VALUES (?, ?, ?)`, channel, ts, digestID); err != nil {
+			return fmt.Errorf("failed to record delivered message: %w", err)
+		}
+	}
+	return nil
+}
+
+// DigestForMessage returns the digest delivered as the Slack message at channel and
+// ts, or "" when the message is not part of a delivered issue
+func (s *Store) DigestForMessage(channel, ts string) (string, error) {
+	var digestID string
+	err := s.db.QueryRow(`SELECT digest_id FROM digest_messages WHERE channel = ? AND ts = ?`, channel, ts).Scan(&digestID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up delivered message: %w", err)
+	}
+	return digestID, nil
+}
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDigestComments_PendingUntilRendered(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, issue := range []SeriesIssue{
+		{DigestID: "a1", Series: "ai-weekly", Title: "AI #1", DateGenerated: base},
+		{DigestID: "p1", Series: "platform-notes", Title: "Platform #1", DateGenerated: base},
+	} {
+		if err := store.SaveSeriesIssue(issue); err != nil {
+			t.Fatalf("SaveSeriesIssue failed: %v", err)
+		}
+	}
+
+	comments := []DigestComment{
+		{DigestID: "a1", ArticleNum: 2, ArticleURL: "https://example.com/b", Author: "sam", Text: "We should try this", CreatedAt: base.Add(time.Hour)},
+		{DigestID: "a1", Reaction: ":fire:", Source: CommentSourceSlack, CreatedAt: base.Add(2 * time.Hour)},
+		{DigestID: "p1", ArticleNum: 1, Text: "Old news", CreatedAt: base.Add(time.Hour)},
+	}
+	for _, comment := range comments {
+		if _, err := store.AddDigestComment(comment); err != nil {
+			t.Fatalf("AddDigestComment failed: %v", err)
+		}
+	}
+	if _, err := store.AddDigestComment(DigestComment{DigestID: "a1", Text: "  "}); err == nil {
+		t.Error("expected an empty comment to be rejected")
+	}
+
+	pending, err := store.PendingSeriesComments("ai-weekly")
+	if err != nil {
+		t.Fatalf("PendingSeriesComments failed: %v", err)
+	}
+	if len(pending) != 2 || pending[0].Author != "sam" || pending[0].Source != CommentSourceCLI {
+		t.Fatalf("expected both ai-weekly comments, oldest first, got %+v", pending)
+	}
+	if pending[1].Reaction != "fire" || pending[1].ArticleNum != 0 {
+		t.Errorf("expected the reaction stored without colons on the whole issue, got %+v", pending[1])
+	}
+
+	if err := store.MarkCommentsRendered([]int64{pending[0].ID, pending[1].ID}, "a2"); err != nil {
+		t.Fatalf("MarkCommentsRendered failed: %v", err)
+	}
+	if pending, _ = store.PendingSeriesComments("ai-weekly"); len(pending) != 0 {
+		t.Errorf("expected no pending comments once rendered, got %+v", pending)
+	}
+
+	listed, err := store.ListDigestComments("a1")
+	if err != nil {
+		t.Fatalf("ListDigestComments failed: %v", err)
+	}
+	if len(listed) != 2 || listed[0].RenderedIn != "a2" {
+		t.Errorf("expected comments to remember the issue that showed them, got %+v", listed)
+	}
+}
+
+func TestDigestMessages(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.SaveDigestMessages("a1", "C123", []string{"1.1", "1.2"}); err != nil {
+		t.Fatalf("SaveDigestMessages failed: %v", err)
+	}
+
+	if digestID, err := store.DigestForMessage("C123", "1.2"); err != nil || digestID != "a1" {
+		t.Errorf("DigestForMessage = %q, %v; want a1", digestID, err)
+	}
+	if digestID, err := store.DigestForMessage("C999", "1.2"); err != nil || digestID != "" {
+		t.Errorf("expected no digest for another channel, got %q, %v", digestID, err)
+	}
+}
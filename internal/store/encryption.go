@@ -0,0 +1,574 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Cache encryption keys come from the environment, or from a command that prints the
+// key, so it can live in the OS keychain, e.g.
+// "security find-generic-password -w -s briefly" on macOS or
+// "secret-tool lookup service briefly" on Linux
+const (
+	CacheKeyEnv        = "BRIEFLY_CACHE_KEY"
+	CacheKeyCommandEnv = "BRIEFLY_CACHE_KEY_COMMAND"
+)
+
+// ArchiveCodecGzipAESGCM is the archive codec for content compressed with gzip, then
+// encrypted with AES-256-GCM
+const ArchiveCodecGzipAESGCM = "gzip+aes-gcm"
+
+// encryptedTextPrefix marks an encrypted value in a TEXT column (summaries, digests,
+// research briefs, trend reports, takes, topic names, comments);
+// values without it are plaintext from before encryption was turned on
+const encryptedTextPrefix = "enc:v1:"
+
+// encryptionCheckValue is encrypted into store_meta when a key is first used, so a
+// wrong key is caught when the cache opens instead of on the first read
+const encryptionCheckValue = "briefly-cache-key-check"
+
+// storeMetaTable holds store-wide settings, such as the encryption key check
+const storeMetaTable = `
+	CREATE TABLE IF NOT EXISTS store_meta (
+		name TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);`
+
+// ErrCacheLocked is returned when the cache holds encrypted content and no key is set
+var ErrCacheLocked = errors.New("cache content is encrypted; set " + CacheKeyEnv + " or " + CacheKeyCommandEnv + " to use it")
+
+// ErrCacheKeyMismatch is returned when a cache is opened with a key other than the
+// one it was encrypted with
+var ErrCacheKeyMismatch = errors.New("cache key does not match the key this cache was encrypted with")
+
+// EncryptionReport counts the rows an encrypt or decrypt pass rewrote
+type EncryptionReport struct {
+	Articles       int
+	Summaries      int
+	Digests        int
+	Redactions     int
+	ResearchBriefs int
+	TrendReports   int
+	Takes          int
+	TopicPages     int
+	Comments       int
+}
+
+// contentCipher seals cached content with AES-256-GCM
+type contentCipher struct {
+	aead cipher.AEAD
+}
+
+// rawCacheKey returns the key when secret is 32 bytes in base64, as GenerateCacheKey
+// prints; anything else is a passphrase
+func rawCacheKey(secret string) ([]byte, bool) {
+	key, err := base64.StdEncoding.DecodeString(secret)
+	return key, err == nil && len(key) == 32
+}
+
+// newContentCipher builds a cipher from a key: 32 bytes in base64 are used as is,
+// and a passphrase is stretched with the same salted PBKDF2 as encrypted backups.
+// A nil salt selects the unsalted SHA-256 of caches encrypted before the cache
+// had a salt, only so they can be upgraded.
+func newContentCipher(secret string, salt []byte) (*contentCipher, error) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return nil, fmt.Errorf("cache encryption key is empty")
+	}
+
+	key, ok := rawCacheKey(secret)
+	switch {
+	case ok:
+	case salt == nil:
+		sum := sha256.Sum256([]byte(secret))
+		key = sum[:]
+	default:
+		var err error
+		if key, err = pbkdf2.Key(sha256.New, secret, salt, backupKDFIterations, 32); err != nil {
+			return nil, fmt.Errorf("failed to derive cache key: %w", err)
+		}
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return &contentCipher{aead: aead}, nil
+}
+
+// seal encrypts data as nonce || ciphertext
+func (c *contentCipher) seal(data []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return c.aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// open reverses seal; it fails when the data was sealed with another key
+func (c *contentCipher) open(blob []byte) ([]byte, error) {
+	if len(blob) < c.aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted content is truncated")
+	}
+	nonce, ciphertext := blob[:c.aead.NonceSize()], blob[c.aead.NonceSize():]
+	data, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt cached content (wrong key?)")
+	}
+	return data, nil
+}
+
+// LoadCacheKey returns the cache encryption key from BRIEFLY_CACHE_KEY, or the output
+// of BRIEFLY_CACHE_KEY_COMMAND. It returns "" when neither is set.
+func LoadCacheKey() (string, error) {
+	if key := strings.TrimSpace(os.Getenv(CacheKeyEnv)); key != "" {
+		return key, nil
+	}
+
+	command := strings.TrimSpace(os.Getenv(CacheKeyCommandEnv))
+	if command == "" {
+		return "", nil
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %w", CacheKeyCommandEnv, err)
+	}
+	key := strings.TrimSpace(string(out))
+	if key == "" {
+		return "", fmt.Errorf("%s printed no key", CacheKeyCommandEnv)
+	}
+	return key, nil
+}
+
+// GenerateCacheKey returns a new random key in the base64 form BRIEFLY_CACHE_KEY takes
+func GenerateCacheKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// setupEncryption checks the key against the one the cache was encrypted with, and
+// records a check for a key used for the first time. Without a key, a cache that has
+// been encrypted is locked: reads of encrypted content and all content writes fail,
+// so plaintext never mixes into an encrypted cache.
+func (s *Store) setupEncryption(secret string) error {
+	var check string
+	err := s.db.QueryRow(`SELECT value FROM store_meta WHERE name = 'encryption_check'`).Scan(&check)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to read encryption settings: %w", err)
+	}
+	encrypted := err == nil
+
+	if secret == "" {
+		s.locked = encrypted
+		return nil
+	}
+
+	salt, err := s.encryptionSalt(!encrypted)
+	if err != nil {
+		return err
+	}
+	if encrypted && salt == nil {
+		if _, raw := rawCacheKey(strings.TrimSpace(secret)); !raw {
+			return s.upgradeUnsaltedKey(secret, check)
+		}
+	}
+
+	c, err := newContentCipher(secret, salt)
+	if err != nil {
+		return err
+	}
+	s.cipher = c
+
+	if encrypted {
+		if value, err := s.openText(check); err != nil || value != encryptionCheckValue {
+			return ErrCacheKeyMismatch
+		}
+		return nil
+	}
+
+	sealed, err := s.sealText(encryptionCheckValue)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(`INSERT INTO store_meta (name, value) VALUES ('encryption_check', ?)`, sealed); err != nil {
+		return fmt.Errorf("failed to record encryption key check: %w", err)
+	}
+	return nil
+}
+
+// encryptionSalt returns the cache's passphrase salt, creating one when create is
+// set and there is none. It returns nil for a cache encrypted before salts.
+func (s *Store) encryptionSalt(create bool) ([]byte, error) {
+	var encoded string
+	err := s.db.QueryRow(`SELECT value FROM store_meta WHERE name = 'encryption_salt'`).Scan(&encoded)
+	switch {
+	case err == nil:
+		salt, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode encryption salt: %w", err)
+		}
+		return salt, nil
+	case err != sql.ErrNoRows:
+		return nil, fmt.Errorf("failed to read encryption settings: %w", err)
+	case !create:
+		return nil, nil
+	}
+
+	salt := make([]byte, backupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	if _, err := s.db.Exec(`INSERT INTO store_meta (name, value) VALUES ('encryption_salt', ?)`, base64.StdEncoding.EncodeToString(salt)); err != nil {
+		return nil, fmt.Errorf("failed to record encryption salt: %w", err)
+	}
+	return salt, nil
+}
+
+// upgradeUnsaltedKey re-encrypts a cache whose passphrase was hashed without a salt:
+// its content is decrypted with the old key, then encrypted again under a salted one
+func (s *Store) upgradeUnsaltedKey(secret, check string) error {
+	legacy, err := newContentCipher(secret, nil)
+	if err != nil {
+		return err
+	}
+	s.cipher = legacy
+	if value, err := s.openText(check); err != nil || value != encryptionCheckValue {
+		return ErrCacheKeyMismatch
+	}
+
+	if _, err := s.DecryptContent(); err != nil {
+		return fmt.Errorf("failed to upgrade cache key: %w", err)
+	}
+	if err := s.setupEncryption(secret); err != nil {
+		return err
+	}
+	if _, err := s.EncryptContent(); err != nil {
+		return fmt.Errorf("failed to upgrade cache key: %w", err)
+	}
+	return nil
+}
+
+// Encrypted reports whether new content is written encrypted
+func (s *Store) Encrypted() bool {
+	return s.cipher != nil
+}
+
+// Locked reports whether the cache was encrypted but opened without a key
+func (s *Store) Locked() bool {
+	return s.locked
+}
+
+// sealText encrypts a TEXT column value when a key is set. Empty values stay empty.
+func (s *Store) sealText(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	if s.cipher == nil {
+		if s.locked {
+			return "", ErrCacheLocked
+		}
+		return text, nil
+	}
+	sealed, err := s.cipher.seal([]byte(text))
+	if err != nil {
+		return "", err
+	}
+	return encryptedTextPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// openText decrypts a TEXT column value written by sealText. Plaintext values from
+// before encryption are returned as they are.
+func (s *Store) openText(text string) (string, error) {
+	if !strings.HasPrefix(text, encryptedTextPrefix) {
+		return text, nil
+	}
+	if s.cipher == nil {
+		return "", ErrCacheLocked
+	}
+	blob, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(text, encryptedTextPrefix))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted content: %w", err)
+	}
+	data, err := s.cipher.open(blob)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// EncryptContent encrypts cached content written before a key was set: archived
+// article text, summaries, digests and their summaries, redacted values, research
+// briefs, trend reports, takes (on digests and articles too), topic page names, and
+// comments. Articles still stored inline
+// in the articles table are moved into the archive on the way, and the database is
+// compacted so no plaintext copy lingers in free pages. Requires a key.
+func (s *Store) EncryptContent() (EncryptionReport, error) {
+	if s.cipher == nil {
+		return EncryptionReport{}, fmt.Errorf("no cache key set (%s or %s)", CacheKeyEnv, CacheKeyCommandEnv)
+	}
+	return s.recryptContent(true)
+}
+
+// DecryptContent writes all encrypted content back as plaintext and forgets the key
+// check and salt, so the cache can be used without a key or re-encrypted with a new one.
+// Requires the current key.
+func (s *Store) DecryptContent() (EncryptionReport, error) {
+	if s.cipher == nil {
+		return EncryptionReport{}, fmt.Errorf("no cache key set (%s or %s)", CacheKeyEnv, CacheKeyCommandEnv)
+	}
+	report, err := s.recryptContent(false)
+	if err != nil {
+		return report, err
+	}
+	if _, err := s.db.Exec(`DELETE FROM store_meta WHERE name IN ('encryption_check', 'encryption_salt')`); err != nil {
+		return report, fmt.Errorf("failed to clear encryption key check: %w", err)
+	}
+	s.cipher = nil
+	s.locked = false
+	return report, nil
+}
+
+// recryptContent rewrites every content value that isn't already in the target form
+func (s *Store) recryptContent(encrypt bool) (EncryptionReport, error) {
+	var report EncryptionReport
+
+	// Rows are read in full before rewriting, so no cursor is open during updates
+	type archivedRow struct {
+		url, codec        string
+		cleaned, html, raw []byte
+	}
+	var archived []archivedRow
+	rows, err := s.db.Query(`SELECT url, codec, cleaned_content, html_content, raw_content FROM content_archive`)
+	if err != nil {
+		return report, fmt.Errorf("failed to read content archive: %w", err)
+	}
+	for rows.Next() {
+		var row archivedRow
+		if err := rows.Scan(&row.url, &row.codec, &row.cleaned, &row.html, &row.raw); err != nil {
+			_ = rows.Close()
+			return report, fmt.Errorf("failed to scan archived content: %w", err)
+		}
+		if (row.codec == ArchiveCodecGzipAESGCM) != encrypt {
+			archived = append(archived, row)
+		}
+	}
+	_ = rows.Close()
+
+	inline := make(map[string][2]string)
+	rows, err = s.db.Query(`SELECT url, COALESCE(content, ''), COALESCE(html_content, '') FROM articles WHERE content != '' OR html_content != ''`)
+	if err != nil {
+		return report, fmt.Errorf("failed to read inline articles: %w", err)
+	}
+	for rows.Next() {
+		var key, cleaned, html string
+		if err := rows.Scan(&key, &cleaned, &html); err != nil {
+			_ = rows.Close()
+			return report, fmt.Errorf("failed to scan inline article: %w", err)
+		}
+		inline[key] = [2]string{cleaned, html}
+	}
+	_ = rows.Close()
+
+	summaries, err := s.queryTextColumn(`SELECT id, summary_text FROM summaries WHERE summary_text != ''`)
+	if err != nil {
+		return report, err
+	}
+	factSheets, err := s.queryTextColumn(`SELECT id, facts FROM summaries WHERE facts != ''`)
+	if err != nil {
+		return report, err
+	}
+	digests, err := s.queryTextColumn(`SELECT id, content FROM digests WHERE content != ''`)
+	if err != nil {
+		return report, err
+	}
+	digestSummaries, err := s.queryTextColumn(`SELECT id, digest_summary FROM digests WHERE digest_summary != ''`)
+	if err != nil {
+		return report, err
+	}
+	digestTakes, err := s.queryTextColumn(`SELECT id, my_take FROM digests WHERE my_take != ''`)
+	if err != nil {
+		return report, err
+	}
+	articleTakes, err := s.queryTextColumn(`SELECT url, my_take FROM articles WHERE my_take != ''`)
+	if err != nil {
+		return report, err
+	}
+	redactions, err := s.queryTextColumn(`SELECT placeholder, original FROM redactions`)
+	if err != nil {
+		return report, err
+	}
+	briefSummaries, err := s.queryTextColumn(`SELECT id, summary FROM research_briefs WHERE summary != ''`)
+	if err != nil {
+		return report, err
+	}
+	briefReports, err := s.queryTextColumn(`SELECT id, report FROM research_briefs WHERE report != ''`)
+	if err != nil {
+		return report, err
+	}
+	trendMarkdown, err := s.queryTextColumn(`SELECT CAST(id AS TEXT), markdown FROM trend_reports WHERE markdown != ''`)
+	if err != nil {
+		return report, err
+	}
+	trendSummaries, err := s.queryTextColumn(`SELECT CAST(id AS TEXT), summary FROM trend_reports WHERE summary != ''`)
+	if err != nil {
+		return report, err
+	}
+	// my_takes is keyed by (kind, target), so its rows are addressed by rowid
+	takes, err := s.queryTextColumn(`SELECT CAST(rowid AS TEXT), take FROM my_takes WHERE take != ''`)
+	if err != nil {
+		return report, err
+	}
+	topicTitles, err := s.queryTextColumn(`SELECT slug, title FROM topic_pages WHERE title != ''`)
+	if err != nil {
+		return report, err
+	}
+	comments, err := s.queryTextColumn(`SELECT CAST(id AS TEXT), text FROM digest_comments WHERE text != ''`)
+	if err != nil {
+		return report, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return report, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	targetCodec := ArchiveCodecGzip
+	if encrypt {
+		targetCodec = ArchiveCodecGzipAESGCM
+	}
+	for _, row := range archived {
+		blobs := make([][]byte, 3)
+		for i, blob := range [][]byte{row.cleaned, row.html, row.raw} {
+			text, err := s.decodeArchived(row.codec, blob)
+			if err != nil {
+				return report, fmt.Errorf("failed to read archived content for %s: %w", row.url, err)
+			}
+			if blobs[i], err = s.encodeArchived(targetCodec, text); err != nil {
+				return report, err
+			}
+		}
+		if _, err := tx.Exec(`UPDATE content_archive SET codec = ?, cleaned_content = ?, html_content = ?, raw_content = ? WHERE url = ?`,
+			targetCodec, blobs[0], blobs[1], blobs[2], row.url); err != nil {
+			return report, fmt.Errorf("failed to rewrite archived content: %w", err)
+		}
+		report.Articles++
+	}
+
+	// Legacy rows keep content inline; move it into the archive in the target form
+	if encrypt {
+		for key, value := range inline {
+			cleaned, html := value[0], value[1]
+			blobs := make([][]byte, 2)
+			for i, text := range []string{cleaned, html} {
+				if blobs[i], err = s.encodeArchived(targetCodec, text); err != nil {
+					return report, err
+				}
+			}
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO content_archive
+				(url, codec, cleaned_content, html_content, raw_content, cleaned_size, original_size, compressed_size, date_archived)
+				VALUES (?, ?, ?, ?, NULL, ?, ?, ?, CURRENT_TIMESTAMP)`,
+				key, targetCodec, blobs[0], blobs[1], len(cleaned), len(cleaned)+len(html), len(blobs[0])+len(blobs[1])); err != nil {
+				return report, fmt.Errorf("failed to archive inline content: %w", err)
+			}
+			if _, err := tx.Exec(`UPDATE articles SET content = '', html_content = '' WHERE url = ?`, key); err != nil {
+				return report, fmt.Errorf("failed to clear inline content: %w", err)
+			}
+			report.Articles++
+		}
+	}
+
+	for _, column := range []struct {
+		values map[string]string
+		update string
+		count  *int
+	}{
+		{summaries, `UPDATE summaries SET summary_text = ? WHERE id = ?`, &report.Summaries},
+		{factSheets, `UPDATE summaries SET facts = ? WHERE id = ?`, new(int)}, // Counted with their summaries
+		{digests, `UPDATE digests SET content = ? WHERE id = ?`, &report.Digests},
+		{digestSummaries, `UPDATE digests SET digest_summary = ? WHERE id = ?`, new(int)}, // Counted with their digests
+		{digestTakes, `UPDATE digests SET my_take = ? WHERE id = ?`, &report.Takes},
+		{articleTakes, `UPDATE articles SET my_take = ? WHERE url = ?`, &report.Takes},
+		{redactions, `UPDATE redactions SET original = ? WHERE placeholder = ?`, &report.Redactions},
+		{briefSummaries, `UPDATE research_briefs SET summary = ? WHERE id = ?`, &report.ResearchBriefs},
+		{briefReports, `UPDATE research_briefs SET report = ? WHERE id = ?`, new(int)}, // Counted with their briefs
+		{trendMarkdown, `UPDATE trend_reports SET markdown = ? WHERE id = CAST(? AS INTEGER)`, &report.TrendReports},
+		{trendSummaries, `UPDATE trend_reports SET summary = ? WHERE id = CAST(? AS INTEGER)`, new(int)}, // Counted with their reports
+		{takes, `UPDATE my_takes SET take = ? WHERE rowid = CAST(? AS INTEGER)`, &report.Takes},
+		{topicTitles, `UPDATE topic_pages SET title = ? WHERE slug = ?`, &report.TopicPages},
+		{comments, `UPDATE digest_comments SET text = ? WHERE id = CAST(? AS INTEGER)`, &report.Comments},
+	} {
+		for id, value := range column.values {
+			if strings.HasPrefix(value, encryptedTextPrefix) == encrypt {
+				continue
+			}
+			rewritten, err := s.openText(value)
+			if err == nil && encrypt {
+				rewritten, err = s.sealText(rewritten)
+			}
+			if err != nil {
+				return report, err
+			}
+			if _, err := tx.Exec(column.update, rewritten, id); err != nil {
+				return report, fmt.Errorf("failed to rewrite content: %w", err)
+			}
+			*column.count++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return report, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	// Rewritten rows leave their old plaintext in free pages until the file is rebuilt
+	if encrypt {
+		if _, err := s.db.Exec("VACUUM"); err != nil {
+			return report, fmt.Errorf("failed to compact cache: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// queryTextColumn reads (key, value) rows into a map
+func (s *Store) queryTextColumn(query string) (map[string]string, error) {
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached content: %w", err)
+	}
+	defer rows.Close()
+
+	values := make(map[string]string)
+	for rows.Next() {
+		var key string
+		var value sql.NullString
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan cached content: %w", err)
+		}
+		values[key] = value.String
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,328 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncryption_MigratesAndLocks(t *testing.T) {
+	dir := t.TempDir()
+	key, err := GenerateCacheKey()
+	if err != nil {
+		t.Fatalf("GenerateCacheKey failed: %v", err)
+	}
+
+	article := core.Article{
+		URL:         "https://intranet.example.com/roadmap",
+		Title:       "Roadmap",
+		CleanedText: "Confidential roadmap text. " + strings.Repeat("More detail. ", 50),
+		DateFetched: time.Now().UTC(),
+	}
+	summary := core.Summary{ID: "s1", SummaryText: "Confidential summary", DateGenerated: time.Now().UTC()}
+
+	// A cache written before encryption was turned on
+	plain, err := NewStoreWithKey(dir, "")
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	if err := plain.CacheArticle(article); err != nil {
+		t.Fatalf("CacheArticle failed: %v", err)
+	}
+	if err := plain.CacheSummary(summary, article.URL, "hash"); err != nil {
+		t.Fatalf("CacheSummary failed: %v", err)
+	}
+	if err := plain.SaveSeriesIssue(SeriesIssue{DigestID: "d1", Series: "ai-weekly", Title: "AI #1", Content: "Confidential digest"}); err != nil {
+		t.Fatalf("SaveSeriesIssue failed: %v", err)
+	}
+	_ = plain.Close()
+
+	encrypted, err := NewStoreWithKey(dir, key)
+	if err != nil {
+		t.Fatalf("NewStoreWithKey with key failed: %v", err)
+	}
+	report, err := encrypted.EncryptContent()
+	if err != nil {
+		t.Fatalf("EncryptContent failed: %v", err)
+	}
+	if report.Articles != 1 || report.Summaries != 1 || report.Digests != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	// Nothing readable is left in the content columns
+	var codec, summaryText, digestContent string
+	_ = encrypted.db.QueryRow("SELECT codec FROM content_archive").Scan(&codec)
+	_ = encrypted.db.QueryRow("SELECT summary_text FROM summaries").Scan(&summaryText)
+	_ = encrypted.db.QueryRow("SELECT content FROM digests").Scan(&digestContent)
+	if codec != ArchiveCodecGzipAESGCM || strings.Contains(summaryText, "Confidential") || strings.Contains(digestContent, "Confidential") {
+		t.Errorf("expected content encrypted, got codec %q, summary %q, digest %q", codec, summaryText, digestContent)
+	}
+
+	// Reads decrypt transparently
+	cached, err := encrypted.GetCachedArticle(article.URL, time.Hour)
+	if err != nil || cached == nil || cached.CleanedText != article.CleanedText {
+		t.Fatalf("GetCachedArticle = %v, %v", cached, err)
+	}
+	cachedSummary, err := encrypted.GetCachedSummary(article.URL, "hash", time.Hour)
+	if err != nil || cachedSummary == nil || cachedSummary.SummaryText != summary.SummaryText {
+		t.Fatalf("GetCachedSummary = %v, %v", cachedSummary, err)
+	}
+	_ = encrypted.Close()
+
+	// Without the key the cache is locked, for reads and writes
+	locked, err := NewStoreWithKey(dir, "")
+	if err != nil {
+		t.Fatalf("NewStoreWithKey without key failed: %v", err)
+	}
+	if _, err := locked.GetCachedArticle(article.URL, time.Hour); !errors.Is(err, ErrCacheLocked) {
+		t.Errorf("expected ErrCacheLocked reading without a key, got %v", err)
+	}
+	if err := locked.CacheArticle(article); !errors.Is(err, ErrCacheLocked) {
+		t.Errorf("expected ErrCacheLocked writing without a key, got %v", err)
+	}
+	_ = locked.Close()
+
+	if _, err := NewStoreWithKey(dir, "not the key"); err == nil {
+		t.Error("expected a wrong key to be rejected")
+	}
+
+	// Decrypting turns encryption off again
+	encrypted, err = NewStoreWithKey(dir, key)
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	if _, err := encrypted.DecryptContent(); err != nil {
+		t.Fatalf("DecryptContent failed: %v", err)
+	}
+	_ = encrypted.Close()
+
+	plain, err = NewStoreWithKey(dir, "")
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	defer func() { _ = plain.Close() }()
+	issues, err := plain.GetSeriesIssues("ai-weekly", 1)
+	if err != nil || len(issues) != 1 || issues[0].Content != "Confidential digest" || plain.Locked() {
+		t.Errorf("expected plaintext after decrypt, got %+v, %v", issues, err)
+	}
+}
+
+// writeSecretContent saves "Confidential" content to every table with encrypted columns
+func writeSecretContent(t *testing.T, s *Store) {
+	t.Helper()
+	brief := &ResearchBrief{ID: "b1", Topic: "Roadmap", Summary: "Confidential brief",
+		Report: &core.ResearchReport{Query: "roadmap", Summary: "Confidential findings"}}
+	if err := s.SaveResearchBrief(brief); err != nil {
+		t.Fatalf("SaveResearchBrief: %v", err)
+	}
+	if err := s.SaveTrendReport(&TrendReport{Title: "Q3 trends", Markdown: "Confidential trends", Summary: "Confidential highlights",
+		Since: time.Now().Add(-time.Hour), Until: time.Now()}); err != nil {
+		t.Fatalf("SaveTrendReport: %v", err)
+	}
+	if err := s.SaveMyTake(MyTake{Kind: TakeDigest, Target: "d1", Take: "Confidential take"}); err != nil {
+		t.Fatalf("SaveMyTake: %v", err)
+	}
+	if err := s.SaveTopicPage(&TopicPage{Slug: "roadmap", Title: "Confidential topic"}); err != nil {
+		t.Fatalf("SaveTopicPage: %v", err)
+	}
+	if _, err := s.AddDigestComment(DigestComment{DigestID: "d1", Text: "Confidential comment"}); err != nil {
+		t.Fatalf("AddDigestComment: %v", err)
+	}
+	if err := s.CacheDigest("d1", "Weekly", "Confidential digest", "Confidential summary", nil, "test"); err != nil {
+		t.Fatalf("CacheDigest: %v", err)
+	}
+	if err := s.UpdateDigestMyTake("d1", "Confidential digest take"); err != nil {
+		t.Fatalf("UpdateDigestMyTake: %v", err)
+	}
+	if err := s.SaveArticle(&core.Article{URL: "https://example.com/a", Title: "A", MyTake: "Confidential article take"}); err != nil {
+		t.Fatalf("SaveArticle: %v", err)
+	}
+}
+
+// assertNoPlaintext fails when a raw content column still holds readable text
+func assertNoPlaintext(t *testing.T, s *Store) {
+	t.Helper()
+	for _, query := range []string{
+		"SELECT summary FROM research_briefs",
+		"SELECT report FROM research_briefs",
+		"SELECT markdown FROM trend_reports",
+		"SELECT summary FROM trend_reports",
+		"SELECT take FROM my_takes",
+		"SELECT title FROM topic_pages",
+		"SELECT text FROM digest_comments",
+		"SELECT digest_summary FROM digests",
+		"SELECT my_take FROM digests",
+		"SELECT my_take FROM articles",
+	} {
+		var raw string
+		if err := s.db.QueryRow(query).Scan(&raw); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		if !strings.HasPrefix(raw, encryptedTextPrefix) || strings.Contains(raw, "Confidential") {
+			t.Errorf("%s: expected an encrypted value, got %q", query, raw)
+		}
+	}
+}
+
+// assertSecretContent checks every table reads back decrypted
+func assertSecretContent(t *testing.T, s *Store) {
+	t.Helper()
+	brief, err := s.GetResearchBrief("b1")
+	if err != nil || brief == nil || brief.Summary != "Confidential brief" || brief.Report == nil || brief.Report.Summary != "Confidential findings" {
+		t.Errorf("GetResearchBrief = %+v, %v", brief, err)
+	}
+	trend, err := s.LatestTrendReport()
+	if err != nil || trend == nil || trend.Markdown != "Confidential trends" || trend.Summary != "Confidential highlights" {
+		t.Errorf("LatestTrendReport = %+v, %v", trend, err)
+	}
+	if take, err := s.GetMyTake(TakeDigest, "d1"); err != nil || take != "Confidential take" {
+		t.Errorf("GetMyTake = %q, %v", take, err)
+	}
+	if pages, err := s.ListTopicPages(); err != nil || len(pages) != 1 || pages[0].Title != "Confidential topic" {
+		t.Errorf("ListTopicPages = %+v, %v", pages, err)
+	}
+	if comments, err := s.ListDigestComments("d1"); err != nil || len(comments) != 1 || comments[0].Text != "Confidential comment" {
+		t.Errorf("ListDigestComments = %+v, %v", comments, err)
+	}
+	digest, err := s.GetCachedDigest("d1")
+	if err != nil || digest == nil || digest.DigestSummary != "Confidential summary" || digest.MyTake != "Confidential digest take" {
+		t.Errorf("GetCachedDigest = %+v, %v", digest, err)
+	}
+	if article, err := s.GetArticleByURL("https://example.com/a"); err != nil || article == nil || article.MyTake != "Confidential article take" {
+		t.Errorf("GetArticleByURL = %+v, %v", article, err)
+	}
+}
+
+func TestEncryption_AllContentTables(t *testing.T) {
+	key, err := GenerateCacheKey()
+	if err != nil {
+		t.Fatalf("GenerateCacheKey failed: %v", err)
+	}
+
+	// Written with a key set, content is sealed as it is stored
+	encrypted, err := NewStoreWithKey(t.TempDir(), key)
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	defer func() { _ = encrypted.Close() }()
+	writeSecretContent(t, encrypted)
+	assertNoPlaintext(t, encrypted)
+	assertSecretContent(t, encrypted)
+
+	// Written before a key was set, `cache encrypt` seals it, and decrypt reverses it
+	dir := t.TempDir()
+	plain, err := NewStoreWithKey(dir, "")
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	writeSecretContent(t, plain)
+	_ = plain.Close()
+
+	migrated, err := NewStoreWithKey(dir, key)
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	defer func() { _ = migrated.Close() }()
+	report, err := migrated.EncryptContent()
+	if err != nil {
+		t.Fatalf("EncryptContent failed: %v", err)
+	}
+	if report.ResearchBriefs != 1 || report.TrendReports != 1 || report.Takes != 3 || report.TopicPages != 1 || report.Comments != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	assertNoPlaintext(t, migrated)
+	assertSecretContent(t, migrated)
+
+	if _, err := migrated.DecryptContent(); err != nil {
+		t.Fatalf("DecryptContent failed: %v", err)
+	}
+	var take string
+	_ = migrated.db.QueryRow("SELECT take FROM my_takes").Scan(&take)
+	if take != "Confidential take" {
+		t.Errorf("expected plaintext after decrypt, got %q", take)
+	}
+	assertSecretContent(t, migrated)
+}
+
+func TestEncryption_PassphraseIsSaltedPerCache(t *testing.T) {
+	const passphrase = "correct horse battery staple"
+	first, err := NewStoreWithKey(t.TempDir(), passphrase)
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	defer func() { _ = first.Close() }()
+	second, err := NewStoreWithKey(t.TempDir(), passphrase)
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	defer func() { _ = second.Close() }()
+
+	var saltA, saltB string
+	_ = first.db.QueryRow(`SELECT value FROM store_meta WHERE name = 'encryption_salt'`).Scan(&saltA)
+	_ = second.db.QueryRow(`SELECT value FROM store_meta WHERE name = 'encryption_salt'`).Scan(&saltB)
+	if saltA == "" || saltA == saltB {
+		t.Fatalf("expected a distinct salt per cache, got %q and %q", saltA, saltB)
+	}
+
+	// The same passphrase derives a different key in each cache
+	if err := first.SaveMyTake(MyTake{Kind: TakeDigest, Target: "d1", Take: "Confidential take"}); err != nil {
+		t.Fatalf("SaveMyTake: %v", err)
+	}
+	var sealed string
+	_ = first.db.QueryRow("SELECT take FROM my_takes").Scan(&sealed)
+	if _, err := second.openText(sealed); err == nil {
+		t.Error("expected another cache's key not to open the value")
+	}
+	unsalted, _ := newContentCipher(passphrase, nil)
+	if _, err := (&Store{cipher: unsalted}).openText(sealed); err == nil {
+		t.Error("expected the unsalted hash of the passphrase not to open the value")
+	}
+}
+
+func TestEncryption_UpgradesUnsaltedPassphrase(t *testing.T) {
+	const passphrase = "correct horse battery staple"
+	dir := t.TempDir()
+
+	// A cache encrypted before salts: the key check and content use the bare hash
+	old, err := NewStoreWithKey(dir, "")
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	if old.cipher, err = newContentCipher(passphrase, nil); err != nil {
+		t.Fatalf("newContentCipher: %v", err)
+	}
+	check, _ := old.sealText(encryptionCheckValue)
+	if _, err := old.db.Exec(`INSERT INTO store_meta (name, value) VALUES ('encryption_check', ?)`, check); err != nil {
+		t.Fatalf("insert check: %v", err)
+	}
+	if err := old.SaveMyTake(MyTake{Kind: TakeDigest, Target: "d1", Take: "Confidential take"}); err != nil {
+		t.Fatalf("SaveMyTake: %v", err)
+	}
+	_ = old.Close()
+
+	if _, err := NewStoreWithKey(dir, "wrong passphrase"); err == nil {
+		t.Fatal("expected a wrong passphrase to be rejected before upgrading")
+	}
+
+	upgraded, err := NewStoreWithKey(dir, passphrase)
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	defer func() { _ = upgraded.Close() }()
+	if take, err := upgraded.GetMyTake(TakeDigest, "d1"); err != nil || take != "Confidential take" {
+		t.Errorf("GetMyTake after upgrade = %q, %v", take, err)
+	}
+
+	var salt, sealed string
+	_ = upgraded.db.QueryRow(`SELECT value FROM store_meta WHERE name = 'encryption_salt'`).Scan(&salt)
+	_ = upgraded.db.QueryRow("SELECT take FROM my_takes").Scan(&sealed)
+	if salt == "" || !strings.HasPrefix(sealed, encryptedTextPrefix) {
+		t.Fatalf("expected a salt and encrypted content, got salt %q and %q", salt, sealed)
+	}
+	unsalted, _ := newContentCipher(passphrase, nil)
+	if _, err := (&Store{cipher: unsalted}).openText(sealed); err == nil {
+		t.Error("expected content re-encrypted under the salted key")
+	}
+}
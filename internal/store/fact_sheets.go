@@ -0,0 +1,48 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"encoding/json"
+	"fmt"
+)
+
+// migrateSummaryFactsColumn adds the facts column, which holds the fact sheet of
+// two-pass summaries, to summaries tables created before it existed
+func (s *Store) migrateSummaryFactsColumn() error {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('summaries') WHERE name='facts'").Scan(&count); err != nil {
+		return fmt.Errorf("failed to check summaries schema for facts: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if _, err := s.db.Exec("ALTER TABLE summaries ADD COLUMN facts TEXT DEFAULT ''"); err != nil {
+		return fmt.Errorf("failed to add facts column to summaries: %w", err)
+	}
+	return nil
+}
+
+// sealFactSheet serializes a fact sheet for the facts column; nil is stored empty
+func (s *Store) sealFactSheet(facts *core.FactSheet) (string, error) {
+	if facts == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(facts)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize fact sheet: %w", err)
+	}
+	return s.sealText(string(data))
+}
+
+// openFactSheet reads a facts column value back; empty values give nil
+func (s *Store) openFactSheet(value string) (*core.FactSheet, error) {
+	text, err := s.openText(value)
+	if err != nil || text == "" {
+		return nil, err
+	}
+	var facts core.FactSheet
+	if err := json.Unmarshal([]byte(text), &facts); err != nil {
+		return nil, fmt.Errorf("failed to parse fact sheet: %w", err)
+	}
+	return &facts, nil
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"testing"
+	"time"
+)
+
+func TestCacheSummary_FactSheet(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	facts := &core.FactSheet{
+		Claims:  []core.Fact{{Text: "Acme released Rocket 2", Quote: "Acme today released Rocket 2"}},
+		Numbers: []core.Fact{{Value: "40%", Text: "p99 latency cut", Quote: "cuts p99 latency by 40%"}},
+	}
+	older := core.Summary{ID: "s1", SummaryText: "Older", DateGenerated: time.Now().UTC().Add(-time.Hour)}
+	newer := core.Summary{ID: "s2", SummaryText: "Newer", DateGenerated: time.Now().UTC(), Facts: facts}
+	if err := store.CacheSummary(older, "https://example.com/a", "old-hash"); err != nil {
+		t.Fatalf("CacheSummary: %v", err)
+	}
+	if err := store.CacheSummary(newer, "https://example.com/a", "new-hash"); err != nil {
+		t.Fatalf("CacheSummary: %v", err)
+	}
+
+	cached, err := store.GetCachedSummary("https://example.com/a", "new-hash", time.Hour)
+	if err != nil || cached == nil {
+		t.Fatalf("GetCachedSummary = %v, %v", cached, err)
+	}
+	if cached.Facts == nil || len(cached.Facts.Numbers) != 1 || cached.Facts.Numbers[0].Value != "40%" {
+		t.Errorf("expected the fact sheet cached with the summary, got %+v", cached.Facts)
+	}
+	plain, _ := store.GetCachedSummary("https://example.com/a", "old-hash", 2*time.Hour)
+	if plain == nil || plain.Facts != nil {
+		t.Errorf("expected a single-pass summary without facts, got %+v", plain)
+	}
+
+}
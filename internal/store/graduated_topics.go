@@ -0,0 +1,74 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// graduatedTopicsTable holds the topics 'briefly quality trends --graduate' moved
+// to ongoing coverage: each is a priority watchlist term and a standing search
+// query alongside the ones in config
+const graduatedTopicsTable = `
+	CREATE TABLE IF NOT EXISTS graduated_topics (
+		keyword TEXT PRIMARY KEY COLLATE NOCASE,
+		streak INTEGER NOT NULL DEFAULT 0,
+		graduated_at DATETIME NOT NULL
+	);`
+
+// GraduatedTopic is a trending topic kept under ongoing coverage
+type GraduatedTopic struct {
+	Keyword     string
+	Streak      int // Consecutive digests it was a top keyword of when it graduated
+	GraduatedAt time.Time
+}
+
+// SaveGraduatedTopic stores a topic. A topic graduated before (in any letter case)
+// keeps its first entry.
+func (s *Store) SaveGraduatedTopic(topic GraduatedTopic) error {
+	topic.Keyword = strings.TrimSpace(topic.Keyword)
+	if topic.Keyword == "" {
+		return fmt.Errorf("graduated topic has no keyword")
+	}
+	if topic.GraduatedAt.IsZero() {
+		topic.GraduatedAt = time.Now()
+	}
+	if _, err := s.db.Exec(`INSERT OR IGNORE INTO graduated_topics (keyword, streak, graduated_at) VALUES (?, ?, ?)`,
+		topic.Keyword, topic.Streak, topic.GraduatedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to save graduated topic %q: %w", topic.Keyword, err)
+	}
+	return nil
+}
+
+// ListGraduatedTopics returns the graduated topics, oldest first
+func (s *Store) ListGraduatedTopics() ([]GraduatedTopic, error) {
+	rows, err := s.db.Query(`SELECT keyword, streak, graduated_at FROM graduated_topics ORDER BY graduated_at, keyword`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list graduated topics: %w", err)
+	}
+	defer rows.Close()
+
+	var topics []GraduatedTopic
+	for rows.Next() {
+		var topic GraduatedTopic
+		if err := rows.Scan(&topic.Keyword, &topic.Streak, &topic.GraduatedAt); err != nil {
+			return nil, fmt.Errorf("failed to read graduated topic: %w", err)
+		}
+		topics = append(topics, topic)
+	}
+	return topics, rows.Err()
+}
+
+// DeleteGraduatedTopic stops ongoing coverage of a topic. Returns false when it
+// wasn't graduated.
+func (s *Store) DeleteGraduatedTopic(keyword string) (bool, error) {
+	result, err := s.db.Exec(`DELETE FROM graduated_topics WHERE keyword = ?`, strings.TrimSpace(keyword))
+	if err != nil {
+		return false, fmt.Errorf("failed to remove graduated topic %q: %w", keyword, err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove graduated topic %q: %w", keyword, err)
+	}
+	return removed > 0, nil
+}
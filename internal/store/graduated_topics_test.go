@@ -0,0 +1,44 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGraduatedTopics(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	now := time.Now()
+	if err := store.SaveGraduatedTopic(GraduatedTopic{Keyword: "mcp", Streak: 3, GraduatedAt: now.Add(-time.Hour)}); err != nil {
+		t.Fatalf("SaveGraduatedTopic failed: %v", err)
+	}
+	if err := store.SaveGraduatedTopic(GraduatedTopic{Keyword: "Rust", Streak: 4, GraduatedAt: now}); err != nil {
+		t.Fatalf("SaveGraduatedTopic failed: %v", err)
+	}
+	// Graduating again in another case keeps the first entry
+	if err := store.SaveGraduatedTopic(GraduatedTopic{Keyword: "MCP", Streak: 5, GraduatedAt: now}); err != nil {
+		t.Fatalf("SaveGraduatedTopic again failed: %v", err)
+	}
+
+	topics, err := store.ListGraduatedTopics()
+	if err != nil {
+		t.Fatalf("ListGraduatedTopics failed: %v", err)
+	}
+	if len(topics) != 2 || topics[0].Keyword != "mcp" || topics[0].Streak != 3 || topics[1].Keyword != "Rust" {
+		t.Fatalf("unexpected topics %+v", topics)
+	}
+
+	if removed, err := store.DeleteGraduatedTopic("rust"); err != nil || !removed {
+		t.Errorf("DeleteGraduatedTopic = %v, %v", removed, err)
+	}
+	if removed, _ := store.DeleteGraduatedTopic("rust"); removed {
+		t.Error("expected nothing removed the second time")
+	}
+	if err := store.SaveGraduatedTopic(GraduatedTopic{Keyword: "  "}); err == nil {
+		t.Error("expected an error for an empty keyword")
+	}
+}
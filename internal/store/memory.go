@@ -0,0 +1,68 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// memoryPath is the path of stores held in memory
+const memoryPath = ":memory:"
+
+// NewMemoryStore creates a store held in memory, with the same tables and behavior
+// as an on-disk cache, for environments where no cache can be written. Nothing
+// outlives the process, and content is never encrypted since it never hits disk.
+func NewMemoryStore() (*Store, error) {
+	db, err := sql.Open("sqlite3", memoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open in-memory database: %w", err)
+	}
+	// Each connection to :memory: is a separate database, so keep exactly one
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+
+	store := &Store{db: db, path: memoryPath}
+	if err := store.initialize(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize in-memory database: %w", err)
+	}
+	return store, nil
+}
+
+// OpenStore opens the cache in dataDir like NewStore. When the cache can't be
+// opened, e.g. on a read-only filesystem in a container, it falls back to
+// NewMemoryStore so every feature except persistence keeps working; Fallback
+// reports why. Key errors are returned rather than hidden behind the fallback, as
+// is an error opening the in-memory store.
+func OpenStore(dataDir string) (*Store, error) {
+	key, err := LoadCacheKey()
+	if err != nil {
+		return nil, err
+	}
+	store, err := NewStoreWithKey(dataDir, key)
+	if err == nil {
+		return store, nil
+	}
+	if errors.Is(err, ErrCacheKeyMismatch) {
+		return nil, err
+	}
+
+	memory, memErr := NewMemoryStore()
+	if memErr != nil {
+		return nil, fmt.Errorf("%w (in-memory fallback also failed: %v)", err, memErr)
+	}
+	memory.fallback = err
+	return memory, nil
+}
+
+// InMemory reports whether the store is held in memory rather than on disk
+func (s *Store) InMemory() bool {
+	return s.path == memoryPath
+}
+
+// Fallback returns why OpenStore fell back to an in-memory store, or nil when the
+// store is the on-disk cache
+func (s *Store) Fallback() error {
+	return s.fallback
+}
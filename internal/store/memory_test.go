@@ -0,0 +1,97 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOpenStore_FallsBackToMemory(t *testing.T) {
+	// A file where the cache directory should be can't hold a cache
+	blocked := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(blocked, []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := OpenStore(blocked)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if !store.InMemory() || store.Fallback() == nil {
+		t.Fatalf("expected an in-memory fallback with its reason, got in-memory=%v fallback=%v", store.InMemory(), store.Fallback())
+	}
+	if _, err := store.Backup(context.Background(), filepath.Join(t.TempDir(), "backup.tar.gz"), ""); err == nil {
+		t.Error("expected backing up an in-memory store to fail")
+	}
+
+	onDisk, err := OpenStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	defer func() { _ = onDisk.Close() }()
+	if onDisk.InMemory() || onDisk.Fallback() != nil {
+		t.Error("expected the on-disk cache when it can be opened")
+	}
+}
+
+func TestOpenStore_WrongKeyDoesNotFallBack(t *testing.T) {
+	dir := t.TempDir()
+	encrypted, err := NewStoreWithKey(dir, "first-key")
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	_ = encrypted.Close()
+
+	t.Setenv(CacheKeyEnv, "second-key")
+	if store, err := OpenStore(dir); !errors.Is(err, ErrCacheKeyMismatch) {
+		t.Errorf("expected the key mismatch, got %v, %v", store, err)
+	}
+}
+
+func TestNewMemoryStore(t *testing.T) {
+	store, err := NewMemoryStore()
+	if err != nil {
+		t.Fatalf("NewMemoryStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	// Concurrent writers share the one in-memory database
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			article := core.Article{
+				ID:          fmt.Sprintf("a%d", i),
+				URL:         fmt.Sprintf("https://example.com/%d", i),
+				Title:       "Article",
+				CleanedText: "Body text",
+				DateFetched: time.Now(),
+			}
+			if err := store.CacheArticle(article); err != nil {
+				t.Errorf("CacheArticle failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	article, err := store.GetCachedArticle("https://example.com/3", time.Hour)
+	if err != nil || article == nil || article.CleanedText != "Body text" {
+		t.Fatalf("expected the cached article back, got %+v, %v", article, err)
+	}
+
+	if err := store.SaveTopicPage(&TopicPage{Slug: "agents", Title: "Agents"}); err != nil {
+		t.Fatalf("SaveTopicPage failed: %v", err)
+	}
+	if pages, err := store.ListTopicPages(); err != nil || len(pages) != 1 {
+		t.Errorf("expected the topic page back, got %+v, %v", pages, err)
+	}
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// priorityAlertsTable records the items the priority inbox pushed, so an item seen
+// again in a later pull or capture isn't alerted twice
+const priorityAlertsTable = `
+	CREATE TABLE IF NOT EXISTS priority_alerts (
+		url TEXT PRIMARY KEY,
+		rule TEXT NOT NULL,
+		title TEXT NOT NULL DEFAULT '',
+		alerted_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_priority_alerts_alerted_at ON priority_alerts (alerted_at);`
+
+// PriorityAlert is an item the priority inbox pushed
+type PriorityAlert struct {
+	URL       string
+	Rule      string // Rule name, or "watchlist"
+	Title     string
+	AlertedAt time.Time
+}
+
+// HasPriorityAlert reports whether url was already alerted
+func (s *Store) HasPriorityAlert(url string) (bool, error) {
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM priority_alerts WHERE url = ?`, url).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check priority alert for %s: %w", url, err)
+	}
+	return count > 0, nil
+}
+
+// RecordPriorityAlert records an alert. A URL alerted before keeps its first record.
+func (s *Store) RecordPriorityAlert(alert PriorityAlert) error {
+	if alert.URL == "" {
+		return fmt.Errorf("priority alert has no URL")
+	}
+	if _, err := s.db.Exec(`INSERT OR IGNORE INTO priority_alerts (url, rule, title, alerted_at) VALUES (?, ?, ?, ?)`,
+		alert.URL, alert.Rule, alert.Title, alert.AlertedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to record priority alert for %s: %w", alert.URL, err)
+	}
+	return nil
+}
+
+// ListPriorityAlerts returns recent alerts, newest first
+func (s *Store) ListPriorityAlerts(limit int) ([]PriorityAlert, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	rows, err := s.db.Query(`SELECT url, rule, title, alerted_at FROM priority_alerts ORDER BY alerted_at DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list priority alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []PriorityAlert
+	for rows.Next() {
+		var alert PriorityAlert
+		if err := rows.Scan(&alert.URL, &alert.Rule, &alert.Title, &alert.AlertedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan priority alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPriorityAlerts_RecordOnce(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	url := "https://example.com/cve-2025-1234"
+	if seen, err := store.HasPriorityAlert(url); err != nil || seen {
+		t.Fatalf("HasPriorityAlert before recording = %v, %v", seen, err)
+	}
+
+	at := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	if err := store.RecordPriorityAlert(PriorityAlert{URL: url, Rule: "security", Title: "CVE-2025-1234", AlertedAt: at}); err != nil {
+		t.Fatalf("RecordPriorityAlert failed: %v", err)
+	}
+	if err := store.RecordPriorityAlert(PriorityAlert{URL: url, Rule: "watchlist", AlertedAt: at.Add(time.Hour)}); err != nil {
+		t.Fatalf("RecordPriorityAlert again failed: %v", err)
+	}
+	if err := store.RecordPriorityAlert(PriorityAlert{URL: "https://example.com/later", Rule: "watchlist", AlertedAt: at.Add(2 * time.Hour)}); err != nil {
+		t.Fatalf("RecordPriorityAlert failed: %v", err)
+	}
+
+	if seen, err := store.HasPriorityAlert(url); err != nil || !seen {
+		t.Fatalf("HasPriorityAlert after recording = %v, %v", seen, err)
+	}
+
+	alerts, err := store.ListPriorityAlerts(10)
+	if err != nil {
+		t.Fatalf("ListPriorityAlerts failed: %v", err)
+	}
+	if len(alerts) != 2 || alerts[0].URL != "https://example.com/later" {
+		t.Fatalf("expected two alerts, newest first, got %+v", alerts)
+	}
+	if alerts[1].Rule != "security" || !alerts[1].AlertedAt.Equal(at) {
+		t.Errorf("a repeated alert replaced the first record: %+v", alerts[1])
+	}
+
+	if err := store.RecordPriorityAlert(PriorityAlert{Rule: "security"}); err == nil {
+		t.Error("expected an error for an alert without a URL")
+	}
+}
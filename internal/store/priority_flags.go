@@ -0,0 +1,56 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// priorityFlagsTable records the pulled feed items whose title or description
+// mentions a priority rule's keywords or a watchlist term, found by the keyword
+// pre-screen before anything is fetched
+const priorityFlagsTable = `
+	CREATE TABLE IF NOT EXISTS priority_flags (
+		url TEXT PRIMARY KEY,
+		rule TEXT NOT NULL,
+		terms TEXT NOT NULL DEFAULT '',
+		flagged_at DATETIME NOT NULL
+	);`
+
+// PriorityFlag marks a feed item as high priority
+type PriorityFlag struct {
+	URL       string
+	Rule      string   // Rule name, or "watchlist"
+	Terms     []string // Keywords or watchlist terms the item mentions
+	FlaggedAt time.Time
+}
+
+// FlagPriorityItem records a flag. A URL flagged before keeps its first flag.
+func (s *Store) FlagPriorityItem(flag PriorityFlag) error {
+	if flag.URL == "" {
+		return fmt.Errorf("priority flag has no URL")
+	}
+	if _, err := s.db.Exec(`INSERT OR IGNORE INTO priority_flags (url, rule, terms, flagged_at) VALUES (?, ?, ?, ?)`,
+		flag.URL, flag.Rule, strings.Join(flag.Terms, ", "), flag.FlaggedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to flag %s: %w", flag.URL, err)
+	}
+	return nil
+}
+
+// GetPriorityFlag returns url's flag, or nil when it wasn't flagged
+func (s *Store) GetPriorityFlag(url string) (*PriorityFlag, error) {
+	flag := PriorityFlag{URL: url}
+	var terms string
+	err := s.db.QueryRow(`SELECT rule, terms, flagged_at FROM priority_flags WHERE url = ?`, url).Scan(&flag.Rule, &terms, &flag.FlaggedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read priority flag for %s: %w", url, err)
+	}
+	if terms != "" {
+		flag.Terms = strings.Split(terms, ", ")
+	}
+	return &flag, nil
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPriorityFlags_FlagOnce(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	url := "https://example.com/cve-2025-1234"
+	if flag, err := store.GetPriorityFlag(url); err != nil || flag != nil {
+		t.Fatalf("GetPriorityFlag before flagging = %+v, %v", flag, err)
+	}
+
+	at := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	if err := store.FlagPriorityItem(PriorityFlag{URL: url, Rule: "security", Terms: []string{"CVE", "zero-day"}, FlaggedAt: at}); err != nil {
+		t.Fatalf("FlagPriorityItem failed: %v", err)
+	}
+	if err := store.FlagPriorityItem(PriorityFlag{URL: url, Rule: "watchlist", FlaggedAt: at.Add(time.Hour)}); err != nil {
+		t.Fatalf("FlagPriorityItem again failed: %v", err)
+	}
+
+	flag, err := store.GetPriorityFlag(url)
+	if err != nil || flag == nil {
+		t.Fatalf("GetPriorityFlag after flagging = %+v, %v", flag, err)
+	}
+	if flag.Rule != "security" || len(flag.Terms) != 2 || flag.Terms[1] != "zero-day" || !flag.FlaggedAt.Equal(at) {
+		t.Errorf("expected the first flag kept, got %+v", flag)
+	}
+
+	if err := store.FlagPriorityItem(PriorityFlag{Rule: "security"}); err == nil {
+		t.Error("expected an error for a flag without a URL")
+	}
+}
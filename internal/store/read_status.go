@@ -0,0 +1,106 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Read status sources record how the store learned an article was read
+const (
+	ReadSourceManual = "manual" // Marked with 'briefly cache read-status mark'
+	ReadSourceOpened = "opened" // Opened from the cache with 'briefly cache open'
+	ReadSourceClick  = "click"  // Clicked through a tracked digest link
+)
+
+// readStatusTable records which articles the reader has already read. It is keyed
+// by URL rather than tied to the articles table, so articles read elsewhere can be
+// marked before they are ever cached, and marks survive cache cleanup.
+const readStatusTable = `
+	CREATE TABLE IF NOT EXISTS read_status (
+		url TEXT PRIMARY KEY,
+		source TEXT NOT NULL,
+		read_at DATETIME NOT NULL
+	);`
+
+// ReadMark records that an article was read
+type ReadMark struct {
+	URL    string
+	Source string
+	ReadAt time.Time
+}
+
+// MarkRead records url as read. An article already marked keeps its first read
+// time and source. Returns whether the mark is new.
+func (s *Store) MarkRead(url string, source string, readAt time.Time) (bool, error) {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return false, fmt.Errorf("url is required")
+	}
+	if readAt.IsZero() {
+		readAt = time.Now().UTC()
+	}
+
+	result, err := s.db.Exec(`INSERT OR IGNORE INTO read_status (url, source, read_at) VALUES (?, ?, ?)`, url, source, readAt.UTC())
+	if err != nil {
+		return false, fmt.Errorf("failed to mark %s as read: %w", url, err)
+	}
+	added, _ := result.RowsAffected()
+	return added > 0, nil
+}
+
+// MarkUnread clears the read mark on url. Returns whether it was marked.
+func (s *Store) MarkUnread(url string) (bool, error) {
+	result, err := s.db.Exec(`DELETE FROM read_status WHERE url = ?`, strings.TrimSpace(url))
+	if err != nil {
+		return false, fmt.Errorf("failed to mark %s as unread: %w", url, err)
+	}
+	removed, _ := result.RowsAffected()
+	return removed > 0, nil
+}
+
+// ReadURLs returns the set of URLs marked as read
+func (s *Store) ReadURLs() (map[string]bool, error) {
+	rows, err := s.db.Query(`SELECT url FROM read_status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query read status: %w", err)
+	}
+	defer rows.Close()
+
+	read := make(map[string]bool)
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, fmt.Errorf("failed to scan read status: %w", err)
+		}
+		read[url] = true
+	}
+	return read, rows.Err()
+}
+
+// ListReadMarks returns read marks since the given time, most recent first
+// (limit <= 0 = all)
+func (s *Store) ListReadMarks(since time.Time, limit int) ([]ReadMark, error) {
+	query := `SELECT url, source, read_at FROM read_status WHERE read_at >= ? ORDER BY read_at DESC`
+	args := []interface{}{since.UTC()}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query read status: %w", err)
+	}
+	defer rows.Close()
+
+	var marks []ReadMark
+	for rows.Next() {
+		var mark ReadMark
+		if err := rows.Scan(&mark.URL, &mark.Source, &mark.ReadAt); err != nil {
+			return nil, fmt.Errorf("failed to scan read status: %w", err)
+		}
+		marks = append(marks, mark)
+	}
+	return marks, rows.Err()
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReadStatus_MarkAndUnmark(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	first := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	added, err := store.MarkRead("https://example.com/a", ReadSourceClick, first)
+	if err != nil || !added {
+		t.Fatalf("MarkRead = %v, %v; want new mark", added, err)
+	}
+	if _, err := store.MarkRead("https://example.com/b", ReadSourceManual, first.Add(time.Hour)); err != nil {
+		t.Fatalf("MarkRead failed: %v", err)
+	}
+
+	// A repeat mark keeps the first read
+	added, err = store.MarkRead("https://example.com/a", ReadSourceManual, first.Add(48*time.Hour))
+	if err != nil || added {
+		t.Fatalf("repeat MarkRead = %v, %v; want existing mark kept", added, err)
+	}
+
+	read, err := store.ReadURLs()
+	if err != nil {
+		t.Fatalf("ReadURLs failed: %v", err)
+	}
+	if !read["https://example.com/a"] || !read["https://example.com/b"] || len(read) != 2 {
+		t.Errorf("unexpected read set: %v", read)
+	}
+
+	marks, err := store.ListReadMarks(first, 0)
+	if err != nil {
+		t.Fatalf("ListReadMarks failed: %v", err)
+	}
+	if len(marks) != 2 || marks[0].URL != "https://example.com/b" {
+		t.Fatalf("expected most recent mark first, got %+v", marks)
+	}
+	if marks[1].Source != ReadSourceClick || !marks[1].ReadAt.Equal(first) {
+		t.Errorf("expected first click mark to be kept, got %+v", marks[1])
+	}
+
+	removed, err := store.MarkUnread("https://example.com/a")
+	if err != nil || !removed {
+		t.Fatalf("MarkUnread = %v, %v; want removed", removed, err)
+	}
+	if removed, _ := store.MarkUnread("https://example.com/a"); removed {
+		t.Error("expected second MarkUnread to find nothing")
+	}
+	if read, _ := store.ReadURLs(); read["https://example.com/a"] {
+		t.Error("expected article to be unread")
+	}
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// redactionsTable keeps the placeholder mapping of the redaction stage, so values
+// redacted before an LLM call can be restored in digests rendered later. Originals
+// are sealed like other cached content when a cache key is set.
+const redactionsTable = `
+	CREATE TABLE IF NOT EXISTS redactions (
+		placeholder TEXT PRIMARY KEY,
+		original TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+// LoadRedactions returns the redaction mapping, placeholder → original
+func (s *Store) LoadRedactions() (map[string]string, error) {
+	values, err := s.queryTextColumn(`SELECT placeholder, original FROM redactions`)
+	if err != nil {
+		return nil, err
+	}
+	for placeholder, original := range values {
+		if values[placeholder], err = s.openText(original); err != nil {
+			return nil, fmt.Errorf("failed to read redaction %s: %w", placeholder, err)
+		}
+	}
+	return values, nil
+}
+
+// SaveRedaction records the original value behind a placeholder. A placeholder
+// already recorded keeps its first value.
+func (s *Store) SaveRedaction(placeholder, original string) error {
+	placeholder = strings.TrimSpace(placeholder)
+	if placeholder == "" || original == "" {
+		return fmt.Errorf("placeholder and original are required")
+	}
+	sealed, err := s.sealText(original)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(`INSERT OR IGNORE INTO redactions (placeholder, original) VALUES (?, ?)`, placeholder, sealed); err != nil {
+		return fmt.Errorf("failed to save redaction %s: %w", placeholder, err)
+	}
+	return nil
+}
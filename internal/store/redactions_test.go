@@ -0,0 +1,63 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactions_SaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	plain, err := NewStoreWithKey(dir, "")
+	if err != nil {
+		t.Fatalf("NewStoreWithKey failed: %v", err)
+	}
+	if err := plain.SaveRedaction("REDACTED_HOST_1", "billing.corp.example.com"); err != nil {
+		t.Fatalf("SaveRedaction failed: %v", err)
+	}
+	// A placeholder keeps its first value
+	if err := plain.SaveRedaction("REDACTED_HOST_1", "other.corp.example.com"); err != nil {
+		t.Fatalf("repeat SaveRedaction failed: %v", err)
+	}
+	if err := plain.SaveRedaction("", "Acme"); err == nil {
+		t.Error("expected an empty placeholder rejected")
+	}
+	_ = plain.Close()
+
+	key, err := GenerateCacheKey()
+	if err != nil {
+		t.Fatalf("GenerateCacheKey failed: %v", err)
+	}
+	encrypted, err := NewStoreWithKey(dir, key)
+	if err != nil {
+		t.Fatalf("NewStoreWithKey with key failed: %v", err)
+	}
+	defer func() { _ = encrypted.Close() }()
+	if err := encrypted.SaveRedaction("REDACTED_TERM_1", "Acme Corp"); err != nil {
+		t.Fatalf("SaveRedaction failed: %v", err)
+	}
+	report, err := encrypted.EncryptContent()
+	if err != nil {
+		t.Fatalf("EncryptContent failed: %v", err)
+	}
+	if report.Redactions != 1 {
+		t.Errorf("expected the plaintext redaction encrypted, got %+v", report)
+	}
+
+	rows, err := encrypted.queryTextColumn(`SELECT placeholder, original FROM redactions`)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	for placeholder, original := range rows {
+		if !strings.HasPrefix(original, encryptedTextPrefix) {
+			t.Errorf("expected %s stored encrypted, got %q", placeholder, original)
+		}
+	}
+
+	mapping, err := encrypted.LoadRedactions()
+	if err != nil {
+		t.Fatalf("LoadRedactions failed: %v", err)
+	}
+	if len(mapping) != 2 || mapping["REDACTED_HOST_1"] != "billing.corp.example.com" || mapping["REDACTED_TERM_1"] != "Acme Corp" {
+		t.Errorf("unexpected mapping %v", mapping)
+	}
+}
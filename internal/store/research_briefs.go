@@ -0,0 +1,181 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// researchBriefsTable holds deep-research briefs so digests can point back to them.
+// The embedding covers the brief's topic and summary, in the same space as article
+// embeddings, so digest clusters can be matched against it.
+const researchBriefsTable = `
+	CREATE TABLE IF NOT EXISTS research_briefs (
+		id TEXT PRIMARY KEY,
+		topic TEXT NOT NULL,
+		summary TEXT NOT NULL,
+		path TEXT DEFAULT '',
+		source_count INTEGER DEFAULT 0,
+		embedding BLOB,
+		report TEXT DEFAULT '',
+		created_at DATETIME NOT NULL
+	);`
+
+// ResearchBrief is a stored deep-research brief
+type ResearchBrief struct {
+	ID          string
+	Topic       string
+	Summary     string               // Synthesized findings, markdown
+	Path        string               // Where the full brief was written, if anywhere
+	SourceCount int                  // Sources the brief cites
+	Embedding   []float64            // Embedding of topic and summary
+	Report      *core.ResearchReport // Full report with sources, for export; nil for older briefs
+	CreatedAt   time.Time
+}
+
+// researchBriefColumns lists research_briefs columns in scanResearchBrief order
+const researchBriefColumns = "id, topic, summary, path, source_count, embedding, report, created_at"
+
+// migrateResearchBriefColumns adds the report column to research_briefs tables
+// created before briefs could be exported
+func (s *Store) migrateResearchBriefColumns() error {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('research_briefs') WHERE name='report'").Scan(&count); err != nil {
+		return fmt.Errorf("failed to check research_briefs schema for report: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if _, err := s.db.Exec("ALTER TABLE research_briefs ADD COLUMN report TEXT DEFAULT ''"); err != nil {
+		return fmt.Errorf("failed to add report column to research_briefs: %w", err)
+	}
+	return nil
+}
+
+// SaveResearchBrief stores a brief, assigning an ID and creation time when unset
+func (s *Store) SaveResearchBrief(brief *ResearchBrief) error {
+	if strings.TrimSpace(brief.Topic) == "" {
+		return fmt.Errorf("research brief topic is required")
+	}
+	if brief.ID == "" {
+		brief.ID = uuid.NewString()
+	}
+	if brief.CreatedAt.IsZero() {
+		brief.CreatedAt = time.Now().UTC()
+	}
+
+	embeddingData, err := serializeEmbedding(brief.Embedding)
+	if err != nil {
+		return fmt.Errorf("failed to serialize embedding: %w", err)
+	}
+
+	var reportJSON []byte
+	if brief.Report != nil {
+		if reportJSON, err = json.Marshal(brief.Report); err != nil {
+			return fmt.Errorf("failed to serialize research report: %w", err)
+		}
+	}
+	summary, err := s.sealText(brief.Summary)
+	if err != nil {
+		return err
+	}
+	report, err := s.sealText(string(reportJSON))
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO research_briefs (`+researchBriefColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		brief.ID, brief.Topic, summary, brief.Path, brief.SourceCount, embeddingData, report, brief.CreatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save research brief: %w", err)
+	}
+	return nil
+}
+
+// GetResearchBrief returns the brief with the given ID, or nil if there is none
+func (s *Store) GetResearchBrief(id string) (*ResearchBrief, error) {
+	row := s.db.QueryRow(`SELECT `+researchBriefColumns+` FROM research_briefs WHERE id = ?`, id)
+	brief, err := s.scanResearchBrief(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return brief, err
+}
+
+// ListResearchBriefs returns stored briefs, most recent first (limit <= 0 = all)
+func (s *Store) ListResearchBriefs(limit int) ([]ResearchBrief, error) {
+	query := `SELECT ` + researchBriefColumns + ` FROM research_briefs ORDER BY created_at DESC`
+	var args []interface{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query research briefs: %w", err)
+	}
+	defer rows.Close()
+
+	var briefs []ResearchBrief
+	for rows.Next() {
+		brief, err := s.scanResearchBrief(rows)
+		if err != nil {
+			return nil, err
+		}
+		briefs = append(briefs, *brief)
+	}
+	return briefs, rows.Err()
+}
+
+// DeleteResearchBrief removes a stored brief. Returns whether it existed.
+func (s *Store) DeleteResearchBrief(id string) (bool, error) {
+	result, err := s.db.Exec(`DELETE FROM research_briefs WHERE id = ?`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete research brief: %w", err)
+	}
+	removed, _ := result.RowsAffected()
+	return removed > 0, nil
+}
+
+// scanResearchBrief reads one research_briefs row, decrypting its summary and report
+func (s *Store) scanResearchBrief(row interface{ Scan(...interface{}) error }) (*ResearchBrief, error) {
+	var brief ResearchBrief
+	var embeddingData []byte
+	var reportJSON sql.NullString
+	if err := row.Scan(&brief.ID, &brief.Topic, &brief.Summary, &brief.Path, &brief.SourceCount, &embeddingData, &reportJSON, &brief.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan research brief: %w", err)
+	}
+	if embeddingData != nil {
+		embedding, err := deserializeEmbedding(embeddingData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize embedding: %w", err)
+		}
+		brief.Embedding = embedding
+	}
+	var err error
+	if brief.Summary, err = s.openText(brief.Summary); err != nil {
+		return nil, err
+	}
+	if reportJSON.Valid && reportJSON.String != "" {
+		text, err := s.openText(reportJSON.String)
+		if err != nil {
+			return nil, err
+		}
+		var report core.ResearchReport
+		if err := json.Unmarshal([]byte(text), &report); err != nil {
+			return nil, fmt.Errorf("failed to deserialize research report: %w", err)
+		}
+		brief.Report = &report
+	}
+	return &brief, nil
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"testing"
+	"time"
+)
+
+func TestResearchBriefs_SaveListDelete(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	older := &ResearchBrief{Topic: "WebGPU", Summary: "Older brief.", CreatedAt: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)}
+	if err := store.SaveResearchBrief(older); err != nil {
+		t.Fatalf("SaveResearchBrief failed: %v", err)
+	}
+	if older.ID == "" {
+		t.Fatal("expected an ID to be assigned")
+	}
+
+	newer := &ResearchBrief{
+		Topic:       "Vector databases",
+		Summary:     "Newer brief.",
+		Path:        "research/vector.md",
+		SourceCount: 7,
+		Embedding:   []float64{0.1, 0.2, 0.3},
+		Report:      &core.ResearchReport{Query: "Vector databases", Results: []core.ResearchResult{{Title: "Paper", CitationCount: 12}}},
+		CreatedAt:   time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := store.SaveResearchBrief(newer); err != nil {
+		t.Fatalf("SaveResearchBrief failed: %v", err)
+	}
+
+	if err := store.SaveResearchBrief(&ResearchBrief{Summary: "no topic"}); err == nil {
+		t.Error("expected an error for a brief without a topic")
+	}
+
+	briefs, err := store.ListResearchBriefs(0)
+	if err != nil {
+		t.Fatalf("ListResearchBriefs failed: %v", err)
+	}
+	if len(briefs) != 2 || briefs[0].ID != newer.ID {
+		t.Fatalf("expected newest brief first, got %+v", briefs)
+	}
+	if briefs[0].SourceCount != 7 || briefs[0].Path != "research/vector.md" || len(briefs[0].Embedding) != 3 {
+		t.Errorf("brief did not round-trip: %+v", briefs[0])
+	}
+	if report := briefs[0].Report; report == nil || len(report.Results) != 1 || report.Results[0].CitationCount != 12 {
+		t.Errorf("report did not round-trip: %+v", report)
+	}
+	if briefs[1].Embedding != nil || briefs[1].Report != nil {
+		t.Errorf("expected no embedding or report on the older brief, got %+v", briefs[1])
+	}
+
+	got, err := store.GetResearchBrief(older.ID)
+	if err != nil || got == nil || got.Topic != "WebGPU" {
+		t.Fatalf("GetResearchBrief = %+v, %v", got, err)
+	}
+
+	removed, err := store.DeleteResearchBrief(older.ID)
+	if err != nil || !removed {
+		t.Fatalf("DeleteResearchBrief = %v, %v", removed, err)
+	}
+	if got, _ := store.GetResearchBrief(older.ID); got != nil {
+		t.Errorf("expected brief to be deleted, got %+v", got)
+	}
+}
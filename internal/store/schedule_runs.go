@@ -0,0 +1,100 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// scheduleRunsTable records each run of a scheduled task in daemon mode, including
+// runs skipped because the previous one was still going
+const scheduleRunsTable = `
+	CREATE TABLE IF NOT EXISTS schedule_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		task TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		message TEXT NOT NULL DEFAULT '',
+		started_at DATETIME NOT NULL,
+		finished_at DATETIME
+	);
+	CREATE INDEX IF NOT EXISTS idx_schedule_runs_name ON schedule_runs (name, started_at);`
+
+// ScheduleRun is one run of a scheduled task
+type ScheduleRun struct {
+	ID         int64
+	Name       string // Schedule name from config
+	Task       string
+	Status     string // running, succeeded, failed, skipped, or interrupted
+	Message    string // Error or skip reason
+	StartedAt  time.Time
+	FinishedAt time.Time // Zero while running
+}
+
+// ScheduleRunInterrupted marks runs left running by a daemon that stopped without finishing them
+const ScheduleRunInterrupted = "interrupted"
+
+// StartScheduleRun records the start of a run and returns its ID
+func (s *Store) StartScheduleRun(name, task, status string, startedAt time.Time) (int64, error) {
+	result, err := s.db.Exec(`INSERT INTO schedule_runs (name, task, status, started_at) VALUES (?, ?, ?, ?)`,
+		name, task, status, startedAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to record run of %s: %w", name, err)
+	}
+	return result.LastInsertId()
+}
+
+// FinishScheduleRun records how a run ended
+func (s *Store) FinishScheduleRun(id int64, status, message string, finishedAt time.Time) error {
+	if _, err := s.db.Exec(`UPDATE schedule_runs SET status = ?, message = ?, finished_at = ? WHERE id = ?`,
+		status, message, finishedAt.UTC(), id); err != nil {
+		return fmt.Errorf("failed to record end of run %d: %w", id, err)
+	}
+	return nil
+}
+
+// InterruptScheduleRuns marks runs still recorded as running as interrupted, for a
+// daemon starting after one that stopped mid-run. Returns how many were marked.
+func (s *Store) InterruptScheduleRuns() (int64, error) {
+	result, err := s.db.Exec(`UPDATE schedule_runs SET status = ?, message = 'daemon stopped during the run' WHERE status = 'running'`,
+		ScheduleRunInterrupted)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark interrupted runs: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ListScheduleRuns returns recent runs, newest first, optionally for one schedule name
+func (s *Store) ListScheduleRuns(name string, limit int) ([]ScheduleRun, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	query := `SELECT id, name, task, status, message, started_at, finished_at FROM schedule_runs`
+	args := []any{}
+	if name != "" {
+		query += ` WHERE name = ?`
+		args = append(args, name)
+	}
+	query += ` ORDER BY started_at DESC, id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedule runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []ScheduleRun
+	for rows.Next() {
+		var run ScheduleRun
+		var finished sql.NullTime
+		if err := rows.Scan(&run.ID, &run.Name, &run.Task, &run.Status, &run.Message, &run.StartedAt, &finished); err != nil {
+			return nil, fmt.Errorf("failed to scan schedule run: %w", err)
+		}
+		if finished.Valid {
+			run.FinishedAt = finished.Time
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleRuns_RecordAndList(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	pull, err := store.StartScheduleRun("hourly-pull", "feed-pull", "running", start)
+	if err != nil {
+		t.Fatalf("StartScheduleRun failed: %v", err)
+	}
+	if err := store.FinishScheduleRun(pull, "failed", "feeds unreachable", start.Add(time.Minute)); err != nil {
+		t.Fatalf("FinishScheduleRun failed: %v", err)
+	}
+	if _, err := store.StartScheduleRun("weekly", "digest", "running", start.Add(time.Hour)); err != nil {
+		t.Fatalf("StartScheduleRun failed: %v", err)
+	}
+
+	interrupted, err := store.InterruptScheduleRuns()
+	if err != nil || interrupted != 1 {
+		t.Fatalf("InterruptScheduleRuns = %d, %v; want 1 run marked", interrupted, err)
+	}
+
+	runs, err := store.ListScheduleRuns("", 10)
+	if err != nil {
+		t.Fatalf("ListScheduleRuns failed: %v", err)
+	}
+	if len(runs) != 2 || runs[0].Name != "weekly" || runs[0].Status != ScheduleRunInterrupted || !runs[0].FinishedAt.IsZero() {
+		t.Fatalf("expected the interrupted digest run first, got %+v", runs)
+	}
+	if runs[1].Status != "failed" || runs[1].Message != "feeds unreachable" || !runs[1].FinishedAt.Equal(start.Add(time.Minute)) {
+		t.Errorf("unexpected finished run %+v", runs[1])
+	}
+
+	if runs, _ := store.ListScheduleRuns("hourly-pull", 10); len(runs) != 1 || runs[0].Task != "feed-pull" {
+		t.Errorf("expected runs filtered by name, got %+v", runs)
+	}
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// searchUsageTable counts billable search requests per provider per UTC day, so
+// search quotas hold across research runs
+const searchUsageTable = `
+	CREATE TABLE IF NOT EXISTS search_usage (
+		provider TEXT NOT NULL,
+		day TEXT NOT NULL,
+		calls INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (provider, day)
+	);`
+
+// usageDay is the UTC calendar day a search counts against
+func usageDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// RecordSearchUsage adds calls to a provider's count for the day of t
+func (s *Store) RecordSearchUsage(provider string, t time.Time, calls int) error {
+	_, err := s.db.Exec(`INSERT INTO search_usage (provider, day, calls) VALUES (?, ?, ?)
+		ON CONFLICT (provider, day) DO UPDATE SET calls = calls + excluded.calls`,
+		provider, usageDay(t), calls)
+	if err != nil {
+		return fmt.Errorf("failed to record %s search usage: %w", provider, err)
+	}
+	return nil
+}
+
+// SearchUsage returns a provider's request count for the day of t
+func (s *Store) SearchUsage(provider string, t time.Time) (int, error) {
+	var calls int
+	err := s.db.QueryRow(`SELECT COALESCE(SUM(calls), 0) FROM search_usage WHERE provider = ? AND day = ?`,
+		provider, usageDay(t)).Scan(&calls)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s search usage: %w", provider, err)
+	}
+	return calls, nil
+}
@@ -0,0 +1,34 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchUsage(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	day := time.Date(2025, 6, 1, 23, 0, 0, 0, time.UTC)
+	for _, calls := range []int{1, 2} {
+		if err := store.RecordSearchUsage("serpapi", day, calls); err != nil {
+			t.Fatalf("RecordSearchUsage failed: %v", err)
+		}
+	}
+	if err := store.RecordSearchUsage("serpapi", day.Add(2*time.Hour), 5); err != nil {
+		t.Fatalf("RecordSearchUsage failed: %v", err)
+	}
+
+	if used, err := store.SearchUsage("serpapi", day); err != nil || used != 3 {
+		t.Errorf("SearchUsage = %d, %v; want 3", used, err)
+	}
+	if used, _ := store.SearchUsage("serpapi", day.Add(2*time.Hour)); used != 5 {
+		t.Errorf("expected the next UTC day counted separately, got %d", used)
+	}
+	if used, _ := store.SearchUsage("google", day); used != 0 {
+		t.Errorf("expected no google usage, got %d", used)
+	}
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// articleSentimentsTable caches article sentiment by content hash and analyzer
+// version, so a digest run only analyzes articles that are new or changed, and a new
+// analyzer version starts over without clearing the old rows
+const articleSentimentsTable = `
+	CREATE TABLE IF NOT EXISTS article_sentiments (
+		content_hash TEXT NOT NULL,
+		analyzer_version TEXT NOT NULL,
+		score REAL NOT NULL,
+		label TEXT NOT NULL,
+		emoji TEXT NOT NULL,
+		analyzed_at DATETIME NOT NULL,
+		PRIMARY KEY (content_hash, analyzer_version)
+	);`
+
+// sentimentLookupChunk keeps IN (...) lookups under SQLite's bound-parameter limit
+const sentimentLookupChunk = 500
+
+// SaveArticleSentiments caches sentiments, replacing any with the same content hash
+// and analyzer version
+func (s *Store) SaveArticleSentiments(sentiments []core.ArticleSentiment) error {
+	if len(sentiments) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin sentiment transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO article_sentiments
+		(content_hash, analyzer_version, score, label, emoji, analyzed_at) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare sentiment insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, sentiment := range sentiments {
+		analyzedAt := sentiment.AnalyzedAt
+		if analyzedAt.IsZero() {
+			analyzedAt = time.Now()
+		}
+		if _, err := stmt.Exec(sentiment.ContentHash, sentiment.AnalyzerVersion, sentiment.Score,
+			sentiment.Label, sentiment.Emoji, analyzedAt.UTC()); err != nil {
+			return fmt.Errorf("failed to cache sentiment: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetArticleSentiments returns the cached sentiments for content hashes from one
+// analyzer version, keyed by content hash. Hashes without one are left out.
+func (s *Store) GetArticleSentiments(contentHashes []string, analyzerVersion string) (map[string]core.ArticleSentiment, error) {
+	sentiments := make(map[string]core.ArticleSentiment)
+	for start := 0; start < len(contentHashes); start += sentimentLookupChunk {
+		chunk := contentHashes[start:min(start+sentimentLookupChunk, len(contentHashes))]
+
+		args := make([]interface{}, 0, len(chunk)+1)
+		args = append(args, analyzerVersion)
+		for _, hash := range chunk {
+			args = append(args, hash)
+		}
+
+		rows, err := s.db.Query(`SELECT content_hash, analyzer_version, score, label, emoji, analyzed_at
+			FROM article_sentiments WHERE analyzer_version = ? AND content_hash IN (?`+strings.Repeat(", ?", len(chunk)-1)+`)`, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query sentiments: %w", err)
+		}
+		for rows.Next() {
+			var sentiment core.ArticleSentiment
+			if err := rows.Scan(&sentiment.ContentHash, &sentiment.AnalyzerVersion, &sentiment.Score,
+				&sentiment.Label, &sentiment.Emoji, &sentiment.AnalyzedAt); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan sentiment: %w", err)
+			}
+			sentiments[sentiment.ContentHash] = sentiment
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sentiments: %w", err)
+		}
+	}
+	return sentiments, nil
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"testing"
+	"time"
+)
+
+func TestArticleSentiments(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	analyzedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	err = store.SaveArticleSentiments([]core.ArticleSentiment{
+		{ContentHash: "a", AnalyzerVersion: "v1", Score: 0.6, Label: "positive", Emoji: "😊", AnalyzedAt: analyzedAt},
+		{ContentHash: "b", AnalyzerVersion: "v1", Score: -0.4, Label: "negative", Emoji: "😞", AnalyzedAt: analyzedAt},
+		{ContentHash: "a", AnalyzerVersion: "v2", Score: 0.1, Label: "neutral", Emoji: "😐", AnalyzedAt: analyzedAt},
+	})
+	if err != nil {
+		t.Fatalf("SaveArticleSentiments failed: %v", err)
+	}
+
+	cached, err := store.GetArticleSentiments([]string{"a", "b", "missing"}, "v1")
+	if err != nil {
+		t.Fatalf("GetArticleSentiments failed: %v", err)
+	}
+	if len(cached) != 2 {
+		t.Fatalf("expected 2 cached sentiments, got %+v", cached)
+	}
+	if cached["a"].Label != "positive" || cached["b"].Score != -0.4 || !cached["a"].AnalyzedAt.Equal(analyzedAt) {
+		t.Errorf("unexpected cached sentiments %+v", cached)
+	}
+
+	// Another analyzer version has its own rows
+	cached, err = store.GetArticleSentiments([]string{"a", "b"}, "v2")
+	if err != nil {
+		t.Fatalf("GetArticleSentiments failed: %v", err)
+	}
+	if len(cached) != 1 || cached["a"].Label != "neutral" {
+		t.Errorf("expected only the v2 sentiment, got %+v", cached)
+	}
+
+	if cached, err := store.GetArticleSentiments(nil, "v1"); err != nil || len(cached) != 0 {
+		t.Errorf("expected no sentiments for no hashes, got %+v, %v", cached, err)
+	}
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SeriesIssue is one published issue of a named digest series. Issues live in the
+// digests table, tagged with their series, so each series keeps its own history of
+// topics and my-takes.
+type SeriesIssue struct {
+	DigestID      string
+	Series        string
+	Title         string
+	Content       string // Rendered digest as delivered
+	Format        string
+	MyTake        string
+	Topics        []string // Topic keywords, compared against later issues for trends
+	ArticleURLs   []string
+	DateGenerated time.Time
+}
+
+// migrateSeriesColumns adds the series and topics columns to the digests table
+func (s *Store) migrateSeriesColumns() error {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('digests') WHERE name='series'").Scan(&count); err != nil {
+		return fmt.Errorf("failed to check digests schema for series: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if _, err := s.db.Exec("ALTER TABLE digests ADD COLUMN series TEXT DEFAULT ''"); err != nil {
+		return fmt.Errorf("failed to add series column to digests: %w", err)
+	}
+	if _, err := s.db.Exec("ALTER TABLE digests ADD COLUMN topics TEXT DEFAULT '[]'"); err != nil {
+		return fmt.Errorf("failed to add topics column to digests: %w", err)
+	}
+	if _, err := s.db.Exec("CREATE INDEX IF NOT EXISTS idx_digests_series ON digests (series, date_generated)"); err != nil {
+		return fmt.Errorf("failed to index digests by series: %w", err)
+	}
+	return nil
+}
+
+// SaveSeriesIssue records an issue of a series
+func (s *Store) SaveSeriesIssue(issue SeriesIssue) error {
+	if issue.Series == "" {
+		return fmt.Errorf("series issue requires a series")
+	}
+	if issue.DateGenerated.IsZero() {
+		issue.DateGenerated = time.Now().UTC()
+	}
+
+	topicsJSON, _ := json.Marshal(issue.Topics)
+	urlsJSON, _ := json.Marshal(issue.ArticleURLs)
+	content, err := s.sealText(issue.Content)
+	if err != nil {
+		return err
+	}
+	myTake, err := s.sealText(issue.MyTake)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	INSERT OR REPLACE INTO digests
+	(id, title, content, digest_summary, my_take, format, article_urls, date_generated, model_used, series, topics)
+	VALUES (?, ?, ?, '', ?, ?, ?, ?, '', ?, ?)`
+
+	_, err = s.db.Exec(query,
+		issue.DigestID,
+		issue.Title,
+		content,
+		myTake,
+		issue.Format,
+		string(urlsJSON),
+		issue.DateGenerated,
+		issue.Series,
+		string(topicsJSON),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save series issue: %w", err)
+	}
+	return nil
+}
+
+// GetSeriesIssues returns the most recent issues of a series, newest first
+func (s *Store) GetSeriesIssues(series string, limit int) ([]SeriesIssue, error) {
+	query := `
+	SELECT id, series, title, content, format, my_take, topics, article_urls, date_generated
+	FROM digests
+	WHERE series = ?
+	ORDER BY date_generated DESC
+	LIMIT ?`
+
+	rows, err := s.db.Query(query, series, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var issues []SeriesIssue
+	for rows.Next() {
+		var issue SeriesIssue
+		var format, myTake, topicsJSON, urlsJSON sql.NullString
+		if err := rows.Scan(&issue.DigestID, &issue.Series, &issue.Title, &issue.Content, &format, &myTake, &topicsJSON, &urlsJSON, &issue.DateGenerated); err != nil {
+			return nil, fmt.Errorf("failed to scan series issue: %w", err)
+		}
+		var err error
+		if issue.Content, err = s.openText(issue.Content); err != nil {
+			return nil, err
+		}
+		issue.Format = format.String
+		if issue.MyTake, err = s.openText(myTake.String); err != nil {
+			return nil, err
+		}
+		_ = json.Unmarshal([]byte(topicsJSON.String), &issue.Topics)
+		_ = json.Unmarshal([]byte(urlsJSON.String), &issue.ArticleURLs)
+		issues = append(issues, issue)
+	}
+
+	return issues, rows.Err()
+}
+
+// CountSeriesIssues returns how many issues of a series have been recorded
+func (s *Store) CountSeriesIssues(series string) (int, error) {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM digests WHERE series = ?", series).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count series issues: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeriesIssues_ScopedBySeries(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	issues := []SeriesIssue{
+		{DigestID: "a1", Series: "ai-weekly", Title: "AI #1", Topics: []string{"agents"}, DateGenerated: base},
+		{DigestID: "p1", Series: "platform-notes", Title: "Platform #1", Topics: []string{"postgres"}, DateGenerated: base},
+		{DigestID: "a2", Series: "ai-weekly", Title: "AI #2", Topics: []string{"agents", "evals"}, DateGenerated: base.AddDate(0, 0, 7)},
+	}
+	for _, issue := range issues {
+		if err := store.SaveSeriesIssue(issue); err != nil {
+			t.Fatalf("SaveSeriesIssue failed: %v", err)
+		}
+	}
+	if err := store.UpdateDigestMyTake("a2", "Evals matter more than models"); err != nil {
+		t.Fatalf("UpdateDigestMyTake failed: %v", err)
+	}
+
+	got, err := store.GetSeriesIssues("ai-weekly", 10)
+	if err != nil {
+		t.Fatalf("GetSeriesIssues failed: %v", err)
+	}
+	if len(got) != 2 || got[0].DigestID != "a2" || got[1].DigestID != "a1" {
+		t.Fatalf("expected ai-weekly issues newest first, got %+v", got)
+	}
+	if got[0].MyTake != "Evals matter more than models" || len(got[0].Topics) != 2 {
+		t.Errorf("unexpected latest issue: %+v", got[0])
+	}
+
+	count, err := store.CountSeriesIssues("platform-notes")
+	if err != nil || count != 1 {
+		t.Errorf("CountSeriesIssues = %d, %v; want 1", count, err)
+	}
+
+	if err := store.SaveSeriesIssue(SeriesIssue{DigestID: "x"}); err == nil {
+		t.Error("expected error for issue without a series")
+	}
+}
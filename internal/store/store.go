@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -16,12 +17,27 @@ import (
 
 // Store represents the SQLite-based caching store
 type Store struct {
-	db   *sql.DB
-	path string
+	db     *sql.DB
+	path   string
+	cipher *contentCipher // Encrypts content at rest; nil writes plaintext
+	locked bool           // Content is encrypted but no key was given
+
+	fallback error // Why OpenStore fell back to memory; nil for the on-disk cache
 }
 
-// NewStore creates a new store instance with SQLite database
+// NewStore creates a new store instance with SQLite database. Content is encrypted
+// at rest with the key from BRIEFLY_CACHE_KEY or BRIEFLY_CACHE_KEY_COMMAND, if set.
 func NewStore(dataDir string) (*Store, error) {
+	key, err := LoadCacheKey()
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreWithKey(dataDir, key)
+}
+
+// NewStoreWithKey creates a store that encrypts content with key ("" = no
+// encryption). A cache encrypted with another key fails to open.
+func NewStoreWithKey(dataDir, key string) (*Store, error) {
 	// Ensure data directory exists
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
@@ -43,6 +59,11 @@ func NewStore(dataDir string) (*Store, error) {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
+	if err := store.setupEncryption(key); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
 	return store, nil
 }
 
@@ -78,6 +99,10 @@ func (s *Store) initialize() error {
 		embedding BLOB,
 		topic_cluster TEXT,
 		topic_confidence REAL,
+		facts TEXT DEFAULT '',
+		quality_score REAL DEFAULT 0,
+		quality_issues TEXT DEFAULT '',
+		quality_flagged INTEGER DEFAULT 0,
 		FOREIGN KEY (article_url) REFERENCES articles (url)
 	);`
 
@@ -126,7 +151,7 @@ func (s *Store) initialize() error {
 		FOREIGN KEY (feed_id) REFERENCES feeds (id)
 	);`
 
-	tables := []string{articlesTable, summariesTable, digestsTable, feedsTable, feedItemsTable}
+	tables := []string{articlesTable, summariesTable, digestsTable, feedsTable, feedItemsTable, archiveTable, readStatusTable, researchBriefsTable, searchUsageTable, articleSentimentsTable, digestCommentsTable, digestMessagesTable, storeMetaTable, redactionsTable, scheduleRunsTable, priorityAlertsTable, priorityFlagsTable, topicPagesTable, trendReportsTable, myTakesTable, graduatedTopicsTable, tenantUsageTable}
 	for _, table := range tables {
 		if _, err := s.db.Exec(table); err != nil {
 			return fmt.Errorf("failed to create table: %w", err)
@@ -270,6 +295,26 @@ func (s *Store) runMigrations() error {
 		}
 	}
 
+	// Add series columns to digests table if they don't exist
+	if err := s.migrateSeriesColumns(); err != nil {
+		return err
+	}
+
+	// Add the report column to research_briefs if it doesn't exist
+	if err := s.migrateResearchBriefColumns(); err != nil {
+		return err
+	}
+
+	// Add the fact sheet column to summaries if it doesn't exist
+	if err := s.migrateSummaryFactsColumn(); err != nil {
+		return err
+	}
+
+	// Add the quality columns to summaries if they don't exist
+	if err := s.migrateSummaryQualityColumns(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -283,12 +328,27 @@ func (s *Store) DB() *sql.DB {
 	return s.db
 }
 
-// CacheArticle stores an article in the cache
+// CacheArticle stores an article in the cache. Content is compressed into the
+// content archive; the articles row itself only carries metadata.
 func (s *Store) CacheArticle(article core.Article) error {
-	metadata, _ := json.Marshal(map[string]interface{}{
-		"link_id": article.LinkID,
+	metadata, _ := json.Marshal(articleMetadata{
+		LinkID:        article.LinkID,
+		Images:        article.Images,
+		DatePublished: article.DatePublished,
+		Author:        article.Author,
+		SiteName:      article.SiteName,
+		HeroImage:     article.HeroImage,
+		CanonicalURL:  article.CanonicalURL,
+		LinkTitle:     article.LinkTitle,
+		PageTitle:     article.PageTitle,
+		Transcript:    article.Transcript,
 	})
 
+	myTake, err := s.sealText(article.MyTake)
+	if err != nil {
+		return err
+	}
+
 	// Serialize embedding
 	embeddingData, err := serializeEmbedding(article.Embedding)
 	if err != nil {
@@ -305,12 +365,18 @@ func (s *Store) CacheArticle(article core.Article) error {
 	 sentiment_score, sentiment_label, sentiment_emoji, alert_triggered, alert_conditions, research_queries)
 	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err = s.db.Exec(query,
-		article.LinkID, // Use LinkID as URL identifier
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(query,
+		articleCacheKey(article),
 		article.Title,
-		article.CleanedText,
-		article.FetchedHTML,
-		article.MyTake,
+		"", // content lives in content_archive
+		"",
+		myTake,
 		article.DateFetched,
 		generateContentHash(article.CleanedText),
 		string(metadata),
@@ -324,8 +390,57 @@ func (s *Store) CacheArticle(article core.Article) error {
 		string(alertConditionsJSON),
 		string(researchQueriesJSON),
 	)
+	if err != nil {
+		return fmt.Errorf("failed to cache article: %w", err)
+	}
 
-	return err
+	if err := s.archiveArticle(tx, articleCacheKey(article), article); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// articleMetadata is the JSON kept in the articles.metadata column
+type articleMetadata struct {
+	LinkID        string                   `json:"link_id"`
+	Images        []core.ArticleImage      `json:"images,omitempty"`
+	DatePublished time.Time                `json:"date_published,omitzero"`
+	Author        string                   `json:"author,omitempty"`
+	SiteName      string                   `json:"site_name,omitempty"`
+	HeroImage     string                   `json:"hero_image,omitempty"`
+	CanonicalURL  string                   `json:"canonical_url,omitempty"`
+	LinkTitle     string                   `json:"link_title,omitempty"`
+	PageTitle     string                   `json:"page_title,omitempty"`
+	Transcript    []core.TranscriptSegment `json:"transcript,omitempty"`
+}
+
+// applyArticleMetadata restores fields kept in the articles.metadata column
+func applyArticleMetadata(article *core.Article, metadata string) {
+	if metadata == "" {
+		return
+	}
+	var meta articleMetadata
+	if err := json.Unmarshal([]byte(metadata), &meta); err == nil {
+		article.Images = meta.Images
+		article.DatePublished = meta.DatePublished
+		article.Author = meta.Author
+		article.SiteName = meta.SiteName
+		article.HeroImage = meta.HeroImage
+		article.CanonicalURL = meta.CanonicalURL
+		article.LinkTitle = meta.LinkTitle
+		article.PageTitle = meta.PageTitle
+		article.Transcript = meta.Transcript
+	}
+}
+
+// articleCacheKey returns the value stored in the articles.url column. Legacy
+// callers set LinkID; articles from the content processor only carry URL.
+func articleCacheKey(article core.Article) string {
+	if article.LinkID != "" {
+		return article.LinkID
+	}
+	return article.URL
 }
 
 // GetCachedArticle retrieves an article from the cache
@@ -415,9 +530,22 @@ func (s *Store) GetCachedArticle(url string, maxAge time.Duration) (*core.Articl
 	}
 
 	article.DateFetched = dateFetched
+	applyArticleMetadata(&article, metadata)
+	if article.URL == "" && strings.HasPrefix(article.LinkID, "http") {
+		article.URL = article.LinkID
+	}
+	if err := s.hydrateArticle(&article); err != nil {
+		return nil, err
+	}
 	return &article, nil
 }
 
+// SummaryContentHash is the content hash summaries are cached under for an article,
+// so a summary is only reused while the article text is unchanged
+func SummaryContentHash(article core.Article) string {
+	return fmt.Sprintf("%x", article.CleanedText)
+}
+
 // CacheSummary stores a summary in the cache
 func (s *Store) CacheSummary(summary core.Summary, articleURL string, contentHash string) error {
 	// Serialize embedding
@@ -428,18 +556,32 @@ func (s *Store) CacheSummary(summary core.Summary, articleURL string, contentHas
 
 	query := `
 	INSERT OR REPLACE INTO summaries 
-	(id, article_url, summary_text, key_insights, action_items, model_used, date_generated, content_hash, embedding, topic_cluster, topic_confidence)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	(id, article_url, summary_text, key_insights, action_items, model_used, date_generated, content_hash, embedding, topic_cluster, topic_confidence, facts, quality_score, quality_issues, quality_flagged)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	// Convert ArticleIDs to JSON for key_insights field (reusing the field for article references)
 	articleIDs, _ := json.Marshal(summary.ArticleIDs)
 	// Use Instructions as action_items (reusing the field)
 	instructions := summary.Instructions
+	qualityIssues := ""
+	if len(summary.QualityIssues) > 0 {
+		data, _ := json.Marshal(summary.QualityIssues)
+		qualityIssues = string(data)
+	}
+
+	summaryText, err := s.sealText(summary.SummaryText)
+	if err != nil {
+		return err
+	}
+	facts, err := s.sealFactSheet(summary.Facts)
+	if err != nil {
+		return err
+	}
 
 	_, err = s.db.Exec(query,
 		summary.ID,
 		articleURL,
-		summary.SummaryText,
+		summaryText,
 		string(articleIDs), // Store ArticleIDs in key_insights field
 		instructions,       // Store Instructions in action_items field
 		summary.ModelUsed,
@@ -448,6 +590,10 @@ func (s *Store) CacheSummary(summary core.Summary, articleURL string, contentHas
 		embeddingData,
 		summary.TopicCluster,
 		summary.TopicConfidence,
+		facts,
+		summary.QualityScore,
+		qualityIssues,
+		summary.QualityFlagged,
 	)
 
 	return err
@@ -456,7 +602,8 @@ func (s *Store) CacheSummary(summary core.Summary, articleURL string, contentHas
 // GetCachedSummary retrieves a summary from the cache
 func (s *Store) GetCachedSummary(articleURL string, contentHash string, maxAge time.Duration) (*core.Summary, error) {
 	query := `
-	SELECT id, summary_text, key_insights, action_items, model_used, date_generated, embedding, topic_cluster, topic_confidence
+	SELECT id, summary_text, key_insights, action_items, model_used, date_generated, embedding, topic_cluster, topic_confidence, COALESCE(facts, ''),
+		COALESCE(quality_score, 0), COALESCE(quality_issues, ''), COALESCE(quality_flagged, 0)
 	FROM summaries 
 	WHERE article_url = ? AND content_hash = ? AND date_generated > ?`
 
@@ -464,7 +611,7 @@ func (s *Store) GetCachedSummary(articleURL string, contentHash string, maxAge t
 	row := s.db.QueryRow(query, articleURL, contentHash, cutoff)
 
 	var summary core.Summary
-	var articleIDsJSON, instructions string
+	var articleIDsJSON, instructions, facts, qualityIssues string
 	var embeddingData []byte
 	var topicCluster sql.NullString
 	var topicConfidence sql.NullFloat64
@@ -479,6 +626,10 @@ func (s *Store) GetCachedSummary(articleURL string, contentHash string, maxAge t
 		&embeddingData,
 		&topicCluster,
 		&topicConfidence,
+		&facts,
+		&summary.QualityScore,
+		&qualityIssues,
+		&summary.QualityFlagged,
 	)
 
 	if err == sql.ErrNoRows {
@@ -504,23 +655,54 @@ func (s *Store) GetCachedSummary(articleURL string, contentHash string, maxAge t
 		summary.TopicConfidence = topicConfidence.Float64
 	}
 
+	if summary.SummaryText, err = s.openText(summary.SummaryText); err != nil {
+		return nil, err
+	}
+	if summary.Facts, err = s.openFactSheet(facts); err != nil {
+		return nil, err
+	}
+
 	// Unmarshal JSON fields
 	_ = json.Unmarshal([]byte(articleIDsJSON), &summary.ArticleIDs)
 	summary.Instructions = instructions
+	if qualityIssues != "" {
+		_ = json.Unmarshal([]byte(qualityIssues), &summary.QualityIssues)
+	}
 
 	return &summary, nil
 }
 
+// GetLatestSummaryText returns the text of an article's newest cached summary,
+// whatever its age or the article text it was made from, or "" when there is none
+func (s *Store) GetLatestSummaryText(articleURL string) (string, error) {
+	var text string
+	err := s.db.QueryRow(`SELECT summary_text FROM summaries WHERE article_url = ? ORDER BY date_generated DESC LIMIT 1`, articleURL).Scan(&text)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read summary for %s: %w", articleURL, err)
+	}
+	return s.openText(text)
+}
+
 // CacheDigest stores a generated digest
 func (s *Store) CacheDigest(digestID, title, content, digestSummary string, articleURLs []string, modelUsed string) error {
 	urlsJSON, _ := json.Marshal(articleURLs)
+	content, err := s.sealText(content)
+	if err != nil {
+		return err
+	}
+	if digestSummary, err = s.sealText(digestSummary); err != nil {
+		return err
+	}
 
 	query := `
 	INSERT OR REPLACE INTO digests 
 	(id, title, content, digest_summary, my_take, format, article_urls, date_generated, model_used)
 	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := s.db.Exec(query,
+	_, err = s.db.Exec(query,
 		digestID,
 		title,
 		content,
@@ -538,6 +720,13 @@ func (s *Store) CacheDigest(digestID, title, content, digestSummary string, arti
 // CacheDigestWithFormat stores a generated digest with format
 func (s *Store) CacheDigestWithFormat(digestID, title, content, digestSummary, format string, articleURLs []string, modelUsed string) error {
 	urlsJSON, _ := json.Marshal(articleURLs)
+	content, err := s.sealText(content)
+	if err != nil {
+		return err
+	}
+	if digestSummary, err = s.sealText(digestSummary); err != nil {
+		return err
+	}
 
 	query := `
 	INSERT OR REPLACE INTO digests 
@@ -545,7 +734,7 @@ func (s *Store) CacheDigestWithFormat(digestID, title, content, digestSummary, f
 	 overall_sentiment, alerts_summary, trends_summary, research_suggestions)
 	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := s.db.Exec(query,
+	_, err = s.db.Exec(query,
 		digestID,
 		title,
 		content,
@@ -602,6 +791,10 @@ func (s *Store) GetCachedDigest(digestID string) (*core.Digest, error) {
 		return nil, fmt.Errorf("failed to get cached digest: %w", err)
 	}
 
+	if err := s.openDigest(&digest); err != nil {
+		return nil, err
+	}
+
 	// Parse article URLs
 	_ = json.Unmarshal([]byte(urlsJSON), &digest.ArticleURLs)
 
@@ -622,10 +815,27 @@ func (s *Store) GetCachedDigest(digestID string) (*core.Digest, error) {
 	return &digest, nil
 }
 
+// openDigest decrypts a digest's content, summary, and take
+func (s *Store) openDigest(digest *core.Digest) error {
+	var err error
+	if digest.Content, err = s.openText(digest.Content); err != nil {
+		return err
+	}
+	if digest.DigestSummary, err = s.openText(digest.DigestSummary); err != nil {
+		return err
+	}
+	digest.MyTake, err = s.openText(digest.MyTake)
+	return err
+}
+
 // UpdateDigestMyTake updates the my_take field for a digest
 func (s *Store) UpdateDigestMyTake(digestID, myTake string) error {
+	myTake, err := s.sealText(myTake)
+	if err != nil {
+		return err
+	}
 	query := `UPDATE digests SET my_take = ? WHERE id = ?`
-	_, err := s.db.Exec(query, myTake, digestID)
+	_, err = s.db.Exec(query, myTake, digestID)
 	return err
 }
 
@@ -672,6 +882,10 @@ func (s *Store) GetLatestDigests(limit int) ([]core.Digest, error) {
 			return nil, fmt.Errorf("failed to scan digest row: %w", err)
 		}
 
+		if err := s.openDigest(&digest); err != nil {
+			return nil, err
+		}
+
 		// Parse article URLs
 		_ = json.Unmarshal([]byte(urlsJSON), &digest.ArticleURLs)
 
@@ -745,6 +959,10 @@ func (s *Store) FindDigestByPartialID(partialID string) (*core.Digest, error) {
 		return nil, fmt.Errorf("failed to find digest by partial ID: %w", err)
 	}
 
+	if err := s.openDigest(&foundDigest); err != nil {
+		return nil, err
+	}
+
 	// Parse article URLs
 	_ = json.Unmarshal([]byte(urlsJSON), &foundDigest.ArticleURLs)
 
@@ -1107,9 +1325,27 @@ func (s *Store) GetCacheStats() (*CacheStats, error) {
 	return stats, nil
 }
 
+// CacheGrowth counts what was added to the cache in a period
+type CacheGrowth struct {
+	Articles  int // Articles fetched (or refetched) in the period
+	Summaries int // Summaries generated in the period
+}
+
+// GetCacheGrowth counts the articles and summaries cached since the given time
+func (s *Store) GetCacheGrowth(since time.Time) (*CacheGrowth, error) {
+	growth := &CacheGrowth{}
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM articles WHERE date_fetched >= ?", since).Scan(&growth.Articles); err != nil {
+		return nil, fmt.Errorf("failed to count new articles: %w", err)
+	}
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM summaries WHERE date_generated >= ?", since).Scan(&growth.Summaries); err != nil {
+		return nil, fmt.Errorf("failed to count new summaries: %w", err)
+	}
+	return growth, nil
+}
+
 // ClearCache removes all cached data
 func (s *Store) ClearCache() error {
-	tables := []string{"articles", "summaries", "digests", "feed_items", "feeds"}
+	tables := []string{"articles", "summaries", "digests", "feed_items", "feeds", "content_archive"}
 
 	for _, table := range tables {
 		_, err := s.db.Exec(fmt.Sprintf("DELETE FROM %s", table))
@@ -1143,6 +1379,12 @@ func (s *Store) CleanupOldCache(articleMaxAge, summaryMaxAge time.Duration) erro
 		return fmt.Errorf("failed to clean old summaries: %w", err)
 	}
 
+	// Archived content goes with its article
+	_, err = s.db.Exec("DELETE FROM content_archive WHERE url NOT IN (SELECT url FROM articles)")
+	if err != nil {
+		return fmt.Errorf("failed to clean orphaned archive content: %w", err)
+	}
+
 	return nil
 }
 
@@ -1281,8 +1523,19 @@ func (s *Store) GetRecentArticles(days int) ([]core.Article, error) {
 		}
 
 		article.DateFetched = dateFetched
+		applyArticleMetadata(&article, metadata)
+		if article.URL == "" && strings.HasPrefix(article.LinkID, "http") {
+			article.URL = article.LinkID
+		}
 		articles = append(articles, article)
 	}
+	_ = rows.Close()
+
+	for i := range articles {
+		if err := s.hydrateArticle(&articles[i]); err != nil {
+			return nil, err
+		}
+	}
 
 	return articles, nil
 }
@@ -1373,6 +1626,13 @@ func (s *Store) GetArticleByURL(url string) (*core.Article, error) {
 	}
 
 	article.DateFetched = dateFetched
+	applyArticleMetadata(&article, metadata)
+	if article.URL == "" && strings.HasPrefix(article.LinkID, "http") {
+		article.URL = article.LinkID
+	}
+	if err := s.hydrateArticle(&article); err != nil {
+		return nil, err
+	}
 	return &article, nil
 }
 
@@ -1470,8 +1730,19 @@ func (s *Store) GetArticlesByDateRange(startDate, endDate time.Time) ([]core.Art
 		}
 
 		article.DateFetched = dateFetched
+		applyArticleMetadata(&article, metadata)
+		if article.URL == "" && strings.HasPrefix(article.LinkID, "http") {
+			article.URL = article.LinkID
+		}
 		articles = append(articles, article)
 	}
+	_ = rows.Close()
+
+	for i := range articles {
+		if err := s.hydrateArticle(&articles[i]); err != nil {
+			return nil, err
+		}
+	}
 
 	return articles, nil
 }
@@ -56,6 +56,9 @@ func TestCacheArticle_GetCachedArticle(t *testing.T) {
 		ID:              uuid.NewString(),
 		LinkID:          "test-link-id",
 		Title:           "Test Article",
+		LinkTitle:       "Test Article",
+		PageTitle:       "Test Article | Example Blog",
+		Transcript:      []core.TranscriptSegment{{Start: 0, Text: "intro"}, {Start: 273, Text: "the key point"}},
 		CleanedText:     "This is a test article content.",
 		FetchedHTML:     "<html><body>Test content</body></html>",
 		MyTake:          "My personal thoughts",
@@ -94,6 +97,12 @@ func TestCacheArticle_GetCachedArticle(t *testing.T) {
 	if cachedArticle.Title != article.Title {
 		t.Errorf("Expected title %s, got %s", article.Title, cachedArticle.Title)
 	}
+	if cachedArticle.LinkTitle != article.LinkTitle || cachedArticle.PageTitle != article.PageTitle {
+		t.Errorf("Expected link/page titles %q/%q, got %q/%q", article.LinkTitle, article.PageTitle, cachedArticle.LinkTitle, cachedArticle.PageTitle)
+	}
+	if len(cachedArticle.Transcript) != 2 || cachedArticle.Transcript[1].Start != 273 {
+		t.Errorf("Expected transcript to round-trip, got %+v", cachedArticle.Transcript)
+	}
 	if cachedArticle.CleanedText != article.CleanedText {
 		t.Errorf("Expected content %s, got %s", article.CleanedText, cachedArticle.CleanedText)
 	}
@@ -247,6 +256,30 @@ func TestCacheSummary_GetCachedSummary(t *testing.T) {
 	}
 }
 
+func TestGetLatestSummaryText(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	articleURL := "https://example.com/a"
+	if text, err := store.GetLatestSummaryText(articleURL); err != nil || text != "" {
+		t.Fatalf("GetLatestSummaryText without summaries = %q, %v", text, err)
+	}
+
+	generated := time.Now().UTC().Add(-90 * 24 * time.Hour)
+	for i, text := range []string{"Older summary.", "Newer summary."} {
+		summary := core.Summary{ID: uuid.NewString(), SummaryText: text, DateGenerated: generated.Add(time.Duration(i) * time.Hour)}
+		if err := store.CacheSummary(summary, articleURL, fmt.Sprintf("hash-%d", i)); err != nil {
+			t.Fatalf("CacheSummary failed: %v", err)
+		}
+	}
+	if text, err := store.GetLatestSummaryText(articleURL); err != nil || text != "Newer summary." {
+		t.Errorf("GetLatestSummaryText = %q, %v; want the newest summary", text, err)
+	}
+}
+
 func TestGetCachedSummary_CacheMiss(t *testing.T) {
 	tmpDir := t.TempDir()
 	store, err := NewStore(tmpDir)
@@ -501,6 +534,29 @@ func TestGetCacheStats(t *testing.T) {
 	}
 }
 
+func TestGetCacheGrowth(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	now := time.Now().UTC()
+	for url, fetched := range map[string]time.Time{"old-url": now.AddDate(0, 0, -10), "new-url": now.Add(-time.Hour)} {
+		if err := store.CacheArticle(core.Article{ID: uuid.NewString(), LinkID: url, CleanedText: "Content", DateFetched: fetched}); err != nil {
+			t.Fatalf("CacheArticle failed: %v", err)
+		}
+	}
+
+	growth, err := store.GetCacheGrowth(now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatalf("GetCacheGrowth failed: %v", err)
+	}
+	if growth.Articles != 1 || growth.Summaries != 0 {
+		t.Errorf("expected 1 new article and no summaries, got %+v", growth)
+	}
+}
+
 func TestClearCache(t *testing.T) {
 	tmpDir := t.TempDir()
 	store, err := NewStore(tmpDir)
@@ -845,3 +901,36 @@ func TestGetArticlesByDateRange(t *testing.T) {
 		t.Errorf("Expected in-range article, got %s", articles[0].Title)
 	}
 }
+
+func TestCacheArticle_URLOnlyArticle(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStore(tmpDir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	now := time.Now().UTC()
+	for _, u := range []string{"https://example.com/a", "https://example.com/b"} {
+		article := core.Article{URL: u, Title: u, CleanedText: "content", DateFetched: now}
+		if err := store.CacheArticle(article); err != nil {
+			t.Fatalf("CacheArticle failed: %v", err)
+		}
+	}
+
+	cached, err := store.GetCachedArticle("https://example.com/a", time.Hour)
+	if err != nil || cached == nil {
+		t.Fatalf("Expected cache hit for URL-only article, got %v, %v", cached, err)
+	}
+	if cached.URL != "https://example.com/a" {
+		t.Errorf("Expected URL to be restored, got %q", cached.URL)
+	}
+
+	articles, err := store.GetArticlesByDateRange(now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetArticlesByDateRange failed: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Errorf("Expected 2 distinct articles, got %d", len(articles))
+	}
+}
@@ -0,0 +1,26 @@
+package store
+
+import "fmt"
+
+// migrateSummaryQualityColumns adds the quality columns to summaries, so a summary
+// flagged for review is still flagged when it is served from the cache
+func (s *Store) migrateSummaryQualityColumns() error {
+	columns := []struct{ name, definition string }{
+		{"quality_score", "REAL DEFAULT 0"},
+		{"quality_issues", "TEXT DEFAULT ''"},
+		{"quality_flagged", "INTEGER DEFAULT 0"},
+	}
+	for _, column := range columns {
+		var count int
+		if err := s.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('summaries') WHERE name=?", column.name).Scan(&count); err != nil {
+			return fmt.Errorf("failed to check summaries schema for %s: %w", column.name, err)
+		}
+		if count > 0 {
+			continue
+		}
+		if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE summaries ADD COLUMN %s %s", column.name, column.definition)); err != nil {
+			return fmt.Errorf("failed to add %s column to summaries: %w", column.name, err)
+		}
+	}
+	return nil
+}
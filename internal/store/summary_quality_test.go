@@ -0,0 +1,77 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"testing"
+	"time"
+)
+
+func TestCacheSummary_Quality(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	flagged := core.Summary{
+		ID:             "s1",
+		SummaryText:    "Vague summary",
+		DateGenerated:  time.Now().UTC(),
+		QualityScore:   0.42,
+		QualityIssues:  []string{"no specific numbers", "generic phrasing"},
+		QualityFlagged: true,
+	}
+	passed := core.Summary{ID: "s2", SummaryText: "Good summary", DateGenerated: time.Now().UTC(), QualityScore: 0.9}
+	if err := store.CacheSummary(flagged, "https://example.com/a", "hash-a"); err != nil {
+		t.Fatalf("CacheSummary: %v", err)
+	}
+	if err := store.CacheSummary(passed, "https://example.com/b", "hash-b"); err != nil {
+		t.Fatalf("CacheSummary: %v", err)
+	}
+	_ = store.Close()
+
+	// Reopen so the summaries are read back from disk, as a later run would
+	store, err = NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	cached, err := store.GetCachedSummary("https://example.com/a", "hash-a", time.Hour)
+	if err != nil || cached == nil {
+		t.Fatalf("GetCachedSummary = %v, %v", cached, err)
+	}
+	if !cached.QualityFlagged || cached.QualityScore != 0.42 || len(cached.QualityIssues) != 2 || cached.QualityIssues[0] != "no specific numbers" {
+		t.Errorf("expected the quality flag, score, and issues read back, got %+v", cached)
+	}
+
+	cached, err = store.GetCachedSummary("https://example.com/b", "hash-b", time.Hour)
+	if err != nil || cached == nil {
+		t.Fatalf("GetCachedSummary = %v, %v", cached, err)
+	}
+	if cached.QualityFlagged || cached.QualityScore != 0.9 || cached.QualityIssues != nil {
+		t.Errorf("expected an unflagged summary, got %+v", cached)
+	}
+}
+
+func TestMigrateSummaryQualityColumns(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	// A cache from before the quality columns existed
+	if _, err := store.db.Exec("ALTER TABLE summaries DROP COLUMN quality_flagged"); err != nil {
+		t.Fatalf("failed to drop column: %v", err)
+	}
+	if err := store.migrateSummaryQualityColumns(); err != nil {
+		t.Fatalf("migrateSummaryQualityColumns: %v", err)
+	}
+	if err := store.migrateSummaryQualityColumns(); err != nil {
+		t.Fatalf("expected the migration to be idempotent, got %v", err)
+	}
+	if err := store.CacheSummary(core.Summary{ID: "s1", QualityFlagged: true, DateGenerated: time.Now().UTC()}, "https://example.com/a", "h"); err != nil {
+		t.Fatalf("CacheSummary after migration: %v", err)
+	}
+}
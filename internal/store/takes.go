@@ -0,0 +1,73 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// myTakesTable holds takes written outside Briefly (e.g. imported with
+// 'briefly my-take import'), keyed by the article URL or digest ID they are about.
+// They live apart from the articles row so re-caching an article keeps its take.
+const myTakesTable = `
+	CREATE TABLE IF NOT EXISTS my_takes (
+		kind TEXT NOT NULL,
+		target TEXT NOT NULL,
+		take TEXT NOT NULL,
+		source TEXT NOT NULL DEFAULT '',
+		updated_at DATETIME NOT NULL,
+		PRIMARY KEY (kind, target)
+	);`
+
+// Kinds of take
+const (
+	TakeArticle = "article" // Target is the article URL
+	TakeDigest  = "digest"  // Target is the digest ID
+)
+
+// MyTake is your commentary on an article or a digest
+type MyTake struct {
+	Kind      string
+	Target    string
+	Take      string
+	Source    string // Where it came from, e.g. the imported file
+	UpdatedAt time.Time
+}
+
+// SaveMyTake stores a take, replacing any earlier take on the same target
+func (s *Store) SaveMyTake(take MyTake) error {
+	if take.Target == "" {
+		return fmt.Errorf("take has no article URL or digest ID")
+	}
+	if take.Kind != TakeArticle && take.Kind != TakeDigest {
+		return fmt.Errorf("unknown take kind %q", take.Kind)
+	}
+	if take.UpdatedAt.IsZero() {
+		take.UpdatedAt = time.Now()
+	}
+	text, err := s.sealText(take.Take)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO my_takes (kind, target, take, source, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(kind, target) DO UPDATE SET take = excluded.take, source = excluded.source, updated_at = excluded.updated_at`,
+		take.Kind, take.Target, text, take.Source, take.UpdatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save take on %s: %w", take.Target, err)
+	}
+	return nil
+}
+
+// GetMyTake returns the take on a target, or "" when there is none
+func (s *Store) GetMyTake(kind, target string) (string, error) {
+	var take string
+	err := s.db.QueryRow(`SELECT take FROM my_takes WHERE kind = ? AND target = ?`, kind, target).Scan(&take)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read take on %s: %w", target, err)
+	}
+	return s.openText(take)
+}
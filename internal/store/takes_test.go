@@ -0,0 +1,45 @@
+package store
+
+import (
+	"briefly/internal/core"
+	"testing"
+	"time"
+)
+
+func TestMyTakes_SaveReplaces(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	url := "https://example.com/post"
+	if take, err := store.GetMyTake(TakeArticle, url); err != nil || take != "" {
+		t.Fatalf("GetMyTake before saving = %q, %v", take, err)
+	}
+
+	if err := store.SaveMyTake(MyTake{Kind: TakeArticle, Target: url, Take: "First draft", Source: "takes.yaml"}); err != nil {
+		t.Fatalf("SaveMyTake failed: %v", err)
+	}
+	if err := store.SaveMyTake(MyTake{Kind: TakeArticle, Target: url, Take: "Edited", Source: "takes.md"}); err != nil {
+		t.Fatalf("SaveMyTake again failed: %v", err)
+	}
+	if take, err := store.GetMyTake(TakeArticle, url); err != nil || take != "Edited" {
+		t.Errorf("expected the newer take, got %q, %v", take, err)
+	}
+	if take, _ := store.GetMyTake(TakeDigest, url); take != "" {
+		t.Errorf("expected takes kept apart by kind, got %q", take)
+	}
+
+	// Re-caching the article doesn't touch its take
+	if err := store.CacheArticle(core.Article{ID: "a1", LinkID: url, Title: "Post", CleanedText: "Content", DateFetched: time.Now()}); err != nil {
+		t.Fatalf("CacheArticle failed: %v", err)
+	}
+	if take, _ := store.GetMyTake(TakeArticle, url); take != "Edited" {
+		t.Errorf("expected the take to survive re-caching, got %q", take)
+	}
+
+	if err := store.SaveMyTake(MyTake{Kind: "issue", Target: url, Take: "x"}); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// tenantUsageTable holds each server tenant's API requests and LLM spend per
+// calendar month (server.tenants), for usage reports and budget checks
+const tenantUsageTable = `
+	CREATE TABLE IF NOT EXISTS tenant_usage (
+		tenant_id TEXT NOT NULL,
+		month TEXT NOT NULL,
+		requests INTEGER NOT NULL DEFAULT 0,
+		llm_calls INTEGER NOT NULL DEFAULT 0,
+		tokens_in INTEGER NOT NULL DEFAULT 0,
+		tokens_out INTEGER NOT NULL DEFAULT 0,
+		cost_usd REAL NOT NULL DEFAULT 0,
+		PRIMARY KEY (tenant_id, month)
+	);`
+
+// TenantUsage is a tenant's usage in one month
+type TenantUsage struct {
+	TenantID  string
+	Month     string // YYYY-MM
+	Requests  int
+	LLMCalls  int
+	TokensIn  int
+	TokensOut int
+	CostUSD   float64 // Estimated LLM spend
+}
+
+// AddTenantUsage adds usage to a tenant's month, starting the month at zero
+func (s *Store) AddTenantUsage(usage TenantUsage) error {
+	usage.TenantID = strings.TrimSpace(usage.TenantID)
+	if usage.TenantID == "" || usage.Month == "" {
+		return fmt.Errorf("tenant usage needs a tenant and a month")
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO tenant_usage (tenant_id, month, requests, llm_calls, tokens_in, tokens_out, cost_usd)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (tenant_id, month) DO UPDATE SET
+			requests = requests + excluded.requests,
+			llm_calls = llm_calls + excluded.llm_calls,
+			tokens_in = tokens_in + excluded.tokens_in,
+			tokens_out = tokens_out + excluded.tokens_out,
+			cost_usd = cost_usd + excluded.cost_usd`,
+		usage.TenantID, usage.Month, usage.Requests, usage.LLMCalls, usage.TokensIn, usage.TokensOut, usage.CostUSD)
+	if err != nil {
+		return fmt.Errorf("failed to record usage for tenant %q: %w", usage.TenantID, err)
+	}
+	return nil
+}
+
+// GetTenantUsage returns a tenant's usage in a month, all zero when it has none
+func (s *Store) GetTenantUsage(tenantID, month string) (TenantUsage, error) {
+	usage := TenantUsage{TenantID: tenantID, Month: month}
+	err := s.db.QueryRow(`
+		SELECT requests, llm_calls, tokens_in, tokens_out, cost_usd
+		FROM tenant_usage WHERE tenant_id = ? AND month = ?`, tenantID, month).
+		Scan(&usage.Requests, &usage.LLMCalls, &usage.TokensIn, &usage.TokensOut, &usage.CostUSD)
+	if err != nil && err != sql.ErrNoRows {
+		return usage, fmt.Errorf("failed to get usage for tenant %q: %w", tenantID, err)
+	}
+	return usage, nil
+}
+
+// ListTenantUsage returns every tenant's usage in a month, by tenant
+func (s *Store) ListTenantUsage(month string) ([]TenantUsage, error) {
+	rows, err := s.db.Query(`
+		SELECT tenant_id, month, requests, llm_calls, tokens_in, tokens_out, cost_usd
+		FROM tenant_usage WHERE month = ? ORDER BY tenant_id`, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenant usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []TenantUsage
+	for rows.Next() {
+		var u TenantUsage
+		if err := rows.Scan(&u.TenantID, &u.Month, &u.Requests, &u.LLMCalls, &u.TokensIn, &u.TokensOut, &u.CostUSD); err != nil {
+			return nil, fmt.Errorf("failed to read tenant usage: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
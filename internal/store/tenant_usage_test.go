@@ -0,0 +1,45 @@
+package store
+
+import "testing"
+
+func TestTenantUsage(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, usage := range []TenantUsage{
+		{TenantID: "research", Month: "2026-10", Requests: 1},
+		{TenantID: "research", Month: "2026-10", LLMCalls: 2, TokensIn: 1000, TokensOut: 200, CostUSD: 0.25},
+		{TenantID: "platform", Month: "2026-10", Requests: 3},
+		{TenantID: "research", Month: "2026-09", Requests: 7},
+	} {
+		if err := store.AddTenantUsage(usage); err != nil {
+			t.Fatalf("AddTenantUsage failed: %v", err)
+		}
+	}
+	if err := store.AddTenantUsage(TenantUsage{Month: "2026-10"}); err == nil {
+		t.Error("expected usage without a tenant rejected")
+	}
+
+	got, err := store.GetTenantUsage("research", "2026-10")
+	if err != nil {
+		t.Fatalf("GetTenantUsage failed: %v", err)
+	}
+	want := TenantUsage{TenantID: "research", Month: "2026-10", Requests: 1, LLMCalls: 2, TokensIn: 1000, TokensOut: 200, CostUSD: 0.25}
+	if got != want {
+		t.Errorf("GetTenantUsage = %+v, want %+v", got, want)
+	}
+	if none, err := store.GetTenantUsage("research", "2026-08"); err != nil || none.Requests != 0 || none.TenantID != "research" {
+		t.Errorf("expected an empty month, got %+v, %v", none, err)
+	}
+
+	month, err := store.ListTenantUsage("2026-10")
+	if err != nil {
+		t.Fatalf("ListTenantUsage failed: %v", err)
+	}
+	if len(month) != 2 || month[0].TenantID != "platform" || month[1].TenantID != "research" {
+		t.Errorf("unexpected usage %+v", month)
+	}
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// topicPagesTable tracks the living topic pages digests append to. The centroid is
+// the running mean of the centroids of every cluster appended to the page, so next
+// week's clusters can be matched to the topic they continue.
+const topicPagesTable = `
+	CREATE TABLE IF NOT EXISTS topic_pages (
+		slug TEXT PRIMARY KEY,
+		title TEXT NOT NULL,
+		centroid BLOB,
+		runs INTEGER DEFAULT 0,
+		first_seen DATETIME NOT NULL,
+		last_seen DATETIME NOT NULL
+	);`
+
+// TopicPage is a recurring topic with a page under the digests' topics directory
+type TopicPage struct {
+	Slug      string    // Page filename without .md
+	Title     string    // Topic name, from the label of the cluster that started it
+	Centroid  []float64 // Running mean of appended cluster centroids
+	Runs      int       // Digest runs that appended to the page
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// SaveTopicPage inserts or replaces a topic page by slug
+func (s *Store) SaveTopicPage(page *TopicPage) error {
+	if strings.TrimSpace(page.Slug) == "" {
+		return fmt.Errorf("topic page slug is required")
+	}
+	if page.FirstSeen.IsZero() {
+		page.FirstSeen = time.Now().UTC()
+	}
+	if page.LastSeen.IsZero() {
+		page.LastSeen = page.FirstSeen
+	}
+
+	centroidData, err := serializeEmbedding(page.Centroid)
+	if err != nil {
+		return fmt.Errorf("failed to serialize centroid: %w", err)
+	}
+
+	title, err := s.sealText(page.Title)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO topic_pages (slug, title, centroid, runs, first_seen, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		page.Slug, title, centroidData, page.Runs, page.FirstSeen.UTC(), page.LastSeen.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save topic page: %w", err)
+	}
+	return nil
+}
+
+// ListTopicPages returns every tracked topic page, most recently updated first
+func (s *Store) ListTopicPages() ([]TopicPage, error) {
+	rows, err := s.db.Query(`SELECT slug, title, centroid, runs, first_seen, last_seen FROM topic_pages ORDER BY last_seen DESC, slug`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query topic pages: %w", err)
+	}
+	defer rows.Close()
+
+	var pages []TopicPage
+	for rows.Next() {
+		var page TopicPage
+		var centroidData []byte
+		if err := rows.Scan(&page.Slug, &page.Title, &centroidData, &page.Runs, &page.FirstSeen, &page.LastSeen); err != nil {
+			return nil, fmt.Errorf("failed to scan topic page: %w", err)
+		}
+		if centroidData != nil {
+			if page.Centroid, err = deserializeEmbedding(centroidData); err != nil {
+				return nil, fmt.Errorf("failed to deserialize centroid: %w", err)
+			}
+		}
+		if page.Title, err = s.openText(page.Title); err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	return pages, rows.Err()
+}
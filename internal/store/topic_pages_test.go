@@ -0,0 +1,52 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTopicPages_SaveList(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.SaveTopicPage(&TopicPage{Title: "No slug"}); err == nil {
+		t.Error("expected an error for a page without a slug")
+	}
+
+	first := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	agents := &TopicPage{Slug: "ai-agents", Title: "AI Agents", Centroid: []float64{0.1, 0.2}, Runs: 1, FirstSeen: first}
+	if err := store.SaveTopicPage(agents); err != nil {
+		t.Fatalf("SaveTopicPage failed: %v", err)
+	}
+	if !agents.LastSeen.Equal(first) {
+		t.Errorf("expected LastSeen to default to FirstSeen, got %v", agents.LastSeen)
+	}
+	if err := store.SaveTopicPage(&TopicPage{Slug: "webgpu", Title: "WebGPU", Runs: 1, FirstSeen: first.AddDate(0, 0, 7)}); err != nil {
+		t.Fatalf("SaveTopicPage failed: %v", err)
+	}
+
+	// A later run updates the agents page in place
+	agents.Runs = 2
+	agents.LastSeen = first.AddDate(0, 0, 14)
+	if err := store.SaveTopicPage(agents); err != nil {
+		t.Fatalf("SaveTopicPage failed: %v", err)
+	}
+
+	pages, err := store.ListTopicPages()
+	if err != nil {
+		t.Fatalf("ListTopicPages failed: %v", err)
+	}
+	if len(pages) != 2 || pages[0].Slug != "ai-agents" || pages[1].Slug != "webgpu" {
+		t.Fatalf("expected the most recently updated page first, got %+v", pages)
+	}
+	got := pages[0]
+	if got.Runs != 2 || !got.FirstSeen.Equal(first) || len(got.Centroid) != 2 || got.Centroid[1] != 0.2 {
+		t.Errorf("unexpected page: %+v", got)
+	}
+	if pages[1].Centroid != nil {
+		t.Errorf("expected no centroid, got %v", pages[1].Centroid)
+	}
+}
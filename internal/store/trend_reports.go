@@ -0,0 +1,113 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// trendReportsTable keeps each saved trend report ('briefly quality trends') and when
+// it was embedded in a newsletter, so a report is embedded at most once a week
+const trendReportsTable = `
+	CREATE TABLE IF NOT EXISTS trend_reports (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		markdown TEXT NOT NULL,
+		summary TEXT NOT NULL DEFAULT '',
+		since DATETIME NOT NULL,
+		until DATETIME NOT NULL,
+		created_at DATETIME NOT NULL,
+		embedded_at DATETIME
+	);
+	CREATE INDEX IF NOT EXISTS idx_trend_reports_created_at ON trend_reports (created_at);`
+
+// TrendEmbedInterval is how often a trend report is embedded in newsletters
+const TrendEmbedInterval = 7 * 24 * time.Hour
+
+// TrendReport is a saved trend report
+type TrendReport struct {
+	ID         int64
+	Title      string
+	Markdown   string // The full report
+	Summary    string // Highlights embedded in newsletters
+	Since      time.Time
+	Until      time.Time
+	CreatedAt  time.Time
+	EmbeddedAt time.Time // Zero until embedded in a newsletter
+}
+
+const trendReportColumns = `id, title, markdown, summary, since, until, created_at, embedded_at`
+
+// SaveTrendReport stores a report, setting its ID and CreatedAt
+func (s *Store) SaveTrendReport(report *TrendReport) error {
+	if report.CreatedAt.IsZero() {
+		report.CreatedAt = time.Now().UTC()
+	}
+	markdown, err := s.sealText(report.Markdown)
+	if err != nil {
+		return err
+	}
+	summary, err := s.sealText(report.Summary)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.Exec(`INSERT INTO trend_reports (title, markdown, summary, since, until, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		report.Title, markdown, summary, report.Since.UTC(), report.Until.UTC(), report.CreatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save trend report: %w", err)
+	}
+	report.ID, err = result.LastInsertId()
+	return err
+}
+
+// LatestTrendReport returns the most recently saved report, or nil when none is saved
+func (s *Store) LatestTrendReport() (*TrendReport, error) {
+	row := s.db.QueryRow(`SELECT ` + trendReportColumns + ` FROM trend_reports ORDER BY created_at DESC, id DESC LIMIT 1`)
+	var report TrendReport
+	var embedded sql.NullTime
+	err := row.Scan(&report.ID, &report.Title, &report.Markdown, &report.Summary, &report.Since, &report.Until, &report.CreatedAt, &embedded)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trend report: %w", err)
+	}
+	if embedded.Valid {
+		report.EmbeddedAt = embedded.Time
+	}
+	if report.Markdown, err = s.openText(report.Markdown); err != nil {
+		return nil, err
+	}
+	if report.Summary, err = s.openText(report.Summary); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// TrendReportToEmbed returns the latest report for a newsletter rendered at now: one
+// saved within TrendEmbedInterval, when no report was embedded within it. Returns
+// nil when there is nothing to embed this week.
+func (s *Store) TrendReportToEmbed(now time.Time) (*TrendReport, error) {
+	cutoff := now.Add(-TrendEmbedInterval).UTC()
+	var recent int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM trend_reports WHERE embedded_at > ?`, cutoff).Scan(&recent); err != nil {
+		return nil, fmt.Errorf("failed to check embedded trend reports: %w", err)
+	}
+	if recent > 0 {
+		return nil, nil
+	}
+
+	report, err := s.LatestTrendReport()
+	if err != nil || report == nil || report.CreatedAt.Before(cutoff) || report.Summary == "" {
+		return nil, err
+	}
+	return report, nil
+}
+
+// MarkTrendReportEmbedded records that a report was embedded in a newsletter
+func (s *Store) MarkTrendReportEmbedded(id int64, at time.Time) error {
+	if _, err := s.db.Exec(`UPDATE trend_reports SET embedded_at = ? WHERE id = ?`, at.UTC(), id); err != nil {
+		return fmt.Errorf("failed to mark trend report %d embedded: %w", id, err)
+	}
+	return nil
+}
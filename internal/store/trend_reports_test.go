@@ -0,0 +1,55 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrendReports_Embed(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	if report, err := store.LatestTrendReport(); err != nil || report != nil {
+		t.Fatalf("expected no report in an empty store, got %+v, %v", report, err)
+	}
+
+	old := &TrendReport{Title: "Old", Markdown: "# Old", Summary: "old", CreatedAt: now.AddDate(0, 0, -10)}
+	if err := store.SaveTrendReport(old); err != nil {
+		t.Fatalf("SaveTrendReport failed: %v", err)
+	}
+	if report, err := store.TrendReportToEmbed(now); err != nil || report != nil {
+		t.Errorf("expected a report older than a week not to be embedded, got %+v, %v", report, err)
+	}
+
+	fresh := &TrendReport{Title: "Fresh", Markdown: "# Fresh", Summary: "**This week's keywords:** agents",
+		Since: now.AddDate(0, 0, -28), Until: now, CreatedAt: now.Add(-time.Hour)}
+	if err := store.SaveTrendReport(fresh); err != nil {
+		t.Fatalf("SaveTrendReport failed: %v", err)
+	}
+	if fresh.ID == 0 {
+		t.Error("expected SaveTrendReport to set the ID")
+	}
+	report, err := store.TrendReportToEmbed(now)
+	if err != nil || report == nil || report.ID != fresh.ID || report.Summary != fresh.Summary || !report.Since.Equal(fresh.Since) {
+		t.Fatalf("expected the fresh report to embed, got %+v, %v", report, err)
+	}
+
+	// Once embedded, nothing else is embedded until a week has passed
+	if err := store.MarkTrendReportEmbedded(report.ID, now); err != nil {
+		t.Fatalf("MarkTrendReportEmbedded failed: %v", err)
+	}
+	if err := store.SaveTrendReport(&TrendReport{Title: "Newer", Markdown: "# Newer", Summary: "newer", CreatedAt: now.AddDate(0, 0, 3)}); err != nil {
+		t.Fatalf("SaveTrendReport failed: %v", err)
+	}
+	if report, err := store.TrendReportToEmbed(now.AddDate(0, 0, 4)); err != nil || report != nil {
+		t.Errorf("expected no second embed within the week, got %+v, %v", report, err)
+	}
+	report, err = store.TrendReportToEmbed(now.AddDate(0, 0, 8))
+	if err != nil || report == nil || report.Title != "Newer" || !report.EmbeddedAt.IsZero() {
+		t.Errorf("expected the newer report to embed the next week, got %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,218 @@
+package summarize
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/language"
+	"briefly/internal/llm"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// CreateFactSheetSchema returns the response schema for the fact extraction pass of
+// two-pass summarization. Every fact must carry a verbatim supporting quote.
+func CreateFactSheetSchema() *genai.Schema {
+	fact := func(valueDescription string) *genai.Schema {
+		schema := &genai.Schema{
+			Type: genai.TypeObject,
+			Properties: map[string]*genai.Schema{
+				"text":  {Type: genai.TypeString, Description: "The fact as one short, self-contained sentence"},
+				"quote": {Type: genai.TypeString, Description: "Verbatim text copied from the article that states this fact (one sentence or less)"},
+			},
+			Required: []string{"text", "quote"},
+		}
+		if valueDescription != "" {
+			schema.Properties["value"] = &genai.Schema{Type: genai.TypeString, Description: valueDescription}
+			schema.Required = append(schema.Required, "value")
+		}
+		return schema
+	}
+
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"claims": {
+				Type:        genai.TypeArray,
+				Description: "The article's main claims, announcements, and findings (3-8)",
+				Items:       fact(""),
+			},
+			"numbers": {
+				Type:        genai.TypeArray,
+				Description: "Exact numbers, percentages, prices, dates, and metrics stated in the article (empty if none)",
+				Items:       fact("The exact figure as written (e.g., \"40%\", \"$1.5M\", \"768 dimensions\")"),
+			},
+			"entities": {
+				Type:        genai.TypeArray,
+				Description: "People, companies, and products the article names, with their role in the story",
+				Items:       fact("The name as written in the article"),
+			},
+		},
+		Required: []string{"claims", "numbers", "entities"},
+	}
+}
+
+// BuildFactExtractionPrompt creates the prompt for the first pass of two-pass
+// summarization, which only collects quote-backed facts
+func BuildFactExtractionPrompt(title, content string) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("Extract the facts stated in this article. Do not summarize, interpret, or add background knowledge.\n\n")
+	if note := language.PromptNote(content); note != "" {
+		prompt.WriteString(note + "\n\n")
+	}
+
+	if title != "" {
+		prompt.WriteString(fmt.Sprintf("**Title:** %s\n\n", title))
+	}
+	prompt.WriteString(fmt.Sprintf("**Content:**\n%s\n\n", truncateContent(content, 4000)))
+
+	prompt.WriteString("**RULES:**\n")
+	prompt.WriteString("- CLAIMS: what the article says happened, was announced, or was found\n")
+	prompt.WriteString("- NUMBERS: every exact figure with what it measures; copy the value exactly as written\n")
+	prompt.WriteString("- ENTITIES: named people, companies, and products, with their role in the story\n")
+	prompt.WriteString("- Every fact needs a quote copied word for word from the content above; facts whose quote is not in the article are discarded\n")
+	prompt.WriteString("- Only include facts the article states; never estimate, round, or infer figures\n")
+
+	return prompt.String()
+}
+
+// BuildFactConstrainedPrompt creates the prompt for the second pass of two-pass
+// summarization: the summary is written from the fact sheet alone, without the
+// article text, so it cannot introduce facts that weren't extracted
+func BuildFactConstrainedPrompt(title string, facts *core.FactSheet, opts PromptOptions) string {
+	var prompt strings.Builder
+
+	if guidance := opts.Audience.Guidance(); guidance != "" {
+		prompt.WriteString(guidance)
+		prompt.WriteString("\n")
+	} else {
+		prompt.WriteString("**TARGET AUDIENCE:** Senior software engineers who want practical implications over marketing hype\n\n")
+		prompt.WriteString("**TONE:** Technical, skeptical of hype, action-oriented\n\n")
+	}
+
+	prompt.WriteString("Write a summary of an article using ONLY the facts listed below, which were extracted from it.\n\n")
+	if title != "" {
+		prompt.WriteString(fmt.Sprintf("**Title:** %s\n\n", title))
+	}
+	writeFacts(&prompt, "CLAIMS", facts.Claims)
+	writeFacts(&prompt, "NUMBERS", facts.Numbers)
+	writeFacts(&prompt, "ENTITIES", facts.Entities)
+
+	prompt.WriteString("**CRITICAL RULES:**\n")
+	prompt.WriteString("- Every statement must come from a fact above; do not add numbers, names, dates, or claims that are not listed\n")
+	prompt.WriteString("- Copy numbers exactly as listed; do not round or convert them\n")
+	prompt.WriteString("- If the facts don't say why something matters, don't speculate\n")
+	prompt.WriteString("- ❌ NEVER use: \"several\", \"various\", \"multiple\", \"many\", \"some\", \"a few\", \"numerous\"\n\n")
+
+	prompt.WriteString("**OUTPUT FORMAT:**\n")
+	prompt.WriteString("SUMMARY:\n")
+	prompt.WriteString(fmt.Sprintf("[Your %d-word summary built from the facts above]\n\n", opts.MaxWords))
+	if opts.IncludeKeyPoints {
+		prompt.WriteString("KEY POINTS:\n")
+		for i := 1; i <= opts.KeyPointCount; i++ {
+			prompt.WriteString(fmt.Sprintf("- [Key point %d, restating one listed fact]\n", i))
+		}
+	}
+
+	return prompt.String()
+}
+
+// writeFacts lists one section of a fact sheet in a prompt
+func writeFacts(prompt *strings.Builder, heading string, facts []core.Fact) {
+	if len(facts) == 0 {
+		return
+	}
+	prompt.WriteString(fmt.Sprintf("**%s:**\n", heading))
+	for _, fact := range facts {
+		if fact.Value != "" {
+			prompt.WriteString(fmt.Sprintf("- %s: %s\n", fact.Value, fact.Text))
+		} else {
+			prompt.WriteString(fmt.Sprintf("- %s\n", fact.Text))
+		}
+	}
+	prompt.WriteString("\n")
+}
+
+// ExtractFacts runs the fact extraction pass over an article. Facts whose quote
+// can't be found in the article text are dropped, as are numbers whose figure
+// isn't in their quote.
+func (s *Summarizer) ExtractFacts(ctx context.Context, article *core.Article) (*core.FactSheet, error) {
+	if article == nil || article.CleanedText == "" {
+		return nil, fmt.Errorf("article has no content to extract facts from")
+	}
+
+	ctx = llm.WithAttribution(ctx, llm.Attribution{ArticleID: article.ID, Phase: "facts"})
+	response, err := s.generateWithOptions(ctx, BuildFactExtractionPrompt(article.Title, article.CleanedText), GenerationOptions{
+		ResponseSchema: CreateFactSheetSchema(),
+		Temperature:    s.options.Temperature,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract facts: %w", err)
+	}
+
+	var facts core.FactSheet
+	if err := json.Unmarshal([]byte(response), &facts); err != nil {
+		return nil, fmt.Errorf("failed to parse fact sheet JSON: %w", err)
+	}
+
+	return GroundFacts(&facts, article.CleanedText), nil
+}
+
+// GroundFacts returns the facts whose quotes appear in the article text, ignoring
+// case, whitespace, and typographic quote differences. A number is kept only when
+// every digit run of its value appears in its quote.
+func GroundFacts(facts *core.FactSheet, articleText string) *core.FactSheet {
+	text := normalizeForMatch(articleText)
+	quoted := func(fact core.Fact) bool {
+		quote := normalizeForMatch(fact.Quote)
+		return quote != "" && strings.Contains(text, quote)
+	}
+
+	grounded := &core.FactSheet{}
+	for _, fact := range facts.Claims {
+		if quoted(fact) {
+			grounded.Claims = append(grounded.Claims, fact)
+		}
+	}
+	for _, fact := range facts.Numbers {
+		if quoted(fact) && figureInQuote(fact.Value, fact.Quote) {
+			grounded.Numbers = append(grounded.Numbers, fact)
+		}
+	}
+	for _, fact := range facts.Entities {
+		if quoted(fact) {
+			grounded.Entities = append(grounded.Entities, fact)
+		}
+	}
+	return grounded
+}
+
+var digitRun = regexp.MustCompile(`\d+`)
+
+// figureInQuote reports whether value has digits and each run of them is in quote
+func figureInQuote(value, quote string) bool {
+	runs := digitRun.FindAllString(value, -1)
+	if len(runs) == 0 {
+		return false
+	}
+	for _, run := range runs {
+		if !strings.Contains(quote, run) {
+			return false
+		}
+	}
+	return true
+}
+
+var matchReplacer = strings.NewReplacer("‘", "'", "’", "'", "“", `"`, "”", `"`, "–", "-", "—", "-")
+
+// normalizeForMatch lowercases text, unifies typographic punctuation, and collapses
+// whitespace so quotes match the article despite formatting differences
+func normalizeForMatch(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.Trim(text, `"'.…`)
+	return strings.Join(strings.Fields(strings.ToLower(matchReplacer.Replace(text))), " ")
+}
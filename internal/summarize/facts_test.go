@@ -0,0 +1,93 @@
+package summarize
+
+import (
+	"briefly/internal/core"
+	"context"
+	"strings"
+	"testing"
+)
+
+const factArticle = `Acme today released Rocket 2, its new inference server. “Rocket 2 cuts p99 latency by 40% on the same hardware,” said CTO Jane Doe.
+The release ships with support for 128 concurrent streams.`
+
+func TestGroundFacts(t *testing.T) {
+	facts := &core.FactSheet{
+		Claims: []core.Fact{
+			{Text: "Acme released Rocket 2", Quote: "Acme today released   Rocket 2"},
+			{Text: "Rocket 2 is open source", Quote: "Rocket 2 is fully open source"},
+		},
+		Numbers: []core.Fact{
+			{Value: "40%", Text: "p99 latency reduction", Quote: `"Rocket 2 cuts p99 latency by 40% on the same hardware,"`},
+			{Value: "128", Text: "concurrent streams", Quote: "support for 128 concurrent streams."},
+			{Value: "50%", Text: "throughput gain", Quote: "cuts p99 latency by 40%"},
+		},
+		Entities: []core.Fact{
+			{Value: "Jane Doe", Text: "Acme CTO", Quote: "said CTO Jane Doe"},
+			{Value: "Globex", Text: "competitor", Quote: "unlike Globex"},
+		},
+	}
+
+	grounded := GroundFacts(facts, factArticle)
+
+	if len(grounded.Claims) != 1 || grounded.Claims[0].Text != "Acme released Rocket 2" {
+		t.Errorf("expected only the quoted claim, got %+v", grounded.Claims)
+	}
+	if len(grounded.Numbers) != 2 {
+		t.Errorf("expected the figure missing from its quote dropped, got %+v", grounded.Numbers)
+	}
+	if len(grounded.Entities) != 1 || grounded.Entities[0].Value != "Jane Doe" {
+		t.Errorf("expected the unquoted entity dropped, got %+v", grounded.Entities)
+	}
+}
+
+func TestSummarizeArticle_TwoPass(t *testing.T) {
+	sheet := `{"claims":[{"text":"Acme released Rocket 2","quote":"Acme today released Rocket 2"}],
+		"numbers":[{"value":"40%","text":"p99 latency cut","quote":"cuts p99 latency by 40%"},{"value":"3x","text":"invented","quote":"three times faster"}],
+		"entities":[]}`
+	prose := "SUMMARY:\nAcme released Rocket 2, an inference server that cuts p99 latency by 40% on the same hardware."
+
+	client := &sequenceLLMClient{responses: []string{sheet, prose}}
+	opts := DefaultSummarizerOptions()
+	opts.MinSummaryWords = 5
+	opts.TwoPass = true
+	summarizer := NewSummarizer(client, opts)
+
+	article := &core.Article{ID: "a1", Title: "Rocket 2", CleanedText: factArticle}
+	summary, err := summarizer.SummarizeArticle(context.Background(), article)
+	if err != nil {
+		t.Fatalf("SummarizeArticle: %v", err)
+	}
+
+	if len(client.prompts) != 2 {
+		t.Fatalf("expected an extraction call and a prose call, got %d", len(client.prompts))
+	}
+	prosePrompt := client.prompts[1]
+	if strings.Contains(prosePrompt, "concurrent streams") {
+		t.Error("expected the prose prompt to leave out the article text")
+	}
+	if !strings.Contains(prosePrompt, "40%: p99 latency cut") || strings.Contains(prosePrompt, "invented") {
+		t.Errorf("expected only grounded facts in the prose prompt:\n%s", prosePrompt)
+	}
+	if summary.Facts == nil || len(summary.Facts.Numbers) != 1 || len(summary.Facts.Claims) != 1 {
+		t.Errorf("expected the grounded fact sheet on the summary, got %+v", summary.Facts)
+	}
+}
+
+func TestSummarizeArticle_TwoPassFallsBackWithoutFacts(t *testing.T) {
+	prose := "SUMMARY:\nAcme released Rocket 2, an inference server that cuts p99 latency by 40% on the same hardware."
+	client := &sequenceLLMClient{responses: []string{"not json", prose}}
+	opts := DefaultSummarizerOptions()
+	opts.MinSummaryWords = 5
+	opts.TwoPass = true
+
+	summary, err := NewSummarizer(client, opts).SummarizeArticle(context.Background(), &core.Article{ID: "a1", CleanedText: factArticle})
+	if err != nil {
+		t.Fatalf("SummarizeArticle: %v", err)
+	}
+	if summary.Facts != nil {
+		t.Errorf("expected no fact sheet, got %+v", summary.Facts)
+	}
+	if !strings.Contains(client.prompts[1], "concurrent streams") {
+		t.Error("expected the single-pass prompt with the article text")
+	}
+}
@@ -1,6 +1,9 @@
 package summarize
 
 import (
+	"briefly/internal/core"
+	"briefly/internal/language"
+	"briefly/internal/llm"
 	"fmt"
 	"strings"
 )
@@ -17,10 +20,11 @@ const (
 // PromptOptions configures prompt generation
 type PromptOptions struct {
 	Type             PromptType
-	MaxWords         int    // Target word count for summary
-	IncludeKeyPoints bool   // Whether to include key points
-	KeyPointCount    int    // Number of key points (3-5)
-	Format           string // Output format preference
+	MaxWords         int           // Target word count for summary
+	IncludeKeyPoints bool          // Whether to include key points
+	KeyPointCount    int           // Number of key points (3-5)
+	Format           string        // Output format preference
+	Audience         core.Audience // Who the summary is for; empty keeps the default framing
 }
 
 // DefaultDigestOptions returns default options for digest summaries
@@ -50,15 +54,23 @@ func BuildSummarizationPrompt(title, content string, opts PromptOptions) string
 	var prompt strings.Builder
 
 	// Target audience context
-	prompt.WriteString("**TARGET AUDIENCE:** Senior software engineers (5+ years) who:\n")
-	prompt.WriteString("- Want GenAI updates but have limited time (5-10 min/week)\n")
-	prompt.WriteString("- Prefer practical implications over marketing hype\n")
-	prompt.WriteString("- Need to advise their teams on what to learn/adopt\n")
-	prompt.WriteString("- Care about \"so what does this mean for my work?\"\n\n")
+	if guidance := opts.Audience.Guidance(); guidance != "" {
+		prompt.WriteString(guidance)
+		prompt.WriteString("\n")
+	} else {
+		prompt.WriteString("**TARGET AUDIENCE:** Senior software engineers (5+ years) who:\n")
+		prompt.WriteString("- Want GenAI updates but have limited time (5-10 min/week)\n")
+		prompt.WriteString("- Prefer practical implications over marketing hype\n")
+		prompt.WriteString("- Need to advise their teams on what to learn/adopt\n")
+		prompt.WriteString("- Care about \"so what does this mean for my work?\"\n\n")
 
-	prompt.WriteString("**TONE:** Technical, skeptical of hype, action-oriented\n\n")
+		prompt.WriteString("**TONE:** Technical, skeptical of hype, action-oriented\n\n")
+	}
 
 	prompt.WriteString("Summarize this article with CONCRETE FACTS and SPECIFIC DETAILS.\n\n")
+	if note := language.PromptNote(content); note != "" {
+		prompt.WriteString(note + "\n\n")
+	}
 
 	// Article details
 	if title != "" {
@@ -133,9 +145,29 @@ func BuildSummarizationPrompt(title, content string, opts PromptOptions) string
 	return prompt.String()
 }
 
+// BuildStrictSummarizationPrompt rebuilds the summarization prompt with the quality
+// issues found in a previous attempt, asking the model to correct them explicitly
+func BuildStrictSummarizationPrompt(title, content string, opts PromptOptions, issues []string) string {
+	return withStrictMode(BuildSummarizationPrompt(title, content, opts), opts, issues)
+}
+
+// withStrictMode appends the rejected attempt's quality issues to a summarization prompt
+func withStrictMode(base string, opts PromptOptions, issues []string) string {
+	var prompt strings.Builder
+
+	prompt.WriteString(base)
+	prompt.WriteString("\n\n**STRICT MODE - PREVIOUS ATTEMPT WAS REJECTED:**\n")
+	for _, issue := range issues {
+		prompt.WriteString(fmt.Sprintf("- %s\n", issue))
+	}
+	prompt.WriteString(fmt.Sprintf("\nThe SUMMARY section must be %d words or fewer, contain at least one exact number, name, or date from the article, and use none of the banned vague phrases.\n", opts.MaxWords))
+
+	return prompt.String()
+}
+
 // BuildKeyPointsPrompt creates a prompt for extracting key points
 func BuildKeyPointsPrompt(content string, count int) string {
-	return fmt.Sprintf(`Extract the %d most important key points from this content.
+	return llm.WithLanguageNote(content, fmt.Sprintf(`Extract the %d most important key points from this content.
 
 **Content:**
 %s
@@ -152,12 +184,12 @@ Return exactly %d key points as a bulleted list:
 - [Key point 2]
 ...
 
-Key points:`, count, truncateContent(content, 3000), count, count)
+Key points:`, count, truncateContent(content, 3000), count, count))
 }
 
 // BuildTitlePrompt creates a prompt for generating article titles
 func BuildTitlePrompt(content string) string {
-	return fmt.Sprintf(`Generate a clear, descriptive title for this article.
+	return llm.WithLanguageNote(content, fmt.Sprintf(`Generate a clear, descriptive title for this article.
 
 **Content:**
 %s
@@ -172,7 +204,7 @@ func BuildTitlePrompt(content string) string {
 **Output Format:**
 Return only the title, nothing else.
 
-Title:`, truncateContent(content, 1500))
+Title:`, truncateContent(content, 1500)))
 }
 
 // BuildThemePrompt creates a prompt for identifying article theme
@@ -0,0 +1,74 @@
+package summarize
+
+import (
+	"briefly/internal/quality"
+	"fmt"
+	"strings"
+)
+
+// SummaryQuality captures the heuristic quality assessment of a single article summary
+type SummaryQuality struct {
+	Score        float64  // 0.0-1.0
+	WordCount    int      // Words in the summary body
+	VaguePhrases []string // Vague phrases found (shared list with digest critique)
+	HasSpecifics bool     // Contains numbers, named entities, or [N] citations
+	Issues       []string // Human-readable reasons the score was reduced
+}
+
+// Passed reports whether the summary meets the given minimum score
+func (q SummaryQuality) Passed(minScore float64) bool {
+	return q.Score >= minScore
+}
+
+// ScoreSummary rates a summary on length bounds, presence of concrete facts or
+// citations, and vague phrasing. The score starts at 1.0 and is reduced per issue.
+func ScoreSummary(text string, minWords, maxWords int) SummaryQuality {
+	q := SummaryQuality{Score: 1.0}
+
+	text = strings.TrimSpace(text)
+	q.WordCount = len(strings.Fields(text))
+
+	if q.WordCount == 0 {
+		q.Score = 0
+		q.Issues = append(q.Issues, "summary is empty")
+		return q
+	}
+
+	// Length bounds
+	if minWords > 0 && q.WordCount < minWords {
+		q.Score -= 0.4
+		q.Issues = append(q.Issues, fmt.Sprintf("too short (%d words, minimum %d)", q.WordCount, minWords))
+	}
+	if maxWords > 0 && q.WordCount > maxWords {
+		q.Score -= 0.3
+		q.Issues = append(q.Issues, fmt.Sprintf("too long (%d words, maximum %d)", q.WordCount, maxWords))
+	}
+
+	// Concrete facts or citations anchoring the summary to the source
+	_, hasNumbers := quality.DetectNumbers(text)
+	_, hasNames := quality.DetectProperNouns(text)
+	hasCitations := len(quality.ExtractCitations(text)) > 0
+	q.HasSpecifics = hasNumbers || hasNames || hasCitations
+	if !q.HasSpecifics {
+		q.Score -= 0.3
+		q.Issues = append(q.Issues, "no concrete numbers, names, or citations")
+	}
+
+	// Vague phrasing
+	vagueCount, found := quality.DetectVaguePhrases(text)
+	q.VaguePhrases = found
+	if vagueCount > 0 {
+		penalty := 0.1 * float64(vagueCount)
+		if penalty > 0.3 {
+			penalty = 0.3
+		}
+		q.Score -= penalty
+		q.Issues = append(q.Issues, fmt.Sprintf("vague phrasing: %s", strings.Join(found, ", ")))
+	}
+
+	if q.Score < 0 {
+		q.Score = 0
+	}
+
+	return q
+}
@@ -0,0 +1,104 @@
+package summarize
+
+import (
+	"briefly/internal/core"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestScoreSummary(t *testing.T) {
+	specific := "Anthropic released Claude with a 200K context window, cutting latency by 40% for enterprise customers in March 2025."
+
+	tests := []struct {
+		name      string
+		text      string
+		minWords  int
+		maxWords  int
+		wantPass  bool
+		wantIssue string
+	}{
+		{"empty", "", 5, 100, false, "empty"},
+		{"specific and within bounds", specific, 5, 100, true, ""},
+		{"too long", specific, 1, 5, true, "too long"},
+		{"vague and generic", "there were several changes and various updates across many teams this week overall", 5, 100, false, "vague phrasing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := ScoreSummary(tt.text, tt.minWords, tt.maxWords)
+			if q.Passed(0.6) != tt.wantPass {
+				t.Errorf("Passed = %v (score %.2f, issues %v), want %v", q.Passed(0.6), q.Score, q.Issues, tt.wantPass)
+			}
+			if tt.wantIssue != "" && !strings.Contains(strings.Join(q.Issues, "; "), tt.wantIssue) {
+				t.Errorf("Expected issue containing %q, got %v", tt.wantIssue, q.Issues)
+			}
+		})
+	}
+}
+
+// sequenceLLMClient returns canned responses in order
+type sequenceLLMClient struct {
+	responses []string
+	prompts   []string
+}
+
+func (s *sequenceLLMClient) GenerateText(ctx context.Context, prompt string, options interface{}) (string, error) {
+	s.prompts = append(s.prompts, prompt)
+	resp := s.responses[len(s.responses)-1]
+	if len(s.prompts) <= len(s.responses) {
+		resp = s.responses[len(s.prompts)-1]
+	}
+	return resp, nil
+}
+
+func TestSummarizeArticle_ResummarizesLowQuality(t *testing.T) {
+	vague := "SUMMARY:\nthere were several changes and various updates across many teams this week overall"
+	good := "SUMMARY:\nAnthropic released Claude with a 200K context window, cutting latency by 40% for enterprise customers in March 2025."
+
+	client := &sequenceLLMClient{responses: []string{vague, good}}
+	opts := DefaultSummarizerOptions()
+	opts.MinSummaryWords = 5
+	summarizer := NewSummarizer(client, opts)
+
+	article := &core.Article{ID: "a1", Title: "Release", CleanedText: "Full article text."}
+	summary, err := summarizer.SummarizeArticle(context.Background(), article)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(client.prompts) != 2 {
+		t.Fatalf("Expected 2 LLM calls (initial + strict retry), got %d", len(client.prompts))
+	}
+	if !strings.Contains(client.prompts[1], "STRICT MODE") {
+		t.Error("Expected retry to use the strict prompt")
+	}
+	if summary.QualityFlagged {
+		t.Errorf("Expected improved summary not to be flagged (score %.2f)", summary.QualityScore)
+	}
+	if !strings.Contains(summary.SummaryText, "Anthropic") {
+		t.Errorf("Expected retry summary to be kept, got %q", summary.SummaryText)
+	}
+}
+
+func TestSummarizeArticle_FlagsPersistentFailure(t *testing.T) {
+	vague := "SUMMARY:\nthere were several changes and various updates across many teams this week overall"
+
+	client := &sequenceLLMClient{responses: []string{vague}}
+	opts := DefaultSummarizerOptions()
+	opts.MinSummaryWords = 5
+	summarizer := NewSummarizer(client, opts)
+
+	article := &core.Article{ID: "a1", Title: "Release", CleanedText: "Full article text."}
+	summary, err := summarizer.SummarizeArticle(context.Background(), article)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !summary.QualityFlagged {
+		t.Error("Expected summary to be flagged after failed re-summarization")
+	}
+	if len(summary.QualityIssues) == 0 {
+		t.Error("Expected quality issues to be recorded")
+	}
+}
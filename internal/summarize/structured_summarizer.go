@@ -2,6 +2,7 @@ package summarize
 
 import (
 	"briefly/internal/core"
+	"briefly/internal/llm"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -41,6 +42,25 @@ func CreateStructuredSummarySchema() *genai.Schema {
 				Type:        genai.TypeString,
 				Description: "Who this affects and how - practical implications (optional, can be empty if not applicable)",
 			},
+			"entities": {
+				Type:        genai.TypeArray,
+				Description: "People, companies, and products named in the article",
+				Items: &genai.Schema{
+					Type: genai.TypeString,
+				},
+			},
+			"stats": {
+				Type:        genai.TypeArray,
+				Description: "Exact numbers and metrics from the article with brief context (empty if none)",
+				Items: &genai.Schema{
+					Type: genai.TypeObject,
+					Properties: map[string]*genai.Schema{
+						"stat":    {Type: genai.TypeString, Description: "The exact metric value (e.g., \"40%\", \"$1.5M\")"},
+						"context": {Type: genai.TypeString, Description: "What the metric measures"},
+					},
+					Required: []string{"stat", "context"},
+				},
+			},
 		},
 		Required: []string{"key_points", "context", "main_insight"},
 	}
@@ -48,7 +68,7 @@ func CreateStructuredSummarySchema() *genai.Schema {
 
 // BuildStructuredSummaryPrompt creates a prompt optimized for generating structured summaries
 func BuildStructuredSummaryPrompt(title, content string) string {
-	return fmt.Sprintf(`Analyze the following article and create a structured summary.
+	return llm.WithLanguageNote(content, fmt.Sprintf(`Analyze the following article and create a structured summary.
 
 Article Title: %s
 
@@ -63,7 +83,7 @@ Create a comprehensive structured summary with the following sections:
 4. TECHNICAL DETAILS: Include technical aspects, methodologies, or specific details (if applicable)
 5. IMPACT: Describe who this affects and how - practical implications (if applicable)
 
-Focus on clarity, accuracy, and providing value to readers who want to quickly understand the article's significance.`, title, content)
+Focus on clarity, accuracy, and providing value to readers who want to quickly understand the article's significance.`, title, content))
 }
 
 // SummarizeArticleStructured creates a structured summary using Gemini's response_schema (Phase 1)
@@ -77,8 +97,13 @@ func (s *Summarizer) SummarizeArticleStructured(ctx context.Context, article *co
 		return nil, fmt.Errorf("article has no content to summarize")
 	}
 
+	ctx = llm.WithAttribution(ctx, llm.Attribution{ArticleID: article.ID, Phase: "summarize"})
+
 	// Build structured summary prompt
 	prompt := BuildStructuredSummaryPrompt(article.Title, article.CleanedText)
+	if guidance := s.options.Audience.Guidance(); guidance != "" {
+		prompt = guidance + "\n" + prompt
+	}
 
 	// Create response schema
 	schema := CreateStructuredSummarySchema()
@@ -89,12 +114,7 @@ func (s *Summarizer) SummarizeArticleStructured(ctx context.Context, article *co
 
 	// Generate with response schema
 	for attempt := 0; attempt <= s.options.MaxRetries; attempt++ {
-		// Note: The options parameter is interface{} in the LLMClient interface
-		// The actual implementation will handle the type conversion
-		options := struct {
-			ResponseSchema *genai.Schema
-			Temperature    float32
-		}{
+		options := GenerationOptions{
 			ResponseSchema: schema,
 			Temperature:    s.options.Temperature,
 		}
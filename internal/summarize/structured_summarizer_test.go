@@ -472,3 +472,45 @@ func anySubstring(s, substr string) bool {
 	}
 	return false
 }
+
+// optionsCapturingClient records the options passed to GenerateText
+type optionsCapturingClient struct {
+	options  interface{}
+	response string
+}
+
+func (c *optionsCapturingClient) GenerateText(ctx context.Context, prompt string, options interface{}) (string, error) {
+	c.options = options
+	return c.response, nil
+}
+
+// Test SummarizeArticleStructured - schema reaches the client and typed fields are decoded
+func TestSummarizeArticleStructured_PassesSchemaAndTypedFields(t *testing.T) {
+	client := &optionsCapturingClient{
+		response: `{"key_points":["Gemini 2.5 ships"],"context":"Background.","main_insight":"Faster models.",
+			"entities":["Google","Gemini 2.5"],"stats":[{"stat":"40%","context":"latency reduction"}]}`,
+	}
+	summarizer := NewSummarizerWithDefaults(client)
+
+	article := &core.Article{ID: "a1", Title: "Gemini", CleanedText: "Article text."}
+	summary, err := summarizer.SummarizeArticleStructured(context.Background(), article)
+	if err != nil {
+		t.Fatalf("SummarizeArticleStructured failed: %v", err)
+	}
+
+	opts, ok := client.options.(GenerationOptions)
+	if !ok {
+		t.Fatalf("Expected GenerationOptions, got %T", client.options)
+	}
+	if opts.ResponseSchema == nil {
+		t.Error("Expected response schema to be passed to the client")
+	}
+
+	content := summary.StructuredContent
+	if len(content.Entities) != 2 || content.Entities[0] != "Google" {
+		t.Errorf("Expected entities to be decoded, got %v", content.Entities)
+	}
+	if len(content.Stats) != 1 || content.Stats[0].Stat != "40%" {
+		t.Errorf("Expected stats to be decoded, got %v", content.Stats)
+	}
+}
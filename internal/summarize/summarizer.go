@@ -2,12 +2,14 @@ package summarize
 
 import (
 	"briefly/internal/core"
+	"briefly/internal/llm"
 	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/genai"
 )
 
 // LLMClient defines the interface for LLM operations
@@ -16,6 +18,19 @@ type LLMClient interface {
 	GenerateText(ctx context.Context, prompt string, options interface{}) (string, error)
 }
 
+// BatchLLMClient is implemented by LLM clients that can run many prompts as one
+// discounted batch job. Responses and errors are returned in prompt order.
+type BatchLLMClient interface {
+	GenerateTextBatch(ctx context.Context, prompts []string) ([]string, []error, error)
+}
+
+// GenerationOptions is passed as GenerateText's options when a call needs structured
+// output. LLMClient adapters translate it into the provider's request config.
+type GenerationOptions struct {
+	ResponseSchema *genai.Schema
+	Temperature    float32
+}
+
 // SummarizerInterface defines the interface for article summarization
 // Both Summarizer and TracedSummarizer implement this interface
 type SummarizerInterface interface {
@@ -46,8 +61,17 @@ type SummarizerOptions struct {
 	RetryDelay time.Duration
 
 	// Quality control
-	MinSummaryWords int // Minimum words for valid summary
-	MaxSummaryWords int // Maximum words before truncation
+	MinSummaryWords int     // Minimum words for valid summary
+	MaxSummaryWords int     // Maximum words before truncation
+	MinQualityScore float64 // Summaries scoring below this are re-summarized (0 disables)
+	QualityRetries  int     // Re-summarization attempts with the stricter prompt
+
+	// Audience sets the depth and vocabulary of summaries (empty = default framing)
+	Audience core.Audience
+
+	// TwoPass extracts a quote-backed fact sheet first and writes the summary from
+	// only those facts, to keep unsupported details out of summaries
+	TwoPass bool
 }
 
 // DefaultSummarizerOptions returns sensible defaults
@@ -61,6 +85,8 @@ func DefaultSummarizerOptions() SummarizerOptions {
 		RetryDelay:           time.Second,
 		MinSummaryWords:      50,
 		MaxSummaryWords:      300,
+		MinQualityScore:      0.6,
+		QualityRetries:       1,
 	}
 }
 
@@ -87,35 +113,67 @@ func (s *Summarizer) SummarizeArticle(ctx context.Context, article *core.Article
 		return nil, fmt.Errorf("article has no content to summarize")
 	}
 
-	// Build prompt
+	// Two-pass summaries fall back to the single prompt when no facts survive extraction
+	var facts *core.FactSheet
+	if s.options.TwoPass {
+		if extracted, err := s.ExtractFacts(ctx, article); err == nil && !extracted.Empty() {
+			facts = extracted
+		}
+	}
+
+	ctx = llm.WithAttribution(ctx, llm.Attribution{ArticleID: article.ID, Phase: "summarize"})
+	prompt := BuildSummarizationPrompt(article.Title, article.CleanedText, s.promptOptions())
+	if facts != nil {
+		prompt = BuildFactConstrainedPrompt(article.Title, facts, s.promptOptions())
+	}
+
+	response, err := s.generateWithRetries(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := s.summaryFromResponse(ctx, article, response, facts)
+	summary.Facts = facts
+	return summary, nil
+}
+
+// promptOptions returns the summarization prompt options for this summarizer
+func (s *Summarizer) promptOptions() PromptOptions {
 	promptOpts := DefaultDigestOptions()
 	promptOpts.MaxWords = s.options.DefaultMaxWords
 	promptOpts.KeyPointCount = s.options.DefaultKeyPointCount
+	promptOpts.Audience = s.options.Audience
+	return promptOpts
+}
 
-	prompt := BuildSummarizationPrompt(article.Title, article.CleanedText, promptOpts)
+// summaryFromResponse parses and scores a summarization response, re-summarizing with
+// the stricter prompt when quality is below threshold, and builds the Summary. With a
+// fact sheet, the stricter prompt is still limited to its facts.
+func (s *Summarizer) summaryFromResponse(ctx context.Context, article *core.Article, response string, facts *core.FactSheet) *core.Summary {
+	promptOpts := s.promptOptions()
 
-	// Generate summary with retries
-	var response string
-	var err error
+	// Parse response and score it
+	summaryText, keyPoints := ParseSummaryResponse(response)
+	score := ScoreSummary(summaryText, s.options.MinSummaryWords, s.options.MaxSummaryWords)
 
-	for attempt := 0; attempt <= s.options.MaxRetries; attempt++ {
-		response, err = s.llmClient.GenerateText(ctx, prompt, nil)
-		if err == nil {
+	// Re-summarize with a stricter prompt while the summary is below threshold
+	for attempt := 0; s.options.MinQualityScore > 0 && !score.Passed(s.options.MinQualityScore) && attempt < s.options.QualityRetries; attempt++ {
+		strictPrompt := BuildStrictSummarizationPrompt(article.Title, article.CleanedText, promptOpts, score.Issues)
+		if facts != nil {
+			strictPrompt = withStrictMode(BuildFactConstrainedPrompt(article.Title, facts, promptOpts), promptOpts, score.Issues)
+		}
+		retryResponse, err := s.generateWithRetries(ctx, strictPrompt)
+		if err != nil {
 			break
 		}
 
-		if attempt < s.options.MaxRetries {
-			time.Sleep(s.options.RetryDelay * time.Duration(attempt+1))
+		retryText, retryKeyPoints := ParseSummaryResponse(retryResponse)
+		retryScore := ScoreSummary(retryText, s.options.MinSummaryWords, s.options.MaxSummaryWords)
+		if retryScore.Score > score.Score {
+			summaryText, keyPoints, score = retryText, retryKeyPoints, retryScore
 		}
 	}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate summary after %d attempts: %w", s.options.MaxRetries+1, err)
-	}
-
-	// Parse response
-	summaryText, keyPoints := ParseSummaryResponse(response)
-
 	// Validate summary
 	if err := s.validateSummary(summaryText); err != nil {
 		// Try to extract first N words as fallback
@@ -125,11 +183,14 @@ func (s *Summarizer) SummarizeArticle(ctx context.Context, article *core.Article
 
 	// Build summary object
 	summary := &core.Summary{
-		ID:            uuid.NewString(),
-		ArticleIDs:    []string{article.ID},
-		SummaryText:   summaryText,
-		ModelUsed:     s.options.ModelName,
-		DateGenerated: time.Now(),
+		ID:             uuid.NewString(),
+		ArticleIDs:     []string{article.ID},
+		SummaryText:    summaryText,
+		ModelUsed:      s.options.ModelName,
+		DateGenerated:  time.Now(),
+		QualityScore:   score.Score,
+		QualityIssues:  score.Issues,
+		QualityFlagged: s.options.MinQualityScore > 0 && !score.Passed(s.options.MinQualityScore),
 	}
 
 	// Store key points if we got them
@@ -138,7 +199,32 @@ func (s *Summarizer) SummarizeArticle(ctx context.Context, article *core.Article
 	// Or wait until we update the core.Summary struct
 	_ = keyPoints // Explicitly acknowledge we're not using key points yet
 
-	return summary, nil
+	return summary
+}
+
+// generateWithRetries calls the LLM, retrying transient failures with linear backoff
+func (s *Summarizer) generateWithRetries(ctx context.Context, prompt string) (string, error) {
+	return s.generateWithOptions(ctx, prompt, nil)
+}
+
+// generateWithOptions is generateWithRetries with GenerateText options, such as a
+// response schema
+func (s *Summarizer) generateWithOptions(ctx context.Context, prompt string, options interface{}) (string, error) {
+	var response string
+	var err error
+
+	for attempt := 0; attempt <= s.options.MaxRetries; attempt++ {
+		response, err = s.llmClient.GenerateText(ctx, prompt, options)
+		if err == nil {
+			return response, nil
+		}
+
+		if attempt < s.options.MaxRetries {
+			time.Sleep(s.options.RetryDelay * time.Duration(attempt+1))
+		}
+	}
+
+	return "", fmt.Errorf("failed to generate summary after %d attempts: %w", s.options.MaxRetries+1, err)
 }
 
 // GenerateKeyPoints extracts key points from content
@@ -173,7 +259,7 @@ func (s *Summarizer) ExtractTitle(ctx context.Context, content string) (string,
 
 	prompt := BuildTitlePrompt(content)
 
-	title, err := s.llmClient.GenerateText(ctx, prompt, nil)
+	title, err := s.llmClient.GenerateText(llm.WithAttribution(ctx, llm.Attribution{Phase: "title"}), prompt, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to extract title: %w", err)
 	}
@@ -249,6 +335,58 @@ func (s *Summarizer) SummarizeBatch(ctx context.Context, articles []*core.Articl
 	return summaries, nil
 }
 
+// SummarizeArticlesBatch summarizes articles with a single batch job when the LLM client
+// supports it, trading latency for lower cost. Quality re-summarization still uses the
+// regular API. Without batch support, or in two-pass mode, it falls back to
+// SummarizeArticle per article.
+// Summaries and errors are returned in article order; a failed article has a nil summary.
+func (s *Summarizer) SummarizeArticlesBatch(ctx context.Context, articles []*core.Article) ([]*core.Summary, []error) {
+	summaries := make([]*core.Summary, len(articles))
+	errs := make([]error, len(articles))
+
+	batchClient, ok := s.llmClient.(BatchLLMClient)
+	if !ok || s.options.TwoPass {
+		for i, article := range articles {
+			summaries[i], errs[i] = s.SummarizeArticle(ctx, article)
+		}
+		return summaries, errs
+	}
+
+	// Only articles with content go into the batch
+	prompts := make([]string, 0, len(articles))
+	indexes := make([]int, 0, len(articles))
+	for i, article := range articles {
+		if article == nil || article.CleanedText == "" {
+			errs[i] = fmt.Errorf("article has no content to summarize")
+			continue
+		}
+		prompts = append(prompts, BuildSummarizationPrompt(article.Title, article.CleanedText, s.promptOptions()))
+		indexes = append(indexes, i)
+	}
+
+	if len(prompts) == 0 {
+		return summaries, errs
+	}
+
+	responses, responseErrs, err := batchClient.GenerateTextBatch(llm.WithAttribution(ctx, llm.Attribution{Phase: "summarize"}), prompts)
+	if err != nil {
+		for _, i := range indexes {
+			errs[i] = fmt.Errorf("batch summarization failed: %w", err)
+		}
+		return summaries, errs
+	}
+
+	for j, i := range indexes {
+		if responseErrs[j] != nil {
+			errs[i] = fmt.Errorf("failed to generate summary: %w", responseErrs[j])
+			continue
+		}
+		summaries[i] = s.summaryFromResponse(ctx, articles[i], responses[j], nil)
+	}
+
+	return summaries, errs
+}
+
 // RefineSum mary refines an existing summary based on feedback
 func (s *Summarizer) RefineSummary(ctx context.Context, originalSummary string, feedback string, targetWords int) (string, error) {
 	prompt := BuildRefinePrompt(originalSummary, feedback, targetWords)
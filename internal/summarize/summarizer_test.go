@@ -325,6 +325,90 @@ func TestSummarizeBatch(t *testing.T) {
 	}
 }
 
+// mockBatchLLMClient adds batch support to MockLLMClient
+type mockBatchLLMClient struct {
+	*MockLLMClient
+	batchCalls   int
+	batchPrompts int
+	failIndex    int // Prompt index that fails (-1 for none)
+}
+
+func (m *mockBatchLLMClient) GenerateTextBatch(ctx context.Context, prompts []string) ([]string, []error, error) {
+	m.batchCalls++
+	m.batchPrompts += len(prompts)
+
+	// Count batch responses separately from realtime calls
+	realtimeCalls := m.callCount
+	defer func() { m.callCount = realtimeCalls }()
+
+	responses := make([]string, len(prompts))
+	errs := make([]error, len(prompts))
+	for i, prompt := range prompts {
+		if i == m.failIndex {
+			errs[i] = &mockError{message: "request failed"}
+			continue
+		}
+		responses[i], _ = m.MockLLMClient.GenerateText(ctx, prompt, nil)
+	}
+	return responses, errs, nil
+}
+
+func TestSummarizeArticlesBatch(t *testing.T) {
+	mockClient := &mockBatchLLMClient{MockLLMClient: NewMockLLMClient(), failIndex: 1}
+	summarizer := NewSummarizerWithDefaults(mockClient)
+
+	articles := []*core.Article{
+		{ID: "article-1", Title: "First Article", CleanedText: "Content for first article with meaningful information."},
+		{ID: "article-2", Title: "Empty Article"},
+		{ID: "article-3", Title: "Second Article", CleanedText: "Content for second article with different information."},
+		{ID: "article-4", Title: "Third Article", CleanedText: "Content for third article with more details."},
+	}
+
+	summaries, errs := summarizer.SummarizeArticlesBatch(context.Background(), articles)
+
+	if mockClient.batchCalls != 1 || mockClient.batchPrompts != 3 {
+		t.Errorf("Expected one batch of 3 prompts, got %d batches with %d prompts", mockClient.batchCalls, mockClient.batchPrompts)
+	}
+	if mockClient.callCount != 0 {
+		t.Errorf("Expected no realtime LLM calls, got %d", mockClient.callCount)
+	}
+
+	if errs[1] == nil || summaries[1] != nil {
+		t.Error("Expected empty article to fail without a summary")
+	}
+	if errs[2] == nil || summaries[2] != nil {
+		t.Error("Expected failed batch request to surface its error")
+	}
+	for _, i := range []int{0, 3} {
+		if errs[i] != nil || summaries[i] == nil {
+			t.Fatalf("Expected summary for article %d, got error: %v", i, errs[i])
+		}
+		if summaries[i].ArticleIDs[0] != articles[i].ID {
+			t.Errorf("Summary %d has incorrect article ID", i)
+		}
+	}
+}
+
+func TestSummarizeArticlesBatch_FallsBackWithoutBatchSupport(t *testing.T) {
+	mockClient := NewMockLLMClient()
+	summarizer := NewSummarizerWithDefaults(mockClient)
+
+	articles := []*core.Article{
+		{ID: "article-1", Title: "First Article", CleanedText: "Content for first article with meaningful information."},
+		{ID: "article-2", Title: "Second Article", CleanedText: "Content for second article with different information."},
+	}
+
+	summaries, errs := summarizer.SummarizeArticlesBatch(context.Background(), articles)
+	for i := range articles {
+		if errs[i] != nil || summaries[i] == nil {
+			t.Errorf("Expected summary for article %d, got error: %v", i, errs[i])
+		}
+	}
+	if mockClient.callCount != 2 {
+		t.Errorf("Expected 2 realtime LLM calls, got %d", mockClient.callCount)
+	}
+}
+
 func TestParseSummaryResponse(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -477,3 +561,37 @@ func TestGetStats(t *testing.T) {
 		t.Error("Expected model name to be set")
 	}
 }
+
+func TestBuildSummarizationPromptAudience(t *testing.T) {
+	defaultPrompt := BuildSummarizationPrompt("Title", "Content", DefaultDigestOptions())
+	if !strings.Contains(defaultPrompt, "Senior software engineers") {
+		t.Error("expected default prompt to keep the senior-engineer framing")
+	}
+
+	opts := DefaultDigestOptions()
+	opts.Audience = core.AudienceNewcomer
+	newcomerPrompt := BuildSummarizationPrompt("Title", "Content", opts)
+	if strings.Contains(newcomerPrompt, "Senior software engineers") {
+		t.Error("expected newcomer prompt to replace the default audience")
+	}
+	if !strings.Contains(newcomerPrompt, "Define every acronym") {
+		t.Error("expected newcomer prompt to ask for definitions")
+	}
+}
+
+func TestBuildSummarizationPromptLanguage(t *testing.T) {
+	spanish := strings.Repeat("El equipo lanzó el nuevo planificador esta semana y es más rápido que el anterior para la mayoría de las cargas de trabajo. ", 3)
+
+	prompt := BuildSummarizationPrompt("Planificador", spanish, DefaultDigestOptions())
+	if !strings.Contains(prompt, "written in Spanish") || !strings.Contains(prompt, "Write your answer in English") {
+		t.Error("expected the prompt to name the article's language and ask for English")
+	}
+	if !strings.Contains(BuildKeyPointsPrompt(spanish, 3), "written in Spanish") || !strings.Contains(BuildStructuredSummaryPrompt("Planificador", spanish), "written in Spanish") {
+		t.Error("expected key point and structured prompts to carry the language note")
+	}
+
+	english := strings.Repeat("The team shipped the new scheduler this week and it is faster than the old one for most of the workloads. ", 3)
+	if prompt := BuildSummarizationPrompt("Scheduler", english, DefaultDigestOptions()); strings.Contains(prompt, "LANGUAGE:") {
+		t.Error("expected no language note for English articles")
+	}
+}
@@ -0,0 +1,191 @@
+// Package takes reads my-takes drafted in an editor: commentary on articles (by
+// URL) and digests (by ID), written as YAML or markdown, for 'briefly my-take
+// import' to merge before a digest is generated or regenerated.
+package takes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Take is one take from a file. Exactly one of URL and DigestID is set.
+type Take struct {
+	URL      string
+	DigestID string
+	Text     string
+	Line     int // Where the take starts in the file, for error messages
+}
+
+// Target is what the take is about: the article URL or the digest ID
+func (t Take) Target() string {
+	if t.URL != "" {
+		return t.URL
+	}
+	return t.DigestID
+}
+
+var (
+	// headingPattern matches a markdown heading, at any level
+	headingPattern = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+	// urlPattern finds the article URL in a heading, bare or as a markdown link
+	urlPattern = regexp.MustCompile(`https?://[^\s<>()\[\]]+`)
+	// digestPattern matches a heading naming a digest: "digest 1234abcd" or "Digest: 1234abcd"
+	digestPattern = regexp.MustCompile(`(?i)^digest:?\s+(\S+)$`)
+)
+
+// ParseFile reads the takes in a .yaml/.yml or .md/.markdown file
+func ParseFile(path string) ([]Take, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var takes []Take
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		takes, err = ParseYAML(data)
+	case ".md", ".markdown":
+		takes, err = ParseMarkdown(string(data))
+	default:
+		return nil, fmt.Errorf("%s: unsupported file type (use .yaml or .md)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return takes, nil
+}
+
+// yamlTake is an entry of the list form of a takes file
+type yamlTake struct {
+	URL    string `yaml:"url"`
+	Digest string `yaml:"digest"`
+	Take   string `yaml:"take"`
+	Note   string `yaml:"note"` // Same as take
+}
+
+// ParseYAML reads takes from YAML, either a list of entries:
+//
+//   - url: https://example.com/post
+//     take: Worth it for the benchmark section alone.
+//   - digest: 1234abcd
+//     take: A quieter week, but the eval results matter.
+//
+// or a mapping from URL or digest ID to take:
+//
+//	https://example.com/post: Worth it for the benchmark section alone.
+//	1234abcd: A quieter week, but the eval results matter.
+func ParseYAML(data []byte) ([]Take, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	doc := root.Content[0]
+
+	var takes []Take
+	switch doc.Kind {
+	case yaml.SequenceNode:
+		for _, node := range doc.Content {
+			var entry yamlTake
+			if err := node.Decode(&entry); err != nil {
+				return nil, fmt.Errorf("line %d: %w", node.Line, err)
+			}
+			text := entry.Take
+			if text == "" {
+				text = entry.Note
+			}
+			take := Take{URL: strings.TrimSpace(entry.URL), DigestID: strings.TrimSpace(entry.Digest), Text: strings.TrimSpace(text), Line: node.Line}
+			if (take.URL == "") == (take.DigestID == "") {
+				return nil, fmt.Errorf("line %d: set exactly one of url and digest", node.Line)
+			}
+			takes = append(takes, take)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(doc.Content); i += 2 {
+			key, value := doc.Content[i], doc.Content[i+1]
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: the take on %s must be text", value.Line, key.Value)
+			}
+			takes = append(takes, newTake(key.Value, value.Value, key.Line))
+		}
+	default:
+		return nil, fmt.Errorf("expected a list of takes or a mapping of URLs and digest IDs to takes")
+	}
+	return takes, checkTexts(takes)
+}
+
+// ParseMarkdown reads takes from markdown: each heading names an article URL (bare
+// or as a link) or a digest ("digest 1234abcd"), and the text under it, up to the
+// next heading, is the take. Anything before the first take, such as a title, is
+// ignored.
+//
+//	## [Postgres 18 ships async I/O](https://example.com/pg18)
+//	Worth it for the benchmark section alone.
+//
+//	## digest 1234abcd
+//	A quieter week, but the eval results matter.
+func ParseMarkdown(content string) ([]Take, error) {
+	var takes []Take
+	var current *Take
+	var body []string
+	flush := func() {
+		if current != nil {
+			current.Text = strings.TrimSpace(strings.Join(body, "\n"))
+			takes = append(takes, *current)
+		}
+		body = nil
+	}
+
+	inFence := false
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		match := headingPattern.FindStringSubmatch(line)
+		if match == nil || inFence {
+			body = append(body, line)
+			continue
+		}
+		take := newTake(match[1], "", i+1)
+		if take.URL == "" && strings.ContainsAny(take.DigestID, " \t") {
+			if current == nil {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: heading %q names neither an article URL nor a digest", i+1, match[1])
+		}
+		flush()
+		current = &take
+	}
+	flush()
+	return takes, checkTexts(takes)
+}
+
+// newTake makes a take on target, an article URL (anywhere in it) or a digest ID
+func newTake(target, text string, line int) Take {
+	target = strings.TrimSpace(target)
+	take := Take{Text: strings.TrimSpace(text), Line: line}
+	if url := urlPattern.FindString(target); url != "" {
+		take.URL = url
+	} else if match := digestPattern.FindStringSubmatch(target); match != nil {
+		take.DigestID = match[1]
+	} else {
+		take.DigestID = target
+	}
+	return take
+}
+
+// checkTexts rejects takes with no text, which are usually a heading left unfinished
+func checkTexts(takes []Take) error {
+	for _, take := range takes {
+		if take.Text == "" {
+			return fmt.Errorf("line %d: the take on %s is empty", take.Line, take.Target())
+		}
+	}
+	return nil
+}
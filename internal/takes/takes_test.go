@@ -0,0 +1,109 @@
+package takes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML_List(t *testing.T) {
+	takes, err := ParseYAML([]byte(`
+- url: https://example.com/pg18
+  take: |
+    Worth it for the benchmark section alone.
+- digest: 1234abcd
+  note: A quieter week.
+`))
+	if err != nil {
+		t.Fatalf("ParseYAML failed: %v", err)
+	}
+	if len(takes) != 2 {
+		t.Fatalf("expected 2 takes, got %+v", takes)
+	}
+	if takes[0].URL != "https://example.com/pg18" || takes[0].Text != "Worth it for the benchmark section alone." {
+		t.Errorf("unexpected article take %+v", takes[0])
+	}
+	if takes[1].DigestID != "1234abcd" || takes[1].Text != "A quieter week." || takes[1].Line != 5 {
+		t.Errorf("expected note accepted as the take, got %+v", takes[1])
+	}
+
+	if _, err := ParseYAML([]byte("- url: https://example.com/a\n  digest: 1234abcd\n  take: x\n")); err == nil {
+		t.Error("expected an error for an entry with both url and digest")
+	}
+	if _, err := ParseYAML([]byte("- url: https://example.com/a\n")); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("expected an empty take rejected, got %v", err)
+	}
+}
+
+func TestParseYAML_Mapping(t *testing.T) {
+	takes, err := ParseYAML([]byte("https://example.com/pg18: Read this one.\n1234abcd: Digest take.\n"))
+	if err != nil {
+		t.Fatalf("ParseYAML failed: %v", err)
+	}
+	if len(takes) != 2 || takes[0].URL != "https://example.com/pg18" || takes[1].DigestID != "1234abcd" || takes[1].Text != "Digest take." {
+		t.Errorf("unexpected takes %+v", takes)
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	takes, err := ParseMarkdown(`# Takes for this week
+
+Drafted Sunday.
+
+## [Postgres 18 ships async I/O](https://example.com/pg18)
+Worth it for the benchmark section alone.
+
+` + "```" + `
+## not a heading inside a code block
+` + "```" + `
+
+## https://example.com/wasm
+Component model finally usable.
+
+### Digest: 1234abcd
+A quieter week, but the eval results matter.
+`)
+	if err != nil {
+		t.Fatalf("ParseMarkdown failed: %v", err)
+	}
+	if len(takes) != 3 {
+		t.Fatalf("expected 3 takes, got %+v", takes)
+	}
+	if takes[0].URL != "https://example.com/pg18" || !strings.HasPrefix(takes[0].Text, "Worth it") || !strings.Contains(takes[0].Text, "## not a heading") {
+		t.Errorf("unexpected first take %+v", takes[0])
+	}
+	if takes[1].URL != "https://example.com/wasm" || takes[1].Line != 12 {
+		t.Errorf("unexpected second take %+v", takes[1])
+	}
+	if takes[2].DigestID != "1234abcd" || takes[2].Text != "A quieter week, but the eval results matter." {
+		t.Errorf("unexpected digest take %+v", takes[2])
+	}
+
+	if _, err := ParseMarkdown("## https://example.com/a\nText\n## Some thoughts on agents\nText\n"); err == nil {
+		t.Error("expected an error for a heading that names neither a URL nor a digest")
+	}
+}
+
+func TestParseFile_ByExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "takes.md")
+	if err := os.WriteFile(path, []byte("## https://example.com/a\nNice.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	takes, err := ParseFile(path)
+	if err != nil || len(takes) != 1 || takes[0].Text != "Nice." {
+		t.Fatalf("ParseFile(.md) = %+v, %v", takes, err)
+	}
+
+	if _, err := ParseFile(filepath.Join(dir, "takes.txt")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	other := filepath.Join(dir, "takes.json")
+	if err := os.WriteFile(other, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseFile(other); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("expected unsupported file type, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package templates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Overrides tweaks a format's template for one digest, without defining a custom template
+type Overrides struct {
+	With     []string // Feature names to turn on
+	Without  []string // Feature names to turn off
+	MaxWords int      // Replaces the format's word budget when > 0
+}
+
+// features maps the names used by --with/--without to the template fields they toggle
+var features = map[string]func(*DigestTemplate) *bool{
+	"summaries":           func(t *DigestTemplate) *bool { return &t.IncludeSummaries },
+	"key-insights":        func(t *DigestTemplate) *bool { return &t.IncludeKeyInsights },
+	"action-items":        func(t *DigestTemplate) *bool { return &t.IncludeActionItems },
+	"source-links":        func(t *DigestTemplate) *bool { return &t.IncludeSourceLinks },
+	"prompt-corner":       func(t *DigestTemplate) *bool { return &t.IncludePromptCorner },
+	"individual-articles": func(t *DigestTemplate) *bool { return &t.IncludeIndividualArticles },
+	"topic-clustering":    func(t *DigestTemplate) *bool { return &t.IncludeTopicClustering },
+	"banner":              func(t *DigestTemplate) *bool { return &t.IncludeBanner },
+	"by-the-numbers":      func(t *DigestTemplate) *bool { return &t.IncludeByTheNumbers },
+	"must-read":           func(t *DigestTemplate) *bool { return &t.IncludeMustRead },
+	"figures":             func(t *DigestTemplate) *bool { return &t.IncludeFigures },
+	"figure-images":       func(t *DigestTemplate) *bool { return &t.EmbedFigureImages },
+	"footnotes":           func(t *DigestTemplate) *bool { return &t.Footnotes },
+	"linkedin-hook":       func(t *DigestTemplate) *bool { return &t.IncludeLinkedInHook },
+	"game-changer":        func(t *DigestTemplate) *bool { return &t.IncludeGameChanger },
+	"discussion-prompt":   func(t *DigestTemplate) *bool { return &t.IncludeDiscussionPrompt },
+	"try-this-week":       func(t *DigestTemplate) *bool { return &t.IncludeTryThisWeek },
+}
+
+// FeatureNames lists the features --with/--without accept, sorted
+func FeatureNames() []string {
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsZero reports whether the overrides leave a template unchanged
+func (o Overrides) IsZero() bool {
+	return len(o.With) == 0 && len(o.Without) == 0 && o.MaxWords == 0
+}
+
+// Apply validates the overrides and applies them to the template. A feature named in
+// both With and Without is an error rather than a silent last-one-wins.
+func (o Overrides) Apply(template *DigestTemplate) error {
+	if o.MaxWords < 0 {
+		return fmt.Errorf("max words must be positive, got %d", o.MaxWords)
+	}
+	with := make(map[string]bool, len(o.With))
+	for _, name := range o.With {
+		name = normalizeFeature(name)
+		if _, ok := features[name]; !ok {
+			return unknownFeature(name)
+		}
+		with[name] = true
+	}
+	for _, name := range o.Without {
+		name = normalizeFeature(name)
+		if _, ok := features[name]; !ok {
+			return unknownFeature(name)
+		}
+		if with[name] {
+			return fmt.Errorf("feature %q is both enabled and disabled", name)
+		}
+	}
+
+	for _, name := range o.With {
+		*features[normalizeFeature(name)](template) = true
+	}
+	for _, name := range o.Without {
+		*features[normalizeFeature(name)](template) = false
+	}
+	if o.MaxWords > 0 {
+		template.MaxDigestWords = o.MaxWords
+	}
+	return nil
+}
+
+// normalizeFeature accepts "Game_Changer" and "game changer" for "game-changer"
+func normalizeFeature(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer("_", "-", " ", "-").Replace(name)
+}
+
+func unknownFeature(name string) error {
+	return fmt.Errorf("unknown feature %q (available: %s)", name, strings.Join(FeatureNames(), ", "))
+}
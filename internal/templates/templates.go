@@ -3,12 +3,20 @@ package templates
 import (
 	"briefly/internal/core"
 	"briefly/internal/email"
+	"briefly/internal/footnotes"
+	"briefly/internal/language"
 	"briefly/internal/llm"
+	"briefly/internal/logger"
 	"briefly/internal/render"
 	"fmt"
+	"html"
+	"math"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // DigestFormat represents different digest formats
@@ -43,11 +51,24 @@ type DigestTemplate struct {
 	IncludeIndividualArticles bool // Whether to include the "Individual Articles" section
 	IncludeTopicClustering    bool // Whether to group articles by topic clusters
 	IncludeBanner             bool // Whether to include banner image
+	IncludeByTheNumbers       bool // Whether to include the "By the Numbers" statistics section
+	IncludeMustRead           bool // Whether to include the "Must Read" callout box
+	IncludeFigures            bool // Whether to include described charts/figures under each article
+	EmbedFigureImages         bool // Whether to embed the figure image itself alongside its description
 	MaxSummaryLength          int  // 0 for no limit (in words for v2.0)
 	MaxDigestWords            int  // v2.0: Maximum total words for entire digest (0 for no limit)
 	IntroductionText          string
 	ConclusionText            string
 	SectionSeparator          string
+	TrendReport               string // Highlights of the week's trend report, rendered before the conclusion (markdown)
+
+	// Footnotes cites sources as [^N] footnotes numbered by the articles' order, with
+	// one references block at the end, instead of linking each article inline
+	Footnotes bool
+
+	// Branding adds the publication's logo above the title and its footer at the end
+	// (set for newsletter and email renders)
+	Branding *render.Branding
 
 	// LinkedIn optimization fields
 	IncludeLinkedInHook     bool   // Whether to include LinkedIn hook at top
@@ -92,6 +113,7 @@ func GetTemplate(format DigestFormat) *DigestTemplate {
 			IncludeIndividualArticles: true,  // Enable to showcase topic clustering
 			IncludeTopicClustering:    true,  // Enable topic clustering for better organization
 			IncludeBanner:             false, // Standard format keeps simple
+			IncludeMustRead:           true,  // Callout for the one article worth reading in full
 			IncludeDiscussionPrompt:   true,  // Enable discussion prompt for engagement
 			MaxSummaryLength:          25,    // v2.0: 15-25 words per article summary
 			MaxDigestWords:            400,   // v2.0: 400-word target for standard format
@@ -111,6 +133,8 @@ func GetTemplate(format DigestFormat) *DigestTemplate {
 			IncludeIndividualArticles: true,  // Enable to showcase topic clustering
 			IncludeTopicClustering:    true,  // Enable topic clustering for detailed analysis
 			IncludeBanner:             false, // Detailed format focuses on content
+			IncludeByTheNumbers:       true,  // Key metrics with links to their sources
+			IncludeFigures:            true,  // Described charts/benchmarks under their articles
 			IncludeDiscussionPrompt:   true,  // Enable discussion prompt for engagement
 			MaxSummaryLength:          50,    // v2.0: Longer summaries for detailed format but still controlled
 			MaxDigestWords:            0,     // No limit for detailed format
@@ -130,6 +154,8 @@ func GetTemplate(format DigestFormat) *DigestTemplate {
 			IncludeIndividualArticles: false,
 			IncludeTopicClustering:    true, // Enable topic clustering for newsletter organization
 			IncludeBanner:             true, // Enable banner for newsletter format
+			IncludeByTheNumbers:       true, // Key metrics with links to their sources
+			IncludeMustRead:           true, // Callout for the one article worth reading in full
 			IncludeDiscussionPrompt:   true, // Enable discussion prompt for engagement
 			MaxSummaryLength:          25,   // v2.0: 15-25 words per article summary
 			MaxDigestWords:            800,  // v2.0: 800-word target for newsletter format to include more articles
@@ -149,6 +175,7 @@ func GetTemplate(format DigestFormat) *DigestTemplate {
 			IncludeIndividualArticles: true,  // Enable individual articles in scannable format
 			IncludeTopicClustering:    false, // Disable clustering for scannable - use flat structure
 			IncludeBanner:             false, // Remove banner for bite-sized format
+			IncludeByTheNumbers:       true,  // Quick-scan metrics fit the bite-sized format
 			IncludeDiscussionPrompt:   true,  // Enable discussion prompt for LinkedIn engagement
 			IncludeLinkedInHook:       true,  // Enable LinkedIn hook for engagement
 			IncludeGameChanger:        true,  // Enable Game-Changer section for LinkedIn
@@ -295,8 +322,27 @@ func truncateToWordLimit(text string, maxWords int) string {
 	return strings.Join(words[:maxWords], " ") + "..."
 }
 
-// truncateToCompleteSentence truncates text to complete sentences within word limit
-// truncateToCompleteSentence was removed as unused
+// fitToWordBudget shortens text to the leading complete sentences that fit within
+// maxWords, so over-budget summaries still read naturally. When even the first
+// sentence is over budget it falls back to truncateToScannableFormat.
+func fitToWordBudget(text string, maxWords int) string {
+	words := strings.Fields(text)
+	if maxWords <= 0 || len(words) <= maxWords {
+		return text
+	}
+
+	kept := 0
+	for i, word := range words[:maxWords] {
+		if strings.HasSuffix(word, ".") || strings.HasSuffix(word, "!") || strings.HasSuffix(word, "?") {
+			kept = i + 1
+		}
+	}
+	if kept > 0 {
+		return strings.Join(words[:kept], " ")
+	}
+
+	return truncateToScannableFormat(text, maxWords/2, maxWords)
+}
 
 // truncateToScannableFormat provides strict 20-25 word enforcement for scannable format
 // Prioritizes readability while enforcing word limits more strictly than truncateToCompleteSentence
@@ -396,11 +442,7 @@ func renderArticlesSection(digestItems []render.DigestData, template *DigestTemp
 					title = fmt.Sprintf("%s %s", item.SentimentEmoji, title)
 				}
 
-				if template.IncludeSourceLinks {
-					content.WriteString(fmt.Sprintf("#### %s\n\n", title))
-				} else {
-					content.WriteString(fmt.Sprintf("#### %s\n\n", title))
-				}
+				content.WriteString(fmt.Sprintf("#### %s%s\n\n", title, footnoteRef(template, digestItems, item.URL)))
 
 				// Content type metadata (for non-HTML content)
 				if item.ContentType != "html" && item.ContentType != "" {
@@ -439,18 +481,20 @@ func renderArticlesSection(digestItems []render.DigestData, template *DigestTemp
 					summary := item.SummaryText
 					// v2.0: Use word-based truncation instead of character-based
 					if template.MaxSummaryLength > 0 {
-						summary = truncateToWordLimit(summary, template.MaxSummaryLength)
+						summary = fitToWordBudget(summary, template.MaxSummaryLength)
 					}
 					content.WriteString(fmt.Sprintf("%s\n\n", summary))
 				}
 
+				content.WriteString(renderFigures(item.Figures, template))
+
 				// Key insights (if template supports and data available)
 				if template.IncludeKeyInsights && item.MyTake != "" {
 					content.WriteString(fmt.Sprintf("**Key Insight:** %s\n\n", item.MyTake))
 				}
 
 				// Source link
-				if template.IncludeSourceLinks {
+				if template.IncludeSourceLinks && !template.Footnotes {
 					content.WriteString(fmt.Sprintf("%s\n\n", formatScannableLink(item.URL)))
 				}
 			}
@@ -468,11 +512,7 @@ func renderArticlesSection(digestItems []render.DigestData, template *DigestTemp
 				titleWithIcon = fmt.Sprintf("%s %s", item.ContentIcon, item.Title)
 			}
 
-			if template.IncludeSourceLinks {
-				content.WriteString(fmt.Sprintf("### %d. %s\n\n", i+1, titleWithIcon))
-			} else {
-				content.WriteString(fmt.Sprintf("### %d. %s\n\n", i+1, titleWithIcon))
-			}
+			content.WriteString(fmt.Sprintf("### %d. %s%s\n\n", i+1, titleWithIcon, footnoteRef(template, digestItems, item.URL)))
 
 			// Content type metadata (for non-HTML content)
 			if item.ContentType != "html" && item.ContentType != "" {
@@ -501,24 +541,52 @@ func renderArticlesSection(digestItems []render.DigestData, template *DigestTemp
 				summary := item.SummaryText
 				// v2.0: Use word-based truncation instead of character-based
 				if template.MaxSummaryLength > 0 {
-					summary = truncateToWordLimit(summary, template.MaxSummaryLength)
+					summary = fitToWordBudget(summary, template.MaxSummaryLength)
 				}
 				content.WriteString(fmt.Sprintf("%s\n\n", summary))
 			}
 
+			content.WriteString(renderFigures(item.Figures, template))
+
 			// Key insights (if template supports and data available)
 			if template.IncludeKeyInsights && item.MyTake != "" {
 				content.WriteString(fmt.Sprintf("**Key Insight:** %s\n\n", item.MyTake))
 			}
 
-			// Footnote citation
-			content.WriteString(fmt.Sprintf("[^%d]: %s\n\n", i+1, item.URL))
+			// Footnote citation, or a reference at the end in footnote mode
+			if !template.Footnotes {
+				content.WriteString(fmt.Sprintf("[^%d]: %s\n\n", i+1, item.URL))
+			}
 		}
 	}
 
 	return content.String()
 }
 
+// renderFigures renders an article's described figures, optionally with the image itself.
+// Figures without a vision description are skipped, since the bare image adds little to a text digest.
+func renderFigures(figures []core.ArticleImage, template *DigestTemplate) string {
+	if !template.IncludeFigures {
+		return ""
+	}
+
+	var content strings.Builder
+	for _, figure := range figures {
+		if figure.Description == "" {
+			continue
+		}
+		if template.EmbedFigureImages {
+			alt := figure.Alt
+			if alt == "" {
+				alt = "Figure"
+			}
+			content.WriteString(fmt.Sprintf("![%s](%s)\n\n", alt, figure.URL))
+		}
+		content.WriteString(fmt.Sprintf("📈 **Figure:** %s\n\n", figure.Description))
+	}
+	return content.String()
+}
+
 // renderScannableArticlesSection renders articles in a scannable newsletter format
 func renderScannableArticlesSection(digestItems []render.DigestData, template *DigestTemplate) string {
 	var content strings.Builder
@@ -582,7 +650,7 @@ func renderScannableArticlesSection(digestItems []render.DigestData, template *D
 					contentEmoji := getContentTypeEmoji(item.ContentType, item.Title)
 
 					// Article title with number and content type emoji
-					content.WriteString(fmt.Sprintf("**[%d] %s %s**\n\n", articleNum, contentEmoji, item.Title))
+					content.WriteString(scannableTitle("**%s**%s\n\n", articleNum, contentEmoji, item.Title, footnoteRef(template, digestItems, item.URL)))
 					articleNum++
 
 					// Key insight (summary) - simplified to just the summary
@@ -596,7 +664,7 @@ func renderScannableArticlesSection(digestItems []render.DigestData, template *D
 					}
 
 					// Link with clear call to action and reference URL
-					if template.IncludeSourceLinks {
+					if template.IncludeSourceLinks && !template.Footnotes {
 						content.WriteString(fmt.Sprintf("%s\n", formatScannableLink(item.URL)))
 						content.WriteString(fmt.Sprintf("*Reference: %s*\n\n", item.URL))
 					}
@@ -631,7 +699,7 @@ func renderScannableArticlesSection(digestItems []render.DigestData, template *D
 					content.WriteString("\n")
 				}
 				contentEmoji := getContentTypeEmoji(item.ContentType, item.Title)
-				content.WriteString(fmt.Sprintf("**[%d] %s %s**\n\n", articleNum, contentEmoji, item.Title))
+				content.WriteString(scannableTitle("**%s**%s\n\n", articleNum, contentEmoji, item.Title, footnoteRef(template, digestItems, item.URL)))
 				articleNum++
 
 				if template.IncludeSummaries && item.SummaryText != "" {
@@ -643,7 +711,7 @@ func renderScannableArticlesSection(digestItems []render.DigestData, template *D
 					content.WriteString(fmt.Sprintf("%s\n\n", summary))
 				}
 
-				if template.IncludeSourceLinks {
+				if template.IncludeSourceLinks && !template.Footnotes {
 					content.WriteString(fmt.Sprintf("%s\n", formatScannableLink(item.URL)))
 					content.WriteString(fmt.Sprintf("*Reference: %s*\n\n", item.URL))
 				}
@@ -665,7 +733,7 @@ func renderScannableArticlesSection(digestItems []render.DigestData, template *D
 			contentEmoji := getContentTypeEmoji(item.ContentType, item.Title)
 
 			// Article title with number and content type emoji
-			content.WriteString(fmt.Sprintf("### [%d] %s %s\n\n", i+1, contentEmoji, item.Title))
+			content.WriteString(scannableTitle("### %s%s\n\n", i+1, contentEmoji, item.Title, footnoteRef(template, digestItems, item.URL)))
 
 			// Key insight (summary) - simplified to just the summary
 			if template.IncludeSummaries && item.SummaryText != "" {
@@ -678,7 +746,7 @@ func renderScannableArticlesSection(digestItems []render.DigestData, template *D
 			}
 
 			// Link with clear call to action and reference URL
-			if template.IncludeSourceLinks {
+			if template.IncludeSourceLinks && !template.Footnotes {
 				content.WriteString(fmt.Sprintf("%s\n", formatScannableLink(item.URL)))
 				content.WriteString(fmt.Sprintf("*Reference: %s*\n\n", item.URL))
 			}
@@ -691,6 +759,15 @@ func renderScannableArticlesSection(digestItems []render.DigestData, template *D
 // calculatePriorityScore computes a priority score for article ordering in scannable format
 // Score is based on multiple factors: sentiment, alerts, topic confidence, content type, etc.
 func calculatePriorityScore(item render.DigestData) float64 {
+	// Scored articles rank by signal, with alerts still surfacing first
+	if item.SignalStrength > 0 {
+		score := item.SignalStrength
+		if item.AlertTriggered {
+			score += 0.1
+		}
+		return math.Min(score, 1.0)
+	}
+
 	score := 0.5 // Base score
 
 	// Alert factor (highest priority) - articles that triggered alerts are most important
@@ -778,7 +855,44 @@ func SortByPriority(items []render.DigestData) []render.DigestData {
 	return sorted
 }
 
+// linkedInCopyGenerator writes LinkedIn copy with the LLM; swapped out in tests
+var linkedInCopyGenerator = llm.GenerateLinkedInCopy
+
+// generateLinkedInCopy asks the LLM for the hook and Game-Changer copy, built from the
+// digest content so it tracks whatever products are in the news. Returns nil when
+// generation fails, in which case callers use the local heuristics below.
+func generateLinkedInCopy(digestItems []render.DigestData, finalDigest string, gameChanger *render.DigestData) *llm.LinkedInCopy {
+	if len(digestItems) == 0 {
+		return nil
+	}
+
+	sortedItems := SortByPriority(digestItems)
+	if gameChanger == nil {
+		gameChanger = &sortedItems[0]
+	}
+
+	topTitles := make([]string, 0, 3)
+	for i, item := range sortedItems {
+		if i >= 3 {
+			break
+		}
+		topTitles = append(topTitles, item.Title)
+	}
+
+	linkedInCopy, err := linkedInCopyGenerator(llm.LinkedInCopyInput{
+		DigestContent:      finalDigest,
+		TopTitles:          topTitles,
+		GameChangerTitle:   gameChanger.Title,
+		GameChangerSummary: gameChanger.SummaryText,
+	})
+	if err != nil {
+		return nil
+	}
+	return linkedInCopy
+}
+
 // generateLinkedInHook creates an engaging 2-3 line hook for LinkedIn posts
+// Local fallback for when LLM copy is unavailable; names come from the articles themselves
 func generateLinkedInHook(digestItems []render.DigestData, pattern string) string {
 	if len(digestItems) == 0 {
 		return "This week's tech highlights worth your attention 👇"
@@ -788,26 +902,14 @@ func generateLinkedInHook(digestItems []render.DigestData, pattern string) strin
 	sortedItems := SortByPriority(digestItems)
 	topItem := sortedItems[0]
 
-	// Extract key themes from top articles
+	// Themes come from the top articles' topic clusters
 	var themes []string
 	for i, item := range sortedItems {
 		if i >= 3 { // Only look at top 3 for themes
 			break
 		}
-
-		// Extract technology names and themes from titles
-		title := strings.ToLower(item.Title)
-		if strings.Contains(title, "ai") || strings.Contains(title, "artificial intelligence") {
-			themes = append(themes, "AI")
-		}
-		if strings.Contains(title, "gemini") || strings.Contains(title, "claude") || strings.Contains(title, "gpt") {
-			themes = append(themes, "LLM development")
-		}
-		if strings.Contains(title, "coding") || strings.Contains(title, "code") || strings.Contains(title, "developer") {
-			themes = append(themes, "coding tools")
-		}
-		if strings.Contains(title, "google") || strings.Contains(title, "anthropic") || strings.Contains(title, "openai") {
-			themes = append(themes, "big tech moves")
+		if item.TopicCluster != "" {
+			themes = append(themes, item.TopicCluster)
 		}
 	}
 
@@ -816,7 +918,7 @@ func generateLinkedInHook(digestItems []render.DigestData, pattern string) strin
 	case "Pattern1":
 		// "X happened this week that changes Y"
 		if len(themes) > 0 {
-			return fmt.Sprintf("%s breakthroughs this week that change how engineers work.\n\n%s just made complex tasks instant. Here's what you need to know 👇",
+			return fmt.Sprintf("%s developments this week that change how engineers work.\n\n%s leads the list. Here's what you need to know 👇",
 				themes[0], extractProductName(topItem.Title))
 		}
 		return fmt.Sprintf("%s just changed the game for engineering teams.\n\nHere's what happened this week that you can't ignore 👇",
@@ -824,92 +926,96 @@ func generateLinkedInHook(digestItems []render.DigestData, pattern string) strin
 
 	case "Pattern2":
 		// "The gap between [leader] and [followers] just widened"
-		leader := extractCompanyName(topItem.Title)
+		leader := extractCompanyName(topItem.URL)
 		if leader != "" {
 			return fmt.Sprintf("The gap between %s and everyone else just widened.\n\nWhile competitors catch up, here's what engineering leaders are already using 👇", leader)
 		}
-		return fmt.Sprintf("The gap between AI leaders and followers just widened.\n\n%s is proof. Here's this week's developments 👇",
+		return fmt.Sprintf("The gap between leaders and followers just widened.\n\n%s is proof. Here's this week's developments 👇",
 			extractProductName(topItem.Title))
 
 	case "Pattern3":
 		// "While everyone talks about X, Y quietly shipped Z"
 		if len(sortedItems) >= 2 {
-			return fmt.Sprintf("While everyone talks about %s, %s quietly shipped game-changing updates.\n\nHere's what you missed this week 👇",
-				extractTopic(sortedItems[1].Title), extractProductName(topItem.Title))
+			return fmt.Sprintf("While everyone talks about %s, %s quietly shipped meaningful updates.\n\nHere's what you missed this week 👇",
+				extractTopic(sortedItems[1]), extractProductName(topItem.Title))
 		}
-		return "While everyone talks about AI hype, real engineering breakthroughs happened this week.\n\nHere's what actually matters 👇"
+		return "While everyone talks about hype, real engineering progress happened this week.\n\nHere's what actually matters 👇"
 
 	default:
 		// Default engaging hook
-		return fmt.Sprintf("%s developments this week that engineering teams are already using.\n\nFrom %s to practical applications - here's what you need to know 👇",
-			themes[0], extractProductName(topItem.Title))
+		if len(themes) > 0 {
+			return fmt.Sprintf("%s developments this week that engineering teams are already using.\n\nFrom %s to practical applications - here's what you need to know 👇",
+				themes[0], extractProductName(topItem.Title))
+		}
+		return fmt.Sprintf("From %s to practical applications - here's what engineering teams need to know this week 👇",
+			extractProductName(topItem.Title))
 	}
 }
 
-// extractProductName extracts product name from article title for hooks
+// extractProductName picks the leading proper noun from an article title for hooks,
+// so new products are named without a hard-coded list
 func extractProductName(title string) string {
-	title = strings.ToLower(title)
-
-	// Common product patterns
-	products := []string{
-		"gemini", "claude", "chatgpt", "copilot", "jules",
-		"deep think", "opus", "gpt-4", "llama", "midjourney",
-	}
-
-	for _, product := range products {
-		if strings.Contains(title, product) {
-			// Capitalize first letter
-			return strings.ToUpper(string(product[0])) + product[1:]
-		}
-	}
-
-	// Fall back to first significant word
 	words := strings.Fields(title)
-	for _, word := range words {
-		if len(word) > 3 && !isStopWord(word) {
-			return strings.ToUpper(string(word[0])) + word[1:]
+	for i, word := range words {
+		word = strings.Trim(word, ".,:;!?\"'()[]")
+		if len(word) < 2 || isStopWord(word) || isHeadlineVerb(word) {
+			continue
+		}
+		// Title-cased headlines capitalize everything, so prefer the first word that
+		// is capitalized or contains a digit (e.g. "GPT-5", "Go 1.24")
+		if strings.ToUpper(word[:1]) == word[:1] && word[:1] != strings.ToLower(word[:1]) || strings.ContainsAny(word, "0123456789") {
+			// Keep a following version number with the name
+			if i+1 < len(words) && strings.ContainsAny(words[i+1], "0123456789") {
+				return word + " " + strings.Trim(words[i+1], ".,:;!?\"'()[]")
+			}
+			return word
 		}
 	}
 
-	return "AI tools"
+	return "This week's top story"
 }
 
-// extractCompanyName extracts company name from article title for hooks
-func extractCompanyName(title string) string {
-	title = strings.ToLower(title)
+// isHeadlineVerb reports words that open headlines without naming anything
+func isHeadlineVerb(word string) bool {
+	switch strings.ToLower(word) {
+	case "introducing", "announcing", "meet", "inside", "how", "why", "what", "when":
+		return true
+	}
+	return false
+}
 
-	companies := []string{
-		"google", "anthropic", "openai", "microsoft", "meta",
-		"apple", "amazon", "nvidia", "deepmind",
+// extractCompanyName derives the publishing organization from the article URL
+// (e.g. "https://blog.example.com/post" → "Example")
+func extractCompanyName(url string) string {
+	domain := extractDomainFromURL(url)
+	if domain == "" {
+		return ""
 	}
 
-	for _, company := range companies {
-		if strings.Contains(title, company) {
-			return strings.ToUpper(string(company[0])) + company[1:]
-		}
+	parts := strings.Split(domain, ".")
+	if len(parts) < 2 {
+		return ""
 	}
+	name := parts[len(parts)-2]
 
-	return ""
+	// Aggregators and code hosts say nothing about who shipped the news
+	switch name {
+	case "github", "medium", "substack", "ycombinator", "reddit", "youtube", "twitter", "x":
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
 }
 
-// extractTopic extracts general topic from title for contrast hooks
-func extractTopic(title string) string {
-	title = strings.ToLower(title)
-
-	if strings.Contains(title, "ai") || strings.Contains(title, "artificial intelligence") {
-		return "AI"
-	}
-	if strings.Contains(title, "code") || strings.Contains(title, "coding") || strings.Contains(title, "programming") {
-		return "coding"
+// extractTopic names the topic of an item for contrast hooks, preferring its cluster label
+func extractTopic(item render.DigestData) string {
+	if item.TopicCluster != "" {
+		return item.TopicCluster
 	}
-	if strings.Contains(title, "cloud") || strings.Contains(title, "infrastructure") {
-		return "cloud infrastructure"
-	}
-
 	return "tech developments"
 }
 
-// isStopWord checks if word should be ignored in product name extraction
+// isStopWord checks if word should be ignored in product name extraction. Function
+// words of other languages count too, so non-English headlines don't name "Die" or "Les".
 func isStopWord(word string) bool {
 	stopWords := []string{
 		"the", "a", "an", "and", "or", "but", "in", "on", "at", "to", "for",
@@ -923,7 +1029,7 @@ func isStopWord(word string) bool {
 			return true
 		}
 	}
-	return false
+	return language.IsStopword(word, "")
 }
 
 // extractDomainFromURL extracts the domain name from a URL for source attribution
@@ -951,50 +1057,21 @@ func extractDomainFromURL(url string) string {
 }
 
 // selectGameChanger identifies the most impactful article for "This Week's Game-Changer" section
+// It takes the highest-priority item, which follows the article's signal score when set,
+// skipping items whose summaries are too thin to feature
 func selectGameChanger(digestItems []render.DigestData) *render.DigestData {
 	if len(digestItems) == 0 {
 		return nil
 	}
 
-	// Sort by priority to get the most impactful items
 	sortedItems := SortByPriority(digestItems)
 
-	// Look for the highest impact item that's not just breaking news
 	for _, item := range sortedItems {
-		// Skip items with generic or unclear summaries
 		if strings.Contains(strings.ToLower(item.SummaryText), "no identifiable information") ||
 			len(item.SummaryText) < 50 {
 			continue
 		}
-
-		// Prefer items with clear practical impact
-		title := strings.ToLower(item.Title)
-		summary := strings.ToLower(item.SummaryText)
-
-		// Boost score for items with practical impact keywords
-		impactScore := item.PriorityScore
-
-		if strings.Contains(title, "launch") || strings.Contains(title, "available") ||
-			strings.Contains(title, "release") || strings.Contains(summary, "now available") {
-			impactScore += 0.2 // Production-ready tools
-		}
-
-		if strings.Contains(summary, "developer") || strings.Contains(summary, "coding") ||
-			strings.Contains(summary, "programming") || strings.Contains(summary, "engineer") {
-			impactScore += 0.15 // Direct relevance to engineers
-		}
-
-		if item.AlertTriggered {
-			impactScore += 0.1 // Alert-worthy developments
-		}
-
-		// Update the item's priority score for comparison
-		item.PriorityScore = impactScore
-
-		// Return the first high-impact item
-		if impactScore > 0.6 {
-			return &item
-		}
+		return &item
 	}
 
 	// Fallback to highest priority item
@@ -1002,7 +1079,8 @@ func selectGameChanger(digestItems []render.DigestData) *render.DigestData {
 }
 
 // formatGameChanger formats the game-changer section with winner details
-func formatGameChanger(gameChanger *render.DigestData) string {
+// LLM-written copy is used when provided; otherwise each line falls back to heuristics
+func formatGameChanger(gameChanger *render.DigestData, linkedInCopy *llm.LinkedInCopy) string {
 	if gameChanger == nil {
 		return ""
 	}
@@ -1018,16 +1096,15 @@ func formatGameChanger(gameChanger *render.DigestData) string {
 	}
 	content.WriteString(fmt.Sprintf("**Winner:** %s\n\n", winnerTitle))
 
-	// Why It Matters - extract key benefit from summary
 	whyItMatters := generateWhyItMatters(gameChanger.SummaryText)
-	content.WriteString(fmt.Sprintf("**Why It Matters:** %s\n\n", whyItMatters))
-
-	// Try It - generate actionable suggestion
 	tryIt := generateTryItSuggestion(gameChanger.Title, gameChanger.SummaryText)
-	content.WriteString(fmt.Sprintf("**Try It:** %s\n\n", tryIt))
-
-	// Reality Check - add balanced perspective
 	realityCheck := generateRealityCheck(gameChanger.SummaryText)
+	if linkedInCopy != nil {
+		whyItMatters, tryIt, realityCheck = linkedInCopy.WhyItMatters, linkedInCopy.TryIt, linkedInCopy.RealityCheck
+	}
+
+	content.WriteString(fmt.Sprintf("**Why It Matters:** %s\n\n", whyItMatters))
+	content.WriteString(fmt.Sprintf("**Try It:** %s\n\n", tryIt))
 	content.WriteString(fmt.Sprintf("**Reality Check:** %s\n\n", realityCheck))
 
 	// User Take - add personal commentary if available
@@ -1039,53 +1116,38 @@ func formatGameChanger(gameChanger *render.DigestData) string {
 }
 
 // generateWhyItMatters extracts the key benefit for engineers from article summary
+// Uses the summary's lead sentence, which names the actual development
 func generateWhyItMatters(summary string) string {
-	summary = strings.ToLower(summary)
-
-	// Look for key impact phrases
-	if strings.Contains(summary, "parallel thinking") || strings.Contains(summary, "complex") {
-		return "Parallel thinking for complex code = junior dev tasks automated"
-	}
-	if strings.Contains(summary, "coding") && strings.Contains(summary, "assistant") {
-		return "AI coding assistance reaches production-ready quality"
+	lead := strings.TrimSpace(summary)
+	// Split on sentence ends, not the dots in version numbers like "2.0"
+	if idx := strings.Index(lead, ". "); idx > 0 {
+		lead = lead[:idx]
 	}
-	if strings.Contains(summary, "beta") || strings.Contains(summary, "available") {
-		return "Production-ready tool for immediate team adoption"
+	lead = strings.TrimSuffix(lead, ".")
+	if lead == "" {
+		return "A capability engineering teams can evaluate today"
 	}
-	if strings.Contains(summary, "improve") && (strings.Contains(summary, "coding") || strings.Contains(summary, "development")) {
-		return "Significant productivity gains for development workflows"
-	}
-	if strings.Contains(summary, "breakthrough") || strings.Contains(summary, "advance") {
-		return "Major capability leap that changes what's possible"
-	}
-
-	// Fallback to generic but engaging message
-	return "Game-changing capability that engineering teams can use today"
+	return truncateToWords(lead, 25)
 }
 
 // generateTryItSuggestion creates actionable advice for testing the tool/feature
 func generateTryItSuggestion(title, summary string) string {
-	title = strings.ToLower(title)
 	summary = strings.ToLower(summary)
+	product := extractProductName(title)
 
-	if strings.Contains(title, "deep think") || strings.Contains(summary, "complex") {
-		return "Upload your gnarliest legacy codebase section and ask for refactoring suggestions"
-	}
-	if strings.Contains(title, "jules") || strings.Contains(summary, "coding agent") {
-		return "Connect to your GitHub and let it handle a simple PR workflow"
+	if strings.Contains(summary, "open source") || strings.Contains(summary, "github") {
+		return fmt.Sprintf("Clone %s and run it against a small slice of your own codebase", product)
 	}
-	if strings.Contains(title, "claude") && strings.Contains(summary, "coding") {
-		return "Test it on a complex debugging session with multi-file context"
+	if strings.Contains(summary, "agent") {
+		return fmt.Sprintf("Hand %s one well-scoped ticket and review its work like a PR", product)
 	}
-	if strings.Contains(title, "copilot") || strings.Contains(summary, "assistant") {
-		return "Try it on your most challenging algorithm implementation this week"
+	if strings.Contains(summary, "api") || strings.Contains(summary, "sdk") {
+		return fmt.Sprintf("Prototype one call to the %s API behind a feature flag", product)
 	}
-	if strings.Contains(summary, "text-to-speech") || strings.Contains(summary, "voice") {
+	if strings.Contains(summary, "voice") || strings.Contains(summary, "speech") {
 		return "Generate voice narration for your technical documentation"
 	}
 
-	// Extract product name and create generic suggestion
-	product := extractProductName(title)
 	return fmt.Sprintf("Test %s on your current project's biggest technical challenge", product)
 }
 
@@ -1226,6 +1288,64 @@ func extractTopicThemes(digestItems []render.DigestData) []string {
 	return themes
 }
 
+// scannableTitle renders a scannable article title in layout: "[num] emoji title"
+// with links, or "emoji title" followed by its footnote reference in footnote mode,
+// where the priority-order number would read as a citation
+func scannableTitle(layout string, num int, emoji, title, ref string) string {
+	if ref == "" {
+		return fmt.Sprintf(layout, fmt.Sprintf("[%d] %s %s", num, emoji, title), "")
+	}
+	return fmt.Sprintf(layout, emoji+" "+title, ref)
+}
+
+// digestFootnotes numbers the digest's articles in order
+func digestFootnotes(digestItems []render.DigestData) *footnotes.Set {
+	set := footnotes.New(1)
+	for _, item := range digestItems {
+		set.Add(item.URL, item.Title)
+	}
+	return set
+}
+
+// footnoteRef returns an article's footnote reference, e.g. "[^3]", when the
+// template cites with footnotes, or ""
+func footnoteRef(template *DigestTemplate, digestItems []render.DigestData, url string) string {
+	if !template.Footnotes {
+		return ""
+	}
+	return digestFootnotes(digestItems).Ref(url)
+}
+
+// finishMarkdown ends a rendered markdown digest: with footnotes, every citation
+// resolves to one references block, and the publication footer closes it
+func finishMarkdown(content string, digestItems []render.DigestData, template *DigestTemplate) string {
+	return applyFootnotes(content, digestItems, template) + template.Branding.MarkdownFooter()
+}
+
+// applyFootnotes rewrites the citations in a rendered digest as footnote references
+// and appends the references block, when the template cites with footnotes.
+// Citations that resolve to no article are logged.
+func applyFootnotes(content string, digestItems []render.DigestData, template *DigestTemplate) string {
+	if !template.Footnotes {
+		return content
+	}
+	set := digestFootnotes(digestItems)
+	content = set.Cite(content)
+	if !strings.HasSuffix(content, "\n\n") {
+		content = strings.TrimRight(content, "\n") + "\n\n"
+	}
+	content += set.References()
+
+	problems := footnotes.Validate(content)
+	for _, unresolved := range set.Unresolved() {
+		problems = append(problems, unresolved+" matches no article in the digest")
+	}
+	for _, problem := range problems {
+		logger.Get().Warn("Unresolved digest citation", "format", template.Format, "problem", problem)
+	}
+	return content
+}
+
 // formatScannableLink formats links with source attribution for scannable format
 func formatScannableLink(url string) string {
 	domain := extractDomainFromURL(url)
@@ -1292,6 +1412,62 @@ func getContentTypeEmoji(contentType, title string) string {
 // capitalizeFirst capitalizes the first letter of a string
 // capitalizeFirst removed as unused
 
+// renderMustReadSection renders the Must Read highlight as a callout box linking to
+// the article, with why it matters and its read time
+func renderMustReadSection(mustRead *core.MustReadHighlight, digestItems []render.DigestData, template *DigestTemplate) string {
+	if !template.IncludeMustRead || mustRead == nil || mustRead.Title == "" {
+		return ""
+	}
+
+	title := mustRead.Title
+	if mustRead.ArticleNum >= 1 && mustRead.ArticleNum <= len(digestItems) && digestItems[mustRead.ArticleNum-1].URL != "" {
+		title = fmt.Sprintf("[%s](%s)", mustRead.Title, digestItems[mustRead.ArticleNum-1].URL)
+	}
+
+	var content strings.Builder
+	content.WriteString("> ### 🎯 Must Read\n")
+	content.WriteString(">\n")
+	content.WriteString(fmt.Sprintf("> **%s**\n", title))
+	if mustRead.WhyMustRead != "" {
+		content.WriteString(">\n")
+		content.WriteString(fmt.Sprintf("> %s\n", mustRead.WhyMustRead))
+	}
+	if mustRead.ReadTime > 0 {
+		content.WriteString(">\n")
+		content.WriteString(fmt.Sprintf("> 📖 %d min read\n", mustRead.ReadTime))
+	}
+	content.WriteString("\n")
+
+	return content.String()
+}
+
+// statCitation matches a [N] citation in a statistic's context
+var statCitation = regexp.MustCompile(`\[(\d+)\]`)
+
+// renderByTheNumbersSection renders key statistics, turning each [N] citation into a
+// link to the Nth article. Citations without a matching article are left as text.
+func renderByTheNumbersSection(stats []core.Statistic, digestItems []render.DigestData, template *DigestTemplate) string {
+	if !template.IncludeByTheNumbers || len(stats) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString("## 📊 By the Numbers\n\n")
+	for _, stat := range stats {
+		context := statCitation.ReplaceAllStringFunc(stat.Context, func(ref string) string {
+			num, err := strconv.Atoi(ref[1 : len(ref)-1])
+			if err != nil || num < 1 || num > len(digestItems) || digestItems[num-1].URL == "" {
+				return ref
+			}
+			return fmt.Sprintf("[[%d]](%s)", num, digestItems[num-1].URL)
+		})
+		content.WriteString(fmt.Sprintf("- **%s** — %s\n", stat.Stat, context))
+	}
+	content.WriteString("\n")
+
+	return content.String()
+}
+
 // renderAlertsSection renders just the alerts section
 func renderAlertsSection(digestItems []render.DigestData, alertsSummary string) string {
 	var content strings.Builder
@@ -1384,6 +1560,15 @@ func renderInsightsSection(digestItems []render.DigestData, template *DigestTemp
 	return content.String()
 }
 
+// renderTrendReportSection renders the template's trend report highlights, or ""
+// when it has none
+func renderTrendReportSection(template *DigestTemplate) string {
+	if template.TrendReport == "" {
+		return ""
+	}
+	return "\n## 📈 Trends This Week\n\n" + strings.TrimSpace(template.TrendReport) + "\n"
+}
+
 // renderActionableSection renders the "Try This Week" section with specific, actionable recommendations
 func renderActionableSection(digestItems []render.DigestData, template *DigestTemplate) string {
 	var content strings.Builder
@@ -1608,7 +1793,7 @@ func isCommonWord(word string) bool {
 		"would": true, "could": true, "should": true, "may": true, "might": true, "can": true,
 		"this": true, "that": true, "these": true, "those": true, "your": true, "you": true,
 	}
-	return commonWords[strings.ToLower(word)]
+	return commonWords[strings.ToLower(word)] || language.IsStopword(word, "")
 }
 
 // getSentimentEmoji returns the appropriate emoji for a sentiment label
@@ -1629,7 +1814,8 @@ func getSentimentEmoji(sentimentLabel string) string {
 
 // renderBannerSection renders the banner image section for formats that support it
 func renderBannerSection(banner *core.BannerImage, template *DigestTemplate, format string) string {
-	if banner == nil || !template.IncludeBanner {
+	// A banner without an image only carries themes and alt text for later generation
+	if banner == nil || banner.ImageURL == "" || !template.IncludeBanner {
 		return ""
 	}
 
@@ -1652,7 +1838,7 @@ func renderBannerSection(banner *core.BannerImage, template *DigestTemplate, for
 	case "html":
 		// HTML format for email templates
 		content.WriteString(fmt.Sprintf(`<img src="%s" alt="%s" style="width: 100%%; max-width: 600px; height: auto; border-radius: 8px; margin-bottom: 20px;" />`,
-			banner.ImageURL, banner.AltText))
+			html.EscapeString(banner.ImageURL), html.EscapeString(banner.AltText)))
 		content.WriteString("\n\n")
 
 	case "plain":
@@ -1695,6 +1881,9 @@ func RenderWithTemplateAndMyTakeWithTitle(digestItems []render.DigestData, outpu
 
 	var content strings.Builder
 
+	// Publication logo
+	content.WriteString(template.Branding.MarkdownHeader())
+
 	// Header - use custom title if provided, otherwise use template title
 	title := template.Title
 	if customTitle != "" {
@@ -1717,6 +1906,9 @@ func RenderWithTemplateAndMyTakeWithTitle(digestItems []render.DigestData, outpu
 	// Process each article using helper function
 	content.WriteString(renderArticlesSection(digestItems, template))
 
+	// The week's trend report highlights
+	content.WriteString(renderTrendReportSection(template))
+
 	// Conclusion
 	if template.ConclusionText != "" {
 		content.WriteString(template.SectionSeparator)
@@ -1745,7 +1937,7 @@ func RenderWithTemplateAndMyTakeWithTitle(digestItems []render.DigestData, outpu
 	}
 
 	// Write to file
-	return render.WriteDigestToFile(content.String(), outputDir, filename)
+	return render.WriteDigestToFile(finishMarkdown(content.String(), digestItems, template), outputDir, filename)
 }
 
 // RenderWithTemplateAndMyTakeReturnContent renders a digest using the specified template and includes digest-level my-take,
@@ -1766,6 +1958,9 @@ func RenderWithTemplateAndMyTakeReturnContentWithTitle(digestItems []render.Dige
 
 	var content strings.Builder
 
+	// Publication logo
+	content.WriteString(template.Branding.MarkdownHeader())
+
 	// Header - use custom title if provided, otherwise use template title
 	title := template.Title
 	if customTitle != "" {
@@ -1788,6 +1983,9 @@ func RenderWithTemplateAndMyTakeReturnContentWithTitle(digestItems []render.Dige
 	// Process each article using helper function
 	content.WriteString(renderArticlesSection(digestItems, template))
 
+	// The week's trend report highlights
+	content.WriteString(renderTrendReportSection(template))
+
 	// Discussion prompt section for LinkedIn engagement
 	if template.IncludeDiscussionPrompt && len(digestItems) > 0 {
 		content.WriteString("\n\n## 💭 Your Take?\n\n")
@@ -1822,11 +2020,13 @@ func RenderWithTemplateAndMyTakeReturnContentWithTitle(digestItems []render.Dige
 		content.WriteString("\n")
 	}
 
+	digestContent := finishMarkdown(content.String(), digestItems, template)
+
 	// References removed - now included in Featured Articles section with numbering
 
 	// Write to file and return both content and path
-	filePath, err := render.WriteDigestToFile(content.String(), outputDir, filename)
-	return content.String(), filePath, err
+	filePath, err := render.WriteDigestToFile(digestContent, outputDir, filename)
+	return digestContent, filePath, err
 }
 
 // RenderWithBanner renders a digest with banner image support
@@ -2075,6 +2275,19 @@ func RenderWithStructuredContent(digestItems []render.DigestData, outputDir stri
 
 // RenderWithBannerAndInsights renders a digest with both banner and insights data
 func RenderWithBannerAndInsights(digestItems []render.DigestData, outputDir string, finalDigest string, digestMyTake string, template *DigestTemplate, customTitle string, overallSentiment string, alertsSummary string, trendsSummary string, researchSuggestions []string, banner *core.BannerImage) (string, string, error) {
+	return RenderWithHighlights(digestItems, outputDir, finalDigest, digestMyTake, template, customTitle, overallSentiment, alertsSummary, trendsSummary, researchSuggestions, banner, DigestHighlights{})
+}
+
+// DigestHighlights holds the generated digest-level highlights rendered alongside the articles.
+// Article numbers and [N] citations refer to digestItems in order, starting at 1.
+type DigestHighlights struct {
+	MustRead     *core.MustReadHighlight // Single article worth reading in full
+	ByTheNumbers []core.Statistic        // Key metrics with citations
+}
+
+// RenderWithHighlights renders a digest with banner, insights, and the digest-level
+// highlights (Must Read callout and "By the Numbers" statistics)
+func RenderWithHighlights(digestItems []render.DigestData, outputDir string, finalDigest string, digestMyTake string, template *DigestTemplate, customTitle string, overallSentiment string, alertsSummary string, trendsSummary string, researchSuggestions []string, banner *core.BannerImage, highlights DigestHighlights) (string, string, error) {
 	dateStr := time.Now().UTC().Format("2006-01-02")
 	filename := fmt.Sprintf("digest_%s_%s.md", strings.ToLower(string(template.Format)), dateStr)
 
@@ -2102,9 +2315,20 @@ func RenderWithBannerAndInsights(digestItems []render.DigestData, outputDir stri
 		content.WriteString(fmt.Sprintf("%s\n\n", template.IntroductionText))
 	}
 
+	// LinkedIn hook and Game-Changer copy are LLM-written when available
+	var gameChanger *render.DigestData
+	var linkedInCopy *llm.LinkedInCopy
+	if (template.IncludeLinkedInHook || template.IncludeGameChanger) && len(digestItems) > 0 {
+		gameChanger = selectGameChanger(digestItems)
+		linkedInCopy = generateLinkedInCopy(digestItems, finalDigest, gameChanger)
+	}
+
 	// LinkedIn Hook (LinkedIn optimization)
 	if template.IncludeLinkedInHook && len(digestItems) > 0 {
 		linkedInHook := generateLinkedInHook(digestItems, "Pattern1")
+		if linkedInCopy != nil {
+			linkedInHook = linkedInCopy.Hook
+		}
 		if linkedInHook != "" {
 			content.WriteString(linkedInHook)
 			content.WriteString("\n\n")
@@ -2126,15 +2350,24 @@ func RenderWithBannerAndInsights(digestItems []render.DigestData, outputDir stri
 		content.WriteString("\n\n")
 	}
 
+	// Must Read callout box
+	content.WriteString(renderMustReadSection(highlights.MustRead, digestItems, template))
+
 	// Game-Changer section (LinkedIn optimization)
 	if template.IncludeGameChanger && len(digestItems) > 0 {
-		gameChanger := selectGameChanger(digestItems)
 		if gameChanger != nil {
-			content.WriteString(formatGameChanger(gameChanger))
+			content.WriteString(formatGameChanger(gameChanger, linkedInCopy))
 			content.WriteString("---\n\n")
 		}
 	}
 
+	// By the Numbers section with links to each stat's source articles
+	numbersSection := renderByTheNumbersSection(highlights.ByTheNumbers, digestItems, template)
+	if numbersSection != "" {
+		content.WriteString(numbersSection)
+		content.WriteString("---\n\n")
+	}
+
 	// Alert Monitoring Section (moved up for prominence) - skip for scannable format
 	if template.Format != FormatScannableNewsletter {
 		alertsSection := renderAlertsSection(digestItems, alertsSummary)
@@ -2170,6 +2403,9 @@ func RenderWithBannerAndInsights(digestItems []render.DigestData, outputDir stri
 		content.WriteString(articlesSection)
 	}
 
+	// The week's trend report highlights
+	content.WriteString(renderTrendReportSection(template))
+
 	// Discussion prompt section for LinkedIn engagement
 	if template.IncludeDiscussionPrompt && len(digestItems) > 0 {
 		content.WriteString("\n\n## 💭 Your Take?\n\n")
@@ -2205,10 +2441,11 @@ func RenderWithBannerAndInsights(digestItems []render.DigestData, outputDir stri
 		content.WriteString("\n")
 	}
 
+	digestContent := finishMarkdown(content.String(), digestItems, template)
+
 	// References removed - now included in Featured Articles section with numbering
 
 	// v2.0: Add word count and read time statistics
-	digestContent := content.String()
 	wordCount := countWords(digestContent)
 	if template.MaxDigestWords > 0 && wordCount > 0 {
 		// Insert word count header after the title
@@ -2221,6 +2458,9 @@ func RenderWithBannerAndInsights(digestItems []render.DigestData, outputDir stri
 		}
 	}
 
+	// Publication logo, above the title and word count
+	digestContent = template.Branding.MarkdownHeader() + digestContent
+
 	// Write to file and return both content and path
 	filePath, err := render.WriteDigestToFile(digestContent, outputDir, filename)
 	return digestContent, filePath, err
@@ -2228,11 +2468,13 @@ func RenderWithBannerAndInsights(digestItems []render.DigestData, outputDir stri
 
 // RenderHTMLEmail renders a digest as HTML email
 func RenderHTMLEmail(digestItems []render.DigestData, outputDir string, finalDigest string, customTitle string, overallSentiment string, alertsSummary string, trendsSummary string, researchSuggestions []string, emailStyle string) (string, string, error) {
-	return RenderHTMLEmailWithBanner(digestItems, outputDir, finalDigest, customTitle, overallSentiment, alertsSummary, trendsSummary, researchSuggestions, emailStyle, nil)
+	return RenderHTMLEmailWithBanner(digestItems, outputDir, finalDigest, customTitle, overallSentiment, alertsSummary, trendsSummary, researchSuggestions, emailStyle, nil, true, nil)
 }
 
-// RenderHTMLEmailWithBanner renders a digest as HTML email with banner support
-func RenderHTMLEmailWithBanner(digestItems []render.DigestData, outputDir string, finalDigest string, customTitle string, overallSentiment string, alertsSummary string, trendsSummary string, researchSuggestions []string, emailStyle string, banner *core.BannerImage) (string, string, error) {
+// RenderHTMLEmailWithBanner renders a digest as HTML email with banner support. With
+// remoteImages false, articles render as text-only cards and the banner is left out.
+// branding, when set, adds the publication's logo and replaces the default footer.
+func RenderHTMLEmailWithBanner(digestItems []render.DigestData, outputDir string, finalDigest string, customTitle string, overallSentiment string, alertsSummary string, trendsSummary string, researchSuggestions []string, emailStyle string, banner *core.BannerImage, remoteImages bool, branding *render.Branding) (string, string, error) {
 	template := GetTemplate(FormatEmail)
 
 	// Choose email template style
@@ -2245,6 +2487,7 @@ func RenderHTMLEmailWithBanner(digestItems []render.DigestData, outputDir string
 	default:
 		emailTemplate = email.GetDefaultEmailTemplate()
 	}
+	emailTemplate.RemoteImages = remoteImages
 
 	// Convert digest data to email format
 	title := customTitle
@@ -2264,6 +2507,7 @@ func RenderHTMLEmailWithBanner(digestItems []render.DigestData, outputDir string
 		researchSuggestions,
 		banner,
 	)
+	emailData.Branding = branding
 
 	// Render HTML email
 	htmlContent, err := email.RenderHTMLEmail(emailData, emailTemplate)
@@ -2486,7 +2730,8 @@ func extractSignalThemes(digestItems []render.DigestData) []string {
 	for _, item := range digestItems {
 		words := strings.Fields(strings.ToLower(item.Title))
 		for _, word := range words {
-			if len(word) > 4 && !isSignalStopWord(word) {
+			word = strings.Trim(word, ".,:;!?\"'()[]«»")
+			if utf8.RuneCountInString(word) > 4 && !isSignalStopWord(word) {
 				themes[word]++
 			}
 		}
@@ -2509,7 +2754,7 @@ func isSignalStopWord(word string) bool {
 		"this": true, "that": true, "your": true, "you": true, "are": true,
 		"how": true, "why": true, "what": true, "when": true, "where": true,
 	}
-	return stopWords[word]
+	return stopWords[word] || language.IsStopword(word, "")
 }
 
 // groupSignalItemsByCategory groups digest items by category for Signal format
@@ -1,7 +1,10 @@
 package templates
 
 import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
 	"briefly/internal/render"
+	"fmt"
 	"math"
 	"strings"
 	"testing"
@@ -129,6 +132,35 @@ func TestGetTemplateUnknown(t *testing.T) {
 	}
 }
 
+func TestOverridesApply(t *testing.T) {
+	template := GetTemplate(FormatScannableNewsletter)
+	overrides := Overrides{With: []string{"topic-clustering"}, Without: []string{"Game_Changer"}, MaxWords: 600}
+	if err := overrides.Apply(template); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if !template.IncludeTopicClustering || template.IncludeGameChanger || template.MaxDigestWords != 600 {
+		t.Errorf("overrides not applied: clustering=%v game-changer=%v max words=%d",
+			template.IncludeTopicClustering, template.IncludeGameChanger, template.MaxDigestWords)
+	}
+	if !template.IncludeLinkedInHook {
+		t.Error("expected features not named to keep the format's setting")
+	}
+
+	for _, bad := range []Overrides{
+		{With: []string{"sparkles"}},
+		{With: []string{"banner"}, Without: []string{"banner"}},
+		{MaxWords: -1},
+	} {
+		template := GetTemplate(FormatStandard)
+		if err := bad.Apply(template); err == nil {
+			t.Errorf("expected %+v to be rejected", bad)
+		}
+		if !template.IncludeMustRead || template.IncludeBanner {
+			t.Errorf("expected a rejected override to leave the template unchanged, got %+v", template)
+		}
+	}
+}
+
 func TestGroupArticlesByTopic(t *testing.T) {
 	digestItems := []render.DigestData{
 		{
@@ -348,6 +380,19 @@ func TestRenderInsightsSectionEmpty(t *testing.T) {
 	}
 }
 
+func TestRenderTrendReportSection(t *testing.T) {
+	template := GetTemplate(FormatNewsletter)
+	if result := renderTrendReportSection(template); result != "" {
+		t.Errorf("Expected no trends section without a report, got: %s", result)
+	}
+
+	template.TrendReport = "**This week's keywords:** agents, MCP\n\n"
+	result := renderTrendReportSection(template)
+	if !strings.Contains(result, "## 📈 Trends This Week") || !strings.HasSuffix(result, "agents, MCP\n") {
+		t.Errorf("Expected the report under a trends heading, got: %q", result)
+	}
+}
+
 func TestRenderInsightsSectionWithData(t *testing.T) {
 	template := GetTemplate(FormatDetailed)
 	digestItems := []render.DigestData{
@@ -374,3 +419,235 @@ func TestRenderInsightsSectionWithData(t *testing.T) {
 		t.Error("Expected research suggestion content")
 	}
 }
+
+func TestRenderByTheNumbersSectionLinksCitations(t *testing.T) {
+	digestItems := []render.DigestData{
+		{Title: "Caching", URL: "https://example.com/caching"},
+		{Title: "Networking", URL: "https://example.com/net"},
+	}
+	stats := []core.Statistic{
+		{Stat: "60%", Context: "Fewer database queries [1]"},
+		{Stat: "400 Gbps", Context: "New interconnect throughput [2][7]"},
+	}
+
+	for _, format := range []DigestFormat{FormatDetailed, FormatNewsletter, FormatScannableNewsletter} {
+		result := renderByTheNumbersSection(stats, digestItems, GetTemplate(format))
+		if !strings.Contains(result, "## 📊 By the Numbers") {
+			t.Errorf("%s: expected By the Numbers header", format)
+		}
+		if !strings.Contains(result, "**60%** — Fewer database queries [[1]](https://example.com/caching)") {
+			t.Errorf("%s: expected linked citation, got: %s", format, result)
+		}
+		if !strings.Contains(result, "[[2]](https://example.com/net)[7]") {
+			t.Errorf("%s: expected unknown citation to stay unlinked, got: %s", format, result)
+		}
+	}
+
+	if result := renderByTheNumbersSection(stats, digestItems, GetTemplate(FormatBrief)); result != "" {
+		t.Errorf("Expected no By the Numbers section for brief format, got: %s", result)
+	}
+	if result := renderByTheNumbersSection(nil, digestItems, GetTemplate(FormatDetailed)); result != "" {
+		t.Errorf("Expected no section without statistics, got: %s", result)
+	}
+}
+
+func TestRenderMustReadSection(t *testing.T) {
+	digestItems := []render.DigestData{
+		{Title: "Caching", URL: "https://example.com/caching"},
+		{Title: "Networking", URL: "https://example.com/net"},
+	}
+	mustRead := &core.MustReadHighlight{
+		ArticleNum:  2,
+		Title:       "Networking",
+		WhyMustRead: "Explains the new interconnect every platform team will adopt.",
+		ReadTime:    12,
+	}
+
+	for _, format := range []DigestFormat{FormatStandard, FormatNewsletter} {
+		result := renderMustReadSection(mustRead, digestItems, GetTemplate(format))
+		if !strings.Contains(result, "> ### 🎯 Must Read") {
+			t.Errorf("%s: expected Must Read callout, got: %s", format, result)
+		}
+		if !strings.Contains(result, "> **[Networking](https://example.com/net)**") {
+			t.Errorf("%s: expected linked title, got: %s", format, result)
+		}
+		if !strings.Contains(result, "> Explains the new interconnect") || !strings.Contains(result, "> 📖 12 min read") {
+			t.Errorf("%s: expected why-it-matters and read time, got: %s", format, result)
+		}
+	}
+
+	if result := renderMustReadSection(mustRead, digestItems, GetTemplate(FormatBrief)); result != "" {
+		t.Errorf("Expected no Must Read callout for brief format, got: %s", result)
+	}
+	if result := renderMustReadSection(nil, digestItems, GetTemplate(FormatStandard)); result != "" {
+		t.Errorf("Expected no callout without a must-read, got: %s", result)
+	}
+
+	unnumbered := &core.MustReadHighlight{ArticleNum: 9, Title: "Elsewhere"}
+	if result := renderMustReadSection(unnumbered, digestItems, GetTemplate(FormatStandard)); !strings.Contains(result, "> **Elsewhere**") {
+		t.Errorf("Expected unlinked title for unknown article number, got: %s", result)
+	}
+}
+
+func TestFormatGameChangerUsesLLMCopy(t *testing.T) {
+	item := &render.DigestData{
+		Title:       "Acme ships Widget 2.0 with streaming inference",
+		URL:         "https://blog.acme.dev/widget-2",
+		SummaryText: "Acme released Widget 2.0, an open source inference server that halves latency on commodity GPUs.",
+	}
+
+	fallback := formatGameChanger(item, nil)
+	if !strings.Contains(fallback, "Acme released Widget 2.0") {
+		t.Errorf("Expected heuristic Why It Matters to use the summary lead, got:\n%s", fallback)
+	}
+
+	withCopy := formatGameChanger(item, &llm.LinkedInCopy{
+		WhyItMatters: "Halved latency without new hardware.",
+		TryIt:        "Swap it in for one staging service.",
+		RealityCheck: "Benchmarks are vendor-run.",
+	})
+	for _, want := range []string{"Halved latency without new hardware.", "Swap it in for one staging service.", "Benchmarks are vendor-run."} {
+		if !strings.Contains(withCopy, want) {
+			t.Errorf("Expected LLM copy %q in section, got:\n%s", want, withCopy)
+		}
+	}
+}
+
+func TestGenerateLinkedInCopyFallsBackOnError(t *testing.T) {
+	original := linkedInCopyGenerator
+	defer func() { linkedInCopyGenerator = original }()
+
+	items := []render.DigestData{{Title: "Acme ships Widget 2.0", SummaryText: "Acme released Widget 2.0 today for everyone."}}
+
+	var gotInput llm.LinkedInCopyInput
+	linkedInCopyGenerator = func(input llm.LinkedInCopyInput) (*llm.LinkedInCopy, error) {
+		gotInput = input
+		return &llm.LinkedInCopy{Hook: "Widget 2.0 is out 👇"}, nil
+	}
+	if result := generateLinkedInCopy(items, "digest", nil); result == nil || result.Hook != "Widget 2.0 is out 👇" {
+		t.Errorf("Expected LLM copy to be returned, got %+v", result)
+	}
+	if gotInput.GameChangerTitle != "Acme ships Widget 2.0" || len(gotInput.TopTitles) != 1 {
+		t.Errorf("Expected game-changer and top titles in input, got %+v", gotInput)
+	}
+
+	linkedInCopyGenerator = func(llm.LinkedInCopyInput) (*llm.LinkedInCopy, error) {
+		return nil, fmt.Errorf("no API key")
+	}
+	if result := generateLinkedInCopy(items, "digest", nil); result != nil {
+		t.Errorf("Expected nil copy on generator error, got %+v", result)
+	}
+}
+
+func TestExtractProductNameIsDataDriven(t *testing.T) {
+	tests := map[string]string{
+		"Introducing Zephyr 3 for edge inference": "Zephyr 3",
+		"the new Kestrel database is fast":        "Kestrel",
+		"lowercase headline with nothing":         "This week's top story",
+		"Die Kestrel 2 Datenbank im Test":         "Kestrel 2",
+		"Les nouveautés de Zephyr 3":              "Zephyr 3",
+	}
+	for title, want := range tests {
+		if got := extractProductName(title); got != want {
+			t.Errorf("extractProductName(%q) = %q, want %q", title, got, want)
+		}
+	}
+
+	themes := extractSignalThemes([]render.DigestData{{Title: "Die Verschlüsselung für Rechenzentren"}, {Title: "Über die Sicherheit, nicht mehr"}})
+	for _, theme := range themes {
+		if theme == "nicht" || theme == "sicherheit," {
+			t.Errorf("Expected function words dropped and accented words kept whole, got %v", themes)
+		}
+	}
+
+	if got := extractCompanyName("https://blog.acme.dev/widget-2"); got != "Acme" {
+		t.Errorf("Expected company from domain, got %q", got)
+	}
+	if got := extractCompanyName("https://github.com/acme/widget"); got != "" {
+		t.Errorf("Expected no company for code hosts, got %q", got)
+	}
+}
+
+func TestFitToWordBudget_KeepsCompleteSentences(t *testing.T) {
+	text := "Go 1.24 ships Swiss-table maps. Lookups are 30% faster in benchmarks. The release also adds generic type aliases for large codebases."
+
+	got := fitToWordBudget(text, 12)
+	if got != "Go 1.24 ships Swiss-table maps. Lookups are 30% faster in benchmarks." {
+		t.Errorf("Expected the two leading sentences, got %q", got)
+	}
+	if strings.HasSuffix(got, "...") {
+		t.Errorf("Expected no ellipsis, got %q", got)
+	}
+
+	if fitToWordBudget(text, 100) != text {
+		t.Error("Expected text within budget to be unchanged")
+	}
+}
+
+func TestTemplateSummaryLimitsMatchLLMBudgets(t *testing.T) {
+	for _, format := range []DigestFormat{FormatBrief, FormatStandard, FormatDetailed, FormatNewsletter, FormatScannableNewsletter, FormatEmail} {
+		template := GetTemplate(format)
+		if budget := llm.FormatBudgetFor(string(format)); budget.MaxWords != template.MaxSummaryLength {
+			t.Errorf("%s: template renders %d words but the LLM budget is %d", format, template.MaxSummaryLength, budget.MaxWords)
+		}
+	}
+}
+
+func TestRenderFigures(t *testing.T) {
+	figures := []core.ArticleImage{
+		{URL: "https://example.com/chart.png", Alt: "Latency chart", Figure: true, Description: "Postgres has the lowest p99 latency."},
+		{URL: "https://example.com/undescribed.png", Figure: true},
+	}
+
+	detailed := GetTemplate(FormatDetailed)
+	result := renderFigures(figures, detailed)
+	if !strings.Contains(result, "📈 **Figure:** Postgres has the lowest p99 latency.") {
+		t.Errorf("Expected figure description, got: %s", result)
+	}
+	if strings.Contains(result, "![") || strings.Contains(result, "undescribed") {
+		t.Errorf("Expected only the described figure without image, got: %s", result)
+	}
+
+	detailed.EmbedFigureImages = true
+	if result := renderFigures(figures, detailed); !strings.Contains(result, "![Latency chart](https://example.com/chart.png)") {
+		t.Errorf("Expected embedded image, got: %s", result)
+	}
+
+	if result := renderFigures(figures, GetTemplate(FormatBrief)); result != "" {
+		t.Errorf("Expected no figures for brief format, got: %s", result)
+	}
+}
+
+func TestRenderWithTemplate_Footnotes(t *testing.T) {
+	items := []render.DigestData{
+		{Title: "Agents ship", URL: "https://a.example", SummaryText: "Agents shipped.", TopicCluster: "AI"},
+		{Title: "Rust 2.0", URL: "https://b.example", SummaryText: "Rust released.", TopicCluster: "Languages"},
+	}
+	template := GetTemplate(FormatStandard)
+	template.Footnotes = true
+
+	content, _, err := RenderWithTemplateAndMyTakeReturnContentWithTitle(items, t.TempDir(), "Agents and Rust both shipped [1][[2]](https://b.example).", "", template, "")
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{
+		"Agents and Rust both shipped [^1][^2].",
+		"#### Agents ship[^1]",
+		"## 📚 References\n\n[^1]: [Agents ship](https://a.example)\n[^2]: [Rust 2.0](https://b.example)\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "[^1]: https://a.example") || strings.Contains(content, "Read more") {
+		t.Errorf("expected no inline links or per-article definitions:\n%s", content)
+	}
+
+	// Scannable titles drop their priority-order number, which would read as a citation
+	scannable := GetTemplate(FormatScannableNewsletter)
+	scannable.Footnotes = true
+	section := renderArticlesSection(items, scannable)
+	if strings.Contains(section, "[1]") || !strings.Contains(section, "Rust 2.0[^2]") {
+		t.Errorf("expected scannable titles cited by footnote:\n%s", section)
+	}
+}
@@ -0,0 +1,26 @@
+// Package textutil holds small text helpers shared by the packages that write digest
+// prose and visuals.
+package textutil
+
+import "strings"
+
+// maxUnendedSentence caps FirstSentence when text has no sentence ending
+const maxUnendedSentence = 100
+
+// FirstSentence returns text up to its first sentence end (. ! or ?). Text without
+// one is returned whole, cut at a word boundary with "..." when it runs past 100
+// characters.
+func FirstSentence(text string) string {
+	text = strings.TrimSpace(text)
+	if end := strings.IndexAny(text, ".!?"); end >= 0 {
+		return text[:end+1]
+	}
+	if len(text) <= maxUnendedSentence {
+		return text
+	}
+	truncated := text[:maxUnendedSentence]
+	if lastSpace := strings.LastIndex(truncated, " "); lastSpace > 0 {
+		truncated = truncated[:lastSpace]
+	}
+	return truncated + "..."
+}
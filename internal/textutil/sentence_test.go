@@ -0,0 +1,24 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFirstSentence(t *testing.T) {
+	tests := []struct {
+		text, want string
+	}{
+		{"  Rust shipped! It is fast.", "Rust shipped!"},
+		{"Is it ready? Not yet.", "Is it ready?"},
+		{"One sentence.", "One sentence."},
+		{"No ending at all", "No ending at all"},
+		{"", ""},
+		{strings.Repeat("word ", 30), strings.TrimSpace(strings.Repeat("word ", 20)) + "..."},
+	}
+	for _, tt := range tests {
+		if got := FirstSentence(tt.text); got != tt.want {
+			t.Errorf("FirstSentence(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
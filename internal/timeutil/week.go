@@ -0,0 +1,11 @@
+// Package timeutil holds calendar helpers shared by packages that group things by week.
+package timeutil
+
+import "time"
+
+// WeekStart returns midnight on the Monday of t's week, in t's location
+func WeekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	day := t.AddDate(0, 0, -offset)
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,20 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []time.Time{
+		monday,
+		time.Date(2025, 6, 9, 23, 59, 0, 0, time.UTC),
+		time.Date(2025, 6, 12, 14, 30, 0, 0, time.UTC),
+		time.Date(2025, 6, 15, 8, 0, 0, 0, time.UTC), // Sunday ends the week
+	} {
+		if got := WeekStart(tt); !got.Equal(monday) {
+			t.Errorf("WeekStart(%s) = %s, want %s", tt, got, monday)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+// Package topics maintains a living markdown page per recurring digest topic. Each
+// run matches its clusters to the topics of earlier runs and appends that week's
+// narrative and articles to the topic's page, building a chronological dossier
+// (e.g. digests/topics/ai-agents.md) that can be shared on its own.
+package topics
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/llm"
+	"briefly/internal/store"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMatchThreshold is the similarity a cluster's centroid needs to a topic's
+// centroid to continue that topic's page rather than start a new one
+const DefaultMatchThreshold = 0.8
+
+// dateLayout dates each page section; a page holds at most one section per day
+const dateLayout = "2006-01-02"
+
+var citationRef = regexp.MustCompile(`\s*\[\d+(?:\s*,\s*\d+)*\]`)
+
+// Update reports one page a run appended to
+type Update struct {
+	Slug     string
+	Title    string
+	Path     string
+	Created  bool // The page was started by this run
+	Sections int  // Dated sections on the page after the update
+}
+
+// Record appends each cluster's section to its topic page under dir, creating pages
+// for clusters that don't continue a known topic, and saves the topic registry.
+// Sections are dated; recording the same topic twice on one day replaces that day's
+// section instead of adding another.
+func Record(cache *store.Store, dir string, clusters []core.TopicCluster, articles map[string]core.Article, date time.Time, threshold float64) ([]Update, error) {
+	pages, err := cache.ListTopicPages()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create topics directory: %w", err)
+	}
+
+	matches := Match(clusters, pages, threshold)
+	taken := make(map[string]bool, len(pages))
+	for _, page := range pages {
+		taken[page.Slug] = true
+	}
+
+	var updates []Update
+	for i, cluster := range clusters {
+		clusterArticles := clusterArticles(cluster, articles)
+		if len(clusterArticles) == 0 {
+			continue
+		}
+
+		var page store.TopicPage
+		created := false
+		if j, ok := matches[i]; ok {
+			page = pages[j]
+		} else {
+			page = store.TopicPage{Slug: uniqueSlug(Slug(cluster.Label), taken), Title: cluster.Label, FirstSeen: date}
+			taken[page.Slug] = true
+			created = true
+		}
+
+		path := filepath.Join(dir, page.Slug+".md")
+		sections, replaced, err := WriteSection(path, page.Title, page.FirstSeen, RenderSection(cluster, clusterArticles, date))
+		if err != nil {
+			return updates, err
+		}
+
+		if !replaced {
+			page.Centroid = meanCentroid(page.Centroid, page.Runs, cluster.Centroid)
+		}
+		page.Runs = sections
+		page.LastSeen = date
+		if err := cache.SaveTopicPage(&page); err != nil {
+			return updates, err
+		}
+		updates = append(updates, Update{Slug: page.Slug, Title: page.Title, Path: path, Created: created, Sections: sections})
+	}
+	return updates, nil
+}
+
+// Match pairs clusters with the topic pages they continue, keyed by cluster index.
+// Pairs are claimed most similar first, each page by at most one cluster, when the
+// centroids are at least threshold apart. Clusters left over continue the unclaimed
+// page with their label's slug, if there is one, which covers runs without
+// embeddings.
+func Match(clusters []core.TopicCluster, pages []store.TopicPage, threshold float64) map[int]int {
+	type candidate struct {
+		cluster    int
+		page       int
+		similarity float64
+	}
+
+	var candidates []candidate
+	for i, cluster := range clusters {
+		if len(cluster.Centroid) == 0 {
+			continue
+		}
+		for j, page := range pages {
+			if similarity := llm.CosineSimilarity(cluster.Centroid, page.Centroid); similarity >= threshold {
+				candidates = append(candidates, candidate{cluster: i, page: j, similarity: similarity})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].similarity > candidates[b].similarity
+	})
+
+	matches := make(map[int]int)
+	pageUsed := make(map[int]bool)
+	for _, c := range candidates {
+		if _, matched := matches[c.cluster]; matched || pageUsed[c.page] {
+			continue
+		}
+		matches[c.cluster] = c.page
+		pageUsed[c.page] = true
+	}
+
+	for i, cluster := range clusters {
+		if _, matched := matches[i]; matched {
+			continue
+		}
+		slug := Slug(cluster.Label)
+		for j, page := range pages {
+			if !pageUsed[j] && page.Slug == slug {
+				matches[i] = j
+				pageUsed[j] = true
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// RenderSection renders one run's section of a topic page: the cluster's narrative,
+// when it has one, and the articles it covered
+func RenderSection(cluster core.TopicCluster, articles []core.Article, date time.Time) string {
+	var section strings.Builder
+
+	heading := cluster.Label
+	if cluster.Narrative != nil && cluster.Narrative.Title != "" {
+		heading = cluster.Narrative.Title
+	}
+	section.WriteString(fmt.Sprintf("## %s — %s\n\n", date.Format(dateLayout), heading))
+
+	if narrative := cluster.Narrative; narrative != nil {
+		if oneLiner := stripCitations(narrative.OneLiner); oneLiner != "" {
+			section.WriteString(oneLiner + "\n\n")
+		}
+		if len(narrative.KeyDevelopments) > 0 {
+			section.WriteString("**Key developments**\n\n")
+			for _, development := range narrative.KeyDevelopments {
+				section.WriteString(fmt.Sprintf("- %s\n", stripCitations(development)))
+			}
+			section.WriteString("\n")
+		}
+		if len(narrative.KeyStats) > 0 {
+			section.WriteString("**By the numbers**\n\n")
+			for _, stat := range narrative.KeyStats {
+				section.WriteString(fmt.Sprintf("- **%s** — %s\n", stat.Stat, stripCitations(stat.Context)))
+			}
+			section.WriteString("\n")
+		}
+	}
+
+	section.WriteString("**Articles**\n\n")
+	for _, article := range articles {
+		title := article.Title
+		if title == "" {
+			title = article.URL
+		}
+		line := fmt.Sprintf("- [%s](%s)", title, article.URL)
+		if article.Publisher != "" {
+			line += " — " + article.Publisher
+		}
+		section.WriteString(line + "\n")
+	}
+	return section.String()
+}
+
+// WriteSection adds a dated section to the page at path, creating the page if
+// needed. A section with the same date is replaced in place; otherwise the new
+// section goes at the end, keeping the page chronological. The page header is
+// rewritten each time so its date range stays current. Returns the number of
+// sections on the page and whether an existing one was replaced.
+func WriteSection(path, title string, firstSeen time.Time, section string) (int, bool, error) {
+	var sections []string
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, false, fmt.Errorf("failed to read topic page: %w", err)
+	}
+	if err == nil {
+		sections = splitSections(string(existing))
+	}
+
+	section = strings.TrimRight(section, "\n") + "\n"
+	date := sectionDate(section)
+	replaced := false
+	for i, current := range sections {
+		if date != "" && sectionDate(current) == date {
+			sections[i] = section
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		sections = append(sections, section)
+	}
+
+	var page strings.Builder
+	page.WriteString(fmt.Sprintf("# %s\n\n", title))
+	lastSeen := sectionDate(sections[len(sections)-1])
+	page.WriteString(fmt.Sprintf("_Topic history from briefly digests: %d %s, first seen %s, last updated %s._\n",
+		len(sections), pluralize(len(sections), "run", "runs"), firstSeen.Format(dateLayout), lastSeen))
+	for _, s := range sections {
+		page.WriteString("\n" + s)
+	}
+
+	if err := os.WriteFile(path, []byte(page.String()), 0644); err != nil {
+		return 0, false, fmt.Errorf("failed to write topic page: %w", err)
+	}
+	return len(sections), replaced, nil
+}
+
+// Slug turns a topic label into a filename-safe slug
+func Slug(label string) string {
+	var slug strings.Builder
+	lastDash := true
+	for _, r := range strings.ToLower(label) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			slug.WriteRune(r)
+			lastDash = false
+		case !lastDash:
+			slug.WriteByte('-')
+			lastDash = true
+		}
+		if slug.Len() >= 60 {
+			break
+		}
+	}
+	result := strings.Trim(slug.String(), "-")
+	if result == "" {
+		return "topic"
+	}
+	return result
+}
+
+// uniqueSlug returns slug, or slug with the first free numeric suffix when taken
+func uniqueSlug(slug string, taken map[string]bool) string {
+	if !taken[slug] {
+		return slug
+	}
+	for n := 2; ; n++ {
+		if candidate := fmt.Sprintf("%s-%d", slug, n); !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// clusterArticles returns the cluster's articles that made it into the run
+func clusterArticles(cluster core.TopicCluster, articles map[string]core.Article) []core.Article {
+	found := make([]core.Article, 0, len(cluster.ArticleIDs))
+	for _, id := range cluster.ArticleIDs {
+		if article, ok := articles[id]; ok {
+			found = append(found, article)
+		}
+	}
+	return found
+}
+
+// meanCentroid folds a cluster centroid into a topic's running mean over runs
+func meanCentroid(mean []float64, runs int, centroid []float64) []float64 {
+	if len(centroid) == 0 {
+		return mean
+	}
+	if len(mean) != len(centroid) || runs <= 0 {
+		return append([]float64(nil), centroid...)
+	}
+	updated := make([]float64, len(mean))
+	for i := range mean {
+		updated[i] = (mean[i]*float64(runs) + centroid[i]) / float64(runs+1)
+	}
+	return updated
+}
+
+// splitSections returns a page's "## " sections, dropping the header above them
+func splitSections(page string) []string {
+	var sections []string
+	var current strings.Builder
+	inSection := false
+	for _, line := range strings.SplitAfter(page, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			if inSection {
+				sections = append(sections, strings.TrimRight(current.String(), "\n")+"\n")
+			}
+			current.Reset()
+			inSection = true
+		}
+		if inSection {
+			current.WriteString(line)
+		}
+	}
+	if inSection {
+		sections = append(sections, strings.TrimRight(current.String(), "\n")+"\n")
+	}
+	return sections
+}
+
+// sectionDate returns the date a section's heading starts with
+func sectionDate(section string) string {
+	heading := strings.TrimPrefix(strings.SplitN(section, "\n", 2)[0], "## ")
+	if len(heading) < len(dateLayout) {
+		return ""
+	}
+	if _, err := time.Parse(dateLayout, heading[:len(dateLayout)]); err != nil {
+		return ""
+	}
+	return heading[:len(dateLayout)]
+}
+
+// stripCitations drops the digest's [n] citations, which don't resolve on a topic page
+func stripCitations(text string) string {
+	return strings.TrimSpace(citationRef.ReplaceAllString(text, ""))
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
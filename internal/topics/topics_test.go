@@ -0,0 +1,142 @@
+package topics
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/store"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatch(t *testing.T) {
+	clusters := []core.TopicCluster{
+		{Label: "Agents", Centroid: []float64{0, 1, 0}},
+		{Label: "Coding agents", Centroid: []float64{0.1, 0.9, 0}},
+		{Label: "Vector DBs"},
+		{Label: "Brand new", Centroid: []float64{0, 0, 1}},
+	}
+	pages := []store.TopicPage{
+		{Slug: "ai-agents", Title: "AI Agents", Centroid: []float64{0, 1, 0}},
+		{Slug: "vector-dbs", Title: "Vector DBs", Centroid: []float64{1, 0, 0}},
+	}
+
+	matches := Match(clusters, pages, 0.8)
+	if page, ok := matches[0]; !ok || page != 0 {
+		t.Errorf("expected the closest cluster to continue ai-agents, got %v", matches)
+	}
+	if _, ok := matches[1]; ok {
+		t.Errorf("expected a page to be continued by one cluster only, got %v", matches)
+	}
+	if page, ok := matches[2]; !ok || page != 1 {
+		t.Errorf("expected the cluster without a centroid to match by slug, got %v", matches)
+	}
+	if _, ok := matches[3]; ok {
+		t.Errorf("expected the unrelated cluster to start a new page, got %v", matches)
+	}
+}
+
+func TestWriteSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ai-agents.md")
+	first := time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)
+
+	sections, replaced, err := WriteSection(path, "AI Agents", first, "## 2026-10-03 — Agents ship\n\nFirst week.\n")
+	if err != nil || sections != 1 || replaced {
+		t.Fatalf("WriteSection = %d, %v, %v", sections, replaced, err)
+	}
+	if _, _, err := WriteSection(path, "AI Agents", first, "## 2026-10-10 — Agents scale\n\nSecond week.\n"); err != nil {
+		t.Fatal(err)
+	}
+	// Re-running a day replaces that day's section
+	sections, replaced, err = WriteSection(path, "AI Agents", first, "## 2026-10-10 — Agents scale, revised\n\nSecond week again.\n")
+	if err != nil || sections != 2 || !replaced {
+		t.Fatalf("WriteSection = %d, %v, %v", sections, replaced, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	if !strings.HasPrefix(page, "# AI Agents\n\n_Topic history from briefly digests: 2 runs, first seen 2026-10-03, last updated 2026-10-10._\n") {
+		t.Errorf("unexpected header:\n%s", page)
+	}
+	if strings.Contains(page, "Second week.\n") || !strings.Contains(page, "Second week again.") {
+		t.Errorf("expected the re-run to replace its section:\n%s", page)
+	}
+	if strings.Index(page, "First week.") > strings.Index(page, "Second week again.") {
+		t.Errorf("expected sections in chronological order:\n%s", page)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	cache, err := store.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer func() { _ = cache.Close() }()
+	dir := filepath.Join(t.TempDir(), "topics")
+
+	articles := map[string]core.Article{
+		"a1": {ID: "a1", Title: "Agents in prod", URL: "https://example.com/a1", Publisher: "example.com"},
+		"a2": {ID: "a2", Title: "More agents", URL: "https://example.com/a2"},
+	}
+	week1 := []core.TopicCluster{{
+		Label:      "AI Agents",
+		ArticleIDs: []string{"a1"},
+		Centroid:   []float64{0, 1},
+		Narrative: &core.ClusterNarrative{
+			Title:           "Agents reach production",
+			OneLiner:        "Teams are shipping agents [1].",
+			KeyDevelopments: []string{"Acme launched an agent SDK [1, 2]"},
+			KeyStats:        []core.Statistic{{Stat: "40%", Context: "fewer tickets [1]"}},
+		},
+	}}
+	updates, err := Record(cache, dir, week1, articles, time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC), DefaultMatchThreshold)
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if len(updates) != 1 || !updates[0].Created || updates[0].Slug != "ai-agents" {
+		t.Fatalf("unexpected updates: %+v", updates)
+	}
+
+	week2 := []core.TopicCluster{
+		{Label: "Agent frameworks", ArticleIDs: []string{"a2"}, Centroid: []float64{0.1, 1}},
+		{Label: "Empty", ArticleIDs: []string{"missing"}},
+	}
+	updates, err = Record(cache, dir, week2, articles, time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC), DefaultMatchThreshold)
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if len(updates) != 1 || updates[0].Created || updates[0].Slug != "ai-agents" || updates[0].Sections != 2 {
+		t.Fatalf("expected week 2 to continue the agents page, got %+v", updates)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "ai-agents.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	for _, want := range []string{
+		"## 2026-10-03 — Agents reach production",
+		"Teams are shipping agents.",
+		"- Acme launched an agent SDK\n",
+		"- **40%** — fewer tickets\n",
+		"- [Agents in prod](https://example.com/a1) — example.com",
+		"## 2026-10-10 — Agent frameworks",
+		"- [More agents](https://example.com/a2)\n",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected page to contain %q:\n%s", want, page)
+		}
+	}
+
+	pages, err := cache.ListTopicPages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 1 || pages[0].Runs != 2 || pages[0].Centroid[0] != 0.05 {
+		t.Errorf("expected the registry to track both runs, got %+v", pages)
+	}
+}
@@ -0,0 +1,183 @@
+// Package transcript works with the timed transcripts of videos and podcasts, so a
+// quote from one can be traced back to the moment it was said and linked to there.
+package transcript
+
+import (
+	"briefly/internal/core"
+	"briefly/internal/language"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// minQuoteWords is how many content words a quote needs before Locate trusts a match
+const minQuoteWords = 3
+
+// minMatchShare is the share of a quote's content words a stretch of the transcript
+// must contain to be taken as where it was said
+const minMatchShare = 0.6
+
+// windowSlack is how many words a stretch may run past the quote's length, since
+// quotes drop filler words and captions break mid-sentence
+const windowSlack = 12
+
+var citationPattern = regexp.MustCompile(`\[\d+\]`)
+
+// ParseTimedText parses YouTube timedtext captions (<transcript><text start="1.5">…)
+func ParseTimedText(data []byte) ([]core.TranscriptSegment, error) {
+	var doc struct {
+		Texts []struct {
+			Start string `xml:"start,attr"`
+			Text  string `xml:",chardata"`
+		} `xml:"text"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse timedtext: %w", err)
+	}
+
+	segments := make([]core.TranscriptSegment, 0, len(doc.Texts))
+	for _, t := range doc.Texts {
+		start, err := strconv.ParseFloat(t.Start, 64)
+		if err != nil {
+			continue
+		}
+		// Caption text is HTML-escaped inside the XML, so entities arrive as "&#39;"
+		text := strings.Join(strings.Fields(html.UnescapeString(t.Text)), " ")
+		if text == "" {
+			continue
+		}
+		segments = append(segments, core.TranscriptSegment{Start: int(start), Text: text})
+	}
+	return segments, nil
+}
+
+// Text joins a transcript into plain running text
+func Text(segments []core.TranscriptSegment) string {
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		parts[i] = segment.Text
+	}
+	return strings.Join(parts, " ")
+}
+
+// Locate returns the start of the stretch of a transcript that best matches quote.
+// It reports false when the quote is too short to place or no stretch contains
+// enough of its words, as with paraphrases.
+func Locate(segments []core.TranscriptSegment, quote string) (int, bool) {
+	quoteWords := language.Words(strings.ToLower(citationPattern.ReplaceAllString(quote, "")))
+	var content []string
+	seen := make(map[string]bool)
+	for _, word := range quoteWords {
+		if len([]rune(word)) < 3 || language.IsStopword(word, "") || seen[word] {
+			continue
+		}
+		seen[word] = true
+		content = append(content, word)
+	}
+	if len(content) < minQuoteWords {
+		return 0, false
+	}
+
+	segmentWords := make([][]string, len(segments))
+	for i, segment := range segments {
+		segmentWords[i] = language.Words(strings.ToLower(segment.Text))
+	}
+
+	best, bestShare := -1, 0.0
+	for i := range segments {
+		window := make(map[string]bool)
+		count := 0
+		for j := i; j < len(segments) && count < len(quoteWords)+windowSlack; j++ {
+			for _, word := range segmentWords[j] {
+				window[word] = true
+			}
+			count += len(segmentWords[j])
+		}
+
+		matched := 0
+		for _, word := range content {
+			if window[word] {
+				matched++
+			}
+		}
+		if share := float64(matched) / float64(len(content)); share > bestShare {
+			best, bestShare = i, share
+		}
+	}
+
+	if best < 0 || bestShare < minMatchShare {
+		return 0, false
+	}
+
+	// A stretch can open with captions before the quote; start at its first match
+	wanted := make(map[string]bool, len(content))
+	for _, word := range content {
+		wanted[word] = true
+	}
+	for j := best; j < len(segments); j++ {
+		for _, word := range segmentWords[j] {
+			if wanted[word] {
+				return segments[j].Start, true
+			}
+		}
+	}
+	return segments[best].Start, true
+}
+
+// FormatTimestamp formats seconds as m:ss, or h:mm:ss past an hour
+func FormatTimestamp(seconds int) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// DeepLink returns a link that starts the media at seconds: a t= parameter for
+// YouTube (youtu.be/ID?t=273) and a media fragment (#t=273) for anything else,
+// which browsers honor for audio and video files
+func DeepLink(mediaURL string, seconds int) string {
+	parsed, err := url.Parse(mediaURL)
+	if err != nil || parsed.Host == "" {
+		return mediaURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if host == "youtu.be" || host == "youtube.com" || strings.HasSuffix(host, ".youtube.com") {
+		query := parsed.Query()
+		query.Set("t", strconv.Itoa(seconds))
+		parsed.RawQuery = query.Encode()
+		return parsed.String()
+	}
+
+	parsed.Fragment = "t=" + strconv.Itoa(seconds)
+	return parsed.String()
+}
+
+// AttachTimestamps sets Timestamp and TimestampURL on the key moments that cite an
+// article with a transcript, where the quote can be found in it. Citation N refers
+// to articles[N-1].
+func AttachTimestamps(moments []core.KeyMoment, articles []core.Article) {
+	for i := range moments {
+		num := moments[i].CitationNumber
+		if num < 1 || num > len(articles) {
+			continue
+		}
+		article := articles[num-1]
+		if len(article.Transcript) == 0 {
+			continue
+		}
+		start, ok := Locate(article.Transcript, moments[i].Quote)
+		if !ok {
+			continue
+		}
+		moments[i].Timestamp = start
+		moments[i].TimestampURL = DeepLink(article.URL, start)
+	}
+}
@@ -0,0 +1,111 @@
+package transcript
+
+import (
+	"briefly/internal/core"
+	"testing"
+)
+
+const sampleTimedText = `<?xml version="1.0" encoding="utf-8" ?><transcript>
+<text start="0.5" dur="3.1">welcome back to the channel</text>
+<text start="4.2" dur="2.8">today we&amp;#39;re looking at the new release</text>
+<text start="273.9" dur="4.0">the scheduler now handles ten thousand</text>
+<text start="277.9" dur="3.5">concurrent jobs without any tuning</text>
+<text start="281.4" dur="2.0"> </text>
+<text start="3725.0" dur="2.0">thanks for watching</text>
+</transcript>`
+
+func TestParseTimedText(t *testing.T) {
+	segments, err := ParseTimedText([]byte(sampleTimedText))
+	if err != nil {
+		t.Fatalf("ParseTimedText: %v", err)
+	}
+	if len(segments) != 5 {
+		t.Fatalf("expected 5 non-empty segments, got %d: %+v", len(segments), segments)
+	}
+	if segments[1].Text != "today we're looking at the new release" {
+		t.Errorf("entities not unescaped: %q", segments[1].Text)
+	}
+	if segments[2].Start != 273 {
+		t.Errorf("start = %d, want 273", segments[2].Start)
+	}
+
+	if _, err := ParseTimedText([]byte("not xml <")); err == nil {
+		t.Error("expected an error for malformed captions")
+	}
+}
+
+func TestLocate(t *testing.T) {
+	segments, _ := ParseTimedText([]byte(sampleTimedText))
+
+	tests := []struct {
+		name  string
+		quote string
+		want  int
+		found bool
+	}{
+		{"spans two captions", `"The scheduler now handles ten thousand concurrent jobs without any tuning" [2]`, 273, true},
+		{"first caption", "Today we're looking at the new release", 4, true},
+		{"paraphrase", "Performance improved dramatically for large deployments", 0, false},
+		{"too short", "thanks", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := Locate(segments, tt.quote)
+			if found != tt.found || got != tt.want {
+				t.Errorf("Locate(%q) = %d, %v; want %d, %v", tt.quote, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	tests := map[int]string{0: "0:00", 9: "0:09", 273: "4:33", 3725: "1:02:05", -5: "0:00"}
+	for seconds, want := range tests {
+		if got := FormatTimestamp(seconds); got != want {
+			t.Errorf("FormatTimestamp(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
+
+func TestDeepLink(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://youtu.be/dQw4w9WgXcQ", "https://youtu.be/dQw4w9WgXcQ?t=273"},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", "https://www.youtube.com/watch?t=273&v=dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ?t=10", "https://youtu.be/dQw4w9WgXcQ?t=273"},
+		{"https://cdn.example.com/episodes/42.mp3", "https://cdn.example.com/episodes/42.mp3#t=273"},
+		{"not a url", "not a url"},
+	}
+	for _, tt := range tests {
+		if got := DeepLink(tt.url, 273); got != tt.want {
+			t.Errorf("DeepLink(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAttachTimestamps(t *testing.T) {
+	segments, _ := ParseTimedText([]byte(sampleTimedText))
+	articles := []core.Article{
+		{URL: "https://example.com/post"},
+		{URL: "https://youtu.be/dQw4w9WgXcQ", Transcript: segments},
+	}
+	moments := []core.KeyMoment{
+		{Quote: "Ten thousand concurrent jobs without any tuning", CitationNumber: 2},
+		{Quote: "The scheduler now handles ten thousand concurrent jobs", CitationNumber: 1},
+		{Quote: "Ten thousand concurrent jobs", CitationNumber: 7},
+	}
+
+	AttachTimestamps(moments, articles)
+
+	if moments[0].Timestamp != 273 || moments[0].TimestampURL != "https://youtu.be/dQw4w9WgXcQ?t=273" {
+		t.Errorf("video moment = %+v", moments[0])
+	}
+	if moments[1].TimestampURL != "" {
+		t.Errorf("text article moment got a timestamp: %+v", moments[1])
+	}
+	if moments[2].TimestampURL != "" {
+		t.Errorf("out-of-range citation got a timestamp: %+v", moments[2])
+	}
+}
@@ -0,0 +1,89 @@
+package trends
+
+import (
+	"briefly/internal/clustering"
+	"briefly/internal/core"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultGraduateAfter is how many consecutive digests a keyword must appear in
+// before it graduates to ongoing coverage
+const DefaultGraduateAfter = 3
+
+// Graduation is a keyword among the top keywords of each of the latest digests,
+// ready to become a watchlist term and a standing search query
+type Graduation struct {
+	Keyword string
+	Streak  int       // Consecutive digests, ending with the latest, it was a top keyword of
+	Since   time.Time // Date of the first digest in the streak
+}
+
+// Graduating returns the keywords that were top keywords of each of the last
+// minStreak or more digests, longest streak first. Keywords are scored by TF-IDF
+// per digest against every article in the period, as in Build. Digests need not
+// be sorted.
+func Graduating(digests []core.Digest, articles map[string][]core.Article, minStreak int) []Graduation {
+	if minStreak < 2 || len(digests) < minStreak {
+		return nil
+	}
+	ordered := make([]core.Digest, len(digests))
+	copy(ordered, digests)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].ProcessedDate.Before(ordered[j].ProcessedDate) })
+
+	var corpus []core.Article
+	seen := make(map[string]bool)
+	for _, digest := range ordered {
+		for _, article := range articles[digest.ID] {
+			if !seen[article.ID] {
+				seen[article.ID] = true
+				corpus = append(corpus, article)
+			}
+		}
+	}
+	if len(corpus) == 0 {
+		return nil
+	}
+
+	// Walk back from the latest digest, keeping the keywords still on a streak
+	latest := ordered[len(ordered)-1]
+	streaks := make(map[string]*Graduation)
+	for _, keyword := range clustering.ExtractKeywords(articles[latest.ID], corpus, nil, clustering.DefaultKeywordCount) {
+		streaks[strings.ToLower(keyword)] = &Graduation{Keyword: keyword, Streak: 1, Since: latest.ProcessedDate}
+	}
+	active := len(streaks)
+	for i := len(ordered) - 2; i >= 0 && active > 0; i-- {
+		digest := ordered[i]
+		inDigest := make(map[string]bool)
+		for _, keyword := range clustering.ExtractKeywords(articles[digest.ID], corpus, nil, clustering.DefaultKeywordCount) {
+			inDigest[strings.ToLower(keyword)] = true
+		}
+		for key, graduation := range streaks {
+			// A streak is still running only if it reached the digest after this one
+			if graduation.Streak != len(ordered)-1-i {
+				continue
+			}
+			if inDigest[key] {
+				graduation.Streak++
+				graduation.Since = digest.ProcessedDate
+			} else {
+				active--
+			}
+		}
+	}
+
+	var graduated []Graduation
+	for _, graduation := range streaks {
+		if graduation.Streak >= minStreak {
+			graduated = append(graduated, *graduation)
+		}
+	}
+	sort.Slice(graduated, func(i, j int) bool {
+		if graduated[i].Streak != graduated[j].Streak {
+			return graduated[i].Streak > graduated[j].Streak
+		}
+		return graduated[i].Keyword < graduated[j].Keyword
+	})
+	return graduated
+}
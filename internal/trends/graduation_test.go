@@ -0,0 +1,43 @@
+package trends
+
+import (
+	"briefly/internal/core"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraduating(t *testing.T) {
+	start := time.Date(2026, 9, 21, 9, 0, 0, 0, time.UTC)
+	var digests []core.Digest
+	articles := make(map[string][]core.Article)
+	// MCP is in the last three digests; Rust is in every digest but the fourth
+	for i, mix := range [][2]int{{0, 3}, {0, 3}, {3, 3}, {3, 0}, {3, 3}} {
+		id := fmt.Sprintf("d%d", i)
+		digests = append(digests, core.Digest{ID: id, ProcessedDate: start.AddDate(0, 0, 7*i)})
+		articles[id] = weekArticles(id, mix[0], mix[1])
+	}
+	// Out of order, as listed newest first
+	digests[0], digests[4] = digests[4], digests[0]
+
+	graduated := Graduating(digests, articles, 3)
+	if len(graduated) == 0 {
+		t.Fatal("expected the three-digest topic to graduate")
+	}
+	for _, graduation := range graduated {
+		if strings.Contains(strings.ToLower(graduation.Keyword), "rust") {
+			t.Errorf("expected the broken Rust streak left out, got %+v", graduation)
+		}
+		if graduation.Streak != 3 || !graduation.Since.Equal(start.AddDate(0, 0, 14)) {
+			t.Errorf("expected a streak of 3 since the third digest, got %+v", graduation)
+		}
+	}
+
+	if got := Graduating(digests, articles, 4); len(got) != 0 {
+		t.Errorf("expected nothing with a streak of 4 required, got %+v", got)
+	}
+	if got := Graduating(digests[:2], articles, 3); got != nil {
+		t.Errorf("expected nothing with fewer digests than the streak, got %+v", got)
+	}
+}
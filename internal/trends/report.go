@@ -0,0 +1,249 @@
+// Package trends builds the weekly trend report ('briefly quality trends'): digest
+// quality by week, each week's distinctive keywords, and the topics emerging in the
+// latest week. The report renders as digest-style markdown for files and delivery,
+// and as a short summary newsletters embed once a week.
+package trends
+
+import (
+	"briefly/internal/clustering"
+	"briefly/internal/core"
+	"briefly/internal/quality"
+	"briefly/internal/timeutil"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// minCoverageWeeks is how many weeks the coverage comparison needs
+const minCoverageWeeks = 4
+
+// Report is the trend analysis of the digests in a period
+type Report struct {
+	Since time.Time
+	Until time.Time
+
+	Weeks    []Week                    // Oldest first
+	Emerging []clustering.KeywordTrend // Keywords spiking in the latest week
+	Coverage *CoverageTrend            // nil with fewer than four weeks
+}
+
+// Week is the quality and topics of one week's digests, starting Monday
+type Week struct {
+	Start       time.Time
+	Digests     int
+	Coverage    float64 // Average share of articles cited, 0-1
+	Vagueness   float64 // Average vague phrases per digest
+	Specificity float64 // Average specificity score
+	Grades      [4]int  // Digests graded A, B, C, and D
+	Keywords    []string
+}
+
+// CoverageTrend compares average citation coverage in the first and second half
+// of the period, in percent
+type CoverageTrend struct {
+	Before float64
+	After  float64
+}
+
+// Change is the difference in coverage, in percentage points
+func (c CoverageTrend) Change() float64 {
+	return c.After - c.Before
+}
+
+// Direction describes the coverage change: improving, declining, or stable within
+// five points
+func (c CoverageTrend) Direction() string {
+	switch change := c.Change(); {
+	case change > 5:
+		return "improving"
+	case change < -5:
+		return "declining"
+	default:
+		return "stable"
+	}
+}
+
+// Build analyzes digests (with their articles by digest ID) by week. Keywords are
+// scored by TF-IDF against every article in the period, and emerging topics are
+// keywords whose weekly mentions accelerate into the latest week. Weeks start on
+// Monday in since's location, so pass a since in the publication time zone.
+func Build(since, until time.Time, digests []core.Digest, articles map[string][]core.Article, velocity clustering.VelocityOptions) *Report {
+	evaluator := quality.NewDigestEvaluator()
+
+	type weekTotals struct {
+		week     Week
+		coverage float64 // Sums, averaged once every digest is counted
+		articles []core.Article
+	}
+	byWeek := make(map[string]*weekTotals)
+	for i := range digests {
+		digest := &digests[i]
+		metrics := evaluator.EvaluateDigest(digest, articles[digest.ID])
+
+		start := timeutil.WeekStart(digest.ProcessedDate.In(since.Location()))
+		key := start.Format("2006-01-02")
+		totals, ok := byWeek[key]
+		if !ok {
+			totals = &weekTotals{week: Week{Start: start}}
+			byWeek[key] = totals
+		}
+		totals.week.Digests++
+		totals.coverage += metrics.CoveragePct
+		totals.week.Vagueness += float64(metrics.VaguePhrases)
+		totals.week.Specificity += float64(metrics.SpecificityScore)
+		totals.articles = append(totals.articles, articles[digest.ID]...)
+		if metrics.Grade != "" {
+			if grade := strings.IndexByte("ABCD", metrics.Grade[0]); grade >= 0 {
+				totals.week.Grades[grade]++
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(byWeek))
+	for key := range byWeek {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	report := &Report{Since: since, Until: until}
+	periods := make([][]core.Article, len(keys))
+	var coverageSums []float64
+	for i, key := range keys {
+		totals := byWeek[key]
+		week := totals.week
+		week.Coverage = totals.coverage / float64(week.Digests)
+		week.Vagueness /= float64(week.Digests)
+		week.Specificity /= float64(week.Digests)
+		report.Weeks = append(report.Weeks, week)
+		periods[i] = totals.articles
+		coverageSums = append(coverageSums, totals.coverage)
+	}
+
+	weekKeywords(report.Weeks, periods)
+	report.Emerging = clustering.EmergingKeywords(periods, velocity)
+
+	// Coverage of the first half of the weeks against the second, weighted by digests
+	if len(report.Weeks) >= minCoverageWeeks {
+		half := len(report.Weeks) / 2
+		var sums [2]float64
+		var counts [2]int
+		for i, week := range report.Weeks {
+			side := 0
+			if i >= half {
+				side = 1
+			}
+			sums[side] += coverageSums[i]
+			counts[side] += week.Digests
+		}
+		report.Coverage = &CoverageTrend{Before: sums[0] / float64(counts[0]) * 100, After: sums[1] / float64(counts[1]) * 100}
+	}
+	return report
+}
+
+// weekKeywords fills in each week's distinctive keywords. Articles shared by
+// several digests in a week count once.
+func weekKeywords(weeks []Week, periods [][]core.Article) {
+	var corpus []core.Article
+	seen := make(map[string]bool)
+	deduped := make([][]core.Article, len(periods))
+	for i, articles := range periods {
+		weekSeen := make(map[string]bool)
+		for _, article := range articles {
+			if !weekSeen[article.ID] {
+				weekSeen[article.ID] = true
+				deduped[i] = append(deduped[i], article)
+			}
+			if !seen[article.ID] {
+				seen[article.ID] = true
+				corpus = append(corpus, article)
+			}
+		}
+	}
+	if len(corpus) == 0 {
+		return
+	}
+	for i := range weeks {
+		weeks[i].Keywords = clustering.ExtractKeywords(deduped[i], corpus, nil, clustering.DefaultKeywordCount)
+	}
+}
+
+// Title is the report's heading, e.g. "Trend report: Sep 1 – Oct 17"
+func (r *Report) Title() string {
+	return fmt.Sprintf("Trend report: %s – %s", r.Since.Format("Jan 2"), r.Until.Format("Jan 2"))
+}
+
+// Markdown renders the full report in the digests' markdown layout
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# 📈 %s\n\n", r.Title())
+
+	if summary := r.Summary(); summary != "" {
+		b.WriteString("## 🔭 This Week\n\n")
+		b.WriteString(summary)
+		b.WriteString("---\n\n")
+	}
+
+	b.WriteString("## 📊 Quality by Week\n\n")
+	b.WriteString("| Week | Digests | Coverage | Vagueness | Specificity | Grades (A/B/C/D) |\n")
+	b.WriteString("|------|---------|----------|-----------|-------------|------------------|\n")
+	for _, week := range r.Weeks {
+		fmt.Fprintf(&b, "| %s | %d | %.0f%% | %.1f | %.0f | %d/%d/%d/%d |\n",
+			week.Start.Format("Jan 02"), week.Digests, week.Coverage*100, week.Vagueness, week.Specificity,
+			week.Grades[0], week.Grades[1], week.Grades[2], week.Grades[3])
+	}
+	b.WriteString("\n")
+
+	var keywords strings.Builder
+	for _, week := range r.Weeks {
+		if len(week.Keywords) > 0 {
+			fmt.Fprintf(&keywords, "- **%s:** %s\n", week.Start.Format("Jan 02"), strings.Join(week.Keywords, ", "))
+		}
+	}
+	if keywords.Len() > 0 {
+		b.WriteString("## 🏷️ Top Keywords by Week\n\n")
+		b.WriteString(keywords.String())
+		b.WriteString("\n")
+	}
+
+	b.WriteString("---\n\n")
+	fmt.Fprintf(&b, "*Generated on %s*\n", r.Until.Format("Jan 2, 2006"))
+	return b.String()
+}
+
+// Summary renders the highlights newsletters embed: topics emerging this week,
+// the latest week's keywords, and the coverage trend. Returns "" when there are
+// none.
+func (r *Report) Summary() string {
+	var b strings.Builder
+	if len(r.Emerging) > 0 {
+		b.WriteString("**Rapidly emerging topics:**\n\n")
+		for _, trend := range r.Emerging {
+			fmt.Fprintf(&b, "- **%s**: %d articles this week (weekly mentions %s, %.1fx the earlier average)\n",
+				trend.Keyword, trend.Counts[len(trend.Counts)-1], MentionCounts(trend.Counts), trend.Growth)
+		}
+		b.WriteString("\n")
+	}
+	if len(r.Weeks) > 0 {
+		if latest := r.Weeks[len(r.Weeks)-1]; len(latest.Keywords) > 0 {
+			fmt.Fprintf(&b, "**This week's keywords:** %s\n\n", strings.Join(latest.Keywords, ", "))
+		}
+	}
+	if r.Coverage != nil {
+		fmt.Fprintf(&b, "**Citation coverage:** %s %.0f%% → %.0f%% (%+.1f points)\n\n",
+			r.Coverage.Direction(), r.Coverage.Before, r.Coverage.After, r.Coverage.Change())
+	}
+	return b.String()
+}
+
+// MentionCounts renders the last few weekly counts, e.g. "0 → 1 → 5"
+func MentionCounts(counts []int) string {
+	if len(counts) > 4 {
+		counts = counts[len(counts)-4:]
+	}
+	parts := make([]string, len(counts))
+	for i, count := range counts {
+		parts[i] = fmt.Sprintf("%d", count)
+	}
+	return strings.Join(parts, " → ")
+}
@@ -0,0 +1,84 @@
+package trends
+
+import (
+	"briefly/internal/clustering"
+	"briefly/internal/core"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func weekArticles(prefix string, mcp, rust int) []core.Article {
+	var articles []core.Article
+	for i := 0; i < mcp; i++ {
+		articles = append(articles, core.Article{ID: fmt.Sprintf("%s-mcp-%d", prefix, i), Title: "Model Context Protocol servers everywhere"})
+	}
+	for i := 0; i < rust; i++ {
+		articles = append(articles, core.Article{ID: fmt.Sprintf("%s-rust-%d", prefix, i), Title: "Rust compiler release notes"})
+	}
+	return articles
+}
+
+func TestBuild(t *testing.T) {
+	monday := time.Date(2026, 9, 21, 9, 0, 0, 0, time.UTC)
+	var digests []core.Digest
+	articles := make(map[string][]core.Article)
+	for week, mix := range [][2]int{{0, 4}, {0, 4}, {1, 4}, {5, 4}} {
+		id := fmt.Sprintf("d%d", week)
+		summary := "Rust shipped [1]."
+		if week >= 2 {
+			summary = "Rust shipped [1][2][3][4]." // Coverage improves in the second half
+		}
+		// Wednesday of each week, so digests group under that week's Monday
+		digests = append(digests, core.Digest{ID: id, Summary: summary, ProcessedDate: monday.AddDate(0, 0, 7*week+2)})
+		articles[id] = weekArticles(id, mix[0], mix[1])
+	}
+
+	until := monday.AddDate(0, 0, 26)
+	report := Build(monday, until, digests, articles, clustering.DefaultVelocityOptions)
+
+	// Weeks start at midnight, whatever the hour of the first digest
+	if len(report.Weeks) != 4 || !report.Weeks[0].Start.Equal(time.Date(2026, 9, 21, 0, 0, 0, 0, time.UTC)) || report.Weeks[3].Digests != 1 {
+		t.Fatalf("expected four Monday-started weeks, got %+v", report.Weeks)
+	}
+	if len(report.Emerging) == 0 || !strings.Contains(report.Emerging[0].Keyword, "Context") {
+		t.Errorf("expected the spiking topic flagged, got %+v", report.Emerging)
+	}
+	if report.Coverage == nil || report.Coverage.Direction() != "improving" {
+		t.Errorf("expected coverage improving, got %+v", report.Coverage)
+	}
+
+	markdown := report.Markdown()
+	for _, want := range []string{"# 📈 Trend report: Sep 21 – Oct 17", "## 🔭 This Week", "| Sep 21 | 1 |", "**Citation coverage:** improving", "*Generated on Oct 17, 2026*"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown missing %q:\n%s", want, markdown)
+		}
+	}
+	if summary := report.Summary(); strings.Contains(summary, "##") || !strings.Contains(summary, "Rapidly emerging topics") {
+		t.Errorf("expected an embeddable summary without headings, got:\n%s", summary)
+	}
+
+	if got := (&Report{}).Summary(); got != "" {
+		t.Errorf("expected no summary for an empty report, got %q", got)
+	}
+}
+
+func TestMentionCounts(t *testing.T) {
+	if got := MentionCounts([]int{9, 0, 1, 2, 5}); got != "0 → 1 → 2 → 5" {
+		t.Errorf("expected the last four counts, got %q", got)
+	}
+}
+
+func TestBuild_WeeksInSinceLocation(t *testing.T) {
+	// 22:00 UTC on Sunday Sep 27 is Monday morning in Tokyo, so it opens a new week there
+	tokyo := time.FixedZone("JST", 9*60*60)
+	since := time.Date(2026, 9, 21, 0, 0, 0, 0, tokyo)
+	digests := []core.Digest{{ID: "d", Summary: "Rust shipped [1].", ProcessedDate: time.Date(2026, 9, 27, 22, 0, 0, 0, time.UTC)}}
+	articles := map[string][]core.Article{"d": weekArticles("d", 0, 1)}
+
+	report := Build(since, since.AddDate(0, 0, 14), digests, articles, clustering.DefaultVelocityOptions)
+	if len(report.Weeks) != 1 || report.Weeks[0].Start.Format("2006-01-02") != "2026-09-28" {
+		t.Fatalf("expected the digest in the week of Monday Sep 28, got %+v", report.Weeks)
+	}
+}
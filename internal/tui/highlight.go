@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ANSI styles for the digest preview
+const (
+	ansiReset     = "\033[0m"
+	ansiBold      = "\033[1m"
+	ansiDim       = "\033[2m"
+	ansiUnderline = "\033[4m"
+	ansiCyan      = "\033[36m"
+	ansiGreen     = "\033[32m"
+	ansiYellow    = "\033[33m"
+)
+
+var (
+	inlineCode  = regexp.MustCompile("`[^`]+`")
+	boldText    = regexp.MustCompile(`\*\*[^*]+\*\*`)
+	markdownURL = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	listMarker  = regexp.MustCompile(`^(\s*)([-*+]|\d+\.)(\s)`)
+)
+
+// Highlight colors markdown for a terminal: headings, list markers, bold, links,
+// inline code, quotes, and fenced code blocks. Slack-flavored digests highlight the
+// same way, as they share the markdown structure.
+func Highlight(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			inFence = !inFence
+			lines[i] = ansiDim + line + ansiReset
+		case inFence:
+			lines[i] = ansiGreen + line + ansiReset
+		case strings.HasPrefix(trimmed, "#"):
+			lines[i] = ansiBold + ansiCyan + line + ansiReset
+		case trimmed == "---" || trimmed == "***":
+			lines[i] = ansiDim + line + ansiReset
+		case strings.HasPrefix(trimmed, ">"):
+			lines[i] = ansiDim + highlightInline(line, ansiDim) + ansiReset
+		default:
+			lines[i] = listMarker.ReplaceAllString(highlightInline(line, ""), "$1"+ansiYellow+"$2"+ansiReset+"$3")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// highlightInline styles spans within a line, restoring base after each. Links go
+// first, as the escape codes added for the other spans contain brackets.
+func highlightInline(line, base string) string {
+	line = markdownURL.ReplaceAllString(line, ansiUnderline+"$1"+ansiReset+base+" "+ansiDim+"($2)"+ansiReset+base)
+	line = inlineCode.ReplaceAllStringFunc(line, func(code string) string {
+		return ansiGreen + code + ansiReset + base
+	})
+	return boldText.ReplaceAllStringFunc(line, func(bold string) string {
+		return ansiBold + bold + ansiReset + base
+	})
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"strings"
+)
+
+// Digest sources the workflow can start from
+const (
+	SourceFile  = "file"  // A curated markdown file of links (digest from-file)
+	SourceFeeds = "feeds" // Database articles of one feed category (--from-feeds)
+	SourceCache = "cache" // Articles already in the local cache (--from-cache)
+	SourceIssue = "issue" // The next scheduled issue (--issue next)
+)
+
+// Plan is a digest run put together in the workflow
+type Plan struct {
+	Source    string
+	InputFile string // SourceFile
+	Category  string // SourceFeeds
+	Since     string // SourceFeeds and SourceCache, YYYY-MM-DD
+	Until     string
+	Format    string
+	OutputDir string
+	Flags     []string // Boolean flags, e.g. "--social"
+}
+
+// Args returns the briefly arguments that run the plan
+func (p Plan) Args() []string {
+	var args []string
+	switch p.Source {
+	case SourceFile:
+		args = []string{"digest", "from-file", p.InputFile}
+	case SourceFeeds:
+		args = []string{"digest", "--from-feeds", "--category", p.Category}
+	case SourceCache:
+		args = []string{"digest", "--from-cache"}
+	case SourceIssue:
+		args = []string{"digest", "--issue", "next"}
+	}
+	if p.Source == SourceFeeds || p.Source == SourceCache {
+		if p.Since != "" {
+			args = append(args, "--since", p.Since)
+		}
+		if p.Until != "" {
+			args = append(args, "--until", p.Until)
+		}
+	}
+	if p.Format != "" {
+		args = append(args, "--format", p.Format)
+	}
+	if p.OutputDir != "" {
+		args = append(args, "--output", p.OutputDir)
+	}
+	return append(args, p.Flags...)
+}
+
+// Command returns the plan as a shell command, so it can be rerun or scripted
+func (p Plan) Command() string {
+	parts := []string{"briefly"}
+	for _, arg := range p.Args() {
+		parts = append(parts, shellQuote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote single-quotes arg when it holds anything a shell would interpret
+func shellQuote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
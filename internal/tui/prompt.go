@@ -0,0 +1,124 @@
+// Package tui is the interactive digest workflow behind 'briefly tui': numbered
+// pickers and forms for the input, format, and flags of a digest run, and a
+// highlighted preview of the result. It works on any line-based terminal, so it
+// needs no terminal UI library.
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrInputClosed is returned once the input ends before a question is answered
+var ErrInputClosed = errors.New("input closed")
+
+// Prompter asks questions on out and reads one answer per line from in
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// Option is one on/off setting in a Toggle form
+type Option struct {
+	Label string
+	Help  string
+	On    bool
+}
+
+// NewPrompter creates a prompter reading answers from in
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// Ask prints question and returns the answer, or def for a blank one
+func (p *Prompter) Ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "   %s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "   %s: ", question)
+	}
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF && line == "" {
+		fmt.Fprintln(p.out)
+		return def, ErrInputClosed
+	}
+	answer := strings.TrimSpace(line)
+	if answer == "" {
+		answer = def
+	}
+	return answer, nil
+}
+
+// Confirm asks a yes/no question, returning def for a blank answer
+func (p *Prompter) Confirm(question string, def bool) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+	for {
+		answer, err := p.Ask(fmt.Sprintf("%s [%s]", question, choices), "")
+		if err != nil {
+			return def, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "   Answer y or n")
+	}
+}
+
+// Choose lists options under title and returns the index picked by number, or def
+// for a blank answer. It asks again until the answer is a listed number.
+func (p *Prompter) Choose(title string, options []string, def int) (int, error) {
+	fmt.Fprintf(p.out, "\n%s\n", title)
+	for i, option := range options {
+		fmt.Fprintf(p.out, "   %d) %s\n", i+1, option)
+	}
+	for {
+		answer, err := p.Ask("Choose", strconv.Itoa(def+1))
+		if err != nil {
+			return def, err
+		}
+		if n, convErr := strconv.Atoi(answer); convErr == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		fmt.Fprintf(p.out, "   Pick a number from 1 to %d\n", len(options))
+	}
+}
+
+// Toggle shows options with their state and flips the ones named by number
+// (several at once as "1 3" or "1,3") until the answer is blank
+func (p *Prompter) Toggle(title string, options []Option) ([]Option, error) {
+	options = append([]Option(nil), options...)
+	for {
+		fmt.Fprintf(p.out, "\n%s\n", title)
+		for i, option := range options {
+			mark := " "
+			if option.On {
+				mark = "x"
+			}
+			fmt.Fprintf(p.out, "   %d) [%s] %-16s %s\n", i+1, mark, option.Label, option.Help)
+		}
+		answer, err := p.Ask("Numbers to toggle (blank when done)", "")
+		if err != nil || answer == "" {
+			return options, err
+		}
+		for _, field := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+			n, err := strconv.Atoi(field)
+			if err != nil || n < 1 || n > len(options) {
+				fmt.Fprintf(p.out, "   Skipping %q: pick numbers from 1 to %d\n", field, len(options))
+				continue
+			}
+			options[n-1].On = !options[n-1].On
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9]+m`)
+
+func TestChoose_ReasksUntilValid(t *testing.T) {
+	var out strings.Builder
+	prompt := NewPrompter(strings.NewReader("0\nabc\n3\n"), &out)
+
+	choice, err := prompt.Choose("Pick", []string{"a", "b", "c"}, 0)
+	if err != nil || choice != 2 {
+		t.Fatalf("Choose = %d, %v, want 2", choice, err)
+	}
+	if got := strings.Count(out.String(), "Pick a number from 1 to 3"); got != 2 {
+		t.Errorf("expected 2 re-asks, got %d:\n%s", got, out.String())
+	}
+
+	// A blank answer takes the default
+	prompt = NewPrompter(strings.NewReader("\n"), io.Discard)
+	if choice, err := prompt.Choose("Pick", []string{"a", "b"}, 1); err != nil || choice != 1 {
+		t.Errorf("Choose blank = %d, %v, want the default 1", choice, err)
+	}
+}
+
+func TestPrompter_InputClosed(t *testing.T) {
+	prompt := NewPrompter(strings.NewReader(""), io.Discard)
+	if _, err := prompt.Choose("Pick", []string{"a"}, 0); !errors.Is(err, ErrInputClosed) {
+		t.Errorf("Choose: expected ErrInputClosed, got %v", err)
+	}
+	if _, err := prompt.Confirm("Sure?", true); !errors.Is(err, ErrInputClosed) {
+		t.Errorf("Confirm: expected ErrInputClosed, got %v", err)
+	}
+}
+
+func TestToggle(t *testing.T) {
+	options := []Option{{Label: "--a"}, {Label: "--b", On: true}, {Label: "--c"}}
+	prompt := NewPrompter(strings.NewReader("1,2 9\n3\n\n"), io.Discard)
+
+	got, err := prompt.Toggle("Options", options)
+	if err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+	var on []string
+	for _, option := range got {
+		if option.On {
+			on = append(on, option.Label)
+		}
+	}
+	if !reflect.DeepEqual(on, []string{"--a", "--c"}) {
+		t.Errorf("enabled = %v, want [--a --c]", on)
+	}
+	if !options[1].On || options[0].On {
+		t.Error("expected the caller's options left unchanged")
+	}
+}
+
+func TestPlan_Args(t *testing.T) {
+	tests := []struct {
+		plan Plan
+		want string
+	}{
+		{
+			Plan{Source: SourceFile, InputFile: "input/my week.md", Format: "slack", OutputDir: "digests", Flags: []string{"--curate"}},
+			"briefly digest from-file 'input/my week.md' --format slack --output digests --curate",
+		},
+		{
+			Plan{Source: SourceFeeds, Category: "security", Since: "2025-06-01", Until: "2025-06-07", Format: "markdown"},
+			"briefly digest --from-feeds --category security --since 2025-06-01 --until 2025-06-07 --format markdown",
+		},
+		{
+			Plan{Source: SourceCache, Since: "2025-06-01", OutputDir: "out"},
+			"briefly digest --from-cache --since 2025-06-01 --output out",
+		},
+		{
+			Plan{Source: SourceIssue, Since: "2025-06-01", Flags: []string{"--social"}},
+			"briefly digest --issue next --social",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.plan.Command(); got != tt.want {
+			t.Errorf("Command() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	digest := "# Weekly\n\n- **Top** story: [Post](https://example.com) with `code`\n\n```\n# not a heading\n```"
+	got := Highlight(digest)
+
+	for _, want := range []string{
+		ansiBold + ansiCyan + "# Weekly" + ansiReset,
+		ansiYellow + "-" + ansiReset,
+		ansiBold + "**Top**" + ansiReset,
+		ansiUnderline + "Post" + ansiReset + " " + ansiDim + "(https://example.com)" + ansiReset,
+		ansiGreen + "`code`" + ansiReset,
+		ansiGreen + "# not a heading" + ansiReset,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%q", want, got)
+		}
+	}
+	if stripped := ansiPattern.ReplaceAllString(got, ""); stripped != strings.Replace(digest, "[Post](https://example.com)", "Post (https://example.com)", 1) {
+		t.Errorf("expected only styling added, got %q", stripped)
+	}
+}
@@ -0,0 +1,399 @@
+// Package update implements 'briefly update': finding the newest GitHub release on a
+// channel, verifying its checksum and signature, and replacing the running binary.
+// Without a public key to check signatures against, an updater refuses to download
+// unless it was explicitly allowed to trust checksums alone.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Release channels
+const (
+	ChannelStable = "stable" // Published releases only
+	ChannelBeta   = "beta"   // Pre-releases too
+)
+
+const (
+	defaultAPIURL   = "https://api.github.com"
+	checksumsAsset  = "checksums.txt"
+	signatureAsset  = "checksums.txt.sig"
+	maxDownloadSize = 200 << 20
+)
+
+// Release is a GitHub release
+type Release struct {
+	TagName    string  `json:"tag_name"`
+	Name       string  `json:"name"`
+	Prerelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+	HTMLURL    string  `json:"html_url"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// Version returns the release version without a leading "v"
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+// asset returns the release asset with the given name
+func (r *Release) asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// ErrUnsigned is returned by Download when no public key is configured and unsigned
+// updates were not allowed
+var ErrUnsigned = errors.New("no public key to verify the release signature against")
+
+// Updater finds and installs releases of one GitHub repository
+type Updater struct {
+	repository    string
+	channel       string
+	publicKey     ed25519.PublicKey // nil refuses downloads unless allowUnsigned
+	allowUnsigned bool
+	apiURL        string
+	client        *http.Client
+}
+
+// NewUpdater creates an updater for repository ("owner/repo") on channel. publicKey is a
+// base64 ed25519 key; when set, every update must carry a valid checksums signature.
+func NewUpdater(repository, channel, publicKey string) (*Updater, error) {
+	if channel == "" {
+		channel = ChannelStable
+	}
+	if channel != ChannelStable && channel != ChannelBeta {
+		return nil, fmt.Errorf("unknown update channel %q (supported: stable, beta)", channel)
+	}
+	if !strings.Contains(repository, "/") {
+		return nil, fmt.Errorf("update repository must be owner/repo, got %q", repository)
+	}
+
+	u := &Updater{
+		repository: repository,
+		channel:    channel,
+		apiURL:     defaultAPIURL,
+		client:     &http.Client{Timeout: 5 * time.Minute},
+	}
+
+	if publicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(publicKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("update public key must be a base64 ed25519 public key")
+		}
+		u.publicKey = ed25519.PublicKey(key)
+	}
+
+	return u, nil
+}
+
+// Channel returns the channel the updater follows
+func (u *Updater) Channel() string {
+	return u.channel
+}
+
+// VerifiesSignatures reports whether updates must be signed
+func (u *Updater) VerifiesSignatures() bool {
+	return u.publicKey != nil
+}
+
+// AllowUnsigned lets an updater without a public key install releases verified by
+// their checksums alone. The checksums come from the same release as the binary, so
+// they catch corrupt downloads but not a tampered release.
+func (u *Updater) AllowUnsigned() {
+	u.allowUnsigned = true
+}
+
+// Latest returns the newest release on the updater's channel. Drafts are never
+// considered; pre-releases only on the beta channel.
+func (u *Updater) Latest(ctx context.Context) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=30", strings.TrimSuffix(u.apiURL, "/"), u.repository)
+	body, err := u.get(ctx, url, 10<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	var releases []Release
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	var latest *Release
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || (r.Prerelease && u.channel != ChannelBeta) {
+			continue
+		}
+		if _, ok := parseVersion(r.Version()); !ok {
+			continue
+		}
+		if latest == nil || Newer(r.Version(), latest.Version()) {
+			latest = r
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no %s releases found for %s", u.channel, u.repository)
+	}
+	return latest, nil
+}
+
+// Download fetches the release binary for this platform and verifies it against the
+// release's checksums.txt, and the checksums against their signature. Without a public
+// key it returns ErrUnsigned unless AllowUnsigned was called.
+func (u *Updater) Download(ctx context.Context, release *Release) ([]byte, error) {
+	if u.publicKey == nil && !u.allowUnsigned {
+		return nil, ErrUnsigned
+	}
+
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	binaryAsset, ok := release.asset(name)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no binary for %s/%s (expected asset %s)", release.TagName, runtime.GOOS, runtime.GOARCH, name)
+	}
+	checksumAsset, ok := release.asset(checksumsAsset)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s; refusing to install an unverified binary", release.TagName, checksumsAsset)
+	}
+
+	checksums, err := u.get(ctx, checksumAsset.URL, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download checksums: %w", err)
+	}
+
+	if u.publicKey != nil {
+		sigAsset, ok := release.asset(signatureAsset)
+		if !ok {
+			return nil, fmt.Errorf("release %s is not signed (no %s)", release.TagName, signatureAsset)
+		}
+		sig, err := u.get(ctx, sigAsset.URL, 4096)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download signature: %w", err)
+		}
+		if err := VerifySignature(u.publicKey, checksums, sig); err != nil {
+			return nil, err
+		}
+	}
+
+	binary, err := u.get(ctx, binaryAsset.URL, maxDownloadSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	if err := VerifyChecksum(binary, name, checksums); err != nil {
+		return nil, err
+	}
+
+	return binary, nil
+}
+
+func (u *Updater) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "briefly-updater")
+	if strings.HasPrefix(url, u.apiURL) {
+		req.Header.Set("Accept", "application/vnd.github+json")
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("GET %s: response larger than %d bytes", url, limit)
+	}
+	return body, nil
+}
+
+// AssetName is the release asset name of the binary for a platform
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("briefly_%s_%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// VerifyChecksum checks data against the sha256 listed for name in a checksums file
+// ("<hex>  <name>" per line, as written by sha256sum)
+func VerifyChecksum(data []byte, name string, checksums []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		want, err := hex.DecodeString(fields[0])
+		if err != nil {
+			return fmt.Errorf("invalid checksum for %s: %w", name, err)
+		}
+		got := sha256.Sum256(data)
+		if !bytes.Equal(got[:], want) {
+			return fmt.Errorf("checksum mismatch for %s: download is corrupt or tampered with", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum listed for %s", name)
+}
+
+// VerifySignature checks an ed25519 signature over the checksums file. The signature
+// may be raw or base64-encoded.
+func VerifySignature(key ed25519.PublicKey, checksums, sig []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return fmt.Errorf("invalid checksums signature encoding")
+		}
+		sig = decoded
+	}
+	if !ed25519.Verify(key, checksums, sig) {
+		return fmt.Errorf("checksums signature does not verify against the configured public key")
+	}
+	return nil
+}
+
+// Apply replaces the binary at target with binary. The new file is written next to the
+// target and renamed over it, so a failed update leaves the old binary in place.
+func Apply(binary []byte, target string) error {
+	dir := filepath.Dir(target)
+	tmp, err := os.CreateTemp(dir, ".briefly-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file next to %s: %w", target, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0755); err != nil {
+		return fmt.Errorf("failed to make new binary executable: %w", err)
+	}
+
+	// A running executable can't be overwritten on Windows, but it can be renamed
+	if runtime.GOOS == "windows" {
+		old := target + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(target, old); err != nil {
+			return fmt.Errorf("failed to move current binary aside: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, target); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", target, err)
+	}
+	return nil
+}
+
+// Newer reports whether version candidate is newer than current. Versions are
+// MAJOR.MINOR.PATCH with an optional -prerelease suffix, which sorts before the
+// plain release. A `git describe` suffix (-N-gSHA, optionally -dirty) marks a build
+// N commits past its tag, which sorts after the tag rather than before it. An
+// unparseable current version is treated as older than anything.
+func Newer(candidate, current string) bool {
+	c, ok := parseVersion(strings.TrimPrefix(candidate, "v"))
+	if !ok {
+		return false
+	}
+	cur, ok := parseVersion(strings.TrimPrefix(current, "v"))
+	if !ok {
+		return true
+	}
+
+	for i := 0; i < 3; i++ {
+		if c.parts[i] != cur.parts[i] {
+			return c.parts[i] > cur.parts[i]
+		}
+	}
+	switch {
+	case c.pre == cur.pre:
+		return c.commits > cur.commits
+	case c.pre == "":
+		return true
+	case cur.pre == "":
+		return false
+	default:
+		return c.pre > cur.pre
+	}
+}
+
+type version struct {
+	parts   [3]int
+	pre     string
+	commits int // Commits past the tag, from a `git describe` suffix
+}
+
+// describeSuffix matches the "-N-gSHA" (and "-dirty") `git describe` adds to a tag
+var describeSuffix = regexp.MustCompile(`(?:^|-)(\d+)-g[0-9a-f]{4,}(?:-dirty)?$`)
+
+func parseVersion(s string) (version, bool) {
+	var v version
+	if idx := strings.IndexAny(s, "-+"); idx >= 0 {
+		if s[idx] == '-' {
+			v.pre = strings.SplitN(s[idx+1:], "+", 2)[0]
+			if m := describeSuffix.FindStringSubmatchIndex(v.pre); m != nil {
+				v.commits, _ = strconv.Atoi(v.pre[m[2]:m[3]])
+				v.pre = v.pre[:m[0]]
+			} else if v.pre == "dirty" {
+				v.pre = "" // Uncommitted changes on the tag itself
+			}
+		}
+		s = s[:idx]
+	}
+
+	fields := strings.Split(s, ".")
+	if len(fields) < 1 || len(fields) > 3 {
+		return v, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.parts[i] = n
+	}
+	return v, true
+}
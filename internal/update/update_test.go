@@ -0,0 +1,168 @@
+package update
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		candidate, current string
+		want               bool
+	}{
+		{"3.2.0", "3.1.0", true},
+		{"v3.1.1", "3.1.0", true},
+		{"3.1.0", "3.1.0", false},
+		{"3.0.9", "3.1.0", false},
+		{"3.1.0", "3.1.0-hierarchical-summarization", true},
+		{"3.2.0-beta.2", "3.2.0-beta.1", true},
+		{"3.2.0-beta.1", "3.2.0", false},
+		{"3.1.0", "dev", true},
+		{"nightly", "3.1.0", false},
+		{"3.1.0", "v3.1.0-5-gabc1234", false},
+		{"3.1.0", "v3.1.0-5-gabc1234-dirty", false},
+		{"3.1.0", "v3.1.0-dirty", false},
+		{"3.1.1", "v3.1.0-5-gabc1234", true},
+		{"3.2.0-beta.1", "v3.2.0-beta.1-2-gabc1234", false},
+		{"3.2.0", "v3.2.0-beta.1-2-gabc1234", true},
+	}
+
+	for _, tt := range tests {
+		if got := Newer(tt.candidate, tt.current); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.candidate, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestLatestRespectsChannel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/rcliao/briefly/releases" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[
+			{"tag_name": "v3.3.0", "draft": true},
+			{"tag_name": "v3.3.0-beta.1", "prerelease": true},
+			{"tag_name": "v3.2.0"},
+			{"tag_name": "v3.1.0"}
+		]`))
+	}))
+	defer server.Close()
+
+	for channel, want := range map[string]string{ChannelStable: "v3.2.0", ChannelBeta: "v3.3.0-beta.1"} {
+		u, err := NewUpdater("rcliao/briefly", channel, "")
+		if err != nil {
+			t.Fatalf("NewUpdater failed: %v", err)
+		}
+		u.apiURL = server.URL
+
+		release, err := u.Latest(context.Background())
+		if err != nil {
+			t.Fatalf("Latest(%s) failed: %v", channel, err)
+		}
+		if release.TagName != want {
+			t.Errorf("Latest(%s) = %s, want %s", channel, release.TagName, want)
+		}
+	}
+}
+
+func TestDownloadVerifiesChecksumAndSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	binary := []byte("new briefly binary")
+	sum := sha256.Sum256(binary)
+	checksums := []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name))
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, checksums))
+	served := binary
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bin":
+			_, _ = w.Write(served)
+		case "/checksums":
+			_, _ = w.Write(checksums)
+		case "/sig":
+			_, _ = w.Write([]byte(signature))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	release := &Release{TagName: "v3.2.0", Assets: []Asset{
+		{Name: name, URL: server.URL + "/bin"},
+		{Name: checksumsAsset, URL: server.URL + "/checksums"},
+		{Name: signatureAsset, URL: server.URL + "/sig"},
+	}}
+
+	u, err := NewUpdater("rcliao/briefly", ChannelStable, base64.StdEncoding.EncodeToString(pub))
+	if err != nil {
+		t.Fatalf("NewUpdater failed: %v", err)
+	}
+
+	got, err := u.Download(context.Background(), release)
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if string(got) != string(binary) {
+		t.Errorf("Download returned %q", got)
+	}
+
+	served = []byte("tampered binary")
+	if _, err := u.Download(context.Background(), release); err == nil {
+		t.Error("expected checksum mismatch for tampered binary")
+	}
+
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+	strict, _ := NewUpdater("rcliao/briefly", ChannelStable, base64.StdEncoding.EncodeToString(otherPub))
+	served = binary
+	if _, err := strict.Download(context.Background(), release); err == nil {
+		t.Error("expected signature failure with a different public key")
+	}
+
+	// Without a public key, checksums alone are trusted only when explicitly allowed
+	unsigned, _ := NewUpdater("rcliao/briefly", ChannelStable, "")
+	if _, err := unsigned.Download(context.Background(), release); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("expected ErrUnsigned without a public key, got %v", err)
+	}
+	unsigned.AllowUnsigned()
+	if got, err := unsigned.Download(context.Background(), release); err != nil || string(got) != string(binary) {
+		t.Errorf("Download with AllowUnsigned = %q, %v", got, err)
+	}
+}
+
+func TestApplyReplacesBinary(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "briefly")
+	if err := os.WriteFile(target, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Apply([]byte("new"), target); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new" {
+		t.Errorf("binary = %q, want new", data)
+	}
+	if info, _ := os.Stat(target); info.Mode().Perm()&0100 == 0 {
+		t.Error("new binary is not executable")
+	}
+}
@@ -264,6 +264,85 @@ func (p *PgVectorAdapter) SearchByTags(ctx context.Context, query SearchQuery, t
 	return results, nil
 }
 
+// SearchPriorCoverage finds earlier digests containing articles similar to query.ArticleID
+// Joins the nearest neighbors through digest_articles so only digested articles match
+func (p *PgVectorAdapter) SearchPriorCoverage(ctx context.Context, query PriorCoverageQuery) ([]core.PriorCoverage, error) {
+	// Apply defaults
+	if query.Limit == 0 {
+		query.Limit = 2
+	}
+	if query.SimilarityThreshold == 0 {
+		query.SimilarityThreshold = 0.8
+	}
+	if query.ExcludeDigestIDs == nil {
+		query.ExcludeDigestIDs = []string{} // A NULL array would exclude every row
+	}
+
+	// One article can sit in several digests, so over-fetch and dedupe by digest below
+	sqlQuery := `
+		SELECT
+			d.id,
+			COALESCE(d.title, ''),
+			COALESCE((d.content->'metadata'->>'date_generated')::timestamptz, d.created_at),
+			COALESCE(d.content->'metadata'->>'issue', ''),
+			COALESCE((d.content->'metadata'->>'part')::int, 0),
+			COALESCE((d.content->'metadata'->>'parts')::int, 0),
+			a.id,
+			COALESCE(a.title, ''),
+			1 - (a.embedding_vector <=> src.embedding_vector) as similarity
+		FROM articles src
+		INNER JOIN articles a ON a.id <> src.id AND a.embedding_vector IS NOT NULL
+		INNER JOIN digest_articles da ON da.article_id = a.id
+		INNER JOIN digests d ON d.id = da.digest_id
+		WHERE src.id = $1
+		  AND src.embedding_vector IS NOT NULL
+		  AND 1 - (a.embedding_vector <=> src.embedding_vector) >= $2
+		  AND NOT (d.id = ANY($4::text[]))
+		ORDER BY a.embedding_vector <=> src.embedding_vector, d.created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := p.db.QueryContext(ctx, sqlQuery,
+		query.ArticleID, query.SimilarityThreshold, query.Limit*5, pq.Array(query.ExcludeDigestIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search prior coverage: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []core.PriorCoverage
+	for rows.Next() {
+		var match core.PriorCoverage
+		if err := rows.Scan(&match.DigestID, &match.DigestTitle, &match.DigestDate,
+			&match.DigestIssue, &match.DigestPart, &match.DigestParts, &match.ArticleID, &match.ArticleTitle, &match.Similarity); err != nil {
+			return nil, fmt.Errorf("failed to scan prior coverage: %w", err)
+		}
+		matches = append(matches, match)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return firstPerDigest(matches, query.Limit), nil
+}
+
+// firstPerDigest keeps the first (most similar) match for each digest, up to limit digests
+func firstPerDigest(matches []core.PriorCoverage, limit int) []core.PriorCoverage {
+	seen := make(map[string]bool)
+	var results []core.PriorCoverage
+	for _, match := range matches {
+		if seen[match.DigestID] {
+			continue
+		}
+		seen[match.DigestID] = true
+		results = append(results, match)
+		if len(results) == limit {
+			break
+		}
+	}
+	return results
+}
+
 // Delete removes an embedding (when article is deleted)
 func (p *PgVectorAdapter) Delete(ctx context.Context, articleID string) error {
 	query := `
@@ -1,6 +1,7 @@
 package vectorstore
 
 import (
+	"briefly/internal/core"
 	"context"
 	"database/sql"
 	"fmt"
@@ -473,6 +474,28 @@ func TestPgVectorStore(t *testing.T) {
 	})
 }
 
+// TestFirstPerDigest checks prior coverage keeps the closest match per digest
+func TestFirstPerDigest(t *testing.T) {
+	matches := []core.PriorCoverage{
+		{DigestID: "d1", ArticleID: "a1", Similarity: 0.95},
+		{DigestID: "d1", ArticleID: "a2", Similarity: 0.91},
+		{DigestID: "d2", ArticleID: "a3", Similarity: 0.88},
+		{DigestID: "d3", ArticleID: "a4", Similarity: 0.85},
+	}
+
+	results := firstPerDigest(matches, 2)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].ArticleID != "a1" || results[1].ArticleID != "a3" {
+		t.Errorf("Expected a1 then a3, got %s then %s", results[0].ArticleID, results[1].ArticleID)
+	}
+
+	if results := firstPerDigest(nil, 2); len(results) != 0 {
+		t.Errorf("Expected no results for no matches, got %d", len(results))
+	}
+}
+
 // generateRandomEmbedding creates a random normalized embedding
 func generateRandomEmbedding(dims int) []float64 {
 	embedding := make([]float64, dims)
@@ -0,0 +1,334 @@
+package vectorstore
+
+import (
+	"briefly/internal/core"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// qdrantTagField is the payload field holding an article's tag IDs
+const qdrantTagField = "tag_ids"
+
+// QdrantAdapter implements VectorStore on a Qdrant collection over its REST API.
+// Unlike pgvector, it keeps vectors outside Postgres, so archives of hundreds of
+// thousands of articles can be searched without the database doing the work.
+//
+// Points are keyed by the article ID when it is a UUID, otherwise by a UUID derived
+// from it; the original ID, title, URL, and tag IDs are kept in the payload so results
+// need no database round trip.
+type QdrantAdapter struct {
+	baseURL    string
+	apiKey     string
+	collection string
+	dimensions int
+	client     *http.Client
+}
+
+// NewQdrantAdapter creates a Qdrant-backed vector store for collection. dimensions
+// is the embedding size used when CreateIndex creates the collection.
+func NewQdrantAdapter(baseURL, apiKey, collection string, dimensions int) *QdrantAdapter {
+	if dimensions == 0 {
+		dimensions = 768
+	}
+	return &QdrantAdapter{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		collection: collection,
+		dimensions: dimensions,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// qdrantPoint is a point as upserted to and returned by Qdrant
+type qdrantPoint struct {
+	ID      string                 `json:"id"`
+	Vector  []float64              `json:"vector,omitempty"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+}
+
+// qdrantScoredPoint is a search hit
+type qdrantScoredPoint struct {
+	ID      interface{}            `json:"id"`
+	Score   float64                `json:"score"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// Store saves or updates an embedding for an article. The point's payload is
+// replaced; use IndexArticle to keep title, URL, and tags alongside it.
+func (q *QdrantAdapter) Store(ctx context.Context, articleID string, embedding []float64) error {
+	return q.upsert(ctx, []qdrantPoint{{
+		ID:      qdrantPointID(articleID),
+		Vector:  embedding,
+		Payload: map[string]interface{}{"article_id": articleID},
+	}})
+}
+
+// IndexArticle stores an article's embedding with its title, URL, and tags
+func (q *QdrantAdapter) IndexArticle(ctx context.Context, article core.Article, embedding []float64, tagIDs []string) error {
+	if tagIDs == nil {
+		tagIDs = []string{}
+	}
+	return q.upsert(ctx, []qdrantPoint{{
+		ID:     qdrantPointID(article.ID),
+		Vector: embedding,
+		Payload: map[string]interface{}{
+			"article_id":   article.ID,
+			"title":        article.Title,
+			"url":          article.URL,
+			qdrantTagField: tagIDs,
+		},
+	}})
+}
+
+// Search finds articles similar to the query embedding
+func (q *QdrantAdapter) Search(ctx context.Context, query SearchQuery) ([]SearchResult, error) {
+	return q.search(ctx, query, nil)
+}
+
+// SearchByTag performs semantic search within a specific tag
+func (q *QdrantAdapter) SearchByTag(ctx context.Context, query SearchQuery, tagID string) ([]SearchResult, error) {
+	return q.search(ctx, query, []string{tagID})
+}
+
+// SearchByTags searches across multiple tags (OR operation)
+func (q *QdrantAdapter) SearchByTags(ctx context.Context, query SearchQuery, tagIDs []string) ([]SearchResult, error) {
+	if len(tagIDs) == 0 {
+		return []SearchResult{}, nil
+	}
+	return q.search(ctx, query, tagIDs)
+}
+
+// SearchPriorCoverage is not supported: digest membership lives in Postgres, so
+// callers should use the pgvector adapter for prior coverage
+func (q *QdrantAdapter) SearchPriorCoverage(ctx context.Context, query PriorCoverageQuery) ([]core.PriorCoverage, error) {
+	return nil, fmt.Errorf("prior coverage lookup: %w", ErrUnsupported)
+}
+
+// Delete removes an article's point
+func (q *QdrantAdapter) Delete(ctx context.Context, articleID string) error {
+	body := map[string]interface{}{"points": []string{qdrantPointID(articleID)}}
+	if err := q.do(ctx, http.MethodPost, q.collectionPath("/points/delete?wait=true"), body, nil); err != nil {
+		return fmt.Errorf("failed to delete embedding: %w", err)
+	}
+	return nil
+}
+
+// CreateIndex creates the collection (cosine distance) and the tag payload index
+// if they do not exist yet. Qdrant maintains its HNSW index itself.
+func (q *QdrantAdapter) CreateIndex(ctx context.Context) error {
+	exists, err := q.collectionExists(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		body := map[string]interface{}{
+			"vectors": map[string]interface{}{"size": q.dimensions, "distance": "Cosine"},
+		}
+		if err := q.do(ctx, http.MethodPut, q.collectionPath(""), body, nil); err != nil {
+			return fmt.Errorf("failed to create collection %s: %w", q.collection, err)
+		}
+	}
+
+	index := map[string]interface{}{"field_name": qdrantTagField, "field_schema": "keyword"}
+	if err := q.do(ctx, http.MethodPut, q.collectionPath("/index?wait=true"), index, nil); err != nil {
+		return fmt.Errorf("failed to create tag index: %w", err)
+	}
+	return nil
+}
+
+// GetStats returns statistics about the collection
+func (q *QdrantAdapter) GetStats(ctx context.Context) (*VectorStoreStats, error) {
+	var info struct {
+		Result struct {
+			PointsCount int64 `json:"points_count"`
+			Config      struct {
+				Params struct {
+					Vectors struct {
+						Size int `json:"size"`
+					} `json:"vectors"`
+				} `json:"params"`
+			} `json:"config"`
+		} `json:"result"`
+	}
+	if err := q.do(ctx, http.MethodGet, q.collectionPath(""), nil, &info); err != nil {
+		return nil, fmt.Errorf("failed to get collection info: %w", err)
+	}
+
+	return &VectorStoreStats{
+		TotalEmbeddings:     info.Result.PointsCount,
+		EmbeddingDimensions: info.Result.Config.Params.Vectors.Size,
+		IndexType:           "hnsw (qdrant)",
+	}, nil
+}
+
+// search runs a similarity search, optionally restricted to articles carrying any of tagIDs
+func (q *QdrantAdapter) search(ctx context.Context, query SearchQuery, tagIDs []string) ([]SearchResult, error) {
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+	if query.SimilarityThreshold == 0 {
+		query.SimilarityThreshold = 0.7
+	}
+
+	filter := map[string]interface{}{}
+	if len(tagIDs) > 0 {
+		filter["must"] = []interface{}{
+			map[string]interface{}{"key": qdrantTagField, "match": map[string]interface{}{"any": tagIDs}},
+		}
+	}
+	if len(query.ExcludeIDs) > 0 {
+		ids := make([]string, len(query.ExcludeIDs))
+		for i, id := range query.ExcludeIDs {
+			ids[i] = qdrantPointID(id)
+		}
+		filter["must_not"] = []interface{}{map[string]interface{}{"has_id": ids}}
+	}
+
+	body := map[string]interface{}{
+		"vector":          query.Embedding,
+		"limit":           query.Limit,
+		"score_threshold": query.SimilarityThreshold,
+		"with_payload":    true,
+	}
+	if len(filter) > 0 {
+		body["filter"] = filter
+	}
+
+	var response struct {
+		Result []qdrantScoredPoint `json:"result"`
+	}
+	if err := q.do(ctx, http.MethodPost, q.collectionPath("/points/search"), body, &response); err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(response.Result))
+	for _, point := range response.Result {
+		result := SearchResult{
+			ArticleID:  payloadString(point.Payload, "article_id"),
+			Similarity: point.Score,
+			Distance:   1 - point.Score,
+			TagIDs:     payloadStrings(point.Payload, qdrantTagField),
+		}
+		if result.ArticleID == "" {
+			result.ArticleID = fmt.Sprint(point.ID)
+		}
+		if query.IncludeArticle {
+			result.Article = &core.Article{
+				ID:    result.ArticleID,
+				Title: payloadString(point.Payload, "title"),
+				URL:   payloadString(point.Payload, "url"),
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// upsert writes points and waits for them to be indexed
+func (q *QdrantAdapter) upsert(ctx context.Context, points []qdrantPoint) error {
+	body := map[string]interface{}{"points": points}
+	if err := q.do(ctx, http.MethodPut, q.collectionPath("/points?wait=true"), body, nil); err != nil {
+		return fmt.Errorf("failed to store embedding: %w", err)
+	}
+	return nil
+}
+
+// collectionExists reports whether the collection has been created
+func (q *QdrantAdapter) collectionExists(ctx context.Context) (bool, error) {
+	err := q.do(ctx, http.MethodGet, q.collectionPath(""), nil, nil)
+	if err == nil {
+		return true, nil
+	}
+	if statusErr, ok := err.(*qdrantStatusError); ok && statusErr.status == http.StatusNotFound {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check collection %s: %w", q.collection, err)
+}
+
+func (q *QdrantAdapter) collectionPath(suffix string) string {
+	return "/collections/" + url.PathEscape(q.collection) + suffix
+}
+
+// qdrantStatusError is a non-2xx response from Qdrant
+type qdrantStatusError struct {
+	status int
+	body   string
+}
+
+func (e *qdrantStatusError) Error() string {
+	return fmt.Sprintf("qdrant returned %d: %s", e.status, e.body)
+}
+
+// do sends a JSON request to Qdrant and decodes the response into out (if non-nil)
+func (q *QdrantAdapter) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, q.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if q.apiKey != "" {
+		req.Header.Set("api-key", q.apiKey)
+	}
+
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &qdrantStatusError{status: resp.StatusCode, body: strings.TrimSpace(string(data))}
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// qdrantPointID maps an article ID to a Qdrant point ID, which must be a UUID
+func qdrantPointID(articleID string) string {
+	if id, err := uuid.Parse(articleID); err == nil {
+		return id.String()
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(articleID)).String()
+}
+
+func payloadString(payload map[string]interface{}, key string) string {
+	value, _ := payload[key].(string)
+	return value
+}
+
+func payloadStrings(payload map[string]interface{}, key string) []string {
+	values := []string{}
+	items, _ := payload[key].([]interface{})
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
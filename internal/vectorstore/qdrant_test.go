@@ -0,0 +1,148 @@
+package vectorstore
+
+import (
+	"briefly/internal/config"
+	"briefly/internal/core"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQdrantAdapter_IndexAndSearch(t *testing.T) {
+	var upserted map[string]interface{}
+	var searched map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("api-key") != "secret" {
+			t.Errorf("missing api-key header")
+		}
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/collections/articles/points":
+			_ = json.NewDecoder(r.Body).Decode(&upserted)
+			_, _ = w.Write([]byte(`{"result":{"status":"completed"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/collections/articles/points/search":
+			_ = json.NewDecoder(r.Body).Decode(&searched)
+			_, _ = w.Write([]byte(`{"result":[{"id":"x","score":0.91,"payload":{"article_id":"article-1","title":"Agents","url":"https://example.com/a","tag_ids":["ai"]}}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	store := NewQdrantAdapter(server.URL, "secret", "articles", 3)
+	ctx := context.Background()
+
+	article := core.Article{ID: "article-1", Title: "Agents", URL: "https://example.com/a"}
+	if err := store.IndexArticle(ctx, article, []float64{0.1, 0.2, 0.3}, []string{"ai"}); err != nil {
+		t.Fatalf("IndexArticle failed: %v", err)
+	}
+
+	points := upserted["points"].([]interface{})
+	point := points[0].(map[string]interface{})
+	if point["id"] != qdrantPointID("article-1") {
+		t.Errorf("point id = %v, want derived UUID", point["id"])
+	}
+	if payload := point["payload"].(map[string]interface{}); payload["article_id"] != "article-1" {
+		t.Errorf("payload article_id = %v", payload["article_id"])
+	}
+
+	results, err := store.SearchByTags(ctx, SearchQuery{
+		Embedding:      []float64{0.1, 0.2, 0.3},
+		Limit:          5,
+		IncludeArticle: true,
+		ExcludeIDs:     []string{"article-2"},
+	}, []string{"ai", "infra"})
+	if err != nil {
+		t.Fatalf("SearchByTags failed: %v", err)
+	}
+
+	if searched["score_threshold"] != 0.7 {
+		t.Errorf("score_threshold = %v, want default 0.7", searched["score_threshold"])
+	}
+	filter := searched["filter"].(map[string]interface{})
+	if _, ok := filter["must"]; !ok {
+		t.Error("expected tag filter")
+	}
+	if _, ok := filter["must_not"]; !ok {
+		t.Error("expected exclusion filter")
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	result := results[0]
+	if result.ArticleID != "article-1" || result.Article == nil || result.Article.Title != "Agents" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(result.TagIDs) != 1 || result.TagIDs[0] != "ai" {
+		t.Errorf("TagIDs = %v, want [ai]", result.TagIDs)
+	}
+}
+
+func TestQdrantAdapter_CreateIndexCreatesMissingCollection(t *testing.T) {
+	created := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/collections/articles":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut && r.URL.Path == "/collections/articles":
+			var body map[string]map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["vectors"]["distance"] != "Cosine" || body["vectors"]["size"] != float64(768) {
+				t.Errorf("unexpected collection config: %v", body)
+			}
+			created = true
+			_, _ = w.Write([]byte(`{"result":true}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/collections/articles/index":
+			_, _ = w.Write([]byte(`{"result":{}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	store := NewQdrantAdapter(server.URL, "", "articles", 0)
+	if err := store.CreateIndex(context.Background()); err != nil {
+		t.Fatalf("CreateIndex failed: %v", err)
+	}
+	if !created {
+		t.Error("expected collection to be created")
+	}
+}
+
+func TestQdrantPointID(t *testing.T) {
+	uuidID := "6f1c2f5e-8a1d-4b1e-9a53-2d7c1f0e9b44"
+	if got := qdrantPointID(uuidID); got != uuidID {
+		t.Errorf("UUID article ID changed: %s", got)
+	}
+	if qdrantPointID("abc") != qdrantPointID("abc") {
+		t.Error("derived point IDs are not stable")
+	}
+	if qdrantPointID("abc") == qdrantPointID("abd") {
+		t.Error("distinct article IDs share a point ID")
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	store, err := NewFromConfig(config.VectorStore{Backend: "qdrant", Qdrant: config.QdrantConfig{URL: "http://localhost:6333"}}, nil)
+	if err != nil {
+		t.Fatalf("NewFromConfig failed: %v", err)
+	}
+	if _, ok := store.(ArticleIndexer); !ok {
+		t.Error("qdrant store should implement ArticleIndexer")
+	}
+	if _, err := store.SearchPriorCoverage(context.Background(), PriorCoverageQuery{}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("SearchPriorCoverage error = %v, want ErrUnsupported", err)
+	}
+
+	if _, err := NewFromConfig(config.VectorStore{Backend: "pgvector"}, nil); err == nil {
+		t.Error("expected error for pgvector without a database")
+	}
+	if _, err := NewFromConfig(config.VectorStore{Backend: "faiss"}, nil); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
@@ -1,10 +1,17 @@
 package vectorstore
 
 import (
+	"briefly/internal/config"
 	"briefly/internal/core"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 )
 
+// ErrUnsupported is returned by backends that cannot perform an operation
+var ErrUnsupported = errors.New("not supported by this vector store backend")
+
 // VectorStore provides semantic search operations for article embeddings
 // Using pgvector for production-scale similarity search with cosine distance
 type VectorStore interface {
@@ -26,6 +33,11 @@ type VectorStore interface {
 	// Useful for finding cross-tag connections
 	SearchByTags(ctx context.Context, query SearchQuery, tagIDs []string) ([]SearchResult, error)
 
+	// SearchPriorCoverage finds earlier digests that included articles similar to
+	// the given article, using the article's stored embedding
+	// Returns at most one result per digest, most similar first
+	SearchPriorCoverage(ctx context.Context, query PriorCoverageQuery) ([]core.PriorCoverage, error)
+
 	// Delete removes an embedding (when article is deleted)
 	Delete(ctx context.Context, articleID string) error
 
@@ -37,6 +49,36 @@ type VectorStore interface {
 	GetStats(ctx context.Context) (*VectorStoreStats, error)
 }
 
+// ArticleIndexer is implemented by vector stores that keep their own copy of article
+// metadata (e.g. Qdrant) and therefore need more than the embedding when indexing.
+// pgvector reads titles and tags from Postgres and does not implement it.
+type ArticleIndexer interface {
+	IndexArticle(ctx context.Context, article core.Article, embedding []float64, tagIDs []string) error
+}
+
+// NewFromConfig creates the vector store selected by vector_store.backend.
+// db is the Postgres connection used by the pgvector backend.
+func NewFromConfig(cfg config.VectorStore, db *sql.DB) (VectorStore, error) {
+	switch cfg.Backend {
+	case "", "pgvector":
+		if db == nil {
+			return nil, fmt.Errorf("pgvector vector store requires a database")
+		}
+		return NewPgVectorAdapter(db), nil
+	case "qdrant":
+		if cfg.Qdrant.URL == "" {
+			return nil, fmt.Errorf("vector_store.qdrant.url is required for the qdrant backend")
+		}
+		collection := cfg.Qdrant.Collection
+		if collection == "" {
+			collection = "briefly_articles"
+		}
+		return NewQdrantAdapter(cfg.Qdrant.URL, cfg.Qdrant.APIKey, collection, cfg.Qdrant.Dimensions), nil
+	default:
+		return nil, fmt.Errorf("unknown vector_store.backend: %s", cfg.Backend)
+	}
+}
+
 // SearchQuery configures semantic search parameters
 type SearchQuery struct {
 	// Embedding is the query vector (768-dim for Gemini)
@@ -57,6 +99,22 @@ type SearchQuery struct {
 	ExcludeIDs []string
 }
 
+// PriorCoverageQuery configures a search for related articles in earlier digests
+type PriorCoverageQuery struct {
+	// ArticleID is the article whose stored embedding is the query vector
+	ArticleID string
+
+	// Limit is the maximum number of earlier digests to return (default: 2)
+	Limit int
+
+	// SimilarityThreshold is the minimum cosine similarity (default: 0.8)
+	// Stricter than Search because the links appear in published digests
+	SimilarityThreshold float64
+
+	// ExcludeDigestIDs filters out digests, such as those from the current run
+	ExcludeDigestIDs []string
+}
+
 // SearchResult contains a similar article and its similarity score
 type SearchResult struct {
 	// ArticleID is the unique identifier
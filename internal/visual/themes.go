@@ -0,0 +1,219 @@
+// Package visual prepares the structured inputs for digest imagery: the content
+// themes a banner should depict and the alt text that describes it.
+package visual
+
+import (
+	"briefly/internal/clustering"
+	"briefly/internal/core"
+	"briefly/internal/textutil"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxBannerThemes is how many themes a banner depicts
+	maxBannerThemes = 3
+
+	// maxAltTextLength keeps alt text short enough for screen readers to read in full
+	maxAltTextLength = 150
+)
+
+// Visual categories for content themes
+const (
+	CategoryDev      = "🔧 Dev"
+	CategoryResearch = "📚 Research"
+	CategoryInsight  = "💡 Insight"
+)
+
+// categoryTerms maps a visual category to the terms that suggest it. Dev is checked
+// before Research, so a theme mentioning both a paper and a library files as Dev.
+var categoryTerms = []struct {
+	category string
+	terms    []string
+}{
+	{CategoryDev, []string{"tool", "tools", "framework", "frameworks", "library", "sdk", "api", "open source", "open-source", "release", "releases", "launch", "launches", "code", "coding", "developer", "infrastructure", "kubernetes", "database", "cli", "deploy"}},
+	{CategoryResearch, []string{"paper", "papers", "research", "study", "benchmark", "benchmarks", "arxiv", "dataset", "evaluation", "eval", "experiment", "training", "scientists"}},
+}
+
+// AnalyzeThemes identifies the content themes of a digest from its article groups,
+// largest group first. Digests without groups fall back to keywords across all of
+// their articles.
+func AnalyzeThemes(digest *core.Digest) []core.ContentTheme {
+	if digest == nil {
+		return nil
+	}
+
+	total := 0
+	for _, group := range digest.ArticleGroups {
+		total += len(group.Articles)
+	}
+	if total == 0 {
+		return keywordThemes(digest)
+	}
+
+	themes := make([]core.ContentTheme, 0, len(digest.ArticleGroups))
+	for _, group := range digest.ArticleGroups {
+		if len(group.Articles) == 0 {
+			continue
+		}
+
+		theme := core.ContentTheme{
+			Theme:      strings.TrimSpace(group.Theme),
+			Confidence: float64(len(group.Articles)) / float64(total),
+		}
+		for _, article := range group.Articles {
+			theme.Articles = append(theme.Articles, article.ID)
+		}
+
+		description := group.Summary
+		if narrative := group.ClusterNarrative; narrative != nil {
+			theme.Keywords = narrative.KeyThemes
+			if narrative.Confidence > 0 {
+				theme.Confidence = narrative.Confidence
+			}
+			if narrative.OneLiner != "" {
+				description = narrative.OneLiner
+			} else if narrative.Summary != "" {
+				description = narrative.Summary
+			}
+			if theme.Theme == "" {
+				theme.Theme = narrative.Title
+			}
+		}
+		if theme.Theme == "" && len(theme.Keywords) > 0 {
+			theme.Theme = theme.Keywords[0]
+		}
+		if theme.Theme == "" {
+			continue
+		}
+
+		theme.Description = textutil.FirstSentence(description)
+		theme.Category = categorize(theme.Theme + " " + strings.Join(theme.Keywords, " ") + " " + theme.Description)
+		themes = append(themes, theme)
+	}
+
+	sort.SliceStable(themes, func(i, j int) bool {
+		return len(themes[i].Articles) > len(themes[j].Articles)
+	})
+	return themes
+}
+
+// keywordThemes builds one theme per keyword across a digest's articles
+func keywordThemes(digest *core.Digest) []core.ContentTheme {
+	if len(digest.Articles) == 0 {
+		return nil
+	}
+
+	keywords := clustering.ExtractKeywords(digest.Articles, nil, nil, maxBannerThemes)
+	themes := make([]core.ContentTheme, 0, len(keywords))
+	for _, keyword := range keywords {
+		theme := core.ContentTheme{
+			Theme:    keyword,
+			Keywords: []string{keyword},
+			Category: categorize(keyword),
+		}
+		lower := strings.ToLower(keyword)
+		for _, article := range digest.Articles {
+			if strings.Contains(strings.ToLower(article.Title+" "+article.CleanedText), lower) {
+				theme.Articles = append(theme.Articles, article.ID)
+			}
+		}
+		theme.Confidence = float64(len(theme.Articles)) / float64(len(digest.Articles))
+		themes = append(themes, theme)
+	}
+	return themes
+}
+
+// PlanBanner fills a banner's themes and alt text from the digest content, without
+// generating an image. The result gives templates accessible alt text and gives an
+// image generator the themes to depict; ImageURL stays empty until one runs.
+func PlanBanner(digest *core.Digest, style string) *core.BannerImage {
+	themes := AnalyzeThemes(digest)
+	if len(themes) == 0 {
+		return nil
+	}
+
+	banner := &core.BannerImage{
+		DigestID: digest.ID,
+		Style:    style,
+	}
+	for _, theme := range themes {
+		if len(banner.Themes) == maxBannerThemes {
+			break
+		}
+		banner.Themes = append(banner.Themes, theme.Theme)
+	}
+	banner.AltText = AltText(digestTitle(digest), banner.Themes)
+	return banner
+}
+
+// AltText describes a digest banner for screen readers: what the digest is and the
+// themes the image depicts, within maxAltTextLength characters
+func AltText(title string, themes []string) string {
+	title = strings.TrimSpace(title)
+
+	var alt string
+	switch {
+	case title != "" && len(themes) > 0:
+		alt = fmt.Sprintf("Banner for “%s” illustrating %s", title, joinThemes(themes))
+	case title != "":
+		alt = fmt.Sprintf("Banner for “%s”", title)
+	case len(themes) > 0:
+		alt = "Digest banner illustrating " + joinThemes(themes)
+	default:
+		return "Digest banner"
+	}
+
+	// Drop trailing themes rather than cutting one mid-word
+	if len(alt) > maxAltTextLength && len(themes) > 1 {
+		return AltText(title, themes[:len(themes)-1])
+	}
+	if len(alt) > maxAltTextLength {
+		cut := alt[:maxAltTextLength]
+		if space := strings.LastIndex(cut, " "); space > 0 {
+			cut = cut[:space]
+		}
+		alt = cut + "…"
+	}
+	return alt
+}
+
+// digestTitle returns the digest's title, preferring the generated one
+func digestTitle(digest *core.Digest) string {
+	if digest.Title != "" {
+		return digest.Title
+	}
+	return digest.Metadata.Title
+}
+
+// joinThemes joins themes as "A", "A and B", or "A, B, and C"
+func joinThemes(themes []string) string {
+	switch len(themes) {
+	case 0:
+		return ""
+	case 1:
+		return themes[0]
+	case 2:
+		return themes[0] + " and " + themes[1]
+	default:
+		return strings.Join(themes[:len(themes)-1], ", ") + ", and " + themes[len(themes)-1]
+	}
+}
+
+// categorize picks the visual category whose terms appear as whole words in text
+func categorize(text string) string {
+	words := " " + strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	}), " ") + " "
+	for _, candidate := range categoryTerms {
+		for _, term := range candidate.terms {
+			if strings.Contains(words, " "+term+" ") {
+				return candidate.category
+			}
+		}
+	}
+	return CategoryInsight
+}
+
@@ -0,0 +1,93 @@
+package visual
+
+import (
+	"briefly/internal/core"
+	"strings"
+	"testing"
+)
+
+func TestPlanBanner_FromArticleGroups(t *testing.T) {
+	digest := &core.Digest{
+		ID:    "d1",
+		Title: "Agents Cut Query Costs 60%",
+		ArticleGroups: []core.ArticleGroup{
+			{
+				Theme:    "Retrieval Research",
+				Articles: []core.Article{{ID: "a3"}},
+				ClusterNarrative: &core.ClusterNarrative{
+					OneLiner:  "A new benchmark paper measures retrieval quality. It finds gaps.",
+					KeyThemes: []string{"benchmark"},
+				},
+			},
+			{
+				Theme:    "Agent Frameworks",
+				Articles: []core.Article{{ID: "a1"}, {ID: "a2"}},
+				ClusterNarrative: &core.ClusterNarrative{
+					OneLiner:  "Open-source frameworks ship production agents.",
+					KeyThemes: []string{"frameworks", "agents"},
+				},
+			},
+			{Theme: "Empty", Articles: nil},
+		},
+	}
+
+	themes := AnalyzeThemes(digest)
+	if len(themes) != 2 {
+		t.Fatalf("expected 2 themes (empty group skipped), got %+v", themes)
+	}
+	if themes[0].Theme != "Agent Frameworks" || len(themes[0].Articles) != 2 {
+		t.Errorf("expected largest group first, got %+v", themes[0])
+	}
+	if themes[0].Category != CategoryDev || themes[1].Category != CategoryResearch {
+		t.Errorf("unexpected categories: %q, %q", themes[0].Category, themes[1].Category)
+	}
+	if themes[1].Description != "A new benchmark paper measures retrieval quality." {
+		t.Errorf("expected first sentence as description, got %q", themes[1].Description)
+	}
+
+	banner := PlanBanner(digest, "tech")
+	if banner == nil {
+		t.Fatal("expected a banner plan")
+	}
+	if banner.ImageURL != "" || banner.DigestID != "d1" || banner.Style != "tech" {
+		t.Errorf("unexpected banner: %+v", banner)
+	}
+	want := "Banner for “Agents Cut Query Costs 60%” illustrating Agent Frameworks and Retrieval Research"
+	if banner.AltText != want {
+		t.Errorf("AltText = %q, want %q", banner.AltText, want)
+	}
+}
+
+func TestPlanBanner_NoContent(t *testing.T) {
+	if banner := PlanBanner(&core.Digest{Title: "Empty"}, "tech"); banner != nil {
+		t.Errorf("expected no banner for a digest without articles, got %+v", banner)
+	}
+}
+
+func TestAltText_StaysShort(t *testing.T) {
+	themes := []string{
+		"Distributed Inference Infrastructure at Scale",
+		"Agentic Coding Assistants in Production",
+		"Open Model Licensing and Governance Debates",
+	}
+	alt := AltText("Voice AI Hits 1-Second Latency", themes)
+	if len(alt) > maxAltTextLength {
+		t.Errorf("alt text is %d chars, want <= %d: %q", len(alt), maxAltTextLength, alt)
+	}
+	if !strings.Contains(alt, themes[0]) || strings.Contains(alt, themes[2]) {
+		t.Errorf("expected trailing themes dropped whole, got %q", alt)
+	}
+
+	if got := AltText("", nil); got != "Digest banner" {
+		t.Errorf("AltText with nothing = %q", got)
+	}
+}
+
+func TestCategorize_WholeWords(t *testing.T) {
+	if got := categorize("Rapid growth in clicks"); got != CategoryInsight {
+		t.Errorf("expected substrings like 'api' in 'rapid' not to match, got %q", got)
+	}
+	if got := categorize("New CLI release"); got != CategoryDev {
+		t.Errorf("categorize = %q, want %q", got, CategoryDev)
+	}
+}